@@ -33,6 +33,7 @@ func action() *cobra.Command {
 		cli.NewCommand(actionDocCmd, actionDocRun, nil),
 		cli.NewCommand(actionImportCmd, actionImportRun, nil),
 		cli.NewCommand(actionExportCmd, actionExportRun, nil),
+		actionVersion(),
 		cli.NewCommand(actionBuiltinCmd, nil, []*cobra.Command{
 			cli.NewListCommand(actionBuiltinListCmd, actionBuiltinListRun, nil),
 			cli.NewGetCommand(actionBuiltinShowCmd, actionBuiltinShowRun, nil),
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var actionVersionCmd = cli.Command{
+	Name:  "version",
+	Short: "Manage the versions of a CDS action",
+	Long: `The versions of an action keep its definition as it was when the version was published. A workflow pins a version
+of an action in its pinned_versions, its runs use this version instead of the latest definition of the action.`,
+}
+
+func actionVersion() *cobra.Command {
+	return cli.NewCommand(actionVersionCmd, nil, []*cobra.Command{
+		cli.NewListCommand(actionVersionListCmd, actionVersionListRun, nil),
+		cli.NewGetCommand(actionVersionPublishCmd, actionVersionPublishRun, nil),
+		cli.NewListCommand(actionVersionUsageCmd, actionVersionUsageRun, nil),
+	})
+}
+
+var actionVersionListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the versions of a CDS action",
+	Args: []cli.Arg{
+		{Name: "action-path"},
+	},
+}
+
+func actionVersionListRun(v cli.Values) (cli.ListResult, error) {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return nil, err
+	}
+
+	vs, err := client.ActionVersionList(groupName, actionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.AsListResult(vs), nil
+}
+
+var actionVersionPublishCmd = cli.Command{
+	Name:    "publish",
+	Short:   "Publish the current definition of a CDS action as a version",
+	Example: `cdsctl action version publish shared.infra/myAction 1.0.0`,
+	Args: []cli.Arg{
+		{Name: "action-path"},
+		{Name: "version"},
+	},
+}
+
+func actionVersionPublishRun(v cli.Values) (interface{}, error) {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ActionVersionPublish(groupName, actionName, v.GetString("version"))
+}
+
+var actionVersionUsageCmd = cli.Command{
+	Name:  "usage",
+	Short: "List the workflows that pin a version of a CDS action",
+	Args: []cli.Arg{
+		{Name: "action-path"},
+		{Name: "version"},
+	},
+}
+
+func actionVersionUsageRun(v cli.Values) (cli.ListResult, error) {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return nil, err
+	}
+
+	us, err := client.ActionVersionUsage(groupName, actionName, v.GetString("version"))
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.AsListResult(us), nil
+}
@@ -20,7 +20,12 @@ func adminCommands() []*cobra.Command {
 		adminMaintenance(),
 		adminMetadata(),
 		adminMigrations(),
+		adminUsers(),
 		adminPlugins(),
+		adminQueue(),
+		adminQuota(),
+		adminSBOM(),
+		adminAssets(),
 		adminBroadcasts(),
 		adminErrors(),
 		adminCurl(),
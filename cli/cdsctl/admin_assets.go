@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var adminAssetsCmd = cli.Command{
+	Name:  "assets",
+	Short: "Manage CDS assets bundle for air-gapped installations",
+	Long: `An assets bundle contains the binaries served by the API, the GRPC plugins and the default actions.
+Export it from a CDS with an internet access, then import it on an air-gapped installation.`,
+}
+
+func adminAssets() *cobra.Command {
+	return cli.NewCommand(adminAssetsCmd, nil, []*cobra.Command{
+		cli.NewCommand(adminAssetsExportCmd, adminAssetsExportFunc, nil),
+		cli.NewGetCommand(adminAssetsImportCmd, adminAssetsImportFunc, nil),
+	})
+}
+
+var adminAssetsExportCmd = cli.Command{
+	Name:  "export",
+	Short: "Export the assets bundle of CDS",
+	Flags: []cli.Flag{
+		{
+			Name:    "output",
+			Usage:   "File to write the bundle",
+			Default: "cds-assets.tar.gz",
+		},
+	},
+}
+
+func adminAssetsExportFunc(v cli.Values) error {
+	output := v.GetString("output")
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("unable to create file %s: %v", output, err)
+	}
+	if err := client.AdminAssetsExport(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to close file %s: %v", output, err)
+	}
+	fmt.Printf("Assets bundle written to %s\n", output)
+	return nil
+}
+
+var adminAssetsImportCmd = cli.Command{
+	Name:  "import",
+	Short: "Import an assets bundle in CDS",
+	Args: []cli.Arg{
+		{Name: "file"},
+	},
+}
+
+func adminAssetsImportFunc(v cli.Values) (interface{}, error) {
+	f, err := os.Open(v.GetString("file"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file %s: %v", v.GetString("file"), err)
+	}
+	defer f.Close() // nolint
+
+	report, err := client.AdminAssetsImport(f)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -28,6 +28,7 @@ func adminPlugins() *cobra.Command {
 		cli.NewDeleteCommand(adminPluginsDeleteCmd, adminPluginsDeleteFunc, nil),
 		cli.NewCommand(adminPluginsAddBinaryCmd, adminPluginsAddBinaryFunc, nil),
 		cli.NewCommand(adminPluginsDocCmd, adminPluginsDocFunc, nil),
+		adminPluginsRegistry(),
 	})
 }
 
@@ -55,34 +56,40 @@ var adminPluginsImportCmd = cli.Command{
 }
 
 func adminPluginsImportFunc(v cli.Values) error {
-	b, err := ioutil.ReadFile(v.GetString("file"))
+	_, err := importPlugin(v.GetString("file"))
+	return err
+}
+
+// importPlugin adds or updates a plugin from its manifest file.
+func importPlugin(file string) (*sdk.GRPCPlugin, error) {
+	b, err := ioutil.ReadFile(file)
 	if err != nil {
-		return fmt.Errorf("unable to read file %s: %v", v.GetString("file"), err)
+		return nil, fmt.Errorf("unable to read file %s: %v", file, err)
 	}
 
 	var expGPRCPlugin exportentities.GRPCPlugin
 	if err := yaml.Unmarshal(b, &expGPRCPlugin); err != nil {
-		return fmt.Errorf("unable to load file: %v", err)
+		return nil, fmt.Errorf("unable to load file: %v", err)
 	}
 
 	m := expGPRCPlugin.GRPCPlugin()
 	existing, err := client.PluginsGet(m.Name)
 	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
-		return fmt.Errorf("unable to get plugin: %v", err)
+		return nil, fmt.Errorf("unable to get plugin: %v", err)
 	}
 
 	if existing == nil {
 		if err := client.PluginAdd(m); err != nil {
-			return fmt.Errorf("unable to add plugin: %v", err)
+			return nil, fmt.Errorf("unable to add plugin: %v", err)
 		}
-		return nil
+		return m, nil
 	}
 
 	if err := client.PluginUpdate(m); err != nil {
-		return fmt.Errorf("unable to update plugin: %v", err)
+		return nil, fmt.Errorf("unable to update plugin: %v", err)
 	}
 
-	return nil
+	return m, nil
 }
 
 var adminPluginsExportCmd = cli.Command{
@@ -149,45 +156,56 @@ func adminPluginsAddBinaryFunc(v cli.Values) error {
 		return fmt.Errorf("unable to get plugin %s: %v", v.GetString("name"), err)
 	}
 
-	f, err := os.Open(v.GetString("filename"))
+	desc, err := readPluginBinary(v.GetString("descriptor"), v.GetString("filename"))
 	if err != nil {
-		return fmt.Errorf("unable to open file %s: %v", v.GetString("filename"), err)
+		return err
 	}
 
+	return client.PluginAddBinary(p, desc)
+}
+
+// readPluginBinary returns a plugin binary from its descriptor file, with the content of the binary file.
+func readPluginBinary(descriptor, filename string) (*sdk.GRPCPluginBinary, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file %s: %v", filename, err)
+	}
+	defer f.Close() // nolint
+
 	fi, err := os.Stat(f.Name())
 	if err != nil {
-		return fmt.Errorf("unable to open file %s: %v", v.GetString("filename"), err)
+		return nil, fmt.Errorf("unable to open file %s: %v", filename, err)
 	}
 
-	b, err := ioutil.ReadFile(v.GetString("descriptor"))
+	b, err := ioutil.ReadFile(descriptor)
 	if err != nil {
-		return fmt.Errorf("unable to read file %s: %v", v.GetString("file"), err)
+		return nil, fmt.Errorf("unable to read file %s: %v", descriptor, err)
 	}
 
 	var desc sdk.GRPCPluginBinary
 	if err := yaml.Unmarshal(b, &desc); err != nil {
-		return fmt.Errorf("unable to load file: %v", err)
+		return nil, fmt.Errorf("unable to load file: %v", err)
 	}
 
 	desc.Name = filepath.Base(f.Name())
 	desc.Perm = uint32(fi.Mode().Perm())
 	desc.FileContent, err = ioutil.ReadFile(f.Name())
 	if err != nil {
-		return fmt.Errorf("unable to open file %s : %v", v.GetString("filename"), err)
+		return nil, fmt.Errorf("unable to open file %s : %v", filename, err)
 	}
 
 	desc.Size = int64(len(desc.FileContent))
-	desc.MD5sum, err = sdk.FileMd5sum(v.GetString("filename"))
+	desc.MD5sum, err = sdk.FileMd5sum(filename)
 	if err != nil {
-		return fmt.Errorf("unable to compute md5sum for file %s: %v", v.GetString("filename"), err)
+		return nil, fmt.Errorf("unable to compute md5sum for file %s: %v", filename, err)
 	}
 
-	desc.SHA512sum, err = sdk.FileSHA512sum(v.GetString("filename"))
+	desc.SHA512sum, err = sdk.FileSHA512sum(filename)
 	if err != nil {
-		return fmt.Errorf("unable to compute sha512sum for file %s: %v", v.GetString("filename"), err)
+		return nil, fmt.Errorf("unable to compute sha512sum for file %s: %v", filename, err)
 	}
 
-	return client.PluginAddBinary(p, &desc)
+	return &desc, nil
 }
 
 var adminPluginsDocCmd = cli.Command{
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var adminPluginsRegistryCmd = cli.Command{
+	Name:  "registry",
+	Short: "Manage the versions of the plugins published in the plugin registry",
+}
+
+func adminPluginsRegistry() *cobra.Command {
+	return cli.NewCommand(adminPluginsRegistryCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminPluginsRegistryListCmd, adminPluginsRegistryListFunc, nil),
+		cli.NewGetCommand(adminPluginsRegistryShowCmd, adminPluginsRegistryShowFunc, nil),
+		cli.NewCommand(adminPluginsRegistryPublishCmd, adminPluginsRegistryPublishFunc, nil),
+		cli.NewCommand(adminPluginsRegistryInstallCmd, adminPluginsRegistryInstallFunc, nil),
+		cli.NewDeleteCommand(adminPluginsRegistryDeleteCmd, adminPluginsRegistryDeleteFunc, nil),
+	})
+}
+
+var adminPluginsRegistryListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the versions of the plugins published in the registry",
+	OptionalArgs: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func adminPluginsRegistryListFunc(v cli.Values) (cli.ListResult, error) {
+	vs, err := client.PluginRegistryList(v.GetString("name"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(vs), nil
+}
+
+var adminPluginsRegistryShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show a version of a plugin published in the registry",
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "version"},
+	},
+}
+
+type pluginVersionDisplay struct {
+	Plugin        string `cli:"plugin"`
+	Version       string `cli:"version"`
+	Publisher     string `cli:"publisher"`
+	Published     string `cli:"published"`
+	Installed     bool   `cli:"installed"`
+	Binaries      string `cli:"binaries"`
+	Documentation string `cli:"documentation"`
+}
+
+func adminPluginsRegistryShowFunc(v cli.Values) (interface{}, error) {
+	pv, err := client.PluginRegistryGet(v.GetString("name"), v.GetString("version"))
+	if err != nil {
+		return nil, err
+	}
+	binaries := make([]string, len(pv.Binaries))
+	for i, b := range pv.Binaries {
+		binaries[i] = b.OS + "/" + b.Arch
+	}
+	return pluginVersionDisplay{
+		Plugin:        pv.PluginName,
+		Version:       pv.Version,
+		Publisher:     pv.Publisher,
+		Published:     pv.Published.String(),
+		Installed:     pv.Installed,
+		Binaries:      strings.Join(binaries, ","),
+		Documentation: pv.Documentation,
+	}, nil
+}
+
+var adminPluginsRegistryPublishCmd = cli.Command{
+	Name:  "publish",
+	Short: "Publish a version of a plugin in the registry",
+	Long: `Import the manifest of the plugin and publish a version of the plugin with its binaries.
+Each binary is given with its descriptor file and its file, the checksums of the binaries are computed and verified by CDS.`,
+	Example: `cdsctl admin plugins registry publish plugin-venom.yml 1.2.0 --binary plugin-venom-linux-amd64.yml,plugin-venom-linux-amd64 --doc README.md`,
+	Args: []cli.Arg{
+		{Name: "manifest"},
+		{Name: "version"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "binary",
+			Usage: "Descriptor file and file of a binary, separated by a comma",
+			Type:  cli.FlagArray,
+		},
+		{
+			Name:  "doc",
+			Usage: "Markdown file documenting the plugin",
+		},
+	},
+}
+
+func adminPluginsRegistryPublishFunc(v cli.Values) error {
+	p, err := importPlugin(v.GetString("manifest"))
+	if err != nil {
+		return err
+	}
+
+	pv := sdk.GRPCPluginVersion{Version: v.GetString("version")}
+	for _, b := range v.GetStringArray("binary") {
+		files := strings.SplitN(b, ",", 2)
+		if len(files) != 2 {
+			return fmt.Errorf("invalid binary %s, must be descriptor,file", b)
+		}
+		desc, err := readPluginBinary(files[0], files[1])
+		if err != nil {
+			return err
+		}
+		pv.Binaries = append(pv.Binaries, *desc)
+	}
+
+	if doc := v.GetString("doc"); doc != "" {
+		b, err := ioutil.ReadFile(doc)
+		if err != nil {
+			return fmt.Errorf("unable to read file %s: %v", doc, err)
+		}
+		pv.Documentation = string(b)
+	}
+
+	if err := client.PluginRegistryPublish(p.Name, &pv); err != nil {
+		return err
+	}
+	fmt.Printf("Version %s of plugin %s published\n", pv.Version, p.Name)
+	return nil
+}
+
+var adminPluginsRegistryInstallCmd = cli.Command{
+	Name:  "install",
+	Short: "Install a version of a plugin from the registry",
+	Long:  `The installed version of a plugin is used by the jobs which don't require a version of the plugin (plugin: name@version).`,
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "version"},
+	},
+}
+
+func adminPluginsRegistryInstallFunc(v cli.Values) error {
+	if err := client.PluginRegistryInstall(v.GetString("name"), v.GetString("version")); err != nil {
+		return err
+	}
+	fmt.Printf("Version %s of plugin %s installed\n", v.GetString("version"), v.GetString("name"))
+	return nil
+}
+
+var adminPluginsRegistryDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a version of a plugin from the registry",
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "version"},
+	},
+}
+
+func adminPluginsRegistryDeleteFunc(v cli.Values) error {
+	return client.PluginRegistryDelete(v.GetString("name"), v.GetString("version"))
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var adminQueueCmd = cli.Command{
+	Name:  "queue",
+	Short: "Manage the queue settings of CDS projects",
+}
+
+func adminQueue() *cobra.Command {
+	return cli.NewCommand(adminQueueCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(adminQueueShowCmd, adminQueueShowRun, nil),
+		cli.NewCommand(adminQueueSetCmd, adminQueueSetRun, nil),
+	})
+}
+
+var adminQueueShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the queue settings of a project",
+	Args: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func adminQueueShowRun(v cli.Values) (interface{}, error) {
+	return client.AdminProjectQueueSettingsGet(v.GetString(_ProjectKey))
+}
+
+var adminQueueSetCmd = cli.Command{
+	Name:  "set",
+	Short: "Set the queue settings of a project",
+	Long: `Set the default priority of the workflow runs of a project, and the maximum number of jobs of the project that can be built at the same time (0 means no limit).
+
+	cdsctl admin queue set MYPROJ --priority 5 --max-building-jobs 20
+`,
+	Args: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "priority",
+			Usage: "Default priority of the workflow runs, between -10 and 10",
+		},
+		{
+			Name:  "max-building-jobs",
+			Usage: "Maximum number of building jobs, 0 means no limit",
+		},
+	},
+}
+
+func adminQueueSetRun(v cli.Values) error {
+	key := v.GetString(_ProjectKey)
+	settings, err := client.AdminProjectQueueSettingsGet(key)
+	if err != nil {
+		return err
+	}
+
+	if v.GetString("priority") != "" {
+		p, err := v.GetInt64("priority")
+		if err != nil {
+			return err
+		}
+		settings.Priority = int(p)
+	}
+	if v.GetString("max-building-jobs") != "" {
+		m, err := v.GetInt64("max-building-jobs")
+		if err != nil {
+			return err
+		}
+		settings.MaxBuildingJobs = int(m)
+	}
+
+	if err := client.AdminProjectQueueSettingsUpdate(key, *settings); err != nil {
+		return err
+	}
+	fmt.Printf("Queue settings of project %s updated.\n", key)
+	return nil
+}
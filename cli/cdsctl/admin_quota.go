@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var adminQuotaCmd = cli.Command{
+	Name:  "quota",
+	Short: "Manage the quotas of CDS projects and groups",
+}
+
+func adminQuota() *cobra.Command {
+	return cli.NewCommand(adminQuotaCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminQuotaListCmd, adminQuotaListRun, nil),
+		cli.NewGetCommand(adminQuotaShowCmd, adminQuotaShowRun, nil),
+		cli.NewCommand(adminQuotaSetCmd, adminQuotaSetRun, nil),
+		cli.NewCommand(adminQuotaDeleteCmd, adminQuotaDeleteRun, nil),
+	})
+}
+
+type quotaDisplay struct {
+	Entity          string `cli:"entity,key"`
+	BuildingJobs    string `cli:"building_jobs"`
+	CPUs            string `cli:"cpus"`
+	Memory          string `cli:"memory"`
+	ArtifactStorage string `cli:"artifact_storage"`
+}
+
+func newQuotaDisplay(r sdk.QuotaReport) quotaDisplay {
+	limit := func(usage string, max float64) string {
+		if max == 0 {
+			return usage
+		}
+		return usage + "/" + strconv.FormatFloat(max, 'f', -1, 64)
+	}
+	return quotaDisplay{
+		Entity:          r.Entity,
+		BuildingJobs:    limit(strconv.Itoa(r.Usage.BuildingJobs), float64(r.Quota.MaxBuildingJobs)),
+		CPUs:            limit(strconv.FormatFloat(r.Usage.CPUs, 'f', -1, 64), r.Quota.MaxCPUs),
+		Memory:          limit(strconv.FormatInt(r.Usage.Memory, 10), float64(r.Quota.MaxMemory)),
+		ArtifactStorage: limit(strconv.FormatInt(r.Usage.ArtifactStorage, 10), float64(r.Quota.MaxArtifactStorage)),
+	}
+}
+
+var adminQuotaListCmd = cli.Command{
+	Name:  "list",
+	Short: "List all the quotas with their usage",
+}
+
+func adminQuotaListRun(v cli.Values) (cli.ListResult, error) {
+	reports, err := client.AdminQuotaList()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]quotaDisplay, len(reports))
+	for i := range reports {
+		res[i] = newQuotaDisplay(reports[i])
+	}
+	return cli.AsListResult(res), nil
+}
+
+var adminQuotaEntityArgs = []cli.Arg{
+	{
+		Name: "type",
+		IsValid: func(s string) bool {
+			return s == "project" || s == "group"
+		},
+	},
+	{Name: "name"},
+}
+
+var adminQuotaShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the quota of a project or a group with its usage",
+	Example: `cdsctl admin quota show project MYPROJ
+cdsctl admin quota show group my-group`,
+	Args: adminQuotaEntityArgs,
+}
+
+func adminQuotaShowRun(v cli.Values) (interface{}, error) {
+	report, err := client.AdminQuotaGet(v.GetString("type"), v.GetString("name"))
+	if err != nil {
+		return nil, err
+	}
+	return newQuotaDisplay(*report), nil
+}
+
+var adminQuotaSetCmd = cli.Command{
+	Name:  "set",
+	Short: "Set the quota of a project or a group",
+	Long: `Set the limits of the quota of a project or a group, 0 means no limit. A group quota applies to all the projects on which the group has the read/write/execute permission.
+The memory is in MB and the artifact storage in bytes.
+
+	cdsctl admin quota set project MYPROJ --max-building-jobs 20 --max-cpus 40 --max-memory 81920 --max-artifact-storage 107374182400
+`,
+	Args: adminQuotaEntityArgs,
+	Flags: []cli.Flag{
+		{Name: "max-building-jobs", Usage: "Maximum number of building jobs"},
+		{Name: "max-cpus", Usage: "Maximum number of CPUs used by the workers"},
+		{Name: "max-memory", Usage: "Maximum memory in MB used by the workers"},
+		{Name: "max-artifact-storage", Usage: "Maximum size in bytes of the stored artifacts"},
+	},
+}
+
+func adminQuotaSetRun(v cli.Values) error {
+	report, err := client.AdminQuotaGet(v.GetString("type"), v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	q := report.Quota
+
+	if s := v.GetString("max-building-jobs"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("max-building-jobs invalid: not a integer")
+		}
+		q.MaxBuildingJobs = n
+	}
+	if s := v.GetString("max-cpus"); s != "" {
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("max-cpus invalid: not a number")
+		}
+		q.MaxCPUs = n
+	}
+	if v.GetString("max-memory") != "" {
+		if q.MaxMemory, err = v.GetInt64("max-memory"); err != nil {
+			return err
+		}
+	}
+	if v.GetString("max-artifact-storage") != "" {
+		if q.MaxArtifactStorage, err = v.GetInt64("max-artifact-storage"); err != nil {
+			return err
+		}
+	}
+
+	if err := client.AdminQuotaUpdate(v.GetString("type"), v.GetString("name"), q); err != nil {
+		return err
+	}
+	fmt.Printf("Quota of %s %s updated.\n", v.GetString("type"), v.GetString("name"))
+	return nil
+}
+
+var adminQuotaDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete the quota of a project or a group",
+	Args:  adminQuotaEntityArgs,
+}
+
+func adminQuotaDeleteRun(v cli.Values) error {
+	if err := client.AdminQuotaDelete(v.GetString("type"), v.GetString("name")); err != nil {
+		return err
+	}
+	fmt.Printf("Quota of %s %s deleted.\n", v.GetString("type"), v.GetString("name"))
+	return nil
+}
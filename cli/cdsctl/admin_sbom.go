@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var adminSBOMCmd = cli.Command{
+	Name:  "sbom",
+	Short: "Search dependencies in the SBOMs of all the projects",
+}
+
+func adminSBOM() *cobra.Command {
+	return cli.NewCommand(adminSBOMCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminSBOMSearchCmd, adminSBOMSearchRun, nil),
+	})
+}
+
+var adminSBOMSearchCmd = cli.Command{
+	Name:    "search",
+	Short:   "List the last workflow runs of all the projects containing a dependency",
+	Example: "cdsctl admin sbom search log4j-core 2.14.1",
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "version"},
+	},
+}
+
+func adminSBOMSearchRun(v cli.Values) (cli.ListResult, error) {
+	res, err := client.AdminSBOMSearch(v.GetString("name"), v.GetString("version"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(res), nil
+}
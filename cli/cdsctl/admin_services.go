@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -19,7 +21,9 @@ func adminServices() *cobra.Command {
 	return cli.NewCommand(adminServicesCmd, nil, []*cobra.Command{
 		cli.NewListCommand(adminServiceListCmd, adminServiceListRun, nil),
 		cli.NewListCommand(adminServiceStatusCmd, adminServiceStatusRun, nil),
+		cli.NewListCommand(adminServiceVersionsCmd, adminServiceVersionsRun, nil),
 		cli.NewCommand(adminServiceGetCmd, adminServiceGetRun, nil),
+		cli.NewGetCommand(adminServiceDrainCmd, adminServiceDrainRun, nil),
 		cli.NewDeleteCommand(adminServiceDeleteCmd, adminServiceDeleteRun, nil, withAllCommandModifiers()...),
 	})
 }
@@ -63,6 +67,19 @@ var adminServiceStatusCmd = cli.Command{
 	},
 }
 
+var adminServiceVersionsCmd = cli.Command{
+	Name:  "versions",
+	Short: "Versions and contract compatibility of CDS services",
+}
+
+func adminServiceVersionsRun(v cli.Values) (cli.ListResult, error) {
+	m, err := client.MonServices()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(m.Services), nil
+}
+
 var adminServiceGetCmd = cli.Command{
 	Name:  "request",
 	Short: "request GET on a CDS service",
@@ -128,6 +145,60 @@ func adminServiceGetRun(v cli.Values) error {
 	return nil
 }
 
+var adminServiceDrainCmd = cli.Command{
+	Name:  "drain",
+	Short: "Drain a hatchery: stop provisioning new workers and wait for the running jobs before stopping it",
+	Example: `
+## How to drain the hatchery named hatcherySwarm, waiting at most 30 minutes for the running jobs:
+` + "```bash" + `
+cdsctl admin services drain hatcherySwarm --timeout 1800
+` + "```" + `
+
+## How to follow the progress of the drain:
+` + "```bash" + `
+cdsctl admin services drain hatcherySwarm --status
+` + "```" + `
+
+`,
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "timeout",
+			Usage:   "Maximum duration in seconds to wait for the running jobs, default to the drain timeout of the hatchery",
+			Default: "",
+		},
+		{
+			Name:  "status",
+			Type:  cli.FlagBool,
+			Usage: "Display the progress of the drain without starting it",
+		},
+	},
+}
+
+func adminServiceDrainRun(v cli.Values) (interface{}, error) {
+	var btes []byte
+	var err error
+	if v.GetBool("status") {
+		btes, err = client.ServiceNameCallGET(v.GetString("name"), "/drain")
+	} else {
+		query := "/drain"
+		if t := v.GetString("timeout"); t != "" {
+			query += "?timeout=" + url.QueryEscape(t)
+		}
+		btes, err = client.ServiceNameCallPOST(v.GetString("name"), query, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var status sdk.HatcheryDrainStatus
+	if err := json.Unmarshal(btes, &status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
 var adminServiceDeleteCmd = cli.Command{
 	Name:  "delete",
 	Short: "Delete a CDS service from registered service",
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var adminUsersCmd = cli.Command{
+	Name:    "users",
+	Aliases: []string{"user"},
+	Short:   "Manage CDS users data",
+}
+
+func adminUsers() *cobra.Command {
+	return cli.NewCommand(adminUsersCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminUsersScrubCmd, adminUsersScrubFunc, nil),
+	})
+}
+
+var adminUsersScrubCmd = cli.Command{
+	Name:  "scrub",
+	Short: "Pseudonymize personal data of a deleted user (USE WITH CAUTION)",
+	Long: `Replace the username, email and fullname of a deleted user in run history, audits, spawn infos and events
+by a generated pseudonym. The completion report gives the count of scrubbed items for each store.`,
+	Args: []cli.Arg{
+		{Name: "username"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "email",
+			Usage: "Email of the deleted user",
+		},
+		{
+			Name:  "fullname",
+			Usage: "Fullname of the deleted user",
+		},
+	},
+}
+
+func adminUsersScrubFunc(v cli.Values) (cli.ListResult, error) {
+	report, err := client.AdminUserScrub(sdk.UserScrubRequest{
+		Username: v.GetString("username"),
+		Email:    v.GetString("email"),
+		Fullname: v.GetString("fullname"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Data of user %s replaced by pseudonym %s\n", report.Username, report.Pseudonym)
+	return cli.AsListResult(report.Stores), nil
+}
@@ -25,11 +25,20 @@ func projectCommands() []*cobra.Command {
 		cli.NewCommand(projectCreateCmd, projectCreateRun, nil),
 		cli.NewDeleteCommand(projectDeleteCmd, projectDeleteRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(projectFavoriteCmd, projectFavoriteRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectPullCmd, projectPullRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectPushCmd, projectPushRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectDeploymentsCmd, projectDeploymentsRun, nil, withAllCommandModifiers()...),
 		projectKey(),
+		projectConsumer(),
 		projectGroup(),
 		projectVariable(),
 		projectIntegration(),
 		projectRepositoryManager(),
+		projectSBOM(),
+		projectTests(),
+		projectDORA(),
+		projectRetention(),
+		projectFreeze(),
 	}
 }
 
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectConsumerCmd = cli.Command{
+	Name:  "consumer",
+	Short: "Manage CDS auth consumers restricted to a project",
+}
+
+func projectConsumer() *cobra.Command {
+	return cli.NewCommand(projectConsumerCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectConsumerListCmd, projectConsumerListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectConsumerNewCmd, projectConsumerNewRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectConsumerDeleteCmd, projectConsumerDeleteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectConsumerListCmd = cli.Command{
+	Name:  "list",
+	Short: "List auth consumers of a project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectConsumerListRun(v cli.Values) (cli.ListResult, error) {
+	consumers, err := client.AuthConsumerListByProject(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(consumers), nil
+}
+
+var projectConsumerNewCmd = cli.Command{
+	Name:  "new",
+	Short: "Create a new auth consumer restricted to a project",
+	Long: `Create a new builtin auth consumer that can only be used on the given project.
+By default the consumer gets all the project's groups you are a member of, use --groups to restrict them.
+
+	cdsctl project consumer new MYPROJ --name my-integration --scopes Project,Run --expire 720h
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "name",
+			Usage: "What is the name of this consumer",
+		},
+		{
+			Name:  "description",
+			Usage: "What is the purpose of this consumer",
+		},
+		{
+			Name:  "groups",
+			Type:  cli.FlagSlice,
+			Usage: "Define the list of project's groups for the consumer",
+		},
+		{
+			Name:  "scopes",
+			Type:  cli.FlagSlice,
+			Usage: "Define the list of scopes for the consumer",
+		},
+		{
+			Name:  "expire",
+			Usage: "Duration of validity of the consumer (ex: 720h), never expires if not set",
+		},
+	},
+}
+
+func projectConsumerNewRun(v cli.Values) error {
+	name := v.GetString("name")
+	if name == "" && !v.GetBool("no-interactive") {
+		name = cli.AskValue("Name")
+	}
+
+	description := v.GetString("description")
+	if description == "" && !v.GetBool("no-interactive") {
+		description = cli.AskValue("Description")
+	}
+
+	var groupIDs []int64
+	if groupNames := v.GetStringSlice("groups"); len(groupNames) > 0 {
+		allGroups, err := client.GroupList()
+		if err != nil {
+			return err
+		}
+		for _, g := range groupNames {
+			var found bool
+			for j := range allGroups {
+				if g == allGroups[j].Name {
+					groupIDs = append(groupIDs, allGroups[j].ID)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return errors.Errorf("invalid given group name: '%s'", g)
+			}
+		}
+	}
+
+	var scopes []sdk.AuthConsumerScope
+	for _, s := range v.GetStringSlice("scopes") {
+		scope := sdk.AuthConsumerScope(s)
+		if !scope.IsValid() {
+			return errors.Errorf("invalid given scope value: '%s'", scope)
+		}
+		scopes = append(scopes, scope)
+	}
+	if len(scopes) == 0 && !v.GetBool("no-interactive") {
+		opts := make([]string, len(sdk.AuthConsumerScopes))
+		for i := range sdk.AuthConsumerScopes {
+			opts[i] = string(sdk.AuthConsumerScopes[i])
+		}
+		choices := cli.AskSelect("Select scopes availables for the new consumer", opts...)
+		for _, choice := range choices {
+			scopes = append(scopes, sdk.AuthConsumerScopes[choice])
+		}
+	}
+
+	var expireAt *time.Time
+	if expire := v.GetString("expire"); expire != "" {
+		d, err := time.ParseDuration(expire)
+		if err != nil {
+			return errors.Errorf("invalid given expire duration: '%s'", expire)
+		}
+		t := time.Now().Add(d)
+		expireAt = &t
+	}
+
+	res, err := client.AuthConsumerCreateForProject(v.GetString(_ProjectKey), sdk.AuthConsumer{
+		Name:         name,
+		Description:  description,
+		GroupIDs:     groupIDs,
+		ScopeDetails: sdk.NewAuthConsumerScopeDetails(scopes...),
+		ExpireAt:     expireAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Builtin consumer successfully created, use the following token to sign in:")
+	fmt.Println(res.Token)
+
+	return nil
+}
+
+var projectConsumerDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete an auth consumer of a project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "consumer-id"},
+	},
+}
+
+func projectConsumerDeleteRun(v cli.Values) error {
+	consumerID := v.GetString("consumer-id")
+	if err := client.AuthConsumerDeleteForProject(v.GetString(_ProjectKey), consumerID); err != nil {
+		return err
+	}
+	fmt.Printf("Consumer '%s' successfully deleted.\n", consumerID)
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/ovh/cds/cli"
+)
+
+var projectDeploymentsCmd = cli.Command{
+	Name:  "deployments",
+	Short: "List the last deployment of each application on each environment of the project",
+	Example: `cdsctl project deployments MYPROJ
+cdsctl project deployments MYPROJ --environment production`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "application",
+			Usage: "Only list the deployments of this application",
+		},
+		{
+			Name:  "environment",
+			Usage: "Only list the deployments on this environment",
+		},
+	},
+}
+
+func projectDeploymentsRun(v cli.Values) (cli.ListResult, error) {
+	ds, err := client.ProjectDeploymentList(v.GetString(_ProjectKey), v.GetString("application"), v.GetString("environment"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ds), nil
+}
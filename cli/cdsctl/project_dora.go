@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var projectDORACmd = cli.Command{
+	Name:  "dora",
+	Short: "Show the DORA metrics of the project and of its workflows",
+	Long: `Show the DORA metrics computed from the runs of the production deployment nodes of the project.
+The last line contains the metrics of the whole project. Lead time and time to restore are in seconds.`,
+	Example: `cdsctl project dora MYPROJ
+cdsctl project dora MYPROJ --days 90`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "days",
+			Usage:   "Number of days of deployments",
+			Default: "30",
+		},
+	},
+}
+
+func projectDORA() *cobra.Command {
+	return cli.NewListCommand(projectDORACmd, projectDORARun, nil, withAllCommandModifiers()...)
+}
+
+func projectDORARun(v cli.Values) (cli.ListResult, error) {
+	days, err := v.GetInt64("days")
+	if err != nil {
+		return nil, err
+	}
+	report, err := client.ProjectDORAMetrics(v.GetString(_ProjectKey), int(days))
+	if err != nil {
+		return nil, err
+	}
+	project := report.Project
+	project.WorkflowName = v.GetString(_ProjectKey)
+	return cli.AsListResult(append(report.Workflows, project)), nil
+}
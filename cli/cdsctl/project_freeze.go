@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectFreezeCmd = cli.Command{
+	Name:  "freeze",
+	Short: "Manage the freeze windows of the deployment workflows of the project",
+}
+
+func projectFreeze() *cobra.Command {
+	return cli.NewCommand(projectFreezeCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectFreezeListCmd, projectFreezeListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectFreezeAddCmd, projectFreezeAddRun, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectFreezeDeleteCmd, projectFreezeDeleteRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectFreezeOverridesCmd, projectFreezeOverridesRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectFreezeListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the freeze windows of the project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectFreezeListRun(v cli.Values) (cli.ListResult, error) {
+	ws, err := client.ProjectFreezeWindowList(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ws), nil
+}
+
+var projectFreezeAddCmd = cli.Command{
+	Name:  "add",
+	Short: "Add a freeze window on the project",
+	Long: `Add a freeze window on the project. During the window, the nodes of the deployment workflows of the project
+are stopped with the block policy, or kept waiting until the end of the window with the queue policy.
+A window is ad-hoc with a start and an end date, or recurring with a cron expression and a duration.
+The members of the override group can run the deployment workflows during the window with "cdsctl workflow run --freeze-override".`,
+	Example: `cdsctl project freeze add MYPROJ christmas block --start 2020-12-20T00:00:00Z --end 2021-01-04T00:00:00Z
+cdsctl project freeze add MYPROJ weekend queue --cron "0 18 * * 5" --duration 62h --timezone Europe/Paris --environment production --override-group ops`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "policy"},
+	},
+	Flags: []cli.Flag{
+		{Name: "start", Usage: "Start date of an ad-hoc window (RFC3339)"},
+		{Name: "end", Usage: "End date of an ad-hoc window (RFC3339)"},
+		{Name: "cron", Usage: "Cron expression of the start of a recurring window"},
+		{Name: "duration", Usage: "Duration of a recurring window (ie. 2h)"},
+		{Name: "timezone", Usage: "Timezone of the cron expression", Default: "UTC"},
+		{Name: "environment", Usage: "Environment frozen by the window, all the environments if empty"},
+		{Name: "override-group", Usage: "Group allowed to override the window"},
+	},
+}
+
+func projectFreezeAddRun(v cli.Values) error {
+	w := sdk.FreezeWindow{
+		Name:              v.GetString("name"),
+		Policy:            v.GetString("policy"),
+		Cron:              v.GetString("cron"),
+		Environment:       v.GetString("environment"),
+		OverrideGroupName: v.GetString("override-group"),
+	}
+	if w.Cron != "" {
+		w.Timezone = v.GetString("timezone")
+		d, err := time.ParseDuration(v.GetString("duration"))
+		if err != nil {
+			return fmt.Errorf("invalid given duration: %v", err)
+		}
+		w.Duration = int64(d.Seconds())
+	} else {
+		var err error
+		if w.Start, err = time.Parse(time.RFC3339, v.GetString("start")); err != nil {
+			return fmt.Errorf("invalid given start date: %v", err)
+		}
+		if w.End, err = time.Parse(time.RFC3339, v.GetString("end")); err != nil {
+			return fmt.Errorf("invalid given end date: %v", err)
+		}
+	}
+	return client.ProjectFreezeWindowAdd(v.GetString(_ProjectKey), &w)
+}
+
+var projectFreezeDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a freeze window of the project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectFreezeDeleteRun(v cli.Values) error {
+	err := client.ProjectFreezeWindowDelete(v.GetString(_ProjectKey), v.GetString("name"))
+	if err != nil && v.GetBool("force") && sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+var projectFreezeOverridesCmd = cli.Command{
+	Name:  "overrides",
+	Short: "List the last runs started during the freeze windows of the project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectFreezeOverridesRun(v cli.Values) (cli.ListResult, error) {
+	overrides, err := client.ProjectFreezeOverrideList(v.GetString(_ProjectKey))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(overrides), nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+const projectIntegrationsDir = "integrations"
+
+var projectPullCmd = cli.Command{
+	Name:  "pull",
+	Short: "Pull all the workflows, pipelines, applications, environments and integrations of a project",
+	Long: `
+Write the project as code files in a directory: a file for each workflow, pipeline, application and environment,
+and the integrations in the sub directory integrations. The secrets are exported encrypted with the keys of the
+project, so they must be set again to push the files to a project on another CDS instance. The passwords of the
+integrations are not exported.
+
+The directory can be pushed to a project with cdsctl project push.
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:      "output-dir",
+			ShortHand: "d",
+			Usage:     "Output directory",
+			Default:   ".cds",
+		},
+		{
+			Type:    cli.FlagBool,
+			Name:    "force",
+			Usage:   "Force, may override files",
+			Default: "false",
+		},
+		{
+			Type:    cli.FlagBool,
+			Name:    "quiet",
+			Usage:   "If true, do not output filename created",
+			Default: "false",
+		},
+	},
+}
+
+func projectPullRun(v cli.Values) error {
+	dir := strings.TrimSpace(v.GetString("output-dir"))
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(filepath.Join(dir, projectIntegrationsDir), os.FileMode(0744)); err != nil {
+		return fmt.Errorf("Unable to create directory %s: %v", v.GetString("output-dir"), err)
+	}
+
+	files, err := projectPullFiles(v.GetString(_ProjectKey), true)
+	if err != nil {
+		return err
+	}
+
+	// Write the files with the same checks as the workflow pull
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for _, name := range sortedFileNames(files) {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(files[name]))}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return workflowTarReaderToFiles(v, dir, tar.NewReader(buf))
+}
+
+// projectPullFiles returns the as code files of a project by file name.
+func projectPullFiles(projectKey string, withIntegrations bool) (map[string][]byte, error) {
+	proj, err := client.ProjectGet(projectKey, func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("withWorkflowNames", "true")
+		q.Set("withPipelineNames", "true")
+		q.Set("withApplicationNames", "true")
+		q.Set("withEnvironmentNames", "true")
+		q.Set("withIntegrations", "true")
+		r.URL.RawQuery = q.Encode()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+
+	// A workflow is pulled with its pipelines, applications and environments
+	for _, wf := range proj.WorkflowNames {
+		tr, err := client.WorkflowPull(projectKey, wf.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to pull workflow %s: %v", wf.Name, err)
+		}
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("unable to read workflow %s: %v", wf.Name, err)
+			}
+			btes, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read workflow %s: %v", wf.Name, err)
+			}
+			files[hdr.Name] = btes
+		}
+	}
+
+	// The entities which are not used by a workflow are exported one by one
+	for _, pip := range proj.PipelineNames {
+		name := fmt.Sprintf(exportentities.PullPipelineName, pip.Name)
+		if _, ok := files[name]; ok {
+			continue
+		}
+		btes, err := client.PipelineExport(projectKey, pip.Name, "yaml")
+		if err != nil {
+			return nil, fmt.Errorf("unable to export pipeline %s: %v", pip.Name, err)
+		}
+		files[name] = btes
+	}
+	for _, app := range proj.ApplicationNames {
+		name := fmt.Sprintf(exportentities.PullApplicationName, app.Name)
+		if _, ok := files[name]; ok {
+			continue
+		}
+		btes, err := client.ApplicationExport(projectKey, app.Name, "yaml")
+		if err != nil {
+			return nil, fmt.Errorf("unable to export application %s: %v", app.Name, err)
+		}
+		files[name] = btes
+	}
+	for _, env := range proj.EnvironmentNames {
+		name := fmt.Sprintf(exportentities.PullEnvironmentName, env.Name)
+		if _, ok := files[name]; ok {
+			continue
+		}
+		btes, err := client.EnvironmentExport(projectKey, env.Name, "yaml")
+		if err != nil {
+			return nil, fmt.Errorf("unable to export environment %s: %v", env.Name, err)
+		}
+		files[name] = btes
+	}
+
+	if withIntegrations {
+		for _, integ := range proj.Integrations {
+			// Public integrations are available on all the projects
+			if integ.Model.Public {
+				continue
+			}
+			pf, err := client.ProjectIntegrationGet(projectKey, integ.Name, false)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get integration %s: %v", integ.Name, err)
+			}
+			btes, err := exportentities.Marshal(pf, exportentities.FormatYAML)
+			if err != nil {
+				return nil, err
+			}
+			files[filepath.Join(projectIntegrationsDir, integ.Name+".yml")] = btes
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/cli"
+)
+
+var projectPushCmd = cli.Command{
+	Name:  "push",
+	Short: "Push all the workflows, pipelines, applications and environments of a directory to a project",
+	Long: `
+Push the files written by cdsctl project pull to a project. The differences with the project are displayed
+before pushing, then the new and modified files are imported in one transaction: if one of them is invalid,
+nothing is imported.
+
+The integrations are not pushed, the ones missing on the project are listed to be imported with
+cdsctl project integration import.
+
+	cdsctl project pull MY-PROJECT -d ./my-project
+	cdsctl project push MY-OTHER-PROJECT ./my-project
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "directory"},
+	},
+	Flags: []cli.Flag{
+		{
+			Type:  cli.FlagBool,
+			Name:  "dry-run",
+			Usage: "Only display the differences with the project",
+		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "force",
+			Usage: "Push without asking for confirmation",
+		},
+	},
+}
+
+func projectPushRun(v cli.Values) error {
+	dir := v.GetString("directory")
+	key := v.GetString(_ProjectKey)
+
+	local := make(map[string][]byte)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("Unable to read directory %s: %v", dir, err)
+	}
+	for _, fi := range infos {
+		if fi.IsDir() || !(strings.HasSuffix(fi.Name(), ".yml") || strings.HasSuffix(fi.Name(), ".yaml")) {
+			continue
+		}
+		btes, err := ioutil.ReadFile(filepath.Join(dir, fi.Name()))
+		if err != nil {
+			return fmt.Errorf("Unable to read file %s: %v", fi.Name(), err)
+		}
+		local[fi.Name()] = btes
+	}
+	if len(local) == 0 {
+		return fmt.Errorf("no file found in directory %s", dir)
+	}
+
+	remote, err := projectPullFiles(key, false)
+	if err != nil {
+		return err
+	}
+
+	// Only the new and modified files are pushed
+	var toPush []string
+	for _, name := range sortedFileNames(local) {
+		old, ok := remote[name]
+		switch {
+		case !ok:
+			fmt.Println(cli.Green("+ %s (new)", name))
+		case !bytes.Equal(old, local[name]):
+			fmt.Println(cli.Magenta("~ %s (modified)", name))
+			for _, l := range projectDiffLines(strings.Split(string(old), "\n"), strings.Split(string(local[name]), "\n")) {
+				fmt.Println(l)
+			}
+		default:
+			continue
+		}
+		toPush = append(toPush, name)
+	}
+	for _, name := range sortedFileNames(remote) {
+		if _, ok := local[name]; !ok {
+			fmt.Printf("  %s is not in the directory, it will be kept on the project\n", name)
+		}
+	}
+
+	if err := projectPushCheckIntegrations(key, filepath.Join(dir, projectIntegrationsDir)); err != nil {
+		return err
+	}
+
+	if len(toPush) == 0 {
+		fmt.Println("Nothing to push")
+		return nil
+	}
+	if v.GetBool("dry-run") {
+		return nil
+	}
+	if !v.GetBool("force") && (v.GetBool("no-interactive") || !cli.AskConfirm(fmt.Sprintf("Push %d files to project %s?", len(toPush), key))) {
+		fmt.Println("Push aborted")
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for _, name := range toPush {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(local[name]))}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(local[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	msgList, err := client.ProjectPush(key, buf)
+	for _, msg := range msgList {
+		fmt.Println(msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Project successfully pushed !")
+	return nil
+}
+
+// projectPushCheckIntegrations lists the integrations of the directory which are missing on the project.
+func projectPushCheckIntegrations(key, dir string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("Unable to read directory %s: %v", dir, err)
+	}
+
+	integs, err := client.ProjectIntegrationList(key)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(integs))
+	for _, i := range integs {
+		existing[i.Name] = true
+	}
+
+	for _, fi := range infos {
+		name := strings.TrimSuffix(fi.Name(), filepath.Ext(fi.Name()))
+		if fi.IsDir() || existing[name] {
+			continue
+		}
+		fmt.Printf("Integration %s is missing on the project, import it with: %s project integration import %s %s\n",
+			name, os.Args[0], key, filepath.Join(dir, fi.Name()))
+	}
+	return nil
+}
+
+func sortedFileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// projectDiffLines returns the removed and added lines between two versions of a file.
+func projectDiffLines(oldLines, newLines []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var res []string
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			i++
+			j++
+		case j < len(newLines) && (i == len(oldLines) || lcs[i][j+1] >= lcs[i+1][j]):
+			res = append(res, cli.Green("    + %s", newLines[j]))
+			j++
+		default:
+			res = append(res, cli.Red("    - %s", oldLines[i]))
+			i++
+		}
+	}
+	return res
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectRetentionCmd = cli.Command{
+	Name:  "retention",
+	Short: "Manage the archival of the workflow runs of the project",
+}
+
+func projectRetention() *cobra.Command {
+	return cli.NewCommand(projectRetentionCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(projectRetentionShowCmd, projectRetentionShowRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectRetentionSetCmd, projectRetentionSetRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectRetentionShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the retention settings of the project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectRetentionShowRun(v cli.Values) (interface{}, error) {
+	return client.ProjectRetentionSettingsGet(v.GetString(_ProjectKey))
+}
+
+var projectRetentionSetCmd = cli.Command{
+	Name:  "set",
+	Short: "Archive the logs and the artifacts of the old workflow runs of the project",
+	Long: `Archive the logs and the artifacts of the workflow runs terminated for more than the given number of days.
+They are moved to the given storage integration of the project, or to the shared storage of CDS.
+An archived run can be rehydrated with "cdsctl workflow rehydrate". Use 0 days to disable the archival.`,
+	Example: `cdsctl project retention set MYPROJ 90 --integration my-cold-storage`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "archive-after-days"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "integration",
+			Usage: "Storage integration of the archives",
+		},
+	},
+}
+
+func projectRetentionSetRun(v cli.Values) error {
+	days, err := v.GetInt64("archive-after-days")
+	if err != nil {
+		return err
+	}
+	return client.ProjectRetentionSettingsUpdate(v.GetString(_ProjectKey), sdk.ProjectRetentionSettings{
+		ArchiveAfterDays: int(days),
+		IntegrationName:  v.GetString("integration"),
+	})
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var projectSBOMCmd = cli.Command{
+	Name:  "sbom",
+	Short: "Search dependencies in the SBOMs of the project",
+}
+
+func projectSBOM() *cobra.Command {
+	return cli.NewCommand(projectSBOMCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectSBOMSearchCmd, projectSBOMSearchRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectSBOMSearchCmd = cli.Command{
+	Name:  "search",
+	Short: "List the last workflow runs of the project containing a dependency",
+	Example: `cdsctl project sbom search MYPROJ log4j-core 2.14.1
+cdsctl project sbom search MYPROJ lodash`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "version"},
+	},
+}
+
+func projectSBOMSearchRun(v cli.Values) (cli.ListResult, error) {
+	res, err := client.ProjectSBOMSearch(v.GetString(_ProjectKey), v.GetString("name"), v.GetString("version"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(res), nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var projectTestsCmd = cli.Command{
+	Name:  "tests",
+	Short: "Show the history of the tests of the project",
+}
+
+func projectTests() *cobra.Command {
+	return cli.NewCommand(projectTestsCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectTestsFlakyCmd, projectTestsFlakyRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectTestsHistoryCmd, projectTestsHistoryRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectTestsFlakyCmd = cli.Command{
+	Name:  "flaky",
+	Short: "List the flakiest tests of the project, which passed and failed on the same commit",
+	Example: `cdsctl project tests flaky MYPROJ
+cdsctl project tests flaky MYPROJ --limit 50 --days 7`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "limit",
+			Usage:   "Number of tests",
+			Default: "20",
+		},
+		{
+			Name:    "days",
+			Usage:   "Number of days of test results",
+			Default: "30",
+		},
+	},
+}
+
+func projectTestsFlakyRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	days, err := v.GetInt64("days")
+	if err != nil {
+		return nil, err
+	}
+	ts, err := client.ProjectFlakyTests(v.GetString(_ProjectKey), int(limit), int(days))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ts), nil
+}
+
+var projectTestsHistoryCmd = cli.Command{
+	Name:    "history",
+	Short:   "List the last results of a test in the workflows of the project",
+	Example: `cdsctl project tests history MYPROJ TestLoadUser --suite github.com/my/app/user`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "suite",
+			Usage: "Name of the test suite",
+		},
+	},
+}
+
+func projectTestsHistoryRun(v cli.Values) (cli.ListResult, error) {
+	hs, err := client.ProjectTestHistory(v.GetString(_ProjectKey), v.GetString("suite"), v.GetString("name"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(hs), nil
+}
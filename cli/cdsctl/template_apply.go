@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"strings"
@@ -183,6 +184,12 @@ func templateApplyRun(v cli.Values) error {
 		}
 	}
 
+	// detect language and module of the local repository to compute default values of params
+	var repoInfo sdk.WorkflowTemplateRepositoryInfo
+	if localRepoName != "" {
+		repoInfo = sdk.DetectWorkflowTemplateRepositoryInfo(localRepoName, ioutil.ReadFile)
+	}
+
 	// ask interactively for params if prompt not disabled
 	if !v.GetBool("no-interactive") {
 		if workflowName == "" {
@@ -237,25 +244,7 @@ func templateApplyRun(v cli.Values) error {
 		// for each param not already fill ask for the value
 		for _, p := range wt.Parameters {
 			if _, ok := params[p.Key]; !ok {
-				label := fmt.Sprintf("Value for param '%s' (type: %s, required: %t)", p.Key, p.Type, p.Required)
-
-				var choice string
-				switch p.Type {
-				case sdk.ParameterTypeRepository:
-					if localRepoPath != "" && cli.AskConfirm(fmt.Sprintf("Use detected repository '%s' for param '%s'", localRepoPath, p.Key)) {
-						choice = localRepoPath
-					} else if len(listRepositories) > 0 {
-						selected := cli.AskChoice(label, listRepositories...)
-						choice = listRepositories[selected]
-					}
-				case sdk.ParameterTypeBoolean:
-					choice = fmt.Sprintf("%t", cli.AskConfirm(fmt.Sprintf("Set value to 'true' for param '%s'", p.Key)))
-				}
-				if choice == "" {
-					choice = cli.AskValue(label)
-				}
-
-				params[p.Key] = choice
+				params[p.Key] = askTemplateParam(p, repoInfo, localRepoPath, listRepositories)
 			}
 		}
 
@@ -288,6 +277,10 @@ func templateApplyRun(v cli.Values) error {
 		Parameters:   params,
 		Detached:     v.GetBool("detach"),
 	}
+	if repoInfo.Name == "" {
+		repoInfo = wt.RepositoryInfo(req)
+	}
+	wt.SetDefaultParams(&req, repoInfo)
 	if err := wt.CheckParams(req); err != nil {
 		return err
 	}
@@ -333,6 +326,60 @@ func templateApplyRun(v cli.Values) error {
 	return workflowTarReaderToFiles(v, dir, tr)
 }
 
+// askTemplateParam asks a value for given param until it is valid.
+func askTemplateParam(p sdk.WorkflowTemplateParameter, repoInfo sdk.WorkflowTemplateRepositoryInfo, localRepoPath string, listRepositories []string) string {
+	label := fmt.Sprintf("Value for param '%s' (type: %s, required: %t)", p.Key, p.Type, p.Required)
+	if p.Description != "" {
+		label = fmt.Sprintf("%s (param: %s, type: %s, required: %t)", p.Description, p.Key, p.Type, p.Required)
+	}
+	defaultValue := p.DefaultValue(repoInfo)
+
+	for {
+		var choice string
+		switch p.Type {
+		case sdk.ParameterTypeRepository:
+			if localRepoPath != "" && cli.AskConfirm(fmt.Sprintf("Use detected repository '%s' for param '%s'", localRepoPath, p.Key)) {
+				choice = localRepoPath
+			} else if len(listRepositories) > 0 {
+				selected := cli.AskChoice(label, listRepositories...)
+				choice = listRepositories[selected]
+			}
+		case sdk.ParameterTypeBoolean:
+			choice = fmt.Sprintf("%t", cli.AskConfirm(fmt.Sprintf("Set value to 'true' for param '%s'", p.Key)))
+		case sdk.ParameterTypeString:
+			if len(p.Choices) > 0 {
+				// the default value is suggested first
+				opts := []string{}
+				if defaultValue != "" {
+					opts = append(opts, defaultValue)
+				}
+				for _, c := range p.Choices {
+					if c != defaultValue {
+						opts = append(opts, c)
+					}
+				}
+				choice = opts[cli.AskChoice(label, opts...)]
+			}
+		}
+		if choice == "" {
+			if defaultValue != "" {
+				choice = cli.AskValue(fmt.Sprintf("%s [default: %s]", label, defaultValue))
+				if choice == "" {
+					choice = defaultValue
+				}
+			} else {
+				choice = cli.AskValue(label)
+			}
+		}
+
+		if err := p.CheckValue(choice); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		return choice
+	}
+}
+
 func teeTarReader(r *tar.Reader, buf io.Writer) (*tar.Reader, error) {
 	var b bytes.Buffer
 	tw1, tw2 := tar.NewWriter(&b), tar.NewWriter(buf)
@@ -469,7 +469,14 @@ func templateBulkRun(v cli.Values) error {
 							}
 							value = fmt.Sprintf("%t", result)
 						default:
-							if err := survey.AskOne(&survey.Input{Message: label}, &value, nil); err != nil {
+							defaultValue := p.DefaultValue(wt.RepositoryInfo(operation.Request))
+							var prompt survey.Prompt = &survey.Input{Message: label, Default: defaultValue}
+							if len(p.Choices) > 0 {
+								prompt = &survey.Select{Message: label, Options: p.Choices, Default: defaultValue}
+							}
+							if err := survey.AskOne(prompt, &value, func(ans interface{}) error {
+								return p.CheckValue(fmt.Sprintf("%v", ans))
+							}); err != nil {
 								return err
 							}
 						}
@@ -24,6 +24,7 @@ var toolsCmd = cli.Command{
 func tools() *cobra.Command {
 	return cli.NewCommand(toolsCmd, nil, []*cobra.Command{
 		cli.NewCommand(toolsYamlSchema, toolsYamlSchemaRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(toolsLSPCmd, toolsLSPRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+var toolsLSPCmd = cli.Command{
+	Name:  "lsp",
+	Short: "Start a language server for the CDS files",
+	Long: `
+Start a server implementing the Language Server Protocol on the standard input and output, for the editors.
+The files are validated against the data of the project (pipelines, applications, environments and
+integrations), the server also provides the documentation of the hook configurations and the completion of the
+parameters of the pipelines.
+
+The project is the one of the current directory, or the one given with --project-key. With VSCode, set the
+command of a generic LSP client extension to:
+
+	cdsctl tools lsp --no-interactive
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func toolsLSPRun(v cli.Values) error {
+	s := newLSPServer(v.GetString(_ProjectKey), os.Stdin, os.Stdout)
+	return s.serve()
+}
+
+// The errors and the kinds of the Language Server Protocol which are used by the server.
+const (
+	lspErrorParse          = -32700
+	lspErrorMethodNotFound = -32601
+	lspErrorInvalidParams  = -32602
+
+	lspSeverityError   = 1
+	lspSeverityWarning = 2
+
+	lspCompletionField = 5
+	lspCompletionValue = 12
+
+	lspTextDocumentSyncFull = 1
+)
+
+type lspRequest struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+type lspResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *lspError        `json:"error,omitempty"`
+}
+
+type lspNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspTextDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text,omitempty"`
+}
+
+type lspTextDocumentParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	Position       lspPosition     `json:"position"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspPublishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+type lspMarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspHover struct {
+	Contents lspMarkupContent `json:"contents"`
+}
+
+type lspCompletionItem struct {
+	Label         string `json:"label"`
+	Kind          int    `json:"kind"`
+	Detail        string `json:"detail,omitempty"`
+	Documentation string `json:"documentation,omitempty"`
+	InsertText    string `json:"insertText,omitempty"`
+}
+
+// lspReadMessage reads a message prefixed by its headers.
+func lspReadMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if i := strings.Index(line, ":"); i > 0 && strings.EqualFold(line[:i], "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(line[i+1:]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid header %q", line)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("missing header Content-Length")
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// lspWriteMessage writes a message prefixed by its headers.
+func lspWriteMessage(w io.Writer, msg interface{}) error {
+	btes, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(btes), btes)
+	return err
+}
+
+// lspServer handles the messages of an editor one by one.
+type lspServer struct {
+	in      *bufio.Reader
+	out     io.Writer
+	docs    map[string]string
+	project *lspProject
+}
+
+func newLSPServer(projectKey string, in io.Reader, out io.Writer) *lspServer {
+	return &lspServer{
+		in:      bufio.NewReader(in),
+		out:     out,
+		docs:    make(map[string]string),
+		project: &lspProject{key: projectKey},
+	}
+}
+
+func (s *lspServer) serve() error {
+	for {
+		btes, err := lspReadMessage(s.in)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		var req lspRequest
+		if err := json.Unmarshal(btes, &req); err != nil {
+			if err := lspWriteMessage(s.out, lspResponse{JSONRPC: "2.0", Error: &lspError{Code: lspErrorParse, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, lerr := s.handle(req)
+		// Notifications have no response
+		if req.ID == nil {
+			if lerr != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", req.Method, lerr.Message)
+			}
+			continue
+		}
+		resp := lspResponse{JSONRPC: "2.0", ID: req.ID, Result: result, Error: lerr}
+		if lerr == nil && result == nil {
+			resp.Result = json.RawMessage("null")
+		}
+		if err := lspWriteMessage(s.out, resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *lspServer) handle(req lspRequest) (interface{}, *lspError) {
+	var params lspTextDocumentParams
+	if strings.HasPrefix(req.Method, "textDocument/") {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &lspError{Code: lspErrorInvalidParams, Message: err.Error()}
+		}
+	}
+	uri := params.TextDocument.URI
+
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   lspTextDocumentSyncFull,
+				"hoverProvider":      true,
+				"completionProvider": map[string]interface{}{"triggerCharacters": []string{":", " "}},
+			},
+			"serverInfo": map[string]string{"name": "cdsctl", "version": sdk.VERSION},
+		}, nil
+	case "initialized", "shutdown", "$/cancelRequest", "workspace/didChangeConfiguration":
+		return nil, nil
+	case "textDocument/didOpen":
+		s.docs[uri] = params.TextDocument.Text
+		return nil, s.publishDiagnostics(uri)
+	case "textDocument/didChange":
+		if len(params.ContentChanges) > 0 {
+			s.docs[uri] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		}
+		return nil, s.publishDiagnostics(uri)
+	case "textDocument/didSave":
+		// The project may have been changed by pushing the files
+		s.project.loadedAt = time.Time{}
+		return nil, s.publishDiagnostics(uri)
+	case "textDocument/didClose":
+		delete(s.docs, uri)
+		return nil, s.notify("textDocument/publishDiagnostics", lspPublishDiagnosticsParams{URI: uri, Diagnostics: []lspDiagnostic{}})
+	case "textDocument/hover":
+		doc, ok := s.docs[uri]
+		if !ok || lspFileKind(uri) != exportentities.SchemaWorkflow {
+			return nil, nil
+		}
+		h := s.project.workflowHover(lspParseYAML(doc), params.Position, lspDir(uri))
+		if h == nil {
+			return nil, nil
+		}
+		return h, nil
+	case "textDocument/completion":
+		doc, ok := s.docs[uri]
+		if !ok || lspFileKind(uri) != exportentities.SchemaWorkflow {
+			return []lspCompletionItem{}, nil
+		}
+		return s.project.workflowCompletion(doc, params.Position, lspDir(uri)), nil
+	}
+	return nil, &lspError{Code: lspErrorMethodNotFound, Message: fmt.Sprintf("method %s not found", req.Method)}
+}
+
+func (s *lspServer) publishDiagnostics(uri string) *lspError {
+	doc, ok := s.docs[uri]
+	if !ok {
+		return nil
+	}
+	var diags []lspDiagnostic
+	switch lspFileKind(uri) {
+	case exportentities.SchemaWorkflow:
+		diags = s.project.workflowDiagnostics(doc, lspDir(uri))
+	case exportentities.SchemaPipeline:
+		diags = s.project.pipelineDiagnostics(doc, lspDir(uri))
+	case exportentities.SchemaApplication:
+		diags = s.project.applicationDiagnostics(doc)
+	case exportentities.SchemaEnvironment:
+		diags = s.project.environmentDiagnostics(doc)
+	}
+	if diags == nil {
+		diags = []lspDiagnostic{}
+	}
+	return s.notify("textDocument/publishDiagnostics", lspPublishDiagnosticsParams{URI: uri, Diagnostics: diags})
+}
+
+func (s *lspServer) notify(method string, params interface{}) *lspError {
+	if err := lspWriteMessage(s.out, lspNotification{JSONRPC: "2.0", Method: method, Params: params}); err != nil {
+		return &lspError{Message: err.Error()}
+	}
+	return nil
+}
+
+// lspFileKind returns the kind of a CDS file from its name.
+func lspFileKind(uri string) string {
+	switch {
+	case strings.HasSuffix(uri, ".inc.yml"), strings.HasSuffix(uri, ".act.yml"):
+		return ""
+	case strings.HasSuffix(uri, ".pip.yml"):
+		return exportentities.SchemaPipeline
+	case strings.HasSuffix(uri, ".app.yml"):
+		return exportentities.SchemaApplication
+	case strings.HasSuffix(uri, ".env.yml"):
+		return exportentities.SchemaEnvironment
+	case strings.HasSuffix(uri, ".yml"), strings.HasSuffix(uri, ".yaml"):
+		return exportentities.SchemaWorkflow
+	}
+	return ""
+}
+
+// lspDir returns the directory of a document, the pipelines, applications and environments of the directory are
+// used as the ones of the project.
+func lspDir(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.Dir(u.Path)
+}
+
+// lspResolveIncludes adds to a pipeline the content of the files of the directory that it includes.
+func lspResolveIncludes(dir string, pip *exportentities.PipelineV1) error {
+	if len(pip.Include) == 0 {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf(exportentities.PullIncludeName, "*")))
+	if err != nil {
+		return err
+	}
+	includes := make(map[string]exportentities.PipelineInclude, len(files))
+	for _, f := range files {
+		btes, err := ioutil.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		var inc exportentities.PipelineInclude
+		if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &inc); err != nil {
+			return fmt.Errorf("%s: %v", filepath.Base(f), lspErrorMessage(err))
+		}
+		if inc.Name == "" {
+			inc.Name = strings.TrimSuffix(filepath.Base(f), ".inc.yml")
+		}
+		includes[inc.Name] = inc
+	}
+	return pip.ResolveIncludes(includes)
+}
+
+// lspProject is the data of the project used to validate the files, it is reloaded when a file is saved.
+type lspProject struct {
+	key          string
+	loaded       bool
+	loadedAt     time.Time
+	pipelines    map[string]bool
+	applications map[string]bool
+	environments map[string]bool
+	integrations map[string]sdk.ProjectIntegration
+	parameters   map[string][]sdk.Parameter
+}
+
+func (p *lspProject) load() {
+	if p.key == "" || time.Since(p.loadedAt) < time.Minute {
+		return
+	}
+	p.loadedAt = time.Now()
+	p.pipelines = make(map[string]bool)
+	p.applications = make(map[string]bool)
+	p.environments = make(map[string]bool)
+	p.integrations = make(map[string]sdk.ProjectIntegration)
+	p.parameters = make(map[string][]sdk.Parameter)
+
+	proj, err := client.ProjectGet(p.key, func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("withPipelineNames", "true")
+		q.Set("withApplicationNames", "true")
+		q.Set("withEnvironmentNames", "true")
+		q.Set("withIntegrations", "true")
+		r.URL.RawQuery = q.Encode()
+	})
+	if err != nil {
+		// The files are still checked without the data of the project
+		fmt.Fprintf(os.Stderr, "unable to load project %s: %v\n", p.key, err)
+		p.loaded = false
+		return
+	}
+	p.loaded = true
+	for _, n := range proj.PipelineNames {
+		p.pipelines[n.Name] = true
+	}
+	for _, n := range proj.ApplicationNames {
+		p.applications[n.Name] = true
+	}
+	for _, n := range proj.EnvironmentNames {
+		p.environments[n.Name] = true
+	}
+	for _, i := range proj.Integrations {
+		p.integrations[i.Name] = i
+	}
+}
+
+// pipelineParameters returns the parameters of a pipeline of the directory, or of the project.
+func (p *lspProject) pipelineParameters(dir, name string) ([]sdk.Parameter, bool) {
+	if params, ok := p.parameters[name]; ok {
+		return params, true
+	}
+	if dir != "" {
+		if btes, err := ioutil.ReadFile(filepath.Join(dir, fmt.Sprintf(exportentities.PullPipelineName, name))); err == nil {
+			var pip exportentities.PipelineV1
+			if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &pip); err == nil && lspResolveIncludes(dir, &pip) == nil {
+				var params []sdk.Parameter
+				for n, v := range pip.Parameters {
+					params = append(params, sdk.Parameter{Name: n, Type: v.Type, Value: v.DefaultValue, Description: v.Description})
+				}
+				return params, true
+			}
+		}
+	}
+	if !p.pipelines[name] {
+		return nil, false
+	}
+	pip, err := client.PipelineGet(p.key, name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to load pipeline %s: %v\n", name, err)
+		return nil, false
+	}
+	p.parameters[name] = pip.Parameter
+	return pip.Parameter, true
+}
+
+// localNames returns the names of the pipelines, applications or environments of the files of a directory.
+func (p *lspProject) localNames(dir, suffix string) map[string]bool {
+	res := make(map[string]bool)
+	if dir == "" {
+		return res
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*"+suffix))
+	if err != nil {
+		return res
+	}
+	for _, f := range files {
+		btes, err := ioutil.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var e struct {
+			Name string `yaml:"name"`
+		}
+		if err := exportentities.Unmarshal(btes, exportentities.FormatYAML, &e); err == nil && e.Name != "" {
+			res[e.Name] = true
+		}
+	}
+	return res
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lspTestWorkflow = `name: test
+version: v1.0
+workflow:
+  build:
+    pipeline: build
+    parameters:
+      debug: "true"
+  deploy:
+    depends_on:
+    - build
+    pipeline: deploy
+hooks:
+  build:
+  - type: Scheduler
+    config:
+      cron: 0 2 * * *
+      catchup_policy: run-once
+event_integrations:
+- my-kafka
+`
+
+func TestLSPYAML(t *testing.T) {
+	lines := lspParseYAML(lspTestWorkflow)
+
+	assert.Equal(t, 3, lspFindPath(lines, "workflow", "build"))
+	assert.Equal(t, 10, lspFindPath(lines, "workflow", "deploy", "pipeline"))
+	assert.Equal(t, -1, lspFindPath(lines, "workflow", "build", "depends_on"))
+
+	items := lspItems(lines, lspFindPath(lines, "hooks", "build"))
+	require.Len(t, items, 1)
+	assert.Equal(t, "Scheduler", lines[lspFind(lines, items[0], "type")].value)
+	assert.Equal(t, 15, lspFind(lines, lspFind(lines, items[0], "config"), "cron"))
+	assert.Equal(t, "0 2 * * *", lines[15].value)
+	assert.Equal(t, "true", lines[6].value)
+
+	assert.Equal(t, []string{"workflow", "build", "parameters"}, lspPath(lines, 6))
+	assert.Equal(t, []string{"hooks", "build", "-"}, lspPath(lines, 13))
+	assert.Equal(t, []string{"hooks", "build", "-", "config"}, lspPath(lines, 16))
+	assert.Equal(t, []string{"event_integrations"}, lspPath(lines, 18))
+	assert.Equal(t, []int{18}, lspItems(lines, lspFindPath(lines, "event_integrations")))
+}
+
+func TestLSPMessages(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, lspWriteMessage(buf, lspNotification{JSONRPC: "2.0", Method: "exit"}))
+	assert.True(t, strings.HasPrefix(buf.String(), "Content-Length: 47\r\n\r\n"))
+
+	btes, err := lspReadMessage(bufio.NewReader(buf))
+	require.NoError(t, err)
+	var req lspRequest
+	require.NoError(t, json.Unmarshal(btes, &req))
+	assert.Equal(t, "exit", req.Method)
+
+	_, err = lspReadMessage(bufio.NewReader(strings.NewReader("Content-Type: json\r\n\r\n{}")))
+	assert.Error(t, err)
+}
+
+func TestLSPWorkflow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cds-lsp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "build.pip.yml"), []byte(`version: v1.0
+name: build
+parameters:
+  debug:
+    type: boolean
+    default: "false"
+  target:
+    type: string
+`), 0644))
+
+	// Without project, only the files are checked
+	p := &lspProject{}
+
+	diags := p.workflowDiagnostics(strings.Replace(lspTestWorkflow, "run-once", "never", 1), dir)
+	require.Len(t, diags, 1)
+	assert.Equal(t, 16, diags[0].Range.Start.Line)
+	assert.Contains(t, diags[0].Message, "invalid value never for catchup_policy")
+
+	diags = p.workflowDiagnostics(strings.Replace(lspTestWorkflow, `debug: "true"`, `debug: "yes"`, 1), dir)
+	require.Len(t, diags, 1)
+	assert.Equal(t, 6, diags[0].Range.Start.Line)
+
+	diags = p.workflowDiagnostics("name: test\nworkflow: [", dir)
+	require.Len(t, diags, 1)
+	assert.Equal(t, lspSeverityError, diags[0].Severity)
+
+	h := p.workflowHover(lspParseYAML(lspTestWorkflow), lspPosition{Line: 15, Character: 7}, dir)
+	require.NotNil(t, h)
+	assert.Contains(t, h.Contents.Value, "**cron** (Scheduler hook)")
+
+	h = p.workflowHover(lspParseYAML(lspTestWorkflow), lspPosition{Line: 6, Character: 7}, dir)
+	require.NotNil(t, h)
+	assert.Contains(t, h.Contents.Value, "**debug** (parameter of pipeline build)")
+
+	// The parameters which are not set are proposed
+	doc := strings.Replace(lspTestWorkflow, `      debug: "true"`, `      debug: "true"`+"\n      ", 1)
+	items := p.workflowCompletion(doc, lspPosition{Line: 7, Character: 6}, dir)
+	require.Len(t, items, 1)
+	assert.Equal(t, "target", items[0].Label)
+
+	items = p.workflowCompletion(lspTestWorkflow, lspPosition{Line: 6, Character: 13}, dir)
+	require.Len(t, items, 2)
+	assert.Equal(t, "false", items[0].Label)
+
+	items = p.workflowCompletion(lspTestWorkflow, lspPosition{Line: 4, Character: 14}, dir)
+	require.Len(t, items, 1)
+	assert.Equal(t, "build", items[0].Label)
+}
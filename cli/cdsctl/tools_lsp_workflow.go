@@ -0,0 +1,551 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func lspErrorMessage(err error) string {
+	if e := sdk.ExtractHTTPError(err, ""); e.ID != sdk.ErrUnknownError.ID {
+		return e.Message
+	}
+	return sdk.Cause(err).Error()
+}
+
+// lspDiagnostics collects the diagnostics of a document, a diagnostic covers a whole line.
+type lspDiagnostics struct {
+	lines []lspYAMLLine
+	diags []lspDiagnostic
+}
+
+func (d *lspDiagnostics) add(line, severity int, format string, args ...interface{}) {
+	if line < 0 || line >= len(d.lines) {
+		line = 0
+	}
+	start, end := 0, 0
+	if line < len(d.lines) {
+		if d.lines[line].indent > 0 {
+			start = d.lines[line].indent
+		}
+		end = d.lines[line].length
+	}
+	d.diags = append(d.diags, lspDiagnostic{
+		Range:    lspRange{Start: lspPosition{Line: line, Character: start}, End: lspPosition{Line: line, Character: end}},
+		Severity: severity,
+		Source:   "cds",
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *lspDiagnostics) addError(line int, err error) {
+	if m, ok := sdk.Cause(err).(*sdk.MultiError); ok {
+		for _, e := range *m {
+			d.addError(line, e)
+		}
+		return
+	}
+	d.add(line, lspSeverityError, "%s", lspErrorMessage(err))
+}
+
+// lspFindIn returns the line of a key in the children of a line, or -1 if the parent is not in the document.
+func lspFindIn(lines []lspYAMLLine, parent int, key string) int {
+	if parent < 0 {
+		return -1
+	}
+	return lspFind(lines, parent, key)
+}
+
+// names returns the names of the pipelines, applications or environments of the directory and of the project.
+func (p *lspProject) names(dir, kind string) map[string]bool {
+	var res, remote map[string]bool
+	switch kind {
+	case exportentities.SchemaPipeline:
+		res, remote = p.localNames(dir, ".pip.yml"), p.pipelines
+	case exportentities.SchemaApplication:
+		res, remote = p.localNames(dir, ".app.yml"), p.applications
+	case exportentities.SchemaEnvironment:
+		res, remote = p.localNames(dir, ".env.yml"), p.environments
+	}
+	for n := range remote {
+		res[n] = true
+	}
+	return res
+}
+
+func (p *lspProject) workflowDiagnostics(doc, dir string) []lspDiagnostic {
+	lines := lspParseYAML(doc)
+	d := &lspDiagnostics{lines: lines}
+
+	var w exportentities.Workflow
+	if err := exportentities.Unmarshal([]byte(doc), exportentities.FormatYAML, &w); err != nil {
+		d.add(lspErrorLine(err), lspSeverityError, "%s", lspErrorMessage(err))
+		return d.diags
+	}
+	if err := w.CheckValidity(); err != nil {
+		d.addError(0, err)
+	}
+	if err := w.CheckDependencies(); err != nil {
+		d.addError(0, err)
+	}
+
+	p.load()
+	names := map[string]map[string]bool{
+		exportentities.SchemaPipeline:    p.names(dir, exportentities.SchemaPipeline),
+		exportentities.SchemaApplication: p.names(dir, exportentities.SchemaApplication),
+		exportentities.SchemaEnvironment: p.names(dir, exportentities.SchemaEnvironment),
+	}
+	// Without the data of the project, only the files of the directory are known
+	checkName := func(node int, key, kind, name string) {
+		if name != "" && p.loaded && !names[kind][name] {
+			d.add(lspFind(lines, node, key), lspSeverityError, "%s %s not found in project %s", kind, name, p.key)
+		}
+	}
+
+	for name, e := range w.Entries() {
+		node := -1
+		if len(w.Workflow) > 0 {
+			if node = lspFindPath(lines, "workflow", name); node < 0 {
+				continue
+			}
+		}
+		checkName(node, "pipeline", exportentities.SchemaPipeline, e.PipelineName)
+		checkName(node, "application", exportentities.SchemaApplication, e.ApplicationName)
+		checkName(node, "environment", exportentities.SchemaEnvironment, e.EnvironmentName)
+		if _, ok := p.integrations[e.ProjectIntegrationName]; e.ProjectIntegrationName != "" && p.loaded && !ok {
+			d.add(lspFind(lines, node, "integration"), lspSeverityError, "integration %s not found in project %s", e.ProjectIntegrationName, p.key)
+		}
+
+		if len(e.Parameters) > 0 && e.PipelineName != "" {
+			if params, ok := p.pipelineParameters(dir, e.PipelineName); ok {
+				paramsLine := lspFind(lines, node, "parameters")
+				for k, v := range e.Parameters {
+					param := sdk.ParameterFind(params, k)
+					if param == nil {
+						d.add(lspFindIn(lines, paramsLine, k), lspSeverityWarning, "%s is not a parameter of pipeline %s", k, e.PipelineName)
+					} else if err := param.CheckValue(v); err != nil {
+						d.add(lspFindIn(lines, paramsLine, k), lspSeverityError, "%s", lspErrorMessage(err))
+					}
+				}
+			}
+		}
+
+		if e.OutgoingHookModelName != "" {
+			m := sdk.GetBuiltinOutgoingHookModelByName(e.OutgoingHookModelName)
+			if m == nil {
+				d.add(lspFind(lines, node, "trigger"), lspSeverityError, "unknown outgoing hook %s", e.OutgoingHookModelName)
+			} else {
+				p.checkHookConfig(d, lspFind(lines, node, "config"), m, e.OutgoingHookConfig)
+			}
+		}
+	}
+
+	checkHooks := func(parent int, hooks []exportentities.HookEntry) {
+		items := lspItems(lines, parent)
+		for i, h := range hooks {
+			item := -1
+			if i < len(items) {
+				item = items[i]
+			}
+			m := sdk.GetBuiltinHookModelByName(h.Model)
+			if m == nil {
+				d.add(lspFindIn(lines, item, "type"), lspSeverityError, "unknown hook %s", h.Model)
+				continue
+			}
+			p.checkHookConfig(d, lspFindIn(lines, item, "config"), m, h.Config)
+		}
+	}
+	checkHooks(lspFindPath(lines, "pipeline_hooks"), w.PipelineHooks)
+	for node, hooks := range w.Hooks {
+		checkHooks(lspFindPath(lines, "hooks", node), hooks)
+	}
+
+	items := lspItems(lines, lspFindPath(lines, "event_integrations"))
+	for i, name := range w.EventIntegrations {
+		line := -1
+		if i < len(items) {
+			line = items[i]
+		}
+		if integ, ok := p.integrations[name]; p.loaded && (!ok || !integ.Model.Event) {
+			d.add(line, lspSeverityError, "event integration %s not found in project %s", name, p.key)
+		}
+	}
+
+	for _, n := range w.Notifications {
+		if _, err := exportentities.ProcessNotificationValues(n); err != nil {
+			d.add(lspFindPath(lines, "notify"), lspSeverityError, "%s", lspErrorMessage(err))
+		}
+	}
+	for nodes, notifs := range w.MapNotifications {
+		for _, n := range notifs {
+			if _, err := exportentities.ProcessNotificationValues(n); err != nil {
+				d.add(lspFindPath(lines, "notifications", nodes), lspSeverityError, "%s", lspErrorMessage(err))
+			}
+		}
+	}
+
+	return d.diags
+}
+
+// checkHookConfig checks the configuration of a hook like the API does when importing the workflow.
+func (p *lspProject) checkHookConfig(d *lspDiagnostics, configLine int, m *sdk.WorkflowHookModel, config map[string]string) {
+	for k, v := range config {
+		line := lspFindIn(d.lines, configLine, k)
+		def, ok := m.DefaultConfig[k]
+		switch {
+		case !ok && k != sdk.HookConfigPriority:
+			d.add(line, lspSeverityWarning, "%s is not a configuration of hook %s", k, m.Name)
+		case !ok:
+		case !def.Configurable && v != def.Value:
+			d.add(line, lspSeverityError, "%s is not configurable for hook %s", k, m.Name)
+		case len(def.MultipleChoiceList) > 0 && !sdk.IsInArray(v, def.MultipleChoiceList):
+			d.add(line, lspSeverityError, "invalid value %s for %s, the choices are: %s", v, k, strings.Join(def.MultipleChoiceList, ", "))
+		case def.Type == sdk.HookConfigTypeIntegration && p.loaded:
+			if integ, ok := p.integrations[v]; !ok || !integ.Model.Hook {
+				d.add(line, lspSeverityError, "hook integration %s not found in project %s", v, p.key)
+			}
+		}
+	}
+}
+
+func (p *lspProject) pipelineDiagnostics(doc, dir string) []lspDiagnostic {
+	d := &lspDiagnostics{lines: lspParseYAML(doc)}
+	var pip exportentities.PipelineV1
+	if err := exportentities.Unmarshal([]byte(doc), exportentities.FormatYAML, &pip); err != nil {
+		d.add(lspErrorLine(err), lspSeverityError, "%s", lspErrorMessage(err))
+		return d.diags
+	}
+	if err := lspResolveIncludes(dir, &pip); err != nil {
+		d.addError(lspFindPath(d.lines, "include"), err)
+		return d.diags
+	}
+	if _, err := pip.Pipeline(); err != nil {
+		d.addError(0, err)
+	}
+	return d.diags
+}
+
+func (p *lspProject) applicationDiagnostics(doc string) []lspDiagnostic {
+	lines := lspParseYAML(doc)
+	d := &lspDiagnostics{lines: lines}
+	var app exportentities.Application
+	if err := exportentities.Unmarshal([]byte(doc), exportentities.FormatYAML, &app); err != nil {
+		d.add(lspErrorLine(err), lspSeverityError, "%s", lspErrorMessage(err))
+		return d.diags
+	}
+	p.load()
+	for name := range app.DeploymentStrategies {
+		if _, ok := p.integrations[name]; p.loaded && !ok {
+			d.add(lspFindPath(lines, "deployments", name), lspSeverityError, "integration %s not found in project %s", name, p.key)
+		}
+	}
+	return d.diags
+}
+
+func (p *lspProject) environmentDiagnostics(doc string) []lspDiagnostic {
+	d := &lspDiagnostics{lines: lspParseYAML(doc)}
+	var env exportentities.Environment
+	if err := exportentities.Unmarshal([]byte(doc), exportentities.FormatYAML, &env); err != nil {
+		d.add(lspErrorLine(err), lspSeverityError, "%s", lspErrorMessage(err))
+	}
+	return d.diags
+}
+
+// hookModel returns the model of the hook of a list item, or of the outgoing hook of a node.
+func lspHookModel(lines []lspYAMLLine, owner int) *sdk.WorkflowHookModel {
+	if owner < 0 {
+		return nil
+	}
+	if t := lspFind(lines, owner, "type"); t >= 0 {
+		return sdk.GetBuiltinHookModelByName(lines[t].value)
+	}
+	if t := lspFind(lines, owner, "trigger"); t >= 0 {
+		return sdk.GetBuiltinOutgoingHookModelByName(lines[t].value)
+	}
+	return nil
+}
+
+func lspHookModelDoc(m *sdk.WorkflowHookModel) string {
+	keys := make([]string, 0, len(m.DefaultConfig))
+	for k, v := range m.DefaultConfig {
+		if v.Configurable {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	doc := fmt.Sprintf("**%s** hook", m.Name)
+	if m.Description != "" {
+		doc += "\n\n" + m.Description
+	}
+	if len(keys) > 0 {
+		doc += "\n\nConfiguration: `" + strings.Join(keys, "`, `") + "`"
+	}
+	return doc
+}
+
+func lspHookConfigDoc(m *sdk.WorkflowHookModel, key string, v sdk.WorkflowNodeHookConfigValue) string {
+	doc := fmt.Sprintf("**%s** (%s hook)\n\nType: %s", key, m.Name, v.Type)
+	if v.Value != "" {
+		doc += fmt.Sprintf("\n\nDefault: `%s`", v.Value)
+	}
+	if len(v.MultipleChoiceList) > 0 {
+		doc += "\n\nChoices: `" + strings.Join(v.MultipleChoiceList, "`, `") + "`"
+	}
+	if !v.Configurable {
+		doc += "\n\nNot configurable"
+	}
+	return doc
+}
+
+func lspParameterDoc(pipeline string, param sdk.Parameter) string {
+	doc := fmt.Sprintf("**%s** (parameter of pipeline %s)\n\nType: %s", param.Name, pipeline, param.Type)
+	if param.Value != "" {
+		doc += fmt.Sprintf("\n\nDefault: `%s`", param.Value)
+	}
+	if param.Description != "" {
+		doc += "\n\n" + param.Description
+	}
+	return doc
+}
+
+// lspEntryType returns the type of the entry of a workflow file at the given path.
+func lspEntryType(path []string) reflect.Type {
+	switch {
+	case len(path) == 0:
+		return reflect.TypeOf(exportentities.Workflow{})
+	case len(path) == 2 && path[0] == "workflow":
+		return reflect.TypeOf(exportentities.NodeEntry{})
+	case len(path) == 2 && path[0] == "pipeline_hooks" && path[1] == "-",
+		len(path) == 3 && path[0] == "hooks" && path[2] == "-":
+		return reflect.TypeOf(exportentities.HookEntry{})
+	case path[len(path)-1] == "concurrency":
+		return reflect.TypeOf(exportentities.ConcurrencyEntry{})
+	}
+	return nil
+}
+
+// lspFields returns the yaml keys of the fields of a type with their description.
+func lspFields(t reflect.Type) map[string]string {
+	res := make(map[string]string)
+	if t == nil {
+		return res
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		res[key] = f.Tag.Get("jsonschema_description")
+	}
+	return res
+}
+
+func (p *lspProject) workflowHover(lines []lspYAMLLine, pos lspPosition, dir string) *lspHover {
+	if pos.Line >= len(lines) || lines[pos.Line].key == "" {
+		return nil
+	}
+	l := lines[pos.Line]
+	path := lspPath(lines, pos.Line)
+	parent := lspParent(lines, pos.Line)
+	markdown := func(s string) *lspHover {
+		return &lspHover{Contents: lspMarkupContent{Kind: "markdown", Value: s}}
+	}
+
+	if len(path) > 0 {
+		switch path[len(path)-1] {
+		case "config":
+			if m := lspHookModel(lines, lspParent(lines, parent)); m != nil {
+				if v, ok := m.DefaultConfig[l.key]; ok {
+					return markdown(lspHookConfigDoc(m, l.key, v))
+				}
+			}
+		case "parameters":
+			if pip := lspFind(lines, lspParent(lines, parent), "pipeline"); pip >= 0 {
+				p.load()
+				params, _ := p.pipelineParameters(dir, lines[pip].value)
+				if param := sdk.ParameterFind(params, l.key); param != nil {
+					return markdown(lspParameterDoc(lines[pip].value, *param))
+				}
+			}
+		}
+	}
+
+	t := lspEntryType(path)
+	if l.key == "type" && t == reflect.TypeOf(exportentities.HookEntry{}) {
+		if m := sdk.GetBuiltinHookModelByName(l.value); m != nil {
+			return markdown(lspHookModelDoc(m))
+		}
+	}
+	if l.key == "trigger" {
+		if m := sdk.GetBuiltinOutgoingHookModelByName(l.value); m != nil {
+			return markdown(lspHookModelDoc(m))
+		}
+	}
+	if desc := lspFields(t)[l.key]; desc != "" {
+		return markdown(fmt.Sprintf("**%s**\n\n%s", l.key, desc))
+	}
+	return nil
+}
+
+func (p *lspProject) workflowCompletion(doc string, pos lspPosition, dir string) []lspCompletionItem {
+	items := []lspCompletionItem{}
+	raw := strings.Split(doc, "\n")
+	if pos.Line >= len(raw) {
+		return items
+	}
+	// The current line is read until the cursor
+	cur := strings.TrimRight(raw[pos.Line], "\r")
+	if pos.Character < len(cur) {
+		cur = cur[:pos.Character]
+	}
+	lines := lspParseYAML(doc)
+	l := lspParseYAMLLine(cur)
+	if l.indent < 0 {
+		l.indent = len(cur)
+	}
+	lines[pos.Line] = l
+	path := lspPath(lines, pos.Line)
+	parent := lspParent(lines, pos.Line)
+	last := ""
+	if len(path) > 0 {
+		last = path[len(path)-1]
+	}
+
+	p.load()
+	values := func(detail string, names ...string) []lspCompletionItem {
+		sort.Strings(names)
+		for _, n := range names {
+			items = append(items, lspCompletionItem{Label: n, Kind: lspCompletionValue, Detail: detail})
+		}
+		return items
+	}
+	keys := func(names map[string]bool) []string {
+		res := make([]string, 0, len(names))
+		for n := range names {
+			res = append(res, n)
+		}
+		return res
+	}
+	integrations := func(filter func(sdk.ProjectIntegration) bool) []string {
+		var res []string
+		for n, i := range p.integrations {
+			if filter(i) {
+				res = append(res, n)
+			}
+		}
+		return res
+	}
+	// The keys already set are not proposed
+	existing := make(map[string]bool)
+	for _, i := range lspChildren(lines, parent) {
+		existing[lines[i].key] = true
+	}
+
+	// Values
+	if l.item && l.key == "" && last == "event_integrations" {
+		return values("event integration", integrations(func(i sdk.ProjectIntegration) bool { return i.Model.Event })...)
+	}
+	if l.key != "" {
+		switch {
+		case last == "config":
+			m := lspHookModel(lines, lspParent(lines, parent))
+			if m == nil {
+				return items
+			}
+			v := m.DefaultConfig[l.key]
+			if v.Type == sdk.HookConfigTypeIntegration {
+				return values("hook integration", integrations(func(i sdk.ProjectIntegration) bool { return i.Model.Hook })...)
+			}
+			return values(m.Name+" hook", v.MultipleChoiceList...)
+		case last == "parameters":
+			pip := lspFind(lines, lspParent(lines, parent), "pipeline")
+			if pip < 0 {
+				return items
+			}
+			params, _ := p.pipelineParameters(dir, lines[pip].value)
+			param := sdk.ParameterFind(params, l.key)
+			switch {
+			case param == nil:
+			case param.Type == sdk.BooleanParameter:
+				return values(param.Type, "true", "false")
+			case param.Type == sdk.ListParameter:
+				return values(param.Type, strings.Split(param.Value, ";")...)
+			}
+			return items
+		case l.key == "pipeline":
+			return values("pipeline", keys(p.names(dir, exportentities.SchemaPipeline))...)
+		case l.key == "application":
+			return values("application", keys(p.names(dir, exportentities.SchemaApplication))...)
+		case l.key == "environment":
+			return values("environment", keys(p.names(dir, exportentities.SchemaEnvironment))...)
+		case l.key == "integration":
+			return values("integration", integrations(func(i sdk.ProjectIntegration) bool { return !i.Model.Event })...)
+		case l.key == "type" && lspEntryType(path) == reflect.TypeOf(exportentities.HookEntry{}):
+			var names []string
+			for _, m := range sdk.BuiltinHookModels {
+				names = append(names, m.Name)
+			}
+			return values("hook", names...)
+		case l.key == "trigger":
+			var names []string
+			for _, m := range sdk.BuiltinOutgoingHookModels {
+				names = append(names, m.Name)
+			}
+			return values("outgoing hook", names...)
+		}
+		return items
+	}
+
+	// Keys
+	switch last {
+	case "config":
+		m := lspHookModel(lines, lspParent(lines, parent))
+		if m == nil {
+			return items
+		}
+		for k, v := range m.DefaultConfig {
+			if !v.Configurable || existing[k] {
+				continue
+			}
+			items = append(items, lspCompletionItem{
+				Label:         k,
+				Kind:          lspCompletionField,
+				Detail:        m.Name + " hook",
+				Documentation: lspHookConfigDoc(m, k, v),
+				InsertText:    fmt.Sprintf("%s: %s", k, v.Value),
+			})
+		}
+	case "parameters":
+		pip := lspFind(lines, lspParent(lines, parent), "pipeline")
+		if pip < 0 {
+			return items
+		}
+		params, _ := p.pipelineParameters(dir, lines[pip].value)
+		for _, param := range params {
+			if existing[param.Name] {
+				continue
+			}
+			items = append(items, lspCompletionItem{
+				Label:         param.Name,
+				Kind:          lspCompletionField,
+				Detail:        param.Type,
+				Documentation: lspParameterDoc(lines[pip].value, param),
+				InsertText:    fmt.Sprintf("%s: %s", param.Name, param.Value),
+			})
+		}
+	default:
+		for k, desc := range lspFields(lspEntryType(path)) {
+			if existing[k] {
+				continue
+			}
+			items = append(items, lspCompletionItem{Label: k, Kind: lspCompletionField, Documentation: desc, InsertText: k + ": "})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Label < items[j].Label })
+	return items
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// lspYAMLLine is a line of a yaml document. The indentation is the column of the key, or of the value for the
+// list items without key. Blank and comment lines have a negative indentation.
+type lspYAMLLine struct {
+	indent int
+	item   bool
+	key    string
+	value  string
+	length int
+}
+
+func lspParseYAMLLine(s string) lspYAMLLine {
+	trimmed := strings.TrimLeft(s, " ")
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return lspYAMLLine{indent: -1}
+	}
+	l := lspYAMLLine{indent: len(s) - len(trimmed)}
+	if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+		l.item = true
+		rest := strings.TrimLeft(strings.TrimPrefix(trimmed, "-"), " ")
+		l.indent += len(trimmed) - len(rest)
+		trimmed = rest
+	}
+
+	if i := strings.Index(trimmed, ":"); i > 0 && (i == len(trimmed)-1 || trimmed[i+1] == ' ') &&
+		!strings.HasPrefix(trimmed, "'") && !strings.HasPrefix(trimmed, "\"") {
+		l.key = trimmed[:i]
+		trimmed = strings.TrimLeft(trimmed[i+1:], " ")
+	}
+	l.value = lspYAMLValue(trimmed)
+	return l
+}
+
+// lspYAMLValue removes the comment and the quotes of a scalar value.
+func lspYAMLValue(s string) string {
+	if strings.HasPrefix(s, "'") || strings.HasPrefix(s, "\"") {
+		if i := strings.LastIndex(s, s[:1]); i > 0 {
+			return s[1:i]
+		}
+		return s[1:]
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func lspParseYAML(text string) []lspYAMLLine {
+	raw := strings.Split(text, "\n")
+	lines := make([]lspYAMLLine, len(raw))
+	for i := range raw {
+		line := strings.TrimRight(raw[i], "\r")
+		lines[i] = lspParseYAMLLine(line)
+		lines[i].length = len(line)
+	}
+	return lines
+}
+
+// lspChildren returns the lines of the direct children of a line, or of the root of the document for -1.
+// The children of a list item are the item line and the keys of the item.
+func lspChildren(lines []lspYAMLLine, parent int) []int {
+	var res []int
+	indent := -1
+	start := 0
+	if parent >= 0 {
+		start = parent + 1
+		p := lines[parent]
+		if p.item {
+			if p.key != "" {
+				res = append(res, parent)
+			}
+			indent = p.indent
+		}
+	}
+	for i := start; i < len(lines); i++ {
+		l := lines[i]
+		if l.indent < 0 {
+			continue
+		}
+		if parent >= 0 {
+			p := lines[parent]
+			if l.indent < p.indent || (l.indent == p.indent && (!p.item || l.item)) {
+				break
+			}
+		}
+		if indent < 0 {
+			indent = l.indent
+		}
+		if l.indent < indent {
+			break
+		}
+		if l.indent == indent {
+			// The keys of a list item are not children of the key of the list
+			if (parent < 0 || !lines[parent].item) && !l.item && i > start && lspItemOf(lines, i) >= start {
+				continue
+			}
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// lspItemOf returns the list item of which a line is a key, or -1.
+func lspItemOf(lines []lspYAMLLine, i int) int {
+	for j := i - 1; j >= 0; j-- {
+		l := lines[j]
+		if l.indent < 0 || l.indent > lines[i].indent {
+			continue
+		}
+		if l.indent < lines[i].indent {
+			return -1
+		}
+		if l.item {
+			return j
+		}
+	}
+	return -1
+}
+
+// lspFind returns the line of a key in the children of a line, or -1.
+func lspFind(lines []lspYAMLLine, parent int, key string) int {
+	for _, i := range lspChildren(lines, parent) {
+		if lines[i].key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// lspFindPath returns the line of a key given by its path from the root of the document, or -1.
+func lspFindPath(lines []lspYAMLLine, path ...string) int {
+	i := -1
+	for _, key := range path {
+		if i = lspFind(lines, i, key); i < 0 {
+			return -1
+		}
+	}
+	return i
+}
+
+// lspItems returns the lines of the list items of a key.
+func lspItems(lines []lspYAMLLine, parent int) []int {
+	if parent < 0 {
+		return nil
+	}
+	var res []int
+	for _, i := range lspChildren(lines, parent) {
+		if lines[i].item {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// lspParent returns the parent line of a line, the list item for the keys of an item, or -1 for the root.
+func lspParent(lines []lspYAMLLine, i int) int {
+	cur := lines[i]
+	for j := i - 1; j >= 0; j-- {
+		l := lines[j]
+		if l.indent < 0 {
+			continue
+		}
+		if l.indent < cur.indent {
+			return j
+		}
+		if l.indent == cur.indent && l.item && !cur.item {
+			return j
+		}
+	}
+	return -1
+}
+
+// lspPath returns the keys of the ancestors of a line from the root, the list items are given as "-". The key of
+// a list item line is a key of the item.
+func lspPath(lines []lspYAMLLine, i int) []string {
+	var path []string
+	if lines[i].item && lines[i].key != "" {
+		path = append(path, "-")
+	}
+	for p := lspParent(lines, i); p >= 0; p = lspParent(lines, p) {
+		if lines[p].item {
+			path = append([]string{"-"}, path...)
+		} else {
+			path = append([]string{lines[p].key}, path...)
+		}
+	}
+	return path
+}
+
+var lspYAMLErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// lspErrorLine returns the line of a yaml parsing error, from 0.
+func lspErrorLine(err error) int {
+	m := lspYAMLErrorLine.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[1])
+	if n > 0 {
+		n--
+	}
+	return n
+}
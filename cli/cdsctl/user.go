@@ -20,6 +20,8 @@ func usr() *cobra.Command {
 		cli.NewListCommand(userListCmd, userListRun, nil),
 		cli.NewGetCommand(userShowCmd, userShowRun, nil),
 		cli.NewCommand(userFavoriteCmd, userFavoriteRun, nil),
+		cli.NewCommand(userChatOpsLinkCmd, userChatOpsLinkRun, nil),
+		cli.NewCommand(userChatOpsUnlinkCmd, userChatOpsUnlinkRun, nil),
 	})
 }
 
@@ -108,3 +110,36 @@ func userFavoriteRun(v cli.Values) error {
 
 	return nil
 }
+
+var userChatOpsLinkCmd = cli.Command{
+	Name:  "link-chatops",
+	Short: "Link your CDS user to a chatops identity",
+	Long:  "Use the token given by the chatops bot to allow it to execute commands on your behalf.",
+	Args: []cli.Arg{
+		{
+			Name: "token",
+		},
+	},
+}
+
+func userChatOpsLinkRun(v cli.Values) error {
+	if err := client.ChatOpsLink(v.GetString("token")); err != nil {
+		return err
+	}
+	fmt.Println("Your chatops identity is now linked to your CDS user")
+	return nil
+}
+
+var userChatOpsUnlinkCmd = cli.Command{
+	Name:  "unlink-chatops",
+	Short: "Remove the link between your CDS user and a chatops identity",
+	Args: []cli.Arg{
+		{
+			Name: "provider",
+		},
+	},
+}
+
+func userChatOpsUnlinkRun(v cli.Values) error {
+	return client.ChatOpsUnlink(v.GetString("provider"))
+}
@@ -24,6 +24,7 @@ func workerModel() *cobra.Command {
 		cli.NewDeleteCommand(workerModelDeleteCmd, workerModelDeleteRun, nil),
 		cli.NewCommand(workerModelImportCmd, workerModelImportRun, nil),
 		cli.NewCommand(workerModelExportCmd, workerModelExportRun, nil, withAllCommandModifiers()...),
+		workerModelTrustPolicy(),
 	})
 }
 
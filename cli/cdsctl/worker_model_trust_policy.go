@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workerModelTrustPolicyCmd = cli.Command{
+	Name:  "trust-policy",
+	Short: "Manage the trust policy of worker model images",
+	Long: `The trust policy restricts docker worker models to images from allowed registries and/or signed with cosign.
+It is checked when a worker model is saved and by hatcheries before spawning a worker.`,
+}
+
+func workerModelTrustPolicy() *cobra.Command {
+	return cli.NewCommand(workerModelTrustPolicyCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(workerModelTrustPolicyShowCmd, workerModelTrustPolicyShowRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workerModelTrustPolicySetCmd, workerModelTrustPolicySetRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workerModelTrustPolicyShowCmd = cli.Command{
+	Name:  "show",
+	Short: "Show the trust policy of worker model images",
+}
+
+func workerModelTrustPolicyShowRun(v cli.Values) (interface{}, error) {
+	p, err := client.WorkerModelTrustPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+var workerModelTrustPolicySetCmd = cli.Command{
+	Name:    "set",
+	Short:   "Set the trust policy of worker model images (admin only)",
+	Example: "cdsctl worker model trust-policy set --enabled --allowed-registry registry.example.com --require-signature --public-key cosign.pub --mode any",
+	Flags: []cli.Flag{
+		{
+			Name:  "enabled",
+			Usage: "Enable the trust policy",
+			Type:  cli.FlagBool,
+		},
+		{
+			Name:    "mode",
+			Usage:   "all: images should pass all the checks, any: images should pass at least one check",
+			Default: sdk.WorkerModelTrustModeAll,
+		},
+		{
+			Name:  "allowed-registry",
+			Usage: "Allowed registry or repository prefix, ie. registry.example.com or docker.io/library",
+			Type:  cli.FlagSlice,
+		},
+		{
+			Name:  "require-signature",
+			Usage: "Require images signed with cosign",
+			Type:  cli.FlagBool,
+		},
+		{
+			Name:  "public-key",
+			Usage: "Path of a cosign public key used to verify signatures",
+			Type:  cli.FlagSlice,
+		},
+	},
+}
+
+func workerModelTrustPolicySetRun(v cli.Values) error {
+	p := sdk.WorkerModelTrustPolicy{
+		Enabled:           v.GetBool("enabled"),
+		Mode:              v.GetString("mode"),
+		AllowedRegistries: v.GetStringSlice("allowed-registry"),
+		RequireSignature:  v.GetBool("require-signature"),
+	}
+	for _, path := range v.GetStringSlice("public-key") {
+		btes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot read public key %s: %v", path, err)
+		}
+		p.PublicKeys = append(p.PublicKeys, string(btes))
+	}
+
+	if _, err := client.WorkerModelTrustPolicyUpdate(p); err != nil {
+		return err
+	}
+	fmt.Println("Worker model trust policy updated")
+	return nil
+}
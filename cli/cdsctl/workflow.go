@@ -21,17 +21,35 @@ func workflow() *cobra.Command {
 		cli.NewCommand(templateApplyCmd("applyTemplate"), templateApplyRun, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(workflowListCmd, workflowListRun, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(workflowHistoryCmd, workflowHistoryRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowSearchCmd, workflowSearchRun, nil, withAllCommandModifiers()...),
 		cli.NewGetCommand(workflowShowCmd, workflowShowRun, nil, withAllCommandModifiers()...),
 		cli.NewGetCommand(workflowStatusCmd, workflowStatusRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowRunManualCmd, workflowRunManualRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowStopCmd, workflowStopRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowRehydrateCmd, workflowRehydrateRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(workflowTriggerURLCmd, workflowTriggerURLRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTriggerURLListCmd, workflowTriggerURLListRun, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(workflowTriggerURLRevokeCmd, workflowTriggerURLRevokeRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTriggerExplainCmd, workflowTriggerExplainRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowCoverageCmd, workflowCoverageRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowInsightsCmd, workflowInsightsRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowReportCmd, workflowReportRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowLinksCmd, workflowLinksRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(workflowGateCmd, workflowGateRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowChangelogCmd, workflowChangelogRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowManifestCmd, workflowManifestRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowExportCmd, workflowExportRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowImportCmd, workflowImportRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowPullCmd, workflowPullRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowPushCmd, workflowPushRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowConvertCmd, workflowConvertRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowFavoriteCmd, workflowFavoriteRun, nil, withAllCommandModifiers()...),
 		cli.NewGetCommand(workflowTransformAsCodeCmd, workflowTransformAsCodeRun, nil, withAllCommandModifiers()...),
 		workflowArtifact(),
+		workflowSBOM(),
+		workflowNotification(),
+		workflowTimer(),
+		workflowSchedule(),
 		workflowLog(),
 		workflowAdvanced(),
 	})
@@ -21,6 +21,8 @@ func workflowArtifact() *cobra.Command {
 	return cli.NewCommand(workflowArtifactCmd, nil, []*cobra.Command{
 		cli.NewListCommand(workflowArtifactListCmd, workflowArtifactListRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowArtifactDownloadCmd, workflowArtifactDownloadRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowArtifactDiffCmd, workflowArtifactDiffRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(workflowArtifactVerifyCmd, workflowArtifactVerifyRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -48,6 +50,123 @@ func workflowArtifactListRun(v cli.Values) (cli.ListResult, error) {
 	return cli.AsListResult(workflowArtifacts), nil
 }
 
+var workflowArtifactDiffCmd = cli.Command{
+	Name:  "diff",
+	Short: "Compare artifacts of one Workflow Run with the last successful run on the same branch",
+	Long: `Compare the checksums of the artifacts of a workflow run with the artifacts of the last successful run on the same branch.
+An artifact that changed while the commit is the same is flagged as unexpected, the build is probably not reproducible.`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "files",
+			Usage: "Compare the files inside the changed archives",
+			Type:  cli.FlagBool,
+		},
+	},
+}
+
+func workflowArtifactDiffRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	diff, err := client.WorkflowRunArtifactsDiff(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, v.GetBool("files"))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(diff.Artifacts), nil
+}
+
+var workflowArtifactVerifyCmd = cli.Command{
+	Name:  "verify",
+	Short: "Verify the provenance attestation of an artifact of one Workflow Run",
+	Long: `Verify the signature of the SLSA provenance attestation of an artifact, produced when the artifact was uploaded with a signing key.
+With --file, the attestation is also checked against a local copy of the artifact.`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+		{Name: "artefact-name"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "file",
+			Usage: "Path of a local copy of the artifact to verify",
+		},
+	},
+}
+
+type workflowArtifactVerification struct {
+	Name     string `cli:"name"`
+	Verified bool   `cli:"verified"`
+	Reason   string `cli:"reason"`
+	Key      string `cli:"key"`
+	Builder  string `cli:"builder"`
+	Source   string `cli:"source"`
+	Commit   string `cli:"commit"`
+}
+
+func workflowArtifactVerifyRun(v cli.Values) (interface{}, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+
+	artifacts, err := client.WorkflowRunArtifacts(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+	var art *sdk.WorkflowNodeRunArtifact
+	for i := range artifacts {
+		if artifacts[i].Name == v.GetString("artefact-name") {
+			art = &artifacts[i]
+			break
+		}
+	}
+	if art == nil {
+		return nil, fmt.Errorf("artifact %s not found", v.GetString("artefact-name"))
+	}
+
+	verification, err := client.WorkflowArtifactAttestationVerify(v.GetString(_ProjectKey), v.GetString(_WorkflowName), art.ID)
+	if err != nil {
+		return nil, err
+	}
+	res := workflowArtifactVerification{
+		Name:     art.Name,
+		Verified: verification.Verified,
+		Reason:   verification.Reason,
+		Key:      verification.KeyName,
+	}
+	if s := verification.Statement; s != nil {
+		res.Builder = s.Predicate.Builder.ID
+		for _, m := range s.Predicate.Materials {
+			res.Source = m.URI
+			res.Commit = m.Digest["sha1"]
+		}
+	}
+
+	if file := v.GetString("file"); file != "" && res.Verified {
+		sha512sum, err := sdk.FileSHA512sum(file)
+		if err != nil {
+			return nil, err
+		}
+		if !verification.Statement.MatchDigest(sha512sum) {
+			res.Verified = false
+			res.Reason = fmt.Sprintf("the attestation digest does not match the file %s", file)
+		}
+	}
+
+	return res, nil
+}
+
 var workflowArtifactDownloadCmd = cli.Command{
 	Name:  "download",
 	Short: "Download artifacts of one Workflow Run",
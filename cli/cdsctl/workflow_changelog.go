@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowChangelogCmd = cli.Command{
+	Name:  "changelog",
+	Short: "Show the changelog of the nodes of a workflow run",
+	Long: `Show the commits between the commit of the last successful run of each node and the commit of the run.
+The changelog of a node can be computed again with the flag --compute:
+
+	$ cdsctl workflow changelog MYPROJ my-workflow 42 deploy-prod --compute
+
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "node-name"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "compute",
+			Type:  cli.FlagBool,
+			Usage: "Compute again the changelog of the given node",
+		},
+	},
+}
+
+func workflowChangelogRun(v cli.Values) error {
+	number, err := v.GetInt64("run-number")
+	if err != nil {
+		return err
+	}
+	nodeName := v.GetString("node-name")
+
+	var changelogs []sdk.WorkflowRunChangelog
+	if v.GetBool("compute") {
+		if nodeName == "" {
+			return fmt.Errorf("node-name is mandatory to compute a changelog")
+		}
+		wr, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+		if err != nil {
+			return err
+		}
+		var nodeRun *sdk.WorkflowNodeRun
+		for _, nrs := range wr.WorkflowNodeRuns {
+			if len(nrs) > 0 && nrs[0].WorkflowNodeName == nodeName {
+				nodeRun = &nrs[0]
+				break
+			}
+		}
+		if nodeRun == nil {
+			return fmt.Errorf("node %s not found in run %d", nodeName, number)
+		}
+		c, err := client.WorkflowNodeRunChangelogCompute(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, nodeRun.ID)
+		if err != nil {
+			return err
+		}
+		changelogs = append(changelogs, *c)
+	} else {
+		changelogs, err = client.WorkflowRunChangelogs(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range changelogs {
+		if nodeName != "" && c.NodeName != nodeName {
+			continue
+		}
+		from := c.FromHash
+		if from == "" {
+			from = "the beginning"
+		} else {
+			from = fmt.Sprintf("%s (run %d)", from, c.FromNumber)
+		}
+		fmt.Printf("%s: %s from %s to %s\n", c.NodeName, c.Repository, from, c.ToHash)
+		fmt.Print(c.Markdown())
+		fmt.Println()
+	}
+	return nil
+}
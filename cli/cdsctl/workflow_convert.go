@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowConvertCmd = cli.Command{
+	Name:  "convert",
+	Short: "Convert the CI files of another tool to CDS files",
+	Long: `Convert the CI files of another tool to CDS workflow and pipeline files. The constructs that can't be converted are listed, the converted files must be reviewed before being pushed.
+
+The supported formats are:
+	- github: the GitHub Actions workflow files, the files of the .github/workflows directory are converted by default
+	- gitlab-ci: the GitLab CI files, the .gitlab-ci.yml file is converted by default`,
+	Example: `cdsctl workflow convert github
+cdsctl workflow convert github .github/workflows/build.yml --output-dir .cds
+cdsctl workflow convert gitlab-ci`,
+	Args: []cli.Arg{
+		{Name: "format"},
+	},
+	VariadicArgs: cli.Arg{
+		Name:       "files",
+		AllowEmpty: true,
+	},
+	Flags: []cli.Flag{
+		{
+			Name:      "output-dir",
+			ShortHand: "d",
+			Usage:     "Output directory",
+			Default:   ".cds",
+		},
+		{
+			Type:    cli.FlagBool,
+			Name:    "force",
+			Usage:   "Force, may override files",
+			Default: "false",
+		},
+	},
+}
+
+func workflowConvertRun(v cli.Values) error {
+	format := v.GetString("format")
+	paths := v.GetStringSlice("files")
+	if len(paths) == 0 {
+		switch format {
+		case sdk.AsCodeConvertGitHub:
+			for _, pattern := range []string{".github/workflows/*.yml", ".github/workflows/*.yaml"} {
+				matches, err := filepath.Glob(pattern)
+				if err != nil {
+					return err
+				}
+				paths = append(paths, matches...)
+			}
+		case sdk.AsCodeConvertGitLabCI:
+			paths = []string{".gitlab-ci.yml"}
+		default:
+			return fmt.Errorf("invalid format %s", format)
+		}
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no file to convert")
+	}
+
+	files := make(map[string]string, len(paths))
+	for _, p := range paths {
+		btes, err := ioutil.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("unable to read file %s: %v", p, err)
+		}
+		files[p] = string(btes)
+	}
+
+	res, err := client.WorkflowAsCodeConvert(format, files)
+	if err != nil {
+		return err
+	}
+
+	dir := strings.TrimSpace(v.GetString("output-dir"))
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, os.FileMode(0744)); err != nil {
+		return fmt.Errorf("unable to create directory %s: %v", dir, err)
+	}
+
+	names := make([]string, 0, len(res.Files))
+	for name := range res.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fname := filepath.Join(dir, name)
+		if _, err := os.Stat(fname); err == nil && !v.GetBool("force") && !v.GetBool("yes") {
+			if v.GetBool("no-interactive") || !cli.AskConfirm(fmt.Sprintf("This will override %s. Do you want to continue?", fname)) {
+				continue
+			}
+		}
+		if err := ioutil.WriteFile(fname, []byte(res.Files[name]), os.FileMode(0644)); err != nil {
+			return fmt.Errorf("unable to write file %s: %v", fname, err)
+		}
+		fmt.Println(fname)
+	}
+
+	for _, w := range res.Warnings {
+		fmt.Println(cli.Yellow("warning: %s", w))
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/ovh/cds/cli"
+)
+
+var workflowCoverageCmd = cli.Command{
+	Name:  "coverage",
+	Short: "Show the coverage trend of the applications of a workflow",
+	Example: `cdsctl workflow coverage MYPROJ myworkflow
+cdsctl workflow coverage MYPROJ myworkflow --branch master --limit 10`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "branch",
+			Usage: "Show only the runs on this branch",
+		},
+		{
+			Name:    "limit",
+			Usage:   "Number of coverage reports",
+			Default: "50",
+		},
+	},
+}
+
+func workflowCoverageRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	ts, err := client.WorkflowCoverageTrend(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("branch"), limit)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ts), nil
+}
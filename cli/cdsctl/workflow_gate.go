@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowGateCmd = cli.Command{
+	Name:  "gate",
+	Short: "Get the callback url of an opened gate of a workflow run",
+	Long: `Get the signed url to which the decision of a gate node is posted. The url can be used without authentication
+until the timeout of the gate:
+
+	$ curl -X POST -d '{"decision": "approve", "author": "john", "comment": "CHG0012345"}' <url>
+
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+		{Name: "node-name"},
+	},
+}
+
+func workflowGateRun(v cli.Values) (interface{}, error) {
+	number, err := v.GetInt64("run-number")
+	if err != nil {
+		return nil, err
+	}
+	wr, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeRun *sdk.WorkflowNodeRun
+	for _, nrs := range wr.WorkflowNodeRuns {
+		for i := range nrs {
+			if nrs[i].WorkflowNodeName == v.GetString("node-name") && nrs[i].Gate != nil {
+				nodeRun = &nrs[i]
+				break
+			}
+		}
+	}
+	if nodeRun == nil {
+		return nil, fmt.Errorf("gate %s not found in run %d", v.GetString("node-name"), number)
+	}
+
+	return client.WorkflowNodeRunGateURL(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, nodeRun.ID)
+}
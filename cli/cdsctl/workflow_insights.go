@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowInsightsCmd = cli.Command{
+	Name:  "insights",
+	Short: "Show the time spent by the jobs of a workflow in the queue, spawning a worker and executing",
+	Long: `Show the percentiles of the time spent by the jobs of a workflow, by node or by worker model:
+ - queue: from the job being queued to a hatchery starting a worker for it
+ - spawn: from a hatchery starting a worker to the job being taken by a worker
+ - execution: from the job being taken to its end
+The durations are in seconds.`,
+	Example: `cdsctl workflow insights MYPROJ myworkflow
+cdsctl workflow insights MYPROJ myworkflow --days 30 --by model`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "days",
+			Usage:   "Number of days of runs",
+			Default: "7",
+		},
+		{
+			Name:    "by",
+			Usage:   "Group the jobs by node or by model",
+			Default: "node",
+		},
+	},
+}
+
+func workflowInsightsRun(v cli.Values) (cli.ListResult, error) {
+	days, err := v.GetInt64("days")
+	if err != nil {
+		return nil, err
+	}
+	is, err := client.WorkflowInsights(v.GetString(_ProjectKey), v.GetString(_WorkflowName), int(days))
+	if err != nil {
+		return nil, err
+	}
+	switch by := v.GetString("by"); by {
+	case "node":
+		return cli.AsListResult(is.Nodes), nil
+	case "model":
+		return cli.AsListResult(is.Models), nil
+	default:
+		return nil, fmt.Errorf("invalid value %q for flag by, expected node or model", by)
+	}
+}
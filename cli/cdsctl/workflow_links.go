@@ -0,0 +1,77 @@
+package main
+
+import (
+	"regexp"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowLinksCmd = cli.Command{
+	Name:  "links",
+	Short: "List the runs linked to a workflow run",
+	Long: `List the parent run that triggered a workflow run and the runs of the workflows it triggered with sub-workflow nodes or outgoing workflow hooks.
+
+	# list the runs linked to the latest run
+	$ cdsctl workflow links KEY WF
+
+	# list the runs linked to run number 1
+	$ cdsctl workflow links KEY WF 1
+
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "run-number",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`[0-9]?`, s)
+				return match
+			},
+			Weight: 1,
+		},
+	},
+}
+
+type workflowRunLinkDisplay struct {
+	Link         string `cli:"link"`
+	ProjectKey   string `cli:"project"`
+	WorkflowName string `cli:"workflow"`
+	Number       int64  `cli:"num"`
+	NodeName     string `cli:"node"`
+	Status       string `cli:"status"`
+}
+
+func workflowLinksRun(v cli.Values) (cli.ListResult, error) {
+	runNumber, err := workflowLogSearchNumber(v)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := client.WorkflowRunLinks(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]workflowRunLinkDisplay, 0, len(links.Children)+1)
+	if links.Parent != nil {
+		res = append(res, workflowRunLinkDisplay{
+			Link:         "parent",
+			ProjectKey:   links.Parent.ProjectKey,
+			WorkflowName: links.Parent.WorkflowName,
+			Number:       links.Parent.Number,
+		})
+	}
+	for _, c := range links.Children {
+		res = append(res, workflowRunLinkDisplay{
+			Link:         "child",
+			ProjectKey:   c.ProjectKey,
+			WorkflowName: c.WorkflowName,
+			Number:       c.Number,
+			NodeName:     c.NodeName,
+			Status:       c.Status,
+		})
+	}
+	return cli.AsListResult(res), nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"regexp"
 	"strings"
 
@@ -32,6 +33,9 @@ var workflowLogCmd = cli.Command{
 	$ cdsctl workflow logs download KEY WF 1 --pattern="MyJob"
 	# this will download file WF-1.0-pipeline.myPipeline-stage.MyStage-job.MyJob-status.Success-step.0.log
 
+	# download all logs files on latest run as a single archive
+	$ cdsctl workflow logs archive KEY WF
+
 `,
 }
 
@@ -39,6 +43,7 @@ func workflowLog() *cobra.Command {
 	return cli.NewCommand(workflowLogCmd, nil, []*cobra.Command{
 		cli.NewCommand(workflowLogListCmd, workflowLogListRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowLogDownloadCmd, workflowLogDownloadRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowLogArchiveCmd, workflowLogArchiveRun, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -260,3 +265,74 @@ func workflowLogDownloadRun(v cli.Values) error {
 	}
 	return nil
 }
+
+var workflowLogArchiveCmd = cli.Command{
+	Name:  "archive",
+	Short: "Download all logs from a workflow run as a single archive.",
+	Long: `Download all logs from a workflow run as a single archive generated by CDS. Logs are organized by node, stage and job,
+and the archive contains a manifest.json file that describes each log file.
+
+	# download the logs archive of latest run
+	$ cdsctl workflow logs archive KEY WF
+
+	# download the logs archive of run number 1 as a tar.gz file
+	$ cdsctl workflow logs archive KEY WF 1 --format tar.gz --output logs.tar.gz
+
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "run-number",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`[0-9]?`, s)
+				return match
+			},
+			Weight: 1,
+		},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "format",
+			Usage:   "Format of the archive: zip or tar.gz",
+			Default: sdk.WorkflowRunLogsArchiveZip,
+			IsValid: sdk.IsValidWorkflowRunLogsArchiveFormat,
+		},
+		{
+			Name:  "output",
+			Usage: "Path of the archive file, default is KEY-WF-number-logs.format",
+		},
+	},
+}
+
+func workflowLogArchiveRun(v cli.Values) error {
+	runNumber, err := workflowLogSearchNumber(v)
+	if err != nil {
+		return err
+	}
+
+	format := v.GetString("format")
+	output := v.GetString("output")
+	if output == "" {
+		output = fmt.Sprintf("%s-%s-%d-logs.%s", v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, format)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Downloading logs archive from workflow %s run %d\n", v.GetString(_WorkflowName), runNumber)
+	if err := client.WorkflowRunLogsDownload(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, format, f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(output)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("file %s created\n", output)
+	return nil
+}
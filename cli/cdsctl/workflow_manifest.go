@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowManifestCmd = cli.Command{
+	Name:  "manifest",
+	Short: "Get the manifest of a pipeline of a workflow run",
+	Long: `Get the manifest of a pipeline of a workflow run: the parameters of its jobs with the secrets masked, the workers, the digests
+of the worker models and of the steps, and the versions of the plugins. The manifest is used to reproduce the run later.
+
+	# get the manifest of the pipeline build of latest run
+	$ cdsctl workflow manifest KEY WF build
+
+	# save the manifest of the pipeline build of run number 1
+	$ cdsctl workflow manifest KEY WF build 1 --output manifest.json
+
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "node-name"},
+	},
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "run-number",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`[0-9]?`, s)
+				return match
+			},
+			Weight: 1,
+		},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "output",
+			Usage: "Path of the manifest file, the manifest is printed if not set",
+		},
+	},
+}
+
+func workflowManifestRun(v cli.Values) error {
+	runNumber, err := workflowLogSearchNumber(v)
+	if err != nil {
+		return err
+	}
+
+	wr, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
+	if err != nil {
+		return err
+	}
+
+	// the last execution of the node is used if the pipeline was restarted
+	nodeName := v.GetString("node-name")
+	var nodeRunID, subNumber int64 = 0, -1
+	for _, nrs := range wr.WorkflowNodeRuns {
+		for _, nr := range nrs {
+			if nr.WorkflowNodeName == nodeName && nr.SubNumber > subNumber {
+				nodeRunID, subNumber = nr.ID, nr.SubNumber
+			}
+		}
+	}
+	if nodeRunID == 0 {
+		return fmt.Errorf("pipeline %s not found in run %d", nodeName, runNumber)
+	}
+
+	m, err := client.WorkflowNodeRunManifest(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, nodeRunID)
+	if err != nil {
+		return err
+	}
+
+	btes, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	output := v.GetString("output")
+	if output == "" {
+		fmt.Println(string(btes))
+		return nil
+	}
+	if err := ioutil.WriteFile(output, btes, os.FileMode(0644)); err != nil {
+		return err
+	}
+	fmt.Printf("file %s created\n", output)
+	return nil
+}
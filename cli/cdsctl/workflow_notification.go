@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowNotificationCmd = cli.Command{
+	Name:  "notification",
+	Short: "Manage Workflow Notification",
+}
+
+func workflowNotification() *cobra.Command {
+	return cli.NewCommand(workflowNotificationCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowNotificationDeliveriesCmd, workflowNotificationDeliveriesRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowNotificationDeliveriesCmd = cli.Command{
+	Name:  "deliveries",
+	Short: "List webhook notification deliveries of one Workflow Run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+}
+
+func workflowNotificationDeliveriesRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	ds, err := client.WorkflowRunNotificationDeliveries(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ds), nil
+}
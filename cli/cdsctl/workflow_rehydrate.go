@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowRehydrateCmd = cli.Command{
+	Name:    "rehydrate",
+	Short:   "Restore the logs and the artifacts of an archived workflow run",
+	Example: `cdsctl workflow rehydrate MYPROJECT myworkflow 5`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+	},
+}
+
+func workflowRehydrateRun(v cli.Values) error {
+	runNumber, err := v.GetInt64("run-number")
+	if err != nil {
+		return err
+	}
+	if _, err := client.WorkflowRunRehydrate(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber); err != nil {
+		return err
+	}
+	fmt.Printf("Workflow run %d of %s/%s rehydrated\n", runNumber, v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	return nil
+}
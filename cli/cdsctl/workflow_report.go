@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowReportCmd = cli.Command{
+	Name:  "report",
+	Short: "Download the report of a workflow run",
+	Long: `Download a self-contained report of a workflow run with the statuses, the durations, the tests results and the links
+of its pipelines and jobs. The report can be attached to a release ticket or kept for an audit.
+
+	# download the HTML report of latest run
+	$ cdsctl workflow report KEY WF
+
+	# download the report of run number 1 as a JUnit file
+	$ cdsctl workflow report KEY WF 1 --format junit --output report.xml
+
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "run-number",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`[0-9]?`, s)
+				return match
+			},
+			Weight: 1,
+		},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "format",
+			Usage:   "Format of the report: html, md or junit",
+			Default: sdk.WorkflowRunReportHTML,
+			IsValid: sdk.IsValidWorkflowRunReportFormat,
+		},
+		{
+			Name:  "output",
+			Usage: "Path of the report file, default is KEY-WF-number-report.ext",
+		},
+	},
+}
+
+func workflowReportRun(v cli.Values) error {
+	runNumber, err := workflowLogSearchNumber(v)
+	if err != nil {
+		return err
+	}
+
+	format := v.GetString("format")
+	output := v.GetString("output")
+	if output == "" {
+		ext := format
+		if format == sdk.WorkflowRunReportJUnit {
+			ext = "xml"
+		}
+		output = fmt.Sprintf("%s-%s-%d-report.%s", v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, ext)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+
+	if err := client.WorkflowRunReportDownload(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, format, f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(output)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("file %s created\n", output)
+	return nil
+}
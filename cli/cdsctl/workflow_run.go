@@ -6,13 +6,16 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/fsamin/go-repo"
+	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/pkg/browser"
 )
 
@@ -70,7 +73,7 @@ var workflowRunManualCmd = cli.Command{
 		{
 			Name:      "interactive",
 			ShortHand: "i",
-			Usage:     "Follow the workflow run in an interactive terminal user interface",
+			Usage:     "Ask the payload and the pipeline parameters, then follow the workflow run in an interactive terminal user interface",
 			Type:      cli.FlagBool,
 		},
 		{
@@ -85,6 +88,10 @@ var workflowRunManualCmd = cli.Command{
 			Usage:     "Synchronise your pipelines with your last editions. Must be used with flag run-number",
 			Type:      cli.FlagBool,
 		},
+		{
+			Name:  "freeze-override",
+			Usage: "Reason of the run of a deployment workflow during the active freeze windows of the project",
+		},
 	},
 }
 
@@ -94,6 +101,7 @@ func workflowRunManualRun(v cli.Values) error {
 	}
 
 	manual := sdk.WorkflowNodeRunManual{}
+	var wf *sdk.Workflow
 	if strings.TrimSpace(v.GetString("data")) != "" {
 		data := map[string]interface{}{}
 		if err := json.Unmarshal([]byte(v.GetString("data")), &data); err != nil {
@@ -115,7 +123,7 @@ func workflowRunManualRun(v cli.Values) error {
 			}
 		}
 
-		wf, err := client.WorkflowGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+		wf, err = client.WorkflowGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), cdsclient.WithDeepPipelines())
 		if err != nil {
 			return sdk.WrapError(err, "cannot load workflow")
 		}
@@ -131,7 +139,20 @@ func workflowRunManualRun(v cli.Values) error {
 			}
 		}
 
-		if gitBranch != "" {
+		if v.GetBool("interactive") && wf.WorkflowData != nil && wf.WorkflowData.Node.Context != nil {
+			defaultPayload, err := wf.WorkflowData.Node.Context.DefaultPayloadToMap()
+			if err != nil {
+				return sdk.WrapError(err, "cannot read the default payload")
+			}
+			if gitBranch != "" {
+				defaultPayload["git.branch"] = gitBranch
+			}
+			payload, err := workflowRunAskPayload(defaultPayload)
+			if err != nil {
+				return err
+			}
+			manual.Payload = payload
+		} else if gitBranch != "" {
 			m := map[string]string{}
 			m["git.branch"] = gitBranch
 			manual.Payload = m
@@ -149,6 +170,16 @@ func workflowRunManualRun(v cli.Values) error {
 		}
 	}
 
+	// Ask the parameters of the pipeline of the root node which are not given with flag parameter
+	if v.GetBool("interactive") && wf != nil && wf.WorkflowData != nil && wf.WorkflowData.Node.Context != nil {
+		if pip, ok := wf.Pipelines[wf.WorkflowData.Node.Context.PipelineID]; ok {
+			params := sdk.ParametersMerge(pip.Parameter, wf.WorkflowData.Node.Context.DefaultPipelineParameters)
+			if err := workflowRunAskPipelineParameters(params, &manual.PipelineParameters); err != nil {
+				return err
+			}
+		}
+	}
+
 	var runNumber, fromNodeID int64
 
 	if v.GetString("run-number") != "" {
@@ -184,7 +215,17 @@ func workflowRunManualRun(v cli.Values) error {
 		}
 	}
 
-	w, err := client.WorkflowRunFromManual(v.GetString(_ProjectKey), v.GetString(_WorkflowName), manual, runNumber, fromNodeID)
+	opts := sdk.WorkflowRunPostHandlerOption{
+		Manual:         &manual,
+		FreezeOverride: v.GetString("freeze-override"),
+	}
+	if runNumber > 0 {
+		opts.Number = &runNumber
+	}
+	if fromNodeID > 0 {
+		opts.FromNodeIDs = []int64{fromNodeID}
+	}
+	w, err := client.WorkflowRunWithOptions(v.GetString(_ProjectKey), v.GetString(_WorkflowName), opts)
 	if err != nil {
 		return err
 	}
@@ -213,3 +254,77 @@ func workflowRunManualRun(v cli.Values) error {
 
 	return workflowRunInteractive(v, w, configUser.URLUI)
 }
+
+// workflowRunAskPayload asks the value of each key of the default payload, the type of a value is given by its default.
+func workflowRunAskPayload(defaultPayload map[string]string) (map[string]string, error) {
+	keys := make([]string, 0, len(defaultPayload))
+	for k := range defaultPayload {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	payload := make(map[string]string, len(defaultPayload))
+	for _, k := range keys {
+		p := sdk.Parameter{Name: k, Type: sdk.StringParameter, Value: defaultPayload[k]}
+		if p.Value == "true" || p.Value == "false" {
+			p.Type = sdk.BooleanParameter
+		} else if _, err := strconv.ParseFloat(p.Value, 64); err == nil {
+			p.Type = sdk.NumberParameter
+		}
+		value, err := workflowRunAskValue(p)
+		if err != nil {
+			return nil, err
+		}
+		payload[k] = value
+	}
+	return payload, nil
+}
+
+// workflowRunAskPipelineParameters asks the value of the pipeline parameters which are not already given.
+func workflowRunAskPipelineParameters(params []sdk.Parameter, given *[]sdk.Parameter) error {
+	for _, p := range params {
+		if sdk.ParameterFind(*given, p.Name) != nil {
+			continue
+		}
+		value, err := workflowRunAskValue(p)
+		if err != nil {
+			return err
+		}
+		sdk.AddParameter(given, p.Name, p.Type, value)
+	}
+	return nil
+}
+
+func workflowRunAskValue(p sdk.Parameter) (string, error) {
+	label := p.Name
+	if p.Description != "" {
+		label = fmt.Sprintf("%s (%s)", p.Name, p.Description)
+	}
+
+	var value string
+	switch p.Type {
+	case sdk.ListParameter:
+		if err := survey.AskOne(&survey.Select{
+			Message: label,
+			Options: strings.Split(p.Value, ";"),
+		}, &value, nil); err != nil {
+			return "", err
+		}
+	case sdk.BooleanParameter:
+		var result bool
+		if err := survey.AskOne(&survey.Confirm{
+			Message: label,
+			Default: p.Value == "true",
+		}, &result, nil); err != nil {
+			return "", err
+		}
+		value = fmt.Sprintf("%t", result)
+	default:
+		if err := survey.AskOne(&survey.Input{Message: label, Default: p.Value}, &value, func(ans interface{}) error {
+			return p.CheckValue(fmt.Sprintf("%v", ans))
+		}); err != nil {
+			return "", err
+		}
+	}
+	return value, nil
+}
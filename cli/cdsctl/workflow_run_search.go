@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowSearchCmd = cli.Command{
+	Name:  "search",
+	Short: "Search the runs of the workflows of a project",
+	Long: `Search the runs of the workflows of a project, all the filters are optional.
+Dates are RFC3339 dates or days (2020-01-31). Tags and payload values are given as key=value.
+The runs are sorted by start date, last modification date or number, the most recent first.
+When there are more runs, the cursor of the next page is printed on the error output.`,
+	Example: `cdsctl workflow search MYPROJ --workflow build --workflow deploy --status Fail --branch master
+cdsctl workflow search MYPROJ --tag environment=prod --from 2020-01-01 --to 2020-02-01 --all
+cdsctl workflow search MYPROJ --payload git.author=john --sort number --limit 100`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "workflow", Usage: "Name of a workflow", Type: cli.FlagArray},
+		{Name: "status", Usage: "Status of the runs", Type: cli.FlagArray},
+		{Name: "branch", Usage: "Git branch of the runs"},
+		{Name: "tag", Usage: "Tag of the runs: key=value", Type: cli.FlagArray},
+		{Name: "triggerer", Usage: "Username of the user who triggered the runs"},
+		{Name: "from", Usage: "Runs started from this date"},
+		{Name: "to", Usage: "Runs started before this date"},
+		{Name: "node", Usage: "Name of a node run by the runs"},
+		{Name: "payload", Usage: "Payload value of a node run: key=value", Type: cli.FlagArray},
+		{Name: "sort", Usage: "Sort the runs by start, last_modified or number", Default: sdk.WorkflowRunSearchSortStart},
+		{Name: "asc", Usage: "Sort the runs in ascending order", Type: cli.FlagBool},
+		{Name: "limit", Usage: "Number of runs by page", Default: fmt.Sprintf("%d", sdk.DefaultWorkflowRunSearchLimit)},
+		{Name: "cursor", Usage: "Cursor of the page"},
+		{Name: "all", Usage: "Load all the pages", Type: cli.FlagBool},
+	},
+}
+
+func workflowSearchRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	search := sdk.WorkflowRunSearch{
+		Workflows: v.GetStringArray("workflow"),
+		Status:    v.GetStringArray("status"),
+		Branch:    v.GetString("branch"),
+		Triggerer: v.GetString("triggerer"),
+		NodeName:  v.GetString("node"),
+		Sort:      v.GetString("sort"),
+		Ascending: v.GetBool("asc"),
+		Limit:     int(limit),
+		Cursor:    v.GetString("cursor"),
+	}
+	if search.Tags, err = parseKeyValues(v.GetStringArray("tag")); err != nil {
+		return nil, err
+	}
+	if search.Payload, err = parseKeyValues(v.GetStringArray("payload")); err != nil {
+		return nil, err
+	}
+	if search.From, err = parseSearchDate(v.GetString("from")); err != nil {
+		return nil, err
+	}
+	if search.To, err = parseSearchDate(v.GetString("to")); err != nil {
+		return nil, err
+	}
+
+	var runs []sdk.WorkflowRun
+	for {
+		res, err := client.WorkflowRunsSearch(v.GetString(_ProjectKey), search)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, res.Runs...)
+		if res.NextCursor == "" {
+			break
+		}
+		if !v.GetBool("all") {
+			fmt.Fprintf(os.Stderr, "next cursor: %s\n", res.NextCursor)
+			break
+		}
+		search.Cursor = res.NextCursor
+	}
+	return cli.AsListResult(runs), nil
+}
+
+func parseKeyValues(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	res := make(map[string]string, len(values))
+	for _, kv := range values {
+		i := strings.Index(kv, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid value %q, expected key=value", kv)
+		}
+		res[kv[:i]] = kv[i+1:]
+	}
+	return res, nil
+}
+
+func parseSearchDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q, expected a RFC3339 date or a day (2020-01-31)", s)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowSBOMCmd = cli.Command{
+	Name:  "sbom",
+	Short: "Manage Workflow Run SBOMs",
+}
+
+func workflowSBOM() *cobra.Command {
+	return cli.NewCommand(workflowSBOMCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowSBOMListCmd, workflowSBOMListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowSBOMDownloadCmd, workflowSBOMDownloadRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowSBOMListCmd = cli.Command{
+	Name:  "list",
+	Short: "List SBOMs of one Workflow Run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+}
+
+func workflowSBOMListRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	sboms, err := client.WorkflowRunSBOMs(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(sboms), nil
+}
+
+var workflowSBOMDownloadCmd = cli.Command{
+	Name:  "download",
+	Short: "Download a SBOM of one Workflow Run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+		{Name: "id"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "file",
+			Usage: "Write the SBOM to this file instead of the standard output",
+		},
+	},
+}
+
+func workflowSBOMDownloadRun(v cli.Values) error {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("number parameter have to be an integer")
+	}
+	id, err := strconv.ParseInt(v.GetString("id"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("id parameter have to be an integer")
+	}
+
+	var w io.Writer = os.Stdout
+	if file := v.GetString("file"); file != "" {
+		f, err := os.OpenFile(file, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("cannot create file %s: %v", file, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return client.WorkflowRunSBOMDownload(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, id, w)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowScheduleCmd = cli.Command{
+	Name:  "schedule",
+	Short: "Manage CDS workflow scheduled runs",
+	Long: `A scheduled run starts a single run of a workflow at a given date, as the user that scheduled it.
+
+Scheduled runs are kept and executed by the hooks µservice.`,
+}
+
+func workflowSchedule() *cobra.Command {
+	return cli.NewCommand(workflowScheduleCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowScheduleListCmd, workflowScheduleListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowScheduleAddCmd, workflowScheduleAddRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowScheduleCancelCmd, workflowScheduleCancelRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowScheduleListCmd = cli.Command{
+	Name:  "list",
+	Short: "List scheduled runs of a workflow with their status",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+}
+
+func workflowScheduleListRun(v cli.Values) (cli.ListResult, error) {
+	rs, err := client.WorkflowScheduledRunList(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(rs), nil
+}
+
+var workflowScheduleAddCmd = cli.Command{
+	Name:  "add",
+	Short: "Schedule a run of a workflow",
+	Long: `Schedule a run of a workflow at a given date, given as RFC3339 (2020-01-04T02:00:00+01:00) or as
+"2020-01-04 02:00" in the given timezone.`,
+	Example: `cdsctl workflow schedule add MYPROJECT myworkflow "2020-01-04 02:00" --timezone Europe/Paris -d '{"env": "prod"}'`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "date"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "timezone",
+			Usage:   "Timezone of the date if it is not given as RFC3339",
+			Default: "UTC",
+		},
+		{
+			Name:      "data",
+			ShortHand: "d",
+			Usage:     "Payload of the run as a JSON object of strings",
+			IsValid: func(s string) bool {
+				if strings.TrimSpace(s) == "" {
+					return true
+				}
+				data := map[string]string{}
+				return json.Unmarshal([]byte(s), &data) == nil
+			},
+		},
+	},
+}
+
+func workflowScheduleAddRun(v cli.Values) error {
+	date, err := time.Parse(time.RFC3339, v.GetString("date"))
+	if err != nil {
+		loc, errL := time.LoadLocation(v.GetString("timezone"))
+		if errL != nil {
+			return fmt.Errorf("invalid given timezone: %v", errL)
+		}
+		date, err = time.ParseInLocation("2006-01-02 15:04", v.GetString("date"), loc)
+		if err != nil {
+			return fmt.Errorf("invalid given date: %v", err)
+		}
+	}
+
+	r := sdk.WorkflowScheduledRun{Date: date}
+	if data := strings.TrimSpace(v.GetString("data")); data != "" {
+		if err := json.Unmarshal([]byte(data), &r.Payload); err != nil {
+			return fmt.Errorf("invalid given payload: %v", err)
+		}
+	}
+
+	if err := client.WorkflowScheduledRunAdd(v.GetString(_ProjectKey), v.GetString(_WorkflowName), &r); err != nil {
+		return err
+	}
+	fmt.Printf("Run %s scheduled at %s\n", r.UUID, r.Date)
+	return nil
+}
+
+var workflowScheduleCancelCmd = cli.Command{
+	Name:  "cancel",
+	Short: "Cancel a scheduled run of a workflow",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "uuid"},
+	},
+}
+
+func workflowScheduleCancelRun(v cli.Values) error {
+	return client.WorkflowScheduledRunCancel(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("uuid"))
+}
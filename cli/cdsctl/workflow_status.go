@@ -74,22 +74,36 @@ func workflowStatusRunWithTrack(v cli.Values) (interface{}, error) {
 		runNumber = runs[0].Number
 	}
 
-	run, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
-	if err != nil {
-		return nil, err
-	}
+	// Only the events of the run are sent by the API
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	chanEvents := make(chan sdk.WebsocketEvent)
+	filter := sdk.WebsocketFilter{
+		Type:              sdk.WebsocketFilterTypeWorkflowRun,
+		ProjectKey:        v.GetString(_ProjectKey),
+		WorkflowName:      v.GetString(_WorkflowName),
+		WorkflowRunNumber: runNumber,
+	}
+	sdk.GoRoutine(ctx, "WorkflowStatusTrack", func(ctx context.Context) {
+		client.WebsocketEventsListen(ctx, []sdk.WebsocketFilter{filter}, chanEvents)
+	})
 
 	for {
-		run, err = client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
+		run, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
 		if err != nil {
 			return nil, err
 		}
 
 		workflowRunFormatDisplay(run, latestCommit, currentDisplay)
-		time.Sleep(500 * time.Millisecond)
 		if sdk.StatusIsTerminated(run.Status) {
 			break
 		}
+
+		// Refresh the run on its events, and periodically if the websocket is not available
+		select {
+		case <-chanEvents:
+		case <-time.After(10 * time.Second):
+		}
 	}
 	fmt.Println()
 	return nil, nil
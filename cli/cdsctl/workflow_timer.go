@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowTimerCmd = cli.Command{
+	Name:  "timer",
+	Short: "Manage CDS workflow timers",
+	Long: `Timers trigger periodic runs of a workflow from CDS API, they work even if the hooks µservice is not deployed.
+
+The catch-up policy defines what happens for executions missed while CDS API was down:
+  - skip: missed executions are not run
+  - once: one run is started for all missed executions
+  - all: one run is started for each missed execution (max 10)`,
+}
+
+func workflowTimer() *cobra.Command {
+	return cli.NewCommand(workflowTimerCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowTimerListCmd, workflowTimerListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowTimerAddCmd, workflowTimerAddRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowTimerDeleteCmd, workflowTimerDeleteRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowTimerListCmd = cli.Command{
+	Name:  "list",
+	Short: "List timers of a workflow with their last execution status",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+}
+
+func workflowTimerListRun(v cli.Values) (cli.ListResult, error) {
+	ts, err := client.WorkflowTimerList(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(ts), nil
+}
+
+var workflowTimerAddCmd = cli.Command{
+	Name:    "add",
+	Short:   "Add a timer on a workflow",
+	Example: `cdsctl workflow timer add MYPROJECT myworkflow nightly "0 2 * * *" --timezone Europe/Paris --catch-up once`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "cron"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "timezone",
+			Usage:   "Timezone of the cron expression",
+			Default: "UTC",
+		},
+		{
+			Name:    "catch-up",
+			Usage:   "Catch-up policy for missed executions: skip, once or all",
+			Default: sdk.WorkflowTimerCatchUpSkip,
+		},
+		{
+			Name:      "data",
+			ShortHand: "d",
+			Usage:     "Payload of the runs as a JSON object of strings",
+			IsValid: func(s string) bool {
+				if strings.TrimSpace(s) == "" {
+					return true
+				}
+				data := map[string]string{}
+				return json.Unmarshal([]byte(s), &data) == nil
+			},
+		},
+	},
+}
+
+func workflowTimerAddRun(v cli.Values) error {
+	t := sdk.WorkflowTimer{
+		Name:     v.GetString("name"),
+		Cron:     v.GetString("cron"),
+		Timezone: v.GetString("timezone"),
+		CatchUp:  v.GetString("catch-up"),
+	}
+	if data := strings.TrimSpace(v.GetString("data")); data != "" {
+		if err := json.Unmarshal([]byte(data), &t.Payload); err != nil {
+			return fmt.Errorf("invalid given payload: %v", err)
+		}
+	}
+
+	if err := client.WorkflowTimerAdd(v.GetString(_ProjectKey), v.GetString(_WorkflowName), &t); err != nil {
+		return err
+	}
+	fmt.Printf("Timer %s added, next execution at %s\n", t.Name, t.NextExecution)
+	return nil
+}
+
+var workflowTimerDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a timer of a workflow",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func workflowTimerDeleteRun(v cli.Values) error {
+	return client.WorkflowTimerDelete(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("name"))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"time"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowTriggerExplainCmd = cli.Command{
+	Name:  "explain-trigger",
+	Short: "Explain why a repository event did or did not start a CDS workflow",
+	Long: `For each repository hook of the workflow, display received events, what the hooks service did with them and which conditions failed.
+
+Events can be filtered on a commit hash (or its prefix) and on a branch.`,
+	Example: `cdsctl workflow explain-trigger MYPROJECT myworkflow --commit 3a1f2e9 --branch master`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "commit",
+			Usage: "Commit hash or prefix of the commit hash",
+		},
+		{
+			Name:  "branch",
+			Usage: "Branch name",
+		},
+	},
+}
+
+type workflowTriggerExplainLine struct {
+	Hook      string `cli:"hook"`
+	Date      string `cli:"date"`
+	Event     string `cli:"event"`
+	Branch    string `cli:"branch"`
+	Commit    string `cli:"commit"`
+	Decision  string `cli:"decision"`
+	Reason    string `cli:"reason"`
+	Condition string `cli:"failed_conditions"`
+}
+
+func workflowTriggerExplainRun(v cli.Values) (cli.ListResult, error) {
+	res, err := client.WorkflowTriggerExplain(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("commit"), v.GetString("branch"))
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []workflowTriggerExplainLine
+	for _, h := range res.Hooks {
+		if h.Error != "" {
+			lines = append(lines, workflowTriggerExplainLine{Hook: h.ModelName, Reason: h.Error})
+			continue
+		}
+		for _, e := range h.Executions {
+			line := workflowTriggerExplainLine{
+				Hook:     h.ModelName,
+				Date:     time.Unix(0, e.Timestamp).Format(time.RFC3339),
+				Event:    e.EventType,
+				Decision: e.Decision,
+				Reason:   e.Reason,
+			}
+			if len(e.Events) == 0 {
+				lines = append(lines, line)
+				continue
+			}
+			for _, ev := range e.Events {
+				l := line
+				l.Branch = ev.Branch
+				l.Commit = ev.Commit
+				l.Decision = ev.Decision
+				l.Reason = ev.Reason
+				for _, c := range ev.Checks {
+					if c.OK {
+						continue
+					}
+					if l.Condition != "" {
+						l.Condition += ", "
+					}
+					if c.Variable != "" {
+						l.Condition += c.Variable + " " + c.Operator + " " + c.Expected + " (got " + c.Value + ")"
+					} else {
+						l.Condition += c.Kind + " " + c.Operator
+					}
+				}
+				lines = append(lines, l)
+			}
+		}
+	}
+
+	return cli.AsListResult(lines), nil
+}
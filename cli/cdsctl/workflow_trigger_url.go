@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowTriggerURLCmd = cli.Command{
+	Name:  "trigger-url",
+	Short: "Generate a signed url that triggers a CDS workflow",
+	Long: `Generate a signed and expiring url that can be used by external tools to trigger a workflow without CDS token.
+
+The run will be started on your behalf with a payload that should match given fields.`,
+	Example: `cdsctl workflow trigger-url MYPROJECT myworkflow --duration 3600 --field version:string:required --field dry_run:boolean`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "duration",
+			Usage: "Validity duration of the url in seconds",
+		},
+		{
+			Name:  "field",
+			Type:  cli.FlagArray,
+			Usage: "Payload field allowed in the trigger request, format is name:type[:required] with type in string, number or boolean",
+		},
+	},
+}
+
+func workflowTriggerURLRun(v cli.Values) (interface{}, error) {
+	duration, err := v.GetInt64("duration")
+	if err != nil {
+		return nil, err
+	}
+
+	req := sdk.WorkflowTriggerURLRequest{Duration: duration}
+	for _, f := range v.GetStringArray("field") {
+		parts := strings.Split(f, ":")
+		if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "required") {
+			return nil, fmt.Errorf("invalid given field %q, format should be name:type[:required]", f)
+		}
+		req.PayloadSchema = append(req.PayloadSchema, sdk.WorkflowTriggerPayloadField{
+			Name:     parts[0],
+			Type:     parts[1],
+			Required: len(parts) == 3,
+		})
+	}
+
+	return client.WorkflowTriggerURLGenerate(v.GetString(_ProjectKey), v.GetString(_WorkflowName), req)
+}
+
+var workflowTriggerURLListCmd = cli.Command{
+	Name:  "trigger-url-list",
+	Short: "List the signed urls that trigger a CDS workflow and are not expired",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+}
+
+func workflowTriggerURLListRun(v cli.Values) (cli.ListResult, error) {
+	us, err := client.WorkflowTriggerURLList(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(us), nil
+}
+
+var workflowTriggerURLRevokeCmd = cli.Command{
+	Name:  "trigger-url-revoke",
+	Short: "Revoke a signed url that triggers a CDS workflow",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func workflowTriggerURLRevokeRun(v cli.Values) error {
+	return client.WorkflowTriggerURLRevoke(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("id"))
+}
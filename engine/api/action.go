@@ -75,7 +75,16 @@ func (api *API) getActionsForProjectHandler() service.Handler {
 			return err
 		}
 
-		return service.WriteJSON(w, as, http.StatusOK)
+		// the actions of the project are the ones pushed as code with its workflows
+		pas, err := action.LoadAllTypeProjectByProjectID(ctx, api.mustDB(), proj.ID,
+			action.LoadOptions.WithRequirements,
+			action.LoadOptions.WithParameters,
+		)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, append(pas, as...), http.StatusOK)
 	}
 }
 
@@ -648,76 +657,12 @@ func (api *API) importActionHandler() service.Handler {
 		}
 		defer tx.Rollback() // nolint
 
-		// set group id on given action, if no group given use shared.infra fo backward compatibility
-		// current user should be admin if the group
-		var grp *sdk.Group
-		if ea.Group == sdk.SharedInfraGroupName || ea.Group == "" {
-			grp = group.SharedInfraGroup
-		} else {
-			grp, err = group.LoadByName(ctx, tx, ea.Group, group.LoadOptions.WithMembers)
-			if err != nil {
-				return err
-			}
-		}
-
-		if !isGroupAdmin(ctx, grp) && !isAdmin(ctx) {
-			return sdk.WithStack(sdk.ErrInvalidGroupAdmin)
-		}
-
-		data, err := ea.GetAction()
-		if err != nil {
-			return err
-		}
-
-		data.GroupID = &grp.ID
-
-		// set action id for children based on action name and group name
-		// if no group name given for child, first search an action for shared.infra for backward compatibility
-		// else search a builtin or plugin action
-		for i := range data.Actions {
-			a, err := action.RetrieveForGroupAndName(ctx, tx, data.Actions[i].Group, data.Actions[i].Name)
-			if err != nil {
-				return err
-			}
-			data.Actions[i].ID = a.ID
-		}
-
-		// check data validity
-		if err := data.IsValidDefault(); err != nil {
-			return err
-		}
-
-		// check if action exists in database
-		old, err := action.LoadTypeDefaultByNameAndGroupID(ctx, tx, data.Name, grp.ID, action.LoadOptions.Default)
+		data, old, err := importAction(ctx, tx, ea)
 		if err != nil {
 			return err
 		}
 		exists := old != nil
 
-		// update or insert depending action if action exists
-		if exists {
-			data.ID = old.ID
-
-			// check that given children exists and can be used, and no loop exists
-			if err := action.CheckChildrenForGroupIDsWithLoop(ctx, tx, &data, []int64{group.SharedInfraGroup.ID, grp.ID}); err != nil {
-				return err
-			}
-
-			if err = action.Update(tx, &data); err != nil {
-				return sdk.WrapError(err, "cannot update action")
-			}
-		} else {
-			// check that given children exists and can be used
-			if err := action.CheckChildrenForGroupIDs(ctx, tx, &data, []int64{group.SharedInfraGroup.ID, grp.ID}); err != nil {
-				return err
-			}
-
-			// inserts action and components
-			if err := action.Insert(tx, &data); err != nil {
-				return err
-			}
-		}
-
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -874,3 +819,79 @@ func getActionUsage(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 
 	return usage, nil
 }
+
+// importAction inserts or updates the default action described by given exported action, it returns the imported
+// action and its previous version if it already existed.
+func importAction(ctx context.Context, tx gorp.SqlExecutor, ea exportentities.Action) (sdk.Action, *sdk.Action, error) {
+	// set group id on given action, if no group given use shared.infra fo backward compatibility
+	// current user should be admin if the group
+	var grp *sdk.Group
+	if ea.Group == sdk.SharedInfraGroupName || ea.Group == "" {
+		grp = group.SharedInfraGroup
+	} else {
+		var err error
+		grp, err = group.LoadByName(ctx, tx, ea.Group, group.LoadOptions.WithMembers)
+		if err != nil {
+			return sdk.Action{}, nil, err
+		}
+	}
+
+	if !isGroupAdmin(ctx, grp) && !isAdmin(ctx) {
+		return sdk.Action{}, nil, sdk.WithStack(sdk.ErrInvalidGroupAdmin)
+	}
+
+	data, err := ea.GetAction()
+	if err != nil {
+		return sdk.Action{}, nil, err
+	}
+
+	data.GroupID = &grp.ID
+
+	// set action id for children based on action name and group name
+	// if no group name given for child, first search an action for shared.infra for backward compatibility
+	// else search a builtin or plugin action
+	for i := range data.Actions {
+		a, err := action.RetrieveForGroupAndName(ctx, tx, data.Actions[i].Group, data.Actions[i].Name)
+		if err != nil {
+			return sdk.Action{}, nil, err
+		}
+		data.Actions[i].ID = a.ID
+	}
+
+	// check data validity
+	if err := data.IsValidDefault(); err != nil {
+		return sdk.Action{}, nil, err
+	}
+
+	// check if action exists in database
+	old, err := action.LoadTypeDefaultByNameAndGroupID(ctx, tx, data.Name, grp.ID, action.LoadOptions.Default)
+	if err != nil {
+		return sdk.Action{}, nil, err
+	}
+
+	// update or insert depending action if action exists
+	if old != nil {
+		data.ID = old.ID
+
+		// check that given children exists and can be used, and no loop exists
+		if err := action.CheckChildrenForGroupIDsWithLoop(ctx, tx, &data, []int64{group.SharedInfraGroup.ID, grp.ID}); err != nil {
+			return sdk.Action{}, nil, err
+		}
+
+		if err = action.Update(tx, &data); err != nil {
+			return sdk.Action{}, nil, sdk.WrapError(err, "cannot update action")
+		}
+	} else {
+		// check that given children exists and can be used
+		if err := action.CheckChildrenForGroupIDs(ctx, tx, &data, []int64{group.SharedInfraGroup.ID, grp.ID}); err != nil {
+			return sdk.Action{}, nil, err
+		}
+
+		// inserts action and components
+		if err := action.Insert(tx, &data); err != nil {
+			return sdk.Action{}, nil, err
+		}
+	}
+
+	return data, old, nil
+}
@@ -11,7 +11,7 @@ import (
 
 // Insert given action and its components in database.
 func Insert(db gorp.SqlExecutor, a *sdk.Action) error {
-	if a.Type == sdk.DefaultAction || a.Type == sdk.BuiltinAction {
+	if a.Type == sdk.DefaultAction || a.Type == sdk.BuiltinAction || a.Type == sdk.ProjectAction {
 		a.Enabled = true
 	}
 
@@ -51,7 +51,7 @@ func Insert(db gorp.SqlExecutor, a *sdk.Action) error {
 
 // Update given action and its components in database.
 func Update(db gorp.SqlExecutor, a *sdk.Action) error {
-	if a.Type == sdk.DefaultAction || a.Type == sdk.BuiltinAction {
+	if a.Type == sdk.DefaultAction || a.Type == sdk.BuiltinAction || a.Type == sdk.ProjectAction {
 		a.Enabled = true
 	}
 
@@ -13,6 +13,7 @@ import (
 	"github.com/ovh/cds/engine/api/test"
 	"github.com/ovh/cds/engine/api/test/assets"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
 )
 
 func TestCRUD(t *testing.T) {
@@ -233,3 +234,63 @@ func Test_CheckChildrenForGroupIDsWithLoop(t *testing.T) {
 	assert.NoError(t, action.CheckChildrenForGroupIDs(context.TODO(), db, &one, []int64{grp1.ID}))
 	assert.Error(t, action.CheckChildrenForGroupIDsWithLoop(context.TODO(), db, &one, []int64{grp1.ID}))
 }
+
+func Test_ImportForProject(t *testing.T) {
+	db, cache, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key)
+	defer func() {
+		assert.NoError(t, assets.DeleteTestProject(t, db, cache, key))
+	}()
+
+	// Import an action of the project with a builtin step
+	setup, err := action.ImportForProject(context.TODO(), db, proj.ID, nil, exportentities.Action{
+		Name:       "setup",
+		Parameters: map[string]exportentities.ParameterValue{"target": {DefaultValue: "all"}},
+		Steps:      []exportentities.Step{{Script: "make {{.target}}"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.ProjectAction, setup.Type)
+
+	// Import an action that uses the first one
+	build, err := action.ImportForProject(context.TODO(), db, proj.ID, nil, exportentities.Action{
+		Name: "build",
+		Steps: []exportentities.Step{{StepCustom: exportentities.StepCustom{
+			"setup": exportentities.StepParameters{"target": "deps"},
+		}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, build.Actions, 1)
+	assert.Equal(t, setup.ID, build.Actions[0].ID)
+
+	// The actions of the project are only retrieved for the project
+	result, err := action.RetrieveForProjectGroupAndName(context.TODO(), db, proj.ID, nil, "Setup")
+	require.NoError(t, err)
+	assert.Equal(t, setup.ID, result.ID)
+	_, err = action.RetrieveForGroupAndName(context.TODO(), db, nil, "setup")
+	assert.Error(t, err)
+	assert.Error(t, action.CheckChildrenForGroupIDs(context.TODO(), db, build, nil))
+	assert.NoError(t, action.CheckChildrenForProjectAndGroupIDs(context.TODO(), db, build, proj.ID, nil))
+
+	// A new import updates the action
+	setup, err = action.ImportForProject(context.TODO(), db, proj.ID, nil, exportentities.Action{
+		Name:  "setup",
+		Steps: []exportentities.Step{{Script: "make"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, result.ID, setup.ID)
+	as, err := action.LoadAllTypeProjectByProjectID(context.TODO(), db, proj.ID, action.LoadOptions.WithParameters)
+	require.NoError(t, err)
+	require.Len(t, as, 2)
+	assert.Empty(t, as[1].Parameters)
+
+	// A project action has no group
+	_, err = action.ImportForProject(context.TODO(), db, proj.ID, nil, exportentities.Action{
+		Name:  "deploy",
+		Group: sdk.SharedInfraGroupName,
+		Steps: []exportentities.Step{{Script: "make deploy"}},
+	})
+	assert.Error(t, err)
+}
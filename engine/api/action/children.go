@@ -53,13 +53,19 @@ func insertActionChild(db gorp.SqlExecutor, child sdk.Action, actionID int64, ex
 
 // CheckChildrenForGroupIDs returns an error if given children not found.
 func CheckChildrenForGroupIDs(ctx context.Context, db gorp.SqlExecutor, a *sdk.Action, groupIDs []int64) error {
+	return CheckChildrenForProjectAndGroupIDs(ctx, db, a, 0, groupIDs)
+}
+
+// CheckChildrenForProjectAndGroupIDs returns an error if given children not found, the children can also be
+// actions of the given project.
+func CheckChildrenForProjectAndGroupIDs(ctx context.Context, db gorp.SqlExecutor, a *sdk.Action, projectID int64, groupIDs []int64) error {
 	if len(a.Actions) == 0 {
 		return nil
 	}
 
 	childrenIDs := a.ToUniqueChildrenIDs()
 
-	children, err := LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(ctx, db, childrenIDs, groupIDs, LoadOptions.WithChildren)
+	children, err := LoadAllByIDsForProjectAndGroupIDs(ctx, db, childrenIDs, projectID, groupIDs, LoadOptions.WithChildren)
 	if err != nil {
 		return err
 	}
@@ -68,10 +74,16 @@ func CheckChildrenForGroupIDs(ctx context.Context, db gorp.SqlExecutor, a *sdk.A
 
 // CheckChildrenForGroupIDsWithLoop return an error if given children not found or tree loop detected.
 func CheckChildrenForGroupIDsWithLoop(ctx context.Context, db gorp.SqlExecutor, a *sdk.Action, groupIDs []int64) error {
-	return checkChildrenForGroupIDsWithLoopStep(ctx, db, a, a, groupIDs)
+	return checkChildrenForGroupIDsWithLoopStep(ctx, db, a, a, 0, groupIDs)
+}
+
+// CheckChildrenForProjectAndGroupIDsWithLoop return an error if given children not found or tree loop detected,
+// the children can also be actions of the given project.
+func CheckChildrenForProjectAndGroupIDsWithLoop(ctx context.Context, db gorp.SqlExecutor, a *sdk.Action, projectID int64, groupIDs []int64) error {
+	return checkChildrenForGroupIDsWithLoopStep(ctx, db, a, a, projectID, groupIDs)
 }
 
-func checkChildrenForGroupIDsWithLoopStep(ctx context.Context, db gorp.SqlExecutor, root, current *sdk.Action, groupIDs []int64) error {
+func checkChildrenForGroupIDsWithLoopStep(ctx context.Context, db gorp.SqlExecutor, root, current *sdk.Action, projectID int64, groupIDs []int64) error {
 	if len(current.Actions) == 0 {
 		return nil
 	}
@@ -85,7 +97,7 @@ func checkChildrenForGroupIDsWithLoopStep(ctx context.Context, db gorp.SqlExecut
 		}
 	}
 
-	children, err := LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(ctx, db, childrenIDs, groupIDs, LoadOptions.WithChildren)
+	children, err := LoadAllByIDsForProjectAndGroupIDs(ctx, db, childrenIDs, projectID, groupIDs, LoadOptions.WithChildren)
 	if err != nil {
 		return err
 	}
@@ -94,7 +106,7 @@ func checkChildrenForGroupIDsWithLoopStep(ctx context.Context, db gorp.SqlExecut
 	}
 
 	for i := range children {
-		if err := checkChildrenForGroupIDsWithLoopStep(ctx, db, root, &children[i], groupIDs); err != nil {
+		if err := checkChildrenForGroupIDsWithLoopStep(ctx, db, root, &children[i], projectID, groupIDs); err != nil {
 			return err
 		}
 	}
@@ -91,7 +91,13 @@ func LoadAllTypeBuiltInOrPluginOrDefaultForGroupIDs(ctx context.Context, db gorp
 // LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs returns all actions for given ids. Action should be
 // of type builtin, plugin or default. Default action should be in given group ids list.
 func LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(ctx context.Context, db gorp.SqlExecutor, ids, groupIDs []int64, opts ...LoadOptionFunc) ([]sdk.Action, error) {
-	// children should be builtin, plugin or default with group matching
+	return LoadAllByIDsForProjectAndGroupIDs(ctx, db, ids, 0, groupIDs, opts...)
+}
+
+// LoadAllByIDsForProjectAndGroupIDs returns all actions for given ids. Action should be of type builtin, plugin,
+// default or project. Default action should be in given group ids list and project action in given project.
+func LoadAllByIDsForProjectAndGroupIDs(ctx context.Context, db gorp.SqlExecutor, ids []int64, projectID int64, groupIDs []int64, opts ...LoadOptionFunc) ([]sdk.Action, error) {
+	// children should be builtin, plugin, default with group matching or project with project matching
 	query := gorpmapping.NewQuery(`
     SELECT *
     FROM action
@@ -101,6 +107,7 @@ func LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(ctx context.Context,
         type = $2
         OR type = $3
         OR (type = $4 AND group_id = ANY(string_to_array($5, ',')::int[]))
+        OR (type = $6 AND project_id = $7)
       )
   `).Args(
 		gorpmapping.IDsToQueryString(ids),
@@ -108,10 +115,20 @@ func LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(ctx context.Context,
 		sdk.PluginAction,
 		sdk.DefaultAction,
 		gorpmapping.IDsToQueryString(groupIDs),
+		sdk.ProjectAction,
+		projectID,
 	)
 	return getAll(ctx, db, query, opts...)
 }
 
+// LoadAllTypeProjectByProjectID returns all the actions of a project.
+func LoadAllTypeProjectByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64, opts ...LoadOptionFunc) ([]sdk.Action, error) {
+	query := gorpmapping.NewQuery(
+		"SELECT * FROM action WHERE type = $1 AND project_id = $2 ORDER BY name",
+	).Args(sdk.ProjectAction, projectID)
+	return getAll(ctx, db, query, opts...)
+}
+
 // LoadTypeDefaultByNameAndGroupID returns an action from database with given name and group id.
 func LoadTypeDefaultByNameAndGroupID(ctx context.Context, db gorp.SqlExecutor, name string, groupID int64, opts ...LoadOptionFunc) (*sdk.Action, error) {
 	query := gorpmapping.NewQuery(
@@ -120,6 +137,14 @@ func LoadTypeDefaultByNameAndGroupID(ctx context.Context, db gorp.SqlExecutor, n
 	return get(ctx, db, query, opts...)
 }
 
+// LoadTypeProjectByNameAndProjectID returns an action from database with given name and project id.
+func LoadTypeProjectByNameAndProjectID(ctx context.Context, db gorp.SqlExecutor, name string, projectID int64, opts ...LoadOptionFunc) (*sdk.Action, error) {
+	query := gorpmapping.NewQuery(
+		"SELECT * FROM action WHERE type = $1 AND lower(name) = lower($2) AND project_id = $3",
+	).Args(sdk.ProjectAction, name, projectID)
+	return get(ctx, db, query, opts...)
+}
+
 // LoadByTypesAndName returns an action from database with given name and type in list.
 func LoadByTypesAndName(ctx context.Context, db gorp.SqlExecutor, types []string, name string, opts ...LoadOptionFunc) (*sdk.Action, error) {
 	query := gorpmapping.NewQuery(
@@ -0,0 +1,49 @@
+package action
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertVersion publishes a version of an action.
+func InsertVersion(db gorp.SqlExecutor, v *sdk.ActionVersion) error {
+	dbV := actionVersion(*v)
+	if err := gorpmapping.Insert(db, &dbV); err != nil {
+		return sdk.WrapError(err, "cannot insert version %s of action %d", v.Version, v.ActionID)
+	}
+	*v = sdk.ActionVersion(dbV)
+	return nil
+}
+
+// LoadVersions returns the versions of an action, the last published first.
+func LoadVersions(ctx context.Context, db gorp.SqlExecutor, actionID int64) ([]sdk.ActionVersion, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM action_version WHERE action_id = $1 ORDER BY published DESC, id DESC").Args(actionID)
+	var dbVs []actionVersion
+	if err := gorpmapping.GetAll(ctx, db, query, &dbVs); err != nil {
+		return nil, sdk.WrapError(err, "cannot load versions of action %d", actionID)
+	}
+	vs := make([]sdk.ActionVersion, len(dbVs))
+	for i := range dbVs {
+		vs[i] = sdk.ActionVersion(dbVs[i])
+	}
+	return vs, nil
+}
+
+// LoadVersion returns a version of an action.
+func LoadVersion(ctx context.Context, db gorp.SqlExecutor, actionID int64, version string) (*sdk.ActionVersion, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM action_version WHERE action_id = $1 AND version = $2").Args(actionID, version)
+	var dbV actionVersion
+	found, err := gorpmapping.Get(ctx, db, query, &dbV)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load version %s of action %d", version, actionID)
+	}
+	if !found {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "version %s of action %d not found", version, actionID)
+	}
+	v := sdk.ActionVersion(dbV)
+	return &v, nil
+}
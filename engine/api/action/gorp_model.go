@@ -70,6 +70,8 @@ type actionEdgeParameter struct {
 	Advanced     bool   `json:"advanced,omitempty" yaml:"advanced,omitempty" db:"advanced"`
 }
 
+type actionVersion sdk.ActionVersion
+
 func init() {
 	gorpmapping.Register(
 		gorpmapping.New(sdk.Action{}, "action", true, "id"),
@@ -78,5 +80,6 @@ func init() {
 		gorpmapping.New(sdk.Requirement{}, "action_requirement", true, "id"),
 		gorpmapping.New(actionEdge{}, "action_edge", true, "id"),
 		gorpmapping.New(actionEdgeParameter{}, "action_edge_parameter", true, "id"),
+		gorpmapping.New(actionVersion{}, "action_version", true, "id"),
 	)
 }
@@ -0,0 +1,82 @@
+package action
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// RetrieveForProjectGroupAndName try to find an action for given project, group and name. If no group given, the
+// actions of the project take precedence over the actions of shared.infra and the builtin or plugin actions.
+func RetrieveForProjectGroupAndName(ctx context.Context, db gorp.SqlExecutor, projectID int64, g *sdk.Group, name string) (*sdk.Action, error) {
+	if g == nil && projectID != 0 {
+		a, err := LoadTypeProjectByNameAndProjectID(ctx, db, name, projectID,
+			LoadOptions.WithRequirements,
+			LoadOptions.WithParameters,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			return a, nil
+		}
+	}
+
+	return RetrieveForGroupAndName(ctx, db, g, name)
+}
+
+// ImportForProject inserts or updates an action of a project from its as code representation. Its steps can use
+// the other actions of the project, the actions of the given groups and the builtin or plugin actions.
+func ImportForProject(ctx context.Context, db gorp.SqlExecutor, projectID int64, groupIDs []int64, ea exportentities.Action) (*sdk.Action, error) {
+	if ea.Group != "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid group %s for action %s, the actions of a project have no group", ea.Group, ea.Name)
+	}
+
+	data, err := ea.GetAction()
+	if err != nil {
+		return nil, err
+	}
+	data.Type = sdk.ProjectAction
+	data.Group = nil
+	data.ProjectID = &projectID
+
+	for i := range data.Actions {
+		a, err := RetrieveForProjectGroupAndName(ctx, db, projectID, data.Actions[i].Group, data.Actions[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		data.Actions[i].ID = a.ID
+	}
+
+	if err := data.IsValidProject(); err != nil {
+		return nil, err
+	}
+
+	old, err := LoadTypeProjectByNameAndProjectID(ctx, db, data.Name, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if old != nil {
+		data.ID = old.ID
+	}
+
+	// check that given children exists and can be used, and no loop exists
+	if err := CheckChildrenForProjectAndGroupIDsWithLoop(ctx, db, &data, projectID, groupIDs); err != nil {
+		return nil, err
+	}
+
+	if old != nil {
+		if err := Update(db, &data); err != nil {
+			return nil, sdk.WrapError(err, "cannot update action %s", data.Name)
+		}
+	} else {
+		if err := Insert(db, &data); err != nil {
+			return nil, sdk.WrapError(err, "cannot insert action %s", data.Name)
+		}
+	}
+
+	return &data, nil
+}
@@ -1,6 +1,8 @@
 package action
 
 import (
+	"encoding/json"
+
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/sdk"
@@ -137,3 +139,44 @@ func Used(db gorp.SqlExecutor, actionID int64) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+// GetWorkflowVersionUsages returns the list of workflows pinning a version of an action used by their pipelines.
+func GetWorkflowVersionUsages(db gorp.SqlExecutor, a sdk.Action, version string) ([]sdk.ActionVersionUsage, error) {
+	pinned, err := json.Marshal([]string{a.Name + "@" + version})
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	rows, err := db.Query(`
+		SELECT DISTINCT
+			project.id, project.projectKey, project.name,
+			workflow.id, workflow.name
+		FROM workflow
+		INNER JOIN project ON project.id = workflow.project_id
+		INNER JOIN w_node ON w_node.workflow_id = workflow.id
+		INNER JOIN w_node_context ON w_node_context.node_id = w_node.id
+		INNER JOIN pipeline_stage ON pipeline_stage.pipeline_id = w_node_context.pipeline_id
+		INNER JOIN pipeline_action ON pipeline_action.pipeline_stage_id = pipeline_stage.id
+		INNER JOIN action_edge ON action_edge.parent_id = pipeline_action.action_id
+		WHERE action_edge.child_id = $1 AND workflow.pinned_versions @> $2::jsonb AND workflow.to_delete = false
+		ORDER BY project.projectKey, workflow.name;
+	`, a.ID, string(pinned))
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load workflow usages for version %s of action with id %d", version, a.ID)
+	}
+	defer rows.Close()
+
+	us := []sdk.ActionVersionUsage{}
+	for rows.Next() {
+		var u sdk.ActionVersionUsage
+		if err := rows.Scan(
+			&u.ProjectID, &u.ProjectKey, &u.ProjectName,
+			&u.WorkflowID, &u.WorkflowName,
+		); err != nil {
+			return nil, sdk.WrapError(err, "cannot scan sql rows")
+		}
+		us = append(us, u)
+	}
+
+	return us, nil
+}
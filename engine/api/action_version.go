@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getActionVersionsHandler returns the published versions of an action.
+func (api *API) getActionVersionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		a, err := api.loadActionForVersion(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		vs, err := action.LoadVersions(ctx, api.mustDB(), a.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, vs, http.StatusOK)
+	}
+}
+
+// postActionVersionHandler publishes the current definition of an action as a version, the workflows can pin
+// this version to be reproducible.
+func (api *API) postActionVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		a, err := api.loadActionForVersion(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var v sdk.ActionVersion
+		if err := service.UnmarshalBody(r, &v); err != nil {
+			return err
+		}
+		if err := v.IsValid(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot begin transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		if _, err := action.LoadVersion(ctx, tx, a.ID, v.Version); err == nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "version %s of action %s already exists", v.Version, a.Name)
+		} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+
+		v.ID = 0
+		v.ActionID = a.ID
+		v.Action = *a
+		v.Publisher = getAPIConsumer(ctx).GetUsername()
+		v.Published = time.Now()
+		if err := action.InsertVersion(tx, &v); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "cannot commit transaction")
+		}
+
+		return service.WriteJSON(w, v, http.StatusCreated)
+	}
+}
+
+// getActionVersionUsageHandler returns the workflows that pin a version of an action.
+func (api *API) getActionVersionUsageHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		a, err := api.loadActionForVersion(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		v, err := action.LoadVersion(ctx, api.mustDB(), a.ID, mux.Vars(r)["version"])
+		if err != nil {
+			return err
+		}
+
+		us, err := action.GetWorkflowVersionUsages(api.mustDB(), *a, v.Version)
+		if err != nil {
+			return err
+		}
+
+		if !isMaintainer(ctx) {
+			// filter usage in workflows by user's projects
+			ps, err := project.LoadAllByGroupIDs(ctx, api.mustDB(), api.Cache, getAPIConsumer(ctx).GetGroupIDs())
+			if err != nil {
+				return err
+			}
+			mProjectIDs := make(map[int64]struct{}, len(ps))
+			for i := range ps {
+				mProjectIDs[ps[i].ID] = struct{}{}
+			}
+
+			filtered := make([]sdk.ActionVersionUsage, 0, len(us))
+			for i := range us {
+				if _, ok := mProjectIDs[us[i].ProjectID]; ok {
+					filtered = append(filtered, us[i])
+				}
+			}
+			us = filtered
+		}
+
+		return service.WriteJSON(w, us, http.StatusOK)
+	}
+}
+
+func (api *API) loadActionForVersion(ctx context.Context, r *http.Request) (*sdk.Action, error) {
+	vars := mux.Vars(r)
+
+	g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), vars["permActionName"], g.ID, action.LoadOptions.Default)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, sdk.WithStack(sdk.ErrNoAction)
+	}
+
+	return a, nil
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -101,27 +103,36 @@ func (api *API) putAdminServiceCallHandler() service.Handler {
 	return putPostAdminServiceCallHandler(api, http.MethodPut)
 }
 
-func selectDeleteAdminServiceCallHandler(api *API, method string) service.Handler {
-	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
-		var srvs []sdk.Service
-		if r.FormValue("name") != "" {
-			srv, err := services.LoadByName(ctx, api.mustDB(), r.FormValue("name"))
-			if err != nil {
-				return err
-			}
-			if srv != nil {
-				srvs = []sdk.Service{*srv}
-			}
-		} else {
-			var errFind error
-			srvs, errFind = services.LoadAllByType(ctx, api.mustDB(), r.FormValue("type"))
-			if errFind != nil {
-				return errFind
-			}
+// loadAdminServicesToCall returns the service given by name, or all the services of given type
+func loadAdminServicesToCall(ctx context.Context, api *API, r *http.Request) ([]sdk.Service, error) {
+	var srvs []sdk.Service
+	if r.FormValue("name") != "" {
+		srv, err := services.LoadByName(ctx, api.mustDB(), r.FormValue("name"))
+		if err != nil {
+			return nil, err
+		}
+		if srv != nil {
+			srvs = []sdk.Service{*srv}
 		}
+	} else {
+		var errFind error
+		srvs, errFind = services.LoadAllByType(ctx, api.mustDB(), r.FormValue("type"))
+		if errFind != nil {
+			return nil, errFind
+		}
+	}
+
+	if len(srvs) == 0 {
+		return nil, sdk.WrapError(sdk.ErrNotFound, "No service found")
+	}
+	return srvs, nil
+}
 
-		if len(srvs) == 0 {
-			return sdk.WrapError(sdk.ErrNotFound, "No service found")
+func selectDeleteAdminServiceCallHandler(api *API, method string) service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		srvs, err := loadAdminServicesToCall(ctx, api, r)
+		if err != nil {
+			return err
 		}
 
 		query := r.FormValue("query")
@@ -144,7 +155,7 @@ func selectDeleteAdminServiceCallHandler(api *API, method string) service.Handle
 
 func putPostAdminServiceCallHandler(api *API, method string) service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
-		srvs, err := services.LoadAllByType(ctx, api.mustDB(), r.FormValue("type"))
+		srvs, err := loadAdminServicesToCall(ctx, api, r)
 		if err != nil {
 			return err
 		}
@@ -247,3 +258,49 @@ func (api *API) postAdminDatabaseRollEncryptedEntityByPrimaryKey() service.Handl
 		return nil
 	}
 }
+
+func (api *API) getAdminProjectQueueSettingsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		s, err := workflow.LoadQueueSettings(ctx, api.mustDB(), proj.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, s, http.StatusOK)
+	}
+}
+
+func (api *API) putAdminProjectQueueSettingsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		var s sdk.ProjectQueueSettings
+		if err := service.UnmarshalBody(r, &s); err != nil {
+			return err
+		}
+		s.ProjectID = proj.ID
+		if err := s.IsValid(); err != nil {
+			return err
+		}
+
+		if err := workflow.UpsertQueueSettings(ctx, api.mustDB(), s); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, s, http.StatusOK)
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ovh/cds/engine/api/audit"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// auditLogDefaultLimit is the max count of audit logs returned when no limit is given.
+const auditLogDefaultLimit = 1000
+
+func (api *API) getAdminAuditsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		filter := sdk.AuditLogFilter{
+			EntityType: QueryString(r, "entity_type"),
+			ProjectKey: QueryString(r, "project"),
+			Username:   QueryString(r, "username"),
+			Limit:      auditLogDefaultLimit,
+		}
+
+		var err error
+		if filter.Since, err = parseAuditLogDate(QueryString(r, "since")); err != nil {
+			return err
+		}
+		if filter.Until, err = parseAuditLogDate(QueryString(r, "until")); err != nil {
+			return err
+		}
+		if filter.Offset, err = FormInt(r, "offset"); err != nil {
+			return err
+		}
+		limit, err := FormInt(r, "limit")
+		if err != nil {
+			return err
+		}
+		if limit > 0 {
+			filter.Limit = limit
+		}
+
+		logs, err := audit.LoadLogs(ctx, api.mustDB(), filter)
+		if err != nil {
+			return err
+		}
+
+		switch format := QueryString(r, "format"); format {
+		case "", sdk.AuditLogFormatJSON:
+			return service.WriteJSON(w, logs, http.StatusOK)
+		case sdk.AuditLogFormatCSV:
+			w.Header().Add("Content-Type", "text/csv")
+			w.Header().Add("Content-Disposition", "attachment; filename=\"cds-audits.csv\"")
+			w.WriteHeader(http.StatusOK)
+			return writeAuditLogsCSV(w, logs)
+		default:
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given format %q", format)
+		}
+	}
+}
+
+// parseAuditLogDate accepts RFC3339 dates or days (ie. 2020-01-31).
+func parseAuditLogDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given date %q", s)
+	}
+	return t, nil
+}
+
+func writeAuditLogsCSV(w http.ResponseWriter, logs []sdk.AuditLog) error {
+	c := csv.NewWriter(w)
+	if err := c.Write([]string{"id", "created", "method", "path", "status_code", "entity_type", "entity_name",
+		"project_key", "user_id", "username", "consumer_id", "consumer_type", "ip_address", "changes"}); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	for _, l := range logs {
+		var changes string
+		if len(l.Changes) > 0 {
+			buf, err := json.Marshal(l.Changes)
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			changes = string(buf)
+		}
+		if err := c.Write([]string{
+			strconv.FormatInt(l.ID, 10),
+			l.Created.Format(time.RFC3339),
+			l.Method,
+			l.Path,
+			strconv.Itoa(l.StatusCode),
+			l.EntityType,
+			l.EntityName,
+			l.ProjectKey,
+			l.UserID,
+			l.Username,
+			l.ConsumerID,
+			l.ConsumerType,
+			l.IPAddress,
+			changes,
+		}); err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+
+	c.Flush()
+	return sdk.WithStack(c.Error())
+}
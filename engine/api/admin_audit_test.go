@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_getAdminAuditsHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+	api.Config.Audit.Enabled = true
+
+	_, jwtAdmin := assets.InsertAdminUser(t, db)
+	gName := sdk.RandomString(10)
+	g := &sdk.Group{Name: gName}
+	u, jwtRaw := assets.InsertLambdaUser(t, db, g)
+
+	newName := sdk.RandomString(10)
+	uri := api.Router.GetRoute(http.MethodPut, api.putGroupHandler, map[string]string{
+		"permGroupName": gName,
+	})
+	require.NotEmpty(t, uri)
+	req := assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodPut, uri, sdk.Group{Name: newName})
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	uri = api.Router.GetRoute(http.MethodGet, api.getAdminAuditsHandler, nil)
+	require.NotEmpty(t, uri)
+	req = assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodGet, uri+"?entity_type=group&username="+u.Username, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var logs []sdk.AuditLog
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &logs))
+	require.Len(t, logs, 1)
+	assert.Equal(t, http.MethodPut, logs[0].Method)
+	assert.Equal(t, gName, logs[0].EntityName)
+	assert.Equal(t, u.ID, logs[0].UserID)
+	require.Len(t, logs[0].Changes, 1)
+	assert.Equal(t, "name", logs[0].Changes[0].Path)
+	assert.Equal(t, gName, logs[0].Changes[0].Before)
+	assert.Equal(t, newName, logs[0].Changes[0].After)
+
+	// Export as CSV
+	req = assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodGet, uri+"?entity_type=group&format=csv&username="+u.Username, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, gName, records[1][6])
+}
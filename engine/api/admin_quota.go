@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/quota"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) quotaReport(ctx context.Context, q sdk.Quota) (sdk.QuotaReport, error) {
+	r := sdk.QuotaReport{Quota: q}
+	if q.ProjectID != nil {
+		proj, err := project.LoadByID(api.mustDB(), api.Cache, *q.ProjectID)
+		if err != nil {
+			return r, err
+		}
+		r.Entity = "project/" + proj.Key
+	} else {
+		g, err := group.LoadByID(ctx, api.mustDB(), *q.GroupID)
+		if err != nil {
+			return r, err
+		}
+		r.Entity = "group/" + g.Name
+	}
+	u, err := quota.ComputeUsage(ctx, api.mustDB(), q)
+	if err != nil {
+		return r, err
+	}
+	r.Usage = u
+	return r, nil
+}
+
+func (api *API) getAdminQuotasHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		qs, err := quota.LoadAll(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+
+		reports := make([]sdk.QuotaReport, 0, len(qs))
+		for i := range qs {
+			report, err := api.quotaReport(ctx, qs[i])
+			if err != nil {
+				return err
+			}
+			reports = append(reports, report)
+		}
+
+		return service.WriteJSON(w, reports, http.StatusOK)
+	}
+}
+
+// loadQuotaFromRequest returns the quota of the project or the group given in the route, with a new quota if none exists.
+func (api *API) loadQuotaFromRequest(ctx context.Context, r *http.Request) (*sdk.Quota, error) {
+	vars := mux.Vars(r)
+
+	if key, ok := vars[permProjectKey]; ok {
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return nil, err
+		}
+		q, err := quota.LoadByProjectID(ctx, api.mustDB(), proj.ID)
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return &sdk.Quota{ProjectID: &proj.ID}, nil
+		}
+		return q, err
+	}
+
+	g, err := group.LoadByName(ctx, api.mustDB(), vars["permGroupName"])
+	if err != nil {
+		return nil, err
+	}
+	q, err := quota.LoadByGroupID(ctx, api.mustDB(), g.ID)
+	if sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return &sdk.Quota{GroupID: &g.ID}, nil
+	}
+	return q, err
+}
+
+func (api *API) getAdminQuotaHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		q, err := api.loadQuotaFromRequest(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		report, err := api.quotaReport(ctx, *q)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, report, http.StatusOK)
+	}
+}
+
+func (api *API) putAdminQuotaHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		old, err := api.loadQuotaFromRequest(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var q sdk.Quota
+		if err := service.UnmarshalBody(r, &q); err != nil {
+			return err
+		}
+		q.ID = old.ID
+		q.ProjectID = old.ProjectID
+		q.GroupID = old.GroupID
+		if err := q.IsValid(); err != nil {
+			return err
+		}
+
+		if q.ID == 0 {
+			err = quota.Insert(api.mustDB(), &q)
+		} else {
+			err = quota.Update(api.mustDB(), &q)
+		}
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, q, http.StatusOK)
+	}
+}
+
+func (api *API) deleteAdminQuotaHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		q, err := api.loadQuotaFromRequest(ctx, r)
+		if err != nil {
+			return err
+		}
+		if q.ID == 0 {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		return quota.Delete(api.mustDB(), q)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ovh/cds/engine/api/authentication/gitlab"
 	"github.com/ovh/cds/engine/api/authentication/ldap"
 	"github.com/ovh/cds/engine/api/authentication/local"
+	"github.com/ovh/cds/engine/api/authentication/oidc"
 	"github.com/ovh/cds/engine/api/bootstrap"
 	"github.com/ovh/cds/engine/api/broadcast"
 	"github.com/ovh/cds/engine/api/cache"
@@ -65,23 +66,22 @@ type Configuration struct {
 		UI  string `toml:"ui" default:"http://localhost:8080" json:"ui"`
 	} `toml:"url" comment:"#####################\n CDS URLs Settings \n####################" json:"url"`
 	HTTP struct {
-		Addr string `toml:"addr" default:"" commented:"true" comment:"Listen HTTP address without port, example: 127.0.0.1" json:"addr"`
-		Port int    `toml:"port" default:"8081" json:"port"`
+		Addr        string `toml:"addr" default:"" commented:"true" comment:"Listen HTTP address without port, example: 127.0.0.1" json:"addr"`
+		Port        int    `toml:"port" default:"8081" json:"port"`
+		MaxBodySize int64  `toml:"maxBodySize" default:"52428800" comment:"Max request body size in bytes for routes without specific limit (default: 50MB)" json:"maxBodySize"`
 	} `toml:"http" json:"http"`
 	Secrets struct {
 		Key string `toml:"key" json:"-"`
 	} `toml:"secrets" json:"secrets"`
-	Database database.DBConfiguration `toml:"database" comment:"################################\n Postgresql Database settings \n###############################" json:"database"`
-	Cache    struct {
-		TTL   int `toml:"ttl" default:"60" json:"ttl"`
-		Redis struct {
-			Host     string `toml:"host" default:"localhost:6379" comment:"If your want to use a redis-sentinel based cluster, follow this syntax! <clustername>@sentinel1:26379,sentinel2:26379,sentinel3:26379" json:"host"`
-			Password string `toml:"password" json:"-"`
-		} `toml:"redis" comment:"Connect CDS to a redis cache If you more than one CDS instance and to avoid losing data at startup" json:"redis"`
-	} `toml:"cache" comment:"######################\n CDS Cache Settings \n#####################\n" json:"cache"`
-	Directories struct {
+	Database        database.DBConfiguration        `toml:"database" comment:"################################\n Postgresql Database settings \n###############################" json:"database"`
+	DatabaseReplica database.DBReplicaConfiguration `toml:"databaseReplica" comment:"################################\n Postgresql read-only replica settings \n Used by the heavy read endpoints: runs search, logs and exports \n###############################" json:"databaseReplica"`
+	Cache           cache.Configuration             `toml:"cache" comment:"######################\n CDS Cache Settings \n#####################\n" json:"cache"`
+	Directories     struct {
 		Download string `toml:"download" default:"/var/lib/cds-engine" json:"download"`
 	} `toml:"directories" json:"directories"`
+	AirGapped struct {
+		Enabled bool `toml:"enabled" default:"false" comment:"Enable if the API has no access to internet. Workers, plugins and default actions are then only served from the API and its storage,\n they can be imported from a bundle exported on a connected CDS with 'cdsctl admin assets export' and 'cdsctl admin assets import'" json:"enabled"`
+	} `toml:"airGapped" json:"airGapped"`
 	Auth struct {
 		DefaultGroup  string `toml:"defaultGroup" default:"" comment:"The default group is the group in which every new user will be granted at signup" json:"defaultGroup"`
 		RSAPrivateKey string `toml:"rsaPrivateKey" default:"" comment:"The RSA Private Key used to sign and verify the JWT Tokens issued by the API \nThis is mandatory." json:"-"`
@@ -133,6 +133,28 @@ type Configuration struct {
 			ApplicationID  string `toml:"applicationID" json:"-" comment:"#######\n Gitlab OAuth Application ID"`
 			Secret         string `toml:"secret" json:"-"  comment:"Gitlab OAuth Application Secret"`
 		} `toml:"gitlab" json:"gitlab"`
+		OIDC struct {
+			Enabled        bool   `toml:"enabled" default:"false" json:"enabled"`
+			SignupDisabled bool   `toml:"signupDisabled" default:"false" json:"signupDisabled"`
+			URL            string `toml:"url" json:"url" comment:"#######\n OpenID Connect provider URL used for discovery (ie. https://accounts.google.com)"`
+			ClientID       string `toml:"clientId" json:"-" comment:"#######\n OpenID Connect Client ID"`
+			ClientSecret   string `toml:"clientSecret" json:"-" comment:"OpenID Connect Client Secret"`
+			Scopes         string `toml:"scopes" default:"openid,profile,email" json:"scopes" comment:"Comma separated list of scopes requested to the provider"`
+			Claims         struct {
+				Username string `toml:"username" default:"preferred_username" json:"username"`
+				Fullname string `toml:"fullname" default:"name" json:"fullname"`
+				Email    string `toml:"email" default:"email" json:"email"`
+				Groups   string `toml:"groups" default:"groups" json:"groups"`
+			} `toml:"claims" json:"claims" comment:"ID token claims used to fill CDS user info"`
+			GroupsSync   bool   `toml:"groupsSync" default:"false" json:"groupsSync" comment:"Synchronize user's groups from the groups claim on signin"`
+			GroupsPrefix string `toml:"groupsPrefix" default:"" json:"groupsPrefix" comment:"Only CDS groups starting with this prefix will be synchronized"`
+			TrustEmail   bool   `toml:"trustEmail" default:"false" json:"trustEmail" comment:"Link the signin to an existing CDS user with the same email. Enable it only if the provider verifies the emails of its users"`
+		} `toml:"oidc" json:"oidc"`
+		SCIM struct {
+			Enabled      bool   `toml:"enabled" default:"false" json:"enabled" comment:"Expose a SCIM 2.0 API on /scim/v2 to let an identity provider provision users and groups"`
+			Token        string `toml:"token" json:"-" comment:"Bearer token that should be sent by the identity provider"`
+			GroupsPrefix string `toml:"groupsPrefix" default:"" json:"groupsPrefix" comment:"Only CDS groups starting with this prefix can be managed by the identity provider"`
+		} `toml:"scim" json:"scim"`
 	} `toml:"auth" comment:"##############################\n CDS Authentication Settings#\n#############################" json:"auth"`
 	SMTP struct {
 		Disable  bool   `toml:"disable" default:"true" json:"disable" comment:"Set to false to enable the internal SMTP client"`
@@ -144,8 +166,9 @@ type Configuration struct {
 		From     string `toml:"from" default:"no-reply@cds.local" json:"from"`
 	} `toml:"smtp" comment:"#####################\n# CDS SMTP Settings \n####################" json:"smtp"`
 	Artifact struct {
-		Mode  string `toml:"mode" default:"local" comment:"swift, awss3 or local" json:"mode"`
-		Local struct {
+		Mode          string `toml:"mode" default:"local" comment:"swift, awss3 or local" json:"mode"`
+		MaxUploadSize int64  `toml:"maxUploadSize" default:"0" comment:"Max size in bytes of an uploaded artifact, static files or cache archive, 0 means unlimited. Uploads are streamed to the storage backend." json:"maxUploadSize"`
+		Local         struct {
 			BaseDirectory string `toml:"baseDirectory" default:"/var/lib/cds-engine/artifacts" json:"baseDirectory"`
 		} `toml:"local"`
 		Openstack struct {
@@ -193,6 +216,10 @@ type Configuration struct {
 		StepMaxSize    int64 `toml:"stepMaxSize" default:"15728640" comment:"Max step logs size in bytes (default: 15MB)" json:"stepMaxSize"`
 		ServiceMaxSize int64 `toml:"serviceMaxSize" default:"15728640" comment:"Max service logs size in bytes (default: 15MB)" json:"serviceMaxSize"`
 	} `toml:"log" json:"log" comment:"###########################\n Log settings.\n##########################"`
+	Audit struct {
+		Enabled   bool `toml:"enabled" default:"true" comment:"Record mutating API calls made by users" json:"enabled"`
+		Retention int  `toml:"retention" default:"365" comment:"Audit logs retention in days, 0 to keep them forever" json:"retention"`
+	} `toml:"audit" json:"audit" comment:"###########################\n Audit log settings.\n##########################"`
 }
 
 // ServiceConfiguration is the configuration of external service
@@ -246,12 +273,16 @@ type API struct {
 	Router              *Router
 	Config              Configuration
 	DBConnectionFactory *database.DBConnectionFactory
-	SharedStorage       objectstore.Driver
-	StartupTime         time.Time
-	Maintenance         bool
-	eventsBroker        *eventsBroker
-	Cache               cache.Store
-	Metrics             struct {
+	// DBReplicaConnectionFactory is nil if no replica is configured
+	DBReplicaConnectionFactory *database.DBConnectionFactory
+	SharedStorage              objectstore.Driver
+	StartupTime                time.Time
+	Maintenance                bool
+	eventsBroker               *eventsBroker
+	websocketBroker            *websocketBroker
+	Cache                      cache.Store
+	EntityCache                *cache.EntityCache
+	Metrics                    struct {
 		WorkflowRunFailed        *stats.Int64Measure
 		WorkflowRunStarted       *stats.Int64Measure
 		Sessions                 *stats.Int64Measure
@@ -270,6 +301,8 @@ type API struct {
 		WorkflowRunsMarkToDelete *stats.Int64Measure
 		WorkflowRunsDeleted      *stats.Int64Measure
 		DatabaseConns            *stats.Int64Measure
+		quotaUsage               *stats.Float64Measure
+		quotaLimit               *stats.Float64Measure
 	}
 	AuthenticationDrivers map[sdk.AuthConsumerType]sdk.AuthDriver
 }
@@ -405,7 +438,10 @@ func (a *API) Serve(ctx context.Context) error {
 	if !hasCtl {
 		log.Error(ctx, "cdsctl is unavailable for download, this may lead to a poor user experience. Please check your configuration file or the %s directory", a.Config.Directories.Download)
 	}
-	if !hasWorker {
+	if !hasWorker && a.Config.AirGapped.Enabled {
+		// On an air-gapped installation, binaries are imported from an assets bundle once the API is started
+		log.Error(ctx, "worker is unavailable for download, no job can be run until an assets bundle is imported with 'cdsctl admin assets import'")
+	} else if !hasWorker {
 		// If no worker, let's exit because CDS for run anything
 		log.Error(ctx, "worker is unavailable for download. Please check your configuration file or the %s directory", a.Config.Directories.Download)
 		return errors.New("worker binary unavailable")
@@ -512,6 +548,14 @@ func (a *API) Serve(ctx context.Context) error {
 		return fmt.Errorf("cannot connect to database: %v", err)
 	}
 
+	if a.Config.DatabaseReplica.DSN != "" {
+		log.Info(ctx, "Initializing database replica connection...")
+		a.DBReplicaConnectionFactory, err = database.InitReplica(ctx, a.Config.DatabaseReplica.DSN, a.Config.DatabaseReplica.Timeout, a.Config.DatabaseReplica.MaxConn)
+		if err != nil {
+			return fmt.Errorf("cannot connect to database replica: %v", err)
+		}
+	}
+
 	log.Info(ctx, "Setting up database keys...")
 	encryptionKeyConfig := a.Config.Database.EncryptionKey.GetKeys(gorpmapping.KeyEcnryptionIdentifier)
 	signatureKeyConfig := a.Config.Database.SignatureKey.GetKeys(gorpmapping.KeySignIdentifier)
@@ -519,20 +563,23 @@ func (a *API) Serve(ctx context.Context) error {
 		return fmt.Errorf("cannot setup database keys: %v", err)
 	}
 
-	log.Info(ctx, "Initializing redis cache on %s...", a.Config.Cache.Redis.Host)
+	log.Info(ctx, "Initializing %s cache...", a.Config.Cache.Driver)
 	// Init the cache
-	a.Cache, err = cache.New(
-		a.Config.Cache.Redis.Host,
-		a.Config.Cache.Redis.Password,
-		a.Config.Cache.TTL)
+	a.Cache, err = cache.NewFromConfiguration(ctx, a.Config.Cache)
 	if err != nil {
 		return fmt.Errorf("cannot connect to cache store: %v", err)
 	}
+	entityTTL := a.Config.Cache.EntityTTL
+	if entityTTL <= 0 {
+		entityTTL = 600
+	}
+	a.EntityCache = cache.NewEntityCache(a.Cache, time.Duration(entityTTL)*time.Second)
 
 	log.Info(ctx, "Initializing HTTP router")
 	a.Router = &Router{
-		Mux:        mux.NewRouter(),
-		Background: ctx,
+		Mux:         mux.NewRouter(),
+		Background:  ctx,
+		MaxBodySize: a.Config.HTTP.MaxBodySize,
 	}
 	a.InitRouter()
 	if err := InitRouterMetrics(a); err != nil {
@@ -545,7 +592,7 @@ func (a *API) Serve(ctx context.Context) error {
 	}
 
 	// Intialize notification package
-	notification.Init(a.Config.URL.UI)
+	notification.Init(a.Config.URL.UI, a.mustDB)
 
 	log.Info(ctx, "Initializing Authentication drivers...")
 	a.AuthenticationDrivers = make(map[sdk.AuthConsumerType]sdk.AuthDriver)
@@ -600,6 +647,28 @@ func (a *API) Serve(ctx context.Context) error {
 		)
 	}
 
+	if a.Config.Auth.OIDC.Enabled {
+		a.AuthenticationDrivers[sdk.ConsumerOIDC] = oidc.NewDriver(
+			a.Config.Auth.OIDC.SignupDisabled,
+			a.Config.URL.UI,
+			oidc.Config{
+				URL:          a.Config.Auth.OIDC.URL,
+				ClientID:     a.Config.Auth.OIDC.ClientID,
+				ClientSecret: a.Config.Auth.OIDC.ClientSecret,
+				Scopes:       strings.Split(a.Config.Auth.OIDC.Scopes, ","),
+				Claims: oidc.ClaimsMapping{
+					Username: a.Config.Auth.OIDC.Claims.Username,
+					Fullname: a.Config.Auth.OIDC.Claims.Fullname,
+					Email:    a.Config.Auth.OIDC.Claims.Email,
+					Groups:   a.Config.Auth.OIDC.Claims.Groups,
+				},
+				GroupsSync:   a.Config.Auth.OIDC.GroupsSync,
+				GroupsPrefix: a.Config.Auth.OIDC.GroupsPrefix,
+				TrustEmail:   a.Config.Auth.OIDC.TrustEmail,
+			},
+		)
+	}
+
 	if a.Config.Auth.CorporateSSO.Enabled {
 		driverConfig := corpsso.Config{
 			MailDomain: a.Config.Auth.CorporateSSO.MailDomain,
@@ -628,6 +697,12 @@ func (a *API) Serve(ctx context.Context) error {
 		}
 	}, a.PanicDump())
 
+	sdk.GoRoutine(ctx, "cache.EntityCache.Listen", func(ctx context.Context) {
+		if err := a.EntityCache.Listen(ctx); err != nil {
+			log.Error(ctx, "error while initializing cache invalidation routine: %s", err)
+		}
+	}, a.PanicDump())
+
 	sdk.GoRoutine(ctx, "workermodel.Initialize", func(ctx context.Context) {
 		if err := workermodel.Initialize(ctx, a.DBConnectionFactory.GetDBMap, a.Cache); err != nil {
 			log.Error(ctx, "error while initializing worker models routine: %s", err)
@@ -652,6 +727,9 @@ func (a *API) Serve(ctx context.Context) error {
 	sdk.GoRoutine(ctx, "workflowtemplate.ComputeAudit", func(ctx context.Context) {
 		workflowtemplate.ComputeAudit(ctx, a.DBConnectionFactory.GetDBMap)
 	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "audit.PurgeLogs", func(ctx context.Context) {
+		audit.PurgeLogs(ctx, a.DBConnectionFactory.GetDBMap, a.Config.Audit.Retention)
+	})
 	sdk.GoRoutine(ctx, "auditCleanerRoutine(ctx", func(ctx context.Context) {
 		auditCleanerRoutine(ctx, a.DBConnectionFactory.GetDBMap)
 	})
@@ -670,6 +748,18 @@ func (a *API) Serve(ctx context.Context) error {
 	sdk.GoRoutine(ctx, "authentication.SessionCleaner", func(ctx context.Context) {
 		authentication.SessionCleaner(ctx, a.mustDB)
 	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.workflowTimerScheduler", func(ctx context.Context) {
+		a.workflowTimerScheduler(ctx)
+	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.subWorkflowScheduler", func(ctx context.Context) {
+		a.subWorkflowScheduler(ctx)
+	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.freezeWindowScheduler", func(ctx context.Context) {
+		a.freezeWindowScheduler(ctx)
+	}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.gateScheduler", func(ctx context.Context) {
+		a.gateScheduler(ctx)
+	}, a.PanicDump())
 
 	migrate.Add(ctx, sdk.Migration{Name: "RefactorGroupMembership", Release: "0.44.0", Blocker: true, Automatic: true, ExecFunc: func(ctx context.Context) error {
 		return migrate.RefactorGroupMembership(ctx, a.DBConnectionFactory.GetDBMap())
@@ -800,6 +890,9 @@ func (a *API) Serve(ctx context.Context) error {
 			log.Warning(ctx, "Cleanup SQL connections")
 			s.Shutdown(ctx)
 			a.DBConnectionFactory.Close()
+			if a.DBReplicaConnectionFactory != nil {
+				a.DBReplicaConnectionFactory.Close()
+			}
 			event.Publish(ctx, sdk.EventEngine{Message: "shutdown"}, nil)
 			event.Close(ctx)
 		}
@@ -75,6 +75,14 @@ func isHatchery(ctx context.Context) bool {
 	return c.Service != nil && c.Service.Type == services.TypeHatchery
 }
 
+func isChatOps(ctx context.Context) bool {
+	c := getAPIConsumer(ctx)
+	if c == nil {
+		return false
+	}
+	return c.Service != nil && c.Service.Type == services.TypeChatOps
+}
+
 func getAPIConsumer(c context.Context) *sdk.AuthConsumer {
 	i := c.Value(contextAPIConsumer)
 	if i == nil {
@@ -122,6 +130,20 @@ func (a *API) mustDB() *gorp.DbMap {
 	return db
 }
 
+// mustDBReplica returns the read-only replica of the database, for the heavy read endpoints which can tolerate
+// the replication lag. It falls back on the primary database if no replica is configured or available.
+func (a *API) mustDBReplica() *gorp.DbMap {
+	if a.DBReplicaConnectionFactory == nil {
+		return a.mustDB()
+	}
+	db := a.DBReplicaConnectionFactory.GetDBMap()
+	if db == nil {
+		log.Warning(context.Background(), "database replica unavailable, using the primary database")
+		return a.mustDB()
+	}
+	return db
+}
+
 func (a *API) mustDBWithCtx(ctx context.Context) *gorp.DbMap {
 	db := a.DBConnectionFactory.GetDBMap()
 	db = db.WithContext(ctx).(*gorp.DbMap)
@@ -23,8 +23,8 @@ var (
 func (api *API) InitRouter() {
 	api.Router.URL = api.Config.URL.API
 	api.Router.SetHeaderFunc = DefaultHeaders
-	api.Router.Middlewares = append(api.Router.Middlewares, api.authMiddleware, api.tracingMiddleware, api.maintenanceMiddleware)
-	api.Router.PostMiddlewares = append(api.Router.PostMiddlewares, TracingPostMiddleware)
+	api.Router.Middlewares = append(api.Router.Middlewares, api.authMiddleware, api.tracingMiddleware, api.maintenanceMiddleware, api.auditLogMiddleware)
+	api.Router.PostMiddlewares = append(api.Router.PostMiddlewares, TracingPostMiddleware, api.auditLogPostMiddleware)
 
 	r := api.Router
 
@@ -39,6 +39,17 @@ func (api *API) InitRouter() {
 	}
 	api.eventsBroker.Init(r.Background, api.PanicDump())
 
+	log.Info(api.Router.Background, "Initializing WebSocket broker")
+	api.websocketBroker = &websocketBroker{
+		router:         api.Router,
+		cache:          api.Cache,
+		clients:        make(map[string]*websocketClient),
+		dbFunc:         api.DBConnectionFactory.GetDBMap,
+		messages:       make(chan sdk.Event),
+		allowedOrigins: []string{api.Config.URL.UI},
+	}
+	api.websocketBroker.Init(r.Background, api.PanicDump())
+
 	// Auth
 	r.Handle("/auth/driver", ScopeNone(), r.GET(api.getAuthDriversHandler, Auth(false)))
 	r.Handle("/auth/me", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getAuthMe))
@@ -51,6 +62,7 @@ func (api *API) InitRouter() {
 	r.Handle("/auth/consumer/builtin/signin", ScopeNone(), r.POST(api.postAuthBuiltinSigninHandler, Auth(false), MaintenanceAware()))
 	r.Handle("/auth/consumer/worker/signin", ScopeNone(), r.POST(api.postRegisterWorkerHandler, Auth(false), MaintenanceAware()))
 	r.Handle("/auth/consumer/worker/signout", ScopeNone(), r.POST(api.postUnregisterWorkerHandler, MaintenanceAware()))
+	r.Handle("/auth/consumer/worker/rotate", ScopeNone(), r.POST(api.postRotateWorkerSessionHandler, MaintenanceAware()))
 	r.Handle("/auth/consumer/{consumerType}/askSignin", ScopeNone(), r.GET(api.getAuthAskSigninHandler, Auth(false)))
 	r.Handle("/auth/consumer/{consumerType}/signin", Scope(sdk.AuthConsumerScopeAccessToken), r.POST(api.postAuthSigninHandler, Auth(false), MaintenanceAware()))
 	r.Handle("/auth/consumer/{consumerType}/detach", Scope(sdk.AuthConsumerScopeAccessToken), r.POST(api.postAuthDetachHandler))
@@ -62,6 +74,8 @@ func (api *API) InitRouter() {
 	r.Handle("/action/{permGroupName}/{permActionName}", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionHandler), r.PUT(api.putActionHandler), r.DELETE(api.deleteActionHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/usage", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionUsageHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/export", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionExportHandler))
+	r.Handle("/action/{permGroupName}/{permActionName}/version", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionVersionsHandler), r.POST(api.postActionVersionHandler))
+	r.Handle("/action/{permGroupName}/{permActionName}/version/{version}/usage", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionVersionUsageHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/audit", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionAuditHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/audit/{auditID}/rollback", Scope(sdk.AuthConsumerScopeAction), r.POST(api.postActionAuditRollbackHandler))
 	r.Handle("/action/requirement", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionsRequirements, Auth(false))) // FIXME add auth used by hatcheries
@@ -73,6 +87,13 @@ func (api *API) InitRouter() {
 
 	// Admin
 	r.Handle("/admin/maintenance", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postMaintenanceHandler, NeedAdmin(true)))
+	r.Handle("/admin/project/{permProjectKey}/queue", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminProjectQueueSettingsHandler, NeedAdmin(true)), r.PUT(api.putAdminProjectQueueSettingsHandler, NeedAdmin(true)))
+	r.Handle("/admin/quota", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminQuotasHandler, NeedAdmin(true)))
+	r.Handle("/admin/sbom/search", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminSBOMSearchHandler, NeedAdmin(true)))
+	r.Handle("/admin/quota/project/{permProjectKey}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminQuotaHandler, NeedAdmin(true)), r.PUT(api.putAdminQuotaHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminQuotaHandler, NeedAdmin(true)))
+	r.Handle("/admin/quota/group/{permGroupName}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminQuotaHandler, NeedAdmin(true)), r.PUT(api.putAdminQuotaHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminQuotaHandler, NeedAdmin(true)))
+	r.Handle("/admin/user/scrub", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminUserScrubHandler, NeedAdmin(true)))
+	r.Handle("/admin/audits", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminAuditsHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminMigrationsHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration/{id}/cancel", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminMigrationCancelHandler, NeedAdmin(true)))
 	r.Handle("/admin/cds/migration/{id}/todo", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postAdminMigrationTodoHandler, NeedAdmin(true)))
@@ -86,11 +107,21 @@ func (api *API) InitRouter() {
 	r.Handle("/admin/debug/cpu", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.getCPUProfileHandler, NeedAdmin(true)), r.GET(api.getCPUProfileHandler, NeedAdmin(true)))
 	r.Handle("/admin/debug/{name}", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.getProfileHandler, NeedAdmin(true)), r.GET(api.getProfileHandler, NeedAdmin(true)))
 
+	r.Handle("/admin/assets/bundle", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAssetsBundleHandler, NeedAdmin(true)), r.POST(api.postAssetsBundleHandler, NeedAdmin(true), MaxBodySize(-1)))
 	r.Handle("/admin/plugin", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postGRPCluginHandler, NeedAdmin(true)), r.GET(api.getAllGRPCluginHandler, NeedAdmin(true)))
 	r.Handle("/admin/plugin/{name}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getGRPCluginHandler, NeedAdmin(true)), r.PUT(api.putGRPCluginHandler, NeedAdmin(true)), r.DELETE(api.deleteGRPCluginHandler, NeedAdmin(true)))
 	r.Handle("/admin/plugin/{name}/binary", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postGRPCluginBinaryHandler, NeedAdmin(true)))
 	r.Handle("/admin/plugin/{name}/binary/{os}/{arch}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getGRPCluginBinaryHandler, Auth(false)), r.DELETE(api.deleteGRPCluginBinaryHandler, NeedAdmin(true)))
 	r.Handle("/admin/plugin/{name}/binary/{os}/{arch}/infos", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getGRPCluginBinaryInfosHandler))
+	r.Handle("/admin/plugin/{name}/version", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postGRPCPluginVersionHandler, NeedAdmin(true), MaxBodySize(api.uploadMaxBodySize())))
+	r.Handle("/admin/plugin/{name}/version/{version}", Scope(sdk.AuthConsumerScopeAdmin), r.DELETE(api.deleteGRPCPluginVersionHandler, NeedAdmin(true)))
+	r.Handle("/admin/plugin/{name}/version/{version}/install", Scope(sdk.AuthConsumerScopeAdmin), r.POST(api.postGRPCPluginVersionInstallHandler, NeedAdmin(true)))
+
+	// Plugin registry
+	r.Handle("/plugin/registry", ScopeNone(), r.GET(api.getGRPCPluginRegistryHandler))
+	r.Handle("/plugin/registry/{name}", ScopeNone(), r.GET(api.getGRPCPluginVersionsHandler))
+	r.Handle("/plugin/registry/{name}/{version}", ScopeNone(), r.GET(api.getGRPCPluginVersionHandler))
+	r.Handle("/plugin/registry/{name}/{version}/binary/{os}/{arch}", ScopeNone(), r.GET(api.getGRPCPluginVersionBinaryHandler))
 
 	// Admin service
 	r.Handle("/admin/service/{name}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminServiceHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminServiceHandler, NeedAdmin(true)))
@@ -110,6 +141,10 @@ func (api *API) InitRouter() {
 	r.Handle("/download/plugin/{name}/binary/{os}/{arch}", ScopeNone(), r.GET(api.getGRPCluginBinaryHandler, Auth(false)))
 	r.Handle("/download/plugin/{name}/binary/{os}/{arch}/infos", ScopeNone(), r.GET(api.getGRPCluginBinaryInfosHandler))
 
+	r.Handle("/download/schemas", ScopeNone(), r.GET(api.getDownloadSchemasHandler, Auth(false)))
+	r.Handle("/download/schemas/{name}", ScopeNone(), r.GET(api.getDownloadSchemaHandler, Auth(false)))
+	r.Handle("/download/schemas/{version}/{name}", ScopeNone(), r.GET(api.getDownloadSchemaHandler, Auth(false)))
+
 	r.Handle("/download/{name}/{os}/{arch}", ScopeNone(), r.GET(api.downloadHandler, Auth(false)))
 
 	// Group
@@ -133,6 +168,7 @@ func (api *API) InitRouter() {
 	// Overall health
 	r.Handle("/mon/status", ScopeNone(), r.GET(api.statusHandler, Auth(false)))
 	r.Handle("/mon/version", ScopeNone(), r.GET(VersionHandler, Auth(false)))
+	r.Handle("/mon/services", ScopeNone(), r.GET(api.getMonServicesHandler, NeedAdmin(true)))
 	r.Handle("/mon/db/migrate", ScopeNone(), r.GET(api.getMonDBStatusMigrateHandler, NeedAdmin(true)))
 	r.Handle("/mon/metrics", ScopeNone(), r.GET(service.GetPrometheustMetricsHandler(api), Auth(false)))
 	r.Handle("/mon/metrics/all", ScopeNone(), r.GET(service.GetMetricsHandler, Auth(false)))
@@ -146,6 +182,10 @@ func (api *API) InitRouter() {
 	r.Handle("/import/{permProjectKey}", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postImportAsCodeHandler))
 	r.Handle("/import/{permProjectKey}/{uuid}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getImportAsCodeHandler))
 	r.Handle("/import/{permProjectKey}/{uuid}/perform", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postPerformImportAsCodeHandler))
+	r.Handle("/ascode/convert/{format}", ScopeNone(), r.POST(api.postConvertAsCodeHandler))
+
+	// GraphQL
+	r.Handle("/graphql", Scope(sdk.AuthConsumerScopeProject, sdk.AuthConsumerScopeRun), r.POST(api.postGraphQLHandler, ReadOnly()))
 
 	// Bookmarks
 	r.Handle("/bookmarks", ScopeNone(), r.GET(api.getBookmarksHandler))
@@ -168,6 +208,17 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/notifications", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectNotificationsHandler, DEPRECATED))
 	r.Handle("/project/{permProjectKey}/keys", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeysInProjectHandler), r.POST(api.addKeyInProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys/{name}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/sbom/search", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getProjectSBOMSearchHandler))
+	r.Handle("/project/{permProjectKey}/tests/history", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getProjectTestHistoryHandler))
+	r.Handle("/project/{permProjectKey}/tests/flaky", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getProjectFlakyTestsHandler))
+	r.Handle("/project/{permProjectKey}/dora", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getProjectDORAMetricsHandler))
+	r.Handle("/project/{permProjectKey}/retention", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectRetentionSettingsHandler), r.PUT(api.putProjectRetentionSettingsHandler))
+	r.Handle("/project/{permProjectKey}/deployments", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectDeploymentsHandler))
+	r.Handle("/project/{permProjectKey}/freeze", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectFreezeWindowsHandler), r.POST(api.postProjectFreezeWindowHandler))
+	r.Handle("/project/{permProjectKey}/freeze/override", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectFreezeOverridesHandler))
+	r.Handle("/project/{permProjectKey}/freeze/{name}", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putProjectFreezeWindowHandler), r.DELETE(api.deleteProjectFreezeWindowHandler))
+	r.Handle("/project/{permProjectKey}/auth/consumer", Scope(sdk.AuthConsumerScopeAccessToken), r.GET(api.getConsumersByProjectHandler), r.POST(api.postConsumerByProjectHandler))
+	r.Handle("/project/{permProjectKey}/auth/consumer/{consumerID}", Scope(sdk.AuthConsumerScopeAccessToken), r.DELETE(api.deleteConsumerByProjectHandler))
 
 	// As Code
 	r.Handle("/project/{key}/ascode/events/resync", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postResyncPRAsCodeHandler, EnableTracing()))
@@ -221,9 +272,12 @@ func (api *API) InitRouter() {
 
 	// Workflows
 	r.Handle("/workflow/artifact/{hash}", ScopeNone(), r.GET(api.downloadworkflowArtifactDirectHandler, Auth(false)))
+	r.Handle("/workflow/trigger/{signature}", ScopeNone(), r.POST(api.postWorkflowTriggerHandler, Auth(false), MaintenanceAware(), MaxBodySize(1<<20)))
+	r.Handle("/workflow/gate/{signature}", ScopeNone(), r.POST(api.postWorkflowGateHandler, Auth(false), MaintenanceAware(), MaxBodySize(1<<20)))
 
 	r.Handle("/project/{permProjectKey}/workflows", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowHandler, EnableTracing()), r.GET(api.getWorkflowsHandler, AllowProvider(true), EnableTracing()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowHandler, AllowProvider(true), EnableTracing()), r.PUT(api.putWorkflowHandler, EnableTracing()), r.DELETE(api.deleteWorkflowHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/graph", Scope(sdk.AuthConsumerScopeProject), r.PATCH(api.patchWorkflowGraphHandler, EnableTracing()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/eventsintegration/{integrationID}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteWorkflowEventsIntegrationHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/icon", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putWorkflowIconHandler), r.DELETE(api.deleteWorkflowIconHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/ascode", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowAsCodeHandler))
@@ -234,7 +288,12 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/notifications/conditions", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowNotificationsConditionsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/groups", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowGroupHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/groups/{groupName}", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putWorkflowGroupHandler), r.DELETE(api.deleteWorkflowGroupHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/timers", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowTimersHandler), r.POST(api.postWorkflowTimerHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/timers/{timerName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowTimerHandler), r.PUT(api.putWorkflowTimerHandler), r.DELETE(api.deleteWorkflowTimerHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/scheduled-runs", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowScheduledRunsHandler), r.POSTEXECUTE(api.postWorkflowScheduledRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/scheduled-runs/{uuid}/cancel", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postWorkflowScheduledRunCancelHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/hooks/{uuid}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowHookHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/hooks/{uuid}/missed", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowHookMissedSchedulesHandler))
 	r.Handle("/project/{key}/workflow/{permWorkflowName}/node/{nodeID}/hook/model", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowHookModelsHandler))
 	r.Handle("/project/{key}/workflow/{permWorkflowName}/node/{nodeID}/outgoinghook/model", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowOutgoingHookModelsHandler))
 
@@ -253,11 +312,19 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/pull/workflows/{permWorkflowName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowPullHandler))
 	// Push workflows
 	r.Handle("/project/{permProjectKey}/push/workflows", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowPushHandler, EnableTracing()))
+	// Push all the workflows of a project
+	r.Handle("/project/{permProjectKey}/push", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postProjectPushHandler, EnableTracing()))
 
 	// Workflows run
 	r.Handle("/project/{permProjectKey}/runs", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowAllRunsHandler, EnableTracing()))
+	r.Handle("/project/{permProjectKey}/runs/search", Scope(sdk.AuthConsumerScopeRun), r.POST(api.postSearchWorkflowRunsHandler, ReadOnly()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getDownloadArtifactHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}/attestation", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowArtifactAttestationHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}/attestation/verify", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowArtifactAttestationVerifyHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunsHandler, EnableTracing()), r.POSTEXECUTE(api.postWorkflowRunHandler /*, AllowServices(true)*/, EnableTracing()))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/trigger/url", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowTriggerURLsHandler), r.POSTEXECUTE(api.postWorkflowTriggerURLHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/trigger/url/{id}", Scope(sdk.AuthConsumerScopeRun), r.DELETE(api.deleteWorkflowTriggerURLHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/trigger/explain", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowTriggerExplainHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/branch/{branch}", Scope(sdk.AuthConsumerScopeRun), r.DELETE(api.deleteWorkflowRunsBranchHandler /*, NeedService()*/))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/latest", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getLatestWorkflowRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/tags", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunTagsHandler))
@@ -265,9 +332,28 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunHandler /*, AllowServices(true)*/, EnableTracing()), r.DELETE(api.deleteWorkflowRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.stopWorkflowRunHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/vcs/resync", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postResyncVCSWorkflowRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/rehydrate", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postWorkflowRunRehydrateHandler, MaintenanceAware()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunArtifactsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts/diff", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunArtifactsDiffHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts/promote", Scope(sdk.AuthConsumerScopeRun), r.POST(api.postWorkflowRunArtifactsPromoteHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/changelog", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunChangelogsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/sbom", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunSBOMsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/sbom/{sbomID}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunSBOMHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/tests", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunTestResultsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/coverage", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowCoverageTrendHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/insights", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowInsightsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifacts/promoted", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowArtifactPromotionsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifacts/promoted/{promotionID}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowArtifactPromotionDownloadHandler), r.DELETE(api.deleteWorkflowArtifactPromotionHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/tests/summary", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunTestsSummaryHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/report", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunReportHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/links", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunLinksHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/logs/download", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunLogsArchiveHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/notifications/deliveries", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunNotificationDeliveriesHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/manifest", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunManifestHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/changelog", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postWorkflowNodeRunChangelogHandler, MaintenanceAware()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.stopWorkflowNodeRunHandler, MaintenanceAware()))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/gate/url", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postWorkflowNodeRunGateURLHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeID}/history", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunHistoryHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/{nodeName}/commits", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowCommitsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/info", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunJobSpawnInfosHandler))
@@ -300,13 +386,13 @@ func (api *API) InitRouter() {
 
 	// Project storage
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getArtifactsStoreHandler))
-	r.Handle("/project/{permProjectKey}/storage/{integrationName}/artifact/{ref}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifactHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/project/{permProjectKey}/storage/{integrationName}/artifact/{ref}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifactHandler, EnableTracing(), MaintenanceAware(), MaxBodySize(api.uploadMaxBodySize())))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/artifact/{ref}/url", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifacWithTempURLHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/artifact/{ref}/url/callback", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifactWithTempURLCallbackHandler, EnableTracing(), MaintenanceAware()))
-	r.Handle("/project/{permProjectKey}/storage/{integrationName}/staticfiles/{name}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobStaticFilesHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/project/{permProjectKey}/storage/{integrationName}/staticfiles/{name}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobStaticFilesHandler, EnableTracing(), MaintenanceAware(), MaxBodySize(api.uploadMaxBodySize())))
 
 	// Cache
-	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cache/{tag}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postPushCacheHandler, MaintenanceAware()), r.GET(api.getPullCacheHandler))
+	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cache/{tag}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postPushCacheHandler, MaintenanceAware(), MaxBodySize(api.uploadMaxBodySize())), r.GET(api.getPullCacheHandler))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cache/{tag}/url", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postPushCacheWithTempURLHandler, MaintenanceAware()), r.GET(api.getPullCacheWithTempURLHandler))
 
 	//Workflow queue
@@ -315,13 +401,16 @@ func (api *API) InitRouter() {
 	r.Handle("/queue/workflows/{id}/take", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(api.postTakeWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/book", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(api.postBookWorkflowJobHandler, EnableTracing(), MaintenanceAware()), r.DELETE(api.deleteBookWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/infos", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/comments", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postCodeCommentsHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/vulnerability", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postVulnerabilityReportHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/spawn/infos", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(r.Asynchronous(api.postSpawnInfosWorkflowJobHandler, 1), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/result", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobResultHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/log", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobLogsHandler, MaintenanceAware()))
 	r.Handle("/queue/workflows/log/service", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(r.Asynchronous(api.postWorkflowJobServiceLogsHandler, 1), MaintenanceAware()))
-	r.Handle("/queue/workflows/{permJobID}/coverage", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobCoverageResultsHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/coverage", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobCoverageHandler), r.POSTEXECUTE(api.postWorkflowJobCoverageResultsHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/test", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobTestsResultsHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/artifact/attestation", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifactAttestationHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/sbom", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobSBOMHandler, EnableTracing(), MaintenanceAware(), MaxBodySize(api.uploadMaxBodySize())))
 	r.Handle("/queue/workflows/{permJobID}/tag", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobTagsHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/step", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobStepStatusHandler, EnableTracing(), MaintenanceAware()))
 
@@ -364,6 +453,8 @@ func (api *API) InitRouter() {
 	r.Handle("/user/favorite", Scope(sdk.AuthConsumerScopeUser), r.POST(api.postUserFavoriteHandler))
 	r.Handle("/user/schema", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserJSONSchema))
 	r.Handle("/user/timeline", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getTimelineHandler))
+	r.Handle("/user/chatops/link", Scope(sdk.AuthConsumerScopeUser), r.POST(api.postChatOpsLinkHandler))
+	r.Handle("/user/chatops/link/{provider}", Scope(sdk.AuthConsumerScopeUser), r.DELETE(api.deleteChatOpsLinkHandler))
 	r.Handle("/user/timeline/filter", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getTimelineFilterHandler), r.POST(api.postTimelineFilterHandler))
 	r.Handle("/user/{permUsernamePublic}", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserHandler), r.PUT(api.putUserHandler), r.DELETE(api.deleteUserHandler))
 	r.Handle("/user/{permUsernamePublic}/group", Scope(sdk.AuthConsumerScopeUser), r.GET(api.getUserGroupsHandler))
@@ -388,6 +479,7 @@ func (api *API) InitRouter() {
 	r.Handle("/worker/model/pattern", Scope(sdk.AuthConsumerScopeWorkerModel), r.POST(api.postAddWorkerModelPatternHandler, NeedAdmin(true)), r.GET(api.getWorkerModelPatternsHandler))
 	r.Handle("/worker/model/pattern/{type}/{name}", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelPatternHandler), r.PUT(api.putWorkerModelPatternHandler, NeedAdmin(true)), r.DELETE(api.deleteWorkerModelPatternHandler, NeedAdmin(true)))
 	r.Handle("/worker/model/import", Scope(sdk.AuthConsumerScopeWorkerModel), r.POST(api.postWorkerModelImportHandler))
+	r.Handle("/worker/model/trust-policy", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelTrustPolicyHandler), r.PUT(api.putWorkerModelTrustPolicyHandler, NeedAdmin(true)))
 	r.Handle("/worker/model/{permGroupName}/{permModelName}", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelHandler), r.PUT(api.putWorkerModelHandler), r.DELETE(api.deleteWorkerModelHandler))
 	r.Handle("/worker/model/{permGroupName}/{permModelName}/export", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelExportHandler))
 	r.Handle("/worker/model/{permGroupName}/{permModelName}/usage", Scope(sdk.AuthConsumerScopeWorkerModel), r.GET(api.getWorkerModelUsageHandler))
@@ -399,10 +491,12 @@ func (api *API) InitRouter() {
 
 	// Workflows
 	r.Handle("/workflow/hook", Scope(sdk.AuthConsumerScopeHooks), r.GET(api.getWorkflowHooksHandler))
+	r.Handle("/workflow/hook/scheduled-run", Scope(sdk.AuthConsumerScopeHooks), r.POST(api.postWorkflowScheduledRunExecuteHandler))
 	r.Handle("/workflow/hook/model/{model}", ScopeNone(), r.GET(api.getWorkflowHookModelHandler), r.POST(api.postWorkflowHookModelHandler, NeedAdmin(true)), r.PUT(api.putWorkflowHookModelHandler, NeedAdmin(true)))
 
 	// SSE
 	r.Handle("/events", ScopeNone(), r.GET(api.eventsBroker.ServeHTTP))
+	r.Handle("/ws", ScopeNone(), r.GET(api.websocketBroker.ServeHTTP))
 
 	// Feature
 	r.Handle("/feature/clean", ScopeNone(), r.POST(api.cleanFeatureHandler, NeedToken("X-Izanami-Token", api.Config.Features.Izanami.Token)))
@@ -412,6 +506,20 @@ func (api *API) InitRouter() {
 	r.Handle("/services/heartbeat", Scope(sdk.AuthConsumerScopeService), r.POST(api.postServiceHearbeatHandler))
 	r.Handle("/services/{type}", Scope(sdk.AuthConsumerScopeService), r.GET(api.getExternalServiceHandler))
 
+	// ChatOps
+	r.Handle("/chatops/command", Scope(sdk.AuthConsumerScopeService), r.POST(api.postChatOpsCommandHandler, MaintenanceAware()))
+	r.Handle("/chatops/link/token", Scope(sdk.AuthConsumerScopeService), r.POST(api.postChatOpsLinkTokenHandler))
+
+	// SCIM provisioning, only available if a token is set
+	if api.Config.Auth.SCIM.Enabled && api.Config.Auth.SCIM.Token != "" {
+		scimToken := NeedSCIMToken()
+		r.Handle("/scim/v2/ServiceProviderConfig", ScopeNone(), r.GET(api.getSCIMServiceProviderConfigHandler, scimToken))
+		r.Handle("/scim/v2/Users", ScopeNone(), r.GET(api.getSCIMUsersHandler, scimToken), r.POST(api.postSCIMUserHandler, scimToken))
+		r.Handle("/scim/v2/Users/{id}", ScopeNone(), r.GET(api.getSCIMUserHandler, scimToken), r.PUT(api.putSCIMUserHandler, scimToken), r.PATCH(api.patchSCIMUserHandler, scimToken), r.DELETE(api.deleteSCIMUserHandler, scimToken))
+		r.Handle("/scim/v2/Groups", ScopeNone(), r.GET(api.getSCIMGroupsHandler, scimToken), r.POST(api.postSCIMGroupHandler, scimToken))
+		r.Handle("/scim/v2/Groups/{id}", ScopeNone(), r.GET(api.getSCIMGroupHandler, scimToken), r.PUT(api.putSCIMGroupHandler, scimToken), r.PATCH(api.patchSCIMGroupHandler, scimToken), r.DELETE(api.deleteSCIMGroupHandler, scimToken))
+	}
+
 	// Templates
 	r.Handle("/template", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplatesHandler), r.POST(api.postTemplateHandler))
 	r.Handle("/template/push", Scope(sdk.AuthConsumerScopeTemplate), r.POST(api.postTemplatePushHandler))
@@ -422,6 +530,8 @@ func (api *API) InitRouter() {
 	r.Handle("/template/{groupName}/{templateSlug}/bulk", Scope(sdk.AuthConsumerScopeTemplate), r.POST(api.postTemplateBulkHandler))
 	r.Handle("/template/{groupName}/{templateSlug}/bulk/{bulkID}", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplateBulkHandler))
 	r.Handle("/template/{groupName}/{templateSlug}/instance", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplateInstancesHandler))
+	r.Handle("/template/{groupName}/{templateSlug}/drift", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplateDriftHandler))
+	r.Handle("/template/{groupName}/{templateSlug}/reapply", Scope(sdk.AuthConsumerScopeTemplate), r.POST(api.postTemplateReapplyHandler))
 	r.Handle("/template/{groupName}/{templateSlug}/instance/{instanceID}", Scope(sdk.AuthConsumerScopeTemplate), r.DELETE(api.deleteTemplateInstanceHandler))
 	r.Handle("/template/{groupName}/{templateSlug}/usage", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplateUsageHandler))
 	r.Handle("/project/{key}/workflow/{permWorkflowName}/templateInstance", Scope(sdk.AuthConsumerScopeTemplate), r.GET(api.getTemplateInstanceHandler))
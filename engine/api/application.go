@@ -465,6 +465,13 @@ func (api *API) updateApplicationHandler() service.Handler {
 		app.Metadata = appPost.Metadata
 		app.RepositoryStrategy = appPost.RepositoryStrategy
 		app.RepositoryStrategy.SSHKeyContent = ""
+		// An application without repositories manager can be linked to a raw git repository
+		if app.VCSServer == "" {
+			if app.RepositoryURL != "" && appPost.RepositoryURL == "" {
+				app.RepositoryFullname = ""
+			}
+			app.RepositoryURL = appPost.RepositoryURL
+		}
 
 		tx, err := api.mustDB().Begin()
 		if err != nil {
@@ -480,6 +487,7 @@ func (api *API) updateApplicationHandler() service.Handler {
 		}
 
 		event.PublishUpdateApplication(ctx, p.Key, *app, old, getAPIConsumer(ctx))
+		setAuditLogData(ctx, old, app)
 
 		return service.WriteJSON(w, app, http.StatusOK)
 
@@ -56,6 +56,7 @@ func ParseAndImport(ctx context.Context, db gorp.SqlExecutor, cache cache.Store,
 	app.Name = eapp.Name
 	app.VCSServer = eapp.VCSServer
 	app.RepositoryFullname = eapp.RepositoryName
+	app.RepositoryURL = eapp.RepositoryURL
 	app.FromRepository = opts.FromRepository
 
 	//Compute variables
@@ -18,6 +18,7 @@ application.id,
 application.name, 
 application.project_id,
 application.repo_fullname,
+application.repository_url,
 application.repositories_manager_id,
 application.last_modified,
 application.metadata,
@@ -154,6 +155,10 @@ func Insert(db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, app *sdk.
 	if err := app.IsValid(); err != nil {
 		return sdk.WrapError(err, "application is not valid")
 	}
+	// The repository fullname of an application linked to a raw git repository is computed from its url
+	if app.IsPlainGit() {
+		app.RepositoryFullname = sdk.GitRepositoryFullnameFromURL(app.RepositoryURL)
+	}
 
 	app.ProjectID = proj.ID
 	app.ProjectKey = proj.Key
@@ -176,6 +181,10 @@ func Update(db gorp.SqlExecutor, store cache.Store, app *sdk.Application) error
 	if err := app.IsValid(); err != nil {
 		return sdk.WrapError(err, "application is not valid")
 	}
+	// The repository fullname of an application linked to a raw git repository is computed from its url
+	if app.IsPlainGit() {
+		app.RepositoryFullname = sdk.GitRepositoryFullnameFromURL(app.RepositoryURL)
+	}
 
 	app.LastModified = time.Now()
 	dbApp := dbApplication(*app)
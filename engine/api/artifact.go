@@ -27,3 +27,11 @@ func (api *API) getArtifactsStoreHandler() service.Handler {
 		return service.WriteJSON(w, s, http.StatusOK)
 	}
 }
+
+// uploadMaxBodySize returns the body size limit for routes that stream uploads to the storage backend.
+func (api *API) uploadMaxBodySize() int64 {
+	if api.Config.Artifact.MaxUploadSize > 0 {
+		return api.Config.Artifact.MaxUploadSize
+	}
+	return -1
+}
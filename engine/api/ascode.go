@@ -16,6 +16,7 @@ import (
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -220,3 +221,40 @@ func (api *API) postResyncPRAsCodeHandler() service.Handler {
 		return nil
 	}
 }
+
+// postConvertAsCodeHandler converts the CI files of another tool to CDS files, the constructs that can't be
+// converted are returned as warnings.
+func (api *API) postConvertAsCodeHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		format := vars["format"]
+
+		var req sdk.AsCodeConversion
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if len(req.Files) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "no file to convert")
+		}
+
+		var c *exportentities.Conversion
+		var err error
+		switch format {
+		case sdk.AsCodeConvertGitHub:
+			c, err = exportentities.ConvertGitHubActions(req.Files)
+		case sdk.AsCodeConvertGitLabCI:
+			c, err = exportentities.ConvertGitLabCI(req.Files)
+		default:
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid format %s", format)
+		}
+		if err != nil {
+			return err
+		}
+
+		files, err := c.Files()
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, sdk.AsCodeConversion{Files: files, Warnings: c.Warnings}, http.StatusOK)
+	}
+}
@@ -52,6 +52,11 @@ forLoop:
 				log.Error(ctx, "operation in error %s: %s", ed.Operation.UUID, ed.Operation.Error)
 				break forLoop
 			}
+			if ed.Operation.Status == sdk.OperationStatusDone && app.IsPlainGit() {
+				// Pull requests can't be created on a raw git repository, the branch is only pushed
+				log.Info(ctx, "UpdateAsCodeResult> branch %s pushed on %s, no pull request created without repositories manager", ed.Operation.Setup.Push.FromBranch, app.RepositoryURL)
+				return nil
+			}
 			if ed.Operation.Status == sdk.OperationStatusDone {
 				vcsServer := repositoriesmanager.GetProjectVCSServer(p, app.VCSServer)
 				if vcsServer == nil {
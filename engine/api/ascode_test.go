@@ -459,3 +459,51 @@ vcs_ssh_key: proj-blabla
 	assert.Equal(t, "urltomyrepo", wUpdated.FromRepository)
 
 }
+
+func Test_postConvertAsCodeHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	_, jwt := assets.InsertLambdaUser(t, db)
+
+	uri := api.Router.GetRoute("POST", api.postConvertAsCodeHandler, map[string]string{
+		"format": sdk.AsCodeConvertGitHub,
+	})
+	req := assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, sdk.AsCodeConversion{
+		Files: map[string]string{
+			".github/workflows/build.yml": "name: Build\non: push\njobs:\n  build:\n    steps:\n    - uses: actions/checkout@v2\n    - run: make\n",
+		},
+	})
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	var res sdk.AsCodeConversion
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res.Files, 2)
+	assert.Contains(t, res.Files["build.pip.yml"], "checkout: '{{.cds.workspace}}'")
+	assert.Contains(t, res.Files["build.yml"], "pipeline: build")
+	require.Len(t, res.Warnings, 1)
+
+	uri = api.Router.GetRoute("POST", api.postConvertAsCodeHandler, map[string]string{
+		"format": sdk.AsCodeConvertGitLabCI,
+	})
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, sdk.AsCodeConversion{
+		Files: map[string]string{
+			".gitlab-ci.yml": "build:\n  stage: build\n  script: make\n",
+		},
+	})
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Contains(t, res.Files, "gitlab-ci.pip.yml")
+
+	uri = api.Router.GetRoute("POST", api.postConvertAsCodeHandler, map[string]string{
+		"format": "unknown",
+	})
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, res)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 400, w.Code)
+}
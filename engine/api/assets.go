@@ -0,0 +1,472 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/actionplugin"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/plugin"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// getAssetsBundleHandler exports the binaries, plugins and default actions of this CDS in a bundle that can be
+// imported on an air-gapped installation.
+func (api *API) getAssetsBundleHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		plugins, err := plugin.LoadAll(api.mustDB())
+		if err != nil {
+			return err
+		}
+		actions, err := action.LoadAllByTypes(ctx, api.mustDB(), []string{sdk.DefaultAction}, action.LoadOptions.Default)
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cds-assets-%s.tar.gz"`, sdk.VERSION))
+		w.WriteHeader(http.StatusOK)
+
+		// Headers are sent, errors while streaming the bundle can only be logged
+		if err := api.writeAssetsBundle(ctx, w, plugins, actions); err != nil {
+			log.Error(ctx, "getAssetsBundleHandler> cannot write assets bundle: %v", err)
+		}
+		return nil
+	}
+}
+
+func (api *API) writeAssetsBundle(ctx context.Context, w io.Writer, plugins []sdk.GRPCPlugin, actions []sdk.Action) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+
+	addFile := func(name string, mode int64, size int64, r io.Reader) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: mode, Size: size, ModTime: now, Typeflag: tar.TypeReg}); err != nil {
+			return sdk.WrapError(err, "cannot write header of %s", name)
+		}
+		if _, err := io.CopyN(tw, r, size); err != nil {
+			return sdk.WrapError(err, "cannot write %s", name)
+		}
+		return nil
+	}
+
+	for _, res := range sdk.AllDownloadableResourcesWithAvailability(api.Config.Directories.Download) {
+		if res.Available == nil || !*res.Available {
+			continue
+		}
+		f, err := os.Open(path.Join(api.Config.Directories.Download, res.Filename))
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		fi, err := f.Stat()
+		if err == nil {
+			err = addFile(path.Join(sdk.AssetsBundleBinariesDirectory, res.Filename), 0755, fi.Size(), f)
+		}
+		_ = f.Close()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+
+	for _, p := range plugins {
+		p.ID = 0
+		p.IntegrationModelID = nil
+		btes, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		if err := addFile(path.Join(sdk.AssetsBundlePluginsDirectory, p.Name, sdk.AssetsBundlePluginFile), 0644, int64(len(btes)), bytes.NewReader(btes)); err != nil {
+			return err
+		}
+		for _, b := range p.Binaries {
+			f, err := api.SharedStorage.Fetch(ctx, b)
+			if err != nil {
+				return sdk.WrapError(err, "cannot fetch binary %s of plugin %s", b.Name, p.Name)
+			}
+			content, err := ioutil.ReadAll(f)
+			_ = f.Close()
+			if err != nil {
+				return sdk.WrapError(err, "cannot read binary %s of plugin %s", b.Name, p.Name)
+			}
+			if err := addFile(sdk.AssetsBundlePluginBinaryPath(p.Name, b), 0755, int64(len(content)), bytes.NewReader(content)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, a := range actions {
+		ea := exportentities.NewAction(a)
+		btes, err := exportentities.Marshal(ea, exportentities.FormatYAML)
+		if err != nil {
+			return err
+		}
+		if err := addFile(path.Join(sdk.AssetsBundleActionsDirectory, ea.Group, a.Name+".yml"), 0644, int64(len(btes)), bytes.NewReader(btes)); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(gw.Close())
+}
+
+// postAssetsBundleHandler imports a bundle of binaries, plugins and default actions.
+func (api *API) postAssetsBundleHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		dir, err := ioutil.TempDir("", "cds-assets")
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer os.RemoveAll(dir) // nolint
+
+		if err := extractAssetsBundle(r.Body, dir); err != nil {
+			return err
+		}
+
+		var report sdk.AssetsBundleReport
+		if report.Binaries, err = api.importAssetsBinaries(ctx, filepath.Join(dir, sdk.AssetsBundleBinariesDirectory)); err != nil {
+			return err
+		}
+		if report.Plugins, err = api.importAssetsPlugins(ctx, filepath.Join(dir, sdk.AssetsBundlePluginsDirectory)); err != nil {
+			return err
+		}
+		if report.Actions, err = api.importAssetsActions(ctx, filepath.Join(dir, sdk.AssetsBundleActionsDirectory)); err != nil {
+			return err
+		}
+
+		log.Info(ctx, "postAssetsBundleHandler> assets bundle imported: %d binaries, %d plugins, %d actions", len(report.Binaries), len(report.Plugins), len(report.Actions))
+		return service.WriteJSON(w, report, http.StatusOK)
+	}
+}
+
+// extractAssetsBundle extracts the regular files of a bundle in given directory.
+func extractAssetsBundle(r io.Reader, dir string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid assets bundle, a tar.gz archive is expected"))
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid assets bundle"))
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		directory, name := sdk.SplitAssetsBundlePath(hdr.Name)
+		switch directory {
+		case sdk.AssetsBundleBinariesDirectory, sdk.AssetsBundlePluginsDirectory, sdk.AssetsBundleActionsDirectory:
+		default:
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid file %s in assets bundle", hdr.Name)
+		}
+		if name == "" || strings.HasPrefix(name, "..") || path.IsAbs(name) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid file %s in assets bundle", hdr.Name)
+		}
+
+		target := filepath.Join(dir, directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return sdk.WithStack(err)
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		_, err = io.Copy(f, tr)
+		_ = f.Close()
+		if err != nil {
+			return sdk.WrapError(err, "cannot extract %s", hdr.Name)
+		}
+	}
+	return nil
+}
+
+// importAssetsBinaries copies the binaries of a bundle into the download directory.
+func (api *API) importAssetsBinaries(ctx context.Context, dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	var names []string
+	for _, fi := range files {
+		if fi.IsDir() || !sdk.IsDownloadableResourceFilename(fi.Name()) {
+			log.Warning(ctx, "importAssetsBinaries> ignoring unknown binary %s", fi.Name())
+			continue
+		}
+		if err := copyFile(filepath.Join(dir, fi.Name()), filepath.Join(api.Config.Directories.Download, fi.Name()), 0755); err != nil {
+			return nil, err
+		}
+		names = append(names, fi.Name())
+	}
+	return names, nil
+}
+
+// copyFile copies src file to dst, dst is replaced only once the copy is complete.
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer in.Close() // nolint
+
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return sdk.WrapError(err, "cannot copy %s", src)
+	}
+	if err := out.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(os.Rename(tmp, dst))
+}
+
+// importAssetsPlugins inserts or updates the plugins of a bundle with their binaries.
+func (api *API) importAssetsPlugins(ctx context.Context, dir string) ([]string, error) {
+	pluginDirs, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	var names []string
+	for _, pluginDir := range pluginDirs {
+		if !pluginDir.IsDir() {
+			continue
+		}
+		btes, err := ioutil.ReadFile(filepath.Join(dir, pluginDir.Name(), sdk.AssetsBundlePluginFile))
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing %s for plugin %s", sdk.AssetsBundlePluginFile, pluginDir.Name())
+		}
+		var p sdk.GRPCPlugin
+		if err := json.Unmarshal(btes, &p); err != nil {
+			return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid plugin %s", pluginDir.Name()))
+		}
+		if p.Name != pluginDir.Name() {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid plugin name %s in directory %s", p.Name, pluginDir.Name())
+		}
+
+		if err := api.importAssetsPlugin(ctx, filepath.Dir(dir), p); err != nil {
+			return nil, err
+		}
+		names = append(names, p.Name)
+	}
+	return names, nil
+}
+
+func (api *API) importAssetsPlugin(ctx context.Context, bundleDir string, p sdk.GRPCPlugin) error {
+	binaries := p.Binaries
+	p.ID = 0
+	p.Binaries = nil
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WrapError(err, "cannot start transaction")
+	}
+	defer tx.Rollback() //nolint
+
+	if p.Integration != "" {
+		integrationModel, err := integration.LoadModelByName(tx, p.Integration, false)
+		if err != nil {
+			return err
+		}
+		p.IntegrationModelID = &integrationModel.ID
+	}
+
+	old, err := plugin.LoadByName(tx, p.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if old != nil {
+		p.ID = old.ID
+		p.Binaries = old.Binaries
+	}
+
+	if p.Type == sdk.GRPCPluginAction {
+		if err := upsertGRPCPluginAction(ctx, tx, &p); err != nil {
+			return err
+		}
+	}
+
+	if old != nil {
+		err = plugin.Update(tx, &p)
+	} else {
+		err = plugin.Insert(tx, &p)
+	}
+	if err != nil {
+		return sdk.WrapError(err, "unable to save plugin %s", p.Name)
+	}
+
+	for i := range binaries {
+		b := binaries[i]
+		f, err := os.Open(filepath.Join(bundleDir, filepath.FromSlash(sdk.AssetsBundlePluginBinaryPath(p.Name, b))))
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing binary %s for %s/%s of plugin %s", b.Name, b.OS, b.Arch, p.Name)
+		}
+		b.ObjectPath = ""
+		b.TempURL = ""
+		b.PluginName = p.Name
+		if p.GetBinary(b.OS, b.Arch) == nil {
+			err = plugin.AddBinary(ctx, tx, api.SharedStorage, &p, &b, f)
+		} else {
+			err = plugin.UpdateBinary(ctx, tx, api.SharedStorage, &p, &b, f)
+		}
+		_ = f.Close()
+		if err != nil {
+			return sdk.WrapError(err, "unable to save binary %s/%s of plugin %s", b.OS, b.Arch, p.Name)
+		}
+	}
+
+	return sdk.WithStack(tx.Commit())
+}
+
+// upsertGRPCPluginAction inserts or updates the action of a plugin of type action.
+func upsertGRPCPluginAction(ctx context.Context, tx gorp.SqlExecutor, p *sdk.GRPCPlugin) error {
+	old, err := action.LoadByTypesAndName(ctx, tx, []string{sdk.PluginAction}, p.Name, action.LoadOptions.Default)
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		if _, err := actionplugin.UpdateGRPCPlugin(ctx, tx, p, p.Parameters); err != nil {
+			return sdk.WrapError(err, "error while updating action %s in database", p.Name)
+		}
+		return nil
+	}
+	if _, err := actionplugin.InsertWithGRPCPlugin(tx, p, p.Parameters); err != nil {
+		return sdk.WrapError(err, "error while inserting action %s in database", p.Name)
+	}
+	return nil
+}
+
+// importAssetsActions inserts or updates the default actions of a bundle, an action is imported after the actions
+// of the bundle it uses as children.
+func (api *API) importAssetsActions(ctx context.Context, dir string) ([]string, error) {
+	eas := make(map[string]exportentities.Action)
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		btes, err := ioutil.ReadFile(p)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		var ea exportentities.Action
+		if err := yaml.Unmarshal(btes, &ea); err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid action %s", fi.Name()))
+		}
+		eas[actionKey(ea.Group, ea.Name)] = ea
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	order := sortAssetsActions(eas)
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	names := make([]string, 0, len(order))
+	for _, k := range order {
+		if _, _, err := importAction(ctx, tx, eas[k]); err != nil {
+			return nil, sdk.WrapError(err, "cannot import action %s", k)
+		}
+		names = append(names, k)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return names, nil
+}
+
+func actionKey(groupName, name string) string {
+	if groupName == "" {
+		groupName = sdk.SharedInfraGroupName
+	}
+	return groupName + "/" + name
+}
+
+// sortAssetsActions returns the keys of given actions, children first.
+func sortAssetsActions(eas map[string]exportentities.Action) []string {
+	keys := make([]string, 0, len(eas))
+	for k := range eas {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var order []string
+	visited := make(map[string]bool, len(eas))
+	var visit func(k string)
+	visit = func(k string) {
+		if visited[k] {
+			return
+		}
+		visited[k] = true
+		for _, step := range eas[k].Steps {
+			for _, child := range childrenKeys(step) {
+				if _, ok := eas[child]; ok {
+					visit(child)
+				}
+			}
+		}
+		order = append(order, k)
+	}
+	for _, k := range keys {
+		visit(k)
+	}
+	return order
+}
+
+// childrenKeys returns the keys of the actions used by a custom step, an action without group is a shared.infra
+// action.
+func childrenKeys(step exportentities.Step) []string {
+	keys := make([]string, 0, len(step.StepCustom))
+	for name := range step.StepCustom {
+		if strings.Contains(name, "/") {
+			keys = append(keys, name)
+		} else {
+			keys = append(keys, actionKey("", name))
+		}
+	}
+	return keys
+}
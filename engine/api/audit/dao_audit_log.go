@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// InsertLog inserts an audit log.
+func InsertLog(db gorp.SqlExecutor, a *sdk.AuditLog) error {
+	dbA := dbAuditLog(*a)
+	if err := gorpmapping.Insert(db, &dbA); err != nil {
+		return sdk.WrapError(err, "unable to insert audit log")
+	}
+	*a = sdk.AuditLog(dbA)
+	return nil
+}
+
+// LoadLogs returns audit logs that match given filter, most recent first.
+func LoadLogs(ctx context.Context, db gorp.SqlExecutor, filter sdk.AuditLogFilter) ([]sdk.AuditLog, error) {
+	var conds []string
+	var args []interface{}
+	addCond := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.EntityType != "" {
+		addCond("entity_type = $%d", filter.EntityType)
+	}
+	if filter.ProjectKey != "" {
+		addCond("project_key = $%d", filter.ProjectKey)
+	}
+	if filter.Username != "" {
+		addCond("username = $%d", filter.Username)
+	}
+	if !filter.Since.IsZero() {
+		addCond("created >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		addCond("created <= $%d", filter.Until)
+	}
+
+	query := "SELECT * FROM audit_log"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY created DESC, id DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+
+	var dbLogs []dbAuditLog
+	if err := gorpmapping.GetAll(ctx, db, gorpmapping.NewQuery(query).Args(args...), &dbLogs); err != nil {
+		return nil, sdk.WrapError(err, "unable to load audit logs")
+	}
+
+	logs := make([]sdk.AuditLog, len(dbLogs))
+	for i := range dbLogs {
+		logs[i] = sdk.AuditLog(dbLogs[i])
+	}
+	return logs, nil
+}
+
+// DeleteLogsBefore removes audit logs older than given date.
+func DeleteLogsBefore(db gorp.SqlExecutor, t time.Time) (int64, error) {
+	res, err := db.Exec("DELETE FROM audit_log WHERE created < $1", t)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to delete audit logs")
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// PurgeLogs must be run as a goroutine, it removes audit logs older than given retention in days.
+func PurgeLogs(ctx context.Context, DBFunc func() *gorp.DbMap, retention int) {
+	if retention <= 0 {
+		return
+	}
+
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "PurgeLogs> Exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			n, err := DeleteLogsBefore(DBFunc(), time.Now().AddDate(0, 0, -retention))
+			if err != nil {
+				log.Error(ctx, "PurgeLogs> %v", err)
+				continue
+			}
+			log.Debug("PurgeLogs> %d audit logs deleted", n)
+		}
+	}
+}
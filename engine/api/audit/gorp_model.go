@@ -0,0 +1,12 @@
+package audit
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbAuditLog sdk.AuditLog
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbAuditLog{}, "audit_log", true, "id"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/authentication"
@@ -145,6 +146,12 @@ func (api *API) postAuthSigninHandler() service.Handler {
 		if consumer == nil {
 			var u *sdk.AuthentifiedUser
 
+			// Some drivers can't guarantee that the email belongs to the user, an existing user should not be linked with it
+			emailTrusted := true
+			if x, ok := driver.(sdk.AuthDriverWithEmailTrust); ok {
+				emailTrusted = x.IsEmailTrusted()
+			}
+
 			currentConsumer := getAPIConsumer(ctx)
 			if currentConsumer != nil {
 				// If no consumer already exists for given request, but there is a current session
@@ -181,7 +188,7 @@ func (api *API) postAuthSigninHandler() service.Handler {
 					// If the user exists with the same email address than in the userInfo,
 					// we will create a new consumer and continue the signin
 					// else we raise an error
-					if u.GetEmail() != userInfo.Email {
+					if !emailTrusted || u.GetEmail() != userInfo.Email {
 						return sdk.NewErrorFrom(sdk.ErrForbidden, "a user already exists for username %s", userInfo.Username)
 					}
 				} else {
@@ -191,6 +198,9 @@ func (api *API) postAuthSigninHandler() service.Handler {
 						return err
 					}
 					if contact != nil {
+						if !emailTrusted {
+							return sdk.NewErrorFrom(sdk.ErrForbidden, "a user already exists for email %s", userInfo.Email)
+						}
 						// A user already exists with an other username but the same email address
 						u, err = user.LoadByID(ctx, tx, contact.UserID, user.LoadOptions.WithContacts)
 						if err != nil {
@@ -260,6 +270,15 @@ func (api *API) postAuthSigninHandler() service.Handler {
 			}
 		}
 
+		// Synchronize user's groups with the ones given by the auth driver
+		if x, ok := driver.(sdk.AuthDriverWithGroupSync); ok {
+			if prefix, enabled := x.GetGroupSyncPrefix(); enabled {
+				if err := syncUserGroups(ctx, tx, consumer.AuthentifiedUserID, userInfo.Groups, prefix); err != nil {
+					return err
+				}
+			}
+		}
+
 		// Generate a new session for consumer
 		session, err := authentication.NewSession(ctx, tx, consumer, driver.GetSessionDuration(), userInfo.MFA)
 		if err != nil {
@@ -367,3 +386,31 @@ func (api *API) getAuthMe() service.Handler {
 		}, http.StatusOK)
 	}
 }
+
+// syncUserGroups updates user's groups from given names then updates user's consumers groups.
+func syncUserGroups(ctx context.Context, tx gorp.SqlExecutor, userID string, groupNames []string, prefix string) error {
+	added, removed, err := group.SyncUserGroups(ctx, tx, userID, groupNames, prefix)
+	if err != nil {
+		return err
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	u, err := user.LoadByID(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	for i := range added {
+		if err := authentication.ConsumerRestoreInvalidatedGroupForUser(ctx, tx, added[i].ID, u.ID); err != nil {
+			return err
+		}
+	}
+	for i := range removed {
+		if err := authentication.ConsumerInvalidateGroupForUser(ctx, tx, &removed[i], u); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
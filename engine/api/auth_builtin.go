@@ -47,6 +47,10 @@ func (api *API) postAuthBuiltinSigninHandler() service.Handler {
 			return err
 		}
 
+		if consumer.Expired() {
+			return sdk.NewErrorFrom(sdk.ErrUnauthorized, "consumer %s is expired", consumer.Name)
+		}
+
 		// Check the Token validity againts the IAT attribute
 		if _, err := builtin.CheckSigninConsumerTokenIssuedAt(req["token"], consumer.IssuedAt); err != nil {
 			return err
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/authentication/builtin"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/service"
 )
@@ -176,6 +177,123 @@ func (api *API) postConsumerRegenByUserHandler() service.Handler {
 	}
 }
 
+func (api *API) getConsumersByProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		key := vars[permProjectKey]
+
+		// Only the administrators of the project can see its consumers
+		if err := api.checkProjectPermissions(ctx, key, sdk.PermissionReadWriteExecute, vars); err != nil {
+			return err
+		}
+
+		cs, err := authentication.LoadConsumersByProjectKey(ctx, api.mustDB(), key,
+			authentication.LoadConsumerOptions.Default, authentication.LoadConsumerOptions.WithAuthentifiedUser)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, cs, http.StatusOK)
+	}
+}
+
+func (api *API) postConsumerByProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		key := vars[permProjectKey]
+
+		consumer := getAPIConsumer(ctx)
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, project.LoadOptions.WithGroups)
+		if err != nil {
+			return err
+		}
+
+		// Check request data
+		var reqData sdk.AuthConsumer
+		if err := service.UnmarshalBody(r, &reqData); err != nil {
+			return err
+		}
+		if err := reqData.IsValid(api.Router.scopeDetails); err != nil {
+			return err
+		}
+
+		// Groups of the consumer should be linked to the project, if no group is given all the project's groups
+		// of the current user are used. A user can't give a group that it is not a member of.
+		projectGroupIDs := make([]int64, 0, len(proj.ProjectGroups))
+		for _, gp := range proj.ProjectGroups {
+			projectGroupIDs = append(projectGroupIDs, gp.Group.ID)
+		}
+		userGroupIDs := consumer.GetGroupIDs()
+		groupIDs := reqData.GroupIDs
+		if len(groupIDs) == 0 {
+			for _, id := range projectGroupIDs {
+				if isAdmin(ctx) || sdk.IsInInt64Array(id, userGroupIDs) {
+					groupIDs = append(groupIDs, id)
+				}
+			}
+		}
+		for _, id := range groupIDs {
+			if !sdk.IsInInt64Array(id, projectGroupIDs) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "group %d is not linked to project %s", id, key)
+			}
+			if !isAdmin(ctx) && !sdk.IsInInt64Array(id, userGroupIDs) {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "a user can't give a group that it is not a member of")
+			}
+		}
+
+		// Create the new built in consumer restricted to the project
+		newConsumer, token, err := builtin.NewProjectConsumer(ctx, api.mustDB(), key, reqData.Name, reqData.Description,
+			reqData.ExpireAt, consumer, groupIDs, reqData.ScopeDetails)
+		if err != nil {
+			return err
+		}
+		if err := authentication.LoadConsumerOptions.Default(ctx, api.mustDB(), newConsumer); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.AuthConsumerCreateResponse{
+			Token:    token,
+			Consumer: newConsumer,
+		}, http.StatusCreated)
+	}
+}
+
+func (api *API) deleteConsumerByProjectHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		key := vars[permProjectKey]
+		consumerID := vars["consumerID"]
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		consumer, err := authentication.LoadConsumerByID(ctx, tx, consumerID)
+		if err != nil {
+			return err
+		}
+		if consumer.ProjectKey != key {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		if err := authentication.DeleteConsumerByID(tx, consumer.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
 func (api *API) getSessionsByUserHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
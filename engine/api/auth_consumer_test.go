@@ -304,3 +304,129 @@ func Test_deleteSessionByUserHandler(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(ss))
 }
+
+func Test_postConsumerByProjectHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	proj1 := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	proj2 := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	g1, g2 := proj1.ProjectGroups[0].Group, proj2.ProjectGroups[0].Group
+	_, jwtRaw := assets.InsertLambdaUser(t, db, &g1, &g2)
+
+	expireAt := time.Now().Add(time.Hour)
+	uri := api.Router.GetRoute(http.MethodPost, api.postConsumerByProjectHandler, map[string]string{
+		"permProjectKey": proj1.Key,
+	})
+	require.NotEmpty(t, uri)
+	req := assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodPost, uri, sdk.AuthConsumer{
+		Name:         sdk.RandomString(10),
+		ScopeDetails: sdk.NewAuthConsumerScopeDetails(sdk.AuthConsumerScopeProject),
+		ExpireAt:     &expireAt,
+	})
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 201, rec.Code)
+
+	var created sdk.AuthConsumerCreateResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.Token)
+	assert.Equal(t, proj1.Key, created.Consumer.ProjectKey)
+	assert.Equal(t, []int64{g1.ID}, []int64(created.Consumer.GroupIDs))
+	require.NotNil(t, created.Consumer.ExpireAt)
+
+	// A group that is not linked to the project can't be given
+	req = assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodPost, uri, sdk.AuthConsumer{
+		Name:         sdk.RandomString(10),
+		GroupIDs:     []int64{g2.ID},
+		ScopeDetails: sdk.NewAuthConsumerScopeDetails(sdk.AuthConsumerScopeProject),
+	})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 400, rec.Code)
+
+	uri = api.Router.GetRoute(http.MethodGet, api.getConsumersByProjectHandler, map[string]string{
+		"permProjectKey": proj1.Key,
+	})
+	require.NotEmpty(t, uri)
+	req = assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodGet, uri, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	var cs []sdk.AuthConsumer
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cs))
+	require.Len(t, cs, 1)
+	assert.Equal(t, created.Consumer.ID, cs[0].ID)
+
+	uri = api.Router.GetRoute(http.MethodDelete, api.deleteConsumerByProjectHandler, map[string]string{
+		"permProjectKey": proj2.Key,
+		"consumerID":     created.Consumer.ID,
+	})
+	require.NotEmpty(t, uri)
+	req = assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodDelete, uri, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 404, rec.Code)
+
+	uri = api.Router.GetRoute(http.MethodDelete, api.deleteConsumerByProjectHandler, map[string]string{
+		"permProjectKey": proj1.Key,
+		"consumerID":     created.Consumer.ID,
+	})
+	require.NotEmpty(t, uri)
+	req = assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodDelete, uri, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	cs, err := authentication.LoadConsumersByProjectKey(context.TODO(), db, proj1.Key)
+	require.NoError(t, err)
+	assert.Len(t, cs, 0)
+}
+
+func Test_projectConsumerRestrictions(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	proj1 := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	proj2 := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	g1, g2 := proj1.ProjectGroups[0].Group, proj2.ProjectGroups[0].Group
+	u, _ := assets.InsertLambdaUser(t, db, &g1, &g2)
+	localConsumer, err := authentication.LoadConsumerByTypeAndUserID(context.TODO(), db, sdk.ConsumerLocal, u.ID,
+		authentication.LoadConsumerOptions.WithAuthentifiedUser)
+	require.NoError(t, err)
+
+	expireAt := time.Now().Add(time.Hour)
+	consumer, _, err := builtin.NewProjectConsumer(context.TODO(), db, proj1.Key, sdk.RandomString(10), "", &expireAt,
+		localConsumer, []int64{g1.ID, g2.ID}, sdk.NewAuthConsumerScopeDetails(sdk.AuthConsumerScopeProject))
+	require.NoError(t, err)
+	session, err := authentication.NewSession(context.TODO(), db, consumer, 5*time.Minute, false)
+	require.NoError(t, err)
+	jwtRaw, err := authentication.NewSessionJWT(session)
+	require.NoError(t, err)
+
+	// The consumer can be used on its project only
+	for key, code := range map[string]int{proj1.Key: 200, proj2.Key: 403} {
+		uri := api.Router.GetRoute(http.MethodGet, api.getVariablesInProjectHandler, map[string]string{
+			"permProjectKey": key,
+		})
+		require.NotEmpty(t, uri)
+		req := assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodGet, uri, nil)
+		rec := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(rec, req)
+		require.Equal(t, code, rec.Code)
+	}
+
+	// An expired consumer is refused
+	expireAt = time.Now().Add(-time.Minute)
+	consumer.ExpireAt = &expireAt
+	require.NoError(t, authentication.UpdateConsumer(context.TODO(), db, consumer))
+
+	uri := api.Router.GetRoute(http.MethodGet, api.getVariablesInProjectHandler, map[string]string{
+		"permProjectKey": proj1.Key,
+	})
+	req := assets.NewJWTAuthentifiedRequest(t, jwtRaw, http.MethodGet, uri, nil)
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 401, rec.Code)
+}
@@ -67,7 +67,39 @@ func (d AuthDriver) CheckSigninRequest(req sdk.AuthConsumerSigninRequest) error
 // The parent consumer should be given with all data loaded including the authentified user.
 func NewConsumer(ctx context.Context, db gorp.SqlExecutor, name, description string, parentConsumer *sdk.AuthConsumer,
 	groupIDs []int64, scopes sdk.AuthConsumerScopeDetails) (*sdk.AuthConsumer, string, error) {
-	if name == "" {
+	return newConsumer(ctx, db, sdk.AuthConsumer{
+		Name:         name,
+		Description:  description,
+		GroupIDs:     groupIDs,
+		ScopeDetails: scopes,
+	}, parentConsumer)
+}
+
+// NewProjectConsumer returns a new builtin consumer that can only be used on routes of given project.
+// If an expiration date is given, the consumer will be refused once expired.
+func NewProjectConsumer(ctx context.Context, db gorp.SqlExecutor, projectKey, name, description string, expireAt *time.Time,
+	parentConsumer *sdk.AuthConsumer, groupIDs []int64, scopes sdk.AuthConsumerScopeDetails) (*sdk.AuthConsumer, string, error) {
+	if projectKey == "" {
+		return nil, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "project key should be given to create a project consumer")
+	}
+	if len(groupIDs) == 0 {
+		return nil, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "at least one group should be given to create a project consumer")
+	}
+	if expireAt != nil && expireAt.Before(time.Now()) {
+		return nil, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "expiration date of the consumer should be in the future")
+	}
+	return newConsumer(ctx, db, sdk.AuthConsumer{
+		Name:         name,
+		Description:  description,
+		GroupIDs:     groupIDs,
+		ScopeDetails: scopes,
+		ProjectKey:   projectKey,
+		ExpireAt:     expireAt,
+	}, parentConsumer)
+}
+
+func newConsumer(ctx context.Context, db gorp.SqlExecutor, c sdk.AuthConsumer, parentConsumer *sdk.AuthConsumer) (*sdk.AuthConsumer, string, error) {
+	if c.Name == "" {
 		return nil, "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "name should be given to create a built in consumer")
 	}
 
@@ -78,30 +110,29 @@ func NewConsumer(ctx context.Context, db gorp.SqlExecutor, name, description str
 		// Only if parentGroupIDs aren't empty. Because empty means all groups access
 		if len(parentConsumer.GroupIDs) > 0 {
 			parentGroupIDs := parentConsumer.GetGroupIDs()
-			for i := range groupIDs {
-				if !sdk.IsInInt64Array(groupIDs[i], parentGroupIDs) {
-					return nil, "", sdk.WrapError(sdk.ErrWrongRequest, "invalid given group id %d", groupIDs[i])
+			for i := range c.GroupIDs {
+				if !sdk.IsInInt64Array(c.GroupIDs[i], parentGroupIDs) {
+					return nil, "", sdk.WrapError(sdk.ErrWrongRequest, "invalid given group id %d", c.GroupIDs[i])
 				}
 			}
 		}
 	}
 
+	// A consumer restricted to a project can't create a child for an other project
+	if parentConsumer.ProjectKey != "" && c.ProjectKey != parentConsumer.ProjectKey {
+		return nil, "", sdk.NewErrorFrom(sdk.ErrForbidden, "consumer is restricted to project %s", parentConsumer.ProjectKey)
+	}
+
 	// Check that given scopes are valid and if they match parent scopes
-	if err := checkNewConsumerScopes(parentConsumer.ScopeDetails, scopes); err != nil {
+	if err := checkNewConsumerScopes(parentConsumer.ScopeDetails, c.ScopeDetails); err != nil {
 		return nil, "", err
 	}
 
-	c := sdk.AuthConsumer{
-		Name:               name,
-		Description:        description,
-		ParentID:           &parentConsumer.ID,
-		AuthentifiedUserID: parentConsumer.AuthentifiedUserID,
-		Type:               sdk.ConsumerBuiltin,
-		Data:               map[string]string{},
-		GroupIDs:           groupIDs,
-		ScopeDetails:       scopes,
-		IssuedAt:           time.Now(),
-	}
+	c.ParentID = &parentConsumer.ID
+	c.AuthentifiedUserID = parentConsumer.AuthentifiedUserID
+	c.Type = sdk.ConsumerBuiltin
+	c.Data = map[string]string{}
+	c.IssuedAt = time.Now()
 
 	if err := authentication.InsertConsumer(ctx, db, &c); err != nil {
 		return nil, "", err
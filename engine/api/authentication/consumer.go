@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/go-gorp/gorp"
@@ -9,13 +10,18 @@ import (
 	"github.com/ovh/cds/sdk"
 )
 
-func NewConsumerWorker(ctx context.Context, db gorp.SqlExecutor, name string, hatcherySrv *sdk.Service, hatcheryConsumer *sdk.AuthConsumer, groupIDs []int64) (*sdk.AuthConsumer, error) {
+func NewConsumerWorker(ctx context.Context, db gorp.SqlExecutor, name string, hatcherySrv *sdk.Service, hatcheryConsumer *sdk.AuthConsumer, groupIDs []int64, jobRunID int64) (*sdk.AuthConsumer, error) {
+	data := map[string]string{}
+	if jobRunID != 0 {
+		data[sdk.AuthConsumerDataWorkerJobRunID] = strconv.FormatInt(jobRunID, 10)
+	}
+
 	c := sdk.AuthConsumer{
 		Name:               name,
 		AuthentifiedUserID: hatcheryConsumer.AuthentifiedUserID,
 		ParentID:           &hatcheryConsumer.ID,
 		Type:               sdk.ConsumerBuiltin,
-		Data:               map[string]string{},
+		Data:               data,
 		GroupIDs:           groupIDs,
 		ScopeDetails: sdk.NewAuthConsumerScopeDetails(
 			sdk.AuthConsumerScopeWorker,
@@ -267,3 +273,67 @@ func ConsumerRestoreInvalidatedGroupsForUser(ctx context.Context, db gorp.SqlExe
 
 	return nil
 }
+
+// ConsumerDisableForUser disables all user's consumers that are still enabled and set warning, this is used when
+// a user is deprovisioned.
+func ConsumerDisableForUser(ctx context.Context, db gorp.SqlExecutor, userID string) error {
+	// Load all consumers for the user
+	cs, err := LoadConsumersByUserID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	for i := range cs {
+		if cs[i].Disabled {
+			continue
+		}
+
+		cs[i].Disabled = true
+		cs[i].Warnings = append(cs[i].Warnings, sdk.NewConsumerWarningUserDeprovisioned())
+
+		if err := UpdateConsumer(ctx, db, &cs[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ConsumerEnableForUser re-enables user's consumers that were disabled by ConsumerDisableForUser and remove warning.
+func ConsumerEnableForUser(ctx context.Context, db gorp.SqlExecutor, userID string) error {
+	// Load all consumers for the user
+	cs, err := LoadConsumersByUserID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+	for i := range cs {
+		if !IsConsumerDeprovisioned(cs[i]) {
+			continue
+		}
+
+		cs[i].Disabled = false
+
+		filteredWarnings := make(sdk.AuthConsumerWarnings, 0, len(cs[i].Warnings))
+		for _, w := range cs[i].Warnings {
+			if w.Type != sdk.WarningUserDeprovisioned {
+				filteredWarnings = append(filteredWarnings, w)
+			}
+		}
+		cs[i].Warnings = filteredWarnings
+
+		if err := UpdateConsumer(ctx, db, &cs[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsConsumerDeprovisioned returns true if the consumer was disabled by ConsumerDisableForUser.
+func IsConsumerDeprovisioned(c sdk.AuthConsumer) bool {
+	for _, w := range c.Warnings {
+		if w.Type == sdk.WarningUserDeprovisioned {
+			return true
+		}
+	}
+	return false
+}
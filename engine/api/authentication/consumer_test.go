@@ -503,3 +503,52 @@ func TestConsumerRestoreInvalidatedGroupsForUser(t *testing.T) {
 	require.Len(t, res.InvalidGroupIDs, 0)
 	require.Len(t, res.Warnings, 0)
 }
+
+// Given an enabled and a disabled consumer, deprovisioning the user should only disable the enabled one and re-enabling the
+// user should restore it.
+func TestConsumerDisableForUser(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	assets.DeleteConsumers(t, db)
+
+	u := sdk.AuthentifiedUser{
+		Username: sdk.RandomString(10),
+	}
+	require.NoError(t, user.Insert(context.TODO(), db, &u))
+
+	c1 := sdk.AuthConsumer{
+		Name:               sdk.RandomString(10),
+		Type:               sdk.ConsumerLocal,
+		AuthentifiedUserID: u.ID,
+		IssuedAt:           time.Now(),
+	}
+	require.NoError(t, authentication.InsertConsumer(context.TODO(), db, &c1))
+	c2 := sdk.AuthConsumer{
+		Name:               sdk.RandomString(10),
+		Type:               sdk.ConsumerBuiltin,
+		AuthentifiedUserID: u.ID,
+		IssuedAt:           time.Now(),
+		Disabled:           true,
+		Warnings:           sdk.AuthConsumerWarnings{sdk.NewConsumerWarningLastGroupRemoved()},
+	}
+	require.NoError(t, authentication.InsertConsumer(context.TODO(), db, &c2))
+
+	require.NoError(t, authentication.ConsumerDisableForUser(context.TODO(), db, u.ID))
+	res1, err := authentication.LoadConsumerByID(context.TODO(), db, c1.ID)
+	require.NoError(t, err)
+	assert.True(t, res1.Disabled)
+	assert.True(t, authentication.IsConsumerDeprovisioned(*res1))
+	res2, err := authentication.LoadConsumerByID(context.TODO(), db, c2.ID)
+	require.NoError(t, err)
+	assert.False(t, authentication.IsConsumerDeprovisioned(*res2))
+
+	require.NoError(t, authentication.ConsumerEnableForUser(context.TODO(), db, u.ID))
+	res1, err = authentication.LoadConsumerByID(context.TODO(), db, c1.ID)
+	require.NoError(t, err)
+	assert.False(t, res1.Disabled)
+	assert.Len(t, res1.Warnings, 0)
+	res2, err = authentication.LoadConsumerByID(context.TODO(), db, c2.ID)
+	require.NoError(t, err)
+	assert.True(t, res2.Disabled, "consumer disabled for another reason should not be enabled")
+}
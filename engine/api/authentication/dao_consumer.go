@@ -91,6 +91,12 @@ func LoadConsumersByGroupID(ctx context.Context, db gorp.SqlExecutor, groupID in
 	return getConsumers(ctx, db, query, opts...)
 }
 
+// LoadConsumersByProjectKey returns all consumers from database that are restricted to given project.
+func LoadConsumersByProjectKey(ctx context.Context, db gorp.SqlExecutor, projectKey string, opts ...LoadConsumerOptionFunc) (sdk.AuthConsumers, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer WHERE project_key = $1 ORDER BY created ASC").Args(projectKey)
+	return getConsumers(ctx, db, query, opts...)
+}
+
 // LoadConsumerByID returns an auth consumer from database.
 func LoadConsumerByID(ctx context.Context, db gorp.SqlExecutor, id string, opts ...LoadConsumerOptionFunc) (*sdk.AuthConsumer, error) {
 	query := gorpmapping.NewQuery("SELECT * FROM auth_consumer WHERE id = $1").Args(id)
@@ -138,3 +144,9 @@ func DeleteConsumerByID(db gorp.SqlExecutor, id string) error {
 	_, err := db.Exec("DELETE FROM auth_consumer WHERE id = $1", id)
 	return sdk.WrapError(err, "unable to delete auth consumer with id %s", id)
 }
+
+// DeleteConsumersByProjectKey removes all auth consumers in database restricted to given project.
+func DeleteConsumersByProjectKey(db gorp.SqlExecutor, projectKey string) error {
+	_, err := db.Exec("DELETE FROM auth_consumer WHERE project_key = $1", projectKey)
+	return sdk.WrapError(err, "unable to delete auth consumers for project %s", projectKey)
+}
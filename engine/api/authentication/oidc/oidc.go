@@ -0,0 +1,348 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	_ sdk.AuthDriverWithRedirect         = new(authDriver)
+	_ sdk.AuthDriverWithSigninStateToken = new(authDriver)
+	_ sdk.AuthDriverWithGroupSync        = new(authDriver)
+	_ sdk.AuthDriverWithEmailTrust       = new(authDriver)
+)
+
+// Minimum delay between two loads of the provider's keys when an id token is signed with an unknown key.
+const keysRefreshInterval = time.Minute
+
+// Config for the OpenID Connect driver.
+type Config struct {
+	// URL of the provider used for discovery (/.well-known/openid-configuration is added to it)
+	URL          string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Claims       ClaimsMapping
+	// If set, user's groups are synchronized from the groups claim for CDS groups starting with GroupsPrefix
+	GroupsSync   bool
+	GroupsPrefix string
+	// If set, an existing CDS user with the same verified email will be linked to the OpenID Connect account
+	TrustEmail bool
+}
+
+// ClaimsMapping describes which ID token claims are used to fill CDS user info.
+type ClaimsMapping struct {
+	Username string
+	Fullname string
+	Email    string
+	Groups   string
+}
+
+// NewDriver returns a new OpenID Connect auth driver for given config.
+func NewDriver(signupDisabled bool, cdsURL string, cfg Config) sdk.AuthDriver {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	if cfg.Claims.Username == "" {
+		cfg.Claims.Username = "preferred_username"
+	}
+	if cfg.Claims.Fullname == "" {
+		cfg.Claims.Fullname = "name"
+	}
+	if cfg.Claims.Email == "" {
+		cfg.Claims.Email = "email"
+	}
+	if cfg.Claims.Groups == "" {
+		cfg.Claims.Groups = "groups"
+	}
+	return &authDriver{
+		signupDisabled: signupDisabled,
+		cdsURL:         cdsURL,
+		config:         cfg,
+	}
+}
+
+type authDriver struct {
+	signupDisabled bool
+	cdsURL         string
+	config         Config
+
+	mutex         sync.Mutex
+	discovery     *providerDiscovery
+	keys          *jose.JSONWebKeySet
+	keysFetchedAt time.Time
+}
+
+type providerDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+func (d *authDriver) GetManifest() sdk.AuthDriverManifest {
+	return sdk.AuthDriverManifest{
+		Type:           sdk.ConsumerOIDC,
+		SignupDisabled: d.signupDisabled,
+	}
+}
+
+func (d *authDriver) GetGroupSyncPrefix() (string, bool) {
+	return d.config.GroupsPrefix, d.config.GroupsSync
+}
+
+func (d *authDriver) IsEmailTrusted() bool {
+	return d.config.TrustEmail
+}
+
+func (d *authDriver) GetSigninURI(signinState sdk.AuthSigninConsumerToken) (sdk.AuthDriverSigningRedirect, error) {
+	// Generate a new state value for the auth signin request
+	jws, err := authentication.NewDefaultSigninStateToken(signinState.Origin,
+		signinState.RedirectURI, signinState.IsFirstConnection)
+	if err != nil {
+		return sdk.AuthDriverSigningRedirect{}, err
+	}
+
+	discovery, err := d.getDiscovery()
+	if err != nil {
+		return sdk.AuthDriverSigningRedirect{}, err
+	}
+
+	var result = sdk.AuthDriverSigningRedirect{
+		Method: http.MethodGet,
+		URL: fmt.Sprintf("%s?client_id=%s&response_type=code&scope=%s&state=%s&redirect_uri=%s", discovery.AuthorizationEndpoint,
+			url.QueryEscape(d.config.ClientID), url.QueryEscape(strings.Join(d.config.Scopes, " ")), jws, url.QueryEscape(d.redirectURI())),
+	}
+
+	return result, nil
+}
+
+func (d *authDriver) GetSessionDuration() time.Duration {
+	return time.Hour * 24
+}
+
+func (d *authDriver) CheckSigninRequest(req sdk.AuthConsumerSigninRequest) error {
+	if code, ok := req["code"]; !ok || code == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing or invalid oidc code")
+	}
+	return nil
+}
+
+func (d *authDriver) CheckSigninStateToken(req sdk.AuthConsumerSigninRequest) error {
+	// Check if state is given and if its valid
+	state, okState := req["state"]
+	if !okState {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing state value")
+	}
+	return authentication.CheckDefaultSigninStateToken(state)
+}
+
+func (d *authDriver) GetUserInfo(ctx context.Context, req sdk.AuthConsumerSigninRequest) (sdk.AuthDriverUserInfo, error) {
+	var info sdk.AuthDriverUserInfo
+
+	discovery, err := d.getDiscovery()
+	if err != nil {
+		return info, err
+	}
+
+	config := &oauth2.Config{
+		ClientID:     d.config.ClientID,
+		ClientSecret: d.config.ClientSecret,
+		RedirectURL:  d.redirectURI(),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+		Scopes: d.config.Scopes,
+	}
+
+	ctx2 := context.WithValue(ctx, oauth2.HTTPClient, http.DefaultClient)
+	t, err := config.Exchange(ctx2, req["code"])
+	if err != nil {
+		return info, sdk.NewError(sdk.ErrUnauthorized, sdk.WrapError(err, "cannot get oidc token with given code"))
+	}
+
+	rawIDToken, ok := t.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return info, sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing id token in oidc token response")
+	}
+
+	claims, err := d.verifyIDToken(discovery, rawIDToken)
+	if err != nil {
+		return info, err
+	}
+
+	return d.userInfoFromClaims(claims)
+}
+
+func (d *authDriver) redirectURI() string {
+	return d.cdsURL + "/auth/callback/" + string(sdk.ConsumerOIDC)
+}
+
+// getDiscovery returns the provider metadata, discovery is done only once on success.
+func (d *authDriver) getDiscovery() (*providerDiscovery, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.discovery != nil {
+		return d.discovery, nil
+	}
+
+	var discovery providerDiscovery
+	if err := getJSON(strings.TrimSuffix(d.config.URL, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, sdk.WrapError(err, "cannot get oidc provider configuration")
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrUnknownError, "invalid oidc provider configuration")
+	}
+
+	d.discovery = &discovery
+	return d.discovery, nil
+}
+
+// getKey returns the provider's key for given key id, keys are reloaded if the id is unknown to handle keys rotation.
+// To not flood the provider with tokens signed by unknown keys, keys are reloaded at most once per keysRefreshInterval.
+func (d *authDriver) getKey(jwksURI, kid string) (*jose.JSONWebKey, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.keys != nil {
+		if ks := d.keys.Key(kid); len(ks) > 0 {
+			return &ks[0], nil
+		}
+		if time.Since(d.keysFetchedAt) < keysRefreshInterval {
+			return nil, sdk.NewErrorFrom(sdk.ErrUnauthorized, "unknown signing key %q for id token", kid)
+		}
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := getJSON(jwksURI, &keys); err != nil {
+		return nil, sdk.WrapError(err, "cannot get oidc provider keys")
+	}
+	d.keys = &keys
+	d.keysFetchedAt = time.Now()
+
+	ks := d.keys.Key(kid)
+	if len(ks) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrUnauthorized, "unknown signing key %q for id token", kid)
+	}
+	return &ks[0], nil
+}
+
+// verifyIDToken checks the signature of given id token then validates its standard claims.
+func (d *authDriver) verifyIDToken(discovery *providerDiscovery, rawIDToken string) (map[string]interface{}, error) {
+	jws, err := jose.ParseSigned(rawIDToken)
+	if err != nil {
+		return nil, sdk.NewError(sdk.ErrUnauthorized, fmt.Errorf("cannot parse id token: %v", err))
+	}
+	if len(jws.Signatures) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing signature in id token")
+	}
+
+	key, err := d.getKey(discovery.JWKSURI, jws.Signatures[0].Header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := jws.Verify(key)
+	if err != nil {
+		return nil, sdk.NewError(sdk.ErrUnauthorized, fmt.Errorf("id token verification failed: %v", err))
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, sdk.NewError(sdk.ErrUnauthorized, fmt.Errorf("cannot parse id token claims: %v", err))
+	}
+
+	if err := checkStandardClaims(claims, discovery.Issuer, d.config.ClientID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func checkStandardClaims(claims map[string]interface{}, issuer, clientID string, now time.Time) error {
+	if iss, _ := claims["iss"].(string); issuer != "" && iss != issuer {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid id token issuer %q", iss)
+	}
+
+	var audienceOK bool
+	switch aud := claims["aud"].(type) {
+	case string:
+		audienceOK = aud == clientID
+	case []interface{}:
+		for i := range aud {
+			if s, _ := aud[i].(string); s == clientID {
+				audienceOK = true
+				break
+			}
+		}
+	}
+	if !audienceOK {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid id token audience")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok || time.Unix(int64(exp), 0).Before(now) {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "expired id token")
+	}
+
+	return nil
+}
+
+func (d *authDriver) userInfoFromClaims(claims map[string]interface{}) (sdk.AuthDriverUserInfo, error) {
+	var info sdk.AuthDriverUserInfo
+
+	info.ExternalID, _ = claims["sub"].(string)
+	info.Username, _ = claims[d.config.Claims.Username].(string)
+	info.Fullname, _ = claims[d.config.Claims.Fullname].(string)
+	info.Email, _ = claims[d.config.Claims.Email].(string)
+	if info.ExternalID == "" || info.Username == "" || info.Email == "" {
+		return info, sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing sub, username or email claim in id token")
+	}
+	if verified, _ := claims["email_verified"].(bool); !verified {
+		return info, sdk.NewErrorFrom(sdk.ErrUnauthorized, "email %s is not verified by the oidc provider", info.Email)
+	}
+	if info.Fullname == "" {
+		info.Fullname = info.Username
+	}
+
+	switch groups := claims[d.config.Claims.Groups].(type) {
+	case string:
+		info.Groups = []string{groups}
+	case []interface{}:
+		for i := range groups {
+			if s, ok := groups[i].(string); ok {
+				info.Groups = append(info.Groups, s)
+			}
+		}
+	}
+
+	return info, nil
+}
+
+func getJSON(u string, i interface{}) error {
+	res, err := http.Get(u)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer res.Body.Close() // nolint
+
+	if res.StatusCode != http.StatusOK {
+		return sdk.WithStack(fmt.Errorf("unexpected http status %d for %s", res.StatusCode, u))
+	}
+
+	return sdk.WithStack(json.NewDecoder(res.Body).Decode(i))
+}
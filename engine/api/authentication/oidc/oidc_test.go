@@ -0,0 +1,139 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+func TestVerifyIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key:       key.Public(),
+			KeyID:     "my-key",
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}}})
+	}))
+	defer s.Close()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithHeader("kid", "my-key"))
+	require.NoError(t, err)
+
+	sign := func(claims map[string]interface{}) string {
+		payload, err := json.Marshal(claims)
+		require.NoError(t, err)
+		jws, err := signer.Sign(payload)
+		require.NoError(t, err)
+		raw, err := jws.CompactSerialize()
+		require.NoError(t, err)
+		return raw
+	}
+
+	d := NewDriver(false, "http://cds", Config{
+		ClientID: "cds",
+		Claims:   ClaimsMapping{Username: "login"},
+	}).(*authDriver)
+	discovery := &providerDiscovery{Issuer: "https://provider", JWKSURI: s.URL}
+
+	claims, err := d.verifyIDToken(discovery, sign(map[string]interface{}{
+		"iss":            "https://provider",
+		"aud":            []string{"other", "cds"},
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"sub":            "1234",
+		"login":          "fry",
+		"email":          "fry@planet-express.com",
+		"email_verified": true,
+		"groups":         []string{"cds-delivery", "planet-express"},
+	}))
+	require.NoError(t, err)
+
+	info, err := d.userInfoFromClaims(claims)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", info.ExternalID)
+	assert.Equal(t, "fry", info.Username)
+	assert.Equal(t, "fry", info.Fullname)
+	assert.Equal(t, "fry@planet-express.com", info.Email)
+	assert.Equal(t, []string{"cds-delivery", "planet-express"}, info.Groups)
+
+	// Unverified email
+	claims["email_verified"] = false
+	_, err = d.userInfoFromClaims(claims)
+	assert.Error(t, err)
+	delete(claims, "email_verified")
+	_, err = d.userInfoFromClaims(claims)
+	assert.Error(t, err)
+
+	// Invalid audience
+	_, err = d.verifyIDToken(discovery, sign(map[string]interface{}{
+		"iss": "https://provider",
+		"aud": "other",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}))
+	assert.Error(t, err)
+
+	// Expired token
+	_, err = d.verifyIDToken(discovery, sign(map[string]interface{}{
+		"iss": "https://provider",
+		"aud": "cds",
+		"exp": time.Now().Add(-time.Minute).Unix(),
+	}))
+	assert.Error(t, err)
+
+	// Invalid issuer
+	_, err = d.verifyIDToken(discovery, sign(map[string]interface{}{
+		"iss": "https://another-provider",
+		"aud": "cds",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}))
+	assert.Error(t, err)
+}
+
+func TestGetKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key:       key.Public(),
+			KeyID:     "my-key",
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}}})
+	}))
+	defer s.Close()
+
+	d := NewDriver(false, "http://cds", Config{ClientID: "cds"}).(*authDriver)
+
+	// Keys are loaded once then cached
+	_, err = d.getKey(s.URL, "my-key")
+	require.NoError(t, err)
+	_, err = d.getKey(s.URL, "my-key")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// Unknown keys don't reload the keys before the refresh interval
+	_, err = d.getKey(s.URL, "unknown-key")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	d.keysFetchedAt = time.Now().Add(-keysRefreshInterval)
+	_, err = d.getKey(s.URL, "unknown-key")
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/dgrijalva/jwt-go"
 	"github.com/go-gorp/gorp"
 
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/hatchery"
@@ -14,9 +16,17 @@ import (
 	"github.com/ovh/cds/sdk/log"
 )
 
-// SessionDuration the life time of a worker session.
+// SessionDuration the life time of the session of a worker that doesn't rotate its session.
 var SessionDuration = 24 * time.Hour
 
+// RotatedSessionDuration the life time of the session of a worker that rotates its session before it expires.
+// It is longer than the rotation interval of the workers plus the time during which they retry a failed rotation.
+var RotatedSessionDuration = time.Hour
+
+// SessionGracePeriod is the time during which an expired worker session remains valid. It allows
+// in-flight requests to end when a session is rotated, or a worker to unregister once its job is done.
+var SessionGracePeriod = time.Minute
+
 // VerifyToken checks token technical validity
 func VerifyToken(ctx context.Context, db gorp.SqlExecutor, s string) (*hatchery.WorkerJWTClaims, error) {
 	// First we try to parse the token without checking the its validity.
@@ -68,3 +78,58 @@ func VerifyToken(ctx context.Context, db gorp.SqlExecutor, s string) (*hatchery.
 
 	return claims, nil
 }
+
+// ConsumeToken marks the given worker token as used. A token issued by a hatchery can only be used once
+// to register a worker, it should be consumed once the registration is done and released if it can't be saved.
+func ConsumeToken(store cache.Store, claims *hatchery.WorkerJWTClaims) error {
+	if claims.StandardClaims.Id == "" {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing worker token id")
+	}
+
+	expiration := time.Until(time.Unix(claims.StandardClaims.ExpiresAt, 0)) + time.Minute
+	ok, err := store.Lock(tokenKey(claims), expiration, -1, 1)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "worker token %s was already used", claims.StandardClaims.Id)
+	}
+
+	return nil
+}
+
+// ReleaseToken allows the given worker token to be used again, the worker can retry a failed registration.
+func ReleaseToken(store cache.Store, claims *hatchery.WorkerJWTClaims) error {
+	return store.Unlock(tokenKey(claims))
+}
+
+func tokenKey(claims *hatchery.WorkerJWTClaims) string {
+	return cache.Key("api:workers:token", claims.StandardClaims.Id)
+}
+
+// ExpireSession shortens given worker session to the grace period.
+func ExpireSession(ctx context.Context, db gorp.SqlExecutor, session *sdk.AuthSession) error {
+	expireAt := time.Now().Add(SessionGracePeriod)
+	if session.ExpireAt.Before(expireAt) {
+		return nil
+	}
+	session.ExpireAt = expireAt
+	return authentication.UpdateSession(ctx, db, session)
+}
+
+// ExpireSessions shortens all the sessions of given worker consumer to the grace period.
+// It should be called when the job of a scoped worker is done.
+func ExpireSessions(ctx context.Context, db gorp.SqlExecutor, consumerID string) error {
+	sessions, err := authentication.LoadSessionsByConsumerIDs(ctx, db, []string{consumerID})
+	if err != nil {
+		return err
+	}
+
+	for i := range sessions {
+		if err := ExpireSession(ctx, db, &sessions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -3,8 +3,10 @@ package cache
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ovh/cds/sdk/log"
@@ -43,6 +45,44 @@ type Store interface {
 	ZScan(key, pattern string) ([]string, error)
 	Lock(key string, expiration time.Duration, retryWaitDurationMillisecond int, retryCount int) (bool, error)
 	Unlock(key string) error
+	SetNX(key string, expiration time.Duration) (bool, error)
+}
+
+// Available cache drivers
+const (
+	DriverRedis = "redis"
+	DriverLocal = "local"
+)
+
+// Configuration of a cache store
+type Configuration struct {
+	Driver    string             `toml:"driver" default:"redis" comment:"Cache driver: redis or local.\n The local driver keeps data in memory and in a BoltDB file, it should only be used by a single instance (dev environments, small installations)" json:"driver"`
+	TTL       int                `toml:"ttl" default:"60" json:"ttl"`
+	EntityTTL int                `toml:"entityTTL" default:"600" comment:"Duration in seconds of the entries computed for the projects and the workflows (exports...), they are also invalidated when the project or the workflow changes" json:"entityTTL"`
+	Redis     RedisConfiguration `toml:"redis" comment:"Connect CDS to a redis cache If you more than one CDS instance and to avoid losing data at startup" json:"redis"`
+	Local     struct {
+		Path       string `toml:"path" default:"" comment:"BoltDB file used to persist the local cache, if empty data is only kept in memory" json:"path"`
+		MaxEntries int    `toml:"maxEntries" default:"100000" comment:"Maximum number of values with an expiration kept by the local cache, the least recently used ones are evicted first. 0 for no limit" json:"maxEntries"`
+	} `toml:"local" json:"local"`
+}
+
+// Redis client modes
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
+// RedisConfiguration of a redis cache store
+type RedisConfiguration struct {
+	Host     string `toml:"host" default:"localhost:6379" comment:"If your want to use a redis-sentinel based cluster, follow this syntax! <clustername>@sentinel1:26379,sentinel2:26379,sentinel3:26379\n If your want to use a redis cluster, give the addresses of some of its nodes: node1:6379,node2:6379,node3:6379" json:"host"`
+	Password string `toml:"password" json:"-"`
+	Mode     string `toml:"mode" default:"" comment:"Client mode: standalone, sentinel or cluster. If empty, the mode is deduced from the host" json:"mode"`
+	TLS      struct {
+		Enabled            bool   `toml:"enabled" default:"false" json:"enabled"`
+		CAFile             string `toml:"caFile" default:"" comment:"PEM file of the certificate authorities of the redis servers, system ones are used if empty" json:"caFile"`
+		InsecureSkipVerify bool   `toml:"insecureSkipVerify" default:"false" json:"insecureSkipVerify"`
+	} `toml:"tls" json:"tls"`
 }
 
 //New init a cache
@@ -50,6 +90,39 @@ func New(redisHost, redisPassword string, TTL int) (Store, error) {
 	return NewRedisStore(redisHost, redisPassword, TTL)
 }
 
+// NewFromConfiguration init a cache for configured driver
+func NewFromConfiguration(ctx context.Context, cfg Configuration) (Store, error) {
+	switch cfg.Driver {
+	case "", DriverRedis:
+		return NewRedisStoreFromConfiguration(cfg.Redis, cfg.TTL)
+	case DriverLocal:
+		return sharedLocalStore(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("invalid cache driver %q", cfg.Driver)
+	}
+}
+
+var (
+	localStoresMutex sync.Mutex
+	localStores      = make(map[string]*LocalStore)
+)
+
+// sharedLocalStore returns the local store of the path of the configuration. The services started by a same
+// process share their local store, like they would share a redis server.
+func sharedLocalStore(ctx context.Context, cfg Configuration) (*LocalStore, error) {
+	localStoresMutex.Lock()
+	defer localStoresMutex.Unlock()
+	if s, ok := localStores[cfg.Local.Path]; ok {
+		return s, nil
+	}
+	s, err := NewLocalStore(ctx, cfg.Local.Path, cfg.TTL, cfg.Local.MaxEntries)
+	if err != nil {
+		return nil, err
+	}
+	localStores[cfg.Local.Path] = s
+	return s, nil
+}
+
 //NewWriteCloser returns a write closer
 func NewWriteCloser(store Store, key string, ttl int) io.WriteCloser {
 	return &writerCloser{
@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// EntityInvalidationChannel is the channel on which the API instances are notified that the entries cached for
+// a project or a workflow are stale.
+const EntityInvalidationChannel = "cds_cache_invalidation"
+
+// Entity identifies the project, or the workflow of a project, for which entries are cached. Invalidating a
+// project invalidates the entries of its workflows.
+type Entity struct {
+	ProjectKey   string `json:"project_key"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+}
+
+// ProjectEntity returns the entity of a project.
+func ProjectEntity(projectKey string) Entity {
+	return Entity{ProjectKey: projectKey}
+}
+
+// WorkflowEntity returns the entity of a workflow.
+func WorkflowEntity(projectKey, workflowName string) Entity {
+	return Entity{ProjectKey: projectKey, WorkflowName: workflowName}
+}
+
+// localPrefix returns the prefix of the keys of the entries of the entity in the memory of an API instance.
+func (e Entity) localPrefix() string {
+	if e.WorkflowName == "" {
+		return Key("entity", e.ProjectKey) + ":"
+	}
+	return Key("entity", e.ProjectKey, "workflow", e.WorkflowName) + ":"
+}
+
+func (e Entity) localKey(key string) string {
+	return e.localPrefix() + key
+}
+
+// versionKeys returns the keys of the versions of the entity in the store, the version of the project first.
+func (e Entity) versionKeys() []string {
+	keys := []string{Key("entity", "version", e.ProjectKey)}
+	if e.WorkflowName != "" {
+		keys = append(keys, Key("entity", "version", e.ProjectKey, "workflow", e.WorkflowName))
+	}
+	return keys
+}
+
+// InvalidateEntity makes the entries cached for an entity stale: the version of the entity is changed in the store so
+// the entries written with the previous version are not read anymore, and all the API instances are notified to drop
+// the entries kept in memory.
+func InvalidateEntity(ctx context.Context, store Store, e Entity) error {
+	keys := e.versionKeys()
+	version := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := store.SetWithTTL(keys[len(keys)-1], version, 0); err != nil {
+		return sdk.WrapError(err, "cannot change version of %s", e.localPrefix())
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return store.Publish(ctx, EntityInvalidationChannel, string(b))
+}
+
+// EntityCache keeps entries computed for projects and workflows in the store, and in the memory of the API instance.
+// The entries are invalidated by InvalidateEntity, the entries of the store are also keyed by the version of the
+// entity so an instance which missed a notification does not read stale entries from the store.
+type EntityCache struct {
+	store      Store
+	local      *gocache.Cache
+	ttl        time.Duration
+	mutex      sync.Mutex
+	generation int64
+}
+
+// NewEntityCache returns an entity cache, the entries are kept for given duration.
+func NewEntityCache(store Store, ttl time.Duration) *EntityCache {
+	return &EntityCache{
+		store: store,
+		local: gocache.New(ttl, 2*ttl),
+		ttl:   ttl,
+	}
+}
+
+// Get reads an entry of an entity, returns false if there is none. A nil entity cache never contains entries.
+func (c *EntityCache) Get(e Entity, key string, value interface{}) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	if data, ok := c.local.Get(e.localKey(key)); ok {
+		return true, sdk.WithStack(json.Unmarshal(data.([]byte), value))
+	}
+
+	c.mutex.Lock()
+	generation := c.generation
+	c.mutex.Unlock()
+
+	storeKey, err := c.storeKey(e, key)
+	if err != nil {
+		return false, err
+	}
+	var data string
+	found, err := c.store.Get(storeKey, &data)
+	if err != nil || !found {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(data), value); err != nil {
+		return false, sdk.WithStack(err)
+	}
+
+	// the entry is not kept in memory if an invalidation was received while it was read from the store
+	c.mutex.Lock()
+	if generation == c.generation {
+		c.local.Set(e.localKey(key), []byte(data), c.ttl)
+	}
+	c.mutex.Unlock()
+	return true, nil
+}
+
+// Set writes an entry of an entity, it does nothing on a nil entity cache.
+func (c *EntityCache) Set(e Entity, key string, value interface{}) error {
+	if c == nil {
+		return nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	storeKey, err := c.storeKey(e, key)
+	if err != nil {
+		return err
+	}
+	if err := c.store.SetWithDuration(storeKey, string(b), c.ttl); err != nil {
+		return err
+	}
+	c.local.Set(e.localKey(key), b, c.ttl)
+	return nil
+}
+
+// storeKey returns the key of an entry in the store, it contains the current versions of the entity.
+func (c *EntityCache) storeKey(e Entity, key string) (string, error) {
+	versions := make([]string, 0, 2)
+	for _, k := range e.versionKeys() {
+		var v string
+		if _, err := c.store.Get(k, &v); err != nil {
+			return "", err
+		}
+		if v == "" {
+			v = "0"
+		}
+		versions = append(versions, v)
+	}
+	parts := []string{"entity", e.ProjectKey, versions[0]}
+	if e.WorkflowName != "" {
+		parts = append(parts, "workflow", e.WorkflowName, versions[1])
+	}
+	return Key(append(parts, key)...), nil
+}
+
+// invalidate drops the entries of an entity kept in memory.
+func (c *EntityCache) invalidate(e Entity) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	prefix := e.localPrefix()
+	for k := range c.local.Items() {
+		if strings.HasPrefix(k, prefix) {
+			c.local.Delete(k)
+		}
+	}
+}
+
+// Listen drops the entries kept in memory when an entity is invalidated, until given context is done.
+func (c *EntityCache) Listen(ctx context.Context) error {
+	pubSub, err := c.store.Subscribe(EntityInvalidationChannel)
+	if err != nil {
+		return sdk.WrapError(err, "unable to subscribe to %s", EntityInvalidationChannel)
+	}
+	defer pubSub.Unsubscribe() // nolint
+	for {
+		msg, err := c.store.GetMessageFromSubscription(ctx, pubSub)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			log.Warning(ctx, "EntityCache.Listen> cannot get message: %v", err)
+			continue
+		}
+		var e Entity
+		if err := json.Unmarshal([]byte(msg), &e); err != nil {
+			log.Warning(ctx, "EntityCache.Listen> cannot parse message %s: %v", msg, err)
+			continue
+		}
+		c.invalidate(e)
+	}
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := NewLocalStore(ctx, "", 60, 0)
+	require.NoError(t, err)
+
+	// two API instances sharing the store
+	c1 := NewEntityCache(s, time.Minute)
+	c2 := NewEntityCache(s, time.Minute)
+	go c1.Listen(ctx) // nolint
+	go c2.Listen(ctx) // nolint
+	time.Sleep(10 * time.Millisecond)
+
+	proj := ProjectEntity("PROJ")
+	wf := WorkflowEntity("PROJ", "build")
+
+	require.NoError(t, c1.Set(proj, "export", localTestValue{Name: "fry"}))
+	require.NoError(t, c1.Set(wf, "export", localTestValue{Name: "leela"}))
+
+	var v localTestValue
+	found, err := c2.Get(proj, "export", &v)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "fry", v.Name)
+	found, err = c2.Get(wf, "export", &v)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "leela", v.Name)
+	found, err = c2.Get(WorkflowEntity("PROJ", "deploy"), "export", &v)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// invalidating a workflow keeps the entries of the project
+	require.NoError(t, InvalidateEntity(ctx, s, wf))
+	time.Sleep(10 * time.Millisecond)
+	for _, c := range []*EntityCache{c1, c2} {
+		found, err = c.Get(wf, "export", &v)
+		require.NoError(t, err)
+		assert.False(t, found)
+		found, err = c.Get(proj, "export", &v)
+		require.NoError(t, err)
+		assert.True(t, found)
+	}
+
+	// invalidating a project invalidates the entries of its workflows
+	require.NoError(t, c1.Set(wf, "export", localTestValue{Name: "leela"}))
+	require.NoError(t, InvalidateEntity(ctx, s, proj))
+	time.Sleep(10 * time.Millisecond)
+	for _, c := range []*EntityCache{c1, c2} {
+		found, err = c.Get(wf, "export", &v)
+		require.NoError(t, err)
+		assert.False(t, found)
+		found, err = c.Get(proj, "export", &v)
+		require.NoError(t, err)
+		assert.False(t, found)
+	}
+
+	// an instance which missed the notification does not read stale entries from the store
+	c3 := NewEntityCache(s, time.Minute)
+	require.NoError(t, c3.Set(wf, "export", localTestValue{Name: "bender"}))
+	require.NoError(t, InvalidateEntity(ctx, s, wf))
+	found, err = NewEntityCache(s, time.Minute).Get(wf, "export", &v)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
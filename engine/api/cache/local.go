@@ -0,0 +1,675 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var (
+	localBucketValues = []byte("values")
+	localBucketQueues = []byte("queues")
+	localBucketSets   = []byte("sets")
+)
+
+// localSubscriptionSize is the number of messages kept for a subscriber, new messages are dropped if full.
+const localSubscriptionSize = 1000
+
+// LocalStore is a cache store for single instance installations, data is kept in memory
+// and persisted in a BoltDB file if a path is given. Pub/sub and locks only work inside the process.
+// If a maximum number of entries is given, the least recently used values having an expiration are
+// evicted first, like the volatile-lru policy of redis: values without expiration (sets, eternal values)
+// are never evicted.
+type LocalStore struct {
+	ttl         int
+	maxEntries  int
+	db          *bolt.DB
+	mutex       sync.RWMutex
+	values      map[string]localValue
+	queues      map[string][]string
+	sets        map[string]map[string]float64
+	subs        map[string][]*localPubSub
+	lru         *list.List
+	lruElements map[string]*list.Element
+}
+
+type localValue struct {
+	Data   string    `json:"data"`
+	Expire time.Time `json:"expire"`
+}
+
+func (v localValue) expired(now time.Time) bool {
+	return !v.Expire.IsZero() && now.After(v.Expire)
+}
+
+type localPubSub struct {
+	store    *LocalStore
+	channels []string
+	msgs     chan string
+}
+
+// Unsubscribe removes the subscriber for given channels, all its channels if none given.
+func (p *localPubSub) Unsubscribe(channels ...string) error {
+	if len(channels) == 0 {
+		channels = p.channels
+	}
+	p.store.mutex.Lock()
+	defer p.store.mutex.Unlock()
+	for _, c := range channels {
+		subs := p.store.subs[c]
+		for i := range subs {
+			if subs[i] == p {
+				p.store.subs[c] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// NewLocalStore initiate a new local store, expired values are removed until given context is done.
+// At most maxEntries values with an expiration are kept, 0 for no limit.
+func NewLocalStore(ctx context.Context, path string, ttl, maxEntries int) (*LocalStore, error) {
+	s := &LocalStore{
+		ttl:         ttl,
+		maxEntries:  maxEntries,
+		values:      make(map[string]localValue),
+		queues:      make(map[string][]string),
+		sets:        make(map[string]map[string]float64),
+		subs:        make(map[string][]*localPubSub),
+		lru:         list.New(),
+		lruElements: make(map[string]*list.Element),
+	}
+
+	if path != "" {
+		db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+		if err != nil {
+			return nil, sdk.WrapError(err, "local> cannot open %s", path)
+		}
+		s.db = db
+		if err := s.load(); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		s.mutex.Lock()
+		err = s.unpersist(localBucketValues, s.evict()...)
+		s.mutex.Unlock()
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.deleteExpired(); err != nil {
+					log.Error(ctx, "local> cannot delete expired values: %v", err)
+				}
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// Close the BoltDB file if any
+func (s *LocalStore) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	return sdk.WithStack(s.db.Close())
+}
+
+// load all persisted data in memory
+func (s *LocalStore) load() error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(localBucketValues); b != nil {
+			if err := b.ForEach(func(k, v []byte) error {
+				var lv localValue
+				if err := json.Unmarshal(v, &lv); err != nil {
+					return sdk.WrapError(err, "local> cannot unmarshal value %s", k)
+				}
+				s.values[string(k)] = lv
+				s.track(string(k), lv)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		if b := tx.Bucket(localBucketQueues); b != nil {
+			if err := b.ForEach(func(k, v []byte) error {
+				var q []string
+				if err := json.Unmarshal(v, &q); err != nil {
+					return sdk.WrapError(err, "local> cannot unmarshal queue %s", k)
+				}
+				s.queues[string(k)] = q
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		if b := tx.Bucket(localBucketSets); b != nil {
+			if err := b.ForEach(func(k, v []byte) error {
+				var set map[string]float64
+				if err := json.Unmarshal(v, &set); err != nil {
+					return sdk.WrapError(err, "local> cannot unmarshal set %s", k)
+				}
+				s.sets[string(k)] = set
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// persist writes given value in the BoltDB file, this should be called with the lock held
+func (s *LocalStore) persist(bucket []byte, key string, value interface{}) error {
+	if s.db == nil {
+		return nil
+	}
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucket)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(key), buf)
+	})
+	return sdk.WrapError(err, "local> cannot persist %s", key)
+}
+
+// unpersist removes given keys from the BoltDB file, this should be called with the lock held
+func (s *LocalStore) unpersist(bucket []byte, keys ...string) error {
+	if s.db == nil || len(keys) == 0 {
+		return nil
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		if b == nil {
+			return nil
+		}
+		for _, k := range keys {
+			if err := b.Delete([]byte(k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return sdk.WrapError(err, "local> cannot delete %v", keys)
+}
+
+func (s *LocalStore) deleteExpired() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := time.Now()
+	var keys []string
+	for k, v := range s.values {
+		if v.expired(now) {
+			s.deleteValue(k)
+			keys = append(keys, k)
+		}
+	}
+	return s.unpersist(localBucketValues, keys...)
+}
+
+// track adds a value having an expiration to the least recently used values, this should be called with the lock held
+func (s *LocalStore) track(key string, v localValue) {
+	e, ok := s.lruElements[key]
+	switch {
+	case v.Expire.IsZero() && ok:
+		s.lru.Remove(e)
+		delete(s.lruElements, key)
+	case v.Expire.IsZero():
+	case ok:
+		s.lru.MoveToFront(e)
+	default:
+		s.lruElements[key] = s.lru.PushFront(key)
+	}
+}
+
+// deleteValue removes a value from memory, this should be called with the lock held
+func (s *LocalStore) deleteValue(key string) {
+	delete(s.values, key)
+	if e, ok := s.lruElements[key]; ok {
+		s.lru.Remove(e)
+		delete(s.lruElements, key)
+	}
+}
+
+// evict removes the least recently used values above the maximum number of entries and returns their keys,
+// this should be called with the lock held
+func (s *LocalStore) evict() []string {
+	var keys []string
+	for s.maxEntries > 0 && s.lru.Len() > s.maxEntries {
+		key := s.lru.Back().Value.(string)
+		s.deleteValue(key)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// globRegexp converts a redis like glob pattern to a regexp
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// Get a key from local store
+func (s *LocalStore) Get(key string, value interface{}) (bool, error) {
+	s.mutex.Lock()
+	v, ok := s.values[key]
+	if e, tracked := s.lruElements[key]; tracked {
+		s.lru.MoveToFront(e)
+	}
+	s.mutex.Unlock()
+	if !ok || v.expired(time.Now()) {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(v.Data), value); err != nil {
+		return false, sdk.WrapError(err, "local> cannot get unmarshal %s", key)
+	}
+	return true, nil
+}
+
+func (s *LocalStore) setRaw(key string, data string, duration time.Duration) error {
+	v := localValue{Data: data}
+	if duration > 0 {
+		v.Expire = time.Now().Add(duration)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.values[key] = v
+	s.track(key, v)
+	if err := s.persist(localBucketValues, key, v); err != nil {
+		return err
+	}
+	return s.unpersist(localBucketValues, s.evict()...)
+}
+
+// SetWithTTL a value in local store (0 for eternity)
+func (s *LocalStore) SetWithTTL(key string, value interface{}, ttl int) error {
+	return s.SetWithDuration(key, value, time.Duration(ttl)*time.Second)
+}
+
+// SetWithDuration a value in local store (0 for eternity)
+func (s *LocalStore) SetWithDuration(key string, value interface{}, duration time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return sdk.WrapError(err, "local> error caching %s", key)
+	}
+	return s.setRaw(key, string(b), duration)
+}
+
+// UpdateTTL update the ttl linked to the key, like redis a negative ttl deletes the key
+func (s *LocalStore) UpdateTTL(key string, ttl int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	v, ok := s.values[key]
+	if !ok {
+		return nil
+	}
+	if ttl <= 0 {
+		s.deleteValue(key)
+		return s.unpersist(localBucketValues, key)
+	}
+	v.Expire = time.Now().Add(time.Duration(ttl) * time.Second)
+	s.values[key] = v
+	s.track(key, v)
+	if err := s.persist(localBucketValues, key, v); err != nil {
+		return err
+	}
+	return s.unpersist(localBucketValues, s.evict()...)
+}
+
+// Set a value in local store
+func (s *LocalStore) Set(key string, value interface{}) error {
+	return s.SetWithTTL(key, value, s.ttl)
+}
+
+// Delete a key in local store
+func (s *LocalStore) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.deleteValue(key)
+	return s.unpersist(localBucketValues, key)
+}
+
+// DeleteAll delete all mathing keys in local store
+func (s *LocalStore) DeleteAll(pattern string) error {
+	r, err := globRegexp(pattern)
+	if err != nil {
+		return sdk.WrapError(err, "local> invalid pattern %s", pattern)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var keys []string
+	for k := range s.values {
+		if r.MatchString(k) {
+			s.deleteValue(k)
+			keys = append(keys, k)
+		}
+	}
+	return s.unpersist(localBucketValues, keys...)
+}
+
+// Enqueue pushes to queue
+func (s *LocalStore) Enqueue(queueName string, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return sdk.WrapError(err, "error queueing %s", queueName)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.queues[queueName] = append(s.queues[queueName], string(b))
+	return s.persist(localBucketQueues, queueName, s.queues[queueName])
+}
+
+// QueueLen returns the length of a queue
+func (s *LocalStore) QueueLen(queueName string) (int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.queues[queueName]), nil
+}
+
+func (s *LocalStore) dequeue(queueName string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	q := s.queues[queueName]
+	if len(q) == 0 {
+		return "", nil
+	}
+	elem := q[0]
+	s.queues[queueName] = q[1:]
+	return elem, s.persist(localBucketQueues, queueName, s.queues[queueName])
+}
+
+// DequeueWithContext gets from queue This is blocking while there is nothing in the queue, it can be cancelled with a context.Context
+func (s *LocalStore) DequeueWithContext(c context.Context, queueName string, value interface{}) error {
+	var elem string
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for elem == "" {
+		var err error
+		elem, err = s.dequeue(queueName)
+		if err != nil {
+			return err
+		}
+		if elem != "" {
+			break
+		}
+		select {
+		case <-ticker.C:
+			if c.Err() != nil {
+				return c.Err()
+			}
+		case <-c.Done():
+			return nil
+		}
+	}
+	if err := json.Unmarshal([]byte(elem), value); err != nil {
+		return sdk.WrapError(err, "local.DequeueWithContext> error on unmarshal value on queue:%s", queueName)
+	}
+	return nil
+}
+
+// RemoveFromQueue removes a member from a list
+func (s *LocalStore) RemoveFromQueue(queueName string, memberKey string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	q := s.queues[queueName]
+	filtered := make([]string, 0, len(q))
+	for _, e := range q {
+		if e != memberKey {
+			filtered = append(filtered, e)
+		}
+	}
+	s.queues[queueName] = filtered
+	return s.persist(localBucketQueues, queueName, filtered)
+}
+
+// Publish a msg in a channel
+func (s *LocalStore) Publish(ctx context.Context, channel string, value interface{}) error {
+	msg, err := json.Marshal(value)
+	if err != nil {
+		return sdk.WrapError(err, "local.Publish> Marshall error, cannot push in channel %s", channel)
+	}
+
+	iUnquoted, err := strconv.Unquote(string(msg))
+	if err != nil {
+		return sdk.WrapError(err, "local.Publish> Unquote error, cannot push in channel %s", channel)
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, sub := range s.subs[channel] {
+		select {
+		case sub.msgs <- iUnquoted:
+		default:
+			log.Warning(ctx, "local.Publish> subscriber is full, message dropped from channel %s", channel)
+		}
+	}
+	return nil
+}
+
+// Subscribe to a channel
+func (s *LocalStore) Subscribe(channel string) (PubSub, error) {
+	p := &localPubSub{
+		store:    s,
+		channels: []string{channel},
+		msgs:     make(chan string, localSubscriptionSize),
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.subs[channel] = append(s.subs[channel], p)
+	return p, nil
+}
+
+// GetMessageFromSubscription from a local PubSub
+func (s *LocalStore) GetMessageFromSubscription(c context.Context, pb PubSub) (string, error) {
+	lps, ok := pb.(*localPubSub)
+	if !ok {
+		return "", fmt.Errorf("local.GetMessage> PubSub is not a local PubSub. Got %T", pb)
+	}
+	select {
+	case msg := <-lps.msgs:
+		return msg, nil
+	case <-c.Done():
+		return "", nil
+	}
+}
+
+// SetAdd add a member (identified by a key) in the cached set
+func (s *LocalStore) SetAdd(rootKey string, memberKey string, member interface{}) error {
+	s.mutex.Lock()
+	set, ok := s.sets[rootKey]
+	if !ok {
+		set = make(map[string]float64)
+		s.sets[rootKey] = set
+	}
+	set[memberKey] = float64(time.Now().UnixNano())
+	err := s.persist(localBucketSets, rootKey, set)
+	s.mutex.Unlock()
+	if err != nil {
+		return sdk.WrapError(err, "error on SetAdd")
+	}
+	return s.SetWithTTL(Key(rootKey, memberKey), member, -1)
+}
+
+// SetRemove removes a member from a set
+func (s *LocalStore) SetRemove(rootKey string, memberKey string, member interface{}) error {
+	s.mutex.Lock()
+	err := s.removeSetMember(rootKey, memberKey)
+	s.mutex.Unlock()
+	if err != nil {
+		return sdk.WrapError(err, "error on SetRemove")
+	}
+	return s.Delete(Key(rootKey, memberKey))
+}
+
+// removeSetMember should be called with the lock held
+func (s *LocalStore) removeSetMember(rootKey string, memberKey string) error {
+	set, ok := s.sets[rootKey]
+	if !ok {
+		return nil
+	}
+	delete(set, memberKey)
+	if len(set) == 0 {
+		delete(s.sets, rootKey)
+		return s.unpersist(localBucketSets, rootKey)
+	}
+	return s.persist(localBucketSets, rootKey, set)
+}
+
+// SetCard returns the cardinality of a set
+func (s *LocalStore) SetCard(key string) (int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.sets[key]), nil
+}
+
+// setMembers returns set's members ordered by score, this should be called with the lock held
+func (s *LocalStore) setMembers(key string) []string {
+	set := s.sets[key]
+	members := make([]string, 0, len(set))
+	for m := range set {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return set[members[i]] < set[members[j]]
+	})
+	return members
+}
+
+// SetScan scans a set
+func (s *LocalStore) SetScan(ctx context.Context, key string, members ...interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	values := s.setMembers(key)
+	now := time.Now()
+	for i := range members {
+		if i >= len(values) {
+			break
+		}
+
+		memberKey := Key(key, values[i])
+		v, ok := s.values[memberKey]
+		if !ok || v.expired(now) {
+			//If the member is not found, return an error because the members are inconsistents
+			// but try to delete the member from the set
+			log.Error(ctx, "local>SetScan member %s not found", memberKey)
+			if err := s.removeSetMember(key, values[i]); err != nil {
+				return sdk.WrapError(err, "local>SetScan unable to delete member %s", memberKey)
+			}
+			return sdk.WithStack(fmt.Errorf("SetScan member %s not found", memberKey))
+		}
+
+		if err := json.Unmarshal([]byte(v.Data), members[i]); err != nil {
+			return sdk.WrapError(err, "local> cannot unmarshal %s", memberKey)
+		}
+	}
+	return nil
+}
+
+// ZScan returns set's members that match given pattern
+func (s *LocalStore) ZScan(key, pattern string) ([]string, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	r, err := globRegexp(pattern)
+	if err != nil {
+		return nil, sdk.WrapError(err, "local> invalid pattern %s", pattern)
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var res []string
+	for _, m := range s.setMembers(key) {
+		if r.MatchString(m) {
+			res = append(res, m)
+		}
+	}
+	return res, nil
+}
+
+// Lock sets the key if not already set
+func (s *LocalStore) Lock(key string, expiration time.Duration, retrywdMillisecond int, retryCount int) (bool, error) {
+	if retrywdMillisecond == -1 {
+		retrywdMillisecond = 30
+	}
+	if retryCount == -1 {
+		retryCount = 3
+	}
+	for i := 0; i < retryCount; i++ {
+		ok, err := s.setNX(key, expiration)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+		time.Sleep(time.Duration(retrywdMillisecond) * time.Millisecond)
+	}
+	return false, nil
+}
+
+func (s *LocalStore) setNX(key string, expiration time.Duration) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if v, ok := s.values[key]; ok && !v.expired(time.Now()) {
+		return false, nil
+	}
+	v := localValue{Data: "true"}
+	if expiration > 0 {
+		v.Expire = time.Now().Add(expiration)
+	}
+	// locks are never evicted
+	s.deleteValue(key)
+	s.values[key] = v
+	return true, s.persist(localBucketValues, key, v)
+}
+
+// Unlock deletes a key from cache
+func (s *LocalStore) Unlock(key string) error {
+	return s.Delete(key)
+}
+
+// SetNX sets a key only if it does not exist, it returns false if the key already exists
+func (s *LocalStore) SetNX(key string, expiration time.Duration) (bool, error) {
+	return s.setNX(key, expiration)
+}
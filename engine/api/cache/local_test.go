@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type localTestValue struct {
+	Name string `json:"name"`
+}
+
+func TestLocalStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "cds-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	path := filepath.Join(dir, "cache.db")
+	s, err := NewLocalStore(ctx, path, 60, 0)
+	require.NoError(t, err)
+
+	// Values
+	require.NoError(t, s.Set("my:key", localTestValue{Name: "fry"}))
+	require.NoError(t, s.SetWithDuration("my:expired", localTestValue{Name: "leela"}, time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	var v localTestValue
+	found, err := s.Get("my:key", &v)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "fry", v.Name)
+	found, err = s.Get("my:expired", &v)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// Queues
+	require.NoError(t, s.Enqueue("my:queue", localTestValue{Name: "first"}))
+	require.NoError(t, s.Enqueue("my:queue", localTestValue{Name: "second"}))
+	l, err := s.QueueLen("my:queue")
+	require.NoError(t, err)
+	assert.Equal(t, 2, l)
+	require.NoError(t, s.DequeueWithContext(ctx, "my:queue", &v))
+	assert.Equal(t, "first", v.Name)
+
+	// Sets
+	require.NoError(t, s.SetAdd("my:set", "a", localTestValue{Name: "a"}))
+	require.NoError(t, s.SetAdd("my:set", "b", localTestValue{Name: "b"}))
+	members := []*localTestValue{{}, {}}
+	require.NoError(t, s.SetScan(ctx, "my:set", members[0], members[1]))
+	assert.Equal(t, "a", members[0].Name)
+	assert.Equal(t, "b", members[1].Name)
+	keys, err := s.ZScan("my:set", "b*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, keys)
+
+	// Locks
+	locked, err := s.Lock("my:lock", time.Minute, 1, 1)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	locked, err = s.Lock("my:lock", time.Minute, 1, 1)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	// Data should be loaded from the BoltDB file
+	require.NoError(t, s.Close())
+	s, err = NewLocalStore(ctx, path, 60, 0)
+	require.NoError(t, err)
+	defer s.Close() // nolint
+	found, err = s.Get("my:key", &v)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "fry", v.Name)
+	require.NoError(t, s.DequeueWithContext(ctx, "my:queue", &v))
+	assert.Equal(t, "second", v.Name)
+	c, err := s.SetCard("my:set")
+	require.NoError(t, err)
+	assert.Equal(t, 2, c)
+
+	require.NoError(t, s.DeleteAll("my:*"))
+	found, err = s.Get("my:key", &v)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestLocalStoreEviction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := NewLocalStore(ctx, "", 60, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Set("my:first", localTestValue{Name: "fry"}))
+	require.NoError(t, s.Set("my:second", localTestValue{Name: "leela"}))
+	require.NoError(t, s.SetWithTTL("my:eternal", localTestValue{Name: "bender"}, 0))
+	locked, err := s.Lock("my:lock", time.Minute, 1, 1)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	// the first value is the most recently used
+	var v localTestValue
+	found, err := s.Get("my:first", &v)
+	require.NoError(t, err)
+	assert.True(t, found)
+	require.NoError(t, s.Set("my:third", localTestValue{Name: "zoidberg"}))
+
+	found, err = s.Get("my:second", &v)
+	require.NoError(t, err)
+	assert.False(t, found)
+	for _, k := range []string{"my:first", "my:third", "my:eternal"} {
+		found, err = s.Get(k, &v)
+		require.NoError(t, err)
+		assert.True(t, found, k)
+	}
+	locked, err = s.Lock("my:lock", time.Minute, 1, 1)
+	require.NoError(t, err)
+	assert.False(t, locked)
+}
+
+func TestLocalStorePubSub(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s, err := NewLocalStore(ctx, "", 60, 0)
+	require.NoError(t, err)
+
+	sub, err := s.Subscribe("events")
+	require.NoError(t, err)
+	require.NoError(t, s.Publish(ctx, "events", "my message"))
+
+	msg, err := s.GetMessageFromSubscription(ctx, sub)
+	require.NoError(t, err)
+	assert.Equal(t, "my message", msg)
+
+	require.NoError(t, sub.Unsubscribe())
+	require.NoError(t, s.Publish(ctx, "events", "another message"))
+	assert.Len(t, sub.(*localPubSub).msgs, 0)
+}
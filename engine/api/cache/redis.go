@@ -2,6 +2,8 @@ package cache
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +11,7 @@ import (
 	stdlog "log"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -20,39 +23,81 @@ import (
 //RedisStore a redis client and a default ttl
 type RedisStore struct {
 	ttl    int
-	Client *redis.Client
+	Client redis.UniversalClient
 }
 
 //NewRedisStore initiate a new redisStore
 func NewRedisStore(host, password string, ttl int) (*RedisStore, error) {
-	var client *redis.Client
+	return NewRedisStoreFromConfiguration(RedisConfiguration{Host: host, Password: password}, ttl)
+}
 
-	//if host is line master@localhost:26379,localhost:26380 => it's a redis sentinel cluster
-	if strings.Contains(host, "@") && strings.Contains(host, ",") {
-		masterName := strings.Split(host, "@")[0]
-		sentinelsStr := strings.Split(host, "@")[1]
+// NewRedisStoreFromConfiguration initiate a new redisStore, connected to a single redis server, to the master
+// given by redis sentinels, or to a redis cluster.
+func NewRedisStoreFromConfiguration(cfg RedisConfiguration, ttl int) (*RedisStore, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLS.Enabled {
+		tlsConfig = &tls.Config{InsecureSkipVerify: cfg.TLS.InsecureSkipVerify} // nolint
+		if cfg.TLS.CAFile != "" {
+			pem, err := ioutil.ReadFile(cfg.TLS.CAFile)
+			if err != nil {
+				return nil, sdk.WrapError(err, "cannot read redis CA file %s", cfg.TLS.CAFile)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("invalid redis CA file %s", cfg.TLS.CAFile)
+			}
+		}
+	}
+
+	mode, err := redisMode(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var client redis.UniversalClient
+	switch mode {
+	case RedisModeSentinel:
+		//if host is line master@localhost:26379,localhost:26380 => it's a redis sentinel cluster
+		masterName := strings.Split(cfg.Host, "@")[0]
+		sentinelsStr := strings.Split(cfg.Host, "@")[1]
 		sentinels := strings.Split(sentinelsStr, ",")
 		opts := &redis.FailoverOptions{
 			MasterName:         masterName,
 			SentinelAddrs:      sentinels,
-			Password:           password,
+			Password:           cfg.Password,
 			IdleCheckFrequency: 10 * time.Second,
 			IdleTimeout:        10 * time.Second,
 			PoolSize:           25,
 			MaxRetries:         10,
 			MinRetryBackoff:    30 * time.Millisecond,
 			MaxRetryBackoff:    100 * time.Millisecond,
+			TLSConfig:          tlsConfig,
 		}
 		client = redis.NewFailoverClient(opts)
-	} else {
+	case RedisModeCluster:
+		// the client discovers the other nodes of the cluster and follows the failovers of the masters
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:              strings.Split(cfg.Host, ","),
+			Password:           cfg.Password,
+			IdleCheckFrequency: 10 * time.Second,
+			IdleTimeout:        10 * time.Second,
+			PoolSize:           25,
+			MaxRedirects:       8,
+			MaxRetries:         10,
+			MinRetryBackoff:    30 * time.Millisecond,
+			MaxRetryBackoff:    100 * time.Millisecond,
+			TLSConfig:          tlsConfig,
+		})
+	default:
 		client = redis.NewClient(&redis.Options{
-			Addr:               host,
-			Password:           password, // no password set
-			DB:                 0,        // use default DB
+			Addr:               cfg.Host,
+			Password:           cfg.Password, // no password set
+			DB:                 0,            // use default DB
 			IdleCheckFrequency: 30 * time.Second,
 			MaxRetries:         10,
 			MinRetryBackoff:    30 * time.Millisecond,
 			MaxRetryBackoff:    100 * time.Millisecond,
+			TLSConfig:          tlsConfig,
 		})
 	}
 
@@ -63,7 +108,7 @@ func NewRedisStore(host, password string, ttl int) (*RedisStore, error) {
 		return nil, err
 	}
 	if pong != "PONG" {
-		return nil, fmt.Errorf("Cannot ping Redis on %s", host)
+		return nil, fmt.Errorf("Cannot ping Redis on %s", cfg.Host)
 	}
 	return &RedisStore{
 		ttl:    ttl,
@@ -71,6 +116,40 @@ func NewRedisStore(host, password string, ttl int) (*RedisStore, error) {
 	}, nil
 }
 
+// redisMode returns the client mode of the configuration, deduced from the host if not given.
+func redisMode(cfg RedisConfiguration) (string, error) {
+	sentinel := strings.Contains(cfg.Host, "@")
+	switch cfg.Mode {
+	case "":
+		if sentinel && strings.Contains(cfg.Host, ",") {
+			return RedisModeSentinel, nil
+		}
+		if strings.Contains(cfg.Host, ",") {
+			return RedisModeCluster, nil
+		}
+		return RedisModeStandalone, nil
+	case RedisModeSentinel:
+		if !sentinel {
+			return "", fmt.Errorf("invalid redis host %q for sentinel mode, expected <clustername>@sentinel1:26379,sentinel2:26379", cfg.Host)
+		}
+		return cfg.Mode, nil
+	case RedisModeStandalone, RedisModeCluster:
+		if sentinel {
+			return "", fmt.Errorf("invalid redis host %q for %s mode", cfg.Host, cfg.Mode)
+		}
+		return cfg.Mode, nil
+	default:
+		return "", fmt.Errorf("invalid redis mode %q", cfg.Mode)
+	}
+}
+
+// isCluster returns true if the keys can be on several redis nodes: the commands on several keys must
+// be split by key.
+func (s *RedisStore) isCluster() bool {
+	_, ok := s.Client.(*redis.ClusterClient)
+	return ok
+}
+
 // Get a key from redis
 func (s *RedisStore) Get(key string, value interface{}) (bool, error) {
 	if s.Client == nil {
@@ -160,19 +239,77 @@ func (s *RedisStore) DeleteAll(pattern string) error {
 	if s.Client == nil {
 		return sdk.WithStack(fmt.Errorf("redis> cannot get redis client"))
 	}
-	keys, err := s.Client.Keys(pattern).Result()
+	keys, err := s.keys(pattern)
 	if err != nil {
 		return sdk.WrapError(err, "redis> Error deleting %s", pattern)
 	}
 	if len(keys) == 0 {
 		return nil
 	}
-	if err := s.Client.Del(keys...).Err(); err != nil {
+	if !s.isCluster() {
+		if err := s.Client.Del(keys...).Err(); err != nil {
+			return sdk.WrapError(err, "redis> Error deleting %s", pattern)
+		}
+		return nil
+	}
+	pipe := s.Client.Pipeline()
+	for _, k := range keys {
+		pipe.Del(k)
+	}
+	if _, err := pipe.Exec(); err != nil {
 		return sdk.WrapError(err, "redis> Error deleting %s", pattern)
 	}
 	return nil
 }
 
+// keys returns the keys matching a pattern, on all the masters of a cluster.
+func (s *RedisStore) keys(pattern string) ([]string, error) {
+	cluster, ok := s.Client.(*redis.ClusterClient)
+	if !ok {
+		return s.Client.Keys(pattern).Result()
+	}
+	var mutex sync.Mutex
+	var keys []string
+	err := cluster.ForEachMaster(func(c *redis.Client) error {
+		ks, err := c.Keys(pattern).Result()
+		if err != nil {
+			return err
+		}
+		mutex.Lock()
+		keys = append(keys, ks...)
+		mutex.Unlock()
+		return nil
+	})
+	return keys, err
+}
+
+// mget returns the values of the keys, nil for the missing ones. The values are read with a pipeline on a cluster.
+func (s *RedisStore) mget(keys ...string) ([]interface{}, error) {
+	if !s.isCluster() {
+		return s.Client.MGet(keys...).Result()
+	}
+	pipe := s.Client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, k := range keys {
+		cmds[i] = pipe.Get(k)
+	}
+	if _, err := pipe.Exec(); err != nil && err != redis.Nil {
+		return nil, err
+	}
+	res := make([]interface{}, len(keys))
+	for i := range cmds {
+		v, err := cmds[i].Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
 // Enqueue pushes to queue
 func (s *RedisStore) Enqueue(queueName string, value interface{}) error {
 	if s.Client == nil {
@@ -366,7 +503,7 @@ func (s *RedisStore) SetScan(ctx context.Context, key string, members ...interfa
 	}
 
 	if len(keys) > 0 {
-		res, err := s.Client.MGet(keys...).Result()
+		res, err := s.mget(keys...)
 		if err != nil {
 			return fmt.Errorf("redis mget error: %v", err)
 		}
@@ -427,3 +564,9 @@ func (s *RedisStore) Lock(key string, expiration time.Duration, retrywdMilliseco
 func (s *RedisStore) Unlock(key string) error {
 	return s.Delete(key)
 }
+
+// SetNX sets a key only if it does not exist, it returns false if the key already exists
+func (s *RedisStore) SetNX(key string, expiration time.Duration) (bool, error) {
+	res, err := s.Client.SetNX(key, "true", expiration).Result()
+	return res, sdk.WrapError(err, "redis> setnx error %s", key)
+}
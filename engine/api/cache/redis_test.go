@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisMode(t *testing.T) {
+	tests := []struct {
+		host, mode, expected string
+	}{
+		{host: "localhost:6379", expected: RedisModeStandalone},
+		{host: "node1:6379,node2:6379,node3:6379", expected: RedisModeCluster},
+		{host: "mymaster@sentinel1:26379,sentinel2:26379", expected: RedisModeSentinel},
+		{host: "node1:6379", mode: RedisModeCluster, expected: RedisModeCluster},
+		{host: "mymaster@sentinel1:26379", mode: RedisModeSentinel, expected: RedisModeSentinel},
+	}
+	for _, tt := range tests {
+		mode, err := redisMode(RedisConfiguration{Host: tt.host, Mode: tt.mode})
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, mode, tt.host)
+	}
+
+	_, err := redisMode(RedisConfiguration{Host: "localhost:6379", Mode: RedisModeSentinel})
+	assert.Error(t, err)
+	_, err = redisMode(RedisConfiguration{Host: "mymaster@sentinel1:26379", Mode: RedisModeCluster})
+	assert.Error(t, err)
+	_, err = redisMode(RedisConfiguration{Host: "localhost:6379", Mode: "unknown"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// chatOpsLinkToken is the content of a signed token used to link a CDS user to an external identity.
+type chatOpsLinkToken struct {
+	Provider         string `json:"provider"`
+	ExternalUserID   string `json:"external_user_id"`
+	ExternalUsername string `json:"external_username"`
+}
+
+func (api *API) postChatOpsLinkTokenHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		// this handler should only answer to a chatops service
+		if ok := isChatOps(ctx); !ok {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var req sdk.ChatOpsLinkTokenRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if req.Provider == "" || req.ExternalUserID == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing chatops provider or user")
+		}
+
+		token, err := authentication.SignJWS(chatOpsLinkToken{
+			Provider:         req.Provider,
+			ExternalUserID:   req.ExternalUserID,
+			ExternalUsername: req.ExternalUsername,
+		}, sdk.ChatOpsLinkTokenDuration)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.ChatOpsLinkToken{
+			Token:  token,
+			Expire: time.Now().Add(sdk.ChatOpsLinkTokenDuration),
+		}, http.StatusOK)
+	}
+}
+
+func (api *API) postChatOpsLinkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		consumer := getAPIConsumer(ctx)
+
+		var req sdk.ChatOpsLinkRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		var token chatOpsLinkToken
+		if err := authentication.VerifyJWS(req.Token, &token); err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid or expired link token"))
+		}
+		externalID := sdk.ChatOpsExternalID(token.Provider, token.ExternalUserID)
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		// An external identity can only be linked to one CDS user, remove existing link if exists
+		existing, err := authentication.LoadConsumerByTypeAndUserExternalID(ctx, tx, sdk.ConsumerChatOps, externalID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			if err := authentication.DeleteConsumerByID(tx, existing.ID); err != nil {
+				return err
+			}
+		}
+
+		c, err := authentication.NewConsumerExternal(ctx, tx, consumer.AuthentifiedUserID, sdk.ConsumerChatOps, sdk.AuthDriverUserInfo{
+			ExternalID: externalID,
+			Username:   token.ExternalUsername,
+			Fullname:   token.ExternalUsername,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		log.Info(ctx, "postChatOpsLinkHandler> user %s linked to %s", consumer.GetUsername(), externalID)
+
+		return service.WriteJSON(w, c, http.StatusOK)
+	}
+}
+
+func (api *API) deleteChatOpsLinkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		provider := vars["provider"]
+
+		cs, err := authentication.LoadConsumersByUserID(ctx, api.mustDB(), getAPIConsumer(ctx).AuthentifiedUserID)
+		if err != nil {
+			return err
+		}
+
+		var found bool
+		for i := range cs {
+			if cs[i].Type != sdk.ConsumerChatOps || !sdk.IsChatOpsExternalIDForProvider(cs[i].Data["external_id"], provider) {
+				continue
+			}
+			if err := authentication.DeleteConsumerByID(api.mustDB(), cs[i].ID); err != nil {
+				return err
+			}
+			found = true
+		}
+		if !found {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) postChatOpsCommandHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		// this handler should only answer to a chatops service
+		if ok := isChatOps(ctx); !ok {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var cmd sdk.ChatOpsCommand
+		if err := service.UnmarshalBody(r, &cmd); err != nil {
+			return err
+		}
+		if err := cmd.IsValid(); err != nil {
+			return err
+		}
+
+		consumer, err := authentication.LoadConsumerByTypeAndUserExternalID(ctx, api.mustDB(), sdk.ConsumerChatOps, cmd.ExternalID(),
+			authentication.LoadConsumerOptions.WithAuthentifiedUser)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				err = sdk.WithStack(sdk.ErrChatOpsUserNotLinked)
+			}
+			event.PublishChatOpsCommand(ctx, cmd, err, nil)
+			return err
+		}
+		if consumer.Disabled {
+			err := sdk.WithStack(sdk.ErrChatOpsUserNotLinked)
+			event.PublishChatOpsCommand(ctx, cmd, err, consumer)
+			return err
+		}
+
+		res, err := api.executeChatOpsCommand(ctx, cmd, consumer)
+		event.PublishChatOpsCommand(ctx, cmd, err, consumer)
+		if err != nil {
+			return err
+		}
+
+		log.Info(ctx, "postChatOpsCommandHandler> command %s on %s/%s executed for user %s from %s", cmd.Command,
+			cmd.ProjectKey, cmd.WorkflowName, consumer.GetUsername(), cmd.ExternalID())
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// executeChatOpsCommand checks permissions for given command then executes it as the linked consumer.
+func (api *API) executeChatOpsCommand(ctx context.Context, cmd sdk.ChatOpsCommand, consumer *sdk.AuthConsumer) (*sdk.ChatOpsCommandResult, error) {
+	perms, err := permission.LoadWorkflowMaxLevelPermission(ctx, api.mustDB(), cmd.ProjectKey, []string{cmd.WorkflowName}, consumer.GetGroupIDs())
+	if err != nil {
+		return nil, err
+	}
+	// Same rules as for workflow routes, maintainers can read and admins can execute any workflow
+	level := perms.Level(cmd.WorkflowName)
+	if consumer.Admin() {
+		level = sdk.PermissionReadWriteExecute
+	} else if consumer.Maintainer() && level < sdk.PermissionRead {
+		level = sdk.PermissionRead
+	}
+	if level < sdk.PermissionRead {
+		return nil, sdk.WrapError(sdk.ErrForbidden, "not authorized for workflow %s/%s", cmd.ProjectKey, cmd.WorkflowName)
+	}
+
+	switch cmd.Command {
+	case sdk.ChatOpsCommandStatus:
+		var run *sdk.WorkflowRun
+		if cmd.RunNumber > 0 {
+			run, err = workflow.LoadRun(ctx, api.mustDB(), cmd.ProjectKey, cmd.WorkflowName, cmd.RunNumber, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		} else {
+			run, err = workflow.LoadLastRun(api.mustDB(), cmd.ProjectKey, cmd.WorkflowName, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &sdk.ChatOpsCommandResult{
+			Message:     fmt.Sprintf("%s/%s #%d is %s", cmd.ProjectKey, cmd.WorkflowName, run.Number, run.Status),
+			WorkflowRun: run,
+		}, nil
+	case sdk.ChatOpsCommandStop:
+		if level < sdk.PermissionReadExecute {
+			return nil, sdk.WithStack(sdk.ErrNoPermExecution)
+		}
+		return api.chatOpsStopRun(ctx, cmd, consumer)
+	case sdk.ChatOpsCommandRun, sdk.ChatOpsCommandApprove:
+		// Execution permission is checked on the node to start
+		return api.chatOpsStartRun(ctx, cmd, consumer)
+	}
+
+	return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown command %q", cmd.Command)
+}
+
+func (api *API) chatOpsStopRun(ctx context.Context, cmd sdk.ChatOpsCommand, consumer *sdk.AuthConsumer) (*sdk.ChatOpsCommandResult, error) {
+	run, err := workflow.LoadRun(ctx, api.mustDB(), cmd.ProjectKey, cmd.WorkflowName, cmd.RunNumber, workflow.LoadRunOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := project.Load(api.mustDB(), api.Cache, cmd.ProjectKey)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load project")
+	}
+
+	report, err := stopWorkflowRun(ctx, api.mustDB, api.Cache, proj, run, consumer, 0)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to stop workflow")
+	}
+	go WorkflowSendEvent(context.Background(), api.mustDB(), api.Cache, proj.Key, report)
+
+	return &sdk.ChatOpsCommandResult{
+		Message:     fmt.Sprintf("%s/%s #%d stopped", cmd.ProjectKey, cmd.WorkflowName, run.Number),
+		WorkflowRun: run,
+	}, nil
+}
+
+func (api *API) chatOpsStartRun(ctx context.Context, cmd sdk.ChatOpsCommand, consumer *sdk.AuthConsumer) (*sdk.ChatOpsCommandResult, error) {
+	p, err := project.Load(api.mustDB(), api.Cache, cmd.ProjectKey,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithFeatures,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithApplicationVariables,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+	)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load project")
+	}
+
+	payload := make(map[string]interface{}, len(cmd.Payload))
+	for k, v := range cmd.Payload {
+		payload[k] = v
+	}
+	opts := &sdk.WorkflowRunPostHandlerOption{
+		Manual: &sdk.WorkflowNodeRunManual{
+			Payload:  payload,
+			Username: consumer.AuthentifiedUser.Username,
+			Fullname: consumer.AuthentifiedUser.Fullname,
+		},
+	}
+
+	var wf *sdk.Workflow
+	var run *sdk.WorkflowRun
+	var message string
+	if cmd.Command == sdk.ChatOpsCommandApprove {
+		run, err = workflow.LoadRun(ctx, api.mustDB(), cmd.ProjectKey, cmd.WorkflowName, cmd.RunNumber, workflow.LoadRunOptions{})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load workflow run")
+		}
+		wf = &run.Workflow
+
+		node := wf.WorkflowData.NodeByName(cmd.NodeName)
+		if node == nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWorkflowNodeNotFound, "unable to find node %s", cmd.NodeName)
+		}
+		if !permission.AccessToWorkflowNode(ctx, api.mustDB(), wf, node, consumer, sdk.PermissionReadExecute) {
+			return nil, sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", node.Name)
+		}
+
+		opts.Number = &run.Number
+		opts.FromNodeIDs = []int64{node.ID}
+		run.Status = sdk.StatusWaiting
+		message = fmt.Sprintf("%s/%s #%d: %s approved", cmd.ProjectKey, cmd.WorkflowName, run.Number, node.Name)
+	} else {
+		wf, err = workflow.Load(ctx, api.mustDB(), api.Cache, p, cmd.WorkflowName, workflow.LoadOptions{
+			DeepPipeline:          true,
+			Base64Keys:            true,
+			WithAsCodeUpdateEvent: true,
+			WithIcon:              true,
+			WithIntegrations:      true,
+		})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load workflow %s", cmd.WorkflowName)
+		}
+
+		if err := workflowtemplate.AggregateTemplateInstanceOnWorkflow(ctx, api.mustDB(), wf); err != nil {
+			return nil, sdk.WrapError(err, "cannot load workflow template")
+		}
+
+		if !permission.AccessToWorkflowNode(ctx, api.mustDB(), wf, &wf.WorkflowData.Node, consumer, sdk.PermissionReadExecute) {
+			return nil, sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", wf.WorkflowData.Node.Name)
+		}
+
+		run, err = workflow.CreateRun(api.mustDB(), wf, opts, consumer)
+		if err != nil {
+			return nil, err
+		}
+		message = fmt.Sprintf("%s/%s #%d started", cmd.ProjectKey, cmd.WorkflowName, run.Number)
+	}
+
+	sdk.GoRoutine(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", run.ID), func(ctx context.Context) {
+		api.initWorkflowRun(ctx, api.mustDB(), api.Cache, p, wf, run, opts, consumer)
+	}, api.PanicDump())
+
+	return &sdk.ChatOpsCommandResult{
+		Message:     message,
+		WorkflowRun: run,
+	}, nil
+}
@@ -27,6 +27,7 @@ type DBConnectionFactory struct {
 	DBTimeout        int
 	DBConnectTimeout int
 	DBMaxConn        int
+	DBDSN            string
 	Database         *sql.DB
 	mutex            *sync.Mutex
 }
@@ -34,10 +35,16 @@ type DBConnectionFactory struct {
 // DB returns the current sql.DB object
 func (f *DBConnectionFactory) DB() *sql.DB {
 	if f.Database == nil {
-		if f.DBName == "" {
+		if f.DBName == "" && f.DBDSN == "" {
 			return nil
 		}
-		newF, err := Init(context.TODO(), f.DBUser, f.DBRole, f.DBPassword, f.DBName, f.DBHost, f.DBPort, f.DBSSLMode, f.DBConnectTimeout, f.DBTimeout, f.DBMaxConn)
+		var newF *DBConnectionFactory
+		var err error
+		if f.DBDSN != "" {
+			newF, err = InitReplica(context.TODO(), f.DBDSN, f.DBTimeout, f.DBMaxConn)
+		} else {
+			newF, err = Init(context.TODO(), f.DBUser, f.DBRole, f.DBPassword, f.DBName, f.DBHost, f.DBPort, f.DBSSLMode, f.DBConnectTimeout, f.DBTimeout, f.DBMaxConn)
+		}
 		if err != nil {
 			log.Error(context.TODO(), "Database> cannot init db connection : %s", err)
 			return nil
@@ -79,6 +86,37 @@ func Init(ctx context.Context, user, role, password, name, host string, port int
 		mutex:            &sync.Mutex{},
 	}
 
+	if f.DBUser == "" ||
+		f.DBPassword == "" ||
+		f.DBName == "" ||
+		f.DBHost == "" ||
+		f.DBPort == 0 {
+		return nil, fmt.Errorf("Missing database infos")
+	}
+
+	if err := f.open(ctx); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// InitReplica initializes a sql.DB object connected to a read-only replica of the database, from its DSN.
+func InitReplica(ctx context.Context, dsn string, timeout, maxconn int) (*DBConnectionFactory, error) {
+	f := &DBConnectionFactory{
+		DBDriver:  "postgres",
+		DBDSN:     dsn,
+		DBTimeout: timeout,
+		DBMaxConn: maxconn,
+		mutex:     &sync.Mutex{},
+	}
+
+	if err := f.open(ctx); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *DBConnectionFactory) open(ctx context.Context) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -91,14 +129,6 @@ func Init(ctx context.Context, user, role, password, name, host string, port int
 
 	var err error
 
-	if f.DBUser == "" ||
-		f.DBPassword == "" ||
-		f.DBName == "" ||
-		f.DBHost == "" ||
-		f.DBPort == 0 {
-		return nil, fmt.Errorf("Missing database infos")
-	}
-
 	if f.DBTimeout < 200 || f.DBTimeout > 30000 {
 		f.DBTimeout = 3000
 	}
@@ -114,12 +144,12 @@ func Init(ctx context.Context, user, role, password, name, host string, port int
 	if err != nil {
 		f.Database = nil
 		log.Error(ctx, "cannot open database: %s", err)
-		return nil, err
+		return err
 	}
 
 	if err = f.Database.Ping(); err != nil {
 		f.Database = nil
-		return nil, err
+		return err
 	}
 
 	f.Database.SetMaxOpenConns(f.DBMaxConn)
@@ -127,22 +157,25 @@ func Init(ctx context.Context, user, role, password, name, host string, port int
 
 	if _, err := f.Database.Exec(fmt.Sprintf("SET statement_timeout = %d", f.DBTimeout)); err != nil {
 		log.Error(ctx, "unable to set statement_timeout with %d on database: %s", f.DBTimeout, err)
-		return nil, sdk.WrapError(err, "unable to set statement_timeout with %d", f.DBTimeout)
+		return sdk.WrapError(err, "unable to set statement_timeout with %d", f.DBTimeout)
 	}
 
 	// Set role if specified
-	if role != "" {
-		log.Debug("database> setting role %s on database", role)
-		if _, err := f.Database.Exec("SET ROLE '" + role + "'"); err != nil {
-			log.Error(ctx, "unable to set role %s on database: %s", role, err)
-			return nil, sdk.WrapError(err, "unable to set role %s", role)
+	if f.DBRole != "" {
+		log.Debug("database> setting role %s on database", f.DBRole)
+		if _, err := f.Database.Exec("SET ROLE '" + f.DBRole + "'"); err != nil {
+			log.Error(ctx, "unable to set role %s on database: %s", f.DBRole, err)
+			return sdk.WrapError(err, "unable to set role %s", f.DBRole)
 		}
 	}
 
-	return f, nil
+	return nil
 }
 
 func (f *DBConnectionFactory) dsn() string {
+	if f.DBDSN != "" {
+		return f.DBDSN
+	}
 	return fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%d sslmode=%s connect_timeout=%d", f.DBUser, f.DBPassword, f.DBName, f.DBHost, f.DBPort, f.DBSSLMode, f.DBConnectTimeout)
 }
 
@@ -0,0 +1,391 @@
+package dbpartition
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Table is a table partitioned by month.
+type Table struct {
+	Name string
+	// ByID is set for the tables referenced by foreign keys, which are partitioned on their id instead of their created
+	// column: the ids of a month are allocated in its own range, see MonthID.
+	ByID bool
+	// Unique are the columns unique in each partition. The unique indexes of a partitioned table must contain its
+	// partition key, so they can't be unique across the partitions.
+	Unique []string
+}
+
+// Tables are the partitioned tables. The tables referencing the others come first, so their partitions are dropped
+// before the partitions they reference.
+var Tables = []Table{
+	{Name: "workflow_node_run_job_logs"},
+	{Name: "requirement_service_logs"},
+	{Name: "workflow_node_run", ByID: true, Unique: []string{"workflow_run_id", "workflow_node_id", "num", "sub_num"}},
+	{Name: "workflow_run", ByID: true},
+}
+
+// Partition is a range partition of a table, containing the rows created between From (included) and To (excluded).
+// From is zero for the legacy partition, which contains all the rows created before the partitioning.
+type Partition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+var boundRegexp = regexp.MustCompile(`^FOR VALUES FROM \((.+)\) TO \((.+)\)$`)
+
+// identifierRegexp matches the names of the tables and of the partitions, which are interpolated in the queries.
+var identifierRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// boundLayout is the layout of the bounds of the partitions created by Maintain.
+const boundLayout = "2006-01-02 15:04:05-07"
+
+var boundLayouts = []string{"2006-01-02 15:04:05-07", "2006-01-02 15:04:05-07:00", "2006-01-02 15:04:05.999999-07", "2006-01-02 15:04:05.999999-07:00"}
+
+// Month returns the start of the month of t, in UTC.
+func Month(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// MonthID returns the first id of the range of a month, for the tables partitioned by id. Each month has a range of
+// 2^32 ids.
+func MonthID(month time.Time) int64 {
+	month = month.UTC()
+	return int64((month.Year()-1970)*12+int(month.Month())-1) << 32
+}
+
+// idMonth returns the month of the range of an id.
+func idMonth(id int64) time.Time {
+	n := int(id >> 32)
+	return time.Date(1970+n/12, time.Month(n%12+1), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Name returns the name of the partition of a table for a month.
+func Name(table string, month time.Time) string {
+	return table + "_" + month.UTC().Format("200601")
+}
+
+// Maintain creates the partitions of the current month and of the premake next months for all the partitioned
+// tables, moves the sequences of the tables partitioned by id to the range of the current month, then detaches and
+// drops the partitions older than retention months. A zero retention keeps all the partitions. The tables which are
+// not partitioned are ignored.
+func Maintain(ctx context.Context, db *gorp.DbMap, now time.Time, premake, retention int) error {
+	for _, table := range Tables {
+		partitioned, err := isPartitioned(db, table.Name)
+		if err != nil {
+			return err
+		}
+		if !partitioned {
+			log.Debug("dbpartition.Maintain> table %s is not partitioned", table.Name)
+			continue
+		}
+
+		partitions, err := Load(db, table)
+		if err != nil {
+			return err
+		}
+		for _, month := range missingMonths(partitions, now, premake) {
+			if err := create(db, table, month); err != nil {
+				return err
+			}
+			log.Info(ctx, "dbpartition.Maintain> partition %s created", Name(table.Name, month))
+		}
+
+		if table.ByID {
+			moved, err := moveSequence(db, table.Name, Month(now))
+			if err != nil {
+				return err
+			}
+			if moved {
+				log.Info(ctx, "dbpartition.Maintain> ids of %s allocated from %d", table.Name, MonthID(Month(now)))
+			}
+		}
+
+		if retention <= 0 {
+			continue
+		}
+		for _, p := range expiredPartitions(partitions, now, retention) {
+			if err := drop(db, table, p); err != nil {
+				return err
+			}
+			log.Info(ctx, "dbpartition.Maintain> partition %s dropped", p.Name)
+		}
+	}
+	return nil
+}
+
+// Load returns the range partitions of a table sorted by name, the default partition is ignored.
+func Load(db gorp.SqlExecutor, table Table) ([]Partition, error) {
+	query := `
+	SELECT c.relname, pg_get_expr(c.relpartbound, c.oid)
+	FROM pg_inherits i
+	JOIN pg_class c ON c.oid = i.inhrelid
+	JOIN pg_class p ON p.oid = i.inhparent
+	WHERE p.relname = $1
+	ORDER BY c.relname`
+	rows, err := db.Query(query, table.Name)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load partitions of %s", table.Name)
+	}
+	defer rows.Close() // nolint
+
+	var partitions []Partition
+	for rows.Next() {
+		var name, bound string
+		if err := rows.Scan(&name, &bound); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		if bound == "DEFAULT" {
+			continue
+		}
+		from, to, err := parseBound(bound, table.ByID)
+		if err != nil {
+			return nil, sdk.WrapError(err, "invalid bound of partition %s", name)
+		}
+		partitions = append(partitions, Partition{Name: name, From: from, To: to})
+	}
+	return partitions, sdk.WithStack(rows.Err())
+}
+
+func isPartitioned(db gorp.SqlExecutor, table string) (bool, error) {
+	n, err := db.SelectInt("SELECT COUNT(1) FROM pg_class WHERE relname = $1 AND relkind = 'p'", table)
+	if err != nil {
+		return false, sdk.WrapError(err, "cannot check partitioning of %s", table)
+	}
+	return n > 0, nil
+}
+
+// checkIdentifier returns an error if a table or a partition name is not a plain lowercase identifier.
+func checkIdentifier(name string) error {
+	if !identifierRegexp.MatchString(name) {
+		return sdk.WithStack(fmt.Errorf("invalid table name %q", name))
+	}
+	return nil
+}
+
+// checkMonth returns an error if month is not the start of a month in UTC, the bounds of the partitions are
+// formatted from it.
+func checkMonth(month time.Time) error {
+	if !month.Equal(Month(month)) || month.Location() != time.UTC {
+		return sdk.WithStack(fmt.Errorf("invalid partition month %s", month))
+	}
+	return nil
+}
+
+func create(db gorp.SqlExecutor, table Table, month time.Time) error {
+	name := Name(table.Name, month)
+	for _, n := range append([]string{table.Name, name}, table.Unique...) {
+		if err := checkIdentifier(n); err != nil {
+			return err
+		}
+	}
+	if err := checkMonth(month); err != nil {
+		return err
+	}
+	from, to := "'"+month.Format(boundLayout)+"'", "'"+month.AddDate(0, 1, 0).Format(boundLayout)+"'"
+	if table.ByID {
+		from, to = strconv.FormatInt(MonthID(month), 10), strconv.FormatInt(MonthID(month.AddDate(0, 1, 0)), 10)
+	}
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM (%s) TO (%s)",
+		pq.QuoteIdentifier(name), pq.QuoteIdentifier(table.Name), from, to)
+	if _, err := db.Exec(query); err != nil {
+		return sdk.WrapError(err, "cannot create partition %s", name)
+	}
+
+	if len(table.Unique) > 0 {
+		columns := make([]string, len(table.Unique))
+		for i := range table.Unique {
+			columns[i] = pq.QuoteIdentifier(table.Unique[i])
+		}
+		query := fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (%s)",
+			pq.QuoteIdentifier(name+"_unique"), pq.QuoteIdentifier(name), strings.Join(columns, ", "))
+		if _, err := db.Exec(query); err != nil {
+			return sdk.WrapError(err, "cannot create unique index of partition %s", name)
+		}
+	}
+	return nil
+}
+
+// drop detaches the partition before dropping it, so the lock on the partitioned table is held the shortest time.
+// A partition of a table partitioned by id can't be detached while rows reference it, they are deleted before.
+func drop(db gorp.SqlExecutor, table Table, p Partition) error {
+	for _, name := range []string{table.Name, p.Name} {
+		if err := checkIdentifier(name); err != nil {
+			return err
+		}
+	}
+	if table.ByID {
+		if err := deleteReferences(db, table.Name, p); err != nil {
+			return err
+		}
+	}
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", pq.QuoteIdentifier(table.Name), pq.QuoteIdentifier(p.Name))); err != nil {
+		return sdk.WrapError(err, "cannot detach partition %s", p.Name)
+	}
+	if _, err := db.Exec(fmt.Sprintf("DROP TABLE %s", pq.QuoteIdentifier(p.Name))); err != nil {
+		return sdk.WrapError(err, "cannot drop partition %s", p.Name)
+	}
+	return nil
+}
+
+// deleteReferences deletes the rows of the other tables referencing the ids of a partition, their foreign keys
+// cascade the deletion.
+func deleteReferences(db gorp.SqlExecutor, table string, p Partition) error {
+	query := `
+	SELECT r.relname, a.attname
+	FROM pg_constraint c
+	JOIN pg_class t ON t.oid = c.confrelid
+	JOIN pg_class r ON r.oid = c.conrelid
+	JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = c.conkey[1]
+	WHERE c.contype = 'f' AND c.conparentid = 0 AND t.relname = $1`
+	rows, err := db.Query(query, table)
+	if err != nil {
+		return sdk.WrapError(err, "cannot load the references of %s", table)
+	}
+	type reference struct{ table, column string }
+	var refs []reference
+	for rows.Next() {
+		var ref reference
+		if err := rows.Scan(&ref.table, &ref.column); err != nil {
+			rows.Close() // nolint
+			return sdk.WithStack(err)
+		}
+		refs = append(refs, ref)
+	}
+	if err := rows.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	for _, ref := range refs {
+		for _, name := range []string{ref.table, ref.column} {
+			if err := checkIdentifier(name); err != nil {
+				return err
+			}
+		}
+		query := fmt.Sprintf("DELETE FROM %s WHERE %s < $1", pq.QuoteIdentifier(ref.table), pq.QuoteIdentifier(ref.column))
+		args := []interface{}{MonthID(p.To)}
+		if !p.From.IsZero() {
+			query += fmt.Sprintf(" AND %s >= $2", pq.QuoteIdentifier(ref.column))
+			args = append(args, MonthID(p.From))
+		}
+		if _, err := db.Exec(query, args...); err != nil {
+			return sdk.WrapError(err, "cannot delete the rows of %s referencing partition %s", ref.table, p.Name)
+		}
+	}
+	return nil
+}
+
+// moveSequence moves the sequence of the id of a table to the range of a month, if it still allocates the ids of the
+// previous months. The migrate services take a lock, so a sequence is never moved back after ids of the month were
+// allocated.
+func moveSequence(db *gorp.DbMap, table string, month time.Time) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", "dbpartition.sequence."+table); err != nil {
+		return false, sdk.WrapError(err, "cannot lock the sequence of %s", table)
+	}
+	query := `
+	SELECT setval(s.seq, $2 - 1)
+	FROM (SELECT pg_get_serial_sequence($1, 'id')::regclass AS seq) s
+	WHERE COALESCE(pg_sequence_last_value(s.seq), 0) < $2 - 1`
+	res, err := tx.Exec(query, table, MonthID(month))
+	if err != nil {
+		return false, sdk.WrapError(err, "cannot move the sequence of %s", table)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, sdk.WithStack(err)
+	}
+	return n > 0, nil
+}
+
+// parseBound parses the bound of a range partition returned by pg_get_expr: FOR VALUES FROM ('...') TO ('...').
+func parseBound(bound string, byID bool) (time.Time, time.Time, error) {
+	m := boundRegexp.FindStringSubmatch(bound)
+	if m == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported partition bound %q", bound)
+	}
+	from, err := parseBoundValue(m[1], byID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := parseBoundValue(m[2], byID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to, nil
+}
+
+// parseBoundValue returns the month of a bound, the bounds of the tables partitioned by id are the first ids of
+// the ranges of the months.
+func parseBoundValue(v string, byID bool) (time.Time, error) {
+	if v == "MINVALUE" {
+		return time.Time{}, nil
+	}
+	v = strings.Trim(v, "'")
+	if byID {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unsupported partition bound value %q", v)
+		}
+		return idMonth(id), nil
+	}
+	for _, layout := range boundLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported partition bound value %q", v)
+}
+
+// missingMonths returns the months from the month of now to the premake next months which are not covered by
+// a partition.
+func missingMonths(partitions []Partition, now time.Time, premake int) []time.Time {
+	var months []time.Time
+	for i := 0; i <= premake; i++ {
+		month := Month(now).AddDate(0, i, 0)
+		var covered bool
+		for _, p := range partitions {
+			if !month.Before(p.From) && month.Before(p.To) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			months = append(months, month)
+		}
+	}
+	return months
+}
+
+// expiredPartitions returns the partitions containing only rows created more than retention months before the
+// month of now.
+func expiredPartitions(partitions []Partition, now time.Time, retention int) []Partition {
+	limit := Month(now).AddDate(0, -retention, 0)
+	var expired []Partition
+	for _, p := range partitions {
+		if !p.To.After(limit) {
+			expired = append(expired, p)
+		}
+	}
+	return expired
+}
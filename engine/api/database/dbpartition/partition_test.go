@@ -0,0 +1,81 @@
+package dbpartition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBound(t *testing.T) {
+	from, to, err := parseBound("FOR VALUES FROM ('2020-01-01 00:00:00+00') TO ('2020-02-01 01:00:00+01')", false)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), to)
+
+	from, to, err = parseBound("FOR VALUES FROM (MINVALUE) TO ('2020-01-01 05:30:00+05:30')", false)
+	require.NoError(t, err)
+	require.True(t, from.IsZero())
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), to)
+
+	_, _, err = parseBound("FOR VALUES IN (1)", false)
+	require.Error(t, err)
+
+	// the bounds of the tables partitioned by id are the first ids of the months
+	from, to, err = parseBound("FOR VALUES FROM ('2576980377600') TO ('2581275344896')", true)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), to)
+
+	_, _, err = parseBound("FOR VALUES FROM ('2020-01-01 00:00:00+00') TO ('2020-02-01 00:00:00+00')", true)
+	require.Error(t, err)
+}
+
+func TestMonthID(t *testing.T) {
+	require.Equal(t, int64(0), MonthID(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, int64(600)<<32, MonthID(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, MonthID(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))+1<<32, MonthID(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, month := range []time.Time{
+		time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		require.Equal(t, month, idMonth(MonthID(month)))
+		require.Equal(t, month, idMonth(MonthID(month.AddDate(0, 1, 0))-1))
+	}
+	// the ids stay exact in javascript
+	require.True(t, MonthID(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)) < 1<<53)
+}
+
+func TestMaintainPartitions(t *testing.T) {
+	now := time.Date(2020, 3, 15, 10, 0, 0, 0, time.UTC)
+	partitions := []Partition{
+		{Name: "logs_legacy", To: time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "logs_201912", From: time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "logs_202001", From: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "logs_202003", From: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	months := missingMonths(partitions, now, 2)
+	require.Equal(t, []time.Time{time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)}, months)
+	require.Equal(t, "logs_202004", Name("logs", months[0]))
+
+	expired := expiredPartitions(partitions, now, 2)
+	require.Len(t, expired, 2)
+	require.Equal(t, "logs_legacy", expired[0].Name)
+	require.Equal(t, "logs_201912", expired[1].Name)
+}
+
+func TestCheckPartitionBounds(t *testing.T) {
+	require.NoError(t, checkIdentifier("workflow_node_run_job_logs"))
+	require.NoError(t, checkIdentifier(Name("requirement_service_logs", time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC))))
+	require.Error(t, checkIdentifier("logs; DROP TABLE workflow"))
+	require.Error(t, checkIdentifier(`"logs"`))
+	require.Error(t, checkIdentifier(""))
+
+	require.NoError(t, checkMonth(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)))
+	require.Error(t, checkMonth(time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC)))
+	require.Error(t, checkMonth(time.Date(2020, 3, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))))
+	require.Equal(t, "2020-03-01 00:00:00+00", time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC).Format(boundLayout))
+}
@@ -98,7 +98,7 @@ func (c *TypeConverter) FromDb(target interface{}) (gorp.CustomScanner, bool) {
 			if err != nil {
 				return err
 			}
-			*t = *date
+			t.Seconds, t.Nanos = date.Seconds, date.Nanos
 			return nil
 		}
 		return gorp.CustomScanner{Holder: new(time.Time), Target: new(timestamp.Timestamp), Binder: binder}, true
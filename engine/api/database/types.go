@@ -25,6 +25,13 @@ type DBConfiguration struct {
 	EncryptionKey  RollingKeyConfig `json:"-" toml:"encryptionRollingKeys" comment:"Encryption rolling keys" mapstructure:"encryptionRollingKeys"`
 }
 
+// DBReplicaConfiguration is the exposed type for the configuration of a read-only replica of the database
+type DBReplicaConfiguration struct {
+	DSN     string `toml:"dsn" default:"" commented:"true" comment:"Replica DSN, example: host=replica port=5432 user=cds password=cds dbname=cds sslmode=disable connect_timeout=10" json:"-"`
+	MaxConn int    `toml:"maxconn" default:"20" comment:"DB Max connection" json:"maxconn"`
+	Timeout int    `toml:"timeout" default:"10000" comment:"Statement timeout value in milliseconds" json:"timeout"`
+}
+
 type RollingKeyConfig struct {
 	Cipher string      `toml:"cipher" mapstructure:"cipher"`
 	Keys   []KeyConfig `toml:"keys" mapstructure:"keys"`
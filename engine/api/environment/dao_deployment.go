@@ -0,0 +1,49 @@
+package environment
+
+import (
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// UpsertDeployment saves the last deployment of an application on an environment. A deployment older than the
+// saved one is ignored.
+func UpsertDeployment(db gorp.SqlExecutor, d *sdk.EnvironmentDeployment) error {
+	if _, err := db.Exec(`
+		INSERT INTO environment_deployment (project_id, application_id, environment_id, workflow_id, workflow_run_id,
+			workflow_node_run_id, run_number, node_name, version, vcs_repository, vcs_branch, vcs_tag, vcs_hash, artifacts,
+			deployed_by, deployed)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (application_id, environment_id) DO UPDATE SET
+			project_id = EXCLUDED.project_id, workflow_id = EXCLUDED.workflow_id, workflow_run_id = EXCLUDED.workflow_run_id,
+			workflow_node_run_id = EXCLUDED.workflow_node_run_id, run_number = EXCLUDED.run_number, node_name = EXCLUDED.node_name,
+			version = EXCLUDED.version, vcs_repository = EXCLUDED.vcs_repository, vcs_branch = EXCLUDED.vcs_branch,
+			vcs_tag = EXCLUDED.vcs_tag, vcs_hash = EXCLUDED.vcs_hash, artifacts = EXCLUDED.artifacts,
+			deployed_by = EXCLUDED.deployed_by, deployed = EXCLUDED.deployed
+		WHERE environment_deployment.deployed <= EXCLUDED.deployed`,
+		d.ProjectID, d.ApplicationID, d.EnvironmentID, d.WorkflowID, d.WorkflowRunID,
+		d.WorkflowNodeRunID, d.RunNumber, d.NodeName, d.Version, d.VCSRepository, d.VCSBranch, d.VCSTag, d.VCSHash, d.Artifacts,
+		d.DeployedBy, d.Deployed); err != nil {
+		return sdk.WrapError(err, "cannot save deployment of application %d on environment %d", d.ApplicationID, d.EnvironmentID)
+	}
+	return nil
+}
+
+// LoadDeploymentsByProjectID returns the last deployments of the applications of a project, optionally filtered by
+// application and environment names.
+func LoadDeploymentsByProjectID(db gorp.SqlExecutor, projectID int64, applicationName, environmentName string) ([]sdk.EnvironmentDeployment, error) {
+	ds := []sdk.EnvironmentDeployment{}
+	if _, err := db.Select(&ds, `
+		SELECT environment_deployment.*, application.name AS application_name, environment.name AS environment_name,
+			workflow.name AS workflow_name
+		FROM environment_deployment
+		JOIN application ON application.id = environment_deployment.application_id
+		JOIN environment ON environment.id = environment_deployment.environment_id
+		JOIN workflow ON workflow.id = environment_deployment.workflow_id
+		WHERE environment_deployment.project_id = $1
+		AND ($2 = '' OR application.name = $2) AND ($3 = '' OR environment.name = $3)
+		ORDER BY application.name, environment.name`, projectID, applicationName, environmentName); err != nil {
+		return nil, sdk.WrapError(err, "cannot load deployments of project %d", projectID)
+	}
+	return ds, nil
+}
@@ -0,0 +1,40 @@
+package environment
+
+import (
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadRollout returns the rollout of an environment, or nil if the environment has no rollout.
+func LoadRollout(db gorp.SqlExecutor, environmentID int64) (*sdk.DeploymentRollout, error) {
+	var r sdk.DeploymentRollout
+	if err := db.QueryRow("SELECT rollout FROM environment_rollout WHERE environment_id = $1", environmentID).Scan(&r); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "cannot load rollout of environment %d", environmentID)
+	}
+	return &r, nil
+}
+
+// UpsertRollout saves the rollout of an environment, the rollout is deleted if nil.
+func UpsertRollout(db gorp.SqlExecutor, environmentID int64, r *sdk.DeploymentRollout) error {
+	if r == nil {
+		if _, err := db.Exec("DELETE FROM environment_rollout WHERE environment_id = $1", environmentID); err != nil {
+			return sdk.WrapError(err, "cannot delete rollout of environment %d", environmentID)
+		}
+		return nil
+	}
+	if err := r.IsValid(); err != nil {
+		return err
+	}
+	if _, err := db.Exec(`
+		INSERT INTO environment_rollout (environment_id, rollout) VALUES ($1, $2)
+		ON CONFLICT (environment_id) DO UPDATE SET rollout = EXCLUDED.rollout`, environmentID, r); err != nil {
+		return sdk.WrapError(err, "cannot save rollout of environment %d", environmentID)
+	}
+	return nil
+}
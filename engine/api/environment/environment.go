@@ -175,6 +175,12 @@ func loadDependencies(db gorp.SqlExecutor, env *sdk.Environment) error {
 		return sdk.WrapError(errK, "loadDependencies> Cannot load environment dependencies")
 	}
 
+	rollout, err := LoadRollout(db, env.ID)
+	if err != nil {
+		return err
+	}
+	env.Rollout = rollout
+
 	return nil
 }
 
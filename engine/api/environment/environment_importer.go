@@ -57,6 +57,10 @@ func Import(db gorp.SqlExecutor, proj *sdk.Project, env *sdk.Environment, msgCha
 		}
 	}
 
+	if err := UpsertRollout(db, env.ID, env.Rollout); err != nil {
+		return err
+	}
+
 	if msgChan != nil {
 		msgChan <- sdk.NewMessage(sdk.MsgEnvironmentCreated, env.Name)
 	}
@@ -112,6 +116,10 @@ func ImportInto(db gorp.SqlExecutor, proj *sdk.Project, env *sdk.Environment, in
 		return sdk.WrapError(err, "unable to update environment")
 	}
 
+	if err := UpsertRollout(db, into.ID, env.Rollout); err != nil {
+		return err
+	}
+
 	log.Debug("ImportInto> Done")
 
 	return nil
@@ -55,6 +55,13 @@ func ParseAndImport(db gorp.SqlExecutor, proj *sdk.Project, eenv *exportentities
 		env.ID = oldEnv.ID
 	}
 
+	if eenv.Rollout != nil {
+		if err := eenv.Rollout.IsValid(); err != nil {
+			return nil, nil, sdk.WrapError(err, "invalid rollout of environment %s", eenv.Name)
+		}
+		env.Rollout = eenv.Rollout
+	}
+
 	//Compute variables
 	for p, v := range eenv.Values {
 		switch v.Type {
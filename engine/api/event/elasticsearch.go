@@ -68,3 +68,20 @@ func GetEvents(ctx context.Context, db gorp.SqlExecutor, store cache.Store, filt
 	}
 	return events, nil
 }
+
+// ScrubInElasticSearch pseudonymizes a deleted user's identifiers in events stored in elasticsearch.
+func ScrubInElasticSearch(ctx context.Context, db gorp.SqlExecutor, req sdk.EventScrubRequest) (int64, error) {
+	srvs, err := services.LoadAllByType(ctx, db, services.TypeElasticsearch)
+	if err != nil {
+		return 0, sdk.WrapError(err, "Unable to get elasticsearch service")
+	}
+	if len(srvs) == 0 {
+		return 0, nil
+	}
+
+	var res sdk.EventScrubResponse
+	if _, _, err := services.NewClient(db, srvs).DoJSONRequest(ctx, "POST", "/events/scrub", req, &res); err != nil {
+		return 0, sdk.WrapError(err, "Unable to scrub events")
+	}
+	return res.Updated, nil
+}
@@ -42,10 +42,57 @@ func getBroker(ctx context.Context, t string, option interface{}) (Broker, error
 	case "kafka":
 		k := &KafkaClient{}
 		return k.initialize(ctx, option)
+	case "nats":
+		n := &NATSClient{}
+		return n.initialize(ctx, option)
 	}
 	return nil, fmt.Errorf("Invalid Broker Type %s", t)
 }
 
+// isNATSIntegration returns true if given event integration should be sent to NATS. Public models
+// can be imported with any name, so the config is also checked.
+func isNATSIntegration(m sdk.IntegrationModel, cfg sdk.IntegrationConfig) bool {
+	if m.Name == sdk.NATSIntegrationModel || m.Identifier == sdk.NATSIntegration.Identifier {
+		return true
+	}
+	_, hasSubject := cfg["subject"]
+	_, hasBrokerURL := cfg["broker url"]
+	return hasSubject && !hasBrokerURL
+}
+
+// getIntegrationBroker returns a broker for given event integration model and config.
+func getIntegrationBroker(ctx context.Context, m sdk.IntegrationModel, cfg sdk.IntegrationConfig) (Broker, error) {
+	switch {
+	case isNATSIntegration(m, cfg):
+		jetStream, _ := strconv.ParseBool(cfg["jetstream"].Value)
+		broker, err := getBroker(ctx, "nats", NATSConfig{
+			Enabled:   true,
+			URL:       cfg["url"].Value,
+			User:      cfg["username"].Value,
+			Password:  cfg["password"].Value,
+			Subject:   cfg["subject"].Value,
+			JetStream: jetStream,
+		})
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot get broker for %s and user %s", cfg["url"].Value, cfg["username"].Value)
+		}
+		return broker, nil
+	default:
+		broker, err := getBroker(ctx, "kafka", KafkaConfig{
+			Enabled:         true,
+			BrokerAddresses: cfg["broker url"].Value,
+			User:            cfg["username"].Value,
+			Password:        cfg["password"].Value,
+			Topic:           cfg["topic"].Value,
+			MaxMessageByte:  10000000,
+		})
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot get broker for %s and user %s", cfg["broker url"].Value, cfg["username"].Value)
+		}
+		return broker, nil
+	}
+}
+
 func ResetPublicIntegrations(ctx context.Context, db *gorp.DbMap) error {
 	filterType := sdk.IntegrationTypeEvent
 	integrations, err := integration.LoadPublicModelsByType(db, &filterType, true)
@@ -55,21 +102,12 @@ func ResetPublicIntegrations(ctx context.Context, db *gorp.DbMap) error {
 
 	for _, integration := range integrations {
 		for _, cfg := range integration.PublicConfigurations {
-			kafkaCfg := KafkaConfig{
-				Enabled:         true,
-				BrokerAddresses: cfg["broker url"].Value,
-				User:            cfg["username"].Value,
-				Password:        cfg["password"].Value,
-				Topic:           cfg["topic"].Value,
-				MaxMessageByte:  10000000,
-			}
-
-			kafkaBroker, errk := getBroker(ctx, "kafka", kafkaCfg)
-			if errk != nil {
-				return sdk.WrapError(errk, "cannot get broker for %s and user %s", cfg["broker url"].Value, cfg["username"].Value)
+			broker, err := getIntegrationBroker(ctx, integration, cfg)
+			if err != nil {
+				return err
 			}
 
-			publicBrokersConnectionCache = append(publicBrokersConnectionCache, kafkaBroker)
+			publicBrokersConnectionCache = append(publicBrokersConnectionCache, broker)
 		}
 	}
 
@@ -91,20 +129,12 @@ func ResetEventIntegration(ctx context.Context, db gorp.SqlExecutor, eventIntegr
 		return fmt.Errorf("cannot load project integration id %d and type event: %v", eventIntegrationID, err)
 	}
 
-	kafkaCfg := KafkaConfig{
-		Enabled:         true,
-		BrokerAddresses: projInt.Config["broker url"].Value,
-		User:            projInt.Config["username"].Value,
-		Password:        projInt.Config["password"].Value,
-		Topic:           projInt.Config["topic"].Value,
-		MaxMessageByte:  10000000,
-	}
-	kafkaBroker, errk := getBroker(ctx, "kafka", kafkaCfg)
-	if errk != nil {
-		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot get broker for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, errk)
+	broker, err := getIntegrationBroker(ctx, projInt.Model, projInt.Config)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "%v", err)
 	}
-	if err := brokersConnectionCache.Add(brokerConnectionKey, kafkaBroker, gocache.DefaultExpiration); err != nil {
-		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot add broker in cache for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, err)
+	if err := brokersConnectionCache.Add(brokerConnectionKey, broker, gocache.DefaultExpiration); err != nil {
+		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot add broker in cache for integration %s: %v", projInt.Name, err)
 	}
 	return nil
 }
@@ -173,24 +203,16 @@ func DequeueEvent(ctx context.Context, db *gorp.DbMap) {
 					continue
 				}
 
-				kafkaCfg := KafkaConfig{
-					Enabled:         true,
-					BrokerAddresses: projInt.Config["broker url"].Value,
-					User:            projInt.Config["username"].Value,
-					Password:        projInt.Config["password"].Value,
-					Topic:           projInt.Config["topic"].Value,
-					MaxMessageByte:  10000000,
-				}
-				kafkaBroker, errk := getBroker(ctx, "kafka", kafkaCfg)
-				if errk != nil {
-					log.Error(ctx, "Event.DequeueEvent> cannot get broker for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, errk)
+				broker, err := getIntegrationBroker(ctx, projInt.Model, projInt.Config)
+				if err != nil {
+					log.Error(ctx, "Event.DequeueEvent> %v", err)
 					continue
 				}
-				if err := brokersConnectionCache.Add(brokerConnectionKey, kafkaBroker, gocache.DefaultExpiration); err != nil {
-					log.Error(ctx, "Event.DequeueEvent> cannot add broker in cache for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, err)
+				if err := brokersConnectionCache.Add(brokerConnectionKey, broker, gocache.DefaultExpiration); err != nil {
+					log.Error(ctx, "Event.DequeueEvent> cannot add broker in cache for integration %s: %v", projInt.Name, err)
 					continue
 				}
-				brokerConnection = kafkaBroker
+				brokerConnection = broker
 			}
 
 			broker, ok := brokerConnection.(Broker)
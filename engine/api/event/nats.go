@@ -0,0 +1,114 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// NATSClient enbeddes the NATS connection
+type NATSClient struct {
+	options NATSConfig
+	conn    *nats.Conn
+}
+
+// NATSConfig handles all config to connect to NATS
+type NATSConfig struct {
+	Enabled   bool
+	URL       string
+	User      string
+	Password  string
+	Subject   string
+	JetStream bool
+}
+
+// natsJetStreamTimeout is the max duration to wait for a JetStream acknowledgement.
+const natsJetStreamTimeout = 5 * time.Second
+
+// natsJetStreamAck is the acknowledgement sent by JetStream for a published message.
+type natsJetStreamAck struct {
+	Stream   string `json:"stream"`
+	Sequence uint64 `json:"seq"`
+	Error    *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error,omitempty"`
+}
+
+// initialize returns broker, isInit and err if
+func (c *NATSClient) initialize(ctx context.Context, options interface{}) (Broker, error) {
+	conf, ok := options.(NATSConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid NATS Initialization")
+	}
+
+	if conf.URL == "" || conf.Subject == "" {
+		return nil, fmt.Errorf("initNATS> Invalid NATS Configuration")
+	}
+	c.options = conf
+
+	opts := []nats.Option{
+		nats.Name("cds-api-" + cdsname),
+		nats.MaxReconnects(-1),
+	}
+	if conf.User != "" {
+		opts = append(opts, nats.UserInfo(conf.User, conf.Password))
+	}
+
+	conn, err := nats.Connect(conf.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("initNATS> Error with connection on %s user:%s: %v", conf.URL, conf.User, err)
+	}
+	c.conn = conn
+
+	log.Debug("initNATS> NATS used at %s on subject:%s (jetstream:%t)", conf.URL, conf.Subject, conf.JetStream)
+	return c, nil
+}
+
+// close drains and closes the connection
+func (c *NATSClient) close(ctx context.Context) {
+	if c.conn != nil {
+		if err := c.conn.Drain(); err != nil {
+			log.Warning(ctx, "closeNATS> Error while closing NATS connection:%s", err.Error())
+		}
+	}
+}
+
+// sendEvent publishes the event on the NATS subject, with JetStream the acknowledgement of the stream is expected
+func (c *NATSClient) sendEvent(event *sdk.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if !c.options.JetStream {
+		return c.conn.Publish(c.options.Subject, data)
+	}
+
+	msg, err := c.conn.Request(c.options.Subject, data, natsJetStreamTimeout)
+	if err != nil {
+		return fmt.Errorf("no JetStream acknowledgement on subject %s: %v", c.options.Subject, err)
+	}
+	var ack natsJetStreamAck
+	if err := json.Unmarshal(msg.Data, &ack); err != nil {
+		return fmt.Errorf("invalid JetStream acknowledgement on subject %s: %v", c.options.Subject, err)
+	}
+	if ack.Error != nil {
+		return fmt.Errorf("JetStream error on subject %s: %d %s", c.options.Subject, ack.Error.Code, ack.Error.Description)
+	}
+	return nil
+}
+
+// status returns the state of the NATS connection
+func (c *NATSClient) status() string {
+	if c.conn == nil || !c.conn.IsConnected() {
+		return "NATS KO"
+	}
+	return "NATS OK"
+}
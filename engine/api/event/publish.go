@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fatih/structs"
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 )
 
 var store cache.Store
@@ -23,6 +25,8 @@ func publishEvent(ctx context.Context, e sdk.Event) error {
 		return err
 	}
 
+	invalidateCache(ctx, e)
+
 	// send to cache for cds repositories manager
 	var toSkipSendReposManager bool
 	// the StatusWaiting is not useful to be sent on repomanager.
@@ -45,6 +49,30 @@ func publishEvent(ctx context.Context, e sdk.Event) error {
 	return store.Publish(ctx, "events_pubsub", string(b))
 }
 
+// invalidateCache makes the entries cached for the project or the workflow of an entity change event stale, on all
+// the API instances.
+func invalidateCache(ctx context.Context, e sdk.Event) {
+	if e.ProjectKey == "" || strings.HasPrefix(e.EventType, "sdk.EventRun") {
+		return
+	}
+	var entity cache.Entity
+	switch {
+	case strings.HasPrefix(e.EventType, "sdk.EventWorkflow") && e.WorkflowName != "":
+		entity = cache.WorkflowEntity(e.ProjectKey, e.WorkflowName)
+	case strings.HasPrefix(e.EventType, "sdk.EventProject"),
+		strings.HasPrefix(e.EventType, "sdk.EventApplication"),
+		strings.HasPrefix(e.EventType, "sdk.EventPipeline"),
+		strings.HasPrefix(e.EventType, "sdk.EventEnvironment"),
+		strings.HasPrefix(e.EventType, "sdk.EventWorkflow"):
+		entity = cache.ProjectEntity(e.ProjectKey)
+	default:
+		return
+	}
+	if err := cache.InvalidateEntity(ctx, store, entity); err != nil {
+		log.Warning(ctx, "invalidateCache> cannot invalidate cache of %s/%s: %v", e.ProjectKey, e.WorkflowName, err)
+	}
+}
+
 // Publish sends a event to a queue
 func Publish(ctx context.Context, payload interface{}, u sdk.Identifiable) {
 	p := structs.Map(payload)
@@ -0,0 +1,43 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/structs"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// PublishChatOpsCommand publishes an audit event for a command received from a chatops provider.
+func PublishChatOpsCommand(ctx context.Context, cmd sdk.ChatOpsCommand, cmdErr error, u sdk.Identifiable) {
+	e := sdk.EventChatOpsCommand{
+		Provider:       cmd.Provider,
+		ExternalUserID: cmd.ExternalUserID,
+		Command:        cmd.Command,
+		ProjectKey:     cmd.ProjectKey,
+		WorkflowName:   cmd.WorkflowName,
+		RunNumber:      cmd.RunNumber,
+		NodeName:       cmd.NodeName,
+	}
+	if cmdErr != nil {
+		e.Error = sdk.Cause(cmdErr).Error()
+	}
+
+	event := sdk.Event{
+		Timestamp:      time.Now(),
+		Hostname:       hostname,
+		CDSName:        cdsname,
+		EventType:      fmt.Sprintf("%T", e),
+		Payload:        structs.Map(e),
+		ProjectKey:     cmd.ProjectKey,
+		WorkflowName:   cmd.WorkflowName,
+		WorkflowRunNum: cmd.RunNumber,
+	}
+	if u != nil {
+		event.Username = u.GetUsername()
+		event.UserMail = u.GetEmail()
+	}
+	_ = publishEvent(ctx, event)
+}
@@ -164,13 +164,17 @@ func PublishWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 // PublishWorkflowNodeJobRun publish a WorkflowNodeJobRun
 func PublishWorkflowNodeJobRun(ctx context.Context, db gorp.SqlExecutor, pkey string, wr sdk.WorkflowRun, jr sdk.WorkflowNodeJobRun) {
 	e := sdk.EventRunWorkflowJob{
-		ID:     jr.ID,
-		Status: jr.Status,
-		Start:  jr.Start.Unix(),
+		ID:           jr.ID,
+		Status:       jr.Status,
+		Start:        jr.Start.Unix(),
+		ExecGroupIDs: make([]int64, len(jr.ExecGroups)),
+	}
+	for i := range jr.ExecGroups {
+		e.ExecGroupIDs[i] = jr.ExecGroups[i].ID
 	}
 
 	if sdk.StatusIsTerminated(jr.Status) {
 		e.Done = jr.Done.Unix()
 	}
-	publishRunWorkflow(ctx, e, pkey, wr.Workflow.Name, "", "", "", 0, 0, jr.Status, nil, wr.Workflow.EventIntegrations)
+	publishRunWorkflow(ctx, e, pkey, wr.Workflow.Name, "", "", "", wr.Number, 0, jr.Status, nil, wr.Workflow.EventIntegrations)
 }
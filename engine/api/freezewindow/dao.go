@@ -0,0 +1,115 @@
+package freezewindow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func get(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) (*sdk.FreezeWindow, error) {
+	var dbW dbFreezeWindow
+	found, err := gorpmapping.Get(ctx, db, q, &dbW)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get freeze window")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.FreezeWindow(dbW)
+	return &res, nil
+}
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.FreezeWindow, error) {
+	var dbWs []dbFreezeWindow
+	if err := gorpmapping.GetAll(ctx, db, q, &dbWs); err != nil {
+		return nil, sdk.WrapError(err, "cannot get freeze windows")
+	}
+	ws := make([]sdk.FreezeWindow, len(dbWs))
+	for i := range dbWs {
+		ws[i] = sdk.FreezeWindow(dbWs[i])
+	}
+	return ws, nil
+}
+
+// LoadAllByProjectID returns all the freeze windows of given project.
+func LoadAllByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.FreezeWindow, error) {
+	return getAll(ctx, db, gorpmapping.NewQuery("SELECT * FROM project_freeze_window WHERE project_id = $1 ORDER BY name").Args(projectID))
+}
+
+// LoadByProjectIDAndName returns the freeze window of given project with given name.
+func LoadByProjectIDAndName(ctx context.Context, db gorp.SqlExecutor, projectID int64, name string) (*sdk.FreezeWindow, error) {
+	return get(ctx, db, gorpmapping.NewQuery("SELECT * FROM project_freeze_window WHERE project_id = $1 AND name = $2").Args(projectID, name))
+}
+
+// LoadActive returns the freeze windows of given project that are active on given environment at given time.
+func LoadActive(ctx context.Context, db gorp.SqlExecutor, projectID int64, env string, now time.Time) ([]sdk.FreezeWindow, error) {
+	ws, err := LoadAllByProjectID(ctx, db, projectID)
+	if err != nil {
+		return nil, err
+	}
+	var res []sdk.FreezeWindow
+	for i := range ws {
+		if active, _ := ws[i].ActiveAt(now); active && ws[i].MatchEnvironment(env) {
+			res = append(res, ws[i])
+		}
+	}
+	return res, nil
+}
+
+// Insert a freeze window in database.
+func Insert(db gorp.SqlExecutor, w *sdk.FreezeWindow) error {
+	w.Created = time.Now()
+	dbW := dbFreezeWindow(*w)
+	if err := gorpmapping.Insert(db, &dbW); err != nil {
+		return sdk.WrapError(err, "unable to insert freeze window %s", w.Name)
+	}
+	*w = sdk.FreezeWindow(dbW)
+	return nil
+}
+
+// Update a freeze window in database.
+func Update(db gorp.SqlExecutor, w *sdk.FreezeWindow) error {
+	dbW := dbFreezeWindow(*w)
+	if err := gorpmapping.Update(db, &dbW); err != nil {
+		return sdk.WrapError(err, "unable to update freeze window %s", w.Name)
+	}
+	return nil
+}
+
+// Delete a freeze window in database.
+func Delete(db gorp.SqlExecutor, w *sdk.FreezeWindow) error {
+	dbW := dbFreezeWindow(*w)
+	if err := gorpmapping.Delete(db, &dbW); err != nil {
+		return sdk.WrapError(err, "unable to delete freeze window %s", w.Name)
+	}
+	return nil
+}
+
+// InsertOverride inserts the audit of an override of freeze windows.
+func InsertOverride(db gorp.SqlExecutor, o *sdk.FreezeWindowOverride) error {
+	o.Created = time.Now()
+	dbO := dbFreezeWindowOverride(*o)
+	if err := gorpmapping.Insert(db, &dbO); err != nil {
+		return sdk.WrapError(err, "unable to insert freeze window override")
+	}
+	*o = sdk.FreezeWindowOverride(dbO)
+	return nil
+}
+
+// LoadOverridesByProjectID returns the last overrides of the freeze windows of given project.
+func LoadOverridesByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64, limit int) ([]sdk.FreezeWindowOverride, error) {
+	var dbOs []dbFreezeWindowOverride
+	query := gorpmapping.NewQuery("SELECT * FROM project_freeze_override WHERE project_id = $1 ORDER BY created DESC LIMIT $2").Args(projectID, limit)
+	if err := gorpmapping.GetAll(ctx, db, query, &dbOs); err != nil {
+		return nil, sdk.WrapError(err, "cannot get freeze window overrides")
+	}
+	res := make([]sdk.FreezeWindowOverride, len(dbOs))
+	for i := range dbOs {
+		res[i] = sdk.FreezeWindowOverride(dbOs[i])
+	}
+	return res, nil
+}
@@ -0,0 +1,17 @@
+package freezewindow
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbFreezeWindow sdk.FreezeWindow
+
+type dbFreezeWindowOverride sdk.FreezeWindowOverride
+
+func init() {
+	gorpmapping.Register(
+		gorpmapping.New(dbFreezeWindow{}, "project_freeze_window", true, "id"),
+		gorpmapping.New(dbFreezeWindowOverride{}, "project_freeze_override", true, "id"),
+	)
+}
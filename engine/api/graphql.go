@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+
+	cdsgraphql "github.com/ovh/cds/engine/api/graphql"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultGraphQLRunsLimit = 10
+
+// postGraphQLHandler executes a GraphQL query on the projects, the workflows and the runs readable by the consumer.
+func (api *API) postGraphQLHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req cdsgraphql.Request
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		schema, err := api.graphQLSchema()
+		if err != nil {
+			return err
+		}
+
+		lang := r.Header.Get("Accept-Language")
+		res := cdsgraphql.Execute(ctx, schema, req, func(err error) string {
+			return sdk.ExtractHTTPError(err, lang).Message
+		})
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// graphQLRunsKey is the key of the runs of a workflow loaded by the runs loader.
+type graphQLRunsKey struct {
+	workflowID int64
+	limit      int
+}
+
+// graphQLSchema returns the schema of the GraphQL API. The resolvers check the permissions of the consumer like the
+// REST handlers, and read from the database replica. The workflows, the runs and the node runs are loaded by
+// loaders, with one query for all the projects, the workflows or the runs of a level of the query.
+func (api *API) graphQLSchema() (graphql.Schema, error) {
+	loadWorkflows := func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error) {
+		projectIDs := make([]int64, len(keys))
+		for i := range keys {
+			projectIDs[i] = keys[i].(int64)
+		}
+		ws, err := workflow.LoadAllByProjectIDs(ctx, api.mustDBReplica(), projectIDs)
+		if err != nil {
+			return nil, err
+		}
+		if !isMaintainer(ctx) {
+			workflowIDs := make([]int64, len(ws))
+			for i := range ws {
+				workflowIDs[i] = ws[i].ID
+			}
+			perms, err := permission.LoadWorkflowMaxLevelPermissionByWorkflowIDs(ctx, api.mustDBReplica(), workflowIDs, getAPIConsumer(ctx).GetGroupIDs())
+			if err != nil {
+				return nil, err
+			}
+			readables := make(sdk.Workflows, 0, len(ws))
+			for i := range ws {
+				if perms.Permissions(strconv.FormatInt(ws[i].ID, 10)).Readable {
+					readables = append(readables, ws[i])
+				}
+			}
+			ws = readables
+		}
+		values := make(map[interface{}]interface{}, len(keys))
+		for i := range keys {
+			values[keys[i]] = []sdk.Workflow{}
+		}
+		for i := range ws {
+			values[ws[i].ProjectID] = append(values[ws[i].ProjectID].([]sdk.Workflow), ws[i])
+		}
+		return values, nil
+	}
+
+	loadRuns := func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error) {
+		workflowIDs := make(map[int][]int64)
+		for i := range keys {
+			k := keys[i].(graphQLRunsKey)
+			workflowIDs[k.limit] = append(workflowIDs[k.limit], k.workflowID)
+		}
+		values := make(map[interface{}]interface{}, len(keys))
+		for limit, ids := range workflowIDs {
+			runs, err := workflow.LoadLastRunsByWorkflowIDs(api.mustDBReplica(), ids, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				values[graphQLRunsKey{workflowID: id, limit: limit}] = runs[id]
+			}
+		}
+		return values, nil
+	}
+
+	loadNodeRuns := func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error) {
+		runIDs := make([]int64, len(keys))
+		for i := range keys {
+			runIDs[i] = keys[i].(int64)
+		}
+		nodeRuns, err := workflow.LoadLastNodeRunsStatusByRunIDs(api.mustDBReplica(), runIDs)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[interface{}]interface{}, len(keys))
+		for i := range keys {
+			values[keys[i]] = nodeRuns[runIDs[i]]
+		}
+		return values, nil
+	}
+
+	nodeRun := graphql.NewObject(graphql.ObjectConfig{Name: "NodeRun", Fields: graphql.Fields{
+		"id":                 {Type: graphql.ID},
+		"workflow_node_name": {Type: graphql.String},
+		"num":                {Type: graphql.Int},
+		"subnumber":          {Type: graphql.Int},
+		"status":             {Type: graphql.String},
+		"start":              {Type: graphql.DateTime},
+		"last_modified":      {Type: graphql.DateTime},
+		"done":               {Type: graphql.DateTime},
+	}})
+
+	tag := graphql.NewObject(graphql.ObjectConfig{Name: "Tag", Fields: graphql.Fields{
+		"tag":   {Type: graphql.String},
+		"value": {Type: graphql.String},
+	}})
+
+	run := graphql.NewObject(graphql.ObjectConfig{Name: "Run", Fields: graphql.Fields{
+		"id":            {Type: graphql.ID},
+		"num":           {Type: graphql.Int},
+		"status":        {Type: graphql.String},
+		"start":         {Type: graphql.DateTime},
+		"last_modified": {Type: graphql.DateTime},
+		"tags":          {Type: graphql.NewList(tag)},
+		"nodes": {
+			Type: graphql.NewList(nodeRun),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return cdsgraphql.Load(p.Context, "nodeRuns", loadNodeRuns, p.Source.(sdk.WorkflowRun).ID), nil
+			},
+		},
+	}})
+
+	wf := graphql.NewObject(graphql.ObjectConfig{Name: "Workflow", Fields: graphql.Fields{
+		"name":          {Type: graphql.String},
+		"description":   {Type: graphql.String},
+		"last_modified": {Type: graphql.DateTime},
+		"runs": {
+			Type: graphql.NewList(run),
+			Args: graphql.FieldConfigArgument{"limit": {Type: graphql.Int, DefaultValue: defaultGraphQLRunsLimit}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				limit, _ := p.Args["limit"].(int)
+				if limit < 1 || limit > sdk.MaxWorkflowRunSearchLimit {
+					return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "limit must be between 1 and %d", sdk.MaxWorkflowRunSearchLimit)
+				}
+				key := graphQLRunsKey{workflowID: p.Source.(sdk.Workflow).ID, limit: limit}
+				return cdsgraphql.Load(p.Context, "runs", loadRuns, key), nil
+			},
+		},
+		"last_run": {
+			Type: run,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				load := cdsgraphql.Load(p.Context, "runs", loadRuns, graphQLRunsKey{workflowID: p.Source.(sdk.Workflow).ID, limit: 1})
+				return func() (interface{}, error) {
+					runs, err := load()
+					if err != nil || len(runs.([]sdk.WorkflowRun)) == 0 {
+						return nil, err
+					}
+					return runs.([]sdk.WorkflowRun)[0], nil
+				}, nil
+			},
+		},
+	}})
+
+	proj := graphql.NewObject(graphql.ObjectConfig{Name: "Project", Fields: graphql.Fields{
+		"key":         {Type: graphql.String},
+		"name":        {Type: graphql.String},
+		"description": {Type: graphql.String},
+		"workflows": {
+			Type: graphql.NewList(wf),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return cdsgraphql.Load(p.Context, "workflows", loadWorkflows, p.Source.(sdk.Project).ID), nil
+			},
+		},
+		"workflow": {
+			Type: wf,
+			Args: graphql.FieldConfigArgument{"name": {Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				name := p.Args["name"].(string)
+				proj := p.Source.(sdk.Project)
+				if err := api.checkWorkflowPermissions(p.Context, name, sdk.PermissionRead, map[string]string{"key": proj.Key}); err != nil {
+					return nil, err
+				}
+				w, err := workflow.Load(p.Context, api.mustDBReplica(), api.Cache, &proj, name, workflow.LoadOptions{Minimal: true})
+				if err != nil {
+					return nil, err
+				}
+				return *w, nil
+			},
+		},
+	}})
+
+	query := graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: graphql.Fields{
+		"projects": {
+			Type: graphql.NewList(proj),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if isMaintainer(p.Context) {
+					return project.LoadAll(p.Context, api.mustDBReplica(), api.Cache)
+				}
+				return project.LoadAllByGroupIDs(p.Context, api.mustDBReplica(), api.Cache, getAPIConsumer(p.Context).GetGroupIDs())
+			},
+		},
+		"project": {
+			Type: proj,
+			Args: graphql.FieldConfigArgument{"key": {Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				key := p.Args["key"].(string)
+				if err := api.checkProjectPermissions(p.Context, key, sdk.PermissionRead, nil); err != nil {
+					return nil, err
+				}
+				proj, err := project.Load(api.mustDBReplica(), api.Cache, key)
+				if err != nil {
+					return nil, err
+				}
+				return *proj, nil
+			},
+		},
+	}})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	return schema, sdk.WithStack(err)
+}
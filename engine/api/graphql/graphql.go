@@ -0,0 +1,226 @@
+// Package graphql executes GraphQL queries with github.com/graphql-go/graphql. It limits the depth and the complexity
+// of the queries before executing them, and batches the loading of the fields of a query with loaders.
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+const (
+	// MaxDepth is the maximum depth of the selections of a query.
+	MaxDepth = 10
+	// MaxComplexity is the maximum complexity of a query: each field costs 1, and the fields selected on a list are
+	// counted for each of its items.
+	MaxComplexity = 5000
+	// DefaultListSize is the size assumed for a list without limit argument to compute the complexity of a query.
+	DefaultListSize = 10
+)
+
+// Request is a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Execute parses, validates and runs a query on the schema. The message of the errors returned by the resolvers is
+// given by formatError, the data is partial when there are errors.
+func Execute(ctx context.Context, schema graphql.Schema, req Request, formatError func(error) string) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if res := graphql.ValidateDocument(&schema, doc, nil); !res.IsValid {
+		return &graphql.Result{Errors: res.Errors}
+	}
+
+	if err := checkLimits(schema, doc, req); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	res := graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx),
+	})
+	for i := range res.Errors {
+		if err := resolverError(res.Errors[i]); err != nil {
+			res.Errors[i].Message = formatError(err)
+		}
+	}
+	return res
+}
+
+// resolverError returns the error returned by a resolver, wrapped by graphql-go with the location of the field.
+func resolverError(err error) error {
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+}
+
+// limits computes the depth and the complexity of the operation of a query.
+type limits struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// checkLimits returns an error if the operation of a valid query exceeds the maximum depth or complexity. An unknown
+// operation is reported by the execution.
+func checkLimits(schema graphql.Schema, doc *ast.Document, req Request) error {
+	l := limits{schema: schema, fragments: make(map[string]*ast.FragmentDefinition), variables: make(map[string]interface{})}
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			l.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if req.OperationName == "" || def.Name != nil && def.Name.Value == req.OperationName {
+				op = def
+			}
+		}
+	}
+	if op == nil {
+		return nil
+	}
+
+	for _, def := range op.VariableDefinitions {
+		name := def.Variable.Name.Value
+		if v, has := req.Variables[name]; has {
+			l.variables[name] = v
+		} else if def.DefaultValue != nil {
+			l.variables[name] = def.DefaultValue.GetValue()
+		}
+	}
+
+	depth, complexity := l.selections(schema.QueryType(), op.SelectionSet)
+	if depth > MaxDepth {
+		return fmt.Errorf("the query exceeds the maximum depth of %d", MaxDepth)
+	}
+	if complexity > MaxComplexity {
+		return fmt.Errorf("the query exceeds the maximum complexity of %d", MaxComplexity)
+	}
+	return nil
+}
+
+// selections returns the depth and the complexity of the selections on an object.
+func (l limits) selections(o *graphql.Object, set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, sel := range set.Selections {
+		var d, c int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			d, c = l.field(o, sel)
+		case *ast.InlineFragment:
+			d, c = l.selections(l.fragmentType(o, sel.TypeCondition), sel.SelectionSet)
+		case *ast.FragmentSpread:
+			// the validation rejects the unknown fragments and the cycles of fragments
+			f := l.fragments[sel.Name.Value]
+			d, c = l.selections(l.fragmentType(o, f.TypeCondition), f.SelectionSet)
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (l limits) field(o *graphql.Object, f *ast.Field) (depth, complexity int) {
+	def, ok := o.Fields()[f.Name.Value]
+	if !ok {
+		// __typename
+		return 1, 1
+	}
+
+	t, list := def.Type, false
+	for {
+		switch w := t.(type) {
+		case *graphql.NonNull:
+			t = w.OfType
+			continue
+		case *graphql.List:
+			t, list = w.OfType, true
+			continue
+		}
+		break
+	}
+	child, ok := t.(*graphql.Object)
+	if !ok {
+		return 1, 1
+	}
+
+	depth, complexity = l.selections(child, f.SelectionSet)
+	if list {
+		complexity *= l.listSize(def, f)
+	}
+	return depth + 1, complexity + 1
+}
+
+// listSize returns the value of the limit argument of a list field, or the default list size.
+func (l limits) listSize(def *graphql.FieldDefinition, f *ast.Field) int {
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		v := arg.Value.GetValue()
+		if name, ok := v.(*ast.Name); ok {
+			v = l.variables[name.Value]
+		}
+		if n, ok := intValue(v); ok {
+			return n
+		}
+	}
+	for _, arg := range def.Args {
+		if arg.Name() == "limit" {
+			if n, ok := intValue(arg.DefaultValue); ok {
+				return n
+			}
+		}
+	}
+	return DefaultListSize
+}
+
+func (l limits) fragmentType(o *graphql.Object, cond *ast.Named) *graphql.Object {
+	if cond == nil {
+		return o
+	}
+	if t, ok := l.schema.Type(cond.Name.Value).(*graphql.Object); ok {
+		return t
+	}
+	return o
+}
+
+// intValue returns the value of an integer given as a literal, as a JSON variable or as a default value.
+func intValue(v interface{}) (int, bool) {
+	switch v := v.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,176 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/require"
+)
+
+type testRun struct {
+	Number int    `json:"num"`
+	Status string `json:"status"`
+}
+
+type testWorkflow struct {
+	Name string `json:"name"`
+}
+
+// testSchema returns a schema of workflows whose runs are loaded by a loader, and a pointer on the number of calls of
+// its batch function.
+func testSchema(t *testing.T) (graphql.Schema, *int) {
+	var batches int
+	runs := map[string][]testRun{
+		"build": {{Number: 2, Status: "Success"}, {Number: 1, Status: "Fail"}},
+	}
+	loadRuns := func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error) {
+		batches++
+		values := make(map[interface{}]interface{}, len(keys))
+		for _, k := range keys {
+			values[k] = runs[k.(string)]
+		}
+		return values, nil
+	}
+
+	run := graphql.NewObject(graphql.ObjectConfig{Name: "Run", Fields: graphql.Fields{
+		"num":    {Type: graphql.Int},
+		"status": {Type: graphql.String},
+	}})
+	var wf *graphql.Object
+	wf = graphql.NewObject(graphql.ObjectConfig{Name: "Workflow", Fields: graphql.FieldsThunk(func() graphql.Fields {
+		return graphql.Fields{
+			"name": {Type: graphql.String},
+			"runs": {
+				Type: graphql.NewList(run),
+				Args: graphql.FieldConfigArgument{"limit": {Type: graphql.Int, DefaultValue: 10}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					load := Load(p.Context, "runs", loadRuns, p.Source.(testWorkflow).Name)
+					return func() (interface{}, error) {
+						rs, err := load()
+						if err != nil {
+							return nil, err
+						}
+						if limit := p.Args["limit"].(int); limit < len(rs.([]testRun)) {
+							return rs.([]testRun)[:limit], nil
+						}
+						return rs, nil
+					}, nil
+				},
+			},
+			"parent": {
+				Type: wf,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		}
+	})})
+
+	workflows := []testWorkflow{{Name: "build"}, {Name: "deploy"}}
+	query := graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: graphql.Fields{
+		"workflow": {
+			Type: wf,
+			Args: graphql.FieldConfigArgument{"name": {Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				for _, w := range workflows {
+					if w.Name == p.Args["name"] {
+						return w, nil
+					}
+				}
+				return nil, fmt.Errorf("workflow %s not found", p.Args["name"])
+			},
+		},
+		"workflows": {
+			Type: graphql.NewList(wf),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return workflows, nil
+			},
+		},
+	}})
+
+	s, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	require.NoError(t, err)
+	return s, &batches
+}
+
+func execute(t *testing.T, s graphql.Schema, req Request) string {
+	res := Execute(context.Background(), s, req, func(err error) string {
+		return "formatted: " + err.Error()
+	})
+	btes, err := json.Marshal(res)
+	require.NoError(t, err)
+	return string(btes)
+}
+
+func TestExecute(t *testing.T) {
+	s, batches := testSchema(t)
+
+	// the runs of all the workflows are loaded with one batch
+	require.Equal(t, `{"data":{"workflows":[{"name":"build","runs":[{"num":2,"status":"Success"},{"num":1,"status":"Fail"}]},{"name":"deploy","runs":[]}]}}`,
+		execute(t, s, Request{Query: `{ workflows { name runs { status num } } }`}))
+	require.Equal(t, 1, *batches)
+
+	// arguments, variables, aliases, fragments and __typename
+	require.Equal(t, `{"data":{"w":{"__typename":"Workflow","last":[{"num":2}]}}}`,
+		execute(t, s, Request{
+			Query:     `query Last($name: String!, $limit: Int = 5) { w: workflow(name: $name) { __typename ...runs } } fragment runs on Workflow { last: runs(limit: $limit) { num } }`,
+			Variables: map[string]interface{}{"name": "build", "limit": float64(1)},
+		}))
+
+	// errors of the resolvers are formatted and returned with the path of the field
+	require.Equal(t, `{"data":{"workflow":null},"errors":[{"message":"formatted: workflow unknown not found","locations":[{"line":1,"column":3}],"path":["workflow"]}]}`,
+		execute(t, s, Request{Query: `{ workflow(name: "unknown") { name } }`}))
+
+	// invalid queries
+	for _, q := range []string{
+		`{ workflows { unknown } }`,
+		`{ workflows }`,
+		`{ workflows { name(limit: 1) } }`,
+		`{ workflow(name: $name) { name } }`,
+		`{ workflows { ...f } }`,
+		`{ workflows { ...f } } fragment f on Workflow { parent { ...f } }`,
+		`{ workflows { name }`,
+	} {
+		res := Execute(context.Background(), s, Request{Query: q}, nil)
+		require.Nil(t, res.Data, q)
+		require.NotEmpty(t, res.Errors, q)
+	}
+}
+
+func TestExecuteLimits(t *testing.T) {
+	s, _ := testSchema(t)
+
+	nested := func(depth int) string {
+		return "{ workflows { " + strings.Repeat("parent { ", depth-2) + "name" + strings.Repeat(" }", depth-2) + " } }"
+	}
+	res := Execute(context.Background(), s, Request{Query: nested(MaxDepth)}, nil)
+	require.Empty(t, res.Errors)
+	res = Execute(context.Background(), s, Request{Query: nested(MaxDepth + 1)}, nil)
+	require.Len(t, res.Errors, 1)
+	require.Equal(t, fmt.Sprintf("the query exceeds the maximum depth of %d", MaxDepth), res.Errors[0].Message)
+
+	// the fields of a list are counted for each of its items, with the limit argument as size when there is one
+	for _, c := range []struct {
+		query     string
+		variables map[string]interface{}
+		valid     bool
+	}{
+		{query: `{ workflows { runs(limit: 200) { num status } } }`, valid: true},
+		{query: `{ workflows { runs(limit: 300) { num status } } }`},
+		{query: `query($limit: Int) { workflows { runs(limit: $limit) { num status } } }`, variables: map[string]interface{}{"limit": float64(300)}},
+		{query: `{ workflows { parent { runs { num } } runs { num } } }`, valid: true},
+		{query: `{ workflows { ...f } } fragment f on Workflow { runs(limit: 300) { num status } }`},
+	} {
+		res := Execute(context.Background(), s, Request{Query: c.query, Variables: c.variables}, nil)
+		if c.valid {
+			require.Empty(t, res.Errors, c.query)
+			continue
+		}
+		require.Len(t, res.Errors, 1, c.query)
+		require.Equal(t, fmt.Sprintf("the query exceeds the maximum complexity of %d", MaxComplexity), res.Errors[0].Message, c.query)
+	}
+}
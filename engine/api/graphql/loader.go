@@ -0,0 +1,85 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc loads the values of several keys at once, a key missing from the returned values resolves to nil.
+type BatchFunc func(ctx context.Context, keys []interface{}) (map[interface{}]interface{}, error)
+
+type contextKey string
+
+const loadersKey contextKey = "graphql.loaders"
+
+type loaders struct {
+	mutex   sync.Mutex
+	loaders map[string]*loader
+}
+
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey, &loaders{loaders: make(map[string]*loader)})
+}
+
+// Load returns a thunk resolving the value of a key with the loader of the given name. graphql-go calls the thunks of
+// a level of the query once all its fields are resolved, so the keys requested by the resolvers of a level are loaded
+// with one call of the batch function instead of one call per key. The loaders are shared by the resolvers of a query.
+func Load(ctx context.Context, name string, batch BatchFunc, key interface{}) func() (interface{}, error) {
+	var l *loader
+	if ls, ok := ctx.Value(loadersKey).(*loaders); ok {
+		ls.mutex.Lock()
+		l = ls.loaders[name]
+		if l == nil {
+			l = newLoader(batch)
+			ls.loaders[name] = l
+		}
+		ls.mutex.Unlock()
+	} else {
+		l = newLoader(batch)
+	}
+	return l.load(ctx, key)
+}
+
+// loader caches the values of the keys loaded by a batch function.
+type loader struct {
+	batch   BatchFunc
+	mutex   sync.Mutex
+	pending []interface{}
+	loaded  map[interface{}]bool
+	values  map[interface{}]interface{}
+	errors  map[interface{}]error
+}
+
+func newLoader(batch BatchFunc) *loader {
+	return &loader{
+		batch:  batch,
+		loaded: make(map[interface{}]bool),
+		values: make(map[interface{}]interface{}),
+		errors: make(map[interface{}]error),
+	}
+}
+
+func (l *loader) load(ctx context.Context, key interface{}) func() (interface{}, error) {
+	l.mutex.Lock()
+	if _, has := l.loaded[key]; !has {
+		l.loaded[key] = false
+		l.pending = append(l.pending, key)
+	}
+	l.mutex.Unlock()
+
+	return func() (interface{}, error) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		if !l.loaded[key] {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.batch(ctx, keys)
+			for _, k := range keys {
+				l.loaded[k] = true
+				l.values[k] = values[k]
+				l.errors[k] = err
+			}
+		}
+		return l.values[key], l.errors[key]
+	}
+}
@@ -148,6 +148,8 @@ func (api *API) putGroupHandler() service.Handler {
 			return sdk.WrapError(err, "cannot commit transaction")
 		}
 
+		setAuditLogData(ctx, oldGroup, newGroup)
+
 		// Load extra data for group
 		if err := group.LoadOptions.Default(ctx, api.mustDB(), &newGroup); err != nil {
 			return err
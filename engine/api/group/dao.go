@@ -97,6 +97,16 @@ func LoadAllByIDs(ctx context.Context, db gorp.SqlExecutor, ids []int64, opts ..
 	return getAll(ctx, db, query, opts...)
 }
 
+// LoadAllByNames returns all groups from database for given names.
+func LoadAllByNames(ctx context.Context, db gorp.SqlExecutor, names []string, opts ...LoadOptionFunc) (sdk.Groups, error) {
+	query := gorpmapping.NewQuery(`
+    SELECT *
+    FROM "group"
+    WHERE name = ANY(string_to_array($1, ',')::text[])
+  `).Args(gorpmapping.IDStringsToQueryString(names))
+	return getAll(ctx, db, query, opts...)
+}
+
 // LoadAllByUserID returns all groups from database for given user id.
 func LoadAllByUserID(ctx context.Context, db gorp.SqlExecutor, userID string, opts ...LoadOptionFunc) (sdk.Groups, error) {
 	query := gorpmapping.NewQuery(`
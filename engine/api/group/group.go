@@ -2,11 +2,13 @@ package group
 
 import (
 	"context"
+	"strings"
 
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 )
 
 // DeleteUserFromGroup remove user from group
@@ -59,3 +61,63 @@ func CheckUserInDefaultGroup(ctx context.Context, db gorp.SqlExecutor, userID st
 
 	return nil
 }
+
+// SyncUserGroups updates user's groups membership from given group names (ie. returned by an auth provider).
+// Only groups with given prefix are managed, membership of other groups and of the default group is left untouched.
+// Given names that don't match an existing group are ignored. Returns the groups that were added and removed.
+func SyncUserGroups(ctx context.Context, db gorp.SqlExecutor, userID string, groupNames []string, prefix string) (sdk.Groups, sdk.Groups, error) {
+	var names []string
+	for _, n := range groupNames {
+		if strings.HasPrefix(n, prefix) {
+			names = append(names, n)
+		}
+	}
+
+	var expected sdk.Groups
+	if len(names) > 0 {
+		var err error
+		expected, err = LoadAllByNames(ctx, db, names)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	current, err := LoadAllByUserID(ctx, db, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var added, removed sdk.Groups
+	for _, g := range expected {
+		if current.HasOneOf(g.ID) {
+			continue
+		}
+		log.Debug("group.SyncUserGroups> add user %s to group %s", userID, g.Name)
+		if err := InsertLinkGroupUser(ctx, db, &LinkGroupUser{
+			GroupID:            g.ID,
+			AuthentifiedUserID: userID,
+			Admin:              false,
+		}); err != nil {
+			return nil, nil, err
+		}
+		added = append(added, g)
+	}
+
+	for _, g := range current {
+		if !strings.HasPrefix(g.Name, prefix) || IsDefaultGroupID(g.ID) || expected.HasOneOf(g.ID) {
+			continue
+		}
+		log.Debug("group.SyncUserGroups> remove user %s from group %s", userID, g.Name)
+		if err := DeleteUserFromGroup(ctx, db, g.ID, userID); err != nil {
+			// The last admin of a group can't be removed, the group should be updated manually
+			if sdk.ErrorIs(err, sdk.ErrNotEnoughAdmin) {
+				log.Warning(ctx, "group.SyncUserGroups> cannot remove user %s from group %s: %v", userID, g.Name, err)
+				continue
+			}
+			return nil, nil, err
+		}
+		removed = append(removed, g)
+	}
+
+	return added, removed, nil
+}
@@ -0,0 +1,44 @@
+package group_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSyncUserGroups(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	prefix := sdk.RandomString(10) + "-"
+	admin, _ := assets.InsertAdminUser(t, db)
+	g1 := &sdk.Group{Name: prefix + "a"}
+	require.NoError(t, group.Create(context.TODO(), db, g1, admin.ID))
+	g2 := &sdk.Group{Name: prefix + "b"}
+	require.NoError(t, group.Create(context.TODO(), db, g2, admin.ID))
+	g3 := &sdk.Group{Name: sdk.RandomString(10)}
+	require.NoError(t, group.Create(context.TODO(), db, g3, admin.ID))
+
+	u, _ := assets.InsertLambdaUser(t, db, g2, g3)
+
+	added, removed, err := group.SyncUserGroups(context.TODO(), db, u.ID, []string{prefix + "a", prefix + "unknown", g3.Name}, prefix)
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	assert.Equal(t, g1.ID, added[0].ID)
+	require.Len(t, removed, 1)
+	assert.Equal(t, g2.ID, removed[0].ID)
+
+	gs, err := group.LoadAllByUserID(context.TODO(), db, u.ID)
+	require.NoError(t, err)
+	assert.True(t, gs.HasOneOf(g1.ID))
+	assert.False(t, gs.HasOneOf(g2.ID))
+	assert.True(t, gs.HasOneOf(g3.ID), "group without prefix should not be managed by the synchronization")
+}
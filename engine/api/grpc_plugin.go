@@ -27,6 +27,9 @@ func (api *API) postGRPCluginHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 		p.Binaries = nil
+		if err := p.Sandbox.IsValid(); err != nil {
+			return err
+		}
 
 		tx, err := db.Begin()
 		if err != nil {
@@ -103,6 +106,9 @@ func (api *API) putGRPCluginHandler() service.Handler {
 		if err := service.UnmarshalBody(r, &p); err != nil {
 			return sdk.WithStack(err)
 		}
+		if err := p.Sandbox.IsValid(); err != nil {
+			return err
+		}
 
 		var name = mux.Vars(r)["name"]
 		old, err := plugin.LoadByName(api.mustDB(), name)
@@ -223,7 +229,8 @@ func (api *API) getGRPCluginBinaryHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrNotFound, "getGRPCluginBinaryHandler")
 		}
 
-		acceptRedirect := FormBool(r, "accept-redirect")
+		// On an air-gapped installation, workers may not reach the storage, binaries are always served by the API
+		acceptRedirect := FormBool(r, "accept-redirect") && !api.Config.AirGapped.Enabled
 
 		s, temporaryURLSupported := api.SharedStorage.(objectstore.DriverWithRedirect)
 		if acceptRedirect && api.SharedStorage.TemporaryURLSupported() && temporaryURLSupported {
@@ -272,6 +279,7 @@ func (api *API) getGRPCluginBinaryInfosHandler() service.Handler {
 		if b == nil {
 			return sdk.WrapError(sdk.ErrNotFound, "getGRPCluginBinaryInfosHandler>")
 		}
+		setBinarySandbox(p, b)
 
 		return service.WriteJSON(w, *b, http.StatusOK)
 	}
@@ -282,3 +290,11 @@ func (api *API) deleteGRPCluginBinaryHandler() service.Handler {
 		return nil
 	}
 }
+
+// setBinarySandbox sets the sandbox of the plugin on a binary given to the workers.
+func setBinarySandbox(p *sdk.GRPCPlugin, b *sdk.GRPCPluginBinary) {
+	if p.Sandbox.IsEnabled() {
+		sandbox := p.Sandbox
+		b.Sandbox = &sandbox
+	}
+}
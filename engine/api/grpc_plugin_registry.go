@@ -0,0 +1,253 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/plugin"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getGRPCPluginRegistryHandler returns the versions of all the plugins published in the registry.
+func (api *API) getGRPCPluginRegistryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		ps, err := plugin.LoadAll(api.mustDB())
+		if err != nil {
+			return err
+		}
+
+		vs, err := plugin.LoadAllVersions(ctx, api.mustDB(), ps)
+		if err != nil {
+			return err
+		}
+		for i := range ps {
+			setInstalledVersions(&ps[i], vs)
+		}
+
+		return service.WriteJSON(w, vs, http.StatusOK)
+	}
+}
+
+// getGRPCPluginVersionsHandler returns the versions of a plugin published in the registry.
+func (api *API) getGRPCPluginVersionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		p, err := plugin.LoadByName(api.mustDB(), mux.Vars(r)["name"])
+		if err != nil {
+			return err
+		}
+
+		vs, err := plugin.LoadVersions(ctx, api.mustDB(), p)
+		if err != nil {
+			return err
+		}
+		setInstalledVersions(p, vs)
+
+		return service.WriteJSON(w, vs, http.StatusOK)
+	}
+}
+
+// postGRPCPluginVersionHandler publishes a version of a plugin in the registry. The checksums of the binaries
+// are computed by the API, and checked against the ones given by the publisher.
+func (api *API) postGRPCPluginVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var v sdk.GRPCPluginVersion
+		if err := service.UnmarshalBody(r, &v); err != nil {
+			return err
+		}
+		if err := v.IsValid(); err != nil {
+			return err
+		}
+
+		contents := make(map[string]io.ReadCloser, len(v.Binaries))
+		for i := range v.Binaries {
+			b := &v.Binaries[i]
+			if len(b.FileContent) == 0 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing content of binary %s/%s", b.OS, b.Arch)
+			}
+			sha512sum := sha512.Sum512(b.FileContent)
+			if b.SHA512sum != "" && b.SHA512sum != hex.EncodeToString(sha512sum[:]) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid sha512sum for binary %s/%s", b.OS, b.Arch)
+			}
+			md5sum := md5.Sum(b.FileContent)
+			b.SHA512sum = hex.EncodeToString(sha512sum[:])
+			b.MD5sum = hex.EncodeToString(md5sum[:])
+			b.Size = int64(len(b.FileContent))
+			contents[b.OS+"/"+b.Arch] = ioutil.NopCloser(bytes.NewReader(b.FileContent))
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "unable to start tx")
+		}
+		defer tx.Rollback() // nolint
+
+		p, err := plugin.LoadByName(tx, mux.Vars(r)["name"])
+		if err != nil {
+			return err
+		}
+		if _, err := plugin.LoadVersion(ctx, tx, p, v.Version); err == nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "version %s of plugin %s is already published", v.Version, p.Name)
+		} else if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+
+		v.ID = 0
+		v.Publisher = getAPIConsumer(ctx).GetUsername()
+		v.Published = time.Now()
+		if err := plugin.InsertVersion(ctx, tx, api.SharedStorage, p, &v, contents); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "unable to commit tx")
+		}
+
+		return service.WriteJSON(w, v, http.StatusOK)
+	}
+}
+
+func (api *API) getGRPCPluginVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		p, err := plugin.LoadByName(api.mustDB(), vars["name"])
+		if err != nil {
+			return err
+		}
+
+		v, err := plugin.LoadVersion(ctx, api.mustDB(), p, vars["version"])
+		if err != nil {
+			return err
+		}
+		v.Installed = isInstalledVersion(p, v)
+		for i := range v.Binaries {
+			setBinarySandbox(p, &v.Binaries[i])
+		}
+
+		return service.WriteJSON(w, v, http.StatusOK)
+	}
+}
+
+func (api *API) deleteGRPCPluginVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		p, err := plugin.LoadByName(api.mustDB(), vars["name"])
+		if err != nil {
+			return err
+		}
+
+		v, err := plugin.LoadVersion(ctx, api.mustDB(), p, vars["version"])
+		if err != nil {
+			return err
+		}
+
+		if err := plugin.DeleteVersion(ctx, api.mustDB(), api.SharedStorage, v); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// postGRPCPluginVersionInstallHandler installs a version of the registry, it will be used by the jobs which don't
+// require a version of the plugin.
+func (api *API) postGRPCPluginVersionInstallHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "unable to start tx")
+		}
+		defer tx.Rollback() // nolint
+
+		p, err := plugin.LoadByName(tx, vars["name"])
+		if err != nil {
+			return err
+		}
+
+		v, err := plugin.LoadVersion(ctx, tx, p, vars["version"])
+		if err != nil {
+			return err
+		}
+
+		if err := plugin.InstallVersion(ctx, tx, api.SharedStorage, p, v); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "unable to commit tx")
+		}
+
+		return service.WriteJSON(w, p, http.StatusOK)
+	}
+}
+
+func (api *API) getGRPCPluginVersionBinaryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		p, err := plugin.LoadByName(api.mustDB(), vars["name"])
+		if err != nil {
+			return err
+		}
+
+		v, err := plugin.LoadVersion(ctx, api.mustDB(), p, vars["version"])
+		if err != nil {
+			return err
+		}
+
+		b := v.GetBinary(vars["os"], vars["arch"])
+		if b == nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "version %s of plugin %s has no binary for %s/%s", v.Version, p.Name, vars["os"], vars["arch"])
+		}
+
+		f, err := api.SharedStorage.Fetch(ctx, b)
+		if err != nil {
+			return sdk.WrapError(err, "unable to get object")
+		}
+		defer f.Close() // nolint
+
+		w.Header().Add("Content-Type", "application/octet-stream")
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", b.Name))
+
+		if _, err := io.Copy(w, f); err != nil {
+			return sdk.WrapError(err, "cannot stream binary")
+		}
+		return nil
+	}
+}
+
+// isInstalledVersion returns true if the binaries of the installed plugin are the binaries of the version.
+func isInstalledVersion(p *sdk.GRPCPlugin, v *sdk.GRPCPluginVersion) bool {
+	if len(p.Binaries) == 0 {
+		return false
+	}
+	for _, b := range v.Binaries {
+		installed := p.GetBinary(b.OS, b.Arch)
+		if installed == nil || installed.SHA512sum != b.SHA512sum {
+			return false
+		}
+	}
+	return true
+}
+
+func setInstalledVersions(p *sdk.GRPCPlugin, vs []sdk.GRPCPluginVersion) {
+	for i := range vs {
+		if vs[i].PluginID == p.ID {
+			vs[i].Installed = isInstalledVersion(p, &vs[i])
+		}
+	}
+}
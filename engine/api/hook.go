@@ -3,20 +3,29 @@ package api
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/vcs/git"
 )
 
+// plainGitPollingDelay is the interval between two polls of a repository without repositories manager
+const plainGitPollingDelay = time.Minute
+
 func (api *API) getHookPollingVCSEvents() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -44,6 +53,10 @@ func (api *API) getHookPollingVCSEvents() service.Handler {
 			return errProj
 		}
 
+		if vcsServerParam == sdk.PlainGitVCSServer {
+			return api.getHookPollingPlainGitEvents(ctx, w, &h, proj, workflowID)
+		}
+
 		//get the client for the repositories manager
 		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, vcsServerParam)
 		client, errR := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, vcsServer)
@@ -99,3 +112,103 @@ func (api *API) getHookPollingVCSEvents() service.Handler {
 		return service.WriteJSON(w, repoEvents, http.StatusOK)
 	}
 }
+
+// getHookPollingPlainGitEvents detects the changes on a repository without repositories manager,
+// by comparing the references listed by git ls-remote with the ones seen at the previous poll.
+func (api *API) getHookPollingPlainGitEvents(ctx context.Context, w http.ResponseWriter, h *sdk.NodeHook, proj *sdk.Project, workflowID int64) error {
+	wf, err := workflow.LoadByID(ctx, api.mustDB(), api.Cache, proj, workflowID, workflow.LoadOptions{})
+	if err != nil {
+		return err
+	}
+	if wf.WorkflowData.Node.Context == nil || wf.WorkflowData.Node.Context.ApplicationID == 0 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow %s is not linked to an application", wf.Name)
+	}
+	app, err := application.LoadByID(api.mustDB(), api.Cache, wf.WorkflowData.Node.Context.ApplicationID, application.LoadOptions.WithClearKeys)
+	if err != nil {
+		return err
+	}
+	if !app.IsPlainGit() {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "application %s is not linked to a raw git repository", app.Name)
+	}
+
+	var auth *git.AuthOpts
+	if app.RepositoryStrategy.ConnectionType == "ssh" {
+		var privateKey string
+		if k := app.GetSSHKey(app.RepositoryStrategy.SSHKey); k != nil {
+			privateKey = k.Private
+		} else if projKeys, err := project.Load(api.mustDB(), api.Cache, proj.Key, project.LoadOptions.WithClearKeys); err != nil {
+			return err
+		} else if k := projKeys.GetSSHKey(app.RepositoryStrategy.SSHKey); k != nil {
+			privateKey = k.Private
+		}
+		if privateKey == "" {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find ssh key %s", app.RepositoryStrategy.SSHKey)
+		}
+		keyDir, err := ioutil.TempDir("", "cds-git-poller")
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer os.RemoveAll(keyDir) // nolint
+		auth = &git.AuthOpts{}
+		auth.PrivateKey.Filename = filepath.Join(keyDir, "id_rsa")
+		if err := ioutil.WriteFile(auth.PrivateKey.Filename, []byte(privateKey), os.FileMode(0600)); err != nil {
+			return sdk.WithStack(err)
+		}
+	} else {
+		if err := application.DecryptVCSStrategyPassword(app); err != nil {
+			return err
+		}
+		if app.RepositoryStrategy.User != "" {
+			auth = &git.AuthOpts{
+				Username: app.RepositoryStrategy.User,
+				Password: app.RepositoryStrategy.Password,
+			}
+		}
+	}
+
+	refs, err := git.LsRemote(app.RepositoryURL, auth)
+	if err != nil {
+		return sdk.WrapError(err, "unable to list references of %s", app.RepositoryURL)
+	}
+	currentRefs := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		currentRefs[ref.Name] = ref.Hash
+	}
+
+	// The first poll only stores the references, existing branches are not built
+	k := cache.Key("api:hook:plaingit", h.UUID)
+	previousRefs := make(map[string]string)
+	found, err := api.Cache.Get(k, &previousRefs)
+	if err != nil {
+		log.Error(ctx, "getHookPollingPlainGitEvents> cannot get previous references from cache %s: %v", k, err)
+	}
+	if err := api.Cache.Set(k, currentRefs); err != nil {
+		log.Error(ctx, "getHookPollingPlainGitEvents> cannot store references in cache %s: %v", k, err)
+	}
+
+	repoEvents := sdk.RepositoryEvents{}
+	if found {
+		for _, ref := range refs {
+			if previousRefs[ref.Name] == ref.Hash {
+				continue
+			}
+			exist, err := workflow.RunExist(api.mustDB(), proj.Key, workflowID, ref.Hash)
+			if err != nil {
+				return sdk.WrapError(err, "cannot check existing builds for push events")
+			}
+			if exist {
+				continue
+			}
+			repoEvents.PushEvents = append(repoEvents.PushEvents, sdk.VCSPushEvent{
+				Repo:     app.RepositoryFullname,
+				Branch:   sdk.VCSBranch{ID: ref.Name, DisplayID: ref.Name, LatestCommit: ref.Hash},
+				Commit:   sdk.VCSCommit{Hash: ref.Hash},
+				CloneURL: app.RepositoryURL,
+			})
+		}
+	}
+
+	w.Header().Add("X-CDS-Poll-Interval", fmt.Sprintf("%.0f", plainGitPollingDelay.Seconds()))
+
+	return service.WriteJSON(w, repoEvents, http.StatusOK)
+}
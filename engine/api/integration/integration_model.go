@@ -11,9 +11,15 @@ var (
 	// BuiltinModels list available integration models
 	BuiltinModels = []sdk.IntegrationModel{
 		sdk.KafkaIntegration,
+		sdk.NATSIntegration,
 		sdk.RabbitMQIntegration,
 		sdk.OpenstackIntegration,
 		sdk.AWSIntegration,
+		sdk.DockerRegistryIntegration,
+		sdk.KubernetesIntegration,
+		sdk.HelmRegistryIntegration,
+		sdk.ServiceNowIntegration,
+		sdk.JiraIntegration,
 	}
 )
 
@@ -123,3 +123,28 @@ func GeneratePGPKeyPair(name string) (sdk.Key, error) {
 	k.Public = string(pub)
 	return k, err
 }
+
+// SignPGPDetached signs data with an armored PGP private key, it returns the binary detached signature
+func SignPGPDetached(privateKey string, data []byte) ([]byte, error) {
+	entity, err := GetOpenPGPEntity(bytes.NewBufferString(privateKey))
+	if err != nil {
+		return nil, err
+	}
+	sig := new(bytes.Buffer)
+	if err := openpgp.DetachSign(sig, entity, bytes.NewReader(data), nil); err != nil {
+		return nil, sdk.WrapError(err, "cannot sign data")
+	}
+	return sig.Bytes(), nil
+}
+
+// VerifyPGPDetached checks a binary detached signature of data with an armored PGP public key
+func VerifyPGPDetached(publicKey string, data, signature []byte) error {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewBufferString(publicKey))
+	if err != nil {
+		return sdk.WrapError(err, "unable to read armored key ring")
+	}
+	if _, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(data), bytes.NewReader(signature)); err != nil {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid signature: %v", err)
+	}
+	return nil
+}
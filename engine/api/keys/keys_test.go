@@ -100,3 +100,18 @@ func TestGenerateGPGKeyPair(t *testing.T) {
 	t.Logf(string(pub2))
 	assert.Equal(t, string([]byte(k.Public)), string(pub2))
 }
+
+func TestSignPGPDetached(t *testing.T) {
+	k, err := GeneratePGPKeyPair("mykey")
+	test.NoError(t, err)
+
+	sig, err := SignPGPDetached(k.Private, []byte("my artifact"))
+	test.NoError(t, err)
+
+	assert.NoError(t, VerifyPGPDetached(k.Public, []byte("my artifact"), sig))
+	assert.Error(t, VerifyPGPDetached(k.Public, []byte("another artifact"), sig))
+
+	other, err := GeneratePGPKeyPair("otherkey")
+	test.NoError(t, err)
+	assert.Error(t, VerifyPGPDetached(other.Public, []byte("my artifact"), sig))
+}
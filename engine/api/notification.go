@@ -4,6 +4,10 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/notification"
+	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 )
@@ -32,6 +36,13 @@ func (api *API) getUserNotificationTypeHandler() service.Handler {
 					Body: sdk.DefaultWorkflowNodeRunReport,
 				},
 			},
+			sdk.WebhookUserNotification: {
+				OnSuccess: sdk.UserNotificationChange,
+				OnFailure: sdk.UserNotificationAlways,
+				OnStart:   &sdk.False,
+				Template:  &sdk.UserNotificationTemplateWebhook,
+				Webhook:   &sdk.UserNotificationWebhook{},
+			},
 		}, http.StatusOK)
 	}
 }
@@ -45,3 +56,27 @@ func (api *API) getUserNotificationStateValueHandler() service.Handler {
 		}, http.StatusOK)
 	}
 }
+
+func (api *API) getWorkflowRunNotificationDeliveriesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		run, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s run number %d", name, number)
+		}
+
+		ds, err := notification.LoadDeliveriesByWorkflowRunID(ctx, api.mustDB(), run.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ds, http.StatusOK)
+	}
+}
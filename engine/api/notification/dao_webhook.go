@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbWorkflowNotificationDelivery sdk.WorkflowNotificationDelivery
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbWorkflowNotificationDelivery{}, "workflow_notification_delivery", true, "id"))
+}
+
+// LoadDeliveriesByWorkflowRunID returns all webhook notification deliveries for given workflow run.
+func LoadDeliveriesByWorkflowRunID(ctx context.Context, db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowNotificationDelivery, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_notification_delivery WHERE workflow_run_id = $1 ORDER BY id").Args(workflowRunID)
+	var dbDeliveries []dbWorkflowNotificationDelivery
+	if err := gorpmapping.GetAll(ctx, db, query, &dbDeliveries); err != nil {
+		return nil, sdk.WrapError(err, "cannot get workflow notification deliveries")
+	}
+	ds := make([]sdk.WorkflowNotificationDelivery, len(dbDeliveries))
+	for i := range dbDeliveries {
+		ds[i] = sdk.WorkflowNotificationDelivery(dbDeliveries[i])
+	}
+	return ds, nil
+}
+
+// InsertDelivery a webhook notification delivery in database.
+func InsertDelivery(db gorp.SqlExecutor, d *sdk.WorkflowNotificationDelivery) error {
+	dbD := dbWorkflowNotificationDelivery(*d)
+	if err := gorpmapping.Insert(db, &dbD); err != nil {
+		return sdk.WrapError(err, "unable to insert workflow notification delivery")
+	}
+	*d = sdk.WorkflowNotificationDelivery(dbD)
+	return nil
+}
+
+// UpdateDelivery a webhook notification delivery in database.
+func UpdateDelivery(db gorp.SqlExecutor, d *sdk.WorkflowNotificationDelivery) error {
+	dbD := dbWorkflowNotificationDelivery(*d)
+	if err := gorpmapping.Update(db, &dbD); err != nil {
+		return sdk.WrapError(err, "unable to update workflow notification delivery %d", d.ID)
+	}
+	return nil
+}
+
+// DeleteDeliveriesByWorkflowRunID removes all webhook notification deliveries for given workflow run.
+func DeleteDeliveriesByWorkflowRunID(db gorp.SqlExecutor, workflowRunID int64) error {
+	_, err := db.Exec("DELETE FROM workflow_notification_delivery WHERE workflow_run_id = $1", workflowRunID)
+	return sdk.WrapError(err, "unable to delete workflow notification deliveries for run %d", workflowRunID)
+}
@@ -3,6 +3,7 @@ package notification
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/go-gorp/gorp"
 
@@ -15,12 +16,14 @@ import (
 )
 
 var (
-	uiURL string
+	uiURL  string
+	dbFunc func() *gorp.DbMap
 )
 
 // Init initializes notification package
-func Init(uiurl string) {
+func Init(uiurl string, DBFunc func() *gorp.DbMap) {
 	uiURL = uiurl
+	dbFunc = DBFunc
 }
 
 // GetUserWorkflowEvents return events to send for the given workflow run
@@ -45,6 +48,12 @@ func GetUserWorkflowEvents(ctx context.Context, db gorp.SqlExecutor, store cache
 		params["cds.author"] = p
 	}
 	params["cds.status"] = nr.Status
+	if nr.Changelog != nil {
+		params["cds.changelog"] = nr.Changelog.Markdown()
+		params["cds.changelog.count"] = strconv.Itoa(len(nr.Changelog.Commits))
+		params["cds.changelog.from"] = nr.Changelog.FromHash
+		params["cds.changelog.to"] = nr.Changelog.ToHash
+	}
 
 	for _, notif := range w.Notifications {
 		if ShouldSendUserWorkflowNotification(ctx, notif, nr, previousWR) {
@@ -121,6 +130,13 @@ func GetUserWorkflowEvents(ctx context.Context, db gorp.SqlExecutor, store cache
 					log.Error(ctx, "notification.GetUserWorkflowEvents> unable to handle event %+v: %v", jn, err)
 				}
 				go SendMailNotif(ctx, notif)
+
+			case sdk.WebhookUserNotification:
+				n := notif
+				data := webhookNotificationData(w, nr, params)
+				sdk.GoRoutine(context.Background(), "notification.SendWebhookNotif", func(ctx context.Context) {
+					SendWebhookNotif(ctx, n, data, nr)
+				})
 			}
 		}
 	}
@@ -0,0 +1,165 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/engine/api/secret"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var (
+	webhookHTTPClient = &http.Client{Timeout: 10 * time.Second}
+	// webhookRetryDelay is the delay before the first retry, it is doubled after each attempt.
+	webhookRetryDelay = 2 * time.Second
+)
+
+// webhookNotificationData returns the fields given to the template of a webhook notification.
+func webhookNotificationData(w sdk.Workflow, nr sdk.WorkflowNodeRun, params map[string]string) sdk.WebhookNotificationData {
+	return sdk.WebhookNotificationData{
+		Project:     w.ProjectKey,
+		Workflow:    w.Name,
+		Number:      nr.Number,
+		SubNumber:   nr.SubNumber,
+		Node:        nr.WorkflowNodeName,
+		Status:      nr.Status,
+		URL:         params["cds.buildURL"],
+		TriggeredBy: params["cds.author"],
+		Start:       nr.Start,
+		Done:        nr.Done,
+		Params:      params,
+		Changelog:   nr.Changelog,
+	}
+}
+
+// SendWebhookNotif posts the templated payload of a webhook notification, failed deliveries are retried with
+// an exponential backoff. The delivery status is saved for the workflow run.
+func SendWebhookNotif(ctx context.Context, notif sdk.WorkflowNotification, data sdk.WebhookNotificationData, nr sdk.WorkflowNodeRun) {
+	hook := notif.Settings.Webhook
+	if err := hook.IsValid(); err != nil {
+		log.Error(ctx, "notification.SendWebhookNotif> invalid webhook for notification %d: %v", notif.ID, err)
+		return
+	}
+
+	d := sdk.WorkflowNotificationDelivery{
+		UUID:              sdk.UUID(),
+		WorkflowRunID:     nr.WorkflowRunID,
+		WorkflowNodeRunID: nr.ID,
+		NotificationID:    notif.ID,
+		NodeName:          nr.WorkflowNodeName,
+		NodeStatus:        nr.Status,
+		URL:               hook.URL,
+		Status:            sdk.StatusBuilding,
+		Created:           time.Now(),
+	}
+	if err := InsertDelivery(dbFunc(), &d); err != nil {
+		log.Error(ctx, "notification.SendWebhookNotif> %v", err)
+		return
+	}
+
+	payload, err := renderWebhookPayload(notif.Settings.Template, data)
+	if err != nil {
+		d.Status = sdk.StatusFail
+		d.Error = err.Error()
+		saveDelivery(ctx, &d)
+		return
+	}
+
+	var signature string
+	if hook.EncryptedSecret != "" {
+		s, err := secret.DecryptValue(hook.EncryptedSecret)
+		if err != nil {
+			d.Status = sdk.StatusFail
+			d.Error = "cannot decrypt webhook secret"
+			saveDelivery(ctx, &d)
+			return
+		}
+		signature = sdk.SignWebhookNotification(s, payload)
+	}
+
+	delay := webhookRetryDelay
+attempts:
+	for {
+		retry := postWebhook(ctx, hook, &d, payload, signature)
+		now := time.Now()
+		d.LastAttempt = &now
+		if d.Status == sdk.StatusSuccess || !retry || d.Attempts >= sdk.WebhookNotificationMaxAttempts {
+			break
+		}
+		saveDelivery(ctx, &d)
+
+		select {
+		case <-ctx.Done():
+			d.Error = ctx.Err().Error()
+			break attempts
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	if d.Status != sdk.StatusSuccess {
+		d.Status = sdk.StatusFail
+		log.Warning(ctx, "notification.SendWebhookNotif> unable to deliver notification %d of run %d to %s after %d attempts: %s", notif.ID, nr.WorkflowRunID, hook.URL, d.Attempts, d.Error)
+	}
+	saveDelivery(ctx, &d)
+}
+
+func renderWebhookPayload(tmpl *sdk.UserNotificationTemplate, data sdk.WebhookNotificationData) ([]byte, error) {
+	body := sdk.UserNotificationTemplateWebhook.Body
+	if tmpl != nil && tmpl.Body != "" {
+		body = tmpl.Body
+	}
+	return sdk.RenderWebhookNotification(body, data)
+}
+
+// postWebhook makes one delivery attempt and returns true if the attempt should be retried.
+func postWebhook(ctx context.Context, hook *sdk.UserNotificationWebhook, d *sdk.WorkflowNotificationDelivery, payload []byte, signature string) bool {
+	d.Attempts++
+	d.StatusCode = 0
+	d.Error = ""
+
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		d.Error = err.Error()
+		return false
+	}
+	req = req.WithContext(ctx)
+	for k, v := range hook.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CDS/"+sdk.VERSION)
+	req.Header.Set(sdk.WebhookNotificationHeaderDelivery, d.UUID)
+	req.Header.Set(sdk.WebhookNotificationHeaderEvent, d.NodeStatus)
+	if signature != "" {
+		req.Header.Set(sdk.WebhookNotificationHeaderSignature, signature)
+	}
+
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		d.Error = err.Error()
+		return true
+	}
+	defer resp.Body.Close() // nolint
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	d.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		d.Status = sdk.StatusSuccess
+		return false
+	}
+	d.Error = fmt.Sprintf("unexpected response status %d", resp.StatusCode)
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
+
+func saveDelivery(ctx context.Context, d *sdk.WorkflowNotificationDelivery) {
+	if err := UpdateDelivery(dbFunc(), d); err != nil {
+		log.Error(ctx, "notification.SendWebhookNotif> %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_postWebhook(t *testing.T) {
+	var status int
+	var received *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	hook := &sdk.UserNotificationWebhook{URL: srv.URL, Headers: map[string]string{"X-Custom": "value"}}
+	payload := []byte(`{"status":"Success"}`)
+	signature := sdk.SignWebhookNotification("secret", payload)
+
+	status = http.StatusOK
+	d := sdk.WorkflowNotificationDelivery{UUID: sdk.UUID(), NodeStatus: sdk.StatusSuccess, Status: sdk.StatusBuilding}
+	retry := postWebhook(context.TODO(), hook, &d, payload, signature)
+	assert.False(t, retry)
+	assert.Equal(t, sdk.StatusSuccess, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, http.StatusOK, d.StatusCode)
+	require.NotNil(t, received)
+	assert.Equal(t, payload, body)
+	assert.Equal(t, "value", received.Header.Get("X-Custom"))
+	assert.Equal(t, signature, received.Header.Get(sdk.WebhookNotificationHeaderSignature))
+	assert.Equal(t, d.UUID, received.Header.Get(sdk.WebhookNotificationHeaderDelivery))
+
+	// Server errors should be retried
+	status = http.StatusBadGateway
+	d = sdk.WorkflowNotificationDelivery{UUID: sdk.UUID(), Status: sdk.StatusBuilding}
+	assert.True(t, postWebhook(context.TODO(), hook, &d, payload, ""))
+	assert.Equal(t, http.StatusBadGateway, d.StatusCode)
+	assert.NotEmpty(t, d.Error)
+
+	// Client errors should not be retried
+	status = http.StatusBadRequest
+	assert.False(t, postWebhook(context.TODO(), hook, &d, payload, ""))
+	assert.Equal(t, 2, d.Attempts)
+	assert.Equal(t, sdk.StatusBuilding, d.Status)
+}
@@ -1,11 +1,9 @@
 package objectstore
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"path"
 	"time"
 
@@ -104,17 +102,14 @@ func (s *AWSS3Store) Status(ctx context.Context) sdk.MonitoringStatusLine {
 func (s *AWSS3Store) Store(o Object, data io.ReadCloser) (string, error) {
 	defer data.Close()
 	log.Debug("AWS-S3-Store> Setting up uploader")
+	// The uploader streams data with multipart uploads, data is never fully loaded in memory
 	uploader := s3manager.NewUploader(s.sess)
-	b, e := ioutil.ReadAll(data)
-	if e != nil {
-		return "", sdk.WrapError(e, "AWS-S3-Store> Unable to read data from input object")
-	}
 
 	log.Debug("AWS-S3-Store> Uploading object %s to bucket %s", s.getObjectPath(o), s.bucketName)
 	out, err := uploader.Upload(&s3manager.UploadInput{
 		Key:    aws.String(s.getObjectPath(o)),
 		Bucket: aws.String(s.bucketName),
-		Body:   bytes.NewReader(b),
+		Body:   data,
 	})
 	if err != nil {
 		return "", sdk.WrapError(err, "AWS-S3-Store> Unable to create object %s", s.getObjectPath(o))
@@ -41,19 +41,20 @@ func (s *SSHStore) Status(ctx context.Context) sdk.MonitoringStatusLine {
 	return sdk.MonitoringStatusLine{Component: "Object-Store", Value: "SSH Storage", Status: sdk.MonitoringStatusOK}
 }
 
-// Copies the encoded contents of an io.Reader to a remote location
+// Copies the encoded contents of an io.Reader to a remote location, content is streamed through the session stdin
 func (s *SSHStore) copy(session *ssh.Session, r io.Reader, path string) error {
-	b, err := ioutil.ReadAll(r)
-	if err != nil {
-		return err
-	}
-
-	cmd := bytes.NewBufferString("echo \"")
-	cmd.WriteString(base64.StdEncoding.EncodeToString(b))
-	cmd.WriteString("\" >")
-	cmd.WriteString(path)
-
-	return session.Run(cmd.String())
+	pr, pw := io.Pipe()
+	go func() {
+		enc := base64.NewEncoder(base64.StdEncoding, pw)
+		_, err := io.Copy(enc, r)
+		if err == nil {
+			err = enc.Close()
+		}
+		pw.CloseWithError(err) // nolint
+	}()
+	session.Stdin = pr
+
+	return session.Run("cat >" + path)
 }
 
 func (s *SSHStore) read(session *ssh.Session, path string) (io.ReadCloser, error) {
@@ -11,11 +11,11 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/sdk/log"
-	"github.com/ovh/cds/sdk/tracingutils"
 )
 
 // Tags contants
@@ -36,38 +36,21 @@ const (
 	TagPermission         = "permission"
 )
 
-// LinkTo a traceID
-func LinkTo(ctx context.Context, traceID [16]byte) {
-	s := Current(ctx)
-	if s == nil {
-		return
-	}
-
-	s.AddLink(
-		trace.Link{
-			TraceID: traceID,
-		},
-	)
-}
-
 // Current return the current span
-func Current(ctx context.Context, tags ...trace.Attribute) *trace.Span {
+func Current(ctx context.Context, tags ...attribute.KeyValue) trace.Span {
 	if ctx == nil {
-		return nil
-	}
-	span := trace.FromContext(ctx)
-	if span == nil {
-		return nil
+		ctx = context.Background()
 	}
+	span := trace.SpanFromContext(ctx)
 	if len(tags) > 0 {
-		span.AddAttributes(tags...)
+		span.SetAttributes(tags...)
 	}
 	return span
 }
 
-// Tag is helper function to instantiate trace.Attribute
-func Tag(key string, value interface{}) trace.Attribute {
-	return trace.StringAttribute(key, fmt.Sprintf("%v", value))
+// Tag is helper function to instantiate attribute.KeyValue
+func Tag(key string, value interface{}) attribute.KeyValue {
+	return attribute.String(key, fmt.Sprintf("%v", value))
 }
 
 func ContextWithTag(ctx context.Context, s ...interface{}) context.Context {
@@ -106,19 +89,12 @@ func ContextGetTags(ctx context.Context, s ...string) []tag.Mutator {
 }
 
 // Span start a new span from the parent context
-func Span(ctx context.Context, name string, tags ...trace.Attribute) (context.Context, func()) {
-	// log.Debug("# %s - begin", name)
+func Span(ctx context.Context, name string, tags ...attribute.KeyValue) (context.Context, func()) {
 	if ctx == nil {
 		return context.Background(), func() {}
 	}
-	var span *trace.Span
-	ctx, span = trace.StartSpan(ctx, name)
-	if len(tags) > 0 {
-		span.AddAttributes(tags...)
-	}
-	ctx = tracingutils.SpanContextToContext(ctx, span.SpanContext())
+	ctx, span := tracer().Start(ctx, name, trace.WithAttributes(tags...))
 	return ctx, func() {
-		// log.Debug("# %s - end", name)
 		span.End()
 	}
 }
@@ -2,20 +2,27 @@ package observability
 
 import (
 	"context"
+	"net/url"
+	"strings"
 	"time"
 
-	"contrib.go.opencensus.io/exporter/jaeger"
 	"contrib.go.opencensus.io/exporter/prometheus"
 	"go.opencensus.io/stats/view"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/tracingutils"
 )
 
 var (
-	traceExporter trace.Exporter
-	statsExporter *prometheus.Exporter
+	tracerProvider *sdktrace.TracerProvider
+	statsExporter  *prometheus.Exporter
 )
 
 type service interface {
@@ -31,29 +38,56 @@ func StatsExporter() *prometheus.Exporter {
 	return statsExporter
 }
 
-// Init the opencensus exporter
+// Init the OpenTelemetry tracer provider and the opencensus stats exporter
 func Init(ctx context.Context, cfg Configuration, s service) (context.Context, error) {
 	ctx = ContextWithTag(ctx,
 		TagServiceType, s.Type(),
 		TagServiceName, s.Name(),
 	)
 
-	if cfg.TracingEnabled {
-		trace.ApplyConfig(
-			trace.Config{
-				DefaultSampler: trace.ProbabilitySampler(cfg.Exporters.Jaeger.SamplingProbability),
-			},
+	// The tracer provider is global, when several services are started in the same process the spans are exported
+	// with the name of the first one.
+	if cfg.TracingEnabled && tracerProvider == nil {
+		var exporter sdktrace.SpanExporter
+		var probability float64
+		if cfg.Exporters.OTLP.Endpoint != "" {
+			log.Info(ctx, "observability> initializing otlp exporter for %s/%s", s.Type(), s.Name())
+			e, err := newOTLPExporter(ctx, cfg.Exporters.OTLP.Endpoint, cfg.Exporters.OTLP.Headers)
+			if err != nil {
+				return ctx, err
+			}
+			exporter = e
+			probability = cfg.Exporters.OTLP.SamplingProbability
+		} else {
+			log.Info(ctx, "observability> initializing jaeger exporter for %s/%s", s.Type(), s.Name())
+			e, err := jaeger.New(jaeger.WithCollectorEndpoint(
+				jaeger.WithEndpoint(strings.TrimSuffix(cfg.Exporters.Jaeger.HTTPCollectorEndpoint, "/") + "/api/traces"), //"http://localhost:14268"
+			))
+			if err != nil {
+				return ctx, sdk.WithStack(err)
+			}
+			exporter = e
+			probability = cfg.Exporters.Jaeger.SamplingProbability
+		}
+
+		tp := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithSampler(newSampler(probability)),
+			sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName(s)))),
 		)
-		log.Info(ctx, "observability> initializing jaeger exporter for %s/%s", s.Type(), s.Name())
-		e, err := jaeger.NewExporter(jaeger.Options{
-			Endpoint:    cfg.Exporters.Jaeger.HTTPCollectorEndpoint, //"http://localhost:14268"
-			ServiceName: serviceName(s),
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(tracingutils.DefaultFormat)
+		tracerProvider = tp
+
+		sdk.GoRoutine(ctx, "observability.shutdown", func(ctx context.Context) {
+			<-ctx.Done()
+			// Export the spans which are still in the batch
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tp.Shutdown(shutdownCtx); err != nil {
+				log.Warning(shutdownCtx, "observability> unable to shutdown the tracer provider: %v", err)
+			}
 		})
-		if err != nil {
-			return ctx, sdk.WithStack(err)
-		}
-		trace.RegisterExporter(e)
-		traceExporter = e
 	}
 
 	if cfg.MetricsEnabled {
@@ -74,3 +108,25 @@ func Init(ctx context.Context, cfg Configuration, s service) (context.Context, e
 
 	return ctx, nil
 }
+
+// newOTLPExporter returns an exporter to an OpenTelemetry collector, Jaeger or Tempo with the OTLP/HTTP protocol,
+// the traces are posted on {endpoint}/v1/traces.
+func newOTLPExporter(ctx context.Context, endpoint string, headers map[string]string) (sdktrace.SpanExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid otlp endpoint %q: %v", endpoint, err)
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(strings.TrimSuffix(u.Path, "/") + "/v1/traces"),
+		otlptracehttp.WithHeaders(headers),
+	}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	e, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return e, nil
+}
@@ -18,6 +18,8 @@ var (
 	DefaultSizeDistribution = view.Distribution(25*1024, 100*1024, 250*1024, 500*1024, 1024*1024, 1.5*1024*1024, 5*1024*1024, 10*1024*1024)
 	// DefaultLatencyDistribution 100ms, ...
 	DefaultLatencyDistribution = view.Distribution(100, 200, 300, 400, 500, 750, 1000, 2000, 5000)
+	// DefaultDurationDistribution 1s, 5s, 10s, 30s, 1min, 2min, 5min, 10min, 30min, 1h
+	DefaultDurationDistribution = view.Distribution(1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600)
 )
 
 const (
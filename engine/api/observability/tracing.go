@@ -2,84 +2,97 @@ package observability
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/go-gorp/gorp"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/feature"
 	"github.com/ovh/cds/sdk/tracingutils"
 )
 
-// New may start a tracing span
-func New(ctx context.Context, s service, name string, sampler trace.Sampler, spanKind int) (context.Context, *trace.Span) {
-	if traceExporter == nil {
-		return ctx, nil
-	}
-	return trace.StartSpan(ctx, name,
-		trace.WithSampler(sampler),
-		trace.WithSpanKind(spanKind))
-}
+const instrumentationName = "github.com/ovh/cds"
 
-// Start may start a tracing span
-func Start(ctx context.Context, s service, w http.ResponseWriter, req *http.Request, opt Options, db gorp.SqlExecutor, store cache.Store) (context.Context, error) {
-	if traceExporter == nil {
-		return ctx, nil
-	}
+// forceSamplingKey is set on the spans which are sampled whatever the sampling probability, ie. when the tracing
+// is enabled for the project.
+const forceSamplingKey = attribute.Key("cds.sampling.force")
 
-	tags := []trace.Attribute{}
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
 
-	var span *trace.Span
-	rootSpanContext, hasSpanContext := tracingutils.DefaultFormat.SpanContextFromRequest(req)
+// sampler samples the spans with the forceSamplingKey attribute, the others are sampled as their parent, or
+// with the configured probability for the root spans.
+type sampler struct {
+	sdktrace.Sampler
+}
 
-	var pkey string
-	var ok bool
-	if db != nil && store != nil {
-		pkey, ok = findPrimaryKeyFromRequest(ctx, req, db, store)
-		if pkey != "" {
-			tags = append(tags, trace.StringAttribute("project_key", pkey))
+func newSampler(probability float64) sdktrace.Sampler {
+	return sampler{Sampler: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(probability))}
+}
+
+func (s sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, a := range p.Attributes {
+		if a.Key == forceSamplingKey && a.Value.AsBool() {
+			return sdktrace.SamplingResult{
+				Decision:   sdktrace.RecordAndSample,
+				Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+			}
 		}
 	}
+	return s.Sampler.ShouldSample(p)
+}
 
-	var traceOpts = []trace.StartOption{
-		trace.WithSpanKind(trace.SpanKindServer),
+func (s sampler) Description() string {
+	return fmt.Sprintf("ForceSampling{%s}", s.Sampler.Description())
+}
+
+// New may start a tracing span which is always sampled, child of the span context of ctx if any
+func New(ctx context.Context, s service, name string, spanKind trace.SpanKind) (context.Context, trace.Span) {
+	if tracerProvider == nil {
+		return ctx, trace.SpanFromContext(ctx)
 	}
+	return tracer().Start(ctx, name,
+		trace.WithSpanKind(spanKind),
+		trace.WithAttributes(forceSamplingKey.Bool(true)))
+}
 
-	var sampler trace.Sampler
-	switch {
-	case ok && feature.IsEnabled(ctx, store, feature.FeatEnableTracing, pkey):
-		sampler = trace.AlwaysSample()
-	case hasSpanContext && rootSpanContext.IsSampled():
-		sampler = trace.AlwaysSample()
+// Start may start a tracing span
+func Start(ctx context.Context, s service, w http.ResponseWriter, req *http.Request, opt Options, db gorp.SqlExecutor, store cache.Store) (context.Context, error) {
+	if tracerProvider == nil {
+		return ctx, nil
 	}
 
-	if sampler != nil {
-		traceOpts = append(traceOpts, trace.WithSampler(sampler))
+	// The span is a child of the span of the caller if it sent its trace context
+	ctx = tracingutils.DefaultFormat.Extract(ctx, propagation.HeaderCarrier(req.Header))
+
+	attrs := []attribute.KeyValue{
+		attribute.String(PathAttribute, req.URL.Path),
+		attribute.String(HostAttribute, req.URL.Host),
+		attribute.String(MethodAttribute, req.Method),
+		attribute.String(UserAgentAttribute, req.UserAgent()),
 	}
 
-	if hasSpanContext {
-		ctx, span = trace.StartSpanWithRemoteParent(ctx, opt.Name, rootSpanContext, traceOpts...)
-		span.AddLink(
-			trace.Link{
-				TraceID: rootSpanContext.TraceID,
-				SpanID:  rootSpanContext.SpanID,
-				Type:    trace.LinkTypeChild,
-			},
-		)
-	} else {
-		ctx, span = trace.StartSpan(ctx, opt.Name, traceOpts...)
+	if db != nil && store != nil {
+		pkey, ok := findPrimaryKeyFromRequest(ctx, req, db, store)
+		if ok {
+			attrs = append(attrs, attribute.String(TagProjectKey, pkey))
+			if feature.IsEnabled(ctx, store, feature.FeatEnableTracing, pkey) {
+				attrs = append(attrs, forceSamplingKey.Bool(true))
+			}
+		}
 	}
 
-	span.AddAttributes(tags...)
-	span.AddAttributes(
-		trace.StringAttribute(PathAttribute, req.URL.Path),
-		trace.StringAttribute(HostAttribute, req.URL.Host),
-		trace.StringAttribute(MethodAttribute, req.Method),
-		trace.StringAttribute(UserAgentAttribute, req.UserAgent()),
-	)
+	ctx, _ = tracer().Start(ctx, opt.Name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...))
 
-	ctx = tracingutils.SpanContextToContext(ctx, span.SpanContext())
 	ctx = ContextWithTag(ctx,
 		TagServiceType, s.Type(),
 		TagServiceName, s.Name(),
@@ -89,11 +102,6 @@ func Start(ctx context.Context, s service, w http.ResponseWriter, req *http.Requ
 
 // End may close a tracing span
 func End(ctx context.Context, w http.ResponseWriter, req *http.Request) (context.Context, error) {
-	span := trace.FromContext(ctx)
-	if span == nil {
-		return ctx, nil
-	}
-
-	span.End()
+	trace.SpanFromContext(ctx).End()
 	return ctx, nil
 }
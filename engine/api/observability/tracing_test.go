@@ -0,0 +1,39 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSampler(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(newSampler(0)))
+	tr := tp.Tracer("test")
+
+	// root spans are sampled with the configured probability
+	_, span := tr.Start(context.Background(), "root")
+	require.False(t, span.SpanContext().IsSampled())
+
+	// unless the sampling is forced, ie. by the tracing feature of the project
+	ctx, span := tr.Start(context.Background(), "forced", trace.WithAttributes(forceSamplingKey.Bool(true)))
+	require.True(t, span.SpanContext().IsSampled())
+
+	// children are sampled as their parent
+	_, child := tr.Start(ctx, "child", trace.WithAttributes(attribute.String(TagProjectKey, "KEY")))
+	require.True(t, child.SpanContext().IsSampled())
+	require.Equal(t, span.SpanContext().TraceID(), child.SpanContext().TraceID())
+
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	_, child = tr.Start(trace.ContextWithRemoteSpanContext(context.Background(), remote), "remote child")
+	require.True(t, child.SpanContext().IsSampled())
+	require.Equal(t, remote.TraceID(), child.SpanContext().TraceID())
+}
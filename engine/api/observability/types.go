@@ -14,6 +14,11 @@ type Configuration struct {
 	MetricsEnabled bool `toml:"metricsEnabled" json:"metricsEnabled"`
 	TracingEnabled bool `toml:"tracingEnabled" json:"tracingEnabled"`
 	Exporters      struct {
+		OTLP struct {
+			Endpoint            string            `toml:"endpoint" comment:"OTLP/HTTP endpoint of an OpenTelemetry collector, Jaeger or Tempo, ie. http://localhost:4318. If set, the traces are exported with OTLP instead of the jaeger exporter" json:"endpoint"`
+			Headers             map[string]string `toml:"headers" comment:"Headers sent with the traces, ie. for authentication" json:"-"`
+			SamplingProbability float64           `toml:"samplingProbability" json:"samplingProbability"`
+		} `json:"otlp"`
 		Jaeger struct {
 			HTTPCollectorEndpoint string  `toml:"HTTPCollectorEndpoint" default:"http://localhost:14268" json:"httpCollectorEndpoint"`
 			SamplingProbability   float64 `toml:"samplingProbability" json:"metricSamplingProbability"`
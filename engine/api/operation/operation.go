@@ -51,24 +51,29 @@ func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 	ope.User.Username = u.GetFullname()
 	ope.User.Username = u.GetUsername()
 
-	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
-	if vcsServer == nil {
-		return nil, sdk.WithStack(fmt.Errorf("no vcsServer found"))
-	}
-	client, errC := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
-	if errC != nil {
-		return nil, errC
-	}
+	if app.IsPlainGit() {
+		// Without repositories manager, the repository is pushed with the given url
+		ope.URL = app.RepositoryURL
+	} else {
+		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
+		if vcsServer == nil {
+			return nil, sdk.WithStack(fmt.Errorf("no vcsServer found"))
+		}
+		client, errC := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
+		if errC != nil {
+			return nil, errC
+		}
 
-	repo, errR := client.RepoByFullname(ctx, app.RepositoryFullname)
-	if errR != nil {
-		return nil, sdk.WrapError(errR, "cannot get repo %s", app.RepositoryFullname)
-	}
+		repo, errR := client.RepoByFullname(ctx, app.RepositoryFullname)
+		if errR != nil {
+			return nil, sdk.WrapError(errR, "cannot get repo %s", app.RepositoryFullname)
+		}
 
-	if app.RepositoryStrategy.ConnectionType == "ssh" {
-		ope.URL = repo.SSHCloneURL
-	} else {
-		ope.URL = repo.HTTPCloneURL
+		if app.RepositoryStrategy.ConnectionType == "ssh" {
+			ope.URL = repo.SSHCloneURL
+		} else {
+			ope.URL = repo.HTTPCloneURL
+		}
 	}
 
 	buf := new(bytes.Buffer)
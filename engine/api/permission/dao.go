@@ -41,6 +41,31 @@ func LoadWorkflowMaxLevelPermission(ctx context.Context, db gorp.SqlExecutor, pr
 	return scanPermissions(rows)
 }
 
+// LoadWorkflowMaxLevelPermissionByWorkflowIDs returns the max permissions of groups on workflows of several projects,
+// keyed by the id of the workflows.
+func LoadWorkflowMaxLevelPermissionByWorkflowIDs(ctx context.Context, db gorp.SqlExecutor, workflowIDs []int64, groupIDs []int64) (sdk.EntitiesPermissions, error) {
+	_, end := observability.Span(ctx, "permission.LoadWorkflowMaxLevelPermissionByWorkflowIDs")
+	defer end()
+
+	query := `
+		SELECT workflow.id::text, max(workflow_perm.role)
+		FROM workflow_perm
+		JOIN workflow ON workflow.id = workflow_perm.workflow_id
+		JOIN project_group ON project_group.id = workflow_perm.project_group_id
+		WHERE project_group.project_id = workflow.project_id
+		AND workflow.id = ANY(string_to_array($1, ',')::int[])
+		AND project_group.group_id = ANY(string_to_array($2, ',')::int[])
+		GROUP BY workflow.id`
+
+	rows, err := db.Query(query, gorpmapping.IDsToQueryString(workflowIDs), gorpmapping.IDsToQueryString(groupIDs))
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer rows.Close()
+
+	return scanPermissions(rows)
+}
+
 func LoadProjectMaxLevelPermission(ctx context.Context, db gorp.SqlExecutor, projectKeys []string, groupIDs []int64) (sdk.EntitiesPermissions, error) {
 	_, end := observability.Span(ctx, "permission.LoadProjectMaxLevelPermission")
 	defer end()
@@ -105,7 +105,7 @@ func UpdatePipelineAction(db gorp.SqlExecutor, job sdk.Job) error {
 }
 
 //CheckJob validate a job
-func CheckJob(ctx context.Context, db gorp.SqlExecutor, job *sdk.Job) error {
+func CheckJob(ctx context.Context, db gorp.SqlExecutor, projectID int64, job *sdk.Job) error {
 	t := time.Now()
 	log.Debug("CheckJob> Begin")
 	defer log.Debug("CheckJob> End (%d ns)", time.Since(t).Nanoseconds())
@@ -115,7 +115,7 @@ func CheckJob(ctx context.Context, db gorp.SqlExecutor, job *sdk.Job) error {
 		step := &job.Action.Actions[i]
 		log.Debug("CheckJob> Checking step %s", step.Name)
 
-		a, err := action.RetrieveForGroupAndName(ctx, db, step.Group, step.Name)
+		a, err := action.RetrieveForProjectGroupAndName(ctx, db, projectID, step.Group, step.Name)
 		if err != nil {
 			if sdk.ErrorIs(err, sdk.ErrNoAction) {
 				errs = append(errs, sdk.NewMessage(sdk.MsgJobNotValidActionNotFound, job.Action.Name, step.Name, i+1))
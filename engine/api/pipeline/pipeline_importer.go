@@ -66,11 +66,11 @@ func ImportUpdate(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Project, p
 			//Insert stage's Jobs
 			for x := range s.Jobs {
 				jobAction := &s.Jobs[x]
-				if errs := CheckJob(ctx, db, jobAction); errs != nil {
+				if errs := CheckJob(ctx, db, proj.ID, jobAction); errs != nil {
 					log.Debug("CheckJob > %s", errs)
 					return errs
 				}
-				if err := action.CheckChildrenForGroupIDs(ctx, db, &jobAction.Action, groupIDs); err != nil {
+				if err := action.CheckChildrenForProjectAndGroupIDs(ctx, db, &jobAction.Action, proj.ID, groupIDs); err != nil {
 					return err
 				}
 				jobAction.PipelineStageID = s.ID
@@ -94,11 +94,11 @@ func ImportUpdate(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Project, p
 			for x := range s.Jobs {
 				jobAction := &s.Jobs[x]
 				//Check the job
-				if errs := CheckJob(ctx, db, jobAction); errs != nil {
+				if errs := CheckJob(ctx, db, proj.ID, jobAction); errs != nil {
 					log.Debug(">> CheckJob > %s", errs)
 					return errs
 				}
-				if err := action.CheckChildrenForGroupIDs(ctx, db, &jobAction.Action, groupIDs); err != nil {
+				if err := action.CheckChildrenForProjectAndGroupIDs(ctx, db, &jobAction.Action, proj.ID, groupIDs); err != nil {
 					return err
 				}
 			}
@@ -270,11 +270,11 @@ func importNew(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj
 			jobAction := &s.Jobs[i]
 			jobAction.Enabled = true
 			jobAction.Action.Enabled = true
-			if errs := CheckJob(ctx, db, jobAction); errs != nil {
+			if errs := CheckJob(ctx, db, proj.ID, jobAction); errs != nil {
 				log.Warning(ctx, "pipeline.importNew.CheckJob > %s", errs)
 				return errs
 			}
-			if err := action.CheckChildrenForGroupIDs(ctx, db, &jobAction.Action, groupIDs); err != nil {
+			if err := action.CheckChildrenForProjectAndGroupIDs(ctx, db, &jobAction.Action, proj.ID, groupIDs); err != nil {
 				return err
 			}
 
@@ -1,14 +1,19 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/pipeline"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
+	"github.com/ovh/cds/sdk/log"
 )
 
 func (api *API) getPipelineExportHandler() service.Handler {
@@ -28,13 +33,30 @@ func (api *API) getPipelineExportHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "Format invalid")
 		}
-		if _, err := pipeline.Export(ctx, api.mustDB(), api.Cache, key, name, f, w); err != nil {
-			return sdk.WrapError(err, "getPipelineExportHandler")
+
+		// the export is cached until the project changes
+		entity := cache.ProjectEntity(key)
+		cacheKey := cache.Key("export", "pipeline", name, format)
+		var export string
+		found, err := api.EntityCache.Get(entity, cacheKey, &export)
+		if err != nil {
+			log.Warning(ctx, "getPipelineExportHandler> cannot get export from cache: %v", err)
+		}
+		if !found {
+			var buf bytes.Buffer
+			if _, err := pipeline.Export(ctx, api.mustDBReplica(), api.Cache, key, name, f, &buf); err != nil {
+				return sdk.WrapError(err, "getPipelineExportHandler")
+			}
+			export = buf.String()
+			if err := api.EntityCache.Set(entity, cacheKey, export); err != nil {
+				log.Warning(ctx, "getPipelineExportHandler> cannot set export in cache: %v", err)
+			}
 		}
 
 		w.Header().Add("Content-Type", exportentities.GetContentType(f))
 		w.WriteHeader(http.StatusOK)
 
-		return nil
+		_, err = io.WriteString(w, export)
+		return sdk.WithStack(err)
 	}
 }
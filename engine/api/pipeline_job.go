@@ -80,7 +80,7 @@ func (api *API) addJobToStageHandler() service.Handler {
 		for i := range project.ProjectGroups {
 			groupIDs = append(groupIDs, project.ProjectGroups[i].Group.ID)
 		}
-		if err := action.CheckChildrenForGroupIDs(ctx, tx, &job.Action, groupIDs); err != nil {
+		if err := action.CheckChildrenForProjectAndGroupIDs(ctx, tx, &job.Action, project.ID, groupIDs); err != nil {
 			return err
 		}
 
@@ -204,7 +204,7 @@ func (api *API) updateJobHandler() service.Handler {
 		for i := range project.ProjectGroups {
 			groupIDs = append(groupIDs, project.ProjectGroups[i].Group.ID)
 		}
-		if err := action.CheckChildrenForGroupIDs(ctx, tx, &job.Action, groupIDs); err != nil {
+		if err := action.CheckChildrenForProjectAndGroupIDs(ctx, tx, &job.Action, project.ID, groupIDs); err != nil {
 			return err
 		}
 
@@ -40,6 +40,7 @@ func (p *grpcPlugin) PostUpdate(db gorp.SqlExecutor) error {
 	for i := range p.Binaries {
 		p.Binaries[i].FileContent = nil
 		p.Binaries[i].PluginName = p.Name
+		p.Binaries[i].Sandbox = nil
 	}
 	s, err := gorpmapping.JSONToNullString(p.Binaries)
 	if err != nil {
@@ -61,6 +62,17 @@ func Delete(ctx context.Context, db gorp.SqlExecutor, storageDriver objectstore.
 		}
 	}
 
+	// the versions of the registry are deleted with the plugin
+	vs, err := LoadVersions(ctx, db, p)
+	if err != nil {
+		return err
+	}
+	for i := range vs {
+		if err := DeleteVersion(ctx, db, storageDriver, &vs[i]); err != nil {
+			return err
+		}
+	}
+
 	m := grpcPlugin(*p)
 	if _, err := db.Delete(&m); err != nil {
 		return sdk.WrapError(err, "plugin.Delete")
@@ -0,0 +1,126 @@
+package plugin
+
+import (
+	"context"
+	"io"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// InsertVersion publishes a version of a plugin in the registry, uploading its binaries to the objectstore.
+func InsertVersion(ctx context.Context, db gorp.SqlExecutor, storage objectstore.Driver, p *sdk.GRPCPlugin, v *sdk.GRPCPluginVersion, contents map[string]io.ReadCloser) error {
+	v.PluginID = p.ID
+	v.PluginName = p.Name
+	for i := range v.Binaries {
+		b := &v.Binaries[i]
+		b.PluginName = p.Name
+		b.Version = v.Version
+		b.FileContent = nil
+		b.Sandbox = nil
+		r, ok := contents[b.OS+"/"+b.Arch]
+		if !ok {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing content of binary %s/%s", b.OS, b.Arch)
+		}
+		objectPath, err := storage.Store(b, r)
+		if err != nil {
+			return sdk.WrapError(err, "cannot store binary %s/%s", b.OS, b.Arch)
+		}
+		b.ObjectPath = objectPath
+	}
+
+	dbV := grpcPluginVersion(*v)
+	if err := gorpmapping.Insert(db, &dbV); err != nil {
+		return sdk.WrapError(err, "cannot insert version %s of plugin %s", v.Version, p.Name)
+	}
+	*v = sdk.GRPCPluginVersion(dbV)
+	v.PluginName = p.Name
+	return nil
+}
+
+// DeleteVersion removes a version of a plugin from the registry, with its binaries.
+func DeleteVersion(ctx context.Context, db gorp.SqlExecutor, storage objectstore.Driver, v *sdk.GRPCPluginVersion) error {
+	for _, b := range v.Binaries {
+		if err := storage.Delete(ctx, b); err != nil {
+			log.Error(ctx, "plugin.DeleteVersion> unable to delete binary %v", b.ObjectPath)
+		}
+	}
+	dbV := grpcPluginVersion(*v)
+	if err := gorpmapping.Delete(db, &dbV); err != nil {
+		return sdk.WrapError(err, "cannot delete version %s of plugin %s", v.Version, v.PluginName)
+	}
+	return nil
+}
+
+// LoadVersions returns the versions of a plugin published in the registry, the last published first.
+func LoadVersions(ctx context.Context, db gorp.SqlExecutor, p *sdk.GRPCPlugin) ([]sdk.GRPCPluginVersion, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM grpc_plugin_version WHERE grpc_plugin_id = $1 ORDER BY published DESC, id DESC").Args(p.ID)
+	return getVersions(ctx, db, query, map[int64]string{p.ID: p.Name})
+}
+
+// LoadAllVersions returns the versions of all the plugins published in the registry.
+func LoadAllVersions(ctx context.Context, db gorp.SqlExecutor, ps []sdk.GRPCPlugin) ([]sdk.GRPCPluginVersion, error) {
+	names := make(map[int64]string, len(ps))
+	for i := range ps {
+		names[ps[i].ID] = ps[i].Name
+	}
+	query := gorpmapping.NewQuery("SELECT * FROM grpc_plugin_version ORDER BY grpc_plugin_id, published DESC, id DESC")
+	return getVersions(ctx, db, query, names)
+}
+
+// LoadVersion returns a version of a plugin published in the registry.
+func LoadVersion(ctx context.Context, db gorp.SqlExecutor, p *sdk.GRPCPlugin, version string) (*sdk.GRPCPluginVersion, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM grpc_plugin_version WHERE grpc_plugin_id = $1 AND version = $2").Args(p.ID, version)
+	var dbV grpcPluginVersion
+	found, err := gorpmapping.Get(ctx, db, query, &dbV)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load version %s of plugin %s", version, p.Name)
+	}
+	if !found {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "version %s of plugin %s not found", version, p.Name)
+	}
+	v := sdk.GRPCPluginVersion(dbV)
+	v.PluginName = p.Name
+	return &v, nil
+}
+
+func getVersions(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query, names map[int64]string) ([]sdk.GRPCPluginVersion, error) {
+	var dbVs []grpcPluginVersion
+	if err := gorpmapping.GetAll(ctx, db, query, &dbVs); err != nil {
+		return nil, sdk.WrapError(err, "cannot load plugin versions")
+	}
+	vs := make([]sdk.GRPCPluginVersion, len(dbVs))
+	for i := range dbVs {
+		vs[i] = sdk.GRPCPluginVersion(dbVs[i])
+		vs[i].PluginName = names[vs[i].PluginID]
+	}
+	return vs, nil
+}
+
+// InstallVersion makes a version of the registry the installed plugin, used by the jobs which don't require
+// a version of the plugin.
+func InstallVersion(ctx context.Context, db gorp.SqlExecutor, storage objectstore.Driver, p *sdk.GRPCPlugin, v *sdk.GRPCPluginVersion) error {
+	for i := range v.Binaries {
+		f, err := storage.Fetch(ctx, v.Binaries[i])
+		if err != nil {
+			return sdk.WrapError(err, "cannot fetch binary %s/%s", v.Binaries[i].OS, v.Binaries[i].Arch)
+		}
+		b := v.Binaries[i]
+		b.Version = ""
+		b.ObjectPath = ""
+		if p.GetBinary(b.OS, b.Arch) == nil {
+			err = AddBinary(ctx, db, storage, p, &b, f)
+		} else {
+			err = UpdateBinary(ctx, db, storage, p, &b, f)
+		}
+		_ = f.Close()
+		if err != nil {
+			return sdk.WrapError(err, "cannot install binary %s/%s", b.OS, b.Arch)
+		}
+	}
+	return nil
+}
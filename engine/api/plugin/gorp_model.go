@@ -7,6 +7,9 @@ import (
 
 type grpcPlugin sdk.GRPCPlugin
 
+type grpcPluginVersion sdk.GRPCPluginVersion
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(grpcPlugin{}, "grpc_plugin", true, "id"))
+	gorpmapping.Register(gorpmapping.New(grpcPluginVersion{}, "grpc_plugin_version", true, "id"))
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/group"
@@ -248,6 +249,7 @@ func (api *API) updateProjectHandler() service.Handler {
 			return sdk.WrapError(errUp, "updateProject> Cannot update project %s", key)
 		}
 		event.PublishUpdateProject(ctx, proj, p, getAPIConsumer(ctx))
+		setAuditLogData(ctx, p, proj)
 
 		proj.Permissions.Readable = true
 		proj.Permissions.Writable = true
@@ -664,6 +666,9 @@ func (api *API) deleteProjectHandler() service.Handler {
 		if err := project.Delete(tx, api.Cache, p.Key); err != nil {
 			return sdk.WrapError(err, "cannot delete project %s", key)
 		}
+		if err := authentication.DeleteConsumersByProjectKey(tx, p.Key); err != nil {
+			return err
+		}
 		if err := tx.Commit(); err != nil {
 			return sdk.WrapError(err, "Cannot commit transaction")
 		}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+)
+
+// getProjectDeploymentsHandler returns the last successful deployment of each application on each environment of
+// a project.
+func (api *API) getProjectDeploymentsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		ds, err := environment.LoadDeploymentsByProjectID(api.mustDB(), proj.ID, FormString(r, "application"), FormString(r, "environment"))
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ds, http.StatusOK)
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultDORADays = 30
+
+// getProjectDORAMetricsHandler returns the DORA metrics of a project and of its workflows, computed from the runs of
+// the production deployment nodes of the last days.
+func (api *API) getProjectDORAMetricsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		days, err := FormInt(r, "days")
+		if err != nil {
+			return err
+		}
+		if days <= 0 {
+			days = defaultDORADays
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		to := time.Now()
+		from := to.AddDate(0, 0, -days)
+		ds, err := workflow.LoadProductionDeployments(api.mustDB(), proj.ID, from, to)
+		if err != nil {
+			return err
+		}
+		report := sdk.ComputeDORAReport(ds, from, to)
+
+		switch format := QueryString(r, "format"); format {
+		case "", "json":
+			return service.WriteJSON(w, report, http.StatusOK)
+		case "csv":
+			w.Header().Add("Content-Type", "text/csv")
+			w.Header().Add("Content-Disposition", "attachment; filename=\"cds-dora-"+proj.Key+".csv\"")
+			w.WriteHeader(http.StatusOK)
+			return writeDORAReportCSV(w, report)
+		default:
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given format %q", format)
+		}
+	}
+}
+
+// writeDORAReportCSV writes a line for each workflow, then a line for the whole project with an empty workflow.
+func writeDORAReportCSV(w http.ResponseWriter, report sdk.DORAReport) error {
+	c := csv.NewWriter(w)
+	if err := c.Write([]string{"from", "to", "workflow", "deployments", "failures", "deployments_per_day",
+		"lead_time_seconds", "change_failure_rate", "time_to_restore_seconds"}); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	for _, m := range append(report.Workflows, report.Project) {
+		if err := c.Write([]string{
+			report.From.Format(time.RFC3339),
+			report.To.Format(time.RFC3339),
+			m.WorkflowName,
+			strconv.FormatInt(m.Deployments, 10),
+			strconv.FormatInt(m.Failures, 10),
+			strconv.FormatFloat(m.DeploymentFrequency, 'f', 3, 64),
+			strconv.FormatInt(m.LeadTime, 10),
+			strconv.FormatFloat(m.ChangeFailureRate, 'f', 3, 64),
+			strconv.FormatInt(m.TimeToRestore, 10),
+		}); err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+
+	c.Flush()
+	return sdk.WithStack(c.Error())
+}
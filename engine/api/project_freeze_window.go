@@ -0,0 +1,295 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/freezewindow"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	// freezeWindowSchedulerInterval is the delay between two checks of the node runs queued by freeze windows.
+	freezeWindowSchedulerInterval = 30 * time.Second
+	// freezeWindowBatchSize is the max count of queued node runs checked at each check.
+	freezeWindowBatchSize = 50
+	// defaultFreezeOverridesLimit is the count of overrides returned by the audit of a project.
+	defaultFreezeOverridesLimit = 100
+)
+
+// freezeWindowScheduler executes the node runs queued by freeze windows once the windows are over. The node runs
+// are locked while they are checked, so each API instance can run the scheduler.
+func (api *API) freezeWindowScheduler(ctx context.Context) {
+	tick := time.NewTicker(freezeWindowSchedulerInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			if api.Maintenance {
+				continue
+			}
+			if err := api.releaseFrozenNodeRuns(ctx); err != nil {
+				log.Error(ctx, "freezeWindowScheduler> %v", err)
+			}
+		}
+	}
+}
+
+func (api *API) releaseFrozenNodeRuns(ctx context.Context) error {
+	db := api.mustDB()
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WrapError(err, "cannot start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	nodeRuns, err := workflow.LoadAndLockFrozenNodeRuns(ctx, tx, freezeWindowBatchSize)
+	if err != nil {
+		return err
+	}
+
+	reports := make(map[string]*workflow.ProcessorReport)
+	for i := range nodeRuns {
+		nr := &nodeRuns[i]
+		wr, err := workflow.LoadRunByID(tx, nr.WorkflowRunID, workflow.LoadRunOptions{})
+		if err != nil {
+			return err
+		}
+		proj, err := project.LoadByID(tx, api.Cache, wr.ProjectID,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithFeatures,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithApplicationVariables,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %d", wr.ProjectID)
+		}
+
+		r1, err := workflow.ReleaseFrozenNodeRun(ctx, tx, api.Cache, proj, nr)
+		if err != nil {
+			return err
+		}
+		if reports[proj.Key] == nil {
+			reports[proj.Key] = new(workflow.ProcessorReport)
+		}
+		reports[proj.Key].Merge(ctx, r1, nil) // nolint
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "unable to commit transaction")
+	}
+
+	for key, report := range reports {
+		go WorkflowSendEvent(context.Background(), db, api.Cache, key, report)
+	}
+	return nil
+}
+
+// freezeOverride returns the override of the freeze windows of the project that are active when a run is started.
+// The consumer should be an admin or a member of the override group of each window.
+func (api *API) freezeOverride(ctx context.Context, proj *sdk.Project, consumer *sdk.AuthConsumer, reason string) (*sdk.WorkflowRunFreeze, sdk.FreezeWindowNames, error) {
+	ws, err := freezewindow.LoadAllByProjectID(ctx, api.mustDB(), proj.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	override := &sdk.WorkflowRunFreeze{Username: consumer.GetUsername(), Reason: reason}
+	var names sdk.FreezeWindowNames
+	for _, w := range ws {
+		if active, _ := w.ActiveAt(now); !active {
+			continue
+		}
+		if !consumer.Admin() && (w.OverrideGroupID == nil || !sdk.IsInInt64Array(*w.OverrideGroupID, consumer.GetGroupIDs())) {
+			return nil, nil, sdk.NewErrorFrom(sdk.ErrForbidden, "you are not allowed to override the freeze window %s", w.Name)
+		}
+		override.WindowIDs = append(override.WindowIDs, w.ID)
+		names = append(names, w.Name)
+	}
+	if len(names) == 0 {
+		return nil, nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "no freeze window is active on project %s", proj.Key)
+	}
+	return override, names, nil
+}
+
+func (api *API) getProjectFreezeWindowsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		ws, err := freezewindow.LoadAllByProjectID(ctx, api.mustDB(), proj.ID)
+		if err != nil {
+			return err
+		}
+		for i := range ws {
+			if ws[i].OverrideGroupID == nil {
+				continue
+			}
+			g, err := group.LoadByID(ctx, api.mustDB(), *ws[i].OverrideGroupID)
+			if err != nil {
+				return err
+			}
+			ws[i].OverrideGroupName = g.Name
+		}
+
+		return service.WriteJSON(w, ws, http.StatusOK)
+	}
+}
+
+// checkFreezeWindow validates a freeze window of the project and sets the id of its override group.
+func (api *API) checkFreezeWindow(ctx context.Context, proj *sdk.Project, fw *sdk.FreezeWindow) error {
+	if err := fw.IsValid(); err != nil {
+		return err
+	}
+
+	if fw.Environment != "" {
+		var found bool
+		for _, env := range proj.Environments {
+			if env.Name == fw.Environment {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "environment %s not found in project %s", fw.Environment, proj.Key)
+		}
+	}
+
+	fw.OverrideGroupID = nil
+	if fw.OverrideGroupName != "" {
+		g, err := group.LoadByName(ctx, api.mustDB(), fw.OverrideGroupName)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "group %s not found", fw.OverrideGroupName)
+		}
+		fw.OverrideGroupID = &g.ID
+	}
+	return nil
+}
+
+func (api *API) postProjectFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, project.LoadOptions.WithEnvironments)
+		if err != nil {
+			return err
+		}
+
+		var fw sdk.FreezeWindow
+		if err := service.UnmarshalBody(r, &fw); err != nil {
+			return err
+		}
+		if err := api.checkFreezeWindow(ctx, proj, &fw); err != nil {
+			return err
+		}
+		fw.ProjectID = proj.ID
+		fw.Author = getAPIConsumer(ctx).GetUsername()
+
+		if err := freezewindow.Insert(api.mustDB(), &fw); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, fw, http.StatusOK)
+	}
+}
+
+func (api *API) putProjectFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, project.LoadOptions.WithEnvironments)
+		if err != nil {
+			return err
+		}
+
+		old, err := freezewindow.LoadByProjectIDAndName(ctx, api.mustDB(), proj.ID, vars["name"])
+		if err != nil {
+			return err
+		}
+
+		var fw sdk.FreezeWindow
+		if err := service.UnmarshalBody(r, &fw); err != nil {
+			return err
+		}
+		if err := api.checkFreezeWindow(ctx, proj, &fw); err != nil {
+			return err
+		}
+		fw.ID = old.ID
+		fw.ProjectID = old.ProjectID
+		fw.Author = getAPIConsumer(ctx).GetUsername()
+		fw.Created = old.Created
+
+		if err := freezewindow.Update(api.mustDB(), &fw); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, fw, http.StatusOK)
+	}
+}
+
+func (api *API) deleteProjectFreezeWindowHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		fw, err := freezewindow.LoadByProjectIDAndName(ctx, api.mustDB(), proj.ID, vars["name"])
+		if err != nil {
+			return err
+		}
+		if err := freezewindow.Delete(api.mustDB(), fw); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) getProjectFreezeOverridesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		limit, err := FormInt(r, "limit")
+		if err != nil {
+			return err
+		}
+		if limit <= 0 {
+			limit = defaultFreezeOverridesLimit
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		overrides, err := freezewindow.LoadOverridesByProjectID(ctx, api.mustDB(), proj.ID, limit)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, overrides, http.StatusOK)
+	}
+}
@@ -0,0 +1,257 @@
+package purge
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// maxArchivedRunsByProject is the maximum number of runs archived for a project at each purge.
+const maxArchivedRunsByProject = 100
+
+// archiveWorkflowRuns archives the old runs of the projects which have retention settings.
+func archiveWorkflowRuns(ctx context.Context, db *gorp.DbMap, store cache.Store, sharedStorage objectstore.Driver) error {
+	settings, err := workflow.LoadAllRetentionSettings(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range settings {
+		proj, err := project.LoadByID(db, store, s.ProjectID)
+		if err != nil {
+			log.Error(ctx, "archiveWorkflowRuns> unable to load project %d: %v", s.ProjectID, err)
+			continue
+		}
+
+		ids, err := workflow.LoadRunIDsToArchive(db, s.ProjectID, time.Now().AddDate(0, 0, -s.ArchiveAfterDays), maxArchivedRunsByProject)
+		if err != nil {
+			log.Error(ctx, "archiveWorkflowRuns> %v", err)
+			continue
+		}
+
+		for _, id := range ids {
+			if err := ArchiveWorkflowRun(ctx, db, sharedStorage, *proj, s, id); err != nil {
+				log.Error(ctx, "archiveWorkflowRuns> unable to archive run %d of project %s: %v", id, proj.Key, err)
+				continue
+			}
+			time.Sleep(10 * time.Millisecond) // avoid DDOS the database
+		}
+	}
+	return nil
+}
+
+// ArchiveWorkflowRun moves the logs and the artifacts of a run to the storage of the archives of its project.
+// The artifacts promoted to other workflows are kept in their storage.
+func ArchiveWorkflowRun(ctx context.Context, db *gorp.DbMap, sharedStorage objectstore.Driver, proj sdk.Project, s sdk.ProjectRetentionSettings, workflowRunID int64) error {
+	wr, err := workflow.LoadRunByID(db, workflowRunID, workflow.LoadRunOptions{WithArtifacts: true})
+	if err != nil {
+		return sdk.WrapError(err, "cannot load run %d", workflowRunID)
+	}
+
+	archive := sdk.NewWorkflowRunArchive(wr.ID, s.StorageIntegrationName())
+	archiveDriver, err := objectstore.GetDriver(ctx, db, sharedStorage, proj.Key, archive.IntegrationName)
+	if err != nil {
+		return err
+	}
+
+	logs, err := workflow.LoadRunLogs(db, *wr)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := writeArchiveLogs(&buf, logs); err != nil {
+		return err
+	}
+	archive.Size = int64(buf.Len())
+	if _, err := archiveDriver.Store(archive.LogsObject(), ioutil.NopCloser(&buf)); err != nil {
+		return sdk.WrapError(err, "cannot store logs of run %d", wr.ID)
+	}
+
+	referencedIDs, err := workflow.LoadReferencedArtifactIDs(db, []int64{wr.ID})
+	if err != nil {
+		return err
+	}
+	referenced := make(map[int64]bool, len(referencedIDs))
+	for _, id := range referencedIDs {
+		referenced[id] = true
+	}
+
+	var archived []sdk.WorkflowNodeRunArtifact
+	var archivedIDs []int64
+	for _, nrs := range wr.WorkflowNodeRuns {
+		for _, nr := range nrs {
+			for _, art := range nr.Artifacts {
+				if referenced[art.ID] || art.Archived {
+					continue
+				}
+				driver, err := artifactStorageDriver(ctx, db, sharedStorage, proj.Key, art)
+				if err != nil {
+					return err
+				}
+				f, err := driver.Fetch(ctx, &art)
+				if err != nil {
+					return sdk.WrapError(err, "cannot fetch artifact %s of run %d", art.Name, wr.ID)
+				}
+				if _, err := archiveDriver.Store(archive.ArtifactObject(art), f); err != nil {
+					return sdk.WrapError(err, "cannot store artifact %s of run %d", art.Name, wr.ID)
+				}
+				archive.Size += art.Size
+				archived = append(archived, art)
+				archivedIDs = append(archivedIDs, art.ID)
+			}
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WrapError(err, "unable to start tx")
+	}
+	defer tx.Rollback() // nolint
+
+	if err := workflow.DeleteRunLogs(tx, wr.ID); err != nil {
+		return err
+	}
+	if err := workflow.InsertRunArchive(tx, &archive, archivedIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "unable to commit tx")
+	}
+
+	for i := range archived {
+		driver, err := artifactStorageDriver(ctx, db, sharedStorage, proj.Key, archived[i])
+		if err != nil {
+			log.Error(ctx, "ArchiveWorkflowRun> %v", err)
+			continue
+		}
+		if err := driver.Delete(ctx, &archived[i]); err != nil {
+			log.Error(ctx, "ArchiveWorkflowRun> unable to delete artifact %s of run %d: %v", archived[i].Name, wr.ID, err)
+		}
+	}
+
+	log.Info(ctx, "ArchiveWorkflowRun> run %d of project %s archived in %s (%d bytes)", wr.ID, proj.Key, archive.IntegrationName, archive.Size)
+	return nil
+}
+
+// RehydrateWorkflowRun restores the logs and the artifacts of an archived run from the storage of the archives.
+// The run is then kept for another retention period.
+func RehydrateWorkflowRun(ctx context.Context, db *gorp.DbMap, sharedStorage objectstore.Driver, proj sdk.Project, workflowRunID int64) error {
+	archive, err := workflow.LoadRunArchive(ctx, db, workflowRunID)
+	if err != nil {
+		return err
+	}
+	archiveDriver, err := objectstore.GetDriver(ctx, db, sharedStorage, proj.Key, archive.IntegrationName)
+	if err != nil {
+		return err
+	}
+
+	f, err := archiveDriver.Fetch(ctx, archive.LogsObject())
+	if err != nil {
+		return sdk.WrapError(err, "cannot fetch logs of run %d", workflowRunID)
+	}
+	logs, err := readArchiveLogs(f)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+
+	wr, err := workflow.LoadRunByID(db, workflowRunID, workflow.LoadRunOptions{WithArtifacts: true})
+	if err != nil {
+		return sdk.WrapError(err, "cannot load run %d", workflowRunID)
+	}
+	for _, nrs := range wr.WorkflowNodeRuns {
+		for _, nr := range nrs {
+			for _, art := range nr.Artifacts {
+				if !art.Archived {
+					continue
+				}
+				driver, err := artifactStorageDriver(ctx, db, sharedStorage, proj.Key, art)
+				if err != nil {
+					return err
+				}
+				f, err := archiveDriver.Fetch(ctx, archive.ArtifactObject(art))
+				if err != nil {
+					return sdk.WrapError(err, "cannot fetch artifact %s of run %d", art.Name, workflowRunID)
+				}
+				objectPath, err := driver.Store(&art, f)
+				if err != nil {
+					return sdk.WrapError(err, "cannot store artifact %s of run %d", art.Name, workflowRunID)
+				}
+				if err := workflow.UpdateArtifactObjectPath(db, art.ID, objectPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WrapError(err, "unable to start tx")
+	}
+	defer tx.Rollback() // nolint
+
+	if err := workflow.InsertRunLogs(tx, logs); err != nil {
+		return err
+	}
+	if err := workflow.DeleteRunArchive(tx, *archive); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "unable to commit tx")
+	}
+
+	if err := archiveDriver.DeleteContainer(ctx, archive.Container); err != nil {
+		log.Error(ctx, "RehydrateWorkflowRun> unable to delete archive of run %d: %v", workflowRunID, err)
+	}
+	return nil
+}
+
+// artifactStorageDriver returns the driver of the storage of an artifact.
+func artifactStorageDriver(ctx context.Context, db gorp.SqlExecutor, sharedStorage objectstore.Driver, projectKey string, art sdk.WorkflowNodeRunArtifact) (objectstore.Driver, error) {
+	integrationName := sdk.DefaultStorageIntegrationName
+	if art.ProjectIntegrationID != nil && *art.ProjectIntegrationID > 0 {
+		projectIntegration, err := integration.LoadProjectIntegrationByID(db, *art.ProjectIntegrationID, false)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot load project integration %s/%d", projectKey, *art.ProjectIntegrationID)
+		}
+		integrationName = projectIntegration.Name
+	}
+	return objectstore.GetDriver(ctx, db, sharedStorage, projectKey, integrationName)
+}
+
+func writeArchiveLogs(w io.Writer, logs sdk.WorkflowRunArchiveLogs) error {
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(logs); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(gz.Close())
+}
+
+func readArchiveLogs(r io.Reader) (sdk.WorkflowRunArchiveLogs, error) {
+	var logs sdk.WorkflowRunArchiveLogs
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return logs, sdk.WrapError(err, "invalid logs archive")
+	}
+	defer gz.Close() // nolint
+	if err := json.NewDecoder(gz).Decode(&logs); err != nil {
+		return logs, sdk.WrapError(err, "invalid logs archive")
+	}
+	return logs, nil
+}
@@ -0,0 +1,38 @@
+package purge
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_writeReadArchiveLogs(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	logs := sdk.WorkflowRunArchiveLogs{
+		Steps: []sdk.Log{
+			{JobID: 1, NodeRunID: 2, Start: &now, Done: &now, StepOrder: 0, Val: "checkout"},
+			{JobID: 1, NodeRunID: 2, Start: &now, Done: &now, StepOrder: 1, Val: "make build"},
+		},
+		Services: []sdk.ServiceLog{
+			{WorkflowNodeJobRunID: 1, WorkflowNodeRunID: 2, ServiceRequirementName: "pg", Start: &now, Val: "ready"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeArchiveLogs(&buf, logs))
+
+	res, err := readArchiveLogs(&buf)
+	require.NoError(t, err)
+	require.Len(t, res.Steps, 2)
+	require.Equal(t, "make build", res.Steps[1].Val)
+	require.Equal(t, now, res.Steps[1].Start.UTC())
+	require.Len(t, res.Services, 1)
+	require.Equal(t, "pg", res.Services[0].ServiceRequirementName)
+
+	_, err = readArchiveLogs(bytes.NewBufferString("not an archive"))
+	require.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/notification"
 	"github.com/ovh/cds/engine/api/objectstore"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/project"
@@ -40,6 +41,11 @@ func Initialize(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMa
 			if err := workflows(ctx, DBFunc(), store, workflowRunsMarkToDelete); err != nil {
 				log.Warning(ctx, "purge> Error on workflows : %v", err)
 			}
+
+			log.Debug("purge> Archiving old workflow runs...")
+			if err := archiveWorkflowRuns(ctx, DBFunc(), store, sharedStorage); err != nil {
+				log.Warning(ctx, "purge> Error on archiveWorkflowRuns : %v", err)
+			}
 		}
 	}
 }
@@ -158,6 +164,11 @@ func deleteWorkflowRunsHistory(ctx context.Context, db gorp.SqlExecutor, store c
 			continue
 		}
 
+		if err := notification.DeleteDeliveriesByWorkflowRunID(db, workflowRunID); err != nil {
+			log.Error(ctx, "deleteWorkflowRunsHistory> %v", err)
+			continue
+		}
+
 		res, err := db.Exec("DELETE FROM workflow_run WHERE workflow_run.id = $1", workflowRunID)
 		if err != nil {
 			log.Error(ctx, "deleteWorkflowRunsHistory> unable to delete workflow run %d: %v", workflowRunID, err)
@@ -184,6 +195,17 @@ func DeleteArtifacts(ctx context.Context, db gorp.SqlExecutor, store cache.Store
 		return sdk.WrapError(errprj, "error while load project for workflow %d", wr.WorkflowID)
 	}
 
+	// The artifacts referenced by promotions to other workflows are kept in the storage
+	referencedIDs, err := workflow.LoadReferencedArtifactIDs(db, []int64{workflowRunID})
+	if err != nil {
+		return err
+	}
+	referenced := make(map[int64]bool, len(referencedIDs))
+	for _, id := range referencedIDs {
+		referenced[id] = true
+	}
+	keptContainers := make(map[string]bool)
+
 	type driversContainersT struct {
 		projectKey      string
 		integrationName string
@@ -194,6 +216,12 @@ func DeleteArtifacts(ctx context.Context, db gorp.SqlExecutor, store cache.Store
 	for _, wnrs := range wr.WorkflowNodeRuns {
 		for _, wnr := range wnrs {
 			for _, art := range wnr.Artifacts {
+				if referenced[art.ID] {
+					log.Debug("DeleteArtifacts> keeping promoted artifact %+v", art)
+					keptContainers[art.GetPath()] = true
+					continue
+				}
+
 				var integrationName string
 				if art.ProjectIntegrationID != nil && *art.ProjectIntegrationID > 0 {
 					projectIntegration, err := integration.LoadProjectIntegrationByID(db, *art.ProjectIntegrationID, false)
@@ -239,6 +267,9 @@ func DeleteArtifacts(ctx context.Context, db gorp.SqlExecutor, store cache.Store
 	}
 
 	for _, dc := range driversContainers {
+		if keptContainers[dc.containerPath] {
+			continue
+		}
 		storageDriver, err := objectstore.GetDriver(ctx, db, sharedStorage, dc.projectKey, dc.integrationName)
 		if err != nil {
 			log.Error(ctx, "error while getting driver prj:%v integrationName:%v err:%v", dc.projectKey, dc.integrationName, err)
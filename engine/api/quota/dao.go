@@ -0,0 +1,88 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func get(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) (*sdk.Quota, error) {
+	var dbQ dbQuota
+	found, err := gorpmapping.Get(ctx, db, q, &dbQ)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get quota")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.Quota(dbQ)
+	return &res, nil
+}
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.Quota, error) {
+	var dbQs []dbQuota
+	if err := gorpmapping.GetAll(ctx, db, q, &dbQs); err != nil {
+		return nil, sdk.WrapError(err, "cannot get quotas")
+	}
+	qs := make([]sdk.Quota, len(dbQs))
+	for i := range dbQs {
+		qs[i] = sdk.Quota(dbQs[i])
+	}
+	return qs, nil
+}
+
+// LoadAll returns all the quotas.
+func LoadAll(ctx context.Context, db gorp.SqlExecutor) ([]sdk.Quota, error) {
+	return getAll(ctx, db, gorpmapping.NewQuery("SELECT * FROM quota ORDER BY id"))
+}
+
+// LoadByProjectID returns the quota of given project.
+func LoadByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64) (*sdk.Quota, error) {
+	return get(ctx, db, gorpmapping.NewQuery("SELECT * FROM quota WHERE project_id = $1").Args(projectID))
+}
+
+// LoadByGroupID returns the quota of given group.
+func LoadByGroupID(ctx context.Context, db gorp.SqlExecutor, groupID int64) (*sdk.Quota, error) {
+	return get(ctx, db, gorpmapping.NewQuery("SELECT * FROM quota WHERE group_id = $1").Args(groupID))
+}
+
+// LoadAllByProjectID returns the quota of given project and the quotas of the groups that own the project.
+func LoadAllByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.Quota, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM quota
+		WHERE project_id = $1
+		OR group_id IN (SELECT group_id FROM project_group WHERE project_id = $1 AND role = $2)
+		ORDER BY id`).Args(projectID, sdk.PermissionReadWriteExecute)
+	return getAll(ctx, db, query)
+}
+
+// Insert a quota in database.
+func Insert(db gorp.SqlExecutor, q *sdk.Quota) error {
+	dbQ := dbQuota(*q)
+	if err := gorpmapping.Insert(db, &dbQ); err != nil {
+		return sdk.WrapError(err, "unable to insert quota")
+	}
+	*q = sdk.Quota(dbQ)
+	return nil
+}
+
+// Update a quota in database.
+func Update(db gorp.SqlExecutor, q *sdk.Quota) error {
+	dbQ := dbQuota(*q)
+	if err := gorpmapping.Update(db, &dbQ); err != nil {
+		return sdk.WrapError(err, "unable to update quota %d", q.ID)
+	}
+	return nil
+}
+
+// Delete a quota in database.
+func Delete(db gorp.SqlExecutor, q *sdk.Quota) error {
+	dbQ := dbQuota(*q)
+	if err := gorpmapping.Delete(db, &dbQ); err != nil {
+		return sdk.WrapError(err, "unable to delete quota %d", q.ID)
+	}
+	return nil
+}
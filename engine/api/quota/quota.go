@@ -0,0 +1,130 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// projectIDs returns the ids of the projects on which the quota applies.
+func projectIDs(db gorp.SqlExecutor, q sdk.Quota) ([]int64, error) {
+	if q.ProjectID != nil {
+		return []int64{*q.ProjectID}, nil
+	}
+	var ids []int64
+	if _, err := db.Select(&ids, "SELECT project_id FROM project_group WHERE group_id = $1 AND role = $2", *q.GroupID, sdk.PermissionReadWriteExecute); err != nil {
+		return nil, sdk.WrapError(err, "cannot load projects of group %d", *q.GroupID)
+	}
+	return ids, nil
+}
+
+// ComputeUsage returns the resources currently used by the projects of the quota.
+func ComputeUsage(ctx context.Context, db gorp.SqlExecutor, q sdk.Quota) (sdk.QuotaUsage, error) {
+	var u sdk.QuotaUsage
+	ids, err := projectIDs(db, q)
+	if err != nil || len(ids) == 0 {
+		return u, err
+	}
+	projects := gorpmapping.IDsToQueryString(ids)
+
+	var jobs []string
+	if _, err := db.Select(&jobs, "SELECT COALESCE(job::text, '{}') FROM workflow_node_run_job WHERE status = $1 AND project_id = ANY(string_to_array($2, ',')::int[])", sdk.StatusBuilding, projects); err != nil {
+		return u, sdk.WrapError(err, "cannot load building jobs")
+	}
+	for i := range jobs {
+		var j sdk.ExecutedJob
+		if err := json.Unmarshal([]byte(jobs[i]), &j); err != nil {
+			return u, sdk.WrapError(err, "cannot unmarshal job")
+		}
+		cpus, memory := sdk.JobResources(j.Action.Requirements)
+		u.BuildingJobs++
+		u.CPUs += cpus
+		u.Memory += memory
+	}
+
+	u.ArtifactStorage, err = db.SelectInt(`
+		SELECT COALESCE(SUM(workflow_node_run_artifacts.size), 0)
+		FROM workflow_node_run_artifacts
+		JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
+		WHERE workflow_run.project_id = ANY(string_to_array($1, ',')::int[])`, projects)
+	if err != nil {
+		return u, sdk.WrapError(err, "cannot compute artifact storage")
+	}
+	return u, nil
+}
+
+// CheckJob returns an error if a job of given project with given requirements can't be started.
+func CheckJob(ctx context.Context, db gorp.SqlExecutor, projectID int64, reqs sdk.RequirementList) error {
+	qs, err := LoadAllByProjectID(ctx, db, projectID)
+	if err != nil {
+		return err
+	}
+	cpus, memory := sdk.JobResources(reqs)
+	for _, q := range qs {
+		if !q.LimitsJobs() {
+			continue
+		}
+		u, err := ComputeUsage(ctx, db, q)
+		if err != nil {
+			return err
+		}
+		if err := q.CheckJob(u, cpus, memory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckArtifact returns an error if an artifact of given size can't be stored for given project.
+func CheckArtifact(ctx context.Context, db gorp.SqlExecutor, projectID int64, size int64) error {
+	qs, err := LoadAllByProjectID(ctx, db, projectID)
+	if err != nil {
+		return err
+	}
+	for _, q := range qs {
+		if q.MaxArtifactStorage == 0 {
+			continue
+		}
+		u, err := ComputeUsage(ctx, db, q)
+		if err != nil {
+			return err
+		}
+		if err := q.CheckArtifact(u, size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadExhaustedProjectIDs returns the ids of the projects that can't start any new job.
+func LoadExhaustedProjectIDs(ctx context.Context, db gorp.SqlExecutor) (map[int64]struct{}, error) {
+	qs, err := LoadAll(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]struct{})
+	for _, q := range qs {
+		if !q.LimitsJobs() {
+			continue
+		}
+		u, err := ComputeUsage(ctx, db, q)
+		if err != nil {
+			return nil, err
+		}
+		if !q.Exhausted(u) {
+			continue
+		}
+		ids, err := projectIDs(db, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			res[id] = struct{}{}
+		}
+	}
+	return res, nil
+}
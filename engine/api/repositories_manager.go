@@ -522,6 +522,7 @@ func (api *API) attachRepositoriesManagerHandler() service.Handler {
 
 		app.VCSServer = rm.Name
 		app.RepositoryFullname = fullname
+		app.RepositoryURL = ""
 
 		tx, errT := db.Begin()
 		if errT != nil {
@@ -153,7 +153,7 @@ func LoadForProject(db gorp.SqlExecutor, projectKey, rmName string) (*sdk.Projec
 
 //InsertForApplication associates a repositories manager with an application
 func InsertForApplication(db gorp.SqlExecutor, app *sdk.Application, projectKey string) error {
-	query := `UPDATE application SET vcs_server = $1, repo_fullname = $2 WHERE id = $3`
+	query := `UPDATE application SET vcs_server = $1, repo_fullname = $2, repository_url = '' WHERE id = $3`
 	if _, err := db.Exec(query, app.VCSServer, app.RepositoryFullname, app.ID); err != nil {
 		return err
 	}
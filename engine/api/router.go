@@ -41,6 +41,8 @@ var (
 	Hits                *stats.Int64Measure
 	SSEClients          *stats.Int64Measure
 	SSEEvents           *stats.Int64Measure
+	WebSocketClients    *stats.Int64Measure
+	WebSocketEvents     *stats.Int64Measure
 	ServerRequestCount  *stats.Int64Measure
 	ServerRequestBytes  *stats.Int64Measure
 	ServerResponseBytes *stats.Int64Measure
@@ -62,6 +64,7 @@ type Router struct {
 	nbPanic                int
 	lastPanic              *time.Time
 	scopeDetails           []sdk.AuthConsumerScopeDetail
+	MaxBodySize            int64
 }
 
 // HandlerConfigParam is a type used in handler configuration, to set specific config on a route given a method
@@ -167,6 +170,7 @@ var headers = []string{
 	http.CanonicalHeaderKey(tracingutils.TraceIDHeader),
 	http.CanonicalHeaderKey(tracingutils.SpanIDHeader),
 	http.CanonicalHeaderKey(tracingutils.SampledHeader),
+	http.CanonicalHeaderKey(tracingutils.TraceParentHeader),
 	http.CanonicalHeaderKey(sdk.WorkflowAsCodeHeader),
 	http.CanonicalHeaderKey(sdk.ResponseWorkflowIDHeader),
 	http.CanonicalHeaderKey(sdk.ResponseWorkflowNameHeader),
@@ -177,7 +181,7 @@ func DefaultHeaders() map[string]string {
 	now := time.Now()
 	return map[string]string{
 		"Access-Control-Allow-Origin":              "*",
-		"Access-Control-Allow-Methods":             "GET,OPTIONS,PUT,POST,DELETE",
+		"Access-Control-Allow-Methods":             "GET,OPTIONS,PUT,POST,PATCH,DELETE",
 		"Access-Control-Allow-Headers":             "Accept, Origin, Referer, User-Agent, Content-Type, Authorization, Session-Token, Last-Event-Id, If-Modified-Since, Content-Disposition, " + strings.Join(headers, ", "),
 		"Access-Control-Expose-Headers":            "Accept, Origin, Referer, User-Agent, Content-Type, Authorization, Session-Token, Last-Event-Id, ETag, Content-Disposition, " + strings.Join(headers, ", "),
 		cdsclient.ResponseAPINanosecondsTimeHeader: fmt.Sprintf("%d", now.UnixNano()),
@@ -326,6 +330,13 @@ func (r *Router) Handle(uri string, scope HandlerScope, handlers ...*service.Han
 			return
 		}
 
+		// Limit the request body size, the route configuration overrides router default value
+		maxBodySize := r.MaxBodySize
+		if rc.MaxBodySize != 0 {
+			maxBodySize = rc.MaxBodySize
+		}
+		service.LimitBody(req, maxBodySize)
+
 		// Make the request context inherit from the context of the router
 		tags := observability.ContextGetTags(r.Background, observability.TagServiceType, observability.TagServiceName)
 		ctx, err = tag.New(ctx, tags...)
@@ -563,6 +574,19 @@ func (r *Router) PUT(h service.HandlerFunc, cfg ...HandlerConfigParam) *service.
 	return &rc
 }
 
+// PATCH will set given handler only for PATCH request
+func (r *Router) PATCH(h service.HandlerFunc, cfg ...HandlerConfigParam) *service.HandlerConfig {
+	var rc service.HandlerConfig
+	rc.Handler = h()
+	rc.NeedAuth = true
+	rc.Method = "PATCH"
+	rc.PermissionLevel = sdk.PermissionReadWriteExecute
+	for _, c := range cfg {
+		c(&rc)
+	}
+	return &rc
+}
+
 // DELETE will set given handler only for DELETE request
 func (r *Router) DELETE(h service.HandlerFunc, cfg ...HandlerConfigParam) *service.HandlerConfig {
 	var rc service.HandlerConfig
@@ -600,6 +624,14 @@ func NeedToken(k, v string) HandlerConfigParam {
 	return f
 }
 
+// NeedSCIMToken set the route for the SCIM identity provider, requests must have the SCIM bearer token
+func NeedSCIMToken() HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.NeedSCIMToken = true
+	}
+	return f
+}
+
 // Auth set manually whether authorisation layer should be applied
 // Authorization is enabled by default
 func Auth(v bool) HandlerConfigParam {
@@ -617,6 +649,23 @@ func MaintenanceAware() HandlerConfigParam {
 	return f
 }
 
+// MaxBodySize set the maximum size in bytes of the request body for a route.
+// A negative value disables the limit for handlers that stream their request body.
+func MaxBodySize(size int64) HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.MaxBodySize = size
+	}
+	return f
+}
+
+// ReadOnly route only reads data, the read permission is required whatever the method of the route
+func ReadOnly() HandlerConfigParam {
+	f := func(rc *service.HandlerConfig) {
+		rc.PermissionLevel = sdk.PermissionRead
+	}
+	return f
+}
+
 // EnableTracing on a route
 func EnableTracing() HandlerConfigParam {
 	f := func(rc *service.HandlerConfig) {
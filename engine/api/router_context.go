@@ -13,6 +13,7 @@ const (
 	contextJWTRaw
 	contextDate
 	contextJWTFromCookie
+	contextAuditLog
 )
 
 // ContextValues retuns auth values of a context
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/audit"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// auditLogMaxBodySize is the maximum size of a request body that will be stored in an audit log.
+const auditLogMaxBodySize = 64 * 1024
+
+// auditLogEntities gives the entity type for route variables, first matching variable wins.
+var auditLogEntities = []struct {
+	entityType string
+	vars       []string
+}{
+	{"workflow", []string{"permWorkflowName", "workflowName"}},
+	{"application", []string{"applicationName"}},
+	{"pipeline", []string{"pipelineKey"}},
+	{"environment", []string{"environmentName"}},
+	{"integration", []string{"integrationName"}},
+	{"workflow_template", []string{"permTemplateSlug", "templateSlug"}},
+	{"worker_model", []string{"permModelName"}},
+	{"action", []string{"permActionName"}},
+	{"group", []string{"permGroupName", "groupName"}},
+	{"user", []string{"permUsername", "username"}},
+	{"consumer", []string{"permConsumerID"}},
+	{"project", []string{permProjectKey, "key"}},
+}
+
+type auditLogRecord struct {
+	body       []byte
+	truncated  bool
+	before     interface{}
+	after      interface{}
+	hasChanges bool
+}
+
+// Write keeps the request body until the max size is reached.
+func (a *auditLogRecord) Write(p []byte) (int, error) {
+	if a.truncated {
+		return len(p), nil
+	}
+	if len(a.body)+len(p) > auditLogMaxBodySize {
+		a.truncated = true
+		a.body = nil
+		return len(p), nil
+	}
+	a.body = append(a.body, p...)
+	return len(p), nil
+}
+
+type auditLogBody struct {
+	io.Reader
+	io.Closer
+}
+
+// setAuditLogData sets the state of the entity before and after the current request. Before
+// should be nil for a creation and after nil for a deletion.
+func setAuditLogData(ctx context.Context, before, after interface{}) {
+	a, ok := ctx.Value(contextAuditLog).(*auditLogRecord)
+	if !ok {
+		return
+	}
+	a.before = before
+	a.after = after
+	a.hasChanges = true
+}
+
+func isAuditLogMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// auditLogMiddleware prepares the audit log for mutating calls made by users. Calls from workers
+// and services are not recorded.
+func (api *API) auditLogMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
+	if !api.Config.Audit.Enabled || !isAuditLogMethod(req.Method) {
+		return ctx, nil
+	}
+
+	c := getAPIConsumer(ctx)
+	if c == nil || c.Worker != nil || c.Service != nil {
+		return ctx, nil
+	}
+
+	a := new(auditLogRecord)
+	if req.Body != nil {
+		req.Body = auditLogBody{Reader: io.TeeReader(req.Body, a), Closer: req.Body}
+	}
+
+	return context.WithValue(ctx, contextAuditLog, a), nil
+}
+
+// auditLogPostMiddleware inserts the audit log for succeeded calls.
+func (api *API) auditLogPostMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
+	a, ok := ctx.Value(contextAuditLog).(*auditLogRecord)
+	if !ok {
+		return ctx, nil
+	}
+
+	c := getAPIConsumer(ctx)
+	if c == nil {
+		return ctx, nil
+	}
+
+	statusCode := http.StatusOK
+	if t, ok := w.(*trackingResponseWriter); ok && t.statusCode != 0 {
+		statusCode = t.statusCode
+	}
+
+	entityType, entityName, projectKey := auditLogEntity(req, mux.Vars(req))
+	l := sdk.AuditLog{
+		Created:      time.Now(),
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		Route:        rc.CleanURL,
+		StatusCode:   statusCode,
+		EntityType:   entityType,
+		EntityName:   entityName,
+		ProjectKey:   projectKey,
+		UserID:       c.AuthentifiedUserID,
+		Username:     c.GetUsername(),
+		ConsumerID:   c.ID,
+		ConsumerType: string(c.Type),
+		IPAddress:    auditLogIPAddress(req),
+	}
+
+	if a.hasChanges {
+		l.DataBefore = sdk.NewAuditLogData(a.before)
+		l.DataAfter = sdk.NewAuditLogData(a.after)
+		l.Changes = sdk.ComputeAuditLogChanges(l.DataBefore, l.DataAfter)
+	} else if !a.truncated && len(a.body) > 0 {
+		l.DataAfter = sdk.NewAuditLogData(a.body)
+	}
+
+	if err := audit.InsertLog(api.mustDB(), &l); err != nil {
+		return ctx, err
+	}
+
+	return ctx, nil
+}
+
+func auditLogEntity(req *http.Request, vars map[string]string) (entityType, entityName, projectKey string) {
+	projectKey = vars[permProjectKey]
+	if projectKey == "" {
+		projectKey = vars["key"]
+	}
+
+	for _, e := range auditLogEntities {
+		for _, v := range e.vars {
+			if vars[v] != "" {
+				return e.entityType, vars[v], projectKey
+			}
+		}
+	}
+
+	// Fallback on the first part of the path, ie. "admin" or "auth"
+	path := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/"), "/", 2)
+	return path[0], "", projectKey
+}
+
+func auditLogIPAddress(req *http.Request) string {
+	if forwarded := req.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
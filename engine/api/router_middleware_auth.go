@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
 	"strings"
 	"time"
@@ -29,6 +30,11 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 	ctx, end := observability.Span(ctx, "router.authMiddleware")
 	defer end()
 
+	// SCIM routes are only available with the SCIM token
+	if rc.NeedSCIMToken {
+		return ctx, api.scimTokenMiddleware(req)
+	}
+
 	// Tokens (like izanamy)
 	ctx, ok, err := api.authStatusTokenMiddleware(ctx, w, req, rc)
 	if err != nil {
@@ -76,6 +82,10 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 		if c.Disabled {
 			return ctx, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is disabled", c.ID)
 		}
+		// If the consumer is expired, return an error
+		if c.Expired() {
+			return ctx, sdk.WrapError(sdk.ErrUnauthorized, "consumer (%s) is expired", c.ID)
+		}
 		// If the driver was disabled for the consumer that was found, ignore it
 		if _, ok := api.AuthenticationDrivers[c.Type]; ok {
 			// Add contacts for consumer's user
@@ -135,6 +145,18 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 			}
 		}
 
+		// A consumer restricted to a project can only be used on the routes of this project
+		if consumer.ProjectKey != "" && rc.NeedAuth {
+			vars := mux.Vars(req)
+			projectKey, has := vars["permProjectKey"]
+			if !has {
+				projectKey = vars["key"]
+			}
+			if projectKey != consumer.ProjectKey {
+				return ctx, sdk.WrapError(sdk.ErrForbidden, "consumer (%s) is restricted to project %s", consumer.ID, consumer.ProjectKey)
+			}
+		}
+
 		// Check that permission are valid for current route and consumer
 		if err := api.checkPermission(ctx, mux.Vars(req), rc.PermissionLevel); err != nil {
 			return ctx, err
@@ -169,7 +191,7 @@ func (api *API) authStatusTokenMiddleware(ctx context.Context, w http.ResponseWr
 	}
 	for _, h := range rc.AllowedTokens {
 		log.Debug("authStatusTokenMiddleware> checking allowed token: %v", h)
-		headerSplitted := strings.Split(h, ":")
+		headerSplitted := strings.SplitN(h, ":", 2)
 		receivedValue := req.Header.Get(headerSplitted[0])
 		if receivedValue != headerSplitted[1] {
 			return ctx, false, sdk.WrapError(sdk.ErrUnauthorized, "Router> Authorization denied token on %s %s for %s", req.Method, req.URL, req.RemoteAddr)
@@ -178,6 +200,16 @@ func (api *API) authStatusTokenMiddleware(ctx context.Context, w http.ResponseWr
 	return ctx, true, nil
 }
 
+// Checks the bearer token sent by the SCIM identity provider, the received value is never logged
+func (api *API) scimTokenMiddleware(req *http.Request) error {
+	token := api.Config.Auth.SCIM.Token
+	received := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" || subtle.ConstantTimeCompare([]byte(received), []byte(token)) != 1 {
+		return sdk.WrapError(sdk.ErrUnauthorized, "invalid scim token on %s %s for %s", req.Method, req.URL.Path, req.RemoteAddr)
+	}
+	return nil
+}
+
 func (api *API) jwtMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
 	ctx, end := observability.Span(ctx, "router.jwtMiddleware")
 	defer end()
@@ -71,6 +71,10 @@ func (api *API) checkJobIDPermissions(ctx context.Context, jobID string, perm in
 	// If the expected permission if >= RX and the consumer is a worker
 	// We check that the worker has took this job
 	if isWorker := isWorker(ctx); isWorker && perm >= sdk.PermissionReadExecute {
+		if scopedJobID := getAPIConsumer(ctx).WorkerJobRunID(); scopedJobID != 0 && scopedJobID != id {
+			return sdk.WrapError(sdk.ErrForbidden, "not authorized for job %s, worker token is scoped to job %d", jobID, scopedJobID)
+		}
+
 		wk, err := worker.LoadByID(ctx, api.mustDB(), getAPIConsumer(ctx).Worker.ID)
 		if err != nil {
 			return err
@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -54,3 +56,38 @@ func Test_computeScopeDetails(t *testing.T) {
 		}
 	}
 }
+
+func Test_routerMaxBodySize(t *testing.T) {
+	r := &Router{
+		Mux:         mux.NewRouter(),
+		Background:  context.TODO(),
+		MaxBodySize: 10,
+	}
+
+	myHandler := func() service.Handler {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			var body map[string]string
+			return service.UnmarshalBody(r, &body)
+		}
+	}
+
+	r.Handle("/default", ScopeNone(), r.POST(myHandler, Auth(false)))
+	r.Handle("/custom", ScopeNone(), r.POST(myHandler, Auth(false), MaxBodySize(100)))
+	r.Handle("/unlimited", ScopeNone(), r.POST(myHandler, Auth(false), MaxBodySize(-1)))
+
+	body := `{"key":"` + strings.Repeat("a", 50) + `"}`
+
+	for _, c := range []struct {
+		uri    string
+		status int
+	}{
+		{uri: "/default", status: http.StatusRequestEntityTooLarge},
+		{uri: "/custom", status: http.StatusNoContent},
+		{uri: "/unlimited", status: http.StatusNoContent},
+	} {
+		req := httptest.NewRequest(http.MethodPost, c.uri, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		r.Mux.ServeHTTP(rec, req)
+		assert.Equal(t, c.status, rec.Code, "invalid status for %s", c.uri)
+	}
+}
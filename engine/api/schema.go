@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// asCodeJSONSchema generates the JSON schema of a type of as code files.
+func asCodeJSONSchema(v interface{}) *jsonschema.Schema {
+	ref := jsonschema.Reflector{
+		RequiredFromJSONSchemaTags: true,
+	}
+	return ref.ReflectFromType(reflect.TypeOf(v))
+}
+
+func (api *API) getDownloadSchemasHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		kinds := make([]string, 0, len(exportentities.SchemaTypes))
+		for k := range exportentities.SchemaTypes {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+
+		var res []sdk.SchemaFile
+		for _, k := range kinds {
+			versions := exportentities.SchemaVersions(k)
+			for i, v := range versions {
+				res = append(res, sdk.SchemaFile{
+					Kind:    k,
+					Version: v,
+					Latest:  i == len(versions)-1,
+					URL:     fmt.Sprintf("%s/download/schemas/%s/%s.json", api.Config.URL.API, v, k),
+				})
+			}
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// getDownloadSchemaHandler returns the JSON schema of a kind of as code files, for the given version of the yaml
+// syntax or for the latest one. The schema does not contain the actions that can be used as pipeline steps, they
+// depend on the groups of the user.
+func (api *API) getDownloadSchemaHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		version := vars["version"]
+		name := vars["name"]
+
+		if !strings.HasSuffix(name, ".json") {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+		kind := strings.TrimSuffix(name, ".json")
+		types, ok := exportentities.SchemaTypes[kind]
+		if !ok {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no schema for %s", kind)
+		}
+		if version == "" {
+			versions := exportentities.SchemaVersions(kind)
+			version = versions[len(versions)-1]
+		}
+		t, ok := types[version]
+		if !ok {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no schema for %s %s", kind, version)
+		}
+
+		return service.WriteJSON(w, asCodeJSONSchema(t), http.StatusOK)
+	}
+}
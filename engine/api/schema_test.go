@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_getDownloadSchemaHandler(t *testing.T) {
+	api, _, _, end := newTestAPI(t)
+	defer end()
+
+	uri := api.Router.GetRoute("GET", api.getDownloadSchemasHandler, nil)
+	req := assets.NewRequest(t, "GET", uri, nil)
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+
+	var files []sdk.SchemaFile
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &files))
+	require.Len(t, files, 4)
+
+	for _, f := range files {
+		paths := []string{uri + "/" + f.Version + "/" + f.Kind + ".json"}
+		if f.Latest {
+			paths = append(paths, uri+"/"+f.Kind+".json")
+		}
+		for _, p := range paths {
+			req := assets.NewRequest(t, "GET", p, nil)
+			w := httptest.NewRecorder()
+			api.Router.Mux.ServeHTTP(w, req)
+			require.Equal(t, 200, w.Code, p)
+
+			var sch map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sch))
+			require.Contains(t, sch, "definitions", p)
+		}
+	}
+
+	req = assets.NewRequest(t, "GET", uri+"/v1.0/unknown.json", nil)
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 404, w.Code)
+}
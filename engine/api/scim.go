@@ -0,0 +1,809 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (api *API) getSCIMServiceProviderConfigHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		cfg := sdk.SCIMServiceProviderConfig{Schemas: []string{sdk.SCIMSchemaServiceProviderConfig}}
+		cfg.Patch.Supported = true
+		cfg.Filter.Supported = true
+		cfg.Filter.MaxResults = sdk.SCIMDefaultCount
+		return service.WriteJSON(w, cfg, http.StatusOK)
+	}
+}
+
+// scimListParams returns the filter and pagination values given in request query.
+func scimListParams(r *http.Request) (*sdk.SCIMFilter, int, int, error) {
+	q := r.URL.Query()
+	filter, err := sdk.ParseSCIMFilter(q.Get("filter"))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	startIndex, count := 1, sdk.SCIMDefaultCount
+	if s := q.Get("startIndex"); s != "" {
+		if startIndex, err = strconv.Atoi(s); err != nil {
+			return nil, 0, 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid startIndex")
+		}
+	}
+	if s := q.Get("count"); s != "" {
+		if count, err = strconv.Atoi(s); err != nil {
+			return nil, 0, 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid count")
+		}
+	}
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	return filter, startIndex, count, nil
+}
+
+func (api *API) scimUser(ctx context.Context, db gorp.SqlExecutor, u sdk.AuthentifiedUser) (sdk.SCIMUser, error) {
+	cs, err := authentication.LoadConsumersByUserID(ctx, db, u.ID)
+	if err != nil {
+		return sdk.SCIMUser{}, err
+	}
+	active := true
+	for i := range cs {
+		if authentication.IsConsumerDeprovisioned(cs[i]) {
+			active = false
+			break
+		}
+	}
+
+	res := sdk.SCIMUser{
+		Schemas:     []string{sdk.SCIMSchemaUser},
+		ID:          u.ID,
+		UserName:    u.Username,
+		Name:        sdk.SCIMName{Formatted: u.Fullname},
+		DisplayName: u.Fullname,
+		Active:      &active,
+		Meta: &sdk.SCIMMeta{
+			ResourceType: "User",
+			Created:      &u.Created,
+			Location:     api.Config.URL.API + "/scim/v2/Users/" + u.ID,
+		},
+	}
+	if email := u.GetEmail(); email != "" {
+		res.Emails = []sdk.SCIMMultiValue{{Value: email, Type: "work", Primary: true}}
+	}
+	return res, nil
+}
+
+// isSCIMManagedGroup returns true if the group can be managed by the identity provider.
+func (api *API) isSCIMManagedGroup(g sdk.Group) bool {
+	return !group.IsDefaultGroupID(g.ID) && strings.HasPrefix(g.Name, api.Config.Auth.SCIM.GroupsPrefix)
+}
+
+func (api *API) scimGroup(g sdk.Group) sdk.SCIMGroup {
+	res := sdk.SCIMGroup{
+		Schemas:     []string{sdk.SCIMSchemaGroup},
+		ID:          strconv.FormatInt(g.ID, 10),
+		DisplayName: g.Name,
+		Members:     make([]sdk.SCIMMultiValue, 0, len(g.Members)),
+		Meta: &sdk.SCIMMeta{
+			ResourceType: "Group",
+			Location:     api.Config.URL.API + "/scim/v2/Groups/" + strconv.FormatInt(g.ID, 10),
+		},
+	}
+	for _, m := range g.Members {
+		res.Members = append(res.Members, sdk.SCIMMultiValue{Value: m.ID, Display: m.Username})
+	}
+	return res
+}
+
+func (api *API) getSCIMUsersHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		filter, startIndex, count, err := scimListParams(r)
+		if err != nil {
+			return err
+		}
+
+		var us sdk.AuthentifiedUsers
+		if filter != nil {
+			var u *sdk.AuthentifiedUser
+			switch filter.Attribute {
+			case "userName":
+				u, err = user.LoadByUsername(ctx, api.mustDB(), filter.Value, user.LoadOptions.WithContacts)
+			case "emails", "emails.value":
+				var contact *sdk.UserContact
+				contact, err = user.LoadContactByTypeAndValue(ctx, api.mustDB(), sdk.UserContactTypeEmail, filter.Value)
+				if err == nil {
+					u, err = user.LoadByID(ctx, api.mustDB(), contact.UserID, user.LoadOptions.WithContacts)
+				}
+			default:
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported filter attribute %q", filter.Attribute)
+			}
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+			if u != nil {
+				us = append(us, *u)
+			}
+		} else {
+			us, err = user.LoadAll(ctx, api.mustDB(), user.LoadOptions.WithContacts)
+			if err != nil {
+				return err
+			}
+		}
+
+		from, to := sdk.SCIMPage(len(us), startIndex, count)
+		res := make([]sdk.SCIMUser, 0, to-from)
+		for i := range us[from:to] {
+			u, err := api.scimUser(ctx, api.mustDB(), us[from+i])
+			if err != nil {
+				return err
+			}
+			res = append(res, u)
+		}
+
+		return service.WriteJSON(w, sdk.NewSCIMListResponse(len(us), startIndex, res, len(res)), http.StatusOK)
+	}
+}
+
+func (api *API) getSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u, err := user.LoadByID(ctx, api.mustDB(), mux.Vars(r)["id"], user.LoadOptions.WithContacts)
+		if err != nil {
+			return err
+		}
+
+		res, err := api.scimUser(ctx, api.mustDB(), *u)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+func (api *API) postSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMUser
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		existing, err := user.LoadByUsername(ctx, tx, req.UserName)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a user already exists for username %s", req.UserName)
+		}
+		contact, err := user.LoadContactByTypeAndValue(ctx, tx, sdk.UserContactTypeEmail, req.PrimaryEmail())
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if contact != nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a user already exists for email %s", req.PrimaryEmail())
+		}
+
+		// The user will be able to signin with any auth driver that returns the same email
+		u := sdk.AuthentifiedUser{
+			Ring:     sdk.UserRingUser,
+			Username: req.UserName,
+			Fullname: req.Fullname(),
+		}
+		if err := u.IsValid(); err != nil {
+			return err
+		}
+		if err := user.Insert(ctx, tx, &u); err != nil {
+			return err
+		}
+		if err := user.InsertContact(ctx, tx, &sdk.UserContact{
+			Primary:  true,
+			Type:     sdk.UserContactTypeEmail,
+			UserID:   u.ID,
+			Value:    req.PrimaryEmail(),
+			Verified: true,
+		}); err != nil {
+			return err
+		}
+		if err := user.InsertSCIMProvisioned(tx, u.ID); err != nil {
+			return err
+		}
+		if err := group.CheckUserInDefaultGroup(ctx, tx, u.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		log.Info(ctx, "postSCIMUserHandler> user %s provisioned", u.Username)
+
+		uu, err := user.LoadByID(ctx, api.mustDB(), u.ID, user.LoadOptions.WithContacts)
+		if err != nil {
+			return err
+		}
+		res, err := api.scimUser(ctx, api.mustDB(), *uu)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, res, http.StatusCreated)
+	}
+}
+
+// loadSCIMProvisionedUser returns the user for given id, only users provisioned by SCIM can be updated by the identity provider.
+func loadSCIMProvisionedUser(ctx context.Context, db gorp.SqlExecutor, id string, opts ...user.LoadOptionFunc) (*sdk.AuthentifiedUser, error) {
+	u, err := user.LoadByID(ctx, db, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	provisioned, err := user.IsSCIMProvisioned(db, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !provisioned {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "user %s was not provisioned by scim", u.Username)
+	}
+	return u, nil
+}
+
+// updateSCIMUser applies given attributes to the user and enables or disables its consumers.
+func (api *API) updateSCIMUser(ctx context.Context, tx gorp.SqlExecutor, u *sdk.AuthentifiedUser, userName, fullname, email string, active *bool) error {
+	if userName != "" {
+		u.Username = userName
+	}
+	if fullname != "" {
+		u.Fullname = fullname
+	}
+	if err := u.IsValid(); err != nil {
+		return err
+	}
+	if err := user.Update(ctx, tx, u); err != nil {
+		return err
+	}
+
+	if email != "" && email != u.GetEmail() {
+		contact, err := user.LoadContactByTypeAndValue(ctx, tx, sdk.UserContactTypeEmail, email)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if contact != nil {
+			return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a user already exists for email %s", email)
+		}
+		primary := u.Contacts.Filter(sdk.UserContactTypeEmail).Primary()
+		if primary == nil {
+			if err := user.InsertContact(ctx, tx, &sdk.UserContact{
+				Primary:  true,
+				Type:     sdk.UserContactTypeEmail,
+				UserID:   u.ID,
+				Value:    email,
+				Verified: true,
+			}); err != nil {
+				return err
+			}
+		} else {
+			primary.Value = email
+			primary.Verified = true
+			if err := user.UpdateContact(ctx, tx, primary); err != nil {
+				return err
+			}
+		}
+	}
+
+	if active != nil {
+		if *active {
+			return authentication.ConsumerEnableForUser(ctx, tx, u.ID)
+		}
+		return authentication.ConsumerDisableForUser(ctx, tx, u.ID)
+	}
+
+	return nil
+}
+
+func (api *API) putSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMUser
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := loadSCIMProvisionedUser(ctx, tx, mux.Vars(r)["id"], user.LoadOptions.WithContacts)
+		if err != nil {
+			return err
+		}
+
+		active := req.IsActive()
+		if err := api.updateSCIMUser(ctx, tx, u, req.UserName, req.Fullname(), req.PrimaryEmail(), &active); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		res, err := api.scimUser(ctx, api.mustDB(), *u)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+func (api *API) patchSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMPatchRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		// Collect all updated attributes, operations without path contain an object with attributes
+		attrs := make(map[string]json.RawMessage)
+		for _, op := range req.Operations {
+			if op.Operation() != sdk.SCIMPatchOpReplace && op.Operation() != sdk.SCIMPatchOpAdd {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported operation %q on user", op.Op)
+			}
+			if op.Path != "" {
+				attrs[op.Path] = op.Value
+				continue
+			}
+			var values map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid operation value")
+			}
+			for k, v := range values {
+				attrs[k] = v
+			}
+		}
+
+		var userName, fullname string
+		var active *bool
+		for k, v := range attrs {
+			var err error
+			switch k {
+			case "userName":
+				err = json.Unmarshal(v, &userName)
+			case "displayName", "name.formatted":
+				err = json.Unmarshal(v, &fullname)
+			case "active":
+				// Some providers send boolean values as string
+				var s string
+				if json.Unmarshal(v, &s) == nil {
+					v = json.RawMessage(strings.ToLower(s))
+				}
+				active = new(bool)
+				err = json.Unmarshal(v, active)
+			}
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid value for %s", k)
+			}
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		u, err := loadSCIMProvisionedUser(ctx, tx, mux.Vars(r)["id"], user.LoadOptions.WithContacts)
+		if err != nil {
+			return err
+		}
+
+		if err := api.updateSCIMUser(ctx, tx, u, userName, fullname, "", active); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		res, err := api.scimUser(ctx, api.mustDB(), *u)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// deleteSCIMUserHandler deprovisions the user, its consumers are disabled but the user is kept for history.
+func (api *API) deleteSCIMUserHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		u, err := loadSCIMProvisionedUser(ctx, api.mustDB(), mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+
+		if err := authentication.ConsumerDisableForUser(ctx, api.mustDB(), u.ID); err != nil {
+			return err
+		}
+
+		log.Info(ctx, "deleteSCIMUserHandler> user %s deprovisioned", u.Username)
+
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+
+func (api *API) loadSCIMGroup(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.Group, error) {
+	groupID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	g, err := group.LoadByID(ctx, db, groupID, group.LoadOptions.WithMembers)
+	if err != nil {
+		return nil, err
+	}
+	if !api.isSCIMManagedGroup(*g) {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return g, nil
+}
+
+// setSCIMGroupMembers adds and removes members of the group, consumers of removed users are invalidated for the group.
+func (api *API) setSCIMGroupMembers(ctx context.Context, tx gorp.SqlExecutor, g *sdk.Group, addIDs, removeIDs []string) error {
+	for _, id := range addIDs {
+		link, err := group.LoadLinkGroupUserForGroupIDAndUserID(ctx, tx, g.ID, id)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if link != nil {
+			continue
+		}
+		if _, err := user.LoadByID(ctx, tx, id); err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown user %s", id)
+		}
+		if err := group.InsertLinkGroupUser(ctx, tx, &group.LinkGroupUser{
+			GroupID:            g.ID,
+			AuthentifiedUserID: id,
+		}); err != nil {
+			return err
+		}
+		if err := authentication.ConsumerRestoreInvalidatedGroupForUser(ctx, tx, g.ID, id); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range removeIDs {
+		u, err := user.LoadByID(ctx, tx, id)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if err := group.DeleteUserFromGroup(ctx, tx, g.ID, u.ID); err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if err := authentication.ConsumerInvalidateGroupForUser(ctx, tx, g, u); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (api *API) getSCIMGroupsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		filter, startIndex, count, err := scimListParams(r)
+		if err != nil {
+			return err
+		}
+
+		var gs sdk.Groups
+		if filter != nil {
+			if filter.Attribute != "displayName" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported filter attribute %q", filter.Attribute)
+			}
+			g, err := group.LoadByName(ctx, api.mustDB(), filter.Value, group.LoadOptions.WithMembers)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+			if g != nil {
+				gs = append(gs, *g)
+			}
+		} else {
+			gs, err = group.LoadAll(ctx, api.mustDB(), group.LoadOptions.WithMembers)
+			if err != nil {
+				return err
+			}
+		}
+
+		managed := make([]sdk.SCIMGroup, 0, len(gs))
+		for i := range gs {
+			if api.isSCIMManagedGroup(gs[i]) {
+				managed = append(managed, api.scimGroup(gs[i]))
+			}
+		}
+
+		from, to := sdk.SCIMPage(len(managed), startIndex, count)
+		return service.WriteJSON(w, sdk.NewSCIMListResponse(len(managed), startIndex, managed[from:to], to-from), http.StatusOK)
+	}
+}
+
+func (api *API) getSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		g, err := api.loadSCIMGroup(ctx, api.mustDB(), mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, api.scimGroup(*g), http.StatusOK)
+	}
+}
+
+func (api *API) postSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMGroup
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		g := sdk.Group{Name: req.DisplayName}
+		if err := g.IsValid(); err != nil {
+			return err
+		}
+		if !api.isSCIMManagedGroup(g) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "group name should start with %s", api.Config.Auth.SCIM.GroupsPrefix)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		existing, err := group.LoadByName(ctx, tx, g.Name)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			return sdk.WithStack(sdk.ErrGroupExists)
+		}
+
+		if err := group.Insert(ctx, tx, &g); err != nil {
+			return err
+		}
+
+		memberIDs := make([]string, len(req.Members))
+		for i := range req.Members {
+			memberIDs[i] = req.Members[i].Value
+		}
+		if err := api.setSCIMGroupMembers(ctx, tx, &g, memberIDs, nil); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		log.Info(ctx, "postSCIMGroupHandler> group %s provisioned", g.Name)
+
+		res, err := api.loadSCIMGroup(ctx, api.mustDB(), strconv.FormatInt(g.ID, 10))
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, api.scimGroup(*res), http.StatusCreated)
+	}
+}
+
+// renameSCIMGroup updates the group name if changed.
+func (api *API) renameSCIMGroup(ctx context.Context, tx gorp.SqlExecutor, g *sdk.Group, name string) error {
+	if name == "" || name == g.Name {
+		return nil
+	}
+	renamed := sdk.Group{ID: g.ID, Name: name}
+	if err := renamed.IsValid(); err != nil {
+		return err
+	}
+	if !api.isSCIMManagedGroup(renamed) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "group name should start with %s", api.Config.Auth.SCIM.GroupsPrefix)
+	}
+	g.Name = name
+	return group.Update(ctx, tx, &renamed)
+}
+
+func (api *API) putSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMGroup
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		g, err := api.loadSCIMGroup(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+
+		if err := api.renameSCIMGroup(ctx, tx, g, req.DisplayName); err != nil {
+			return err
+		}
+
+		// Members are replaced by the given ones
+		expected := make(map[string]struct{}, len(req.Members))
+		var addIDs, removeIDs []string
+		for _, m := range req.Members {
+			expected[m.Value] = struct{}{}
+			addIDs = append(addIDs, m.Value)
+		}
+		for _, m := range g.Members {
+			if _, ok := expected[m.ID]; !ok {
+				removeIDs = append(removeIDs, m.ID)
+			}
+		}
+		if err := api.setSCIMGroupMembers(ctx, tx, g, addIDs, removeIDs); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		res, err := api.loadSCIMGroup(ctx, api.mustDB(), strconv.FormatInt(g.ID, 10))
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, api.scimGroup(*res), http.StatusOK)
+	}
+}
+
+func (api *API) patchSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.SCIMPatchRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		g, err := api.loadSCIMGroup(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+
+		for _, op := range req.Operations {
+			var addIDs, removeIDs []string
+
+			switch {
+			case op.Path == "displayName" && op.Operation() == sdk.SCIMPatchOpReplace:
+				var name string
+				if err := json.Unmarshal(op.Value, &name); err != nil {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid value for displayName")
+				}
+				if err := api.renameSCIMGroup(ctx, tx, g, name); err != nil {
+					return err
+				}
+				continue
+			case op.Path == "members":
+				members, err := op.MembersValue()
+				if err != nil {
+					return err
+				}
+				switch op.Operation() {
+				case sdk.SCIMPatchOpAdd:
+					for _, m := range members {
+						addIDs = append(addIDs, m.Value)
+					}
+				case sdk.SCIMPatchOpRemove:
+					// Without value all members are removed
+					if len(op.Value) == 0 {
+						for _, m := range g.Members {
+							removeIDs = append(removeIDs, m.ID)
+						}
+					}
+					for _, m := range members {
+						removeIDs = append(removeIDs, m.Value)
+					}
+				case sdk.SCIMPatchOpReplace:
+					expected := make(map[string]struct{}, len(members))
+					for _, m := range members {
+						expected[m.Value] = struct{}{}
+						addIDs = append(addIDs, m.Value)
+					}
+					for _, m := range g.Members {
+						if _, ok := expected[m.ID]; !ok {
+							removeIDs = append(removeIDs, m.ID)
+						}
+					}
+				default:
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported operation %q", op.Op)
+				}
+			default:
+				id, ok := sdk.ParseSCIMMemberPath(op.Path)
+				if !ok || op.Operation() != sdk.SCIMPatchOpRemove {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported operation %q on path %q", op.Op, op.Path)
+				}
+				removeIDs = append(removeIDs, id)
+			}
+
+			if err := api.setSCIMGroupMembers(ctx, tx, g, addIDs, removeIDs); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		res, err := api.loadSCIMGroup(ctx, api.mustDB(), strconv.FormatInt(g.ID, 10))
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, api.scimGroup(*res), http.StatusOK)
+	}
+}
+
+func (api *API) deleteSCIMGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		g, err := api.loadSCIMGroup(ctx, tx, mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+
+		// Consumers that contain the group should be updated like when a group is removed from CDS
+		if err := authentication.ConsumerRemoveGroup(ctx, tx, g); err != nil {
+			return err
+		}
+		if err := group.Delete(ctx, tx, g); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		log.Info(ctx, "deleteSCIMGroupHandler> group %s deprovisioned", g.Name)
+
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func newTestAPIWithSCIMToken(t *testing.T, token string, bootstrapFunc ...test.Bootstrapf) (*API, *gorp.DbMap, *Router, context.CancelFunc) {
+	bootstrapFunc = append(bootstrapFunc, bootstrap.InitiliazeDB)
+	db, cache, end := test.SetupPG(t, bootstrapFunc...)
+	router := newRouter(mux.NewRouter(), "/"+test.GetTestName(t))
+	var cancel context.CancelFunc
+	router.Background, cancel = context.WithCancel(context.Background())
+	api := &API{
+		StartupTime:         time.Now(),
+		Router:              router,
+		DBConnectionFactory: test.DBConnectionFactory,
+		Config:              Configuration{},
+		Cache:               cache,
+	}
+	api.Config.Auth.SCIM.Enabled = true
+	api.Config.Auth.SCIM.Token = token
+	api.Config.Auth.SCIM.GroupsPrefix = "scim-"
+	api.InitRouter()
+	f := func() {
+		cancel()
+		end()
+	}
+	return api, db, router, f
+}
+
+func newSCIMRequest(t *testing.T, method, uri string, body interface{}) *http.Request {
+	var buf []byte
+	if body != nil {
+		var err error
+		buf, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req, err := http.NewRequest(method, uri, bytes.NewReader(buf))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer mytoken")
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func Test_SCIMUserProvisioning(t *testing.T) {
+	api, db, router, end := newTestAPIWithSCIMToken(t, "mytoken")
+	defer end()
+
+	username := sdk.RandomString(10)
+
+	// Request without token should be rejected
+	uri := router.GetRoute("POST", api.postSCIMUserHandler, nil)
+	req := newSCIMRequest(t, "POST", uri, sdk.SCIMUser{UserName: username})
+	req.Header.Del("Authorization")
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Request with an invalid token should be rejected
+	req = newSCIMRequest(t, "POST", uri, sdk.SCIMUser{UserName: username})
+	req.Header.Set("Authorization", "Bearer mytokenbis")
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req = newSCIMRequest(t, "POST", uri, sdk.SCIMUser{
+		UserName: username,
+		Name:     sdk.SCIMName{GivenName: "Philip", FamilyName: "Fry"},
+		Emails:   []sdk.SCIMMultiValue{{Value: username + "@planet-express.com", Primary: true}},
+	})
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created sdk.SCIMUser
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "Philip Fry", created.DisplayName)
+	assert.True(t, created.IsActive())
+
+	// Same user can't be created twice
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "POST", uri, created))
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	// Lookup by username
+	uri = router.GetRoute("GET", api.getSCIMUsersHandler, nil) + "?filter=" + url.QueryEscape(`userName eq "`+username+`"`)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "GET", uri, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		TotalResults int            `json:"totalResults"`
+		Resources    []sdk.SCIMUser `json:"Resources"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 1, list.TotalResults)
+	assert.Equal(t, created.ID, list.Resources[0].ID)
+
+	// Deactivate the user then add it to a group
+	uri = router.GetRoute("PATCH", api.patchSCIMUserHandler, map[string]string{"id": created.ID})
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "PATCH", uri, sdk.SCIMPatchRequest{
+		Operations: []sdk.SCIMPatchOperation{{Op: "Replace", Path: "active", Value: json.RawMessage(`false`)}},
+	}))
+	require.Equal(t, http.StatusOK, w.Code)
+	var patched sdk.SCIMUser
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &patched))
+	assert.False(t, patched.IsActive())
+
+	// Users that were not provisioned by SCIM can't be updated or deleted
+	lambdaUser, _ := assets.InsertLambdaUser(t, db)
+	uri = router.GetRoute("PATCH", api.patchSCIMUserHandler, map[string]string{"id": lambdaUser.ID})
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "PATCH", uri, sdk.SCIMPatchRequest{
+		Operations: []sdk.SCIMPatchOperation{{Op: "Replace", Path: "active", Value: json.RawMessage(`false`)}},
+	}))
+	require.Equal(t, http.StatusForbidden, w.Code)
+	uri = router.GetRoute("DELETE", api.deleteSCIMUserHandler, map[string]string{"id": lambdaUser.ID})
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "DELETE", uri, nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	groupName := "scim-" + sdk.RandomString(10)
+	uri = router.GetRoute("POST", api.postSCIMGroupHandler, nil)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "POST", uri, sdk.SCIMGroup{
+		DisplayName: groupName,
+		Members:     []sdk.SCIMMultiValue{{Value: created.ID}},
+	}))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var g sdk.SCIMGroup
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	require.Len(t, g.Members, 1)
+
+	// Groups that don't match the prefix can't be managed
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "POST", uri, sdk.SCIMGroup{DisplayName: sdk.RandomString(10)}))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	uri = router.GetRoute("PATCH", api.patchSCIMGroupHandler, map[string]string{"id": g.ID})
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "PATCH", uri, sdk.SCIMPatchRequest{
+		Operations: []sdk.SCIMPatchOperation{{Op: "remove", Path: `members[value eq "` + created.ID + `"]`}},
+	}))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	uri = router.GetRoute("DELETE", api.deleteSCIMGroupHandler, map[string]string{"id": g.ID})
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, newSCIMRequest(t, "DELETE", uri, nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	_, err := group.LoadByName(context.TODO(), db, groupName)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
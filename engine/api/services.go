@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/go-gorp/gorp"
@@ -46,6 +48,11 @@ func (api *API) postServiceRegisterHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrForbidden, "cannot register service of type %s for consumer %s", data.Type, consumer.ID)
 		}
 
+		if sdk.ServiceContractCompatibility(data.ContractVersion) == sdk.ServiceCompatibilityIncompatible {
+			return sdk.NewErrorFrom(sdk.ErrServiceIncompatible, "service %s %s uses contract version %d, the API requires at least version %d",
+				data.Name, data.Version, data.ContractVersion, sdk.ServiceContractMinVersion)
+		}
+
 		// Insert or update the service
 		tx, err := api.mustDB().Begin()
 		if err != nil {
@@ -120,6 +127,14 @@ func (api *API) postServiceHearbeatHandler() service.Handler {
 			}
 		}
 
+		// The API could have been upgraded to a contract that is not supported by the service anymore
+		compatibility := sdk.ServiceContractCompatibility(s.ContractVersion)
+		if compatibility == sdk.ServiceCompatibilityIncompatible {
+			return sdk.NewErrorFrom(sdk.ErrServiceIncompatible, "service %s %s uses contract version %d, the API requires at least version %d",
+				s.Name, s.Version, s.ContractVersion, sdk.ServiceContractMinVersion)
+		}
+		mon.Lines = append(mon.Lines, serviceContractStatusLine(s.ContractVersion, compatibility))
+
 		tx, err := api.mustDB().Begin()
 		if err != nil {
 			return sdk.WithStack(err)
@@ -180,6 +195,8 @@ func (api *API) serviceAPIHeartbeatUpdate(ctx context.Context, db *gorp.DbMap) {
 		},
 		MonitoringStatus: api.Status(ctx),
 		LastHeartbeat:    time.Now(),
+		Version:          sdk.VERSION,
+		ContractVersion:  sdk.ServiceContractVersion,
 	}
 
 	//Try to find the service, and keep; else generate a new one
@@ -207,3 +224,63 @@ func (api *API) serviceAPIHeartbeatUpdate(ctx context.Context, db *gorp.DbMap) {
 		return
 	}
 }
+
+// serviceContractStatusLine returns a monitoring line that warns when the contract of a service is not the one of the API.
+func serviceContractStatusLine(contractVersion int, compatibility string) sdk.MonitoringStatusLine {
+	l := sdk.MonitoringStatusLine{
+		Component: "Contract",
+		Value:     fmt.Sprintf("%d (%s)", contractVersion, compatibility),
+		Status:    sdk.MonitoringStatusOK,
+	}
+	if compatibility != sdk.ServiceCompatibilityOK {
+		l.Status = sdk.MonitoringStatusWarn
+	}
+	return l
+}
+
+func (api *API) getMonServicesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		srvs, err := services.LoadAll(ctx, api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, computeServicesMatrix(srvs), http.StatusOK)
+	}
+}
+
+// computeServicesMatrix returns the versions and contract compatibility of given services.
+func computeServicesMatrix(srvs []sdk.Service) sdk.MonitoringServicesMatrix {
+	m := sdk.MonitoringServicesMatrix{
+		APIVersion:            sdk.VERSION,
+		ContractVersion:       sdk.ServiceContractVersion,
+		ContractMinVersion:    sdk.ServiceContractMinVersion,
+		Services:              make([]sdk.MonitoringServiceVersion, 0, len(srvs)),
+		VersionsByServiceType: make(map[string]map[string]int64),
+	}
+	for _, s := range srvs {
+		version := s.Version
+		if version == "" {
+			version = sdk.ServiceCompatibilityUnknown
+		}
+		m.Services = append(m.Services, sdk.MonitoringServiceVersion{
+			Name:            s.Name,
+			Type:            s.Type,
+			Version:         version,
+			ContractVersion: s.ContractVersion,
+			Compatibility:   sdk.ServiceContractCompatibility(s.ContractVersion),
+			Uptodate:        s.Version == sdk.VERSION,
+			LastHeartbeat:   s.LastHeartbeat,
+		})
+		if _, ok := m.VersionsByServiceType[s.Type]; !ok {
+			m.VersionsByServiceType[s.Type] = make(map[string]int64)
+		}
+		m.VersionsByServiceType[s.Type][version]++
+	}
+	sort.Slice(m.Services, func(i, j int) bool {
+		if m.Services[i].Type != m.Services[j].Type {
+			return m.Services[i].Type < m.Services[j].Type
+		}
+		return m.Services[i].Name < m.Services[j].Name
+	})
+	return m
+}
@@ -10,4 +10,5 @@ const (
 	TypeUI            = "ui"
 	TypeHatchery      = "hatchery"
 	TypeDBMigrate     = "dbmigrate"
+	TypeChatOps       = "chatops"
 )
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/go-gorp/gorp"
+	"go.opentelemetry.io/otel/propagation"
 	"gopkg.in/spacemonkeygo/httpsig.v0"
 
 	"github.com/ovh/cds/engine/api/authentication"
@@ -275,10 +276,7 @@ func doRequestFromURL(ctx context.Context, db gorp.SqlExecutor, method string, c
 
 	req = req.WithContext(ctx)
 
-	spanCtx, ok := tracingutils.ContextToSpanContext(ctx)
-	if ok {
-		tracingutils.DefaultFormat.SpanContextToRequest(spanCtx, req)
-	}
+	tracingutils.DefaultFormat.Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	req.Header.Set("Connection", "close")
 	for i := range mods {
@@ -84,3 +84,22 @@ func TestServicesHandlers(t *testing.T) {
 
 	require.NoError(t, services.Delete(api.mustDB(), &srv))
 }
+
+func Test_computeServicesMatrix(t *testing.T) {
+	srvs := []sdk.Service{
+		{CanonicalService: sdk.CanonicalService{Name: "hatchery-2", Type: services.TypeHatchery}, Version: "0.1.0", ContractVersion: sdk.ServiceContractVersion + 1},
+		{CanonicalService: sdk.CanonicalService{Name: "hatchery-1", Type: services.TypeHatchery}, Version: sdk.VERSION, ContractVersion: sdk.ServiceContractVersion},
+		{CanonicalService: sdk.CanonicalService{Name: "hooks", Type: services.TypeHooks}},
+	}
+
+	m := computeServicesMatrix(srvs)
+	require.Equal(t, sdk.VERSION, m.APIVersion)
+	require.Len(t, m.Services, 3)
+	require.Equal(t, "hatchery-1", m.Services[0].Name)
+	require.True(t, m.Services[0].Uptodate)
+	require.Equal(t, sdk.ServiceCompatibilityOK, m.Services[0].Compatibility)
+	require.Equal(t, sdk.ServiceCompatibilityNewer, m.Services[1].Compatibility)
+	require.Equal(t, sdk.ServiceCompatibilityUnknown, m.Services[2].Compatibility)
+	require.Equal(t, map[string]int64{sdk.VERSION: 1, "0.1.0": 1}, m.VersionsByServiceType[services.TypeHatchery])
+	require.Equal(t, map[string]int64{sdk.ServiceCompatibilityUnknown: 1}, m.VersionsByServiceType[services.TypeHooks])
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ovh/cds/engine/api/mail"
 	"github.com/ovh/cds/engine/api/migrate"
 	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/quota"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/service"
@@ -41,6 +42,15 @@ func (api *API) Status(ctx context.Context) sdk.MonitoringStatus {
 	m.Lines = append(m.Lines, api.SharedStorage.Status(ctx))
 	m.Lines = append(m.Lines, mail.Status(ctx))
 	m.Lines = append(m.Lines, api.DBConnectionFactory.Status(ctx))
+	if api.DBReplicaConnectionFactory != nil {
+		line := api.DBReplicaConnectionFactory.Status(ctx)
+		line.Component = "Database Replica Conns"
+		// the primary database is used when the replica is unavailable
+		if line.Status == sdk.MonitoringStatusAlert {
+			line.Status = sdk.MonitoringStatusWarn
+		}
+		m.Lines = append(m.Lines, line)
+	}
 	m.Lines = append(m.Lines, workermodel.Status(api.mustDB()))
 	m.Lines = append(m.Lines, migrate.Status(api.mustDB()))
 
@@ -77,6 +87,8 @@ var (
 	tagServiceName tag.Key
 	tagService     tag.Key
 	tagsService    []tag.Key
+	tagQuota       tag.Key
+	tagResource    tag.Key
 )
 
 // computeGlobalStatus returns global status
@@ -95,6 +107,7 @@ func (api *API) computeGlobalStatus(srvs []sdk.Service) sdk.MonitoringStatus {
 		services.TypeHatchery:      {},
 		services.TypeDBMigrate:     {},
 		services.TypeElasticsearch: {},
+		services.TypeChatOps:       {},
 	}
 	var nbg computeGlobalNumbers
 	for _, s := range srvs {
@@ -223,6 +236,8 @@ func (api *API) initMetrics(ctx context.Context) error {
 		fmt.Sprintf("cds/cds-api/%s/database_conn°", api.Name()),
 		"number database connections",
 		stats.UnitDimensionless)
+	api.Metrics.quotaUsage = stats.Float64("cds/cds-api/quota_usage", "resources used by the projects of a quota", stats.UnitDimensionless)
+	api.Metrics.quotaLimit = stats.Float64("cds/cds-api/quota_limit", "resources limit of a quota", stats.UnitDimensionless)
 
 	tagRange, _ = tag.NewKey("range")
 	tagStatus, _ = tag.NewKey("status")
@@ -230,6 +245,9 @@ func (api *API) initMetrics(ctx context.Context) error {
 	tagServiceType := observability.MustNewKey(observability.TagServiceType)
 	tagServiceName := observability.MustNewKey(observability.TagServiceName)
 	tagsRange := []tag.Key{tagRange, tagStatus}
+	tagQuota, _ = tag.NewKey("quota")
+	tagResource, _ = tag.NewKey("resource")
+	tagsQuota := []tag.Key{tagQuota, tagResource}
 	tagsService = []tag.Key{tagServiceName, tagServiceType}
 
 	err := observability.RegisterView(
@@ -250,8 +268,14 @@ func (api *API) initMetrics(ctx context.Context) error {
 		observability.NewViewCount("cds/workflow_runs_mark_to_delete", api.Metrics.WorkflowRunsMarkToDelete, tagsService),
 		observability.NewViewCount("cds/workflow_runs_deleted", api.Metrics.WorkflowRunsDeleted, tagsService),
 		observability.NewViewLast("cds/database_conn", api.Metrics.DatabaseConns, tagsService),
+		observability.NewViewLastFloat64("cds/quota_usage", api.Metrics.quotaUsage, tagsQuota),
+		observability.NewViewLastFloat64("cds/quota_limit", api.Metrics.quotaLimit, tagsQuota),
 	)
 
+	if err == nil {
+		err = initWorkflowRunMetrics()
+	}
+
 	api.computeMetrics(ctx)
 
 	return err
@@ -304,6 +328,7 @@ func (api *API) computeMetrics(ctx context.Context) {
 				api.countMetricRange(ctx, "waiting", "70_more_10min", api.Metrics.queue, queryOld, now10min)
 
 				api.processStatusMetrics(ctx)
+				api.processQuotaMetrics(ctx)
 			}
 		}
 	})
@@ -326,6 +351,35 @@ func (api *API) countMetricRange(ctx context.Context, status string, timerange s
 	observability.Record(ctx, v, n)
 }
 
+func (api *API) processQuotaMetrics(ctx context.Context) {
+	qs, err := quota.LoadAll(ctx, api.mustDB())
+	if err != nil {
+		log.Warning(ctx, "metrics>Errors while loading quotas: %v", err)
+		return
+	}
+	for _, q := range qs {
+		report, err := api.quotaReport(ctx, q)
+		if err != nil {
+			log.Warning(ctx, "metrics>Errors while computing usage of quota %d: %v", q.ID, err)
+			continue
+		}
+		values := []struct {
+			resource     string
+			usage, limit float64
+		}{
+			{"building_jobs", float64(report.Usage.BuildingJobs), float64(q.MaxBuildingJobs)},
+			{"cpus", report.Usage.CPUs, q.MaxCPUs},
+			{"memory", float64(report.Usage.Memory), float64(q.MaxMemory)},
+			{"artifact_storage", float64(report.Usage.ArtifactStorage), float64(q.MaxArtifactStorage)},
+		}
+		for _, v := range values {
+			ctx, _ := tag.New(ctx, tag.Upsert(tagQuota, report.Entity), tag.Upsert(tagResource, v.resource))
+			observability.RecordFloat64(ctx, api.Metrics.quotaUsage, v.usage)
+			observability.RecordFloat64(ctx, api.Metrics.quotaLimit, v.limit)
+		}
+	}
+}
+
 func (api *API) processStatusMetrics(ctx context.Context) {
 	srvs, err := services.LoadAll(ctx, api.mustDB())
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 	yaml "gopkg.in/yaml.v2"
 
@@ -418,6 +419,7 @@ func (api *API) postTemplateApplyHandler() service.Handler {
 		if err := service.UnmarshalBody(r, &req); err != nil {
 			return err
 		}
+		wt.SetDefaultParams(&req, wt.RepositoryInfo(req))
 		if err := wt.CheckParams(req); err != nil {
 			return err
 		}
@@ -518,7 +520,10 @@ func (api *API) postTemplateBulkHandler() service.Handler {
 			return err
 		}
 		m := make(map[string]struct{}, len(req.Operations))
-		for _, o := range req.Operations {
+		for i := range req.Operations {
+			o := &req.Operations[i]
+			wt.SetDefaultParams(&o.Request, wt.RepositoryInfo(o.Request))
+
 			// check for duplicated request
 			key := fmt.Sprintf("%s-%s", o.Request.ProjectKey, o.Request.WorkflowName)
 			if _, ok := m[key]; ok {
@@ -557,91 +562,96 @@ func (api *API) postTemplateBulkHandler() service.Handler {
 			return err
 		}
 
-		var ident sdk.Identifiable = *consumer
 		// start async bulk tasks
-		sdk.GoRoutine(context.Background(), "api.templateBulkApply", func(ctx context.Context) {
-			for i := range bulk.Operations {
-				if bulk.Operations[i].Status == sdk.OperationStatusPending {
-					bulk.Operations[i].Status = sdk.OperationStatusProcessing
-					if err := workflowtemplate.UpdateBulk(api.mustDB(), &bulk); err != nil {
-						log.Error(ctx, "%v", err)
-						return
-					}
+		api.processTemplateBulk(consumer, wt, &bulk)
 
-					errorDefer := func(err error) error {
-						if err != nil {
-							bulk.Operations[i].Status = sdk.OperationStatusError
-							bulk.Operations[i].Error = fmt.Sprintf("%s", sdk.Cause(err))
-							if err := workflowtemplate.UpdateBulk(api.mustDB(), &bulk); err != nil {
-								return err
-							}
-						}
+		// returns created bulk
+		return service.WriteJSON(w, bulk, http.StatusOK)
+	}
+}
 
-						return nil
-					}
+// processTemplateBulk starts an asynchronous task that will apply and import all pending operations of given bulk.
+func (api *API) processTemplateBulk(consumer *sdk.AuthConsumer, wt *sdk.WorkflowTemplate, bulk *sdk.WorkflowTemplateBulk) {
+	var ident sdk.Identifiable = *consumer
+	sdk.GoRoutine(context.Background(), "api.templateBulkApply", func(ctx context.Context) {
+		for i := range bulk.Operations {
+			if bulk.Operations[i].Status == sdk.OperationStatusPending {
+				bulk.Operations[i].Status = sdk.OperationStatusProcessing
+				if err := workflowtemplate.UpdateBulk(api.mustDB(), bulk); err != nil {
+					log.Error(ctx, "%v", err)
+					return
+				}
 
-					// load project with key
-					p, err := project.Load(api.mustDB(), api.Cache, bulk.Operations[i].Request.ProjectKey,
-						project.LoadOptions.WithGroups,
-						project.LoadOptions.WithApplications,
-						project.LoadOptions.WithEnvironments,
-						project.LoadOptions.WithPipelines,
-						project.LoadOptions.WithApplicationWithDeploymentStrategies,
-						project.LoadOptions.WithIntegrations)
+				errorDefer := func(err error) error {
 					if err != nil {
-						if errD := errorDefer(err); errD != nil {
-							log.Error(ctx, "%v", errD)
-							return
+						bulk.Operations[i].Status = sdk.OperationStatusError
+						bulk.Operations[i].Error = fmt.Sprintf("%s", sdk.Cause(err))
+						if err := workflowtemplate.UpdateBulk(api.mustDB(), bulk); err != nil {
+							return err
 						}
-						continue
 					}
 
-					// apply and import workflow
-					res, err := api.applyTemplate(ctx, consumer, p, wt, bulk.Operations[i].Request)
-					if err != nil {
-						if errD := errorDefer(err); errD != nil {
-							log.Error(ctx, "%v", errD)
-							return
-						}
-						continue
-					}
+					return nil
+				}
 
-					buf := new(bytes.Buffer)
-					if err := workflowtemplate.Tar(ctx, wt, res, buf); err != nil {
-						if errD := errorDefer(err); errD != nil {
-							log.Error(ctx, "%v", errD)
-							return
-						}
-						continue
+				// load project with key
+				p, err := project.Load(api.mustDB(), api.Cache, bulk.Operations[i].Request.ProjectKey,
+					project.LoadOptions.WithGroups,
+					project.LoadOptions.WithApplications,
+					project.LoadOptions.WithEnvironments,
+					project.LoadOptions.WithPipelines,
+					project.LoadOptions.WithApplicationWithDeploymentStrategies,
+					project.LoadOptions.WithIntegrations)
+				if err != nil {
+					if errD := errorDefer(err); errD != nil {
+						log.Error(ctx, "%v", errD)
+						return
 					}
+					continue
+				}
 
-					tr := tar.NewReader(buf)
-
-					_, wkf, _, err := workflow.Push(ctx, api.mustDB(), api.Cache, p, tr, nil, consumer, project.DecryptWithBuiltinKey)
-					if err != nil {
-						if errD := errorDefer(sdk.WrapError(err, "cannot push generated workflow")); errD != nil {
-							log.Error(ctx, "%v", errD)
-							return
-						}
-						continue
+				// apply and import workflow
+				res, err := api.applyTemplate(ctx, consumer, p, wt, bulk.Operations[i].Request)
+				if err != nil {
+					if errD := errorDefer(err); errD != nil {
+						log.Error(ctx, "%v", errD)
+						return
 					}
+					continue
+				}
 
-					if err := workflowtemplate.SetTemplateData(ctx, api.mustDB(), p, wkf, ident, wt); err != nil {
-						log.Error(ctx, "postTemplateBulkHandler> unable to set template data: %v", err)
+				buf := new(bytes.Buffer)
+				if err := workflowtemplate.Tar(ctx, wt, res, buf); err != nil {
+					if errD := errorDefer(err); errD != nil {
+						log.Error(ctx, "%v", errD)
+						return
 					}
+					continue
+				}
 
-					bulk.Operations[i].Status = sdk.OperationStatusDone
-					if err := workflowtemplate.UpdateBulk(api.mustDB(), &bulk); err != nil {
-						log.Error(ctx, "%v", err)
+				tr := tar.NewReader(buf)
+
+				_, wkf, _, err := workflow.Push(ctx, api.mustDB(), api.Cache, p, tr, nil, consumer, project.DecryptWithBuiltinKey)
+				if err != nil {
+					if errD := errorDefer(sdk.WrapError(err, "cannot push generated workflow")); errD != nil {
+						log.Error(ctx, "%v", errD)
 						return
 					}
+					continue
 				}
-			}
-		})
 
-		// returns created bulk
-		return service.WriteJSON(w, bulk, http.StatusOK)
-	}
+				if err := workflowtemplate.SetTemplateData(ctx, api.mustDB(), p, wkf, ident, wt); err != nil {
+					log.Error(ctx, "processTemplateBulk> unable to set template data: %v", err)
+				}
+
+				bulk.Operations[i].Status = sdk.OperationStatusDone
+				if err := workflowtemplate.UpdateBulk(api.mustDB(), bulk); err != nil {
+					log.Error(ctx, "%v", err)
+					return
+				}
+			}
+		}
+	})
 }
 
 func (api *API) getTemplateBulkHandler() service.Handler {
@@ -744,6 +754,218 @@ func (api *API) getTemplateInstancesHandler() service.Handler {
 	}
 }
 
+// computeTemplateInstancesDrift returns the drift for each given instance, an error on a single instance is
+// returned in its drift and does not stop the computation for others.
+func (api *API) computeTemplateInstancesDrift(ctx context.Context, wt *sdk.WorkflowTemplate, wtis []sdk.WorkflowTemplateInstance, ps []sdk.Project) ([]sdk.WorkflowTemplateInstanceDrift, error) {
+	mProjects := make(map[int64]sdk.Project, len(ps))
+	for i := range ps {
+		mProjects[ps[i].ID] = ps[i]
+	}
+
+	wtisPointers := make([]*sdk.WorkflowTemplateInstance, len(wtis))
+	for i := range wtis {
+		p := mProjects[wtis[i].ProjectID]
+		wtis[i].Project = &p
+		wtisPointers[i] = &wtis[i]
+	}
+	if err := workflow.AggregateOnWorkflowTemplateInstance(api.mustDB(), wtisPointers...); err != nil {
+		return nil, err
+	}
+
+	// secret values can't be compared so there is no need to encrypt them
+	maskSecret := func(gorp.SqlExecutor, int64, string, string) (string, error) {
+		return sdk.PasswordPlaceholder, nil
+	}
+
+	drifts := make([]sdk.WorkflowTemplateInstanceDrift, len(wtis))
+	for i := range wtis {
+		var current *exportentities.WorkflowPulled
+		if wtis[i].Workflow != nil {
+			wp, err := workflow.Pull(ctx, api.mustDB(), api.Cache, wtis[i].Project, wtis[i].Workflow.Name, exportentities.FormatYAML, maskSecret)
+			if err != nil {
+				drifts[i] = sdk.WorkflowTemplateInstanceDrift{
+					InstanceID:   wtis[i].ID,
+					ProjectKey:   wtis[i].Project.Key,
+					WorkflowName: wtis[i].WorkflowName,
+					Error:        fmt.Sprintf("%s", sdk.Cause(err)),
+				}
+				continue
+			}
+			current = &wp
+		}
+
+		drift, err := workflowtemplate.ComputeDrift(wt, &wtis[i], current)
+		if err != nil {
+			drift.Error = fmt.Sprintf("%s", sdk.Cause(err))
+		}
+		drifts[i] = drift
+	}
+
+	return drifts, nil
+}
+
+func (api *API) getTemplateDriftHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["groupName"]
+		templateSlug := vars["templateSlug"]
+		projectKey := FormString(r, "project")
+		onlyDrifted := FormBool(r, "onlyDrifted")
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName, group.LoadOptions.WithMembers)
+		if err != nil {
+			return err
+		}
+		if !(isGroupMember(ctx, g) || isMaintainer(ctx)) {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		wt, err := workflowtemplate.LoadBySlugAndGroupID(ctx, api.mustDB(), templateSlug, g.ID)
+		if err != nil {
+			return err
+		}
+
+		var ps sdk.Projects
+		if isMaintainer(ctx) {
+			ps, err = project.LoadAll(ctx, api.mustDB(), api.Cache)
+		} else {
+			ps, err = project.LoadAllByGroupIDs(ctx, api.mustDB(), api.Cache, getAPIConsumer(ctx).GetGroupIDs())
+		}
+		if err != nil {
+			return err
+		}
+		if projectKey != "" {
+			var filtered sdk.Projects
+			for i := range ps {
+				if ps[i].Key == projectKey {
+					filtered = append(filtered, ps[i])
+				}
+			}
+			ps = filtered
+		}
+
+		wtis, err := workflowtemplate.GetInstancesByTemplateIDAndProjectIDs(api.mustDB(), wt.ID, sdk.ProjectsToIDs(ps))
+		if err != nil {
+			return err
+		}
+
+		drifts, err := api.computeTemplateInstancesDrift(ctx, wt, wtis, ps)
+		if err != nil {
+			return err
+		}
+
+		if onlyDrifted {
+			var filtered []sdk.WorkflowTemplateInstanceDrift
+			for i := range drifts {
+				if drifts[i].HasDrift() || drifts[i].Error != "" {
+					filtered = append(filtered, drifts[i])
+				}
+			}
+			drifts = filtered
+		}
+
+		return service.WriteJSON(w, drifts, http.StatusOK)
+	}
+}
+
+func (api *API) postTemplateReapplyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["groupName"]
+		templateSlug := vars["templateSlug"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName, group.LoadOptions.WithMembers)
+		if err != nil {
+			return err
+		}
+		if !(isGroupMember(ctx, g) || isMaintainer(ctx)) {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		wt, err := workflowtemplate.LoadBySlugAndGroupID(ctx, api.mustDB(), templateSlug, g.ID, workflowtemplate.LoadOptions.Default)
+		if err != nil {
+			return err
+		}
+
+		var req sdk.WorkflowTemplateReapplyRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		// load all projects targeted by the request
+		var ps []sdk.Project
+		if req.ProjectKey != "" {
+			p, err := project.Load(api.mustDB(), api.Cache, req.ProjectKey)
+			if err != nil {
+				return err
+			}
+			ps = []sdk.Project{*p}
+		} else {
+			targetGroup, err := group.LoadByName(ctx, api.mustDB(), req.GroupName)
+			if err != nil {
+				return err
+			}
+			ps, err = project.LoadAllByGroupIDs(ctx, api.mustDB(), api.Cache, []int64{targetGroup.ID})
+			if err != nil {
+				return err
+			}
+		}
+
+		consumer := getAPIConsumer(ctx)
+
+		// non admin user should have read/write access to all given project
+		if !consumer.Admin() {
+			for i := range ps {
+				if err := api.checkProjectPermissions(ctx, ps[i].Key, sdk.PermissionReadWriteExecute, nil); err != nil {
+					return sdk.NewErrorFrom(sdk.ErrForbidden, "write permission on project %s required to import generated workflow.", ps[i].Key)
+				}
+			}
+		}
+
+		wtis, err := workflowtemplate.GetInstancesByTemplateIDAndProjectIDs(api.mustDB(), wt.ID, sdk.ProjectsToIDs(ps))
+		if err != nil {
+			return err
+		}
+
+		if req.OnlyDrifted {
+			drifts, err := api.computeTemplateInstancesDrift(ctx, wt, wtis, ps)
+			if err != nil {
+				return err
+			}
+			var drifted []sdk.WorkflowTemplateInstance
+			for i := range drifts {
+				if drifts[i].HasDrift() {
+					drifted = append(drifted, wtis[i])
+				}
+			}
+			wtis = drifted
+		}
+
+		// store the bulk request, the report will be available with the bulk route
+		bulk := sdk.WorkflowTemplateBulk{
+			UserID:             consumer.AuthentifiedUser.ID,
+			WorkflowTemplateID: wt.ID,
+			Operations:         make([]sdk.WorkflowTemplateBulkOperation, len(wtis)),
+		}
+		for i := range wtis {
+			bulk.Operations[i].Status = sdk.OperationStatusPending
+			bulk.Operations[i].Request = wtis[i].Request
+		}
+		if err := workflowtemplate.InsertBulk(api.mustDB(), &bulk); err != nil {
+			return err
+		}
+
+		api.processTemplateBulk(consumer, wt, &bulk)
+
+		return service.WriteJSON(w, bulk, http.StatusOK)
+	}
+}
+
 func (api *API) getTemplateInstanceHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -156,6 +156,20 @@ func SetupPG(t log.Logger, bootstrapFunc ...Bootstrapf) (*gorp.DbMap, cache.Stor
 		}
 	}
 
+	// Without redis configuration, tests use an in-memory cache
+	if RedisHost == "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		store, err := cache.NewLocalStore(ctx, "", 60, 0)
+		if err != nil {
+			t.Fatalf("Unable to init local cache: %v", err)
+		}
+		dbMap := DBConnectionFactory.GetDBMap()
+		if dbMap == nil {
+			t.Fatalf("unable to init database connection")
+		}
+		return dbMap, store, cancel
+	}
+
 	store, err := cache.NewRedisStore(RedisHost, RedisPassword, 60)
 	if err != nil {
 		t.Fatalf("Unable to connect to redis: %v", err)
@@ -3,12 +3,14 @@ package api
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/lib/pq"
 
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/database"
+	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/user"
 	"github.com/ovh/cds/engine/service"
@@ -179,3 +181,59 @@ func (api *API) deleteUserHandler() service.Handler {
 		return service.WriteJSON(w, nil, http.StatusOK)
 	}
 }
+
+// postAdminUserScrubHandler pseudonymizes personal identifiers of a deleted user in all stores.
+func (api *API) postAdminUserScrubHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.UserScrubRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		// Only data of deleted users can be scrubbed
+		if _, err := user.LoadByUsername(ctx, api.mustDB(), req.Username); err == nil {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "user %s should be deleted before scrubbing its data", req.Username)
+		} else if !sdk.ErrorIs(err, sdk.ErrUserNotFound) {
+			return err
+		}
+
+		report := sdk.UserScrubReport{
+			Username:  req.Username,
+			Pseudonym: sdk.NewUserPseudonym(),
+			Started:   time.Now(),
+		}
+
+		report.Stores = user.Scrub(ctx, api.mustDB(), req, report.Pseudonym)
+
+		esReport := sdk.UserScrubStoreReport{Store: "elasticsearch:events"}
+		esReq := sdk.EventScrubRequest{
+			Username:  req.Username,
+			Email:     req.Email,
+			Pseudonym: report.Pseudonym,
+		}
+		if req.Email != "" {
+			esReq.EmailPseudonym = sdk.UserPseudonymEmail(report.Pseudonym)
+		}
+		n, err := event.ScrubInElasticSearch(ctx, api.mustDB(), esReq)
+		if err != nil {
+			log.Error(ctx, "postAdminUserScrubHandler> %v", err)
+			esReport.Error = err.Error()
+		}
+		esReport.Count = n
+		report.Stores = append(report.Stores, esReport)
+
+		report.Done = time.Now()
+
+		// Scrubbed identifiers should not be kept in the audit log of this call
+		auditReport := report
+		auditReport.Username = ""
+		setAuditLogData(ctx, nil, auditReport)
+
+		log.Info(ctx, "postAdminUserScrubHandler> data of deleted user scrubbed with pseudonym %s (errors: %t)", report.Pseudonym, report.HasErrors())
+
+		return service.WriteJSON(w, report, http.StatusOK)
+	}
+}
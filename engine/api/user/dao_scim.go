@@ -0,0 +1,22 @@
+package user
+
+import (
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertSCIMProvisioned marks given user as provisioned by the SCIM identity provider.
+func InsertSCIMProvisioned(db gorp.SqlExecutor, userID string) error {
+	_, err := db.Exec("INSERT INTO authentified_user_scim (authentified_user_id) VALUES ($1)", userID)
+	return sdk.WrapError(err, "cannot insert scim provisioning for user %s", userID)
+}
+
+// IsSCIMProvisioned returns true if given user was provisioned by the SCIM identity provider.
+func IsSCIMProvisioned(db gorp.SqlExecutor, userID string) (bool, error) {
+	count, err := db.SelectInt("SELECT COUNT(1) FROM authentified_user_scim WHERE authentified_user_id = $1", userID)
+	if err != nil {
+		return false, sdk.WrapError(err, "cannot check scim provisioning for user %s", userID)
+	}
+	return count > 0, nil
+}
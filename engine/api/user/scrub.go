@@ -0,0 +1,126 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// scrubColumn is a column that can contain a user's personal identifiers.
+type scrubColumn struct {
+	table  string
+	column string
+	// json is true for JSONB columns where identifiers are string values of the document.
+	json bool
+	// where is an optional extra condition to select rows.
+	where string
+}
+
+func (c scrubColumn) String() string { return c.table + "." + c.column }
+
+// scrubColumns lists all the columns where a user's identifiers are kept after its deletion
+// (run history, audits, spawn infos...).
+var scrubColumns = []scrubColumn{
+	{table: "workflow_run_tag", column: "value", where: "tag = 'triggered_by'"},
+	{table: "workflow_run", column: "infos", json: true},
+	{table: "workflow_run", column: "join_triggers_run", json: true},
+	{table: "workflow_node_run", column: "manual", json: true},
+	{table: "workflow_node_run", column: "payload", json: true},
+	{table: "workflow_node_run", column: "build_parameters", json: true},
+	{table: "workflow_node_run", column: "triggers_run", json: true},
+	{table: "workflow_node_run", column: "stages", json: true},
+	{table: "workflow_node_run_job", column: "variables", json: true},
+	{table: "workflow_node_run_job", column: "job", json: true},
+	{table: "workflow_node_run_job_info", column: "spawninfos", json: true},
+	{table: "as_code_events", column: "username"},
+	{table: "audit_log", column: "username"},
+	{table: "workflow_audit", column: "triggered_by"},
+	{table: "workflow_template_audit", column: "triggered_by"},
+	{table: "workflow_template_instance_audit", column: "triggered_by"},
+	{table: "action_audit", column: "triggered_by"},
+	{table: "pipeline_audit", column: "username"},
+	{table: "project_variable_audit", column: "author"},
+	{table: "application_variable_audit", column: "author"},
+	{table: "environment_variable_audit", column: "author"},
+}
+
+// scrubReplacements returns pairs of identifier and pseudonymized value for given request.
+func scrubReplacements(req sdk.UserScrubRequest, pseudonym string) [][2]string {
+	rs := [][2]string{{req.Username, pseudonym}}
+	if req.Email != "" {
+		rs = append(rs, [2]string{req.Email, sdk.UserPseudonymEmail(pseudonym)})
+	}
+	if req.Fullname != "" && req.Fullname != req.Username {
+		rs = append(rs, [2]string{req.Fullname, pseudonym})
+	}
+	return rs
+}
+
+// jsonScrubValue returns the JSON representation of a string value as stored by Postgres in a JSONB column.
+func jsonScrubValue(s string) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return "", sdk.WithStack(err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func scrubColumnValues(db gorp.SqlExecutor, c scrubColumn, from, to string) (int64, error) {
+	var query string
+	if c.json {
+		var err error
+		if from, err = jsonScrubValue(from); err != nil {
+			return 0, err
+		}
+		if to, err = jsonScrubValue(to); err != nil {
+			return 0, err
+		}
+		// Only whole string values are replaced to prevent altering unrelated data
+		query = fmt.Sprintf("UPDATE %[1]s SET %[2]s = replace(%[2]s::text, $1, $2)::jsonb WHERE strpos(%[2]s::text, $1) > 0", c.table, c.column)
+	} else {
+		query = fmt.Sprintf("UPDATE %[1]s SET %[2]s = $2 WHERE %[2]s = $1", c.table, c.column)
+	}
+	if c.where != "" {
+		query += " AND " + c.where
+	}
+
+	res, err := db.Exec(query, from, to)
+	if err != nil {
+		return 0, sdk.WrapError(err, "cannot scrub %s", c)
+	}
+	n, err := res.RowsAffected()
+	return n, sdk.WithStack(err)
+}
+
+// Scrub replaces all personal identifiers of given deleted user by a pseudonym in the database.
+// Each column is scrubbed independently and failures are given in the report instead of stopping
+// the scrubbing.
+func Scrub(ctx context.Context, db gorp.SqlExecutor, req sdk.UserScrubRequest, pseudonym string) []sdk.UserScrubStoreReport {
+	replacements := scrubReplacements(req, pseudonym)
+
+	reports := make([]sdk.UserScrubStoreReport, 0, len(scrubColumns))
+	for _, c := range scrubColumns {
+		r := sdk.UserScrubStoreReport{Store: "database:" + c.String()}
+		for _, rep := range replacements {
+			n, err := scrubColumnValues(db, c, rep[0], rep[1])
+			if err != nil {
+				log.Error(ctx, "user.Scrub> %v", err)
+				r.Error = err.Error()
+				break
+			}
+			r.Count += n
+		}
+		reports = append(reports, r)
+	}
+
+	return reports
+}
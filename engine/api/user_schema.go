@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"reflect"
 
 	"github.com/alecthomas/jsonschema"
 	"github.com/iancoleman/orderedmap"
@@ -24,13 +23,9 @@ func (api *API) getUserJSONSchema() service.Handler {
 
 		var res sdk.SchemaResponse
 
-		ref := jsonschema.Reflector{
-			RequiredFromJSONSchemaTags: true,
-		}
-
 		var sch *jsonschema.Schema
 		if filter == "" || filter == "workflow" {
-			sch = ref.ReflectFromType(reflect.TypeOf(exportentities.Workflow{}))
+			sch = asCodeJSONSchema(exportentities.Workflow{})
 			buf, _ := json.Marshal(sch)
 			res.Workflow = string(buf)
 		}
@@ -55,7 +50,7 @@ func (api *API) getUserJSONSchema() service.Handler {
 				return err
 			}
 
-			sch = ref.ReflectFromType(reflect.TypeOf(exportentities.PipelineV1{}))
+			sch = asCodeJSONSchema(exportentities.PipelineV1{})
 			for i := range as {
 				path := as[i].Name
 				if as[i].Group.Name != sdk.SharedInfraGroupName {
@@ -103,13 +98,13 @@ func (api *API) getUserJSONSchema() service.Handler {
 		}
 
 		if filter == "" || filter == "application" {
-			sch = ref.ReflectFromType(reflect.TypeOf(exportentities.Application{}))
+			sch = asCodeJSONSchema(exportentities.Application{})
 			buf, _ := json.Marshal(sch)
 			res.Application = string(buf)
 		}
 
 		if filter == "" || filter == "environment" {
-			sch = ref.ReflectFromType(reflect.TypeOf(exportentities.Environment{}))
+			sch = asCodeJSONSchema(exportentities.Environment{})
 			buf, _ := json.Marshal(sch)
 			res.Environment = string(buf)
 		}
@@ -1,14 +1,17 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ovh/cds/engine/api/audit"
 	"github.com/ovh/cds/engine/api/test/assets"
 	"github.com/ovh/cds/sdk"
 )
@@ -251,3 +254,52 @@ func Test_deleteUserHandler(t *testing.T) {
 		})
 	}
 }
+
+func Test_postAdminUserScrubHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	_, jwtAdmin := assets.InsertAdminUser(t, db)
+	lambda, _ := assets.InsertLambdaUser(t, db)
+
+	deletedUsername := sdk.RandomString(10)
+	require.NoError(t, audit.InsertLog(db, &sdk.AuditLog{
+		Created:    time.Now(),
+		Method:     http.MethodPut,
+		Path:       "/project/KEY",
+		EntityType: "project",
+		Username:   deletedUsername,
+	}))
+
+	uri := api.Router.GetRoute(http.MethodPost, api.postAdminUserScrubHandler, nil)
+	require.NotEmpty(t, uri)
+
+	// Data of an existing user can't be scrubbed
+	req := assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodPost, uri, sdk.UserScrubRequest{Username: lambda.Username})
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = assets.NewJWTAuthentifiedRequest(t, jwtAdmin, http.MethodPost, uri, sdk.UserScrubRequest{Username: deletedUsername})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var report sdk.UserScrubReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.False(t, report.HasErrors())
+	assert.Equal(t, deletedUsername, report.Username)
+	require.NotEmpty(t, report.Pseudonym)
+	for _, s := range report.Stores {
+		if s.Store == "database:audit_log.username" {
+			assert.Equal(t, int64(1), s.Count)
+		}
+	}
+
+	logs, err := audit.LoadLogs(context.TODO(), db, sdk.AuditLogFilter{Username: deletedUsername})
+	require.NoError(t, err)
+	assert.Len(t, logs, 0)
+	logs, err = audit.LoadLogs(context.TODO(), db, sdk.AuditLogFilter{Username: report.Pseudonym})
+	require.NoError(t, err)
+	assert.Len(t, logs, 1)
+}
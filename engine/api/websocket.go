@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/tevino/abool"
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// websocketClient is a client of the websocket, it only receives the events selected by its filters.
+type websocketClient struct {
+	UUID         string
+	consumer     *sdk.AuthConsumer
+	lang         string
+	isAlive      *abool.AtomicBool
+	conn         *websocket.Conn
+	mutex        sync.Mutex
+	filtersMutex sync.RWMutex
+	filters      map[string]sdk.WebsocketFilter
+}
+
+// websocketBroker sends the events to the websocket clients, the events are filtered before being sent.
+type websocketBroker struct {
+	clients          map[string]*websocketClient
+	messages         chan sdk.Event
+	dbFunc           func() *gorp.DbMap
+	cache            cache.Store
+	router           *Router
+	allowedOrigins   []string
+	chanAddClient    chan (*websocketClient)
+	chanRemoveClient chan (string)
+}
+
+// Init the websocketBroker
+func (b *websocketBroker) Init(ctx context.Context, panicCallback func(s string) (io.WriteCloser, error)) {
+	b.chanAddClient = make(chan (*websocketClient))
+	b.chanRemoveClient = make(chan (string))
+
+	sdk.GoRoutine(ctx, "websocketBroker.Init.CacheSubscribe", func(ctx context.Context) {
+		b.cacheSubscribe(ctx)
+	}, panicCallback)
+
+	sdk.GoRoutine(ctx, "websocketBroker.Init.Start", func(ctx context.Context) {
+		b.Start(ctx, panicCallback)
+	}, panicCallback)
+}
+
+func (b *websocketBroker) cacheSubscribe(ctx context.Context) {
+	if b.cache == nil {
+		return
+	}
+	pubSub, err := b.cache.Subscribe("events_pubsub")
+	if err != nil {
+		log.Error(ctx, "websocketBroker.cacheSubscribe> Exiting on error: %v", err)
+		return
+	}
+	for ctx.Err() == nil {
+		msg, err := b.cache.GetMessageFromSubscription(ctx, pubSub)
+		if err != nil {
+			log.Warning(ctx, "websocketBroker.cacheSubscribe> Cannot get message: %v", err)
+			continue
+		}
+		var e sdk.Event
+		if err := json.Unmarshal([]byte(msg), &e); err != nil {
+			continue
+		}
+		select {
+		case b.messages <- e:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// Start the broker
+func (b *websocketBroker) Start(ctx context.Context, panicCallback func(s string) (io.WriteCloser, error)) {
+	tickerMetrics := time.NewTicker(10 * time.Second)
+	defer tickerMetrics.Stop()
+
+	for {
+		select {
+		case <-tickerMetrics.C:
+			observability.Record(b.router.Background, WebSocketClients, int64(len(b.clients)))
+
+		case <-ctx.Done():
+			for uuid := range b.clients {
+				delete(b.clients, uuid)
+			}
+			observability.Record(b.router.Background, WebSocketClients, 0)
+			return
+
+		case receivedEvent := <-b.messages:
+			for i := range b.clients {
+				c := b.clients[i]
+				if !c.match(receivedEvent) {
+					continue
+				}
+				observability.Record(b.router.Background, WebSocketEvents, 1)
+				sdk.GoRoutine(ctx, "websocket-"+c.UUID, func(ctx context.Context) {
+					if err := c.send(sdk.WebsocketEvent{Status: "OK", Event: receivedEvent}); err != nil {
+						log.Debug("websocketBroker> unable to send event to %s: %v", c.UUID, err)
+						b.chanRemoveClient <- c.UUID
+					}
+				}, panicCallback)
+			}
+
+		case client := <-b.chanAddClient:
+			b.clients[client.UUID] = client
+
+		case uuid := <-b.chanRemoveClient:
+			client, has := b.clients[uuid]
+			if !has {
+				continue
+			}
+			client.isAlive.UnSet()
+			delete(b.clients, uuid)
+		}
+	}
+}
+
+// ServeHTTP upgrades the connection to a websocket. The client sends messages to subscribe to the events
+// selected by filters, and receives the events matching its subscriptions.
+func (b *websocketBroker) ServeHTTP() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		client := &websocketClient{
+			UUID:     sdk.UUID(),
+			consumer: getAPIConsumer(ctx),
+			lang:     r.Header.Get("Accept-Language"),
+			isAlive:  abool.NewBool(true),
+			filters:  make(map[string]sdk.WebsocketFilter),
+		}
+
+		server := websocket.Server{
+			Handshake: b.checkOrigin,
+			Handler: func(conn *websocket.Conn) {
+				defer conn.Close() // nolint
+				client.conn = conn
+				b.chanAddClient <- client
+				client.read(ctx, b.dbFunc())
+				b.chanRemoveClient <- client.UUID
+			},
+		}
+		server.ServeHTTP(w, r)
+		return nil
+	}
+}
+
+// checkOrigin accepts the clients which are not browsers, and the browsers on the UI or the API.
+func (b *websocketBroker) checkOrigin(config *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "invalid origin %s", origin)
+	}
+	if u.Host == r.Host {
+		return nil
+	}
+	for _, o := range b.allowedOrigins {
+		if allowed, err := url.Parse(o); err == nil && allowed.Host == u.Host {
+			return nil
+		}
+	}
+	return sdk.NewErrorFrom(sdk.ErrForbidden, "origin %s is not allowed", origin)
+}
+
+// read the messages of the client until it is disconnected.
+func (c *websocketClient) read(ctx context.Context, db gorp.SqlExecutor) {
+	for ctx.Err() == nil {
+		var msg sdk.WebsocketMessage
+		err := websocket.JSON.Receive(c.conn, &msg)
+		if err != nil {
+			switch err.(type) {
+			case *json.SyntaxError, *json.UnmarshalTypeError:
+				err = sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid message: %v", err)
+			default:
+				if err != io.EOF {
+					log.Debug("websocketClient.read> unable to read message from %s: %v", c.UUID, err)
+				}
+				return
+			}
+		} else {
+			err = c.updateFilters(ctx, db, msg)
+		}
+		if err != nil {
+			if err := c.send(sdk.WebsocketEvent{Status: "KO", Error: sdk.ExtractHTTPError(err, c.lang).Message}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *websocketClient) updateFilters(ctx context.Context, db gorp.SqlExecutor, msg sdk.WebsocketMessage) error {
+	switch msg.Type {
+	case sdk.WebsocketMessageSubscribe:
+		if err := msg.Filter.IsValid(); err != nil {
+			return err
+		}
+		f, err := c.checkFilter(ctx, db, msg.Filter)
+		if err != nil {
+			return err
+		}
+		c.filtersMutex.Lock()
+		c.filters[msg.Filter.Key()] = f
+		c.filtersMutex.Unlock()
+	case sdk.WebsocketMessageUnsubscribe:
+		c.filtersMutex.Lock()
+		delete(c.filters, msg.Filter.Key())
+		c.filtersMutex.Unlock()
+	default:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid message type %q", msg.Type)
+	}
+	return nil
+}
+
+// checkFilter checks that the consumer can read the events selected by a filter, the permissions are checked
+// once when subscribing. A queue filter is restricted to the groups of the consumer.
+func (c *websocketClient) checkFilter(ctx context.Context, db gorp.SqlExecutor, f sdk.WebsocketFilter) (sdk.WebsocketFilter, error) {
+	var isHatcheryWithGroups = c.consumer.Service != nil && c.consumer.Service.Type == services.TypeHatchery && len(c.consumer.GroupIDs) > 0
+	if c.consumer.Maintainer() && !isHatcheryWithGroups {
+		return f, nil
+	}
+
+	switch f.Type {
+	case sdk.WebsocketFilterTypeWorkflowRun:
+		perms, err := permission.LoadWorkflowMaxLevelPermission(ctx, db, f.ProjectKey, []string{f.WorkflowName}, c.consumer.GetGroupIDs())
+		if err != nil {
+			return f, err
+		}
+		if perms.Level(f.WorkflowName) < sdk.PermissionRead {
+			return f, sdk.WithStack(sdk.ErrForbidden)
+		}
+	case sdk.WebsocketFilterTypeAsCodeEvent:
+		perms, err := permission.LoadProjectMaxLevelPermission(ctx, db, []string{f.ProjectKey}, c.consumer.GetGroupIDs())
+		if err != nil {
+			return f, err
+		}
+		if perms.Level(f.ProjectKey) < sdk.PermissionRead {
+			return f, sdk.WithStack(sdk.ErrForbidden)
+		}
+	case sdk.WebsocketFilterTypeQueue:
+		groupIDs := c.consumer.GetGroupIDs()
+		if len(groupIDs) == 0 {
+			return f, sdk.NewErrorFrom(sdk.ErrForbidden, "the consumer has no group")
+		}
+		if len(f.GroupIDs) == 0 {
+			f.GroupIDs = groupIDs
+		}
+		for _, id := range f.GroupIDs {
+			if !sdk.IsInInt64Array(id, groupIDs) {
+				return f, sdk.NewErrorFrom(sdk.ErrForbidden, "group %d is not a group of the consumer", id)
+			}
+		}
+	}
+	return f, nil
+}
+
+// match returns true if one of the filters of the client selects the event.
+func (c *websocketClient) match(e sdk.Event) bool {
+	c.filtersMutex.RLock()
+	defer c.filtersMutex.RUnlock()
+	for _, f := range c.filters {
+		if f.Match(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// send a message to the client
+func (c *websocketClient) send(e sdk.WebsocketEvent) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.isAlive.IsSet() {
+		return nil
+	}
+	return sdk.WithStack(websocket.JSON.Send(c.conn, e))
+}
@@ -69,7 +69,7 @@ func (api *API) postRegisterWorkerHandler() service.Handler {
 		}
 
 		// We have to issue a new consumer for the worker
-		workerConsumer, err := authentication.NewConsumerWorker(ctx, tx, workerTokenFromHatchery.Subject, hatchSrv, hatcheryConsumer, groupIDs)
+		workerConsumer, err := authentication.NewConsumerWorker(ctx, tx, workerTokenFromHatchery.Subject, hatchSrv, hatcheryConsumer, groupIDs, workerTokenFromHatchery.Worker.JobID)
 		if err != nil {
 			return err
 		}
@@ -85,7 +85,12 @@ func (api *API) postRegisterWorkerHandler() service.Handler {
 
 		log.Debug("New worker: [%s] - %s", wk.ID, wk.Name)
 
-		workerSession, err := authentication.NewSession(ctx, tx, workerConsumer, workerauth.SessionDuration, false)
+		// Workers that don't rotate their session keep a long-lived session
+		sessionDuration := workerauth.SessionDuration
+		if registrationForm.SessionRotation {
+			sessionDuration = workerauth.RotatedSessionDuration
+		}
+		workerSession, err := authentication.NewSession(ctx, tx, workerConsumer, sessionDuration, false)
 		if err != nil {
 			return sdk.NewErrorWithStack(
 				sdk.WrapError(err, "[%s] Registering failed", workerTokenFromHatchery.Worker.WorkerName),
@@ -93,10 +98,6 @@ func (api *API) postRegisterWorkerHandler() service.Handler {
 			)
 		}
 
-		if err := tx.Commit(); err != nil {
-			return sdk.WithStack(err)
-		}
-
 		jwt, err = authentication.NewSessionJWT(workerSession)
 		if err != nil {
 			return sdk.NewErrorWithStack(
@@ -105,6 +106,18 @@ func (api *API) postRegisterWorkerHandler() service.Handler {
 			)
 		}
 
+		// A token issued by a hatchery can't be used to register several workers, it is consumed
+		// only once the registration is done so the worker can retry a failed registration
+		if err := workerauth.ConsumeToken(api.Cache, workerTokenFromHatchery); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			if err := workerauth.ReleaseToken(api.Cache, workerTokenFromHatchery); err != nil {
+				log.Error(ctx, "worker.registerWorkerHandler> unable to release worker token: %v", err)
+			}
+			return sdk.WithStack(err)
+		}
+
 		// Set the JWT token as a header
 		log.Debug("worker.registerWorkerHandler> X-CDS-JWT:%s", jwt[:12])
 		w.Header().Add("X-CDS-JWT", jwt)
@@ -114,6 +127,56 @@ func (api *API) postRegisterWorkerHandler() service.Handler {
 	}
 }
 
+func (api *API) postRotateWorkerSessionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !isWorker(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+		consumer := getAPIConsumer(ctx)
+		currentSession := getAuthSession(ctx)
+
+		// A worker scoped to a job can't get a new session once its job is done
+		if jobID := consumer.WorkerJobRunID(); jobID != 0 {
+			if _, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, jobID); err != nil {
+				if sdk.ErrorIs(err, sdk.ErrWorkflowNodeRunJobNotFound) {
+					return sdk.NewErrorFrom(sdk.ErrForbidden, "job %d is done, worker session can't be rotated", jobID)
+				}
+				return err
+			}
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		newSession, err := authentication.NewSession(ctx, tx, consumer, workerauth.RotatedSessionDuration, false)
+		if err != nil {
+			return err
+		}
+
+		// Previous session remains valid for a short time to let in-flight requests end
+		if err := workerauth.ExpireSession(ctx, tx, currentSession); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		jwt, err := authentication.NewSessionJWT(newSession)
+		if err != nil {
+			return err
+		}
+
+		log.Debug("worker.postRotateWorkerSessionHandler> new session %s for worker %s", newSession.ID, consumer.Worker.Name)
+		w.Header().Add("X-CDS-JWT", jwt)
+
+		return service.WriteJSON(w, newSession, http.StatusOK)
+	}
+}
+
 func (api *API) getWorkersHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		var workers []sdk.Worker
@@ -193,6 +256,11 @@ func (api *API) postUnregisterWorkerHandler() service.Handler {
 		if err := DisableWorker(ctx, api.mustDB(), wk.ID); err != nil {
 			return sdk.WrapError(err, "cannot delete worker %s", wk.Name)
 		}
+		if s := getAuthSession(ctx); s != nil {
+			if err := authentication.DeleteSessionByID(api.mustDB(), s.ID); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 }
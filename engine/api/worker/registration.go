@@ -80,6 +80,11 @@ func RegisterWorker(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		return nil, sdk.WithStack(sdk.ErrForbidden)
 	}
 
+	// A worker started from an image of another architecture would register capabilities that are wrong for the model
+	if model != nil && model.DeclaredArch() != "" && registrationForm.Arch != "" && model.DeclaredArch() != registrationForm.Arch {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "worker architecture %s doesn't match the architecture %s of the worker model %s", registrationForm.Arch, model.DeclaredArch(), model.Name)
+	}
+
 	//Instanciate a new worker
 	w := &sdk.Worker{
 		ID:         sdk.UUID(),
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ovh/cds/engine/api/workermodel"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getWorkerModelTrustPolicyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		p, err := workermodel.LoadTrustPolicy(api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, p, http.StatusOK)
+	}
+}
+
+func (api *API) putWorkerModelTrustPolicyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !isAdmin(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var p sdk.WorkerModelTrustPolicy
+		if err := service.UnmarshalBody(r, &p); err != nil {
+			return err
+		}
+		if p.Mode == "" {
+			p.Mode = sdk.WorkerModelTrustModeAll
+		}
+		if err := p.IsValid(); err != nil {
+			return err
+		}
+
+		if err := workermodel.UpsertTrustPolicy(api.mustDB(), &p); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, p, http.StatusOK)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	workerauth "github.com/ovh/cds/engine/api/authentication/worker"
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/test"
 	"github.com/ovh/cds/engine/api/test/assets"
@@ -132,3 +133,92 @@ func TestPostRegisterWorkerHandler(t *testing.T) {
 	api.Router.Mux.ServeHTTP(rec, req)
 	assert.Equal(t, 204, rec.Code)
 }
+
+func TestPostRegisterWorkerHandlerTokenReuse(t *testing.T) {
+	api, _, _, end := newTestAPI(t)
+	defer end()
+
+	g := LoadSharedInfraGroup(t, api)
+	model := LoadOrCreateWorkerModel(t, api, g.ID, "Test1")
+	hSrv, hPrivKey, _, _ := assets.InsertHatchery(t, api.mustDB(), *g)
+
+	jwt, err := hatchery.NewWorkerToken(hSrv.Name, hPrivKey, time.Now().Add(time.Hour), hatchery.SpawnArguments{
+		HatcheryName: hSrv.Name,
+		Model:        model,
+		WorkerName:   hSrv.Name + "-worker",
+	})
+	test.NoError(t, err)
+
+	uri := api.Router.GetRoute("POST", api.postRegisterWorkerHandler, nil)
+	test.NotEmpty(t, uri)
+	form := sdk.WorkerRegistrationForm{Arch: runtime.GOARCH, OS: runtime.GOOS, Version: sdk.VERSION}
+
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, form))
+	assert.Equal(t, 200, rec.Code)
+
+	// The same token can't be used to register another worker
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, form))
+	assert.Equal(t, 401, rec.Code)
+}
+
+func TestPostRegisterWorkerHandlerTokenNotConsumedOnFailure(t *testing.T) {
+	api, _, _, end := newTestAPI(t)
+	defer end()
+
+	g := LoadSharedInfraGroup(t, api)
+	model := LoadOrCreateWorkerModel(t, api, g.ID, "Test1")
+	hSrv, hPrivKey, _, _ := assets.InsertHatchery(t, api.mustDB(), *g)
+
+	// The registration fails because the job doesn't exist
+	jwt, err := hatchery.NewWorkerToken(hSrv.Name, hPrivKey, time.Now().Add(time.Hour), hatchery.SpawnArguments{
+		HatcheryName: hSrv.Name,
+		Model:        model,
+		WorkerName:   hSrv.Name + "-worker",
+		JobID:        999999999,
+	})
+	test.NoError(t, err)
+
+	uri := api.Router.GetRoute("POST", api.postRegisterWorkerHandler, nil)
+	test.NotEmpty(t, uri)
+	form := sdk.WorkerRegistrationForm{Arch: runtime.GOARCH, OS: runtime.GOOS, Version: sdk.VERSION}
+
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, form))
+	assert.Equal(t, 403, rec.Code)
+
+	// The token was not consumed, it can be used again
+	claims, err := workerauth.VerifyToken(context.TODO(), api.mustDB(), jwt)
+	test.NoError(t, err)
+	test.NoError(t, workerauth.ConsumeToken(api.Cache, claims))
+}
+
+func TestPostRotateWorkerSessionHandler(t *testing.T) {
+	api, _, _, end := newTestAPI(t)
+	defer end()
+
+	g := LoadSharedInfraGroup(t, api)
+	model := LoadOrCreateWorkerModel(t, api, g.ID, "Test1")
+	_, workerJWT := RegisterWorker(t, api, g.ID, model.Name)
+
+	uri := api.Router.GetRoute("POST", api.postRotateWorkerSessionHandler, nil)
+	test.NotEmpty(t, uri)
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, assets.NewJWTAuthentifiedRequest(t, workerJWT, "POST", uri, nil))
+	assert.Equal(t, 200, rec.Code)
+
+	var session sdk.AuthSession
+	test.NoError(t, json.Unmarshal(rec.Body.Bytes(), &session))
+	assert.True(t, session.ExpireAt.After(time.Now().Add(time.Minute)))
+	newJWT := rec.Header().Get("X-CDS-JWT")
+	assert.NotEmpty(t, newJWT)
+	assert.NotEqual(t, workerJWT, newJWT)
+
+	// The new session can be used by the worker
+	uri = api.Router.GetRoute("POST", api.postRefreshWorkerHandler, nil)
+	test.NotEmpty(t, uri)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, assets.NewJWTAuthentifiedRequest(t, newJWT, "POST", uri, nil))
+	assert.Equal(t, 204, rec.Code)
+}
@@ -51,6 +51,10 @@ func Create(ctx context.Context, db gorp.SqlExecutor, data sdk.Model, ident sdk.
 	model.Author.Fullname = ident.GetFullname()
 	model.Author.Email = ident.GetEmail()
 
+	if err := CheckTrustPolicy(ctx, db, model); err != nil {
+		return nil, err
+	}
+
 	if err := Insert(db, &model); err != nil {
 		return nil, sdk.WrapError(err, "cannot add worker model")
 	}
@@ -106,6 +110,10 @@ func Update(ctx context.Context, db gorp.SqlExecutor, old *sdk.Model, data sdk.M
 	model := sdk.Model(*old)
 	model.Update(data)
 
+	if err := CheckTrustPolicy(ctx, db, model); err != nil {
+		return nil, err
+	}
+
 	// update model in db
 	if err := UpdateDB(db, &model); err != nil {
 		return nil, sdk.WrapError(err, "cannot update worker model")
@@ -73,6 +73,14 @@ chmod +x worker
 				Cmd:   "curl {{.API}}/download/worker/linux/$(uname -m) -o worker --retry 10 --retry-max-time 120 && chmod +x worker && exec ./worker",
 			},
 		},
+		{
+			Type: sdk.Docker,
+			Name: "basic_windows",
+			Model: sdk.ModelCmds{
+				Shell: "powershell -Command",
+				Cmd:   "Invoke-WebRequest -Uri {{.API}}/download/worker/windows/amd64 -OutFile worker.exe -UseBasicParsing; .\\worker.exe",
+			},
+		},
 		{
 			Type: sdk.Openstack,
 			Name: "basic_debian",
@@ -91,6 +99,24 @@ chmod +x worker
 				PostCmd: "sudo shutdown -h now",
 			},
 		},
+		{
+			Type: sdk.EC2,
+			Name: "basic_debian",
+			Model: sdk.ModelCmds{
+				PreCmd:  preCmdOs,
+				Cmd:     "./worker",
+				PostCmd: "sudo shutdown -h now",
+			},
+		},
+		{
+			Type: sdk.GCE,
+			Name: "basic_debian",
+			Model: sdk.ModelCmds{
+				PreCmd:  preCmdOs,
+				Cmd:     "./worker",
+				PostCmd: "sudo shutdown -h now",
+			},
+		},
 		{
 			Type: sdk.HostProcess,
 			Name: "basic_unix",
@@ -101,7 +127,7 @@ chmod +x worker
 	}
 
 	for _, pattern := range patterns {
-		numPattern, err := db.SelectInt("SELECT COUNT(1) FROM worker_model_pattern WHERE type = $1", pattern.Type)
+		numPattern, err := db.SelectInt("SELECT COUNT(1) FROM worker_model_pattern WHERE type = $1 AND name = $2", pattern.Type, pattern.Name)
 		if err == sql.ErrNoRows {
 			continue
 		}
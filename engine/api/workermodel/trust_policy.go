@@ -0,0 +1,64 @@
+package workermodel
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cosign"
+)
+
+// LoadTrustPolicy returns the worker model trust policy, a disabled policy is returned if none was set.
+func LoadTrustPolicy(db gorp.SqlExecutor) (sdk.WorkerModelTrustPolicy, error) {
+	p := sdk.WorkerModelTrustPolicy{Mode: sdk.WorkerModelTrustModeAll}
+	var data []byte
+	if err := db.QueryRow("SELECT policy FROM worker_model_trust_policy WHERE id = 1").Scan(&data); err != nil {
+		if err == sql.ErrNoRows {
+			return p, nil
+		}
+		return p, sdk.WrapError(err, "cannot load worker model trust policy")
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return p, sdk.WrapError(err, "cannot unmarshal worker model trust policy")
+	}
+	return p, nil
+}
+
+// UpsertTrustPolicy saves the worker model trust policy.
+func UpsertTrustPolicy(db gorp.SqlExecutor, p *sdk.WorkerModelTrustPolicy) error {
+	p.Modified = time.Now()
+	data, err := json.Marshal(p)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	query := `INSERT INTO worker_model_trust_policy (id, policy, modified) VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET policy = $1, modified = $2`
+	if _, err := db.Exec(query, data, p.Modified); err != nil {
+		return sdk.WrapError(err, "cannot save worker model trust policy")
+	}
+	return nil
+}
+
+// CheckTrustPolicy returns an error if the image of given worker model is not allowed by the trust policy.
+// The model password should be given in clear for private images.
+func CheckTrustPolicy(ctx context.Context, db gorp.SqlExecutor, m sdk.Model) error {
+	p, err := LoadTrustPolicy(db)
+	if err != nil {
+		return err
+	}
+
+	var verifier sdk.ImageVerifier
+	if p.Enabled && p.RequireSignature {
+		v, err := cosign.NewVerifier(p.PublicKeys)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWorkerModelUntrusted, "invalid trust policy: %v", err)
+		}
+		verifier = v
+	}
+
+	return p.CheckModel(ctx, m, verifier)
+}
@@ -483,6 +483,7 @@ func (api *API) putWorkflowHandler() service.Handler {
 		}
 
 		event.PublishWorkflowUpdate(ctx, p.Key, *wf1, *oldW, getAPIConsumer(ctx))
+		setAuditLogData(ctx, oldW, wf1)
 
 		wf1.Permissions.Readable = true
 		wf1.Permissions.Writable = true
@@ -500,6 +501,89 @@ func (api *API) putWorkflowHandler() service.Handler {
 	}
 }
 
+// patchWorkflowGraphHandler applies incremental edits on the graph of a workflow. Only the nodes changed by the
+// operations are validated, with dryRun=true the patched workflow is returned without being saved.
+func (api *API) patchWorkflowGraphHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		dryRun := FormBool(r, "dryRun")
+
+		var patch sdk.WorkflowGraphPatch
+		if err := service.UnmarshalBody(r, &patch); err != nil {
+			return sdk.WrapError(err, "cannot read body")
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithPipelines,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithIntegrations,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, p, name, workflow.LoadOptions{WithIcon: true, WithIntegrations: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+		if wf.FromRepository != "" {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		names, err := patch.Apply(wf)
+		if err != nil {
+			return err
+		}
+		if err := workflow.CheckNodes(ctx, api.Cache, api.mustDB(), wf, p, patch, names); err != nil {
+			return err
+		}
+
+		if dryRun {
+			wf.FilterHooksConfig(sdk.HookConfigProject, sdk.HookConfigWorkflow)
+			return service.WriteJSON(w, wf, http.StatusOK)
+		}
+
+		// The graph of the loaded workflow was changed, load it again to remove its old data
+		oldW, err := workflow.Load(ctx, api.mustDB(), api.Cache, p, name, workflow.LoadOptions{WithIcon: true, WithIntegrations: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s", name)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		if err := workflow.Update(ctx, tx, api.Cache, wf, p, workflow.UpdateOptions{OldWorkflow: oldW}); err != nil {
+			return sdk.WrapError(err, "cannot update workflow")
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "cannot commit transaction")
+		}
+
+		wf1, err := workflow.LoadByID(ctx, api.mustDB(), api.Cache, p, wf.ID, workflow.LoadOptions{WithIntegrations: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow")
+		}
+
+		event.PublishWorkflowUpdate(ctx, p.Key, *wf1, *oldW, getAPIConsumer(ctx))
+		setAuditLogData(ctx, oldW, wf1)
+
+		wf1.Permissions.Readable = true
+		wf1.Permissions.Writable = true
+		wf1.Permissions.Executable = true
+
+		//We filter project and workflow configuration key, because they are always set on insertHooks
+		wf1.FilterHooksConfig(sdk.HookConfigProject, sdk.HookConfigWorkflow)
+		return service.WriteJSON(w, wf1, http.StatusOK)
+	}
+}
+
 // putWorkflowIconHandler updates a workflow
 func (api *API) putWorkflowIconHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
@@ -704,6 +788,46 @@ func (api *API) getWorkflowHookHandler() service.Handler {
 	}
 }
 
+func (api *API) getWorkflowHookMissedSchedulesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		uuid := vars["uuid"]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s/%s", key, name)
+		}
+
+		h, has := wf.WorkflowData.GetHooks()[uuid]
+		if !has {
+			return sdk.WrapError(sdk.ErrNotFound, "cannot load workflow %s/%s hook %s", key, name, uuid)
+		}
+		if h.HookModelName != sdk.SchedulerModelName {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "hook %s is not a scheduler", uuid)
+		}
+
+		srvs, err := services.LoadAllByType(ctx, api.mustDB(), services.TypeHooks)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load hooks services")
+		}
+
+		path := fmt.Sprintf("/task/%s/missed", uuid)
+		var ms []sdk.MissedSchedule
+		if _, _, err := services.NewClient(api.mustDB(), srvs).DoJSONRequest(ctx, "GET", path, nil, &ms); err != nil {
+			return sdk.WrapError(err, "unable to get missed schedules of hook %s", uuid)
+		}
+
+		return service.WriteJSON(w, ms, http.StatusOK)
+	}
+}
+
 func (api *API) getWorkflowNotificationsConditionsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
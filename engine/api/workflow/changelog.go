@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// ComputeChangelog computes and saves the list of the commits between the commit of the last successful run of a
+// node and the commit of a node run. It returns nil if the node run is not linked to a repository or if its
+// commit is not known yet. If the node never succeeded, the changelog lists the commits of the branch.
+func ComputeChangelog(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wf *sdk.Workflow, nr *sdk.WorkflowNodeRun, app *sdk.Application, cur sdk.BuildNumberAndHash) (*sdk.WorkflowRunChangelog, error) {
+	if app == nil || app.VCSServer == "" || cur.Hash == "" {
+		return nil, nil
+	}
+	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
+	if vcsServer == nil {
+		return nil, nil
+	}
+
+	repo := cur.Remote
+	if repo == "" {
+		repo = app.RepositoryFullname
+	}
+	prev, err := loadLastDeployedNodeRunVCSInfos(db, wf.ID, nr.WorkflowNodeName, repo, nr.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &sdk.WorkflowRunChangelog{
+		WorkflowRunID:     nr.WorkflowRunID,
+		WorkflowNodeRunID: nr.ID,
+		NodeName:          nr.WorkflowNodeName,
+		Repository:        repo,
+		Branch:            cur.Branch,
+		FromNumber:        prev.BuildNumber,
+		FromHash:          prev.Hash,
+		ToHash:            cur.Hash,
+		Commits:           sdk.ChangelogCommits{},
+	}
+
+	if prev.Hash != cur.Hash && (prev.Hash != "" || cur.Branch != "") {
+		client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot get vcs client")
+		}
+		var commits []sdk.VCSCommit
+		if cur.Branch != "" {
+			commits, err = client.Commits(ctx, repo, cur.Branch, prev.Hash, cur.Hash)
+		} else {
+			commits, err = client.CommitsBetweenRefs(ctx, repo, prev.Hash, cur.Hash)
+		}
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot get commits between %s and %s on %s", prev.Hash, cur.Hash, repo)
+		}
+		if len(commits) > sdk.WorkflowRunChangelogMaxCommits {
+			commits = commits[:sdk.WorkflowRunChangelogMaxCommits]
+			c.Truncated = true
+		}
+		c.Commits = commits
+	}
+
+	if err := InsertChangelog(db, c); err != nil {
+		return nil, err
+	}
+	log.Debug("ComputeChangelog> %d commits between %s and %s for node run %d", len(c.Commits), prev.Hash, cur.Hash, nr.ID)
+	return c, nil
+}
@@ -0,0 +1,174 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// A node run of a concurrency group is pending while it is at status Waiting without any job run,
+// it becomes active once its jobs are added to the queue.
+const concurrencyGroupNodeRuns = `
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_run.project_id = $1
+	and workflow_node_run.concurrency_group = $2`
+
+const concurrencyGroupPending = `
+	workflow_node_run.status = 'Waiting'
+	and not exists (select 1 from workflow_node_run_job where workflow_node_run_job.workflow_node_run_id = workflow_node_run.id)`
+
+// checkConcurrencyGroup returns true if the node run can't be executed because another run of its concurrency group
+// is in progress. With the cancel-superseded policy, the older pending runs of the group are stopped first.
+func checkConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Project, wr *sdk.WorkflowRun, n *sdk.Node, nr *sdk.WorkflowNodeRun) (*ProcessorReport, bool, error) {
+	ctx, end := observability.Span(ctx, "workflow.checkConcurrencyGroup")
+	defer end()
+
+	report := new(ProcessorReport)
+
+	if n.Context.ConcurrencyPolicy == sdk.ConcurrencyPolicyCancelSuperseded {
+		r, err := cancelSupersededNodeRuns(ctx, db, proj, nr)
+		if err != nil {
+			return nil, false, err
+		}
+		_, _ = report.Merge(ctx, r, nil)
+	}
+
+	// Same rule as the mutex: a previous run waiting in the group or any other run building in the group locks the node run
+	query := `select count(1)` + concurrencyGroupNodeRuns + `
+	and (
+		(workflow_node_run.id < $3 and workflow_node_run.status = $4)
+		or
+		(workflow_node_run.id <> $3 and workflow_node_run.status = $5)
+	)`
+	nb, err := db.SelectInt(query, proj.ID, nr.ConcurrencyGroup, nr.ID, string(sdk.StatusWaiting), string(sdk.StatusBuilding))
+	if err != nil {
+		return nil, false, sdk.WrapError(err, "unable to check concurrency group %s", nr.ConcurrencyGroup)
+	}
+	if nb == 0 {
+		return report, false, nil
+	}
+
+	log.Debug("Noderun %s processed but not executed because of concurrency group %s", n.Name, nr.ConcurrencyGroup)
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeConcurrencyGroup.ID,
+		Args: []interface{}{n.Name, nr.ConcurrencyGroup},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, false, sdk.WrapError(err, "unable to update workflow run")
+	}
+	return report, true, nil
+}
+
+// cancelSupersededNodeRuns stops the pending node runs of the concurrency group that are older than the given one.
+func cancelSupersededNodeRuns(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Project, nr *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+
+	var ids []int64
+	query := `select workflow_node_run.id` + concurrencyGroupNodeRuns + `
+	and workflow_node_run.id < $3
+	and ` + concurrencyGroupPending + `
+	order by workflow_node_run.id`
+	if _, err := db.Select(&ids, query, proj.ID, nr.ConcurrencyGroup, nr.ID); err != nil {
+		return nil, sdk.WrapError(err, "unable to load superseded node runs of concurrency group %s", nr.ConcurrencyGroup)
+	}
+
+	for _, id := range ids {
+		supersededRun, err := LoadNodeRunByID(db, id, LoadRunOptions{})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load node run %d", id)
+		}
+		supersededRun.Status = sdk.StatusStopped
+		supersededRun.Done = time.Now()
+		stopWorkflowNodeRunStages(ctx, db, supersededRun)
+		if err := UpdateNodeRun(db, supersededRun); err != nil {
+			return nil, sdk.WrapError(err, "unable to stop node run %d", id)
+		}
+		report.Add(ctx, *supersededRun)
+
+		supersededWorkflowRun, err := LoadRunByID(db, supersededRun.WorkflowRunID, LoadRunOptions{})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load workflow run %d", supersededRun.WorkflowRunID)
+		}
+		AddWorkflowRunInfo(supersededWorkflowRun, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeConcurrencySuperseded.ID,
+			Args: []interface{}{supersededRun.WorkflowNodeName, nr.ConcurrencyGroup},
+		})
+		if err := UpdateWorkflowRun(ctx, db, supersededWorkflowRun); err != nil {
+			return nil, sdk.WrapError(err, "unable to update workflow run %d", supersededWorkflowRun.ID)
+		}
+		r, err := computeAndUpdateWorkflowRunStatus(ctx, db, supersededWorkflowRun)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = report.Merge(ctx, r, nil)
+		report.Add(ctx, *supersededWorkflowRun)
+	}
+
+	return report, nil
+}
+
+// releaseConcurrencyGroup executes the oldest pending node run of the concurrency group
+// if no other run of the group is in progress.
+func releaseConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, group string) (*ProcessorReport, error) {
+	ctx, end := observability.Span(ctx, "workflow.releaseConcurrencyGroup")
+	defer end()
+
+	report := new(ProcessorReport)
+
+	query := `select count(1)` + concurrencyGroupNodeRuns + `
+	and (
+		workflow_node_run.status = 'Building'
+		or (workflow_node_run.status = 'Waiting' and exists (select 1 from workflow_node_run_job where workflow_node_run_job.workflow_node_run_id = workflow_node_run.id))
+	)`
+	nb, err := db.SelectInt(query, proj.ID, group)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to check concurrency group %s", group)
+	}
+	if nb > 0 {
+		return report, nil
+	}
+
+	query = `select workflow_node_run.id` + concurrencyGroupNodeRuns + `
+	and ` + concurrencyGroupPending + `
+	order by workflow_node_run.id
+	limit 1`
+	waitingRunID, err := db.SelectInt(query, proj.ID, group)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, sdk.WrapError(err, "unable to load waiting node run of concurrency group %s", group)
+	}
+	if waitingRunID == 0 {
+		return report, nil
+	}
+
+	waitingRun, err := LoadNodeRunByID(db, waitingRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load node run %d", waitingRunID)
+	}
+	workflowRun, err := LoadRunByID(db, waitingRun.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run %d", waitingRun.WorkflowRunID)
+	}
+	AddWorkflowRunInfo(workflowRun, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeMutexRelease.ID,
+		Args: []interface{}{waitingRun.WorkflowNodeName},
+	})
+	if err := UpdateWorkflowRun(ctx, db, workflowRun); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d after concurrency group release", workflowRun.ID)
+	}
+
+	log.Debug("workflow.execute> process the node run %d because concurrency group %s has been released", waitingRun.ID, group)
+	r, err := executeNodeRun(ctx, db, store, proj, waitingRun)
+	report, err = report.Merge(ctx, r, err)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to execute node run %d", waitingRun.ID)
+	}
+	return report, nil
+}
@@ -7,12 +7,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-gorp/gorp"
 
+	"github.com/ovh/cds/engine/api/action"
 	"github.com/ovh/cds/engine/api/application"
 	"github.com/ovh/cds/engine/api/ascode"
 	"github.com/ovh/cds/engine/api/cache"
@@ -24,7 +26,9 @@ import (
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/pipeline"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/luascript"
 )
 
 func getAll(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) (sdk.Workflows, error) {
@@ -341,6 +345,9 @@ func Load(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk
 		workflow.root_node_id,
 		workflow.metadata,
 		workflow.history_length,
+		workflow.cancel_in_progress,
+		workflow.deployment,
+		workflow.pinned_versions,
 		workflow.purge_tags,
 		workflow.from_repository,
 		workflow.derived_from_workflow_id,
@@ -622,7 +629,7 @@ func Insert(ctx context.Context, db gorp.SqlExecutor, store cache.Store, w *sdk.
 	}
 
 	w.LastModified = time.Now()
-	if err := db.QueryRow("INSERT INTO workflow (name, description, icon, project_id, history_length, from_repository) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id", w.Name, w.Description, w.Icon, w.ProjectID, w.HistoryLength, w.FromRepository).Scan(&w.ID); err != nil {
+	if err := db.QueryRow("INSERT INTO workflow (name, description, icon, project_id, history_length, from_repository, cancel_in_progress, deployment, pinned_versions) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id", w.Name, w.Description, w.Icon, w.ProjectID, w.HistoryLength, w.FromRepository, w.CancelInProgress, w.Deployment, w.PinnedVersions).Scan(&w.ID); err != nil {
 		return sdk.WrapError(err, "Unable to insert workflow %s/%s", w.ProjectKey, w.Name)
 	}
 
@@ -732,7 +739,9 @@ func RenameNode(ctx context.Context, db gorp.SqlExecutor, w *sdk.Workflow) error
 	var maxJoinNumber int
 	maxNumberByPipeline := map[int64]int{}
 	maxNumberByHookModel := map[int64]int{}
+	maxNumberBySubWorkflow := map[string]int{}
 	var maxForkNumber int
+	var maxGateNumber int
 
 	nodesToNamed := []*sdk.Node{}
 	// Search max numbers by nodes type
@@ -820,6 +829,22 @@ func RenameNode(ctx context.Context, db gorp.SqlExecutor, w *sdk.Workflow) error
 					maxForkNumber = forkNumber
 				}
 			}
+		case sdk.NodeTypeGate:
+			if nodes[i].Name == sdk.NodeTypeGate || strings.HasPrefix(nodes[i].Name, sdk.NodeTypeGate+"_") {
+				var gateNumber int
+				if nodes[i].Name == sdk.NodeTypeGate {
+					gateNumber = 1
+				} else {
+					// Retrieve Number
+					current, errI := strconv.Atoi(strings.Replace(nodes[i].Name, sdk.NodeTypeGate+"_", "", 1))
+					if errI == nil {
+						gateNumber = current
+					}
+				}
+				if maxGateNumber < gateNumber {
+					maxGateNumber = gateNumber
+				}
+			}
 		case sdk.NodeTypeOutGoingHook:
 			model := w.OutGoingHookModels[nodes[i].OutGoingHookContext.HookModelID]
 			// Check if node is named pipName_12
@@ -839,6 +864,26 @@ func RenameNode(ctx context.Context, db gorp.SqlExecutor, w *sdk.Workflow) error
 					maxNumberByHookModel[model.ID] = hookNumber
 				}
 			}
+		case sdk.NodeTypeSubWorkflow:
+			if nodes[i].SubWorkflowContext == nil {
+				break
+			}
+			wfName := nodes[i].SubWorkflowContext.WorkflowName
+			if nodes[i].Name == wfName || strings.HasPrefix(nodes[i].Name, wfName+"_") {
+				var wfNumber int
+				if nodes[i].Name == wfName {
+					wfNumber = 1
+				} else {
+					// Retrieve Number
+					current, errI := strconv.Atoi(strings.Replace(nodes[i].Name, wfName+"_", "", 1))
+					if errI == nil {
+						wfNumber = current
+					}
+				}
+				if maxNumberBySubWorkflow[wfName] < wfNumber {
+					maxNumberBySubWorkflow[wfName] = wfNumber
+				}
+			}
 		}
 
 		if nodes[i].Ref == "" {
@@ -874,6 +919,14 @@ func RenameNode(ctx context.Context, db gorp.SqlExecutor, w *sdk.Workflow) error
 				nodesToNamed[i].Name = sdk.NodeTypeFork
 			}
 			maxForkNumber++
+		case sdk.NodeTypeGate:
+			nextNumber := maxGateNumber + 1
+			if nextNumber > 1 {
+				nodesToNamed[i].Name = fmt.Sprintf("%s_%d", sdk.NodeTypeGate, nextNumber)
+			} else {
+				nodesToNamed[i].Name = sdk.NodeTypeGate
+			}
+			maxGateNumber++
 		case sdk.NodeTypeOutGoingHook:
 			hookModelID := nodesToNamed[i].OutGoingHookContext.HookModelID
 			nextNumber := maxNumberByHookModel[hookModelID] + 1
@@ -883,6 +936,18 @@ func RenameNode(ctx context.Context, db gorp.SqlExecutor, w *sdk.Workflow) error
 				nodesToNamed[i].Name = w.OutGoingHookModels[hookModelID].Name
 			}
 			maxNumberByHookModel[hookModelID] = nextNumber
+		case sdk.NodeTypeSubWorkflow:
+			if nodesToNamed[i].SubWorkflowContext == nil {
+				break
+			}
+			wfName := nodesToNamed[i].SubWorkflowContext.WorkflowName
+			nextNumber := maxNumberBySubWorkflow[wfName] + 1
+			if nextNumber > 1 {
+				nodesToNamed[i].Name = fmt.Sprintf("%s_%d", wfName, nextNumber)
+			} else {
+				nodesToNamed[i].Name = wfName
+			}
+			maxNumberBySubWorkflow[wfName] = nextNumber
 		}
 		if nodesToNamed[i].Ref == "" {
 			nodesToNamed[i].Ref = nodesToNamed[i].Name
@@ -1055,11 +1120,18 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 		return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Invalid workflow name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := w.PinnedVersions.IsValid(); err != nil {
+		return err
+	}
+
 	//Check refs
 	for _, j := range w.WorkflowData.Joins {
 		if len(j.JoinContext) == 0 {
 			return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Source node references is mandatory"))
 		}
+		if err := j.IsValidJoinPolicy(); err != nil {
+			return err
+		}
 	}
 
 	if w.Pipelines == nil {
@@ -1101,40 +1173,121 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 
 	nodesArray := w.WorkflowData.Array()
 	for i := range nodesArray {
-		n := nodesArray[i]
-		if n.Context == nil {
-			continue
-		}
-
-		if err := checkPipeline(ctx, db, proj, w, n, opts); err != nil {
+		if err := checkNode(ctx, store, db, proj, w, nodesArray[i], opts); err != nil {
 			return err
 		}
-		if err := checkApplication(store, db, proj, w, n); err != nil {
-			return err
-		}
-		if err := checkEnvironment(db, proj, w, n); err != nil {
-			return err
+	}
+
+	return nil
+}
+
+// CheckNodes checks the validity of the nodes with given names only, it is used to validate the
+// nodes changed by a graph patch without checking the whole workflow.
+// Errors are returned for the operation that changed the node.
+func CheckNodes(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk.Workflow, proj *sdk.Project, patch sdk.WorkflowGraphPatch, names []string) error {
+	if w.Pipelines == nil {
+		w.Pipelines = make(map[int64]sdk.Pipeline)
+	}
+	if w.Applications == nil {
+		w.Applications = make(map[int64]sdk.Application)
+	}
+	if w.Environments == nil {
+		w.Environments = make(map[int64]sdk.Environment)
+	}
+	if w.ProjectIntegrations == nil {
+		w.ProjectIntegrations = make(map[int64]sdk.ProjectIntegration)
+	}
+	if w.HookModels == nil {
+		w.HookModels = make(map[int64]sdk.WorkflowHookModel)
+	}
+	if w.OutGoingHookModels == nil {
+		w.OutGoingHookModels = make(map[int64]sdk.WorkflowHookModel)
+	}
+
+	w.AssignEmptyType()
+	for i, name := range names {
+		op := patch.Operations[i]
+		n := w.WorkflowData.NodeByName(name)
+		if n == nil {
+			return sdk.WorkflowGraphOperationError(i, op, "node %q not found", name)
 		}
-		if err := checkProjectIntegration(proj, w, n); err != nil {
-			return err
+		if !n.HasValidType() {
+			return sdk.WorkflowGraphOperationError(i, op, "wrong type %q for node %s", n.Type, name)
 		}
-		if err := checkEventIntegration(proj, w); err != nil {
-			return err
+		if err := checkNode(ctx, store, db, proj, w, n, LoadOptions{}); err != nil {
+			return sdk.WorkflowGraphOperationError(i, op, "node %s: %s", name, sdk.ExtractHTTPError(err, "").Message)
 		}
-		if err := checkHooks(db, w, n); err != nil {
-			return err
+		if n.Context != nil && n.Context.Conditions.LuaScript != "" {
+			if err := luascript.Compile(n.Context.Conditions.LuaScript); err != nil {
+				return sdk.WorkflowGraphOperationError(i, op, "node %s: invalid lua script: %v", name, err)
+			}
 		}
-		if err := checkOutGoingHook(db, w, n); err != nil {
+	}
+	return nil
+}
+
+func checkNode(ctx context.Context, store cache.Store, db gorp.SqlExecutor, proj *sdk.Project, w *sdk.Workflow, n *sdk.Node, opts LoadOptions) error {
+	if err := checkSubWorkflow(db, proj, w, n); err != nil {
+		return err
+	}
+	if n.Type == sdk.NodeTypeGate && n.GateContext != nil {
+		if err := n.GateContext.IsValid(); err != nil {
 			return err
 		}
+	}
+	if err := n.CheckVerification(w.WorkflowData); err != nil {
+		return err
+	}
 
-		if n.Context.ApplicationID != 0 && n.Context.ProjectIntegrationID != 0 {
-			if err := n.CheckApplicationDeploymentStrategies(proj, w); err != nil {
-				return sdk.NewError(sdk.ErrWorkflowInvalid, err)
-			}
+	if n.Context == nil {
+		return nil
+	}
+
+	if err := checkPipeline(ctx, db, proj, w, n, opts); err != nil {
+		return err
+	}
+	if err := checkApplication(store, db, proj, w, n); err != nil {
+		return err
+	}
+	if err := checkEnvironment(db, proj, w, n); err != nil {
+		return err
+	}
+	if err := checkProjectIntegration(proj, w, n); err != nil {
+		return err
+	}
+	if err := checkEventIntegration(proj, w); err != nil {
+		return err
+	}
+	if err := checkHooks(db, w, n); err != nil {
+		return err
+	}
+	if err := checkOutGoingHook(db, w, n); err != nil {
+		return err
+	}
+
+	if n.Context.ApplicationID != 0 && n.Context.ProjectIntegrationID != 0 {
+		if err := n.CheckApplicationDeploymentStrategies(proj, w); err != nil {
+			return sdk.NewError(sdk.ErrWorkflowInvalid, err)
 		}
 	}
+	return nil
+}
 
+// checkSubWorkflow checks that the workflow called by a sub-workflow node exists in the project.
+func checkSubWorkflow(db gorp.SqlExecutor, proj *sdk.Project, w *sdk.Workflow, n *sdk.Node) error {
+	if n.Type != sdk.NodeTypeSubWorkflow || n.SubWorkflowContext == nil {
+		return nil
+	}
+	if err := n.SubWorkflowContext.IsValid(w); err != nil {
+		return err
+	}
+	exist, err := Exists(db, proj.Key, n.SubWorkflowContext.WorkflowName)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return sdk.NewErrorFrom(sdk.ErrWorkflowInvalid, "workflow %s called by node %s not found in project %s", n.SubWorkflowContext.WorkflowName, n.Name, proj.Key)
+	}
 	return nil
 }
 
@@ -1216,6 +1369,31 @@ func checkHooks(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
 				}
 			}
 		}
+
+		if p := h.Config[sdk.HookConfigPriority].Value; p != "" {
+			i, err := strconv.Atoi(p)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given priority '%s' for hook", p)
+			}
+			if err := sdk.IsValidWorkflowRunPriority(i); err != nil {
+				return err
+			}
+		}
+
+		switch model.Name {
+		case sdk.GitPollerModelName:
+			if err := sdk.CheckGitPollerConfig(h.Config); err != nil {
+				return err
+			}
+		case sdk.RepositoryWebHookModelName:
+			if err := sdk.CheckRepositoryWebHookConfig(h.Config); err != nil {
+				return err
+			}
+		case sdk.PullRequestHookModelName:
+			if err := sdk.CheckPullRequestHookConfig(h.Config); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -1259,10 +1437,13 @@ func checkProjectIntegration(proj *sdk.Project, w *sdk.Workflow, n *sdk.Node) er
 
 // checkEventIntegration checks event integration data
 func checkEventIntegration(proj *sdk.Project, w *sdk.Workflow) error {
-	for _, eventIntegration := range w.EventIntegrations {
+	for i := range w.EventIntegrations {
+		eventIntegration := &w.EventIntegrations[i]
 		found := false
 		for _, projInt := range proj.Integrations {
-			if eventIntegration.ID == projInt.ID {
+			// Imported workflows give the event integrations by name
+			if eventIntegration.ID == projInt.ID || (eventIntegration.ID == 0 && eventIntegration.Name == projInt.Name && projInt.Model.Event) {
+				*eventIntegration = projInt
 				found = true
 				break
 			}
@@ -1414,51 +1595,15 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 	}
 	defer tx.Rollback() // nolint
 
-	for filename, app := range data.apps {
-		log.Debug("Push> Parsing %s", filename)
-		var fromRepo string
-		if opts != nil {
-			fromRepo = opts.FromRepository
-		}
-		appDB, msgList, err := application.ParseAndImport(ctx, tx, store, proj, &app, application.ImportOptions{Force: true, FromRepository: fromRepo}, decryptFunc, u)
-		if err != nil {
-			return nil, nil, nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import application %s/%s", proj.Key, app.Name)
-		}
-		allMsg = append(allMsg, msgList...)
-		proj.SetApplication(*appDB)
-		log.Debug("Push> -- %s OK", filename)
-	}
-
-	for filename, env := range data.envs {
-		log.Debug("Push> Parsing %s", filename)
-		var fromRepo string
-		if opts != nil {
-			fromRepo = opts.FromRepository
-		}
-		envDB, msgList, err := environment.ParseAndImport(tx, proj, &env, environment.ImportOptions{Force: true, FromRepository: fromRepo}, decryptFunc, u)
-		if err != nil {
-			return nil, nil, nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import environment %s/%s", proj.Key, env.Name)
-		}
-		allMsg = append(allMsg, msgList...)
-		proj.SetEnvironment(*envDB)
-		log.Debug("Push> -- %s OK", filename)
+	var fromRepo string
+	if opts != nil {
+		fromRepo = opts.FromRepository
 	}
-
-	for filename, pip := range data.pips {
-		log.Debug("Push> Parsing %s", filename)
-		var fromRepo string
-		if opts != nil {
-			fromRepo = opts.FromRepository
-		}
-		pipDB, msgList, err := pipeline.ParseAndImport(ctx, tx, store, proj, &pip, u, pipeline.ImportOptions{Force: true, FromRepository: fromRepo})
-		if err != nil {
-			return nil, nil, nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import pipeline %s/%s", proj.Key, pip.Name)
-
-		}
-		allMsg = append(allMsg, msgList...)
-		proj.SetPipeline(*pipDB)
-		log.Debug("Push> -- %s OK", filename)
+	msgList, err := importDependencies(ctx, tx, store, proj, data, fromRepo, u, decryptFunc)
+	if err != nil {
+		return nil, nil, nil, err
 	}
+	allMsg = append(allMsg, msgList...)
 
 	isDefaultBranch := true
 	if opts != nil {
@@ -1519,6 +1664,183 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 	return allMsg, wf, oldWf, nil
 }
 
+// importDependencies imports the applications, the environments and the pipelines pushed with workflows.
+func importDependencies(ctx context.Context, tx gorp.SqlExecutor, store cache.Store, proj *sdk.Project, data *exportedEntities, fromRepo string, u sdk.Identifiable, decryptFunc keys.DecryptFunc) ([]sdk.Message, error) {
+	allMsg := []sdk.Message{}
+
+	for filename, app := range data.apps {
+		log.Debug("Push> Parsing %s", filename)
+		appDB, msgList, err := application.ParseAndImport(ctx, tx, store, proj, &app, application.ImportOptions{Force: true, FromRepository: fromRepo}, decryptFunc, u)
+		if err != nil {
+			return nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import application %s/%s", proj.Key, app.Name)
+		}
+		allMsg = append(allMsg, msgList...)
+		proj.SetApplication(*appDB)
+		log.Debug("Push> -- %s OK", filename)
+	}
+
+	for filename, env := range data.envs {
+		log.Debug("Push> Parsing %s", filename)
+		envDB, msgList, err := environment.ParseAndImport(tx, proj, &env, environment.ImportOptions{Force: true, FromRepository: fromRepo}, decryptFunc, u)
+		if err != nil {
+			return nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import environment %s/%s", proj.Key, env.Name)
+		}
+		allMsg = append(allMsg, msgList...)
+		proj.SetEnvironment(*envDB)
+		log.Debug("Push> -- %s OK", filename)
+	}
+
+	if err := importActions(ctx, tx, proj, data.actions); err != nil {
+		return nil, err
+	}
+
+	for filename, pip := range data.pips {
+		log.Debug("Push> Parsing %s", filename)
+		pipDB, msgList, err := pipeline.ParseAndImport(ctx, tx, store, proj, &pip, u, pipeline.ImportOptions{Force: true, FromRepository: fromRepo})
+		if err != nil {
+			return nil, sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import pipeline %s/%s", proj.Key, pip.Name)
+		}
+		allMsg = append(allMsg, msgList...)
+		proj.SetPipeline(*pipDB)
+		log.Debug("Push> -- %s OK", filename)
+	}
+
+	return allMsg, nil
+}
+
+// importActions imports the actions pushed with workflows as actions of the project, the actions used by the
+// steps of an action are imported before it.
+func importActions(ctx context.Context, tx gorp.SqlExecutor, proj *sdk.Project, actions map[string]exportentities.Action) error {
+	groupIDs := make([]int64, 0, len(proj.ProjectGroups)+1)
+	groupIDs = append(groupIDs, group.SharedInfraGroup.ID)
+	for i := range proj.ProjectGroups {
+		groupIDs = append(groupIDs, proj.ProjectGroups[i].Group.ID)
+	}
+
+	byName := make(map[string]exportentities.Action, len(actions))
+	for _, ea := range actions {
+		byName[strings.ToLower(ea.Name)] = ea
+	}
+
+	imported := make(map[string]bool, len(byName))
+	var importAction func(name string, stack []string) error
+	importAction = func(name string, stack []string) error {
+		if imported[name] {
+			return nil
+		}
+		if sdk.IsInArray(name, stack) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "action loop usage detected for action %s", byName[name].Name)
+		}
+		ea := byName[name]
+		for _, s := range ea.Steps {
+			for child := range s.StepCustom {
+				if _, ok := byName[strings.ToLower(child)]; ok {
+					if err := importAction(strings.ToLower(child), append(stack, name)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		log.Debug("Push> Importing action %s", ea.Name)
+		if _, err := action.ImportForProject(ctx, tx, proj.ID, groupIDs, ea); err != nil {
+			return sdk.ErrorWithFallback(err, sdk.ErrWrongRequest, "unable to import action %s/%s", proj.Key, ea.Name)
+		}
+		imported[name] = true
+		return nil
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := importAction(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PushProject pushes the workflows of a project and their dependencies from cds files in one transaction,
+// nothing is imported if one of the files can't be. It returns the imported workflows and the previous
+// version of each of them, nil for a new workflow.
+func PushProject(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Project, tr *tar.Reader, u sdk.Identifiable, decryptFunc keys.DecryptFunc) ([]sdk.Message, []sdk.Workflow, []*sdk.Workflow, error) {
+	ctx, end := observability.Span(ctx, "workflow.PushProject")
+	defer end()
+
+	data, err := extractFromCDSFiles(ctx, tr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(data.wrkflws)+len(data.apps)+len(data.pips)+len(data.envs)+len(data.actions) == 0 {
+		return nil, nil, nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "no file to push")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, nil, nil, sdk.WrapError(err, "unable to start tx")
+	}
+	defer tx.Rollback() // nolint
+
+	allMsg, err := importDependencies(ctx, tx, store, proj, data, "", u, decryptFunc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	filenames := make([]string, 0, len(data.wrkflws))
+	for filename := range data.wrkflws {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	wfs := make([]sdk.Workflow, 0, len(filenames))
+	oldWfs := make([]*sdk.Workflow, 0, len(filenames))
+	for _, filename := range filenames {
+		ew := data.wrkflws[filename]
+		log.Debug("PushProject> Parsing %s", filename)
+
+		var oldWf *sdk.Workflow
+		exists, err := Exists(tx, proj.Key, ew.Name)
+		if err != nil {
+			return nil, nil, nil, sdk.WrapError(err, "cannot check if workflow %s exists", ew.Name)
+		}
+		if exists {
+			oldWf, err = Load(ctx, tx, store, proj, ew.Name, LoadOptions{WithIcon: true})
+			if err != nil {
+				return nil, nil, nil, sdk.WrapError(err, "unable to load existing workflow %s", ew.Name)
+			}
+			if oldWf.FromRepository != "" {
+				return nil, nil, nil, sdk.NewErrorFrom(sdk.ErrWorkflowAlreadyAsCode, "workflow %s is managed from repository %s", ew.Name, oldWf.FromRepository)
+			}
+		}
+
+		wf, msgList, err := ParseAndImport(ctx, tx, store, proj, oldWf, &ew, u, ImportOptions{Force: true})
+		allMsg = append(allMsg, msgList...)
+		if err != nil {
+			return allMsg, nil, nil, sdk.WrapError(err, "unable to import workflow %s", ew.Name)
+		}
+
+		if wf.WorkflowData.Node.Context.ApplicationID != 0 {
+			app := wf.Applications[wf.WorkflowData.Node.Context.ApplicationID]
+			if err := application.Update(tx, store, &app); err != nil {
+				return nil, nil, nil, sdk.WrapError(err, "unable to update application vcs datas")
+			}
+			wf.Applications[wf.WorkflowData.Node.Context.ApplicationID] = app
+		}
+
+		wfs = append(wfs, *wf)
+		oldWfs = append(oldWfs, oldWf)
+		log.Debug("PushProject> -- %s OK", filename)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, nil, sdk.WrapError(err, "cannot commit transaction")
+	}
+
+	return allMsg, wfs, oldWfs, nil
+}
+
 // UpdateFavorite add or delete workflow from user favorites
 func UpdateFavorite(db gorp.SqlExecutor, workflowID int64, u string, add bool) error {
 	var query string
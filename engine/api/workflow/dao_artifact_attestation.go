@@ -0,0 +1,35 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertArtifactAttestation saves the signed provenance attestation of an artifact.
+func InsertArtifactAttestation(db gorp.SqlExecutor, a *sdk.WorkflowArtifactAttestation) error {
+	dbA := dbArtifactAttestation(*a)
+	if err := gorpmapping.Insert(db, &dbA); err != nil {
+		return sdk.WrapError(err, "cannot insert artifact attestation")
+	}
+	*a = sdk.WorkflowArtifactAttestation(dbA)
+	return nil
+}
+
+// LoadArtifactAttestation returns the last provenance attestation of an artifact.
+func LoadArtifactAttestation(ctx context.Context, db gorp.SqlExecutor, artifactID int64) (*sdk.WorkflowArtifactAttestation, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_node_run_artifact_attestation WHERE workflow_node_run_artifact_id = $1 ORDER BY created DESC, id DESC LIMIT 1").Args(artifactID)
+	var dbA dbArtifactAttestation
+	found, err := gorpmapping.Get(ctx, db, query, &dbA)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load attestation of artifact %d", artifactID)
+	}
+	if !found {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "artifact %d has no attestation", artifactID)
+	}
+	a := sdk.WorkflowArtifactAttestation(dbA)
+	return &a, nil
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertArtifactPromotion saves an artifact promoted to a workflow.
+func InsertArtifactPromotion(db gorp.SqlExecutor, p *sdk.WorkflowArtifactPromotion) error {
+	dbP := dbArtifactPromotion(*p)
+	if err := gorpmapping.Insert(db, &dbP); err != nil {
+		return sdk.WrapError(err, "cannot insert artifact promotion")
+	}
+	*p = sdk.WorkflowArtifactPromotion(dbP)
+	return nil
+}
+
+// DeleteArtifactPromotion removes an artifact promoted to a workflow.
+func DeleteArtifactPromotion(db gorp.SqlExecutor, p *sdk.WorkflowArtifactPromotion) error {
+	dbP := dbArtifactPromotion(*p)
+	return sdk.WrapError(gorpmapping.Delete(db, &dbP), "cannot delete artifact promotion %d", p.ID)
+}
+
+func getArtifactPromotions(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.WorkflowArtifactPromotion, error) {
+	var dbPs []dbArtifactPromotion
+	if err := gorpmapping.GetAll(ctx, db, query, &dbPs); err != nil {
+		return nil, sdk.WrapError(err, "cannot load artifact promotions")
+	}
+	ps := make([]sdk.WorkflowArtifactPromotion, len(dbPs))
+	for i := range dbPs {
+		ps[i] = sdk.WorkflowArtifactPromotion(dbPs[i])
+	}
+	return ps, nil
+}
+
+// LoadArtifactPromotions returns the artifacts promoted to a workflow, the last promoted first.
+func LoadArtifactPromotions(ctx context.Context, db gorp.SqlExecutor, workflowID int64) ([]sdk.WorkflowArtifactPromotion, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_artifact_promotion WHERE workflow_id = $1 ORDER BY created DESC, id DESC").Args(workflowID)
+	return getArtifactPromotions(ctx, db, query)
+}
+
+// LoadArtifactPromotionByID returns an artifact promoted to a workflow.
+func LoadArtifactPromotionByID(ctx context.Context, db gorp.SqlExecutor, workflowID, id int64) (*sdk.WorkflowArtifactPromotion, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_artifact_promotion WHERE workflow_id = $1 AND id = $2").Args(workflowID, id)
+	var dbP dbArtifactPromotion
+	found, err := gorpmapping.Get(ctx, db, query, &dbP)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load artifact promotion %d", id)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	p := sdk.WorkflowArtifactPromotion(dbP)
+	return &p, nil
+}
+
+// LoadReferencedArtifactIDs returns the ids of the artifacts of workflow runs referenced by promotions,
+// the storage of these artifacts must be kept when the runs are purged.
+func LoadReferencedArtifactIDs(db gorp.SqlExecutor, workflowRunIDs []int64) ([]int64, error) {
+	var ids []int64
+	if _, err := db.Select(&ids, "SELECT DISTINCT source_artifact_id FROM workflow_artifact_promotion WHERE mode = $1 AND source_workflow_run_id = ANY($2)",
+		sdk.ArtifactPromotionModeReference, pq.Int64Array(workflowRunIDs)); err != nil {
+		return nil, sdk.WrapError(err, "cannot load referenced artifacts")
+	}
+	return ids, nil
+}
@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertChangelog saves the changelog of a node run, it replaces the previous changelog of the node run if any.
+func InsertChangelog(db gorp.SqlExecutor, c *sdk.WorkflowRunChangelog) error {
+	if _, err := db.Exec("DELETE FROM workflow_run_changelog WHERE workflow_node_run_id = $1", c.WorkflowNodeRunID); err != nil {
+		return sdk.WrapError(err, "cannot delete changelog of node run %d", c.WorkflowNodeRunID)
+	}
+	dbC := dbRunChangelog(*c)
+	if err := gorpmapping.Insert(db, &dbC); err != nil {
+		return sdk.WrapError(err, "cannot insert changelog")
+	}
+	*c = sdk.WorkflowRunChangelog(dbC)
+	return nil
+}
+
+// LoadChangelogsByRunID returns the changelogs of the node runs of a workflow run.
+func LoadChangelogsByRunID(ctx context.Context, db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowRunChangelog, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_run_changelog WHERE workflow_run_id = $1 ORDER BY id").Args(workflowRunID)
+	var dbCs []dbRunChangelog
+	if err := gorpmapping.GetAll(ctx, db, query, &dbCs); err != nil {
+		return nil, sdk.WrapError(err, "cannot load changelogs")
+	}
+	cs := make([]sdk.WorkflowRunChangelog, len(dbCs))
+	for i := range dbCs {
+		cs[i] = sdk.WorkflowRunChangelog(dbCs[i])
+	}
+	return cs, nil
+}
+
+// LoadChangelogByNodeRunID returns the changelog of a node run, or nil if it was not computed.
+func LoadChangelogByNodeRunID(ctx context.Context, db gorp.SqlExecutor, nodeRunID int64) (*sdk.WorkflowRunChangelog, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_run_changelog WHERE workflow_node_run_id = $1").Args(nodeRunID)
+	var dbC dbRunChangelog
+	found, err := gorpmapping.Get(ctx, db, query, &dbC)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load changelog of node run %d", nodeRunID)
+	}
+	if !found {
+		return nil, nil
+	}
+	c := sdk.WorkflowRunChangelog(dbC)
+	return &c, nil
+}
+
+// loadLastDeployedNodeRunVCSInfos returns the number and the commit of the last successful run of a node on a
+// repository, before the given run number. The returned value is zero if the node never succeeded.
+func loadLastDeployedNodeRunVCSInfos(db gorp.SqlExecutor, workflowID int64, nodeName, repository string, number int64) (sdk.BuildNumberAndHash, error) {
+	var res sdk.BuildNumberAndHash
+	var branch, tag sql.NullString
+	err := db.QueryRow(`
+		SELECT num, vcs_hash, vcs_branch, vcs_tag
+		FROM workflow_node_run
+		WHERE workflow_id = $1 AND workflow_node_name = $2 AND vcs_repository = $3 AND num < $4
+		AND status = $5 AND vcs_hash IS NOT NULL AND vcs_hash <> ''
+		ORDER BY num DESC, sub_num DESC
+		LIMIT 1`, workflowID, nodeName, repository, number, sdk.StatusSuccess).Scan(&res.BuildNumber, &res.Hash, &branch, &tag)
+	if err == sql.ErrNoRows {
+		return res, nil
+	}
+	if err != nil {
+		return res, sdk.WrapError(err, "cannot load last deployed run of node %s", nodeName)
+	}
+	res.Remote = repository
+	res.Branch = branch.String
+	res.Tag = tag.String
+	return res, nil
+}
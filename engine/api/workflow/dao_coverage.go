@@ -67,6 +67,26 @@ func LoadCoverageReport(db gorp.SqlExecutor, workflowNodeRunID int64) (sdk.Workf
 	return sdk.WorkflowNodeRunCoverage(cov), nil
 }
 
+// LoadCoverageTrend returns the coverage of the applications in the last runs of a workflow, the last run first.
+// If branch is not empty, only the runs on this branch are returned.
+func LoadCoverageTrend(db gorp.SqlExecutor, projectKey, workflowName, branch string, limit int) ([]sdk.WorkflowCoverageTrend, error) {
+	query := `
+    SELECT c.run_number, application.name AS application_name, c.repository, c.branch, c.percent
+    FROM workflow_node_run_coverage c
+    JOIN workflow ON workflow.id = c.workflow_id
+    JOIN project ON project.id = workflow.project_id
+    JOIN application ON application.id = c.application_id
+    WHERE project.projectkey = $1 AND workflow.name = $2 AND ($3 = '' OR c.branch = $3)
+    ORDER BY c.run_number DESC, application.name
+    LIMIT $4
+  `
+	var ts []sdk.WorkflowCoverageTrend
+	if _, err := db.Select(&ts, query, projectKey, workflowName, branch, limit); err != nil {
+		return nil, sdk.WrapError(err, "Unable to load coverage trend")
+	}
+	return ts, nil
+}
+
 // InsertCoverage insert a coverage report for a workflow run
 func InsertCoverage(db gorp.SqlExecutor, cov sdk.WorkflowNodeRunCoverage) error {
 	c := Coverage(cov)
@@ -143,6 +163,7 @@ func ComputeNewReport(ctx context.Context, db gorp.SqlExecutor, cache cache.Stor
 		Report:            report,
 		Trend:             sdk.WorkflowNodeRunCoverageTrends{},
 	}
+	covReport.Percent, _ = sdk.CoveragePercent(report)
 
 	// Get previous report
 	previousReport, errP := loadPreviousCoverageReport(db, wnr.WorkflowID, wnr.Number, wnr.VCSRepository, wnr.VCSBranch, covReport.ApplicationID)
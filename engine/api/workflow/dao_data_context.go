@@ -21,6 +21,11 @@ type sqlNodeContextData struct {
 	DefaultPipelineParameters sql.NullString `db:"default_pipeline_parameters"`
 	Conditions                sql.NullString `db:"conditions"`
 	Mutex                     bool           `db:"mutex"`
+	ConcurrencyGroup          sql.NullString `db:"concurrency_group"`
+	ConcurrencyPolicy         sql.NullString `db:"concurrency_policy"`
+	ProductionDeployment      bool           `db:"production_deployment"`
+	VerificationOf            sql.NullString `db:"verification_of"`
+	RollbackNode              sql.NullString `db:"rollback_node"`
 }
 
 func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
@@ -70,6 +75,21 @@ func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) er
 	}
 
 	tempContext.Mutex = n.Context.Mutex
+	tempContext.ProductionDeployment = n.Context.ProductionDeployment
+
+	if err := n.Context.CheckConcurrency(); err != nil {
+		return err
+	}
+	if n.Context.ConcurrencyGroup != "" {
+		tempContext.ConcurrencyGroup = sql.NullString{Valid: true, String: n.Context.ConcurrencyGroup}
+	}
+	if n.Context.ConcurrencyPolicy != "" {
+		tempContext.ConcurrencyPolicy = sql.NullString{Valid: true, String: n.Context.ConcurrencyPolicy}
+	}
+	if n.Context.VerificationOf != "" {
+		tempContext.VerificationOf = sql.NullString{Valid: true, String: n.Context.VerificationOf}
+		tempContext.RollbackNode = sql.NullString{Valid: true, String: n.Context.RollbackNode}
+	}
 
 	if n.Context.PipelineID != 0 {
 		//Checks pipeline parameters
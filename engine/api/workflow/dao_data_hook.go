@@ -16,9 +16,9 @@ func CountRepositoryWebHooksByApplication(db gorp.SqlExecutor, appID int64) (int
     JOIN w_node_context ON w_node_context.node_id = w_node_hook.node_id
     JOIN workflow_hook_model ON workflow_hook_model.id = w_node_hook.hook_model_id
 	WHERE w_node_context.application_id = $1
-	AND workflow_hook_model.name = $2;
+	AND workflow_hook_model.name IN ($2, $3);
   `
-	count, err := db.SelectInt(query, appID, sdk.RepositoryWebHookModelName)
+	count, err := db.SelectInt(query, appID, sdk.RepositoryWebHookModelName, sdk.PullRequestHookModelName)
 	if err != nil {
 		return 0, sdk.WithStack(err)
 	}
@@ -0,0 +1,28 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadProductionDeployments returns the terminated runs of the production deployment nodes of a project between two
+// dates. The first build of a deployment is the start of the first node run of the workflow on the same commit.
+func LoadProductionDeployments(db gorp.SqlExecutor, projectID int64, from, to time.Time) ([]sdk.DORADeployment, error) {
+	var ds []sdk.DORADeployment
+	if _, err := db.Select(&ds, `SELECT workflow.name AS workflow_name, r.workflow_node_name AS node_name, r.num AS run_number,
+		r.status, COALESCE(r.vcs_hash, '') AS vcs_hash, r.start, r.done,
+		COALESCE((
+			SELECT MIN(first.start) FROM workflow_node_run first
+			WHERE first.workflow_id = r.workflow_id AND first.vcs_hash = r.vcs_hash
+		), r.start) AS first_build
+	FROM workflow_node_run r
+	JOIN workflow ON workflow.id = r.workflow_id
+	WHERE workflow.project_id = $1 AND r.production_deployment AND r.done >= $2 AND r.done < $3 AND r.status IN ($4, $5)
+	ORDER BY r.done`, projectID, from, to, sdk.StatusSuccess, sdk.StatusFail); err != nil {
+		return nil, sdk.WrapError(err, "cannot load production deployments")
+	}
+	return ds, nil
+}
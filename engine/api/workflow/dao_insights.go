@@ -0,0 +1,45 @@
+package workflow
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// maxInsightsNodeRuns is the maximum number of node runs loaded to compute the insights of a workflow.
+const maxInsightsNodeRuns = 1000
+
+// LoadInsightJobs returns the job runs of the last node runs of a workflow terminated between two dates.
+func LoadInsightJobs(db gorp.SqlExecutor, workflowID int64, from, to time.Time) ([]sdk.WorkflowInsightJob, error) {
+	var res []struct {
+		NodeName string         `db:"workflow_node_name"`
+		Stages   sql.NullString `db:"stages"`
+	}
+	if _, err := db.Select(&res, `SELECT workflow_node_name, stages
+	FROM workflow_node_run
+	WHERE workflow_id = $1 AND done >= $2 AND done < $3
+	ORDER BY done DESC
+	LIMIT $4`, workflowID, from, to, maxInsightsNodeRuns); err != nil {
+		return nil, sdk.WrapError(err, "cannot load node runs of workflow %d", workflowID)
+	}
+
+	var jobs []sdk.WorkflowInsightJob
+	for _, r := range res {
+		var stages []sdk.Stage
+		if err := gorpmapping.JSONNullString(r.Stages, &stages); err != nil {
+			return nil, sdk.WrapError(err, "cannot unmarshal stages of node %s", r.NodeName)
+		}
+		for _, s := range stages {
+			for _, j := range s.RunJobs {
+				if job, ok := sdk.NewWorkflowInsightJob(r.NodeName, j); ok {
+					jobs = append(jobs, job)
+				}
+			}
+		}
+	}
+	return jobs, nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
 	"github.com/ovh/venom"
 
 	"github.com/ovh/cds/engine/api/cache"
@@ -50,7 +51,12 @@ workflow_node_run.uuid,
 workflow_node_run.outgoinghook,
 workflow_node_run.hook_execution_timestamp,
 workflow_node_run.execution_id,
-workflow_node_run.callback
+workflow_node_run.callback,
+workflow_node_run.concurrency_group,
+workflow_node_run.production_deployment,
+workflow_node_run.frozen,
+workflow_node_run.subworkflow,
+workflow_node_run.gate
 `
 
 const nodeRunTestsField string = ", workflow_node_run.tests"
@@ -184,6 +190,114 @@ func LoadAndLockNodeRunByID(ctx context.Context, db gorp.SqlExecutor, id int64)
 	return fromDBNodeRun(rr, LoadRunOptions{})
 }
 
+// LoadAndLockSubWorkflowNodeRunsToStart loads the sub-workflow node runs for which the run of the called
+// workflow has not been started yet. The node runs locked by another transaction are skipped.
+func LoadAndLockSubWorkflowNodeRunsToStart(ctx context.Context, db gorp.SqlExecutor, limit int) ([]sdk.WorkflowNodeRun, error) {
+	var end func()
+	_, end = observability.Span(ctx, "workflow.LoadAndLockSubWorkflowNodeRunsToStart")
+	defer end()
+
+	var rrs []NodeRun
+	query := fmt.Sprintf(`select %s
+	from workflow_node_run
+	where workflow_node_run.subworkflow is not null and workflow_node_run.status = $1
+	order by workflow_node_run.id
+	limit $2
+	for update SKIP LOCKED`, nodeRunFields)
+	if _, err := db.Select(&rrs, query, sdk.StatusWaiting, limit); err != nil {
+		return nil, sdk.WrapError(err, "unable to load sub-workflow node runs")
+	}
+
+	res := make([]sdk.WorkflowNodeRun, 0, len(rrs))
+	for _, rr := range rrs {
+		r, err := fromDBNodeRun(rr, LoadRunOptions{})
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *r)
+	}
+	return res, nil
+}
+
+// LoadAndLockFrozenNodeRuns loads the waiting node runs queued by freeze windows. The node runs locked by another
+// transaction are skipped.
+func LoadAndLockFrozenNodeRuns(ctx context.Context, db gorp.SqlExecutor, limit int) ([]sdk.WorkflowNodeRun, error) {
+	var end func()
+	_, end = observability.Span(ctx, "workflow.LoadAndLockFrozenNodeRuns")
+	defer end()
+
+	var rrs []NodeRun
+	query := fmt.Sprintf(`select %s
+	from workflow_node_run
+	where workflow_node_run.frozen and workflow_node_run.status = $1
+	order by workflow_node_run.id
+	limit $2
+	for update SKIP LOCKED`, nodeRunFields)
+	if _, err := db.Select(&rrs, query, sdk.StatusWaiting, limit); err != nil {
+		return nil, sdk.WrapError(err, "unable to load frozen node runs")
+	}
+
+	res := make([]sdk.WorkflowNodeRun, 0, len(rrs))
+	for _, rr := range rrs {
+		r, err := fromDBNodeRun(rr, LoadRunOptions{})
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *r)
+	}
+	return res, nil
+}
+
+// LoadAndLockGateNodeRunsToOpen loads the gate node runs for which the gate has not been opened yet. The node
+// runs locked by another transaction are skipped.
+func LoadAndLockGateNodeRunsToOpen(ctx context.Context, db gorp.SqlExecutor, limit int) ([]sdk.WorkflowNodeRun, error) {
+	var end func()
+	_, end = observability.Span(ctx, "workflow.LoadAndLockGateNodeRunsToOpen")
+	defer end()
+
+	query := fmt.Sprintf(`select %s
+	from workflow_node_run
+	where workflow_node_run.gate is not null and workflow_node_run.status = $1
+	order by workflow_node_run.id
+	limit $2
+	for update SKIP LOCKED`, nodeRunFields)
+	return loadGateNodeRuns(db, query, sdk.StatusWaiting, limit)
+}
+
+// LoadAndLockExpiredGateNodeRuns loads the opened gate node runs for which no decision was taken before the
+// timeout. The node runs locked by another transaction are skipped.
+func LoadAndLockExpiredGateNodeRuns(ctx context.Context, db gorp.SqlExecutor, limit int) ([]sdk.WorkflowNodeRun, error) {
+	var end func()
+	_, end = observability.Span(ctx, "workflow.LoadAndLockExpiredGateNodeRuns")
+	defer end()
+
+	query := fmt.Sprintf(`select %s
+	from workflow_node_run
+	where workflow_node_run.gate is not null and workflow_node_run.status = $1
+	and (workflow_node_run.gate->>'expire')::timestamptz <= now()
+	order by workflow_node_run.id
+	limit $2
+	for update SKIP LOCKED`, nodeRunFields)
+	return loadGateNodeRuns(db, query, sdk.StatusBuilding, limit)
+}
+
+func loadGateNodeRuns(db gorp.SqlExecutor, query string, status string, limit int) ([]sdk.WorkflowNodeRun, error) {
+	var rrs []NodeRun
+	if _, err := db.Select(&rrs, query, status, limit); err != nil {
+		return nil, sdk.WrapError(err, "unable to load gate node runs")
+	}
+
+	res := make([]sdk.WorkflowNodeRun, 0, len(rrs))
+	for _, rr := range rrs {
+		r, err := fromDBNodeRun(rr, LoadRunOptions{})
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *r)
+	}
+	return res, nil
+}
+
 //LoadNodeRunByID load a specific node run on a workflow
 func LoadNodeRunByID(db gorp.SqlExecutor, id int64, loadOpts LoadRunOptions) (*sdk.WorkflowNodeRun, error) {
 	var rr = NodeRun{}
@@ -362,6 +476,13 @@ func fromDBNodeRun(rr NodeRun, opts LoadRunOptions) (*sdk.WorkflowNodeRun, error
 		r.HookExecutionID = rr.ExecutionID.String
 	}
 
+	if rr.ConcurrencyGroup.Valid {
+		r.ConcurrencyGroup = rr.ConcurrencyGroup.String
+	}
+
+	r.ProductionDeployment = rr.ProductionDeployment
+	r.Frozen = rr.Frozen
+
 	if rr.HookExecutionTimestamp.Valid {
 		r.HookExecutionTimeStamp = rr.HookExecutionTimestamp.Int64
 	}
@@ -378,6 +499,18 @@ func fromDBNodeRun(rr NodeRun, opts LoadRunOptions) (*sdk.WorkflowNodeRun, error
 		}
 	}
 
+	if rr.SubWorkflow.Valid {
+		if err := gorpmapping.JSONNullString(rr.SubWorkflow, &r.SubWorkflow); err != nil {
+			return nil, sdk.WrapError(err, "Error loading node run %d: SubWorkflow", r.ID)
+		}
+	}
+
+	if rr.Gate.Valid {
+		if err := gorpmapping.JSONNullString(rr.Gate, &r.Gate); err != nil {
+			return nil, sdk.WrapError(err, "Error loading node run %d: Gate", r.ID)
+		}
+	}
+
 	return r, nil
 }
 
@@ -414,6 +547,12 @@ func makeDBNodeRun(n sdk.WorkflowNodeRun) (*NodeRun, error) {
 	nodeRunDB.HookExecutionTimestamp.Int64 = n.HookExecutionTimeStamp
 	nodeRunDB.UUID.Valid = true
 	nodeRunDB.UUID.String = n.UUID
+	if n.ConcurrencyGroup != "" {
+		nodeRunDB.ConcurrencyGroup.Valid = true
+		nodeRunDB.ConcurrencyGroup.String = n.ConcurrencyGroup
+	}
+	nodeRunDB.ProductionDeployment = n.ProductionDeployment
+	nodeRunDB.Frozen = n.Frozen
 
 	if n.TriggersRun != nil {
 		s, err := gorpmapping.JSONToNullString(n.TriggersRun)
@@ -503,6 +642,22 @@ func makeDBNodeRun(n sdk.WorkflowNodeRun) (*NodeRun, error) {
 	}
 	nodeRunDB.OutgoingHook = oh
 
+	if n.SubWorkflow != nil {
+		sw, err := gorpmapping.JSONToNullString(n.SubWorkflow)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to get json from sub-workflow")
+		}
+		nodeRunDB.SubWorkflow = sw
+	}
+
+	if n.Gate != nil {
+		g, err := gorpmapping.JSONToNullString(n.Gate)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to get json from gate")
+		}
+		nodeRunDB.Gate = g
+	}
+
 	return nodeRunDB, nil
 }
 
@@ -816,3 +971,30 @@ func RunExist(db gorp.SqlExecutor, projectKey string, workflowID int64, hash str
 	count, err := db.SelectInt(query, projectKey, workflowID, hash)
 	return count != 0, err
 }
+
+// LoadLastNodeRunsStatusByRunIDs returns by run id the last sub number of the node runs of runs, with their status but
+// without their details. The node runs of all the runs are loaded with one query.
+func LoadLastNodeRunsStatusByRunIDs(db gorp.SqlExecutor, workflowRunIDs []int64) (map[int64][]sdk.WorkflowNodeRun, error) {
+	query := `
+	SELECT DISTINCT ON (workflow_run_id, workflow_node_id) id, workflow_run_id, workflow_node_id, workflow_node_name, num, sub_num, status, start, last_modified, done
+	FROM workflow_node_run
+	WHERE workflow_run_id = ANY($1)
+	ORDER BY workflow_run_id, workflow_node_id, sub_num DESC`
+	rows, err := db.Query(query, pq.Int64Array(workflowRunIDs))
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load node runs of runs %v", workflowRunIDs)
+	}
+	defer rows.Close() // nolint
+
+	nodeRuns := make(map[int64][]sdk.WorkflowNodeRun, len(workflowRunIDs))
+	for rows.Next() {
+		var nr sdk.WorkflowNodeRun
+		var done sql.NullTime
+		if err := rows.Scan(&nr.ID, &nr.WorkflowRunID, &nr.WorkflowNodeID, &nr.WorkflowNodeName, &nr.Number, &nr.SubNumber, &nr.Status, &nr.Start, &nr.LastModified, &done); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		nr.Done = done.Time
+		nodeRuns[nr.WorkflowRunID] = append(nodeRuns[nr.WorkflowRunID], nr)
+	}
+	return nodeRuns, sdk.WithStack(rows.Err())
+}
@@ -0,0 +1,52 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertCodeComments saves the code comments sent by a job, comments over the max number kept for a node run are
+// ignored.
+func InsertCodeComments(ctx context.Context, db gorp.SqlExecutor, nodeRunID, jobRunID int64, comments []sdk.WorkflowNodeRunCodeComment) error {
+	var count int64
+	if err := db.QueryRow("SELECT COUNT(id) FROM workflow_node_run_code_comment WHERE workflow_node_run_id = $1", nodeRunID).Scan(&count); err != nil {
+		return sdk.WrapError(err, "cannot count code comments of node run %d", nodeRunID)
+	}
+
+	for i := range comments {
+		if count >= sdk.MaxCodeCommentsByNodeRun {
+			break
+		}
+		c := comments[i]
+		if err := c.IsValid(); err != nil {
+			return err
+		}
+		c.ID = 0
+		c.WorkflowNodeRunID = nodeRunID
+		c.WorkflowNodeJobRunID = jobRunID
+		dbC := dbNodeRunCodeComment(c)
+		if err := gorpmapping.Insert(db, &dbC); err != nil {
+			return sdk.WrapError(err, "cannot insert code comment")
+		}
+		count++
+	}
+	return nil
+}
+
+// LoadCodeComments returns the code comments of a node run.
+func LoadCodeComments(ctx context.Context, db gorp.SqlExecutor, nodeRunID int64) ([]sdk.WorkflowNodeRunCodeComment, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_node_run_code_comment WHERE workflow_node_run_id = $1 ORDER BY path, line, id").Args(nodeRunID)
+	var dbComments []dbNodeRunCodeComment
+	if err := gorpmapping.GetAll(ctx, db, query, &dbComments); err != nil {
+		return nil, sdk.WrapError(err, "cannot load code comments of node run %d", nodeRunID)
+	}
+	comments := make([]sdk.WorkflowNodeRunCodeComment, len(dbComments))
+	for i := range dbComments {
+		comments[i] = sdk.WorkflowNodeRunCodeComment(dbComments[i])
+	}
+	return comments, nil
+}
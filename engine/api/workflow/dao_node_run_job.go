@@ -14,6 +14,7 @@ import (
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/group"
 	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/quota"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -88,21 +89,31 @@ func LoadNodeJobRunQueue(ctx context.Context, db gorp.SqlExecutor, store cache.S
 		}
 	}
 
-	query := gorpmapping.NewQuery(`select distinct workflow_node_run_job.*
+	// Workers and hatcheries don't get the jobs of the projects that reached their maximum number of building jobs or their quotas
+	applyLimits := filter.Rights >= sdk.PermissionReadExecute
+	exhausted, err := loadExhaustedProjectIDs(ctx, db, applyLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	query := gorpmapping.NewQuery(`select workflow_node_run_job.*
 	from workflow_node_run_job
+	`+queueLimitsJoins+`
 	where workflow_node_run_job.queued >= $1
 	and workflow_node_run_job.queued <= $2
 	and workflow_node_run_job.status = ANY(string_to_array($3, ','))
 	AND contains_service IN ($4, $5)
 	AND (model_type is NULL OR model_type = '' OR model_type = ANY(string_to_array($6, ',')))
-	ORDER BY workflow_node_run_job.queued ASC
-	`).Args(
+	AND `+queueLimitsCondition("$7", "$8")+`
+	`+queueOrderBy).Args(
 		*filter.Since,                       // $1
 		*filter.Until,                       // $2
 		strings.Join(filter.Statuses, ","),  // $3
 		containsService[0],                  // $4
 		containsService[1],                  // $5
 		strings.Join(filter.ModelType, ","), // $6
+		applyLimits,                         // $7
+		exhausted,                           // $8
 	)
 
 	return loadNodeJobRunQueue(ctx, db, store, query, filter.Limit)
@@ -121,6 +132,13 @@ func LoadNodeJobRunQueueByGroupIDs(ctx context.Context, db gorp.SqlExecutor, sto
 		}
 	}
 
+	// Workers and hatcheries don't get the jobs of the projects that reached their maximum number of building jobs or their quotas
+	applyLimits := filter.Rights >= sdk.PermissionReadExecute
+	exhausted, err := loadExhaustedProjectIDs(ctx, db, applyLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	query := gorpmapping.NewQuery(`
 	-- Parameters:
 	--  $1: Queue since
@@ -131,6 +149,8 @@ func LoadNodeJobRunQueueByGroupIDs(ctx context.Context, db gorp.SqlExecutor, sto
 	--  $7: Comman separated list of groups ID
 	--  $8: shared infra group ID
 	--  $9: minimum level of permission
+	--  $10: apply the limits of the projects
+	--  $11: Comma separated list of projects ID that exhausted their quotas
 	WITH workflow_id_with_permissions AS (
 		SELECT workflow_perm.workflow_id,
 			CASE WHEN $8 = ANY(string_to_array($7, ',')::int[]) THEN 7
@@ -151,11 +171,12 @@ func LoadNodeJobRunQueueByGroupIDs(ctx context.Context, db gorp.SqlExecutor, sto
 		FROM workflow_node_run_job_exec_groups
 		WHERE exec_group_id::text = ANY(string_to_array($7, ','))
 	)
-	SELECT DISTINCT workflow_node_run_job.*
+	SELECT workflow_node_run_job.*
 	FROM workflow_node_run_job
 	JOIN workflow_node_run ON workflow_node_run.id = workflow_node_run_job.workflow_node_run_id
 	JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id
 	JOIN workflow ON workflow.id = workflow_run.workflow_id
+	`+queueLimitsJoins+`
 	WHERE workflow.id IN (
 		SELECT workflow_id
 		FROM workflow_id_with_permissions
@@ -174,8 +195,8 @@ func LoadNodeJobRunQueueByGroupIDs(ctx context.Context, db gorp.SqlExecutor, sto
 		OR
 		model_type = '' OR model_type = ANY(string_to_array($6, ','))
 	)
-	ORDER BY workflow_node_run_job.queued ASC
-	`).Args(
+	AND `+queueLimitsCondition("$10", "$11")+`
+	`+queueOrderBy).Args(
 		*filter.Since,                          // $1
 		*filter.Until,                          // $2
 		strings.Join(filter.Statuses, ","),     // $3
@@ -185,10 +206,54 @@ func LoadNodeJobRunQueueByGroupIDs(ctx context.Context, db gorp.SqlExecutor, sto
 		gorpmapping.IDsToQueryString(groupIDs), // $7
 		group.SharedInfraGroup.ID,              // $8
 		filter.Rights,                          // $9
+		applyLimits,                            // $10
+		exhausted,                              // $11
 	)
 	return loadNodeJobRunQueue(ctx, db, store, query, filter.Limit)
 }
 
+// queueLimitsJoins joins the number of building jobs and the queue settings of the project of each job.
+const queueLimitsJoins = `LEFT JOIN (
+		SELECT project_id, COUNT(1) AS building
+		FROM workflow_node_run_job
+		WHERE status = 'Building'
+		GROUP BY project_id
+	) AS project_building ON project_building.project_id = workflow_node_run_job.project_id
+	LEFT JOIN project_queue_settings ON project_queue_settings.project_id = workflow_node_run_job.project_id`
+
+// queueLimitsCondition excludes the waiting jobs of the projects that reached their maximum number of building jobs
+// or their quotas, if the limits should be applied.
+func queueLimitsCondition(applyLimits, exhaustedProjectIDs string) string {
+	return `(
+		NOT ` + applyLimits + `
+		OR workflow_node_run_job.status <> 'Waiting'
+		OR (
+			(COALESCE(project_queue_settings.max_building_jobs, 0) = 0 OR COALESCE(project_building.building, 0) < project_queue_settings.max_building_jobs)
+			AND NOT workflow_node_run_job.project_id = ANY(string_to_array(` + exhaustedProjectIDs + `, ',')::bigint[])
+		)
+	)`
+}
+
+// queueOrderBy serves the jobs fairly: jobs with a higher priority first, then jobs of the projects
+// that have less building jobs, then the oldest jobs.
+const queueOrderBy = `ORDER BY workflow_node_run_job.priority DESC, COALESCE(project_building.building, 0) ASC, workflow_node_run_job.queued ASC`
+
+// loadExhaustedProjectIDs returns the comma separated list of the projects that exhausted their quotas.
+func loadExhaustedProjectIDs(ctx context.Context, db gorp.SqlExecutor, applyLimits bool) (string, error) {
+	if !applyLimits {
+		return "", nil
+	}
+	exhausted, err := quota.LoadExhaustedProjectIDs(ctx, db)
+	if err != nil {
+		return "", err
+	}
+	ids := make([]int64, 0, len(exhausted))
+	for id := range exhausted {
+		ids = append(ids, id)
+	}
+	return gorpmapping.IDsToQueryString(ids), nil
+}
+
 func loadNodeJobRunQueue(ctx context.Context, db gorp.SqlExecutor, store cache.Store, query gorpmapping.Query, limit *int) ([]sdk.WorkflowNodeJobRun, error) {
 	ctx, end := observability.Span(ctx, "workflow.loadNodeJobRunQueue")
 	defer end()
@@ -236,7 +301,7 @@ func LoadNodeJobRunIDByNodeRunID(db gorp.SqlExecutor, runNodeID int64) ([]int64,
 	return ids, nil
 }
 
-//LoadNodeJobRun load a NodeJobRun given its ID
+// LoadNodeJobRun load a NodeJobRun given its ID
 func LoadNodeJobRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	j := JobRun{}
 	query := `select workflow_node_run_job.* from workflow_node_run_job where id = $1`
@@ -259,7 +324,7 @@ func LoadNodeJobRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 	return &jr, nil
 }
 
-//LoadDeadNodeJobRun load a NodeJobRun which is Building but without worker
+// LoadDeadNodeJobRun load a NodeJobRun which is Building but without worker
 func LoadDeadNodeJobRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store) ([]sdk.WorkflowNodeJobRun, error) {
 	var deadJobsDB []JobRun
 	query := `SELECT workflow_node_run_job.* FROM workflow_node_run_job WHERE worker_id IS NULL`
@@ -286,7 +351,7 @@ func LoadDeadNodeJobRun(ctx context.Context, db gorp.SqlExecutor, store cache.St
 	return deadJobs, nil
 }
 
-//LoadAndLockNodeJobRunWait load for update a NodeJobRun given its ID
+// LoadAndLockNodeJobRunWait load for update a NodeJobRun given its ID
 func LoadAndLockNodeJobRunWait(ctx context.Context, db gorp.SqlExecutor, store cache.Store, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	j := JobRun{}
 	query := `select workflow_node_run_job.* from workflow_node_run_job where id = $1 for update`
@@ -301,7 +366,7 @@ func LoadAndLockNodeJobRunWait(ctx context.Context, db gorp.SqlExecutor, store c
 	return &jr, nil
 }
 
-//LoadAndLockNodeJobRunSkipLocked load for update a NodeJobRun given its ID
+// LoadAndLockNodeJobRunSkipLocked load for update a NodeJobRun given its ID
 func LoadAndLockNodeJobRunSkipLocked(ctx context.Context, db gorp.SqlExecutor, store cache.Store, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	var end func()
 	_, end = observability.Span(ctx, "workflow.LoadAndLockNodeJobRunSkipLocked")
@@ -336,7 +401,7 @@ func insertWorkflowNodeJobRun(db gorp.SqlExecutor, j *sdk.WorkflowNodeJobRun) er
 	return nil
 }
 
-//DeleteNodeJobRuns deletes all workflow_node_run_job for a given workflow_node_run
+// DeleteNodeJobRuns deletes all workflow_node_run_job for a given workflow_node_run
 func DeleteNodeJobRuns(db gorp.SqlExecutor, nodeID int64) error {
 	query := `delete from workflow_node_run_job where workflow_node_run_id = $1`
 	_, err := db.Exec(query, nodeID)
@@ -350,7 +415,7 @@ func DeleteNodeJobRun(db gorp.SqlExecutor, nodeRunJob int64) error {
 	return err
 }
 
-//UpdateNodeJobRun updates a workflow_node_run_job
+// UpdateNodeJobRun updates a workflow_node_run_job
 func UpdateNodeJobRun(ctx context.Context, db gorp.SqlExecutor, j *sdk.WorkflowNodeJobRun) error {
 	var end func()
 	_, end = observability.Span(ctx, "workflow.UpdateNodeJobRun")
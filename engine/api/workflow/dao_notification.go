@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-gorp/gorp"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -86,6 +87,12 @@ func InsertNotification(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.WorkflowNot
 	n.WorkflowID = w.ID
 	n.ID = 0
 	n.NodeIDs = nil
+
+	if n.Type == sdk.WebhookUserNotification {
+		if err := prepareWebhookNotification(n); err != nil {
+			return err
+		}
+	}
 	dbNotif := Notification(*n)
 
 	//Check references to sources
@@ -118,6 +125,28 @@ func InsertNotification(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.WorkflowNot
 	return nil
 }
 
+// prepareWebhookNotification checks the webhook settings and encrypts the secret given in clear.
+func prepareWebhookNotification(n *sdk.WorkflowNotification) error {
+	hook := n.Settings.Webhook
+	if err := hook.IsValid(); err != nil {
+		return err
+	}
+	if n.Settings.Template != nil && n.Settings.Template.Body != "" {
+		if _, err := sdk.RenderWebhookNotification(n.Settings.Template.Body, sdk.WebhookNotificationData{}); err != nil {
+			return err
+		}
+	}
+	if hook.Secret != "" {
+		encrypted, err := secret.EncryptValue(hook.Secret)
+		if err != nil {
+			return err
+		}
+		hook.EncryptedSecret = encrypted
+		hook.Secret = ""
+	}
+	return nil
+}
+
 // PostInsert is a db hook
 func (no *Notification) PostInsert(db gorp.SqlExecutor) error {
 	b, err := gorpmapping.JSONToNullString(no.Settings)
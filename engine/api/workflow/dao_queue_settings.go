@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// LoadQueueSettings returns the queue settings of a project, or the default ones if they were never set.
+func LoadQueueSettings(ctx context.Context, db gorp.SqlExecutor, projectID int64) (sdk.ProjectQueueSettings, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM project_queue_settings WHERE project_id = $1").Args(projectID)
+	var s dbProjectQueueSettings
+	found, err := gorpmapping.Get(ctx, db, query, &s)
+	if err != nil {
+		return sdk.ProjectQueueSettings{}, sdk.WrapError(err, "cannot get queue settings for project %d", projectID)
+	}
+	if !found {
+		return sdk.ProjectQueueSettings{ProjectID: projectID}, nil
+	}
+	return sdk.ProjectQueueSettings(s), nil
+}
+
+// UpsertQueueSettings inserts or updates the queue settings of a project.
+func UpsertQueueSettings(ctx context.Context, db gorp.SqlExecutor, s sdk.ProjectQueueSettings) error {
+	count, err := db.SelectInt("SELECT COUNT(1) FROM project_queue_settings WHERE project_id = $1", s.ProjectID)
+	if err != nil {
+		return sdk.WrapError(err, "cannot count queue settings for project %d", s.ProjectID)
+	}
+	dbS := dbProjectQueueSettings(s)
+	if count == 0 {
+		if err := gorpmapping.Insert(db, &dbS); err != nil {
+			return sdk.WrapError(err, "unable to insert queue settings for project %d", s.ProjectID)
+		}
+		return nil
+	}
+	if err := gorpmapping.Update(db, &dbS); err != nil {
+		return sdk.WrapError(err, "unable to update queue settings for project %d", s.ProjectID)
+	}
+	return nil
+}
+
+// runPriority returns the priority of a new run: the one given when starting the run, or the one set on the hook
+// that started the run, or the default priority of the project.
+func runPriority(db gorp.SqlExecutor, wf *sdk.Workflow, opts *sdk.WorkflowRunPostHandlerOption) (int, error) {
+	if opts != nil && opts.Priority != nil {
+		if err := sdk.IsValidWorkflowRunPriority(*opts.Priority); err != nil {
+			return 0, err
+		}
+		return *opts.Priority, nil
+	}
+	if opts != nil && opts.Hook != nil {
+		if h, ok := wf.WorkflowData.GetHooks()[opts.Hook.WorkflowNodeHookUUID]; ok && h.Config[sdk.HookConfigPriority].Value != "" {
+			p, err := strconv.Atoi(h.Config[sdk.HookConfigPriority].Value)
+			if err == nil && sdk.IsValidWorkflowRunPriority(p) == nil {
+				return p, nil
+			}
+			log.Warning(context.TODO(), "runPriority> invalid priority %q on hook %s", h.Config[sdk.HookConfigPriority].Value, h.UUID)
+		}
+	}
+	s, err := LoadQueueSettings(context.TODO(), db, wf.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+	return s.Priority, nil
+}
@@ -30,7 +30,10 @@ workflow_run.last_modified,
 workflow_run.status,
 workflow_run.last_sub_num,
 workflow_run.last_execution,
-workflow_run.to_delete
+workflow_run.to_delete,
+workflow_run.priority,
+workflow_run.archived,
+workflow_run.freeze_override
 `
 
 // LoadRunOptions are options for loading a run (node or workflow)
@@ -238,6 +241,24 @@ func LoadLastRun(db gorp.SqlExecutor, projectkey, workflowname string, loadOpts
 	return loadRun(db, loadOpts, query, projectkey, workflowname)
 }
 
+// LoadLastSuccessfulRunByBranch returns the last successful run of a workflow on given branch, before the run with
+// given number.
+func LoadLastSuccessfulRunByBranch(db gorp.SqlExecutor, projectkey, workflowname, branch string, beforeNumber int64, loadOpts LoadRunOptions) (*sdk.WorkflowRun, error) {
+	query := fmt.Sprintf(`select %s
+	from workflow_run
+	join project on workflow_run.project_id = project.id
+	join workflow on workflow_run.workflow_id = workflow.id
+	join workflow_run_tag on workflow_run_tag.workflow_run_id = workflow_run.id
+	where project.projectkey = $1
+	and workflow.name = $2
+	and workflow_run_tag.tag = 'git.branch'
+	and workflow_run_tag.value = $3
+	and workflow_run.status = $4
+	and workflow_run.num < $5
+	order by workflow_run.num desc limit 1`, wfRunfields)
+	return loadRun(db, loadOpts, query, projectkey, workflowname, branch, sdk.StatusSuccess, beforeNumber)
+}
+
 // LoadRun returns a specific run
 func LoadRun(ctx context.Context, db gorp.SqlExecutor, projectkey, workflowname string, number int64, loadOpts LoadRunOptions) (*sdk.WorkflowRun, error) {
 	_, end := observability.Span(ctx, "workflow.LoadRun",
@@ -422,6 +443,28 @@ func LoadRunsIDByTag(db gorp.SqlExecutor, projectKey, workflowName, tag, tagValu
 	return ids, nil
 }
 
+// LoadRunsIDInProgressByBranch load the ids of the runs of a workflow that are still in progress on given branch,
+// before the run with given number.
+func LoadRunsIDInProgressByBranch(db gorp.SqlExecutor, workflowID int64, branch string, beforeNumber int64) ([]int64, error) {
+	query := `SELECT workflow_run.id
+		FROM workflow_run
+		JOIN workflow_run_tag on workflow_run_tag.workflow_run_id = workflow_run.id
+		WHERE workflow_run.workflow_id = $1
+		AND workflow_run_tag.tag = 'git.branch'
+		AND workflow_run_tag.value = $2
+		AND workflow_run.num < $3
+		AND workflow_run.status = ANY(string_to_array($4, ','))
+		AND workflow_run.to_delete = false
+		ORDER BY workflow_run.num`
+
+	var ids []int64
+	inProgress := strings.Join([]string{sdk.StatusPending, sdk.StatusWaiting, sdk.StatusBuilding}, ",")
+	if _, err := db.Select(&ids, query, workflowID, branch, beforeNumber, inProgress); err != nil {
+		return nil, sdk.WrapError(err, "cannot load in progress runs id for branch %s", branch)
+	}
+	return ids, nil
+}
+
 func loadRunTags(db gorp.SqlExecutor, run *sdk.WorkflowRun) error {
 	dbRunTags := []RunTag{}
 	if _, err := db.Select(&dbRunTags, "SELECT * from workflow_run_tag WHERE workflow_run_id=$1", run.ID); err != nil {
@@ -582,6 +625,12 @@ func CreateRun(db *gorp.DbMap, wf *sdk.Workflow, opts *sdk.WorkflowRunPostHandle
 		Workflow:      sdk.Workflow{Name: wf.Name},
 	}
 
+	priority, err := runPriority(db, wf, opts)
+	if err != nil {
+		return nil, err
+	}
+	wr.Priority = priority
+
 	if opts != nil && opts.Hook != nil {
 		if trigg, ok := opts.Hook.Payload["cds.triggered_by.username"]; ok {
 			wr.Tag(tagTriggeredBy, trigg)
@@ -0,0 +1,175 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadRetentionSettings returns the retention settings of a project, or the default ones if they were never set.
+func LoadRetentionSettings(ctx context.Context, db gorp.SqlExecutor, projectID int64) (sdk.ProjectRetentionSettings, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM project_retention_settings WHERE project_id = $1").Args(projectID)
+	var s dbProjectRetentionSettings
+	found, err := gorpmapping.Get(ctx, db, query, &s)
+	if err != nil {
+		return sdk.ProjectRetentionSettings{}, sdk.WrapError(err, "cannot get retention settings for project %d", projectID)
+	}
+	if !found {
+		return sdk.ProjectRetentionSettings{ProjectID: projectID}, nil
+	}
+	return sdk.ProjectRetentionSettings(s), nil
+}
+
+// LoadAllRetentionSettings returns the retention settings of the projects which archive their runs.
+func LoadAllRetentionSettings(ctx context.Context, db gorp.SqlExecutor) ([]sdk.ProjectRetentionSettings, error) {
+	var ss []dbProjectRetentionSettings
+	query := gorpmapping.NewQuery("SELECT * FROM project_retention_settings WHERE archive_after_days > 0 ORDER BY project_id")
+	if err := gorpmapping.GetAll(ctx, db, query, &ss); err != nil {
+		return nil, sdk.WrapError(err, "cannot get retention settings")
+	}
+	res := make([]sdk.ProjectRetentionSettings, len(ss))
+	for i := range ss {
+		res[i] = sdk.ProjectRetentionSettings(ss[i])
+	}
+	return res, nil
+}
+
+// UpsertRetentionSettings inserts or updates the retention settings of a project.
+func UpsertRetentionSettings(ctx context.Context, db gorp.SqlExecutor, s sdk.ProjectRetentionSettings) error {
+	count, err := db.SelectInt("SELECT COUNT(1) FROM project_retention_settings WHERE project_id = $1", s.ProjectID)
+	if err != nil {
+		return sdk.WrapError(err, "cannot count retention settings for project %d", s.ProjectID)
+	}
+	dbS := dbProjectRetentionSettings(s)
+	if count == 0 {
+		if err := gorpmapping.Insert(db, &dbS); err != nil {
+			return sdk.WrapError(err, "unable to insert retention settings for project %d", s.ProjectID)
+		}
+		return nil
+	}
+	if err := gorpmapping.Update(db, &dbS); err != nil {
+		return sdk.WrapError(err, "unable to update retention settings for project %d", s.ProjectID)
+	}
+	return nil
+}
+
+// LoadRunIDsToArchive returns the ids of the terminated runs of a project that were not modified since a date
+// and are not archived yet.
+func LoadRunIDsToArchive(db gorp.SqlExecutor, projectID int64, before time.Time, limit int) ([]int64, error) {
+	var ids []int64
+	if _, err := db.Select(&ids, `SELECT id FROM workflow_run
+	WHERE project_id = $1 AND archived = false AND to_delete = false AND last_modified < $2 AND status = ANY($3)
+	ORDER BY id
+	LIMIT $4`, projectID, before, pq.StringArray([]string{sdk.StatusSuccess, sdk.StatusFail, sdk.StatusStopped}), limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot load runs to archive for project %d", projectID)
+	}
+	return ids, nil
+}
+
+// InsertRunArchive inserts the archive of a run, and marks the run and its archived artifacts as archived.
+func InsertRunArchive(db gorp.SqlExecutor, a *sdk.WorkflowRunArchive, artifactIDs []int64) error {
+	dbA := dbRunArchive(*a)
+	if err := gorpmapping.Insert(db, &dbA); err != nil {
+		return sdk.WrapError(err, "unable to insert archive of run %d", a.WorkflowRunID)
+	}
+	if _, err := db.Exec("UPDATE workflow_run SET archived = true WHERE id = $1", a.WorkflowRunID); err != nil {
+		return sdk.WrapError(err, "unable to mark run %d as archived", a.WorkflowRunID)
+	}
+	if _, err := db.Exec("UPDATE workflow_node_run_artifacts SET archived = true WHERE workflow_run_id = $1 AND id = ANY($2)", a.WorkflowRunID, pq.Int64Array(artifactIDs)); err != nil {
+		return sdk.WrapError(err, "unable to mark artifacts of run %d as archived", a.WorkflowRunID)
+	}
+	return nil
+}
+
+// LoadRunArchive returns the archive of a run.
+func LoadRunArchive(ctx context.Context, db gorp.SqlExecutor, workflowRunID int64) (*sdk.WorkflowRunArchive, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_run_archive WHERE workflow_run_id = $1").Args(workflowRunID)
+	var a dbRunArchive
+	found, err := gorpmapping.Get(ctx, db, query, &a)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get archive of run %d", workflowRunID)
+	}
+	if !found {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "run %d is not archived", workflowRunID)
+	}
+	res := sdk.WorkflowRunArchive(a)
+	return &res, nil
+}
+
+// DeleteRunArchive deletes the archive of a rehydrated run, and marks the run and its artifacts as not archived.
+// The last modification of the run is updated, so it's not archived again before another retention period.
+func DeleteRunArchive(db gorp.SqlExecutor, a sdk.WorkflowRunArchive) error {
+	dbA := dbRunArchive(a)
+	if err := gorpmapping.Delete(db, &dbA); err != nil {
+		return sdk.WrapError(err, "unable to delete archive of run %d", a.WorkflowRunID)
+	}
+	if _, err := db.Exec("UPDATE workflow_run SET archived = false, last_modified = current_timestamp WHERE id = $1", a.WorkflowRunID); err != nil {
+		return sdk.WrapError(err, "unable to mark run %d as not archived", a.WorkflowRunID)
+	}
+	if _, err := db.Exec("UPDATE workflow_node_run_artifacts SET archived = false WHERE workflow_run_id = $1", a.WorkflowRunID); err != nil {
+		return sdk.WrapError(err, "unable to mark artifacts of run %d as not archived", a.WorkflowRunID)
+	}
+	return nil
+}
+
+// UpdateArtifactObjectPath updates the path of an artifact restored in its storage.
+func UpdateArtifactObjectPath(db gorp.SqlExecutor, artifactID int64, objectPath string) error {
+	if _, err := db.Exec("UPDATE workflow_node_run_artifacts SET object_path = $2 WHERE id = $1", artifactID, objectPath); err != nil {
+		return sdk.WrapError(err, "unable to update object path of artifact %d", artifactID)
+	}
+	return nil
+}
+
+// LoadRunLogs returns the logs of the steps and of the services of all the jobs of a run.
+func LoadRunLogs(db gorp.SqlExecutor, wr sdk.WorkflowRun) (sdk.WorkflowRunArchiveLogs, error) {
+	var logs sdk.WorkflowRunArchiveLogs
+	for _, nrs := range wr.WorkflowNodeRuns {
+		for _, nr := range nrs {
+			for _, s := range nr.Stages {
+				for _, rj := range s.RunJobs {
+					steps, err := LoadLogs(db, rj.ID)
+					if err != nil {
+						return logs, sdk.WrapError(err, "cannot load logs of job %d", rj.ID)
+					}
+					logs.Steps = append(logs.Steps, steps...)
+					services, err := LoadServicesLogsByJob(db, rj.ID)
+					if err != nil {
+						return logs, sdk.WrapError(err, "cannot load services logs of job %d", rj.ID)
+					}
+					logs.Services = append(logs.Services, services...)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
+
+// InsertRunLogs inserts the logs of a rehydrated run.
+func InsertRunLogs(db gorp.SqlExecutor, logs sdk.WorkflowRunArchiveLogs) error {
+	for i := range logs.Steps {
+		if err := insertLog(db, &logs.Steps[i]); err != nil {
+			return sdk.WrapError(err, "cannot insert logs of job %d", logs.Steps[i].JobID)
+		}
+	}
+	for i := range logs.Services {
+		if err := insertServiceLog(db, &logs.Services[i]); err != nil {
+			return sdk.WrapError(err, "cannot insert services logs of job %d", logs.Services[i].WorkflowNodeJobRunID)
+		}
+	}
+	return nil
+}
+
+// DeleteRunLogs deletes the logs of the steps and of the services of all the jobs of a run.
+func DeleteRunLogs(db gorp.SqlExecutor, workflowRunID int64) error {
+	for _, table := range []string{"workflow_node_run_job_logs", "requirement_service_logs"} {
+		if _, err := db.Exec("DELETE FROM "+table+" WHERE workflow_node_run_id IN (SELECT id FROM workflow_node_run WHERE workflow_run_id = $1)", workflowRunID); err != nil {
+			return sdk.WrapError(err, "cannot delete logs of run %d", workflowRunID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// AppendNodeRunManifestJob adds a job taken by a worker to the manifest of a node run, the manifest is created
+// with the first job.
+func AppendNodeRunManifestJob(db gorp.SqlExecutor, nodeRun *sdk.WorkflowNodeRun, j sdk.WorkflowNodeRunManifestJob) error {
+	btes, err := json.Marshal(sdk.WorkflowNodeRunManifestJobs{j})
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	now := time.Now()
+	if _, err := db.Exec(`INSERT INTO workflow_node_run_manifest (workflow_id, workflow_run_id, workflow_node_run_id, num, sub_num, jobs, created, last_modified)
+	VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7, $7)
+	ON CONFLICT (workflow_node_run_id) DO UPDATE SET jobs = workflow_node_run_manifest.jobs || EXCLUDED.jobs, last_modified = EXCLUDED.last_modified`,
+		nodeRun.WorkflowID, nodeRun.WorkflowRunID, nodeRun.ID, nodeRun.Number, nodeRun.SubNumber, string(btes), now); err != nil {
+		return sdk.WrapError(err, "cannot save manifest of node run %d", nodeRun.ID)
+	}
+	return nil
+}
+
+// LoadNodeRunManifest returns the manifest of a node run of a workflow run.
+func LoadNodeRunManifest(ctx context.Context, db gorp.SqlExecutor, workflowRunID, nodeRunID int64) (*sdk.WorkflowNodeRunManifest, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_node_run_manifest WHERE workflow_run_id = $1 AND workflow_node_run_id = $2").Args(workflowRunID, nodeRunID)
+	var dbM dbNodeRunManifest
+	found, err := gorpmapping.Get(ctx, db, query, &dbM)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load manifest of node run %d", nodeRunID)
+	}
+	if !found {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "no manifest for node run %d", nodeRunID)
+	}
+	m := sdk.WorkflowNodeRunManifest(dbM)
+	return &m, nil
+}
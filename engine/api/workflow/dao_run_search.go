@@ -0,0 +1,153 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var runSearchSortColumns = map[string]string{
+	sdk.WorkflowRunSearchSortStart:        "workflow_run.start",
+	sdk.WorkflowRunSearchSortLastModified: "workflow_run.last_modified",
+	sdk.WorkflowRunSearchSortNumber:       "workflow_run.num",
+}
+
+// SearchRuns returns a page of the runs of a project matching a search, and the cursor of the next page. The pages
+// are paginated on the sort key and the id of the runs, so a page is loaded with the same cost whatever its position.
+// The search must be valid.
+func SearchRuns(db gorp.SqlExecutor, projectID int64, s sdk.WorkflowRunSearch) ([]sdk.WorkflowRun, string, error) {
+	args := []interface{}{projectID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	tagFilter := func(tag, value string) string {
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM workflow_run_tag WHERE workflow_run_tag.workflow_run_id = workflow_run.id AND workflow_run_tag.tag = %s AND workflow_run_tag.value = %s)", arg(tag), arg(value))
+	}
+
+	filters := []string{"workflow_run.project_id = $1", "workflow_run.to_delete = false"}
+	if len(s.Workflows) > 0 {
+		filters = append(filters, fmt.Sprintf("workflow.name = ANY(%s)", arg(pq.StringArray(s.Workflows))))
+	}
+	if len(s.Status) > 0 {
+		filters = append(filters, fmt.Sprintf("workflow_run.status = ANY(%s)", arg(pq.StringArray(s.Status))))
+	}
+	if s.Branch != "" {
+		filters = append(filters, tagFilter(tagGitBranch, s.Branch))
+	}
+	if s.Triggerer != "" {
+		filters = append(filters, tagFilter(tagTriggeredBy, s.Triggerer))
+	}
+	for tag, value := range s.Tags {
+		filters = append(filters, tagFilter(tag, value))
+	}
+	if s.From != nil {
+		filters = append(filters, fmt.Sprintf("workflow_run.start >= %s", arg(*s.From)))
+	}
+	if s.To != nil {
+		filters = append(filters, fmt.Sprintf("workflow_run.start < %s", arg(*s.To)))
+	}
+	if s.NodeName != "" || len(s.Payload) > 0 {
+		nodeRunFilters := []string{"workflow_node_run.workflow_run_id = workflow_run.id"}
+		if s.NodeName != "" {
+			nodeRunFilters = append(nodeRunFilters, fmt.Sprintf("workflow_node_run.workflow_node_name = %s", arg(s.NodeName)))
+		}
+		if len(s.Payload) > 0 {
+			payload, err := json.Marshal(s.Payload)
+			if err != nil {
+				return nil, "", sdk.WithStack(err)
+			}
+			nodeRunFilters = append(nodeRunFilters, fmt.Sprintf("workflow_node_run.payload @> %s::jsonb", arg(string(payload))))
+		}
+		filters = append(filters, "EXISTS (SELECT 1 FROM workflow_node_run WHERE "+strings.Join(nodeRunFilters, " AND ")+")")
+	}
+
+	column := runSearchSortColumns[s.Sort]
+	order, operator := "DESC", "<"
+	if s.Ascending {
+		order, operator = "ASC", ">"
+	}
+	if s.Cursor != "" {
+		c, err := sdk.ParseWorkflowRunSearchCursor(s.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		value, err := c.SortValue()
+		if err != nil {
+			return nil, "", err
+		}
+		filters = append(filters, fmt.Sprintf("(%s, workflow_run.id) %s (%s, %s)", column, operator, arg(value), arg(c.ID)))
+	}
+
+	// one more run is loaded to know if there is a next page
+	query := fmt.Sprintf(`SELECT %s
+	FROM workflow_run
+	JOIN workflow ON workflow_run.workflow_id = workflow.id
+	WHERE %s
+	ORDER BY %s %s, workflow_run.id %s
+	LIMIT %s`, wfRunfields, strings.Join(filters, " AND "), column, order, order, arg(s.Limit+1))
+
+	var runs []Run
+	if _, err := db.Select(&runs, query, args...); err != nil {
+		return nil, "", sdk.WrapError(err, "cannot search runs")
+	}
+
+	var next string
+	if len(runs) > s.Limit {
+		runs = runs[:s.Limit]
+		next = sdk.NewWorkflowRunSearchCursor(s, sdk.WorkflowRun(runs[len(runs)-1]))
+	}
+
+	wruns := make([]sdk.WorkflowRun, len(runs))
+	for i := range runs {
+		wruns[i] = sdk.WorkflowRun(runs[i])
+		if err := loadRunTags(db, &wruns[i]); err != nil {
+			return nil, "", sdk.WrapError(err, "cannot load tags of run %d", wruns[i].ID)
+		}
+	}
+	return wruns, next, nil
+}
+
+// LoadLastRunsByWorkflowIDs returns by workflow id the last runs of workflows sorted by start like the runs search,
+// with their tags. The runs of all the workflows are loaded with one query.
+func LoadLastRunsByWorkflowIDs(db gorp.SqlExecutor, workflowIDs []int64, limit int) (map[int64][]sdk.WorkflowRun, error) {
+	query := fmt.Sprintf(`SELECT %s
+	FROM (
+		SELECT *, ROW_NUMBER() OVER (PARTITION BY workflow_id ORDER BY start DESC, id DESC) AS run_rank
+		FROM workflow_run
+		WHERE workflow_id = ANY($1) AND to_delete = false
+	) workflow_run
+	WHERE workflow_run.run_rank <= $2
+	ORDER BY workflow_run.workflow_id, workflow_run.start DESC, workflow_run.id DESC`, wfRunfields)
+
+	var runs []Run
+	if _, err := db.Select(&runs, query, pq.Int64Array(workflowIDs), limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot load last runs of workflows %v", workflowIDs)
+	}
+
+	runIDs := make([]int64, len(runs))
+	for i := range runs {
+		runIDs[i] = runs[i].ID
+	}
+	var tags []RunTag
+	if _, err := db.Select(&tags, "SELECT * FROM workflow_run_tag WHERE workflow_run_id = ANY($1)", pq.Int64Array(runIDs)); err != nil {
+		return nil, sdk.WrapError(err, "cannot load tags of runs %v", runIDs)
+	}
+	runTags := make(map[int64][]sdk.WorkflowRunTag, len(runs))
+	for i := range tags {
+		runTags[tags[i].WorkflowRunID] = append(runTags[tags[i].WorkflowRunID], sdk.WorkflowRunTag(tags[i]))
+	}
+
+	wruns := make(map[int64][]sdk.WorkflowRun, len(workflowIDs))
+	for i := range runs {
+		r := sdk.WorkflowRun(runs[i])
+		r.Tags = runTags[r.ID]
+		wruns[r.WorkflowID] = append(wruns[r.WorkflowID], r)
+	}
+	return wruns, nil
+}
@@ -0,0 +1,79 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertSBOM saves the SBOM produced by a job of a workflow run.
+func InsertSBOM(db gorp.SqlExecutor, s *sdk.WorkflowRunSBOM) error {
+	dbS := dbRunSBOM(*s)
+	if err := gorpmapping.Insert(db, &dbS); err != nil {
+		return sdk.WrapError(err, "cannot insert sbom")
+	}
+	*s = sdk.WorkflowRunSBOM(dbS)
+	return nil
+}
+
+// LoadSBOMsByRunID returns the SBOMs of a workflow run, without their document and components.
+func LoadSBOMsByRunID(ctx context.Context, db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowRunSBOM, error) {
+	query := gorpmapping.NewQuery(`SELECT id, workflow_id, workflow_run_id, workflow_node_run_id, name, artifact, format, created
+	FROM workflow_run_sbom WHERE workflow_run_id = $1 ORDER BY id`).Args(workflowRunID)
+	var dbSs []dbRunSBOM
+	if err := gorpmapping.GetAll(ctx, db, query, &dbSs); err != nil {
+		return nil, sdk.WrapError(err, "cannot load sboms")
+	}
+	ss := make([]sdk.WorkflowRunSBOM, len(dbSs))
+	for i := range dbSs {
+		ss[i] = sdk.WorkflowRunSBOM(dbSs[i])
+	}
+	return ss, nil
+}
+
+// LoadSBOMByID returns a SBOM of a workflow run with its document.
+func LoadSBOMByID(ctx context.Context, db gorp.SqlExecutor, workflowRunID, id int64) (*sdk.WorkflowRunSBOM, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_run_sbom WHERE workflow_run_id = $1 AND id = $2").Args(workflowRunID, id)
+	var dbS dbRunSBOM
+	found, err := gorpmapping.Get(ctx, db, query, &dbS)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load sbom %d", id)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	s := sdk.WorkflowRunSBOM(dbS)
+	return &s, nil
+}
+
+// SearchSBOMComponents returns the last runs with a SBOM containing a dependency, in a project or in all the
+// projects if projectID is 0. All the versions of the dependency are returned if version is empty.
+func SearchSBOMComponents(db gorp.SqlExecutor, projectID int64, name, version string, limit int) ([]sdk.SBOMSearchResult, error) {
+	component := sdk.SBOMComponent{Name: name, Version: version}
+	contains, err := json.Marshal(sdk.SBOMComponents{component})
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	var res []sdk.SBOMSearchResult
+	if _, err := db.Select(&res, `SELECT project.projectkey AS project_key, workflow.name AS workflow_name, workflow_run.num AS run_number,
+		workflow_run_sbom.workflow_node_run_id, workflow_run_sbom.id AS sbom_id, workflow_run_sbom.name AS sbom_name, workflow_run_sbom.artifact,
+		c->>'name' AS name, COALESCE(c->>'version', '') AS version, COALESCE(c->>'purl', '') AS purl, workflow_run_sbom.created
+	FROM workflow_run_sbom
+	JOIN workflow_run ON workflow_run.id = workflow_run_sbom.workflow_run_id
+	JOIN workflow ON workflow.id = workflow_run_sbom.workflow_id
+	JOIN project ON project.id = workflow.project_id
+	CROSS JOIN LATERAL jsonb_array_elements(workflow_run_sbom.components) c
+	WHERE workflow_run_sbom.components @> $1::jsonb
+	AND c->>'name' = $2 AND ($3 = '' OR c->>'version' = $3)
+	AND ($4 = 0 OR project.id = $4)
+	ORDER BY workflow_run_sbom.created DESC, workflow_run_sbom.id DESC
+	LIMIT $5`, string(contains), name, version, projectID, limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot search sbom components")
+	}
+	return res, nil
+}
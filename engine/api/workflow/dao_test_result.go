@@ -0,0 +1,88 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertTestResults saves the results of the test cases sent by a job of a workflow run.
+func InsertTestResults(db gorp.SqlExecutor, rs []sdk.WorkflowRunTestResult) error {
+	now := time.Now()
+	for i := range rs {
+		rs[i].Created = now
+		dbR := dbRunTestResult(rs[i])
+		if err := gorpmapping.Insert(db, &dbR); err != nil {
+			return sdk.WrapError(err, "cannot insert test result")
+		}
+		rs[i] = sdk.WorkflowRunTestResult(dbR)
+	}
+	return nil
+}
+
+// LoadTestResultsByRunID returns the results of the test cases of a workflow run.
+func LoadTestResultsByRunID(ctx context.Context, db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowRunTestResult, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_run_test_result WHERE workflow_run_id = $1 ORDER BY suite, name, id").Args(workflowRunID)
+	var dbRs []dbRunTestResult
+	if err := gorpmapping.GetAll(ctx, db, query, &dbRs); err != nil {
+		return nil, sdk.WrapError(err, "cannot load test results")
+	}
+	rs := make([]sdk.WorkflowRunTestResult, len(dbRs))
+	for i := range dbRs {
+		rs[i] = sdk.WorkflowRunTestResult(dbRs[i])
+	}
+	return rs, nil
+}
+
+// LoadTestHistory returns the last results of a test case in the workflows of a project, the last first.
+func LoadTestHistory(db gorp.SqlExecutor, projectID int64, suite, name string, limit int) ([]sdk.TestHistory, error) {
+	var hs []sdk.TestHistory
+	if _, err := db.Select(&hs, `SELECT workflow.name AS workflow_name, r.run_number, r.vcs_hash, r.vcs_branch, r.status, r.duration, r.created
+	FROM workflow_run_test_result r
+	JOIN workflow ON workflow.id = r.workflow_id
+	WHERE workflow.project_id = $1 AND r.suite = $2 AND r.name = $3
+	ORDER BY r.created DESC, r.id DESC
+	LIMIT $4`, projectID, suite, name, limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot load test history")
+	}
+	sdk.SetFlakyTestHistory(hs)
+	return hs, nil
+}
+
+// LoadFlakyTests returns the tests of a project which passed and failed on the same commit since a date, the
+// flakiest first: the tests with the most flaky commits, then the most changes of result between two runs.
+func LoadFlakyTests(db gorp.SqlExecutor, projectID int64, since time.Time, limit int) ([]sdk.FlakyTest, error) {
+	var ts []sdk.FlakyTest
+	if _, err := db.Select(&ts, `WITH results AS (
+		SELECT r.suite, r.name, r.status, r.vcs_hash, r.created,
+			LAG(r.status) OVER (PARTITION BY r.workflow_id, r.suite, r.name ORDER BY r.created, r.id) AS previous
+		FROM workflow_run_test_result r
+		JOIN workflow ON workflow.id = r.workflow_id
+		WHERE workflow.project_id = $1 AND r.created > $2 AND r.status <> $3
+	), flaky AS (
+		SELECT suite, name, COUNT(*) AS flaky_commits FROM (
+			SELECT suite, name, vcs_hash FROM results
+			WHERE vcs_hash <> ''
+			GROUP BY suite, name, vcs_hash
+			HAVING COUNT(DISTINCT status) > 1
+		) commits
+		GROUP BY suite, name
+	)
+	SELECT results.suite, results.name, COUNT(*) AS runs,
+		COUNT(*) FILTER (WHERE results.status = $4) AS failures,
+		COUNT(*) FILTER (WHERE results.previous IS NOT NULL AND results.previous <> results.status) AS flips,
+		flaky.flaky_commits,
+		MAX(results.created) FILTER (WHERE results.status = $4) AS last_failure
+	FROM results
+	JOIN flaky ON flaky.suite = results.suite AND flaky.name = results.name
+	GROUP BY results.suite, results.name, flaky.flaky_commits
+	ORDER BY flaky.flaky_commits DESC, flips DESC, results.suite, results.name
+	LIMIT $5`, projectID, since, sdk.TestResultSkipped, sdk.TestResultFailure, limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot load flaky tests")
+	}
+	return ts, nil
+}
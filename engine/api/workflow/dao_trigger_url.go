@@ -0,0 +1,64 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertTriggerURL saves a signed trigger url generated for a workflow, the expired urls of the workflow are removed.
+func InsertTriggerURL(db gorp.SqlExecutor, u *sdk.WorkflowTriggerURL) error {
+	if _, err := db.Exec("DELETE FROM workflow_trigger_url WHERE workflow_id = $1 AND expire < NOW()", u.WorkflowID); err != nil {
+		return sdk.WrapError(err, "cannot delete expired trigger urls")
+	}
+	dbU := dbTriggerURL(*u)
+	if err := gorpmapping.Insert(db, &dbU); err != nil {
+		return sdk.WrapError(err, "cannot insert trigger url")
+	}
+	*u = sdk.WorkflowTriggerURL(dbU)
+	return nil
+}
+
+// RevokeTriggerURL revokes a signed trigger url, it can't be used anymore.
+func RevokeTriggerURL(db gorp.SqlExecutor, workflowID int64, id string) error {
+	res, err := db.Exec("UPDATE workflow_trigger_url SET revoked = true WHERE workflow_id = $1 AND id = $2", workflowID, id)
+	if err != nil {
+		return sdk.WrapError(err, "cannot revoke trigger url %s", id)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sdk.WithStack(sdk.ErrNotFound)
+	}
+	return nil
+}
+
+// LoadTriggerURLs returns the signed trigger urls of a workflow which are not expired, the last generated first.
+func LoadTriggerURLs(ctx context.Context, db gorp.SqlExecutor, workflowID int64) ([]sdk.WorkflowTriggerURL, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_trigger_url WHERE workflow_id = $1 AND expire >= NOW() ORDER BY created DESC").Args(workflowID)
+	var dbUs []dbTriggerURL
+	if err := gorpmapping.GetAll(ctx, db, query, &dbUs); err != nil {
+		return nil, sdk.WrapError(err, "cannot load trigger urls")
+	}
+	us := make([]sdk.WorkflowTriggerURL, len(dbUs))
+	for i := range dbUs {
+		us[i] = sdk.WorkflowTriggerURL(dbUs[i])
+	}
+	return us, nil
+}
+
+// LoadTriggerURLByID returns a signed trigger url of a workflow.
+func LoadTriggerURLByID(ctx context.Context, db gorp.SqlExecutor, workflowID int64, id string) (*sdk.WorkflowTriggerURL, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_trigger_url WHERE workflow_id = $1 AND id = $2").Args(workflowID, id)
+	var dbU dbTriggerURL
+	found, err := gorpmapping.Get(ctx, db, query, &dbU)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load trigger url %s", id)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	u := sdk.WorkflowTriggerURL(dbU)
+	return &u, nil
+}
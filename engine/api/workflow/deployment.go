@@ -0,0 +1,45 @@
+package workflow
+
+import (
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/sdk"
+)
+
+// saveEnvironmentDeployment saves a successful node run of a pipeline linked to an application and an environment
+// as the last deployment of the application on the environment, with the artifacts of the workflow run.
+func saveEnvironmentDeployment(db gorp.SqlExecutor, wr *sdk.WorkflowRun, node *sdk.Node, nr *sdk.WorkflowNodeRun) error {
+	if node == nil || node.Context == nil || node.Context.ApplicationID == 0 || node.Context.EnvironmentID == 0 {
+		return nil
+	}
+
+	var artifacts []sdk.DeploymentArtifact
+	if _, err := db.Select(&artifacts, `
+		SELECT DISTINCT ON (name) name, tag
+		FROM workflow_node_run_artifacts
+		WHERE workflow_run_id = $1
+		ORDER BY name, id DESC`, wr.ID); err != nil {
+		return sdk.WrapError(err, "cannot load artifacts of workflow run %d", wr.ID)
+	}
+
+	params := sdk.ParametersToMap(nr.BuildParameters)
+	return environment.UpsertDeployment(db, &sdk.EnvironmentDeployment{
+		ProjectID:         wr.ProjectID,
+		ApplicationID:     node.Context.ApplicationID,
+		EnvironmentID:     node.Context.EnvironmentID,
+		WorkflowID:        wr.WorkflowID,
+		WorkflowRunID:     wr.ID,
+		WorkflowNodeRunID: nr.ID,
+		RunNumber:         nr.Number,
+		NodeName:          nr.WorkflowNodeName,
+		Version:           params["cds.version"],
+		VCSRepository:     nr.VCSRepository,
+		VCSBranch:         nr.VCSBranch,
+		VCSTag:            nr.VCSTag,
+		VCSHash:           nr.VCSHash,
+		Artifacts:         sdk.DeploymentArtifacts(artifacts),
+		DeployedBy:        params["cds.triggered_by.username"],
+		Deployed:          nr.Done,
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ovh/cds/engine/api/environment"
 	"github.com/ovh/cds/engine/api/integration"
 	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/quota"
 	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -257,6 +258,9 @@ func TakeNodeJobRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 	if err := checkStatusWaiting(ctx, store, jobID, job.Status); err != nil {
 		return nil, report, err
 	}
+	if err := quota.CheckJob(ctx, db, job.ProjectID, job.Job.Action.Requirements); err != nil {
+		return nil, report, err
+	}
 
 	job.Model = workerModel
 	job.Job.WorkerName = workerName
@@ -512,6 +516,40 @@ func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNo
 			return nil, sdk.WrapError(err, "Unable to decrypt variables")
 		}
 	}
+
+	// Docker registries, kubernetes clusters, helm registries, ServiceNow and Jira instances credentials, used by the
+	// DockerBuild, DeployKubernetes, HelmPush, ServiceNowChangeRequest and JiraRelease actions
+	integrations, err := integration.LoadIntegrationsByProjectID(db, w.Workflow.ProjectID, true)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load project integrations")
+	}
+	for _, pi := range integrations {
+		var prefix string
+		switch pi.Model.Name {
+		case sdk.DockerRegistryIntegrationModel:
+			prefix = sdk.DockerRegistryVariablePrefix(pi.Name)
+		case sdk.KubernetesIntegrationModel:
+			prefix = sdk.KubernetesVariablePrefix(pi.Name)
+		case sdk.HelmRegistryIntegrationModel:
+			prefix = sdk.HelmRegistryVariablePrefix(pi.Name)
+		case sdk.ServiceNowIntegrationModel:
+			prefix = sdk.ServiceNowVariablePrefix(pi.Name)
+		case sdk.JiraIntegrationModel:
+			prefix = sdk.JiraVariablePrefix(pi.Name)
+		default:
+			continue
+		}
+		rv := make([]sdk.Variable, 0, len(pi.Config))
+		for k, v := range pi.Config {
+			rv = append(rv, sdk.Variable{
+				Name:  k,
+				Type:  v.Type,
+				Value: v.Value,
+			})
+		}
+		secrets = append(secrets, sdk.VariablesPrefix(rv, prefix)...)
+	}
+
 	return secrets, nil
 }
 
@@ -294,11 +294,35 @@ func executeNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 			report, _ = report.Merge(ctx, r1, nil)
 		}
 
+		if nr.Status == sdk.StatusFail {
+			r1, err := processRollback(ctx, db, store, proj, updatedWorkflowRun, nr)
+			report, err = report.Merge(ctx, r1, err)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if nr.Status == sdk.StatusSuccess {
+			node := updatedWorkflowRun.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
+			if err := saveEnvironmentDeployment(db, updatedWorkflowRun, node, nr); err != nil {
+				return nil, err
+			}
+		}
+
 		//Delete the line in workflow_node_run_job
 		if err := DeleteNodeJobRuns(db, nr.ID); err != nil {
 			return nil, sdk.WrapError(err, "Unable to delete node %d job runs ", nr.ID)
 		}
 
+		//Do we release a concurrency group ?
+		if nr.ConcurrencyGroup != "" {
+			r1, err := releaseConcurrencyGroup(ctx, db, store, proj, nr.ConcurrencyGroup)
+			report, err = report.Merge(ctx, r1, err)
+			if err != nil {
+				return nil, sdk.WrapError(err, "Unable to release concurrency group %s", nr.ConcurrencyGroup)
+			}
+		}
+
 		var hasMutex bool
 		var nodeName string
 
@@ -448,6 +472,11 @@ jobLoop:
 		// errors generated in the loop will be added to job run spawn info
 		spawnErrs := sdk.MultiError{}
 
+		// use the versions of the actions and plugins pinned by the workflow
+		if err := processNodeJobRunPinnedVersions(ctx, db, wr.Workflow.PinnedVersions, job); err != nil {
+			spawnErrs.Append(err)
+		}
+
 		//Process variables for the jobs
 		_, next = observability.Span(ctx, "workflow..getNodeJobRunParameters")
 		jobParams, err := getNodeJobRunParameters(db, *job, nr, stage)
@@ -464,7 +493,7 @@ jobLoop:
 		}
 
 		// check that children actions used by job can be used by the project
-		if err := action.CheckChildrenForGroupIDsWithLoop(ctx, db, &job.Action, sdk.Groups(groups).ToIDs()); err != nil {
+		if err := action.CheckChildrenForProjectAndGroupIDsWithLoop(ctx, db, &job.Action, wr.ProjectID, sdk.Groups(groups).ToIDs()); err != nil {
 			spawnErrs.Append(err)
 		}
 
@@ -488,6 +517,7 @@ jobLoop:
 			},
 			Header:          nr.Header,
 			ContainsService: containsService,
+			Priority:        wr.Priority,
 		}
 		if wm != nil {
 			wjob.ModelType = wm.Type
@@ -847,6 +877,22 @@ func stopWorkflowNodeOutGoingHook(ctx context.Context, dbFunc func() *gorp.DbMap
 	return nil
 }
 
+// stopWorkflowNodeSubWorkflow marks a sub-workflow or a gate node run as stopped, the run of the called workflow
+// is stopped by the caller.
+func stopWorkflowNodeSubWorkflow(dbFunc func() *gorp.DbMap, nodeRun *sdk.WorkflowNodeRun) error {
+	if nodeRun.Callback == nil {
+		nodeRun.Callback = new(sdk.WorkflowNodeOutgoingHookRunCallback)
+		nodeRun.Callback.Start = nodeRun.Start
+	}
+	nodeRun.Callback.Done = time.Now()
+	nodeRun.Callback.Log += "\nStopped"
+	nodeRun.Callback.Status = sdk.StatusStopped
+
+	nodeRun.Status = sdk.StatusStopped
+	nodeRun.Done = time.Now()
+	return sdk.WrapError(UpdateNodeRun(dbFunc(), nodeRun), "cannot update node run")
+}
+
 // StopWorkflowNodeRun to stop a workflow node run with a specific spawn info
 func StopWorkflowNodeRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, proj *sdk.Project, nodeRun sdk.WorkflowNodeRun, stopInfos sdk.SpawnInfo) (*ProcessorReport, error) {
 	var end func()
@@ -863,6 +909,9 @@ func StopWorkflowNodeRun(ctx context.Context, dbFunc func() *gorp.DbMap, store c
 	if nodeRun.OutgoingHook != nil {
 		errS = stopWorkflowNodeOutGoingHook(ctx, dbFunc, &nodeRun)
 	}
+	if nodeRun.SubWorkflow != nil || nodeRun.Gate != nil {
+		errS = stopWorkflowNodeSubWorkflow(dbFunc, &nodeRun)
+	}
 
 	if errS != nil {
 		return report, sdk.WrapError(errS, "Unable to stop workflow node run")
@@ -1000,6 +1049,25 @@ func (i vcsInfos) String() string {
 	return fmt.Sprintf("%s:%s:%s:%s", i.Server, i.Repository, i.Branch, i.Hash)
 }
 
+// getPlainGitVCSInfos returns git infos for an application linked to a raw git repository. There is no repositories manager
+// to ask for the commit of a branch, it will be resolved by the worker when cloning the repository.
+func getPlainGitVCSInfos(gitValues map[string]string, app sdk.Application) *vcsInfos {
+	infos := vcsInfos{
+		Repository: app.RepositoryFullname,
+		Branch:     gitValues[tagGitBranch],
+		Tag:        gitValues[tagGitTag],
+		Hash:       gitValues[tagGitHash],
+		Author:     gitValues[tagGitAuthor],
+		Message:    gitValues[tagGitMessage],
+		URL:        app.RepositoryURL,
+		HTTPUrl:    app.RepositoryURL,
+	}
+	if infos.Branch == "" && infos.Tag == "" && infos.Hash == "" {
+		infos.Branch = app.RepositoryStrategy.DefaultBranch
+	}
+	return &infos
+}
+
 func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, projectKey string, vcsServer *sdk.ProjectVCSServer, gitValues map[string]string, applicationName, applicationVCSServer, applicationRepositoryFullname string) (*vcsInfos, error) {
 	var vcsInfos vcsInfos
 	vcsInfos.Repository = gitValues[tagGitRepository]
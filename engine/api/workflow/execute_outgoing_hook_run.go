@@ -61,10 +61,18 @@ loop:
 		}
 	}
 
-	report1, _, err := processNodeOutGoingHook(ctx, db, store, proj, wr, mapNodes, nil, node, int(nodeRun.SubNumber), nil)
+	var report1 *ProcessorReport
+	switch node.Type {
+	case sdk.NodeTypeSubWorkflow:
+		report1, _, err = processNodeSubWorkflow(ctx, db, store, proj, wr, nil, node, int(nodeRun.SubNumber), nil)
+	case sdk.NodeTypeGate:
+		report1, _, err = processNodeGate(ctx, db, store, proj, wr, nil, node, int(nodeRun.SubNumber), nil)
+	default:
+		report1, _, err = processNodeOutGoingHook(ctx, db, store, proj, wr, mapNodes, nil, node, int(nodeRun.SubNumber), nil)
+	}
 	report.Merge(ctx, report1, err) //nolint
 	if err != nil {
-		return nil, sdk.WrapError(err, "Unable to processNodeOutGoingHook")
+		return nil, sdk.WrapError(err, "Unable to process node %s", node.Name)
 	}
 
 	oldStatus := wr.Status
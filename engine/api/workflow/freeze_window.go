@@ -0,0 +1,122 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/freezewindow"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// activeFreezeWindows returns the freeze windows of the project active on the environment of the node.
+func activeFreezeWindows(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Project, wr *sdk.WorkflowRun, n *sdk.Node) ([]sdk.FreezeWindow, error) {
+	var env string
+	if n.Context != nil && n.Context.EnvironmentID != 0 {
+		env = wr.Workflow.Environments[n.Context.EnvironmentID].Name
+	}
+	return freezewindow.LoadActive(ctx, db, proj.ID, env, time.Now())
+}
+
+// checkFreezeWindows applies the active freeze windows of the project to a node run of a deployment workflow, the
+// windows overridden by the run are skipped. A window with the block policy stops the node run, a window with the
+// queue policy keeps it waiting until it is released by ReleaseFrozenNodeRun.
+func checkFreezeWindows(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Project, wr *sdk.WorkflowRun, n *sdk.Node, nr *sdk.WorkflowNodeRun) error {
+	ctx, end := observability.Span(ctx, "workflow.checkFreezeWindows")
+	defer end()
+
+	windows, err := activeFreezeWindows(ctx, db, proj, wr, n)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var queuedBy string
+	var queuedUntil time.Time
+	for _, w := range windows {
+		if wr.FreezeOverride.Overrides(w) {
+			AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeFreezeOverridden.ID,
+				Args: []interface{}{w.Name, n.Name, wr.FreezeOverride.Username, wr.FreezeOverride.Reason},
+			})
+			continue
+		}
+		if w.Policy == sdk.FreezeWindowPolicyBlock {
+			log.Debug("Noderun %s stopped by freeze window %s", n.Name, w.Name)
+			AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeFreezeBlocked.ID,
+				Args: []interface{}{n.Name, w.Name},
+			})
+			nr.Status = sdk.StatusStopped
+			nr.Done = now
+			stopWorkflowNodeRunStages(ctx, db, nr)
+			return nil
+		}
+		if _, until := w.ActiveAt(now); until.After(queuedUntil) {
+			queuedBy = w.Name
+			queuedUntil = until
+		}
+	}
+
+	if queuedBy != "" {
+		log.Debug("Noderun %s queued by freeze window %s", n.Name, queuedBy)
+		AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeFreezeQueued.ID,
+			Args: []interface{}{n.Name, queuedBy, queuedUntil.Format(time.RFC3339)},
+		})
+		nr.Frozen = true
+	}
+	return nil
+}
+
+// ReleaseFrozenNodeRun executes a node run queued by freeze windows if none of the windows of the project
+// freezes it anymore.
+func ReleaseFrozenNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, nr *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	ctx, end := observability.Span(ctx, "workflow.ReleaseFrozenNodeRun")
+	defer end()
+
+	report := new(ProcessorReport)
+
+	wr, err := LoadRunByID(db, nr.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run %d", nr.WorkflowRunID)
+	}
+	n := wr.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
+	if n == nil {
+		return nil, sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "unable to find node %d of workflow run %d", nr.WorkflowNodeID, wr.ID)
+	}
+
+	windows, err := activeFreezeWindows(ctx, db, proj, wr, n)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range windows {
+		if !wr.FreezeOverride.Overrides(w) {
+			return report, nil
+		}
+	}
+
+	nr.Frozen = false
+	if err := UpdateNodeRun(db, nr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update node run %d", nr.ID)
+	}
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeMutexRelease.ID,
+		Args: []interface{}{nr.WorkflowNodeName},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d after freeze window release", wr.ID)
+	}
+
+	log.Debug("workflow.ReleaseFrozenNodeRun> process the node run %d because the freeze windows are over", nr.ID)
+	r, err := executeNodeRun(ctx, db, store, proj, nr)
+	report, err = report.Merge(ctx, r, err)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to execute node run %d", nr.ID)
+	}
+	return report, nil
+}
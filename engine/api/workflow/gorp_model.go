@@ -58,6 +58,11 @@ type NodeRun struct {
 	HookExecutionTimestamp sql.NullInt64  `db:"hook_execution_timestamp"`
 	ExecutionID            sql.NullString `db:"execution_id"`
 	Callback               sql.NullString `db:"callback"`
+	ConcurrencyGroup       sql.NullString `db:"concurrency_group"`
+	ProductionDeployment   bool           `db:"production_deployment"`
+	Frozen                 bool           `db:"frozen"`
+	SubWorkflow            sql.NullString `db:"subworkflow"`
+	Gate                   sql.NullString `db:"gate"`
 }
 
 // JobRun is a gorp wrapper around sdk.WorkflowNodeJobRun
@@ -79,6 +84,7 @@ type JobRun struct {
 	ContainsService           bool           `db:"contains_service"`
 	ModelType                 sql.NullString `db:"model_type"`
 	Header                    sql.NullString `db:"header"`
+	Priority                  int            `db:"priority"`
 }
 
 // ToJobRun transform the JobRun with data of the provided sdk.WorkflowNodeJobRun
@@ -102,6 +108,7 @@ func (j *JobRun) ToJobRun(jr *sdk.WorkflowNodeJobRun) (err error) {
 	j.Model = jr.Model
 	j.ModelType = sql.NullString{Valid: true, String: string(jr.ModelType)}
 	j.ContainsService = jr.ContainsService
+	j.Priority = jr.Priority
 	j.ExecGroups, err = gorpmapping.JSONToNullString(jr.ExecGroups)
 	if err != nil {
 		return sdk.WrapError(err, "column exec_groups")
@@ -131,6 +138,7 @@ func (j JobRun) WorkflowNodeRunJob() (sdk.WorkflowNodeJobRun, error) {
 		Done:              j.Done,
 		BookedBy:          j.BookedBy,
 		ContainsService:   j.ContainsService,
+		Priority:          j.Priority,
 	}
 	if err := gorpmapping.JSONNullString(j.Job, &jr.Job); err != nil {
 		return jr, sdk.WrapError(err, "column job")
@@ -198,6 +206,28 @@ type dbNodeHookData sdk.NodeHook
 
 type dbAsCodeEvents sdk.AsCodeEvent
 
+type dbNodeRunCodeComment sdk.WorkflowNodeRunCodeComment
+
+type dbProjectQueueSettings sdk.ProjectQueueSettings
+
+type dbArtifactPromotion sdk.WorkflowArtifactPromotion
+
+type dbTriggerURL sdk.WorkflowTriggerURL
+
+type dbArtifactAttestation sdk.WorkflowArtifactAttestation
+
+type dbRunSBOM sdk.WorkflowRunSBOM
+
+type dbRunChangelog sdk.WorkflowRunChangelog
+
+type dbRunTestResult sdk.WorkflowRunTestResult
+
+type dbProjectRetentionSettings sdk.ProjectRetentionSettings
+
+type dbRunArchive sdk.WorkflowRunArchive
+
+type dbNodeRunManifest sdk.WorkflowNodeRunManifest
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(Workflow{}, "workflow", true, "id"))
 	gorpmapping.Register(gorpmapping.New(Run{}, "workflow_run", true, "id"))
@@ -219,4 +249,15 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbNodeOutGoingHookData{}, "w_node_outgoing_hook", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbNodeJoinData{}, "w_node_join", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbAsCodeEvents{}, "as_code_events", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbNodeRunCodeComment{}, "workflow_node_run_code_comment", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectQueueSettings{}, "project_queue_settings", false, "project_id"))
+	gorpmapping.Register(gorpmapping.New(dbArtifactPromotion{}, "workflow_artifact_promotion", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbArtifactAttestation{}, "workflow_node_run_artifact_attestation", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbRunSBOM{}, "workflow_run_sbom", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbRunChangelog{}, "workflow_run_changelog", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbRunTestResult{}, "workflow_run_test_result", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbProjectRetentionSettings{}, "project_retention_settings", false, "project_id"))
+	gorpmapping.Register(gorpmapping.New(dbRunArchive{}, "workflow_run_archive", false, "workflow_run_id"))
+	gorpmapping.Register(gorpmapping.New(dbNodeRunManifest{}, "workflow_node_run_manifest", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbTriggerURL{}, "workflow_trigger_url", false, "id"))
 }
@@ -29,6 +29,50 @@ func computeHookToDelete(newWorkflow *sdk.Workflow, oldWorkflow *sdk.Workflow) m
 	return hookToDelete
 }
 
+// retrieveHooksUUID gives to the new hooks without UUID the UUID of the previous hooks, so they are updated instead of recreated.
+// Hooks are matched by reference, then the remaining schedulers are matched in order with the previous schedulers
+// to keep their UUID when their cron or their payload changed. A previous UUID is never given twice.
+func retrieveHooksUUID(hooks []sdk.NodeHook, oldHooks []sdk.NodeHook) {
+	used := make(map[string]struct{}, len(hooks))
+	for i := range hooks {
+		if hooks[i].UUID != "" {
+			used[hooks[i].UUID] = struct{}{}
+		}
+	}
+
+	for i := range hooks {
+		h := &hooks[i]
+		if h.UUID != "" {
+			continue
+		}
+		for _, oldH := range oldHooks {
+			if _, has := used[oldH.UUID]; has {
+				continue
+			}
+			if oldH.Ref() == h.Ref() {
+				h.UUID = oldH.UUID
+				used[oldH.UUID] = struct{}{}
+				break
+			}
+		}
+	}
+
+	for i := range hooks {
+		h := &hooks[i]
+		if h.UUID != "" || h.HookModelName != sdk.SchedulerModelName {
+			continue
+		}
+		for _, oldH := range oldHooks {
+			if _, has := used[oldH.UUID]; has || oldH.HookModelName != sdk.SchedulerModelName {
+				continue
+			}
+			h.UUID = oldH.UUID
+			used[oldH.UUID] = struct{}{}
+			break
+		}
+	}
+}
+
 func hookUnregistration(ctx context.Context, db gorp.SqlExecutor, store cache.Store, p *sdk.Project, hookToDelete map[string]sdk.NodeHook) error {
 	ctx, end := observability.Span(ctx, "workflow.hookUnregistration")
 	defer end()
@@ -39,7 +83,7 @@ func hookUnregistration(ctx context.Context, db gorp.SqlExecutor, store cache.St
 
 	// Delete from vcs configuration if needed
 	for _, h := range hookToDelete {
-		if h.HookModelName == sdk.RepositoryWebHookModelName {
+		if sdk.IsRepositoryWebHookModel(h.HookModelName) {
 			// Call VCS to know if repository allows webhook and get the configuration fields
 			projectVCSServer := repositoriesmanager.GetProjectVCSServer(p, h.Config["vcsServer"].Value)
 			if projectVCSServer != nil {
@@ -80,10 +124,8 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 	defer end()
 
 	var oldHooks map[string]*sdk.NodeHook
-	var oldHooksByRef map[string]sdk.NodeHook
 	if oldWorkflow != nil {
 		oldHooks = oldWorkflow.WorkflowData.GetHooks()
-		oldHooksByRef = oldWorkflow.WorkflowData.GetHooksMapRef()
 	}
 	if len(wf.WorkflowData.Node.Hooks) <= 0 {
 		return nil
@@ -99,6 +141,10 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 		return sdk.WrapError(fmt.Errorf("no hooks service available, please try again"), "Unable to get services")
 	}
 
+	if oldWorkflow != nil {
+		retrieveHooksUUID(wf.WorkflowData.Node.Hooks, oldWorkflow.WorkflowData.Node.Hooks)
+	}
+
 	hookToUpdate := make(map[string]sdk.NodeHook)
 	for i := range wf.WorkflowData.Node.Hooks {
 		h := &wf.WorkflowData.Node.Hooks[i]
@@ -116,17 +162,7 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 			Configurable: false,
 		}
 
-		if h.UUID == "" && oldHooksByRef != nil {
-			// search previous hook configuration by ref
-			previousHook, has := oldHooksByRef[h.Ref()]
-			if has {
-				h.UUID = previousHook.UUID
-				// If previous hook is the same, we do nothing
-				if h.Equals(previousHook) {
-					continue
-				}
-			}
-		} else if oldHooks != nil {
+		if oldHooks != nil {
 			// search previous hook configuration by uuid
 			previousHook, has := oldHooks[h.UUID]
 			// If previous hook is the same, we do nothing
@@ -139,12 +175,21 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 			h.UUID = sdk.UUID()
 		}
 
-		if h.HookModelName == sdk.RepositoryWebHookModelName || h.HookModelName == sdk.GitPollerModelName || h.HookModelName == sdk.GerritHookModelName {
-			if wf.WorkflowData.Node.Context.ApplicationID == 0 || wf.Applications[wf.WorkflowData.Node.Context.ApplicationID].RepositoryFullname == "" || wf.Applications[wf.WorkflowData.Node.Context.ApplicationID].VCSServer == "" {
+		if sdk.IsRepositoryWebHookModel(h.HookModelName) || h.HookModelName == sdk.GitPollerModelName || h.HookModelName == sdk.GerritHookModelName {
+			app := wf.Applications[wf.WorkflowData.Node.Context.ApplicationID]
+			if wf.WorkflowData.Node.Context.ApplicationID == 0 || app.RepositoryFullname == "" || (app.VCSServer == "" && !app.IsPlainGit()) {
 				return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot create a git poller or repository webhook on an application without a repository")
 			}
+			vcsServerName := app.VCSServer
+			if app.IsPlainGit() {
+				// Without repositories manager, changes on the repository can only be detected by polling
+				if h.HookModelName != sdk.GitPollerModelName {
+					return sdk.NewErrorFrom(sdk.ErrForbidden, "application %s is not linked to a repositories manager, use a git poller instead of a repository webhook", app.Name)
+				}
+				vcsServerName = sdk.PlainGitVCSServer
+			}
 			h.Config[sdk.HookConfigVCSServer] = sdk.WorkflowNodeHookConfigValue{
-				Value:        wf.Applications[wf.WorkflowData.Node.Context.ApplicationID].VCSServer,
+				Value:        vcsServerName,
 				Configurable: false,
 			}
 			h.Config[sdk.HookConfigRepoFullName] = sdk.WorkflowNodeHookConfigValue{
@@ -179,7 +224,7 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 				continue
 			}
 			v, ok := h.Config[sdk.HookConfigWebHookID]
-			if h.HookModelName == sdk.RepositoryWebHookModelName && h.Config["vcsServer"].Value != "" {
+			if sdk.IsRepositoryWebHookModel(h.HookModelName) && h.Config["vcsServer"].Value != "" {
 				if !ok || v.Value == "" {
 					if err := createVCSConfiguration(ctx, db, store, p, h); err != nil {
 						return sdk.WrapError(err, "Cannot create vcs configuration")
@@ -297,6 +342,19 @@ func createVCSConfiguration(ctx context.Context, db gorp.SqlExecutor, store cach
 		return sdk.WrapError(sdk.ErrInvalidHookConfiguration, "wrong webHookURL value (project: %s, repository: %s)", p.Key, h.Config["repoFullName"].Value)
 	}
 
+	// Pull request hooks only listen to the pull request events of the repositories manager
+	if h.HookModelName == sdk.PullRequestHookModelName {
+		prEvents := sdk.FilterPullRequestVCSEvents(webHookInfo.Events)
+		if len(prEvents) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "pull request hooks are not supported by repositories manager %s", projectVCSServer.Name)
+		}
+		h.Config[sdk.HookConfigEventFilter] = sdk.WorkflowNodeHookConfigValue{
+			Value:        strings.Join(prEvents, ";"),
+			Configurable: false,
+			Type:         sdk.HookConfigTypeString,
+		}
+	}
+
 	// If empty, take the first event
 	var valueSplitted = strings.Split(h.Config[sdk.HookConfigEventFilter].Value, ";")
 	if valueSplitted[0] == "" && webHookInfo.Events != nil {
@@ -396,6 +454,9 @@ func DefaultPayload(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 
 	if app.RepositoryFullname != "" {
 		defaultBranch := "master"
+		if app.IsPlainGit() && app.RepositoryStrategy.DefaultBranch != "" {
+			defaultBranch = app.RepositoryStrategy.DefaultBranch
+		}
 		projectVCSServer := repositoriesmanager.GetProjectVCSServer(p, app.VCSServer)
 		if projectVCSServer != nil {
 			client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, p.Key, projectVCSServer)
@@ -0,0 +1,59 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestRetrieveHooksUUID(t *testing.T) {
+	scheduler := func(uuid, cron, payload string) sdk.NodeHook {
+		return sdk.NodeHook{
+			UUID:          uuid,
+			HookModelName: sdk.SchedulerModelName,
+			Config: sdk.WorkflowNodeHookConfig{
+				sdk.SchedulerModelCron: {Value: cron, Configurable: true},
+				sdk.Payload:            {Value: payload, Configurable: true},
+			},
+		}
+	}
+	webhook := sdk.NodeHook{
+		UUID:          "uuid-webhook",
+		HookModelName: sdk.RepositoryWebHookModelName,
+		Config:        sdk.WorkflowNodeHookConfig{sdk.RepositoryWebHookModelBranches: {Value: "master", Configurable: true}},
+	}
+	oldHooks := []sdk.NodeHook{
+		scheduler("uuid-nightly", "0 1 * * *", `{"git.branch": "develop"}`),
+		webhook,
+		scheduler("uuid-weekly", "0 1 * * 0", `{"git.branch": "master"}`),
+	}
+
+	newWebhook := webhook
+	newWebhook.UUID = ""
+	newWebhook.Config = webhook.Config.Clone()
+	newWebhook.Config[sdk.RepositoryWebHookModelBranches] = sdk.WorkflowNodeHookConfigValue{Value: "master,develop", Configurable: true}
+	hooks := []sdk.NodeHook{
+		// same weekly scheduler
+		scheduler("", "0 1 * * 0", `{"git.branch": "master"}`),
+		// the nightly scheduler runs at 2AM
+		scheduler("", "0 2 * * *", `{"git.branch": "develop"}`),
+		// a new scheduler
+		scheduler("", "0 12 * * *", `{"git.branch": "develop"}`),
+		newWebhook,
+	}
+	retrieveHooksUUID(hooks, oldHooks)
+
+	assert.Equal(t, "uuid-weekly", hooks[0].UUID)
+	assert.Equal(t, "uuid-nightly", hooks[1].UUID)
+	assert.Equal(t, "", hooks[2].UUID)
+	assert.Equal(t, "", hooks[3].UUID, "only schedulers are matched when their configuration changed")
+
+	// Two identical hooks can't get the same UUID
+	hooks = []sdk.NodeHook{webhook, webhook}
+	hooks[0].UUID, hooks[1].UUID = "", ""
+	retrieveHooksUUID(hooks, oldHooks)
+	assert.Equal(t, "uuid-webhook", hooks[0].UUID)
+	assert.Equal(t, "", hooks[1].UUID)
+}
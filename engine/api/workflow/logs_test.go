@@ -39,3 +39,10 @@ func Test_truncateStepLogs(t *testing.T) {
 
 	assert.Equal(t, true, truncateServiceLogs(15, 20, logs))
 }
+
+func Test_logExcerpt(t *testing.T) {
+	assert.Equal(t, "3\n4", logExcerpt("1\n2\n3\n4\n", 2, 100))
+	assert.Equal(t, "1\n2", logExcerpt("1\n2\n", 5, 100))
+	assert.Equal(t, "bbbb\ncccc", logExcerpt("aaaa\nbbbb\ncccc", 5, 10))
+	assert.Equal(t, "cccccccccc", logExcerpt("aaaa\ncccccccccccc", 5, 10))
+}
@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"sort"
+
 	"github.com/ovh/cds/sdk"
 )
 
@@ -42,3 +44,43 @@ func MergeArtifactWithPreviousSubRun(runs []sdk.WorkflowNodeRun) []sdk.WorkflowN
 		return artifacts
 	}
 }
+
+// RunArtifacts returns the artifacts of the last sub run of each node of a workflow run, with the name of the node.
+func RunArtifacts(wr *sdk.WorkflowRun) []sdk.NodeRunArtifact {
+	var arts []sdk.NodeRunArtifact
+	for _, runs := range wr.WorkflowNodeRuns {
+		if len(runs) == 0 {
+			continue
+		}
+		sort.Slice(runs, func(i, j int) bool {
+			return runs[i].SubNumber > runs[j].SubNumber
+		})
+		for _, a := range MergeArtifactWithPreviousSubRun(runs) {
+			arts = append(arts, sdk.NodeRunArtifact{NodeName: runs[0].WorkflowNodeName, WorkflowNodeRunArtifact: a})
+		}
+	}
+	return arts
+}
+
+// RunTestsSummary aggregates the tests results and the coverage of the last sub run of each node of a workflow run.
+// The run must be loaded with its tests and its coverage reports.
+func RunTestsSummary(wr *sdk.WorkflowRun) sdk.WorkflowRunTestsSummary {
+	s := sdk.WorkflowRunTestsSummary{
+		WorkflowName: wr.Workflow.Name,
+		Number:       wr.Number,
+		Branch:       wr.TagValue("git.branch"),
+	}
+	for _, runs := range wr.WorkflowNodeRuns {
+		if len(runs) == 0 {
+			continue
+		}
+		sort.Slice(runs, func(i, j int) bool {
+			return runs[i].SubNumber > runs[j].SubNumber
+		})
+		if n, ok := sdk.NewWorkflowNodeRunTestsSummary(runs[0]); ok {
+			s.Nodes = append(s.Nodes, n)
+		}
+	}
+	sort.Slice(s.Nodes, func(i, j int) bool { return s.Nodes[i].NodeName < s.Nodes[j].NodeName })
+	return s
+}
@@ -0,0 +1,107 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/ovh/venom"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	// failedStepLogExcerptLines is the max number of lines at the end of the logs of a failed step sent to VCS servers.
+	failedStepLogExcerptLines = 40
+	// failedStepLogExcerptSize is the max size of the log excerpt of a failed step.
+	failedStepLogExcerptSize = 4096
+	// maxFailedTests is the max number of failed tests sent to VCS servers.
+	maxFailedTests = 50
+)
+
+// loadNodeRunFailures returns the failed steps of a node run with the end of their logs, and the failed tests.
+func loadNodeRunFailures(db gorp.SqlExecutor, nodeRun *sdk.WorkflowNodeRun) (*sdk.WorkflowNodeRunFailures, error) {
+	failures := new(sdk.WorkflowNodeRunFailures)
+
+	for _, s := range nodeRun.Stages {
+		for _, rj := range s.RunJobs {
+			for _, ss := range rj.Job.StepStatus {
+				if ss.Status != sdk.StatusFail {
+					continue
+				}
+				step := sdk.WorkflowNodeRunFailedStep{
+					Stage:     s.Name,
+					Job:       rj.Job.Action.Name,
+					StepOrder: ss.StepOrder,
+				}
+				if ss.StepOrder >= 0 && ss.StepOrder < len(rj.Job.Action.Actions) {
+					a := rj.Job.Action.Actions[ss.StepOrder]
+					step.StepName = a.StepName
+					if step.StepName == "" {
+						step.StepName = a.Name
+					}
+				}
+				logs, err := LoadStepLogs(db, rj.ID, int64(ss.StepOrder))
+				if err != nil {
+					return nil, sdk.WrapError(err, "cannot load logs for job %d on step %d", rj.ID, ss.StepOrder)
+				}
+				if logs != nil {
+					step.LogExcerpt = logExcerpt(logs.Val, failedStepLogExcerptLines, failedStepLogExcerptSize)
+				}
+				failures.Steps = append(failures.Steps, step)
+			}
+		}
+	}
+
+	tests := nodeRun.Tests
+	if tests == nil {
+		nr, err := LoadNodeRunByID(db, nodeRun.ID, LoadRunOptions{WithTests: true})
+		if err != nil {
+			return nil, err
+		}
+		tests = nr.Tests
+	}
+	if tests != nil {
+	suites:
+		for _, ts := range tests.TestSuites {
+			for _, tc := range ts.TestCases {
+				for _, fs := range [][]venom.Failure{tc.Failures, tc.Errors} {
+					for _, f := range fs {
+						if len(failures.Tests) >= maxFailedTests {
+							break suites
+						}
+						failures.Tests = append(failures.Tests, sdk.WorkflowNodeRunFailedTest{
+							TestSuite: ts.Name,
+							TestCase:  tc.Name,
+							Classname: tc.Classname,
+							Message:   f.Message,
+							Details:   logExcerpt(f.Value, failedStepLogExcerptLines, failedStepLogExcerptSize),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	if len(failures.Steps) == 0 && len(failures.Tests) == 0 {
+		return nil, nil
+	}
+	return failures, nil
+}
+
+// logExcerpt returns the last lines of given logs, limited to maxLines lines and maxSize bytes.
+func logExcerpt(logs string, maxLines, maxSize int) string {
+	logs = strings.TrimRight(logs, "\n")
+	lines := strings.Split(logs, "\n")
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	excerpt := strings.Join(lines, "\n")
+	if len(excerpt) > maxSize {
+		excerpt = excerpt[len(excerpt)-maxSize:]
+		// Do not start with a partial line
+		if i := strings.Index(excerpt, "\n"); i >= 0 && i < len(excerpt)-1 {
+			excerpt = excerpt[i+1:]
+		}
+	}
+	return excerpt
+}
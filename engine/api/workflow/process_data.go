@@ -2,7 +2,6 @@ package workflow
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 
 	"github.com/go-gorp/gorp"
@@ -38,9 +37,10 @@ func processWorkflowDataRun(ctx context.Context, db gorp.SqlExecutor, store cach
 	wr.Header.Set(sdk.ProjectKeyHeader, proj.Key)
 
 	// Push data in header to allow tracing
-	if observability.Current(ctx).SpanContext().IsSampled() {
+	if sc := observability.Current(ctx).SpanContext(); sc.IsSampled() {
 		wr.Header.Set(tracingutils.SampledHeader, "1")
-		wr.Header.Set(tracingutils.TraceIDHeader, fmt.Sprintf("%v", observability.Current(ctx).SpanContext().TraceID))
+		wr.Header.Set(tracingutils.TraceIDHeader, sc.TraceID().String())
+		tracingutils.DefaultFormat.Inject(ctx, tracingutils.MapCarrier(wr.Header))
 	}
 	//////
 
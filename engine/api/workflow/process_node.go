@@ -22,6 +22,11 @@ func processNodeTriggers(ctx context.Context, db gorp.SqlExecutor, store cache.S
 	for j := range node.Triggers {
 		t := &node.Triggers[j]
 
+		// A rollback node is only triggered by the failure of its verification
+		if wr.Workflow.WorkflowData.IsRollbackNode(t.ChildNode.Name) {
+			continue
+		}
+
 		var abortTrigger bool
 		if previousRunArray, ok := wr.WorkflowNodeRuns[t.ChildNode.ID]; ok {
 			for _, previousRun := range previousRunArray {
@@ -95,6 +100,20 @@ func processNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		}
 		report.Merge(ctx, r1, nil) // nolint
 		return report, conditionOK, nil
+	case sdk.NodeTypeSubWorkflow:
+		r1, conditionOK, err := processNodeSubWorkflow(ctx, db, store, proj, wr, parentNodeRuns, n, subNumber, manual)
+		if err != nil {
+			return nil, false, sdk.WrapError(err, "unable to processNodeSubWorkflow")
+		}
+		report.Merge(ctx, r1, nil) // nolint
+		return report, conditionOK, nil
+	case sdk.NodeTypeGate:
+		r1, conditionOK, err := processNodeGate(ctx, db, store, proj, wr, parentNodeRuns, n, subNumber, manual)
+		if err != nil {
+			return nil, false, sdk.WrapError(err, "unable to processNodeGate")
+		}
+		report.Merge(ctx, r1, nil) // nolint
+		return report, conditionOK, nil
 	}
 	return nil, false, nil
 }
@@ -236,7 +255,9 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 	// * different repo
 	var vcsInf *vcsInfos
 	var errVcs error
-	if needVCSInfo {
+	if needVCSInfo && app.IsPlainGit() {
+		vcsInf = getPlainGitVCSInfos(currentJobGitValues, app)
+	} else if needVCSInfo {
 		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
 		vcsInf, errVcs = getVCSInfos(ctx, db, store, proj.Key, vcsServer, currentJobGitValues, app.Name, app.VCSServer, app.RepositoryFullname)
 		if errVcs != nil {
@@ -305,6 +326,13 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		}
 	}
 
+	//Check the freeze windows of the project if the workflow deploys
+	if wr.Workflow.Deployment && !sdk.StatusIsTerminated(nr.Status) {
+		if err := checkFreezeWindows(ctx, db, proj, wr, n, nr); err != nil {
+			return nil, false, err
+		}
+	}
+
 	if err := insertWorkflowNodeRun(db, nr); err != nil {
 		return nil, false, sdk.WrapError(err, "unable to insert run (node id : %d, node name : %s, subnumber : %d)", nr.WorkflowNodeID, nr.WorkflowNodeName, nr.SubNumber)
 	}
@@ -340,6 +368,11 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		return nil, false, sdk.WrapError(err, "unable to update workflow run")
 	}
 
+	//A node run queued by a freeze window is executed at the end of the window
+	if nr.Frozen {
+		return report, true, nil
+	}
+
 	//Check the context.mutex to know if we are allowed to run it
 	if n.Context.Mutex {
 		//Check if there are previous waiting or builing workflownoderun
@@ -380,6 +413,18 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		//Mutex is free, continue
 	}
 
+	//Check the context.concurrency_group to know if another run of the group is in progress
+	if n.Context.ConcurrencyGroup != "" {
+		r1, locked, err := checkConcurrencyGroup(ctx, db, proj, wr, n, nr)
+		if err != nil {
+			return nil, false, err
+		}
+		_, _ = report.Merge(ctx, r1, nil)
+		if locked {
+			return report, true, nil
+		}
+	}
+
 	//Execute the node run !
 	r1, err := executeNodeRun(ctx, db, store, proj, nr)
 	if err != nil {
@@ -428,6 +473,10 @@ func createWorkflowNodeRun(wr *sdk.WorkflowRun, n *sdk.Node, parents []*sdk.Work
 		Stages:           stages,
 		Header:           wr.Header,
 	}
+	if n.Context != nil {
+		nodeRun.ConcurrencyGroup = n.Context.ConcurrencyGroup
+		nodeRun.ProductionDeployment = n.Context.ProductionDeployment
+	}
 
 	if nodeRun.SubNumber >= wr.LastSubNumber {
 		wr.LastSubNumber = nodeRun.SubNumber
@@ -60,6 +60,13 @@ func getBuildParameterFromNodeContext(proj *sdk.Project, w *sdk.Workflow, runCon
 		for k, v := range tmp {
 			vars[k] = v
 		}
+
+		// COMPUTE ROLLOUT VARIABLE
+		if runContext.Environment.Rollout != nil {
+			for k, v := range sdk.ParametersFromRollout(*runContext.Environment.Rollout) {
+				vars[k] = v
+			}
+		}
 	}
 
 	// COMPUTE  INTEGRATION VARIABLE
@@ -167,7 +174,14 @@ func getParentParameters(w *sdk.WorkflowRun, nodeRuns []*sdk.WorkflowNodeRun) ([
 				continue
 			}
 
-			if strings.HasPrefix(param.Name, "workflow.") {
+			if strings.HasPrefix(param.Name, "workflow.") || strings.HasPrefix(param.Name, sdk.NodeOutputsPrefix) {
+				parentParams = append(parentParams, param)
+				continue
+			}
+
+			// The outputs exported by the jobs of the parent are available as outputs.nodename.key
+			if sdk.IsNodeRunOutput(param.Name) {
+				param.Name = sdk.NodeOutputParameterName(nodeName, param.Name)
 				parentParams = append(parentParams, param)
 				continue
 			}
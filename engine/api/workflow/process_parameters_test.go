@@ -0,0 +1,37 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestGetParentParametersOutputs(t *testing.T) {
+	wr := &sdk.WorkflowRun{
+		Workflow: sdk.Workflow{
+			WorkflowData: &sdk.WorkflowData{
+				Node: sdk.Node{ID: 1, Name: "build"},
+			},
+		},
+	}
+	parent := &sdk.WorkflowNodeRun{
+		WorkflowNodeID: 1,
+		BuildParameters: []sdk.Parameter{
+			{Name: "cds.output.image_tag", Type: sdk.StringParameter, Value: "v1.2.0"},
+			{Name: "cds.build.foo", Type: sdk.StringParameter, Value: "bar"},
+			{Name: "outputs.root.version", Type: sdk.StringParameter, Value: "1.0.0"},
+		},
+	}
+
+	params, err := getParentParameters(wr, []*sdk.WorkflowNodeRun{parent})
+	require.NoError(t, err)
+	m := sdk.ParametersToMap(params)
+	assert.Equal(t, "v1.2.0", m["outputs.build.image_tag"])
+	assert.Equal(t, "bar", m["workflow.build.build.foo"])
+	assert.Equal(t, "1.0.0", m["outputs.root.version"])
+	_, ok := m["workflow.build.output.image_tag"]
+	assert.False(t, ok)
+}
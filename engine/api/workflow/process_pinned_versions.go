@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/sdk"
+)
+
+// processNodeJobRunPinnedVersions replaces the steps of a job using an action pinned by the workflow with the
+// definition of the pinned version, and requires the pinned version of the plugins used by the job.
+func processNodeJobRunPinnedVersions(ctx context.Context, db gorp.SqlExecutor, pins sdk.WorkflowPinnedVersions, j *sdk.Job) error {
+	if len(pins) == 0 {
+		return nil
+	}
+
+	if err := pinActionVersions(ctx, db, pins, j.Action.Actions); err != nil {
+		return err
+	}
+
+	// add the requirements of the pinned versions that are not already required by the job
+	if j.Action.Enabled {
+		j.Action.Requirements = j.Action.FlattenRequirements()
+	}
+
+	pins.PinPluginRequirements(&j.Action)
+	return nil
+}
+
+func pinActionVersions(ctx context.Context, db gorp.SqlExecutor, pins sdk.WorkflowPinnedVersions, as []sdk.Action) error {
+	for i := range as {
+		a := &as[i]
+		version := pins.Version(a.Name)
+		if version == "" || (a.Type != sdk.DefaultAction && a.Type != sdk.ProjectAction) {
+			if err := pinActionVersions(ctx, db, pins, a.Actions); err != nil {
+				return err
+			}
+			continue
+		}
+
+		v, err := action.LoadVersion(ctx, db, a.ID, version)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "version %s of action %s pinned by the workflow not found", version, a.Name)
+			}
+			return err
+		}
+		a.UseVersion(*v)
+	}
+	return nil
+}
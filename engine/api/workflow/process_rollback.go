@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+)
+
+// processRollback triggers the rollback node of a failed verification node run. The payload of the rollback node
+// run contains the version and the commit of the last deployment of the verified node before the current run.
+func processRollback(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	node := wr.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
+	if node == nil || node.Context == nil || node.Context.VerificationOf == "" || node.Context.RollbackNode == "" {
+		return nil, nil
+	}
+
+	ctx, end := observability.Span(ctx, "workflow.processRollback")
+	defer end()
+
+	rollback := wr.Workflow.WorkflowData.NodeByName(node.Context.RollbackNode)
+	if rollback == nil {
+		return nil, sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "unable to find rollback node %s", node.Context.RollbackNode)
+	}
+
+	previous, err := loadPreviousDeployment(db, wr.WorkflowID, node.Context.VerificationOf, node.Name, wr.Number)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		AddWorkflowRunInfo(wr, true, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeRollbackNoDeployment.ID,
+			Args: []interface{}{node.Name, node.Context.VerificationOf},
+		})
+		return nil, UpdateWorkflowRun(ctx, db, wr)
+	}
+
+	payload := sdk.RollbackPayload(node.Name, node.Context.VerificationOf, *previous)
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeRollback.ID,
+		Args: []interface{}{node.Name, rollback.Name, sdk.ParameterValue(previous.BuildParameters, "cds.version")},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, err
+	}
+
+	// The rollback is triggered on behalf of the user who triggered the verification
+	manual := &sdk.WorkflowNodeRunManual{
+		Payload:  payload,
+		Username: sdk.ParameterValue(nr.BuildParameters, "cds.triggered_by.username"),
+		Fullname: sdk.ParameterValue(nr.BuildParameters, "cds.triggered_by.fullname"),
+		Email:    sdk.ParameterValue(nr.BuildParameters, "cds.triggered_by.email"),
+	}
+	report, _, err := processWorkflowDataRun(ctx, db, store, proj, wr, nil, manual, &rollback.ID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to trigger rollback node %s", rollback.Name)
+	}
+	return report, nil
+}
+
+// loadPreviousDeployment returns the last successful run of a deployment node before given run number, ignoring
+// the runs in which the verification of the deployment failed. It returns nil if there is no such run.
+func loadPreviousDeployment(db gorp.SqlExecutor, workflowID int64, deploymentName, verificationName string, number int64) (*sdk.WorkflowNodeRun, error) {
+	id, err := db.SelectInt(`
+		SELECT d.id
+		FROM workflow_node_run d
+		WHERE d.workflow_id = $1 AND d.workflow_node_name = $2 AND d.num < $3 AND d.status = $4
+		AND NOT EXISTS (
+			SELECT 1 FROM workflow_node_run v
+			WHERE v.workflow_run_id = d.workflow_run_id AND v.workflow_node_name = $5 AND v.status = $6
+		)
+		ORDER BY d.num DESC, d.sub_num DESC
+		LIMIT 1`, workflowID, deploymentName, number, sdk.StatusSuccess, verificationName, sdk.StatusFail)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, sdk.WrapError(err, "cannot load previous deployment of node %s", deploymentName)
+	}
+	if id == 0 {
+		return nil, nil
+	}
+	return LoadNodeRunByID(db, id, LoadRunOptions{})
+}
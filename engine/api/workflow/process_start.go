@@ -101,41 +101,8 @@ func processAllJoins(ctx context.Context, db gorp.SqlExecutor, store cache.Store
 			continue
 		}
 
-		sources := make([]*sdk.WorkflowNodeRun, 0)
-
-		//we have to check noderun for every sources
-		for _, nodeJoin := range j.JoinContext {
-			if _, okF := wr.WorkflowNodeRuns[nodeJoin.ParentID]; okF {
-				// Get latest run on parent
-				sources = append(sources, &wr.WorkflowNodeRuns[nodeJoin.ParentID][0])
-			}
-		}
-
-		//now checks if all sources have been completed
-		var ok = true
-		nodeRunIDs := []int64{}
-		sourcesParams := map[string]string{}
-		for _, nodeRun := range sources {
-			if nodeRun == nil {
-				ok = false
-				break
-			}
-
-			if !sdk.StatusIsTerminated(nodeRun.Status) || nodeRun.Status == sdk.StatusFail || nodeRun.Status == sdk.StatusNeverBuilt || nodeRun.Status == sdk.StatusStopped {
-				ok = false
-				break
-			}
-
-			nodeRunIDs = append(nodeRunIDs, nodeRun.ID)
-			//Merge build parameters from all sources
-			sourcesParams = sdk.ParametersMapMerge(sourcesParams, sdk.ParametersToMap(nodeRun.BuildParameters))
-		}
-
-		if len(sources) != len(j.JoinContext) {
-			ok = false
-		}
-
-		//All the sources are completed
+		// checks if the parents that succeeded satisfy the policy of the join
+		sources, ok := j.JoinSources(wr.WorkflowNodeRuns)
 		if ok {
 			r1, _, err := processNodeRun(ctx, db, store, proj, wr, mapNodes, j, int(wr.LastSubNumber), sources, nil, nil)
 			if err != nil {
@@ -0,0 +1,149 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/interpolate"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// processNodeSubWorkflow inserts the node run of a sub-workflow node at status Waiting, the run of the called
+// workflow is started by the API once the transaction is committed. When the called run is over, the node
+// run takes its status and the children of the node are processed.
+func processNodeSubWorkflow(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun, parentNodeRun []*sdk.WorkflowNodeRun, node *sdk.Node, subNumber int, manual *sdk.WorkflowNodeRunManual) (*ProcessorReport, bool, error) {
+	ctx, end := observability.Span(ctx, "workflow.processNodeSubWorkflow")
+	defer end()
+
+	report := new(ProcessorReport)
+
+	//Check if the sub-workflow node run already exist with the same subnumber
+	for _, nr := range wr.WorkflowNodeRuns[node.ID] {
+		if nr.Number != wr.Number || int(nr.SubNumber) != subNumber {
+			continue
+		}
+		// If the called run is over, let's trigger the children
+		if sdk.StatusIsTerminated(nr.Status) && nr.Status != sdk.StatusStopped {
+			log.Debug("sub-workflow %d is over, we have to reprocess all the things", node.ID)
+			r1, _, err := processWorkflowDataRun(ctx, db, store, proj, wr, nil, nil, nil)
+			if err != nil {
+				return nil, false, sdk.WrapError(err, "unable to process workflow run after sub-workflow")
+			}
+			report.Merge(ctx, r1, nil) // nolint
+		}
+		return report, false, nil
+	}
+
+	parentsIDs := make([]int64, 0, len(parentNodeRun))
+	for _, r := range parentNodeRun {
+		parentsIDs = append(parentsIDs, r.ID)
+	}
+	var nodeRun = sdk.WorkflowNodeRun{
+		WorkflowRunID:    wr.ID,
+		WorkflowID:       wr.Workflow.ID,
+		WorkflowNodeID:   node.ID,
+		WorkflowNodeName: node.Name,
+		Number:           wr.Number,
+		SubNumber:        int64(subNumber),
+		Status:           sdk.StatusWaiting,
+		Start:            time.Now(),
+		LastModified:     time.Now(),
+		SourceNodeRuns:   parentsIDs,
+		UUID:             sdk.UUID(),
+	}
+
+	var err error
+	nodeRun.BuildParameters, err = computeBuildParameters(wr, &nodeRun, parentNodeRun, manual)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// PARENT BUILD PARAMETER
+	if len(parentNodeRun) > 0 {
+		parentsParams, err := getParentParameters(wr, parentNodeRun)
+		if err != nil {
+			return nil, false, sdk.WrapError(err, "getParentParameters failed")
+		}
+		mapBuildParams := sdk.ParametersToMap(nodeRun.BuildParameters)
+		mapParentParams := sdk.ParametersToMap(parentsParams)
+		nodeRun.BuildParameters = sdk.ParametersFromMap(sdk.ParametersMapMerge(mapBuildParams, mapParentParams))
+	}
+
+	if node.Context != nil && !checkCondition(ctx, wr, node.Context.Conditions, nodeRun.BuildParameters) {
+		log.Debug("Condition failed on processNodeSubWorkflow %d/%d %+v", wr.ID, node.ID, nodeRun.BuildParameters)
+		return report, false, nil
+	}
+
+	// Take all parent parameters without any exceptions to interpolate the payload
+	allParentParams := make([]sdk.Parameter, 0, len(nodeRun.BuildParameters))
+	for _, parentNodeRun := range parentNodeRun {
+		allParentParams = append(allParentParams, parentNodeRun.BuildParameters...)
+	}
+	payload, err := interpolate.Do(node.SubWorkflowContext.Payload, sdk.ParametersToMap(allParentParams))
+	if err != nil {
+		return nil, true, sdk.WrapError(err, "unable to interpolate payload %s", node.SubWorkflowContext.Payload)
+	}
+	payloadValues, err := sdk.SubWorkflowPayloadToMap(payload)
+	if err != nil {
+		// The run of the called workflow can't be started, the node run fails
+		payloadValues = map[string]string{}
+		nodeRun.Status = sdk.StatusFail
+		nodeRun.Done = time.Now()
+		nodeRun.Callback = &sdk.WorkflowNodeOutgoingHookRunCallback{
+			Start:  nodeRun.Start,
+			Done:   nodeRun.Done,
+			Status: sdk.StatusFail,
+			Log:    err.Error(),
+		}
+	}
+
+	// Keep the user that triggered the parent run on the called run
+	for _, p := range nodeRun.BuildParameters {
+		if p.Name == "cds.triggered_by.username" || p.Name == "cds.triggered_by.fullname" || p.Name == "cds.triggered_by.email" {
+			if _, ok := payloadValues[p.Name]; !ok {
+				payloadValues[p.Name] = p.Value
+			}
+		}
+	}
+	nodeRun.SubWorkflow = &sdk.WorkflowNodeRunSubWorkflow{
+		WorkflowName: node.SubWorkflowContext.WorkflowName,
+		Payload:      payloadValues,
+	}
+
+	if err := insertWorkflowNodeRun(db, &nodeRun); err != nil {
+		return nil, true, sdk.WrapError(err, "unable to insert run (node id : %d, node name : %s, subnumber : %d)", nodeRun.WorkflowNodeID, nodeRun.WorkflowNodeName, nodeRun.SubNumber)
+	}
+	wr.LastExecution = time.Now()
+
+	if _, ok := sdk.ParametersToMap(nodeRun.BuildParameters)["cds.node.id"]; !ok {
+		sdk.AddParameter(&nodeRun.BuildParameters, "cds.node.id", sdk.StringParameter, fmt.Sprintf("%d", nodeRun.ID))
+	}
+	if err := UpdateNodeRunBuildParameters(db, nodeRun.ID, nodeRun.BuildParameters); err != nil {
+		return nil, false, sdk.WrapError(err, "unable to update workflow node run build parameters")
+	}
+
+	report.Add(ctx, nodeRun)
+
+	//Update workflow run
+	if wr.WorkflowNodeRuns == nil {
+		wr.WorkflowNodeRuns = make(map[int64][]sdk.WorkflowNodeRun)
+	}
+	wr.WorkflowNodeRuns[node.ID] = append(wr.WorkflowNodeRuns[node.ID], nodeRun)
+	sort.Slice(wr.WorkflowNodeRuns[node.ID], func(i, j int) bool {
+		return wr.WorkflowNodeRuns[node.ID][i].SubNumber > wr.WorkflowNodeRuns[node.ID][j].SubNumber
+	})
+	wr.LastSubNumber = MaxSubNumber(wr.WorkflowNodeRuns)
+
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, true, sdk.WrapError(err, "unable to update workflow run")
+	}
+
+	return report, true, nil
+}
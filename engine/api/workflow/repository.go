@@ -141,19 +141,24 @@ func ReadCDSFiles(files map[string][]byte) (*tar.Reader, error) {
 }
 
 type exportedEntities struct {
-	wrkflw exportentities.Workflow
-	apps   map[string]exportentities.Application
-	pips   map[string]exportentities.PipelineV1
-	envs   map[string]exportentities.Environment
+	wrkflw  exportentities.Workflow
+	wrkflws map[string]exportentities.Workflow
+	apps    map[string]exportentities.Application
+	pips    map[string]exportentities.PipelineV1
+	envs    map[string]exportentities.Environment
+	actions map[string]exportentities.Action
 }
 
 func extractFromCDSFiles(ctx context.Context, tr *tar.Reader) (*exportedEntities, error) {
 	var res = exportedEntities{
-		apps: make(map[string]exportentities.Application),
-		pips: make(map[string]exportentities.PipelineV1),
-		envs: make(map[string]exportentities.Environment),
+		wrkflws: make(map[string]exportentities.Workflow),
+		apps:    make(map[string]exportentities.Application),
+		pips:    make(map[string]exportentities.PipelineV1),
+		envs:    make(map[string]exportentities.Environment),
+		actions: make(map[string]exportentities.Action),
 	}
 
+	includes := make(map[string]exportentities.PipelineInclude)
 	mError := new(sdk.MultiError)
 	for {
 		hdr, err := tr.Next()
@@ -200,6 +205,25 @@ func extractFromCDSFiles(ctx context.Context, tr *tar.Reader) (*exportedEntities
 				continue
 			}
 			res.envs[hdr.Name] = env
+		case strings.Contains(hdr.Name, ".act."):
+			var act exportentities.Action
+			if err := yaml.Unmarshal(b, &act); err != nil {
+				log.Error(ctx, "Push> Unable to unmarshal action %s: %v", hdr.Name, err)
+				mError.Append(fmt.Errorf("Unable to unmarshal action %s: %v", hdr.Name, err))
+				continue
+			}
+			res.actions[hdr.Name] = act
+		case strings.Contains(hdr.Name, ".inc."):
+			var inc exportentities.PipelineInclude
+			if err := yaml.Unmarshal(b, &inc); err != nil {
+				log.Error(ctx, "Push> Unable to unmarshal included file %s: %v", hdr.Name, err)
+				mError.Append(fmt.Errorf("Unable to unmarshal included file %s: %v", hdr.Name, err))
+				continue
+			}
+			if inc.Name == "" {
+				inc.Name = strings.Split(hdr.Name, ".inc.")[0]
+			}
+			includes[inc.Name] = inc
 		default:
 			// if a workflow was already found, it's a mistake
 			if workflowFileName != "" {
@@ -212,7 +236,20 @@ func extractFromCDSFiles(ctx context.Context, tr *tar.Reader) (*exportedEntities
 				mError.Append(fmt.Errorf("Unable to unmarshal workflow %s: %v", hdr.Name, err))
 				continue
 			}
+			res.wrkflws[hdr.Name] = res.wrkflw
+		}
+	}
+
+	// Includes can only be resolved once all the files are read
+	for name, pip := range res.pips {
+		if len(pip.Include) == 0 {
+			continue
+		}
+		if err := pip.ResolveIncludes(includes); err != nil {
+			mError.Append(fmt.Errorf("Unable to include files in %s: %v", name, sdk.Cause(err)))
+			continue
 		}
+		res.pips[name] = pip
 	}
 
 	// We only use the multiError during unmarshalling steps.
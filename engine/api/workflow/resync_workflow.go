@@ -133,6 +133,10 @@ func ResyncNodeRunsWithCommits(ctx context.Context, db gorp.SqlExecutor, store c
 				}
 			}
 
+			if _, err := ComputeChangelog(context.TODO(), db, store, proj, &wr.Workflow, &nr, &app, curVCSInfos); err != nil {
+				log.Error(ctx, "ResyncNodeRuns> Unable to compute changelog of node run %d: %v", nr.ID, err)
+			}
+
 			tagsUpdated := false
 			if curVCSInfos.Branch != "" && curVCSInfos.Tag == "" {
 				tagsUpdated = wr.Tag(tagGitBranch, curVCSInfos.Branch)
@@ -0,0 +1,233 @@
+package workflow
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// logsArchive writes files in a zip or a tar.gz archive.
+type logsArchive interface {
+	add(name string, modTime time.Time, content []byte) error
+	Close() error
+}
+
+type zipLogsArchive struct {
+	w *zip.Writer
+}
+
+func (a *zipLogsArchive) add(name string, modTime time.Time, content []byte) error {
+	h := &zip.FileHeader{Name: name, Method: zip.Deflate}
+	h.SetModTime(modTime)
+	f, err := a.w.CreateHeader(h)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	_, err = f.Write(content)
+	return sdk.WithStack(err)
+}
+
+func (a *zipLogsArchive) Close() error {
+	return sdk.WithStack(a.w.Close())
+}
+
+type tarGzLogsArchive struct {
+	gz *gzip.Writer
+	w  *tar.Writer
+}
+
+func (a *tarGzLogsArchive) add(name string, modTime time.Time, content []byte) error {
+	if err := a.w.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return sdk.WithStack(err)
+	}
+	_, err := a.w.Write(content)
+	return sdk.WithStack(err)
+}
+
+func (a *tarGzLogsArchive) Close() error {
+	if err := a.w.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(a.gz.Close())
+}
+
+func newLogsArchive(w io.Writer, format string) (logsArchive, error) {
+	switch format {
+	case sdk.WorkflowRunLogsArchiveZip:
+		return &zipLogsArchive{w: zip.NewWriter(w)}, nil
+	case sdk.WorkflowRunLogsArchiveTarGz:
+		gz := gzip.NewWriter(w)
+		return &tarGzLogsArchive{gz: gz, w: tar.NewWriter(gz)}, nil
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid logs archive format %q", format)
+}
+
+// runLogsLoader loads the logs of the jobs of a workflow run.
+type runLogsLoader interface {
+	stepLogs(jobRunID, stepOrder int64) (*sdk.Log, error)
+	serviceLogs(jobRunID int64) ([]sdk.ServiceLog, error)
+}
+
+type dbRunLogsLoader struct {
+	db gorp.SqlExecutor
+}
+
+func (l dbRunLogsLoader) stepLogs(jobRunID, stepOrder int64) (*sdk.Log, error) {
+	logs, err := LoadStepLogs(l.db, jobRunID, stepOrder)
+	return logs, sdk.WrapError(err, "cannot load logs for job %d on step %d", jobRunID, stepOrder)
+}
+
+func (l dbRunLogsLoader) serviceLogs(jobRunID int64) ([]sdk.ServiceLog, error) {
+	logs, err := LoadServicesLogsByJob(l.db, jobRunID)
+	return logs, sdk.WrapError(err, "cannot load service logs for job %d", jobRunID)
+}
+
+// WriteRunLogsArchive writes in w a zip or tar.gz archive that contains the logs of all the steps and services of
+// the workflow run, organized by node/stage/job, with a manifest that describes each file.
+// Logs are loaded one step at a time and written directly to w, so the memory used does not depend on the size of the
+// run and the generation follows the pace of the reader.
+func WriteRunLogsArchive(ctx context.Context, db gorp.SqlExecutor, w io.Writer, format string, wr sdk.WorkflowRun) error {
+	return writeRunLogsArchive(ctx, dbRunLogsLoader{db: db}, w, format, wr)
+}
+
+func writeRunLogsArchive(ctx context.Context, loader runLogsLoader, w io.Writer, format string, wr sdk.WorkflowRun) error {
+	a, err := newLogsArchive(w, format)
+	if err != nil {
+		return err
+	}
+
+	manifest := sdk.WorkflowRunLogsManifest{
+		ProjectKey:   wr.Workflow.ProjectKey,
+		WorkflowName: wr.Workflow.Name,
+		Number:       wr.Number,
+		Status:       wr.Status,
+		Generated:    time.Now(),
+		Files:        []sdk.WorkflowRunLogsManifestEntry{},
+	}
+
+	var nodeRuns []sdk.WorkflowNodeRun
+	for _, nrs := range wr.WorkflowNodeRuns {
+		nodeRuns = append(nodeRuns, nrs...)
+	}
+	sort.Slice(nodeRuns, func(i, j int) bool { return nodeRuns[i].ID < nodeRuns[j].ID })
+
+	for _, nr := range nodeRuns {
+		for _, s := range nr.Stages {
+			for _, rj := range s.RunJobs {
+				if err := ctx.Err(); err != nil {
+					return sdk.WithStack(err)
+				}
+				entries, err := addJobLogsToArchive(a, loader, nr, s, rj)
+				if err != nil {
+					return err
+				}
+				manifest.Files = append(manifest.Files, entries...)
+			}
+		}
+	}
+
+	btes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := a.add(sdk.WorkflowRunLogsManifestFilename, manifest.Generated, btes); err != nil {
+		return err
+	}
+	return a.Close()
+}
+
+func addJobLogsToArchive(a logsArchive, loader runLogsLoader, nr sdk.WorkflowNodeRun, s sdk.Stage, rj sdk.WorkflowNodeJobRun) ([]sdk.WorkflowRunLogsManifestEntry, error) {
+	var entries []sdk.WorkflowRunLogsManifestEntry
+	jobPath := sdk.WorkflowRunLogsJobPath(nr.WorkflowNodeName, nr.SubNumber, s.Name, rj.Job.Action.Name, rj.ID)
+
+	for _, ss := range rj.Job.StepStatus {
+		order := int64(ss.StepOrder)
+		logs, err := loader.stepLogs(rj.ID, order)
+		if err != nil {
+			return nil, err
+		}
+		if logs == nil {
+			continue
+		}
+
+		var stepName string
+		if ss.StepOrder >= 0 && ss.StepOrder < len(rj.Job.Action.Actions) {
+			step := rj.Job.Action.Actions[ss.StepOrder]
+			stepName = step.StepName
+			if stepName == "" {
+				stepName = step.Name
+			}
+		}
+
+		e := sdk.WorkflowRunLogsManifestEntry{
+			Path:      fmt.Sprintf("%s/step.%d.%s.log", jobPath, order, sdk.WorkflowRunLogsPathElement(stepName)),
+			Node:      nr.WorkflowNodeName,
+			SubNumber: nr.SubNumber,
+			NodeRunID: nr.ID,
+			Stage:     s.Name,
+			Job:       rj.Job.Action.Name,
+			JobRunID:  rj.ID,
+			StepOrder: &order,
+			StepName:  stepName,
+			Status:    ss.Status,
+			Start:     logs.Start,
+			Done:      logs.Done,
+			Size:      int64(len(logs.Val)),
+		}
+		if err := a.add(e.Path, logsModTime(logs.LastModified, rj.Done), []byte(logs.Val)); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	servicesLogs, err := loader.serviceLogs(rj.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sl := range servicesLogs {
+		e := sdk.WorkflowRunLogsManifestEntry{
+			Path:        fmt.Sprintf("%s/service.%s.log", jobPath, sdk.WorkflowRunLogsPathElement(sl.ServiceRequirementName)),
+			Node:        nr.WorkflowNodeName,
+			SubNumber:   nr.SubNumber,
+			NodeRunID:   nr.ID,
+			Stage:       s.Name,
+			Job:         rj.Job.Action.Name,
+			JobRunID:    rj.ID,
+			ServiceName: sl.ServiceRequirementName,
+			Status:      rj.Status,
+			Start:       sl.Start,
+			Size:        int64(len(sl.Val)),
+		}
+		if err := a.add(e.Path, logsModTime(sl.LastModified, rj.Done), []byte(sl.Val)); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func logsModTime(lastModified *time.Time, done time.Time) time.Time {
+	if lastModified != nil && !lastModified.IsZero() {
+		return *lastModified
+	}
+	if !done.IsZero() {
+		return done
+	}
+	return time.Now()
+}
@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+type testRunLogsLoader struct {
+	steps    map[int64]map[int64]string
+	services map[int64][]sdk.ServiceLog
+}
+
+func (l testRunLogsLoader) stepLogs(jobRunID, stepOrder int64) (*sdk.Log, error) {
+	v, ok := l.steps[jobRunID][stepOrder]
+	if !ok {
+		return nil, nil
+	}
+	return &sdk.Log{JobID: jobRunID, StepOrder: stepOrder, Val: v}, nil
+}
+
+func (l testRunLogsLoader) serviceLogs(jobRunID int64) ([]sdk.ServiceLog, error) {
+	return l.services[jobRunID], nil
+}
+
+func testRunForLogsArchive() sdk.WorkflowRun {
+	job := func(id int64, name string, steps ...string) sdk.WorkflowNodeJobRun {
+		rj := sdk.WorkflowNodeJobRun{ID: id, Status: sdk.StatusSuccess}
+		rj.Job.Action.Name = name
+		for i, s := range steps {
+			rj.Job.Action.Actions = append(rj.Job.Action.Actions, sdk.Action{Name: "Script", StepName: s})
+			rj.Job.StepStatus = append(rj.Job.StepStatus, sdk.StepStatus{StepOrder: i, Status: sdk.StatusSuccess})
+		}
+		return rj
+	}
+	return sdk.WorkflowRun{
+		Number:   12,
+		Status:   sdk.StatusSuccess,
+		Workflow: sdk.Workflow{ProjectKey: "KEY", Name: "my-workflow"},
+		WorkflowNodeRuns: map[int64][]sdk.WorkflowNodeRun{
+			1: {
+				{ID: 11, WorkflowNodeName: "build", SubNumber: 1, Stages: []sdk.Stage{{Name: "Stage 1", RunJobs: []sdk.WorkflowNodeJobRun{job(101, "Compile", "go build", "")}}}},
+				{ID: 10, WorkflowNodeName: "build", SubNumber: 0, Stages: []sdk.Stage{{Name: "Stage 1", RunJobs: []sdk.WorkflowNodeJobRun{job(100, "Compile", "go build", "")}}}},
+			},
+			2: {
+				{ID: 20, WorkflowNodeName: "deploy", SubNumber: 0, Stages: []sdk.Stage{{Name: "Deploy", RunJobs: []sdk.WorkflowNodeJobRun{job(200, "Deploy/Prod", "deploy")}}}},
+			},
+		},
+	}
+}
+
+func testLoaderForLogsArchive() testRunLogsLoader {
+	return testRunLogsLoader{
+		steps: map[int64]map[int64]string{
+			100: {0: "build 0\n", 1: "script 0\n"},
+			101: {0: "build 1\n"},
+			200: {0: "deploy\n"},
+		},
+		services: map[int64][]sdk.ServiceLog{
+			200: {{ServiceRequirementName: "pg", Val: "database ready\n"}},
+		},
+	}
+}
+
+var expectedLogsArchiveFiles = map[string]string{
+	"build.0/Stage_1/Compile.100/step.0.go_build.log":   "build 0\n",
+	"build.0/Stage_1/Compile.100/step.1.Script.log":     "script 0\n",
+	"build.1/Stage_1/Compile.101/step.0.go_build.log":   "build 1\n",
+	"deploy.0/Deploy/Deploy_Prod.200/step.0.deploy.log": "deploy\n",
+	"deploy.0/Deploy/Deploy_Prod.200/service.pg.log":    "database ready\n",
+}
+
+func checkLogsArchiveContent(t *testing.T, files map[string]string, names []string) {
+	require.Contains(t, files, sdk.WorkflowRunLogsManifestFilename)
+	assert.Equal(t, sdk.WorkflowRunLogsManifestFilename, names[len(names)-1])
+
+	var manifest sdk.WorkflowRunLogsManifest
+	require.NoError(t, json.Unmarshal([]byte(files[sdk.WorkflowRunLogsManifestFilename]), &manifest))
+	assert.Equal(t, "KEY", manifest.ProjectKey)
+	assert.Equal(t, "my-workflow", manifest.WorkflowName)
+	assert.Equal(t, int64(12), manifest.Number)
+	require.Len(t, manifest.Files, len(expectedLogsArchiveFiles))
+
+	for _, e := range manifest.Files {
+		content, ok := expectedLogsArchiveFiles[e.Path]
+		require.True(t, ok, "unexpected file %s", e.Path)
+		assert.Equal(t, content, files[e.Path])
+		assert.Equal(t, int64(len(content)), e.Size)
+	}
+	assert.Equal(t, "build.0/Stage_1/Compile.100/step.0.go_build.log", manifest.Files[0].Path)
+	assert.Equal(t, "go build", manifest.Files[0].StepName)
+	require.NotNil(t, manifest.Files[1].StepOrder)
+	assert.Equal(t, int64(1), *manifest.Files[1].StepOrder)
+	assert.Equal(t, "pg", manifest.Files[4].ServiceName)
+}
+
+func Test_writeRunLogsArchiveZip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeRunLogsArchive(context.TODO(), testLoaderForLogsArchive(), &buf, sdk.WorkflowRunLogsArchiveZip, testRunForLogsArchive()))
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	var names []string
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		btes, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(btes)
+		names = append(names, f.Name)
+	}
+	checkLogsArchiveContent(t, files, names)
+}
+
+func Test_writeRunLogsArchiveTarGz(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeRunLogsArchive(context.TODO(), testLoaderForLogsArchive(), &buf, sdk.WorkflowRunLogsArchiveTarGz, testRunForLogsArchive()))
+
+	gz, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	r := tar.NewReader(gz)
+	files := map[string]string{}
+	var names []string
+	for {
+		h, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		btes, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		files[h.Name] = string(btes)
+		names = append(names, h.Name)
+	}
+	checkLogsArchiveContent(t, files, names)
+}
+
+func Test_writeRunLogsArchiveInvalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeRunLogsArchive(context.TODO(), testLoaderForLogsArchive(), &buf, "rar", testRunForLogsArchive())
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+}
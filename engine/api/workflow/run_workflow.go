@@ -36,7 +36,10 @@ func runFromHook(ctx context.Context, db gorp.SqlExecutor, store cache.Store, p
 	report := new(ProcessorReport)
 
 	var h *sdk.NodeHook
-	if sdk.IsValidUUID(e.WorkflowNodeHookUUID) {
+	if e.IsSubWorkflowCall() {
+		// A workflow called by a sub-workflow node is started from its root node, without hook
+		h = &sdk.NodeHook{NodeID: wr.Workflow.WorkflowData.Node.ID}
+	} else if sdk.IsValidUUID(e.WorkflowNodeHookUUID) {
 		hooks := wr.Workflow.WorkflowData.GetHooks()
 		h = hooks[e.WorkflowNodeHookUUID]
 	} else {
@@ -22,7 +22,7 @@ func Export(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *s
 	ctx, end := observability.Span(ctx, "workflow.Export")
 	defer end()
 
-	wf, errload := Load(ctx, db, cache, proj, name, LoadOptions{})
+	wf, errload := Load(ctx, db, cache, proj, name, LoadOptions{WithIntegrations: true})
 	if errload != nil {
 		return 0, sdk.WrapError(errload, "workflow.Export> Cannot load workflow %s", name)
 	}
@@ -64,7 +64,8 @@ func Pull(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk
 	var wp exportentities.WorkflowPulled
 
 	options := LoadOptions{
-		DeepPipeline: true,
+		DeepPipeline:     true,
+		WithIntegrations: true,
 	}
 	wf, errload := Load(ctx, db, cache, proj, name, options)
 	if errload != nil {
@@ -49,22 +49,21 @@ func Import(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *s
 	}
 
 	// Retrieve existing hook
-	oldHooksByRef := oldW.WorkflowData.GetHooksMapRef()
+	withoutUUID := make(map[int]bool, len(w.WorkflowData.Node.Hooks))
+	for i := range w.WorkflowData.Node.Hooks {
+		withoutUUID[i] = w.WorkflowData.Node.Hooks[i].UUID == ""
+	}
+	retrieveHooksUUID(w.WorkflowData.Node.Hooks, oldW.WorkflowData.Node.Hooks)
+	oldHooks := oldW.WorkflowData.GetHooks()
 	for i := range w.WorkflowData.Node.Hooks {
 		h := &w.WorkflowData.Node.Hooks[i]
-		if h.UUID == "" {
-			if oldH, has := oldHooksByRef[h.Ref()]; has {
-				if len(h.Config) == 0 {
-					h.Config = oldH.Config.Clone()
-					// the oldW can have a different name than the workflow to import
-					//we have to rename the workflow name in the hook config retrieve from old workflow
-					h.Config[sdk.HookConfigWorkflow] = sdk.WorkflowNodeHookConfigValue{
-						Value:        w.Name,
-						Configurable: false,
-					}
-				}
-				h.UUID = oldH.UUID
-				continue
+		if oldH, has := oldHooks[h.UUID]; has && withoutUUID[i] && len(h.Config) == 0 {
+			h.Config = oldH.Config.Clone()
+			// the oldW can have a different name than the workflow to import
+			//we have to rename the workflow name in the hook config retrieve from old workflow
+			h.Config[sdk.HookConfigWorkflow] = sdk.WorkflowNodeHookConfigValue{
+				Value:        w.Name,
+				Configurable: false,
 			}
 		}
 	}
@@ -41,6 +41,10 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 		if !node.IsLinkedToRepo(&wr.Workflow) {
 			continue
 		}
+		// Commit statuses can't be sent for a raw git repository, there is no repositories manager
+		if wr.Workflow.Applications[node.Context.ApplicationID].IsPlainGit() {
+			continue
+		}
 		vcsServerName = wr.Workflow.Applications[node.Context.ApplicationID].VCSServer
 		repoFullName = wr.Workflow.Applications[node.Context.ApplicationID].RepositoryFullname
 
@@ -136,6 +140,10 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 	}
 
 	app = wr.Workflow.Applications[node.Context.ApplicationID]
+	if app.IsPlainGit() {
+		log.Debug("sendVCSEventStatus> commit statuses are disabled for application %s linked to a raw git repository", app.Name)
+		return nil
+	}
 	if node.Context.PipelineID > 0 {
 		pip = wr.Workflow.Pipelines[node.Context.PipelineID]
 	}
@@ -233,10 +241,42 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 				Report:     report,
 				URL:        url,
 			}
+
+			notif, err := loadVCSNotificationWithNodeID(db, wr.WorkflowID, node.ID)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load notification")
+			}
+			eventWNR.GerritChange.Labels = sdk.UserNotificationLabelsVotes(notif.Settings.Labels, nodeRun.Status)
+
+			if sdk.StatusIsTerminated(nodeRun.Status) {
+				comments, err := LoadCodeComments(ctx, db, nodeRun.ID)
+				if err != nil {
+					log.Error(ctx, "sendVCSEventStatus> unable to load code comments of node run %d: %v", nodeRun.ID, err)
+				}
+				eventWNR.GerritChange.Comments = comments
+			}
 		}
 
 	}
 
+	if nodeRun.Status == sdk.StatusFail {
+		failures, err := loadNodeRunFailures(db, nodeRun)
+		if err != nil {
+			log.Error(ctx, "sendVCSEventStatus> unable to load failures of node run %d: %v", nodeRun.ID, err)
+		}
+		eventWNR.Failures = failures
+	}
+
+	if sdk.StatusIsTerminated(nodeRun.Status) {
+		arts, err := loadArtifactByNodeRunID(db, nodeRun.ID)
+		if err != nil {
+			log.Error(ctx, "sendVCSEventStatus> unable to load artifacts of node run %d: %v", nodeRun.ID, err)
+		}
+		for _, a := range arts {
+			eventWNR.Artifacts = append(eventWNR.Artifacts, a.Name)
+		}
+	}
+
 	evt := sdk.Event{
 		EventType:       fmt.Sprintf("%T", eventWNR),
 		Payload:         structs.Map(eventWNR),
@@ -330,3 +370,64 @@ func sendVCSPullRequestComment(ctx context.Context, db gorp.SqlExecutor, store c
 
 	return nil
 }
+
+// SendVCSPullRequestTestsSummary posts the summary of the unit tests and the coverage of a terminated workflow run
+// on the pull request of its branch. It has to be enabled on the VCS notification of the root node.
+func SendVCSPullRequestTestsSummary(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun) error {
+	if !sdk.StatusIsTerminated(wr.Status) {
+		return nil
+	}
+
+	root := wr.Workflow.WorkflowData.Node
+	if !root.IsLinkedToRepo(&wr.Workflow) {
+		return nil
+	}
+	notif, err := loadVCSNotificationWithNodeID(db, wr.WorkflowID, root.ID)
+	if err != nil {
+		return sdk.WrapError(err, "cannot load notification")
+	}
+	if notif.ID == 0 || notif.Settings.Template == nil || notif.Settings.Template.TestsSummary == nil || !*notif.Settings.Template.TestsSummary {
+		return nil
+	}
+
+	rootRun := wr.RootRun()
+	if rootRun == nil {
+		return nil
+	}
+
+	// Reload the run with the tests results and the coverage reports of its nodes
+	run, err := LoadRunByID(db, wr.ID, LoadRunOptions{WithLightTests: true, WithCoverage: true})
+	if err != nil {
+		return err
+	}
+	summary := RunTestsSummary(run)
+	if summary.IsEmpty() {
+		return nil
+	}
+
+	app := wr.Workflow.Applications[root.Context.ApplicationID]
+	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
+	if vcsServer == nil {
+		return nil
+	}
+
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
+	if err != nil {
+		return sdk.WrapError(err, "cannot get client")
+	}
+
+	prs, err := client.PullRequests(ctx, app.RepositoryFullname)
+	if err != nil {
+		return sdk.WrapError(err, "unable to get pull requests on repo %s", app.RepositoryFullname)
+	}
+	for _, pr := range prs {
+		if pr.Head.Branch.DisplayID == rootRun.VCSBranch && pr.Head.Branch.LatestCommit == rootRun.VCSHash && !pr.Merged && !pr.Closed {
+			if err := client.PullRequestComment(ctx, app.RepositoryFullname, pr.ID, summary.Markdown()); err != nil {
+				return sdk.WrapError(err, "unable to send tests summary on pull request %d", pr.ID)
+			}
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultCoverageTrendLimit = 50
+
+// getWorkflowCoverageTrendHandler returns the coverage of the applications in the last runs of a workflow,
+// optionally filtered on a branch.
+func (api *API) getWorkflowCoverageTrendHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		limit, err := FormInt(r, "limit")
+		if err != nil {
+			return err
+		}
+		if limit <= 0 {
+			limit = defaultCoverageTrendLimit
+		}
+
+		ts, err := workflow.LoadCoverageTrend(api.mustDB(), key, name, FormString(r, "branch"), limit)
+		if err != nil {
+			return err
+		}
+		if ts == nil {
+			ts = []sdk.WorkflowCoverageTrend{}
+		}
+
+		return service.WriteJSON(w, ts, http.StatusOK)
+	}
+}
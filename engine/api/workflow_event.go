@@ -19,6 +19,7 @@ func WorkflowSendEvent(ctx context.Context, db gorp.SqlExecutor, store cache.Sto
 	}
 	for _, wr := range report.Workflows() {
 		event.PublishWorkflowRun(ctx, wr, key)
+		recordWorkflowRunMetrics(ctx, store, key, wr)
 	}
 	for _, wnr := range report.Nodes() {
 		wr, errWR := workflow.LoadRunByID(db, wnr.WorkflowRunID, workflow.LoadRunOptions{
@@ -40,7 +41,16 @@ func WorkflowSendEvent(ctx context.Context, db gorp.SqlExecutor, store cache.Sto
 			}
 		}
 
+		if wnr.Changelog == nil {
+			c, err := workflow.LoadChangelogByNodeRunID(ctx, db, wnr.ID)
+			if err != nil {
+				log.Warning(ctx, "WorkflowSendEvent> Cannot load changelog of node run %d: %s", wnr.ID, err)
+			}
+			wnr.Changelog = c
+		}
+
 		event.PublishWorkflowNodeRun(ctx, db, store, wnr, wr.Workflow, &previousNodeRun)
+		recordWorkflowNodeRunMetrics(ctx, store, key, wr.Workflow.Name, wnr)
 	}
 
 	for _, jobrun := range report.Jobs() {
@@ -57,5 +67,6 @@ func WorkflowSendEvent(ctx context.Context, db gorp.SqlExecutor, store cache.Sto
 			continue
 		}
 		event.PublishWorkflowNodeJobRun(ctx, db, key, *wr, jobrun)
+		recordWorkflowNodeJobRunMetrics(ctx, store, key, wr.Workflow.Name, jobrun)
 	}
 }
@@ -5,14 +5,17 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
+	"github.com/ovh/cds/sdk/log"
 )
 
 func (api *API) getWorkflowExportHandler() service.Handler {
@@ -37,16 +40,34 @@ func (api *API) getWorkflowExportHandler() service.Handler {
 			return sdk.WrapError(err, "Format invalid")
 		}
 
-		proj, err := project.Load(api.mustDB(), api.Cache, key, project.LoadOptions.WithIntegrations)
+		// the export is cached until the workflow or its project changes
+		entity := cache.WorkflowEntity(key, name)
+		cacheKey := cache.Key("export", format, strconv.FormatBool(withPermissions))
+		var export string
+		found, err := api.EntityCache.Get(entity, cacheKey, &export)
 		if err != nil {
-			return sdk.WrapError(err, "unable to load projet")
+			log.Warning(ctx, "getWorkflowExportHandler> cannot get export from cache: %v", err)
 		}
-		if _, err := workflow.Export(ctx, api.mustDB(), api.Cache, proj, name, f, w, opts...); err != nil {
-			return sdk.WithStack(err)
+		if !found {
+			db := api.mustDBReplica()
+
+			proj, err := project.Load(db, api.Cache, key, project.LoadOptions.WithIntegrations)
+			if err != nil {
+				return sdk.WrapError(err, "unable to load projet")
+			}
+			var buf bytes.Buffer
+			if _, err := workflow.Export(ctx, db, api.Cache, proj, name, f, &buf, opts...); err != nil {
+				return sdk.WithStack(err)
+			}
+			export = buf.String()
+			if err := api.EntityCache.Set(entity, cacheKey, export); err != nil {
+				log.Warning(ctx, "getWorkflowExportHandler> cannot set export in cache: %v", err)
+			}
 		}
 
 		w.Header().Add("Content-Type", exportentities.GetContentType(f))
-		return nil
+		_, err = io.WriteString(w, export)
+		return sdk.WithStack(err)
 	}
 }
 
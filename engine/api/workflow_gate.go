@@ -0,0 +1,330 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	// gateSchedulerInterval is the delay between two checks of the gate node runs to open or to expire.
+	gateSchedulerInterval = 5 * time.Second
+	// gateBatchSize is the max count of gate node runs opened or expired at each check.
+	gateBatchSize = 20
+)
+
+var gateWebhookHTTPClient = &http.Client{Timeout: 5 * time.Second}
+
+// workflowGateSignature is the content of the signed callback url of a gate node run.
+type workflowGateSignature struct {
+	ProjectKey    string `json:"project_key"`
+	WorkflowRunID int64  `json:"workflow_run_id"`
+	NodeRunID     int64  `json:"node_run_id"`
+}
+
+// gateScheduler opens the gates of the gate node runs and takes the decision of the gates for which the timeout
+// is over. The node runs are locked while they are updated, so each API instance can run the scheduler.
+func (api *API) gateScheduler(ctx context.Context) {
+	tick := time.NewTicker(gateSchedulerInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			if api.Maintenance {
+				continue
+			}
+			if err := api.openGates(ctx); err != nil {
+				log.Error(ctx, "gateScheduler> %v", err)
+			}
+			if err := api.expireGates(ctx); err != nil {
+				log.Error(ctx, "gateScheduler> %v", err)
+			}
+		}
+	}
+}
+
+// loadGateProject loads the project and the workflow run of a gate node run with what is needed to process the run.
+func (api *API) loadGateProject(db gorp.SqlExecutor, nr *sdk.WorkflowNodeRun) (*sdk.Project, *sdk.WorkflowRun, error) {
+	wr, err := workflow.LoadRunByID(db, nr.WorkflowRunID, workflow.LoadRunOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	proj, err := project.LoadByID(db, api.Cache, wr.ProjectID,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithFeatures,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithApplicationVariables,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+	)
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "unable to load project %d", wr.ProjectID)
+	}
+	return proj, wr, nil
+}
+
+// gateCallbackURL returns the signed url to which the decision of a gate node run is posted, it expires with the gate.
+func (api *API) gateCallbackURL(projectKey string, nr *sdk.WorkflowNodeRun) (*sdk.WorkflowNodeRunGateURL, error) {
+	signature, err := authentication.SignJWS(workflowGateSignature{
+		ProjectKey:    projectKey,
+		WorkflowRunID: nr.WorkflowRunID,
+		NodeRunID:     nr.ID,
+	}, time.Until(nr.Gate.Expire))
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.WorkflowNodeRunGateURL{
+		URL:    fmt.Sprintf("%s/workflow/gate/%s", api.Config.URL.API, signature),
+		Expire: nr.Gate.Expire,
+	}, nil
+}
+
+func (api *API) openGates(ctx context.Context) error {
+	db := api.mustDB()
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WrapError(err, "cannot start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	nodeRuns, err := workflow.LoadAndLockGateNodeRunsToOpen(ctx, tx, gateBatchSize)
+	if err != nil {
+		return err
+	}
+
+	reports := make(map[string]*workflow.ProcessorReport)
+	for i := range nodeRuns {
+		nr := &nodeRuns[i]
+		proj, wr, err := api.loadGateProject(tx, nr)
+		if err != nil {
+			return err
+		}
+
+		callback := sdk.WorkflowNodeOutgoingHookRunCallback{
+			Start:  time.Now(),
+			Status: sdk.StatusBuilding,
+			Log:    fmt.Sprintf("Waiting for a decision until %s", nr.Gate.Expire.Format(time.RFC3339)),
+		}
+		if nr.Gate.Webhook != "" {
+			if err := api.notifyGateWebhook(ctx, proj, wr, nr); err != nil {
+				log.Warning(ctx, "openGates> unable to notify webhook of gate %s on %s/%s#%d: %v", nr.WorkflowNodeName, proj.Key, wr.Workflow.Name, wr.Number, err)
+				callback.Log += fmt.Sprintf("\nUnable to notify %s: %v", nr.Gate.Webhook, err)
+			} else {
+				callback.Log += fmt.Sprintf("\n%s has been notified", nr.Gate.Webhook)
+			}
+		}
+
+		r1, err := workflow.UpdateOutgoingHookRunStatus(ctx, tx, api.Cache, proj, wr, nr.UUID, callback)
+		if err != nil {
+			return sdk.WrapError(err, "unable to update gate node run %d", nr.ID)
+		}
+		if reports[proj.Key] == nil {
+			reports[proj.Key] = new(workflow.ProcessorReport)
+		}
+		reports[proj.Key].Merge(ctx, r1, nil) // nolint
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "unable to commit transaction")
+	}
+
+	for key, report := range reports {
+		go WorkflowSendEvent(context.Background(), db, api.Cache, key, report)
+	}
+	return nil
+}
+
+// notifyGateWebhook posts the callback url of a gate node run to the webhook of the gate.
+func (api *API) notifyGateWebhook(ctx context.Context, proj *sdk.Project, wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun) error {
+	callbackURL, err := api.gateCallbackURL(proj.Key, nr)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(sdk.WorkflowNodeRunGateNotification{
+		ProjectKey:   proj.Key,
+		WorkflowName: wr.Workflow.Name,
+		Number:       wr.Number,
+		NodeName:     nr.WorkflowNodeName,
+		URL:          fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", api.Config.URL.UI, proj.Key, wr.Workflow.Name, wr.Number),
+		CallbackURL:  callbackURL.URL,
+		Expire:       callbackURL.Expire,
+	})
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, nr.Gate.Webhook, bytes.NewReader(payload))
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CDS/"+sdk.VERSION)
+
+	resp, err := gateWebhookHTTPClient.Do(req)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer resp.Body.Close() // nolint
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (api *API) expireGates(ctx context.Context) error {
+	db := api.mustDB()
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WrapError(err, "cannot start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	nodeRuns, err := workflow.LoadAndLockExpiredGateNodeRuns(ctx, tx, gateBatchSize)
+	if err != nil {
+		return err
+	}
+
+	reports := make(map[string]*workflow.ProcessorReport)
+	for i := range nodeRuns {
+		nr := &nodeRuns[i]
+		proj, wr, err := api.loadGateProject(tx, nr)
+		if err != nil {
+			return err
+		}
+
+		r1, err := workflow.CloseGate(ctx, tx, api.Cache, proj, wr, nr, sdk.GateTimeoutDecision(*nr.Gate))
+		if err != nil {
+			return sdk.WrapError(err, "unable to close gate node run %d", nr.ID)
+		}
+		if reports[proj.Key] == nil {
+			reports[proj.Key] = new(workflow.ProcessorReport)
+		}
+		reports[proj.Key].Merge(ctx, r1, nil) // nolint
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "unable to commit transaction")
+	}
+
+	for key, report := range reports {
+		go WorkflowSendEvent(context.Background(), db, api.Cache, key, report)
+	}
+	return nil
+}
+
+func (api *API) postWorkflowNodeRunGateURLHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+
+		nr, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, id, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run %d", id)
+		}
+		if nr.Gate == nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "node %s is not a gate", nr.WorkflowNodeName)
+		}
+		if sdk.StatusIsTerminated(nr.Status) || nr.Gate.IsExpired(time.Now()) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "gate %s is closed", nr.WorkflowNodeName)
+		}
+
+		u, err := api.gateCallbackURL(key, nr)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, u, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowGateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		var sig workflowGateSignature
+		if err := authentication.VerifyJWS(vars["signature"], &sig); err != nil {
+			return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+		}
+
+		var decision sdk.WorkflowNodeRunGateDecision
+		if err := service.UnmarshalBody(r, &decision); err != nil {
+			return err
+		}
+		if err := decision.IsValid(); err != nil {
+			return err
+		}
+
+		db := api.mustDB()
+		tx, err := db.Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		nr, err := workflow.LoadAndLockNodeRunByID(ctx, tx, sig.NodeRunID)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run %d", sig.NodeRunID)
+		}
+		if nr.WorkflowRunID != sig.WorkflowRunID || nr.Gate == nil {
+			return sdk.WithStack(sdk.ErrUnauthorized)
+		}
+		// The gate can be closed by a previous decision, by the timeout or if the run was stopped
+		if nr.Status != sdk.StatusBuilding {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "gate %s is closed", nr.WorkflowNodeName)
+		}
+
+		proj, wr, err := api.loadGateProject(tx, nr)
+		if err != nil {
+			return err
+		}
+		if proj.Key != sig.ProjectKey {
+			return sdk.WithStack(sdk.ErrUnauthorized)
+		}
+
+		report, err := workflow.CloseGate(ctx, tx, api.Cache, proj, wr, nr, decision)
+		if err != nil {
+			return sdk.WrapError(err, "unable to close gate node run %d", nr.ID)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "unable to commit transaction")
+		}
+
+		log.Info(ctx, "postWorkflowGateHandler> gate %s on %s/%s#%d closed: %s", nr.WorkflowNodeName, proj.Key, wr.Workflow.Name, wr.Number, decision.String())
+
+		go WorkflowSendEvent(context.Background(), db, api.Cache, proj.Key, report)
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
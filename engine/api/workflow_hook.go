@@ -137,6 +137,11 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 					}
 					models = append(models, m[i])
 				}
+			case sdk.PullRequestHookModelName:
+				if repoWebHookEnable && len(sdk.FilterPullRequestVCSEvents(webHookInfo.Events)) > 0 {
+					m[i].Icon = webHookInfo.Icon
+					models = append(models, m[i])
+				}
 			case sdk.GitPollerModelName:
 				if repoPollerEnable {
 					models = append(models, m[i])
@@ -352,3 +352,51 @@ func (api *API) postWorkflowPushHandler() service.Handler {
 		return service.WriteJSON(w, msgListString, http.StatusOK)
 	}
 }
+
+func (api *API) postProjectPushHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		observability.Current(ctx,
+			observability.Tag(observability.TagProjectKey, key),
+		)
+
+		if r.Body == nil {
+			return sdk.WithStack(sdk.ErrWrongRequest)
+		}
+		btes, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.ErrWrongRequest)
+		}
+		defer r.Body.Close()
+
+		u := getAPIConsumer(ctx)
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key,
+			project.LoadOptions.WithGroups,
+			project.LoadOptions.WithApplications,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithIntegrations)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		allMsg, wfs, oldWfs, err := workflow.PushProject(ctx, api.mustDB(), api.Cache, proj, tar.NewReader(bytes.NewReader(btes)), u, project.DecryptWithBuiltinKey)
+		if err != nil {
+			return err
+		}
+
+		for i := range wfs {
+			if oldWfs[i] != nil {
+				event.PublishWorkflowUpdate(ctx, proj.Key, wfs[i], *oldWfs[i], u)
+			} else {
+				event.PublishWorkflowAdd(ctx, proj.Key, wfs[i], u)
+			}
+		}
+
+		return service.WriteJSON(w, translate(r, allMsg), http.StatusOK)
+	}
+}
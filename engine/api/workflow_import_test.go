@@ -1,6 +1,7 @@
 package api
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"io/ioutil"
@@ -921,3 +922,65 @@ metadata:
 	t.Logf("%+v", wUpdated.WorkflowData)
 	assert.Equal(t, 1, len(wUpdated.WorkflowData.Joins))
 }
+
+func Test_postProjectPushHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	u, pass := assets.InsertAdminUser(t, db)
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+
+	push := func(files map[string]string) *httptest.ResponseRecorder {
+		buf := new(bytes.Buffer)
+		tw := tar.NewWriter(buf)
+		for name, content := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+
+		uri := api.Router.GetRoute("POST", api.postProjectPushHandler, map[string]string{"permProjectKey": proj.Key})
+		test.NotEmpty(t, uri)
+		req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, nil)
+		req.Body = ioutil.NopCloser(buf)
+		req.Header.Set("Content-Type", "application/tar")
+		rec := httptest.NewRecorder()
+		api.Router.Mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	pip := `version: v1.0
+name: pip1
+jobs:
+- job: build
+  steps:
+  - script:
+    - echo "build"
+`
+
+	// nothing is imported if one of the workflows is invalid
+	rec := push(map[string]string{
+		"pip1.pip.yml": pip,
+		"wf1.yml":      "name: wf1\nversion: v1.0\npipeline: pip1\n",
+		"wf2.yml":      "name: wf2\nversion: v1.0\npipeline: unknown\n",
+	})
+	assert.NotEqual(t, 200, rec.Code)
+	_, err := pipeline.LoadPipeline(context.TODO(), db, proj.Key, "pip1", false)
+	assert.Error(t, err)
+	exists, err := workflow.Exists(db, proj.Key, "wf1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	rec = push(map[string]string{
+		"pip1.pip.yml": pip,
+		"wf1.yml":      "name: wf1\nversion: v1.0\npipeline: pip1\n",
+		"wf2.yml":      "name: wf2\nversion: v1.0\npipeline: pip1\n",
+	})
+	require.Equal(t, 200, rec.Code, rec.Body.String())
+	for _, name := range []string{"wf1", "wf2"} {
+		exists, err := workflow.Exists(db, proj.Key, name)
+		require.NoError(t, err)
+		assert.True(t, exists, name)
+	}
+}
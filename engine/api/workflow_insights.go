@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultInsightsDays = 7
+
+// getWorkflowInsightsHandler returns the percentiles of the time spent by the jobs of a workflow in the queue,
+// waiting for a worker and executing, by node and by worker model, over the last days.
+func (api *API) getWorkflowInsightsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		days, err := FormInt(r, "days")
+		if err != nil {
+			return err
+		}
+		if days <= 0 {
+			days = defaultInsightsDays
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow %s/%s", key, name)
+		}
+
+		to := time.Now()
+		from := to.AddDate(0, 0, -days)
+		jobs, err := workflow.LoadInsightJobs(api.mustDB(), wf.ID, from, to)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.ComputeWorkflowInsights(jobs, from, to), http.StatusOK)
+	}
+}
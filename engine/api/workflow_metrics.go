@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// workflowMetricsRecordedTTL is the time during which the events of a run with an already recorded status are
+// ignored, the duplicated events are sent right after the first one.
+const workflowMetricsRecordedTTL = 10 * time.Minute
+
+// Metrics of the workflow runs by project and workflow, recorded from the events sent once the runs are updated.
+var (
+	workflowRunsByStatus     *stats.Int64Measure
+	workflowNodeRunDuration  *stats.Float64Measure
+	workflowJobQueueDuration *stats.Float64Measure
+)
+
+func initWorkflowRunMetrics() error {
+	workflowRunsByStatus = stats.Int64("cds/cds-api/workflow_runs_terminated", "number of terminated workflow runs", stats.UnitDimensionless)
+	workflowNodeRunDuration = stats.Float64("cds/cds-api/workflow_node_run_duration", "duration of the pipelines in seconds", "s")
+	workflowJobQueueDuration = stats.Float64("cds/cds-api/workflow_job_queue_duration", "time spent by the jobs in the queue in seconds", "s")
+
+	tagProjectKey := observability.MustNewKey(observability.TagProjectKey)
+	tagWorkflow := observability.MustNewKey(observability.TagWorkflow)
+	tags := []tag.Key{tagProjectKey, tagWorkflow, tagStatus}
+
+	return observability.RegisterView(
+		observability.NewViewCount("cds/workflow_runs_by_status", workflowRunsByStatus, tags),
+		&view.View{
+			Name:        "cds/workflow_node_run_duration",
+			Description: workflowNodeRunDuration.Description(),
+			Measure:     workflowNodeRunDuration,
+			TagKeys:     tags,
+			Aggregation: observability.DefaultDurationDistribution,
+		},
+		&view.View{
+			Name:        "cds/workflow_job_queue_duration",
+			Description: workflowJobQueueDuration.Description(),
+			Measure:     workflowJobQueueDuration,
+			TagKeys:     []tag.Key{tagProjectKey, tagWorkflow},
+			Aggregation: observability.DefaultDurationDistribution,
+		},
+	)
+}
+
+func workflowMetricsContext(ctx context.Context, key, workflowName, status string) context.Context {
+	return observability.ContextWithTag(ctx,
+		observability.TagProjectKey, key,
+		observability.TagWorkflow, workflowName,
+		"status", status,
+	)
+}
+
+// shouldRecordWorkflowMetrics returns true only the first time it is called for a run with a given status. The
+// events of a run can be sent several times with the same status, and by several API instances.
+func shouldRecordWorkflowMetrics(ctx context.Context, store cache.Store, kind string, id int64, status string) bool {
+	k := cache.Key("api:workflow:metrics", kind, strconv.FormatInt(id, 10), status)
+	ok, err := store.SetNX(k, workflowMetricsRecordedTTL)
+	if err != nil {
+		log.Warning(ctx, "shouldRecordWorkflowMetrics> unable to set %s: %v", k, err)
+		return false
+	}
+	return ok
+}
+
+// recordWorkflowRunMetrics counts the workflow runs when they are terminated.
+func recordWorkflowRunMetrics(ctx context.Context, store cache.Store, key string, wr sdk.WorkflowRun) {
+	if !sdk.StatusIsTerminated(wr.Status) || !shouldRecordWorkflowMetrics(ctx, store, "run", wr.ID, wr.Status) {
+		return
+	}
+	observability.Record(workflowMetricsContext(ctx, key, wr.Workflow.Name, wr.Status), workflowRunsByStatus, 1)
+}
+
+// recordWorkflowNodeRunMetrics records the duration of the pipelines when they are terminated.
+func recordWorkflowNodeRunMetrics(ctx context.Context, store cache.Store, key, workflowName string, wnr sdk.WorkflowNodeRun) {
+	if !sdk.StatusIsTerminated(wnr.Status) || wnr.Start.IsZero() || wnr.Done.Before(wnr.Start) {
+		return
+	}
+	if !shouldRecordWorkflowMetrics(ctx, store, "node", wnr.ID, wnr.Status) {
+		return
+	}
+	observability.RecordFloat64(workflowMetricsContext(ctx, key, workflowName, wnr.Status), workflowNodeRunDuration, wnr.Done.Sub(wnr.Start).Seconds())
+}
+
+// recordWorkflowNodeJobRunMetrics records the time spent by the jobs in the queue when they are taken by a worker.
+func recordWorkflowNodeJobRunMetrics(ctx context.Context, store cache.Store, key, workflowName string, job sdk.WorkflowNodeJobRun) {
+	if job.Status != sdk.StatusBuilding || job.Start.IsZero() || job.Start.Before(job.Queued) {
+		return
+	}
+	if !shouldRecordWorkflowMetrics(ctx, store, "job", job.ID, job.Status) {
+		return
+	}
+	ctx = observability.ContextWithTag(ctx,
+		observability.TagProjectKey, key,
+		observability.TagWorkflow, workflowName,
+	)
+	observability.RecordFloat64(ctx, workflowJobQueueDuration, job.Start.Sub(job.Queued).Seconds())
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestShouldRecordWorkflowMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	store, err := cache.NewLocalStore(ctx, "", 60, 0)
+	require.NoError(t, err)
+
+	// A run sent several times with the same status is recorded once
+	assert.True(t, shouldRecordWorkflowMetrics(ctx, store, "run", 1, sdk.StatusFail))
+	assert.False(t, shouldRecordWorkflowMetrics(ctx, store, "run", 1, sdk.StatusFail))
+
+	// A run restarted is recorded again with its new status
+	assert.True(t, shouldRecordWorkflowMetrics(ctx, store, "run", 1, sdk.StatusSuccess))
+	assert.True(t, shouldRecordWorkflowMetrics(ctx, store, "node", 1, sdk.StatusFail))
+	assert.True(t, shouldRecordWorkflowMetrics(ctx, store, "run", 2, sdk.StatusFail))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ovh/venom"
 	"github.com/sguiheux/go-coverage"
 
+	workerauth "github.com/ovh/cds/engine/api/authentication/worker"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/group"
@@ -38,6 +39,11 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 			return sdk.WithStack(sdk.ErrForbidden)
 		}
 
+		// A worker spawned for a job can't take another one
+		if jobID := getAPIConsumer(ctx).WorkerJobRunID(); jobID != 0 && jobID != id {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "worker token is scoped to job %d", jobID)
+		}
+
 		wk, err := worker.LoadByID(ctx, api.mustDB(), getAPIConsumer(ctx).Worker.ID)
 		if err != nil {
 			return err
@@ -49,9 +55,10 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 		}
 
 		// Load worker model
+		var wm *sdk.Model
 		var workerModelName string
 		if wk.ModelID != nil {
-			wm, err := workermodel.LoadByID(api.mustDB(), *wk.ModelID)
+			wm, err = workermodel.LoadByID(api.mustDB(), *wk.ModelID)
 			if err != nil {
 				return sdk.WithStack(sdk.ErrNoWorkerModel)
 			}
@@ -76,7 +83,7 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 		}
 
 		pbji := &sdk.WorkflowNodeJobRunData{}
-		report, err := takeJob(ctx, api.mustDB, api.Cache, p, id, workerModelName, pbji, wk)
+		report, err := takeJob(ctx, api.mustDB, api.Cache, p, id, workerModelName, wm, pbji, wk)
 		if err != nil {
 			return sdk.WrapError(err, "cannot takeJob nodeJobRunID:%d", id)
 		}
@@ -88,7 +95,7 @@ func (api *API) postTakeWorkflowJobHandler() service.Handler {
 	}
 }
 
-func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, id int64, workerModel string, wnjri *sdk.WorkflowNodeJobRunData, wk *sdk.Worker) (*workflow.ProcessorReport, error) {
+func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, id int64, workerModel string, wm *sdk.Model, wnjri *sdk.WorkflowNodeJobRunData, wk *sdk.Worker) (*workflow.ProcessorReport, error) {
 	// Start a tx
 	tx, errBegin := dbFunc().Begin()
 	if errBegin != nil {
@@ -163,6 +170,16 @@ func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store,
 	wnjri.Secrets = append(wnjri.Secrets, secretsKeys...)
 	wnjri.NodeJobRun.Parameters = append(wnjri.NodeJobRun.Parameters, params...)
 
+	// Keep what is used by the job to reproduce the run later
+	plugins, err := loadJobPluginBinaries(ctx, tx, wnjri.NodeJobRun.Job.Action, wk)
+	if err != nil {
+		return nil, err
+	}
+	manifestJob := sdk.NewWorkflowNodeRunManifestJob(wnjri.NodeJobRun, wnjri.Secrets, *wk, wm, plugins)
+	if err := workflow.AppendNodeRunManifestJob(tx, noderun, manifestJob); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, sdk.WrapError(err, "Cannot commit transaction")
 	}
@@ -228,6 +245,43 @@ func (api *API) getWorkflowJobHandler() service.Handler {
 	}
 }
 
+func (api *API) postCodeCommentsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return sdk.WrapError(err, "invalid id")
+		}
+
+		nr, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{
+			DisableDetailledNodeRun: true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run")
+		}
+
+		var comments []sdk.WorkflowNodeRunCodeComment
+		if err := service.UnmarshalBody(r, &comments); err != nil {
+			return sdk.WrapError(err, "unable to read body")
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "unable to start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		if err := workflow.InsertCodeComments(ctx, tx, nr.ID, id, comments); err != nil {
+			return err
+		}
+
+		return sdk.WithStack(tx.Commit())
+	}
+}
+
 func (api *API) postVulnerabilityReportHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		if isWorker := isWorker(ctx); !isWorker {
@@ -370,6 +424,13 @@ func (api *API) postWorkflowJobResultHandler() service.Handler {
 			return sdk.WrapError(err, "unable to post job result")
 		}
 
+		// The worker spawned for this job can only unregister now
+		if getAPIConsumer(ctx).WorkerJobRunID() == id {
+			if err := workerauth.ExpireSessions(ctx, api.mustDB(), getAPIConsumer(ctx).ID); err != nil {
+				log.Error(ctx, "postWorkflowJobResultHandler> unable to expire sessions of worker %s: %v", wk.Name, err)
+			}
+		}
+
 		workflowRuns := report.WorkflowRuns()
 		if len(workflowRuns) > 0 {
 			observability.Current(ctx,
@@ -508,6 +569,9 @@ func postJobResult(ctx context.Context, dbFunc func(context.Context) *gorp.DbMap
 					if err := workflow.ResyncCommitStatus(context.Background(), dbFunc(context.Background()), store, proj, wRun); err != nil {
 						log.Error(ctx, "workflow.UpdateNodeJobRunStatus> %v", err)
 					}
+					if err := workflow.SendVCSPullRequestTestsSummary(context.Background(), dbFunc(context.Background()), store, proj, wRun); err != nil {
+						log.Error(ctx, "workflow.SendVCSPullRequestTestsSummary> %v", err)
+					}
 				}
 			}(run)
 		}
@@ -632,6 +696,7 @@ func (api *API) postWorkflowJobStepStatusHandler() service.Handler {
 				}
 				if sdk.StatusIsTerminated(step.Status) {
 					jobStep.Done = step.Done
+					jobStep.Metrics = step.Metrics
 				}
 				found = true
 				break
@@ -857,6 +922,7 @@ func (api *API) postWorkflowJobCoverageResultsHandler() service.Handler {
 
 		// update
 		existingReport.Report = report
+		existingReport.Percent, _ = sdk.CoveragePercent(report)
 		if err := workflow.ComputeLatestDefaultBranchReport(ctx, api.mustDB(), api.Cache, p, wnr, &existingReport); err != nil {
 			return sdk.WrapError(err, "cannot compute default branch coverage report")
 		}
@@ -869,6 +935,36 @@ func (api *API) postWorkflowJobCoverageResultsHandler() service.Handler {
 	}
 }
 
+// getWorkflowJobCoverageHandler returns the coverage of the node run of a job with the baseline of the default
+// branch, without the details of the files.
+func (api *API) getWorkflowJobCoverageHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		wnr, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run")
+		}
+
+		cov, err := workflow.LoadCoverageReport(api.mustDB(), wnr.ID)
+		if err != nil {
+			return err
+		}
+		cov.Report.Files = nil
+		cov.Trend.CurrentBranch.Files = nil
+		cov.Trend.DefaultBranch.Files = nil
+
+		return service.WriteJSON(w, cov, http.StatusOK)
+	}
+}
+
 func (api *API) postWorkflowJobTestsResultsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		if isWorker := isWorker(ctx); !isWorker {
@@ -907,6 +1003,11 @@ func (api *API) postWorkflowJobTestsResultsHandler() service.Handler {
 			nr.Tests = &venom.Tests{}
 		}
 
+		// the results are kept with the names of the test suites, before they are made unique in the node run
+		if err := workflow.InsertTestResults(tx, sdk.NewWorkflowRunTestResults(*nr, new.TestSuites)); err != nil {
+			return err
+		}
+
 		for k := range new.TestSuites {
 			for i := range nr.Tests.TestSuites {
 				if nr.Tests.TestSuites[i].Name == new.TestSuites[k].Name {
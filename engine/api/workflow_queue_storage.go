@@ -3,8 +3,11 @@ package api
 import (
 	"context"
 	"encoding/base64"
+	"io"
+	"io/ioutil"
 	"mime"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
@@ -12,111 +15,164 @@ import (
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/quota"
 	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
-func (api *API) postWorkflowJobStaticFilesHandler() service.Handler {
-	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
-		if isWorker := isWorker(ctx); !isWorker {
-			return sdk.WithStack(sdk.ErrForbidden)
-		}
+// streamMultipartUpload reads a multipart request part by part without buffering it in memory.
+// Form values are collected and the file part is given to fileFunc with all the values read before it.
+// Workers send form values before the file, if the file is sent first it is spooled in a temporary
+// file on disk until all values are read.
+func streamMultipartUpload(r *http.Request, fileName string, requiredValue string, fileFunc func(values map[string]string, file io.Reader) error) error {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
 
-		vars := mux.Vars(r)
-		name := vars["name"]
+	var spooled *os.File
+	defer func() {
+		if spooled != nil {
+			_ = spooled.Close()
+			_ = os.Remove(spooled.Name())
+		}
+	}()
 
-		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Disposition"))
+	values := make(map[string]string)
+	var fileFound bool
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return sdk.WrapError(err, "cannot read Content Disposition header")
+			return service.BodyReadError(r, sdk.NewError(sdk.ErrWrongRequest, err))
 		}
-		fileName := params["filename"]
 
-		//parse the multipart form in the request
-		if err := r.ParseMultipartForm(100000); err != nil {
-			return sdk.WrapError(err, "error parsing multipart form")
+		if part.FormName() != fileName {
+			// Form values are small, avoid reading unbounded data
+			btes, err := ioutil.ReadAll(io.LimitReader(part, 4096))
+			if err != nil {
+				return service.BodyReadError(r, sdk.WrapError(err, "cannot read form value %s", part.FormName()))
+			}
+			values[part.FormName()] = string(btes)
+			continue
 		}
-		//get a ref to the parsed multipart form
-		m := r.MultipartForm
 
-		var entrypoint string
-		if len(m.Value["entrypoint"]) > 0 {
-			entrypoint = m.Value["entrypoint"][0]
+		// Only one file is expected, an earlier part may already be spooled on disk
+		if fileFound {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "file %s is sent several times", fileName)
 		}
-
-		var staticKey string
-		if len(m.Value["static_key"]) > 0 {
-			staticKey = m.Value["static_key"][0]
+		fileFound = true
+		if _, ok := values[requiredValue]; ok {
+			if err := fileFunc(values, part); err != nil {
+				return service.BodyReadError(r, err)
+			}
+			continue
 		}
 
-		var nodeJobRunIDStr string
-		if len(m.Value["nodeJobRunID"]) > 0 {
-			nodeJobRunIDStr = m.Value["nodeJobRunID"][0]
-		}
-		nodeJobRunID, err := strconv.ParseInt(nodeJobRunIDStr, 10, 64)
+		spooled, err = ioutil.TempFile("", "cds-upload-")
 		if err != nil {
-			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrInvalidID, "invalid node job run ID: %v", nodeJobRunIDStr))
+			return sdk.WrapError(err, "cannot create temporary file")
 		}
-
-		if fileName == "" {
-			return sdk.WrapError(sdk.ErrWrongRequest, "Content-Disposition header is not set")
+		if _, err := io.Copy(spooled, part); err != nil {
+			return service.BodyReadError(r, sdk.WrapError(err, "cannot read file %s", fileName))
 		}
+	}
 
-		nodeJobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, nodeJobRunID)
-		if err != nil {
-			return sdk.WrapError(err, "cannot load node job run")
+	if spooled != nil {
+		if _, err := spooled.Seek(0, io.SeekStart); err != nil {
+			return sdk.WithStack(err)
 		}
+		return fileFunc(values, spooled)
+	}
 
-		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
-		if err != nil {
-			return sdk.WrapError(err, "cannot load node run")
-		}
+	if !fileFound {
+		// There is no file to store, keep the metadata
+		return fileFunc(values, nil)
+	}
+	return nil
+}
 
-		staticFile := sdk.StaticFiles{
-			Name:         name,
-			EntryPoint:   entrypoint,
-			StaticKey:    staticKey,
-			WorkflowID:   nodeRun.WorkflowID,
-			NodeRunID:    nodeJobRun.WorkflowNodeRunID,
-			NodeJobRunID: nodeJobRunID,
+func (api *API) postWorkflowJobStaticFilesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
 		}
 
-		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars["permProjectKey"], vars["integrationName"])
+		vars := mux.Vars(r)
+		name := vars["name"]
+
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Disposition"))
 		if err != nil {
-			return err
+			return sdk.WrapError(err, "cannot read Content Disposition header")
+		}
+		fileName := params["filename"]
+		if fileName == "" {
+			return sdk.WrapError(sdk.ErrWrongRequest, "Content-Disposition header is not set")
 		}
 
-		if staticFile.StaticKey != "" {
-			if err := storageDriver.Delete(ctx, &staticFile); err != nil {
-				return sdk.WrapError(err, "cannot delete existing static files")
+		var staticFile sdk.StaticFiles
+		if err := streamMultipartUpload(r, fileName, "nodeJobRunID", func(values map[string]string, file io.Reader) error {
+			nodeJobRunID, err := strconv.ParseInt(values["nodeJobRunID"], 10, 64)
+			if err != nil {
+				return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrInvalidID, "invalid node job run ID: %v", values["nodeJobRunID"]))
 			}
-		}
 
-		id := storageDriver.GetProjectIntegration().ID
-		if id > 0 {
-			staticFile.ProjectIntegrationID = &id
-		}
+			nodeJobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, nodeJobRunID)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load node job run")
+			}
 
-		files := m.File[fileName]
-		if len(files) == 1 {
-			file, err := files[0].Open()
+			nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
 			if err != nil {
-				return sdk.WrapError(err, "cannot open file")
+				return sdk.WrapError(err, "cannot load node run")
+			}
+
+			staticFile = sdk.StaticFiles{
+				Name:         name,
+				EntryPoint:   values["entrypoint"],
+				StaticKey:    values["static_key"],
+				WorkflowID:   nodeRun.WorkflowID,
+				NodeRunID:    nodeJobRun.WorkflowNodeRunID,
+				NodeJobRunID: nodeJobRunID,
 			}
-			defer file.Close()
 
-			publicURL, err := storageDriver.ServeStaticFiles(&staticFile, staticFile.EntryPoint, file)
+			storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars["permProjectKey"], vars["integrationName"])
 			if err != nil {
-				return sdk.WrapError(err, "cannot serve static files in store")
+				return err
+			}
+
+			if staticFile.StaticKey != "" {
+				if err := storageDriver.Delete(ctx, &staticFile); err != nil {
+					return sdk.WrapError(err, "cannot delete existing static files")
+				}
 			}
-			staticFile.PublicURL = publicURL
-		}
 
-		if err := workflow.InsertStaticFiles(api.mustDB(), &staticFile); err != nil {
-			_ = storageDriver.Delete(ctx, &staticFile)
-			return sdk.WrapError(err, "cannot insert static files in database")
+			id := storageDriver.GetProjectIntegration().ID
+			if id > 0 {
+				staticFile.ProjectIntegrationID = &id
+			}
+
+			if file != nil {
+				publicURL, err := storageDriver.ServeStaticFiles(&staticFile, staticFile.EntryPoint, ioutil.NopCloser(file))
+				if err != nil {
+					return sdk.WrapError(err, "cannot serve static files in store")
+				}
+				staticFile.PublicURL = publicURL
+			}
+
+			if err := workflow.InsertStaticFiles(api.mustDB(), &staticFile); err != nil {
+				_ = storageDriver.Delete(ctx, &staticFile)
+				return sdk.WrapError(err, "cannot insert static files in database")
+			}
+			return nil
+		}); err != nil {
+			return err
 		}
+
 		return service.WriteJSON(w, staticFile, http.StatusOK)
 	}
 }
@@ -136,118 +192,91 @@ func (api *API) postWorkflowJobArtifactHandler() service.Handler {
 		}
 
 		fileName := params["filename"]
-
-		//parse the multipart form in the request
-		if err := r.ParseMultipartForm(100000); err != nil {
-			return sdk.WrapError(err, "error parsing multipart form")
-		}
-		//get a ref to the parsed multipart form
-		m := r.MultipartForm
-
-		var sizeStr, permStr, md5sum, sha512sum, nodeJobRunIDStr string
-		if len(m.Value["size"]) > 0 {
-			sizeStr = m.Value["size"][0]
-		}
-		if len(m.Value["perm"]) > 0 {
-			permStr = m.Value["perm"][0]
-		}
-		if len(m.Value["md5sum"]) > 0 {
-			md5sum = m.Value["md5sum"][0]
-		}
-		if len(m.Value["sha512sum"]) > 0 {
-			sha512sum = m.Value["sha512sum"][0]
-		}
-		if len(m.Value["nodeJobRunID"]) > 0 {
-			nodeJobRunIDStr = m.Value["nodeJobRunID"][0]
-		}
-		nodeJobRunID, err := strconv.ParseInt(nodeJobRunIDStr, 10, 64)
-		if err != nil {
-			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrInvalidID, "invalid node job run ID"))
-		}
-
 		if fileName == "" {
 			log.Warning(ctx, "Content-Disposition header is not set")
 			return sdk.WrapError(sdk.ErrWrongRequest, "Content-Disposition header is not set")
 		}
 
-		nodeJobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, nodeJobRunID)
+		tag, err := base64.RawURLEncoding.DecodeString(ref)
 		if err != nil {
-			return sdk.WrapError(err, "cannot load node job run")
+			return sdk.WrapError(err, "cannot decode ref")
 		}
 
-		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{WithArtifacts: true, DisableDetailledNodeRun: true})
-		if err != nil {
-			return sdk.WrapError(err, "cannot load node run")
-		}
+		return streamMultipartUpload(r, fileName, "nodeJobRunID", func(values map[string]string, file io.Reader) error {
+			nodeJobRunID, err := strconv.ParseInt(values["nodeJobRunID"], 10, 64)
+			if err != nil {
+				return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrInvalidID, "invalid node job run ID"))
+			}
 
-		hash, err := sdk.GenerateHash()
-		if err != nil {
-			return sdk.WrapError(err, "could not generate hash")
-		}
+			nodeJobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, nodeJobRunID)
+			if err != nil {
+				return sdk.WrapError(err, "cannot load node job run")
+			}
 
-		var size int64
-		var perm uint64
+			nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{WithArtifacts: true, DisableDetailledNodeRun: true})
+			if err != nil {
+				return sdk.WrapError(err, "cannot load node run")
+			}
 
-		if sizeStr != "" {
-			size, _ = strconv.ParseInt(sizeStr, 10, 64)
-		}
+			hash, err := sdk.GenerateHash()
+			if err != nil {
+				return sdk.WrapError(err, "could not generate hash")
+			}
 
-		if permStr != "" {
-			perm, _ = strconv.ParseUint(permStr, 10, 32)
-		}
+			var size int64
+			var perm uint64
 
-		tag, err := base64.RawURLEncoding.DecodeString(ref)
-		if err != nil {
-			return sdk.WrapError(err, "cannot decode ref")
-		}
+			if values["size"] != "" {
+				size, _ = strconv.ParseInt(values["size"], 10, 64)
+			}
 
-		art := sdk.WorkflowNodeRunArtifact{
-			Name:              fileName,
-			Tag:               string(tag),
-			Ref:               ref,
-			DownloadHash:      hash,
-			Size:              size,
-			Perm:              uint32(perm),
-			MD5sum:            md5sum,
-			SHA512sum:         sha512sum,
-			WorkflowNodeRunID: nodeRun.ID,
-			WorkflowID:        nodeRun.WorkflowRunID,
-			Created:           time.Now(),
-		}
+			if values["perm"] != "" {
+				perm, _ = strconv.ParseUint(values["perm"], 10, 32)
+			}
 
-		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars["permProjectKey"], vars["integrationName"])
-		if err != nil {
-			return err
-		}
-		id := storageDriver.GetProjectIntegration().ID
-		if id > 0 {
-			art.ProjectIntegrationID = &id
-		}
+			if err := quota.CheckArtifact(ctx, api.mustDB(), nodeJobRun.ProjectID, size); err != nil {
+				return err
+			}
 
-		files := m.File[fileName]
-		if len(files) == 1 {
-			file, err := files[0].Open()
-			if err != nil {
-				file.Close()
-				return sdk.WrapError(err, "cannot open file")
+			art := sdk.WorkflowNodeRunArtifact{
+				Name:              fileName,
+				Tag:               string(tag),
+				Ref:               ref,
+				DownloadHash:      hash,
+				Size:              size,
+				Perm:              uint32(perm),
+				MD5sum:            values["md5sum"],
+				SHA512sum:         values["sha512sum"],
+				WorkflowNodeRunID: nodeRun.ID,
+				WorkflowID:        nodeRun.WorkflowRunID,
+				Created:           time.Now(),
 			}
 
-			objectPath, err := storageDriver.Store(&art, file)
+			storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars["permProjectKey"], vars["integrationName"])
 			if err != nil {
-				file.Close()
-				return sdk.WrapError(err, "Cannot store artifact")
+				return err
+			}
+			id := storageDriver.GetProjectIntegration().ID
+			if id > 0 {
+				art.ProjectIntegrationID = &id
 			}
-			log.Debug("objectpath=%s\n", objectPath)
-			art.ObjectPath = objectPath
-			file.Close()
-		}
 
-		nodeRun.Artifacts = append(nodeRun.Artifacts, art)
-		if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
-			_ = storageDriver.Delete(ctx, &art)
-			return sdk.WrapError(err, "Cannot update workflow node run")
-		}
-		return nil
+			if file != nil {
+				objectPath, err := storageDriver.Store(&art, ioutil.NopCloser(file))
+				if err != nil {
+					return sdk.WrapError(err, "Cannot store artifact")
+				}
+				log.Debug("objectpath=%s\n", objectPath)
+				art.ObjectPath = objectPath
+			}
+
+			nodeRun.Artifacts = append(nodeRun.Artifacts, art)
+			if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
+				_ = storageDriver.Delete(ctx, &art)
+				return sdk.WrapError(err, "Cannot update workflow node run")
+			}
+			return nil
+		})
 	}
 }
 
@@ -347,6 +376,10 @@ func (api *API) postWorkflowJobArtifacWithTempURLHandler() service.Handler {
 			return sdk.WrapError(err, "cannot load node job run with art.WorkflowNodeJobRunID: %d", art.WorkflowNodeJobRunID)
 		}
 
+		if err := quota.CheckArtifact(ctx, api.mustDB(), nodeJobRun.ProjectID, art.Size); err != nil {
+			return err
+		}
+
 		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{WithArtifacts: true, DisableDetailledNodeRun: true})
 		if err != nil {
 			return sdk.WrapError(err, "cannot load node run")
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultipartUploadRequest(t *testing.T, parts ...[2]string) *http.Request {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	for _, p := range parts {
+		if p[0] == "file" {
+			part, err := w.CreateFormFile("file.txt", "file.txt")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(p[1]))
+			require.NoError(t, err)
+			continue
+		}
+		require.NoError(t, w.WriteField(p[0], p[1]))
+	}
+	require.NoError(t, w.Close())
+	req, err := http.NewRequest(http.MethodPost, "/upload", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestStreamMultipartUpload(t *testing.T) {
+	var content, id string
+	fileFunc := func(values map[string]string, file io.Reader) error {
+		id = values["id"]
+		btes, err := ioutil.ReadAll(file)
+		content = string(btes)
+		return err
+	}
+
+	// The file sent before the required value is spooled
+	req := newMultipartUploadRequest(t, [2]string{"file", "content"}, [2]string{"id", "1"})
+	require.NoError(t, streamMultipartUpload(req, "file.txt", "id", fileFunc))
+	assert.Equal(t, "1", id)
+	assert.Equal(t, "content", content)
+
+	// Only one file is accepted
+	req = newMultipartUploadRequest(t, [2]string{"file", "first"}, [2]string{"file", "second"}, [2]string{"id", "1"})
+	assert.Error(t, streamMultipartUpload(req, "file.txt", "id", fileFunc))
+}
@@ -16,6 +16,7 @@ import (
 	ascodesync "github.com/ovh/cds/engine/api/ascode/sync"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/freezewindow"
 	"github.com/ovh/cds/engine/api/integration"
 	"github.com/ovh/cds/engine/api/objectstore"
 	"github.com/ovh/cds/engine/api/observability"
@@ -70,7 +71,7 @@ func (api *API) searchWorkflowRun(ctx context.Context, w http.ResponseWriter, r
 
 	//Maximim range is set to 50
 	w.Header().Add("Accept-Range", "run 50")
-	runs, offset, limit, count, err := workflow.LoadRuns(api.mustDB(), key, name, offset, limit, mapFilters)
+	runs, offset, limit, count, err := workflow.LoadRuns(api.mustDBReplica(), key, name, offset, limit, mapFilters)
 	if err != nil {
 		return sdk.WrapError(err, "Unable to load workflow runs")
 	}
@@ -473,6 +474,24 @@ func stopWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache
 					report.Merge(ctx, r2, nil) // nolint
 				}
 			}
+
+			// If it's a sub-workflow, we stop the run of the called workflow
+			if wnr.SubWorkflow != nil && wnr.Callback != nil && wnr.Callback.WorkflowRunNumber != nil {
+				targetRun, err := workflow.LoadRun(ctx, dbFunc(), p.Key, wnr.SubWorkflow.WorkflowName, *wnr.Callback.WorkflowRunNumber, workflow.LoadRunOptions{})
+				if err != nil {
+					log.Error(ctx, "stopWorkflowRun> Unable to load sub-workflow run: %v", err)
+					continue
+				}
+				if sdk.StatusIsTerminated(targetRun.Status) {
+					continue
+				}
+				r2, err := stopWorkflowRun(ctx, dbFunc, store, p, targetRun, ident, run.ID)
+				if err != nil {
+					log.Error(ctx, "stopWorkflowRun> Unable to stop sub-workflow %v", err)
+					continue
+				}
+				report.Merge(ctx, r2, nil) // nolint
+			}
 		}
 	}
 
@@ -498,6 +517,39 @@ func stopWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache
 	return report, nil
 }
 
+// cancelInProgressRuns stops the older runs of the workflow that are still in progress on the branch of the given run.
+func cancelInProgressRuns(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, wfRun *sdk.WorkflowRun, ident sdk.Identifiable) (*workflow.ProcessorReport, error) {
+	report := new(workflow.ProcessorReport)
+
+	branch := wfRun.TagValue("git.branch")
+	if branch == "" {
+		return report, nil
+	}
+
+	ids, err := workflow.LoadRunsIDInProgressByBranch(dbFunc(), wfRun.WorkflowID, branch, wfRun.Number)
+	if err != nil {
+		return report, err
+	}
+
+	for _, id := range ids {
+		run, err := workflow.LoadRunByID(dbFunc(), id, workflow.LoadRunOptions{})
+		if err != nil {
+			return report, err
+		}
+		workflow.AddWorkflowRunInfo(run, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowRunCancelledByNewRun.ID,
+			Args: []interface{}{wfRun.Number, branch},
+		})
+		r1, err := stopWorkflowRun(ctx, dbFunc, store, p, run, ident, 0)
+		if err != nil {
+			return report, sdk.WrapError(err, "unable to stop workflow run %d", run.Number)
+		}
+		report.Merge(ctx, r1, nil) // nolint
+	}
+
+	return report, nil
+}
+
 func updateParentWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, run *sdk.WorkflowRun) (*workflow.ProcessorReport, error) {
 	if !run.HasParentWorkflow() {
 		return nil, nil
@@ -527,12 +579,20 @@ func updateParentWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, sto
 		return nil, sdk.WrapError(err, "Unable to load parent run: %v", run.RootRun().HookEvent)
 	}
 
+	parentStatus := parentWR.Status
 	report, err := workflow.UpdateParentWorkflowRun(ctx, dbFunc, store, run, parentProj, parentWR)
 	if err != nil {
 		return nil, sdk.WrapError(err, "updateParentWorkflowRun")
 	}
 	go WorkflowSendEvent(context.Background(), dbFunc(), store, parentProj.Key, report)
 
+	// The parent can itself be called by a sub-workflow node, it is updated when its run is over
+	if parentWR.Status != parentStatus && sdk.StatusIsTerminated(parentWR.Status) {
+		if _, err := updateParentWorkflowRun(ctx, dbFunc, store, parentWR); err != nil {
+			return report, err
+		}
+	}
+
 	return report, nil
 }
 
@@ -769,6 +829,10 @@ func (api *API) getWorkflowNodeRunHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "Unable to load last workflow run")
 		}
+		run.Changelog, err = workflow.LoadChangelogByNodeRunID(ctx, api.mustDB(), run.ID)
+		if err != nil {
+			return err
+		}
 
 		run.Translate(r.Header.Get("Accept-Language"))
 		return service.WriteJSON(w, run, http.StatusOK)
@@ -857,6 +921,17 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 			}
 		}
 
+		// Override the active freeze windows of the project
+		var freezeOverride *sdk.WorkflowRunFreeze
+		var freezeWindows sdk.FreezeWindowNames
+		if opts.FreezeOverride != "" {
+			var err error
+			freezeOverride, freezeWindows, err = api.freezeOverride(ctx, p, c, opts.FreezeOverride)
+			if err != nil {
+				return err
+			}
+		}
+
 		var wf *sdk.Workflow
 		// IF CONTINUE EXISTING RUN
 		if lastRun != nil {
@@ -915,6 +990,23 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 			}
 		}
 
+		if freezeOverride != nil {
+			lastRun.FreezeOverride = freezeOverride
+			if err := workflow.UpdateWorkflowRun(ctx, api.mustDB(), lastRun); err != nil {
+				return err
+			}
+			if err := freezewindow.InsertOverride(api.mustDB(), &sdk.FreezeWindowOverride{
+				ProjectID:         p.ID,
+				WorkflowName:      wf.Name,
+				WorkflowRunNumber: lastRun.Number,
+				FreezeWindows:     freezeWindows,
+				Username:          c.GetUsername(),
+				Reason:            freezeOverride.Reason,
+			}); err != nil {
+				return err
+			}
+		}
+
 		// Workflow Run initialization
 		sdk.GoRoutine(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", lastRun.ID), func(ctx context.Context) {
 			api.initWorkflowRun(ctx, api.mustDB(), api.Cache, p, wf, lastRun, opts, c)
@@ -958,7 +1050,7 @@ func (api *API) initWorkflowRun(ctx context.Context, db *gorp.DbMap, cache cache
 		// NOTICE: Only repository webhooks and manual run will perform the repository analysis
 		if wf.FromRepository != "" && ((opts.Hook != nil &&
 			wf.WorkflowData.Node.GetHook(opts.Hook.WorkflowNodeHookUUID) != nil &&
-			wf.WorkflowData.Node.GetHook(opts.Hook.WorkflowNodeHookUUID).HookModelName == sdk.RepositoryWebHookModelName) ||
+			sdk.IsRepositoryWebHookModel(wf.WorkflowData.Node.GetHook(opts.Hook.WorkflowNodeHookUUID).HookModelName)) ||
 			(opts.Manual != nil)) {
 			log.Debug("initWorkflowRun> rebuild workflow %s/%s from as code configuration", p.Key, wf.Name)
 			p1, errp := project.Load(db, cache, p.Key,
@@ -1010,6 +1102,14 @@ func (api *API) initWorkflowRun(ctx context.Context, db *gorp.DbMap, cache cache
 		return
 	}
 
+	if wf.CancelInProgress {
+		r1, err := cancelInProgressRuns(ctx, api.mustDB, cache, p, wfRun, u)
+		if err != nil {
+			log.Error(ctx, "unable to cancel in progress runs of workflow %s/%s: %v", p.Key, wf.Name, err)
+		}
+		report.Merge(ctx, r1, nil) // nolint
+	}
+
 	workflow.ResyncNodeRunsWithCommits(ctx, db, cache, p, report)
 
 	// Purge workflow run
@@ -1104,6 +1204,9 @@ func (api *API) getDownloadArtifactHandler() service.Handler {
 		if errA != nil {
 			return sdk.WrapError(errA, "getDownloadArtifactHandler> Cannot load artifacts")
 		}
+		if art.Archived {
+			return sdk.NewErrorFrom(sdk.ErrWorkflowRunArchived, "artifact %s is archived", art.Name)
+		}
 
 		w.Header().Add("Content-Type", "application/octet-stream")
 		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", art.Name))
@@ -1241,7 +1344,7 @@ func (api *API) getWorkflowNodeRunJobServiceLogsHandler() service.Handler {
 		if errJ != nil {
 			return sdk.WrapError(errJ, "runJobId: invalid number")
 		}
-		db := api.mustDB()
+		db := api.mustDBReplica()
 
 		logsServices, err := workflow.LoadServicesLogsByJob(db, runJobID)
 		if err != nil {
@@ -1274,8 +1377,10 @@ func (api *API) getWorkflowNodeRunJobStepHandler() service.Handler {
 			return sdk.WrapError(errS, "stepOrder: invalid number")
 		}
 
+		db := api.mustDBReplica()
+
 		// Check nodeRunID is link to workflow
-		nodeRun, errNR := workflow.LoadNodeRun(api.mustDB(), projectKey, workflowName, number, nodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		nodeRun, errNR := workflow.LoadNodeRun(db, projectKey, workflowName, number, nodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
 		if errNR != nil {
 			return sdk.WrapError(errNR, "cannot find nodeRun %d/%d for workflow %s in project %s", nodeRunID, number, workflowName, projectKey)
 		}
@@ -1304,11 +1409,17 @@ func (api *API) getWorkflowNodeRunJobStepHandler() service.Handler {
 				stepOrder, runJobID, nodeRunID, number, workflowName, projectKey)
 		}
 
-		logs, errL := workflow.LoadStepLogs(api.mustDB(), runJobID, stepOrder)
+		logs, errL := workflow.LoadStepLogs(db, runJobID, stepOrder)
 		if errL != nil {
 			return sdk.WrapError(errL, "cannot load log for runJob %d on step %d", runJobID, stepOrder)
 		}
 
+		if logs == nil {
+			if _, err := workflow.LoadRunArchive(ctx, db, nodeRun.WorkflowRunID); err == nil {
+				return sdk.WithStack(sdk.ErrWorkflowRunArchived)
+			}
+		}
+
 		ls := &sdk.Log{}
 		if logs != nil {
 			ls = logs
@@ -1324,6 +1435,50 @@ func (api *API) getWorkflowNodeRunJobStepHandler() service.Handler {
 	}
 }
 
+func (api *API) getWorkflowRunLogsArchiveHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["key"]
+		workflowName := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		format := r.FormValue("format")
+		if format == "" {
+			format = sdk.WorkflowRunLogsArchiveZip
+		}
+		if !sdk.IsValidWorkflowRunLogsArchiveFormat(format) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid logs archive format %q", format)
+		}
+
+		db := api.mustDBReplica()
+
+		wr, err := workflow.LoadRun(ctx, db, projectKey, workflowName, number, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow run %d for workflow %s in project %s", number, workflowName, projectKey)
+		}
+		if wr.Archived {
+			return sdk.WithStack(sdk.ErrWorkflowRunArchived)
+		}
+
+		contentType := "application/zip"
+		if format == sdk.WorkflowRunLogsArchiveTarGz {
+			contentType = "application/gzip"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s-%d-logs.%s"`, projectKey, workflowName, number, format))
+		w.WriteHeader(http.StatusOK)
+
+		// Headers are sent, errors while streaming the archive can only be logged
+		if err := workflow.WriteRunLogsArchive(ctx, db, w, format, *wr); err != nil {
+			log.Error(ctx, "getWorkflowRunLogsArchiveHandler> cannot write logs archive of workflow run %d for workflow %s in project %s: %v", number, workflowName, projectKey, err)
+		}
+		return nil
+	}
+}
+
 func (api *API) getWorkflowRunTagsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
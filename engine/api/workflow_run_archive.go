@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/purge"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectRetentionSettingsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		s, err := workflow.LoadRetentionSettings(ctx, api.mustDB(), proj.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, s, http.StatusOK)
+	}
+}
+
+// putProjectRetentionSettingsHandler sets the age of the runs to archive and the storage integration of the archives.
+func (api *API) putProjectRetentionSettingsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		var s sdk.ProjectRetentionSettings
+		if err := service.UnmarshalBody(r, &s); err != nil {
+			return err
+		}
+		s.ProjectID = proj.ID
+		if err := s.IsValid(); err != nil {
+			return err
+		}
+		if s.IntegrationName != "" {
+			pi, err := integration.LoadProjectIntegrationByName(api.mustDB(), proj.Key, s.IntegrationName, false)
+			if err != nil {
+				return err
+			}
+			if !pi.Model.Storage {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "integration %s is not a storage integration", s.IntegrationName)
+			}
+		}
+
+		if err := workflow.UpsertRetentionSettings(ctx, api.mustDB(), s); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, s, http.StatusOK)
+	}
+}
+
+// postWorkflowRunRehydrateHandler restores the logs and the artifacts of an archived run.
+func (api *API) postWorkflowRunRehydrateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow run %d for workflow %s in project %s", number, name, key)
+		}
+		if !wr.Archived {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow run %d is not archived", number)
+		}
+
+		if err := purge.RehydrateWorkflowRun(ctx, api.mustDB(), api.SharedStorage, *proj, wr.ID); err != nil {
+			return err
+		}
+		wr.Archived = false
+
+		return service.WriteJSON(w, wr, http.StatusOK)
+	}
+}
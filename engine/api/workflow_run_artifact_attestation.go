@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// postWorkflowJobArtifactAttestationHandler signs the provenance of the artifacts uploaded by a job with a PGP key
+// of the project. The attestations are in-toto statements with a SLSA provenance predicate in a DSSE envelope.
+func (api *API) postWorkflowJobArtifactAttestationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		var req sdk.WorkflowArtifactAttestationRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		nodeJobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node job run")
+		}
+		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{WithArtifacts: true, DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node run")
+		}
+
+		proj, err := project.LoadByID(api.mustDB(), api.Cache, nodeJobRun.ProjectID, project.LoadOptions.WithClearKeys)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+		key := proj.GetPGPKey(req.KeyName)
+		if key == nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "PGP key %s not found in project %s", req.KeyName, proj.Key)
+		}
+
+		var attestations []sdk.WorkflowArtifactAttestation
+		for _, art := range nodeRun.Artifacts {
+			if !req.Match(art) {
+				continue
+			}
+			statement := sdk.NewArtifactProvenanceStatement(art, api.Config.URL.API, nodeJobRun.Parameters, nodeJobRun.Start, time.Now())
+			envelope, err := sdk.NewDSSEEnvelope(statement)
+			if err != nil {
+				return err
+			}
+			data, err := envelope.SignedData()
+			if err != nil {
+				return err
+			}
+			sig, err := keys.SignPGPDetached(key.Private, data)
+			if err != nil {
+				return sdk.WrapError(err, "cannot sign attestation of artifact %s with key %s", art.Name, key.Name)
+			}
+			envelope.Signatures = []sdk.DSSESignature{{
+				KeyID: key.KeyID,
+				Sig:   base64.StdEncoding.EncodeToString(sig),
+			}}
+
+			a := sdk.WorkflowArtifactAttestation{
+				ArtifactID: art.ID,
+				KeyName:    key.Name,
+				KeyID:      key.KeyID,
+				Envelope:   envelope,
+				Created:    time.Now(),
+			}
+			if err := workflow.InsertArtifactAttestation(api.mustDB(), &a); err != nil {
+				return err
+			}
+			attestations = append(attestations, a)
+		}
+		if len(attestations) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no artifact to sign with tag %s", req.Tag)
+		}
+
+		return service.WriteJSON(w, attestations, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowArtifactAttestationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		_, art, err := api.loadWorkflowArtifact(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		a, err := workflow.LoadArtifactAttestation(ctx, api.mustDB(), art.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, a, http.StatusOK)
+	}
+}
+
+// getWorkflowArtifactAttestationVerifyHandler checks the signature of the attestation of an artifact with the public
+// part of the project key, and that the attestation is about the stored artifact.
+func (api *API) getWorkflowArtifactAttestationVerifyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		proj, art, err := api.loadWorkflowArtifact(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		a, err := workflow.LoadArtifactAttestation(ctx, api.mustDB(), art.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := project.LoadAllKeys(api.mustDB(), proj); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, verifyArtifactAttestation(*proj, *art, *a), http.StatusOK)
+	}
+}
+
+func (api *API) loadWorkflowArtifact(ctx context.Context, r *http.Request) (*sdk.Project, *sdk.WorkflowNodeRunArtifact, error) {
+	vars := mux.Vars(r)
+	key := vars["key"]
+	name := vars["permWorkflowName"]
+
+	id, err := requestVarInt(r, "artifactId")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proj, err := project.Load(api.mustDB(), api.Cache, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{Minimal: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	art, err := workflow.LoadArtifactByIDs(api.mustDB(), wf.ID, id)
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "cannot load artifact %d", id)
+	}
+	return proj, art, nil
+}
+
+func verifyArtifactAttestation(proj sdk.Project, art sdk.WorkflowNodeRunArtifact, a sdk.WorkflowArtifactAttestation) sdk.WorkflowArtifactAttestationVerification {
+	res := sdk.WorkflowArtifactAttestationVerification{
+		ArtifactID: art.ID,
+		KeyName:    a.KeyName,
+		KeyID:      a.KeyID,
+	}
+	fail := func(format string, args ...interface{}) sdk.WorkflowArtifactAttestationVerification {
+		res.Reason = fmt.Sprintf(format, args...)
+		return res
+	}
+
+	statement, err := a.Envelope.Statement()
+	if err != nil {
+		return fail("%v", sdk.ExtractHTTPError(err, "").Message)
+	}
+	res.Statement = statement
+
+	key := proj.GetPGPKey(a.KeyName)
+	if key == nil {
+		return fail("PGP key %s not found in project %s", a.KeyName, proj.Key)
+	}
+	data, err := a.Envelope.SignedData()
+	if err != nil {
+		return fail("%v", sdk.ExtractHTTPError(err, "").Message)
+	}
+
+	var signed bool
+	for _, s := range a.Envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if keys.VerifyPGPDetached(key.Public, data, sig) == nil {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return fail("the attestation is not signed by the key %s", key.Name)
+	}
+
+	if !statement.MatchDigest(art.SHA512sum) {
+		return fail("the attestation digest does not match the artifact %s", art.Name)
+	}
+
+	res.Verified = true
+	return res
+}
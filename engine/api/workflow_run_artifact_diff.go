@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRunArtifactsDiffHandler compares the artifacts of a run with the artifacts of the last successful run
+// on the same branch. With files=true, the files inside the changed archives are compared too.
+func (api *API) getWorkflowRunArtifactsDiffHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		withFiles := FormBool(r, "files")
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{WithArtifacts: true})
+		if err != nil {
+			return err
+		}
+
+		diff := sdk.WorkflowRunArtifactsDiff{
+			Number: wr.Number,
+			Branch: wr.TagValue("git.branch"),
+			Hash:   wr.TagValue("git.hash"),
+		}
+		if diff.Branch == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow run %d has no git branch", wr.Number)
+		}
+
+		var previousArtifacts []sdk.NodeRunArtifact
+		previous, err := workflow.LoadLastSuccessfulRunByBranch(api.mustDB(), key, name, diff.Branch, wr.Number, workflow.LoadRunOptions{WithArtifacts: true})
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrWorkflowNotFound) {
+			return err
+		}
+		if previous != nil {
+			diff.PreviousNumber = previous.Number
+			diff.PreviousHash = previous.TagValue("git.hash")
+			previousArtifacts = workflow.RunArtifacts(previous)
+		}
+
+		sameCommit := previous != nil && diff.Hash != "" && diff.Hash == diff.PreviousHash
+		diff.Artifacts = sdk.DiffWorkflowRunArtifacts(workflow.RunArtifacts(wr), previousArtifacts, sameCommit)
+
+		if withFiles {
+			for i := range diff.Artifacts {
+				d := &diff.Artifacts[i]
+				if d.Status != sdk.ArtifactDiffStatusChanged || !sdk.IsArchive(d.Name) {
+					continue
+				}
+				current, err := api.artifactArchiveFiles(ctx, key, d.Current)
+				if err != nil {
+					return err
+				}
+				previous, err := api.artifactArchiveFiles(ctx, key, d.Previous)
+				if err != nil {
+					return err
+				}
+				d.SetFiles(current, previous)
+			}
+		}
+
+		return service.WriteJSON(w, diff, http.StatusOK)
+	}
+}
+
+// artifactArchiveFiles fetches an archive artifact from its storage and returns the checksums of its files.
+func (api *API) artifactArchiveFiles(ctx context.Context, projectKey string, art *sdk.WorkflowNodeRunArtifact) (map[string]string, error) {
+	storageDriver, err := api.artifactStorageDriver(ctx, projectKey, art.ProjectIntegrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := storageDriver.Fetch(ctx, art)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot fetch artifact %s", art.Name)
+	}
+	defer f.Close() // nolint
+
+	files, err := sdk.ArchiveFiles(art.Name, f)
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// postWorkflowRunArtifactsPromoteHandler promotes the artifacts of a workflow run to another workflow, the artifacts
+// are copied in the storage or referenced. The caller must be allowed to run the target workflow.
+func (api *API) postWorkflowRunArtifactsPromoteHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		var req sdk.WorkflowArtifactPromotionRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		if err := api.checkWorkflowPermissions(ctx, req.WorkflowName, sdk.PermissionReadExecute, map[string]string{"key": req.ProjectKey}); err != nil {
+			return err
+		}
+		targetProj, err := project.Load(api.mustDB(), api.Cache, req.ProjectKey)
+		if err != nil {
+			return err
+		}
+		targetWorkflow, err := workflow.Load(ctx, api.mustDB(), api.Cache, targetProj, req.WorkflowName, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{WithArtifacts: true})
+		if err != nil {
+			return err
+		}
+
+		var arts []sdk.WorkflowNodeRunArtifact
+		for _, a := range workflow.RunArtifacts(wr) {
+			if req.Match(a.WorkflowNodeRunArtifact) {
+				arts = append(arts, a.WorkflowNodeRunArtifact)
+			}
+		}
+		if len(arts) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no artifact to promote in workflow run %s/%s #%d", key, name, number)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		promotions := make([]sdk.WorkflowArtifactPromotion, 0, len(arts))
+		for i := range arts {
+			art := &arts[i]
+			p := sdk.WorkflowArtifactPromotion{
+				WorkflowID:           targetWorkflow.ID,
+				Name:                 art.Name,
+				Tag:                  art.Tag,
+				Mode:                 req.Mode,
+				ContainerPath:        art.GetPath(),
+				StorageProjectKey:    key,
+				ProjectIntegrationID: art.ProjectIntegrationID,
+				Size:                 art.Size,
+				Perm:                 art.Perm,
+				MD5sum:               art.MD5sum,
+				SHA512sum:            art.SHA512sum,
+				SourceWorkflowRunID:  wr.ID,
+				SourceArtifactID:     art.ID,
+				Provenance: sdk.WorkflowArtifactProvenance{
+					ProjectKey:        key,
+					WorkflowName:      name,
+					RunNumber:         wr.Number,
+					WorkflowNodeRunID: art.WorkflowNodeRunID,
+					ArtifactTag:       art.Tag,
+					PromotedBy:        getAPIConsumer(ctx).GetUsername(),
+				},
+				Created: time.Now(),
+			}
+			if req.PromotedTag != "" {
+				p.Tag = req.PromotedTag
+			}
+
+			if req.Mode == sdk.ArtifactPromotionModeCopy {
+				p.ContainerPath = sdk.PromotedContainerPath(targetWorkflow.ID, p.Tag)
+				if err := api.copyPromotedArtifact(ctx, key, art, &p); err != nil {
+					return err
+				}
+			}
+
+			if err := workflow.InsertArtifactPromotion(tx, &p); err != nil {
+				return err
+			}
+			promotions = append(promotions, p)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, promotions, http.StatusOK)
+	}
+}
+
+// copyPromotedArtifact copies an artifact in its storage, in the container of the promoted artifact
+func (api *API) copyPromotedArtifact(ctx context.Context, projectKey string, art *sdk.WorkflowNodeRunArtifact, p *sdk.WorkflowArtifactPromotion) error {
+	storageDriver, err := api.artifactStorageDriver(ctx, projectKey, art.ProjectIntegrationID)
+	if err != nil {
+		return err
+	}
+
+	f, err := storageDriver.Fetch(ctx, art)
+	if err != nil {
+		return sdk.WrapError(err, "cannot fetch artifact %s", art.Name)
+	}
+
+	if _, err := storageDriver.Store(p, f); err != nil {
+		return sdk.WrapError(err, "cannot store promoted artifact %s", p.Name)
+	}
+	return nil
+}
+
+func (api *API) getWorkflowArtifactPromotionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return err
+		}
+
+		promotions, err := workflow.LoadArtifactPromotions(ctx, api.mustDB(), wf.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, promotions, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowArtifactPromotionDownloadHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		id, err := requestVarInt(r, "promotionID")
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return err
+		}
+
+		p, err := workflow.LoadArtifactPromotionByID(ctx, api.mustDB(), wf.ID, id)
+		if err != nil {
+			return err
+		}
+
+		storageDriver, err := api.artifactStorageDriver(ctx, p.StorageProjectKey, p.ProjectIntegrationID)
+		if err != nil {
+			return err
+		}
+
+		f, err := storageDriver.Fetch(ctx, p)
+		if err != nil {
+			return sdk.WrapError(err, "cannot fetch promoted artifact %s", p.Name)
+		}
+		defer f.Close() // nolint
+
+		w.Header().Add("Content-Type", "application/octet-stream")
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", p.Name))
+
+		if _, err := io.Copy(w, f); err != nil {
+			return sdk.WrapError(err, "cannot stream promoted artifact %s", p.Name)
+		}
+		return nil
+	}
+}
+
+func (api *API) deleteWorkflowArtifactPromotionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		id, err := requestVarInt(r, "promotionID")
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return err
+		}
+
+		p, err := workflow.LoadArtifactPromotionByID(ctx, api.mustDB(), wf.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := workflow.DeleteArtifactPromotion(api.mustDB(), p); err != nil {
+			return err
+		}
+
+		// A copied artifact belongs to the promotion, a referenced artifact belongs to its source run
+		if p.Mode == sdk.ArtifactPromotionModeCopy {
+			storageDriver, err := api.artifactStorageDriver(ctx, p.StorageProjectKey, p.ProjectIntegrationID)
+			if err != nil {
+				return err
+			}
+			if err := storageDriver.Delete(ctx, p); err != nil {
+				return sdk.WrapError(err, "cannot delete promoted artifact %s", p.Name)
+			}
+		}
+
+		return nil
+	}
+}
+
+// artifactStorageDriver returns the storage driver of an artifact of a project
+func (api *API) artifactStorageDriver(ctx context.Context, projectKey string, projectIntegrationID *int64) (objectstore.Driver, error) {
+	integrationName := sdk.DefaultStorageIntegrationName
+	if projectIntegrationID != nil && *projectIntegrationID > 0 {
+		projectIntegration, err := integration.LoadProjectIntegrationByID(api.mustDB(), *projectIntegrationID, false)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot load project integration %s/%d", projectKey, *projectIntegrationID)
+		}
+		integrationName = projectIntegration.Name
+	}
+	return objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, projectKey, integrationName)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRunChangelogsHandler returns the changelogs of the node runs of a workflow run.
+func (api *API) getWorkflowRunChangelogsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		changelogs, err := workflow.LoadChangelogsByRunID(ctx, api.mustDB(), wr.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, changelogs, http.StatusOK)
+	}
+}
+
+// postWorkflowNodeRunChangelogHandler computes again the changelog of a node run, from the commit of the last
+// successful run of the node.
+func (api *API) postWorkflowNodeRunChangelogHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+		nr, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, id, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run %d", id)
+		}
+
+		n := wr.Workflow.WorkflowData.NodeByID(nr.WorkflowNodeID)
+		if n == nil || n.Context == nil || n.Context.ApplicationID == 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "node %s is not linked to an application", nr.WorkflowNodeName)
+		}
+		app := wr.Workflow.Applications[n.Context.ApplicationID]
+
+		changelog, err := workflow.ComputeChangelog(ctx, api.mustDB(), api.Cache, proj, &wr.Workflow, nr, &app, sdk.BuildNumberAndHash{
+			BuildNumber: nr.Number,
+			Remote:      nr.VCSRepository,
+			Branch:      nr.VCSBranch,
+			Hash:        nr.VCSHash,
+			Tag:         nr.VCSTag,
+		})
+		if err != nil {
+			return err
+		}
+		if changelog == nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "node run %s is not linked to a commit of a repository", nr.WorkflowNodeName)
+		}
+
+		return service.WriteJSON(w, changelog, http.StatusOK)
+	}
+}
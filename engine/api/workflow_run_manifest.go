@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/plugin"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowNodeRunManifestHandler returns the manifest of a node run: the resolved parameters with masked
+// secrets, the workers, worker models, steps and plugins used by its jobs.
+func (api *API) getWorkflowNodeRunManifestHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		nodeRunID, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		m, err := workflow.LoadNodeRunManifest(ctx, api.mustDB(), wr.ID, nodeRunID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, m, http.StatusOK)
+	}
+}
+
+// loadJobPluginBinaries returns the binaries of the plugins used by the steps of a job for the os and arch of
+// the worker, from the plugin registry if the job requires a version of the plugin. A plugin that is not found
+// has no checksum, the job will fail when the worker starts it.
+func loadJobPluginBinaries(ctx context.Context, db gorp.SqlExecutor, a sdk.Action, wk *sdk.Worker) ([]sdk.GRPCPluginBinary, error) {
+	versions := make(map[string]string)
+	for _, r := range a.Requirements {
+		if r.Type != sdk.PluginRequirement {
+			continue
+		}
+		if name, version := sdk.ParsePluginRequirement(r.Value); version != "" {
+			versions[name] = version
+		}
+	}
+
+	var names []string
+	var walk func(as []sdk.Action)
+	walk = func(as []sdk.Action) {
+		for _, child := range as {
+			if child.Type == sdk.PluginAction && !sdk.IsInArray(child.Name, names) {
+				names = append(names, child.Name)
+			}
+			walk(child.Actions)
+		}
+	}
+	walk(a.Actions)
+
+	wkOS, wkArch := strings.ToLower(wk.OS), strings.ToLower(wk.Arch)
+	var res []sdk.GRPCPluginBinary
+	for _, name := range names {
+		b, err := loadPluginBinary(ctx, db, name, versions[name], wkOS, wkArch)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, err
+		}
+		bin := sdk.GRPCPluginBinary{}
+		if b != nil {
+			bin = *b
+		}
+		bin.PluginName = name
+		bin.Version = versions[name]
+		res = append(res, bin)
+	}
+	return res, nil
+}
+
+func loadPluginBinary(ctx context.Context, db gorp.SqlExecutor, name, version, os, arch string) (*sdk.GRPCPluginBinary, error) {
+	p, err := plugin.LoadByName(db, name)
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		return p.GetBinary(os, arch), nil
+	}
+	v, err := plugin.LoadVersion(ctx, db, p, version)
+	if err != nil {
+		return nil, err
+	}
+	return v.GetBinary(os, arch), nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRunReportHandler returns a self-contained report of a workflow run with the statuses, the durations,
+// the tests results and the links of its nodes and jobs, as an HTML page (default), a markdown file or a JUnit file.
+func (api *API) getWorkflowRunReportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		format := FormString(r, "format")
+		if format == "" {
+			format = sdk.WorkflowRunReportHTML
+		}
+		if !sdk.IsValidWorkflowRunReportFormat(format) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid report format %q", format)
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDBReplica(), key, name, number, workflow.LoadRunOptions{WithTests: true, WithCoverage: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow run %d for workflow %s in project %s", number, name, key)
+		}
+
+		report := sdk.NewWorkflowRunReport(*wr, api.Config.URL.UI)
+
+		var btes []byte
+		var contentType, ext string
+		switch format {
+		case sdk.WorkflowRunReportHTML:
+			btes, err = report.HTML()
+			contentType, ext = "text/html; charset=utf-8", "html"
+		case sdk.WorkflowRunReportMarkdown:
+			btes = []byte(report.Markdown())
+			contentType, ext = "text/markdown; charset=utf-8", "md"
+		case sdk.WorkflowRunReportJUnit:
+			btes, err = report.JUnit()
+			contentType, ext = "application/xml", "xml"
+		}
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s-%d-report.%s"`, key, name, number, ext))
+		return service.Write(w, btes, http.StatusOK, contentType)
+	}
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultSBOMSearchLimit = 100
+
+// postWorkflowJobSBOMHandler saves the SBOM produced by a job, the components of the SBOM are indexed to find
+// the runs containing a dependency.
+func (api *API) postWorkflowJobSBOMHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		var sbom sdk.WorkflowRunSBOM
+		if err := service.UnmarshalBody(r, &sbom); err != nil {
+			return err
+		}
+		if sbom.Name == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "the name of the SBOM is mandatory")
+		}
+		sbom.Format, sbom.Components, err = sdk.ParseSBOM(sbom.Format, sbom.Document)
+		if err != nil {
+			return err
+		}
+
+		nodeJobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node job run")
+		}
+		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node run")
+		}
+
+		sbom.ID = 0
+		sbom.WorkflowID = nodeRun.WorkflowID
+		sbom.WorkflowRunID = nodeRun.WorkflowRunID
+		sbom.WorkflowNodeRunID = nodeRun.ID
+		sbom.Created = time.Now()
+		if err := workflow.InsertSBOM(api.mustDB(), &sbom); err != nil {
+			return err
+		}
+		sbom.Document = nil
+
+		return service.WriteJSON(w, sbom, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowRunSBOMsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{})
+		if err != nil {
+			return err
+		}
+
+		sboms, err := workflow.LoadSBOMsByRunID(ctx, api.mustDB(), wr.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sboms, http.StatusOK)
+	}
+}
+
+// getWorkflowRunSBOMHandler returns the CycloneDX or SPDX document of a SBOM of a workflow run.
+func (api *API) getWorkflowRunSBOMHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "sbomID")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{})
+		if err != nil {
+			return err
+		}
+
+		sbom, err := workflow.LoadSBOMByID(ctx, api.mustDB(), wr.ID, id)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sbom.Document, http.StatusOK)
+	}
+}
+
+// getProjectSBOMSearchHandler returns the last runs of the workflows of a project containing a dependency.
+func (api *API) getProjectSBOMSearchHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		return api.searchSBOMComponents(w, r, proj.ID)
+	}
+}
+
+// getAdminSBOMSearchHandler returns the last runs of all the workflows containing a dependency.
+func (api *API) getAdminSBOMSearchHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return api.searchSBOMComponents(w, r, 0)
+	}
+}
+
+func (api *API) searchSBOMComponents(w http.ResponseWriter, r *http.Request, projectID int64) error {
+	name := FormString(r, "name")
+	if name == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "the name of the dependency is mandatory")
+	}
+	limit, err := FormInt(r, "limit")
+	if err != nil {
+		return err
+	}
+	if limit <= 0 {
+		limit = defaultSBOMSearchLimit
+	}
+
+	res, err := workflow.SearchSBOMComponents(api.mustDBReplica(), projectID, name, FormString(r, "version"), limit)
+	if err != nil {
+		return err
+	}
+
+	return service.WriteJSON(w, res, http.StatusOK)
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// postSearchWorkflowRunsHandler returns a page of the runs of the workflows of a project matching a search.
+func (api *API) postSearchWorkflowRunsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		var search sdk.WorkflowRunSearch
+		if err := service.UnmarshalBody(r, &search); err != nil {
+			return err
+		}
+		if err := search.IsValid(); err != nil {
+			return err
+		}
+
+		db := api.mustDBReplica()
+
+		proj, err := project.Load(db, api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		runs, next, err := workflow.SearchRuns(db, proj.ID, search)
+		if err != nil {
+			return err
+		}
+		for i := range runs {
+			runs[i].Translate(r.Header.Get("Accept-Language"))
+		}
+
+		return service.WriteJSON(w, sdk.WorkflowRunSearchResult{Runs: runs, NextCursor: next}, http.StatusOK)
+	}
+}
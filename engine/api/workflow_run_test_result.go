@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	defaultTestHistoryLimit = 50
+	defaultFlakyTestsLimit  = 20
+	defaultFlakyTestsDays   = 30
+)
+
+// getWorkflowRunTestResultsHandler returns the results of the test cases of a workflow run.
+func (api *API) getWorkflowRunTestResultsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{})
+		if err != nil {
+			return err
+		}
+
+		rs, err := workflow.LoadTestResultsByRunID(ctx, api.mustDB(), wr.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, rs, http.StatusOK)
+	}
+}
+
+// getProjectTestHistoryHandler returns the last results of a test case in the workflows of a project, the results
+// of the test on a commit with another result are flagged as flaky.
+func (api *API) getProjectTestHistoryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		name := FormString(r, "name")
+		if name == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "the name of the test is mandatory")
+		}
+		limit, err := FormInt(r, "limit")
+		if err != nil {
+			return err
+		}
+		if limit <= 0 {
+			limit = defaultTestHistoryLimit
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		hs, err := workflow.LoadTestHistory(api.mustDB(), proj.ID, FormString(r, "suite"), name, limit)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, hs, http.StatusOK)
+	}
+}
+
+// getProjectFlakyTestsHandler returns the flakiest tests of a project, the tests which passed and failed on the
+// same commit during the last days.
+func (api *API) getProjectFlakyTestsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)[permProjectKey]
+
+		limit, err := FormInt(r, "limit")
+		if err != nil {
+			return err
+		}
+		if limit <= 0 {
+			limit = defaultFlakyTestsLimit
+		}
+		days, err := FormInt(r, "days")
+		if err != nil {
+			return err
+		}
+		if days <= 0 {
+			days = defaultFlakyTestsDays
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return err
+		}
+
+		ts, err := workflow.LoadFlakyTests(api.mustDB(), proj.ID, time.Now().AddDate(0, 0, -days), limit)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ts, http.StatusOK)
+	}
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+)
+
+// getWorkflowRunTestsSummaryHandler returns the unit tests results and the coverage of each node of a workflow run,
+// with the coverage delta against the default branch. With format=markdown, the summary posted on pull requests is returned.
+func (api *API) getWorkflowRunTestsSummaryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{WithLightTests: true, WithCoverage: true})
+		if err != nil {
+			return err
+		}
+
+		summary := workflow.RunTestsSummary(wr)
+		if FormString(r, "format") == "markdown" {
+			return service.Write(w, []byte(summary.Markdown()), http.StatusOK, "text/markdown")
+		}
+		return service.WriteJSON(w, summary, http.StatusOK)
+	}
+}
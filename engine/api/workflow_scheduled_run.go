@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getWorkflowScheduledRunsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForTimer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		srvs, err := services.LoadAllByType(ctx, api.mustDB(), services.TypeHooks)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load hooks services")
+		}
+
+		path := fmt.Sprintf("/scheduled-run/%s/%s", url.PathEscape(wf.ProjectKey), url.PathEscape(wf.Name))
+		var runs []sdk.WorkflowScheduledRun
+		if _, _, err := services.NewClient(api.mustDB(), srvs).DoJSONRequest(ctx, http.MethodGet, path, nil, &runs); err != nil {
+			return sdk.WrapError(err, "unable to get scheduled runs of workflow %s", wf.Name)
+		}
+		for i := range runs {
+			runs[i].AuthorConsumerID = ""
+		}
+
+		return service.WriteJSON(w, runs, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowScheduledRunHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForTimer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var run sdk.WorkflowScheduledRun
+		if err := service.UnmarshalBody(r, &run); err != nil {
+			return err
+		}
+		if err := run.IsValid(time.Now()); err != nil {
+			return err
+		}
+
+		// The run will be started as the consumer that scheduled it
+		consumer := getAPIConsumer(ctx)
+		if !permission.AccessToWorkflowNode(ctx, api.mustDB(), wf, &wf.WorkflowData.Node, consumer, sdk.PermissionReadExecute) {
+			return sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", wf.WorkflowData.Node.Name)
+		}
+		run.UUID = sdk.UUID()
+		run.ProjectKey = wf.ProjectKey
+		run.WorkflowName = wf.Name
+		run.Author = consumer.GetUsername()
+		run.AuthorConsumerID = consumer.ID
+		run.WorkflowRun = 0
+		run.LastError = ""
+
+		srvs, err := services.LoadAllByType(ctx, api.mustDB(), services.TypeHooks)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load hooks services")
+		}
+		if _, _, err := services.NewClient(api.mustDB(), srvs).DoJSONRequest(ctx, http.MethodPost, "/scheduled-run", run, &run); err != nil {
+			return sdk.WrapError(err, "unable to schedule a run of workflow %s", wf.Name)
+		}
+		run.AuthorConsumerID = ""
+
+		return service.WriteJSON(w, run, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowScheduledRunCancelHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForTimer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		srvs, err := services.LoadAllByType(ctx, api.mustDB(), services.TypeHooks)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load hooks services")
+		}
+
+		uuid := mux.Vars(r)["uuid"]
+		path := fmt.Sprintf("/scheduled-run/%s/%s/%s", url.PathEscape(wf.ProjectKey), url.PathEscape(wf.Name), url.PathEscape(uuid))
+		if _, _, err := services.NewClient(api.mustDB(), srvs).DoJSONRequest(ctx, http.MethodDelete, path, nil, nil); err != nil {
+			return sdk.WrapError(err, "unable to cancel scheduled run %s", uuid)
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// postWorkflowScheduledRunExecuteHandler is called by the hooks µservice at the date of a scheduled run, the
+// workflow run is started as the author of the scheduled run.
+func (api *API) postWorkflowScheduledRunExecuteHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if !isService(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		var run sdk.WorkflowScheduledRun
+		if err := service.UnmarshalBody(r, &run); err != nil {
+			return err
+		}
+
+		db := api.mustDB()
+		consumer, err := authentication.LoadConsumerByID(ctx, db, run.AuthorConsumerID,
+			authentication.LoadConsumerOptions.Default, authentication.LoadConsumerOptions.WithAuthentifiedUser)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load author of scheduled run %s", run.UUID)
+		}
+		if consumer.Disabled {
+			return sdk.NewErrorFrom(sdk.ErrUnauthorized, "author of scheduled run %s is disabled", run.UUID)
+		}
+
+		proj, err := project.Load(db, api.Cache, run.ProjectKey,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithFeatures,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithApplicationVariables,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", run.ProjectKey)
+		}
+
+		wf, err := workflow.Load(ctx, db, api.Cache, proj, run.WorkflowName, workflow.LoadOptions{
+			DeepPipeline:          true,
+			Base64Keys:            true,
+			WithAsCodeUpdateEvent: true,
+			WithIcon:              true,
+			WithIntegrations:      true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", run.WorkflowName)
+		}
+		if err := workflowtemplate.AggregateTemplateInstanceOnWorkflow(ctx, db, wf); err != nil {
+			return sdk.WrapError(err, "cannot load workflow template")
+		}
+
+		wr, err := api.startWorkflowRunAs(ctx, proj, wf, consumer, run.Payload)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, wr, http.StatusAccepted)
+	}
+}
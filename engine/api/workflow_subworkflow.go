@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	// subWorkflowSchedulerInterval is the delay between two checks of the sub-workflow node runs to start.
+	subWorkflowSchedulerInterval = 5 * time.Second
+	// subWorkflowBatchSize is the max count of sub-workflow node runs started at each check.
+	subWorkflowBatchSize = 20
+)
+
+// subWorkflowScheduler starts the runs of the workflows called by sub-workflow nodes. The node runs are locked
+// while the called runs are created, so each API instance can run the scheduler.
+func (api *API) subWorkflowScheduler(ctx context.Context) {
+	tick := time.NewTicker(subWorkflowSchedulerInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			if api.Maintenance {
+				continue
+			}
+			if err := api.startSubWorkflowRuns(ctx); err != nil {
+				log.Error(ctx, "subWorkflowScheduler> %v", err)
+			}
+		}
+	}
+}
+
+// subWorkflowCall is a run of a called workflow created for a sub-workflow node run, it is started once the
+// node run is updated.
+type subWorkflowCall struct {
+	proj *sdk.Project
+	run  *sdk.WorkflowRun
+	opts *sdk.WorkflowRunPostHandlerOption
+}
+
+func (api *API) startSubWorkflowRuns(ctx context.Context) error {
+	db := api.mustDB()
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WrapError(err, "cannot start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	nodeRuns, err := workflow.LoadAndLockSubWorkflowNodeRunsToStart(ctx, tx, subWorkflowBatchSize)
+	if err != nil {
+		return err
+	}
+
+	reports := make(map[string]*workflow.ProcessorReport)
+	var calls []subWorkflowCall
+	for i := range nodeRuns {
+		nr := &nodeRuns[i]
+		parentRun, err := workflow.LoadRunByID(tx, nr.WorkflowRunID, workflow.LoadRunOptions{})
+		if err != nil {
+			return err
+		}
+		proj, err := project.LoadByID(tx, api.Cache, parentRun.ProjectID,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithFeatures,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithApplicationVariables,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %d", parentRun.ProjectID)
+		}
+
+		callback := sdk.WorkflowNodeOutgoingHookRunCallback{Start: time.Now()}
+		call, err := api.createSubWorkflowRun(ctx, proj, parentRun, nr)
+		if err != nil {
+			log.Warning(ctx, "startSubWorkflowRuns> unable to call workflow %s from %s/%s#%d: %v", nr.SubWorkflow.WorkflowName, proj.Key, parentRun.Workflow.Name, parentRun.Number, err)
+			callback.Done = time.Now()
+			callback.Status = sdk.StatusFail
+			callback.Log = fmt.Sprintf("Unable to start workflow %s: %s", nr.SubWorkflow.WorkflowName, sdk.Cause(err).Error())
+		} else {
+			callback.Status = sdk.StatusBuilding
+			callback.Log = fmt.Sprintf("Workflow %s/%s #%d has been started", proj.Key, nr.SubWorkflow.WorkflowName, call.run.Number)
+			callback.WorkflowRunNumber = &call.run.Number
+			calls = append(calls, *call)
+		}
+
+		r1, err := workflow.UpdateOutgoingHookRunStatus(ctx, tx, api.Cache, proj, parentRun, nr.UUID, callback)
+		if err != nil {
+			return sdk.WrapError(err, "unable to update sub-workflow node run %d", nr.ID)
+		}
+		if reports[proj.Key] == nil {
+			reports[proj.Key] = new(workflow.ProcessorReport)
+		}
+		reports[proj.Key].Merge(ctx, r1, nil) // nolint
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "unable to commit transaction")
+	}
+
+	for key, report := range reports {
+		go WorkflowSendEvent(context.Background(), db, api.Cache, key, report)
+	}
+
+	// The called runs are started once the node runs are at status Building, so they can be updated when a
+	// called run is over
+	for _, c := range calls {
+		call := c
+		sdk.GoRoutine(context.Background(), fmt.Sprintf("api.startSubWorkflowRun-%d", call.run.ID), func(ctx context.Context) {
+			api.startSubWorkflowRun(ctx, call)
+		}, api.PanicDump())
+	}
+
+	return nil
+}
+
+// createSubWorkflowRun creates the run of the workflow called by a sub-workflow node run, the event of the run
+// links it to the node run of the parent workflow.
+func (api *API) createSubWorkflowRun(ctx context.Context, proj *sdk.Project, parentRun *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun) (*subWorkflowCall, error) {
+	db := api.mustDB()
+	wf, err := workflow.Load(ctx, db, api.Cache, proj, nr.SubWorkflow.WorkflowName, workflow.LoadOptions{
+		DeepPipeline:          true,
+		Base64Keys:            true,
+		WithAsCodeUpdateEvent: true,
+		WithIcon:              true,
+		WithIntegrations:      true,
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow %s", nr.SubWorkflow.WorkflowName)
+	}
+	if err := workflowtemplate.AggregateTemplateInstanceOnWorkflow(ctx, db, wf); err != nil {
+		return nil, sdk.WrapError(err, "cannot load workflow template")
+	}
+
+	evt := &sdk.WorkflowNodeRunHookEvent{Payload: make(map[string]string, len(nr.SubWorkflow.Payload))}
+	for k, v := range nr.SubWorkflow.Payload {
+		evt.Payload[k] = v
+	}
+	evt.ParentWorkflow.Key = proj.Key
+	evt.ParentWorkflow.Name = parentRun.Workflow.Name
+	evt.ParentWorkflow.Run = parentRun.Number
+	evt.ParentWorkflow.HookRunID = nr.UUID
+	opts := &sdk.WorkflowRunPostHandlerOption{Hook: evt}
+
+	wr, err := workflow.CreateRun(db, wf, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	wr.Workflow = *wf
+
+	return &subWorkflowCall{proj: proj, run: wr, opts: opts}, nil
+}
+
+// startSubWorkflowRun starts a called run, if it can't be started the node run of the parent workflow takes
+// the status of the called run.
+func (api *API) startSubWorkflowRun(ctx context.Context, call subWorkflowCall) {
+	db := api.mustDB()
+	report := new(workflow.ProcessorReport)
+	defer func() {
+		go WorkflowSendEvent(context.Background(), db, api.Cache, call.proj.Key, report)
+	}()
+
+	r1, err := workflow.StartWorkflowRun(ctx, db, api.Cache, call.proj, call.run, call.opts, nil, nil)
+	report.Merge(ctx, r1, nil) // nolint
+	if err == nil {
+		workflow.ResyncNodeRunsWithCommits(ctx, db, api.Cache, call.proj, report)
+		return
+	}
+
+	r2 := failInitWorkflowRun(ctx, db, call.run, sdk.WrapError(err, "unable to start workflow %s/%s", call.proj.Key, call.run.Workflow.Name))
+	report.Merge(ctx, r2, nil) // nolint
+	if _, err := updateParentWorkflowRun(ctx, api.mustDB, api.Cache, call.run); err != nil {
+		log.Error(ctx, "startSubWorkflowRun> unable to update parent of workflow run %s/%s#%d: %v", call.proj.Key, call.run.Workflow.Name, call.run.Number, err)
+	}
+}
+
+func (api *API) getWorkflowRunLinksHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDBReplica(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow run %d for workflow %s in project %s", number, name, key)
+		}
+
+		return service.WriteJSON(w, wr.Links(), http.StatusOK)
+	}
+}
@@ -0,0 +1,359 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/database"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/api/workflowtimer"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	// workflowTimerSchedulerInterval is the delay between two checks of due timers.
+	workflowTimerSchedulerInterval = 30 * time.Second
+	// workflowTimerLeaderLease is the name of the lease used to elect the API instance that executes timers.
+	workflowTimerLeaderLease = "workflow-timers"
+	// workflowTimerBatchSize is the max count of timers executed at each check.
+	workflowTimerBatchSize = 50
+)
+
+func (api *API) loadWorkflowForTimer(ctx context.Context, r *http.Request) (*sdk.Workflow, error) {
+	vars := mux.Vars(r)
+	key := vars["key"]
+	name := vars["permWorkflowName"]
+
+	proj, err := project.Load(api.mustDB(), api.Cache, key)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load project %s", key)
+	}
+
+	wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow %s", name)
+	}
+	return wf, nil
+}
+
+func (api *API) getWorkflowTimersHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForTimer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		ts, err := workflowtimer.LoadAllByWorkflowID(ctx, api.mustDB(), wf.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, ts, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowTimerHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForTimer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		t, err := workflowtimer.LoadByWorkflowIDAndName(ctx, api.mustDB(), wf.ID, mux.Vars(r)["timerName"])
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, t, http.StatusOK)
+	}
+}
+
+// prepareWorkflowTimer sets default values and the author of the timer, only a consumer that can run the
+// workflow can create or update a timer.
+func (api *API) prepareWorkflowTimer(ctx context.Context, wf *sdk.Workflow, t *sdk.WorkflowTimer) error {
+	if t.Timezone == "" {
+		t.Timezone = "UTC"
+	}
+	if t.CatchUp == "" {
+		t.CatchUp = sdk.WorkflowTimerCatchUpSkip
+	}
+	if err := t.IsValid(); err != nil {
+		return err
+	}
+
+	consumer := getAPIConsumer(ctx)
+	if !permission.AccessToWorkflowNode(ctx, api.mustDB(), wf, &wf.WorkflowData.Node, consumer, sdk.PermissionReadExecute) {
+		return sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", wf.WorkflowData.Node.Name)
+	}
+	t.AuthorConsumerID = consumer.ID
+
+	next, err := t.Next(time.Now())
+	if err != nil {
+		return err
+	}
+	t.NextExecution = next
+	return nil
+}
+
+func (api *API) postWorkflowTimerHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForTimer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var t sdk.WorkflowTimer
+		if err := service.UnmarshalBody(r, &t); err != nil {
+			return err
+		}
+		t.ID = 0
+		t.WorkflowID = wf.ID
+		t.Created = time.Now()
+		if err := api.prepareWorkflowTimer(ctx, wf, &t); err != nil {
+			return err
+		}
+
+		if err := workflowtimer.Insert(api.mustDB(), &t); err != nil {
+			if e, ok := sdk.Cause(err).(*pq.Error); ok && e.Code == database.ViolateUniqueKeyPGCode {
+				return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a timer named %s already exists on workflow %s", t.Name, wf.Name)
+			}
+			return err
+		}
+
+		return service.WriteJSON(w, t, http.StatusOK)
+	}
+}
+
+func (api *API) putWorkflowTimerHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForTimer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		old, err := workflowtimer.LoadByWorkflowIDAndName(ctx, api.mustDB(), wf.ID, mux.Vars(r)["timerName"])
+		if err != nil {
+			return err
+		}
+
+		var t sdk.WorkflowTimer
+		if err := service.UnmarshalBody(r, &t); err != nil {
+			return err
+		}
+		t.ID = old.ID
+		t.WorkflowID = old.WorkflowID
+		t.Created = old.Created
+		t.LastExecution = old.LastExecution
+		t.LastStatus = old.LastStatus
+		t.LastError = old.LastError
+		t.LastRunNumber = old.LastRunNumber
+		t.MissedExecutions = old.MissedExecutions
+		if err := api.prepareWorkflowTimer(ctx, wf, &t); err != nil {
+			return err
+		}
+
+		if err := workflowtimer.Update(api.mustDB(), &t); err != nil {
+			if e, ok := sdk.Cause(err).(*pq.Error); ok && e.Code == database.ViolateUniqueKeyPGCode {
+				return sdk.NewErrorFrom(sdk.ErrAlreadyExist, "a timer named %s already exists on workflow %s", t.Name, wf.Name)
+			}
+			return err
+		}
+
+		return service.WriteJSON(w, t, http.StatusOK)
+	}
+}
+
+func (api *API) deleteWorkflowTimerHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wf, err := api.loadWorkflowForTimer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		t, err := workflowtimer.LoadByWorkflowIDAndName(ctx, api.mustDB(), wf.ID, mux.Vars(r)["timerName"])
+		if err != nil {
+			return err
+		}
+
+		if err := workflowtimer.Delete(api.mustDB(), t); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// workflowTimerScheduler executes due workflow timers. Only the API instance that holds the leader lease
+// executes timers, the lease is renewed at each check.
+func (api *API) workflowTimerScheduler(ctx context.Context) {
+	holder := fmt.Sprintf("%s-%s", api.ServiceName, sdk.UUID())
+	tick := time.NewTicker(workflowTimerSchedulerInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := workflowtimer.ReleaseLeadership(api.mustDB(), workflowTimerLeaderLease, holder); err != nil {
+				log.Error(context.Background(), "workflowTimerScheduler> %v", err)
+			}
+			return
+		case <-tick.C:
+			// Missed executions will be caught up at the end of the maintenance
+			if api.Maintenance {
+				continue
+			}
+			isLeader, err := workflowtimer.AcquireLeadership(api.mustDB(), workflowTimerLeaderLease, holder, 3*workflowTimerSchedulerInterval)
+			if err != nil {
+				log.Error(ctx, "workflowTimerScheduler> %v", err)
+				continue
+			}
+			if !isLeader {
+				continue
+			}
+			if err := api.executeDueWorkflowTimers(ctx, time.Now()); err != nil {
+				log.Error(ctx, "workflowTimerScheduler> %v", err)
+			}
+		}
+	}
+}
+
+func (api *API) executeDueWorkflowTimers(ctx context.Context, now time.Time) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WrapError(err, "cannot start transaction")
+	}
+	defer tx.Rollback() // nolint
+
+	ts, err := workflowtimer.LoadDueForUpdate(ctx, tx, now, workflowTimerBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range ts {
+		t := &ts[i]
+		executions, missed, next, err := t.ExecutionsDue(now)
+		if err != nil {
+			// Invalid timers are disabled to not be loaded again
+			t.Disabled = true
+			t.LastStatus = sdk.StatusFail
+			t.LastError = err.Error()
+		}
+		for _, at := range executions {
+			execution := at
+			t.LastExecution = &execution
+			num, err := api.executeWorkflowTimer(ctx, t)
+			if err != nil {
+				log.Error(ctx, "executeDueWorkflowTimers> unable to execute timer %s on workflow %d: %v", t.Name, t.WorkflowID, err)
+				t.LastStatus = sdk.StatusFail
+				t.LastError = err.Error()
+				continue
+			}
+			t.LastStatus = sdk.StatusSuccess
+			t.LastError = ""
+			t.LastRunNumber = num
+		}
+		t.MissedExecutions += missed
+		if !next.IsZero() {
+			t.NextExecution = next
+		}
+		if err := workflowtimer.Update(tx, t); err != nil {
+			return err
+		}
+	}
+
+	return sdk.WithStack(tx.Commit())
+}
+
+// executeWorkflowTimer starts a run of the timer's workflow as its author and returns the run number.
+func (api *API) executeWorkflowTimer(ctx context.Context, t *sdk.WorkflowTimer) (int64, error) {
+	db := api.mustDB()
+
+	consumer, err := authentication.LoadConsumerByID(ctx, db, t.AuthorConsumerID,
+		authentication.LoadConsumerOptions.Default, authentication.LoadConsumerOptions.WithAuthentifiedUser)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to load author of the timer")
+	}
+	if consumer.Disabled {
+		return 0, sdk.NewErrorFrom(sdk.ErrUnauthorized, "author of the timer is disabled")
+	}
+
+	projectID, err := db.SelectInt("SELECT project_id FROM workflow WHERE id = $1", t.WorkflowID)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to load project id of workflow %d", t.WorkflowID)
+	}
+	proj, err := project.LoadByID(db, api.Cache, projectID,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithFeatures,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithApplicationVariables,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+	)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to load project %d", projectID)
+	}
+
+	wf, err := workflow.LoadByID(ctx, db, api.Cache, proj, t.WorkflowID, workflow.LoadOptions{
+		DeepPipeline:          true,
+		Base64Keys:            true,
+		WithAsCodeUpdateEvent: true,
+		WithIcon:              true,
+		WithIntegrations:      true,
+	})
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to load workflow %d", t.WorkflowID)
+	}
+	if err := workflowtemplate.AggregateTemplateInstanceOnWorkflow(ctx, db, wf); err != nil {
+		return 0, sdk.WrapError(err, "cannot load workflow template")
+	}
+
+	wr, err := api.startWorkflowRunAs(ctx, proj, wf, consumer, t.Payload)
+	if err != nil {
+		return 0, err
+	}
+	return wr.Number, nil
+}
+
+// startWorkflowRunAs starts a manual run of a workflow on behalf of a consumer, with the given payload.
+func (api *API) startWorkflowRunAs(ctx context.Context, proj *sdk.Project, wf *sdk.Workflow, consumer *sdk.AuthConsumer, payload map[string]string) (*sdk.WorkflowRun, error) {
+	db := api.mustDB()
+
+	if !permission.AccessToWorkflowNode(ctx, db, wf, &wf.WorkflowData.Node, consumer, sdk.PermissionReadExecute) {
+		return nil, sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", wf.WorkflowData.Node.Name)
+	}
+
+	values := make(map[string]string, len(payload))
+	for k, v := range payload {
+		values[k] = v
+	}
+	opts := &sdk.WorkflowRunPostHandlerOption{
+		Manual: &sdk.WorkflowNodeRunManual{
+			Payload:  values,
+			Username: consumer.GetUsername(),
+			Fullname: consumer.GetFullname(),
+		},
+	}
+
+	wr, err := workflow.CreateRun(db, wf, opts, consumer)
+	if err != nil {
+		return nil, err
+	}
+
+	sdk.GoRoutine(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", wr.ID), func(ctx context.Context) {
+		api.initWorkflowRun(ctx, db, api.Cache, proj, wf, wr, opts, consumer)
+	}, api.PanicDump())
+
+	return wr, nil
+}
@@ -0,0 +1,315 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/luascript"
+)
+
+// hookTaskExecutionDone is the status set by the hooks service once an execution was processed.
+const hookTaskExecutionDone = "DONE"
+
+// repositoryEventHeaders contains headers that are used by repository managers to give the event type.
+var repositoryEventHeaders = []string{"X-Gitea-Event", "X-Github-Event", "X-Gitlab-Event", "X-Event-Key"}
+
+// snsMessageTypeHeader is sent by AWS SNS that delivers CodeCommit events.
+const snsMessageTypeHeader = "X-Amz-Sns-Message-Type"
+
+// repositoryDefaultEvents are accepted by repository webhooks when no event filter is set.
+var repositoryDefaultEvents = []string{"push", "Push Hook", "repo:refs_changed", "repo:push"}
+
+func (api *API) getWorkflowTriggerExplainHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		commit := r.FormValue("commit")
+		branch := r.FormValue("branch")
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, p, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		srvs, err := services.LoadAllByType(ctx, api.mustDB(), services.TypeHooks)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load hooks services")
+		}
+
+		res := sdk.WorkflowTriggerExplain{
+			ProjectKey:   p.Key,
+			WorkflowName: wf.Name,
+			Commit:       commit,
+			Branch:       branch,
+			Hooks:        []sdk.WorkflowTriggerExplainHook{},
+		}
+
+		for _, n := range wf.WorkflowData.Array() {
+			for i := range n.Hooks {
+				h := &n.Hooks[i]
+				switch h.HookModelName {
+				case sdk.RepositoryWebHookModelName, sdk.PullRequestHookModelName, sdk.GitPollerModelName, sdk.GerritHookModelName:
+				default:
+					continue
+				}
+
+				var task sdk.Task
+				if _, _, err := services.NewClient(api.mustDB(), srvs).DoJSONRequest(ctx, http.MethodGet, fmt.Sprintf("/task/%s/execution", h.UUID), nil, &task); err != nil {
+					log.Warning(ctx, "getWorkflowTriggerExplainHandler> unable to get hook %s task and executions: %v", h.UUID, err)
+					res.Hooks = append(res.Hooks, sdk.WorkflowTriggerExplainHook{
+						UUID:      h.UUID,
+						ModelName: h.HookModelName,
+						NodeName:  n.Name,
+						Error:     fmt.Sprintf("unable to get hook executions from hooks service: %v", sdk.Cause(err)),
+					})
+					continue
+				}
+
+				res.Hooks = append(res.Hooks, explainHookExecutions(*h, *n, task, commit, branch))
+			}
+		}
+
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// explainHookExecutions returns explanations for all hook executions that match given commit and branch.
+func explainHookExecutions(h sdk.NodeHook, n sdk.Node, task sdk.Task, commit, branch string) sdk.WorkflowTriggerExplainHook {
+	res := sdk.WorkflowTriggerExplainHook{
+		UUID:       h.UUID,
+		ModelName:  h.HookModelName,
+		NodeName:   n.Name,
+		Stopped:    task.Stopped,
+		Executions: []sdk.WorkflowTriggerExplainExecution{},
+	}
+
+	sort.Slice(task.Executions, func(i, j int) bool {
+		return task.Executions[i].Timestamp > task.Executions[j].Timestamp
+	})
+	for _, e := range task.Executions {
+		if !matchTaskExecution(e, commit, branch) {
+			continue
+		}
+		res.Executions = append(res.Executions, explainTaskExecution(h, n, e))
+	}
+
+	return res
+}
+
+// matchTaskExecution returns true if the execution is about given commit and branch. For executions
+// without computed events the raw request is used.
+func matchTaskExecution(e sdk.TaskExecution, commit, branch string) bool {
+	if commit == "" && branch == "" {
+		return true
+	}
+
+	if len(e.Events) > 0 {
+		for _, ev := range e.Events {
+			if (commit == "" || strings.HasPrefix(ev.Payload["git.hash"], commit)) &&
+				(branch == "" || ev.Payload["git.branch"] == branch) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var raw string
+	switch {
+	case e.WebHook != nil:
+		raw = string(e.WebHook.RequestBody)
+	case e.GerritEvent != nil:
+		raw = string(e.GerritEvent.Message)
+	default:
+		return false
+	}
+	return (commit == "" || strings.Contains(raw, commit)) && (branch == "" || strings.Contains(raw, branch))
+}
+
+func taskExecutionEventType(e sdk.TaskExecution) string {
+	if e.WebHook == nil {
+		return e.Type
+	}
+	header := http.Header(e.WebHook.RequestHeader)
+	for _, k := range repositoryEventHeaders {
+		if v := header.Get(k); v != "" {
+			return v
+		}
+	}
+	return e.Type
+}
+
+func explainTaskExecution(h sdk.NodeHook, n sdk.Node, e sdk.TaskExecution) sdk.WorkflowTriggerExplainExecution {
+	res := sdk.WorkflowTriggerExplainExecution{
+		Timestamp: e.Timestamp,
+		EventType: taskExecutionEventType(e),
+		Status:    e.Status,
+	}
+
+	if e.Status != hookTaskExecutionDone {
+		res.Decision = sdk.WorkflowTriggerDecisionPending
+		res.Reason = "event not yet processed by the hooks service"
+		if e.LastError != "" {
+			res.Reason = fmt.Sprintf("event will be retried by the hooks service after error: %s", e.LastError)
+		}
+		return res
+	}
+
+	// Check the event type against hook's filter for repository webhooks, CodeCommit events are sent through
+	// SNS and filtered by the hooks service on the type of the updated references
+	if sdk.IsRepositoryWebHookModel(h.HookModelName) && e.WebHook != nil && http.Header(e.WebHook.RequestHeader).Get(snsMessageTypeHeader) == "" {
+		allowed := repositoryDefaultEvents
+		if f, ok := h.Config[sdk.HookConfigEventFilter]; ok && f.Value != "" {
+			allowed = strings.Split(f.Value, ";")
+		}
+		if !sdk.IsInArray(res.EventType, allowed) {
+			res.Decision = sdk.WorkflowTriggerDecisionIgnored
+			res.Reason = fmt.Sprintf("event type %q is not in hook's events filter (%s)", res.EventType, strings.Join(allowed, ", "))
+			return res
+		}
+	}
+
+	if len(e.Events) == 0 {
+		switch {
+		case e.WorkflowRun > 0:
+			res.Decision = sdk.WorkflowTriggerDecisionTriggered
+			res.Reason = fmt.Sprintf("workflow run %d was started", e.WorkflowRun)
+		case e.LastError != "":
+			res.Decision = sdk.WorkflowTriggerDecisionError
+			res.Reason = fmt.Sprintf("hooks service failed to process the event: %s", e.LastError)
+		default:
+			res.Decision = sdk.WorkflowTriggerDecisionIgnored
+			res.Reason = "no commit to build was found in the event (ie. branch or tag deletion)"
+		}
+		return res
+	}
+
+	for _, ev := range e.Events {
+		res.Events = append(res.Events, explainTriggerEvent(h, n, ev))
+	}
+
+	// The execution is considered triggered if at least one of its events started a run
+	res.Decision = res.Events[0].Decision
+	res.Reason = res.Events[0].Reason
+	for _, ev := range res.Events {
+		if ev.Decision == sdk.WorkflowTriggerDecisionTriggered {
+			res.Decision = ev.Decision
+			res.Reason = ev.Reason
+			break
+		}
+	}
+
+	return res
+}
+
+func explainTriggerEvent(h sdk.NodeHook, n sdk.Node, ev sdk.TaskExecutionEvent) sdk.WorkflowTriggerExplainEvent {
+	res := sdk.WorkflowTriggerExplainEvent{
+		Branch:      ev.Payload["git.branch"],
+		Tag:         ev.Payload["git.tag"],
+		Commit:      ev.Payload["git.hash"],
+		Message:     ev.Payload["git.message"],
+		Author:      ev.Payload["git.author"],
+		WorkflowRun: ev.WorkflowRun,
+	}
+
+	params := sdk.ParametersFromMap(ev.Payload)
+	hookChecks := checkTriggerConditions(sdk.WorkflowTriggerCheckHookCondition, h.Conditions, params, false)
+	var nodeChecks []sdk.WorkflowTriggerCheck
+	if n.Context != nil {
+		// Only variables given by the hook are known here, other conditions can't be evaluated
+		nodeChecks = checkTriggerConditions(sdk.WorkflowTriggerCheckNodeCondition, n.Context.Conditions, params, true)
+	}
+	res.Checks = append(hookChecks, nodeChecks...)
+
+	failed := func(checks []sdk.WorkflowTriggerCheck) bool {
+		for _, c := range checks {
+			if !c.OK {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case ev.WorkflowRun > 0 && failed(nodeChecks):
+		res.Decision = sdk.WorkflowTriggerDecisionTriggered
+		res.Reason = fmt.Sprintf("workflow run %d was started but conditions on node %s are not satisfied", ev.WorkflowRun, n.Name)
+	case ev.WorkflowRun > 0:
+		res.Decision = sdk.WorkflowTriggerDecisionTriggered
+		res.Reason = fmt.Sprintf("workflow run %d was started", ev.WorkflowRun)
+	case failed(hookChecks):
+		res.Decision = sdk.WorkflowTriggerDecisionRejected
+		res.Reason = "hook conditions are not satisfied"
+	case ev.Error != "":
+		res.Decision = sdk.WorkflowTriggerDecisionError
+		res.Reason = fmt.Sprintf("API refused to start the workflow: %s", ev.Error)
+	default:
+		res.Decision = sdk.WorkflowTriggerDecisionIgnored
+		res.Reason = "no workflow run was started"
+	}
+
+	return res
+}
+
+// checkTriggerConditions evaluates each condition independently to return which one failed.
+// If onlyKnown is true, plain conditions on variables that are not in params are ignored.
+func checkTriggerConditions(kind string, conditions sdk.WorkflowNodeConditions, params []sdk.Parameter, onlyKnown bool) []sdk.WorkflowTriggerCheck {
+	var checks []sdk.WorkflowTriggerCheck
+	mapParams := sdk.ParametersToMap(params)
+
+	if conditions.LuaScript != "" {
+		if onlyKnown {
+			return nil
+		}
+		check := sdk.WorkflowTriggerCheck{Kind: kind, Operator: "lua", Expected: conditions.LuaScript}
+		luacheck, err := luascript.NewCheck()
+		if err != nil {
+			check.Message = err.Error()
+			return append(checks, check)
+		}
+		luacheck.SetVariables(mapParams)
+		if err := luacheck.Perform(conditions.LuaScript); err != nil {
+			check.Message = err.Error()
+		}
+		check.OK = luacheck.Result
+		return append(checks, check)
+	}
+
+	for _, c := range conditions.PlainConditions {
+		value, known := mapParams[c.Variable]
+		if onlyKnown && !known {
+			continue
+		}
+		check := sdk.WorkflowTriggerCheck{
+			Kind:     kind,
+			Variable: c.Variable,
+			Operator: c.Operator,
+			Expected: c.Value,
+			Value:    value,
+		}
+		ok, err := sdk.WorkflowCheckConditions([]sdk.WorkflowNodeCondition{c}, params)
+		if err != nil {
+			check.Message = err.Error()
+		}
+		check.OK = ok
+		checks = append(checks, check)
+	}
+
+	return checks
+}
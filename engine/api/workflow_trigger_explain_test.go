@@ -0,0 +1,78 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_explainHookExecutions(t *testing.T) {
+	h := sdk.NodeHook{
+		UUID:          "hook-uuid",
+		HookModelName: sdk.RepositoryWebHookModelName,
+		Config:        sdk.WorkflowNodeHookConfig{},
+		Conditions: sdk.WorkflowNodeConditions{
+			PlainConditions: []sdk.WorkflowNodeCondition{{Variable: "git.branch", Operator: sdk.WorkflowConditionsOperatorEquals, Value: "master"}},
+		},
+	}
+	n := sdk.Node{Name: "build"}
+
+	pushHeader := map[string][]string{"X-Github-Event": {"push"}}
+	task := sdk.Task{
+		Executions: []sdk.TaskExecution{
+			{
+				Timestamp: 1,
+				Status:    hookTaskExecutionDone,
+				WebHook:   &sdk.WebHookExecution{RequestHeader: pushHeader},
+				Events: []sdk.TaskExecutionEvent{{
+					Payload:     map[string]string{"git.branch": "master", "git.hash": "3a1f2e9c"},
+					WorkflowRun: 12,
+				}},
+			},
+			{
+				Timestamp: 2,
+				Status:    hookTaskExecutionDone,
+				WebHook:   &sdk.WebHookExecution{RequestHeader: pushHeader},
+				Events: []sdk.TaskExecutionEvent{{
+					Payload: map[string]string{"git.branch": "feat/my-feature", "git.hash": "b7c4d2e1"},
+					Error:   "conditions not ok",
+				}},
+			},
+			{
+				Timestamp: 3,
+				Status:    hookTaskExecutionDone,
+				WebHook:   &sdk.WebHookExecution{RequestHeader: map[string][]string{"X-Github-Event": {"pull_request"}}, RequestBody: []byte(`{"after": "b7c4d2e1"}`)},
+			},
+			{
+				Timestamp: 4,
+				Status:    "ENQUEUED",
+				WebHook:   &sdk.WebHookExecution{RequestHeader: pushHeader, RequestBody: []byte(`{"after": "f00d"}`)},
+			},
+		},
+	}
+
+	res := explainHookExecutions(h, n, task, "", "")
+	require.Len(t, res.Executions, 4)
+	assert.Equal(t, int64(4), res.Executions[0].Timestamp, "executions should be sorted from the most recent")
+	assert.Equal(t, sdk.WorkflowTriggerDecisionPending, res.Executions[0].Decision)
+	assert.Equal(t, sdk.WorkflowTriggerDecisionIgnored, res.Executions[1].Decision)
+	assert.Equal(t, "pull_request", res.Executions[1].EventType)
+	assert.Equal(t, sdk.WorkflowTriggerDecisionRejected, res.Executions[2].Decision)
+	assert.Equal(t, sdk.WorkflowTriggerDecisionTriggered, res.Executions[3].Decision)
+
+	res = explainHookExecutions(h, n, task, "b7c4", "")
+	require.Len(t, res.Executions, 2)
+	require.Len(t, res.Executions[1].Events, 1)
+	ev := res.Executions[1].Events[0]
+	assert.Equal(t, "feat/my-feature", ev.Branch)
+	require.Len(t, ev.Checks, 1)
+	assert.False(t, ev.Checks[0].OK)
+	assert.Equal(t, "feat/my-feature", ev.Checks[0].Value)
+
+	res = explainHookExecutions(h, n, task, "", "master")
+	require.Len(t, res.Executions, 1)
+	assert.Equal(t, int64(12), res.Executions[0].Events[0].WorkflowRun)
+}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// workflowTriggerAudience is the audience of the signed trigger urls, other signatures of the API are rejected.
+const workflowTriggerAudience = "workflow-trigger"
+
+// workflowTriggerSignature is the content of a signed trigger url. The run will be started
+// with the consumer that generated the url, the url can be revoked with its id.
+type workflowTriggerSignature struct {
+	ID            string                           `json:"id"`
+	Audience      string                           `json:"aud"`
+	ProjectKey    string                           `json:"project_key"`
+	WorkflowID    int64                            `json:"workflow_id"`
+	ConsumerID    string                           `json:"consumer_id"`
+	PayloadSchema sdk.WorkflowTriggerPayloadSchema `json:"payload_schema"`
+}
+
+func (api *API) postWorkflowTriggerURLHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		var req sdk.WorkflowTriggerURLRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+		if err := req.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, p, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		now := time.Now()
+		u := sdk.WorkflowTriggerURL{
+			ID:         sdk.UUID(),
+			WorkflowID: wf.ID,
+			ConsumerID: getAPIConsumer(ctx).ID,
+			Created:    now,
+			Expire:     now.Add(req.GetDuration()),
+		}
+		if err := workflow.InsertTriggerURL(api.mustDB(), &u); err != nil {
+			return err
+		}
+
+		signature, err := authentication.SignJWS(workflowTriggerSignature{
+			ID:            u.ID,
+			Audience:      workflowTriggerAudience,
+			ProjectKey:    p.Key,
+			WorkflowID:    wf.ID,
+			ConsumerID:    u.ConsumerID,
+			PayloadSchema: req.PayloadSchema,
+		}, req.GetDuration())
+		if err != nil {
+			return err
+		}
+		u.URL = fmt.Sprintf("%s/workflow/trigger/%s", api.Config.URL.API, signature)
+
+		return service.WriteJSON(w, u, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowTriggerURLsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, p, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		us, err := workflow.LoadTriggerURLs(ctx, api.mustDB(), wf.ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, us, http.StatusOK)
+	}
+}
+
+func (api *API) deleteWorkflowTriggerURLHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, p, name, workflow.LoadOptions{Minimal: true})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		if err := workflow.RevokeTriggerURL(api.mustDB(), wf.ID, vars["id"]); err != nil {
+			return err
+		}
+		log.Info(ctx, "deleteWorkflowTriggerURLHandler> trigger url %s of workflow %s/%s revoked by %s", vars["id"], key, name, getAPIConsumer(ctx).GetUsername())
+		return nil
+	}
+}
+
+func (api *API) postWorkflowTriggerHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		var sig workflowTriggerSignature
+		if err := authentication.VerifyJWS(vars["signature"], &sig); err != nil {
+			return sdk.NewErrorWithStack(err, sdk.ErrUnauthorized)
+		}
+		if sig.Audience != workflowTriggerAudience || sig.ID == "" {
+			return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid trigger url")
+		}
+
+		u, err := workflow.LoadTriggerURLByID(ctx, api.mustDB(), sig.WorkflowID, sig.ID)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrUnauthorized, "trigger url was revoked")
+			}
+			return err
+		}
+		if u.Revoked {
+			return sdk.NewErrorFrom(sdk.ErrUnauthorized, "trigger url was revoked")
+		}
+
+		// The consumer that generated the url should still exists and be enabled
+		consumer, err := authentication.LoadConsumerByID(ctx, api.mustDB(), sig.ConsumerID,
+			authentication.LoadConsumerOptions.Default,
+			authentication.LoadConsumerOptions.WithAuthentifiedUser)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrUnauthorized, "trigger url was revoked")
+			}
+			return err
+		}
+		if consumer.Disabled {
+			return sdk.NewErrorFrom(sdk.ErrUnauthorized, "trigger url was revoked")
+		}
+
+		payload := make(map[string]interface{})
+		if r.ContentLength != 0 {
+			if err := service.UnmarshalBody(r, &payload); err != nil {
+				return err
+			}
+		}
+		if err := sig.PayloadSchema.Check(payload); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, sig.ProjectKey,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithFeatures,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithApplicationVariables,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project")
+		}
+
+		wf, err := workflow.LoadByID(ctx, api.mustDB(), api.Cache, p, sig.WorkflowID, workflow.LoadOptions{
+			DeepPipeline:          true,
+			Base64Keys:            true,
+			WithAsCodeUpdateEvent: true,
+			WithIcon:              true,
+			WithIntegrations:      true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %d", sig.WorkflowID)
+		}
+
+		if err := workflowtemplate.AggregateTemplateInstanceOnWorkflow(ctx, api.mustDB(), wf); err != nil {
+			return sdk.WrapError(err, "cannot load workflow template")
+		}
+
+		// Permissions are checked at trigger time, the consumer could have lost access to the workflow
+		if !permission.AccessToWorkflowNode(ctx, api.mustDB(), wf, &wf.WorkflowData.Node, consumer, sdk.PermissionReadExecute) {
+			return sdk.WrapError(sdk.ErrNoPermExecution, "not enough right on node %s", wf.WorkflowData.Node.Name)
+		}
+
+		opts := &sdk.WorkflowRunPostHandlerOption{
+			Manual: &sdk.WorkflowNodeRunManual{
+				Payload:  payload,
+				Username: consumer.AuthentifiedUser.Username,
+				Fullname: consumer.AuthentifiedUser.Fullname,
+			},
+		}
+
+		wr, err := workflow.CreateRun(api.mustDB(), wf, opts, consumer)
+		if err != nil {
+			return err
+		}
+
+		log.Info(ctx, "postWorkflowTriggerHandler> workflow %s/%s triggered from signed url generated by consumer %s", p.Key, wf.Name, consumer.ID)
+
+		sdk.GoRoutine(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", wr.ID), func(ctx context.Context) {
+			api.initWorkflowRun(ctx, api.mustDB(), api.Cache, p, wf, wr, opts, consumer)
+		}, api.PanicDump())
+
+		return service.WriteJSON(w, wr, http.StatusAccepted)
+	}
+}
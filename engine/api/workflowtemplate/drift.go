@@ -0,0 +1,231 @@
+package workflowtemplate
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// Entity types used in drift differences.
+const (
+	driftTypeWorkflow    = "workflow"
+	driftTypePipeline    = "pipeline"
+	driftTypeApplication = "application"
+	driftTypeEnvironment = "environment"
+)
+
+// ComputeDrift returns the differences between the result of the latest template version for given instance
+// and the entities currently pulled from the project.
+func ComputeDrift(wt *sdk.WorkflowTemplate, wti *sdk.WorkflowTemplateInstance, current *exportentities.WorkflowPulled) (sdk.WorkflowTemplateInstanceDrift, error) {
+	drift := sdk.WorkflowTemplateInstanceDrift{
+		InstanceID:      wti.ID,
+		WorkflowName:    wti.WorkflowName,
+		InstanceVersion: wti.WorkflowTemplateVersion,
+		TemplateVersion: wt.Version,
+		Outdated:        wti.WorkflowTemplateVersion < wt.Version,
+	}
+	if wti.Project != nil {
+		drift.ProjectKey = wti.Project.Key
+	}
+
+	res, err := Execute(wt, wti)
+	if err != nil {
+		return drift, err
+	}
+
+	// If the workflow was not imported, all generated entities are missing
+	if current == nil {
+		current = new(exportentities.WorkflowPulled)
+	}
+
+	generated := map[string][]string{
+		driftTypeWorkflow:    {res.Workflow},
+		driftTypePipeline:    res.Pipelines,
+		driftTypeApplication: res.Applications,
+		driftTypeEnvironment: res.Environments,
+	}
+	existing := map[string][]exportentities.WorkflowPulledItem{
+		driftTypePipeline:    current.Pipelines,
+		driftTypeApplication: current.Applications,
+		driftTypeEnvironment: current.Environments,
+	}
+	if current.Workflow.Value != "" {
+		existing[driftTypeWorkflow] = []exportentities.WorkflowPulledItem{current.Workflow}
+	}
+
+	for _, t := range []string{driftTypeWorkflow, driftTypePipeline, driftTypeApplication, driftTypeEnvironment} {
+		diffs, err := diffEntities(t, generated[t], existing[t])
+		if err != nil {
+			return drift, err
+		}
+		drift.Differences = append(drift.Differences, diffs...)
+	}
+
+	return drift, nil
+}
+
+func diffEntities(entityType string, generated []string, existing []exportentities.WorkflowPulledItem) ([]sdk.WorkflowTemplateDifference, error) {
+	mGenerated := make(map[string]string, len(generated))
+	for i := range generated {
+		name, value, err := normalizeEntity(entityType, []byte(generated[i]))
+		if err != nil {
+			return nil, err
+		}
+		mGenerated[name] = value
+	}
+
+	mExisting := make(map[string]string, len(existing))
+	for i := range existing {
+		btes, err := base64.StdEncoding.DecodeString(existing[i].Value)
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		_, value, err := normalizeEntity(entityType, btes)
+		if err != nil {
+			return nil, err
+		}
+		mExisting[existing[i].Name] = value
+	}
+
+	var diffs []sdk.WorkflowTemplateDifference
+	for name, value := range mGenerated {
+		old, ok := mExisting[name]
+		if !ok {
+			diffs = append(diffs, sdk.WorkflowTemplateDifference{
+				Type:   entityType,
+				Name:   name,
+				Status: sdk.WorkflowTemplateDifferenceAdded,
+			})
+			continue
+		}
+		if old != value {
+			diffs = append(diffs, sdk.WorkflowTemplateDifference{
+				Type:   entityType,
+				Name:   name,
+				Status: sdk.WorkflowTemplateDifferenceModified,
+				Diff:   diffLines(old, value),
+			})
+		}
+	}
+
+	// Entities that are no more generated by the template are only reported for
+	// the workflow, others may be used by another workflow.
+	if entityType == driftTypeWorkflow {
+		for name := range mExisting {
+			if _, ok := mGenerated[name]; !ok {
+				diffs = append(diffs, sdk.WorkflowTemplateDifference{
+					Type:   entityType,
+					Name:   name,
+					Status: sdk.WorkflowTemplateDifferenceRemoved,
+				})
+			}
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+
+	return diffs, nil
+}
+
+// normalizeEntity returns the name and a comparable yaml representation of given entity.
+// Secret values are masked on both side because exported and generated values can't be compared.
+func normalizeEntity(entityType string, btes []byte) (string, string, error) {
+	var m map[string]interface{}
+	if err := yaml.Unmarshal(btes, &m); err != nil {
+		return "", "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot parse %s: %v", entityType, err)
+	}
+
+	delete(m, "version")
+	if entityType == driftTypeWorkflow {
+		delete(m, "template")
+	}
+	if _, ok := m["vcs_password"]; ok {
+		m["vcs_password"] = sdk.PasswordPlaceholder
+	}
+	for k := range m {
+		m[k] = maskSecrets(m[k])
+	}
+
+	res, err := yaml.Marshal(m)
+	if err != nil {
+		return "", "", sdk.WithStack(err)
+	}
+
+	return fmt.Sprintf("%v", m["name"]), string(res), nil
+}
+
+func maskSecrets(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[interface{}]interface{}:
+		if t, ok := v["type"]; ok {
+			switch t {
+			case sdk.SecretVariable, sdk.KeyTypeSSH, sdk.KeyTypePGP:
+				if _, ok := v["value"]; ok {
+					v["value"] = sdk.PasswordPlaceholder
+				}
+			}
+		}
+		for k := range v {
+			v[k] = maskSecrets(v[k])
+		}
+		return v
+	case []interface{}:
+		for j := range v {
+			v[j] = maskSecrets(v[j])
+		}
+		return v
+	}
+	return i
+}
+
+// diffLines returns a line diff between a and b, based on the longest common subsequence.
+func diffLines(a, b string) string {
+	as := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	bs := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+
+	lcs := make([][]int, len(as)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bs)+1)
+	}
+	for i := len(as) - 1; i >= 0; i-- {
+		for j := len(bs) - 1; j >= 0; j-- {
+			if as[i] == bs[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	var i, j int
+	for i < len(as) && j < len(bs) {
+		switch {
+		case as[i] == bs[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			sb.WriteString("- " + as[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+ " + bs[j] + "\n")
+			j++
+		}
+	}
+	for ; i < len(as); i++ {
+		sb.WriteString("- " + as[i] + "\n")
+	}
+	for ; j < len(bs); j++ {
+		sb.WriteString("+ " + bs[j] + "\n")
+	}
+
+	return sb.String()
+}
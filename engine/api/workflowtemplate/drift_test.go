@@ -0,0 +1,106 @@
+package workflowtemplate_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestComputeDrift(t *testing.T) {
+	tmpl := &sdk.WorkflowTemplate{
+		ID:      42,
+		Version: 3,
+		Workflow: base64.StdEncoding.EncodeToString([]byte(`
+name: [[.name]]
+version: v1.0
+workflow:
+  Node-1:
+    pipeline: Pipeline-[[.id]]`)),
+		Pipelines: []sdk.PipelineTemplate{{
+			Value: base64.StdEncoding.EncodeToString([]byte(`
+version: v1.0
+name: Pipeline-[[.id]]
+jobs:
+- job: Job 1
+  steps:
+  - script:
+    - echo "Hello World!"`)),
+		}},
+		Environments: []sdk.EnvironmentTemplate{{
+			Value: base64.StdEncoding.EncodeToString([]byte(`
+name: Environment-[[.id]]
+values:
+  key1:
+    type: password
+    value: my-secret`)),
+		}},
+	}
+
+	instance := &sdk.WorkflowTemplateInstance{
+		ID:                      5,
+		WorkflowTemplateVersion: 2,
+		WorkflowName:            "my-workflow",
+		Request:                 sdk.WorkflowTemplateRequest{WorkflowName: "my-workflow"},
+		Project:                 &sdk.Project{Key: "PROJ"},
+	}
+
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	current := &exportentities.WorkflowPulled{
+		Workflow: exportentities.WorkflowPulledItem{
+			Name: "my-workflow",
+			Value: encode(`name: my-workflow
+version: v1.0
+template: group/template
+workflow:
+  Node-1:
+    pipeline: Pipeline-5
+`),
+		},
+		Pipelines: []exportentities.WorkflowPulledItem{{
+			Name: "Pipeline-5",
+			Value: encode(`version: v1.0
+name: Pipeline-5
+jobs:
+- job: Job 1
+  steps:
+  - script:
+    - echo "Hello Moon!"
+`),
+		}},
+		Environments: []exportentities.WorkflowPulledItem{{
+			Name: "Environment-5",
+			Value: encode(`name: Environment-5
+values:
+  key1:
+    type: password
+    value: 6f8e1fb3c4ef0aa6
+`),
+		}},
+	}
+
+	drift, err := workflowtemplate.ComputeDrift(tmpl, instance, current)
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ", drift.ProjectKey)
+	assert.True(t, drift.Outdated)
+	assert.True(t, drift.HasDrift())
+	require.Len(t, drift.Differences, 1)
+	assert.Equal(t, "pipeline", drift.Differences[0].Type)
+	assert.Equal(t, "Pipeline-5", drift.Differences[0].Name)
+	assert.Equal(t, sdk.WorkflowTemplateDifferenceModified, drift.Differences[0].Status)
+	assert.Regexp(t, `(?m)^- +- echo "Hello Moon!"$`, drift.Differences[0].Diff)
+	assert.Regexp(t, `(?m)^\+ +- echo "Hello World!"$`, drift.Differences[0].Diff)
+
+	// Without existing entities everything should be reported as added
+	drift, err = workflowtemplate.ComputeDrift(tmpl, instance, nil)
+	require.NoError(t, err)
+	require.Len(t, drift.Differences, 3)
+	for _, d := range drift.Differences {
+		assert.Equal(t, sdk.WorkflowTemplateDifferenceAdded, d.Status)
+	}
+}
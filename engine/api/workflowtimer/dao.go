@@ -0,0 +1,83 @@
+package workflowtimer
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.WorkflowTimer, error) {
+	var dbTimers []dbWorkflowTimer
+	if err := gorpmapping.GetAll(ctx, db, q, &dbTimers); err != nil {
+		return nil, sdk.WrapError(err, "cannot get workflow timers")
+	}
+	ts := make([]sdk.WorkflowTimer, len(dbTimers))
+	for i := range dbTimers {
+		ts[i] = sdk.WorkflowTimer(dbTimers[i])
+	}
+	return ts, nil
+}
+
+// LoadAllByWorkflowID returns all timers for given workflow.
+func LoadAllByWorkflowID(ctx context.Context, db gorp.SqlExecutor, workflowID int64) ([]sdk.WorkflowTimer, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_timer WHERE workflow_id = $1 ORDER BY name").Args(workflowID)
+	return getAll(ctx, db, query)
+}
+
+// LoadByWorkflowIDAndName returns a timer for given workflow and name.
+func LoadByWorkflowIDAndName(ctx context.Context, db gorp.SqlExecutor, workflowID int64, name string) (*sdk.WorkflowTimer, error) {
+	query := gorpmapping.NewQuery("SELECT * FROM workflow_timer WHERE workflow_id = $1 AND name = $2").Args(workflowID, name)
+	var t dbWorkflowTimer
+	found, err := gorpmapping.Get(ctx, db, query, &t)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get workflow timer")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.WorkflowTimer(t)
+	return &res, nil
+}
+
+// LoadDueForUpdate returns and locks active timers that should be executed at given time.
+func LoadDueForUpdate(ctx context.Context, db gorp.SqlExecutor, now time.Time, limit int) ([]sdk.WorkflowTimer, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_timer
+		WHERE disabled = false AND next_execution <= $1
+		ORDER BY next_execution
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`).Args(now, limit)
+	return getAll(ctx, db, query)
+}
+
+// Insert a workflow timer in database.
+func Insert(db gorp.SqlExecutor, t *sdk.WorkflowTimer) error {
+	dbT := dbWorkflowTimer(*t)
+	if err := gorpmapping.Insert(db, &dbT); err != nil {
+		return sdk.WrapError(err, "unable to insert workflow timer %s", t.Name)
+	}
+	*t = sdk.WorkflowTimer(dbT)
+	return nil
+}
+
+// Update a workflow timer in database.
+func Update(db gorp.SqlExecutor, t *sdk.WorkflowTimer) error {
+	dbT := dbWorkflowTimer(*t)
+	if err := gorpmapping.Update(db, &dbT); err != nil {
+		return sdk.WrapError(err, "unable to update workflow timer %s", t.Name)
+	}
+	return nil
+}
+
+// Delete a workflow timer in database.
+func Delete(db gorp.SqlExecutor, t *sdk.WorkflowTimer) error {
+	dbT := dbWorkflowTimer(*t)
+	if err := gorpmapping.Delete(db, &dbT); err != nil {
+		return sdk.WrapError(err, "unable to delete workflow timer %s", t.Name)
+	}
+	return nil
+}
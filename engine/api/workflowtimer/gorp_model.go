@@ -0,0 +1,12 @@
+package workflowtimer
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbWorkflowTimer sdk.WorkflowTimer
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbWorkflowTimer{}, "workflow_timer", true, "id"))
+}
@@ -0,0 +1,34 @@
+package workflowtimer
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// AcquireLeadership tries to get or renew the lease with given name for the holder. Only one API
+// instance can hold a lease until it expires, so it should be renewed before the end of its duration.
+func AcquireLeadership(db gorp.SqlExecutor, name, holder string, duration time.Duration) (bool, error) {
+	now := time.Now()
+	res, err := db.Exec(`
+		INSERT INTO api_leader_lease (name, holder, expire_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET holder = $2, expire_at = $3
+		WHERE api_leader_lease.holder = $2 OR api_leader_lease.expire_at < $4`,
+		name, holder, now.Add(duration), now)
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to acquire leader lease %s", name)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	return n == 1, nil
+}
+
+// ReleaseLeadership releases the lease if it is owned by the holder.
+func ReleaseLeadership(db gorp.SqlExecutor, name, holder string) error {
+	_, err := db.Exec("DELETE FROM api_leader_lease WHERE name = $1 AND holder = $2", name, holder)
+	return sdk.WrapError(err, "unable to release leader lease %s", name)
+}
@@ -0,0 +1,110 @@
+package chatops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// New returns a new service
+func New() *Service {
+	s := new(Service)
+	s.Router = &api.Router{
+		Mux: mux.NewRouter(),
+	}
+	return s
+}
+
+// ApplyConfiguration apply an object of type chatops.Configuration after checking it
+func (s *Service) ApplyConfiguration(config interface{}) error {
+	if err := s.CheckConfiguration(config); err != nil {
+		return err
+	}
+	var ok bool
+	s.Cfg, ok = config.(Configuration)
+	if !ok {
+		return fmt.Errorf("ApplyConfiguration> Invalid ChatOps configuration")
+	}
+
+	s.HTTPURL = s.Cfg.URL
+	s.ServiceName = s.Cfg.Name
+	s.ServiceType = services.TypeChatOps
+	s.MaxHeartbeatFailures = s.Cfg.API.MaxHeartbeatFailures
+	return nil
+}
+
+// CheckConfiguration checks the validity of the configuration object
+func (s *Service) CheckConfiguration(config interface{}) error {
+	sConfig, ok := config.(Configuration)
+	if !ok {
+		return fmt.Errorf("CheckConfiguration> Invalid ChatOps configuration")
+	}
+
+	if sConfig.URL == "" {
+		return fmt.Errorf("your CDS configuration seems to be empty. Please use environment variables, file or Consul to set your configuration")
+	}
+	if sConfig.Name == "" {
+		return fmt.Errorf("please enter a name in your ChatOps configuration")
+	}
+	if sConfig.Slack.SigningSecret == "" {
+		return fmt.Errorf("please enter the Slack signing secret in your ChatOps configuration")
+	}
+
+	return nil
+}
+
+func (s *Service) Init(config interface{}) (cdsclient.ServiceConfig, error) {
+	var cfg cdsclient.ServiceConfig
+	sConfig, ok := config.(Configuration)
+	if !ok {
+		return cfg, sdk.WithStack(fmt.Errorf("invalid ChatOps configuration"))
+	}
+
+	cfg.Host = sConfig.API.HTTP.URL
+	cfg.Token = sConfig.API.Token
+	cfg.InsecureSkipVerifyTLS = sConfig.API.HTTP.Insecure
+	cfg.RequestSecondsTimeout = sConfig.API.RequestTimeout
+	return cfg, nil
+}
+
+// Serve will start the http api server
+func (s *Service) Serve(c context.Context) error {
+	ctx, cancel := context.WithCancel(c)
+	defer cancel()
+
+	//Init the http server
+	s.initRouter(ctx)
+	server := &http.Server{
+		Addr:           fmt.Sprintf("%s:%d", s.Cfg.HTTP.Addr, s.Cfg.HTTP.Port),
+		Handler:        s.Router.Mux,
+		ReadTimeout:    10 * time.Second,
+		WriteTimeout:   10 * time.Second,
+		MaxHeaderBytes: 1 << 20,
+	}
+
+	//Gracefully shutdown the http server
+	go func() {
+		select {
+		case <-ctx.Done():
+			log.Info(ctx, "ChatOps> Shutdown HTTP Server")
+			_ = server.Shutdown(ctx)
+		}
+	}()
+
+	//Start the http server
+	log.Info(ctx, "ChatOps> Starting HTTP Server on port %d", s.Cfg.HTTP.Port)
+	if err := server.ListenAndServe(); err != nil {
+		log.Error(ctx, "ChatOps> Listen and serve failed: %v", err)
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,137 @@
+package chatops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const maxSlackRequestSize = 1 << 20
+
+func (s *Service) getStatusHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var status = http.StatusOK
+		return service.WriteJSON(w, s.Status(ctx), status)
+	}
+}
+
+// readSlackRequest checks the request signature then returns its form values.
+func (s *Service) readSlackRequest(r *http.Request) (url.Values, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxSlackRequestSize))
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if err := verifySlackSignature(s.Cfg.Slack.SigningSecret, r.Header, body, time.Now()); err != nil {
+		return nil, err
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.ErrWrongRequest)
+	}
+	return values, nil
+}
+
+func (s *Service) postSlackCommandHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		values, err := s.readSlackRequest(r)
+		if err != nil {
+			return err
+		}
+
+		text := values.Get("text")
+		cmd, err := parseCommand(text)
+		if err != nil {
+			return service.WriteJSON(w, ephemeralMessage(fmt.Sprintf("%s\n%s", sdk.Cause(err).Error(), usage)), http.StatusOK)
+		}
+		if cmd.Command == commandHelp {
+			return service.WriteJSON(w, ephemeralMessage(usage), http.StatusOK)
+		}
+		cmd.Provider = sdk.ChatOpsProviderSlack
+		cmd.ExternalUserID = values.Get("user_id")
+
+		// Slack expects an answer within 3 seconds, the command result will be sent using the response url
+		s.executeCommandAsync(cmd, values.Get("user_name"), values.Get("response_url"))
+
+		return service.WriteJSON(w, ephemeralMessage(fmt.Sprintf("Executing `%s`...", text)), http.StatusOK)
+	}
+}
+
+func (s *Service) postSlackInteractionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		values, err := s.readSlackRequest(r)
+		if err != nil {
+			return err
+		}
+
+		var interaction slackInteraction
+		if err := json.Unmarshal([]byte(values.Get("payload")), &interaction); err != nil {
+			return sdk.NewErrorWithStack(err, sdk.ErrWrongRequest)
+		}
+
+		// Buttons values contain the command to execute
+		for _, a := range interaction.Actions {
+			cmd, err := parseCommand(a.Value)
+			if err != nil {
+				log.Warning(ctx, "postSlackInteractionHandler> invalid action value %q: %v", a.Value, err)
+				continue
+			}
+			cmd.Provider = sdk.ChatOpsProviderSlack
+			cmd.ExternalUserID = interaction.User.ID
+			s.executeCommandAsync(cmd, interaction.User.Username, interaction.ResponseURL)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+func (s *Service) executeCommandAsync(cmd sdk.ChatOpsCommand, externalUsername, responseURL string) {
+	sdk.GoRoutine(s.Router.Background, "chatops.executeCommand", func(ctx context.Context) {
+		msg := s.executeCommand(ctx, cmd, externalUsername)
+		if err := postSlackResponse(responseURL, msg); err != nil {
+			log.Error(ctx, "chatops.executeCommand> unable to send response to slack: %v", err)
+		}
+	})
+}
+
+// executeCommand sends the command to the API and returns the message to display to the user.
+func (s *Service) executeCommand(ctx context.Context, cmd sdk.ChatOpsCommand, externalUsername string) slackMessage {
+	if cmd.Command == commandLink {
+		return s.linkMessage(ctx, cmd, externalUsername)
+	}
+
+	res, err := s.Client.ChatOpsCommand(cmd)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrChatOpsUserNotLinked) {
+			return s.linkMessage(ctx, cmd, externalUsername)
+		}
+		return ephemeralMessage(fmt.Sprintf("Command `%s` failed: %s", cmd.Command, sdk.Cause(err).Error()))
+	}
+
+	return s.resultMessage(cmd, *res)
+}
+
+// linkMessage returns a message containing a link token, it should only be displayed to the user that requested it.
+func (s *Service) linkMessage(ctx context.Context, cmd sdk.ChatOpsCommand, externalUsername string) slackMessage {
+	token, err := s.Client.ChatOpsLinkToken(sdk.ChatOpsLinkTokenRequest{
+		Provider:         cmd.Provider,
+		ExternalUserID:   cmd.ExternalUserID,
+		ExternalUsername: externalUsername,
+	})
+	if err != nil {
+		log.Error(ctx, "chatops.linkMessage> unable to get link token: %v", err)
+		return ephemeralMessage("Unable to link your account, please retry later")
+	}
+
+	return ephemeralMessage(fmt.Sprintf("Your Slack user is not linked to a CDS user. To link it, run the following command before %s:\n`cdsctl user link-chatops %s`",
+		token.Expire.Format(time.RFC1123), token.Token))
+}
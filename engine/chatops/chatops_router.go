@@ -0,0 +1,26 @@
+package chatops
+
+import (
+	"context"
+
+	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (s *Service) initRouter(ctx context.Context) {
+	log.Debug("ChatOps> Router initialized")
+	r := s.Router
+	r.Background = ctx
+	r.URL = s.Cfg.URL
+	r.SetHeaderFunc = api.DefaultHeaders
+	r.Middlewares = append(r.Middlewares, service.CheckRequestSignatureMiddleware(s.ParsedAPIPublicKey))
+	r.Handle("/mon/version", nil, r.GET(api.VersionHandler, api.Auth(false)))
+	r.Handle("/mon/status", nil, r.GET(s.getStatusHandler))
+	r.Handle("/mon/metrics", nil, r.GET(service.GetPrometheustMetricsHandler(s), api.Auth(false)))
+	r.Handle("/mon/metrics/all", nil, r.GET(service.GetMetricsHandler, api.Auth(false)))
+
+	// Requests sent by Slack are authenticated with the signing secret
+	r.Handle("/slack/command", nil, r.POST(s.postSlackCommandHandler, api.Auth(false)))
+	r.Handle("/slack/interaction", nil, r.POST(s.postSlackInteractionHandler, api.Auth(false)))
+}
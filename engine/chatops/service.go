@@ -0,0 +1,20 @@
+package chatops
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Status returns sdk.MonitoringStatus, implements interface service.Service
+func (s *Service) Status(ctx context.Context) sdk.MonitoringStatus {
+	m := s.CommonMonitoring()
+	status := sdk.MonitoringStatusOK
+	value := "configured"
+	if s.Cfg.Slack.SigningSecret == "" {
+		status = sdk.MonitoringStatusWarn
+		value = "missing signing secret"
+	}
+	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Slack", Value: value, Status: status})
+	return m
+}
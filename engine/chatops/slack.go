@@ -0,0 +1,264 @@
+package chatops
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	slackSignatureVersion = "v0"
+	slackMaxRequestAge    = 5 * time.Minute
+)
+
+// Commands handled by the service without calling the API
+const (
+	commandLink = "link"
+	commandHelp = "help"
+)
+
+const usage = "Usage:\n" +
+	"• `run PROJECT/workflow [key=value...]` start a new run of a workflow\n" +
+	"• `status PROJECT/workflow [number]` show the status of the last or given run\n" +
+	"• `stop PROJECT/workflow number` stop a run\n" +
+	"• `approve PROJECT/workflow number node` run a manual node of an existing run\n" +
+	"• `link` link your Slack user to your CDS user"
+
+type slackMessage struct {
+	ResponseType    string       `json:"response_type,omitempty"`
+	ReplaceOriginal bool         `json:"replace_original,omitempty"`
+	Text            string       `json:"text"`
+	Blocks          []slackBlock `json:"blocks,omitempty"`
+}
+
+type slackBlock struct {
+	Type     string         `json:"type"`
+	Text     *slackText     `json:"text,omitempty"`
+	Elements []slackElement `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackElement struct {
+	Type     string     `json:"type"`
+	Text     *slackText `json:"text,omitempty"`
+	ActionID string     `json:"action_id,omitempty"`
+	Value    string     `json:"value,omitempty"`
+	URL      string     `json:"url,omitempty"`
+	Style    string     `json:"style,omitempty"`
+}
+
+type slackInteraction struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	ResponseURL string `json:"response_url"`
+	Actions     []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// verifySlackSignature checks that a request was sent by Slack using the application signing secret.
+// See https://api.slack.com/authentication/verifying-requests-from-slack
+func verifySlackSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	signature := header.Get("X-Slack-Signature")
+	if timestamp == "" || signature == "" {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing slack signature")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid slack request timestamp")
+	}
+	// Prevent replay attacks
+	if math.Abs(now.Sub(time.Unix(ts, 0)).Seconds()) > slackMaxRequestAge.Seconds() {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "slack request is too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(slackSignatureVersion + ":" + timestamp + ":"))
+	_, _ = mac.Write(body)
+	expected := slackSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid slack signature")
+	}
+	return nil
+}
+
+// parseCommand returns a chatops command from a slash command text like "run PROJ/workflow key=value".
+func parseCommand(text string) (sdk.ChatOpsCommand, error) {
+	var cmd sdk.ChatOpsCommand
+
+	args := strings.Fields(text)
+	if len(args) == 0 {
+		return cmd, sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing command")
+	}
+	cmd.Command = strings.ToLower(args[0])
+	args = args[1:]
+
+	switch cmd.Command {
+	case commandLink, commandHelp:
+		return cmd, nil
+	case sdk.ChatOpsCommandRun, sdk.ChatOpsCommandStatus, sdk.ChatOpsCommandStop, sdk.ChatOpsCommandApprove:
+	default:
+		return cmd, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown command %q", cmd.Command)
+	}
+
+	if len(args) == 0 {
+		return cmd, sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing workflow, expected PROJECT/workflow")
+	}
+	ref := strings.SplitN(args[0], "/", 2)
+	if len(ref) != 2 || ref[0] == "" || ref[1] == "" {
+		return cmd, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid workflow %q, expected PROJECT/workflow", args[0])
+	}
+	cmd.ProjectKey, cmd.WorkflowName = ref[0], ref[1]
+	args = args[1:]
+
+	parseNumber := func(s string) (int64, error) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid run number %q", s)
+		}
+		return n, nil
+	}
+
+	var err error
+	switch cmd.Command {
+	case sdk.ChatOpsCommandRun:
+		for _, a := range args {
+			kv := strings.SplitN(a, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return cmd, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid payload value %q, expected key=value", a)
+			}
+			if cmd.Payload == nil {
+				cmd.Payload = make(map[string]string)
+			}
+			cmd.Payload[kv[0]] = kv[1]
+		}
+	case sdk.ChatOpsCommandStatus:
+		if len(args) > 1 {
+			return cmd, sdk.NewErrorFrom(sdk.ErrWrongRequest, "too many arguments")
+		}
+		if len(args) == 1 {
+			if cmd.RunNumber, err = parseNumber(args[0]); err != nil {
+				return cmd, err
+			}
+		}
+	case sdk.ChatOpsCommandStop:
+		if len(args) != 1 {
+			return cmd, sdk.NewErrorFrom(sdk.ErrWrongRequest, "expected a run number")
+		}
+		if cmd.RunNumber, err = parseNumber(args[0]); err != nil {
+			return cmd, err
+		}
+	case sdk.ChatOpsCommandApprove:
+		if len(args) != 2 {
+			return cmd, sdk.NewErrorFrom(sdk.ErrWrongRequest, "expected a run number and a node name")
+		}
+		if cmd.RunNumber, err = parseNumber(args[0]); err != nil {
+			return cmd, err
+		}
+		cmd.NodeName = args[1]
+	}
+
+	return cmd, nil
+}
+
+// checkResponseURL prevents the service from sending requests to an url that was not given by Slack.
+func checkResponseURL(responseURL string) error {
+	u, err := url.Parse(responseURL)
+	if err != nil || u.Scheme != "https" || !(u.Hostname() == "slack.com" || strings.HasSuffix(u.Hostname(), ".slack.com")) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid slack response url")
+	}
+	return nil
+}
+
+func postSlackResponse(responseURL string, msg slackMessage) error {
+	if err := checkResponseURL(responseURL); err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(msg)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	res, err := httpClient.Post(responseURL, "application/json", bytes.NewReader(buf))
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer res.Body.Close() // nolint
+
+	if res.StatusCode >= 400 {
+		return sdk.WithStack(fmt.Errorf("slack response url returned status %d", res.StatusCode))
+	}
+	return nil
+}
+
+func ephemeralMessage(text string) slackMessage {
+	return slackMessage{ResponseType: "ephemeral", Text: text}
+}
+
+// resultMessage returns a message for given command result, buttons are added to act on the run.
+func (s *Service) resultMessage(cmd sdk.ChatOpsCommand, res sdk.ChatOpsCommandResult) slackMessage {
+	msg := slackMessage{
+		ResponseType: "in_channel",
+		Text:         res.Message,
+		Blocks: []slackBlock{{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: res.Message},
+		}},
+	}
+	if res.WorkflowRun == nil {
+		return msg
+	}
+
+	ref := cmd.ProjectKey + "/" + cmd.WorkflowName
+	actions := slackBlock{Type: "actions"}
+	if s.Cfg.UI.URL != "" {
+		actions.Elements = append(actions.Elements, slackElement{
+			Type: "button",
+			Text: &slackText{Type: "plain_text", Text: "Open in CDS"},
+			URL: fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", strings.TrimSuffix(s.Cfg.UI.URL, "/"),
+				cmd.ProjectKey, cmd.WorkflowName, res.WorkflowRun.Number),
+		})
+	}
+	if !sdk.StatusIsTerminated(res.WorkflowRun.Status) && cmd.Command != sdk.ChatOpsCommandStop {
+		actions.Elements = append(actions.Elements, slackElement{
+			Type:     "button",
+			Text:     &slackText{Type: "plain_text", Text: "Stop"},
+			ActionID: sdk.ChatOpsCommandStop,
+			Value:    fmt.Sprintf("%s %s %d", sdk.ChatOpsCommandStop, ref, res.WorkflowRun.Number),
+			Style:    "danger",
+		})
+	}
+	actions.Elements = append(actions.Elements, slackElement{
+		Type:     "button",
+		Text:     &slackText{Type: "plain_text", Text: "Refresh status"},
+		ActionID: sdk.ChatOpsCommandStatus,
+		Value:    fmt.Sprintf("%s %s %d", sdk.ChatOpsCommandStatus, ref, res.WorkflowRun.Number),
+	})
+	msg.Blocks = append(msg.Blocks, actions)
+
+	return msg
+}
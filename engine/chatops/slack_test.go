@@ -0,0 +1,81 @@
+package chatops
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestVerifySlackSignature(t *testing.T) {
+	secret := "my-secret"
+	body := []byte("command=%2Fcds&text=status+PROJ%2Fmy-workflow")
+	now := time.Now()
+
+	sign := func(ts time.Time) http.Header {
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write([]byte(fmt.Sprintf("v0:%d:", ts.Unix())))
+		_, _ = mac.Write(body)
+		h := http.Header{}
+		h.Set("X-Slack-Request-Timestamp", fmt.Sprintf("%d", ts.Unix()))
+		h.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		return h
+	}
+
+	assert.NoError(t, verifySlackSignature(secret, sign(now), body, now))
+	assert.Error(t, verifySlackSignature("another-secret", sign(now), body, now))
+	assert.Error(t, verifySlackSignature(secret, sign(now), []byte("command=%2Fcds&text=stop"), now))
+	assert.Error(t, verifySlackSignature(secret, sign(now.Add(-10*time.Minute)), body, now), "old requests should be rejected")
+	assert.Error(t, verifySlackSignature(secret, http.Header{}, body, now))
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		text   string
+		result sdk.ChatOpsCommand
+		err    bool
+	}{
+		{text: "run PROJ/my-workflow", result: sdk.ChatOpsCommand{Command: "run", ProjectKey: "PROJ", WorkflowName: "my-workflow"}},
+		{text: "run PROJ/my-workflow git.branch=master env=prod", result: sdk.ChatOpsCommand{Command: "run", ProjectKey: "PROJ", WorkflowName: "my-workflow",
+			Payload: map[string]string{"git.branch": "master", "env": "prod"}}},
+		{text: "run PROJ/my-workflow invalid", err: true},
+		{text: "Status PROJ/my-workflow", result: sdk.ChatOpsCommand{Command: "status", ProjectKey: "PROJ", WorkflowName: "my-workflow"}},
+		{text: "status PROJ/my-workflow 12", result: sdk.ChatOpsCommand{Command: "status", ProjectKey: "PROJ", WorkflowName: "my-workflow", RunNumber: 12}},
+		{text: "stop PROJ/my-workflow 12", result: sdk.ChatOpsCommand{Command: "stop", ProjectKey: "PROJ", WorkflowName: "my-workflow", RunNumber: 12}},
+		{text: "stop PROJ/my-workflow", err: true},
+		{text: "stop PROJ/my-workflow last", err: true},
+		{text: "approve PROJ/my-workflow 12 deploy-prod", result: sdk.ChatOpsCommand{Command: "approve", ProjectKey: "PROJ", WorkflowName: "my-workflow", RunNumber: 12, NodeName: "deploy-prod"}},
+		{text: "approve PROJ/my-workflow 12", err: true},
+		{text: "link", result: sdk.ChatOpsCommand{Command: "link"}},
+		{text: "run my-workflow", err: true},
+		{text: "delete PROJ/my-workflow", err: true},
+		{text: "", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			cmd, err := parseCommand(tt.text)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.result, cmd)
+		})
+	}
+}
+
+func TestCheckResponseURL(t *testing.T) {
+	assert.NoError(t, checkResponseURL("https://hooks.slack.com/commands/1234/5678"))
+	assert.Error(t, checkResponseURL("http://hooks.slack.com/commands/1234/5678"))
+	assert.Error(t, checkResponseURL("https://hooks.slack.com.evil.io/commands"))
+	assert.Error(t, checkResponseURL("https://internal-service/commands"))
+}
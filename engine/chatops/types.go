@@ -0,0 +1,31 @@
+package chatops
+
+import (
+	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/service"
+)
+
+// Service is the chatops service
+type Service struct {
+	service.Common
+	Cfg    Configuration
+	Router *api.Router
+}
+
+// Configuration is the chatops configuration structure
+type Configuration struct {
+	Name string `toml:"name" comment:"Name of this CDS ChatOps Service\n Enter a name to enable this service" json:"name"`
+	HTTP struct {
+		Addr string `toml:"addr" default:"" commented:"true" comment:"Listen address without port, example: 127.0.0.1" json:"addr"`
+		Port int    `toml:"port" default:"8089" json:"port"`
+	} `toml:"http" comment:"######################\n CDS ChatOps HTTP Configuration \n######################" json:"http"`
+	URL       string `toml:"url" default:"http://localhost:8089" json:"url"`
+	URLPublic string `toml:"urlPublic" comment:"Public url of this service for external call (Slack commands and interactions)" json:"urlPublic"`
+	UI        struct {
+		URL string `toml:"url" default:"http://localhost:8080" comment:"CDS UI url, used to add links in messages" json:"url"`
+	} `toml:"ui" json:"ui"`
+	Slack struct {
+		SigningSecret string `toml:"signingSecret" comment:"Signing secret of your Slack application, used to verify requests sent by Slack" json:"-"`
+	} `toml:"slack" comment:"######################\n CDS ChatOps Slack Settings \n Configure your Slack application slash command with {urlPublic}/slack/command\n and interactivity with {urlPublic}/slack/interaction\n######################" json:"slack"`
+	API service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
+}
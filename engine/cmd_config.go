@@ -11,6 +11,10 @@ import (
 	toml "github.com/yesnault/go-toml"
 
 	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/chatops"
+	"github.com/ovh/cds/engine/hatchery/ec2"
+	"github.com/ovh/cds/engine/hatchery/ecs"
+	"github.com/ovh/cds/engine/hatchery/gce"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -75,7 +79,7 @@ For advanced usage, Debug and Tracing section can be generated as:
 	$ engine config new debug tracing [µService(s)...]
 
 All options
-	$ engine config new [debug] [tracing] [api] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate]
+	$ engine config new [debug] [tracing] [api] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [hatchery:ec2] [hatchery:gce] [hatchery:ecs] [elasticsearch] [chatops] [hooks] [vcs] [repositories] [migrate]
 
 `,
 
@@ -187,6 +191,30 @@ var configCheckCmd = &cobra.Command{
 			}
 		}
 
+		if conf.Hatchery != nil && conf.Hatchery.EC2 != nil && conf.Hatchery.EC2.API.HTTP.URL != "" {
+			fmt.Printf("checking hatchery:ec2 configuration...\n")
+			if err := ec2.New().CheckConfiguration(*conf.Hatchery.EC2); err != nil {
+				fmt.Printf("hatchery:ec2 Configuration: %v\n", err)
+				hasError = true
+			}
+		}
+
+		if conf.Hatchery != nil && conf.Hatchery.GCE != nil && conf.Hatchery.GCE.API.HTTP.URL != "" {
+			fmt.Printf("checking hatchery:gce configuration...\n")
+			if err := gce.New().CheckConfiguration(*conf.Hatchery.GCE); err != nil {
+				fmt.Printf("hatchery:gce Configuration: %v\n", err)
+				hasError = true
+			}
+		}
+
+		if conf.Hatchery != nil && conf.Hatchery.ECS != nil && conf.Hatchery.ECS.API.HTTP.URL != "" {
+			fmt.Printf("checking hatchery:ecs configuration...\n")
+			if err := ecs.New().CheckConfiguration(*conf.Hatchery.ECS); err != nil {
+				fmt.Printf("hatchery:ecs Configuration: %v\n", err)
+				hasError = true
+			}
+		}
+
 		if conf.VCS != nil && conf.VCS.API.HTTP.URL != "" {
 			fmt.Printf("checking vcs configuration...\n")
 			if err := vcs.New().CheckConfiguration(*conf.VCS); err != nil {
@@ -203,6 +231,14 @@ var configCheckCmd = &cobra.Command{
 			}
 		}
 
+		if conf.ChatOps != nil && conf.ChatOps.API.HTTP.URL != "" {
+			fmt.Printf("checking chatops configuration...\n")
+			if err := chatops.New().CheckConfiguration(*conf.ChatOps); err != nil {
+				fmt.Printf("chatops Configuration: %v\n", err)
+				hasError = true
+			}
+		}
+
 		if !hasError {
 			fmt.Println("Configuration file OK")
 		}
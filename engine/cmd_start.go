@@ -14,7 +14,11 @@ import (
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/chatops"
 	"github.com/ovh/cds/engine/elasticsearch"
+	"github.com/ovh/cds/engine/hatchery/ec2"
+	"github.com/ovh/cds/engine/hatchery/ecs"
+	"github.com/ovh/cds/engine/hatchery/gce"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -69,6 +73,9 @@ They are the components responsible for spawning workers. Supported integrations
 * Docker Swarm
 * Openstack
 * Vsphere
+* AWS EC2
+* Google Compute Engine
+* AWS ECS Fargate
 
 #### Hooks
 This component operates CDS workflow hooks
@@ -79,9 +86,12 @@ This component operates CDS workflow repositories
 #### VCS
 This component operates CDS VCS connectivity
 
+#### ChatOps
+This component allows to run, approve, stop and follow workflow runs from Slack
+
 Start all of this with a single command:
 
-	$ engine start [api] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate] [ui]
+	$ engine start [api] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [hatchery:ec2] [hatchery:gce] [hatchery:ecs] [elasticsearch] [chatops] [hooks] [vcs] [repositories] [migrate] [ui]
 
 All the services are using the same configuration file format.
 
@@ -205,6 +215,30 @@ See $ engine config command for more details.
 				names = append(names, conf.Hatchery.VSphere.Name)
 				types = append(types, services.TypeHatchery)
 
+			case "hatchery:ec2":
+				if conf.Hatchery.EC2 == nil {
+					sdk.Exit("Unable to start: missing service %s configuration", a)
+				}
+				serviceConfs = append(serviceConfs, serviceConf{arg: a, service: ec2.New(), cfg: *conf.Hatchery.EC2})
+				names = append(names, conf.Hatchery.EC2.Name)
+				types = append(types, services.TypeHatchery)
+
+			case "hatchery:gce":
+				if conf.Hatchery.GCE == nil {
+					sdk.Exit("Unable to start: missing service %s configuration", a)
+				}
+				serviceConfs = append(serviceConfs, serviceConf{arg: a, service: gce.New(), cfg: *conf.Hatchery.GCE})
+				names = append(names, conf.Hatchery.GCE.Name)
+				types = append(types, services.TypeHatchery)
+
+			case "hatchery:ecs":
+				if conf.Hatchery.ECS == nil {
+					sdk.Exit("Unable to start: missing service %s configuration", a)
+				}
+				serviceConfs = append(serviceConfs, serviceConf{arg: a, service: ecs.New(), cfg: *conf.Hatchery.ECS})
+				names = append(names, conf.Hatchery.ECS.Name)
+				types = append(types, services.TypeHatchery)
+
 			case "hooks":
 				if conf.Hooks == nil {
 					sdk.Exit("Unable to start: missing service %s configuration", a)
@@ -237,6 +271,14 @@ See $ engine config command for more details.
 				names = append(names, conf.ElasticSearch.Name)
 				types = append(types, services.TypeElasticsearch)
 
+			case "chatops":
+				if conf.ChatOps == nil {
+					sdk.Exit("Unable to start: missing service %s configuration", a)
+				}
+				serviceConfs = append(serviceConfs, serviceConf{arg: a, service: chatops.New(), cfg: *conf.ChatOps})
+				names = append(names, conf.ChatOps.Name)
+				types = append(types, services.TypeChatOps)
+
 			default:
 				fmt.Printf("Error: service '%s' unknown\n", a)
 				os.Exit(1)
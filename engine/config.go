@@ -21,7 +21,11 @@ import (
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/chatops"
 	"github.com/ovh/cds/engine/elasticsearch"
+	"github.com/ovh/cds/engine/hatchery/ec2"
+	"github.com/ovh/cds/engine/hatchery/ecs"
+	"github.com/ovh/cds/engine/hatchery/gce"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -50,7 +54,7 @@ func configBootstrap(args []string) Configuration {
 	if len(args) == 0 {
 		args = []string{
 			"api", "ui", "migrate", "hooks", "vcs", "repositories", "elasticsearch",
-			"hatchery:local", "hatchery:kubernetes", "hatchery:marathon", "hatchery:openstack", "hatchery:swarm", "hatchery:vsphere",
+			"hatchery:local", "hatchery:kubernetes", "hatchery:marathon", "hatchery:openstack", "hatchery:swarm", "hatchery:vsphere", "hatchery:ec2", "hatchery:gce", "hatchery:ecs",
 		}
 	}
 
@@ -115,6 +119,18 @@ func configBootstrap(args []string) Configuration {
 			conf.Hatchery.VSphere = &vsphere.HatcheryConfiguration{}
 			defaults.SetDefaults(conf.Hatchery.VSphere)
 			conf.Hatchery.VSphere.Name = "cds-hatchery-vsphere-" + namesgenerator.GetRandomNameCDS(0)
+		case "hatchery:ec2":
+			conf.Hatchery.EC2 = &ec2.HatcheryConfiguration{}
+			defaults.SetDefaults(conf.Hatchery.EC2)
+			conf.Hatchery.EC2.Name = "cds-hatchery-ec2-" + namesgenerator.GetRandomNameCDS(0)
+		case "hatchery:gce":
+			conf.Hatchery.GCE = &gce.HatcheryConfiguration{}
+			defaults.SetDefaults(conf.Hatchery.GCE)
+			conf.Hatchery.GCE.Name = "cds-hatchery-gce-" + namesgenerator.GetRandomNameCDS(0)
+		case "hatchery:ecs":
+			conf.Hatchery.ECS = &ecs.HatcheryConfiguration{}
+			defaults.SetDefaults(conf.Hatchery.ECS)
+			conf.Hatchery.ECS.Name = "cds-hatchery-ecs-" + namesgenerator.GetRandomNameCDS(0)
 		case "hooks":
 			conf.Hooks = &hooks.Configuration{}
 			defaults.SetDefaults(conf.Hooks)
@@ -132,12 +148,18 @@ func configBootstrap(args []string) Configuration {
 			defaults.SetDefaults(&gitlab)
 			var gerrit vcs.GerritServerConfiguration
 			defaults.SetDefaults(&gerrit)
+			var gitea vcs.GiteaServerConfiguration
+			defaults.SetDefaults(&gitea)
+			var codecommit vcs.CodeCommitServerConfiguration
+			defaults.SetDefaults(&codecommit)
 			conf.VCS.Servers = map[string]vcs.ServerConfiguration{
 				"github":         vcs.ServerConfiguration{URL: "https://github.com", Github: &github},
 				"bitbucket":      vcs.ServerConfiguration{URL: "https://mybitbucket.com", Bitbucket: &bitbucket},
 				"bitbucketcloud": vcs.ServerConfiguration{BitbucketCloud: &bitbucketcloud},
 				"gitlab":         vcs.ServerConfiguration{URL: "https://gitlab.com", Gitlab: &gitlab},
 				"gerrit":         vcs.ServerConfiguration{URL: "http://localhost:8080", Gerrit: &gerrit},
+				"gitea":          vcs.ServerConfiguration{URL: "https://gitea.com", Gitea: &gitea},
+				"codecommit":     vcs.ServerConfiguration{URL: "https://git-codecommit.eu-west-1.amazonaws.com", CodeCommit: &codecommit},
 			}
 			conf.VCS.Name = "cds-vcs-" + namesgenerator.GetRandomNameCDS(0)
 		case "repositories":
@@ -147,6 +169,10 @@ func configBootstrap(args []string) Configuration {
 		case "elasticsearch":
 			conf.ElasticSearch = &elasticsearch.Configuration{}
 			defaults.SetDefaults(conf.ElasticSearch)
+		case "chatops":
+			conf.ChatOps = &chatops.Configuration{}
+			defaults.SetDefaults(conf.ChatOps)
+			conf.ChatOps.Name = "cds-chatops-" + namesgenerator.GetRandomNameCDS(0)
 		default:
 			sdk.Exit("Error service '%s' is unknown", a)
 		}
@@ -390,6 +416,87 @@ func configSetStartupData(conf *Configuration) (string, error) {
 			privateKeyPEM, _ := jws.ExportPrivateKey(privateKey)
 			h.VSphere.RSAPrivateKey = string(privateKeyPEM)
 		}
+		if h.EC2 != nil {
+			var cfg = api.StartupConfigService{
+				ID:          sdk.UUID(),
+				Name:        "hatchery:ec2",
+				Description: "Autogenerated configuration for ec2 hatchery",
+				ServiceType: services.TypeHatchery,
+			}
+
+			var c = sdk.AuthConsumer{
+				ID:          cfg.ID,
+				Name:        cfg.Name,
+				Description: cfg.Description,
+				Type:        sdk.ConsumerBuiltin,
+				Data:        map[string]string{},
+				IssuedAt:    iat,
+			}
+
+			h.EC2.API.Token, err = builtin.NewSigninConsumerToken(&c)
+			if err != nil {
+				return "", err
+			}
+
+			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+			privateKey, _ := jws.NewRandomRSAKey()
+			privateKeyPEM, _ := jws.ExportPrivateKey(privateKey)
+			h.EC2.RSAPrivateKey = string(privateKeyPEM)
+		}
+		if h.GCE != nil {
+			var cfg = api.StartupConfigService{
+				ID:          sdk.UUID(),
+				Name:        "hatchery:gce",
+				Description: "Autogenerated configuration for gce hatchery",
+				ServiceType: services.TypeHatchery,
+			}
+
+			var c = sdk.AuthConsumer{
+				ID:          cfg.ID,
+				Name:        cfg.Name,
+				Description: cfg.Description,
+				Type:        sdk.ConsumerBuiltin,
+				Data:        map[string]string{},
+				IssuedAt:    iat,
+			}
+
+			h.GCE.API.Token, err = builtin.NewSigninConsumerToken(&c)
+			if err != nil {
+				return "", err
+			}
+
+			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+			privateKey, _ := jws.NewRandomRSAKey()
+			privateKeyPEM, _ := jws.ExportPrivateKey(privateKey)
+			h.GCE.RSAPrivateKey = string(privateKeyPEM)
+		}
+		if h.ECS != nil {
+			var cfg = api.StartupConfigService{
+				ID:          sdk.UUID(),
+				Name:        "hatchery:ecs",
+				Description: "Autogenerated configuration for ecs hatchery",
+				ServiceType: services.TypeHatchery,
+			}
+
+			var c = sdk.AuthConsumer{
+				ID:          cfg.ID,
+				Name:        cfg.Name,
+				Description: cfg.Description,
+				Type:        sdk.ConsumerBuiltin,
+				Data:        map[string]string{},
+				IssuedAt:    iat,
+			}
+
+			h.ECS.API.Token, err = builtin.NewSigninConsumerToken(&c)
+			if err != nil {
+				return "", err
+			}
+
+			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+			privateKey, _ := jws.NewRandomRSAKey()
+			privateKeyPEM, _ := jws.ExportPrivateKey(privateKey)
+			h.ECS.RSAPrivateKey = string(privateKeyPEM)
+		}
 		if h.Swarm != nil {
 			var cfg = api.StartupConfigService{
 				ID:          sdk.UUID(),
@@ -598,6 +705,31 @@ func configSetStartupData(conf *Configuration) (string, error) {
 		startupCfg.Consumers = append(startupCfg.Consumers, cfg)
 	}
 
+	if conf.ChatOps != nil {
+		var cfg = api.StartupConfigService{
+			ID:          sdk.UUID(),
+			Name:        "chatops",
+			Description: "Autogenerated configuration for chatops service",
+			ServiceType: services.TypeChatOps,
+		}
+
+		var c = sdk.AuthConsumer{
+			ID:          cfg.ID,
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			Type:        sdk.ConsumerBuiltin,
+			Data:        map[string]string{},
+			IssuedAt:    iat,
+		}
+
+		conf.ChatOps.API.Token, err = builtin.NewSigninConsumerToken(&c)
+		if err != nil {
+			return "", err
+		}
+
+		startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+	}
+
 	return authentication.SignJWS(startupCfg, time.Hour)
 }
 
@@ -686,6 +818,57 @@ func getInitTokenFromExistingConfiguration(conf Configuration) (string, error) {
 			}
 			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
 		}
+		if h.EC2 != nil {
+			consumerID, iat, err := builtin.CheckSigninConsumerToken(h.EC2.API.Token)
+			if err != nil {
+				return "", fmt.Errorf("cannot parse hatchery:ec2 signin token: %v", err)
+			}
+			if iat < globalIAT {
+				globalIAT = iat
+			}
+
+			var cfg = api.StartupConfigService{
+				ID:          consumerID,
+				Name:        "hatchery:ec2",
+				Description: "Autogenerated configuration for ec2 hatchery",
+				ServiceType: services.TypeHatchery,
+			}
+			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+		}
+		if h.GCE != nil {
+			consumerID, iat, err := builtin.CheckSigninConsumerToken(h.GCE.API.Token)
+			if err != nil {
+				return "", fmt.Errorf("cannot parse hatchery:gce signin token: %v", err)
+			}
+			if iat < globalIAT {
+				globalIAT = iat
+			}
+
+			var cfg = api.StartupConfigService{
+				ID:          consumerID,
+				Name:        "hatchery:gce",
+				Description: "Autogenerated configuration for gce hatchery",
+				ServiceType: services.TypeHatchery,
+			}
+			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+		}
+		if h.ECS != nil {
+			consumerID, iat, err := builtin.CheckSigninConsumerToken(h.ECS.API.Token)
+			if err != nil {
+				return "", fmt.Errorf("cannot parse hatchery:ecs signin token: %v", err)
+			}
+			if iat < globalIAT {
+				globalIAT = iat
+			}
+
+			var cfg = api.StartupConfigService{
+				ID:          consumerID,
+				Name:        "hatchery:ecs",
+				Description: "Autogenerated configuration for ecs hatchery",
+				ServiceType: services.TypeHatchery,
+			}
+			startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+		}
 		if h.Swarm != nil {
 			consumerID, iat, err := builtin.CheckSigninConsumerToken(h.Swarm.API.Token)
 			if err != nil {
@@ -837,6 +1020,25 @@ func getInitTokenFromExistingConfiguration(conf Configuration) (string, error) {
 		startupCfg.Consumers = append(startupCfg.Consumers, cfg)
 	}
 
+	if conf.ChatOps != nil {
+		consumerID, iat, err := builtin.CheckSigninConsumerToken(conf.ChatOps.API.Token)
+		if err != nil {
+			return "", fmt.Errorf("cannot parse chatops token: %v", err)
+		}
+		if iat < globalIAT {
+			globalIAT = iat
+		}
+
+		var cfg = api.StartupConfigService{
+			ID:          consumerID,
+			Name:        "chatops",
+			Description: "Autogenerated configuration for chatops service",
+			ServiceType: services.TypeChatOps,
+		}
+
+		startupCfg.Consumers = append(startupCfg.Consumers, cfg)
+	}
+
 	startupCfg.IAT = globalIAT
 
 	return authentication.SignJWS(startupCfg, time.Hour)
@@ -64,6 +64,62 @@ func (s *Service) postEventHandler() service.Handler {
 	}
 }
 
+// eventsScrubScript replaces a user's identifiers in an event document.
+const eventsScrubScript = `
+if (ctx._source.username == params.username) { ctx._source.username = params.pseudonym }
+if (params.email != '' && ctx._source.user_mail == params.email) { ctx._source.user_mail = params.email_pseudonym }
+if (ctx._source.tag != null) {
+  for (t in ctx._source.tag) {
+    if (t.tag == 'triggered_by' && t.value == params.username) { t.value = params.pseudonym }
+  }
+}`
+
+func (s *Service) postEventsScrubHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if s.Cfg.ElasticSearch.IndexEvents == "" {
+			return sdk.WrapError(sdk.ErrNotFound, "No events index found")
+		}
+
+		var req sdk.EventScrubRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return sdk.WrapError(err, "Unable to read body")
+		}
+		if req.Username == "" || req.Pseudonym == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given username or pseudonym")
+		}
+
+		query := elastic.NewBoolQuery().MinimumNumberShouldMatch(1).
+			Should(elastic.NewMatchPhraseQuery("username", req.Username)).
+			Should(elastic.NewMatchPhraseQuery("tag.value", req.Username))
+		if req.Email != "" {
+			query.Should(elastic.NewMatchPhraseQuery("user_mail", req.Email))
+		}
+
+		script := elastic.NewScript(eventsScrubScript).Lang("painless").Params(map[string]interface{}{
+			"username":        req.Username,
+			"email":           req.Email,
+			"pseudonym":       req.Pseudonym,
+			"email_pseudonym": req.EmailPseudonym,
+		})
+
+		res, err := esClient.UpdateByQuery(s.Cfg.ElasticSearch.IndexEvents).
+			Type(fmt.Sprintf("%T", sdk.Event{})).
+			Query(query).
+			Script(script).
+			ProceedOnVersionConflict().
+			Do(ctx)
+		if err != nil {
+			if strings.Contains(err.Error(), indexNotFoundException) {
+				log.Warning(ctx, "elasticsearch> postEventsScrubHandler> %v", err.Error())
+				return service.WriteJSON(w, sdk.EventScrubResponse{}, http.StatusOK)
+			}
+			return sdk.WrapError(err, "Unable to scrub events")
+		}
+
+		return service.WriteJSON(w, sdk.EventScrubResponse{Updated: res.Updated}, http.StatusOK)
+	}
+}
+
 func (s *Service) getMetricsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		if s.Cfg.ElasticSearch.IndexMetrics == "" {
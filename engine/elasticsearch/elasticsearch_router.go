@@ -21,5 +21,6 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/mon/metrics", nil, r.GET(service.GetPrometheustMetricsHandler(s), api.Auth(false)))
 	r.Handle("/mon/metrics/all", nil, r.GET(service.GetMetricsHandler, api.Auth(false)))
 	r.Handle("/events", nil, r.GET(s.getEventsHandler), r.POST(s.postEventHandler))
+	r.Handle("/events/scrub", nil, r.POST(s.postEventsScrubHandler))
 	r.Handle("/metrics", nil, r.GET(s.getMetricsHandler), r.POST(s.postMetricsHandler))
 }
@@ -0,0 +1,318 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// New instanciates a new Hatchery EC2
+func New() *HatcheryEC2 {
+	s := new(HatcheryEC2)
+	s.Router = &api.Router{
+		Mux: mux.NewRouter(),
+	}
+	return s
+}
+
+func (s *HatcheryEC2) Init(config interface{}) (cdsclient.ServiceConfig, error) {
+	var cfg cdsclient.ServiceConfig
+	sConfig, ok := config.(HatcheryConfiguration)
+	if !ok {
+		return cfg, sdk.WithStack(fmt.Errorf("invalid ec2 hatchery configuration"))
+	}
+
+	cfg.Host = sConfig.API.HTTP.URL
+	cfg.Token = sConfig.API.Token
+	cfg.InsecureSkipVerifyTLS = sConfig.API.HTTP.Insecure
+	cfg.RequestSecondsTimeout = sConfig.API.RequestTimeout
+	return cfg, nil
+}
+
+// ApplyConfiguration apply an object of type HatcheryConfiguration after checking it
+func (h *HatcheryEC2) ApplyConfiguration(cfg interface{}) error {
+	if err := h.CheckConfiguration(cfg); err != nil {
+		return err
+	}
+
+	var ok bool
+	h.Config, ok = cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	h.Common.Common.ServiceName = h.Config.Name
+	h.Common.Common.ServiceType = services.TypeHatchery
+	h.HTTPURL = h.Config.URL
+
+	h.MaxHeartbeatFailures = h.Config.API.MaxHeartbeatFailures
+	var err error
+	h.Common.Common.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(h.Config.RSAPrivateKey))
+	if err != nil {
+		return fmt.Errorf("unable to parse RSA private Key: %v", err)
+	}
+
+	return nil
+}
+
+// Status returns sdk.MonitoringStatus, implements interface service.Service
+func (h *HatcheryEC2) Status(ctx context.Context) sdk.MonitoringStatus {
+	m := h.CommonMonitoring()
+	instances := h.getInstances(ctx)
+	var nbSpot int
+	for _, i := range instances {
+		if i.Spot {
+			nbSpot++
+		}
+	}
+	m.Lines = append(m.Lines,
+		sdk.MonitoringStatusLine{Component: "Workers", Value: fmt.Sprintf("%d/%d", len(instances), h.Config.Provision.MaxWorker), Status: sdk.MonitoringStatusOK},
+		sdk.MonitoringStatusLine{Component: "Spot instances", Value: fmt.Sprintf("%d", nbSpot), Status: sdk.MonitoringStatusOK},
+	)
+	return m
+}
+
+// CheckConfiguration checks the validity of the configuration object
+func (h *HatcheryEC2) CheckConfiguration(cfg interface{}) error {
+	hconfig, ok := cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	if hconfig.API.HTTP.URL == "" {
+		return fmt.Errorf("API HTTP(s) URL is mandatory")
+	}
+
+	if hconfig.API.Token == "" {
+		return fmt.Errorf("API Token URL is mandatory")
+	}
+
+	if hconfig.Region == "" {
+		return fmt.Errorf("AWS region is mandatory")
+	}
+
+	if (hconfig.AccessKeyID == "") != (hconfig.SecretAccessKey == "") {
+		return fmt.Errorf("AWS access key ID and secret access key must be set together")
+	}
+
+	if hconfig.Name == "" {
+		return fmt.Errorf("please enter a name in your ec2 hatchery configuration")
+	}
+
+	return nil
+}
+
+// Serve start the hatchery server
+func (h *HatcheryEC2) Serve(ctx context.Context) error {
+	return h.CommonServe(ctx, h)
+}
+
+// Configuration returns Hatchery CommonConfiguration
+func (h *HatcheryEC2) Configuration() service.HatcheryCommonConfiguration {
+	return h.Config.HatcheryCommonConfiguration
+}
+
+// ModelType returns type of hatchery
+func (*HatcheryEC2) ModelType() string {
+	return sdk.EC2
+}
+
+// WorkerModelsEnabled returns Worker model enabled
+func (h *HatcheryEC2) WorkerModelsEnabled() ([]sdk.Model, error) {
+	return h.CDSClient().WorkerModelsEnabled()
+}
+
+// CanSpawn return wether or not hatchery can spawn model
+// requirements are not supported
+func (h *HatcheryEC2) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
+	for _, r := range requirements {
+		if r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement || r.Type == sdk.HostnameRequirement {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *HatcheryEC2) main(ctx context.Context) {
+	instanceListTick := time.NewTicker(10 * time.Second).C
+	killAwolInstancesTick := time.NewTicker(30 * time.Second).C
+	killDisabledWorkersTick := time.NewTicker(60 * time.Second).C
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-instanceListTick:
+			h.updateInstanceList(ctx)
+		case <-killAwolInstancesTick:
+			h.killAwolInstances(ctx)
+		case <-killDisabledWorkersTick:
+			h.killDisabledWorkers(ctx)
+		}
+	}
+}
+
+func (h *HatcheryEC2) updateInstanceList(ctx context.Context) {
+	var total, spot int
+	status := map[string]int{}
+	for _, i := range h.getInstances(ctx) {
+		status[i.State]++
+		total++
+		if i.Spot {
+			spot++
+		}
+	}
+	var st string
+	for k, s := range status {
+		st += fmt.Sprintf("%d %s ", s, k)
+	}
+	log.Debug("Got %d instances %s", total, st)
+
+	recordInstances(ctx, total, spot)
+}
+
+// killAwolInstances terminates the instances of workers that are not known by CDS anymore,
+// this is how instances are terminated at the end of the job if they were not shut down by the worker
+func (h *HatcheryEC2) killAwolInstances(ctx context.Context) {
+	ctxList, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	workers, err := h.CDSClient().WorkerList(ctxList)
+	if err != nil {
+		log.Warning(ctx, "killAwolInstances> Cannot fetch worker list: %s", err)
+		return
+	}
+
+	now := time.Now().Unix()
+	inWorkersList := make(map[string]bool, len(workers))
+	for _, w := range workers {
+		inWorkersList[w.Name] = true
+	}
+
+	for _, i := range h.getInstances(ctx) {
+		workerName := i.Tags[tagWorker]
+
+		// if the instance is pending since > 15 min, we terminate it
+		if i.State == "pending" {
+			if time.Since(i.LaunchTime) > 15*time.Minute {
+				log.Warning(ctx, "killAwolInstances> Terminating instance %s state: %s launched: %s", i.Name, i.State, time.Since(i.LaunchTime))
+				_ = h.terminateInstance(ctx, i)
+			}
+			continue
+		}
+
+		if inWorkersList[workerName] {
+			h.workersAlive[workerName] = now
+			continue
+		}
+
+		// The worker was registered on CDS and is gone: the job is over
+		_, wasAlive := h.workersAlive[workerName]
+
+		// Wait for 10 minutes before terminating workers not identified by CDS API, to avoid killing worker babies
+		if i.State == "stopped" || wasAlive || time.Since(i.LaunchTime) > 10*time.Minute {
+			log.Debug("killAwolInstances> Terminating instance %s state: %s launched: %s wasAlive:%t", i.Name, i.State, time.Since(i.LaunchTime), wasAlive)
+			_ = h.terminateInstance(ctx, i)
+			delete(h.workersAlive, workerName)
+		}
+	}
+
+	// then clean workersAlive map
+	for workerName, t := range h.workersAlive {
+		if t != now {
+			delete(h.workersAlive, workerName)
+		}
+	}
+}
+
+func (h *HatcheryEC2) killDisabledWorkers(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	workerPoolDisabled, err := hatchery.WorkerPool(ctx, h, sdk.StatusDisabled)
+	if err != nil {
+		log.Error(ctx, "killDisabledWorkers> Pool> Error: %v", err)
+		return
+	}
+
+	instances := h.getInstances(ctx)
+	for _, w := range workerPoolDisabled {
+		for _, i := range instances {
+			if i.Name == w.Name {
+				log.Info(ctx, "killDisabledWorkers> killDisabledWorkers %v", i.Name)
+				_ = h.terminateInstance(ctx, i)
+				break
+			}
+		}
+	}
+}
+
+func (h *HatcheryEC2) terminateInstance(ctx context.Context, i instance) error {
+	log.Info(ctx, "Terminating worker %s (instance %s)", i.Name, i.ID)
+
+	// If its a worker "register", check registration before terminating it
+	if i.Tags[tagRegisterOnly] == "true" {
+		modelPath := i.Tags[tagWorkerModelPath]
+		consoleLog, err := h.getConsoleOutput(ctx, i)
+		if err != nil {
+			log.Error(ctx, "terminateInstance> unable to get console output from registering instance %s: %v", i.Name, err)
+		}
+		if err := hatchery.CheckWorkerModelRegister(h, modelPath); err != nil {
+			var spawnErr = sdk.SpawnErrorForm{
+				Error: err.Error(),
+				Logs:  []byte(consoleLog),
+			}
+			tuple := strings.SplitN(modelPath, "/", 2)
+			if err := h.CDSClient().WorkerModelSpawnError(tuple[0], tuple[1], spawnErr); err != nil {
+				log.Error(ctx, "CheckWorkerModelRegister> error on call client.WorkerModelSpawnError on worker model %s for register: %s", modelPath, spawnErr)
+			}
+		}
+	}
+
+	if err := h.terminate(ctx, i.ID); err != nil {
+		log.Warning(ctx, "terminateInstance> Cannot terminate worker %s: %s", i.Name, err)
+		return err
+	}
+	h.resetInstancesCache()
+	return nil
+}
+
+// WorkersStarted returns the number of instances started but
+// not necessarily register on CDS yet
+func (h *HatcheryEC2) WorkersStarted(ctx context.Context) []string {
+	instances := h.getInstances(ctx)
+	res := make([]string, len(instances))
+	for i := range instances {
+		res[i] = instances[i].Tags[tagWorker]
+	}
+	return res
+}
+
+// WorkersStartedByModel returns the number of instances of given model started but
+// not necessarily register on CDS yet
+func (h *HatcheryEC2) WorkersStartedByModel(ctx context.Context, model *sdk.Model) int {
+	var x int
+	for _, i := range h.getInstances(ctx) {
+		if i.Tags[tagWorkerModelPath] == model.Group.Name+"/"+model.Name {
+			x++
+		}
+	}
+	log.Debug("WorkersStartedByModel> %s : %d", model.Name, x)
+	return x
+}
+
+// NeedRegistration return true if worker model need regsitration
+func (h *HatcheryEC2) NeedRegistration(ctx context.Context, m *sdk.Model) bool {
+	return m.NeedRegistration
+}
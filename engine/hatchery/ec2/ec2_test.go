@@ -0,0 +1,118 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+func TestHatcheryEC2_WorkersStarted(t *testing.T) {
+	m := &ec2ClientMock{
+		instances: []*ec2.Instance{
+			{
+				InstanceId: aws.String("i-1"),
+				State:      &ec2.InstanceState{Name: aws.String("running")},
+				Tags:       []*ec2.Tag{{Key: aws.String(tagWorker), Value: aws.String("w1")}, {Key: aws.String(tagWorkerModelPath), Value: aws.String("shared.infra/debian")}},
+			},
+			{
+				InstanceId:        aws.String("i-2"),
+				InstanceLifecycle: aws.String(ec2.InstanceLifecycleTypeSpot),
+				State:             &ec2.InstanceState{Name: aws.String("pending")},
+				Tags:              []*ec2.Tag{{Key: aws.String(tagWorker), Value: aws.String("w2")}, {Key: aws.String(tagWorkerModelPath), Value: aws.String("shared.infra/alpine")}},
+			},
+		},
+	}
+	h := NewHatcheryEC2Test(m)
+
+	ws := h.WorkersStarted(context.TODO())
+	require.Equal(t, []string{"w1", "w2"}, ws)
+
+	instances := h.getInstances(context.TODO())
+	require.False(t, instances[0].Spot)
+	require.True(t, instances[1].Spot)
+
+	model := &sdk.Model{Name: "debian", Group: &sdk.Group{Name: "shared.infra"}}
+	require.Equal(t, 1, h.WorkersStartedByModel(context.TODO(), model))
+}
+
+func TestHatcheryEC2_SpawnWorkerSpotFallback(t *testing.T) {
+	m := &ec2ClientMock{
+		runErrors: []error{awserr.New("InsufficientInstanceCapacity", "no spot capacity", nil)},
+	}
+	h := NewHatcheryEC2Test(m)
+
+	err := h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		WorkerName: "w1",
+		JobID:      42,
+		Model: &sdk.Model{
+			Name:  "debian",
+			Group: &sdk.Group{Name: "shared.infra"},
+			ModelVirtualMachine: sdk.ModelVirtualMachine{
+				Image:  "ami-123",
+				Flavor: "t3.medium",
+				Cmd:    "./worker --name={{.Name}}",
+				Spot:   true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, m.runInputs, 2)
+
+	// First try with a spot instance, then fallback on an on-demand instance
+	require.NotNil(t, m.runInputs[0].InstanceMarketOptions)
+	require.Equal(t, ec2.MarketTypeSpot, aws.StringValue(m.runInputs[0].InstanceMarketOptions.MarketType))
+	require.Nil(t, m.runInputs[1].InstanceMarketOptions)
+
+	input := m.runInputs[1]
+	require.Equal(t, "ami-123", aws.StringValue(input.ImageId))
+	require.Equal(t, "t3.medium", aws.StringValue(input.InstanceType))
+	require.Equal(t, "subnet-default", aws.StringValue(input.SubnetId))
+	require.Equal(t, ec2.ShutdownBehaviorTerminate, aws.StringValue(input.InstanceInitiatedShutdownBehavior))
+
+	tags := map[string]string{}
+	for _, tag := range input.TagSpecifications[0].Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	require.Equal(t, "w1", tags[tagWorker])
+	require.Equal(t, "kyubi", tags[tagHatcheryName])
+	require.Equal(t, "shared.infra/debian", tags[tagWorkerModelPath])
+	require.Equal(t, "42", tags[tagJobID])
+	require.Equal(t, "cds", tags["CostCenter"])
+}
+
+func TestHatcheryEC2_SpawnWorkerRegister(t *testing.T) {
+	m := &ec2ClientMock{}
+	h := NewHatcheryEC2Test(m)
+
+	err := h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		WorkerName:   "register-w1",
+		RegisterOnly: true,
+		Model: &sdk.Model{
+			Name:  "debian",
+			Group: &sdk.Group{Name: "shared.infra"},
+			ModelVirtualMachine: sdk.ModelVirtualMachine{
+				Image:          "ami-123",
+				Flavor:         "t3.medium",
+				Cmd:            "./worker",
+				Subnet:         "subnet-model",
+				SecurityGroups: []string{"sg-1"},
+				Spot:           true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, m.runInputs, 1)
+
+	input := m.runInputs[0]
+	require.Nil(t, input.InstanceMarketOptions)
+	require.Equal(t, "subnet-model", aws.StringValue(input.SubnetId))
+	require.Equal(t, []string{"sg-1"}, aws.StringValueSlice(input.SecurityGroupIds))
+	require.Equal(t, ec2.ShutdownBehaviorStop, aws.StringValue(input.InstanceInitiatedShutdownBehavior))
+}
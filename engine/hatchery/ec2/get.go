@@ -0,0 +1,113 @@
+package ec2
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// This a embedded cache for instances list
+var linstances = struct {
+	mu   sync.RWMutex
+	list []instance
+}{
+	mu:   sync.RWMutex{},
+	list: []instance{},
+}
+
+// getInstances returns the worker instances spawned by this hatchery that are not terminated
+func (h *HatcheryEC2) getInstances(ctx context.Context) []instance {
+	t := time.Now()
+	defer func() {
+		log.Debug("getInstances() : %fs", time.Since(t).Seconds())
+	}()
+
+	linstances.mu.RLock()
+	nbInstances := len(linstances.list)
+	linstances.mu.RUnlock()
+
+	if nbInstances == 0 {
+		input := &ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("tag:" + tagHatcheryName), Values: aws.StringSlice([]string{h.Name()})},
+				{Name: aws.String("tag-key"), Values: aws.StringSlice([]string{tagWorker})},
+				{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{"pending", "running", "stopping", "stopped"})},
+			},
+		}
+
+		instances := []instance{}
+		if err := h.ec2Client.DescribeInstancesPagesWithContext(ctx, input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+			for _, r := range out.Reservations {
+				for _, i := range r.Instances {
+					instances = append(instances, newInstance(i))
+				}
+			}
+			return true
+		}); err != nil {
+			log.Error(ctx, "getInstances> error on DescribeInstances: %v", err)
+			return linstances.list
+		}
+
+		linstances.mu.Lock()
+		linstances.list = instances
+		linstances.mu.Unlock()
+		//Remove data from the cache after 2 seconds
+		go func() {
+			time.Sleep(2 * time.Second)
+			h.resetInstancesCache()
+		}()
+	}
+
+	return linstances.list
+}
+
+func (h *HatcheryEC2) resetInstancesCache() {
+	linstances.mu.Lock()
+	linstances.list = []instance{}
+	linstances.mu.Unlock()
+}
+
+func newInstance(i *ec2.Instance) instance {
+	res := instance{
+		ID:         aws.StringValue(i.InstanceId),
+		LaunchTime: aws.TimeValue(i.LaunchTime),
+		Spot:       aws.StringValue(i.InstanceLifecycle) == ec2.InstanceLifecycleTypeSpot,
+		Tags:       make(map[string]string, len(i.Tags)),
+	}
+	if i.State != nil {
+		res.State = aws.StringValue(i.State.Name)
+	}
+	for _, t := range i.Tags {
+		res.Tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	res.Name = res.Tags[tagWorker]
+	return res
+}
+
+func (h *HatcheryEC2) terminate(ctx context.Context, instanceID string) error {
+	_, err := h.ec2Client.TerminateInstancesWithContext(ctx, &ec2.TerminateInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	})
+	return sdk.WrapError(err, "unable to terminate instance %s", instanceID)
+}
+
+func (h *HatcheryEC2) getConsoleOutput(ctx context.Context, i instance) (string, error) {
+	out, err := h.ec2Client.GetConsoleOutputWithContext(ctx, &ec2.GetConsoleOutputInput{
+		InstanceId: aws.String(i.ID),
+	})
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to get console output from %s", i.ID)
+	}
+	output, err := base64.StdEncoding.DecodeString(aws.StringValue(out.Output))
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to decode console output from %s", i.ID)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,56 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// ec2ClientMock implements the calls to the EC2 API made by the hatchery
+type ec2ClientMock struct {
+	ec2iface.EC2API
+	instances     []*ec2.Instance
+	runInputs     []*ec2.RunInstancesInput
+	runErrors     []error
+	terminatedIDs []string
+}
+
+func (m *ec2ClientMock) DescribeInstancesPagesWithContext(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+	fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: m.instances}}}, true)
+	return nil
+}
+
+func (m *ec2ClientMock) RunInstancesWithContext(_ context.Context, input *ec2.RunInstancesInput, _ ...request.Option) (*ec2.Reservation, error) {
+	m.runInputs = append(m.runInputs, input)
+	if len(m.runErrors) > 0 {
+		err := m.runErrors[0]
+		m.runErrors = m.runErrors[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: input.ImageId}}}, nil
+}
+
+func (m *ec2ClientMock) TerminateInstancesWithContext(_ context.Context, input *ec2.TerminateInstancesInput, _ ...request.Option) (*ec2.TerminateInstancesOutput, error) {
+	for _, id := range input.InstanceIds {
+		m.terminatedIDs = append(m.terminatedIDs, *id)
+	}
+	return &ec2.TerminateInstancesOutput{}, nil
+}
+
+func NewHatcheryEC2Test(m *ec2ClientMock) *HatcheryEC2 {
+	h := new(HatcheryEC2)
+	h.ec2Client = m
+	h.workersAlive = map[string]int64{}
+	h.Common.Common.ServiceName = "kyubi"
+	h.Config.Name = "kyubi"
+	h.Config.Provision.MaxWorker = 10
+	h.Config.SubnetID = "subnet-default"
+	h.Config.SpotFallbackOnDemand = true
+	h.Config.Tags = map[string]string{"CostCenter": "cds"}
+	h.resetInstancesCache()
+	return h
+}
@@ -0,0 +1,42 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/ovh/cds/sdk/log"
+)
+
+// InitHatchery creates the EC2 client
+// then starts the routines that terminate the instances of finished workers
+func (h *HatcheryEC2) InitHatchery(ctx context.Context) error {
+	h.workersAlive = map[string]int64{}
+
+	conf := aws.NewConfig().WithRegion(h.Config.Region)
+	// Without static credentials, the default chain is used: environment, shared credentials file or instance profile
+	if h.Config.AccessKeyID != "" {
+		conf = conf.WithCredentials(credentials.NewStaticCredentials(h.Config.AccessKeyID, h.Config.SecretAccessKey, ""))
+	}
+	if h.Config.Endpoint != "" {
+		conf = conf.WithEndpoint(h.Config.Endpoint)
+	}
+
+	sess, err := session.NewSession(conf)
+	if err != nil {
+		return fmt.Errorf("Unable to create an AWS session: %v", err)
+	}
+	h.ec2Client = ec2.New(sess)
+
+	if err := initMetrics(); err != nil {
+		log.Warning(ctx, "Error initializing ec2 metrics: %v", err)
+	}
+
+	go h.main(ctx)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package ec2
+
+import (
+	"context"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/engine/api/observability"
+)
+
+var (
+	onceMetrics sync.Once
+	metrics     struct {
+		SpawnedOnDemandInstances *stats.Int64Measure
+		SpawnedSpotInstances     *stats.Int64Measure
+		SpotFallbacks            *stats.Int64Measure
+		Instances                *stats.Int64Measure
+		SpotInstances            *stats.Int64Measure
+	}
+)
+
+func initMetrics() error {
+	var err error
+	onceMetrics.Do(func() {
+		metrics.SpawnedOnDemandInstances = stats.Int64("cds/ec2/spawned_ondemand_instances", "number of spawned on-demand instances", stats.UnitDimensionless)
+		metrics.SpawnedSpotInstances = stats.Int64("cds/ec2/spawned_spot_instances", "number of spawned spot instances", stats.UnitDimensionless)
+		metrics.SpotFallbacks = stats.Int64("cds/ec2/spot_fallbacks", "number of on-demand instances spawned because of missing spot capacity", stats.UnitDimensionless)
+		metrics.Instances = stats.Int64("cds/ec2/instances", "number of worker instances", stats.UnitDimensionless)
+		metrics.SpotInstances = stats.Int64("cds/ec2/spot_instances", "number of spot worker instances", stats.UnitDimensionless)
+
+		tags := []tag.Key{observability.MustNewKey(observability.TagServiceType), observability.MustNewKey(observability.TagServiceName)}
+		err = observability.RegisterView(
+			observability.NewViewCount("cds/hatchery/ec2/spawned_ondemand_instances_count", metrics.SpawnedOnDemandInstances, tags),
+			observability.NewViewCount("cds/hatchery/ec2/spawned_spot_instances_count", metrics.SpawnedSpotInstances, tags),
+			observability.NewViewCount("cds/hatchery/ec2/spot_fallbacks_count", metrics.SpotFallbacks, tags),
+			observability.NewViewLast("cds/hatchery/ec2/instances", metrics.Instances, tags),
+			observability.NewViewLast("cds/hatchery/ec2/spot_instances", metrics.SpotInstances, tags),
+		)
+	})
+	return err
+}
+
+func recordSpawnedInstance(ctx context.Context, spot bool) {
+	if spot {
+		observability.Record(ctx, metrics.SpawnedSpotInstances, 1)
+		return
+	}
+	observability.Record(ctx, metrics.SpawnedOnDemandInstances, 1)
+}
+
+func recordSpotFallback(ctx context.Context) {
+	observability.Record(ctx, metrics.SpotFallbacks, 1)
+}
+
+func recordInstances(ctx context.Context, total, spot int) {
+	observability.Record(ctx, metrics.Instances, int64(total))
+	observability.Record(ctx, metrics.SpotInstances, int64(spot))
+}
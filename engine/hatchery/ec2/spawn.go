@@ -0,0 +1,193 @@
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// spotCapacityErrors are the error codes returned by EC2 when a spot instance can't be started,
+// an on-demand instance can be started instead
+var spotCapacityErrors = []string{
+	"InsufficientInstanceCapacity",
+	"MaxSpotInstanceCountExceeded",
+	"SpotMaxPriceTooLow",
+	"UnfulfillableCapacity",
+}
+
+// SpawnWorker starts a new EC2 instance
+// requirements are not supported
+func (h *HatcheryEC2) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) error {
+	if spawnArgs.JobID > 0 {
+		log.Debug("spawnWorker> spawning worker %s model:%s for job %d", spawnArgs.WorkerName, spawnArgs.Model.Name, spawnArgs.JobID)
+	} else {
+		log.Debug("spawnWorker> spawning worker %s model:%s", spawnArgs.WorkerName, spawnArgs.Model.Name)
+	}
+
+	if len(h.getInstances(ctx)) >= h.Configuration().Provision.MaxWorker {
+		log.Debug("MaxWorker limit (%d) reached", h.Configuration().Provision.MaxWorker)
+		return nil
+	}
+
+	vm := spawnArgs.Model.ModelVirtualMachine
+	if spawnArgs.RegisterOnly {
+		vm.Cmd += " register"
+	}
+
+	udata := vm.PreCmd + "\n" + vm.Cmd + "\n" + vm.PostCmd
+	tmpl, err := template.New("udata").Parse(udata)
+	if err != nil {
+		return err
+	}
+	udataParam := sdk.WorkerArgs{
+		API:               h.Configuration().API.HTTP.URL,
+		Name:              spawnArgs.WorkerName,
+		Token:             spawnArgs.WorkerToken,
+		Model:             spawnArgs.Model.Group.Name + "/" + spawnArgs.Model.Name,
+		HatcheryName:      h.Name(),
+		TTL:               h.Config.WorkerTTL,
+		GraylogHost:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Host,
+		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
+		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
+		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		ToolRegistry:      h.Configuration().Provision.WorkerToolRegistry,
+		WorkflowJobID:     spawnArgs.JobID,
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, udataParam); err != nil {
+		return err
+	}
+
+	input := &ec2.RunInstancesInput{
+		ImageId:      aws.String(vm.Image),
+		InstanceType: aws.String(vm.Flavor),
+		MinCount:     aws.Int64(1),
+		MaxCount:     aws.Int64(1),
+		UserData:     aws.String(base64.StdEncoding.EncodeToString(buffer.Bytes())),
+		// The worker shuts the instance down at the end of the job, a registering instance is only stopped
+		// to let the hatchery check the registration before terminating it
+		InstanceInitiatedShutdownBehavior: aws.String(ec2.ShutdownBehaviorTerminate),
+		TagSpecifications:                 h.tagSpecifications(spawnArgs),
+	}
+	if spawnArgs.RegisterOnly {
+		input.InstanceInitiatedShutdownBehavior = aws.String(ec2.ShutdownBehaviorStop)
+	}
+
+	subnetID := h.Config.SubnetID
+	if vm.Subnet != "" {
+		subnetID = vm.Subnet
+	}
+	if subnetID != "" {
+		input.SubnetId = aws.String(subnetID)
+	}
+	securityGroupIDs := h.Config.SecurityGroupIDs
+	if len(vm.SecurityGroups) > 0 {
+		securityGroupIDs = vm.SecurityGroups
+	}
+	if len(securityGroupIDs) > 0 {
+		input.SecurityGroupIds = aws.StringSlice(securityGroupIDs)
+	}
+	if h.Config.KeyName != "" {
+		input.KeyName = aws.String(h.Config.KeyName)
+	}
+	if h.Config.IAMInstanceProfile != "" {
+		input.IamInstanceProfile = &ec2.IamInstanceProfileSpecification{Name: aws.String(h.Config.IAMInstanceProfile)}
+	}
+
+	// Registering instances are never spot instances, they could be interrupted before the end of the registration
+	spot := vm.Spot && !spawnArgs.RegisterOnly
+	if spot {
+		spotOptions := &ec2.SpotMarketOptions{
+			SpotInstanceType:             aws.String(ec2.SpotInstanceTypeOneTime),
+			InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorTerminate),
+		}
+		if h.Config.SpotMaxPrice != "" {
+			spotOptions.MaxPrice = aws.String(h.Config.SpotMaxPrice)
+		}
+		input.InstanceMarketOptions = &ec2.InstanceMarketOptionsRequest{
+			MarketType:  aws.String(ec2.MarketTypeSpot),
+			SpotOptions: spotOptions,
+		}
+	}
+
+	out, err := h.ec2Client.RunInstancesWithContext(ctx, input)
+	if err != nil && spot && h.Config.SpotFallbackOnDemand && isSpotCapacityError(err) {
+		log.Warning(ctx, "SpawnWorker> Unable to start spot instance for worker %s: %v - falling back to on-demand instance", spawnArgs.WorkerName, err)
+		recordSpotFallback(ctx)
+		spot = false
+		onDemandInput := *input
+		onDemandInput.InstanceMarketOptions = nil
+		out, err = h.ec2Client.RunInstancesWithContext(ctx, &onDemandInput)
+	}
+	if err != nil {
+		return sdk.WrapError(err, "unable to run instance: name:%s image:%s type:%s subnet:%s", spawnArgs.WorkerName, vm.Image, vm.Flavor, subnetID)
+	}
+
+	for _, i := range out.Instances {
+		log.Debug("SpawnWorker> Started instance %s for worker %s (spot:%t)", aws.StringValue(i.InstanceId), spawnArgs.WorkerName, spot)
+	}
+	recordSpawnedInstance(ctx, spot)
+	h.resetInstancesCache()
+
+	return nil
+}
+
+// tagSpecifications returns the tags of the instance and of its volumes, the tags from the configuration
+// are added for cost allocation
+func (h *HatcheryEC2) tagSpecifications(spawnArgs hatchery.SpawnArguments) []*ec2.TagSpecification {
+	tags := make(map[string]string, len(h.Config.Tags)+8)
+	for k, v := range h.Config.Tags {
+		tags[k] = v
+	}
+	tags[tagName] = spawnArgs.WorkerName
+	tags[tagWorker] = spawnArgs.WorkerName
+	tags[tagHatcheryName] = h.Name()
+	tags[tagRegisterOnly] = fmt.Sprintf("%t", spawnArgs.RegisterOnly)
+	tags[tagWorkerModelPath] = spawnArgs.Model.Group.Name + "/" + spawnArgs.Model.Name
+	tags[tagWorkerModelName] = spawnArgs.Model.Name
+	tags[tagWorkerModelLastModified] = fmt.Sprintf("%d", spawnArgs.Model.UserLastModified.Unix())
+	if spawnArgs.JobID > 0 {
+		tags[tagJobID] = fmt.Sprintf("%d", spawnArgs.JobID)
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ec2Tags := make([]*ec2.Tag, len(keys))
+	for i, k := range keys {
+		ec2Tags[i] = &ec2.Tag{Key: aws.String(k), Value: aws.String(tags[k])}
+	}
+
+	return []*ec2.TagSpecification{
+		{ResourceType: aws.String(ec2.ResourceTypeInstance), Tags: ec2Tags},
+		{ResourceType: aws.String(ec2.ResourceTypeVolume), Tags: ec2Tags},
+	}
+}
+
+func isSpotCapacityError(err error) bool {
+	aerr, ok := sdk.Cause(err).(awserr.Error)
+	if !ok {
+		return false
+	}
+	for _, code := range spotCapacityErrors {
+		if aerr.Code() == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package ec2
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	hatcheryCommon "github.com/ovh/cds/engine/hatchery"
+	"github.com/ovh/cds/engine/service"
+)
+
+// Tags set on each instance and volume spawned by the hatchery
+const (
+	tagName                    = "Name"
+	tagWorker                  = "worker"
+	tagHatcheryName            = "hatchery_name"
+	tagRegisterOnly            = "register_only"
+	tagWorkerModelPath         = "worker_model_path"
+	tagWorkerModelName         = "worker_model_name"
+	tagWorkerModelLastModified = "worker_model_last_modified"
+	tagJobID                   = "cds_job_id"
+)
+
+// HatcheryConfiguration is the configuration for hatchery
+type HatcheryConfiguration struct {
+	service.HatcheryCommonConfiguration `mapstructure:"commonConfiguration" toml:"commonConfiguration" json:"commonConfiguration"`
+
+	// Region AWS region
+	Region string `mapstructure:"region" toml:"region" default:"" commented:"false" comment:"AWS Region" json:"region"`
+
+	// AccessKeyID AWS access key ID
+	AccessKeyID string `mapstructure:"accessKeyId" toml:"accessKeyId" default:"" commented:"true" comment:"AWS Access Key ID. If not set, credentials are loaded from the environment or from the instance profile" json:"-"`
+
+	// SecretAccessKey AWS secret access key
+	SecretAccessKey string `mapstructure:"secretAccessKey" toml:"secretAccessKey" default:"" commented:"true" comment:"AWS Secret Access Key" json:"-"`
+
+	// Endpoint custom EC2 endpoint
+	Endpoint string `mapstructure:"endpoint" toml:"endpoint" default:"" commented:"true" comment:"Custom endpoint of the EC2 API, for example a VPC endpoint" json:"endpoint,omitempty"`
+
+	// SubnetID default subnet
+	SubnetID string `mapstructure:"subnetId" toml:"subnetId" default:"" commented:"false" comment:"Default subnet used to spawn CDS Workers if the worker model doesn't define one" json:"subnetId"`
+
+	// SecurityGroupIDs default security groups
+	SecurityGroupIDs []string `mapstructure:"securityGroupIds" toml:"securityGroupIds" commented:"true" comment:"Default security groups used to spawn CDS Workers if the worker model doesn't define any" json:"securityGroupIds,omitempty"`
+
+	// KeyName key pair
+	KeyName string `mapstructure:"keyName" toml:"keyName" default:"" commented:"true" comment:"Name of the key pair set on spawned instances" json:"keyName,omitempty"`
+
+	// IAMInstanceProfile instance profile
+	IAMInstanceProfile string `mapstructure:"iamInstanceProfile" toml:"iamInstanceProfile" default:"" commented:"true" comment:"Name of the IAM instance profile set on spawned instances" json:"iamInstanceProfile,omitempty"`
+
+	// SpotMaxPrice max price for spot instances
+	SpotMaxPrice string `mapstructure:"spotMaxPrice" toml:"spotMaxPrice" default:"" commented:"true" comment:"Maximum hourly price for spot instances, the on-demand price if not set" json:"spotMaxPrice,omitempty"`
+
+	// SpotFallbackOnDemand if true: spawn an on-demand instance when no spot instance is available
+	SpotFallbackOnDemand bool `mapstructure:"spotFallbackOnDemand" toml:"spotFallbackOnDemand" default:"true" commented:"false" comment:"if true: hatchery spawns an on-demand instance when no spot capacity is available for a worker model" json:"spotFallbackOnDemand"`
+
+	// Tags cost allocation tags
+	Tags map[string]string `mapstructure:"tags" toml:"tags" commented:"true" comment:"Tags added to all the instances and volumes, useful for cost allocation. Ex: tags = { CostCenter = \"cds\" }" json:"tags,omitempty"`
+
+	// WorkerTTL Worker TTL (minutes)
+	WorkerTTL int `mapstructure:"workerTTL" toml:"workerTTL" default:"30" commented:"false" comment:"Worker TTL (minutes)" json:"workerTTL"`
+}
+
+// HatcheryEC2 spawns instances of worker model with type 'ec2'
+// by starting AWS EC2 instances
+type HatcheryEC2 struct {
+	hatcheryCommon.Common
+	Config    HatcheryConfiguration
+	ec2Client ec2iface.EC2API
+
+	workersAlive map[string]int64
+}
+
+// instance is a worker instance spawned by the hatchery
+type instance struct {
+	ID         string
+	Name       string
+	State      string
+	Spot       bool
+	LaunchTime time.Time
+	Tags       map[string]string
+}
@@ -0,0 +1,400 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// New instanciates a new Hatchery ECS
+func New() *HatcheryECS {
+	s := new(HatcheryECS)
+	s.Router = &api.Router{
+		Mux: mux.NewRouter(),
+	}
+	return s
+}
+
+func (s *HatcheryECS) Init(config interface{}) (cdsclient.ServiceConfig, error) {
+	var cfg cdsclient.ServiceConfig
+	sConfig, ok := config.(HatcheryConfiguration)
+	if !ok {
+		return cfg, sdk.WithStack(fmt.Errorf("invalid ecs hatchery configuration"))
+	}
+
+	cfg.Host = sConfig.API.HTTP.URL
+	cfg.Token = sConfig.API.Token
+	cfg.InsecureSkipVerifyTLS = sConfig.API.HTTP.Insecure
+	cfg.RequestSecondsTimeout = sConfig.API.RequestTimeout
+	return cfg, nil
+}
+
+// ApplyConfiguration apply an object of type HatcheryConfiguration after checking it
+func (h *HatcheryECS) ApplyConfiguration(cfg interface{}) error {
+	if err := h.CheckConfiguration(cfg); err != nil {
+		return err
+	}
+
+	var ok bool
+	h.Config, ok = cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	h.Common.Common.ServiceName = h.Config.Name
+	h.Common.Common.ServiceType = services.TypeHatchery
+	h.HTTPURL = h.Config.URL
+
+	h.MaxHeartbeatFailures = h.Config.API.MaxHeartbeatFailures
+	var err error
+	h.Common.Common.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(h.Config.RSAPrivateKey))
+	if err != nil {
+		return fmt.Errorf("unable to parse RSA private Key: %v", err)
+	}
+
+	return nil
+}
+
+// Status returns sdk.MonitoringStatus, implements interface service.Service
+func (h *HatcheryECS) Status(ctx context.Context) sdk.MonitoringStatus {
+	m := h.CommonMonitoring()
+	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Workers", Value: fmt.Sprintf("%d/%d", len(h.WorkersStarted(ctx)), h.Config.Provision.MaxWorker), Status: sdk.MonitoringStatusOK})
+	return m
+}
+
+// CheckConfiguration checks the validity of the configuration object
+func (h *HatcheryECS) CheckConfiguration(cfg interface{}) error {
+	hconfig, ok := cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	if hconfig.API.HTTP.URL == "" {
+		return fmt.Errorf("API HTTP(s) URL is mandatory")
+	}
+
+	if hconfig.API.Token == "" {
+		return fmt.Errorf("API Token URL is mandatory")
+	}
+
+	if hconfig.Region == "" {
+		return fmt.Errorf("AWS region is mandatory")
+	}
+
+	if (hconfig.AccessKeyID == "") != (hconfig.SecretAccessKey == "") {
+		return fmt.Errorf("AWS access key ID and secret access key must be set together")
+	}
+
+	if hconfig.Cluster == "" {
+		return fmt.Errorf("ECS cluster is mandatory")
+	}
+
+	if len(hconfig.Subnets) == 0 {
+		return fmt.Errorf("at least one subnet is mandatory to start Fargate tasks")
+	}
+
+	if hconfig.LogGroup != "" && hconfig.ExecutionRoleARN == "" {
+		return fmt.Errorf("a task execution role is mandatory to send the logs of the workers to CloudWatch")
+	}
+
+	if _, _, err := fargateSize(hconfig.DefaultCPU, hconfig.DefaultMemory); err != nil {
+		return fmt.Errorf("invalid default CPU and memory: %v", err)
+	}
+
+	if hconfig.Name == "" {
+		return fmt.Errorf("please enter a name in your ecs hatchery configuration")
+	}
+
+	return nil
+}
+
+// Serve start the hatchery server
+func (h *HatcheryECS) Serve(ctx context.Context) error {
+	return h.CommonServe(ctx, h)
+}
+
+// Configuration returns Hatchery CommonConfiguration
+func (h *HatcheryECS) Configuration() service.HatcheryCommonConfiguration {
+	return h.Config.HatcheryCommonConfiguration
+}
+
+// ModelType returns type of hatchery
+func (*HatcheryECS) ModelType() string {
+	return sdk.Docker
+}
+
+// WorkerModelsEnabled returns Worker model enabled
+func (h *HatcheryECS) WorkerModelsEnabled() ([]sdk.Model, error) {
+	return h.CDSClient().WorkerModelsEnabled()
+}
+
+// CanSpawn return wether or not hatchery can spawn model.
+// Service and hostname requirements are not supported
+func (h *HatcheryECS) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
+	for _, r := range requirements {
+		if r.Type == sdk.ServiceRequirement || r.Type == sdk.HostnameRequirement {
+			log.Debug("CanSpawn> Job %d has a %s requirement. ECS can't spawn a worker for this job", jobID, r.Type)
+			return false
+		}
+	}
+	// Fargate only reads the credentials of private registries from AWS Secrets Manager
+	if model != nil && model.ModelDocker.Private {
+		log.Debug("CanSpawn> Model %s uses a private registry. ECS can't spawn a worker for this model", model.Name)
+		return false
+	}
+	// Tasks are started on the default Fargate platform: linux/amd64
+	if model != nil && (model.ModelDocker.GetOS() != sdk.ModelDockerOSLinux || (model.ModelDocker.Arch != "" && model.ModelDocker.Arch != "amd64")) {
+		log.Debug("CanSpawn> Model %s is a %s/%s model. ECS can't spawn a worker for this model", model.Name, model.ModelDocker.GetOS(), model.ModelDocker.Arch)
+		return false
+	}
+	if _, _, err := h.resources(requirements); err != nil {
+		log.Debug("CanSpawn> Job %d: %v", jobID, err)
+		return false
+	}
+	return true
+}
+
+// resources returns the Fargate CPU units and memory of a task, given the requirements of the job
+func (h *HatcheryECS) resources(requirements []sdk.Requirement) (int64, int64, error) {
+	cpu, memory := h.Config.DefaultCPU, h.Config.DefaultMemory
+	for _, r := range requirements {
+		switch r.Type {
+		case sdk.CPURequirement:
+			cpus, err := strconv.ParseFloat(r.Value, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("unable to parse cpu requirement %s: %v", r.Value, err)
+			}
+			cpu = int64(cpus * 1024)
+		case sdk.MemoryRequirement:
+			var err error
+			memory, err = strconv.ParseInt(r.Value, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("unable to parse memory requirement %s: %v", r.Value, err)
+			}
+		}
+	}
+	return fargateSize(cpu, memory)
+}
+
+func (h *HatcheryECS) main(ctx context.Context) {
+	taskListTick := time.NewTicker(10 * time.Second).C
+	killAwolWorkersTick := time.NewTicker(30 * time.Second).C
+	checkStoppedTasksTick := time.NewTicker(30 * time.Second).C
+	killDisabledWorkersTick := time.NewTicker(60 * time.Second).C
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-taskListTick:
+			h.updateTaskList(ctx)
+		case <-killAwolWorkersTick:
+			h.killAwolWorkers(ctx)
+		case <-checkStoppedTasksTick:
+			h.checkStoppedTasks(ctx)
+		case <-killDisabledWorkersTick:
+			h.killDisabledWorkers(ctx)
+		}
+	}
+}
+
+func (h *HatcheryECS) updateTaskList(ctx context.Context) {
+	status := map[string]int{}
+	tasks := h.getTasks(ctx)
+	for _, t := range tasks {
+		status[t.LastStatus]++
+	}
+	var st string
+	for k, s := range status {
+		st += fmt.Sprintf("%d %s ", s, k)
+	}
+	log.Debug("Got %d tasks %s", len(tasks), st)
+}
+
+// killAwolWorkers stops the tasks of workers that are not known by CDS anymore
+func (h *HatcheryECS) killAwolWorkers(ctx context.Context) {
+	ctxList, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	workers, err := h.CDSClient().WorkerList(ctxList)
+	if err != nil {
+		log.Warning(ctx, "killAwolWorkers> Cannot fetch worker list: %s", err)
+		return
+	}
+
+	now := time.Now().Unix()
+	inWorkersList := make(map[string]bool, len(workers))
+	for _, w := range workers {
+		inWorkersList[w.Name] = true
+	}
+
+	for _, t := range h.getTasks(ctx) {
+		// if the task is provisioning since > 15 min, we stop it
+		if t.LastStatus == "PROVISIONING" || t.LastStatus == "PENDING" {
+			if time.Since(t.CreatedAt) > 15*time.Minute {
+				log.Warning(ctx, "killAwolWorkers> Stopping task %s of worker %s status: %s created: %s", t.ID, t.Name, t.LastStatus, time.Since(t.CreatedAt))
+				_ = h.stopTask(ctx, t, "worker not started")
+			}
+			continue
+		}
+
+		if inWorkersList[t.Name] {
+			h.workersAlive[t.Name] = now
+			continue
+		}
+
+		// The worker was registered on CDS and is gone: the job is over
+		_, wasAlive := h.workersAlive[t.Name]
+
+		// Wait for 10 minutes before stopping workers not identified by CDS API, to avoid killing worker babies
+		if wasAlive || time.Since(t.CreatedAt) > 10*time.Minute {
+			log.Debug("killAwolWorkers> Stopping task %s of worker %s status: %s created: %s wasAlive:%t", t.ID, t.Name, t.LastStatus, time.Since(t.CreatedAt), wasAlive)
+			_ = h.stopTask(ctx, t, "worker unknown by CDS")
+			delete(h.workersAlive, t.Name)
+		}
+	}
+
+	// then clean workersAlive map
+	for workerName, t := range h.workersAlive {
+		if t != now {
+			delete(h.workersAlive, workerName)
+		}
+	}
+}
+
+// checkStoppedTasks checks the registration of the worker models once the registering tasks are stopped,
+// and explains on the job why its worker was stopped if it was killed by Fargate
+func (h *HatcheryECS) checkStoppedTasks(ctx context.Context) {
+	tasks, err := h.listTasks(ctx, ecs.DesiredStatusStopped)
+	if err != nil {
+		log.Error(ctx, "checkStoppedTasks> %v", err)
+		return
+	}
+
+	stopped := make(map[string]struct{}, len(tasks))
+	for _, t := range tasks {
+		stopped[t.ARN] = struct{}{}
+		if _, checked := h.stoppedTasks[t.ARN]; checked {
+			continue
+		}
+		h.stoppedTasks[t.ARN] = struct{}{}
+
+		if t.Tags[tagRegisterOnly] == "true" {
+			h.checkRegistration(ctx, t)
+			continue
+		}
+
+		if strings.HasPrefix(t.ContainerReason, "OutOfMemoryError") {
+			h.sendWorkerKilledInfo(ctx, t)
+		}
+	}
+
+	// ECS forgets the stopped tasks after a while
+	for arn := range h.stoppedTasks {
+		if _, ok := stopped[arn]; !ok {
+			delete(h.stoppedTasks, arn)
+		}
+	}
+}
+
+func (h *HatcheryECS) checkRegistration(ctx context.Context, t task) {
+	modelPath := t.Tags[tagWorkerModelPath]
+	if err := hatchery.CheckWorkerModelRegister(h, modelPath); err != nil {
+		logs, errL := h.getLogs(ctx, t)
+		if errL != nil {
+			log.Error(ctx, "checkRegistration> unable to get logs from registering task %s: %v", t.ID, errL)
+		}
+		var spawnErr = sdk.SpawnErrorForm{
+			Error: err.Error(),
+			Logs:  []byte(logs),
+		}
+		if t.StoppedReason != "" {
+			spawnErr.Error += ": " + t.StoppedReason
+		}
+		tuple := strings.SplitN(modelPath, "/", 2)
+		if err := h.CDSClient().WorkerModelSpawnError(tuple[0], tuple[1], spawnErr); err != nil {
+			log.Error(ctx, "checkRegistration> error on call client.WorkerModelSpawnError on worker model %s for register: %s", modelPath, err)
+		}
+	}
+}
+
+// sendWorkerKilledInfo explains on the job why its worker was killed by Fargate
+func (h *HatcheryECS) sendWorkerKilledInfo(ctx context.Context, t task) {
+	jobID, _ := strconv.ParseInt(t.Tags[tagJobID], 10, 64)
+	if jobID == 0 {
+		return
+	}
+	log.Warning(ctx, "killAwolWorkers> worker %s for job %d killed: %s", t.Name, jobID, t.ContainerReason)
+	// Fargate doesn't give the peak usage of a stopped task
+	hatchery.SendSpawnInfo(ctx, h, jobID, sdk.SpawnMsg{
+		ID:   sdk.MsgSpawnInfoWorkerOOMKilled.ID,
+		Args: []interface{}{t.Name, sdk.FormatMemory(t.Memory * 1024 * 1024), sdk.FormatMemory(0)},
+	})
+}
+
+func (h *HatcheryECS) killDisabledWorkers(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	workerPoolDisabled, err := hatchery.WorkerPool(ctx, h, sdk.StatusDisabled)
+	if err != nil {
+		log.Error(ctx, "killDisabledWorkers> Pool> Error: %v", err)
+		return
+	}
+
+	tasks := h.getTasks(ctx)
+	for _, w := range workerPoolDisabled {
+		for _, t := range tasks {
+			if t.Name == w.Name {
+				log.Info(ctx, "killDisabledWorkers> killDisabledWorkers %v", t.Name)
+				_ = h.stopTask(ctx, t, "worker disabled")
+				break
+			}
+		}
+	}
+}
+
+// WorkersStarted returns the number of instances started but
+// not necessarily register on CDS yet
+func (h *HatcheryECS) WorkersStarted(ctx context.Context) []string {
+	tasks := h.getTasks(ctx)
+	res := make([]string, len(tasks))
+	for i := range tasks {
+		res[i] = tasks[i].Name
+	}
+	return res
+}
+
+// WorkersStartedByModel returns the number of instances of given model started but
+// not necessarily register on CDS yet
+func (h *HatcheryECS) WorkersStartedByModel(ctx context.Context, model *sdk.Model) int {
+	var x int
+	for _, t := range h.getTasks(ctx) {
+		if t.Tags[tagWorkerModelPath] == model.Group.Name+"/"+model.Name {
+			x++
+		}
+	}
+	log.Debug("WorkersStartedByModel> %s : %d", model.Name, x)
+	return x
+}
+
+// NeedRegistration return true if worker model need regsitration
+func (h *HatcheryECS) NeedRegistration(ctx context.Context, m *sdk.Model) bool {
+	if m.NeedRegistration || m.LastRegistration.Unix() < m.UserLastModified.Unix() {
+		return true
+	}
+	return false
+}
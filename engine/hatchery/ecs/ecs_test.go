@@ -0,0 +1,164 @@
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+func TestHatcheryECS_WorkersStarted(t *testing.T) {
+	m := &ecsClientMock{
+		tasks: []*ecs.Task{
+			{
+				TaskArn:    aws.String("arn:aws:ecs:eu-west-1:123456789012:task/cds/1"),
+				LastStatus: aws.String("RUNNING"),
+				Memory:     aws.String("1024"),
+				Tags:       []*ecs.Tag{{Key: aws.String(tagHatcheryName), Value: aws.String("kyubi")}, {Key: aws.String(tagWorker), Value: aws.String("w1")}, {Key: aws.String(tagWorkerModelPath), Value: aws.String("shared.infra/debian")}},
+			},
+			{
+				TaskArn:    aws.String("arn:aws:ecs:eu-west-1:123456789012:task/cds/2"),
+				LastStatus: aws.String("PROVISIONING"),
+				Tags:       []*ecs.Tag{{Key: aws.String(tagHatcheryName), Value: aws.String("kyubi")}, {Key: aws.String(tagWorker), Value: aws.String("w2")}, {Key: aws.String(tagWorkerModelPath), Value: aws.String("shared.infra/alpine")}},
+			},
+			{
+				TaskArn:    aws.String("arn:aws:ecs:eu-west-1:123456789012:task/cds/3"),
+				LastStatus: aws.String("RUNNING"),
+				Tags:       []*ecs.Tag{{Key: aws.String(tagHatcheryName), Value: aws.String("kyubi-long-name-truncated")}, {Key: aws.String(tagWorker), Value: aws.String("w3")}},
+			},
+		},
+	}
+	h := NewHatcheryECSTest(m)
+
+	ws := h.WorkersStarted(context.TODO())
+	require.Equal(t, []string{"w1", "w2"}, ws)
+
+	tasks := h.getTasks(context.TODO())
+	require.Equal(t, "1", tasks[0].ID)
+	require.Equal(t, int64(1024), tasks[0].Memory)
+
+	model := &sdk.Model{Name: "debian", Group: &sdk.Group{Name: "shared.infra"}}
+	require.Equal(t, 1, h.WorkersStartedByModel(context.TODO(), model))
+}
+
+func TestHatcheryECS_SpawnWorker(t *testing.T) {
+	m := &ecsClientMock{}
+	h := NewHatcheryECSTest(m)
+	h.Config.ExecutionRoleARN = "arn:aws:iam::123456789012:role/ecsTaskExecutionRole"
+
+	model := &sdk.Model{
+		Name:  "debian",
+		Group: &sdk.Group{Name: "shared.infra"},
+		ModelDocker: sdk.ModelDocker{
+			Image: "debian:10",
+			Shell: "sh -c",
+			Cmd:   "curl {{.API}}/download/worker/linux/$(uname -m) -o worker && chmod +x worker && exec ./worker",
+			Envs:  map[string]string{"MY_WORKER": "{{.Name}}"},
+		},
+	}
+
+	err := h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		WorkerName:   "kyubi-debian-w1",
+		JobID:        42,
+		Model:        model,
+		Requirements: []sdk.Requirement{{Type: sdk.CPURequirement, Value: "1.5"}, {Type: sdk.MemoryRequirement, Value: "3000"}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, m.registered, 1)
+	td := m.registered[0]
+	require.Equal(t, "cds-shared-infra-debian-2048-4096", *td.Family)
+	require.Equal(t, "2048", *td.Cpu)
+	require.Equal(t, "4096", *td.Memory)
+	require.Equal(t, ecs.NetworkModeAwsvpc, *td.NetworkMode)
+	require.Equal(t, "arn:aws:iam::123456789012:role/ecsTaskExecutionRole", *td.ExecutionRoleArn)
+	require.Equal(t, "debian:10", *td.ContainerDefinitions[0].Image)
+	require.Equal(t, []string{"sh", "-c"}, aws.StringValueSlice(td.ContainerDefinitions[0].EntryPoint))
+	require.Equal(t, ecs.LogDriverAwslogs, *td.ContainerDefinitions[0].LogConfiguration.LogDriver)
+	require.Equal(t, "/cds/workers", *td.ContainerDefinitions[0].LogConfiguration.Options["awslogs-group"])
+
+	require.Len(t, m.runInputs, 1)
+	run := m.runInputs[0]
+	require.Equal(t, "arn:aws:ecs:eu-west-1:123456789012:task-definition/cds-shared-infra-debian-2048-4096:1", *run.TaskDefinition)
+	require.Equal(t, ecs.LaunchTypeFargate, *run.LaunchType)
+	require.Equal(t, "kyubi", *run.StartedBy)
+	require.Equal(t, ecs.AssignPublicIpEnabled, *run.NetworkConfiguration.AwsvpcConfiguration.AssignPublicIp)
+	override := run.Overrides.ContainerOverrides[0]
+	require.Equal(t, []string{"curl /download/worker/linux/$(uname -m) -o worker && chmod +x worker && exec ./worker"}, aws.StringValueSlice(override.Command))
+	envs := map[string]string{}
+	for _, e := range override.Environment {
+		envs[*e.Name] = *e.Value
+	}
+	require.Equal(t, "kyubi-debian-w1", envs["CDS_NAME"])
+	require.Equal(t, "42", envs["CDS_BOOKED_WORKFLOW_JOB_ID"])
+	require.Equal(t, "kyubi-debian-w1", envs["MY_WORKER"])
+	tags := map[string]string{}
+	for _, tag := range run.Tags {
+		tags[*tag.Key] = *tag.Value
+	}
+	require.Equal(t, "kyubi-debian-w1", tags[tagWorker])
+	require.Equal(t, "kyubi", tags[tagHatcheryName])
+	require.Equal(t, "shared.infra/debian", tags[tagWorkerModelPath])
+	require.Equal(t, "42", tags[tagJobID])
+	require.Equal(t, "cds", tags["CostCenter"])
+
+	// The task definition is registered once per worker model and task size
+	err = h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		WorkerName:   "kyubi-debian-w2",
+		JobID:        43,
+		Model:        model,
+		Requirements: []sdk.Requirement{{Type: sdk.CPURequirement, Value: "2"}, {Type: sdk.MemoryRequirement, Value: "4096"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, m.registered, 1)
+	require.Equal(t, 1, m.describeCalls)
+	require.Len(t, m.runInputs, 2)
+}
+
+func TestHatcheryECS_CanSpawn(t *testing.T) {
+	h := NewHatcheryECSTest(&ecsClientMock{})
+	model := &sdk.Model{Name: "debian", Group: &sdk.Group{Name: "shared.infra"}}
+
+	require.True(t, h.CanSpawn(context.TODO(), model, 1, []sdk.Requirement{{Type: sdk.MemoryRequirement, Value: "8192"}}))
+	require.False(t, h.CanSpawn(context.TODO(), model, 1, []sdk.Requirement{{Type: sdk.MemoryRequirement, Value: "65536"}}))
+	require.False(t, h.CanSpawn(context.TODO(), model, 1, []sdk.Requirement{{Type: sdk.ServiceRequirement, Value: "postgres:9.6"}}))
+
+	model.ModelDocker.Arch = "arm64"
+	require.False(t, h.CanSpawn(context.TODO(), model, 1, nil))
+	model.ModelDocker.Arch = "amd64"
+	require.True(t, h.CanSpawn(context.TODO(), model, 1, nil))
+
+	model.ModelDocker.Private = true
+	require.False(t, h.CanSpawn(context.TODO(), model, 1, nil))
+}
+
+func Test_fargateSize(t *testing.T) {
+	tests := []struct {
+		cpu, memory       int64
+		expCPU, expMemory int64
+		expErr            bool
+	}{
+		{cpu: 0, memory: 128, expCPU: 256, expMemory: 512},
+		{cpu: 512, memory: 1024, expCPU: 512, expMemory: 1024},
+		{cpu: 256, memory: 3000, expCPU: 512, expMemory: 3072},
+		{cpu: 1536, memory: 3000, expCPU: 2048, expMemory: 4096},
+		{cpu: 4096, memory: 30720, expCPU: 4096, expMemory: 30720},
+		{cpu: 8192, memory: 1024, expErr: true},
+		{cpu: 512, memory: 32768, expErr: true},
+	}
+	for _, tt := range tests {
+		cpu, memory, err := fargateSize(tt.cpu, tt.memory)
+		if tt.expErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tt.expCPU, cpu)
+		require.Equal(t, tt.expMemory, memory)
+	}
+}
@@ -0,0 +1,178 @@
+package ecs
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ecs"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// This a embedded cache for tasks list
+var ltasks = struct {
+	mu   sync.RWMutex
+	list []task
+}{
+	mu:   sync.RWMutex{},
+	list: []task{},
+}
+
+// getTasks returns the worker tasks spawned by this hatchery that are not stopped
+func (h *HatcheryECS) getTasks(ctx context.Context) []task {
+	t := time.Now()
+	defer func() {
+		log.Debug("getTasks() : %fs", time.Since(t).Seconds())
+	}()
+
+	ltasks.mu.RLock()
+	nbTasks := len(ltasks.list)
+	ltasks.mu.RUnlock()
+
+	if nbTasks == 0 {
+		tasks, err := h.listTasks(ctx, ecs.DesiredStatusRunning)
+		if err != nil {
+			log.Error(ctx, "getTasks> %v", err)
+			return ltasks.list
+		}
+
+		ltasks.mu.Lock()
+		ltasks.list = tasks
+		ltasks.mu.Unlock()
+		//Remove data from the cache after 2 seconds
+		go func() {
+			time.Sleep(2 * time.Second)
+			h.resetTasksCache()
+		}()
+	}
+
+	return ltasks.list
+}
+
+func (h *HatcheryECS) resetTasksCache() {
+	ltasks.mu.Lock()
+	ltasks.list = []task{}
+	ltasks.mu.Unlock()
+}
+
+// listTasks returns the worker tasks spawned by this hatchery with given desired status
+func (h *HatcheryECS) listTasks(ctx context.Context, desiredStatus string) ([]task, error) {
+	var arns []*string
+	if err := h.ecsClient.ListTasksPagesWithContext(ctx, &ecs.ListTasksInput{
+		Cluster:       aws.String(h.Config.Cluster),
+		StartedBy:     aws.String(h.startedBy()),
+		DesiredStatus: aws.String(desiredStatus),
+	}, func(out *ecs.ListTasksOutput, _ bool) bool {
+		arns = append(arns, out.TaskArns...)
+		return true
+	}); err != nil {
+		return nil, sdk.WrapError(err, "unable to list tasks of cluster %s", h.Config.Cluster)
+	}
+
+	tasks := []task{}
+	// DescribeTasks accepts 100 tasks at most
+	for len(arns) > 0 {
+		n := len(arns)
+		if n > 100 {
+			n = 100
+		}
+		out, err := h.ecsClient.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
+			Cluster: aws.String(h.Config.Cluster),
+			Tasks:   arns[:n],
+			Include: aws.StringSlice([]string{ecs.TaskFieldTags}),
+		})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to describe tasks of cluster %s", h.Config.Cluster)
+		}
+		for _, t := range out.Tasks {
+			tt := newTask(t)
+			// StartedBy may have been truncated, the tags are checked too
+			if tt.Tags[tagHatcheryName] != h.Name() || tt.Name == "" {
+				continue
+			}
+			tasks = append(tasks, tt)
+		}
+		arns = arns[n:]
+	}
+
+	return tasks, nil
+}
+
+// startedBy returns the identifier of the tasks started by the hatchery, limited to 36 characters by ECS
+func (h *HatcheryECS) startedBy() string {
+	s := h.Name()
+	if len(s) > 36 {
+		s = s[:36]
+	}
+	return s
+}
+
+func newTask(t *ecs.Task) task {
+	res := task{
+		ARN:           aws.StringValue(t.TaskArn),
+		LastStatus:    aws.StringValue(t.LastStatus),
+		DesiredStatus: aws.StringValue(t.DesiredStatus),
+		CreatedAt:     aws.TimeValue(t.CreatedAt),
+		StoppedReason: aws.StringValue(t.StoppedReason),
+		Tags:          make(map[string]string, len(t.Tags)),
+	}
+	res.ID = res.ARN[strings.LastIndex(res.ARN, "/")+1:]
+	res.Memory, _ = strconv.ParseInt(aws.StringValue(t.Memory), 10, 64)
+	for _, tag := range t.Tags {
+		res.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	for _, c := range t.Containers {
+		if aws.StringValue(c.Name) == containerName {
+			res.ContainerReason = aws.StringValue(c.Reason)
+		}
+	}
+	res.Name = res.Tags[tagWorker]
+	return res
+}
+
+func (h *HatcheryECS) stopTask(ctx context.Context, t task, reason string) error {
+	log.Info(ctx, "Stopping worker %s (task %s): %s", t.Name, t.ID, reason)
+	if _, err := h.ecsClient.StopTaskWithContext(ctx, &ecs.StopTaskInput{
+		Cluster: aws.String(h.Config.Cluster),
+		Task:    aws.String(t.ARN),
+		Reason:  aws.String("CDS: " + reason),
+	}); err != nil {
+		log.Warning(ctx, "stopTask> Cannot stop worker %s: %s", t.Name, err)
+		return sdk.WrapError(err, "unable to stop task %s", t.ID)
+	}
+	h.resetTasksCache()
+	return nil
+}
+
+// getLogs returns the output of the worker container sent to CloudWatch
+func (h *HatcheryECS) getLogs(ctx context.Context, t task) (string, error) {
+	if h.Config.LogGroup == "" {
+		return "", nil
+	}
+	out, err := h.logsClient.GetLogEventsWithContext(ctx, &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String(h.Config.LogGroup),
+		LogStreamName: aws.String(h.logStreamPrefix() + "/" + containerName + "/" + t.ID),
+		StartFromHead: aws.Bool(true),
+	})
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to get logs of task %s", t.ID)
+	}
+	lines := make([]string, len(out.Events))
+	for i, e := range out.Events {
+		lines[i] = aws.StringValue(e.Message)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (h *HatcheryECS) logStreamPrefix() string {
+	if h.Config.LogStreamPrefix == "" {
+		return "cds"
+	}
+	return h.Config.LogStreamPrefix
+}
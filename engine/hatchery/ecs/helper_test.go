@@ -0,0 +1,78 @@
+package ecs
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+)
+
+// ecsClientMock implements the calls to the ECS API made by the hatchery
+type ecsClientMock struct {
+	ecsiface.ECSAPI
+	tasks         []*ecs.Task
+	registered    []*ecs.RegisterTaskDefinitionInput
+	runInputs     []*ecs.RunTaskInput
+	stoppedARNs   []string
+	describeCalls int
+}
+
+func (m *ecsClientMock) ListTasksPagesWithContext(_ context.Context, _ *ecs.ListTasksInput, fn func(*ecs.ListTasksOutput, bool) bool, _ ...request.Option) error {
+	out := &ecs.ListTasksOutput{}
+	for _, t := range m.tasks {
+		out.TaskArns = append(out.TaskArns, t.TaskArn)
+	}
+	fn(out, true)
+	return nil
+}
+
+func (m *ecsClientMock) DescribeTasksWithContext(_ context.Context, _ *ecs.DescribeTasksInput, _ ...request.Option) (*ecs.DescribeTasksOutput, error) {
+	return &ecs.DescribeTasksOutput{Tasks: m.tasks}, nil
+}
+
+func (m *ecsClientMock) DescribeTaskDefinitionWithContext(_ context.Context, input *ecs.DescribeTaskDefinitionInput, _ ...request.Option) (*ecs.DescribeTaskDefinitionOutput, error) {
+	m.describeCalls++
+	return nil, awserr.New(ecs.ErrCodeClientException, "Unable to describe task definition.", nil)
+}
+
+func (m *ecsClientMock) RegisterTaskDefinitionWithContext(_ context.Context, input *ecs.RegisterTaskDefinitionInput, _ ...request.Option) (*ecs.RegisterTaskDefinitionOutput, error) {
+	m.registered = append(m.registered, input)
+	return &ecs.RegisterTaskDefinitionOutput{TaskDefinition: &ecs.TaskDefinition{
+		TaskDefinitionArn: aws.String("arn:aws:ecs:eu-west-1:123456789012:task-definition/" + *input.Family + ":1"),
+	}}, nil
+}
+
+func (m *ecsClientMock) RunTaskWithContext(_ context.Context, input *ecs.RunTaskInput, _ ...request.Option) (*ecs.RunTaskOutput, error) {
+	m.runInputs = append(m.runInputs, input)
+	return &ecs.RunTaskOutput{Tasks: []*ecs.Task{{TaskArn: aws.String("arn:aws:ecs:eu-west-1:123456789012:task/cds/1")}}}, nil
+}
+
+func (m *ecsClientMock) StopTaskWithContext(_ context.Context, input *ecs.StopTaskInput, _ ...request.Option) (*ecs.StopTaskOutput, error) {
+	m.stoppedARNs = append(m.stoppedARNs, *input.Task)
+	return &ecs.StopTaskOutput{}, nil
+}
+
+func NewHatcheryECSTest(m *ecsClientMock) *HatcheryECS {
+	h := new(HatcheryECS)
+	h.ecsClient = m
+	h.workersAlive = map[string]int64{}
+	h.stoppedTasks = map[string]struct{}{}
+	h.taskDefinitions = map[string]taskDefinition{}
+	h.Common.Common.ServiceName = "kyubi"
+	h.Config.Name = "kyubi"
+	h.Config.Region = "eu-west-1"
+	h.Config.Cluster = "cds"
+	h.Config.Subnets = []string{"subnet-1"}
+	h.Config.AssignPublicIP = true
+	h.Config.LogGroup = "/cds/workers"
+	h.Config.LogStreamPrefix = "cds"
+	h.Config.DefaultCPU = 512
+	h.Config.DefaultMemory = 1024
+	h.Config.Provision.MaxWorker = 10
+	h.Config.Tags = map[string]string{"CostCenter": "cds"}
+	h.resetTasksCache()
+	return h
+}
@@ -0,0 +1,42 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+// InitHatchery creates the ECS and CloudWatch Logs clients
+// then starts the routines that stop the tasks of finished workers
+func (h *HatcheryECS) InitHatchery(ctx context.Context) error {
+	h.workersAlive = map[string]int64{}
+	h.stoppedTasks = map[string]struct{}{}
+	h.taskDefinitions = map[string]taskDefinition{}
+
+	conf := aws.NewConfig().WithRegion(h.Config.Region)
+	// Without static credentials, the default chain is used: environment, shared credentials file or task role
+	if h.Config.AccessKeyID != "" {
+		conf = conf.WithCredentials(credentials.NewStaticCredentials(h.Config.AccessKeyID, h.Config.SecretAccessKey, ""))
+	}
+
+	sess, err := session.NewSession(conf)
+	if err != nil {
+		return fmt.Errorf("Unable to create an AWS session: %v", err)
+	}
+
+	ecsConf := aws.NewConfig()
+	if h.Config.Endpoint != "" {
+		ecsConf = ecsConf.WithEndpoint(h.Config.Endpoint)
+	}
+	h.ecsClient = ecs.New(sess, ecsConf)
+	h.logsClient = cloudwatchlogs.New(sess)
+
+	go h.main(ctx)
+
+	return nil
+}
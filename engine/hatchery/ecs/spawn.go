@@ -0,0 +1,187 @@
+package ecs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// SpawnWorker starts a new Fargate task
+func (h *HatcheryECS) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) error {
+	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly && !spawnArgs.WarmPool {
+		return sdk.WithStack(fmt.Errorf("no job ID and no register"))
+	}
+
+	if len(h.getTasks(ctx)) >= h.Configuration().Provision.MaxWorker {
+		log.Debug("MaxWorker limit (%d) reached", h.Configuration().Provision.MaxWorker)
+		return nil
+	}
+
+	cpu, memory, err := h.resources(spawnArgs.Requirements)
+	if err != nil {
+		return err
+	}
+	if spawnArgs.RegisterOnly {
+		cpu, memory, _ = fargateSize(0, hatchery.MemoryRegisterContainer)
+	}
+
+	taskDefinitionARN, err := h.taskDefinition(ctx, spawnArgs.Model, cpu, memory)
+	if err != nil {
+		return err
+	}
+
+	udataParam := sdk.WorkerArgs{
+		API:               h.Configuration().API.HTTP.URL,
+		Token:             spawnArgs.WorkerToken,
+		HTTPInsecure:      h.Config.API.HTTP.Insecure,
+		Name:              spawnArgs.WorkerName,
+		Model:             spawnArgs.Model.Group.Name + "/" + spawnArgs.Model.Name,
+		HatcheryName:      h.Name(),
+		TTL:               h.Config.WorkerTTL,
+		GraylogHost:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Host,
+		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
+		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
+		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		ToolRegistry:      h.Configuration().Provision.WorkerToolRegistry,
+		WorkflowJobID:     spawnArgs.JobID,
+	}
+
+	tmpl, err := template.New("cmd").Parse(spawnArgs.Model.ModelDocker.Cmd)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, udataParam); err != nil {
+		return err
+	}
+	cmd := buffer.String()
+	if spawnArgs.RegisterOnly {
+		cmd += " register"
+	}
+
+	envsWm := map[string]string{}
+	envsWm["CDS_FORCE_EXIT"] = "1"
+	envsWm["CDS_MODEL_MEMORY"] = fmt.Sprintf("%d", memory)
+	envsWm["CDS_API"] = udataParam.API
+	envsWm["CDS_TOKEN"] = udataParam.Token
+	envsWm["CDS_NAME"] = udataParam.Name
+	envsWm["CDS_MODEL_PATH"] = udataParam.Model
+	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
+	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
+	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	envsWm["CDS_TOOL_REGISTRY"] = udataParam.ToolRegistry
+	if spawnArgs.JobID > 0 {
+		envsWm["CDS_BOOKED_WORKFLOW_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
+	}
+
+	envTemplated, err := sdk.TemplateEnvs(udataParam, spawnArgs.Model.ModelDocker.Envs)
+	if err != nil {
+		return err
+	}
+	for envName, envValue := range envTemplated {
+		envsWm[envName] = envValue
+	}
+
+	envNames := make([]string, 0, len(envsWm))
+	for k := range envsWm {
+		envNames = append(envNames, k)
+	}
+	sort.Strings(envNames)
+	envs := make([]*ecs.KeyValuePair, len(envNames))
+	for i, k := range envNames {
+		envs[i] = &ecs.KeyValuePair{Name: aws.String(k), Value: aws.String(envsWm[k])}
+	}
+
+	assignPublicIP := ecs.AssignPublicIpDisabled
+	if h.Config.AssignPublicIP {
+		assignPublicIP = ecs.AssignPublicIpEnabled
+	}
+	vpcConfiguration := &ecs.AwsVpcConfiguration{
+		Subnets:        aws.StringSlice(h.Config.Subnets),
+		AssignPublicIp: aws.String(assignPublicIP),
+	}
+	if len(h.Config.SecurityGroups) > 0 {
+		vpcConfiguration.SecurityGroups = aws.StringSlice(h.Config.SecurityGroups)
+	}
+
+	tags := map[string]string{
+		tagWorker:                  spawnArgs.WorkerName,
+		tagRegisterOnly:            fmt.Sprintf("%t", spawnArgs.RegisterOnly),
+		tagWorkerModelPath:         udataParam.Model,
+		tagWorkerModelLastModified: fmt.Sprintf("%d", spawnArgs.Model.UserLastModified.Unix()),
+	}
+	if spawnArgs.JobID > 0 {
+		tags[tagJobID] = fmt.Sprintf("%d", spawnArgs.JobID)
+	}
+
+	input := &ecs.RunTaskInput{
+		Cluster:              aws.String(h.Config.Cluster),
+		TaskDefinition:       aws.String(taskDefinitionARN),
+		LaunchType:           aws.String(ecs.LaunchTypeFargate),
+		Count:                aws.Int64(1),
+		StartedBy:            aws.String(h.startedBy()),
+		NetworkConfiguration: &ecs.NetworkConfiguration{AwsvpcConfiguration: vpcConfiguration},
+		Overrides: &ecs.TaskOverride{
+			ContainerOverrides: []*ecs.ContainerOverride{{
+				Name:        aws.String(containerName),
+				Command:     aws.StringSlice([]string{cmd}),
+				Environment: envs,
+			}},
+		},
+		Tags: h.tags(tags),
+	}
+	if h.Config.PlatformVersion != "" {
+		input.PlatformVersion = aws.String(h.Config.PlatformVersion)
+	}
+
+	out, err := h.ecsClient.RunTaskWithContext(ctx, input)
+	if err != nil {
+		return sdk.WrapError(err, "unable to run task: name:%s image:%s cpu:%d memory:%d", spawnArgs.WorkerName, spawnArgs.Model.ModelDocker.Image, cpu, memory)
+	}
+	if len(out.Tasks) == 0 {
+		var reason string
+		for _, f := range out.Failures {
+			reason += aws.StringValue(f.Reason) + " "
+		}
+		return sdk.WithStack(fmt.Errorf("unable to run task for worker %s: %s", spawnArgs.WorkerName, reason))
+	}
+
+	log.Debug("SpawnWorker> Started task %s for worker %s (cpu:%d memory:%d)", aws.StringValue(out.Tasks[0].TaskArn), spawnArgs.WorkerName, cpu, memory)
+	h.resetTasksCache()
+
+	return nil
+}
+
+// tags returns the tags of a task or of a task definition, the tags from the configuration
+// are added for cost allocation
+func (h *HatcheryECS) tags(values map[string]string) []*ecs.Tag {
+	tags := make(map[string]string, len(h.Config.Tags)+len(values)+1)
+	for k, v := range h.Config.Tags {
+		tags[k] = v
+	}
+	for k, v := range values {
+		tags[k] = v
+	}
+	tags[tagHatcheryName] = h.Name()
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ecsTags := make([]*ecs.Tag, len(keys))
+	for i, k := range keys {
+		ecsTags[i] = &ecs.Tag{Key: aws.String(k), Value: aws.String(tags[k])}
+	}
+	return ecsTags
+}
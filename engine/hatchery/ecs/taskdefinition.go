@@ -0,0 +1,131 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/slug"
+)
+
+// fargateMemories are the memory sizes in MB supported by Fargate for each CPU size in CPU units
+var fargateMemories = []struct {
+	cpu      int64
+	memories []int64
+}{
+	{cpu: 256, memories: []int64{512, 1024, 2048}},
+	{cpu: 512, memories: memoryRange(1024, 4096)},
+	{cpu: 1024, memories: memoryRange(2048, 8192)},
+	{cpu: 2048, memories: memoryRange(4096, 16384)},
+	{cpu: 4096, memories: memoryRange(8192, 30720)},
+}
+
+func memoryRange(min, max int64) []int64 {
+	var res []int64
+	for m := min; m <= max; m += 1024 {
+		res = append(res, m)
+	}
+	return res
+}
+
+// fargateSize returns the smallest CPU and memory sizes supported by Fargate that are greater or equal to given ones
+func fargateSize(cpu, memory int64) (int64, int64, error) {
+	for _, s := range fargateMemories {
+		if s.cpu < cpu {
+			continue
+		}
+		for _, m := range s.memories {
+			if m >= memory {
+				return s.cpu, m, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("no Fargate task size for %d CPU units and %dMB of memory", cpu, memory)
+}
+
+// taskDefinitionFamily returns the family of the task definitions of a worker model, for a task size
+func taskDefinitionFamily(model *sdk.Model, cpu, memory int64) string {
+	return fmt.Sprintf("cds-%s-%d-%d", slug.Convert(model.Group.Name+"-"+model.Name), cpu, memory)
+}
+
+// taskDefinition returns the ARN of the task definition of a worker model for a task size.
+// A new revision is registered if the worker model was modified since the last one
+func (h *HatcheryECS) taskDefinition(ctx context.Context, model *sdk.Model, cpu, memory int64) (string, error) {
+	family := taskDefinitionFamily(model, cpu, memory)
+	lastModified := fmt.Sprintf("%d", model.UserLastModified.Unix())
+
+	h.taskDefinitionsMutex.Lock()
+	defer h.taskDefinitionsMutex.Unlock()
+
+	if td, ok := h.taskDefinitions[family]; ok && td.LastModified == lastModified {
+		return td.ARN, nil
+	}
+
+	// The task definition may have been registered before a restart of the hatchery
+	out, err := h.ecsClient.DescribeTaskDefinitionWithContext(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(family),
+		Include:        aws.StringSlice([]string{ecs.TaskDefinitionFieldTags}),
+	})
+	if err == nil && out.TaskDefinition != nil && aws.StringValue(out.TaskDefinition.Status) == ecs.TaskDefinitionStatusActive {
+		for _, t := range out.Tags {
+			if aws.StringValue(t.Key) == tagWorkerModelLastModified && aws.StringValue(t.Value) == lastModified {
+				arn := aws.StringValue(out.TaskDefinition.TaskDefinitionArn)
+				h.taskDefinitions[family] = taskDefinition{ARN: arn, LastModified: lastModified}
+				return arn, nil
+			}
+		}
+	}
+
+	container := &ecs.ContainerDefinition{
+		Name:      aws.String(containerName),
+		Image:     aws.String(model.ModelDocker.Image),
+		Essential: aws.Bool(true),
+	}
+	if shell := strings.Fields(model.ModelDocker.Shell); len(shell) > 0 {
+		container.EntryPoint = aws.StringSlice(shell)
+	}
+	if h.Config.LogGroup != "" {
+		container.LogConfiguration = &ecs.LogConfiguration{
+			LogDriver: aws.String(ecs.LogDriverAwslogs),
+			Options: aws.StringMap(map[string]string{
+				"awslogs-group":         h.Config.LogGroup,
+				"awslogs-region":        h.Config.Region,
+				"awslogs-stream-prefix": h.logStreamPrefix(),
+			}),
+		}
+	}
+
+	input := &ecs.RegisterTaskDefinitionInput{
+		Family:                  aws.String(family),
+		RequiresCompatibilities: aws.StringSlice([]string{ecs.CompatibilityFargate}),
+		NetworkMode:             aws.String(ecs.NetworkModeAwsvpc),
+		Cpu:                     aws.String(fmt.Sprintf("%d", cpu)),
+		Memory:                  aws.String(fmt.Sprintf("%d", memory)),
+		ContainerDefinitions:    []*ecs.ContainerDefinition{container},
+		Tags: h.tags(map[string]string{
+			tagWorkerModelPath:         model.Group.Name + "/" + model.Name,
+			tagWorkerModelLastModified: lastModified,
+		}),
+	}
+	if h.Config.ExecutionRoleARN != "" {
+		input.ExecutionRoleArn = aws.String(h.Config.ExecutionRoleARN)
+	}
+	if h.Config.TaskRoleARN != "" {
+		input.TaskRoleArn = aws.String(h.Config.TaskRoleARN)
+	}
+
+	res, err := h.ecsClient.RegisterTaskDefinitionWithContext(ctx, input)
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to register task definition %s", family)
+	}
+	arn := aws.StringValue(res.TaskDefinition.TaskDefinitionArn)
+	log.Info(ctx, "taskDefinition> Registered task definition %s for worker model %s", arn, model.Name)
+
+	h.taskDefinitions[family] = taskDefinition{ARN: arn, LastModified: lastModified}
+	return arn, nil
+}
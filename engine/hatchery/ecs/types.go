@@ -0,0 +1,120 @@
+package ecs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+
+	hatcheryCommon "github.com/ovh/cds/engine/hatchery"
+	"github.com/ovh/cds/engine/service"
+)
+
+// Tags set on each task and task definition registered by the hatchery
+const (
+	tagWorker                  = "worker"
+	tagHatcheryName            = "hatchery_name"
+	tagRegisterOnly            = "register_only"
+	tagWorkerModelPath         = "worker_model_path"
+	tagWorkerModelLastModified = "worker_model_last_modified"
+	tagJobID                   = "cds_job_id"
+)
+
+// containerName is the name of the worker container in the task definitions
+const containerName = "worker"
+
+// HatcheryConfiguration is the configuration for hatchery
+type HatcheryConfiguration struct {
+	service.HatcheryCommonConfiguration `mapstructure:"commonConfiguration" toml:"commonConfiguration" json:"commonConfiguration"`
+
+	// Region AWS region
+	Region string `mapstructure:"region" toml:"region" default:"" commented:"false" comment:"AWS Region" json:"region"`
+
+	// AccessKeyID AWS access key ID
+	AccessKeyID string `mapstructure:"accessKeyId" toml:"accessKeyId" default:"" commented:"true" comment:"AWS Access Key ID. If not set, credentials are loaded from the environment or from the task role" json:"-"`
+
+	// SecretAccessKey AWS secret access key
+	SecretAccessKey string `mapstructure:"secretAccessKey" toml:"secretAccessKey" default:"" commented:"true" comment:"AWS Secret Access Key" json:"-"`
+
+	// Endpoint custom ECS endpoint
+	Endpoint string `mapstructure:"endpoint" toml:"endpoint" default:"" commented:"true" comment:"Custom endpoint of the ECS API, for example a VPC endpoint" json:"endpoint,omitempty"`
+
+	// Cluster ECS cluster
+	Cluster string `mapstructure:"cluster" toml:"cluster" default:"cds" commented:"false" comment:"Name of the ECS cluster in which the Fargate tasks are started" json:"cluster"`
+
+	// PlatformVersion Fargate platform version
+	PlatformVersion string `mapstructure:"platformVersion" toml:"platformVersion" default:"LATEST" commented:"true" comment:"Fargate platform version" json:"platformVersion"`
+
+	// Subnets subnets of the tasks
+	Subnets []string `mapstructure:"subnets" toml:"subnets" commented:"false" comment:"Subnets in which the tasks are started" json:"subnets"`
+
+	// SecurityGroups security groups of the tasks
+	SecurityGroups []string `mapstructure:"securityGroups" toml:"securityGroups" commented:"true" comment:"Security groups of the tasks, the default security group of the VPC if not set" json:"securityGroups,omitempty"`
+
+	// AssignPublicIP if true: tasks get a public IP
+	AssignPublicIP bool `mapstructure:"assignPublicIp" toml:"assignPublicIp" default:"true" commented:"false" comment:"if true: tasks get a public IP. It's needed in a public subnet to pull images and to reach the CDS API" json:"assignPublicIp"`
+
+	// ExecutionRoleARN task execution role
+	ExecutionRoleARN string `mapstructure:"executionRoleArn" toml:"executionRoleArn" default:"" commented:"false" comment:"ARN of the task execution role, used by Fargate to pull images from ECR and to send logs to CloudWatch" json:"executionRoleArn"`
+
+	// TaskRoleARN task role
+	TaskRoleARN string `mapstructure:"taskRoleArn" toml:"taskRoleArn" default:"" commented:"true" comment:"ARN of the IAM role assumed by the workers" json:"taskRoleArn,omitempty"`
+
+	// LogGroup CloudWatch log group
+	LogGroup string `mapstructure:"logGroup" toml:"logGroup" default:"/cds/workers" commented:"false" comment:"CloudWatch log group to which the output of the workers is sent. Leave empty to disable the log forwarding" json:"logGroup"`
+
+	// LogStreamPrefix CloudWatch log stream prefix
+	LogStreamPrefix string `mapstructure:"logStreamPrefix" toml:"logStreamPrefix" default:"cds" commented:"false" comment:"Prefix of the CloudWatch log streams, a log stream is named <prefix>/worker/<task id>" json:"logStreamPrefix"`
+
+	// DefaultCPU Worker default CPU
+	DefaultCPU int64 `mapstructure:"defaultCPU" toml:"defaultCPU" default:"512" commented:"false" comment:"Worker default CPU in CPU units (1024 units = 1 vCPU), used if the job has no CPU requirement" json:"defaultCPU"`
+
+	// DefaultMemory Worker default memory
+	DefaultMemory int64 `mapstructure:"defaultMemory" toml:"defaultMemory" default:"1024" commented:"false" comment:"Worker default memory in Mo, used if the job has no memory requirement" json:"defaultMemory"`
+
+	// Tags cost allocation tags
+	Tags map[string]string `mapstructure:"tags" toml:"tags" commented:"true" comment:"Tags added to all the tasks and task definitions, useful for cost allocation. Ex: tags = { CostCenter = \"cds\" }" json:"tags,omitempty"`
+
+	// WorkerTTL Worker TTL (minutes)
+	WorkerTTL int `mapstructure:"workerTTL" toml:"workerTTL" default:"10" commented:"false" comment:"Worker TTL (minutes)" json:"workerTTL"`
+}
+
+// HatcheryECS spawns instances of worker model with type 'docker'
+// by starting AWS Fargate tasks
+type HatcheryECS struct {
+	hatcheryCommon.Common
+	Config     HatcheryConfiguration
+	ecsClient  ecsiface.ECSAPI
+	logsClient cloudwatchlogsiface.CloudWatchLogsAPI
+
+	workersAlive map[string]int64
+	// stoppedTasks are the stopped tasks already checked by the hatchery
+	stoppedTasks map[string]struct{}
+
+	// taskDefinitions caches the ARN of the task definitions registered by the hatchery, by family
+	taskDefinitionsMutex sync.Mutex
+	taskDefinitions      map[string]taskDefinition
+}
+
+// task is a worker task spawned by the hatchery
+type task struct {
+	ARN           string
+	ID            string
+	Name          string
+	LastStatus    string
+	DesiredStatus string
+	CreatedAt     time.Time
+	// Memory is the memory of the task in MB
+	Memory        int64
+	StoppedReason string
+	// ContainerReason is the reason why the worker container stopped, if any
+	ContainerReason string
+	Tags            map[string]string
+}
+
+// taskDefinition is a task definition registered for a worker model
+type taskDefinition struct {
+	ARN          string
+	LastModified string
+}
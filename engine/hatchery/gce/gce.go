@@ -0,0 +1,310 @@
+package gce
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// New instanciates a new Hatchery GCE
+func New() *HatcheryGCE {
+	s := new(HatcheryGCE)
+	s.Router = &api.Router{
+		Mux: mux.NewRouter(),
+	}
+	return s
+}
+
+func (s *HatcheryGCE) Init(config interface{}) (cdsclient.ServiceConfig, error) {
+	var cfg cdsclient.ServiceConfig
+	sConfig, ok := config.(HatcheryConfiguration)
+	if !ok {
+		return cfg, sdk.WithStack(fmt.Errorf("invalid gce hatchery configuration"))
+	}
+
+	cfg.Host = sConfig.API.HTTP.URL
+	cfg.Token = sConfig.API.Token
+	cfg.InsecureSkipVerifyTLS = sConfig.API.HTTP.Insecure
+	cfg.RequestSecondsTimeout = sConfig.API.RequestTimeout
+	return cfg, nil
+}
+
+// ApplyConfiguration apply an object of type HatcheryConfiguration after checking it
+func (h *HatcheryGCE) ApplyConfiguration(cfg interface{}) error {
+	if err := h.CheckConfiguration(cfg); err != nil {
+		return err
+	}
+
+	var ok bool
+	h.Config, ok = cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	h.Common.Common.ServiceName = h.Config.Name
+	h.Common.Common.ServiceType = services.TypeHatchery
+	h.HTTPURL = h.Config.URL
+
+	h.MaxHeartbeatFailures = h.Config.API.MaxHeartbeatFailures
+	var err error
+	h.Common.Common.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(h.Config.RSAPrivateKey))
+	if err != nil {
+		return fmt.Errorf("unable to parse RSA private Key: %v", err)
+	}
+
+	return nil
+}
+
+// Status returns sdk.MonitoringStatus, implements interface service.Service
+func (h *HatcheryGCE) Status(ctx context.Context) sdk.MonitoringStatus {
+	m := h.CommonMonitoring()
+	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Workers", Value: fmt.Sprintf("%d/%d", len(h.WorkersStarted(ctx)), h.Config.Provision.MaxWorker), Status: sdk.MonitoringStatusOK})
+	return m
+}
+
+// CheckConfiguration checks the validity of the configuration object
+func (h *HatcheryGCE) CheckConfiguration(cfg interface{}) error {
+	hconfig, ok := cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	if hconfig.API.HTTP.URL == "" {
+		return fmt.Errorf("API HTTP(s) URL is mandatory")
+	}
+
+	if hconfig.API.Token == "" {
+		return fmt.Errorf("API Token URL is mandatory")
+	}
+
+	if hconfig.Project == "" {
+		return fmt.Errorf("GCP project is mandatory")
+	}
+
+	if hconfig.Zone == "" {
+		return fmt.Errorf("GCE zone is mandatory")
+	}
+
+	if hconfig.Name == "" {
+		return fmt.Errorf("please enter a name in your gce hatchery configuration")
+	}
+
+	return nil
+}
+
+// Serve start the hatchery server
+func (h *HatcheryGCE) Serve(ctx context.Context) error {
+	return h.CommonServe(ctx, h)
+}
+
+// Configuration returns Hatchery CommonConfiguration
+func (h *HatcheryGCE) Configuration() service.HatcheryCommonConfiguration {
+	return h.Config.HatcheryCommonConfiguration
+}
+
+// ModelType returns type of hatchery
+func (*HatcheryGCE) ModelType() string {
+	return sdk.GCE
+}
+
+// WorkerModelsEnabled returns Worker model enabled
+func (h *HatcheryGCE) WorkerModelsEnabled() ([]sdk.Model, error) {
+	return h.CDSClient().WorkerModelsEnabled()
+}
+
+// CanSpawn return wether or not hatchery can spawn model
+// requirements are not supported
+func (h *HatcheryGCE) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
+	for _, r := range requirements {
+		if r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement || r.Type == sdk.HostnameRequirement {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *HatcheryGCE) main(ctx context.Context) {
+	instanceListTick := time.NewTicker(10 * time.Second).C
+	killAwolInstancesTick := time.NewTicker(30 * time.Second).C
+	killDisabledWorkersTick := time.NewTicker(60 * time.Second).C
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-instanceListTick:
+			h.updateInstanceList(ctx)
+		case <-killAwolInstancesTick:
+			h.killAwolInstances(ctx)
+		case <-killDisabledWorkersTick:
+			h.killDisabledWorkers(ctx)
+		}
+	}
+}
+
+func (h *HatcheryGCE) updateInstanceList(ctx context.Context) {
+	var out string
+	var total int
+	status := map[string]int{}
+
+	for _, i := range h.getInstances(ctx) {
+		out += fmt.Sprintf("- [%s] %s:%s ", i.CreationTimestamp, i.Status, i.Name)
+		status[i.Status]++
+		total++
+	}
+	var st string
+	for k, s := range status {
+		st += fmt.Sprintf("%d %s ", s, k)
+	}
+	log.Debug("Got %d instances %s", total, st)
+	if total > 0 {
+		log.Debug(out)
+	}
+}
+
+// killAwolInstances deletes the instances of workers that are not known by CDS anymore
+// and the instances that were shut down by their worker at the end of the job
+func (h *HatcheryGCE) killAwolInstances(ctx context.Context) {
+	ctxList, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	workers, err := h.CDSClient().WorkerList(ctxList)
+	if err != nil {
+		log.Warning(ctx, "killAwolInstances> Cannot fetch worker list: %s", err)
+		return
+	}
+
+	now := time.Now().Unix()
+	inWorkersList := make(map[string]bool, len(workers))
+	for _, w := range workers {
+		inWorkersList[w.Name] = true
+	}
+
+	for _, i := range h.getInstances(ctx) {
+		workerName := metadataValue(i, metadataWorker)
+		created := creationTime(i)
+
+		// if the instance is starting since > 15 min, we delete it
+		if i.Status == "PROVISIONING" || i.Status == "STAGING" {
+			if time.Since(created) > 15*time.Minute {
+				log.Warning(ctx, "killAwolInstances> Deleting instance %s status: %s created: %s", i.Name, i.Status, time.Since(created))
+				_ = h.deleteInstance(ctx, i)
+			}
+			continue
+		}
+
+		if inWorkersList[workerName] {
+			h.workersAlive[workerName] = now
+			continue
+		}
+
+		_, wasAlive := h.workersAlive[workerName]
+
+		// Wait for 10 minutes before deleting workers not identified by CDS API, to avoid killing worker babies
+		if i.Status == "TERMINATED" || wasAlive || time.Since(created) > 10*time.Minute {
+			log.Debug("killAwolInstances> Deleting instance %s status: %s created: %s wasAlive:%t", i.Name, i.Status, time.Since(created), wasAlive)
+			_ = h.deleteInstance(ctx, i)
+			delete(h.workersAlive, workerName)
+		}
+	}
+
+	// then clean workersAlive map
+	for workerName, t := range h.workersAlive {
+		if t != now {
+			delete(h.workersAlive, workerName)
+		}
+	}
+}
+
+func (h *HatcheryGCE) killDisabledWorkers(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	workerPoolDisabled, err := hatchery.WorkerPool(ctx, h, sdk.StatusDisabled)
+	if err != nil {
+		log.Error(ctx, "killDisabledWorkers> Pool> Error: %v", err)
+		return
+	}
+
+	instances := h.getInstances(ctx)
+	for _, w := range workerPoolDisabled {
+		for _, i := range instances {
+			if metadataValue(i, metadataWorker) == w.Name {
+				log.Info(ctx, "killDisabledWorkers> killDisabledWorkers %v", i.Name)
+				_ = h.deleteInstance(ctx, i)
+				break
+			}
+		}
+	}
+}
+
+func (h *HatcheryGCE) deleteInstance(ctx context.Context, i *compute.Instance) error {
+	log.Info(ctx, "Deleting worker %s", i.Name)
+
+	// If its a worker "register", check registration before deleting it
+	if metadataValue(i, metadataRegisterOnly) == "true" {
+		modelPath := metadataValue(i, metadataWorkerModelPath)
+		serialLog, err := h.getSerialPortOutput(ctx, i)
+		if err != nil {
+			log.Error(ctx, "deleteInstance> unable to get serial port output from registering instance %s: %v", i.Name, err)
+		}
+		if err := hatchery.CheckWorkerModelRegister(h, modelPath); err != nil {
+			var spawnErr = sdk.SpawnErrorForm{
+				Error: err.Error(),
+				Logs:  []byte(serialLog),
+			}
+			tuple := strings.SplitN(modelPath, "/", 2)
+			if err := h.CDSClient().WorkerModelSpawnError(tuple[0], tuple[1], spawnErr); err != nil {
+				log.Error(ctx, "CheckWorkerModelRegister> error on call client.WorkerModelSpawnError on worker model %s for register: %s", modelPath, spawnErr)
+			}
+		}
+	}
+
+	if _, err := h.computeService.Instances.Delete(h.Config.Project, h.Config.Zone, i.Name).Context(ctx).Do(); err != nil {
+		log.Warning(ctx, "deleteInstance> Cannot delete worker %s: %s", i.Name, err)
+		return sdk.WrapError(err, "unable to delete instance %s", i.Name)
+	}
+	h.resetInstancesCache()
+	return nil
+}
+
+// WorkersStarted returns the number of instances started but
+// not necessarily register on CDS yet
+func (h *HatcheryGCE) WorkersStarted(ctx context.Context) []string {
+	instances := h.getInstances(ctx)
+	res := make([]string, len(instances))
+	for i := range instances {
+		res[i] = metadataValue(instances[i], metadataWorker)
+	}
+	return res
+}
+
+// WorkersStartedByModel returns the number of instances of given model started but
+// not necessarily register on CDS yet
+func (h *HatcheryGCE) WorkersStartedByModel(ctx context.Context, model *sdk.Model) int {
+	var x int
+	for _, i := range h.getInstances(ctx) {
+		if metadataValue(i, metadataWorkerModelPath) == model.Group.Name+"/"+model.Name {
+			x++
+		}
+	}
+	log.Debug("WorkersStartedByModel> %s : %d", model.Name, x)
+	return x
+}
+
+// NeedRegistration return true if worker model need regsitration
+func (h *HatcheryGCE) NeedRegistration(ctx context.Context, m *sdk.Model) bool {
+	return m.NeedRegistration
+}
@@ -0,0 +1,114 @@
+package gce
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+func TestHatcheryGCE_WorkersStarted(t *testing.T) {
+	defer gock.Off()
+	h := NewHatcheryGCETest(t)
+
+	instances := compute.InstanceList{
+		Items: []*compute.Instance{
+			{
+				Name:     "w1",
+				Status:   "RUNNING",
+				Metadata: &compute.Metadata{Items: []*compute.MetadataItems{{Key: metadataWorker, Value: googleapi.String("w1")}, {Key: metadataWorkerModelPath, Value: googleapi.String("shared.infra/debian")}}},
+			},
+			{
+				Name:   "not-a-worker",
+				Status: "RUNNING",
+			},
+			{
+				Name:     "w2",
+				Status:   "STAGING",
+				Metadata: &compute.Metadata{Items: []*compute.MetadataItems{{Key: metadataWorker, Value: googleapi.String("w2")}, {Key: metadataWorkerModelPath, Value: googleapi.String("shared.infra/alpine")}}},
+			},
+		},
+	}
+	gock.New("http://lolcat.gce").Get("/compute/v1/projects/hachibi/zones/europe-west1-b/instances").
+		MatchParam("filter", "labels.hatchery_name = kyubi").
+		Reply(http.StatusOK).JSON(instances)
+
+	ws := h.WorkersStarted(context.TODO())
+	require.Equal(t, []string{"w1", "w2"}, ws)
+
+	model := &sdk.Model{Name: "debian", Group: &sdk.Group{Name: "shared.infra"}}
+	require.Equal(t, 1, h.WorkersStartedByModel(context.TODO(), model))
+	require.True(t, gock.IsDone())
+}
+
+func TestHatcheryGCE_SpawnWorker(t *testing.T) {
+	defer gock.Off()
+	h := NewHatcheryGCETest(t)
+	h.Config.ImageProject = "cds-images"
+	h.Config.Labels = map[string]string{"cost-center": "cds"}
+
+	gock.New("http://lolcat.gce").Get("/compute/v1/projects/hachibi/zones/europe-west1-b/instances").
+		Reply(http.StatusOK).JSON(compute.InstanceList{})
+	gock.New("http://lolcat.gce").Post("/compute/v1/projects/hachibi/zones/europe-west1-b/instances").
+		Reply(http.StatusOK).JSON(compute.Operation{Name: "op-1"})
+
+	var instance compute.Instance
+	gock.Observe(func(request *http.Request, mock gock.Mock) {
+		if request.Method != http.MethodPost || request.Body == nil {
+			return
+		}
+		bodyContent, err := ioutil.ReadAll(request.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bodyContent, &instance))
+	})
+
+	err := h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		WorkerName: "kyubi-debian-w1",
+		JobID:      666,
+		Model: &sdk.Model{
+			Name:  "debian",
+			Group: &sdk.Group{Name: "shared.infra"},
+			ModelVirtualMachine: sdk.ModelVirtualMachine{
+				Image:       "debian-10",
+				Flavor:      "n1-standard-2",
+				Cmd:         "./worker --name={{.Name}}",
+				Preemptible: true,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+
+	require.Equal(t, "kyubi-debian-w1", instance.Name)
+	require.Equal(t, "zones/europe-west1-b/machineTypes/n1-standard-2", instance.MachineType)
+	require.Equal(t, "projects/cds-images/global/images/family/debian-10", instance.Disks[0].InitializeParams.SourceImage)
+	require.Equal(t, int64(20), instance.Disks[0].InitializeParams.DiskSizeGb)
+	require.NotNil(t, instance.Scheduling)
+	require.True(t, instance.Scheduling.Preemptible)
+	require.Len(t, instance.NetworkInterfaces[0].AccessConfigs, 1)
+	require.Equal(t, "#!/bin/bash\n\n./worker --name=kyubi-debian-w1\n", metadataValue(&instance, metadataStartupScript))
+	require.Equal(t, "kyubi-debian-w1", metadataValue(&instance, metadataWorker))
+	require.Equal(t, "shared.infra/debian", metadataValue(&instance, metadataWorkerModelPath))
+	require.Equal(t, "kyubi", instance.Labels[labelHatcheryName])
+	require.Equal(t, "shared-infra-debian", instance.Labels[labelWorkerModel])
+	require.Equal(t, "666", instance.Labels[labelJobID])
+	require.Equal(t, "cds", instance.Labels["cost-center"])
+}
+
+func TestHatcheryGCE_sourceImage(t *testing.T) {
+	h := &HatcheryGCE{}
+	h.Config.Project = "hachibi"
+
+	require.Equal(t, "projects/hachibi/global/images/family/debian-10", h.sourceImage("debian-10"))
+	require.Equal(t, "projects/debian-cloud/global/images/family/debian-10", h.sourceImage("debian-cloud/debian-10"))
+	require.Equal(t, "projects/cds/global/images/my-image", h.sourceImage("projects/cds/global/images/my-image"))
+}
@@ -0,0 +1,93 @@
+package gce
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// This a embedded cache for instances list
+var linstances = struct {
+	mu   sync.RWMutex
+	list []*compute.Instance
+}{
+	mu:   sync.RWMutex{},
+	list: []*compute.Instance{},
+}
+
+// getInstances returns the worker instances spawned by this hatchery
+func (h *HatcheryGCE) getInstances(ctx context.Context) []*compute.Instance {
+	t := time.Now()
+	defer func() {
+		log.Debug("getInstances() : %fs", time.Since(t).Seconds())
+	}()
+
+	linstances.mu.RLock()
+	nbInstances := len(linstances.list)
+	linstances.mu.RUnlock()
+
+	if nbInstances == 0 {
+		instances := []*compute.Instance{}
+		filter := fmt.Sprintf("labels.%s = %s", labelHatcheryName, labelValue(h.Name()))
+		if err := h.computeService.Instances.List(h.Config.Project, h.Config.Zone).Filter(filter).Pages(ctx, func(l *compute.InstanceList) error {
+			for _, i := range l.Items {
+				if metadataValue(i, metadataWorker) == "" {
+					continue
+				}
+				instances = append(instances, i)
+			}
+			return nil
+		}); err != nil {
+			log.Error(ctx, "getInstances> error on instances.List: %v", err)
+			return linstances.list
+		}
+
+		linstances.mu.Lock()
+		linstances.list = instances
+		linstances.mu.Unlock()
+		//Remove data from the cache after 2 seconds
+		go func() {
+			time.Sleep(2 * time.Second)
+			h.resetInstancesCache()
+		}()
+	}
+
+	return linstances.list
+}
+
+func (h *HatcheryGCE) resetInstancesCache() {
+	linstances.mu.Lock()
+	linstances.list = []*compute.Instance{}
+	linstances.mu.Unlock()
+}
+
+func (h *HatcheryGCE) getSerialPortOutput(ctx context.Context, i *compute.Instance) (string, error) {
+	out, err := h.computeService.Instances.GetSerialPortOutput(h.Config.Project, h.Config.Zone, i.Name).Context(ctx).Do()
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to get serial port output from %s", i.Name)
+	}
+	return out.Contents, nil
+}
+
+func metadataValue(i *compute.Instance, key string) string {
+	if i.Metadata == nil {
+		return ""
+	}
+	for _, item := range i.Metadata.Items {
+		if item.Key == key && item.Value != nil {
+			return *item.Value
+		}
+	}
+	return ""
+}
+
+func creationTime(i *compute.Instance) time.Time {
+	t, _ := time.Parse(time.RFC3339, i.CreationTimestamp)
+	return t
+}
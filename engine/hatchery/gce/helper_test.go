@@ -0,0 +1,38 @@
+package gce
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func NewHatcheryGCETest(t *testing.T) *HatcheryGCE {
+	h := new(HatcheryGCE)
+	h.Common.Common.ServiceName = "kyubi"
+	h.Config.Name = "kyubi"
+	h.Config.Project = "hachibi"
+	h.Config.Zone = "europe-west1-b"
+	h.Config.Network = "global/networks/default"
+	h.Config.DiskSizeGB = 20
+	h.Config.Provision.MaxWorker = 10
+	h.workersAlive = map[string]int64{}
+
+	httpClient := &http.Client{}
+	gock.InterceptClient(httpClient)
+	computeService, err := compute.NewService(context.TODO(), option.WithHTTPClient(httpClient))
+	require.NoError(t, err)
+	// The requests are sent to the mocked host, on the base path of the API
+	basePath, err := url.Parse(computeService.BasePath)
+	require.NoError(t, err)
+	computeService.BasePath = "http://lolcat.gce" + basePath.Path
+	h.computeService = computeService
+
+	h.resetInstancesCache()
+	return h
+}
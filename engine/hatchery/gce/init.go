@@ -0,0 +1,31 @@
+package gce
+
+import (
+	"context"
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+)
+
+// InitHatchery creates the compute service
+// then starts the routines that delete the instances of finished workers
+func (h *HatcheryGCE) InitHatchery(ctx context.Context) error {
+	h.workersAlive = map[string]int64{}
+
+	// Without credentials file, the application default credentials are used: environment or instance service account
+	var opts []option.ClientOption
+	if h.Config.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(h.Config.CredentialsFile))
+	}
+
+	computeService, err := compute.NewService(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("Unable to create compute service: %v", err)
+	}
+	h.computeService = computeService
+
+	go h.main(ctx)
+
+	return nil
+}
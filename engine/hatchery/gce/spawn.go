@@ -0,0 +1,164 @@
+package gce
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/slug"
+)
+
+// SpawnWorker creates a new GCE instance, the worker is started by the startup script of the instance
+// requirements are not supported
+func (h *HatcheryGCE) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) error {
+	if spawnArgs.JobID > 0 {
+		log.Debug("spawnWorker> spawning worker %s model:%s for job %d", spawnArgs.WorkerName, spawnArgs.Model.Name, spawnArgs.JobID)
+	} else {
+		log.Debug("spawnWorker> spawning worker %s model:%s", spawnArgs.WorkerName, spawnArgs.Model.Name)
+	}
+
+	if len(h.getInstances(ctx)) >= h.Configuration().Provision.MaxWorker {
+		log.Debug("MaxWorker limit (%d) reached", h.Configuration().Provision.MaxWorker)
+		return nil
+	}
+
+	vm := spawnArgs.Model.ModelVirtualMachine
+	if spawnArgs.RegisterOnly {
+		vm.Cmd += " register"
+	}
+
+	script := "#!/bin/bash\n" + vm.PreCmd + "\n" + vm.Cmd + "\n" + vm.PostCmd
+	tmpl, err := template.New("startup-script").Parse(script)
+	if err != nil {
+		return err
+	}
+	scriptParam := sdk.WorkerArgs{
+		API:               h.Configuration().API.HTTP.URL,
+		Name:              spawnArgs.WorkerName,
+		Token:             spawnArgs.WorkerToken,
+		Model:             spawnArgs.Model.Group.Name + "/" + spawnArgs.Model.Name,
+		HatcheryName:      h.Name(),
+		TTL:               h.Config.WorkerTTL,
+		GraylogHost:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Host,
+		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
+		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
+		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		ToolRegistry:      h.Configuration().Provision.WorkerToolRegistry,
+		WorkflowJobID:     spawnArgs.JobID,
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, scriptParam); err != nil {
+		return err
+	}
+
+	networkInterface := &compute.NetworkInterface{
+		Network:    h.Config.Network,
+		Subnetwork: h.Config.Subnetwork,
+	}
+	if !h.Config.DisableExternalIP {
+		networkInterface.AccessConfigs = []*compute.AccessConfig{{Type: "ONE_TO_ONE_NAT", Name: "External NAT"}}
+	}
+
+	instance := &compute.Instance{
+		Name:        spawnArgs.WorkerName,
+		MachineType: fmt.Sprintf("zones/%s/machineTypes/%s", h.Config.Zone, vm.Flavor),
+		Disks: []*compute.AttachedDisk{{
+			Boot:       true,
+			AutoDelete: true,
+			InitializeParams: &compute.AttachedDiskInitializeParams{
+				SourceImage: h.sourceImage(vm.Image),
+				DiskSizeGb:  h.Config.DiskSizeGB,
+			},
+		}},
+		NetworkInterfaces: []*compute.NetworkInterface{networkInterface},
+		Metadata: &compute.Metadata{
+			Items: []*compute.MetadataItems{
+				{Key: metadataStartupScript, Value: googleapi.String(buffer.String())},
+				{Key: metadataWorker, Value: googleapi.String(spawnArgs.WorkerName)},
+				{Key: metadataRegisterOnly, Value: googleapi.String(fmt.Sprintf("%t", spawnArgs.RegisterOnly))},
+				{Key: metadataWorkerModelPath, Value: googleapi.String(spawnArgs.Model.Group.Name + "/" + spawnArgs.Model.Name)},
+				{Key: metadataWorkerModelLastModified, Value: googleapi.String(fmt.Sprintf("%d", spawnArgs.Model.UserLastModified.Unix()))},
+			},
+		},
+		Labels: h.labels(spawnArgs),
+	}
+	if len(h.Config.NetworkTags) > 0 {
+		instance.Tags = &compute.Tags{Items: h.Config.NetworkTags}
+	}
+	if h.Config.ServiceAccount != "" {
+		instance.ServiceAccounts = []*compute.ServiceAccount{{
+			Email:  h.Config.ServiceAccount,
+			Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+		}}
+	}
+
+	// Registering instances are never preemptible, they could be stopped before the end of the registration
+	if vm.Preemptible && !spawnArgs.RegisterOnly {
+		instance.Scheduling = &compute.Scheduling{
+			Preemptible:       true,
+			AutomaticRestart:  googleapi.Bool(false),
+			OnHostMaintenance: "TERMINATE",
+		}
+	}
+
+	op, err := h.computeService.Instances.Insert(h.Config.Project, h.Config.Zone, instance).Context(ctx).Do()
+	if err != nil {
+		return sdk.WrapError(err, "unable to insert instance: name:%s image:%s machineType:%s", spawnArgs.WorkerName, vm.Image, vm.Flavor)
+	}
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return sdk.WithStack(fmt.Errorf("unable to insert instance %s: %s", spawnArgs.WorkerName, op.Error.Errors[0].Message))
+	}
+	log.Debug("SpawnWorker> Inserted instance %s (preemptible:%t)", spawnArgs.WorkerName, instance.Scheduling != nil)
+	h.resetInstancesCache()
+
+	return nil
+}
+
+// sourceImage returns the URL of the image family of a worker model, the image family can be
+// given with its project as 'project/family' or as a full image URL
+func (h *HatcheryGCE) sourceImage(image string) string {
+	if strings.HasPrefix(image, "projects/") || strings.HasPrefix(image, "https://") {
+		return image
+	}
+	project := h.Config.ImageProject
+	if project == "" {
+		project = h.Config.Project
+	}
+	family := image
+	if tuple := strings.SplitN(image, "/", 2); len(tuple) == 2 {
+		project, family = tuple[0], tuple[1]
+	}
+	return fmt.Sprintf("projects/%s/global/images/family/%s", project, family)
+}
+
+// labels returns the labels of the instance, the labels from the configuration are added for billing reports
+func (h *HatcheryGCE) labels(spawnArgs hatchery.SpawnArguments) map[string]string {
+	labels := make(map[string]string, len(h.Config.Labels)+3)
+	for k, v := range h.Config.Labels {
+		labels[k] = v
+	}
+	labels[labelHatcheryName] = labelValue(h.Name())
+	labels[labelWorkerModel] = labelValue(spawnArgs.Model.Group.Name + "-" + spawnArgs.Model.Name)
+	if spawnArgs.JobID > 0 {
+		labels[labelJobID] = fmt.Sprintf("%d", spawnArgs.JobID)
+	}
+	return labels
+}
+
+// labelValue converts a value to a valid GCE label value: lower case letters, numbers and dashes, 63 characters max
+func labelValue(s string) string {
+	s = slug.Convert(s)
+	if len(s) > 63 {
+		s = s[:63]
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package gce
+
+import (
+	compute "google.golang.org/api/compute/v1"
+
+	hatcheryCommon "github.com/ovh/cds/engine/hatchery"
+	"github.com/ovh/cds/engine/service"
+)
+
+// Metadata items and labels set on each instance spawned by the hatchery
+const (
+	metadataStartupScript           = "startup-script"
+	metadataWorker                  = "worker"
+	metadataRegisterOnly            = "register_only"
+	metadataWorkerModelPath         = "worker_model_path"
+	metadataWorkerModelLastModified = "worker_model_last_modified"
+	labelHatcheryName               = "hatchery_name"
+	labelWorkerModel                = "worker_model"
+	labelJobID                      = "cds_job_id"
+)
+
+// HatcheryConfiguration is the configuration for hatchery
+type HatcheryConfiguration struct {
+	service.HatcheryCommonConfiguration `mapstructure:"commonConfiguration" toml:"commonConfiguration" json:"commonConfiguration"`
+
+	// Project GCP project
+	Project string `mapstructure:"project" toml:"project" default:"" commented:"false" comment:"GCP Project ID" json:"project"`
+
+	// Zone GCE zone
+	Zone string `mapstructure:"zone" toml:"zone" default:"" commented:"false" comment:"GCE Zone, ex: europe-west1-b" json:"zone"`
+
+	// CredentialsFile service account key
+	CredentialsFile string `mapstructure:"credentialsFile" toml:"credentialsFile" default:"" commented:"true" comment:"Path of a service account JSON key file. If not set, the application default credentials are used" json:"-"`
+
+	// ImageProject project of the image families
+	ImageProject string `mapstructure:"imageProject" toml:"imageProject" default:"" commented:"true" comment:"Project of the image families used by worker models, the GCP project if not set. A worker model can also use an image family of another project with 'project/family'" json:"imageProject,omitempty"`
+
+	// Network network of the instances
+	Network string `mapstructure:"network" toml:"network" default:"global/networks/default" commented:"false" comment:"Network used to spawn CDS Workers" json:"network"`
+
+	// Subnetwork subnetwork of the instances
+	Subnetwork string `mapstructure:"subnetwork" toml:"subnetwork" default:"" commented:"true" comment:"Subnetwork used to spawn CDS Workers, ex: regions/europe-west1/subnetworks/cds" json:"subnetwork,omitempty"`
+
+	// DisableExternalIP if true: instances don't get an external IP
+	DisableExternalIP bool `mapstructure:"disableExternalIP" toml:"disableExternalIP" default:"false" commented:"false" comment:"if true: spawned instances don't get an external IP, a Cloud NAT is needed to reach the CDS API" json:"disableExternalIP"`
+
+	// NetworkTags network tags of the instances
+	NetworkTags []string `mapstructure:"networkTags" toml:"networkTags" commented:"true" comment:"Network tags set on spawned instances, used by firewall rules" json:"networkTags,omitempty"`
+
+	// ServiceAccount service account of the instances
+	ServiceAccount string `mapstructure:"serviceAccount" toml:"serviceAccount" default:"" commented:"true" comment:"Email of the service account set on spawned instances" json:"serviceAccount,omitempty"`
+
+	// DiskSizeGB boot disk size
+	DiskSizeGB int64 `mapstructure:"diskSizeGB" toml:"diskSizeGB" default:"20" commented:"false" comment:"Size of the boot disk of spawned instances (GB)" json:"diskSizeGB"`
+
+	// Labels cost allocation labels
+	Labels map[string]string `mapstructure:"labels" toml:"labels" commented:"true" comment:"Labels added to all the instances, useful for billing reports. Ex: labels = { cost-center = \"cds\" }" json:"labels,omitempty"`
+
+	// WorkerTTL Worker TTL (minutes)
+	WorkerTTL int `mapstructure:"workerTTL" toml:"workerTTL" default:"30" commented:"false" comment:"Worker TTL (minutes)" json:"workerTTL"`
+}
+
+// HatcheryGCE spawns instances of worker model with type 'gce'
+// by starting Google Compute Engine instances
+type HatcheryGCE struct {
+	hatcheryCommon.Common
+	Config         HatcheryConfiguration
+	computeService *compute.Service
+
+	workersAlive map[string]int64
+}
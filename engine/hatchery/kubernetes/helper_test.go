@@ -21,6 +21,7 @@ func NewHatcheryKubernetesTest(t *testing.T) *HatcheryKubernetes {
 
 	h.k8sClient = clientSet
 	gock.InterceptClient(h.k8sClient.CoreV1().RESTClient().(*rest.RESTClient).Client)
+	gock.InterceptClient(h.k8sClient.AppsV1().RESTClient().(*rest.RESTClient).Client)
 
 	h.Config.Name = "kyubi"
 	h.Config.Namespace = "hachibi"
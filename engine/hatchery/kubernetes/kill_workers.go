@@ -2,8 +2,10 @@ package kubernetes
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/ovh/cds/sdk"
@@ -25,6 +27,14 @@ func (h *HatcheryKubernetes) killAwolWorkers(ctx context.Context) error {
 				(container.State.Waiting != nil && container.State.Waiting.Reason == "ErrImagePull") {
 				toDelete = true
 			}
+			if container.State.Terminated != nil && container.State.Terminated.Reason == "OOMKilled" {
+				h.sendWorkerKilledInfo(ctx, pod, sdk.MsgSpawnInfoWorkerOOMKilled)
+				toDelete = true
+			}
+		}
+		if pod.Status.Reason == "Evicted" {
+			h.sendWorkerKilledInfo(ctx, pod, sdk.MsgSpawnInfoWorkerEvicted)
+			toDelete = true
 		}
 		if toDelete {
 			// If its a worker "register", check registration before deleting it
@@ -55,3 +65,33 @@ func (h *HatcheryKubernetes) killAwolWorkers(ctx context.Context) error {
 	}
 	return globalErr
 }
+
+// sendWorkerKilledInfo explains on the job why its worker was killed by kubernetes
+func (h *HatcheryKubernetes) sendWorkerKilledInfo(ctx context.Context, pod apiv1.Pod, msg *sdk.Message) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+	var jobID, memory int64
+	for _, e := range pod.Spec.Containers[0].Env {
+		switch e.Name {
+		case "CDS_BOOKED_WORKFLOW_JOB_ID":
+			jobID, _ = strconv.ParseInt(e.Value, 10, 64)
+		case "CDS_MODEL_MEMORY":
+			memory, _ = strconv.ParseInt(e.Value, 10, 64)
+		}
+	}
+	if jobID == 0 {
+		return
+	}
+
+	var args []interface{}
+	if msg.ID == sdk.MsgSpawnInfoWorkerEvicted.ID {
+		log.Warning(ctx, "hatchery:kubernetes> worker %s for job %d evicted: %s", pod.Name, jobID, pod.Status.Message)
+		args = []interface{}{pod.Name, pod.Status.Message}
+	} else {
+		log.Warning(ctx, "hatchery:kubernetes> worker %s for job %d killed: out of memory", pod.Name, jobID)
+		// memory requirement is expressed in MB, kubernetes doesn't give the peak usage of a terminated container
+		args = []interface{}{pod.Name, sdk.FormatMemory(memory * 1024 * 1024), sdk.FormatMemory(0)}
+	}
+	hatchery.SendSpawnInfo(ctx, h, jobID, sdk.SpawnMsg{ID: msg.ID, Args: args})
+}
@@ -74,6 +74,53 @@ func TestHatcheryKubernetes_KillAwolWorkers(t *testing.T) {
 					},
 				},
 			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "w4",
+					Namespace: "kyubi",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Env: []v1.EnvVar{
+								{Name: "CDS_BOOKED_WORKFLOW_JOB_ID", Value: "42"},
+								{Name: "CDS_MODEL_MEMORY", Value: "2048"},
+							},
+						},
+					},
+				},
+				Status: v1.PodStatus{
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							State: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									Reason: "OOMKilled",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "w5",
+					Namespace: "kyubi",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Env: []v1.EnvVar{
+								{Name: "CDS_BOOKED_WORKFLOW_JOB_ID", Value: "43"},
+							},
+						},
+					},
+				},
+				Status: v1.PodStatus{
+					Phase:   v1.PodFailed,
+					Reason:  "Evicted",
+					Message: "The node was low on resource: memory.",
+				},
+			},
 		},
 	}
 	gock.New("http://lolcat.kube").Get("/api/v1/namespaces/hachibi/pods").Reply(http.StatusOK).JSON(podsList)
@@ -81,6 +128,11 @@ func TestHatcheryKubernetes_KillAwolWorkers(t *testing.T) {
 	gock.New("http://lolcat.kube").Delete("/api/v1/namespaces/kyubi/pods/w1").Reply(http.StatusOK).JSON(nil)
 	gock.New("http://lolcat.kube").Delete("/api/v1/namespaces/kyubi/pods/w2").Reply(http.StatusOK).JSON(nil)
 	gock.New("http://lolcat.kube").Delete("/api/v1/namespaces/kyubi/pods/w3").Reply(http.StatusOK).JSON(nil)
+	gock.New("http://lolcat.kube").Delete("/api/v1/namespaces/kyubi/pods/w4").Reply(http.StatusOK).JSON(nil)
+	gock.New("http://lolcat.kube").Delete("/api/v1/namespaces/kyubi/pods/w5").Reply(http.StatusOK).JSON(nil)
+
+	gock.New("http://lolcat.api").Post("/queue/workflows/42/spawn/infos").Reply(http.StatusOK)
+	gock.New("http://lolcat.api").Post("/queue/workflows/43/spawn/infos").Reply(http.StatusOK)
 
 	err := h.killAwolWorkers(context.TODO())
 	require.NoError(t, err)
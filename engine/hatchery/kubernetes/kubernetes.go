@@ -19,6 +19,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
@@ -46,6 +47,12 @@ func (h *HatcheryKubernetes) InitHatchery(ctx context.Context) error {
 	sdk.GoRoutine(context.Background(), "hatchery kubernetes routines", func(ctx context.Context) {
 		h.routines(ctx)
 	})
+
+	if h.Config.PrePullImages {
+		sdk.GoRoutine(context.Background(), "hatchery kubernetes prePullImages", func(ctx context.Context) {
+			h.prePullImagesRoutine(ctx)
+		})
+	}
 	return nil
 }
 
@@ -226,14 +233,11 @@ func (h *HatcheryKubernetes) WorkerModelsEnabled() ([]sdk.Model, error) {
 }
 
 // CanSpawn return wether or not hatchery can spawn model.
-// requirements are not supported
+// hostname requirement is not supported
 func (h *HatcheryKubernetes) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
-	// Service and Hostname requirement are not supported
+	// Hostname requirement is not supported, services are spawned as sidecar containers
 	for _, r := range requirements {
-		if r.Type == sdk.ServiceRequirement {
-			log.Debug("CanSpawn> Job %d has a service requirement. Kubernetes can't spawn a worker for this job", jobID)
-			return false
-		} else if r.Type == sdk.HostnameRequirement {
+		if r.Type == sdk.HostnameRequirement {
 			log.Debug("CanSpawn> Job %d has a hostname requirement. Kubernetes can't spawn a worker for this job", jobID)
 			return false
 		}
@@ -243,7 +247,7 @@ func (h *HatcheryKubernetes) CanSpawn(ctx context.Context, model *sdk.Model, job
 
 // SpawnWorker starts a new worker process
 func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) error {
-	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly {
+	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly && !spawnArgs.WarmPool {
 		return sdk.WithStack(fmt.Errorf("no job ID and no register"))
 	}
 
@@ -258,6 +262,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	}
 
 	memory := int64(h.Config.DefaultMemory)
+	var cpu *resource.Quantity
 	for _, r := range spawnArgs.Requirements {
 		if r.Type == sdk.MemoryRequirement {
 			var err error
@@ -266,6 +271,13 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 				log.Warning(ctx, "spawnKubernetesDockerWorker> %s unable to parse memory requirement %d: %v", logJob, memory, err)
 				return err
 			}
+		} else if r.Type == sdk.CPURequirement {
+			q, err := resource.ParseQuantity(r.Value)
+			if err != nil {
+				log.Warning(ctx, "spawnKubernetesDockerWorker> %s unable to parse cpu requirement %s: %v", logJob, r.Value, err)
+				return err
+			}
+			cpu = &q
 		}
 	}
 
@@ -281,6 +293,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
 		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
 		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		ToolRegistry:      h.Configuration().Provision.WorkerToolRegistry,
 	}
 
 	udataParam.WorkflowJobID = spawnArgs.JobID
@@ -313,6 +326,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	envsWm["CDS_TOOL_REGISTRY"] = udataParam.ToolRegistry
 
 	if spawnArgs.JobID > 0 {
 		envsWm["CDS_BOOKED_WORKFLOW_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
@@ -366,6 +380,26 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		},
 	}
 
+	if cpu != nil && !spawnArgs.RegisterOnly {
+		podSchema.Spec.Containers[0].Resources.Requests[apiv1.ResourceCPU] = *cpu
+		podSchema.Spec.Containers[0].Resources.Limits = apiv1.ResourceList{apiv1.ResourceCPU: *cpu}
+	}
+
+	// Multi-arch images can run on any node, the os-architecture requirement of the job gives the expected one
+	workerModelArch := spawnArgs.Model.ModelDocker.Arch
+	for _, r := range spawnArgs.Requirements {
+		if r.Type != sdk.OSArchRequirement || workerModelArch != "" {
+			continue
+		}
+		if osArch := strings.SplitN(r.Value, "/", 2); len(osArch) == 2 {
+			workerModelArch = osArch[1]
+		}
+	}
+	podSchema.Spec.NodeSelector, podSchema.Spec.Tolerations = nodeScheduling(spawnArgs.Model, workerModelArch)
+	if spawnArgs.Model.ModelDocker.GetOS() == sdk.ModelDockerOSWindows && spawnArgs.Model.ModelDocker.Isolation == sdk.ModelDockerIsolationHyperV {
+		podSchema.ObjectMeta.Annotations = map[string]string{annotationIsolationType: sdk.ModelDockerIsolationHyperV}
+	}
+
 	var services []sdk.Requirement
 	for _, req := range spawnArgs.Requirements {
 		if req.Type == sdk.ServiceRequirement {
@@ -418,6 +452,21 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 			delete(envm, "CDS_SERVICE_ARGS")
 		}
 
+		// the service is ready when its port accepts connections, the worker waits for it before running the job
+		if sp, ok := envm["CDS_SERVICE_PORT"]; ok {
+			if port, err := strconv.Atoi(sp); err != nil {
+				log.Warning(ctx, "hatchery> kubernetes> SpawnWorker> Unable to parse CDS_SERVICE_PORT value '%s': %s", sp, err)
+			} else {
+				servContainer.ReadinessProbe = &apiv1.Probe{
+					Handler: apiv1.Handler{
+						TCPSocket: &apiv1.TCPSocketAction{Port: intstr.FromInt(port)},
+					},
+					PeriodSeconds: 2,
+				}
+			}
+			delete(envm, "CDS_SERVICE_PORT")
+		}
+
 		if len(envm) > 0 {
 			servContainer.Env = make([]apiv1.EnvVar, 0, len(envm))
 			for key, val := range envm {
@@ -437,6 +486,26 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	return err
 }
 
+// nodeScheduling returns the node selector and the tolerations to schedule the pods of a worker model on the nodes
+// matching its operating system and given architecture, windows nodes are usually tainted
+func nodeScheduling(model *sdk.Model, arch string) (map[string]string, []apiv1.Toleration) {
+	workerModelOS := model.ModelDocker.GetOS()
+	nodeSelector := map[string]string{nodeSelectorOS: workerModelOS}
+	if arch != "" {
+		nodeSelector[nodeSelectorArch] = arch
+	}
+	var tolerations []apiv1.Toleration
+	if workerModelOS == sdk.ModelDockerOSWindows {
+		tolerations = []apiv1.Toleration{{
+			Key:      "os",
+			Operator: apiv1.TolerationOpEqual,
+			Value:    sdk.ModelDockerOSWindows,
+			Effect:   apiv1.TaintEffectNoSchedule,
+		}}
+	}
+	return nodeSelector, tolerations
+}
+
 // WorkersStarted returns the number of instances started but
 // not necessarily register on CDS yet
 func (h *HatcheryKubernetes) WorkersStarted(ctx context.Context) []string {
@@ -98,6 +98,11 @@ func TestHatcheryKubernetes_Status(t *testing.T) {
 		require.Equal(t, 1, len(podRequest.Spec.Containers[1].Env))
 		require.Equal(t, "PG_USERNAME", podRequest.Spec.Containers[1].Env[0].Name)
 		require.Equal(t, "toto", podRequest.Spec.Containers[1].Env[0].Value)
+		require.NotNil(t, podRequest.Spec.Containers[1].ReadinessProbe)
+		require.Equal(t, 5432, podRequest.Spec.Containers[1].ReadinessProbe.TCPSocket.Port.IntValue())
+		require.Equal(t, []string{"worker", "pg"}, podRequest.Spec.HostAliases[0].Hostnames)
+		require.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, podRequest.Spec.NodeSelector)
+		require.Empty(t, podRequest.Spec.Tolerations)
 	}
 	gock.Observe(checkRequest)
 
@@ -113,10 +118,72 @@ func TestHatcheryKubernetes_Status(t *testing.T) {
 			}, {
 				Name:  "pg",
 				Type:  sdk.ServiceRequirement,
-				Value: "postgresql:5.6.7 PG_USERNAME=toto",
+				Value: "postgresql:5.6.7 PG_USERNAME=toto CDS_SERVICE_PORT=5432",
 			},
 		},
 	})
 	require.NoError(t, err)
 	require.True(t, gock.IsDone())
 }
+
+func TestHatcheryKubernetes_SpawnWindows(t *testing.T) {
+	defer gock.Off()
+	h := NewHatcheryKubernetesTest(t)
+
+	m := &sdk.Model{
+		Name: "windows",
+		Group: &sdk.Group{
+			Name: "group",
+		},
+		ModelDocker: sdk.ModelDocker{
+			Image:     "mcr.microsoft.com/windows/servercore:ltsc2019",
+			Shell:     "powershell -Command",
+			Cmd:       ".\\worker.exe",
+			OS:        sdk.ModelDockerOSWindows,
+			Isolation: sdk.ModelDockerIsolationHyperV,
+		},
+	}
+
+	gock.New("http://lolcat.kube").Post("/api/v1/namespaces/hachibi/pods").Reply(http.StatusOK).JSON(v1.Pod{})
+
+	var podRequest v1.Pod
+	gock.Observe(func(request *http.Request, mock gock.Mock) {
+		if request.Body == nil {
+			return
+		}
+		bodyContent, err := ioutil.ReadAll(request.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bodyContent, &podRequest))
+	})
+
+	err := h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		JobID:      666,
+		Model:      m,
+		WorkerName: "k8s-windows",
+		Requirements: []sdk.Requirement{
+			{
+				Name:  "windows/amd64",
+				Type:  sdk.OSArchRequirement,
+				Value: "windows/amd64",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+
+	require.Equal(t, map[string]string{"kubernetes.io/os": "windows", "kubernetes.io/arch": "amd64"}, podRequest.Spec.NodeSelector)
+	require.Len(t, podRequest.Spec.Tolerations, 1)
+	require.Equal(t, "os", podRequest.Spec.Tolerations[0].Key)
+	require.Equal(t, "windows", podRequest.Spec.Tolerations[0].Value)
+	require.Equal(t, v1.TaintEffectNoSchedule, podRequest.Spec.Tolerations[0].Effect)
+	require.Equal(t, "hyperv", podRequest.Annotations["experimental.windows.kubernetes.io/isolation-type"])
+	require.Equal(t, []string{"powershell", "-Command"}, podRequest.Spec.Containers[0].Command)
+}
+
+func TestHatcheryKubernetes_CanSpawn(t *testing.T) {
+	h := NewHatcheryKubernetesTest(t)
+	m := &sdk.Model{Name: "model1", Group: &sdk.Group{Name: "group"}}
+
+	require.True(t, h.CanSpawn(context.TODO(), m, 666, []sdk.Requirement{{Name: "pg", Type: sdk.ServiceRequirement, Value: "postgres:9.6"}}))
+	require.False(t, h.CanSpawn(context.TODO(), m, 666, []sdk.Requirement{{Name: "host", Type: sdk.HostnameRequirement, Value: "myhost"}}))
+}
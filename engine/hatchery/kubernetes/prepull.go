@@ -0,0 +1,155 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/slug"
+)
+
+func (h *HatcheryKubernetes) prePullImagesRoutine(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if err := h.prePullImages(ctx); err != nil {
+			log.Error(ctx, "hatchery> kubernetes> prePullImages> %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// prePullImages creates a daemon set for each enabled worker model, to pull its image on all the nodes
+// ahead of the workers spawn. The daemon set is updated when the worker model is updated, so the nodes
+// pull the image again, and it is deleted when the worker model is disabled.
+func (h *HatcheryKubernetes) prePullImages(ctx context.Context) error {
+	models, err := h.WorkerModelsEnabled()
+	if err != nil {
+		return sdk.WrapError(err, "unable to get worker models")
+	}
+
+	daemonSets := make(map[string]appsv1.DaemonSet)
+	for i := range models {
+		m := &models[i]
+		if m.Type != sdk.Docker || m.ModelDocker.Image == "" {
+			continue
+		}
+		ds, err := h.prePullDaemonSet(m)
+		if err != nil {
+			log.Error(ctx, "hatchery> kubernetes> prePullImages> %v", err)
+			continue
+		}
+		daemonSets[ds.Name] = ds
+	}
+
+	existing, err := h.k8sClient.AppsV1().DaemonSets(h.Config.Namespace).List(metav1.ListOptions{LabelSelector: LABEL_PREPULL_HATCHERY + "=" + h.Config.Name})
+	if err != nil {
+		return sdk.WrapError(err, "cannot get pre-pull daemon sets")
+	}
+
+	for _, e := range existing.Items {
+		ds, has := daemonSets[e.Name]
+		if !has {
+			log.Info(ctx, "hatchery> kubernetes> prePullImages> deleting daemon set %s", e.Name)
+			if err := h.k8sClient.AppsV1().DaemonSets(h.Config.Namespace).Delete(e.Name, nil); err != nil {
+				log.Error(ctx, "hatchery> kubernetes> prePullImages> cannot delete daemon set %s: %v", e.Name, err)
+			}
+			continue
+		}
+		delete(daemonSets, e.Name)
+
+		if e.Spec.Template.Annotations[annotationWorkerModelLastModified] == ds.Spec.Template.Annotations[annotationWorkerModelLastModified] {
+			continue
+		}
+		log.Info(ctx, "hatchery> kubernetes> prePullImages> updating daemon set %s with image %s", ds.Name, ds.Spec.Template.Spec.InitContainers[0].Image)
+		ds.ResourceVersion = e.ResourceVersion
+		if _, err := h.k8sClient.AppsV1().DaemonSets(h.Config.Namespace).Update(&ds); err != nil {
+			log.Error(ctx, "hatchery> kubernetes> prePullImages> cannot update daemon set %s: %v", ds.Name, err)
+		}
+	}
+
+	for name := range daemonSets {
+		ds := daemonSets[name]
+		log.Info(ctx, "hatchery> kubernetes> prePullImages> creating daemon set %s with image %s", ds.Name, ds.Spec.Template.Spec.InitContainers[0].Image)
+		if _, err := h.k8sClient.AppsV1().DaemonSets(h.Config.Namespace).Create(&ds); err != nil {
+			log.Error(ctx, "hatchery> kubernetes> prePullImages> cannot create daemon set %s: %v", ds.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// prePullDaemonSet returns the daemon set pulling the image of a worker model: the image is pulled for an
+// init container that exits immediately, then the pods only run a pause container.
+func (h *HatcheryKubernetes) prePullDaemonSet(m *sdk.Model) (appsv1.DaemonSet, error) {
+	name := slug.Convert(fmt.Sprintf("cds-prepull-%s-%s-%s", h.Config.Name, m.Group.Name, m.Name))
+	if len(name) > 63 {
+		name = name[:63]
+	}
+
+	labels := map[string]string{LABEL_PREPULL: name}
+	podLabels := map[string]string{LABEL_PREPULL: name}
+
+	exitCommand := []string{"sh", "-c", "exit 0"}
+	if m.ModelDocker.GetOS() == sdk.ModelDockerOSWindows {
+		exitCommand = []string{"cmd", "/c", "exit 0"}
+	}
+
+	var gracePeriodSecs int64
+	podSpec := apiv1.PodSpec{
+		TerminationGracePeriodSeconds: &gracePeriodSecs,
+		InitContainers: []apiv1.Container{{
+			Name:    "prepull",
+			Image:   m.ModelDocker.Image,
+			Command: exitCommand,
+		}},
+		Containers: []apiv1.Container{{
+			Name:  "pause",
+			Image: h.Config.PrePullPauseImage,
+		}},
+	}
+	podSpec.NodeSelector, podSpec.Tolerations = nodeScheduling(m, m.ModelDocker.Arch)
+
+	if m.ModelDocker.Private {
+		secretName := "cds-credreg-" + m.Name
+		if err := h.createSecret(secretName, *m); err != nil {
+			return appsv1.DaemonSet{}, sdk.WrapError(err, "cannot create secret for model %s", m.Name)
+		}
+		podSpec.ImagePullSecrets = []apiv1.LocalObjectReference{{Name: secretName}}
+		podLabels[LABEL_SECRET] = secretName
+	}
+
+	return appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: h.Config.Namespace,
+			Labels: map[string]string{
+				LABEL_PREPULL:          name,
+				LABEL_PREPULL_HATCHERY: h.Config.Name,
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+					Annotations: map[string]string{
+						annotationWorkerModelLastModified: m.UserLastModified.UTC().Format(time.RFC3339Nano),
+					},
+				},
+				Spec: podSpec,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestHatcheryKubernetes_PrePullImages(t *testing.T) {
+	defer gock.Off()
+	h := NewHatcheryKubernetesTest(t)
+	h.Config.PrePullPauseImage = "k8s.gcr.io/pause:3.2"
+
+	lastModified := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	models := []sdk.Model{
+		{
+			ID:               1,
+			Name:             "debian",
+			Group:            &sdk.Group{Name: "shared.infra"},
+			Type:             sdk.Docker,
+			UserLastModified: lastModified,
+			ModelDocker:      sdk.ModelDocker{Image: "debian:10", Arch: "arm64"},
+		},
+		{
+			ID:               2,
+			Name:             "alpine",
+			Group:            &sdk.Group{Name: "shared.infra"},
+			Type:             sdk.Docker,
+			UserLastModified: lastModified,
+			ModelDocker:      sdk.ModelDocker{Image: "alpine:3.11"},
+		},
+	}
+	gock.New("http://lolcat.api").Get("/worker/model/enabled").Reply(http.StatusOK).JSON(models)
+
+	existing := appsv1.DaemonSetList{
+		Items: []appsv1.DaemonSet{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cds-prepull-kyubi-shared-infra-old"}},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "cds-prepull-kyubi-shared-infra-alpine", ResourceVersion: "42"},
+				Spec: appsv1.DaemonSetSpec{Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+						annotationWorkerModelLastModified: lastModified.Add(-time.Hour).Format(time.RFC3339Nano),
+					}},
+				}},
+			},
+		},
+	}
+	gock.New("http://lolcat.kube").Get("/apis/apps/v1/namespaces/hachibi/daemonsets").
+		MatchParam("labelSelector", "CDS_PREPULL_HATCHERY=kyubi").
+		Reply(http.StatusOK).JSON(existing)
+	gock.New("http://lolcat.kube").Delete("/apis/apps/v1/namespaces/hachibi/daemonsets/cds-prepull-kyubi-shared-infra-old").Reply(http.StatusOK).JSON(nil)
+	gock.New("http://lolcat.kube").Put("/apis/apps/v1/namespaces/hachibi/daemonsets/cds-prepull-kyubi-shared-infra-alpine").Reply(http.StatusOK).JSON(appsv1.DaemonSet{})
+	gock.New("http://lolcat.kube").Post("/apis/apps/v1/namespaces/hachibi/daemonsets").Reply(http.StatusOK).JSON(appsv1.DaemonSet{})
+
+	daemonSets := map[string]appsv1.DaemonSet{}
+	gock.Observe(func(request *http.Request, mock gock.Mock) {
+		if request.Body == nil || (request.Method != http.MethodPost && request.Method != http.MethodPut) {
+			return
+		}
+		bodyContent, err := ioutil.ReadAll(request.Body)
+		require.NoError(t, err)
+		var ds appsv1.DaemonSet
+		require.NoError(t, json.Unmarshal(bodyContent, &ds))
+		daemonSets[ds.Name] = ds
+	})
+
+	require.NoError(t, h.prePullImages(context.TODO()))
+	require.True(t, gock.IsDone())
+
+	require.Len(t, daemonSets, 2)
+	debian := daemonSets["cds-prepull-kyubi-shared-infra-debian"]
+	require.Equal(t, "kyubi", debian.Labels[LABEL_PREPULL_HATCHERY])
+	require.Equal(t, "debian:10", debian.Spec.Template.Spec.InitContainers[0].Image)
+	require.Equal(t, "k8s.gcr.io/pause:3.2", debian.Spec.Template.Spec.Containers[0].Image)
+	require.Equal(t, map[string]string{nodeSelectorOS: "linux", nodeSelectorArch: "arm64"}, debian.Spec.Template.Spec.NodeSelector)
+	require.Equal(t, "2020-03-01T10:00:00Z", debian.Spec.Template.Annotations[annotationWorkerModelLastModified])
+
+	alpine := daemonSets["cds-prepull-kyubi-shared-infra-alpine"]
+	require.Equal(t, "42", alpine.ResourceVersion)
+	require.Equal(t, "alpine:3.11", alpine.Spec.Template.Spec.InitContainers[0].Image)
+}
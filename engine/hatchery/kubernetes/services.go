@@ -2,9 +2,7 @@ package kubernetes
 
 import (
 	"context"
-	"fmt"
 	"strconv"
-	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -63,15 +61,8 @@ func (h *HatcheryKubernetes) getServicesLogs(ctx context.Context) error {
 		}
 	}
 
-	if len(servicesLogs) > 0 {
-		// Do call api
-		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-		if err := h.Client.QueueServiceLogs(ctx, servicesLogs); err != nil {
-			cancel()
-			return fmt.Errorf("Hatchery> Swarm> Cannot send service logs : %v", err)
-		}
-		cancel()
-	}
+	// Do call api, the logs are buffered if the api is unreachable
+	h.SendServiceLogs(context.Background(), servicesLogs)
 
 	return nil
 }
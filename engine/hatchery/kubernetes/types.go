@@ -20,6 +20,20 @@ const (
 	LABEL_SECRET         = "CDS_SECRET"
 	LABEL_WORKER_MODEL   = "CDS_WORKER_MODEL"
 	LABEL_SERVICE_JOB_ID = "CDS_SERVICE_JOB_ID"
+	// LABEL_PREPULL is set on the daemon sets which pull the images of the worker models
+	LABEL_PREPULL          = "CDS_PREPULL"
+	LABEL_PREPULL_HATCHERY = "CDS_PREPULL_HATCHERY"
+)
+
+const (
+	// nodeSelectorOS is the well-known label of the nodes operating system
+	nodeSelectorOS = "kubernetes.io/os"
+	// nodeSelectorArch is the well-known label of the nodes architecture
+	nodeSelectorArch = "kubernetes.io/arch"
+	// annotationIsolationType is the annotation to run windows pods with Hyper-V isolation
+	annotationIsolationType = "experimental.windows.kubernetes.io/isolation-type"
+	// annotationWorkerModelLastModified is the last modification date of the worker model of a pre-pull daemon set
+	annotationWorkerModelLastModified = "cds/worker-model-last-modified"
 )
 
 var containerServiceNameRegexp = regexp.MustCompile(`service-([0-9]+)-(.*)`)
@@ -49,6 +63,10 @@ type HatcheryConfiguration struct {
 	KubernetesClientCertData string `mapstructure:"clientCertData" toml:"clientCertData" default:"" commented:"true" comment:"Client certificate data (content, not path and not base64 encoded) for tls kubernetes (optional if no tls needed)" json:"-"`
 	// KubernetesKeyData Client certificate data for tls kubernetes (optional if no tls needed)
 	KubernetesClientKeyData string `mapstructure:"clientKeyData" toml:"clientKeyData" default:"" commented:"true" comment:"Client certificate data (content, not path and not base64 encoded) for tls kubernetes (optional if no tls needed)" json:"-"`
+	// PrePullImages if true: pull the images of the worker models on the nodes before the workers are spawned
+	PrePullImages bool `mapstructure:"prePullImages" toml:"prePullImages" default:"false" commented:"false" comment:"if true: hatchery creates a daemon set for each worker model to pull its image on all the nodes, the daemon set is updated when the worker model is updated" json:"prePullImages"`
+	// PrePullPauseImage image of the containers of the pre-pull daemon sets
+	PrePullPauseImage string `mapstructure:"prePullPauseImage" toml:"prePullPauseImage" default:"k8s.gcr.io/pause:3.2" commented:"true" comment:"Image started by the pre-pull daemon sets once the image of the worker model is pulled, it must be available for the os and architecture of the nodes" json:"prePullPauseImage"`
 }
 
 // HatcheryKubernetes implements HatcheryMode interface for local usage
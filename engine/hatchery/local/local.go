@@ -228,7 +228,7 @@ func (h *HatcheryLocal) CanSpawn(ctx context.Context, _ *sdk.Model, jobID int64,
 	}
 
 	for _, r := range requirements {
-		if r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement {
+		if r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement {
 			log.Debug("CanSpawn false service or memory")
 			return false
 		}
@@ -350,6 +350,10 @@ func (h *HatcheryLocal) killAwolWorkers() error {
 func (h *HatcheryLocal) checkRequirement(r sdk.Requirement) (bool, error) {
 	switch r.Type {
 	case sdk.BinaryRequirement:
+		// tools with a version are installed by the worker from the tool registry
+		if _, version := sdk.ParseBinaryRequirement(r.Value); version != "" && h.Configuration().Provision.WorkerToolRegistry != "" {
+			return true, nil
+		}
 		if _, err := exec.LookPath(r.Value); err != nil {
 			log.Debug("checkRequirement> %v not in path", r.Value)
 			// Return nil because the error contains 'Exit status X', that's what we wanted
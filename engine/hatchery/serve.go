@@ -10,6 +10,8 @@ import (
 	"net/http/pprof"
 	"os"
 	"path/filepath"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -20,11 +22,16 @@ import (
 	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/hatchery"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/logbuffer"
 )
 
 type Common struct {
 	service.Common
-	Router *api.Router
+	Router      *api.Router
+	serviceLogs struct {
+		once   sync.Once
+		buffer *logbuffer.Buffer
+	}
 }
 
 const panicDumpDir = "panic_dumps"
@@ -162,6 +169,8 @@ func (c *Common) initRouter(ctx context.Context, h hatchery.Interface) {
 	r.Handle("/mon/errors", nil, r.GET(c.getPanicDumpListHandler, api.Auth(false)))
 	r.Handle("/mon/errors/{id}", nil, r.GET(c.getPanicDumpHandler, api.Auth(false)))
 
+	r.Handle("/drain", nil, r.GET(getDrainHandler), r.POST(postDrainHandler(h)))
+
 	r.Mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 	r.Mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
 	r.Mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
@@ -234,7 +243,34 @@ func getStatusHandler(h hatchery.Interface) service.HandlerFunc {
 				return fmt.Errorf("unable to get status from %s", h.Service().Name)
 			}
 			status := srv.Status(ctx)
+			if drain := hatchery.DrainStatus(); drain.Draining {
+				status.Lines = append(status.Lines, sdk.MonitoringStatusLine{Component: "Drain", Value: fmt.Sprintf("%d running workers", drain.RunningWorkers), Status: sdk.MonitoringStatusWarn})
+			}
 			return service.WriteJSON(w, status, status.HTTPStatusCode())
 		}
 	}
 }
+
+func getDrainHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return service.WriteJSON(w, hatchery.DrainStatus(), http.StatusOK)
+	}
+}
+
+func postDrainHandler(h hatchery.Interface) service.HandlerFunc {
+	return func() service.Handler {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			timeout := h.Configuration().Provision.DrainTimeout
+			if t := r.FormValue("timeout"); t != "" {
+				var err error
+				timeout, err = strconv.Atoi(t)
+				if err != nil || timeout <= 0 {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid drain timeout %q", t)
+				}
+			}
+			log.Info(ctx, "hatchery> drain requested with a timeout of %d seconds", timeout)
+			status := hatchery.Drain(time.Duration(timeout) * time.Second)
+			return service.WriteJSON(w, status, http.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package hatchery
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+	"go.opencensus.io/stats"
+
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/logbuffer"
+)
+
+// serviceLogsBufferMaxSize is the max size of the service logs kept on disk while the API is unreachable
+const serviceLogsBufferMaxSize = 50 * 1024 * 1024
+
+// SendServiceLogs sends the logs of the services containers to the API. While the API is unreachable,
+// the logs are buffered on disk and sent again with a backoff, the logs are dropped when the buffer is full.
+func (c *Common) SendServiceLogs(ctx context.Context, servicesLogs []sdk.ServiceLog) {
+	ctx = observability.ContextWithTag(ctx,
+		observability.TagServiceName, c.Name(),
+		observability.TagServiceType, c.Type(),
+	)
+
+	buffer := c.serviceLogsBuffer(ctx)
+	if buffer == nil || buffer.Len() == 0 {
+		if len(servicesLogs) == 0 {
+			return
+		}
+		err := c.queueServiceLogs(ctx, servicesLogs)
+		if err == nil {
+			return
+		}
+		log.Error(ctx, "hatchery> cannot send service logs: %v", err)
+		if buffer == nil {
+			return
+		}
+	}
+
+	// Logs are sent in order, so the new logs are buffered while the buffer is not empty
+	for _, l := range servicesLogs {
+		entry, err := json.Marshal(l)
+		if err != nil {
+			log.Error(ctx, "hatchery> cannot marshal service logs: %v", err)
+			continue
+		}
+		pushed, err := buffer.Push(entry)
+		if err != nil {
+			log.Error(ctx, "hatchery> cannot buffer service logs: %v", err)
+		}
+		if !pushed && hatchery.GetMetrics().DroppedLogs != nil {
+			stats.Record(ctx, hatchery.GetMetrics().DroppedLogs.M(1))
+		}
+	}
+
+	if buffer.Ready(time.Now()) {
+		if err := buffer.Flush(func(entry []byte) error {
+			var l sdk.ServiceLog
+			if err := json.Unmarshal(entry, &l); err != nil {
+				log.Error(ctx, "hatchery> cannot unmarshal buffered service logs: %v", err)
+				return nil
+			}
+			return c.queueServiceLogs(ctx, []sdk.ServiceLog{l})
+		}); err != nil {
+			log.Warning(ctx, "hatchery> cannot send buffered service logs, %d entries are still buffered: %v", buffer.Len(), err)
+		}
+	}
+
+	if hatchery.GetMetrics().BufferedLogs != nil {
+		stats.Record(ctx, hatchery.GetMetrics().BufferedLogs.M(int64(buffer.Len())))
+	}
+}
+
+func (c *Common) queueServiceLogs(ctx context.Context, servicesLogs []sdk.ServiceLog) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	return c.Client.QueueServiceLogs(ctx, servicesLogs)
+}
+
+func (c *Common) serviceLogsBuffer(ctx context.Context) *logbuffer.Buffer {
+	c.serviceLogs.once.Do(func() {
+		path := filepath.Join(os.TempDir(), "cds-"+c.Name()+"-service-logs.buffer")
+		buffer, err := logbuffer.New(afero.NewOsFs(), path, serviceLogsBufferMaxSize)
+		if err != nil {
+			log.Error(ctx, "hatchery> unable to create service logs buffer, logs will be lost if the API is unreachable: %v", err)
+			return
+		}
+		c.serviceLogs.buffer = buffer
+	})
+	return c.serviceLogs.buffer
+}
@@ -51,7 +51,8 @@ func (h *HatcherySwarm) InitHatchery(ctx context.Context) error {
 		}
 		ctxDocker, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		if _, errPing := d.Ping(ctxDocker); errPing != nil {
+		ping, errPing := d.Ping(ctxDocker)
+		if errPing != nil {
 			log.Error(ctx, "hatchery> swarm> unable to ping docker host:%s", errPing)
 			return errPing
 		}
@@ -59,8 +60,10 @@ func (h *HatcherySwarm) InitHatchery(ctx context.Context) error {
 			Client:        *d,
 			MaxContainers: h.Config.MaxContainers,
 			name:          "default",
+			os:            dockerEngineOS(ping),
+			arch:          dockerEngineArch(ctxDocker, d),
 		}
-		log.Info(ctx, "hatchery> swarm> connected to default docker engine")
+		log.Info(ctx, "hatchery> swarm> connected to default docker engine (%s/%s)", h.dockerClients["default"].os, h.dockerClients["default"].arch)
 
 	} else {
 		for hostName, cfg := range h.Config.DockerEngines {
@@ -155,17 +158,19 @@ func (h *HatcherySwarm) InitHatchery(ctx context.Context) error {
 			}
 			ctxDocker, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
-			if _, errPing := d.Ping(ctxDocker); errPing != nil {
+			ping, errPing := d.Ping(ctxDocker)
+			if errPing != nil {
 				log.Error(ctx, "hatchery> swarm> unable to ping docker host:%s", errPing)
 				continue
 			}
-			log.Info(ctx, "hatchery> swarm> connected to %s (%s)", hostName, cfg.Host)
-
 			h.dockerClients[hostName] = &dockerClient{
 				Client:        *d,
 				MaxContainers: cfg.MaxContainers,
 				name:          hostName,
+				os:            dockerEngineOS(ping),
+				arch:          dockerEngineArch(ctxDocker, d),
 			}
+			log.Info(ctx, "hatchery> swarm> connected to %s (%s - %s/%s)", hostName, cfg.Host, h.dockerClients[hostName].os, h.dockerClients[hostName].arch)
 		}
 		if len(h.dockerClients) == 0 {
 			log.Error(ctx, "hatchery> swarm> no docker host available. Please check errors")
@@ -175,9 +180,32 @@ func (h *HatcherySwarm) InitHatchery(ctx context.Context) error {
 
 	sdk.GoRoutine(context.Background(), "swarm", func(ctx context.Context) { h.routines(ctx) })
 
+	if h.Config.PrePullImages {
+		h.prePulledModels = map[int64]time.Time{}
+		sdk.GoRoutine(context.Background(), "swarm prePullImages", func(ctx context.Context) { h.prePullImagesRoutine(ctx) })
+	}
+
 	return nil
 }
 
+// dockerEngineOS returns the operating system of a docker engine, old engines don't give it
+func dockerEngineOS(ping types.Ping) string {
+	if ping.OSType == "" {
+		return sdk.ModelDockerOSLinux
+	}
+	return ping.OSType
+}
+
+// dockerEngineArch returns the architecture of a docker engine host, empty if it can't be retrieved
+func dockerEngineArch(ctx context.Context, d *docker.Client) string {
+	info, err := d.Info(ctx)
+	if err != nil {
+		log.Warning(ctx, "hatchery> swarm> unable to get docker engine info: %v", err)
+		return ""
+	}
+	return sdk.GetArchName(info.Architecture)
+}
+
 // SpawnWorker start a new docker container
 // User can add option on prerequisite, as --port and --privileged
 // but only hatchery NOT 'shared.infra' can launch containers with options
@@ -185,7 +213,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 	ctx, end := observability.Span(ctx, "swarm.SpawnWorker")
 	defer end()
 
-	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly {
+	if spawnArgs.JobID == 0 && !spawnArgs.RegisterOnly && !spawnArgs.WarmPool {
 		return sdk.WithStack(fmt.Errorf("unable to spawn worker, no Job ID and no Register."))
 	}
 
@@ -201,6 +229,9 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 
 	_, next := observability.Span(ctx, "swarm.chooseDockerEngine")
 	for dname, dclient := range h.dockerClients {
+		if !dclient.canRun(spawnArgs.Model, spawnArgs.Requirements) {
+			continue
+		}
 		ctxList, cancelList := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancelList()
 
@@ -249,6 +280,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		memory = spawnArgs.Model.ModelDocker.Memory
 	}
 
+	var cpus float64
 	var network, networkAlias string
 	services := []string{}
 
@@ -261,6 +293,13 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 					log.Warning(ctx, "hatchery> swarm> SpawnWorker>Unable to parse memory requirement %d :%v", memory, err)
 					return err
 				}
+			} else if r.Type == sdk.CPURequirement {
+				var err error
+				cpus, err = strconv.ParseFloat(r.Value, 64)
+				if err != nil {
+					log.Warning(ctx, "hatchery> swarm> SpawnWorker>Unable to parse cpu requirement %s :%v", r.Value, err)
+					return err
+				}
 			} else if r.Type == sdk.ServiceRequirement {
 				//Create a network if not already created
 				if network == "" {
@@ -340,6 +379,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 	if spawnArgs.RegisterOnly {
 		spawnArgs.Model.ModelDocker.Cmd += " register"
 		memory = hatchery.MemoryRegisterContainer
+		cpus = 0
 	}
 
 	//labels are used to make container cleanup easier
@@ -368,6 +408,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		GraylogPort:       h.Config.Provision.WorkerLogsOptions.Graylog.Port,
 		GraylogExtraKey:   h.Config.Provision.WorkerLogsOptions.Graylog.ExtraKey,
 		GraylogExtraValue: h.Config.Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		ToolRegistry:      h.Config.Provision.WorkerToolRegistry,
 	}
 
 	udataParam.WorkflowJobID = spawnArgs.JobID
@@ -399,6 +440,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	envsWm["CDS_TOOL_REGISTRY"] = udataParam.ToolRegistry
 
 	if spawnArgs.JobID > 0 {
 		envsWm["CDS_BOOKED_WORKFLOW_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
@@ -428,6 +470,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		cmd:          cmds,
 		labels:       labels,
 		memory:       memory,
+		cpus:         cpus,
 		dockerOpts:   *dockerOpts,
 		entryPoint:   []string{},
 		env:          envs,
@@ -462,6 +505,10 @@ func (h *HatcherySwarm) CanSpawn(ctx context.Context, model *sdk.Model, jobID in
 		}
 	}
 	for dockerName, dockerClient := range h.dockerClients {
+		if !dockerClient.canRun(model, requirements) {
+			log.Debug("hatchery> swarm> CanSpawn> docker engine %s (%s/%s) can't run the worker of job %d", dockerName, dockerClient.os, dockerClient.arch, jobID)
+			continue
+		}
 		//List all containers to check if we can spawn a new one
 		cs, errList := h.getContainers(dockerClient, types.ContainerListOptions{All: true})
 		if errList != nil {
@@ -568,3 +568,111 @@ func TestHatcherySwarm_CanSpawnNoDockerClient(t *testing.T) {
 	assert.False(t, b)
 	assert.True(t, gock.IsDone())
 }
+
+func TestHatcherySwarm_CanSpawnWindowsModel(t *testing.T) {
+	defer gock.Off()
+	h := InitTestHatcherySwarm(t)
+	h.dockerClients["default"].MaxContainers = 2
+	m := sdk.Model{
+		ID:   1,
+		Name: "my-windows-model",
+		Group: &sdk.Group{
+			ID:   1,
+			Name: "mygroup",
+		},
+		ModelDocker: sdk.ModelDocker{
+			Image: "mcr.microsoft.com/windows/servercore:ltsc2019",
+			OS:    sdk.ModelDockerOSWindows,
+		},
+	}
+	jobID := int64(1)
+
+	// The docker engine is a linux one, no call should be done
+	b := h.CanSpawn(context.TODO(), &m, jobID, []sdk.Requirement{})
+	assert.False(t, b)
+
+	h.dockerClients["default"].os = sdk.ModelDockerOSWindows
+	gock.New("https://lolcat.host").Get("/v6.66/containers/json").Reply(http.StatusOK).JSON([]types.Container{})
+	b = h.CanSpawn(context.TODO(), &m, jobID, []sdk.Requirement{})
+	assert.True(t, b)
+	assert.True(t, gock.IsDone())
+}
+
+func TestHatcherySwarm_CanSpawnArm64Model(t *testing.T) {
+	defer gock.Off()
+	h := InitTestHatcherySwarm(t)
+	h.dockerClients["default"].MaxContainers = 2
+	h.dockerClients["default"].arch = "amd64"
+	m := sdk.Model{
+		ID:   1,
+		Name: "my-arm64-model",
+		Group: &sdk.Group{
+			ID:   1,
+			Name: "mygroup",
+		},
+		ModelDocker: sdk.ModelDocker{
+			Image: "arm64v8/debian:10",
+			Arch:  "arm64",
+		},
+	}
+	jobID := int64(1)
+
+	// The docker engine runs on amd64, no call should be done
+	b := h.CanSpawn(context.TODO(), &m, jobID, []sdk.Requirement{})
+	assert.False(t, b)
+
+	// Multi-arch image but the job requires arm64
+	m.ModelDocker.Arch = ""
+	b = h.CanSpawn(context.TODO(), &m, jobID, []sdk.Requirement{{Type: sdk.OSArchRequirement, Value: "linux/arm64"}})
+	assert.False(t, b)
+	m.ModelDocker.Arch = "arm64"
+
+	h.dockerClients["default"].arch = "arm64"
+	gock.New("https://lolcat.host").Get("/v6.66/containers/json").Reply(http.StatusOK).JSON([]types.Container{})
+	b = h.CanSpawn(context.TODO(), &m, jobID, []sdk.Requirement{})
+	assert.True(t, b)
+	assert.True(t, gock.IsDone())
+}
+
+func TestHatcherySwarm_PrePullImages(t *testing.T) {
+	defer gock.Off()
+	h := InitTestHatcherySwarm(t)
+	h.prePulledModels = map[int64]time.Time{}
+
+	lastModified := time.Now()
+	models := []sdk.Model{
+		{
+			ID:               1,
+			Name:             "my-model",
+			Group:            &sdk.Group{Name: "shared.infra"},
+			Type:             sdk.Docker,
+			UserLastModified: lastModified,
+			ModelDocker:      sdk.ModelDocker{Image: "model:9"},
+		},
+		{
+			ID:               2,
+			Name:             "my-windows-model",
+			Group:            &sdk.Group{Name: "shared.infra"},
+			Type:             sdk.Docker,
+			UserLastModified: lastModified,
+			ModelDocker:      sdk.ModelDocker{Image: "windows-model:9", OS: sdk.ModelDockerOSWindows},
+		},
+	}
+	gock.New("https://lolcat.api").Get("/worker/model/enabled").Reply(http.StatusOK).JSON(models)
+	gock.New("https://lolcat.host").Post("/v6.66/images/create").MatchParam("fromImage", "model").MatchParam("tag", "9").Reply(http.StatusOK).JSON(nil)
+
+	require.NoError(t, h.prePullImages(context.TODO()))
+	require.True(t, gock.IsDone())
+	require.True(t, lastModified.Equal(h.prePulledModels[1]))
+
+	// the image is pulled again only when the model is updated
+	gock.New("https://lolcat.api").Get("/worker/model/enabled").Reply(http.StatusOK).JSON(models)
+	require.NoError(t, h.prePullImages(context.TODO()))
+	require.True(t, gock.IsDone())
+
+	models[0].UserLastModified = lastModified.Add(time.Minute)
+	gock.New("https://lolcat.api").Get("/worker/model/enabled").Reply(http.StatusOK).JSON(models)
+	gock.New("https://lolcat.host").Post("/v6.66/images/create").MatchParam("fromImage", "model").MatchParam("tag", "9").Reply(http.StatusOK).JSON(nil)
+	require.NoError(t, h.prePullImages(context.TODO()))
+	require.True(t, gock.IsDone())
+}
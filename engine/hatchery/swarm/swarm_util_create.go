@@ -44,6 +44,7 @@ type containerArgs struct {
 	cmd, env                           []string
 	labels                             map[string]string
 	memory                             int64
+	cpus                               float64
 	dockerOpts                         dockerOpts
 	entryPoint                         strslice.StrSlice
 }
@@ -88,6 +89,13 @@ func (h *HatcherySwarm) createAndStartContainer(ctx context.Context, dockerClien
 		Memory:     cArgs.memory * 1024 * 1024, //from MB to B
 		MemorySwap: -1,
 	}
+	if cArgs.cpus > 0 {
+		hostConfig.Resources.NanoCPUs = int64(cArgs.cpus * 1e9)
+	}
+	// Windows containers can run with process or Hyper-V isolation, the default of the docker engine is used if not set
+	if spawnArgs.Model != nil && spawnArgs.Model.ModelDocker.GetOS() == sdk.ModelDockerOSWindows && spawnArgs.Model.ModelDocker.Isolation != "" {
+		hostConfig.Isolation = container.Isolation(spawnArgs.Model.ModelDocker.Isolation)
+	}
 
 	networkingConfig := &network.NetworkingConfig{
 		EndpointsConfig: map[string]*network.EndpointSettings{},
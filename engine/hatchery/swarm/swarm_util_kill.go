@@ -3,6 +3,7 @@ package swarm
 import (
 	"fmt"
 	"io/ioutil"
+	"strconv"
 	"strings"
 	"time"
 
@@ -71,6 +72,9 @@ func (h *HatcherySwarm) killAndRemove(ctx context.Context, dockerClient *dockerC
 			}
 		}
 
+		if container.State != nil && container.State.OOMKilled {
+			h.sendWorkerOOMKilledInfo(ctx, container)
+		}
 	}
 
 	if err := h.killAndRemoveContainer(ctx, dockerClient, ID); err != nil {
@@ -120,6 +124,33 @@ func (h *HatcherySwarm) killAndRemove(ctx context.Context, dockerClient *dockerC
 	return nil
 }
 
+// sendWorkerOOMKilledInfo explains on the job that its worker container was killed because it ran out of memory
+func (h *HatcherySwarm) sendWorkerOOMKilledInfo(ctx context.Context, container types.ContainerJSON) {
+	if container.Config == nil {
+		return
+	}
+	var jobID int64
+	for _, e := range container.Config.Env {
+		if strings.HasPrefix(e, "CDS_BOOKED_WORKFLOW_JOB_ID=") {
+			jobID, _ = strconv.ParseInt(strings.TrimPrefix(e, "CDS_BOOKED_WORKFLOW_JOB_ID="), 10, 64)
+		}
+	}
+	if jobID == 0 {
+		return
+	}
+	var memory int64
+	if container.HostConfig != nil {
+		memory = container.HostConfig.Memory
+	}
+	workerName := strings.TrimPrefix(container.Name, "/")
+	log.Warning(ctx, "hatchery> swarm> worker %s for job %d killed: out of memory", workerName, jobID)
+	// docker doesn't give the peak usage of a dead container
+	hatchery.SendSpawnInfo(ctx, h, jobID, sdk.SpawnMsg{
+		ID:   sdk.MsgSpawnInfoWorkerOOMKilled.ID,
+		Args: []interface{}{workerName, sdk.FormatMemory(memory), sdk.FormatMemory(0)},
+	})
+}
+
 func (h *HatcherySwarm) killAndRemoveContainer(ctx context.Context, dockerClient *dockerClient, ID string) error {
 	log.Debug("hatchery> swarm> killAndRemove> remove container %s on %s", ID, dockerClient.name)
 	ctxDocker, cancelList := context.WithTimeout(context.Background(), 20*time.Second)
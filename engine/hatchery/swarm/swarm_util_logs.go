@@ -77,13 +77,11 @@ func (h *HatcherySwarm) getServicesLogs() error {
 
 			if len(servicesLogs) > 0 {
 				// Do call api
-				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				if err := h.Client.QueueServiceLogs(ctx, servicesLogs); err != nil {
-					log.Error(ctx, "Hatchery> Swarm> Cannot send service logs : %v", err)
-				}
-				cancel()
+				h.SendServiceLogs(context.Background(), servicesLogs)
 			}
 		}
+		// Retry to send the buffered logs
+		h.SendServiceLogs(context.Background(), nil)
 	}
 	return nil
 }
@@ -65,3 +65,53 @@ func (h *HatcherySwarm) pullImage(dockerClient *dockerClient, img string, timeou
 
 	return nil
 }
+
+func (h *HatcherySwarm) prePullImagesRoutine(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if err := h.prePullImages(ctx); err != nil {
+			log.Error(ctx, "hatchery> swarm> prePullImages> %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// prePullImages pulls the images of the enabled worker models on the docker engines ahead of the workers spawn.
+// The image of a worker model is pulled again when the worker model is updated.
+func (h *HatcherySwarm) prePullImages(ctx context.Context) error {
+	models, err := h.WorkerModelsEnabled()
+	if err != nil {
+		return sdk.WrapError(err, "unable to get worker models")
+	}
+
+	for i := range models {
+		m := &models[i]
+		if m.Type != sdk.Docker || m.ModelDocker.Image == "" {
+			continue
+		}
+		if lastModified, has := h.prePulledModels[m.ID]; has && !lastModified.Before(m.UserLastModified) {
+			continue
+		}
+
+		var errPull error
+		for _, dockerClient := range h.dockerClients {
+			if !dockerClient.canRun(m, nil) {
+				continue
+			}
+			log.Info(ctx, "hatchery> swarm> prePullImages> pulling image %s of model %s/%s on %s", m.ModelDocker.Image, m.Group.Name, m.Name, dockerClient.name)
+			if err := h.pullImage(dockerClient, m.ModelDocker.Image, timeoutPullImage, *m); err != nil {
+				errPull = err
+			}
+		}
+		if errPull == nil {
+			h.prePulledModels[m.ID] = m.UserLastModified
+		}
+	}
+	return nil
+}
@@ -1,10 +1,14 @@
 package swarm
 
 import (
+	"strings"
+	"time"
+
 	docker "github.com/docker/docker/client"
 	"github.com/ovh/cds/engine/service"
 
 	hatcheryCommon "github.com/ovh/cds/engine/hatchery"
+	"github.com/ovh/cds/sdk"
 )
 
 // HatcheryConfiguration is the configuration for hatchery
@@ -29,6 +33,9 @@ type HatcheryConfiguration struct {
 	// NetworkEnableIPv6 if true: set ipv6 to true
 	NetworkEnableIPv6 bool `mapstructure:"networkEnableIPv6" toml:"networkEnableIPv6" default:"false" commented:"false" comment:"if true: hatchery creates private network between services with ipv6 enabled" json:"networkEnableIPv6"`
 
+	// PrePullImages if true: pull the images of the worker models on the docker engines before the workers are spawned
+	PrePullImages bool `mapstructure:"prePullImages" toml:"prePullImages" default:"false" commented:"false" comment:"if true: hatchery pulls the images of the worker models on all the docker engines when the worker models are created or updated, so the workers don't wait for the image pull" json:"prePullImages"`
+
 	DockerEngines map[string]DockerEngineConfiguration `mapstructure:"dockerEngines" toml:"dockerEngines" comment:"List of Docker Engines" json:"dockerEngines,omitempty"`
 }
 
@@ -37,12 +44,46 @@ type HatcherySwarm struct {
 	hatcheryCommon.Common
 	Config        HatcheryConfiguration
 	dockerClients map[string]*dockerClient
+	// prePulledModels are the last modification dates of the worker models whose image was pre-pulled, by worker model id
+	prePulledModels map[int64]time.Time
 }
 
 type dockerClient struct {
 	docker.Client
 	MaxContainers int
 	name          string
+	// os is the operating system of the docker engine, given by its ping
+	os string
+	// arch is the architecture of the docker engine host, empty if unknown
+	arch string
+}
+
+// canRun returns true if the operating system and the architecture of the docker engine match
+// the worker model ones and the os-architecture requirement of the job
+func (d *dockerClient) canRun(model *sdk.Model, requirements []sdk.Requirement) bool {
+	engineOS := d.os
+	if engineOS == "" {
+		engineOS = sdk.ModelDockerOSLinux
+	}
+	if model != nil {
+		if engineOS != model.ModelDocker.GetOS() {
+			return false
+		}
+		if model.ModelDocker.Arch != "" && d.arch != "" && d.arch != model.ModelDocker.Arch {
+			return false
+		}
+	}
+	// Multi-arch images can run on any engine, the job requirement gives the expected architecture
+	for _, r := range requirements {
+		if r.Type != sdk.OSArchRequirement {
+			continue
+		}
+		osArch := strings.SplitN(r.Value, "/", 2)
+		if len(osArch) != 2 || osArch[0] != engineOS || (d.arch != "" && osArch[1] != d.arch) {
+			return false
+		}
+	}
+	return true
 }
 
 // DockerEngineConfiguration is a configuration to be able to connect to a docker engine
@@ -0,0 +1,122 @@
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// linkedCloneSnapshotName is the name of the snapshot of the worker model vm used to create linked clones
+const linkedCloneSnapshotName = "cds-linked-clone"
+
+// linkedCloneSnapshot returns the snapshot of a worker model vm, the snapshot is created if it does not exist
+func (h *HatcheryVSphere) linkedCloneSnapshot(ctx context.Context, vm *object.VirtualMachine, modelName string) (*types.ManagedObjectReference, error) {
+	h.snapshotMu.Lock()
+	defer h.snapshotMu.Unlock()
+
+	ctxC, cancelC := context.WithTimeout(ctx, reqTimeout)
+	defer cancelC()
+	var mvm mo.VirtualMachine
+	if err := vm.Properties(ctxC, vm.Reference(), []string{"snapshot"}, &mvm); err != nil {
+		return nil, sdk.WrapError(err, "cannot get snapshots of model %s", modelName)
+	}
+	if mvm.Snapshot != nil {
+		if ref := findSnapshot(mvm.Snapshot.RootSnapshotList, linkedCloneSnapshotName); ref != nil {
+			return ref, nil
+		}
+	}
+
+	log.Info(ctx, "linkedCloneSnapshot> create snapshot of model %s", modelName)
+	task, err := vm.CreateSnapshot(ctx, linkedCloneSnapshotName, "Snapshot used by CDS to spawn workers as linked clones", false, false)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot create snapshot of model %s", modelName)
+	}
+	info, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		return nil, sdk.WrapError(err, "error on waiting result for snapshot of model %s", modelName)
+	}
+	ref, ok := info.Result.(types.ManagedObjectReference)
+	if !ok {
+		return nil, sdk.WithStack(fmt.Errorf("invalid snapshot result for model %s", modelName))
+	}
+	return &ref, nil
+}
+
+func findSnapshot(trees []types.VirtualMachineSnapshotTree, name string) *types.ManagedObjectReference {
+	for i := range trees {
+		if trees[i].Name == name {
+			return &trees[i].Snapshot
+		}
+		if ref := findSnapshot(trees[i].ChildSnapshotList, name); ref != nil {
+			return ref
+		}
+	}
+	return nil
+}
+
+// markModelOutdated renames the previous vm of an updated worker model and flags it as outdated,
+// it will be deleted by killAwolServers when its linked clones are gone
+func (h *HatcheryVSphere) markModelOutdated(ctx context.Context, s mo.VirtualMachine) error {
+	var annot annotation
+	if err := json.Unmarshal([]byte(s.Config.Annotation), &annot); err != nil {
+		return sdk.WrapError(err, "cannot unmarshal annotation of model %s", s.Name)
+	}
+	annot.Outdated = true
+	annotStr, err := json.Marshal(annot)
+	if err != nil {
+		return sdk.WrapError(err, "cannot marshal annotation of model %s", s.Name)
+	}
+
+	vm := object.NewVirtualMachine(h.vclient.Client, s.Self)
+
+	ctxTo, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	task, err := vm.Rename(ctxTo, fmt.Sprintf("%s-outdated-%s", s.Name, annot.WorkerModelLastModified))
+	if err != nil {
+		return sdk.WrapError(err, "cannot rename model %s", s.Name)
+	}
+	if _, err := task.WaitForResult(ctxTo, nil); err != nil {
+		return sdk.WrapError(err, "error on waiting result for vm renaming %s", s.Name)
+	}
+
+	ctxTo, cancel = context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	task, err = vm.Reconfigure(ctxTo, types.VirtualMachineConfigSpec{Annotation: string(annotStr)})
+	if err != nil {
+		return sdk.WrapError(err, "cannot reconfigure model %s", s.Name)
+	}
+	if _, err := task.WaitForResult(ctxTo, nil); err != nil {
+		return sdk.WrapError(err, "error on waiting result for vm reconfigure %s", s.Name)
+	}
+	return nil
+}
+
+// hasLinkedClones returns true if a worker was spawned from the worker model vm with given annotation
+func hasLinkedClones(srvs []mo.VirtualMachine, model annotation) bool {
+	for _, s := range srvs {
+		if s.Config == nil || s.Config.Annotation == "" {
+			continue
+		}
+		var annot annotation
+		if err := json.Unmarshal([]byte(s.Config.Annotation), &annot); err != nil || annot.Model {
+			continue
+		}
+		if annot.WorkerModelLastModified != model.WorkerModelLastModified {
+			continue
+		}
+		// the models created before the worker model path was stored in annotation are matched by name
+		if annot.WorkerModelName == model.WorkerModelPath || (model.WorkerModelPath == "" && strings.HasSuffix(annot.WorkerModelName, "/"+model.WorkerModelName)) {
+			return true
+		}
+	}
+	return false
+}
@@ -24,6 +24,7 @@ type annotation struct {
 	WorkerModelPath         string    `json:"worker_model_path"`
 	WorkerModelLastModified string    `json:"worker_model_last_modified"`
 	Model                   bool      `json:"model"`
+	Outdated                bool      `json:"outdated,omitempty"`
 	ToDelete                bool      `json:"to_delete"`
 	Created                 time.Time `json:"created"`
 }
@@ -60,6 +61,15 @@ func (h *HatcheryVSphere) SpawnWorker(ctx context.Context, spawnArgs hatchery.Sp
 		return sdk.WrapError(errCfg, "cannot create VM configuration")
 	}
 
+	if h.Config.LinkedClone {
+		snapshot, err := h.linkedCloneSnapshot(ctx, vm, spawnArgs.Model.Name)
+		if err != nil {
+			return err
+		}
+		cloneSpec.Snapshot = snapshot
+		cloneSpec.Location.DiskMoveType = string(types.VirtualMachineRelocateDiskMoveOptionsCreateNewChildDiskBacking)
+	}
+
 	log.Info(ctx, "Create vm to exec worker %s", spawnArgs.WorkerName)
 	defer log.Info(ctx, "Terminate to create vm for worker %s", spawnArgs.WorkerName)
 	task, errC := vm.Clone(ctx, folder, spawnArgs.WorkerName, *cloneSpec)
@@ -89,6 +99,7 @@ func (h *HatcheryVSphere) createVMModel(model sdk.Model) (*object.VirtualMachine
 		HatcheryName:            h.Name(),
 		WorkerModelLastModified: fmt.Sprintf("%d", model.UserLastModified.Unix()),
 		WorkerModelName:         model.Name,
+		WorkerModelPath:         model.Group.Name + "/" + model.Name,
 		Model:                   true,
 		Created:                 time.Now(),
 	}
@@ -133,7 +144,12 @@ func (h *HatcheryVSphere) createVMModel(model sdk.Model) (*object.VirtualMachine
 
 	modelFound, errM := h.getModelByName(ctx, model.Name)
 	if errM == nil {
-		if errD := h.deleteServer(modelFound); errD != nil {
+		if h.Config.LinkedClone {
+			// The linked clones of the previous model share its disks, it is deleted once they are all gone
+			if err := h.markModelOutdated(ctx, modelFound); err != nil {
+				log.Warning(ctx, "createVMModel> Cannot mark previous model %s as outdated : %s", model.Name, err)
+			}
+		} else if errD := h.deleteServer(modelFound); errD != nil {
 			log.Warning(ctx, "createVMModel> Cannot delete previous model %s : %s", model.Name, errD)
 		}
 	}
@@ -151,6 +167,12 @@ func (h *HatcheryVSphere) createVMModel(model sdk.Model) (*object.VirtualMachine
 		return vm, sdk.WrapError(err, "error on waiting result for vm renaming %s", model.Name)
 	}
 
+	if h.Config.LinkedClone {
+		if _, err := h.linkedCloneSnapshot(ctx, vm, model.Name); err != nil {
+			return vm, err
+		}
+	}
+
 	return vm, nil
 }
 
@@ -196,6 +218,7 @@ func (h *HatcheryVSphere) launchScriptWorker(name string, jobID int64, token str
 		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
 		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
 		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		ToolRegistry:      h.Configuration().Provision.WorkerToolRegistry,
 	}
 
 	udataParam.WorkflowJobID = jobID
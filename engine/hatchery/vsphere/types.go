@@ -1,6 +1,8 @@
 package vsphere
 
 import (
+	"sync"
+
 	"github.com/ovh/cds/engine/service"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
@@ -42,6 +44,9 @@ type HatcheryConfiguration struct {
 
 	// CreateImageTimeout max wait for create a vsphere image (in seconds)
 	CreateImageTimeout int `mapstructure:"createImageTimeout" toml:"createImageTimeout" default:"180" commented:"false" comment:"max wait for create a vsphere image (in seconds)" json:"createImageTimeout"`
+
+	// LinkedClone if true: workers are linked clones of a snapshot of the worker model vm
+	LinkedClone bool `mapstructure:"linkedClone" toml:"linkedClone" default:"false" commented:"false" comment:"if true: hatchery spawns workers as linked clones of a snapshot of the worker model vm instead of full clones, the snapshot is refreshed when the worker model is updated" json:"linkedClone"`
 }
 
 // HatcheryVSphere spawns vm
@@ -53,6 +58,8 @@ type HatcheryVSphere struct {
 	finder     *find.Finder
 	network    object.NetworkReference
 	vclient    *govmomi.Client
+	// snapshotMu prevents concurrent spawns to create several snapshots of a worker model vm
+	snapshotMu sync.Mutex
 
 	// User provided parameters
 	endpoint           string
@@ -118,7 +118,7 @@ func (h *HatcheryVSphere) CheckConfiguration(cfg interface{}) error {
 // requirements are not supported
 func (h *HatcheryVSphere) CanSpawn(ctx context.Context, model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
 	for _, r := range requirements {
-		if r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.HostnameRequirement {
+		if r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement || r.Type == sdk.HostnameRequirement {
 			return false
 		}
 	}
@@ -267,6 +267,14 @@ func (h *HatcheryVSphere) killAwolServers() {
 			continue
 		}
 
+		if annot.Model && annot.Outdated && !hasLinkedClones(srvs, annot) {
+			log.Info(context.Background(), "killAwolServers> deleting outdated model %s", s.Name)
+			if err := h.deleteServer(s); err != nil {
+				log.Warning(context.Background(), "killAwolServers> cannot delete outdated model %s", s.Name)
+			}
+			continue
+		}
+
 		if annot.ToDelete || (s.Summary.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOn && (!annot.Model || annot.RegisterOnly)) {
 			if err := h.deleteServer(s); err != nil {
 				log.Warning(context.Background(), "killAwolServers> cannot delete server %s", s.Name)
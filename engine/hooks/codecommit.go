@@ -0,0 +1,199 @@
+package hooks
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	snsTypeNotification             = "Notification"
+	snsTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+)
+
+var (
+	// snsHostRegex matches the hosts of the SNS endpoints, signing certificate and subscription URLs must be on them
+	snsHostRegex  = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+	snsHTTPClient = &http.Client{Timeout: 10 * time.Second}
+	snsCerts      sync.Map
+)
+
+func (s *Service) generatePayloadsFromCodeCommitRequest(ctx context.Context, t *sdk.TaskExecution, events []string) ([]map[string]interface{}, error) {
+	projectKey := t.Config["project"].Value
+	workflowName := t.Config["workflow"].Value
+
+	var msg SNSMessage
+	if err := json.Unmarshal(t.WebHook.RequestBody, &msg); err != nil {
+		return nil, sdk.WrapError(err, "unable to read SNS message: %s", string(t.WebHook.RequestBody))
+	}
+	if err := verifySNSMessage(msg); err != nil {
+		return nil, err
+	}
+
+	switch msg.Type {
+	case snsTypeSubscriptionConfirmation:
+		return nil, confirmSNSSubscription(ctx, msg)
+	case snsTypeNotification:
+	default:
+		log.Debug("generatePayloadsFromCodeCommitRequest> ignore SNS message of type %s", msg.Type)
+		return nil, nil
+	}
+
+	var event CodeCommitEvent
+	if err := json.Unmarshal([]byte(msg.Message), &event); err != nil {
+		return nil, sdk.WrapError(err, "unable to read codecommit event: %s", msg.Message)
+	}
+
+	var payloads []map[string]interface{}
+	for _, record := range event.Records {
+		// The SNS topic receives the events of all the repositories, keep only the ones for this hook
+		if !strings.HasSuffix(record.CustomData, "/"+t.UUID) {
+			continue
+		}
+		repo := record.EventSourceARN[strings.LastIndex(record.EventSourceARN, ":")+1:]
+		user := record.UserIdentityARN[strings.LastIndex(record.UserIdentityARN, "/")+1:]
+
+		for _, ref := range record.CodeCommit.References {
+			eventName := "updateReference"
+			if ref.Created {
+				eventName = "createReference"
+			} else if ref.Deleted {
+				eventName = "deleteReference"
+			}
+			if len(events) > 0 && !sdk.IsInArray(eventName, events) && !sdk.IsInArray("all", events) {
+				continue
+			}
+
+			if ref.Deleted {
+				if strings.HasPrefix(ref.Ref, "refs/heads/") {
+					if err := s.enqueueBranchDeletion(projectKey, workflowName, strings.TrimPrefix(ref.Ref, "refs/heads/")); err != nil {
+						return nil, sdk.WrapError(err, "cannot enqueue branch deletion")
+					}
+				}
+				continue
+			}
+
+			payload := make(map[string]interface{})
+			payload[GIT_EVENT] = eventName
+			if strings.HasPrefix(ref.Ref, "refs/tags/") {
+				payload[GIT_TAG] = strings.TrimPrefix(ref.Ref, "refs/tags/")
+			} else {
+				branch := strings.TrimPrefix(ref.Ref, "refs/heads/")
+				payload[GIT_BRANCH] = branch
+				if err := s.stopBranchDeletionTask(ctx, branch); err != nil {
+					log.Error(ctx, "cannot stop branch deletion task for branch %s : %v", branch, err)
+				}
+			}
+			payload[GIT_HASH] = ref.Commit
+			hashShort := ref.Commit
+			if len(hashShort) >= 7 {
+				hashShort = hashShort[:7]
+			}
+			payload[GIT_HASH_SHORT] = hashShort
+			payload[GIT_REPOSITORY] = repo
+			payload[GIT_AUTHOR] = user
+			payload[CDS_TRIGGERED_BY_USERNAME] = user
+			getPayloadStringVariable(ctx, payload, record)
+			payloads = append(payloads, payload)
+		}
+	}
+	return payloads, nil
+}
+
+// confirmSNSSubscription visits the subscribe URL sent by SNS when the hook URL was subscribed to the topic
+func confirmSNSSubscription(ctx context.Context, msg SNSMessage) error {
+	u, err := url.Parse(msg.SubscribeURL)
+	if err != nil || u.Scheme != "https" || !snsHostRegex.MatchString(u.Hostname()) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid SNS subscribe URL %s", msg.SubscribeURL)
+	}
+	resp, err := snsHTTPClient.Get(u.String())
+	if err != nil {
+		return sdk.WrapError(err, "cannot confirm SNS subscription to %s", msg.TopicArn)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("cannot confirm SNS subscription to %s: HTTP %d", msg.TopicArn, resp.StatusCode)
+	}
+	log.Info(ctx, "hooks> SNS subscription to %s confirmed", msg.TopicArn)
+	return nil
+}
+
+// verifySNSMessage checks the signature of a SNS message with the certificate of AWS
+// https://docs.aws.amazon.com/sns/latest/dg/sns-verify-signature-of-message.html
+func verifySNSMessage(msg SNSMessage) error {
+	u, err := url.Parse(msg.SigningCertURL)
+	if err != nil || u.Scheme != "https" || !snsHostRegex.MatchString(u.Hostname()) {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "invalid SNS signing certificate URL %s", msg.SigningCertURL)
+	}
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "invalid SNS signature")
+	}
+	cert, err := getSNSCertificate(msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	algo := x509.SHA1WithRSA
+	if msg.SignatureVersion == "2" {
+		algo = x509.SHA256WithRSA
+	}
+	if err := cert.CheckSignature(algo, []byte(snsStringToSign(msg)), signature); err != nil {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "invalid SNS signature: %v", err)
+	}
+	return nil
+}
+
+func snsStringToSign(msg SNSMessage) string {
+	var fields []string
+	if msg.Type == snsTypeNotification {
+		fields = []string{"Message", msg.Message, "MessageId", msg.MessageID}
+		if msg.Subject != "" {
+			fields = append(fields, "Subject", msg.Subject)
+		}
+		fields = append(fields, "Timestamp", msg.Timestamp, "TopicArn", msg.TopicArn, "Type", msg.Type)
+	} else {
+		fields = []string{"Message", msg.Message, "MessageId", msg.MessageID, "SubscribeURL", msg.SubscribeURL,
+			"Timestamp", msg.Timestamp, "Token", msg.Token, "TopicArn", msg.TopicArn, "Type", msg.Type}
+	}
+	return strings.Join(fields, "\n") + "\n"
+}
+
+// getSNSCertificate downloads the certificate used to sign SNS messages, certificates are kept in memory
+var getSNSCertificate = func(certURL string) (*x509.Certificate, error) {
+	if cert, ok := snsCerts.Load(certURL); ok {
+		return cert.(*x509.Certificate), nil
+	}
+	resp, err := snsHTTPClient.Get(certURL)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get SNS signing certificate")
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot read SNS signing certificate")
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("invalid SNS signing certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, sdk.WrapError(err, "invalid SNS signing certificate")
+	}
+	snsCerts.Store(certURL, cert)
+	return cert, nil
+}
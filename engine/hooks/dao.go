@@ -70,7 +70,20 @@ func (d *dao) DeleteTask(ctx context.Context, r *sdk.Task) error {
 			return err
 		}
 	}
-	return nil
+	return d.store.Delete(cache.Key(missedRootKey, r.UUID))
+}
+
+func (d *dao) FindMissedSchedules(ctx context.Context, uuid string) ([]sdk.MissedSchedule, error) {
+	key := cache.Key(missedRootKey, uuid)
+	var ms []sdk.MissedSchedule
+	if _, err := d.store.Get(key, &ms); err != nil {
+		return nil, sdk.WrapError(err, "unable to get from cache %s", key)
+	}
+	return ms, nil
+}
+
+func (d *dao) SaveMissedSchedules(uuid string, ms []sdk.MissedSchedule) error {
+	return d.store.Set(cache.Key(missedRootKey, uuid), ms)
 }
 
 func (d *dao) SaveTaskExecution(r *sdk.TaskExecution) error {
@@ -0,0 +1,82 @@
+package hooks
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// changedFilesKey is a payload key used to give the files changed by a push event to the filters, it is never sent to the API
+const changedFilesKey = "__changed_files"
+
+// setChangedFiles stores in the payload the files changed by the commits of a push event
+func setChangedFiles(payload map[string]interface{}, files []string) {
+	payload[changedFilesKey] = files
+}
+
+// matchRepositoryWebHookFilters returns true if the payload matches the branch, tag and path filters of a repository webhook.
+// Tags are not concerned by branch filters, path filters are ignored if the repository manager doesn't send the changed files.
+func matchRepositoryWebHookFilters(config sdk.WorkflowNodeHookConfig, payload map[string]interface{}) bool {
+	files, hasFiles := payload[changedFilesKey].([]string)
+	delete(payload, changedFilesKey)
+
+	if tag, ok := payload[GIT_TAG].(string); ok && tag != "" {
+		if !sdk.MatchHookGlobPatterns(config[sdk.RepositoryWebHookModelTags].Value, tag) {
+			return false
+		}
+		if !sdk.MatchHookSemverRange(config[sdk.RepositoryWebHookModelSemver].Value, config[sdk.RepositoryWebHookModelSemverPrerelease].Value == "true", tag) {
+			return false
+		}
+	}
+
+	if branch, ok := payload[GIT_BRANCH].(string); ok && branch != "" {
+		if !sdk.MatchHookGlobPatterns(config[sdk.RepositoryWebHookModelBranches].Value, branch) {
+			return false
+		}
+		if excluded := config[sdk.RepositoryWebHookModelExcludedBranches].Value; excluded != "" && sdk.MatchHookGlobPatterns(excluded, branch) {
+			return false
+		}
+	}
+
+	paths := config[sdk.RepositoryWebHookModelPaths].Value
+	excludedPaths := config[sdk.RepositoryWebHookModelExcludedPaths].Value
+	if !hasFiles || (paths == "" && excludedPaths == "") {
+		return true
+	}
+	for _, f := range files {
+		if (paths == "" || sdk.MatchPathGlobPatterns(paths, f)) && !sdk.MatchPathGlobPatterns(excludedPaths, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// tagSemverVariables returns the components of a tag that is a semantic version, or nil for other tags
+func tagSemverVariables(tag string) map[string]string {
+	v, err := sdk.ParseHookTagSemver(tag)
+	if err != nil {
+		return nil
+	}
+	pre := make([]string, len(v.Pre))
+	for i := range v.Pre {
+		pre[i] = v.Pre[i].String()
+	}
+	return map[string]string{
+		GIT_TAG_MAJOR:      strconv.FormatUint(v.Major, 10),
+		GIT_TAG_MINOR:      strconv.FormatUint(v.Minor, 10),
+		GIT_TAG_PATCH:      strconv.FormatUint(v.Patch, 10),
+		GIT_TAG_PRERELEASE: strings.Join(pre, "."),
+		GIT_TAG_BUILD:      strings.Join(v.Build, "."),
+	}
+}
+
+func interfacesToStrings(values []interface{}) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
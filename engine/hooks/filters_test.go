@@ -0,0 +1,71 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_matchRepositoryWebHookFilters(t *testing.T) {
+	config := sdk.WorkflowNodeHookConfig{
+		sdk.RepositoryWebHookModelBranches:         sdk.WorkflowNodeHookConfigValue{Value: "master,release/*"},
+		sdk.RepositoryWebHookModelExcludedBranches: sdk.WorkflowNodeHookConfigValue{Value: "release/old-*"},
+		sdk.RepositoryWebHookModelPaths:            sdk.WorkflowNodeHookConfigValue{Value: "services/api/**,go.mod"},
+		sdk.RepositoryWebHookModelExcludedPaths:    sdk.WorkflowNodeHookConfigValue{Value: "**/*.md"},
+	}
+	assert.NoError(t, sdk.CheckRepositoryWebHookConfig(config))
+
+	tests := []struct {
+		name    string
+		payload map[string]interface{}
+		want    bool
+	}{
+		{"matching branch and path", map[string]interface{}{GIT_BRANCH: "master", changedFilesKey: []string{"services/api/main.go"}}, true},
+		{"matching root file", map[string]interface{}{GIT_BRANCH: "release/1.0", changedFilesKey: []string{"go.mod"}}, true},
+		{"excluded branch", map[string]interface{}{GIT_BRANCH: "release/old-1", changedFilesKey: []string{"services/api/main.go"}}, false},
+		{"not included branch", map[string]interface{}{GIT_BRANCH: "feat/my-feature", changedFilesKey: []string{"services/api/main.go"}}, false},
+		{"other service", map[string]interface{}{GIT_BRANCH: "master", changedFilesKey: []string{"services/ui/main.go"}}, false},
+		{"excluded path", map[string]interface{}{GIT_BRANCH: "master", changedFilesKey: []string{"services/api/README.md"}}, false},
+		{"tags are not filtered by branches", map[string]interface{}{GIT_TAG: "v1.0.0"}, true},
+		{"no changed files sent", map[string]interface{}{GIT_BRANCH: "master"}, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchRepositoryWebHookFilters(config, tt.payload), tt.name)
+		_, has := tt.payload[changedFilesKey]
+		assert.False(t, has, tt.name)
+	}
+
+	// Without filter all the events are kept
+	assert.True(t, matchRepositoryWebHookFilters(sdk.WorkflowNodeHookConfig{}, map[string]interface{}{GIT_BRANCH: "feat/my-feature", changedFilesKey: []string{"README.md"}}))
+}
+
+func Test_matchRepositoryWebHookFiltersSemver(t *testing.T) {
+	config := sdk.WorkflowNodeHookConfig{
+		sdk.RepositoryWebHookModelTags:   sdk.WorkflowNodeHookConfigValue{Value: "v*.*.*"},
+		sdk.RepositoryWebHookModelSemver: sdk.WorkflowNodeHookConfigValue{Value: ">=1.2.0 <2.0.0"},
+	}
+	assert.NoError(t, sdk.CheckRepositoryWebHookConfig(config))
+
+	assert.True(t, matchRepositoryWebHookFilters(config, map[string]interface{}{GIT_TAG: "v1.4.2"}))
+	assert.False(t, matchRepositoryWebHookFilters(config, map[string]interface{}{GIT_TAG: "v2.0.0"}))
+	assert.False(t, matchRepositoryWebHookFilters(config, map[string]interface{}{GIT_TAG: "1.4.2"}))
+	assert.False(t, matchRepositoryWebHookFilters(config, map[string]interface{}{GIT_TAG: "v1.5.0-rc.1"}))
+	assert.True(t, matchRepositoryWebHookFilters(config, map[string]interface{}{GIT_BRANCH: "master"}))
+
+	config[sdk.RepositoryWebHookModelSemverPrerelease] = sdk.WorkflowNodeHookConfigValue{Value: "true"}
+	assert.True(t, matchRepositoryWebHookFilters(config, map[string]interface{}{GIT_TAG: "v1.5.0-rc.1"}))
+
+	config[sdk.RepositoryWebHookModelSemver] = sdk.WorkflowNodeHookConfigValue{Value: "~1.0"}
+	assert.Error(t, sdk.CheckRepositoryWebHookConfig(config))
+
+	assert.Equal(t, map[string]string{
+		GIT_TAG_MAJOR:      "1",
+		GIT_TAG_MINOR:      "5",
+		GIT_TAG_PATCH:      "0",
+		GIT_TAG_PRERELEASE: "rc.1",
+		GIT_TAG_BUILD:      "",
+	}, tagSemverVariables("v1.5.0-rc.1"))
+	assert.Nil(t, tagSemverVariables("nightly"))
+}
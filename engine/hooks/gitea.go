@@ -0,0 +1,98 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (s *Service) generatePayloadFromGiteaRequest(ctx context.Context, t *sdk.TaskExecution, event string) (map[string]interface{}, error) {
+	projectKey := t.Config["project"].Value
+	workflowName := t.Config["workflow"].Value
+
+	var request GiteaEvent
+	if err := json.Unmarshal(t.WebHook.RequestBody, &request); err != nil {
+		return nil, sdk.WrapError(err, "unable ro read gitea request: %s", string(t.WebHook.RequestBody))
+	}
+
+	// Branch deletion is sent as a delete event with the short name of the branch
+	if event == "delete" {
+		if request.RefType != "branch" {
+			return nil, nil
+		}
+		err := s.enqueueBranchDeletion(projectKey, workflowName, strings.TrimPrefix(request.Ref, "refs/heads/"))
+		return nil, sdk.WrapError(err, "cannot enqueue branch deletion")
+	}
+	// Some versions also send a push event with 0000000000000000000000000000000000000000 as git hash
+	if request.After == "0000000000000000000000000000000000000000" {
+		if strings.HasPrefix(request.Ref, "refs/tags/") {
+			return nil, nil
+		}
+		err := s.enqueueBranchDeletion(projectKey, workflowName, strings.TrimPrefix(request.Ref, "refs/heads/"))
+		return nil, sdk.WrapError(err, "cannot enqueue branch deletion")
+	}
+
+	payload := make(map[string]interface{})
+	payload[GIT_EVENT] = event
+
+	if request.Ref != "" {
+		if !strings.HasPrefix(request.Ref, "refs/tags/") {
+			branch := strings.TrimPrefix(request.Ref, "refs/heads/")
+			payload[GIT_BRANCH] = branch
+			if err := s.stopBranchDeletionTask(ctx, branch); err != nil {
+				log.Error(ctx, "cannot stop branch deletion task for branch %s : %v", branch, err)
+			}
+		} else {
+			payload[GIT_TAG] = strings.TrimPrefix(request.Ref, "refs/tags/")
+		}
+	}
+	if request.Before != "" {
+		payload[GIT_HASH_BEFORE] = request.Before
+	}
+	if request.After != "" {
+		payload[GIT_HASH] = request.After
+		hashShort := request.After
+		if len(hashShort) >= 7 {
+			hashShort = hashShort[:7]
+		}
+		payload[GIT_HASH_SHORT] = hashShort
+	}
+
+	if request.Repository != nil {
+		payload[GIT_REPOSITORY] = request.Repository.FullName
+	}
+	getPayloadFromGiteaUser(payload, request.Pusher)
+	if request.HeadCommit != nil {
+		payload[GIT_AUTHOR] = request.HeadCommit.Author.Username
+		payload[GIT_AUTHOR_EMAIL] = request.HeadCommit.Author.Email
+		payload[GIT_MESSAGE] = request.HeadCommit.Message
+	} else if len(request.Commits) > 0 {
+		payload[GIT_MESSAGE] = request.Commits[0].Message
+	}
+	if len(request.Commits) > 0 {
+		var files []string
+		for _, c := range request.Commits {
+			files = append(files, c.Added...)
+			files = append(files, c.Removed...)
+			files = append(files, c.Modified...)
+		}
+		setChangedFiles(payload, files)
+	}
+	getPayloadStringVariable(ctx, payload, request)
+
+	return payload, nil
+}
+
+func getPayloadFromGiteaUser(payload map[string]interface{}, user *GiteaUser) {
+	if user == nil {
+		return
+	}
+	payload[GIT_AUTHOR] = user.Login
+	payload[GIT_AUTHOR_EMAIL] = user.Email
+	payload[CDS_TRIGGERED_BY_USERNAME] = user.Login
+	payload[CDS_TRIGGERED_BY_FULLNAME] = user.FullName
+	payload[CDS_TRIGGERED_BY_EMAIL] = user.Email
+}
@@ -56,6 +56,16 @@ func (s *Service) generatePayloadFromGithubRequest(ctx context.Context, t *sdk.T
 		payload[GIT_MESSAGE] = request.Commits[0].Message
 	}
 
+	if len(request.Commits) > 0 {
+		var files []string
+		for _, c := range request.Commits {
+			files = append(files, interfacesToStrings(c.Added)...)
+			files = append(files, interfacesToStrings(c.Removed)...)
+			files = append(files, c.Modified...)
+		}
+		setChangedFiles(payload, files)
+	}
+
 	for i := range request.Commits {
 		request.Commits[i].Added = nil
 		request.Commits[i].Removed = nil
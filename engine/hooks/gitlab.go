@@ -69,6 +69,14 @@ func getPayloadFromGitlabCommit(payload map[string]interface{}, commits []Gitlab
 		return
 	}
 	payload[GIT_MESSAGE] = commits[0].Message
+
+	var files []string
+	for _, c := range commits {
+		files = append(files, c.Added...)
+		files = append(files, c.Modified...)
+		files = append(files, interfacesToStrings(c.Removed)...)
+	}
+	setChangedFiles(payload, files)
 }
 
 func getPayloadFromGitlabProject(payload map[string]interface{}, project *GitlabProject) {
@@ -81,9 +81,9 @@ func (s *Service) Serve(c context.Context) error {
 
 	//Init the cache
 	var errCache error
-	s.Cache, errCache = cache.New(s.Cfg.Cache.Redis.Host, s.Cfg.Cache.Redis.Password, s.Cfg.Cache.TTL)
+	s.Cache, errCache = cache.NewFromConfiguration(ctx, s.Cfg.Cache)
 	if errCache != nil {
-		return fmt.Errorf("Cannot connect to redis instance : %v", errCache)
+		return fmt.Errorf("Cannot connect to cache store : %v", errCache)
 	}
 
 	//Init the DAO
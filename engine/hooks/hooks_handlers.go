@@ -327,6 +327,57 @@ func (s *Service) getTaskExecutionsHandler() service.Handler {
 	}
 }
 
+func (s *Service) getTaskMissedSchedulesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		//Get the UUID of the task from the URL
+		vars := mux.Vars(r)
+		uuid := vars["uuid"]
+
+		//Load the task
+		t := s.Dao.FindTask(ctx, uuid)
+		if t == nil {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		ms, err := s.Dao.FindMissedSchedules(ctx, t.UUID)
+		if err != nil {
+			return err
+		}
+		if ms == nil {
+			ms = []sdk.MissedSchedule{}
+		}
+
+		return service.WriteJSON(w, ms, http.StatusOK)
+	}
+}
+
+func (s *Service) getMissedSchedulesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		tasks, err := s.Dao.FindAllTasks(ctx)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+
+		ms := []sdk.MissedSchedule{}
+		for _, t := range tasks {
+			if t.Type != TypeScheduler {
+				continue
+			}
+			res, err := s.Dao.FindMissedSchedules(ctx, t.UUID)
+			if err != nil {
+				return err
+			}
+			ms = append(ms, res...)
+		}
+
+		sort.Slice(ms, func(i, j int) bool {
+			return ms[i].Date.After(ms[j].Date)
+		})
+
+		return service.WriteJSON(w, ms, http.StatusOK)
+	}
+}
+
 func (s *Service) deleteAllTaskExecutionsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		//Get the UUID of the task from the URL
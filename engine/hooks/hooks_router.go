@@ -12,7 +12,8 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Background = ctx
 	r.URL = s.Cfg.URL
 	r.SetHeaderFunc = api.DefaultHeaders
-	r.Middlewares = append(r.Middlewares, service.CheckRequestSignatureMiddleware(s.ParsedAPIPublicKey))
+	r.Middlewares = append(r.Middlewares, service.CheckRequestSignatureMiddleware(s.ParsedAPIPublicKey), api.TracingMiddlewareFunc(s, nil, nil))
+	r.PostMiddlewares = append(r.PostMiddlewares, api.TracingPostMiddleware)
 
 	r.Handle("/admin/maintenance", nil, r.POST(s.postMaintenanceHandler))
 	r.Handle("/mon/version", nil, r.GET(api.VersionHandler, api.Auth(false)))
@@ -25,10 +26,15 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/task/bulk/stop", nil, r.GET(s.stopTasksHandler))
 	r.Handle("/task/bulk", nil, r.POST(s.postTaskBulkHandler), r.DELETE(s.deleteTaskBulkHandler))
 	r.Handle("/task/execute", nil, r.POST(s.postAndExecuteTaskHandler))
+	r.Handle("/task/missed", nil, r.GET(s.getMissedSchedulesHandler))
 	r.Handle("/task/{uuid}", nil, r.GET(s.getTaskHandler), r.PUT(s.putTaskHandler), r.DELETE(s.deleteTaskHandler))
 	r.Handle("/task/{uuid}/start", nil, r.GET(s.startTaskHandler))
 	r.Handle("/task/{uuid}/stop", nil, r.GET(s.stopTaskHandler))
+	r.Handle("/task/{uuid}/missed", nil, r.GET(s.getTaskMissedSchedulesHandler))
 	r.Handle("/task/{uuid}/execution", nil, r.GET(s.getTaskExecutionsHandler), r.DELETE(s.deleteAllTaskExecutionsHandler))
 	r.Handle("/task/{uuid}/execution/{timestamp}", nil, r.GET(s.getTaskExecutionHandler))
 	r.Handle("/task/{uuid}/execution/{timestamp}/stop", nil, r.POST(s.postStopTaskExecutionHandler))
+	r.Handle("/scheduled-run", nil, r.POST(s.postScheduledRunHandler))
+	r.Handle("/scheduled-run/{key}/{workflow}", nil, r.GET(s.getScheduledRunsHandler))
+	r.Handle("/scheduled-run/{key}/{workflow}/{uuid}", nil, r.DELETE(s.deleteScheduledRunHandler))
 }
@@ -38,11 +38,39 @@ func fillPayload(ctx context.Context, pushEvent sdk.VCSPushEvent) map[string]str
 
 	if strings.HasPrefix(pushEvent.Branch.DisplayID, "refs/tags/") {
 		payload["git.tag"] = strings.TrimPrefix(pushEvent.Branch.DisplayID, "refs/tags/")
+		for k, v := range tagSemverVariables(payload["git.tag"]) {
+			payload[k] = v
+		}
 	}
 
 	return payload
 }
 
+// pollerEventMatches returns true if the branch or the tag of given event matches the filters of the poller
+func pollerEventMatches(config sdk.WorkflowNodeHookConfig, e sdk.VCSPushEvent) bool {
+	if strings.HasPrefix(e.Branch.DisplayID, "refs/tags/") {
+		tag := strings.TrimPrefix(e.Branch.DisplayID, "refs/tags/")
+		return sdk.MatchHookGlobPatterns(config[sdk.GitPollerModelTags].Value, tag) &&
+			sdk.MatchHookSemverRange(config[sdk.GitPollerModelSemver].Value, config[sdk.GitPollerModelSemverPrerelease].Value == "true", tag)
+	}
+	return sdk.MatchHookGlobPatterns(config[sdk.GitPollerModelBranches].Value, strings.TrimPrefix(e.Branch.DisplayID, "refs/heads/"))
+}
+
+func filterPollerEvents(config sdk.WorkflowNodeHookConfig, events sdk.RepositoryEvents) sdk.RepositoryEvents {
+	var res sdk.RepositoryEvents
+	for _, e := range events.PushEvents {
+		if pollerEventMatches(config, e) {
+			res.PushEvents = append(res.PushEvents, e)
+		}
+	}
+	for _, e := range events.PullRequestEvents {
+		if pollerEventMatches(config, e.Head) {
+			res.PullRequestEvents = append(res.PullRequestEvents, e)
+		}
+	}
+	return res
+}
+
 func (s *Service) doPollerTaskExecution(ctx context.Context, task *sdk.Task, taskExec *sdk.TaskExecution) ([]sdk.WorkflowNodeRunHookEvent, error) {
 	log.Debug("Hooks> Processing polling task %s:%d", taskExec.UUID, taskExec.Timestamp)
 
@@ -92,6 +120,8 @@ func (s *Service) doPollerTaskExecution(ctx context.Context, task *sdk.Task, tas
 		payloadValues["payload"] = string(payload.Value)
 	}
 
+	events = filterPollerEvents(taskExec.Config, events)
+
 	var hookEvents []sdk.WorkflowNodeRunHookEvent
 	if len(events.PushEvents) > 0 || len(events.PullRequestEvents) > 0 {
 		i := 0
@@ -115,6 +145,13 @@ func (s *Service) doPollerTaskExecution(ctx context.Context, task *sdk.Task, tas
 		}
 	}
 
+	// The configured interval can only slow down the polling, the repositories manager gives the minimal one
+	if d, err := sdk.GitPollerInterval(taskExec.Config); err != nil {
+		log.Error(ctx, "Hooks> doPollerTaskExecution> %v", err)
+	} else if d > interval {
+		interval = d
+	}
+
 	nextExec := fmt.Sprint(time.Now().Add(interval).Unix())
 	taskExec.Config["next_execution"] = sdk.WorkflowNodeHookConfigValue{
 		Configurable: false,
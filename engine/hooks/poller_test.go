@@ -0,0 +1,55 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_filterPollerEvents(t *testing.T) {
+	config := sdk.WorkflowNodeHookConfig{
+		sdk.GitPollerModelInterval: sdk.WorkflowNodeHookConfigValue{Value: "5m"},
+		sdk.GitPollerModelBranches: sdk.WorkflowNodeHookConfigValue{Value: "master, release/*"},
+		sdk.GitPollerModelTags:     sdk.WorkflowNodeHookConfigValue{Value: "v*"},
+	}
+	require.NoError(t, sdk.CheckGitPollerConfig(config))
+	d, err := sdk.GitPollerInterval(config)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, d)
+
+	push := func(ref string) sdk.VCSPushEvent {
+		return sdk.VCSPushEvent{Branch: sdk.VCSBranch{DisplayID: ref}}
+	}
+	events := sdk.RepositoryEvents{
+		PushEvents: []sdk.VCSPushEvent{
+			push("master"),
+			push("refs/heads/release/1.0"),
+			push("feat/my-feature"),
+			push("refs/tags/v1.0.0"),
+			push("refs/tags/nightly"),
+		},
+		PullRequestEvents: []sdk.VCSPullRequestEvent{
+			{Head: push("release/2.0")},
+			{Head: push("feat/other")},
+		},
+	}
+	res := filterPollerEvents(config, events)
+	require.Len(t, res.PushEvents, 3)
+	assert.Equal(t, "master", res.PushEvents[0].Branch.DisplayID)
+	assert.Equal(t, "refs/heads/release/1.0", res.PushEvents[1].Branch.DisplayID)
+	assert.Equal(t, "refs/tags/v1.0.0", res.PushEvents[2].Branch.DisplayID)
+	require.Len(t, res.PullRequestEvents, 1)
+	assert.Equal(t, "release/2.0", res.PullRequestEvents[0].Head.Branch.DisplayID)
+
+	// Without filter all the events are kept
+	res = filterPollerEvents(sdk.WorkflowNodeHookConfig{}, events)
+	assert.Len(t, res.PushEvents, 5)
+	assert.Len(t, res.PullRequestEvents, 2)
+
+	config[sdk.GitPollerModelInterval] = sdk.WorkflowNodeHookConfigValue{Value: "10s"}
+	assert.Error(t, sdk.CheckGitPollerConfig(config))
+}
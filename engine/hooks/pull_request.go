@@ -0,0 +1,262 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	dump "github.com/fsamin/go-dump"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// pullRequestEvent is a pull request event normalized from the repository manager payloads
+type pullRequestEvent struct {
+	Action       string
+	ID           int64
+	Title        string
+	URL          string
+	State        string
+	Labels       []string
+	AddedLabels  []string
+	SourceBranch string
+	TargetBranch string
+	Hash         string
+	Repository   string
+	Author       string
+	AuthorEmail  string
+}
+
+func (s *Service) executePullRequestWebHook(ctx context.Context, t *sdk.TaskExecution) ([]sdk.WorkflowNodeRunHookEvent, error) {
+	var header string
+	var event *pullRequestEvent
+	var err error
+
+	// Gitea and Forgejo also send the Github header, so check the Gitea one first
+	if v, ok := t.WebHook.RequestHeader[GiteaHeader]; ok {
+		header = v[0]
+		if strings.HasPrefix(header, "pull_request") {
+			event, err = parseGithubPullRequestEvent(t.WebHook.RequestBody)
+		}
+	} else if v, ok := t.WebHook.RequestHeader[GithubHeader]; ok {
+		header = v[0]
+		if header == "pull_request" {
+			event, err = parseGithubPullRequestEvent(t.WebHook.RequestBody)
+		}
+	} else if v, ok := t.WebHook.RequestHeader[GitlabHeader]; ok {
+		header = v[0]
+		if header == "Merge Request Hook" {
+			event, err = parseGitlabMergeRequestEvent(t.WebHook.RequestBody)
+		}
+	} else {
+		log.Warning(ctx, "executePullRequestWebHook> Repository manager not found. Cannot read %s", string(t.WebHook.RequestBody))
+		return nil, fmt.Errorf("Repository manager not found. Cannot read request body")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		log.Debug("executePullRequestWebHook> event %s ignored on hook %s", header, t.UUID)
+		return nil, nil
+	}
+
+	if !matchPullRequestHookFilters(t.Config, *event) {
+		log.Info(ctx, "executePullRequestWebHook> pull request %d event %s on hook %s filtered by actions or labels", event.ID, event.Action, t.UUID)
+		return nil, nil
+	}
+
+	payload := event.payload()
+	payload[GIT_EVENT] = header
+	payload[PAYLOAD] = string(t.WebHook.RequestBody)
+
+	d := dump.NewDefaultEncoder()
+	d.ExtraFields.Type = false
+	d.ExtraFields.Len = false
+	d.ExtraFields.DetailedMap = false
+	d.ExtraFields.DetailedStruct = false
+	d.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
+	payloadValues, err := d.ToStringMap(payload)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot dump payload %+v", payload)
+	}
+
+	return []sdk.WorkflowNodeRunHookEvent{{
+		WorkflowNodeHookUUID: t.UUID,
+		Payload:              payloadValues,
+	}}, nil
+}
+
+// matchPullRequestHookFilters returns true if the event action is one of the hook actions.
+// When labels are configured, a labeled event must add one of them, other events need the pull request to have one of them.
+func matchPullRequestHookFilters(config sdk.WorkflowNodeHookConfig, e pullRequestEvent) bool {
+	if !sdk.IsInArray(e.Action, sdk.HookGlobPatterns(config[sdk.PullRequestHookModelActions].Value)) {
+		return false
+	}
+	labels := sdk.HookGlobPatterns(config[sdk.PullRequestHookModelLabels].Value)
+	if len(labels) == 0 {
+		return true
+	}
+	eventLabels := e.Labels
+	if e.Action == sdk.PullRequestActionLabeled {
+		eventLabels = e.AddedLabels
+	}
+	for _, l := range eventLabels {
+		if sdk.IsInArray(l, labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e pullRequestEvent) payload() map[string]interface{} {
+	payload := map[string]interface{}{
+		PR_ID:            e.ID,
+		PR_TITLE:         e.Title,
+		PR_STATE:         e.State,
+		PR_ACTION:        e.Action,
+		PR_URL:           e.URL,
+		PR_LABELS:        strings.Join(e.Labels, ","),
+		PR_TARGET_BRANCH: e.TargetBranch,
+		GIT_BRANCH:       e.SourceBranch,
+		GIT_REPOSITORY:   e.Repository,
+		GIT_AUTHOR:       e.Author,
+	}
+	if e.AuthorEmail != "" {
+		payload[GIT_AUTHOR_EMAIL] = e.AuthorEmail
+	}
+	if e.Hash != "" {
+		payload[GIT_HASH] = e.Hash
+		hashShort := e.Hash
+		if len(hashShort) >= 7 {
+			hashShort = hashShort[:7]
+		}
+		payload[GIT_HASH_SHORT] = hashShort
+	}
+	payload[CDS_TRIGGERED_BY_USERNAME] = e.Author
+	payload[CDS_TRIGGERED_BY_FULLNAME] = e.Author
+	if e.AuthorEmail != "" {
+		payload[CDS_TRIGGERED_BY_EMAIL] = e.AuthorEmail
+	}
+	return payload
+}
+
+// parseGithubPullRequestEvent reads a pull request event sent by Github or Gitea.
+// It returns nil for the actions that are not handled by pull request hooks.
+func parseGithubPullRequestEvent(body []byte) (*pullRequestEvent, error) {
+	var request GithubPullRequestEvent
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, sdk.WrapError(err, "unable to read pull request event: %s", string(body))
+	}
+
+	pr := request.PullRequest
+	e := pullRequestEvent{
+		ID:           pr.Number,
+		Title:        pr.Title,
+		URL:          pr.HTMLURL,
+		State:        pr.State,
+		SourceBranch: pr.Head.Ref,
+		TargetBranch: pr.Base.Ref,
+		Hash:         pr.Head.Sha,
+		Author:       pr.User.Login,
+		AuthorEmail:  pr.User.Email,
+	}
+	if e.ID == 0 {
+		e.ID = request.Number
+	}
+	for _, l := range pr.Labels {
+		e.Labels = append(e.Labels, l.Name)
+	}
+	if request.Repository != nil {
+		e.Repository = request.Repository.FullName
+	} else if pr.Base.Repo != nil {
+		e.Repository = pr.Base.Repo.FullName
+	}
+
+	switch request.Action {
+	case "opened", "reopened":
+		e.Action = request.Action
+	case "synchronize", "synchronized":
+		e.Action = sdk.PullRequestActionSynchronized
+	case "labeled", "label_updated":
+		e.Action = sdk.PullRequestActionLabeled
+		if request.Label != nil {
+			e.AddedLabels = []string{request.Label.Name}
+		} else {
+			// Gitea doesn't send the updated label, all the labels are considered as added
+			e.AddedLabels = e.Labels
+		}
+	case "closed":
+		e.Action = sdk.PullRequestActionClosed
+		if pr.Merged {
+			e.Action = sdk.PullRequestActionMerged
+		}
+	default:
+		return nil, nil
+	}
+	return &e, nil
+}
+
+// parseGitlabMergeRequestEvent reads a merge request event sent by Gitlab.
+// It returns nil for the actions that are not handled by pull request hooks.
+func parseGitlabMergeRequestEvent(body []byte) (*pullRequestEvent, error) {
+	var request GitlabMergeRequestEvent
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, sdk.WrapError(err, "unable to read merge request event: %s", string(body))
+	}
+
+	attr := request.ObjectAttributes
+	e := pullRequestEvent{
+		ID:           attr.IID,
+		Title:        attr.Title,
+		URL:          attr.URL,
+		State:        attr.State,
+		SourceBranch: attr.SourceBranch,
+		TargetBranch: attr.TargetBranch,
+		Hash:         attr.LastCommit.ID,
+		Author:       request.User.Username,
+		AuthorEmail:  request.User.Email,
+	}
+	for _, l := range request.Labels {
+		e.Labels = append(e.Labels, l.Title)
+	}
+	if request.Project != nil {
+		e.Repository = request.Project.PathWithNamespace
+	}
+
+	switch attr.Action {
+	case "open":
+		e.Action = sdk.PullRequestActionOpened
+	case "reopen":
+		e.Action = sdk.PullRequestActionReopened
+	case "close":
+		e.Action = sdk.PullRequestActionClosed
+	case "merge":
+		e.Action = sdk.PullRequestActionMerged
+	case "update":
+		switch {
+		case attr.OldRev != "":
+			e.Action = sdk.PullRequestActionSynchronized
+		case request.Changes.Labels != nil:
+			e.Action = sdk.PullRequestActionLabeled
+			for _, current := range request.Changes.Labels.Current {
+				var found bool
+				for _, previous := range request.Changes.Labels.Previous {
+					if current.Title == previous.Title {
+						found = true
+						break
+					}
+				}
+				if !found {
+					e.AddedLabels = append(e.AddedLabels, current.Title)
+				}
+			}
+		default:
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	return &e, nil
+}
@@ -0,0 +1,228 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// scheduledRunRetention is the delay during which a scheduled run is kept after its execution.
+const scheduledRunRetention = 7 * 24 * time.Hour
+
+// scheduledRunToTask returns the task of a scheduled run, the task has a single execution at the date of the run.
+func scheduledRunToTask(r sdk.WorkflowScheduledRun) (*sdk.Task, error) {
+	payload, err := json.Marshal(r.Payload)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to marshal payload of scheduled run %s", r.UUID)
+	}
+	return &sdk.Task{
+		UUID: r.UUID,
+		Type: TypeScheduledRun,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigProject:              {Value: r.ProjectKey},
+			sdk.HookConfigWorkflow:             {Value: r.WorkflowName},
+			ConfigScheduledRunDate:             {Value: r.Date.Format(time.RFC3339)},
+			ConfigScheduledRunAuthor:           {Value: r.Author},
+			ConfigScheduledRunAuthorConsumerID: {Value: r.AuthorConsumerID},
+			sdk.Payload:                        {Value: string(payload)},
+		},
+	}, nil
+}
+
+// taskToScheduledRun returns the scheduled run of a task, its status is given by the last execution of the task.
+func taskToScheduledRun(t sdk.Task, execs []sdk.TaskExecution) (sdk.WorkflowScheduledRun, error) {
+	r := sdk.WorkflowScheduledRun{
+		UUID:             t.UUID,
+		ProjectKey:       t.Config[sdk.HookConfigProject].Value,
+		WorkflowName:     t.Config[sdk.HookConfigWorkflow].Value,
+		Author:           t.Config[ConfigScheduledRunAuthor].Value,
+		AuthorConsumerID: t.Config[ConfigScheduledRunAuthorConsumerID].Value,
+		Status:           sdk.WorkflowScheduledRunStatusScheduled,
+	}
+
+	date, err := time.Parse(time.RFC3339, t.Config[ConfigScheduledRunDate].Value)
+	if err != nil {
+		return r, sdk.WrapError(err, "invalid date of scheduled run %s", t.UUID)
+	}
+	r.Date = date
+
+	if payload := t.Config[sdk.Payload].Value; payload != "" {
+		if err := json.Unmarshal([]byte(payload), &r.Payload); err != nil {
+			return r, sdk.WrapError(err, "invalid payload of scheduled run %s", t.UUID)
+		}
+	}
+
+	if len(execs) == 0 {
+		return r, nil
+	}
+	sort.Slice(execs, func(i, j int) bool { return execs[i].Timestamp > execs[j].Timestamp })
+	e := execs[0]
+	if e.Status != TaskExecutionDone {
+		return r, nil
+	}
+	r.WorkflowRun = e.WorkflowRun
+	r.LastError = e.LastError
+	if e.LastError != "" {
+		r.Status = sdk.WorkflowScheduledRunStatusFail
+	} else {
+		r.Status = sdk.WorkflowScheduledRunStatusTriggered
+	}
+	return r, nil
+}
+
+// newScheduledRunExecution returns the single execution of a scheduled run. A scheduled run whose date passed
+// before its execution was created is not executed late, its execution is created as failed.
+func newScheduledRunExecution(t *sdk.Task, now time.Time) (*sdk.TaskExecution, error) {
+	date, err := time.Parse(time.RFC3339, t.Config[ConfigScheduledRunDate].Value)
+	if err != nil {
+		return nil, sdk.WrapError(err, "invalid date of scheduled run %s", t.UUID)
+	}
+
+	e := &sdk.TaskExecution{
+		Timestamp: date.UnixNano(),
+		Status:    TaskExecutionScheduled,
+		Type:      t.Type,
+		UUID:      t.UUID,
+		Config:    t.Config,
+		ScheduledTask: &sdk.ScheduledTaskExecution{
+			DateScheduledExecution: fmt.Sprintf("%v", date),
+		},
+	}
+	if date.Before(now) {
+		failScheduledRunExecution(e, now, fmt.Sprintf("scheduled run was not executed at %s", date.Format(time.RFC3339)))
+	}
+	return e, nil
+}
+
+// failScheduledRunExecution ends the execution of a scheduled run without starting the workflow run. The
+// execution is kept until the end of the retention, so the scheduled run is listed as failed and is not executed
+// again.
+func failScheduledRunExecution(e *sdk.TaskExecution, now time.Time, reason string) {
+	e.Status = TaskExecutionDone
+	e.ProcessingTimestamp = now.UnixNano()
+	if e.LastError == "" {
+		e.LastError = reason
+	}
+}
+
+// doScheduledRunExecution asks CDS API to start the run of the workflow as the user that scheduled it.
+func (s *Service) doScheduledRunExecution(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution) error {
+	log.Debug("Hooks> Processing scheduled run %s", t.UUID)
+
+	r, err := taskToScheduledRun(*t, nil)
+	if err != nil {
+		return err
+	}
+
+	run, err := s.Client.WorkflowScheduledRunExecute(r)
+	if err != nil {
+		return sdk.WrapError(err, "unable to run workflow %s/%s", r.ProjectKey, r.WorkflowName)
+	}
+	e.WorkflowRun = run.Number
+	log.Debug("Hooks> workflow %s/%s#%d has been triggered by scheduled run %s", r.ProjectKey, r.WorkflowName, run.Number, t.UUID)
+	return nil
+}
+
+// findScheduledRuns returns the scheduled runs of a workflow sorted by date.
+func (s *Service) findScheduledRuns(ctx context.Context, key, workflowName string) ([]sdk.WorkflowScheduledRun, error) {
+	tasks, err := s.Dao.FindAllTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []sdk.WorkflowScheduledRun{}
+	for i := range tasks {
+		t := &tasks[i]
+		if t.Type != TypeScheduledRun || t.Config[sdk.HookConfigProject].Value != key || t.Config[sdk.HookConfigWorkflow].Value != workflowName {
+			continue
+		}
+		execs, err := s.Dao.FindAllTaskExecutions(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		r, err := taskToScheduledRun(*t, execs)
+		if err != nil {
+			log.Error(ctx, "Hooks> findScheduledRuns> %v", err)
+			continue
+		}
+		res = append(res, r)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Date.Before(res[j].Date) })
+	return res, nil
+}
+
+func (s *Service) postScheduledRunHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var run sdk.WorkflowScheduledRun
+		if err := service.UnmarshalBody(r, &run); err != nil {
+			return sdk.WithStack(err)
+		}
+		if err := run.IsValid(time.Now()); err != nil {
+			return err
+		}
+
+		t, err := scheduledRunToTask(run)
+		if err != nil {
+			return err
+		}
+		if err := s.Dao.SaveTask(t); err != nil {
+			return sdk.WrapError(err, "unable to save task %v", t)
+		}
+		if _, err := s.startTask(ctx, t); err != nil {
+			return sdk.WrapError(err, "unable to start task %v", t)
+		}
+
+		run.Status = sdk.WorkflowScheduledRunStatusScheduled
+		return service.WriteJSON(w, run, http.StatusOK)
+	}
+}
+
+func (s *Service) getScheduledRunsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		runs, err := s.findScheduledRuns(ctx, vars["key"], vars["workflow"])
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, runs, http.StatusOK)
+	}
+}
+
+func (s *Service) deleteScheduledRunHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		t := s.Dao.FindTask(ctx, vars["uuid"])
+		if t == nil || t.Type != TypeScheduledRun || t.Config[sdk.HookConfigProject].Value != vars["key"] || t.Config[sdk.HookConfigWorkflow].Value != vars["workflow"] {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		execs, err := s.Dao.FindAllTaskExecutions(ctx, t)
+		if err != nil {
+			return err
+		}
+		run, err := taskToScheduledRun(*t, execs)
+		if err != nil {
+			return err
+		}
+		if run.Status != sdk.WorkflowScheduledRunStatusScheduled {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "scheduled run %s has already been executed", t.UUID)
+		}
+
+		if err := s.stopTask(ctx, t); err != nil {
+			return err
+		}
+		return s.deleteTask(ctx, t)
+	}
+}
@@ -0,0 +1,84 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestScheduledRunToTask(t *testing.T) {
+	run := sdk.WorkflowScheduledRun{
+		UUID:             "abcd",
+		ProjectKey:       "PROJ",
+		WorkflowName:     "deploy",
+		Date:             time.Date(2020, 1, 4, 2, 0, 0, 0, time.UTC),
+		Payload:          map[string]string{"env": "prod"},
+		Author:           "john",
+		AuthorConsumerID: "efgh",
+	}
+
+	task, err := scheduledRunToTask(run)
+	require.NoError(t, err)
+	assert.Equal(t, TypeScheduledRun, task.Type)
+	assert.Equal(t, "2020-01-04T02:00:00Z", task.Config[ConfigScheduledRunDate].Value)
+
+	res, err := taskToScheduledRun(*task, nil)
+	require.NoError(t, err)
+	run.Status = sdk.WorkflowScheduledRunStatusScheduled
+	assert.Equal(t, run, res)
+
+	res, err = taskToScheduledRun(*task, []sdk.TaskExecution{{Status: TaskExecutionDoing}})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.WorkflowScheduledRunStatusScheduled, res.Status)
+
+	res, err = taskToScheduledRun(*task, []sdk.TaskExecution{{Status: TaskExecutionDone, WorkflowRun: 42}})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.WorkflowScheduledRunStatusTriggered, res.Status)
+	assert.Equal(t, int64(42), res.WorkflowRun)
+
+	res, err = taskToScheduledRun(*task, []sdk.TaskExecution{{Status: TaskExecutionDone, LastError: "workflow not found"}})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.WorkflowScheduledRunStatusFail, res.Status)
+	assert.Equal(t, "workflow not found", res.LastError)
+}
+
+func TestNewScheduledRunExecution(t *testing.T) {
+	now := time.Date(2020, 1, 3, 12, 0, 0, 0, time.UTC)
+	task, err := scheduledRunToTask(sdk.WorkflowScheduledRun{UUID: "abcd", Date: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	e, err := newScheduledRunExecution(task, now)
+	require.NoError(t, err)
+	assert.Equal(t, TaskExecutionScheduled, e.Status)
+	assert.Equal(t, now.Add(time.Hour).UnixNano(), e.Timestamp)
+
+	// The execution was lost and the date is over, the run must not be started late
+	e, err = newScheduledRunExecution(task, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, TaskExecutionDone, e.Status)
+	res, err := taskToScheduledRun(*task, []sdk.TaskExecution{*e})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.WorkflowScheduledRunStatusFail, res.Status)
+	assert.NotEmpty(t, res.LastError)
+}
+
+func TestFailScheduledRunExecution(t *testing.T) {
+	task, err := scheduledRunToTask(sdk.WorkflowScheduledRun{UUID: "abcd", Date: time.Date(2020, 1, 4, 2, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+
+	// All the retries failed, the execution is dropped from the queue
+	e := sdk.TaskExecution{Status: TaskExecutionDoing, NbErrors: 10, LastError: "workflow not found"}
+	now := time.Date(2020, 1, 4, 3, 0, 0, 0, time.UTC)
+	failScheduledRunExecution(&e, now, "")
+	assert.Equal(t, TaskExecutionDone, e.Status)
+	assert.Equal(t, now.UnixNano(), e.ProcessingTimestamp)
+
+	res, err := taskToScheduledRun(*task, []sdk.TaskExecution{e})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.WorkflowScheduledRunStatusFail, res.Status)
+	assert.Equal(t, "workflow not found", res.LastError)
+}
@@ -44,11 +44,15 @@ func (s *Service) doScheduledTaskExecution(ctx context.Context, t *sdk.TaskExecu
 	}
 	for k, v := range t.Config {
 		switch k {
-		case sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.SchedulerModelCron, sdk.SchedulerModelTimezone, sdk.Payload:
+		case sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.SchedulerModelCron, sdk.SchedulerModelTimezone, sdk.SchedulerModelCatchUpPolicy, sdk.Payload:
 		default:
 			payloadValues[k] = v.Value
 		}
 	}
+	if t.ScheduledTask != nil && t.ScheduledTask.CatchUp {
+		payloadValues["cds.scheduler.catch_up"] = "true"
+		payloadValues["cds.scheduler.date"] = t.ScheduledTask.DateScheduledExecution
+	}
 	payloadValues["cds.triggered_by.username"] = "cds.scheduler"
 	payloadValues["cds.triggered_by.fullname"] = "CDS Scheduler"
 	h.Payload = payloadValues
@@ -156,7 +156,8 @@ func (s *Service) enqueueScheduledTaskExecutionsRoutine(ctx context.Context) err
 					if e.Status == TaskExecutionScheduled && e.ProcessingTimestamp == 0 && e.Timestamp <= time.Now().UnixNano() {
 						// update status before enqueue
 						// this will avoid to re-enqueue the same scheduled task execution if the dequeue take more than 30s (ticker of this goroutine)
-						if alreadyEnqueued {
+						// catch-up executions of missed schedules are all enqueued
+						if alreadyEnqueued && (e.ScheduledTask == nil || !e.ScheduledTask.CatchUp) {
 							log.Info(ctx, "Hooks> enqueueScheduledTaskExecutionsRoutine > task execution already enqueued for this task %s of type %s- delete it", e.UUID, e.Type)
 							if err := s.Dao.DeleteTaskExecution(&e); err != nil {
 								log.Error(ctx, "Hooks> enqueueScheduledTaskExecutionsRoutine > error on DeleteTaskExecution: %v", err)
@@ -217,6 +218,11 @@ func (s *Service) deleteTaskExecutionsRoutine(ctx context.Context) error {
 							}
 							taskToDelete = true
 						}
+					// Keep a scheduled run a few days after its execution
+					case TypeScheduledRun:
+						if e.Status == TaskExecutionDone && e.ProcessingTimestamp != 0 && e.ProcessingTimestamp < time.Now().Add(-scheduledRunRetention).UnixNano() {
+							taskToDelete = true
+						}
 					default:
 						if i >= s.Cfg.ExecutionHistory && e.ProcessingTimestamp != 0 {
 							if err := s.Dao.DeleteTaskExecution(&e); err != nil {
@@ -294,6 +300,13 @@ func (s *Service) dequeueTaskExecutions(ctx context.Context) error {
 			}
 			continue
 
+		} else if t.NbErrors >= s.Cfg.RetryError && task.Type == TypeScheduledRun {
+			// The execution of a scheduled run is kept to list the scheduled run as failed until its retention
+			log.Info(ctx, "Hooks> dequeueTaskExecutions> Scheduled run %s failed cause: to many errors:%d lastError:%s", t.UUID, t.NbErrors, t.LastError)
+			failScheduledRunExecution(&t, time.Now(), "")
+			s.Dao.SaveTaskExecution(&t)
+			continue
+
 		} else if t.NbErrors >= s.Cfg.RetryError {
 			log.Info(ctx, "Hooks> dequeueTaskExecutions> Deleting task execution %s cause: to many errors:%d lastError:%s", t.UUID, t.NbErrors, t.LastError)
 			if err := s.Dao.DeleteTaskExecution(&t); err != nil {
@@ -0,0 +1,146 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// maxMissedSchedules limits the number of missed schedules caught up and kept for a scheduler task
+const maxMissedSchedules = 100
+
+// catchUpScheduledTasks applies the catch-up policy of all the scheduler tasks, it has to be called at startup
+// before the tasks are started.
+func (s *Service) catchUpScheduledTasks(ctx context.Context) error {
+	tasks, err := s.Dao.FindAllTasks(ctx)
+	if err != nil {
+		return sdk.WrapError(err, "unable to find all tasks")
+	}
+
+	now := time.Now()
+	for i := range tasks {
+		t := &tasks[i]
+		if t.Type != TypeScheduler || t.Stopped {
+			continue
+		}
+		if err := s.catchUpScheduledTask(ctx, t, now); err != nil {
+			log.Error(ctx, "Hooks> catchUpScheduledTasks> unable to catch up task %s: %v", t.UUID, err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) catchUpScheduledTask(ctx context.Context, t *sdk.Task, now time.Time) error {
+	execs, err := s.Dao.FindAllTaskExecutions(ctx, t)
+	if err != nil {
+		return sdk.WrapError(err, "unable to load executions")
+	}
+
+	// Executions still scheduled in the past have been missed while the service was down
+	var missedExecs []sdk.TaskExecution
+	existing := make(map[int64]bool, len(execs))
+	for _, e := range execs {
+		existing[e.Timestamp] = true
+		if e.Status == TaskExecutionScheduled && e.ProcessingTimestamp == 0 && e.Timestamp < now.UnixNano() &&
+			(e.ScheduledTask == nil || !e.ScheduledTask.CatchUp) {
+			missedExecs = append(missedExecs, e)
+		}
+	}
+	if len(missedExecs) == 0 {
+		return nil
+	}
+	sort.Slice(missedExecs, func(i, j int) bool { return missedExecs[i].Timestamp < missedExecs[j].Timestamp })
+
+	loc, err := time.LoadLocation(t.Config[sdk.SchedulerModelTimezone].Value)
+	if err != nil {
+		return sdk.WrapError(err, "unable to parse timezone: %v", t.Config[sdk.SchedulerModelTimezone])
+	}
+	cronExpr, err := cronexpr.Parse(t.Config[sdk.SchedulerModelCron].Value)
+	if err != nil {
+		return sdk.WrapError(err, "unable to parse cron expression: %v", t.Config[sdk.SchedulerModelCron])
+	}
+
+	policy := t.Config[sdk.SchedulerModelCatchUpPolicy].Value
+	if policy == "" {
+		policy = sdk.SchedulerCatchUpRunOnce
+	}
+
+	dates := missedScheduleDates(cronExpr, time.Unix(0, missedExecs[0].Timestamp).In(loc), now, maxMissedSchedules)
+	log.Info(ctx, "Hooks> catchUpScheduledTask> task %s missed %d schedules, applying policy %s", t.UUID, len(dates), policy)
+
+	missed := make([]sdk.MissedSchedule, len(dates))
+	for i := range dates {
+		missed[i] = sdk.MissedSchedule{
+			UUID:       t.UUID,
+			Date:       dates[i],
+			DetectedAt: now,
+			Policy:     policy,
+		}
+	}
+
+	switch policy {
+	case sdk.SchedulerCatchUpSkip:
+		for i := range missedExecs {
+			if err := s.Dao.DeleteTaskExecution(&missedExecs[i]); err != nil {
+				return sdk.WrapError(err, "unable to delete missed execution")
+			}
+		}
+	case sdk.SchedulerCatchUpRunOnce:
+		// The first missed execution is kept and will be enqueued by the scheduler
+		missed[0].Executed = true
+		for i := 1; i < len(missedExecs); i++ {
+			if err := s.Dao.DeleteTaskExecution(&missedExecs[i]); err != nil {
+				return sdk.WrapError(err, "unable to delete missed execution")
+			}
+		}
+	case sdk.SchedulerCatchUpRunAllMissed:
+		for i := range missed {
+			missed[i].Executed = true
+			ts := dates[i].UnixNano()
+			if i == 0 || existing[ts] {
+				continue
+			}
+			exec := &sdk.TaskExecution{
+				Timestamp: ts,
+				Status:    TaskExecutionScheduled,
+				Type:      t.Type,
+				UUID:      t.UUID,
+				Config:    t.Config,
+				ScheduledTask: &sdk.ScheduledTaskExecution{
+					DateScheduledExecution: fmt.Sprintf("%v", dates[i]),
+					CatchUp:                true,
+				},
+			}
+			if err := s.Dao.SaveTaskExecution(exec); err != nil {
+				return sdk.WrapError(err, "unable to save catch-up execution")
+			}
+		}
+	default:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid catch-up policy %s", policy)
+	}
+
+	previous, err := s.Dao.FindMissedSchedules(ctx, t.UUID)
+	if err != nil {
+		return err
+	}
+	all := append(previous, missed...)
+	if len(all) > maxMissedSchedules {
+		all = all[len(all)-maxMissedSchedules:]
+	}
+	return s.Dao.SaveMissedSchedules(t.UUID, all)
+}
+
+// missedScheduleDates returns the dates given by the cron expression from the first missed date until now
+func missedScheduleDates(cronExpr *cronexpr.Expression, first, now time.Time, max int) []time.Time {
+	dates := []time.Time{first}
+	for next := cronExpr.Next(first); !next.IsZero() && next.Before(now) && len(dates) < max; next = cronExpr.Next(next) {
+		dates = append(dates, next)
+	}
+	return dates
+}
@@ -0,0 +1,29 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissedScheduleDates(t *testing.T) {
+	cronExpr, err := cronexpr.Parse("0 * * * *")
+	require.NoError(t, err)
+
+	first := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := time.Date(2020, 1, 1, 13, 30, 0, 0, time.UTC)
+
+	dates := missedScheduleDates(cronExpr, first, now, maxMissedSchedules)
+	require.Len(t, dates, 4)
+	assert.Equal(t, first, dates[0])
+	assert.Equal(t, time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC), dates[3])
+
+	dates = missedScheduleDates(cronExpr, first, now, 2)
+	require.Len(t, dates, 2)
+
+	dates = missedScheduleDates(cronExpr, first, first.Add(time.Minute), maxMissedSchedules)
+	require.Len(t, dates, 1)
+}
@@ -17,6 +17,7 @@ import (
 //This are all the types
 const (
 	TypeRepoManagerWebHook = "RepoWebHook"
+	TypePullRequestWebHook = "PullRequestWebHook"
 	TypeWebHook            = "Webhook"
 	TypeScheduler          = "Scheduler"
 	TypeRepoPoller         = "RepoPoller"
@@ -27,9 +28,12 @@ const (
 	TypeWorkflowHook       = "Workflow"
 	TypeOutgoingWebHook    = "OutgoingWebhook"
 	TypeOutgoingWorkflow   = "OutgoingWorkflow"
+	TypeScheduledRun       = "ScheduledRun"
 
 	GithubHeader         = "X-Github-Event"
 	GitlabHeader         = "X-Gitlab-Event"
+	GiteaHeader          = "X-Gitea-Event"
+	CodeCommitHeader     = "X-Amz-Sns-Message-Type" // CodeCommit events are sent through AWS SNS
 	BitbucketHeader      = "X-Event-Key"
 	BitbucketCloudHeader = "X-Event-Key_Cloud" // Fake header, do not use to fetch header, just to return custom header
 
@@ -37,12 +41,17 @@ const (
 	ConfigSubNumber = "SubNumber"
 	ConfigHookID    = "HookID"
 	ConfigHookRunID = "HookRunID"
+
+	ConfigScheduledRunDate             = "date"
+	ConfigScheduledRunAuthor           = "author"
+	ConfigScheduledRunAuthorConsumerID = "author_consumer_id"
 )
 
 var (
 	rootKey           = cache.Key("hooks", "tasks")
 	executionRootKey  = cache.Key("hooks", "tasks", "executions")
 	schedulerQueueKey = cache.Key("hooks", "scheduler", "queue")
+	missedRootKey     = cache.Key("hooks", "tasks", "missed")
 	gerritRepoKey     = cache.Key("hooks", "gerrit", "repo")
 	gerritRepoHooks   = make(map[string]bool)
 )
@@ -53,6 +62,10 @@ func (s *Service) runTasks(ctx context.Context) error {
 		log.Error(ctx, "Hook> Unable to synchronize tasks: %v", err)
 	}
 
+	if err := s.catchUpScheduledTasks(ctx); err != nil {
+		log.Error(ctx, "Hook> Unable to catch up scheduled tasks: %v", err)
+	}
+
 	if err := s.startTasks(ctx); err != nil {
 		log.Error(ctx, "Hook> Exit running tasks: %v", err)
 		return err
@@ -91,7 +104,7 @@ func (s *Service) synchronizeTasks(ctx context.Context) error {
 				break
 			}
 		}
-		if !found && t.Type != TypeOutgoingWebHook && t.Type != TypeOutgoingWorkflow {
+		if !found && t.Type != TypeOutgoingWebHook && t.Type != TypeOutgoingWorkflow && t.Type != TypeScheduledRun {
 			if err := s.deleteTask(ctx, t); err != nil {
 				log.Error(ctx, "Hook> Error on task %s delete on synchronization: %v", t.UUID, err)
 			} else {
@@ -188,6 +201,16 @@ func (s *Service) hookToTask(h *sdk.NodeHook) (*sdk.Task, error) {
 			Type:   TypeRepoManagerWebHook,
 			Config: h.Config,
 		}, nil
+	case sdk.PullRequestHookModelName:
+		h.Config["webHookURL"] = sdk.WorkflowNodeHookConfigValue{
+			Value:        fmt.Sprintf("%s/webhook/%s", s.Cfg.URLPublic, h.UUID),
+			Configurable: false,
+		}
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypePullRequestWebHook,
+			Config: h.Config,
+		}, nil
 	case sdk.SchedulerModelName:
 		return &sdk.Task{
 			UUID:   h.UUID,
@@ -258,9 +281,9 @@ func (s *Service) startTask(ctx context.Context, t *sdk.Task) (*sdk.TaskExecutio
 	}
 
 	switch t.Type {
-	case TypeWebHook, TypeRepoManagerWebHook, TypeWorkflowHook:
+	case TypeWebHook, TypeRepoManagerWebHook, TypePullRequestWebHook, TypeWorkflowHook:
 		return nil, nil
-	case TypeScheduler, TypeRepoPoller, TypeBranchDeletion:
+	case TypeScheduler, TypeRepoPoller, TypeBranchDeletion, TypeScheduledRun:
 		return nil, s.prepareNextScheduledTaskExecution(ctx, t)
 	case TypeKafka:
 		return nil, s.startKafkaHook(ctx, t)
@@ -288,6 +311,18 @@ func (s *Service) prepareNextScheduledTaskExecution(ctx context.Context, t *sdk.
 		return sdk.WrapError(err, "unable to load last executions")
 	}
 
+	//A scheduled run is executed only once, at its date
+	if t.Type == TypeScheduledRun {
+		if len(execs) > 0 {
+			return nil
+		}
+		exec, err := newScheduledRunExecution(t, time.Now())
+		if err != nil {
+			return err
+		}
+		return s.Dao.SaveTaskExecution(exec)
+	}
+
 	//The last execution has not been executed, let it go
 	if len(execs) > 0 && execs[len(execs)-1].ProcessingTimestamp == 0 {
 		log.Debug("Hooks> Scheduled task %s:%d ready. Next execution already scheduled on %v", t.UUID, execs[len(execs)-1].Timestamp, time.Unix(0, execs[len(execs)-1].Timestamp))
@@ -359,7 +394,7 @@ func (s *Service) stopTask(ctx context.Context, t *sdk.Task) error {
 	}
 
 	switch t.Type {
-	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypeRepoPoller, TypeKafka, TypeWorkflowHook:
+	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypePullRequestWebHook, TypeRepoPoller, TypeKafka, TypeWorkflowHook, TypeScheduledRun:
 		log.Debug("Hooks> Tasks %s has been stopped", t.UUID)
 		return nil
 	case TypeGerrit:
@@ -376,6 +411,7 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 	if t.Stopped {
 		return false, nil
 	}
+	e.Events = nil
 
 	var hs []sdk.WorkflowNodeRunHookEvent
 	var h *sdk.WorkflowNodeRunHookEvent
@@ -389,7 +425,7 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 		err = s.doOutgoingWebHookExecution(ctx, e)
 	case e.Type == TypeOutgoingWorkflow:
 		err = s.doOutgoingWorkflowExecution(ctx, e)
-	case e.WebHook != nil && (e.Type == TypeWebHook || e.Type == TypeRepoManagerWebHook):
+	case e.WebHook != nil && (e.Type == TypeWebHook || e.Type == TypeRepoManagerWebHook || e.Type == TypePullRequestWebHook):
 		hs, err = s.doWebHookExecution(ctx, e)
 	case e.ScheduledTask != nil && e.Type == TypeScheduler:
 		h, err = s.doScheduledTaskExecution(ctx, e)
@@ -400,6 +436,8 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 		doRestart = true
 	case e.ScheduledTask != nil && e.Type == TypeBranchDeletion:
 		_, err = s.doBranchDeletionTaskExecution(e)
+	case e.ScheduledTask != nil && e.Type == TypeScheduledRun:
+		err = s.doScheduledRunExecution(ctx, t, e)
 	case e.Kafka != nil && e.Type == TypeKafka:
 		h, err = s.doKafkaTaskExecution(e)
 	case e.RabbitMQ != nil && e.Type == TypeRabbitMQ:
@@ -422,16 +460,29 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 	confProj := t.Config[sdk.HookConfigProject]
 	confWorkflow := t.Config[sdk.HookConfigWorkflow]
 	var globalErr error
+	// Keep computed events on the execution to be able to explain why a workflow was not triggered
+	e.Events = make([]sdk.TaskExecutionEvent, 0, len(hs))
 	for _, hEvent := range hs {
+		event := sdk.TaskExecutionEvent{Payload: make(map[string]string, len(hEvent.Payload))}
+		for k, v := range hEvent.Payload {
+			// The raw payload is already stored in the execution
+			if k != PAYLOAD {
+				event.Payload[k] = v
+			}
+		}
+
 		run, err := s.Client.WorkflowRunFromHook(confProj.Value, confWorkflow.Value, hEvent)
 		if err != nil {
 			globalErr = err
+			event.Error = sdk.Cause(err).Error()
 			log.Warning(ctx, "Hooks> %s > unable to run workflow %s/%s : %v", t.UUID, confProj.Value, confWorkflow.Value, err)
 		} else {
 			//Save the run number
 			e.WorkflowRun = run.Number
+			event.WorkflowRun = run.Number
 			log.Debug("Hooks> workflow %s/%s#%d has been triggered", confProj.Value, confWorkflow.Value, run.Number)
 		}
+		e.Events = append(e.Events, event)
 	}
 
 	if globalErr != nil {
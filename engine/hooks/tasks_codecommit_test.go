@@ -0,0 +1,102 @@
+package hooks
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func newTestSNSSigner(t *testing.T) func(msg *SNSMessage) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	getSNSCertificate = func(string) (*x509.Certificate, error) { return cert, nil }
+
+	return func(msg *SNSMessage) {
+		msg.SignatureVersion = "1"
+		msg.SigningCertURL = "https://sns.eu-west-1.amazonaws.com/SimpleNotificationService-cert.pem"
+		h := sha1.Sum([]byte(snsStringToSign(*msg)))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, h[:])
+		require.NoError(t, err)
+		msg.Signature = base64.StdEncoding.EncodeToString(sig)
+	}
+}
+
+func Test_doWebHookExecutionCodeCommit(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	sign := newTestSNSSigner(t)
+
+	uuid := sdk.RandomString(10)
+	msg := SNSMessage{
+		Type:      snsTypeNotification,
+		MessageID: "a5bc7ee5-5d1e-5a43-9be4-1d4ee8f8d72e",
+		TopicArn:  "arn:aws:sns:eu-west-1:123456789012:cds",
+		Message: `{"Records":[
+  {"awsRegion":"eu-west-1","codecommit":{"references":[{"commit":"5c4ef1049f1d27deadbeeff313e0730018be182b","ref":"refs/heads/develop"}]},
+   "customData":"https://cds.local/webhook/` + uuid + `","eventName":"ReferenceChanges","eventSource":"aws:codecommit",
+   "eventSourceARN":"arn:aws:codecommit:eu-west-1:123456789012:my-repo","userIdentityARN":"arn:aws:iam::123456789012:user/john"},
+  {"awsRegion":"eu-west-1","codecommit":{"references":[{"commit":"1111111111111111111111111111111111111111","ref":"refs/heads/master"}]},
+   "customData":"https://cds.local/webhook/another-hook","eventSourceARN":"arn:aws:codecommit:eu-west-1:123456789012:other-repo"}
+]}`,
+		Timestamp: "2020-01-01T10:00:00.000Z",
+	}
+	sign(&msg)
+	body, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	task := &sdk.TaskExecution{
+		UUID: uuid,
+		Type: TypeRepoManagerWebHook,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody:   body,
+			RequestHeader: map[string][]string{CodeCommitHeader: {snsTypeNotification}},
+		},
+		Config: sdk.WorkflowNodeHookConfig{
+			"project":  sdk.WorkflowNodeHookConfigValue{Value: "KEY"},
+			"workflow": sdk.WorkflowNodeHookConfigValue{Value: "my-workflow"},
+		},
+	}
+	hs, err := s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+
+	require.Equal(t, 1, len(hs))
+	assert.Equal(t, "develop", hs[0].Payload["git.branch"])
+	assert.Equal(t, "john", hs[0].Payload["git.author"])
+	assert.Equal(t, "5c4ef1049f1d27deadbeeff313e0730018be182b", hs[0].Payload["git.hash"])
+	assert.Equal(t, "my-repo", hs[0].Payload["git.repository"])
+
+	// A message with an invalid signature is refused
+	msg.Message = `{"Records":[]}`
+	body, err = json.Marshal(msg)
+	require.NoError(t, err)
+	task.WebHook.RequestBody = body
+	_, err = s.doWebHookExecution(context.TODO(), task)
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_doWebHookExecutionGitea(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(giteaPushEvent),
+			RequestHeader: map[string][]string{
+				GiteaHeader:  {"push"},
+				GithubHeader: {"push"},
+			},
+			RequestURL: "",
+		},
+	}
+	hs, err := s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+
+	assert.Equal(t, 1, len(hs))
+	assert.Equal(t, "develop", hs[0].Payload["git.branch"])
+	assert.Equal(t, "gitea", hs[0].Payload["git.author"])
+	assert.Equal(t, "Update README.md", hs[0].Payload["git.message"])
+	assert.Equal(t, "bffeb74224043ba2feb48d137756c8a9331c449a", hs[0].Payload["git.hash"])
+	assert.Equal(t, "gitea/webhooks", hs[0].Payload["git.repository"])
+}
+
+var giteaPushEvent = `
+{
+  "ref": "refs/heads/develop",
+  "before": "28e1879d029cb852e4844d9c718537df08844e03",
+  "after": "bffeb74224043ba2feb48d137756c8a9331c449a",
+  "compare_url": "http://localhost:3000/gitea/webhooks/compare/28e1879d029cb852e4844d9c718537df08844e03...bffeb74224043ba2feb48d137756c8a9331c449a",
+  "commits": [
+    {
+      "id": "bffeb74224043ba2feb48d137756c8a9331c449a",
+      "message": "Update README.md",
+      "url": "http://localhost:3000/gitea/webhooks/commit/bffeb74224043ba2feb48d137756c8a9331c449a",
+      "author": {
+        "name": "Gitea",
+        "email": "someone@gitea.io",
+        "username": "gitea"
+      },
+      "committer": {
+        "name": "Gitea",
+        "email": "someone@gitea.io",
+        "username": "gitea"
+      },
+      "timestamp": "2017-03-13T13:52:11-04:00"
+    }
+  ],
+  "head_commit": {
+    "id": "bffeb74224043ba2feb48d137756c8a9331c449a",
+    "message": "Update README.md",
+    "url": "http://localhost:3000/gitea/webhooks/commit/bffeb74224043ba2feb48d137756c8a9331c449a",
+    "author": {
+      "name": "Gitea",
+      "email": "someone@gitea.io",
+      "username": "gitea"
+    },
+    "committer": {
+      "name": "Gitea",
+      "email": "someone@gitea.io",
+      "username": "gitea"
+    },
+    "timestamp": "2017-03-13T13:52:11-04:00"
+  },
+  "repository": {
+    "id": 140,
+    "name": "webhooks",
+    "full_name": "gitea/webhooks",
+    "html_url": "http://localhost:3000/gitea/webhooks",
+    "clone_url": "http://localhost:3000/gitea/webhooks.git",
+    "ssh_url": "ssh://gitea@localhost:2222/gitea/webhooks.git"
+  },
+  "pusher": {
+    "id": 1,
+    "login": "gitea",
+    "full_name": "Gitea",
+    "email": "someone@gitea.io",
+    "username": "gitea"
+  },
+  "sender": {
+    "id": 1,
+    "login": "gitea",
+    "full_name": "Gitea",
+    "email": "someone@gitea.io",
+    "username": "gitea"
+  }
+}
+`
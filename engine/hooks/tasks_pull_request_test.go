@@ -0,0 +1,119 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_doWebHookExecutionPullRequest(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypePullRequestWebHook,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody:   []byte(githubPullRequestEvent),
+			RequestHeader: map[string][]string{GithubHeader: {"pull_request"}},
+		},
+		Config: sdk.PullRequestHookModel.DefaultConfig.Clone(),
+	}
+	hs, err := s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+
+	require.Equal(t, 1, len(hs))
+	assert.Equal(t, "synchronized", hs[0].Payload["git.pr.action"])
+	assert.Equal(t, "42", hs[0].Payload["git.pr.id"])
+	assert.Equal(t, "feat/my-feature", hs[0].Payload["git.branch"])
+	assert.Equal(t, "master", hs[0].Payload["git.pr.target.branch"])
+	assert.Equal(t, "bug,ready", hs[0].Payload["git.pr.labels"])
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", hs[0].Payload["git.hash"])
+	assert.Equal(t, "octocat/Hello-World", hs[0].Payload["git.repository"])
+	assert.Equal(t, "octocat", hs[0].Payload["git.author"])
+
+	// The action is not in the hook configuration
+	task.Config[sdk.PullRequestHookModelActions] = sdk.WorkflowNodeHookConfigValue{Value: "opened,merged"}
+	hs, err = s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+	assert.Equal(t, 0, len(hs))
+
+	// Other events are ignored
+	task.WebHook.RequestHeader = map[string][]string{GithubHeader: {"push"}}
+	hs, err = s.doWebHookExecution(context.TODO(), task)
+	test.NoError(t, err)
+	assert.Equal(t, 0, len(hs))
+}
+
+func Test_parseGitlabMergeRequestEvent(t *testing.T) {
+	e, err := parseGitlabMergeRequestEvent([]byte(gitlabMergeRequestLabelEvent))
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	assert.Equal(t, sdk.PullRequestActionLabeled, e.Action)
+	assert.Equal(t, int64(1), e.ID)
+	assert.Equal(t, []string{"bug", "deploy"}, e.Labels)
+	assert.Equal(t, []string{"deploy"}, e.AddedLabels)
+	assert.Equal(t, "ms-viewport", e.SourceBranch)
+	assert.Equal(t, "master", e.TargetBranch)
+	assert.Equal(t, "gitlabhq/gitlab-test", e.Repository)
+
+	config := sdk.PullRequestHookModel.DefaultConfig.Clone()
+	config[sdk.PullRequestHookModelActions] = sdk.WorkflowNodeHookConfigValue{Value: "labeled"}
+	config[sdk.PullRequestHookModelLabels] = sdk.WorkflowNodeHookConfigValue{Value: "deploy"}
+	assert.True(t, matchPullRequestHookFilters(config, *e))
+
+	// The bug label was already set on the merge request
+	config[sdk.PullRequestHookModelLabels] = sdk.WorkflowNodeHookConfigValue{Value: "bug"}
+	assert.False(t, matchPullRequestHookFilters(config, *e))
+}
+
+var githubPullRequestEvent = `
+{
+  "action": "synchronize",
+  "number": 42,
+  "pull_request": {
+    "number": 42,
+    "title": "Add my feature",
+    "html_url": "https://github.com/octocat/Hello-World/pull/42",
+    "state": "open",
+    "merged": false,
+    "labels": [{"name": "bug"}, {"name": "ready"}],
+    "head": {"ref": "feat/my-feature", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"},
+    "base": {"ref": "master", "sha": "9049f1265b7d61be4a8904a9a27120d2064dab3b"},
+    "user": {"login": "octocat"}
+  },
+  "repository": {"name": "Hello-World", "full_name": "octocat/Hello-World"},
+  "sender": {"login": "octocat"}
+}
+`
+
+var gitlabMergeRequestLabelEvent = `
+{
+  "object_kind": "merge_request",
+  "user": {"name": "Administrator", "username": "root", "email": "admin@example.com"},
+  "project": {"id": 1, "name": "Gitlab Test", "path_with_namespace": "gitlabhq/gitlab-test"},
+  "object_attributes": {
+    "iid": 1,
+    "title": "MS-Viewport",
+    "url": "http://example.com/diaspora/merge_requests/1",
+    "state": "opened",
+    "action": "update",
+    "source_branch": "ms-viewport",
+    "target_branch": "master",
+    "last_commit": {"id": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7"}
+  },
+  "labels": [{"title": "bug"}, {"title": "deploy"}],
+  "changes": {
+    "labels": {
+      "previous": [{"title": "bug"}],
+      "current": [{"title": "bug"}, {"title": "deploy"}]
+    }
+  }
+}
+`
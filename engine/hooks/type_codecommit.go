@@ -0,0 +1,48 @@
+package hooks
+
+// SNSMessage represents a message sent by AWS SNS to an HTTP(S) subscription
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// CodeCommitEvent represents the message sent by a CodeCommit repository trigger
+type CodeCommitEvent struct {
+	Records []CodeCommitRecord `json:"Records"`
+}
+
+// CodeCommitRecord represents an event on a CodeCommit repository
+type CodeCommitRecord struct {
+	AWSRegion        string               `json:"awsRegion"`
+	CodeCommit       CodeCommitReferences `json:"codecommit"`
+	CustomData       string               `json:"customData"`
+	EventID          string               `json:"eventId"`
+	EventName        string               `json:"eventName"`
+	EventSource      string               `json:"eventSource"`
+	EventSourceARN   string               `json:"eventSourceARN"`
+	EventTime        string               `json:"eventTime"`
+	EventTriggerName string               `json:"eventTriggerName"`
+	UserIdentityARN  string               `json:"userIdentityARN"`
+}
+
+// CodeCommitReferences contains the references updated by a CodeCommit event
+type CodeCommitReferences struct {
+	References []CodeCommitReference `json:"references"`
+}
+
+// CodeCommitReference represents a git reference updated by a CodeCommit event
+type CodeCommitReference struct {
+	Commit  string `json:"commit"`
+	Ref     string `json:"ref"`
+	Created bool   `json:"created,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
@@ -0,0 +1,54 @@
+package hooks
+
+// GiteaEvent represents a webhook sent by Gitea or Forgejo on push and delete events
+type GiteaEvent struct {
+	Ref        string           `json:"ref"`
+	RefType    string           `json:"ref_type,omitempty"`
+	Before     string           `json:"before,omitempty"`
+	After      string           `json:"after,omitempty"`
+	CompareURL string           `json:"compare_url,omitempty"`
+	Commits    []GiteaCommit    `json:"commits,omitempty"`
+	HeadCommit *GiteaCommit     `json:"head_commit,omitempty"`
+	Repository *GiteaRepository `json:"repository"`
+	Pusher     *GiteaUser       `json:"pusher,omitempty"`
+	Sender     *GiteaUser       `json:"sender"`
+}
+
+// GiteaCommit represents a commit of a Gitea push event
+type GiteaCommit struct {
+	ID        string          `json:"id"`
+	Message   string          `json:"message"`
+	URL       string          `json:"url"`
+	Author    GiteaCommitUser `json:"author"`
+	Committer GiteaCommitUser `json:"committer"`
+	Timestamp string          `json:"timestamp"`
+	Added     []string        `json:"added,omitempty"`
+	Removed   []string        `json:"removed,omitempty"`
+	Modified  []string        `json:"modified,omitempty"`
+}
+
+// GiteaCommitUser represents the author or the committer of a commit
+type GiteaCommitUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// GiteaRepository represents the repository of a Gitea event
+type GiteaRepository struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+	CloneURL string `json:"clone_url"`
+	SSHURL   string `json:"ssh_url"`
+}
+
+// GiteaUser represents the pusher or the sender of a Gitea event
+type GiteaUser struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
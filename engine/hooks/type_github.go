@@ -156,3 +156,40 @@ func (g *GithubWebHookEvent) GetCommits() []sdk.VCSCommit {
 	}
 	return commits
 }
+
+// GithubPullRequestEvent represents payload send by github and gitea on a pull request event
+type GithubPullRequestEvent struct {
+	Action      string            `json:"action"`
+	Number      int64             `json:"number"`
+	Label       *GithubLabel      `json:"label"`
+	PullRequest GithubPullRequest `json:"pull_request"`
+	Repository  *GithubRepository `json:"repository"`
+	Sender      GithubSender      `json:"sender"`
+}
+
+type GithubPullRequest struct {
+	Number  int64                 `json:"number"`
+	Title   string                `json:"title"`
+	HTMLURL string                `json:"html_url"`
+	State   string                `json:"state"`
+	Merged  bool                  `json:"merged"`
+	Labels  []GithubLabel         `json:"labels"`
+	Head    GithubPullRequestRef  `json:"head"`
+	Base    GithubPullRequestRef  `json:"base"`
+	User    GithubPullRequestUser `json:"user"`
+}
+
+type GithubPullRequestRef struct {
+	Ref  string            `json:"ref"`
+	Sha  string            `json:"sha"`
+	Repo *GithubRepository `json:"repo"`
+}
+
+type GithubPullRequestUser struct {
+	Login string `json:"login"`
+	Email string `json:"email"`
+}
+
+type GithubLabel struct {
+	Name string `json:"name"`
+}
@@ -86,3 +86,44 @@ func (g *GitlabEvent) GetCommits() []sdk.VCSCommit {
 	}
 	return commits
 }
+
+// GitlabMergeRequestEvent represents payload send by gitlab on a merge request event
+type GitlabMergeRequestEvent struct {
+	ObjectKind       string                       `json:"object_kind"`
+	User             GitlabMergeRequestUser       `json:"user"`
+	Project          *GitlabProject               `json:"project"`
+	ObjectAttributes GitlabMergeRequestAttributes `json:"object_attributes"`
+	Labels           []GitlabLabel                `json:"labels"`
+	Changes          GitlabMergeRequestChanges    `json:"changes"`
+}
+
+type GitlabMergeRequestUser struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type GitlabMergeRequestAttributes struct {
+	IID          int64        `json:"iid"`
+	Title        string       `json:"title"`
+	URL          string       `json:"url"`
+	State        string       `json:"state"`
+	Action       string       `json:"action"`
+	SourceBranch string       `json:"source_branch"`
+	TargetBranch string       `json:"target_branch"`
+	OldRev       string       `json:"oldrev"`
+	LastCommit   GitlabCommit `json:"last_commit"`
+}
+
+type GitlabMergeRequestChanges struct {
+	Labels *GitlabLabelsChange `json:"labels"`
+}
+
+type GitlabLabelsChange struct {
+	Previous []GitlabLabel `json:"previous"`
+	Current  []GitlabLabel `json:"current"`
+}
+
+type GitlabLabel struct {
+	Title string `json:"title"`
+}
@@ -38,11 +38,5 @@ type Configuration struct {
 	ExecutionHistory int                             `toml:"executionHistory" default:"10" comment:"Number of execution to keep" json:"executionHistory"`
 	Disable          bool                            `toml:"disable" default:"false" comment:"Disable all hooks executions" json:"disable"`
 	API              service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
-	Cache            struct {
-		TTL   int `toml:"ttl" default:"60" json:"ttl"`
-		Redis struct {
-			Host     string `toml:"host" default:"localhost:6379" comment:"If your want to use a redis-sentinel based cluster, follow this syntax! <clustername>@sentinel1:26379,sentinel2:26379,sentinel3:26379" json:"host"`
-			Password string `toml:"password" json:"-"`
-		} `toml:"redis" comment:"Connect CDS to a redis cache If you more than one CDS instance and to avoid losing data at startup" json:"redis"`
-	} `toml:"cache" comment:"######################\n CDS Hooks Cache Settings \n######################" json:"cache"`
+	Cache            cache.Configuration             `toml:"cache" comment:"######################\n CDS Hooks Cache Settings \n######################" json:"cache"`
 }
@@ -4,6 +4,10 @@ const (
 	PR_ID              = "git.pr.id"
 	PR_TITLE           = "git.pr.title"
 	PR_STATE           = "git.pr.state"
+	PR_ACTION          = "git.pr.action"
+	PR_URL             = "git.pr.url"
+	PR_LABELS          = "git.pr.labels"
+	PR_TARGET_BRANCH   = "git.pr.target.branch"
 	PR_PREVIOUS_TITLE  = "git.pr.previous.title"
 	PR_PREVIOUS_BRANCH = "git.pr.previous.branch"
 	PR_PREVIOUS_HASH   = "git.pr.previous.has"
@@ -24,6 +28,11 @@ const (
 	GIT_BRANCH            = "git.branch"
 	GIT_BRANCH_BEFORE     = "git.branch.before"
 	GIT_TAG               = "git.tag"
+	GIT_TAG_MAJOR         = "git.tag.major"
+	GIT_TAG_MINOR         = "git.tag.minor"
+	GIT_TAG_PATCH         = "git.tag.patch"
+	GIT_TAG_PRERELEASE    = "git.tag.prerelease"
+	GIT_TAG_BUILD         = "git.tag.build"
 	GIT_HASH_BEFORE       = "git.hash.before"
 	GIT_HASH              = "git.hash"
 	GIT_HASH_SHORT        = "git.hash.short"
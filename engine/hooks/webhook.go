@@ -17,8 +17,11 @@ import (
 func (s *Service) doWebHookExecution(ctx context.Context, e *sdk.TaskExecution) ([]sdk.WorkflowNodeRunHookEvent, error) {
 	log.Debug("Hooks> Processing webhook %s %s", e.UUID, e.Type)
 
-	if e.Type == TypeRepoManagerWebHook {
+	switch e.Type {
+	case TypeRepoManagerWebHook:
 		return s.executeRepositoryWebHook(ctx, e)
+	case TypePullRequestWebHook:
+		return s.executePullRequestWebHook(ctx, e)
 	}
 	event, err := executeWebHook(e)
 	if err != nil {
@@ -28,6 +31,17 @@ func (s *Service) doWebHookExecution(ctx context.Context, e *sdk.TaskExecution)
 }
 
 func getRepositoryHeader(whe *sdk.WebHookExecution, events []string) string {
+	// CodeCommit events are filtered with the references of the SNS notification
+	if _, ok := whe.RequestHeader[CodeCommitHeader]; ok {
+		return CodeCommitHeader
+	}
+	// Gitea and Forgejo also send the Github header, so check the Gitea one first
+	if v, ok := whe.RequestHeader[GiteaHeader]; ok {
+		if (len(events) == 0 && (v[0] == "push" || v[0] == "delete")) || sdk.IsInArray(v[0], events) {
+			return GiteaHeader
+		}
+		return ""
+	}
 	if v, ok := whe.RequestHeader[GithubHeader]; ok && ((len(events) == 0 && v[0] == "push") || sdk.IsInArray(v[0], events)) {
 		return GithubHeader
 	} else if v, ok := whe.RequestHeader[GitlabHeader]; ok && ((len(events) == 0 && v[0] == "Push Hook") || sdk.IsInArray(v[0], events)) {
@@ -60,6 +74,21 @@ func (s *Service) executeRepositoryWebHook(ctx context.Context, t *sdk.TaskExecu
 		if payload != nil {
 			payloads = append(payloads, payload)
 		}
+	case GiteaHeader:
+		headerValue := t.WebHook.RequestHeader[GiteaHeader][0]
+		payload, err := s.generatePayloadFromGiteaRequest(ctx, t, headerValue)
+		if err != nil {
+			return nil, err
+		}
+		if payload != nil {
+			payloads = append(payloads, payload)
+		}
+	case CodeCommitHeader:
+		var errG error
+		payloads, errG = s.generatePayloadsFromCodeCommitRequest(ctx, t, events)
+		if errG != nil {
+			return nil, errG
+		}
 	case GitlabHeader:
 		headerValue := t.WebHook.RequestHeader[GitlabHeader][0]
 		payload, err := s.generatePayloadFromGitlabRequest(ctx, t, headerValue)
@@ -90,6 +119,15 @@ func (s *Service) executeRepositoryWebHook(ctx context.Context, t *sdk.TaskExecu
 
 	hs := make([]sdk.WorkflowNodeRunHookEvent, 0, len(payloads))
 	for _, payload := range payloads {
+		if !matchRepositoryWebHookFilters(t.Config, payload) {
+			log.Info(ctx, "executeRepositoryWebHook> event on hook %s filtered by branches, tags or paths", t.UUID)
+			continue
+		}
+		if tag, ok := payload[GIT_TAG].(string); ok {
+			for k, v := range tagSemverVariables(tag) {
+				payload[k] = v
+			}
+		}
 		h := sdk.WorkflowNodeRunHookEvent{
 			WorkflowNodeHookUUID: t.UUID,
 		}
@@ -0,0 +1,29 @@
+package migrateservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/cds/engine/api/database/dbpartition"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// maintainPartitions creates the next partitions of the logs and runs tables and drops the expired ones, at startup
+// then every hour.
+func (s *dbmigservice) maintainPartitions(ctx context.Context) {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+
+	for {
+		s.currentStatus.partitionsErr = dbpartition.Maintain(ctx, s.db, time.Now(), s.cfg.Partitions.Premake, s.cfg.Partitions.Retention)
+		if s.currentStatus.partitionsErr != nil {
+			log.Error(ctx, "DBMigrate> Partitions maintenance failed: %v", s.currentStatus.partitionsErr)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
@@ -24,9 +24,11 @@ type dbmigservice struct {
 	cfg           Configuration
 	Router        *api.Router
 	currentStatus struct {
-		err        error
-		migrations []sdk.DatabaseMigrationStatus
+		err           error
+		migrations    []sdk.DatabaseMigrationStatus
+		partitionsErr error
 	}
+	db *gorp.DbMap
 }
 
 var _ service.BeforeStart = new(dbmigservice)
@@ -41,8 +43,12 @@ type Configuration struct {
 		Port     int    `toml:"port" default:"8087" json:"port"`
 		Insecure bool   `toml:"insecure" default:"false" commented:"true" comment:"sslInsecureSkipVerify, set to true if you use a self-signed SSL on CDS API" json:"insecure"`
 	} `toml:"http" comment:"######################\n CDS DB Migrate HTTP Configuration \n######################" json:"http"`
-	API service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
-	DB  database.DBConfiguration        `toml:"db" comment:"################################\n Postgresql Database settings \n###############################" json:"db"`
+	API        service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
+	DB         database.DBConfiguration        `toml:"db" comment:"################################\n Postgresql Database settings \n###############################" json:"db"`
+	Partitions struct {
+		Premake   int `toml:"premake" default:"2" comment:"Number of monthly partitions of the logs and runs tables created in advance" json:"premake"`
+		Retention int `toml:"retention" default:"0" comment:"Number of months of logs and runs kept, the older partitions are detached and dropped whatever the retention rules of the workflows. 0 keeps all the logs and runs" json:"retention"`
+	} `toml:"partitions" comment:"######################\n Partitions of the logs and runs tables \n######################" json:"partitions"`
 }
 
 // New instanciates a new API object
@@ -128,8 +134,9 @@ func (s *dbmigservice) BeforeStart(ctx context.Context) error {
 	}
 	s.currentStatus.migrations = status
 
-	// From now the database access won't be used. Erase the configuration...
+	// From now the database access is only used to maintain the partitions. Erase the configuration...
 	// This limits the attack surface
+	s.db = dbConn.GetDBMap()
 	s.cfg.DB = database.DBConfiguration{}
 
 	return nil
@@ -149,6 +156,10 @@ func (s *dbmigservice) Serve(ctx context.Context) error {
 		MaxHeaderBytes: 1 << 20,
 	}
 
+	if s.currentStatus.err == nil {
+		sdk.GoRoutine(ctx, "dbmigrate.maintainPartitions", s.maintainPartitions)
+	}
+
 	go func() {
 		//Start the http server
 		log.Info(ctx, "DBMigrate> Starting HTTP Server on port %d", s.cfg.HTTP.Port)
@@ -169,6 +180,15 @@ func (s *dbmigservice) Serve(ctx context.Context) error {
 
 func (s *dbmigservice) Status(ctx context.Context) sdk.MonitoringStatus {
 	response := s.CommonMonitoring()
+	if s.currentStatus.partitionsErr != nil {
+		response.Lines = append(response.Lines,
+			sdk.MonitoringStatusLine{
+				Component: "Partitions",
+				Value:     s.currentStatus.partitionsErr.Error(),
+				Status:    sdk.MonitoringStatusAlert,
+			},
+		)
+	}
 	if s.currentStatus.err != nil {
 		response.Lines = append(response.Lines,
 			sdk.MonitoringStatusLine{
@@ -31,13 +31,7 @@ type Configuration struct {
 	} `toml:"http" comment:"######################\n CDS Repositories HTTP Configuration \n######################" json:"http"`
 	URL   string                          `default:"http://localhost:8085" json:"url"`
 	API   service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
-	Cache struct {
-		TTL   int `toml:"ttl" default:"60" json:"ttl"`
-		Redis struct {
-			Host     string `toml:"host" default:"localhost:6379" comment:"If your want to use a redis-sentinel based cluster, follow this syntax! <clustername>@sentinel1:26379,sentinel2:26379,sentinel3:26379" json:"host"`
-			Password string `toml:"password" json:"-"`
-		} `toml:"redis" json:"redis"`
-	} `toml:"cache" comment:"######################\n CDS Repositories Cache Settings \n######################" json:"cache"`
+	Cache cache.Configuration             `toml:"cache" comment:"######################\n CDS Repositories Cache Settings \n######################" json:"cache"`
 }
 
 // Repo retiens a sdk.OperationRepo from an sdk.Operation
@@ -5,6 +5,7 @@ import (
 	"crypto/rsa"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strconv"
@@ -54,9 +55,11 @@ type HandlerConfig struct {
 	EnableTracing    bool
 	AllowProvider    bool
 	AllowedTokens    []string
+	NeedSCIMToken    bool
 	AllowedScopes    []sdk.AuthConsumerScope
 	PermissionLevel  int
 	CleanURL         string
+	MaxBodySize      int64
 }
 
 // Accepted is a helper function used by asynchronous handlers
@@ -141,6 +144,9 @@ func UnmarshalBody(r *http.Request, i interface{}) error {
 	}
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrRequestEntityTooLarge) {
+			return err
+		}
 		return sdk.NewError(sdk.ErrWrongRequest, err)
 	}
 	defer r.Body.Close()
@@ -150,6 +156,57 @@ func UnmarshalBody(r *http.Request, i interface{}) error {
 	return nil
 }
 
+// LimitBody replaces the request body by a reader that returns sdk.ErrRequestEntityTooLarge
+// if more than limit bytes are read from it. Data is never buffered so it is safe to stream large bodies.
+func LimitBody(r *http.Request, limit int64) {
+	if r.Body == nil || limit <= 0 {
+		return
+	}
+	r.Body = &maxBytesReader{r: r.Body, n: limit}
+}
+
+// BodyReadError returns sdk.ErrRequestEntityTooLarge if the body size limit of given request was reached, else
+// it returns given error. It should be used when an error occurred while reading a body through an other reader.
+func BodyReadError(r *http.Request, err error) error {
+	if l, ok := r.Body.(*maxBytesReader); ok && l.err != nil && sdk.ErrorIs(l.err, sdk.ErrRequestEntityTooLarge) {
+		return l.err
+	}
+	return err
+}
+
+type maxBytesReader struct {
+	r   io.ReadCloser
+	n   int64 // remaining bytes
+	err error // sticky error
+}
+
+func (l *maxBytesReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// Try to read one more byte than remaining to detect oversized bodies
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) <= l.n {
+		l.n -= int64(n)
+		l.err = err
+		return n, err
+	}
+	n = int(l.n)
+	l.n = 0
+	l.err = sdk.WithStack(sdk.ErrRequestEntityTooLarge)
+	return n, l.err
+}
+
+func (l *maxBytesReader) Close() error {
+	return l.r.Close()
+}
+
 type httpVerifier struct {
 	sync.Mutex
 	pubKey *rsa.PublicKey
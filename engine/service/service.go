@@ -103,8 +103,9 @@ func (c *Common) Register(ctx context.Context, cfg sdk.ServiceConfig) error {
 			Type:    c.ServiceType,
 			Config:  cfg,
 		},
-		LastHeartbeat: time.Time{},
-		Version:       sdk.VERSION,
+		LastHeartbeat:   time.Time{},
+		Version:         sdk.VERSION,
+		ContractVersion: sdk.ServiceContractVersion,
 	}
 
 	if c.PrivateKey != nil {
@@ -45,7 +45,14 @@ type HatcheryCommonConfiguration struct {
 		MaxConcurrentProvisioning int  `toml:"maxConcurrentProvisioning" default:"10" comment:"Maximum allowed simultaneous workers provisioning" json:"maxConcurrentProvisioning"`
 		MaxConcurrentRegistering  int  `toml:"maxConcurrentRegistering" default:"2" comment:"Maximum allowed simultaneous workers registering. -1 to disable registering on this hatchery" json:"maxConcurrentRegistering"`
 		RegisterFrequency         int  `toml:"registerFrequency" default:"60" comment:"Check if some worker model have to be registered each n Seconds" json:"registerFrequency"`
-		WorkerLogsOptions         struct {
+		DrainTimeout              int  `toml:"drainTimeout" default:"0" comment:"On SIGTERM, drain the hatchery: stop provisioning new workers and wait for the running jobs at most n Seconds before stopping. 0 to stop immediately" json:"drainTimeout"`
+		WarmPool                  struct {
+			Enabled   bool `toml:"enabled" default:"false" comment:"Keep a pool of started workers waiting for jobs, sized for each worker model from the recent jobs rate and spawn duration" json:"enabled"`
+			MaxSize   int  `toml:"maxSize" default:"3" comment:"Maximum number of warm workers for each worker model" json:"maxSize"`
+			Window    int  `toml:"window" default:"600" comment:"Duration (in seconds) of the window used to compute the rate of incoming jobs" json:"window"`
+			Frequency int  `toml:"frequency" default:"30" comment:"Check the size of the warm pool each n Seconds" json:"frequency"`
+		} `toml:"warmPool" comment:"Warm pool of workers, to reduce the time in queue of bursty workloads" json:"warmPool"`
+		WorkerLogsOptions struct {
 			Graylog struct {
 				Host       string `toml:"host" comment:"Example: thot.ovh.com" json:"host"`
 				Port       int    `toml:"port" comment:"Example: 12202" json:"port"`
@@ -54,6 +61,7 @@ type HatcheryCommonConfiguration struct {
 				ExtraValue string `toml:"extraValue" comment:"value for extraKey field. For many keys: valueaaa,valuebbb" json:"-"`
 			} `toml:"graylog" json:"graylog"`
 		} `toml:"workerLogsOptions" comment:"Worker Log Configuration" json:"workerLogsOptions"`
+		WorkerToolRegistry string `toml:"workerToolRegistry" default:"" commented:"true" comment:"URL of the registry from which the workers download the tools required with a version (binary: name@version). The URL can contain the placeholders {name}, {version}, {os} and {arch}, default layout is {url}/{name}/{version}/{os}/{arch}/{name}" json:"workerToolRegistry"`
 	} `toml:"provision" json:"provision"`
 	LogOptions struct {
 		SpawnOptions struct {
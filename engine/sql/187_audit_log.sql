@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    method VARCHAR(10),
+    path TEXT,
+    route TEXT,
+    status_code INT,
+    entity_type VARCHAR(100),
+    entity_name VARCHAR(256),
+    project_key VARCHAR(256),
+    user_id VARCHAR(256),
+    username VARCHAR(256),
+    consumer_id VARCHAR(256),
+    consumer_type VARCHAR(100),
+    ip_address VARCHAR(256),
+    data_before JSONB,
+    data_after JSONB,
+    changes JSONB
+);
+SELECT create_index('audit_log', 'IDX_AUDIT_LOG_CREATED', 'created');
+SELECT create_index('audit_log', 'IDX_AUDIT_LOG_ENTITY', 'entity_type, project_key');
+
+-- +migrate Down
+DROP TABLE IF EXISTS audit_log;
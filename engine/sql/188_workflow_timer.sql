@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_timer
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    cron VARCHAR(256) NOT NULL,
+    timezone VARCHAR(256) NOT NULL DEFAULT 'UTC',
+    catch_up VARCHAR(10) NOT NULL DEFAULT 'skip',
+    payload JSONB,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    author_consumer_id VARCHAR(64) NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    next_execution TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_execution TIMESTAMP WITH TIME ZONE,
+    last_status VARCHAR(100) NOT NULL DEFAULT '',
+    last_error TEXT NOT NULL DEFAULT '',
+    last_run_number BIGINT NOT NULL DEFAULT 0,
+    missed_executions BIGINT NOT NULL DEFAULT 0
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TIMER_WORKFLOW', 'workflow_timer', 'workflow', 'workflow_id', 'id');
+SELECT create_unique_index('workflow_timer', 'IDX_WORKFLOW_TIMER_NAME_UNIQ', 'workflow_id,name');
+SELECT create_index('workflow_timer', 'IDX_WORKFLOW_TIMER_NEXT_EXECUTION', 'next_execution');
+
+CREATE TABLE IF NOT EXISTS api_leader_lease
+(
+    name VARCHAR(256) PRIMARY KEY,
+    holder VARCHAR(256) NOT NULL,
+    expire_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_timer;
+DROP TABLE IF EXISTS api_leader_lease;
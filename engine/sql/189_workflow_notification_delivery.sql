@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_notification_delivery
+(
+    id BIGSERIAL PRIMARY KEY,
+    uuid VARCHAR(36) NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    notification_id BIGINT NOT NULL,
+    node_name VARCHAR(256) NOT NULL DEFAULT '',
+    node_status VARCHAR(100) NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    status VARCHAR(100) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_attempt TIMESTAMP WITH TIME ZONE
+);
+SELECT create_index('workflow_notification_delivery', 'IDX_WORKFLOW_NOTIFICATION_DELIVERY_RUN', 'workflow_run_id');
+SELECT create_unique_index('workflow_notification_delivery', 'IDX_WORKFLOW_NOTIFICATION_DELIVERY_UUID', 'uuid');
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_notification_delivery;
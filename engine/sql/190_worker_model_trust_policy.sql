@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS worker_model_trust_policy
+(
+    id INT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    policy JSONB NOT NULL,
+    modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS worker_model_trust_policy;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE service ADD COLUMN IF NOT EXISTS version VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE service ADD COLUMN IF NOT EXISTS contract_version INT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE service DROP COLUMN IF EXISTS version;
+ALTER TABLE service DROP COLUMN IF EXISTS contract_version;
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_node_run_code_comment
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_node_run_id BIGINT NOT NULL,
+    workflow_node_job_run_id BIGINT NOT NULL,
+    path TEXT NOT NULL,
+    line INT NOT NULL DEFAULT 0,
+    message TEXT NOT NULL,
+    severity VARCHAR(32) NOT NULL DEFAULT 'warning'
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_CODE_COMMENT_NODE_RUN', 'workflow_node_run_code_comment', 'workflow_node_run', 'workflow_node_run_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_node_run_code_comment;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE "auth_consumer" ADD COLUMN IF NOT EXISTS project_key VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE "auth_consumer" ADD COLUMN IF NOT EXISTS expire_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS "IDX_AUTH_CONSUMER_PROJECT_KEY" ON "auth_consumer" (project_key);
+
+-- +migrate Down
+DROP INDEX IF EXISTS "IDX_AUTH_CONSUMER_PROJECT_KEY";
+ALTER TABLE "auth_consumer" DROP COLUMN IF EXISTS project_key;
+ALTER TABLE "auth_consumer" DROP COLUMN IF EXISTS expire_at;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE application ADD COLUMN IF NOT EXISTS repository_url TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE application DROP COLUMN IF EXISTS repository_url;
@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE workflow_run ADD COLUMN IF NOT EXISTS priority INT NOT NULL DEFAULT 0;
+ALTER TABLE workflow_node_run_job ADD COLUMN IF NOT EXISTS priority INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS project_queue_settings
+(
+    project_id BIGINT PRIMARY KEY,
+    priority INT NOT NULL DEFAULT 0,
+    max_building_jobs INT NOT NULL DEFAULT 0
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_QUEUE_SETTINGS_PROJECT', 'project_queue_settings', 'project', 'project_id', 'id');
+
+-- +migrate Down
+ALTER TABLE workflow_run DROP COLUMN IF EXISTS priority;
+ALTER TABLE workflow_node_run_job DROP COLUMN IF EXISTS priority;
+DROP TABLE IF EXISTS project_queue_settings;
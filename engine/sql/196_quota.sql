@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS quota
+(
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT,
+    group_id BIGINT,
+    max_building_jobs INT NOT NULL DEFAULT 0,
+    max_cpus DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_memory BIGINT NOT NULL DEFAULT 0,
+    max_artifact_storage BIGINT NOT NULL DEFAULT 0
+);
+SELECT create_foreign_key_idx_cascade('FK_QUOTA_PROJECT', 'quota', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_QUOTA_GROUP', 'quota', 'group', 'group_id', 'id');
+SELECT create_unique_index('quota', 'IDX_QUOTA_PROJECT_ID', 'project_id');
+SELECT create_unique_index('quota', 'IDX_QUOTA_GROUP_ID', 'group_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS quota;
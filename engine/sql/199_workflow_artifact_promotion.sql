@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_artifact_promotion
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    tag VARCHAR(256) NOT NULL,
+    mode VARCHAR(32) NOT NULL,
+    container_path TEXT NOT NULL,
+    storage_project_key VARCHAR(256) NOT NULL,
+    project_integration_id BIGINT,
+    size BIGINT NOT NULL DEFAULT 0,
+    perm INT NOT NULL DEFAULT 0,
+    md5sum TEXT NOT NULL DEFAULT '',
+    sha512sum TEXT NOT NULL DEFAULT '',
+    source_workflow_run_id BIGINT NOT NULL,
+    source_artifact_id BIGINT NOT NULL,
+    provenance JSONB,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_ARTIFACT_PROMOTION_WORKFLOW', 'workflow_artifact_promotion', 'workflow', 'workflow_id', 'id');
+SELECT create_index('workflow_artifact_promotion', 'IDX_WORKFLOW_ARTIFACT_PROMOTION_SOURCE_RUN', 'source_workflow_run_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_artifact_promotion;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_node_run_artifact_attestation
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_node_run_artifact_id BIGINT NOT NULL,
+    key_name VARCHAR(256) NOT NULL,
+    key_id VARCHAR(256) NOT NULL DEFAULT '',
+    envelope JSONB NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_ARTIFACT_ATTESTATION_ARTIFACT', 'workflow_node_run_artifact_attestation', 'workflow_node_run_artifacts', 'workflow_node_run_artifact_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_node_run_artifact_attestation;
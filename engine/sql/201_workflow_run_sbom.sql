@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_run_sbom
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    artifact VARCHAR(256) NOT NULL DEFAULT '',
+    format VARCHAR(32) NOT NULL,
+    document JSONB NOT NULL,
+    components JSONB NOT NULL DEFAULT '[]',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_SBOM_WORKFLOW_RUN', 'workflow_run_sbom', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_index('workflow_run_sbom', 'IDX_WORKFLOW_RUN_SBOM_WORKFLOW', 'workflow_id');
+CREATE INDEX IF NOT EXISTS IDX_WORKFLOW_RUN_SBOM_COMPONENTS ON workflow_run_sbom USING GIN (components jsonb_path_ops);
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_run_sbom;
@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS grpc_plugin_version
+(
+    id BIGSERIAL PRIMARY KEY,
+    grpc_plugin_id BIGINT NOT NULL,
+    version VARCHAR(256) NOT NULL,
+    documentation TEXT NOT NULL DEFAULT '',
+    binaries JSONB NOT NULL DEFAULT '[]',
+    publisher VARCHAR(256) NOT NULL DEFAULT '',
+    published TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_GRPC_PLUGIN_VERSION_GRPC_PLUGIN', 'grpc_plugin_version', 'grpc_plugin', 'grpc_plugin_id', 'id');
+SELECT create_unique_index('grpc_plugin_version', 'IDX_GRPC_PLUGIN_VERSION_UNIQ', 'grpc_plugin_id,version');
+
+-- +migrate Down
+DROP TABLE IF EXISTS grpc_plugin_version;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE grpc_plugin ADD COLUMN IF NOT EXISTS sandbox JSONB NOT NULL DEFAULT '{}';
+
+-- +migrate Down
+ALTER TABLE grpc_plugin DROP COLUMN IF EXISTS sandbox;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_run_test_result
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    run_number BIGINT NOT NULL,
+    vcs_hash VARCHAR(256) NOT NULL DEFAULT '',
+    vcs_branch VARCHAR(256) NOT NULL DEFAULT '',
+    suite TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    duration DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_TEST_RESULT_WORKFLOW_RUN', 'workflow_run_test_result', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_index('workflow_run_test_result', 'IDX_WORKFLOW_RUN_TEST_RESULT_WORKFLOW', 'workflow_id,created');
+SELECT create_index('workflow_run_test_result', 'IDX_WORKFLOW_RUN_TEST_RESULT_NAME', 'suite,name');
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_run_test_result;
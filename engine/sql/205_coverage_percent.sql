@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE workflow_node_run_coverage ADD COLUMN IF NOT EXISTS percent DOUBLE PRECISION NOT NULL DEFAULT 0;
+UPDATE workflow_node_run_coverage SET percent = (report->>'covered_lines')::DOUBLE PRECISION * 100 / (report->>'total_lines')::DOUBLE PRECISION
+WHERE (report->>'total_lines')::DOUBLE PRECISION > 0;
+
+-- +migrate Down
+ALTER TABLE workflow_node_run_coverage DROP COLUMN IF EXISTS percent;
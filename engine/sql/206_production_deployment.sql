@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE w_node_context ADD COLUMN IF NOT EXISTS production_deployment BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE workflow_node_run ADD COLUMN IF NOT EXISTS production_deployment BOOLEAN NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS IDX_WORKFLOW_NODE_RUN_PRODUCTION_DEPLOYMENT ON workflow_node_run (workflow_id, done) WHERE production_deployment;
+
+-- +migrate Down
+DROP INDEX IF EXISTS IDX_WORKFLOW_NODE_RUN_PRODUCTION_DEPLOYMENT;
+ALTER TABLE w_node_context DROP COLUMN IF EXISTS production_deployment;
+ALTER TABLE workflow_node_run DROP COLUMN IF EXISTS production_deployment;
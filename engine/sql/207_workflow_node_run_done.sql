@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS IDX_WORKFLOW_NODE_RUN_WORKFLOW_DONE ON workflow_node_run (workflow_id, done);
+
+-- +migrate Down
+DROP INDEX IF EXISTS IDX_WORKFLOW_NODE_RUN_WORKFLOW_DONE;
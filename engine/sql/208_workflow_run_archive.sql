@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS project_retention_settings
+(
+    project_id BIGINT PRIMARY KEY,
+    archive_after_days INT NOT NULL DEFAULT 0,
+    integration_name VARCHAR(256) NOT NULL DEFAULT ''
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_RETENTION_SETTINGS_PROJECT', 'project_retention_settings', 'project', 'project_id', 'id');
+
+CREATE TABLE IF NOT EXISTS workflow_run_archive
+(
+    workflow_run_id BIGINT PRIMARY KEY,
+    integration_name VARCHAR(256) NOT NULL,
+    container VARCHAR(256) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_ARCHIVE_WORKFLOW_RUN', 'workflow_run_archive', 'workflow_run', 'workflow_run_id', 'id');
+
+ALTER TABLE workflow_run ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE workflow_node_run_artifacts ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT false;
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_run_archive;
+DROP TABLE IF EXISTS project_retention_settings;
+ALTER TABLE workflow_run DROP COLUMN IF EXISTS archived;
+ALTER TABLE workflow_node_run_artifacts DROP COLUMN IF EXISTS archived;
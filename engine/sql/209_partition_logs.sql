@@ -0,0 +1,69 @@
+-- +migrate Up
+-- The logs tables are partitioned by month on their new created column. The existing logs are kept in a legacy
+-- partition, the next partitions are created by the migrate service. Requires PostgreSQL >= 11.
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t TEXT;
+    i INTEGER;
+    current_month TIMESTAMP WITH TIME ZONE := date_trunc('month', now() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC';
+    month TIMESTAMP WITH TIME ZONE;
+BEGIN
+    IF current_setting('server_version_num')::INTEGER < 110000 THEN
+        RAISE NOTICE 'PostgreSQL >= 11 is required to partition the logs tables';
+        RETURN;
+    END IF;
+
+    FOREACH t IN ARRAY ARRAY['workflow_node_run_job_logs', 'requirement_service_logs'] LOOP
+        EXECUTE format('ALTER TABLE %I RENAME TO %I', t, t || '_legacy');
+        EXECUTE format('ALTER TABLE %I ADD COLUMN created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT %L', t || '_legacy', '1970-01-01 00:00:00+00');
+
+        EXECUTE format('CREATE TABLE %I (LIKE %I INCLUDING DEFAULTS) PARTITION BY RANGE (created)', t, t || '_legacy');
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN created SET DEFAULT now()', t);
+        EXECUTE format('ALTER SEQUENCE %s OWNED BY %I.id', pg_get_serial_sequence(t || '_legacy', 'id'), t);
+
+        EXECUTE format('CREATE INDEX %I ON %I (id)', 'idx_' || t || '_id', t);
+        EXECUTE format('CREATE INDEX %I ON %I (workflow_node_run_id)', 'idx_' || t || '_node_run', t);
+        EXECUTE format('ALTER TABLE %I ADD CONSTRAINT %I FOREIGN KEY (workflow_node_run_id) REFERENCES workflow_node_run (id) ON DELETE CASCADE', t, 'fk_' || t || '_node_run');
+
+        EXECUTE format('ALTER TABLE %I ATTACH PARTITION %I FOR VALUES FROM (MINVALUE) TO (%L)', t, t || '_legacy', current_month);
+        FOR i IN 0..1 LOOP
+            month := (date_trunc('month', now() AT TIME ZONE 'UTC') + i * INTERVAL '1 month') AT TIME ZONE 'UTC';
+            EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)',
+                t || '_' || to_char(month AT TIME ZONE 'UTC', 'YYYYMM'), t,
+                month, (date_trunc('month', now() AT TIME ZONE 'UTC') + (i + 1) * INTERVAL '1 month') AT TIME ZONE 'UTC');
+        END LOOP;
+        -- catches the logs if the migrate service didn't create the partition of the month
+        EXECUTE format('CREATE TABLE %I PARTITION OF %I DEFAULT', t || '_default', t);
+    END LOOP;
+
+    CREATE INDEX idx_workflow_node_run_job_logs_job_step ON workflow_node_run_job_logs (workflow_node_run_job_id, step_order);
+    CREATE INDEX idx_requirement_service_logs_job ON requirement_service_logs (workflow_node_run_job_id);
+END $$;
+-- +migrate StatementEnd
+
+-- +migrate Down
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'workflow_node_run_job_logs' AND relkind = 'p') THEN
+        RETURN;
+    END IF;
+
+    FOREACH t IN ARRAY ARRAY['workflow_node_run_job_logs', 'requirement_service_logs'] LOOP
+        EXECUTE format('CREATE TABLE %I (LIKE %I INCLUDING DEFAULTS)', t || '_plain', t);
+        EXECUTE format('INSERT INTO %I SELECT * FROM %I', t || '_plain', t);
+        EXECUTE format('ALTER SEQUENCE %s OWNED BY %I.id', pg_get_serial_sequence(t, 'id'), t || '_plain');
+        EXECUTE format('DROP TABLE %I', t);
+        EXECUTE format('ALTER TABLE %I RENAME TO %I', t || '_plain', t);
+        EXECUTE format('ALTER TABLE %I DROP COLUMN created', t);
+        EXECUTE format('ALTER TABLE %I ADD PRIMARY KEY (id)', t);
+    END LOOP;
+
+    PERFORM create_index('workflow_node_run_job_logs', 'IDX_WORKFLOW_LOG_STEP', 'workflow_node_run_job_id,step_order');
+    PERFORM create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_JOBS_WORKFLOW_NODE_RUN', 'workflow_node_run_job_logs', 'workflow_node_run', 'workflow_node_run_id', 'id');
+    PERFORM create_foreign_key_idx_cascade('FK_REQUIREMENT_SERVICE_LOGS_WORKFLOW_NODE_RUN', 'requirement_service_logs', 'workflow_node_run', 'workflow_node_run_id', 'id');
+END $$;
+-- +migrate StatementEnd
@@ -0,0 +1,5 @@
+-- +migrate Up
+SELECT create_index('workflow_run', 'IDX_WORKFLOW_RUN_SEARCH_START', 'project_id, start, id');
+
+-- +migrate Down
+DROP INDEX idx_workflow_run_search_start;
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE "action" ADD COLUMN project_id BIGINT;
+SELECT create_foreign_key_idx_cascade('FK_ACTION_PROJECT', 'action', 'project', 'project_id', 'id');
+
+-- +migrate Down
+ALTER TABLE "action" DROP COLUMN project_id;
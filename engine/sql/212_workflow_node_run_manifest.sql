@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_node_run_manifest
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    num BIGINT NOT NULL,
+    sub_num BIGINT NOT NULL,
+    jobs JSONB NOT NULL DEFAULT '[]',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_MANIFEST_WORKFLOW_RUN', 'workflow_node_run_manifest', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_unique_index('workflow_node_run_manifest', 'IDX_WORKFLOW_NODE_RUN_MANIFEST_NODE_RUN', 'workflow_node_run_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_node_run_manifest;
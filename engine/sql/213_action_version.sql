@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS action_version
+(
+    id BIGSERIAL PRIMARY KEY,
+    action_id BIGINT NOT NULL,
+    version VARCHAR(256) NOT NULL,
+    action JSONB NOT NULL,
+    publisher VARCHAR(256) NOT NULL DEFAULT '',
+    published TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_ACTION_VERSION_ACTION', 'action_version', 'action', 'action_id', 'id');
+SELECT create_unique_index('action_version', 'IDX_ACTION_VERSION_UNIQ', 'action_id,version');
+
+ALTER TABLE workflow ADD COLUMN IF NOT EXISTS pinned_versions JSONB NOT NULL DEFAULT '[]';
+
+-- +migrate Down
+DROP TABLE IF EXISTS action_version;
+ALTER TABLE workflow DROP COLUMN IF EXISTS pinned_versions;
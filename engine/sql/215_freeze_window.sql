@@ -0,0 +1,46 @@
+-- +migrate Up
+ALTER TABLE workflow ADD COLUMN IF NOT EXISTS deployment BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE workflow_run ADD COLUMN IF NOT EXISTS freeze_override JSONB;
+ALTER TABLE workflow_node_run ADD COLUMN IF NOT EXISTS frozen BOOLEAN NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS IDX_WORKFLOW_NODE_RUN_FROZEN ON workflow_node_run (id) WHERE frozen;
+
+CREATE TABLE IF NOT EXISTS project_freeze_window
+(
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    environment VARCHAR(256) NOT NULL DEFAULT '',
+    start_date TIMESTAMP WITH TIME ZONE,
+    end_date TIMESTAMP WITH TIME ZONE,
+    cron VARCHAR(256) NOT NULL DEFAULT '',
+    duration BIGINT NOT NULL DEFAULT 0,
+    timezone VARCHAR(256) NOT NULL DEFAULT '',
+    policy VARCHAR(64) NOT NULL,
+    override_group_id BIGINT,
+    author VARCHAR(256) NOT NULL DEFAULT '',
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_FREEZE_WINDOW_PROJECT', 'project_freeze_window', 'project', 'project_id', 'id');
+ALTER TABLE project_freeze_window ADD CONSTRAINT FK_PROJECT_FREEZE_WINDOW_GROUP FOREIGN KEY (override_group_id) REFERENCES "group"(id) ON DELETE SET NULL;
+SELECT create_unique_index('project_freeze_window', 'IDX_PROJECT_FREEZE_WINDOW_NAME', 'project_id,name');
+
+CREATE TABLE IF NOT EXISTS project_freeze_override
+(
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    workflow_name VARCHAR(256) NOT NULL,
+    workflow_run_number BIGINT NOT NULL,
+    freeze_windows JSONB,
+    username VARCHAR(256) NOT NULL,
+    reason TEXT NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_FREEZE_OVERRIDE_PROJECT', 'project_freeze_override', 'project', 'project_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS project_freeze_override;
+DROP TABLE IF EXISTS project_freeze_window;
+DROP INDEX IF EXISTS IDX_WORKFLOW_NODE_RUN_FROZEN;
+ALTER TABLE workflow_node_run DROP COLUMN IF EXISTS frozen;
+ALTER TABLE workflow_run DROP COLUMN IF EXISTS freeze_override;
+ALTER TABLE workflow DROP COLUMN IF EXISTS deployment;
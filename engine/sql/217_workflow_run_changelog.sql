@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_run_changelog
+(
+    id BIGSERIAL PRIMARY KEY,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    node_name VARCHAR(256) NOT NULL,
+    repository VARCHAR(256) NOT NULL,
+    branch VARCHAR(256) NOT NULL DEFAULT '',
+    from_number BIGINT NOT NULL DEFAULT 0,
+    from_hash VARCHAR(256) NOT NULL DEFAULT '',
+    to_hash VARCHAR(256) NOT NULL,
+    commits JSONB NOT NULL DEFAULT '[]',
+    truncated BOOLEAN NOT NULL DEFAULT false,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_RUN_CHANGELOG_WORKFLOW_RUN', 'workflow_run_changelog', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_unique_index('workflow_run_changelog', 'IDX_WORKFLOW_RUN_CHANGELOG_NODE_RUN', 'workflow_node_run_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_run_changelog;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS environment_deployment
+(
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    application_id BIGINT NOT NULL,
+    environment_id BIGINT NOT NULL,
+    workflow_id BIGINT NOT NULL,
+    workflow_run_id BIGINT NOT NULL,
+    workflow_node_run_id BIGINT NOT NULL,
+    run_number BIGINT NOT NULL,
+    node_name VARCHAR(256) NOT NULL,
+    version VARCHAR(256) NOT NULL DEFAULT '',
+    vcs_repository VARCHAR(256) NOT NULL DEFAULT '',
+    vcs_branch VARCHAR(256) NOT NULL DEFAULT '',
+    vcs_tag VARCHAR(256) NOT NULL DEFAULT '',
+    vcs_hash VARCHAR(256) NOT NULL DEFAULT '',
+    artifacts JSONB NOT NULL DEFAULT '[]',
+    deployed_by VARCHAR(256) NOT NULL DEFAULT '',
+    deployed TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_PROJECT', 'environment_deployment', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_APPLICATION', 'environment_deployment', 'application', 'application_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_ENVIRONMENT', 'environment_deployment', 'environment', 'environment_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_DEPLOYMENT_WORKFLOW', 'environment_deployment', 'workflow', 'workflow_id', 'id');
+SELECT create_unique_index('environment_deployment', 'IDX_ENVIRONMENT_DEPLOYMENT_UNIQ', 'application_id,environment_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS environment_deployment;
@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS environment_rollout
+(
+    environment_id BIGINT PRIMARY KEY,
+    rollout JSONB NOT NULL
+);
+SELECT create_foreign_key_idx_cascade('FK_ENVIRONMENT_ROLLOUT_ENVIRONMENT', 'environment_rollout', 'environment', 'environment_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS environment_rollout;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE w_node_context ADD COLUMN IF NOT EXISTS verification_of VARCHAR(256);
+ALTER TABLE w_node_context ADD COLUMN IF NOT EXISTS rollback_node VARCHAR(256);
+
+-- +migrate Down
+ALTER TABLE w_node_context DROP COLUMN IF EXISTS verification_of;
+ALTER TABLE w_node_context DROP COLUMN IF EXISTS rollback_node;
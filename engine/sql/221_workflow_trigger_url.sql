@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS workflow_trigger_url
+(
+    id VARCHAR(36) PRIMARY KEY,
+    workflow_id BIGINT NOT NULL,
+    consumer_id VARCHAR(36) NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    expire TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked BOOLEAN NOT NULL DEFAULT false
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TRIGGER_URL_WORKFLOW', 'workflow_trigger_url', 'workflow', 'workflow_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TRIGGER_URL_CONSUMER', 'workflow_trigger_url', 'auth_consumer', 'consumer_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_trigger_url;
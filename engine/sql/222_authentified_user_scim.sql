@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS authentified_user_scim
+(
+    authentified_user_id VARCHAR(36) PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_AUTHENTIFIED_USER_SCIM_USER', 'authentified_user_scim', 'authentified_user', 'authentified_user_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS authentified_user_scim;
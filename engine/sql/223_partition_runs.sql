@@ -0,0 +1,140 @@
+-- +migrate Up
+-- The runs tables are partitioned by month on their id, so the foreign keys referencing them are kept: the ids of a
+-- month are allocated in its own range of 2^32 ids, the migrate service moves the sequences to the range of the
+-- current month. The existing runs are kept in a legacy partition. Requires PostgreSQL >= 12.
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t TEXT;
+    i INTEGER;
+    r RECORD;
+    max_id BIGINT;
+    index_names TEXT[];
+    index_defs TEXT[];
+    month TIMESTAMP WITH TIME ZONE;
+    month_id BIGINT;
+    current_id BIGINT := ((date_part('year', now() AT TIME ZONE 'UTC') - 1970) * 12 + date_part('month', now() AT TIME ZONE 'UTC') - 1)::BIGINT << 32;
+BEGIN
+    IF current_setting('server_version_num')::INTEGER < 120000 THEN
+        RAISE NOTICE 'PostgreSQL >= 12 is required to partition the runs tables';
+        RETURN;
+    END IF;
+
+    -- workflow_run first, the foreign key of workflow_node_run is recreated on the partitioned workflow_run
+    FOREACH t IN ARRAY ARRAY['workflow_run', 'workflow_node_run'] LOOP
+        EXECUTE format('SELECT COALESCE(MAX(id), 0) FROM %I', t) INTO max_id;
+        IF max_id >= current_id THEN
+            RAISE EXCEPTION 'the ids of % exceed the range of the current month', t;
+        END IF;
+
+        EXECUTE format('ALTER TABLE %I RENAME TO %I', t, t || '_legacy');
+
+        -- the indexes are recreated with their names on the partitioned table
+        index_names := ARRAY[]::TEXT[];
+        index_defs := ARRAY[]::TEXT[];
+        FOR r IN SELECT c.relname AS name, pg_get_indexdef(x.indexrelid) AS def, x.indisprimary AS pkey
+                 FROM pg_index x JOIN pg_class c ON c.oid = x.indexrelid
+                 WHERE x.indrelid = (t || '_legacy')::regclass LOOP
+            IF NOT r.pkey THEN
+                index_names := index_names || r.name;
+                index_defs := index_defs || r.def;
+            END IF;
+            EXECUTE format('ALTER INDEX %I RENAME TO %I', r.name, left(r.name, 56) || '_legacy');
+        END LOOP;
+
+        EXECUTE format('CREATE TABLE %I (LIKE %I INCLUDING DEFAULTS) PARTITION BY RANGE (id)', t, t || '_legacy');
+        EXECUTE format('ALTER SEQUENCE %s OWNED BY %I.id', pg_get_serial_sequence(t || '_legacy', 'id'), t);
+        EXECUTE format('ALTER TABLE %I ATTACH PARTITION %I FOR VALUES FROM (MINVALUE) TO (%s)', t, t || '_legacy', current_id);
+        EXECUTE format('ALTER TABLE %I ADD CONSTRAINT %I PRIMARY KEY (id)', t, t || '_pkey');
+
+        -- the unique indexes of a partitioned table must contain its partition key: they are only unique in each
+        -- partition, the partitions have their own unique index
+        FOR i IN 1..COALESCE(array_length(index_names, 1), 0) LOOP
+            EXECUTE format('CREATE INDEX %I ON %I USING %s', index_names[i], t, substring(index_defs[i] from ' USING (.*)$'));
+        END LOOP;
+
+        FOR r IN SELECT conname, pg_get_constraintdef(oid) AS def FROM pg_constraint
+                 WHERE conrelid = (t || '_legacy')::regclass AND contype = 'f' LOOP
+            EXECUTE format('ALTER TABLE %I ADD CONSTRAINT %I %s', t, r.conname, r.def);
+        END LOOP;
+
+        FOR r IN SELECT conrelid::regclass AS tbl, conname, pg_get_constraintdef(oid) AS def FROM pg_constraint
+                 WHERE confrelid = (t || '_legacy')::regclass AND contype = 'f' AND conparentid = 0 LOOP
+            EXECUTE format('ALTER TABLE %s DROP CONSTRAINT %I', r.tbl, r.conname);
+            EXECUTE format('ALTER TABLE %s ADD CONSTRAINT %I %s', r.tbl, r.conname,
+                replace(r.def, 'REFERENCES ' || t || '_legacy(', 'REFERENCES ' || t || '('));
+        END LOOP;
+
+        FOR i IN 0..1 LOOP
+            month := (date_trunc('month', now() AT TIME ZONE 'UTC') + i * INTERVAL '1 month') AT TIME ZONE 'UTC';
+            month_id := ((date_part('year', month AT TIME ZONE 'UTC') - 1970) * 12 + date_part('month', month AT TIME ZONE 'UTC') - 1)::BIGINT << 32;
+            EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%s) TO (%s)',
+                t || '_' || to_char(month AT TIME ZONE 'UTC', 'YYYYMM'), t, month_id, month_id + (1::BIGINT << 32));
+            IF t = 'workflow_node_run' THEN
+                EXECUTE format('CREATE UNIQUE INDEX %I ON %I (workflow_run_id, workflow_node_id, num, sub_num)',
+                    t || '_' || to_char(month AT TIME ZONE 'UTC', 'YYYYMM') || '_unique', t || '_' || to_char(month AT TIME ZONE 'UTC', 'YYYYMM'));
+            END IF;
+        END LOOP;
+        -- catches the runs if the migrate service didn't create the partition of the month
+        EXECUTE format('CREATE TABLE %I PARTITION OF %I DEFAULT', t || '_default', t);
+
+        -- the next ids are allocated in the range of the current month
+        PERFORM setval(pg_get_serial_sequence(t, 'id'), current_id - 1);
+    END LOOP;
+END $$;
+-- +migrate StatementEnd
+
+-- +migrate Down
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t TEXT;
+    i INTEGER;
+    r RECORD;
+    index_names TEXT[];
+    index_defs TEXT[];
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_class WHERE relname = 'workflow_run' AND relkind = 'p') THEN
+        RETURN;
+    END IF;
+
+    FOREACH t IN ARRAY ARRAY['workflow_run', 'workflow_node_run'] LOOP
+        EXECUTE format('CREATE TABLE %I (LIKE %I INCLUDING DEFAULTS)', t || '_plain', t);
+        EXECUTE format('INSERT INTO %I SELECT * FROM %I', t || '_plain', t);
+        EXECUTE format('ALTER TABLE %I ADD PRIMARY KEY (id)', t || '_plain');
+
+        FOR r IN SELECT conrelid::regclass AS tbl, conname, pg_get_constraintdef(oid) AS def FROM pg_constraint
+                 WHERE confrelid = t::regclass AND contype = 'f' AND conparentid = 0 LOOP
+            EXECUTE format('ALTER TABLE %s DROP CONSTRAINT %I', r.tbl, r.conname);
+            EXECUTE format('ALTER TABLE %s ADD CONSTRAINT %I %s', r.tbl, r.conname,
+                replace(r.def, 'REFERENCES ' || t || '(', 'REFERENCES ' || t || '_plain('));
+        END LOOP;
+
+        FOR r IN SELECT conname, pg_get_constraintdef(oid) AS def FROM pg_constraint
+                 WHERE conrelid = t::regclass AND contype = 'f' AND conparentid = 0 LOOP
+            EXECUTE format('ALTER TABLE %I ADD CONSTRAINT %I %s', t || '_plain', r.conname, r.def);
+        END LOOP;
+
+        index_names := ARRAY[]::TEXT[];
+        index_defs := ARRAY[]::TEXT[];
+        FOR r IN SELECT c.relname AS name, pg_get_indexdef(x.indexrelid) AS def
+                 FROM pg_index x JOIN pg_class c ON c.oid = x.indexrelid
+                 WHERE x.indrelid = t::regclass AND NOT x.indisprimary LOOP
+            index_names := index_names || r.name;
+            index_defs := index_defs || r.def;
+        END LOOP;
+
+        EXECUTE format('ALTER SEQUENCE %s OWNED BY %I.id', pg_get_serial_sequence(t, 'id'), t || '_plain');
+        EXECUTE format('DROP TABLE %I', t);
+        EXECUTE format('ALTER TABLE %I RENAME TO %I', t || '_plain', t);
+        EXECUTE format('ALTER INDEX %I RENAME TO %I', t || '_plain_pkey', t || '_pkey');
+
+        FOR i IN 1..COALESCE(array_length(index_names, 1), 0) LOOP
+            EXECUTE format('CREATE INDEX %I ON %I USING %s', index_names[i], t, substring(index_defs[i] from ' USING (.*)$'));
+        END LOOP;
+    END LOOP;
+
+    DROP INDEX idx_workflow_node_run_subnum;
+    PERFORM create_unique_index('workflow_node_run', 'idx_workflow_node_run_subnum', 'workflow_run_id,workflow_node_id, num, sub_num');
+END $$;
+-- +migrate StatementEnd
@@ -3,7 +3,11 @@ package main
 import (
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/chatops"
 	"github.com/ovh/cds/engine/elasticsearch"
+	"github.com/ovh/cds/engine/hatchery/ec2"
+	"github.com/ovh/cds/engine/hatchery/ecs"
+	"github.com/ovh/cds/engine/hatchery/gce"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -39,6 +43,7 @@ type Configuration struct {
 	VCS             *vcs.Configuration            `toml:"vcs" comment:"######################\n CDS VCS Settings \n######################" json:"vcs"`
 	Repositories    *repositories.Configuration   `toml:"repositories" comment:"######################\n CDS Repositories Settings \n######################" json:"repositories"`
 	ElasticSearch   *elasticsearch.Configuration  `toml:"elasticsearch" comment:"######################\n CDS ElasticSearch Settings \n This is use for CDS timeline and is optional\n######################" json:"elasticsearch"`
+	ChatOps         *chatops.Configuration        `toml:"chatops" comment:"######################\n CDS ChatOps Settings \n This is used to control workflow runs from Slack and is optional\n######################" json:"chatops"`
 	DatabaseMigrate *migrateservice.Configuration `toml:"databaseMigrate" comment:"######################\n CDS DB Migrate Service Settings \n######################" json:"databaseMigrate"`
 }
 
@@ -50,4 +55,7 @@ type HatcheryConfiguration struct {
 	Openstack  *openstack.HatcheryConfiguration  `toml:"openstack" comment:"Hatchery OpenStack. Doc: https://ovh.github.io/cds/docs/integrations/hatchery/openstack/" json:"openstack"`
 	Swarm      *swarm.HatcheryConfiguration      `toml:"swarm" comment:"Hatchery Swarm. Doc: https://ovh.github.io/cds/docs/integrations/swarm/" json:"swarm"`
 	VSphere    *vsphere.HatcheryConfiguration    `toml:"vsphere" comment:"Hatchery VShpere. Doc: https://ovh.github.io/cds/docs/integrations/hatchery/vsphere/" json:"vshpere"`
+	EC2        *ec2.HatcheryConfiguration        `toml:"ec2" comment:"Hatchery AWS EC2. Doc: https://ovh.github.io/cds/docs/integrations/aws/aws_ec2/" json:"ec2"`
+	GCE        *gce.HatcheryConfiguration        `toml:"gce" comment:"Hatchery Google Compute Engine. Doc: https://ovh.github.io/cds/docs/integrations/gce/" json:"gce"`
+	ECS        *ecs.HatcheryConfiguration        `toml:"ecs" comment:"Hatchery AWS ECS Fargate. Doc: https://ovh.github.io/cds/docs/integrations/aws/aws_ecs/" json:"ecs"`
 }
@@ -0,0 +1,74 @@
+package codecommit
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Branches returns the branches of a repository
+func (c *codecommitClient) Branches(ctx context.Context, fullname string) ([]sdk.VCSBranch, error) {
+	repo, err := c.repository(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []sdk.VCSBranch
+	input := &codecommit.ListBranchesInput{RepositoryName: aws.String(fullname)}
+	for {
+		out, err := c.client.ListBranchesWithContext(ctx, input)
+		if err != nil {
+			return nil, sdk.WrapError(convertError(err), "cannot list branches of %s", fullname)
+		}
+		for _, name := range out.Branches {
+			b, err := c.branch(ctx, fullname, aws.StringValue(name))
+			if err != nil {
+				return nil, err
+			}
+			branches = append(branches, toVCSBranch(b, aws.StringValue(repo.DefaultBranch)))
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return branches, nil
+}
+
+// Branch returns a branch of a repository
+func (c *codecommitClient) Branch(ctx context.Context, fullname, branchName string) (*sdk.VCSBranch, error) {
+	repo, err := c.repository(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.branch(ctx, fullname, branchName)
+	if err != nil {
+		return nil, err
+	}
+	branch := toVCSBranch(b, aws.StringValue(repo.DefaultBranch))
+	return &branch, nil
+}
+
+func (c *codecommitClient) branch(ctx context.Context, fullname, branchName string) (*codecommit.BranchInfo, error) {
+	out, err := c.client.GetBranchWithContext(ctx, &codecommit.GetBranchInput{
+		RepositoryName: aws.String(fullname),
+		BranchName:     aws.String(branchName),
+	})
+	if err != nil {
+		return nil, sdk.WrapError(convertError(err), "cannot get branch %s of %s", branchName, fullname)
+	}
+	return out.Branch, nil
+}
+
+func toVCSBranch(b *codecommit.BranchInfo, defaultBranch string) sdk.VCSBranch {
+	name := aws.StringValue(b.BranchName)
+	return sdk.VCSBranch{
+		ID:           "refs/heads/" + name,
+		DisplayID:    name,
+		LatestCommit: aws.StringValue(b.CommitId),
+		Default:      name == defaultBranch,
+	}
+}
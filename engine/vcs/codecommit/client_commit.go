@@ -0,0 +1,118 @@
+package codecommit
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// maxCommits limits the number of commits read when walking the history, the API returns one commit per call
+const maxCommits = 50
+
+// Commits returns the commits of a branch from a commit (until) back to another commit (since), excluded
+func (c *codecommitClient) Commits(ctx context.Context, fullname, branch, since, until string) ([]sdk.VCSCommit, error) {
+	if until == "" {
+		b, err := c.branch(ctx, fullname, branch)
+		if err != nil {
+			return nil, err
+		}
+		until = aws.StringValue(b.CommitId)
+	}
+	return c.walkCommits(ctx, fullname, until, since)
+}
+
+// Commit returns a commit from its hash
+func (c *codecommitClient) Commit(ctx context.Context, fullname, hash string) (sdk.VCSCommit, error) {
+	commit, err := c.commit(ctx, fullname, hash)
+	if err != nil {
+		return sdk.VCSCommit{}, err
+	}
+	return c.toVCSCommit(fullname, commit), nil
+}
+
+// CommitsBetweenRefs returns the commits between two refs, a ref can be a branch name or a commit hash
+func (c *codecommitClient) CommitsBetweenRefs(ctx context.Context, fullname, base, head string) ([]sdk.VCSCommit, error) {
+	baseHash, err := c.resolveRef(ctx, fullname, base)
+	if err != nil {
+		return nil, err
+	}
+	headHash, err := c.resolveRef(ctx, fullname, head)
+	if err != nil {
+		return nil, err
+	}
+	return c.walkCommits(ctx, fullname, headHash, baseHash)
+}
+
+// walkCommits follows the first parent of the commits from a commit until the stop commit or the root commit
+func (c *codecommitClient) walkCommits(ctx context.Context, fullname, from, stop string) ([]sdk.VCSCommit, error) {
+	var commits []sdk.VCSCommit
+	hash := from
+	for len(commits) < maxCommits && hash != "" && hash != stop && ctx.Err() == nil {
+		commit, err := c.commit(ctx, fullname, hash)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, c.toVCSCommit(fullname, commit))
+		hash = ""
+		if len(commit.Parents) > 0 {
+			hash = aws.StringValue(commit.Parents[0])
+		}
+	}
+	return commits, nil
+}
+
+func (c *codecommitClient) commit(ctx context.Context, fullname, hash string) (*codecommit.Commit, error) {
+	out, err := c.client.GetCommitWithContext(ctx, &codecommit.GetCommitInput{
+		RepositoryName: aws.String(fullname),
+		CommitId:       aws.String(hash),
+	})
+	if err != nil {
+		return nil, sdk.WrapError(convertError(err), "cannot get commit %s on %s", hash, fullname)
+	}
+	return out.Commit, nil
+}
+
+// resolveRef returns the hash of the last commit of a branch, or the given ref if it's not a branch
+func (c *codecommitClient) resolveRef(ctx context.Context, fullname, ref string) (string, error) {
+	b, err := c.branch(ctx, fullname, strings.TrimPrefix(ref, "refs/heads/"))
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return ref, nil
+		}
+		return "", err
+	}
+	return aws.StringValue(b.CommitId), nil
+}
+
+func (c *codecommitClient) toVCSCommit(fullname string, commit *codecommit.Commit) sdk.VCSCommit {
+	hash := aws.StringValue(commit.CommitId)
+	vcsCommit := sdk.VCSCommit{
+		Hash:    hash,
+		Message: aws.StringValue(commit.Message),
+		URL:     c.consoleURL(fullname, "commit/"+hash),
+	}
+	if commit.Author != nil {
+		vcsCommit.Author = sdk.VCSAuthor{
+			Name:        aws.StringValue(commit.Author.Name),
+			DisplayName: aws.StringValue(commit.Author.Name),
+			Email:       aws.StringValue(commit.Author.Email),
+		}
+		vcsCommit.Timestamp = parseCommitDate(aws.StringValue(commit.Author.Date)) * 1000
+	}
+	return vcsCommit
+}
+
+// parseCommitDate returns the unix timestamp of a git date given by the API (ex: "1484167798 -0800")
+func parseCommitDate(date string) int64 {
+	fields := strings.Fields(date)
+	if len(fields) == 0 {
+		return 0
+	}
+	ts, _ := strconv.ParseInt(fields[0], 10, 64)
+	return ts
+}
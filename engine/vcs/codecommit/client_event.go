@@ -0,0 +1,34 @@
+package codecommit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// GetEvents is not implemented
+func (c *codecommitClient) GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	return nil, 0.0, fmt.Errorf("Not implemented on CodeCommit")
+}
+
+// PushEvents is not implemented
+func (c *codecommitClient) PushEvents(context.Context, string, []interface{}) ([]sdk.VCSPushEvent, error) {
+	return nil, fmt.Errorf("Not implemented on CodeCommit")
+}
+
+// CreateEvents is not implemented
+func (c *codecommitClient) CreateEvents(context.Context, string, []interface{}) ([]sdk.VCSCreateEvent, error) {
+	return nil, fmt.Errorf("Not implemented on CodeCommit")
+}
+
+// DeleteEvents is not implemented
+func (c *codecommitClient) DeleteEvents(context.Context, string, []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	return nil, fmt.Errorf("Not implemented on CodeCommit")
+}
+
+// PullRequestEvents is not implemented
+func (c *codecommitClient) PullRequestEvents(context.Context, string, []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	return nil, fmt.Errorf("Not implemented on CodeCommit")
+}
@@ -0,0 +1,12 @@
+package codecommit
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ListForks returns no fork, CodeCommit repositories can't be forked
+func (c *codecommitClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	return []sdk.VCSRepo{}, nil
+}
@@ -0,0 +1,168 @@
+package codecommit
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/aws/aws-sdk-go/service/sns"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// CodeCommit sends repository events to a SNS topic thanks to repository triggers. The hook URL is subscribed to
+// the topic and given as custom data of the trigger, so the hooks µService ignores the events of other repositories.
+
+const (
+	triggerNamePrefix = "cds-"
+	// snsPendingConfirmation is the subscription ARN returned until the endpoint confirms the subscription
+	snsPendingConfirmation = "PendingConfirmation"
+)
+
+// GetHook returns the hook for given URL
+func (c *codecommitClient) GetHook(ctx context.Context, repo, hookURL string) (sdk.VCSHook, error) {
+	triggers, err := c.triggers(ctx, repo)
+	if err != nil {
+		return sdk.VCSHook{}, err
+	}
+	for _, t := range triggers {
+		if aws.StringValue(t.CustomData) == hookURL {
+			return toVCSHook(t), nil
+		}
+	}
+	return sdk.VCSHook{}, sdk.WithStack(sdk.ErrNotFound)
+}
+
+// CreateHook subscribes the hook URL to the SNS topic and creates a trigger on the repository
+func (c *codecommitClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	if c.snsTopicARN == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "webhooks need a SNS topic in the CodeCommit configuration")
+	}
+	u, err := url.Parse(hook.URL)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid hook URL %s", hook.URL)
+	}
+	if _, err := c.sns.SubscribeWithContext(ctx, &sns.SubscribeInput{
+		TopicArn: aws.String(c.snsTopicARN),
+		Protocol: aws.String(u.Scheme),
+		Endpoint: aws.String(hook.URL),
+	}); err != nil {
+		return sdk.WrapError(err, "cannot subscribe %s to SNS topic %s", hook.URL, c.snsTopicARN)
+	}
+
+	hook.ID = triggerNamePrefix + hook.URL[strings.LastIndex(hook.URL, "/")+1:]
+	return c.putTrigger(ctx, repo, *hook)
+}
+
+// UpdateHook updates the events of the repository trigger
+func (c *codecommitClient) UpdateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	return c.putTrigger(ctx, repo, *hook)
+}
+
+// DeleteHook removes the trigger from the repository and unsubscribes the hook URL from the SNS topic
+func (c *codecommitClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	triggers, err := c.triggers(ctx, repo)
+	if err != nil {
+		return err
+	}
+	kept := make([]*codecommit.RepositoryTrigger, 0, len(triggers))
+	for _, t := range triggers {
+		if aws.StringValue(t.Name) == hook.ID || (hook.URL != "" && aws.StringValue(t.CustomData) == hook.URL) {
+			continue
+		}
+		kept = append(kept, t)
+	}
+	if err := c.putTriggers(ctx, repo, kept); err != nil {
+		return err
+	}
+
+	if c.snsTopicARN == "" || hook.URL == "" {
+		return nil
+	}
+	input := &sns.ListSubscriptionsByTopicInput{TopicArn: aws.String(c.snsTopicARN)}
+	for {
+		out, err := c.sns.ListSubscriptionsByTopicWithContext(ctx, input)
+		if err != nil {
+			return sdk.WrapError(err, "cannot list subscriptions of SNS topic %s", c.snsTopicARN)
+		}
+		for _, s := range out.Subscriptions {
+			if aws.StringValue(s.Endpoint) != hook.URL || aws.StringValue(s.SubscriptionArn) == snsPendingConfirmation {
+				continue
+			}
+			if _, err := c.sns.UnsubscribeWithContext(ctx, &sns.UnsubscribeInput{SubscriptionArn: s.SubscriptionArn}); err != nil {
+				return sdk.WrapError(err, "cannot unsubscribe %s from SNS topic %s", hook.URL, c.snsTopicARN)
+			}
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return nil
+}
+
+func (c *codecommitClient) triggers(ctx context.Context, repo string) ([]*codecommit.RepositoryTrigger, error) {
+	out, err := c.client.GetRepositoryTriggersWithContext(ctx, &codecommit.GetRepositoryTriggersInput{
+		RepositoryName: aws.String(repo),
+	})
+	if err != nil {
+		return nil, sdk.WrapError(convertError(err), "cannot get triggers of %s", repo)
+	}
+	return out.Triggers, nil
+}
+
+// putTrigger creates or replaces the trigger of the hook, the API only allows to replace all the triggers of a repository
+func (c *codecommitClient) putTrigger(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	triggers, err := c.triggers(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	events := hook.Events
+	if len(events) == 0 {
+		events = []string{codecommit.RepositoryTriggerEventEnumAll}
+	}
+	trigger := &codecommit.RepositoryTrigger{
+		Name:           aws.String(hook.ID),
+		DestinationArn: aws.String(c.snsTopicARN),
+		CustomData:     aws.String(hook.URL),
+		Events:         aws.StringSlice(events),
+		Branches:       []*string{},
+	}
+
+	found := false
+	for i := range triggers {
+		if aws.StringValue(triggers[i].Name) == hook.ID {
+			triggers[i] = trigger
+			found = true
+		}
+	}
+	if !found {
+		triggers = append(triggers, trigger)
+	}
+	return c.putTriggers(ctx, repo, triggers)
+}
+
+func (c *codecommitClient) putTriggers(ctx context.Context, repo string, triggers []*codecommit.RepositoryTrigger) error {
+	if _, err := c.client.PutRepositoryTriggersWithContext(ctx, &codecommit.PutRepositoryTriggersInput{
+		RepositoryName: aws.String(repo),
+		Triggers:       triggers,
+	}); err != nil {
+		return sdk.WrapError(convertError(err), "cannot update triggers of %s", repo)
+	}
+	return nil
+}
+
+func toVCSHook(t *codecommit.RepositoryTrigger) sdk.VCSHook {
+	return sdk.VCSHook{
+		ID:          aws.StringValue(t.Name),
+		Name:        aws.StringValue(t.Name),
+		URL:         aws.StringValue(t.CustomData),
+		Events:      aws.StringValueSlice(t.Events),
+		Method:      "POST",
+		ContentType: "application/json",
+		Workflow:    true,
+	}
+}
@@ -0,0 +1,146 @@
+package codecommit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// PullRequest returns a pull request from its ID
+func (c *codecommitClient) PullRequest(ctx context.Context, fullname string, id int) (sdk.VCSPullRequest, error) {
+	pr, err := c.pullRequest(ctx, strconv.Itoa(id))
+	if err != nil {
+		return sdk.VCSPullRequest{}, err
+	}
+	return c.toVCSPullRequest(fullname, pr), nil
+}
+
+// PullRequests returns the open pull requests of a repository
+func (c *codecommitClient) PullRequests(ctx context.Context, fullname string) ([]sdk.VCSPullRequest, error) {
+	var prs []sdk.VCSPullRequest
+	input := &codecommit.ListPullRequestsInput{
+		RepositoryName:    aws.String(fullname),
+		PullRequestStatus: aws.String(codecommit.PullRequestStatusEnumOpen),
+	}
+	for {
+		out, err := c.client.ListPullRequestsWithContext(ctx, input)
+		if err != nil {
+			return nil, sdk.WrapError(convertError(err), "cannot list pull requests of %s", fullname)
+		}
+		for _, id := range out.PullRequestIds {
+			pr, err := c.pullRequest(ctx, aws.StringValue(id))
+			if err != nil {
+				return nil, err
+			}
+			prs = append(prs, c.toVCSPullRequest(fullname, pr))
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return prs, nil
+}
+
+// PullRequestComment adds a comment on a pull request, on the last revision of its source branch
+func (c *codecommitClient) PullRequestComment(ctx context.Context, fullname string, id int, text string) error {
+	pr, err := c.pullRequest(ctx, strconv.Itoa(id))
+	if err != nil {
+		return err
+	}
+	target := pullRequestTarget(fullname, pr)
+	if target == nil {
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "pull request %d is not on repository %s", id, fullname)
+	}
+	if _, err := c.client.PostCommentForPullRequestWithContext(ctx, &codecommit.PostCommentForPullRequestInput{
+		PullRequestId:  pr.PullRequestId,
+		RepositoryName: aws.String(fullname),
+		BeforeCommitId: target.DestinationCommit,
+		AfterCommitId:  target.SourceCommit,
+		Content:        aws.String(text),
+	}); err != nil {
+		return sdk.WrapError(convertError(err), "cannot comment pull request %d on %s", id, fullname)
+	}
+	return nil
+}
+
+// PullRequestCreate creates a pull request
+func (c *codecommitClient) PullRequestCreate(ctx context.Context, fullname string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
+	out, err := c.client.CreatePullRequestWithContext(ctx, &codecommit.CreatePullRequestInput{
+		Title: aws.String(pr.Title),
+		Targets: []*codecommit.Target{{
+			RepositoryName:       aws.String(fullname),
+			SourceReference:      aws.String(pr.Head.Branch.DisplayID),
+			DestinationReference: aws.String(pr.Base.Branch.DisplayID),
+		}},
+	})
+	if err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(convertError(err), "cannot create pull request on %s", fullname)
+	}
+	return c.toVCSPullRequest(fullname, out.PullRequest), nil
+}
+
+func (c *codecommitClient) pullRequest(ctx context.Context, id string) (*codecommit.PullRequest, error) {
+	out, err := c.client.GetPullRequestWithContext(ctx, &codecommit.GetPullRequestInput{
+		PullRequestId: aws.String(id),
+	})
+	if err != nil {
+		return nil, sdk.WrapError(convertError(err), "cannot get pull request %s", id)
+	}
+	return out.PullRequest, nil
+}
+
+// pullRequestTarget returns the target of the pull request on the given repository
+func pullRequestTarget(fullname string, pr *codecommit.PullRequest) *codecommit.PullRequestTarget {
+	for _, t := range pr.PullRequestTargets {
+		if aws.StringValue(t.RepositoryName) == fullname {
+			return t
+		}
+	}
+	return nil
+}
+
+func (c *codecommitClient) toVCSPullRequest(fullname string, pr *codecommit.PullRequest) sdk.VCSPullRequest {
+	id, _ := strconv.Atoi(aws.StringValue(pr.PullRequestId))
+	vcsPR := sdk.VCSPullRequest{
+		ID:     id,
+		URL:    c.consoleURL(fullname, fmt.Sprintf("pull-requests/%d", id)),
+		Title:  aws.StringValue(pr.Title),
+		User:   sdk.VCSAuthor{Name: userFromARN(aws.StringValue(pr.AuthorArn))},
+		Closed: aws.StringValue(pr.PullRequestStatus) == codecommit.PullRequestStatusEnumClosed,
+	}
+	vcsPR.User.DisplayName = vcsPR.User.Name
+	if t := pullRequestTarget(fullname, pr); t != nil {
+		vcsPR.Head = c.toVCSPushEvent(fullname, aws.StringValue(t.SourceReference), aws.StringValue(t.SourceCommit))
+		vcsPR.Base = c.toVCSPushEvent(fullname, aws.StringValue(t.DestinationReference), aws.StringValue(t.DestinationCommit))
+		vcsPR.Merged = t.MergeMetadata != nil && aws.BoolValue(t.MergeMetadata.IsMerged)
+	}
+	return vcsPR
+}
+
+func (c *codecommitClient) toVCSPushEvent(fullname, ref, hash string) sdk.VCSPushEvent {
+	branch := strings.TrimPrefix(ref, "refs/heads/")
+	return sdk.VCSPushEvent{
+		Repo: fullname,
+		Branch: sdk.VCSBranch{
+			ID:           "refs/heads/" + branch,
+			DisplayID:    branch,
+			LatestCommit: hash,
+		},
+		Commit: sdk.VCSCommit{
+			Hash: hash,
+			URL:  c.consoleURL(fullname, "commit/"+hash),
+		},
+	}
+}
+
+// userFromARN returns the user name from an IAM ARN (ex: arn:aws:iam::123456789012:user/john)
+func userFromARN(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
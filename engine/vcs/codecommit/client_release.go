@@ -0,0 +1,19 @@
+package codecommit
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release is not supported, CodeCommit has no release
+func (c *codecommitClient) Release(ctx context.Context, fullname string, tagName string, title string, releaseNote string) (*sdk.VCSRelease, error) {
+	return nil, fmt.Errorf("Not implemented on CodeCommit")
+}
+
+// UploadReleaseFile is not supported, CodeCommit has no release
+func (c *codecommitClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.ReadCloser) error {
+	return fmt.Errorf("Not implemented on CodeCommit")
+}
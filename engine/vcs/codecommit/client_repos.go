@@ -0,0 +1,100 @@
+package codecommit
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// batchGetRepositoriesLimit is the max number of repositories that can be given to BatchGetRepositories
+const batchGetRepositoriesLimit = 25
+
+// Repos returns the list of accessible repositories
+func (c *codecommitClient) Repos(ctx context.Context) ([]sdk.VCSRepo, error) {
+	var names []*string
+	input := &codecommit.ListRepositoriesInput{}
+	for {
+		out, err := c.client.ListRepositoriesWithContext(ctx, input)
+		if err != nil {
+			return nil, sdk.WrapError(convertError(err), "cannot list repositories")
+		}
+		for _, r := range out.Repositories {
+			names = append(names, r.RepositoryName)
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	repos := make([]sdk.VCSRepo, 0, len(names))
+	for i := 0; i < len(names); i += batchGetRepositoriesLimit {
+		end := i + batchGetRepositoriesLimit
+		if end > len(names) {
+			end = len(names)
+		}
+		out, err := c.client.BatchGetRepositoriesWithContext(ctx, &codecommit.BatchGetRepositoriesInput{
+			RepositoryNames: names[i:end],
+		})
+		if err != nil {
+			return nil, sdk.WrapError(convertError(err), "cannot get repositories")
+		}
+		for _, r := range out.Repositories {
+			repos = append(repos, c.toVCSRepo(r))
+		}
+	}
+	return repos, nil
+}
+
+// RepoByFullname returns the repo from its name, CodeCommit repositories are not owned by a user or an organization
+func (c *codecommitClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	r, err := c.repository(ctx, fullname)
+	if err != nil {
+		return sdk.VCSRepo{}, err
+	}
+	return c.toVCSRepo(r), nil
+}
+
+// GrantWritePermission is not needed, access is managed by IAM policies
+func (c *codecommitClient) GrantWritePermission(ctx context.Context, repo string) error {
+	return nil
+}
+
+func (c *codecommitClient) repository(ctx context.Context, name string) (*codecommit.RepositoryMetadata, error) {
+	out, err := c.client.GetRepositoryWithContext(ctx, &codecommit.GetRepositoryInput{
+		RepositoryName: aws.String(name),
+	})
+	if err != nil {
+		return nil, sdk.WrapError(convertError(err), "cannot get repository %s", name)
+	}
+	c.repoARNs.Store(name, aws.StringValue(out.RepositoryMetadata.Arn))
+	return out.RepositoryMetadata, nil
+}
+
+// repositoryARN returns the ARN of a repository, needed to manage its tags
+func (c *codecommitClient) repositoryARN(ctx context.Context, name string) (string, error) {
+	if arn, ok := c.repoARNs.Load(name); ok {
+		return arn.(string), nil
+	}
+	r, err := c.repository(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(r.Arn), nil
+}
+
+func (c *codecommitClient) toVCSRepo(r *codecommit.RepositoryMetadata) sdk.VCSRepo {
+	name := aws.StringValue(r.RepositoryName)
+	return sdk.VCSRepo{
+		ID:           aws.StringValue(r.RepositoryId),
+		Name:         name,
+		Slug:         name,
+		Fullname:     name,
+		URL:          c.consoleURL(name, "browse"),
+		HTTPCloneURL: aws.StringValue(r.CloneUrlHttp),
+		SSHCloneURL:  aws.StringValue(r.CloneUrlSsh),
+	}
+}
@@ -0,0 +1,188 @@
+package codecommit
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// CodeCommit has no commit status, so statuses are stored as tags on the repository resource:
+// the key contains the commit hash and the status context, the value contains the state and the date.
+const (
+	statusTagPrefix = "cds-status:"
+	// maxStatusTags keeps room for the other tags of the repository, a resource can't have more than 50 tags
+	maxStatusTags   = 40
+	maxTagKeyLength = 128
+)
+
+var invalidTagCharsRegex = regexp.MustCompile(`[^\pL\pN\s_.:/=+\-@]`)
+
+// SetStatus sets the status of a commit as a tag of the repository
+func (c *codecommitClient) SetStatus(ctx context.Context, event sdk.Event) error {
+	if c.disableStatus {
+		log.Warning(ctx, "codecommit.SetStatus> ⚠ CodeCommit statuses are disabled")
+		return nil
+	}
+
+	var eventNR sdk.EventRunWorkflowNode
+	if err := mapstructure.Decode(event.Payload, &eventNR); err != nil {
+		return sdk.WrapError(err, "cannot read payload")
+	}
+	if eventNR.RepositoryFullName == "" || eventNR.Hash == "" {
+		return nil
+	}
+	state := processCodeCommitStatus(eventNR.Status)
+	if state == "" {
+		return nil
+	}
+
+	arn, err := c.repositoryARN(ctx, eventNR.RepositoryFullName)
+	if err != nil {
+		return err
+	}
+	tags, err := c.statusTags(ctx, arn)
+	if err != nil {
+		return err
+	}
+
+	key := statusTagKey(eventNR.Hash, sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR))
+	if _, exists := tags[key]; !exists && len(tags) >= maxStatusTags {
+		if err := c.removeOldestStatusTags(ctx, arn, tags, len(tags)-maxStatusTags+1); err != nil {
+			return err
+		}
+	}
+
+	if _, err := c.client.TagResourceWithContext(ctx, &codecommit.TagResourceInput{
+		ResourceArn: aws.String(arn),
+		Tags:        map[string]*string{key: aws.String(fmt.Sprintf("%s %d", state, time.Now().Unix()))},
+	}); err != nil {
+		return sdk.WrapError(convertError(err), "cannot set status on %s", eventNR.RepositoryFullName)
+	}
+	return nil
+}
+
+// ListStatuses returns the statuses of a commit from the tags of the repository
+func (c *codecommitClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	arn, err := c.repositoryARN(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := c.statusTags(ctx, arn)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := statusTagPrefix + ref + ":"
+	statuses := []sdk.VCSCommitStatus{}
+	for k, v := range tags {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		state, date := parseStatusTagValue(v)
+		statuses = append(statuses, sdk.VCSCommitStatus{
+			Ref:        ref,
+			CreatedAt:  date,
+			State:      processCDSState(state),
+			Decription: strings.TrimPrefix(k, prefix),
+		})
+	}
+	return statuses, nil
+}
+
+// statusTags returns the tags of the repository set by CDS
+func (c *codecommitClient) statusTags(ctx context.Context, arn string) (map[string]string, error) {
+	tags := map[string]string{}
+	input := &codecommit.ListTagsForResourceInput{ResourceArn: aws.String(arn)}
+	for {
+		out, err := c.client.ListTagsForResourceWithContext(ctx, input)
+		if err != nil {
+			return nil, sdk.WrapError(convertError(err), "cannot list tags of %s", arn)
+		}
+		for k, v := range out.Tags {
+			if strings.HasPrefix(k, statusTagPrefix) {
+				tags[k] = aws.StringValue(v)
+			}
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return tags, nil
+}
+
+func (c *codecommitClient) removeOldestStatusTags(ctx context.Context, arn string, tags map[string]string, count int) error {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		_, di := parseStatusTagValue(tags[keys[i]])
+		_, dj := parseStatusTagValue(tags[keys[j]])
+		return di.Before(dj)
+	})
+	if _, err := c.client.UntagResourceWithContext(ctx, &codecommit.UntagResourceInput{
+		ResourceArn: aws.String(arn),
+		TagKeys:     aws.StringSlice(keys[:count]),
+	}); err != nil {
+		return sdk.WrapError(convertError(err), "cannot remove old statuses of %s", arn)
+	}
+	return nil
+}
+
+// statusTagKey returns a valid tag key for the status of a commit
+func statusTagKey(hash, context string) string {
+	key := statusTagPrefix + hash + ":" + invalidTagCharsRegex.ReplaceAllString(context, "_")
+	if len(key) > maxTagKeyLength {
+		key = key[:maxTagKeyLength]
+	}
+	return key
+}
+
+func parseStatusTagValue(v string) (string, time.Time) {
+	fields := strings.Fields(v)
+	if len(fields) != 2 {
+		return v, time.Time{}
+	}
+	ts, _ := strconv.ParseInt(fields[1], 10, 64)
+	return fields[0], time.Unix(ts, 0)
+}
+
+func processCodeCommitStatus(status string) string {
+	switch status {
+	case sdk.StatusChecking, sdk.StatusDisabled, sdk.StatusNeverBuilt, sdk.StatusSkipped, sdk.StatusUnknown, sdk.StatusWaiting:
+		return ""
+	case sdk.StatusFail:
+		return "failure"
+	case sdk.StatusSuccess:
+		return "success"
+	case sdk.StatusStopped:
+		return "error"
+	default:
+		return "pending"
+	}
+}
+
+func processCDSState(state string) string {
+	switch state {
+	case "success":
+		return sdk.StatusSuccess
+	case "error", "failure":
+		return sdk.StatusFail
+	case "pending":
+		return sdk.StatusBuilding
+	default:
+		return sdk.StatusDisabled
+	}
+}
@@ -0,0 +1,12 @@
+package codecommit
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags returns no tag, the CodeCommit API doesn't allow to list the git tags of a repository
+func (c *codecommitClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	return []sdk.VCSTag{}, nil
+}
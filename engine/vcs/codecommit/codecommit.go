@@ -0,0 +1,73 @@
+package codecommit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/aws/aws-sdk-go/service/codecommit/codecommitiface"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+// codecommitClient implements VCSAuthorizedClient interface
+type codecommitClient struct {
+	client        codecommitiface.CodeCommitAPI
+	sns           snsiface.SNSAPI
+	region        string
+	accessKeyID   string
+	snsTopicARN   string
+	disableStatus bool
+	// repositories ARN are needed to tag them, keep them to avoid a call for each status
+	repoARNs sync.Map
+}
+
+// codecommitConsumer implements vcs.Server and it's used to instantiate a codecommitClient
+type codecommitConsumer struct {
+	region        string
+	endpoint      string
+	snsTopicARN   string
+	cache         cache.Store
+	disableStatus bool
+}
+
+// New instantiate a new CodeCommit consumer
+func New(region, endpoint, snsTopicARN string, store cache.Store, disableStatus bool) sdk.VCSServer {
+	return &codecommitConsumer{
+		region:        region,
+		endpoint:      endpoint,
+		snsTopicARN:   snsTopicARN,
+		cache:         store,
+		disableStatus: disableStatus,
+	}
+}
+
+// GetAccessToken returns the IAM access key ID used by the client
+func (c *codecommitClient) GetAccessToken(_ context.Context) string {
+	return c.accessKeyID
+}
+
+// consoleURL returns the AWS console URL of a repository page
+func (c *codecommitClient) consoleURL(repo string, path string) string {
+	u := fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/%s", c.region, url.PathEscape(repo), path)
+	return u + "?region=" + c.region
+}
+
+// convertError converts not found errors from the CodeCommit API to CDS errors
+func convertError(err error) error {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case codecommit.ErrCodeRepositoryDoesNotExistException,
+			codecommit.ErrCodeBranchDoesNotExistException,
+			codecommit.ErrCodeCommitIdDoesNotExistException,
+			codecommit.ErrCodePullRequestDoesNotExistException:
+			return sdk.NewErrorWithStack(err, sdk.ErrNotFound)
+		}
+	}
+	return sdk.WithStack(err)
+}
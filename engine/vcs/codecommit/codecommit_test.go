@@ -0,0 +1,68 @@
+package codecommit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/aws/aws-sdk-go/service/codecommit/codecommitiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+type fakeCodeCommit struct {
+	codecommitiface.CodeCommitAPI
+	triggers []*codecommit.RepositoryTrigger
+}
+
+func (f *fakeCodeCommit) GetRepositoryTriggersWithContext(_ aws.Context, in *codecommit.GetRepositoryTriggersInput, _ ...request.Option) (*codecommit.GetRepositoryTriggersOutput, error) {
+	return &codecommit.GetRepositoryTriggersOutput{Triggers: f.triggers}, nil
+}
+
+func (f *fakeCodeCommit) PutRepositoryTriggersWithContext(_ aws.Context, in *codecommit.PutRepositoryTriggersInput, _ ...request.Option) (*codecommit.PutRepositoryTriggersOutput, error) {
+	f.triggers = in.Triggers
+	return &codecommit.PutRepositoryTriggersOutput{}, nil
+}
+
+func TestHookTriggers(t *testing.T) {
+	fake := &fakeCodeCommit{
+		triggers: []*codecommit.RepositoryTrigger{{Name: aws.String("my-trigger"), CustomData: aws.String("other")}},
+	}
+	c := &codecommitClient{client: fake, snsTopicARN: "arn:aws:sns:eu-west-1:123456789012:cds"}
+
+	hook := sdk.VCSHook{ID: "cds-my-uuid", URL: "https://cds.local/webhook/my-uuid"}
+	require.NoError(t, c.putTrigger(context.TODO(), "my-repo", hook))
+	require.Len(t, fake.triggers, 2)
+	assert.Equal(t, []string{"all"}, aws.StringValueSlice(fake.triggers[1].Events))
+
+	h, err := c.GetHook(context.TODO(), "my-repo", hook.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "cds-my-uuid", h.ID)
+
+	hook.Events = []string{"updateReference"}
+	require.NoError(t, c.UpdateHook(context.TODO(), "my-repo", &hook))
+	require.Len(t, fake.triggers, 2)
+	assert.Equal(t, []string{"updateReference"}, aws.StringValueSlice(fake.triggers[1].Events))
+
+	c.snsTopicARN = ""
+	require.NoError(t, c.DeleteHook(context.TODO(), "my-repo", hook))
+	require.Len(t, fake.triggers, 1)
+	assert.Equal(t, "my-trigger", aws.StringValue(fake.triggers[0].Name))
+}
+
+func TestStatusTags(t *testing.T) {
+	key := statusTagKey("5c4ef1049f1d27deadbeeff313e0730018be182b", "CDS/KEY-my-workflow-build (1)")
+	assert.Equal(t, "cds-status:5c4ef1049f1d27deadbeeff313e0730018be182b:CDS/KEY-my-workflow-build _1_", key)
+
+	state, date := parseStatusTagValue("success 1577872800")
+	assert.Equal(t, "success", state)
+	assert.Equal(t, time.Unix(1577872800, 0), date)
+
+	assert.Equal(t, int64(1484167798), parseCommitDate("1484167798 -0800"))
+	assert.Equal(t, "john", userFromARN("arn:aws:iam::123456789012:user/john"))
+}
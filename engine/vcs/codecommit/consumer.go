@@ -0,0 +1,50 @@
+package codecommit
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/aws/aws-sdk-go/service/sns"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// AuthorizeRedirect is not implemented for CodeCommit, IAM credentials are given with basic auth
+func (c *codecommitConsumer) AuthorizeRedirect(ctx context.Context) (string, string, error) {
+	return "", "", nil
+}
+
+// AuthorizeToken is not implemented for CodeCommit, IAM credentials are given with basic auth
+func (c *codecommitConsumer) AuthorizeToken(ctx context.Context, state, code string) (string, string, error) {
+	return "", "", nil
+}
+
+// GetAuthorizedClient returns an authorized client from an IAM access key ID and its secret access key,
+// requests are signed with AWS Signature Version 4 by the AWS SDK
+func (c *codecommitConsumer) GetAuthorizedClient(ctx context.Context, accessKeyID, secretAccessKey string, _ int64) (sdk.VCSAuthorizedClient, error) {
+	conf := aws.NewConfig().
+		WithRegion(c.region).
+		WithCredentials(credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""))
+	sess, err := session.NewSession(conf)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to create an AWS session")
+	}
+
+	// A custom endpoint is only used for CodeCommit (ex: VPC endpoint)
+	codecommitConf := aws.NewConfig()
+	if c.endpoint != "" {
+		codecommitConf = codecommitConf.WithEndpoint(c.endpoint)
+	}
+
+	return &codecommitClient{
+		client:        codecommit.New(sess, codecommitConf),
+		sns:           sns.New(sess),
+		region:        c.region,
+		accessKeyID:   accessKeyID,
+		snsTopicARN:   c.snsTopicARN,
+		disableStatus: c.disableStatus,
+	}, nil
+}
@@ -29,10 +29,11 @@ func (c *gerritClient) SetStatus(ctx context.Context, event sdk.Event) error {
 
 	// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#review-input
 	ri := gerrit.ReviewInput{
-		Message: c.buildMessage(eventNR),
-		Tag:     "CDS",
-		Labels:  c.buildLabel(eventNR),
-		Notify:  "OWNER", // Send notification to the owner
+		Message:  c.buildMessage(eventNR),
+		Tag:      "CDS",
+		Labels:   c.buildLabel(eventNR),
+		Comments: c.buildComments(eventNR),
+		Notify:   "OWNER", // Send notification to the owner
 	}
 
 	// Check if we already send the message
@@ -80,6 +81,10 @@ func (c *gerritClient) buildMessage(eventNR sdk.EventRunWorkflowNode) string {
 }
 
 func (c *gerritClient) buildLabel(eventNR sdk.EventRunWorkflowNode) map[string]string {
+	// Labels configured on the workflow notification override the default Verified vote
+	if eventNR.GerritChange.Labels != nil {
+		return eventNR.GerritChange.Labels
+	}
 	labels := make(map[string]string)
 	switch eventNR.Status {
 	case sdk.StatusSuccess:
@@ -91,3 +96,18 @@ func (c *gerritClient) buildLabel(eventNR sdk.EventRunWorkflowNode) map[string]s
 	}
 	return labels
 }
+
+// buildComments returns the inline comments sent by the steps of the node run, grouped by file.
+func (c *gerritClient) buildComments(eventNR sdk.EventRunWorkflowNode) map[string][]gerrit.CommentInput {
+	if len(eventNR.GerritChange.Comments) == 0 {
+		return nil
+	}
+	comments := make(map[string][]gerrit.CommentInput)
+	for _, cc := range eventNR.GerritChange.Comments {
+		comments[cc.Path] = append(comments[cc.Path], gerrit.CommentInput{
+			Line:    cc.Line,
+			Message: cc.String(),
+		})
+	}
+	return comments
+}
@@ -0,0 +1,55 @@
+package gitea
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Branches returns the branches of a repository
+func (c *giteaClient) Branches(ctx context.Context, fullname string) ([]sdk.VCSBranch, error) {
+	var repo Repository
+	if err := c.get(ctx, "/repos/"+fullname, nil, &repo); err != nil {
+		return nil, sdk.WrapError(err, "cannot get repository %s", fullname)
+	}
+
+	var branches []sdk.VCSBranch
+	for page := 1; ctx.Err() == nil; page++ {
+		var giteaBranches []Branch
+		if err := c.get(ctx, "/repos/"+fullname+"/branches", pageParams(page), &giteaBranches); err != nil {
+			return nil, sdk.WrapError(err, "cannot list branches of %s", fullname)
+		}
+		for _, b := range giteaBranches {
+			branches = append(branches, toVCSBranch(b, repo.DefaultBranch))
+		}
+		if len(giteaBranches) < pageLimit {
+			break
+		}
+	}
+	return branches, nil
+}
+
+// Branch returns a branch of a repository
+func (c *giteaClient) Branch(ctx context.Context, fullname, branchName string) (*sdk.VCSBranch, error) {
+	var repo Repository
+	if err := c.get(ctx, "/repos/"+fullname, nil, &repo); err != nil {
+		return nil, sdk.WrapError(err, "cannot get repository %s", fullname)
+	}
+
+	var b Branch
+	if err := c.get(ctx, "/repos/"+fullname+"/branches/"+url.PathEscape(branchName), nil, &b); err != nil {
+		return nil, err
+	}
+	branch := toVCSBranch(b, repo.DefaultBranch)
+	return &branch, nil
+}
+
+func toVCSBranch(b Branch, defaultBranch string) sdk.VCSBranch {
+	return sdk.VCSBranch{
+		ID:           "refs/heads/" + b.Name,
+		DisplayID:    b.Name,
+		LatestCommit: b.Commit.ID,
+		Default:      b.Name == defaultBranch,
+	}
+}
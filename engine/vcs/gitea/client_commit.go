@@ -0,0 +1,80 @@
+package gitea
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// maxCommitsPages limits the number of pages read when looking for the 'since' commit
+const maxCommitsPages = 10
+
+// Commits returns the commits of a branch from a commit (until) back to another commit (since), excluded
+func (c *giteaClient) Commits(ctx context.Context, fullname, branch, since, until string) ([]sdk.VCSCommit, error) {
+	ref := until
+	if ref == "" {
+		ref = branch
+	}
+
+	var commits []sdk.VCSCommit
+	for page := 1; page <= maxCommitsPages && ctx.Err() == nil; page++ {
+		params := pageParams(page)
+		params.Set("sha", ref)
+		var giteaCommits []Commit
+		if err := c.get(ctx, "/repos/"+fullname+"/commits", params, &giteaCommits); err != nil {
+			return nil, sdk.WrapError(err, "cannot list commits of %s", fullname)
+		}
+		for _, gc := range giteaCommits {
+			if since != "" && gc.SHA == since {
+				return commits, nil
+			}
+			commits = append(commits, toVCSCommit(gc))
+		}
+		if len(giteaCommits) < pageLimit {
+			break
+		}
+	}
+	return commits, nil
+}
+
+// Commit returns a commit from its hash
+func (c *giteaClient) Commit(ctx context.Context, fullname, hash string) (sdk.VCSCommit, error) {
+	var gc Commit
+	if err := c.get(ctx, "/repos/"+fullname+"/git/commits/"+url.PathEscape(hash), nil, &gc); err != nil {
+		return sdk.VCSCommit{}, sdk.WrapError(err, "cannot get commit %s on %s", hash, fullname)
+	}
+	return toVCSCommit(gc), nil
+}
+
+// CommitsBetweenRefs returns the commits between two refs
+func (c *giteaClient) CommitsBetweenRefs(ctx context.Context, fullname, base, head string) ([]sdk.VCSCommit, error) {
+	var compare Compare
+	if err := c.get(ctx, "/repos/"+fullname+"/compare/"+url.PathEscape(base)+"..."+url.PathEscape(head), nil, &compare); err != nil {
+		return nil, sdk.WrapError(err, "cannot compare %s and %s on %s", base, head, fullname)
+	}
+	commits := make([]sdk.VCSCommit, 0, len(compare.Commits))
+	for _, gc := range compare.Commits {
+		commits = append(commits, toVCSCommit(gc))
+	}
+	return commits, nil
+}
+
+func toVCSCommit(gc Commit) sdk.VCSCommit {
+	commit := sdk.VCSCommit{
+		Hash: gc.SHA,
+		Author: sdk.VCSAuthor{
+			Name:        gc.Commit.Author.Name,
+			DisplayName: gc.Commit.Author.Name,
+			Email:       gc.Commit.Author.Email,
+		},
+		Timestamp: gc.Commit.Author.Date.Unix() * 1000,
+		Message:   gc.Commit.Message,
+		URL:       gc.HTMLURL,
+	}
+	if gc.Author != nil {
+		commit.Author.Name = gc.Author.Login
+		commit.Author.Avatar = gc.Author.AvatarURL
+	}
+	return commit
+}
@@ -0,0 +1,25 @@
+package gitea
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ListForks returns the forks of a repository
+func (c *giteaClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	var repos []sdk.VCSRepo
+	for page := 1; ctx.Err() == nil; page++ {
+		var forks []Repository
+		if err := c.get(ctx, "/repos/"+repo+"/forks", pageParams(page), &forks); err != nil {
+			return nil, sdk.WrapError(err, "cannot list forks of %s", repo)
+		}
+		for _, r := range forks {
+			repos = append(repos, toVCSRepo(r))
+		}
+		if len(forks) < pageLimit {
+			break
+		}
+	}
+	return repos, nil
+}
@@ -0,0 +1,100 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// CreateHook creates a webhook on the repository
+func (c *giteaClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	c.proxifyHookURL(hook)
+	if len(hook.Events) == 0 {
+		hook.Events = []string{"push"}
+	}
+
+	h := Hook{
+		Type:   "gitea",
+		Active: true,
+		Events: hook.Events,
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+	}
+	if err := c.post(ctx, "/repos/"+repo+"/hooks", h, &h); err != nil {
+		return sdk.WrapError(err, "cannot create hook on %s", repo)
+	}
+	hook.ID = fmt.Sprintf("%d", h.ID)
+	return nil
+}
+
+// UpdateHook updates the url and the events of a webhook
+func (c *giteaClient) UpdateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	c.proxifyHookURL(hook)
+	if len(hook.Events) == 0 {
+		hook.Events = []string{"push"}
+	}
+
+	h := Hook{
+		Active: true,
+		Events: hook.Events,
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+	}
+	if err := c.patch(ctx, "/repos/"+repo+"/hooks/"+hook.ID, h, nil); err != nil {
+		return sdk.WrapError(err, "cannot update hook %s on %s", hook.ID, repo)
+	}
+	return nil
+}
+
+// GetHook returns the webhook of the repository matching the given url
+func (c *giteaClient) GetHook(ctx context.Context, repo, webhookURL string) (sdk.VCSHook, error) {
+	for page := 1; ctx.Err() == nil; page++ {
+		var hooks []Hook
+		if err := c.get(ctx, "/repos/"+repo+"/hooks", pageParams(page), &hooks); err != nil {
+			return sdk.VCSHook{}, sdk.WrapError(err, "cannot list hooks of %s", repo)
+		}
+		for _, h := range hooks {
+			if h.Config["url"] != webhookURL {
+				continue
+			}
+			return sdk.VCSHook{
+				ID:          fmt.Sprintf("%d", h.ID),
+				Name:        h.Type,
+				Events:      h.Events,
+				URL:         h.Config["url"],
+				ContentType: h.Config["content_type"],
+				Disable:     !h.Active,
+			}, nil
+		}
+		if len(hooks) < pageLimit {
+			break
+		}
+	}
+	return sdk.VCSHook{}, sdk.WithStack(sdk.ErrNotFound)
+}
+
+// DeleteHook deletes a webhook
+func (c *giteaClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	if err := c.delete(ctx, "/repos/"+repo+"/hooks/"+hook.ID); err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return sdk.WrapError(err, "cannot delete hook %s on %s", hook.ID, repo)
+	}
+	return nil
+}
+
+// proxifyHookURL replaces the base url of the hook by the proxy one if set
+func (c *giteaClient) proxifyHookURL(hook *sdk.VCSHook) {
+	if c.proxyURL == "" {
+		return
+	}
+	lastIndexSlash := strings.LastIndex(hook.URL, "/")
+	if c.proxyURL[len(c.proxyURL)-1] == '/' {
+		lastIndexSlash++
+	}
+	hook.URL = c.proxyURL + hook.URL[lastIndexSlash:]
+}
@@ -0,0 +1,91 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// PullRequest returns a pull request from its index
+func (c *giteaClient) PullRequest(ctx context.Context, fullname string, id int) (sdk.VCSPullRequest, error) {
+	var pr PullRequest
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/pulls/%d", fullname, id), nil, &pr); err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "cannot get pull request %d on %s", id, fullname)
+	}
+	return toVCSPullRequest(pr), nil
+}
+
+// PullRequests returns the open pull requests of a repository
+func (c *giteaClient) PullRequests(ctx context.Context, fullname string) ([]sdk.VCSPullRequest, error) {
+	var prs []sdk.VCSPullRequest
+	for page := 1; ctx.Err() == nil; page++ {
+		params := pageParams(page)
+		params.Set("state", "open")
+		var giteaPRs []PullRequest
+		if err := c.get(ctx, "/repos/"+fullname+"/pulls", params, &giteaPRs); err != nil {
+			return nil, sdk.WrapError(err, "cannot list pull requests of %s", fullname)
+		}
+		for _, pr := range giteaPRs {
+			prs = append(prs, toVCSPullRequest(pr))
+		}
+		if len(giteaPRs) < pageLimit {
+			break
+		}
+	}
+	return prs, nil
+}
+
+// PullRequestComment adds a comment on a pull request
+func (c *giteaClient) PullRequestComment(ctx context.Context, fullname string, id int, text string) error {
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments", fullname, id)
+	if err := c.post(ctx, path, CreateIssueCommentOption{Body: text}, nil); err != nil {
+		return sdk.WrapError(err, "cannot comment pull request %d on %s", id, fullname)
+	}
+	return nil
+}
+
+// PullRequestCreate creates a pull request
+func (c *giteaClient) PullRequestCreate(ctx context.Context, fullname string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
+	var created PullRequest
+	opts := CreatePullRequestOption{
+		Head:  pr.Head.Branch.DisplayID,
+		Base:  pr.Base.Branch.DisplayID,
+		Title: pr.Title,
+	}
+	if err := c.post(ctx, "/repos/"+fullname+"/pulls", opts, &created); err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "cannot create pull request on %s", fullname)
+	}
+	return toVCSPullRequest(created), nil
+}
+
+func toVCSPullRequest(pr PullRequest) sdk.VCSPullRequest {
+	return sdk.VCSPullRequest{
+		ID:    pr.Index,
+		URL:   pr.HTMLURL,
+		Title: pr.Title,
+		User: sdk.VCSAuthor{
+			Name:        pr.User.Login,
+			DisplayName: pr.User.FullName,
+			Email:       pr.User.Email,
+			Avatar:      pr.User.AvatarURL,
+		},
+		Head:   toVCSPushEvent(pr.Head),
+		Base:   toVCSPushEvent(pr.Base),
+		Merged: pr.Merged,
+		Closed: pr.State == "closed",
+	}
+}
+
+func toVCSPushEvent(b PRBranchInfo) sdk.VCSPushEvent {
+	return sdk.VCSPushEvent{
+		Repo: b.Repo.FullName,
+		Branch: sdk.VCSBranch{
+			ID:           "refs/heads/" + b.Ref,
+			DisplayID:    b.Ref,
+			LatestCommit: b.Sha,
+		},
+		Commit:   sdk.VCSCommit{Hash: b.Sha},
+		CloneURL: b.Repo.CloneURL,
+	}
+}
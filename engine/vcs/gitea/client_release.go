@@ -0,0 +1,60 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release creates a release on a tag
+func (c *giteaClient) Release(ctx context.Context, fullname string, tagName string, title string, releaseNote string) (*sdk.VCSRelease, error) {
+	r := Release{
+		TagName: tagName,
+		Name:    title,
+		Body:    releaseNote,
+	}
+	if err := c.post(ctx, "/repos/"+fullname+"/releases", r, &r); err != nil {
+		return nil, sdk.WrapError(err, "cannot create release %s on %s", tagName, fullname)
+	}
+	return &sdk.VCSRelease{
+		ID:        r.ID,
+		UploadURL: fmt.Sprintf("/repos/%s/releases/%d/assets", fullname, r.ID),
+	}, nil
+}
+
+// UploadReleaseFile attaches a file to a release
+func (c *giteaClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.ReadCloser) error {
+	defer r.Close()
+
+	// Stream the multipart body to avoid loading the artifact in memory
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("attachment", artifactName)
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	params := url.Values{}
+	params.Set("name", artifactName)
+	req, err := c.newRequest(ctx, http.MethodPost, strings.TrimPrefix(uploadURL, c.apiURL), params, pr)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if err := c.doRequest(ctx, req, nil); err != nil {
+		return sdk.WrapError(err, "cannot upload %s on release %s", artifactName, releaseName)
+	}
+	return nil
+}
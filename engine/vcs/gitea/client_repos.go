@@ -0,0 +1,51 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Repos returns the list of accessible repositories
+func (c *giteaClient) Repos(ctx context.Context) ([]sdk.VCSRepo, error) {
+	var repos []sdk.VCSRepo
+	for page := 1; ctx.Err() == nil; page++ {
+		var giteaRepos []Repository
+		if err := c.get(ctx, "/user/repos", pageParams(page), &giteaRepos); err != nil {
+			return nil, sdk.WrapError(err, "cannot list repositories")
+		}
+		for _, r := range giteaRepos {
+			repos = append(repos, toVCSRepo(r))
+		}
+		if len(giteaRepos) < pageLimit {
+			break
+		}
+	}
+	return repos, nil
+}
+
+// RepoByFullname returns the repo from its fullname
+func (c *giteaClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	var r Repository
+	if err := c.get(ctx, "/repos/"+fullname, nil, &r); err != nil {
+		return sdk.VCSRepo{}, sdk.WrapError(err, "cannot get repository %s", fullname)
+	}
+	return toVCSRepo(r), nil
+}
+
+func (c *giteaClient) GrantWritePermission(ctx context.Context, repo string) error {
+	return nil
+}
+
+func toVCSRepo(r Repository) sdk.VCSRepo {
+	return sdk.VCSRepo{
+		ID:           fmt.Sprintf("%d", r.ID),
+		Name:         r.FullName,
+		Slug:         r.Name,
+		Fullname:     r.FullName,
+		URL:          r.HTMLURL,
+		HTTPCloneURL: r.CloneURL,
+		SSHCloneURL:  r.SSHURL,
+	}
+}
@@ -0,0 +1,99 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// SetStatus sets the build status of a commit
+func (c *giteaClient) SetStatus(ctx context.Context, event sdk.Event) error {
+	if c.disableStatus {
+		log.Warning(ctx, "gitea.SetStatus>  ⚠ Gitea statuses are disabled")
+		return nil
+	}
+
+	if event.EventType != fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}) {
+		log.Error(ctx, "gitea.SetStatus> Unknown event %v", event)
+		return nil
+	}
+
+	var eventNR sdk.EventRunWorkflowNode
+	if err := mapstructure.Decode(event.Payload, &eventNR); err != nil {
+		return sdk.WrapError(err, "cannot read payload")
+	}
+
+	state := processGiteaStatus(eventNR.Status)
+	if state == "" {
+		log.Debug("gitea.SetStatus> Do not process event for current status: %v", event)
+		return nil
+	}
+
+	s := Status{
+		State:       state,
+		Description: eventNR.NodeName + ": " + eventNR.Status,
+		Context:     sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR),
+	}
+	if !c.disableStatusDetail {
+		s.TargetURL = fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", c.uiURL, event.ProjectKey, event.WorkflowName, eventNR.Number)
+	}
+
+	path := fmt.Sprintf("/repos/%s/statuses/%s", eventNR.RepositoryFullName, eventNR.Hash)
+	if err := c.post(ctx, path, s, nil); err != nil {
+		return sdk.WrapError(err, "cannot set status on %s", eventNR.RepositoryFullName)
+	}
+	return nil
+}
+
+// ListStatuses returns the CDS statuses of a commit
+func (c *giteaClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	var ss []Status
+	if err := c.get(ctx, "/repos/"+repo+"/statuses/"+ref, nil, &ss); err != nil {
+		return nil, sdk.WrapError(err, "cannot list statuses of %s on %s", ref, repo)
+	}
+
+	vcsStatuses := []sdk.VCSCommitStatus{}
+	for _, s := range ss {
+		if !strings.HasPrefix(s.Context, "CDS/") {
+			continue
+		}
+		vcsStatuses = append(vcsStatuses, sdk.VCSCommitStatus{
+			CreatedAt:  s.CreatedAt,
+			Decription: s.Context,
+			Ref:        ref,
+			State:      processCDSState(s.State),
+		})
+	}
+	return vcsStatuses, nil
+}
+
+func processGiteaStatus(status string) string {
+	switch status {
+	case sdk.StatusChecking, sdk.StatusDisabled, sdk.StatusNeverBuilt, sdk.StatusSkipped, sdk.StatusUnknown, sdk.StatusWaiting:
+		return ""
+	case sdk.StatusFail:
+		return "failure"
+	case sdk.StatusSuccess:
+		return "success"
+	case sdk.StatusStopped:
+		return "error"
+	default:
+		return "pending"
+	}
+}
+
+func processCDSState(state string) string {
+	switch state {
+	case "success":
+		return sdk.StatusSuccess
+	case "error", "failure":
+		return sdk.StatusFail
+	default:
+		return sdk.StatusDisabled
+	}
+}
@@ -0,0 +1,30 @@
+package gitea
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags returns the tags of a repository
+func (c *giteaClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	var tags []sdk.VCSTag
+	for page := 1; ctx.Err() == nil; page++ {
+		var giteaTags []Tag
+		if err := c.get(ctx, "/repos/"+fullname+"/tags", pageParams(page), &giteaTags); err != nil {
+			return nil, sdk.WrapError(err, "cannot list tags of %s", fullname)
+		}
+		for _, t := range giteaTags {
+			tags = append(tags, sdk.VCSTag{
+				Tag:     t.Name,
+				Sha:     t.ID,
+				Message: t.Message,
+				Hash:    t.Commit.SHA,
+			})
+		}
+		if len(giteaTags) < pageLimit {
+			break
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,60 @@
+package gitea
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	_ sdk.VCSAuthorizedClient = &giteaClient{}
+	_ sdk.VCSServer           = &giteaConsumer{}
+)
+
+// giteaClient implements VCSAuthorizedClient interface for Gitea and Forgejo
+type giteaClient struct {
+	apiURL              string
+	accessToken         string
+	refreshToken        string
+	expiresAt           time.Time
+	uiURL               string
+	proxyURL            string
+	disableStatus       bool
+	disableStatusDetail bool
+	cache               cache.Store
+}
+
+// giteaConsumer implements vcs.Server and it's used to instantiate a giteaClient
+type giteaConsumer struct {
+	URL                      string `json:"url"`
+	clientID                 string
+	clientSecret             string
+	cache                    cache.Store
+	AuthorizationCallbackURL string
+	uiURL                    string
+	proxyURL                 string
+	disableStatus            bool
+	disableStatusDetail      bool
+}
+
+// New instantiate a new gitea consumer
+func New(clientID, clientSecret, URL, callbackURL, uiURL, proxyURL string, store cache.Store, disableStatus bool, disableStatusDetail bool) sdk.VCSServer {
+	return &giteaConsumer{
+		URL:                      strings.TrimSuffix(URL, "/"),
+		clientID:                 clientID,
+		clientSecret:             clientSecret,
+		cache:                    store,
+		AuthorizationCallbackURL: callbackURL,
+		uiURL:                    uiURL,
+		proxyURL:                 proxyURL,
+		disableStatus:            disableStatus,
+		disableStatusDetail:      disableStatusDetail,
+	}
+}
+
+func (c *giteaClient) GetAccessToken(_ context.Context) string {
+	return c.accessToken
+}
@@ -0,0 +1,87 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func newTestClient(h http.HandlerFunc) (*giteaClient, func()) {
+	srv := httptest.NewServer(h)
+	consumer := New("id", "secret", srv.URL, "", "https://cds.local", "", nil, false, false).(*giteaConsumer)
+	return consumer.newClient("my-token", "", time.Time{}), srv.Close
+}
+
+func TestCommits(t *testing.T) {
+	c, closeSrv := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token my-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/api/v1/repos/owner/repo/commits", r.URL.Path)
+		assert.Equal(t, "abcd", r.URL.Query().Get("sha"))
+		json.NewEncoder(w).Encode([]Commit{
+			{SHA: "abcd", Commit: RepoCommit{Message: "second"}, Author: &User{Login: "john"}},
+			{SHA: "1234", Commit: RepoCommit{Message: "first"}},
+			{SHA: "0000", Commit: RepoCommit{Message: "init"}},
+		})
+	})
+	defer closeSrv()
+
+	commits, err := c.Commits(context.TODO(), "owner/repo", "master", "0000", "abcd")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "abcd", commits[0].Hash)
+	assert.Equal(t, "john", commits[0].Author.Name)
+	assert.Equal(t, "first", commits[1].Message)
+}
+
+func TestPullRequestComment(t *testing.T) {
+	c, closeSrv := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/repos/owner/repo/issues/3/comments", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"body":"my comment"}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer closeSrv()
+
+	require.NoError(t, c.PullRequestComment(context.TODO(), "owner/repo", 3, "my comment"))
+}
+
+func TestCreateHook(t *testing.T) {
+	c, closeSrv := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/repos/owner/repo/hooks", r.URL.Path)
+		var h Hook
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&h))
+		assert.Equal(t, "gitea", h.Type)
+		assert.Equal(t, []string{"push"}, h.Events)
+		assert.Equal(t, "https://proxy.local/my-uuid", h.Config["url"])
+		h.ID = 42
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(h)
+	})
+	defer closeSrv()
+	c.proxyURL = "https://proxy.local/"
+
+	hook := sdk.VCSHook{URL: "https://cds.local/webhook/my-uuid"}
+	require.NoError(t, c.CreateHook(context.TODO(), "owner/repo", &hook))
+	assert.Equal(t, "42", hook.ID)
+}
+
+func TestNotFound(t *testing.T) {
+	c, closeSrv := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer closeSrv()
+
+	_, err := c.RepoByFullname(context.TODO(), "owner/unknown")
+	require.Error(t, err)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
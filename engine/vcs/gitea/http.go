@@ -0,0 +1,135 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// pageLimit is the max page size allowed by default on Gitea API
+const pageLimit = 50
+
+var httpClient = cdsclient.NewHTTPClient(time.Second*30, false)
+
+func pageParams(page int) url.Values {
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("limit", strconv.Itoa(pageLimit))
+	return params
+}
+
+func (c *giteaClient) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, params, nil, out)
+}
+
+func (c *giteaClient) post(ctx context.Context, path string, in, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, nil, in, out)
+}
+
+func (c *giteaClient) patch(ctx context.Context, path string, in, out interface{}) error {
+	return c.do(ctx, http.MethodPatch, path, nil, in, out)
+}
+
+func (c *giteaClient) delete(ctx context.Context, path string) error {
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// do calls the Gitea API, the body is sent as JSON and the response is unmarshalled into out if not nil
+func (c *giteaClient) do(ctx context.Context, method, path string, params url.Values, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return sdk.WrapError(err, "cannot marshal body")
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := c.newRequest(ctx, method, path, params, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.doRequest(ctx, req, out)
+}
+
+func (c *giteaClient) newRequest(ctx context.Context, method, path string, params url.Values, body io.Reader) (*http.Request, error) {
+	u := c.apiURL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "token "+c.accessToken)
+	return req, nil
+}
+
+func (c *giteaClient) doRequest(ctx context.Context, req *http.Request, out interface{}) error {
+	log.Debug("Gitea API>> %s %s", req.Method, req.URL.String())
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return sdk.WrapError(err, "cannot call gitea API")
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return sdk.WithStack(sdk.ErrNotFound)
+	case res.StatusCode == http.StatusForbidden:
+		return sdk.WithStack(sdk.ErrForbidden)
+	case res.StatusCode == http.StatusUnauthorized:
+		return sdk.WithStack(sdk.ErrUnauthorized)
+	case res.StatusCode >= 400:
+		return sdk.WithStack(errorAPI(res.StatusCode, body))
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return sdk.WrapError(err, "cannot unmarshal gitea response: %s", string(body))
+	}
+	return nil
+}
+
+// Error match Gitea API error format
+type Error struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message"`
+	URL        string `json:"url"`
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("gitea error (%d) %s", e.StatusCode, e.Message)
+}
+
+func errorAPI(status int, body []byte) error {
+	e := Error{StatusCode: status}
+	if err := json.Unmarshal(body, &e); err != nil || e.Message == "" {
+		e.Message = string(body)
+	}
+	return e
+}
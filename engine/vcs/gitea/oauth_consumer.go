@@ -0,0 +1,158 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Gitea access tokens expire after one hour by default
+const accessTokenLifetime = time.Hour
+
+type authorizeResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// oauthError match Gitea OAuth2 error format
+type oauthError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// AuthorizeRedirect returns the request token, the Authorize URL
+func (g *giteaConsumer) AuthorizeRedirect(ctx context.Context) (string, string, error) {
+	// See https://docs.gitea.io/en-us/oauth2-provider/
+	requestToken, err := sdk.GenerateHash()
+	if err != nil {
+		return "", "", err
+	}
+
+	val := url.Values{}
+	val.Add("redirect_uri", g.AuthorizationCallbackURL)
+	val.Add("client_id", g.clientID)
+	val.Add("response_type", "code")
+	val.Add("state", requestToken)
+
+	return requestToken, fmt.Sprintf("%s/login/oauth/authorize?%s", g.URL, val.Encode()), nil
+}
+
+// AuthorizeToken returns the authorized token (and its refresh_token)
+// from the request token and the code got on authorize url
+func (g *giteaConsumer) AuthorizeToken(ctx context.Context, state, code string) (string, string, error) {
+	params := url.Values{}
+	params.Add("client_id", g.clientID)
+	params.Add("client_secret", g.clientSecret)
+	params.Add("code", code)
+	params.Add("grant_type", "authorization_code")
+	params.Add("redirect_uri", g.AuthorizationCallbackURL)
+
+	resp, err := g.accessToken(params)
+	if err != nil {
+		return "", "", err
+	}
+	return resp.AccessToken, resp.RefreshToken, nil
+}
+
+// RefreshToken returns a new access token from a refresh token
+func (g *giteaConsumer) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+	params := url.Values{}
+	params.Add("client_id", g.clientID)
+	params.Add("client_secret", g.clientSecret)
+	params.Add("refresh_token", refreshToken)
+	params.Add("grant_type", "refresh_token")
+
+	resp, err := g.accessToken(params)
+	if err != nil {
+		return "", "", err
+	}
+	return resp.AccessToken, resp.RefreshToken, nil
+}
+
+func (g *giteaConsumer) accessToken(params url.Values) (authorizeResponse, error) {
+	var resp authorizeResponse
+
+	req, err := http.NewRequest(http.MethodPost, g.URL+"/login/oauth/access_token", strings.NewReader(params.Encode()))
+	if err != nil {
+		return resp, sdk.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return resp, sdk.WrapError(err, "cannot get gitea access token")
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return resp, sdk.WithStack(err)
+	}
+
+	if res.StatusCode >= 400 {
+		var oErr oauthError
+		if err := json.Unmarshal(body, &oErr); err == nil && oErr.Error != "" {
+			return resp, fmt.Errorf("gitea error (%d) %s: %s", res.StatusCode, oErr.Error, oErr.Description)
+		}
+		return resp, fmt.Errorf("gitea error (%d) %s", res.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, fmt.Errorf("unable to parse gitea response (%d) %s", res.StatusCode, string(body))
+	}
+	return resp, nil
+}
+
+// keep client in memory
+var (
+	instancesAuthorizedClient      = map[string]*giteaClient{}
+	instancesAuthorizedClientMutex sync.Mutex
+)
+
+// GetAuthorizedClient returns an authorized client. Clients are kept by the access token stored in CDS, when the
+// access token expires it is refreshed and the client is replaced.
+func (g *giteaConsumer) GetAuthorizedClient(ctx context.Context, accessToken, refreshToken string, created int64) (sdk.VCSAuthorizedClient, error) {
+	instancesAuthorizedClientMutex.Lock()
+	defer instancesAuthorizedClientMutex.Unlock()
+
+	c, ok := instancesAuthorizedClient[accessToken]
+	if !ok {
+		c = g.newClient(accessToken, refreshToken, time.Unix(created, 0).Add(accessTokenLifetime))
+		instancesAuthorizedClient[accessToken] = c
+	}
+
+	if created > 0 && c.expiresAt.Before(time.Now()) {
+		newAccessToken, newRefreshToken, err := g.RefreshToken(ctx, c.refreshToken)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot refresh token")
+		}
+		c = g.newClient(newAccessToken, newRefreshToken, time.Now().Add(accessTokenLifetime))
+		instancesAuthorizedClient[accessToken] = c
+	}
+
+	return c, nil
+}
+
+func (g *giteaConsumer) newClient(accessToken, refreshToken string, expiresAt time.Time) *giteaClient {
+	return &giteaClient{
+		apiURL:              g.URL + "/api/v1",
+		accessToken:         accessToken,
+		refreshToken:        refreshToken,
+		expiresAt:           expiresAt,
+		uiURL:               g.uiURL,
+		proxyURL:            g.proxyURL,
+		disableStatus:       g.disableStatus,
+		disableStatusDetail: g.disableStatusDetail,
+		cache:               g.cache,
+	}
+}
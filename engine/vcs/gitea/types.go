@@ -0,0 +1,143 @@
+package gitea
+
+import "time"
+
+// User represents a Gitea user
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// Repository represents a Gitea repository
+type Repository struct {
+	ID            int64  `json:"id"`
+	Owner         User   `json:"owner"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+	SSHURL        string `json:"ssh_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// CommitUser is the author or the committer of a commit
+type CommitUser struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// RepoCommit is the git part of a commit
+type RepoCommit struct {
+	Message   string     `json:"message"`
+	Author    CommitUser `json:"author"`
+	Committer CommitUser `json:"committer"`
+}
+
+// Commit represents a commit returned by the commits API
+type Commit struct {
+	SHA     string     `json:"sha"`
+	HTMLURL string     `json:"html_url"`
+	Commit  RepoCommit `json:"commit"`
+	Author  *User      `json:"author"`
+}
+
+// PayloadCommit represents a commit in a branch
+type PayloadCommit struct {
+	ID        string    `json:"id"`
+	Message   string    `json:"message"`
+	URL       string    `json:"url"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    struct {
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+// Branch represents a repository branch
+type Branch struct {
+	Name   string        `json:"name"`
+	Commit PayloadCommit `json:"commit"`
+}
+
+// Tag represents a repository tag
+type Tag struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	ID      string `json:"id"`
+	Commit  struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// Compare is the result of the comparison of two refs
+type Compare struct {
+	TotalCommits int      `json:"total_commits"`
+	Commits      []Commit `json:"commits"`
+}
+
+// PRBranchInfo is the head or the base of a pull request
+type PRBranchInfo struct {
+	Name string     `json:"label"`
+	Ref  string     `json:"ref"`
+	Sha  string     `json:"sha"`
+	Repo Repository `json:"repo"`
+}
+
+// PullRequest represents a Gitea pull request
+type PullRequest struct {
+	ID      int64        `json:"id"`
+	Index   int          `json:"number"`
+	HTMLURL string       `json:"html_url"`
+	Title   string       `json:"title"`
+	Body    string       `json:"body"`
+	State   string       `json:"state"`
+	Merged  bool         `json:"merged"`
+	User    User         `json:"user"`
+	Head    PRBranchInfo `json:"head"`
+	Base    PRBranchInfo `json:"base"`
+}
+
+// CreatePullRequestOption is the body to create a pull request
+type CreatePullRequestOption struct {
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// CreateIssueCommentOption is the body to comment a pull request
+type CreateIssueCommentOption struct {
+	Body string `json:"body"`
+}
+
+// Hook represents a repository webhook
+type Hook struct {
+	ID     int64             `json:"id,omitempty"`
+	Type   string            `json:"type,omitempty"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// Status represents a commit status
+type Status struct {
+	ID          int64     `json:"id,omitempty"`
+	State       string    `json:"state"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	Context     string    `json:"context"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+// Release represents a repository release
+type Release struct {
+	ID      int64  `json:"id,omitempty"`
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	Body    string `json:"body"`
+}
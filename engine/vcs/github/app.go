@@ -0,0 +1,137 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// App is a GitHub App, it authenticates as an installation on a repository to use APIs that are not
+// available with OAuth tokens like the checks API.
+type App struct {
+	ID         int64
+	PrivateKey string
+}
+
+// jwt returns a token that authenticates as the GitHub App, valid for a few minutes.
+func (a *App) jwt() (string, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(a.PrivateKey))
+	if err != nil {
+		return "", sdk.WrapError(err, "invalid GitHub App private key")
+	}
+	now := time.Now()
+	// Issue the token in the past to allow some clock drift with GitHub
+	claims := jwt.StandardClaims{
+		IssuedAt:  now.Add(-time.Minute).Unix(),
+		ExpiresAt: now.Add(9 * time.Minute).Unix(),
+		Issuer:    strconv.FormatInt(a.ID, 10),
+	}
+	s, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	return s, sdk.WithStack(err)
+}
+
+// installationToken returns a token of the App installation on given repository.
+// Tokens are valid for one hour and kept in cache until they are about to expire.
+func (c *githubClient) installationToken(ctx context.Context, repo string) (string, error) {
+	k := cache.Key("vcs", "github", "app", strconv.FormatInt(c.checkRunsApp.ID, 10), "token", repo)
+	var token string
+	if _, err := c.Cache.Get(k, &token); err != nil {
+		log.Error(ctx, "cannot get from cache %s: %v", k, err)
+	}
+	if token != "" {
+		return token, nil
+	}
+
+	appToken, err := c.checkRunsApp.jwt()
+	if err != nil {
+		return "", err
+	}
+
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.appRequest(ctx, http.MethodGet, "/repos/"+repo+"/installation", "Bearer "+appToken, nil, &installation); err != nil {
+		return "", sdk.WrapError(err, "cannot get GitHub App installation on repository %s", repo)
+	}
+
+	var accessToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := c.appRequest(ctx, http.MethodPost, fmt.Sprintf("/app/installations/%d/access_tokens", installation.ID), "Bearer "+appToken, nil, &accessToken); err != nil {
+		return "", sdk.WrapError(err, "cannot get GitHub App installation token for repository %s", repo)
+	}
+
+	ttl := int(time.Until(accessToken.ExpiresAt).Seconds()) - 5*60
+	if ttl > 0 {
+		if err := c.Cache.SetWithTTL(k, accessToken.Token, ttl); err != nil {
+			log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
+		}
+	}
+	return accessToken.Token, nil
+}
+
+// appRequest sends a JSON request authenticated as the GitHub App or one of its installations.
+func (c *githubClient) appRequest(ctx context.Context, method, path, authorization string, in, out interface{}) error {
+	if !strings.HasPrefix(path, c.GitHubAPIURL) {
+		path = c.GitHubAPIURL + path
+	}
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, path, body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", "CDS-gh_client_id="+c.ClientID)
+	// Preview media types are still required by GitHub Enterprise for apps and checks APIs
+	req.Header.Add("Accept", "application/vnd.github.machine-man-preview+json")
+	req.Header.Add("Accept", "application/vnd.github.antiope-preview+json")
+	req.Header.Set("Authorization", authorization)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	log.Debug("Github API>> Request URL %s", req.URL.String())
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if res.StatusCode >= 400 {
+		return sdk.WithStack(fmt.Errorf("%s %s returned status %d: %s", method, path, res.StatusCode, resBody))
+	}
+	if out != nil {
+		if err := json.Unmarshal(resBody, out); err != nil {
+			return sdk.WrapError(err, "cannot unmarshal response of %s %s", method, path)
+		}
+	}
+	return nil
+}
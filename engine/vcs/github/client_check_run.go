@@ -0,0 +1,207 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	// checkRunAnnotationPath is the path of annotations, failures are not attached to a file of the repository.
+	checkRunAnnotationPath = "."
+	// maxCheckRunAnnotations is the max number of annotations accepted by GitHub in one request.
+	maxCheckRunAnnotations = 50
+	// checkRunCacheTTL is the duration while the ID of a check run is kept to update it.
+	checkRunCacheTTL = 7 * 24 * 60 * 60
+)
+
+// checkRunState is kept in cache to update a check run created for a node run.
+type checkRunState struct {
+	ID         int64  `json:"id"`
+	Conclusion string `json:"conclusion"`
+	Detailed   bool   `json:"detailed"`
+}
+
+// setCheckRun creates or updates the check run of a workflow node run.
+// https://developer.github.com/v3/checks/runs/
+func (g *githubClient) setCheckRun(ctx context.Context, event sdk.Event) error {
+	var eventNR sdk.EventRunWorkflowNode
+	if err := mapstructure.Decode(event.Payload, &eventNR); err != nil {
+		return sdk.WrapError(err, "Error during consumption")
+	}
+
+	run, ok := checkRunFromEvent(event, eventNR, g.uiURL, g.DisableStatusDetail)
+	if !ok {
+		log.Debug("github.setCheckRun> Do not process event for current status: %v", event)
+		return nil
+	}
+	repo := eventNR.RepositoryFullName
+	detailed := eventNR.Failures != nil
+
+	k := cache.Key("vcs", "github", "checkrun", repo, run.HeadSHA, run.ExternalID)
+	var state checkRunState
+	if _, err := g.Cache.Get(k, &state); err != nil {
+		log.Error(ctx, "cannot get from cache %s: %v", k, err)
+	}
+	// The result of a node run is sent twice, do not lose the failures sent by the first one
+	if state.ID != 0 && state.Detailed && !detailed && state.Conclusion == run.Conclusion {
+		return nil
+	}
+
+	token, err := g.installationToken(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	var res CheckRun
+	if state.ID == 0 {
+		err = g.appRequest(ctx, http.MethodPost, "/repos/"+repo+"/check-runs", "token "+token, run, &res)
+	} else {
+		path := fmt.Sprintf("/repos/%s/check-runs/%d", repo, state.ID)
+		// Name and head sha can't be updated
+		run.Name, run.HeadSHA = "", ""
+		err = g.appRequest(ctx, http.MethodPatch, path, "token "+token, run, &res)
+	}
+	if err != nil {
+		return sdk.WrapError(err, "unable to set check run %s on %s", run.ExternalID, repo)
+	}
+
+	state = checkRunState{ID: res.ID, Conclusion: run.Conclusion, Detailed: detailed}
+	if err := g.Cache.SetWithTTL(k, state, checkRunCacheTTL); err != nil {
+		log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
+	}
+
+	log.Debug("setCheckRun> Check run %d %s set to %s %s", res.ID, run.ExternalID, run.Status, run.Conclusion)
+	return nil
+}
+
+// checkRunFromEvent returns the check run for the status of a node run, it returns false if the status is not reported.
+func checkRunFromEvent(event sdk.Event, eventNR sdk.EventRunWorkflowNode, cdsUIURL string, disabledStatusDetail bool) (CheckRun, bool) {
+	run := CheckRun{
+		Name:       sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR),
+		HeadSHA:    eventNR.Hash,
+		ExternalID: fmt.Sprintf("%s/%s/%d/%s", event.ProjectKey, event.WorkflowName, eventNR.Number, eventNR.NodeName),
+	}
+
+	switch eventNR.Status {
+	case sdk.StatusWaiting:
+		run.Status = "queued"
+	case sdk.StatusBuilding:
+		run.Status = "in_progress"
+	case sdk.StatusSuccess:
+		run.Status, run.Conclusion = "completed", "success"
+	case sdk.StatusFail:
+		run.Status, run.Conclusion = "completed", "failure"
+	case sdk.StatusStopped:
+		run.Status, run.Conclusion = "completed", "cancelled"
+	default:
+		return run, false
+	}
+
+	if eventNR.Start > 0 {
+		start := time.Unix(eventNR.Start, 0)
+		run.StartedAt = &start
+	}
+	if run.Status == "completed" {
+		done := time.Now()
+		if eventNR.Done > 0 {
+			done = time.Unix(eventNR.Done, 0)
+		}
+		run.CompletedAt = &done
+	}
+
+	runURL := fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", cdsUIURL, event.ProjectKey, event.WorkflowName, eventNR.Number)
+	//CDS can avoid sending github target url in check run, if it's disable
+	if !disabledStatusDetail {
+		run.DetailsURL = runURL
+	}
+
+	run.Output = &CheckRunOutput{
+		Title:   eventNR.NodeName + ": " + eventNR.Status,
+		Summary: checkRunSummary(event, eventNR, runURL, disabledStatusDetail),
+	}
+	if eventNR.Failures != nil {
+		run.Output.Text, run.Output.Annotations = checkRunFailures(*eventNR.Failures)
+	}
+	return run, true
+}
+
+func checkRunSummary(event sdk.Event, eventNR sdk.EventRunWorkflowNode, runURL string, disabledStatusDetail bool) string {
+	var b strings.Builder
+	runName := fmt.Sprintf("%s/%s #%d.%d", event.ProjectKey, event.WorkflowName, eventNR.Number, eventNR.SubNumber)
+	if !disabledStatusDetail {
+		runName = fmt.Sprintf("[%s](%s)", runName, runURL)
+	}
+	fmt.Fprintf(&b, "Node **%s** of workflow run %s: **%s**\n", eventNR.NodeName, runName, eventNR.Status)
+
+	for _, s := range eventNR.StagesSummary {
+		for _, rj := range s.RunJobsSummary {
+			fmt.Fprintf(&b, "\n* %s / %s: %s", s.Name, rj.Job.JobName, rj.Status)
+		}
+	}
+	return b.String()
+}
+
+// checkRunFailures returns the text of a check run with the logs of failed steps, and annotations for failed
+// steps and tests.
+func checkRunFailures(failures sdk.WorkflowNodeRunFailures) (string, []CheckRunAnnotation) {
+	var b strings.Builder
+	var annotations []CheckRunAnnotation
+
+	for _, s := range failures.Steps {
+		title := fmt.Sprintf("Job %s failed on step %d", s.Job, s.StepOrder)
+		if s.StepName != "" {
+			title += " " + s.StepName
+		}
+		fmt.Fprintf(&b, "### %s\n\n```\n%s\n```\n\n", title, s.LogExcerpt)
+		message := s.LogExcerpt
+		if message == "" {
+			message = title
+		}
+		annotations = append(annotations, CheckRunAnnotation{
+			Path:            checkRunAnnotationPath,
+			StartLine:       1,
+			EndLine:         1,
+			AnnotationLevel: "failure",
+			Title:           title,
+			Message:         message,
+		})
+	}
+
+	if len(failures.Tests) > 0 {
+		b.WriteString("### Failed tests\n\n")
+	}
+	for _, t := range failures.Tests {
+		name := t.TestSuite + " / " + t.TestCase
+		fmt.Fprintf(&b, "* %s", name)
+		message := t.Message
+		if message != "" {
+			fmt.Fprintf(&b, ": %s", message)
+		} else {
+			message = "Test failed"
+		}
+		b.WriteString("\n")
+		annotations = append(annotations, CheckRunAnnotation{
+			Path:            checkRunAnnotationPath,
+			StartLine:       1,
+			EndLine:         1,
+			AnnotationLevel: "failure",
+			Title:           "Test " + name + " failed",
+			Message:         message,
+			RawDetails:      t.Details,
+		})
+	}
+
+	if len(annotations) > maxCheckRunAnnotations {
+		annotations = annotations[:maxCheckRunAnnotations]
+	}
+	return strings.TrimSpace(b.String()), annotations
+}
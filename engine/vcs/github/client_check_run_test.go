@@ -0,0 +1,71 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestCheckRunFromEvent(t *testing.T) {
+	event := sdk.Event{ProjectKey: "KEY", WorkflowName: "my-workflow"}
+	eventNR := sdk.EventRunWorkflowNode{
+		Number:   12,
+		NodeName: "build",
+		Hash:     "abcdef",
+		Status:   sdk.StatusBuilding,
+		Start:    1500000000,
+		StagesSummary: []sdk.StageSummary{{
+			Name:           "Stage 1",
+			RunJobsSummary: []sdk.WorkflowNodeJobRunSummary{{Status: sdk.StatusBuilding, Job: sdk.ExecutedJobSummary{JobName: "Compile"}}},
+		}},
+	}
+
+	run, ok := checkRunFromEvent(event, eventNR, "https://cds.local", false)
+	require.True(t, ok)
+	assert.Equal(t, "CDS/KEY-my-workflow-build", run.Name)
+	assert.Equal(t, "abcdef", run.HeadSHA)
+	assert.Equal(t, "KEY/my-workflow/12/build", run.ExternalID)
+	assert.Equal(t, "in_progress", run.Status)
+	assert.Equal(t, "", run.Conclusion)
+	assert.Nil(t, run.CompletedAt)
+	assert.Equal(t, "https://cds.local/project/KEY/workflow/my-workflow/run/12", run.DetailsURL)
+	assert.Contains(t, run.Output.Summary, "Stage 1 / Compile: Building")
+
+	eventNR.Status = sdk.StatusFail
+	eventNR.Failures = &sdk.WorkflowNodeRunFailures{
+		Steps: []sdk.WorkflowNodeRunFailedStep{{Stage: "Stage 1", Job: "Compile", StepOrder: 1, StepName: "go build", LogExcerpt: "main.go:3: syntax error"}},
+		Tests: []sdk.WorkflowNodeRunFailedTest{{TestSuite: "pkg", TestCase: "TestFoo", Message: "expected 1", Details: "foo_test.go:12"}},
+	}
+	run, ok = checkRunFromEvent(event, eventNR, "https://cds.local", true)
+	require.True(t, ok)
+	assert.Equal(t, "completed", run.Status)
+	assert.Equal(t, "failure", run.Conclusion)
+	assert.NotNil(t, run.CompletedAt)
+	assert.Equal(t, "", run.DetailsURL)
+	assert.False(t, strings.Contains(run.Output.Summary, "https://cds.local"))
+	assert.Contains(t, run.Output.Text, "main.go:3: syntax error")
+	assert.Contains(t, run.Output.Text, "pkg / TestFoo: expected 1")
+	require.Len(t, run.Output.Annotations, 2)
+	assert.Equal(t, "Job Compile failed on step 1 go build", run.Output.Annotations[0].Title)
+	assert.Equal(t, "failure", run.Output.Annotations[0].AnnotationLevel)
+	assert.Equal(t, "expected 1", run.Output.Annotations[1].Message)
+	assert.Equal(t, "foo_test.go:12", run.Output.Annotations[1].RawDetails)
+
+	eventNR.Status = sdk.StatusSkipped
+	_, ok = checkRunFromEvent(event, eventNR, "https://cds.local", false)
+	assert.False(t, ok)
+}
+
+func TestCheckRunFailuresMaxAnnotations(t *testing.T) {
+	var failures sdk.WorkflowNodeRunFailures
+	for i := 0; i < 60; i++ {
+		failures.Tests = append(failures.Tests, sdk.WorkflowNodeRunFailedTest{TestSuite: "pkg", TestCase: "TestFoo"})
+	}
+	_, annotations := checkRunFailures(failures)
+	assert.Len(t, annotations, maxCheckRunAnnotations)
+	assert.Equal(t, "Test failed", annotations[0].Message)
+}
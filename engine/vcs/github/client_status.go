@@ -34,6 +34,11 @@ func (g *githubClient) SetStatus(ctx context.Context, event sdk.Event) error {
 		return nil
 	}
 
+	// Report node results as check runs when a GitHub App is configured
+	if g.checkRunsApp != nil && event.EventType == fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}) {
+		return g.setCheckRun(ctx, event)
+	}
+
 	var data statusData
 	var err error
 	switch event.EventType {
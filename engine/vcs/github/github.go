@@ -16,6 +16,7 @@ type githubClient struct {
 	DisableStatus       bool
 	DisableStatusDetail bool
 	Cache               cache.Store
+	checkRunsApp        *App
 	apiURL              string
 	uiURL               string
 	proxyURL            string
@@ -35,12 +36,13 @@ type githubConsumer struct {
 	proxyURL            string
 	disableStatus       bool
 	disableStatusDetail bool
+	checkRunsApp        *App
 	username            string
 	token               string
 }
 
-//New creates a new GithubConsumer
-func New(ClientID, ClientSecret, githubURL, githubAPIURL, apiURL, uiURL, proxyURL, username, token string, store cache.Store, disableStatus, disableStatusDetail bool) sdk.VCSServer {
+//New creates a new GithubConsumer, if checkRunsApp is set node results are reported as check runs instead of statuses
+func New(ClientID, ClientSecret, githubURL, githubAPIURL, apiURL, uiURL, proxyURL, username, token string, store cache.Store, disableStatus, disableStatusDetail bool, checkRunsApp *App) sdk.VCSServer {
 	//Github const
 	const (
 		publicURL    = "https://github.com"
@@ -65,6 +67,7 @@ func New(ClientID, ClientSecret, githubURL, githubAPIURL, apiURL, uiURL, proxyUR
 		proxyURL:            proxyURL,
 		disableStatus:       disableStatus,
 		disableStatusDetail: disableStatusDetail,
+		checkRunsApp:        checkRunsApp,
 		username:            username,
 		token:               token,
 	}
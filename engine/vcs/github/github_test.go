@@ -40,7 +40,7 @@ func getNewConsumer(t *testing.T) sdk.VCSServer {
 		t.Fatalf("Unable to init cache (%s): %v", redisHost, err)
 	}
 
-	ghConsummer := New(clientID, clientSecret, "", "", "http://localhost", "", "", "", "", cache, true, true)
+	ghConsummer := New(clientID, clientSecret, "", "", "http://localhost", "", "", "", "", cache, true, true, nil)
 	return ghConsummer
 }
 
@@ -63,7 +63,7 @@ func getNewAuthorizedClient(t *testing.T) sdk.VCSAuthorizedClient {
 		t.Fatalf("Unable to init cache (%s): %v", redisHost, err)
 	}
 
-	ghConsummer := New(clientID, clientSecret, "", "", "http://localhost", "", "", "", "", cache, true, true)
+	ghConsummer := New(clientID, clientSecret, "", "", "http://localhost", "", "", "", "", cache, true, true, nil)
 	cli, err := ghConsummer.GetAuthorizedClient(context.Background(), accessToken, "", 0)
 	if err != nil {
 		t.Fatalf("Unable to init authorized client (%s): %v", redisHost, err)
@@ -93,6 +93,7 @@ func (g *githubConsumer) GetAuthorizedClient(ctx context.Context, accessToken, a
 			uiURL:               g.uiURL,
 			DisableStatus:       g.disableStatus,
 			DisableStatusDetail: g.disableStatusDetail,
+			checkRunsApp:        g.checkRunsApp,
 			apiURL:              g.apiURL,
 			proxyURL:            g.proxyURL,
 			username:            g.username,
@@ -0,0 +1,150 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// mergeRequestNoteCacheTTL is the duration while the summary note of a workflow run is kept to update it.
+const mergeRequestNoteCacheTTL = 7 * 24 * 60 * 60
+
+// mergeRequestNote is kept in cache to update the summary note of a workflow run on a merge request.
+type mergeRequestNote struct {
+	NoteID int                    `json:"note_id"`
+	Nodes  []mergeRequestNoteNode `json:"nodes"`
+}
+
+type mergeRequestNoteNode struct {
+	Name      string   `json:"name"`
+	SubNumber int64    `json:"sub_number"`
+	Status    string   `json:"status"`
+	URL       string   `json:"url"`
+	Artifacts []string `json:"artifacts"`
+}
+
+// setMergeRequestNote creates or updates the note that summarizes the status of the nodes of a workflow run on
+// the opened merge requests of the run's commit.
+func (c *gitlabClient) setMergeRequestNote(ctx context.Context, event sdk.Event, data statusData) error {
+	if data.branchName == "" || data.hash == "" {
+		return nil
+	}
+
+	mrs, err := c.openedMergeRequests(data.repoFullName, &data.branchName)
+	if err != nil {
+		return err
+	}
+
+	for _, mr := range mrs {
+		if mr.SHA != data.hash {
+			continue
+		}
+
+		k := cache.Key("vcs", "gitlab", "mrnote", data.repoFullName, strconv.Itoa(mr.IID), event.ProjectKey, event.WorkflowName, strconv.FormatInt(data.nodeRun.Number, 10))
+		var note mergeRequestNote
+		if _, err := c.cache.Get(k, &note); err != nil {
+			log.Error(ctx, "cannot get from cache %s: %v", k, err)
+		}
+
+		node := mergeRequestNoteNode{
+			Name:      data.nodeRun.NodeName,
+			SubNumber: data.nodeRun.SubNumber,
+			Status:    data.nodeRun.Status,
+			Artifacts: data.nodeRun.Artifacts,
+		}
+		if data.url != "" {
+			node.URL = fmt.Sprintf("%s/node/%d?name=%s", data.url, data.nodeRun.ID, url.QueryEscape(data.nodeRun.NodeName))
+		}
+		note.setNode(node)
+		body := note.body(event, data.nodeRun.Number, data.url)
+
+		if note.NoteID != 0 {
+			_, resp, err := c.client.Notes.UpdateMergeRequestNote(data.repoFullName, mr.IID, note.NoteID, &gitlab.UpdateMergeRequestNoteOptions{Body: &body})
+			// The note was deleted from the merge request, post a new one
+			if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+				note.NoteID = 0
+			} else if err != nil {
+				return sdk.WrapError(err, "unable to update note %d on merge request %d", note.NoteID, mr.IID)
+			}
+		}
+		if note.NoteID == 0 {
+			n, _, err := c.client.Notes.CreateMergeRequestNote(data.repoFullName, mr.IID, &gitlab.CreateMergeRequestNoteOptions{Body: &body})
+			if err != nil {
+				return sdk.WrapError(err, "unable to create note on merge request %d", mr.IID)
+			}
+			note.NoteID = n.ID
+		}
+
+		if err := c.cache.SetWithTTL(k, note, mergeRequestNoteCacheTTL); err != nil {
+			log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
+		}
+	}
+	return nil
+}
+
+// setNode adds or replaces the status of a node, nodes are kept in the order of their first status.
+func (n *mergeRequestNote) setNode(node mergeRequestNoteNode) {
+	for i := range n.Nodes {
+		if n.Nodes[i].Name == node.Name {
+			// Do not override the result of a restarted node with an older event
+			if n.Nodes[i].SubNumber > node.SubNumber {
+				return
+			}
+			n.Nodes[i] = node
+			return
+		}
+	}
+	n.Nodes = append(n.Nodes, node)
+}
+
+// body returns the markdown content of the note.
+func (n mergeRequestNote) body(event sdk.Event, number int64, runURL string) string {
+	var b strings.Builder
+	runName := fmt.Sprintf("%s/%s #%d", event.ProjectKey, event.WorkflowName, number)
+	if runURL != "" {
+		runName = fmt.Sprintf("[%s](%s)", runName, runURL)
+	}
+	fmt.Fprintf(&b, "**CDS** workflow run %s\n\n", runName)
+	b.WriteString("| Node | Status | Artifacts |\n| --- | --- | --- |\n")
+	for _, node := range n.Nodes {
+		name := node.Name
+		if node.SubNumber > 0 {
+			name = fmt.Sprintf("%s (#%d.%d)", name, number, node.SubNumber)
+		}
+		artifacts := make([]string, len(node.Artifacts))
+		for i, a := range node.Artifacts {
+			artifacts[i] = "`" + a + "`"
+			if node.URL != "" {
+				artifacts[i] = fmt.Sprintf("[%s](%s)", a, node.URL)
+			}
+		}
+		if node.URL != "" {
+			name = fmt.Sprintf("[%s](%s)", name, node.URL)
+		}
+		fmt.Fprintf(&b, "| %s | %s %s | %s |\n", name, mergeRequestNoteStatusIcon(node.Status), node.Status, strings.Join(artifacts, ", "))
+	}
+	return b.String()
+}
+
+func mergeRequestNoteStatusIcon(status string) string {
+	switch status {
+	case sdk.StatusSuccess:
+		return ":white_check_mark:"
+	case sdk.StatusFail:
+		return ":x:"
+	case sdk.StatusStopped:
+		return ":no_entry:"
+	case sdk.StatusBuilding, sdk.StatusWaiting, sdk.StatusChecking:
+		return ":hourglass:"
+	}
+	return ":grey_question:"
+}
@@ -0,0 +1,28 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestMergeRequestNote(t *testing.T) {
+	var note mergeRequestNote
+	note.setNode(mergeRequestNoteNode{Name: "build", Status: sdk.StatusBuilding, URL: "https://cds.local/run/1/node/10?name=build"})
+	note.setNode(mergeRequestNoteNode{Name: "deploy", Status: sdk.StatusWaiting})
+	note.setNode(mergeRequestNoteNode{Name: "build", SubNumber: 1, Status: sdk.StatusSuccess, URL: "https://cds.local/run/1/node/11?name=build", Artifacts: []string{"app.tar.gz"}})
+	// An event of the first execution of the node received after the restart must be ignored
+	note.setNode(mergeRequestNoteNode{Name: "build", Status: sdk.StatusFail})
+
+	require.Len(t, note.Nodes, 2)
+	assert.Equal(t, "build", note.Nodes[0].Name)
+	assert.Equal(t, sdk.StatusSuccess, note.Nodes[0].Status)
+
+	body := note.body(sdk.Event{ProjectKey: "KEY", WorkflowName: "my-workflow"}, 1, "https://cds.local/run/1")
+	assert.Contains(t, body, "**CDS** workflow run [KEY/my-workflow #1](https://cds.local/run/1)")
+	assert.Contains(t, body, "| [build (#1.1)](https://cds.local/run/1/node/11?name=build) | :white_check_mark: Success | [app.tar.gz](https://cds.local/run/1/node/11?name=build) |")
+	assert.Contains(t, body, "| deploy | :hourglass: Waiting |  |")
+}
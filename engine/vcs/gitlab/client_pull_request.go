@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/xanzy/go-gitlab"
+
 	"github.com/ovh/cds/sdk"
 )
 
@@ -11,13 +13,25 @@ func (c *gitlabClient) PullRequest(ctx context.Context, repo string, id int) (sd
 	return sdk.VCSPullRequest{}, nil
 }
 
-// PullRequests fetch all the pull request for a repository
-func (c *gitlabClient) PullRequests(context.Context, string) ([]sdk.VCSPullRequest, error) {
-	return []sdk.VCSPullRequest{}, nil
+// PullRequests fetch all the opened merge requests for a repository
+func (c *gitlabClient) PullRequests(ctx context.Context, repo string) ([]sdk.VCSPullRequest, error) {
+	mrs, err := c.openedMergeRequests(repo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]sdk.VCSPullRequest, len(mrs))
+	for i := range mrs {
+		prs[i] = toVCSPullRequest(repo, mrs[i])
+	}
+	return prs, nil
 }
 
-// PullRequestComment push a new comment on a pull request
-func (c *gitlabClient) PullRequestComment(context.Context, string, int, string) error {
+// PullRequestComment push a new comment on a merge request
+func (c *gitlabClient) PullRequestComment(ctx context.Context, repo string, id int, body string) error {
+	if _, _, err := c.client.Notes.CreateMergeRequestNote(repo, id, &gitlab.CreateMergeRequestNoteOptions{Body: &body}); err != nil {
+		return sdk.WrapError(err, "unable to comment merge request %d on %s", id, repo)
+	}
 	return nil
 }
 
@@ -25,3 +39,49 @@ func (c *gitlabClient) PullRequestComment(context.Context, string, int, string)
 func (c *gitlabClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	return sdk.VCSPullRequest{}, fmt.Errorf("not yet implemented")
 }
+
+// openedMergeRequests returns all the opened merge requests of a repository, filtered on the source branch if given.
+func (c *gitlabClient) openedMergeRequests(repo string, sourceBranch *string) ([]*gitlab.MergeRequest, error) {
+	opt := &gitlab.ListProjectMergeRequestsOptions{
+		State:        gitlab.String("opened"),
+		SourceBranch: sourceBranch,
+		ListOptions:  gitlab.ListOptions{PerPage: 100},
+	}
+
+	var mrs []*gitlab.MergeRequest
+	for {
+		page, resp, err := c.client.MergeRequests.ListProjectMergeRequests(repo, opt)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to list merge requests on %s", repo)
+		}
+		mrs = append(mrs, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return mrs, nil
+}
+
+// toVCSPullRequest converts a merge request, the ID of the pull request is the IID of the merge request in its project.
+func toVCSPullRequest(repo string, mr *gitlab.MergeRequest) sdk.VCSPullRequest {
+	pr := sdk.VCSPullRequest{
+		ID:     mr.IID,
+		URL:    mr.WebURL,
+		Title:  mr.Title,
+		Merged: mr.State == "merged",
+		Closed: mr.State == "closed",
+		Head: sdk.VCSPushEvent{
+			Repo:   repo,
+			Branch: sdk.VCSBranch{ID: mr.SourceBranch, DisplayID: mr.SourceBranch, LatestCommit: mr.SHA},
+		},
+		Base: sdk.VCSPushEvent{
+			Repo:   repo,
+			Branch: sdk.VCSBranch{ID: mr.TargetBranch, DisplayID: mr.TargetBranch},
+		},
+	}
+	if mr.Author.Username != "" {
+		pr.User = sdk.VCSAuthor{Name: mr.Author.Username, DisplayName: mr.Author.Name}
+	}
+	return pr
+}
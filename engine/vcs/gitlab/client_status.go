@@ -19,6 +19,7 @@ type statusData struct {
 	desc         string
 	repoFullName string
 	hash         string
+	nodeRun      sdk.EventRunWorkflowNode
 }
 
 func getGitlabStateFromStatus(s string) gitlab.BuildStateValue {
@@ -70,6 +71,10 @@ func (c *gitlabClient) SetStatus(ctx context.Context, event sdk.Event) error {
 	}
 
 	cds := "CDS"
+	// Each node is a job of the GitLab external pipeline, otherwise the status of the last node overrides the others
+	if c.externalPipeline {
+		cds = data.desc
+	}
 	opt := &gitlab.SetCommitStatusOptions{
 		Name:        &cds,
 		Context:     &cds,
@@ -104,6 +109,12 @@ func (c *gitlabClient) SetStatus(ctx context.Context, event sdk.Event) error {
 			return sdk.WrapError(err, "cannot process event %v - repo:%s hash:%s", event, data.repoFullName, data.hash)
 		}
 	}
+
+	if c.mergeRequestNote {
+		if err := c.setMergeRequestNote(ctx, event, data); err != nil {
+			log.Error(ctx, "gitlabClient.SetStatus> unable to set merge request note - repo:%s hash:%s: %v", data.repoFullName, data.hash, err)
+		}
+	}
 	return nil
 }
 
@@ -161,5 +172,6 @@ func processWorkflowNodeRunEvent(event sdk.Event, uiURL string) (statusData, err
 	data.repoFullName = eventNR.RepositoryFullName
 	data.status = eventNR.Status
 	data.branchName = eventNR.BranchName
+	data.nodeRun = eventNR
 	return data, nil
 }
@@ -22,6 +22,9 @@ type gitlabClient struct {
 	proxyURL            string
 	disableStatus       bool
 	disableStatusDetail bool
+	externalPipeline    bool
+	mergeRequestNote    bool
+	cache               cache.Store
 }
 
 // gitlabConsumer implements vcs.Server and it's used to instantiate a gitlabClient
@@ -35,10 +38,12 @@ type gitlabConsumer struct {
 	proxyURL                 string
 	disableStatus            bool
 	disableStatusDetail      bool
+	externalPipeline         bool
+	mergeRequestNote         bool
 }
 
 // New instantiate a new gitlab consumer
-func New(appID, clientSecret, URL, callbackURL, uiURL, proxyURL string, store cache.Store, disableStatus bool, disableStatusDetail bool) sdk.VCSServer {
+func New(appID, clientSecret, URL, callbackURL, uiURL, proxyURL string, store cache.Store, disableStatus bool, disableStatusDetail bool, externalPipeline bool, mergeRequestNote bool) sdk.VCSServer {
 	return &gitlabConsumer{
 		URL:                      URL,
 		secret:                   clientSecret,
@@ -49,6 +54,8 @@ func New(appID, clientSecret, URL, callbackURL, uiURL, proxyURL string, store ca
 		proxyURL:                 proxyURL,
 		disableStatus:            disableStatus,
 		disableStatusDetail:      disableStatusDetail,
+		externalPipeline:         externalPipeline,
+		mergeRequestNote:         mergeRequestNote,
 	}
 }
 
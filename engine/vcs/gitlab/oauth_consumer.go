@@ -130,6 +130,9 @@ func (g *gitlabConsumer) GetAuthorizedClient(ctx context.Context, accessToken, a
 			uiURL:               g.uiURL,
 			disableStatus:       g.disableStatus,
 			disableStatusDetail: g.disableStatusDetail,
+			externalPipeline:    g.externalPipeline,
+			mergeRequestNote:    g.mergeRequestNote,
+			cache:               g.cache,
 		}
 		c.client.SetBaseURL(g.URL + "/api/v4")
 		instancesAuthorizedClient[accessToken] = c
@@ -31,25 +31,21 @@ type Configuration struct {
 			URL string `toml:"url" default:"http://localhost:8080" json:"url"`
 		} `toml:"http" json:"http"`
 	} `toml:"ui" json:"ui"`
-	API   service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
-	Cache struct {
-		TTL   int `toml:"ttl" default:"60" json:"ttl"`
-		Redis struct {
-			Host     string `toml:"host" default:"localhost:6379" comment:"If your want to use a redis-sentinel based cluster, follow this syntax ! <clustername>@sentinel1:26379,sentinel2:26379sentinel3:26379" json:"host"`
-			Password string `toml:"password" json:"-"`
-		} `toml:"redis" json:"redis"`
-	} `toml:"cache" comment:"######################\n CDS VCS Cache Settings \n######################" json:"cache"`
-	Servers map[string]ServerConfiguration `toml:"servers" comment:"######################\n CDS VCS Server Settings \n######################" json:"servers"`
+	API     service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
+	Cache   cache.Configuration             `toml:"cache" comment:"######################\n CDS VCS Cache Settings \n######################" json:"cache"`
+	Servers map[string]ServerConfiguration  `toml:"servers" comment:"######################\n CDS VCS Server Settings \n######################" json:"servers"`
 }
 
 // ServerConfiguration is the configuration for a VCS server
 type ServerConfiguration struct {
-	URL            string                        `toml:"url" comment:"URL of this VCS Server" json:"url"`
-	Github         *GithubServerConfiguration    `toml:"github" json:"github,omitempty"`
-	Gitlab         *GitlabServerConfiguration    `toml:"gitlab" json:"gitlab,omitempty"`
-	Bitbucket      *BitbucketServerConfiguration `toml:"bitbucket" json:"bitbucket,omitempty"`
-	BitbucketCloud *BitbucketCloudConfiguration  `toml:"bitbucketcloud" json:"bitbucketcloud,omitempty"`
-	Gerrit         *GerritServerConfiguration    `toml:"gerrit" json:"gerrit,omitempty"`
+	URL            string                         `toml:"url" comment:"URL of this VCS Server" json:"url"`
+	Github         *GithubServerConfiguration     `toml:"github" json:"github,omitempty"`
+	Gitlab         *GitlabServerConfiguration     `toml:"gitlab" json:"gitlab,omitempty"`
+	Bitbucket      *BitbucketServerConfiguration  `toml:"bitbucket" json:"bitbucket,omitempty"`
+	BitbucketCloud *BitbucketCloudConfiguration   `toml:"bitbucketcloud" json:"bitbucketcloud,omitempty"`
+	Gerrit         *GerritServerConfiguration     `toml:"gerrit" json:"gerrit,omitempty"`
+	Gitea          *GiteaServerConfiguration      `toml:"gitea" json:"gitea,omitempty"`
+	CodeCommit     *CodeCommitServerConfiguration `toml:"codecommit" json:"codecommit,omitempty"`
 }
 
 // GithubServerConfiguration represents the github configuration
@@ -61,6 +57,11 @@ type GithubServerConfiguration struct {
 		Disable    bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push statuses on the VCS server" json:"disable"`
 		ShowDetail bool `toml:"showDetail" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push CDS URL in statuses on the VCS server" json:"show_detail"`
 	}
+	CheckRuns struct {
+		Enable     bool   `toml:"enable" default:"false" commented:"true" comment:"Set to true to report workflow node results as GitHub Check Runs with annotations instead of commit statuses. A GitHub App installed on the repositories is required" json:"enable"`
+		AppID      int64  `toml:"appId" default:"0" commented:"true" comment:"ID of the GitHub App used to create check runs" json:"-"`
+		PrivateKey string `toml:"privateKey" default:"" commented:"true" comment:"PEM encoded private key of the GitHub App" json:"-"`
+	}
 	DisableWebHooks bool   `toml:"disableWebHooks" comment:"Does webhooks are supported by VCS Server" json:"disable_web_hook"`
 	DisablePolling  bool   `toml:"disablePolling" comment:"Does polling is supported by VCS Server" json:"disable_polling"`
 	ProxyWebhook    string `toml:"proxyWebhook" default:"" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
@@ -78,6 +79,9 @@ func (s GithubServerConfiguration) check() error {
 	if s.ProxyWebhook != "" && !strings.Contains(s.ProxyWebhook, "://") {
 		return fmt.Errorf("Github proxy webhook must have the HTTP scheme")
 	}
+	if s.CheckRuns.Enable && (s.CheckRuns.AppID == 0 || s.CheckRuns.PrivateKey == "") {
+		return fmt.Errorf("Github check runs require the ID and the private key of a GitHub App")
+	}
 	return nil
 }
 
@@ -89,12 +93,14 @@ type GitlabServerConfiguration struct {
 	Secret      string `toml:"secret" json:"-" default:"xxxxx"`
 	CallbackURL string `toml:"callbackUrl" json:"callbackUrl" default:"http://localhost:8081/repositories_manager/oauth2/callback" comment:"OAuth Application Callback URL"`
 	Status      struct {
-		Disable    bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push statuses on the VCS server" json:"disable"`
-		ShowDetail bool `toml:"showDetail" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push CDS URL in statuses on the VCS server" json:"show_detail"`
+		Disable          bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push statuses on the VCS server" json:"disable"`
+		ShowDetail       bool `toml:"showDetail" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push CDS URL in statuses on the VCS server" json:"show_detail"`
+		ExternalPipeline bool `toml:"externalPipeline" default:"false" commented:"true" comment:"Set to true to report each workflow node as a job of a GitLab external pipeline instead of a single CDS status" json:"external_pipeline"`
 	}
-	DisableWebHooks bool   `toml:"disableWebHooks" comment:"Does webhooks are supported by VCS Server" json:"disable_web_hook"`
-	DisablePolling  bool   `toml:"disablePolling" comment:"Does polling is supported by VCS Server" json:"disable_polling"`
-	ProxyWebhook    string `toml:"proxyWebhook" default:"" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
+	MergeRequestNote bool   `toml:"mergeRequestNote" default:"false" commented:"true" comment:"Set to true to post and update a comment on merge requests with the status of the workflow nodes and their artifacts" json:"merge_request_note"`
+	DisableWebHooks  bool   `toml:"disableWebHooks" comment:"Does webhooks are supported by VCS Server" json:"disable_web_hook"`
+	DisablePolling   bool   `toml:"disablePolling" comment:"Does polling is supported by VCS Server" json:"disable_polling"`
+	ProxyWebhook     string `toml:"proxyWebhook" default:"" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
 }
 
 func (s GitlabServerConfiguration) check() error {
@@ -147,6 +153,50 @@ func (s BitbucketCloudConfiguration) check() error {
 	return nil
 }
 
+// GiteaServerConfiguration represents the gitea configuration, it's also compatible with Forgejo
+type GiteaServerConfiguration struct {
+	ClientID     string `toml:"clientId" json:"-" default:"xxxxx" comment:"#######\n CDS <-> Gitea. Documentation on https://ovh.github.io/cds/docs/integrations/gitea/ \n#######\n Gitea OAuth2 Application Client ID"`
+	ClientSecret string `toml:"clientSecret" json:"-" default:"xxxxx" comment:"Gitea OAuth2 Application Client Secret"`
+	CallbackURL  string `toml:"callbackUrl" json:"callbackUrl" default:"http://localhost:8081/repositories_manager/oauth2/callback" comment:"OAuth2 Application Redirect URI"`
+	Status       struct {
+		Disable    bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push statuses on the VCS server" json:"disable"`
+		ShowDetail bool `toml:"showDetail" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push CDS URL in statuses on the VCS server" json:"show_detail"`
+	}
+	DisableWebHooks bool   `toml:"disableWebHooks" comment:"Does webhooks are supported by VCS Server" json:"disable_web_hook"`
+	ProxyWebhook    string `toml:"proxyWebhook" default:"" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
+}
+
+func (s GiteaServerConfiguration) check() error {
+	if s.ClientID == "" || s.ClientSecret == "" {
+		return fmt.Errorf("Gitea configuration Error")
+	}
+	if s.ProxyWebhook != "" && !strings.Contains(s.ProxyWebhook, "://") {
+		return fmt.Errorf("Gitea proxy webhook must have the HTTP scheme")
+	}
+	return nil
+}
+
+// CodeCommitServerConfiguration represents the AWS CodeCommit configuration
+type CodeCommitServerConfiguration struct {
+	Region      string `toml:"region" json:"region" default:"eu-west-1" comment:"#######\n CDS <-> AWS CodeCommit. Documentation on https://ovh.github.io/cds/docs/integrations/codecommit/ \n#######\n AWS region of the repositories"`
+	Endpoint    string `toml:"endpoint" json:"-" default:"" commented:"true" comment:"Custom endpoint of the CodeCommit API, for example a VPC endpoint"`
+	SNSTopicARN string `toml:"snsTopicArn" json:"sns_topic_arn" default:"" comment:"ARN of the SNS topic that receives the repository triggers, needed for webhooks"`
+	Status      struct {
+		Disable bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push statuses as repository tags" json:"disable"`
+	}
+	DisableWebHooks bool `toml:"disableWebHooks" comment:"Does webhooks are supported by VCS Server" json:"disable_web_hook"`
+}
+
+func (s CodeCommitServerConfiguration) check() error {
+	if s.Region == "" {
+		return fmt.Errorf("CodeCommit configuration Error: region is mandatory")
+	}
+	if s.SNSTopicARN != "" && !strings.HasPrefix(s.SNSTopicARN, "arn:") {
+		return fmt.Errorf("CodeCommit configuration Error: invalid SNS topic ARN")
+	}
+	return nil
+}
+
 func (s *Service) addServerConfiguration(name string, c ServerConfiguration) error {
 	if name == "" {
 		return fmt.Errorf("Invalid VCS server name")
@@ -195,6 +245,18 @@ func (s ServerConfiguration) check() error {
 		}
 	}
 
+	if s.Gitea != nil {
+		if err := s.Gitea.check(); err != nil {
+			return err
+		}
+	}
+
+	if s.CodeCommit != nil {
+		if err := s.CodeCommit.check(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -13,7 +13,9 @@ import (
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/vcs/bitbucketcloud"
 	"github.com/ovh/cds/engine/vcs/bitbucketserver"
+	"github.com/ovh/cds/engine/vcs/codecommit"
 	"github.com/ovh/cds/engine/vcs/gerrit"
+	"github.com/ovh/cds/engine/vcs/gitea"
 	"github.com/ovh/cds/engine/vcs/github"
 	"github.com/ovh/cds/engine/vcs/gitlab"
 	"github.com/ovh/cds/sdk"
@@ -88,6 +90,13 @@ func (s *Service) getConsumer(name string) (sdk.VCSServer, error) {
 	}
 
 	if serverCfg.Github != nil {
+		var checkRunsApp *github.App
+		if serverCfg.Github.CheckRuns.Enable {
+			checkRunsApp = &github.App{
+				ID:         serverCfg.Github.CheckRuns.AppID,
+				PrivateKey: serverCfg.Github.CheckRuns.PrivateKey,
+			}
+		}
 		return github.New(
 			serverCfg.Github.ClientID,
 			serverCfg.Github.ClientSecret,
@@ -101,6 +110,7 @@ func (s *Service) getConsumer(name string) (sdk.VCSServer, error) {
 			s.Cache,
 			serverCfg.Github.Status.Disable,
 			!serverCfg.Github.Status.ShowDetail,
+			checkRunsApp,
 		), nil
 	}
 	if serverCfg.Bitbucket != nil {
@@ -137,6 +147,28 @@ func (s *Service) getConsumer(name string) (sdk.VCSServer, error) {
 			s.Cache,
 			serverCfg.Gitlab.Status.Disable,
 			serverCfg.Gitlab.Status.ShowDetail,
+			serverCfg.Gitlab.Status.ExternalPipeline,
+			serverCfg.Gitlab.MergeRequestNote,
+		), nil
+	}
+	if serverCfg.Gitea != nil {
+		return gitea.New(serverCfg.Gitea.ClientID,
+			serverCfg.Gitea.ClientSecret,
+			serverCfg.URL,
+			serverCfg.Gitea.CallbackURL,
+			s.Cfg.UI.HTTP.URL,
+			serverCfg.Gitea.ProxyWebhook,
+			s.Cache,
+			serverCfg.Gitea.Status.Disable,
+			!serverCfg.Gitea.Status.ShowDetail,
+		), nil
+	}
+	if serverCfg.CodeCommit != nil {
+		return codecommit.New(serverCfg.CodeCommit.Region,
+			serverCfg.CodeCommit.Endpoint,
+			serverCfg.CodeCommit.SNSTopicARN,
+			s.Cache,
+			serverCfg.CodeCommit.Status.Disable,
 		), nil
 	}
 	if serverCfg.Gerrit != nil {
@@ -159,9 +191,9 @@ func (s *Service) Serve(c context.Context) error {
 
 	//Init the cache
 	var errCache error
-	s.Cache, errCache = cache.New(s.Cfg.Cache.Redis.Host, s.Cfg.Cache.Redis.Password, s.Cfg.Cache.TTL)
+	s.Cache, errCache = cache.NewFromConfiguration(c, s.Cfg.Cache)
 	if errCache != nil {
-		return fmt.Errorf("Cannot connect to redis instance : %v", errCache)
+		return fmt.Errorf("Cannot connect to cache store : %v", errCache)
 	}
 
 	//Init the http server
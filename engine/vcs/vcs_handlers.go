@@ -38,6 +38,10 @@ func (s *Service) getAllVCSServersHandler() service.Handler {
 				vcsType = "github"
 			} else if v.Gitlab != nil {
 				vcsType = "gitlab"
+			} else if v.Gitea != nil {
+				vcsType = "gitea"
+			} else if v.CodeCommit != nil {
+				vcsType = "codecommit"
 			}
 
 			servers[k] = sdk.VCSConfiguration{
@@ -70,6 +74,10 @@ func (s *Service) getVCSServersHandler() service.Handler {
 			s.Type = "github"
 		} else if cfg.Gitlab != nil {
 			s.Type = "gitlab"
+		} else if cfg.Gitea != nil {
+			s.Type = "gitea"
+		} else if cfg.CodeCommit != nil {
+			s.Type = "codecommit"
 		}
 		return service.WriteJSON(w, s, http.StatusOK)
 	}
@@ -207,6 +215,37 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 				"Pipeline Hook",
 				"Job Hook",
 			}
+		case cfg.Gitea != nil:
+			res.WebhooksSupported = true
+			res.WebhooksDisabled = cfg.Gitea.DisableWebHooks
+			res.WebhooksIcon = sdk.GiteaIcon
+			// https://docs.gitea.io/en-us/webhooks/
+			res.Events = []string{
+				"push",
+				"create",
+				"delete",
+				"fork",
+				"issues",
+				"issue_comment",
+				"pull_request",
+				"pull_request_review_approved",
+				"pull_request_review_rejected",
+				"pull_request_review_comment",
+				"pull_request_sync",
+				"repository",
+				"release",
+			}
+		case cfg.CodeCommit != nil:
+			res.WebhooksSupported = true
+			res.WebhooksDisabled = cfg.CodeCommit.DisableWebHooks || cfg.CodeCommit.SNSTopicARN == ""
+			res.WebhooksIcon = sdk.CodeCommitIcon
+			// https://docs.aws.amazon.com/codecommit/latest/userguide/how-to-notify.html
+			res.Events = []string{
+				"all",
+				"updateReference",
+				"createReference",
+				"deleteReference",
+			}
 		case cfg.Gerrit != nil:
 			res.WebhooksSupported = false
 			res.GerritHookDisabled = cfg.Gerrit.DisableGerritEvent
@@ -262,6 +301,10 @@ func (s *Service) getVCSServersPollingHandler() service.Handler {
 		case cfg.Gitlab != nil:
 			res.PollingSupported = false
 			res.PollingDisabled = cfg.Gitlab.DisablePolling
+		case cfg.Gitea != nil:
+			res.PollingSupported = false
+		case cfg.CodeCommit != nil:
+			res.PollingSupported = false
 		}
 
 		return service.WriteJSON(w, res, http.StatusOK)
@@ -34,6 +34,8 @@ const (
 	flagName                = "name"
 	flagModel               = "model"
 	flagHatcheryName        = "hatchery-name"
+	flagToolRegistry        = "tool-registry"
+	flagToolCacheDir        = "tool-cache-dir"
 )
 
 func initFlagsRun(cmd *cobra.Command) {
@@ -53,6 +55,8 @@ func initFlagsRun(cmd *cobra.Command) {
 	flags.String(flagName, "", "Name of worker")
 	flags.String(flagModel, "", "Model of worker")
 	flags.String(flagHatcheryName, "", "Hatchery Name spawing worker")
+	flags.String(flagToolRegistry, "", "URL of the registry from which the tools required with a version (binary: name@version) are downloaded")
+	flags.String(flagToolCacheDir, "", "This directory (default {basedir}/tools) will contains the tools downloaded from the tool registry")
 }
 
 // FlagBool replaces viper.GetBool
@@ -170,4 +174,10 @@ func initFromFlags(cmd *cobra.Command, w *internal.CurrentWorker) {
 		log.Error(context.TODO(), "Cannot init worker: %v", err)
 		os.Exit(1)
 	}
+
+	toolCacheDir := FlagString(cmd, flagToolCacheDir)
+	if toolCacheDir == "" {
+		toolCacheDir = filepath.Join(basedir, "tools")
+	}
+	w.SetToolRegistry(FlagString(cmd, flagToolRegistry), toolCacheDir)
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+func cmdCodeComments() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "code-comments",
+		Short: "worker code-comments comments.json",
+		Long: `
+
+Inside a step script (https://ovh.github.io/cds/docs/actions/builtin-script/), you can send comments on lines of the files of your repository,
+for example from the result of a static analysis. On Gerrit, the comments are posted on the change that triggered the workflow at the end of the pipeline.
+
+The file must contain a JSON array of comments, the path is relative to the root of the repository and the severity is one of info, warning or error:
+
+` + "```bash" + `
+#!/bin/bash
+
+cat << EOF > comments.json
+[{"path": "main.go", "line": 12, "message": "error return value not checked", "severity": "warning"}]
+EOF
+
+worker code-comments comments.json
+` + "```" + `
+
+		`,
+		Run: codeCommentsCmd(),
+	}
+	return c
+}
+
+func codeCommentsCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if len(args) != 1 {
+			sdk.Exit("Wrong usage: Example: worker code-comments comments.json")
+		}
+
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			sdk.Exit("cannot read file %s: %v\n", args[0], err)
+		}
+
+		var comments []sdk.WorkflowNodeRunCodeComment
+		if err := json.Unmarshal(data, &comments); err != nil {
+			sdk.Exit("invalid file %s: %v\n", args[0], err)
+		}
+		if len(comments) == 0 {
+			return
+		}
+
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/code-comments", port), bytes.NewReader(data))
+		if err != nil {
+			sdk.Exit("cannot post worker code-comments (Request): %s\n", err)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Minute
+
+		resp, err := client.Do(req)
+		if err != nil {
+			sdk.Exit("cannot post worker code-comments (Do): %s\n", err)
+		}
+
+		if resp.StatusCode >= 300 {
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				sdk.Exit("cannot read response body %v\n", err)
+			}
+			cdsError := sdk.DecodeError(body)
+			if cdsError != nil {
+				sdk.Exit("%v\n", cdsError)
+			}
+			sdk.Exit("%v\n", string(body))
+		}
+	}
+}
@@ -21,12 +21,13 @@ var (
 	cmdDownloadNumber       string
 	cmdDownloadArtefactName string
 	cmdDownloadTag          string
+	cmdDownloadPromoted     bool
 )
 
 func cmdDownload() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "download",
-		Short: "worker download [--workflow=<workflow-name>] [--number=<run-number>] [--tag=<tag>] [--pattern=<pattern>]",
+		Short: "worker download [--workflow=<workflow-name>] [--number=<run-number>] [--tag=<tag>] [--pattern=<pattern>] [--promoted]",
 		Long: `
 Inside a job, there are two ways to download an artifact:
 
@@ -46,6 +47,10 @@ Theses two commands have the same result:
 	worker download
 	worker download --workflow={{.cds.workflow}} --number={{.cds.run.number}}
 
+The artifacts promoted to the current workflow by another workflow (see worker promote) are downloaded with:
+
+	worker download --promoted --tag=<tag>
+
 		`,
 		Run: downloadCmd(),
 	}
@@ -53,6 +58,7 @@ Theses two commands have the same result:
 	c.Flags().StringVar(&cmdDownloadNumber, "number", "", "Workflow Number to download from. Optional, default: current workflow run")
 	c.Flags().StringVar(&cmdDownloadArtefactName, "pattern", "", "Pattern matching files to download. Optional, default: *")
 	c.Flags().StringVar(&cmdDownloadTag, "tag", "", "Tag matching files to download. Optional")
+	c.Flags().BoolVar(&cmdDownloadPromoted, "promoted", false, "Download the artifacts promoted to the workflow instead of the artifacts of a run. Optional")
 	return c
 }
 
@@ -84,6 +90,7 @@ func downloadCmd() func(cmd *cobra.Command, args []string) {
 			Pattern:     cmdDownloadArtefactName,
 			Tag:         cmdDownloadTag,
 			Destination: wd,
+			Promoted:    cmdDownloadPromoted,
 		}
 
 		data, errMarshal := json.Marshal(a)
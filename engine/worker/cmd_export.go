@@ -14,10 +14,13 @@ import (
 	"github.com/ovh/cds/sdk"
 )
 
-var cmdExport = &cobra.Command{
-	Use:   "export",
-	Short: "worker export <varname> <value>",
-	Long: `
+var cmdExportOutput bool
+
+func cmdExport() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "export",
+		Short: "worker export <varname> <value>",
+		Long: `
 Inside a step script (https://ovh.github.io/cds/docs/actions/builtin-script/), you can create a build variable with the worker command:
 
 	worker export foo bar
@@ -36,8 +39,23 @@ You can use the build variable in :
 * the next stages in same pipeline ` + "`{{.cds.build.varname}}`" + `
 * the next pipelines ` + "`{{.workflow.pipelineName.build.varname}}`" + ` with ` + "`pipelineName`" + ` the name of the pipeline in your workflow
 
+## Outputs
+
+A job can export an output of its pipeline with the flag --output:
+
+	worker export --output image_tag v1.2.0
+
+The output can be used in :
+
+* another step of the current job with ` + "`{{.cds.output.image_tag}}`" + `
+* the next stages in same pipeline ` + "`{{.cds.output.image_tag}}`" + `
+* the downstream pipelines ` + "`{{.outputs.nodeName.image_tag}}`" + ` with ` + "`nodeName`" + ` the name of the pipeline in your workflow
+
 	`,
-	Run: exportCmd,
+		Run: exportCmd,
+	}
+	c.Flags().BoolVar(&cmdExportOutput, "output", false, "Export an output available to the downstream pipelines. Optional")
+	return c
 }
 
 func exportCmd(cmd *cobra.Command, args []string) {
@@ -66,7 +84,7 @@ func exportCmd(cmd *cobra.Command, args []string) {
 		sdk.Exit("internal error (%s)\n", err)
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/var", port), bytes.NewReader(data))
+	req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/var?output=%t", port, cmdExportOutput), bytes.NewReader(data))
 	if err != nil {
 		sdk.Exit("cannot add variable: %s\n", err)
 	}
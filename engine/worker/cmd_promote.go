@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	cmdPromoteProject     string
+	cmdPromoteWorkflow    string
+	cmdPromotePattern     string
+	cmdPromoteTag         string
+	cmdPromotePromotedTag string
+	cmdPromoteMode        string
+)
+
+func cmdPromote() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "promote",
+		Short: "worker promote --project=<project-key> --workflow=<workflow-name> [--pattern=<pattern>] [--tag=<tag>] [--promoted-tag=<tag>] [--mode=copy|reference]",
+		Long: `
+Inside a job, you can promote the artifacts of the current workflow run to another workflow, for example to hand off
+the binaries of a build workflow to a deployment workflow without uploading them again:
+
+	worker promote --project=DEPLOY --workflow=deploy-app --pattern="app-.*\.tar\.gz" --promoted-tag={{.cds.version}}
+
+With the mode copy (default), the artifacts are copied in the storage, with the mode reference the promoted artifacts
+reference the artifacts of the current run, which are then kept when the run is purged.
+
+The promoted artifacts are downloaded in the target workflow with the worker command:
+
+	worker download --promoted --tag=<tag>
+
+		`,
+		Run: promoteCmd(),
+	}
+	c.Flags().StringVar(&cmdPromoteProject, "project", "", "Key of the project of the target workflow. Optional, default: current project")
+	c.Flags().StringVar(&cmdPromoteWorkflow, "workflow", "", "Name of the target workflow")
+	c.Flags().StringVar(&cmdPromotePattern, "pattern", "", "Pattern matching the artifacts to promote. Optional, default: all the artifacts")
+	c.Flags().StringVar(&cmdPromoteTag, "tag", "", "Tag of the artifacts to promote. Optional")
+	c.Flags().StringVar(&cmdPromotePromotedTag, "promoted-tag", "", "Tag of the promoted artifacts. Optional, default: the tag of the artifacts")
+	c.Flags().StringVar(&cmdPromoteMode, "mode", sdk.ArtifactPromotionModeCopy, "Promotion mode: copy or reference")
+	return c
+}
+
+func promoteCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if cmdPromoteWorkflow == "" {
+			sdk.Exit("Wrong usage: Example: worker promote --project=DEPLOY --workflow=deploy-app")
+		}
+
+		data, err := json.Marshal(sdk.WorkflowArtifactPromotionRequest{
+			ProjectKey:   cmdPromoteProject,
+			WorkflowName: cmdPromoteWorkflow,
+			Pattern:      cmdPromotePattern,
+			Tag:          cmdPromoteTag,
+			PromotedTag:  cmdPromotePromotedTag,
+			Mode:         cmdPromoteMode,
+		})
+		if err != nil {
+			sdk.Exit("internal error (%s)\n", err)
+		}
+
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/promote", port), bytes.NewReader(data))
+		if err != nil {
+			sdk.Exit("cannot post worker promote (Request): %s\n", err)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 30 * time.Minute
+
+		resp, err := client.Do(req)
+		if err != nil {
+			sdk.Exit("cannot post worker promote (Do): %s\n", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				sdk.Exit("cannot read response body %v\n", err)
+			}
+			cdsError := sdk.DecodeError(body)
+			if cdsError != nil {
+				sdk.Exit("promote failed: %v\n", cdsError)
+			}
+			sdk.Exit("promote failed: %v\n", string(body))
+		}
+	}
+}
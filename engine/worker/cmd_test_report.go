@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	cmdTestReportJUnit          []string
+	cmdTestReportCoverage       string
+	cmdTestReportCoverageFormat string
+)
+
+func cmdTestReport() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "test-report",
+		Short: "worker test-report [--junit=<pattern>] [--coverage=<file> --coverage-format=<lcov|cobertura|clover>]",
+		Long: `
+
+Inside a step script (https://ovh.github.io/cds/docs/actions/builtin-script/), you can send the JUnit results and the coverage report
+of your tests in one command. The results are displayed on the workflow run, and a summary with the coverage delta against the
+default branch can be posted on the pull request if enabled in the VCS notification of the workflow.
+
+	worker test-report --junit="results/*.xml" --coverage=coverage.xml --coverage-format=cobertura
+
+		`,
+		Run: testReportCmd(),
+	}
+	c.Flags().StringSliceVar(&cmdTestReportJUnit, "junit", nil, "Pattern matching JUnit files. Optional, can be repeated")
+	c.Flags().StringVar(&cmdTestReportCoverage, "coverage", "", "Coverage report file. Optional")
+	c.Flags().StringVar(&cmdTestReportCoverageFormat, "coverage-format", "", "Coverage report format: lcov, cobertura or clover")
+	return c
+}
+
+func testReportCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if len(cmdTestReportJUnit) == 0 && cmdTestReportCoverage == "" {
+			sdk.Exit("Wrong usage: Example: worker test-report --junit=\"results/*.xml\" --coverage=coverage.xml --coverage-format=cobertura")
+		}
+		if cmdTestReportCoverage != "" && cmdTestReportCoverageFormat == "" {
+			sdk.Exit("Wrong usage: --coverage-format is mandatory with --coverage")
+		}
+
+		currentDir, err := os.Getwd()
+		if err != nil {
+			sdk.Exit("Internal error during Getwd command")
+		}
+
+		report := workerruntime.TestReport{
+			CoverageFormat: cmdTestReportCoverageFormat,
+		}
+		for _, p := range cmdTestReportJUnit {
+			report.JUnit = append(report.JUnit, getAbsoluteDir(p, currentDir))
+		}
+		if cmdTestReportCoverage != "" {
+			report.Coverage = getAbsoluteDir(cmdTestReportCoverage, currentDir)
+		}
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			sdk.Exit("internal error (%s)\n", err)
+		}
+
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/test-report", port), bytes.NewReader(data))
+		if err != nil {
+			sdk.Exit("cannot post worker test-report (Request): %s\n", err)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Minute
+
+		resp, err := client.Do(req)
+		if err != nil {
+			sdk.Exit("cannot post worker test-report (Do): %s\n", err)
+		}
+
+		if resp.StatusCode >= 300 {
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				sdk.Exit("cannot read response body %v\n", err)
+			}
+			cdsError := sdk.DecodeError(body)
+			if cdsError != nil {
+				sdk.Exit("%v\n", cdsError)
+			}
+			sdk.Exit("%v\n", string(body))
+		}
+	}
+}
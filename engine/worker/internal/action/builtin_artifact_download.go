@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -95,7 +94,7 @@ func RunArtifactDownload(ctx context.Context, wk workerruntime.Runtime, a sdk.Ac
 		go func(a *sdk.WorkflowNodeRunArtifact) {
 			defer wg.Done()
 
-			destFile := path.Join(destPath, a.Name)
+			destFile := filepath.Join(destPath, a.Name)
 			f, err := wkDirFS.OpenFile(destFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(a.Perm))
 			if err != nil {
 				res.Status = sdk.StatusFail
@@ -109,5 +109,18 @@ func RunArtifactUpload(ctx context.Context, wk workerruntime.Runtime, a sdk.Acti
 		return res, fmt.Errorf("error: %v", globalError.Error())
 	}
 
+	// The provenance of the uploaded artifacts is attested by the API, signed with a PGP key of the project
+	if keyName := strings.TrimSpace(sdk.ParameterValue(a.Parameters, "signKey")); keyName != "" {
+		req := sdk.WorkflowArtifactAttestationRequest{KeyName: keyName, Tag: tag.Value}
+		for _, p := range filesPath {
+			req.Names = append(req.Names, filepath.Base(p))
+		}
+		attestations, err := wk.Client().QueueArtifactAttestation(ctx, jobID, req)
+		if err != nil {
+			return res, fmt.Errorf("cannot sign artifacts with key %s: %v", keyName, err)
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%d artifacts signed with key %s", len(attestations), keyName))
+	}
+
 	return res, nil
 }
@@ -38,18 +38,6 @@ func RunParseCoverageResultAction(ctx context.Context, wk workerruntime.Runtime,
 		minReq = f
 	}
 
-	var parserMode coverage.CoverageMode
-	switch mode {
-	case string(coverage.COBERTURA):
-		parserMode = coverage.COBERTURA
-	case string(coverage.LCOV):
-		parserMode = coverage.LCOV
-	case string(coverage.CLOVER):
-		parserMode = coverage.CLOVER
-	default:
-		return res, fmt.Errorf("coverage parser: unknown format %s", mode)
-	}
-
 	workdir, err := workerruntime.WorkingDirectory(ctx)
 	if err != nil {
 		return res, err
@@ -69,10 +57,9 @@ func RunParseCoverageResultAction(ctx context.Context, wk workerruntime.Runtime,
 		fpath = p
 	}
 
-	parser := coverage.New(fpath, parserMode)
-	report, errR := parser.Parse()
-	if errR != nil {
-		return res, fmt.Errorf("coverage parser: unable to parse report: %v", errR)
+	report, err := ParseCoverageReport(fpath, mode)
+	if err != nil {
+		return res, err
 	}
 
 	jobID, err := workerruntime.JobID(ctx)
@@ -94,3 +81,25 @@ func RunParseCoverageResultAction(ctx context.Context, wk workerruntime.Runtime,
 	res.Status = sdk.StatusSuccess
 	return res, nil
 }
+
+// ParseCoverageReport parses a coverage report file in lcov, cobertura or clover format.
+func ParseCoverageReport(path, format string) (coverage.Report, error) {
+	var parserMode coverage.CoverageMode
+	switch format {
+	case string(coverage.COBERTURA):
+		parserMode = coverage.COBERTURA
+	case string(coverage.LCOV):
+		parserMode = coverage.LCOV
+	case string(coverage.CLOVER):
+		parserMode = coverage.CLOVER
+	default:
+		return coverage.Report{}, fmt.Errorf("coverage parser: unknown format %s", format)
+	}
+
+	parser := coverage.New(path, parserMode)
+	report, err := parser.Parse()
+	if err != nil {
+		return report, fmt.Errorf("coverage parser: unable to parse report: %v", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,46 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+// RunCoverageCheck fails if the coverage of the run dropped more than maxDelta compared to the default branch
+func RunCoverageCheck(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+
+	var maxDelta float64
+	if v := sdk.ParameterValue(a.Parameters, "maxDelta"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			return res, fmt.Errorf("coverage check: wrong value for 'maxDelta': %s", v)
+		}
+		maxDelta = f
+	}
+
+	jobID, err := workerruntime.JobID(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	cov, err := wk.Client().QueueJobCoverage(ctx, jobID)
+	if err != nil {
+		return res, fmt.Errorf("coverage check: unable to get the coverage of the run: %v", err)
+	}
+
+	msg, err := sdk.CheckCoverageDelta(*cov, maxDelta)
+	if msg != "" {
+		wk.SendLog(ctx, workerruntime.LevelInfo, msg)
+	}
+	if err != nil {
+		return res, fmt.Errorf("coverage check: %v", err)
+	}
+
+	res.Status = sdk.StatusSuccess
+	return res, nil
+}
@@ -84,31 +84,39 @@ func RunDeployApplication(ctx context.Context, wk workerruntime.Runtime, a sdk.A
 
 	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Plugin %s v%s is ready", manifest.Name, manifest.Version))
 
-	query := integrationplugin.DeployQuery{
-		Options: sdk.ParametersToMap(wk.Parameters()),
+	deploy := func(ctx context.Context, options map[string]string) (string, string, error) {
+		res, err := integrationPluginClient.Deploy(ctx, &integrationplugin.DeployQuery{Options: options})
+		if err != nil {
+			return "", "", err
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Details: %s", res.Details))
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Status: %s", res.Status))
+		return res.Status, res.Details, nil
 	}
+	defer integrationPluginClientStop(ctx, integrationPluginClient, done, stopLogs)
 
-	res, err := integrationPluginClient.Deploy(ctx, &query)
+	rollout, err := sdk.RolloutFromParameters(wk.Parameters())
 	if err != nil {
-		integrationPluginClientStop(ctx, integrationPluginClient, done, stopLogs)
-		return sdk.Result{}, fmt.Errorf("Error deploying application: %v", err)
+		return sdk.Result{}, fmt.Errorf("invalid rollout: %v", err)
+	}
+	if rollout != nil {
+		return runRollout(ctx, wk, *rollout, sdk.ParametersToMap(wk.Parameters()), deploy)
 	}
 
-	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Details: %s", res.Details))
-	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Status: %s", res.Status))
+	status, details, err := deploy(ctx, sdk.ParametersToMap(wk.Parameters()))
+	if err != nil {
+		return sdk.Result{}, fmt.Errorf("Error deploying application: %v", err)
+	}
 
-	if strings.ToUpper(res.Status) == strings.ToUpper(sdk.StatusSuccess) {
-		integrationPluginClientStop(ctx, integrationPluginClient, done, stopLogs)
+	if strings.ToUpper(status) == strings.ToUpper(sdk.StatusSuccess) {
 		return sdk.Result{
 			Status: sdk.StatusSuccess,
 		}, nil
 	}
 
-	integrationPluginClientStop(ctx, integrationPluginClient, done, stopLogs)
-
 	return sdk.Result{
 		Status: sdk.StatusFail,
-		Reason: res.Details,
+		Reason: details,
 	}, nil
 }
 
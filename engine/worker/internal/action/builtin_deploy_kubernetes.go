@@ -0,0 +1,218 @@
+package action
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultDeployKubernetesTimeout = 180
+
+type deployKubernetesOptions struct {
+	namespace   string
+	manifests   []string
+	kustomize   string
+	helmChart   string
+	helmValues  []string
+	releaseName string
+	timeout     int
+}
+
+// RunDeployKubernetes applies manifests, a kustomization or a helm chart on a kubernetes cluster, then waits for the rollout of the workloads
+func RunDeployKubernetes(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+
+	integrationName := sdk.ParameterValue(a.Parameters, "integration")
+	if integrationName == "" {
+		return res, fmt.Errorf("deploy kubernetes: integration is not set")
+	}
+
+	opts := deployKubernetesOptions{
+		namespace:   sdk.ParameterValue(a.Parameters, "namespace"),
+		kustomize:   sdk.ParameterValue(a.Parameters, "kustomize"),
+		helmChart:   sdk.ParameterValue(a.Parameters, "helmChart"),
+		helmValues:  splitList(sdk.ParameterValue(a.Parameters, "helmValues")),
+		releaseName: sdk.ParameterValue(a.Parameters, "releaseName"),
+		timeout:     defaultDeployKubernetesTimeout,
+	}
+	if opts.namespace == "" {
+		opts.namespace = "default"
+	}
+	if t := sdk.ParameterValue(a.Parameters, "timeout"); t != "" {
+		timeout, err := strconv.Atoi(t)
+		if err != nil {
+			return res, fmt.Errorf("deploy kubernetes: invalid timeout %q: %v", t, err)
+		}
+		opts.timeout = timeout
+	}
+	if opts.helmChart != "" && opts.releaseName == "" {
+		opts.releaseName = sdk.ParameterValue(wk.Parameters(), "cds.application")
+		if opts.releaseName == "" {
+			return res, fmt.Errorf("deploy kubernetes: release name is not set")
+		}
+	}
+
+	workdir, err := workerruntime.WorkingDirectory(ctx)
+	if err != nil {
+		return res, err
+	}
+	var dir string
+	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		dir, _ = x.RealPath(workdir.Name())
+	} else {
+		dir = workdir.Name()
+	}
+
+	if manifests := sdk.ParameterValue(a.Parameters, "manifests"); manifests != "" {
+		for _, pattern := range splitList(manifests) {
+			if !sdk.PathIsAbs(pattern) {
+				pattern = filepath.Join(dir, pattern)
+			}
+			files, err := filepath.Glob(pattern)
+			if err != nil {
+				return res, fmt.Errorf("deploy kubernetes: invalid manifests pattern %q: %v", pattern, err)
+			}
+			opts.manifests = append(opts.manifests, files...)
+		}
+		if len(opts.manifests) == 0 {
+			return res, fmt.Errorf("deploy kubernetes: no manifest found for %q", manifests)
+		}
+	}
+	if len(opts.manifests) == 0 && opts.kustomize == "" && opts.helmChart == "" {
+		return res, fmt.Errorf("deploy kubernetes: manifests, kustomize or helmChart must be set")
+	}
+
+	kubeconfig, err := kubernetesConfig(secrets, integrationName)
+	if err != nil {
+		return res, err
+	}
+	tmpDir, err := ioutil.TempDir("", "cds-deploy-kubernetes")
+	if err != nil {
+		return res, fmt.Errorf("deploy kubernetes: cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir) // nolint
+	kubeconfigPath := filepath.Join(tmpDir, "kubeconfig")
+	if err := ioutil.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		return res, fmt.Errorf("deploy kubernetes: cannot write kubeconfig: %v", err)
+	}
+	env := append(wk.Environ(), "KUBECONFIG="+kubeconfigPath)
+
+	var resources []string
+	if opts.helmChart != "" {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Deploying helm chart %s as release %s in namespace %s", opts.helmChart, opts.releaseName, opts.namespace))
+		if _, err := runCommand(ctx, wk, env, dir, nil, "helm", helmUpgradeArgs(opts)...); err != nil {
+			kubernetesEvents(ctx, wk, env, dir, opts.namespace)
+			return res, fmt.Errorf("deploy kubernetes: helm upgrade failure: %v", err)
+		}
+	} else {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Applying manifests in namespace %s", opts.namespace))
+		resources, err = runCommand(ctx, wk, env, dir, nil, "kubectl", kubectlApplyArgs(opts)...)
+		if err != nil {
+			kubernetesEvents(ctx, wk, env, dir, opts.namespace)
+			return res, fmt.Errorf("deploy kubernetes: kubectl apply failure: %v", err)
+		}
+	}
+
+	// helm waits for the rollout of the release
+	for _, r := range rolloutResources(resources) {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Waiting for the rollout of %s", r))
+		if _, err := runCommand(ctx, wk, env, dir, nil, "kubectl", "rollout", "status", r,
+			"--namespace", opts.namespace, "--timeout", fmt.Sprintf("%ds", opts.timeout)); err != nil {
+			kubernetesEvents(ctx, wk, env, dir, opts.namespace)
+			return res, fmt.Errorf("deploy kubernetes: rollout of %s failure: %v", r, err)
+		}
+	}
+
+	return sdk.Result{Status: sdk.StatusSuccess}, nil
+}
+
+// kubernetesConfig returns the kubeconfig of a kubernetes integration: the kubeconfig of the integration
+// if it is set, or a kubeconfig built from the api url, the certificate and the token
+func kubernetesConfig(secrets []sdk.Variable, integrationName string) (string, error) {
+	prefix := sdk.KubernetesVariablePrefix(integrationName)
+	if kubeconfig := sdk.VariableFind(secrets, prefix+"kubeconfig"); kubeconfig != nil && kubeconfig.Value != "" {
+		return kubeconfig.Value, nil
+	}
+
+	apiURL := sdk.VariableFind(secrets, prefix+"api_url")
+	token := sdk.VariableFind(secrets, prefix+"token")
+	if apiURL == nil || token == nil {
+		return "", fmt.Errorf("deploy kubernetes: kubernetes integration %s not found", integrationName)
+	}
+	cluster := fmt.Sprintf("    server: %s\n", apiURL.Value)
+	if ca := sdk.VariableFind(secrets, prefix+"ca_certificate"); ca != nil && ca.Value != "" {
+		cluster += fmt.Sprintf("    certificate-authority-data: %s\n", base64.StdEncoding.EncodeToString([]byte(ca.Value)))
+	}
+
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: cds
+  cluster:
+%scontexts:
+- name: cds
+  context:
+    cluster: cds
+    user: cds
+current-context: cds
+users:
+- name: cds
+  user:
+    token: %s
+`, cluster, token.Value), nil
+}
+
+// kubectlApplyArgs returns the arguments of the kubectl apply command, the names of the applied resources are printed
+func kubectlApplyArgs(opts deployKubernetesOptions) []string {
+	args := []string{"apply", "--namespace", opts.namespace, "--output", "name"}
+	if opts.kustomize != "" {
+		return append(args, "--kustomize", opts.kustomize)
+	}
+	for _, m := range opts.manifests {
+		args = append(args, "--filename", m)
+	}
+	return args
+}
+
+// helmUpgradeArgs returns the arguments of the helm upgrade command, which installs the release if needed and waits for its rollout
+func helmUpgradeArgs(opts deployKubernetesOptions) []string {
+	args := []string{"upgrade", opts.releaseName, opts.helmChart, "--install", "--wait",
+		"--namespace", opts.namespace, "--timeout", fmt.Sprintf("%ds", opts.timeout)}
+	for _, v := range opts.helmValues {
+		args = append(args, "--values", v)
+	}
+	return args
+}
+
+// rolloutResources returns the applied resources with a rollout status
+func rolloutResources(resources []string) []string {
+	var res []string
+	for _, r := range resources {
+		kind := strings.SplitN(strings.TrimSpace(r), "/", 2)[0]
+		switch kind {
+		case "deployment.apps", "statefulset.apps", "daemonset.apps":
+			res = append(res, strings.TrimSpace(r))
+		}
+	}
+	return res
+}
+
+// kubernetesEvents sends the warning events of the namespace in the logs of the step, to help to understand a failure
+func kubernetesEvents(ctx context.Context, wk workerruntime.Runtime, env []string, dir, namespace string) {
+	wk.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Warning events of namespace %s:", namespace))
+	if _, err := runCommand(ctx, wk, env, dir, nil, "kubectl", "get", "events", "--namespace", namespace,
+		"--field-selector", "type=Warning", "--sort-by", ".lastTimestamp"); err != nil {
+		wk.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Cannot get events: %v", err))
+	}
+}
@@ -0,0 +1,57 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestKubernetesConfig(t *testing.T) {
+	_, err := kubernetesConfig(nil, "my-cluster")
+	require.Error(t, err)
+
+	secrets := []sdk.Variable{
+		{Name: "cds.kubernetes.my-cluster.api_url", Value: "https://k8s.example.com"},
+		{Name: "cds.kubernetes.my-cluster.ca_certificate", Value: "ca"},
+		{Name: "cds.kubernetes.my-cluster.token", Value: "secret-token"},
+	}
+	kubeconfig, err := kubernetesConfig(secrets, "my-cluster")
+	require.NoError(t, err)
+	require.Contains(t, kubeconfig, "    server: https://k8s.example.com\n    certificate-authority-data: Y2E=\n")
+	require.Contains(t, kubeconfig, "    token: secret-token\n")
+
+	secrets = append(secrets, sdk.Variable{Name: "cds.kubernetes.my-cluster.kubeconfig", Value: "apiVersion: v1"})
+	kubeconfig, err = kubernetesConfig(secrets, "my-cluster")
+	require.NoError(t, err)
+	require.Equal(t, "apiVersion: v1", kubeconfig)
+}
+
+func TestKubernetesDeployArgs(t *testing.T) {
+	opts := deployKubernetesOptions{
+		namespace:   "prod",
+		manifests:   []string{"k8s/deployment.yml", "k8s/service.yml"},
+		releaseName: "app",
+		helmChart:   "./chart",
+		helmValues:  []string{"values.yml", "values-prod.yml"},
+		timeout:     60,
+	}
+	require.Equal(t, []string{"apply", "--namespace", "prod", "--output", "name",
+		"--filename", "k8s/deployment.yml", "--filename", "k8s/service.yml"}, kubectlApplyArgs(opts))
+	require.Equal(t, []string{"upgrade", "app", "./chart", "--install", "--wait", "--namespace", "prod", "--timeout", "60s",
+		"--values", "values.yml", "--values", "values-prod.yml"}, helmUpgradeArgs(opts))
+
+	opts.kustomize = "k8s/overlays/prod"
+	require.Equal(t, []string{"apply", "--namespace", "prod", "--output", "name",
+		"--kustomize", "k8s/overlays/prod"}, kubectlApplyArgs(opts))
+}
+
+func TestRolloutResources(t *testing.T) {
+	require.Equal(t, []string{"deployment.apps/app", "statefulset.apps/db"}, rolloutResources([]string{
+		"service/app",
+		"deployment.apps/app",
+		"configmap/app-config",
+		"statefulset.apps/db ",
+	}))
+}
@@ -0,0 +1,228 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+// rolloutMetricInterval is the delay between two checks of the metric of a rollout while baking
+var rolloutMetricInterval = 30 * time.Second
+
+// rolloutDeployFunc deploys the application with given options, it returns the status and the details of the deployment
+type rolloutDeployFunc func(ctx context.Context, options map[string]string) (string, string, error)
+
+// rolloutMetricProvider returns the current value of the metric watched during a rollout
+type rolloutMetricProvider interface {
+	Value(ctx context.Context) (float64, error)
+}
+
+// rolloutMetricProviders are the supported metric providers, indexed by name
+var rolloutMetricProviders = map[string]func(m sdk.DeploymentRolloutMetric) (rolloutMetricProvider, error){
+	sdk.RolloutMetricProviderPrometheus: newPrometheusMetricProvider,
+}
+
+// runRollout deploys the application step by step, the weight of each step is given to the integration plugin
+// with the variable cds.rollout.weight. After each step the metric is watched during the bake time, the deployment
+// is rolled back if the metric crosses its threshold or if a step fails.
+func runRollout(ctx context.Context, wk workerruntime.Runtime, rollout sdk.DeploymentRollout, options map[string]string, deploy rolloutDeployFunc) (sdk.Result, error) {
+	var provider rolloutMetricProvider
+	if rollout.Metric != nil {
+		newProvider, ok := rolloutMetricProviders[rollout.Metric.Provider]
+		if !ok {
+			return sdk.Result{}, fmt.Errorf("unsupported rollout metric provider %s", rollout.Metric.Provider)
+		}
+		var err error
+		provider, err = newProvider(*rollout.Metric)
+		if err != nil {
+			return sdk.Result{}, fmt.Errorf("unable to init rollout metric provider: %v", err)
+		}
+	}
+
+	steps := rollout.RolloutSteps()
+	for i, weight := range steps {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Rollout %s: step %d/%d, %d%% of the traffic on the new version", rollout.Strategy, i+1, len(steps), weight))
+		status, details, err := deploy(ctx, rolloutOptions(options, i+1, weight, false))
+		if err != nil {
+			return rolloutRollback(ctx, wk, options, deploy, fmt.Sprintf("Error deploying application: %v", err))
+		}
+		if !strings.EqualFold(status, sdk.StatusSuccess) {
+			return rolloutRollback(ctx, wk, options, deploy, details)
+		}
+
+		// The new version takes all the traffic at the last step, there is nothing to bake
+		if i == len(steps)-1 {
+			break
+		}
+		if reason := rolloutBake(ctx, wk, rollout, provider); reason != "" {
+			return rolloutRollback(ctx, wk, options, deploy, reason)
+		}
+	}
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Rollout %s: done", rollout.Strategy))
+	return sdk.Result{Status: sdk.StatusSuccess}, nil
+}
+
+// rolloutBake waits for the bake time of the rollout, it returns the reason of the rollback if the metric crosses its
+// threshold or if the job is stopped
+func rolloutBake(ctx context.Context, wk workerruntime.Runtime, rollout sdk.DeploymentRollout, provider rolloutMetricProvider) string {
+	if rollout.BakeTime == 0 {
+		return ""
+	}
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("# Rollout %s: baking for %ds", rollout.Strategy, rollout.BakeTime))
+	bake := time.NewTimer(time.Duration(rollout.BakeTime) * time.Second)
+	defer bake.Stop()
+	tick := time.NewTicker(rolloutMetricInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return "rollout interrupted"
+		case <-bake.C:
+			return ""
+		case <-tick.C:
+			if provider == nil {
+				continue
+			}
+			value, err := provider.Value(ctx)
+			if err != nil {
+				// The metric provider may be unavailable for a while, it does not mean that the new version is faulty
+				wk.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("# Rollout %s: unable to get metric: %v", rollout.Strategy, err))
+				continue
+			}
+			if rollout.Metric.Breached(value) {
+				return fmt.Sprintf("metric %s is %s threshold %v: %v", rollout.Metric.Query, rolloutOperator(*rollout.Metric), rollout.Metric.Threshold, value)
+			}
+		}
+	}
+}
+
+// rolloutRollback sends all the traffic back to the previous version, the result is always a failure with given reason
+func rolloutRollback(ctx context.Context, wk workerruntime.Runtime, options map[string]string, deploy rolloutDeployFunc, reason string) (sdk.Result, error) {
+	wk.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("# Rollout failed: %s", reason))
+	wk.SendLog(ctx, workerruntime.LevelInfo, "# Rolling back to the previous version")
+	// The rollback must be done even if the job was stopped
+	status, details, err := deploy(context.Background(), rolloutOptions(options, 0, 0, true))
+	if err != nil {
+		return sdk.Result{}, fmt.Errorf("unable to rollback deployment after %q: %v", reason, err)
+	}
+	if !strings.EqualFold(status, sdk.StatusSuccess) {
+		return sdk.Result{}, fmt.Errorf("unable to rollback deployment after %q: %s", reason, details)
+	}
+	return sdk.Result{
+		Status: sdk.StatusFail,
+		Reason: fmt.Sprintf("Deployment rolled back: %s", reason),
+	}, nil
+}
+
+// rolloutOptions returns the options given to the integration plugin for a step of a rollout
+func rolloutOptions(options map[string]string, step, weight int, rollback bool) map[string]string {
+	res := make(map[string]string, len(options)+3)
+	for k, v := range options {
+		res[k] = v
+	}
+	res["cds.rollout.step"] = strconv.Itoa(step)
+	res["cds.rollout.weight"] = strconv.Itoa(weight)
+	res["cds.rollout.rollback"] = strconv.FormatBool(rollback)
+	return res
+}
+
+func rolloutOperator(m sdk.DeploymentRolloutMetric) string {
+	if m.Operator == sdk.RolloutMetricBelow {
+		return sdk.RolloutMetricBelow
+	}
+	return sdk.RolloutMetricAbove
+}
+
+// prometheusMetricProvider runs an instant query on the HTTP API of Prometheus
+type prometheusMetricProvider struct {
+	url    string
+	query  string
+	client *http.Client
+}
+
+func newPrometheusMetricProvider(m sdk.DeploymentRolloutMetric) (rolloutMetricProvider, error) {
+	if _, err := url.Parse(m.URL); err != nil {
+		return nil, err
+	}
+	return &prometheusMetricProvider{
+		url:    strings.TrimSuffix(m.URL, "/"),
+		query:  m.Query,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Value returns the value of the query, the query must return a single sample
+func (p *prometheusMetricProvider) Value(ctx context.Context) (float64, error) {
+	req, err := http.NewRequest(http.MethodGet, p.url+"/api/v1/query?"+url.Values{"query": {p.query}}.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("prometheus returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var res struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return 0, err
+	}
+	if res.Status != "success" {
+		return 0, fmt.Errorf("prometheus query failed: %s", res.Error)
+	}
+
+	// A sample is a [timestamp, "value"] pair
+	var sample []interface{}
+	switch res.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(res.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(res.Data.Result, &vector); err != nil {
+			return 0, err
+		}
+		if len(vector) != 1 {
+			return 0, fmt.Errorf("prometheus query returned %d samples, expected 1", len(vector))
+		}
+		sample = vector[0].Value
+	default:
+		return 0, fmt.Errorf("unsupported prometheus result type %s", res.Data.ResultType)
+	}
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid prometheus sample %v", sample)
+	}
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid prometheus sample value %v", sample[1])
+	}
+	return strconv.ParseFloat(s, 64)
+}
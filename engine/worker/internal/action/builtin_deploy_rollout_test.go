@@ -0,0 +1,109 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestRunRollout(t *testing.T) {
+	wk, ctx := SetupTest(t)
+	rolloutMetricInterval = 5 * time.Millisecond
+
+	var errorRate string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/query", r.URL.Path)
+		require.Equal(t, "error_rate", r.URL.Query().Get("query"))
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1600000000,"%s"]}]}}`, errorRate)
+	}))
+	defer srv.Close()
+
+	var deployments []map[string]string
+	deploy := func(ctx context.Context, options map[string]string) (string, string, error) {
+		deployments = append(deployments, options)
+		return sdk.StatusSuccess, "", nil
+	}
+	rollout := sdk.DeploymentRollout{
+		Strategy: sdk.RolloutStrategyCanary,
+		Steps:    []int{10, 50, 100},
+		BakeTime: 1,
+		Metric: &sdk.DeploymentRolloutMetric{
+			Provider:  sdk.RolloutMetricProviderPrometheus,
+			URL:       srv.URL,
+			Query:     "error_rate",
+			Threshold: 0.05,
+		},
+	}
+
+	// All the steps are deployed while the metric is under its threshold
+	errorRate = "0.01"
+	res, err := runRollout(ctx, wk, rollout, map[string]string{"cds.application": "app"}, deploy)
+	require.NoError(t, err)
+	require.Equal(t, sdk.StatusSuccess, res.Status)
+	require.Len(t, deployments, 3)
+	for i, weight := range []string{"10", "50", "100"} {
+		require.Equal(t, "app", deployments[i]["cds.application"])
+		require.Equal(t, fmt.Sprintf("%d", i+1), deployments[i]["cds.rollout.step"])
+		require.Equal(t, weight, deployments[i]["cds.rollout.weight"])
+		require.Equal(t, "false", deployments[i]["cds.rollout.rollback"])
+	}
+
+	// The deployment is rolled back at the first step when the metric crosses its threshold
+	deployments = nil
+	errorRate = "0.2"
+	res, err = runRollout(ctx, wk, rollout, map[string]string{}, deploy)
+	require.NoError(t, err)
+	require.Equal(t, sdk.StatusFail, res.Status)
+	require.Contains(t, res.Reason, "error_rate")
+	require.Len(t, deployments, 2)
+	require.Equal(t, "10", deployments[0]["cds.rollout.weight"])
+	require.Equal(t, "0", deployments[1]["cds.rollout.weight"])
+	require.Equal(t, "true", deployments[1]["cds.rollout.rollback"])
+
+	// The deployment is rolled back when a step fails
+	deployments = nil
+	res, err = runRollout(ctx, wk, sdk.DeploymentRollout{Strategy: sdk.RolloutStrategyBlueGreen}, map[string]string{},
+		func(ctx context.Context, options map[string]string) (string, string, error) {
+			deployments = append(deployments, options)
+			if options["cds.rollout.weight"] == "100" {
+				return sdk.StatusFail, "unhealthy", nil
+			}
+			return sdk.StatusSuccess, "", nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, sdk.StatusFail, res.Status)
+	require.Contains(t, res.Reason, "unhealthy")
+	require.Len(t, deployments, 3)
+	require.Equal(t, "true", deployments[2]["cds.rollout.rollback"])
+}
+
+func TestPrometheusMetricProvider(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	p, err := newPrometheusMetricProvider(sdk.DeploymentRolloutMetric{URL: srv.URL + "/", Query: "up"})
+	require.NoError(t, err)
+
+	body = `{"status":"success","data":{"resultType":"scalar","result":[1600000000,"42.5"]}}`
+	v, err := p.Value(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 42.5, v)
+
+	body = `{"status":"success","data":{"resultType":"vector","result":[]}}`
+	_, err = p.Value(context.Background())
+	require.Error(t, err)
+
+	body = `{"status":"error","error":"parse error"}`
+	_, err = p.Value(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,257 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/slug"
+)
+
+const dockerBuildCacheDir = "cache"
+
+type dockerBuildOptions struct {
+	image      string
+	tags       []string
+	context    string
+	dockerfile string
+	platforms  []string
+	buildArgs  []string
+	labels     map[string]string
+	builder    string
+	cacheFrom  string
+	cacheTo    string
+	push       bool
+}
+
+// RunDockerBuild builds a docker image with buildx, and pushes it to a registry
+func RunDockerBuild(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+
+	opts := dockerBuildOptions{
+		image:      sdk.ParameterValue(a.Parameters, "image"),
+		tags:       splitList(sdk.ParameterValue(a.Parameters, "tags")),
+		context:    sdk.ParameterValue(a.Parameters, "context"),
+		dockerfile: sdk.ParameterValue(a.Parameters, "dockerfile"),
+		platforms:  splitList(sdk.ParameterValue(a.Parameters, "platforms")),
+		buildArgs:  splitLines(sdk.ParameterValue(a.Parameters, "buildArgs")),
+		labels:     dockerBuildLabels(wk.Parameters(), time.Now()),
+		push:       sdk.ParameterValue(a.Parameters, "push") != "false",
+	}
+	if opts.image == "" {
+		return res, fmt.Errorf("docker build: image is not set")
+	}
+	if len(opts.tags) == 0 {
+		opts.tags = []string{"latest"}
+	}
+	if opts.context == "" {
+		opts.context = "."
+	}
+	if len(opts.platforms) > 1 && !opts.push {
+		return res, fmt.Errorf("docker build: an image with several platforms must be pushed")
+	}
+	for _, l := range splitLines(sdk.ParameterValue(a.Parameters, "labels")) {
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) != 2 {
+			return res, fmt.Errorf("docker build: invalid label %q, must be KEY=VALUE", l)
+		}
+		opts.labels[kv[0]] = kv[1]
+	}
+	useCache, _ := strconv.ParseBool(sdk.ParameterValue(a.Parameters, "cache"))
+
+	workdir, err := workerruntime.WorkingDirectory(ctx)
+	if err != nil {
+		return res, err
+	}
+	var dir string
+	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		dir, _ = x.RealPath(workdir.Name())
+	} else {
+		dir = workdir.Name()
+	}
+
+	// The docker configuration is isolated in a temporary directory, so the registry credentials are removed with it
+	tmpDir, err := ioutil.TempDir("", "cds-docker-build")
+	if err != nil {
+		return res, fmt.Errorf("docker build: cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir) // nolint
+	env := append(wk.Environ(), "DOCKER_BUILDKIT=1", "DOCKER_CONFIG="+filepath.Join(tmpDir, "config"))
+
+	if registry := sdk.ParameterValue(a.Parameters, "registry"); registry != "" {
+		prefix := sdk.DockerRegistryVariablePrefix(registry)
+		url := sdk.VariableFind(secrets, prefix+"url")
+		username := sdk.VariableFind(secrets, prefix+"username")
+		password := sdk.VariableFind(secrets, prefix+"password")
+		if url == nil || username == nil || password == nil {
+			return res, fmt.Errorf("docker build: docker registry integration %s not found", registry)
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Login to docker registry %s", registry))
+		if _, err := runCommand(ctx, wk, env, dir, strings.NewReader(password.Value), "docker", "login", "--username", username.Value, "--password-stdin", url.Value); err != nil {
+			return res, fmt.Errorf("docker build: cannot login to docker registry %s: %v", registry, err)
+		}
+	}
+
+	// The default builder of docker can't build multi-arch images nor export the cache
+	if len(opts.platforms) > 0 || useCache {
+		jobID, _ := workerruntime.JobID(ctx)
+		opts.builder = fmt.Sprintf("cds-%d-%d", jobID, time.Now().Unix())
+		if _, err := runCommand(ctx, wk, env, dir, nil, "docker", "buildx", "create", "--name", opts.builder, "--driver", "docker-container"); err != nil {
+			return res, fmt.Errorf("docker build: cannot create buildx builder: %v", err)
+		}
+		defer func() {
+			if _, err := runCommand(ctx, wk, env, dir, nil, "docker", "buildx", "rm", opts.builder); err != nil {
+				log.Warning(ctx, "docker build: cannot remove buildx builder %s: %v", opts.builder, err)
+			}
+		}()
+	}
+
+	projectKey := sdk.ParameterValue(wk.Parameters(), "cds.project")
+	cacheRef := slug.Convert("docker-build-" + opts.image)
+	if useCache {
+		opts.cacheFrom, opts.cacheTo = pullDockerBuildCache(ctx, wk, projectKey, cacheRef, tmpDir)
+	}
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Building image %s with tags %s", opts.image, strings.Join(opts.tags, ", ")))
+	if _, err := runCommand(ctx, wk, env, dir, nil, "docker", dockerBuildArgs(opts)...); err != nil {
+		return res, fmt.Errorf("docker build: build failure: %v", err)
+	}
+
+	if useCache {
+		if err := pushDockerBuildCache(wk, projectKey, cacheRef, tmpDir); err != nil {
+			wk.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Cannot push docker layer cache: %v", err))
+		}
+	}
+
+	return sdk.Result{Status: sdk.StatusSuccess}, nil
+}
+
+// dockerBuildArgs returns the arguments of the docker buildx build command
+func dockerBuildArgs(opts dockerBuildOptions) []string {
+	args := []string{"buildx", "build", "--progress", "plain"}
+	if opts.builder != "" {
+		args = append(args, "--builder", opts.builder)
+	}
+	if opts.dockerfile != "" {
+		args = append(args, "--file", opts.dockerfile)
+	}
+	for _, t := range opts.tags {
+		args = append(args, "--tag", opts.image+":"+t)
+	}
+	if len(opts.platforms) > 0 {
+		args = append(args, "--platform", strings.Join(opts.platforms, ","))
+	}
+	for _, b := range opts.buildArgs {
+		args = append(args, "--build-arg", b)
+	}
+
+	labels := make([]string, 0, len(opts.labels))
+	for k := range opts.labels {
+		labels = append(labels, k)
+	}
+	sort.Strings(labels)
+	for _, k := range labels {
+		args = append(args, "--label", k+"="+opts.labels[k])
+	}
+
+	if opts.cacheFrom != "" {
+		args = append(args, "--cache-from", "type=local,src="+opts.cacheFrom)
+	}
+	if opts.cacheTo != "" {
+		args = append(args, "--cache-to", "type=local,mode=max,dest="+opts.cacheTo)
+	}
+
+	if opts.push {
+		args = append(args, "--push")
+	} else {
+		args = append(args, "--load")
+	}
+	return append(args, opts.context)
+}
+
+// dockerBuildLabels returns the OCI annotations and the metadata of the run for the image
+func dockerBuildLabels(params []sdk.Parameter, now time.Time) map[string]string {
+	labels := map[string]string{
+		"org.opencontainers.image.created": now.UTC().Format(time.RFC3339),
+	}
+	set := func(label, param string) {
+		if v := sdk.ParameterValue(params, param); v != "" {
+			labels[label] = v
+		}
+	}
+	set("org.opencontainers.image.revision", "git.hash")
+	set("org.opencontainers.image.source", "git.url")
+	set("org.opencontainers.image.source", "git.http_url")
+	set("org.opencontainers.image.version", "cds.version")
+	set("org.opencontainers.image.url", "cds.ui.pipeline.run")
+	set("com.ovh.cds.project", "cds.project")
+	set("com.ovh.cds.workflow", "cds.workflow")
+	set("com.ovh.cds.run.number", "cds.run.number")
+	set("com.ovh.cds.node", "cds.node")
+	set("com.ovh.cds.git.branch", "git.branch")
+	return labels
+}
+
+// pullDockerBuildCache downloads the layer cache from the CDS cache, it returns the directory
+// to import the cache from, empty if there is no cache yet, and the directory to export the cache to
+func pullDockerBuildCache(ctx context.Context, wk workerruntime.Runtime, projectKey, ref, tmpDir string) (string, string) {
+	cacheTo := filepath.Join(tmpDir, "cache-new")
+	r, err := wk.Client().WorkflowCachePull(projectKey, sdk.DefaultStorageIntegrationName, ref)
+	if err != nil {
+		wk.SendLog(ctx, workerruntime.LevelInfo, "No docker layer cache found")
+		return "", cacheTo
+	}
+	if err := sdk.Untar(afero.NewOsFs(), tmpDir, r); err != nil {
+		wk.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Cannot extract docker layer cache: %v", err))
+		return "", cacheTo
+	}
+	return filepath.Join(tmpDir, dockerBuildCacheDir), cacheTo
+}
+
+// pushDockerBuildCache uploads the layer cache exported by the build to the CDS cache
+func pushDockerBuildCache(wk workerruntime.Runtime, projectKey, ref, tmpDir string) error {
+	cacheDir := filepath.Join(tmpDir, dockerBuildCacheDir)
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(tmpDir, "cache-new"), cacheDir); err != nil {
+		return err
+	}
+	tar, size, err := sdk.CreateTarFromPaths(afero.NewOsFs(), tmpDir, []string{dockerBuildCacheDir}, nil)
+	if err != nil {
+		return err
+	}
+	return wk.Client().WorkflowCachePush(projectKey, sdk.DefaultStorageIntegrationName, ref, tar, size)
+}
+
+func splitList(s string) []string {
+	var res []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func splitLines(s string) []string {
+	var res []string
+	for _, v := range strings.Split(s, "\n") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
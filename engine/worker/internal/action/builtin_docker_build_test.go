@@ -0,0 +1,67 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestDockerBuildArgs(t *testing.T) {
+	args := dockerBuildArgs(dockerBuildOptions{
+		image:   "registry.example.com/team/app",
+		tags:    []string{"1.0.0", "latest"},
+		context: ".",
+		labels:  map[string]string{"b": "2", "a": "1"},
+		push:    false,
+	})
+	require.Equal(t, []string{"buildx", "build", "--progress", "plain",
+		"--tag", "registry.example.com/team/app:1.0.0", "--tag", "registry.example.com/team/app:latest",
+		"--label", "a=1", "--label", "b=2",
+		"--load", "."}, args)
+
+	args = dockerBuildArgs(dockerBuildOptions{
+		image:      "registry.example.com/team/app",
+		tags:       []string{"1.0.0"},
+		context:    "app",
+		dockerfile: "app/Dockerfile.prod",
+		platforms:  []string{"linux/amd64", "linux/arm64"},
+		buildArgs:  []string{"VERSION=1.0.0"},
+		builder:    "cds-1",
+		cacheFrom:  "/tmp/cache",
+		cacheTo:    "/tmp/cache-new",
+		push:       true,
+	})
+	require.Equal(t, []string{"buildx", "build", "--progress", "plain", "--builder", "cds-1",
+		"--file", "app/Dockerfile.prod",
+		"--tag", "registry.example.com/team/app:1.0.0",
+		"--platform", "linux/amd64,linux/arm64",
+		"--build-arg", "VERSION=1.0.0",
+		"--cache-from", "type=local,src=/tmp/cache",
+		"--cache-to", "type=local,mode=max,dest=/tmp/cache-new",
+		"--push", "app"}, args)
+}
+
+func TestDockerBuildLabels(t *testing.T) {
+	now := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	labels := dockerBuildLabels([]sdk.Parameter{
+		{Name: "git.hash", Value: "abcdef"},
+		{Name: "git.url", Value: "ssh://git@github.com/ovh/cds.git"},
+		{Name: "git.http_url", Value: "https://github.com/ovh/cds.git"},
+		{Name: "cds.version", Value: "12"},
+		{Name: "cds.project", Value: "PROJ"},
+		{Name: "cds.workflow", Value: "build"},
+		{Name: "cds.run.number", Value: "12"},
+	}, now)
+	require.Equal(t, map[string]string{
+		"org.opencontainers.image.created":  "2020-03-01T10:00:00Z",
+		"org.opencontainers.image.revision": "abcdef",
+		"org.opencontainers.image.source":   "https://github.com/ovh/cds.git",
+		"org.opencontainers.image.version":  "12",
+		"com.ovh.cds.project":               "PROJ",
+		"com.ovh.cds.workflow":              "build",
+		"com.ovh.cds.run.number":            "12",
+	}, labels)
+}
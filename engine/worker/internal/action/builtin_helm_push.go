@@ -0,0 +1,231 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+type helmPackageOptions struct {
+	chart       string
+	destination string
+	version     string
+	appVersion  string
+	signKey     string
+	keyring     string
+}
+
+// RunHelmPush packages a helm chart, signs it, uploads it as an artifact of the run and pushes it to a chart repository
+func RunHelmPush(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+
+	opts := helmPackageOptions{
+		chart:      sdk.ParameterValue(a.Parameters, "chart"),
+		version:    sdk.ParameterValue(a.Parameters, "version"),
+		appVersion: sdk.ParameterValue(a.Parameters, "appVersion"),
+	}
+	if opts.chart == "" {
+		return res, fmt.Errorf("helm push: chart is not set")
+	}
+
+	workdir, err := workerruntime.WorkingDirectory(ctx)
+	if err != nil {
+		return res, err
+	}
+	var dir string
+	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		dir, _ = x.RealPath(workdir.Name())
+	} else {
+		dir = workdir.Name()
+	}
+
+	tmpDir, err := ioutil.TempDir("", "cds-helm-push")
+	if err != nil {
+		return res, fmt.Errorf("helm push: cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir) // nolint
+	opts.destination = filepath.Join(tmpDir, "package")
+	if err := os.Mkdir(opts.destination, 0700); err != nil {
+		return res, fmt.Errorf("helm push: cannot create package directory: %v", err)
+	}
+	env := append(wk.Environ(),
+		"HELM_EXPERIMENTAL_OCI=1",
+		"HELM_REGISTRY_CONFIG="+filepath.Join(tmpDir, "registry.json"),
+	)
+
+	if keyName := sdk.ParameterValue(a.Parameters, "signKey"); keyName != "" {
+		key := sdk.VariableFind(secrets, "cds.key."+keyName+".priv")
+		if key == nil || key.Type != sdk.KeyTypePGP {
+			return res, fmt.Errorf("helm push: PGP key %s not found", keyName)
+		}
+		opts.keyring = filepath.Join(tmpDir, "secring.gpg")
+		opts.signKey, err = writeHelmKeyring(key.Value, opts.keyring)
+		if err != nil {
+			return res, fmt.Errorf("helm push: cannot use PGP key %s: %v", keyName, err)
+		}
+	}
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Packaging helm chart %s", opts.chart))
+	if _, err := runCommand(ctx, wk, env, dir, nil, "helm", "dependency", "update", opts.chart); err != nil {
+		return res, fmt.Errorf("helm push: cannot update dependencies: %v", err)
+	}
+	if _, err := runCommand(ctx, wk, env, dir, nil, "helm", helmPackageArgs(opts)...); err != nil {
+		return res, fmt.Errorf("helm push: cannot package chart: %v", err)
+	}
+	packages, err := filepath.Glob(filepath.Join(opts.destination, "*.tgz"))
+	if err != nil || len(packages) != 1 {
+		return res, fmt.Errorf("helm push: chart package not found")
+	}
+	chartPackage := packages[0]
+	var provenance string
+	if opts.signKey != "" {
+		provenance = chartPackage + ".prov"
+	}
+
+	// The chart package and its provenance file are kept as artifacts of the run
+	tag := sdk.ParameterValue(a.Parameters, "tag")
+	if tag == "" {
+		tag = strings.TrimSuffix(filepath.Base(chartPackage), ".tgz")
+	}
+	uploadAction := sdk.Action{
+		Parameters: []sdk.Parameter{
+			{Name: "path", Type: sdk.StringParameter, Value: filepath.Join(opts.destination, "*")},
+			{Name: "tag", Type: sdk.StringParameter, Value: tag},
+		},
+	}
+	if _, err := RunArtifactUpload(ctx, wk, uploadAction, secrets); err != nil {
+		return res, fmt.Errorf("helm push: cannot upload chart package: %v", err)
+	}
+
+	registry := sdk.ParameterValue(a.Parameters, "registry")
+	if registry == "" {
+		return sdk.Result{Status: sdk.StatusSuccess}, nil
+	}
+	prefix := sdk.HelmRegistryVariablePrefix(registry)
+	url := sdk.VariableFind(secrets, prefix+"url")
+	username := sdk.VariableFind(secrets, prefix+"username")
+	password := sdk.VariableFind(secrets, prefix+"password")
+	if url == nil || username == nil || password == nil {
+		return res, fmt.Errorf("helm push: helm registry integration %s not found", registry)
+	}
+
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Pushing %s to helm registry %s", filepath.Base(chartPackage), registry))
+	if strings.HasPrefix(url.Value, "oci://") {
+		host := strings.SplitN(strings.TrimPrefix(url.Value, "oci://"), "/", 2)[0]
+		if _, err := runCommand(ctx, wk, env, dir, strings.NewReader(password.Value), "helm", "registry", "login", host,
+			"--username", username.Value, "--password-stdin"); err != nil {
+			return res, fmt.Errorf("helm push: cannot login to helm registry %s: %v", registry, err)
+		}
+		if _, err := runCommand(ctx, wk, env, dir, nil, "helm", "push", chartPackage, url.Value); err != nil {
+			return res, fmt.Errorf("helm push: cannot push chart: %v", err)
+		}
+	} else if err := pushChartMuseum(ctx, url.Value, username.Value, password.Value, chartPackage, provenance); err != nil {
+		return res, fmt.Errorf("helm push: cannot push chart: %v", err)
+	}
+
+	return sdk.Result{Status: sdk.StatusSuccess}, nil
+}
+
+// helmPackageArgs returns the arguments of the helm package command
+func helmPackageArgs(opts helmPackageOptions) []string {
+	args := []string{"package", opts.chart, "--destination", opts.destination}
+	if opts.version != "" {
+		args = append(args, "--version", opts.version)
+	}
+	if opts.appVersion != "" {
+		args = append(args, "--app-version", opts.appVersion)
+	}
+	if opts.signKey != "" {
+		args = append(args, "--sign", "--key", opts.signKey, "--keyring", opts.keyring)
+	}
+	return args
+}
+
+// writeHelmKeyring writes an armored PGP private key as a binary keyring, which is the format expected by helm.
+// It returns the name of the identity of the key, used by helm to select the signing key.
+func writeHelmKeyring(armoredKey, path string) (string, error) {
+	block, err := armor.Decode(strings.NewReader(armoredKey))
+	if err != nil {
+		return "", err
+	}
+	keyring, err := ioutil.ReadAll(block.Body)
+	if err != nil {
+		return "", err
+	}
+	entities, err := openpgp.ReadKeyRing(bytes.NewReader(keyring))
+	if err != nil {
+		return "", err
+	}
+	if len(entities) != 1 || len(entities[0].Identities) == 0 {
+		return "", fmt.Errorf("invalid PGP key")
+	}
+	var name string
+	for n := range entities[0].Identities {
+		name = n
+	}
+	return name, ioutil.WriteFile(path, keyring, 0600)
+}
+
+// pushChartMuseum uploads a chart package and its provenance file with the API of ChartMuseum
+func pushChartMuseum(ctx context.Context, url, username, password, chartPackage, provenance string) error {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	files := map[string]string{"chart": chartPackage}
+	if provenance != "" {
+		files["prov"] = provenance
+	}
+	for field, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		part, err := w.CreateFormFile(field, filepath.Base(path))
+		if err != nil {
+			f.Close() // nolint
+			return err
+		}
+		_, err = io.Copy(part, f)
+		f.Close() // nolint
+		if err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(url, "/")+"/api/charts", body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("chart repository returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestHelmPackageArgs(t *testing.T) {
+	require.Equal(t, []string{"package", "./chart", "--destination", "/tmp/package"}, helmPackageArgs(helmPackageOptions{
+		chart:       "./chart",
+		destination: "/tmp/package",
+	}))
+	require.Equal(t, []string{"package", "./chart", "--destination", "/tmp/package", "--version", "1.2.0", "--app-version", "12",
+		"--sign", "--key", "proj-key", "--keyring", "/tmp/secring.gpg"}, helmPackageArgs(helmPackageOptions{
+		chart:       "./chart",
+		destination: "/tmp/package",
+		version:     "1.2.0",
+		appVersion:  "12",
+		signKey:     "proj-key",
+		keyring:     "/tmp/secring.gpg",
+	}))
+}
+
+func TestWriteHelmKeyring(t *testing.T) {
+	entity, err := openpgp.NewEntity("proj-key", "proj-key", "cds@localhost", nil)
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	w, err := armor.Encode(buf, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(w, nil))
+	require.NoError(t, w.Close())
+
+	dir, err := ioutil.TempDir("", "test-helm-keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	path := filepath.Join(dir, "secring.gpg")
+	name, err := writeHelmKeyring(buf.String(), path)
+	require.NoError(t, err)
+	require.Equal(t, "proj-key (proj-key) <cds@localhost>", name)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close() // nolint
+	entities, err := openpgp.ReadKeyRing(f)
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	require.NotNil(t, entities[0].PrivateKey)
+}
+
+func TestPushChartMuseum(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-helm-push")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+	chart := filepath.Join(dir, "app-1.0.0.tgz")
+	require.NoError(t, ioutil.WriteFile(chart, []byte("chart"), 0600))
+	require.NoError(t, ioutil.WriteFile(chart+".prov", []byte("prov"), 0600))
+
+	var received map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if r.URL.Path != "/api/charts" || user != "cds" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received = make(map[string]string)
+		for _, field := range []string{"chart", "prov"} {
+			f, h, err := r.FormFile(field)
+			if err != nil {
+				continue
+			}
+			content, _ := ioutil.ReadAll(f)
+			received[field] = h.Filename + ":" + string(content)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	require.NoError(t, pushChartMuseum(context.Background(), srv.URL+"/", "cds", "secret", chart, chart+".prov"))
+	require.Equal(t, map[string]string{"chart": "app-1.0.0.tgz:chart", "prov": "app-1.0.0.tgz.prov:prov"}, received)
+
+	require.Error(t, pushChartMuseum(context.Background(), srv.URL, "cds", "wrong", chart, ""))
+}
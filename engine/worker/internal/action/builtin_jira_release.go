@@ -0,0 +1,287 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultJiraReleaseNotes = "release-notes.md"
+
+var jiraIssueKeyRegexp = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
+// jiraIssue is an issue returned by the REST API of Jira
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary   string `json:"summary"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Status struct {
+			Name string `json:"name"`
+		} `json:"status"`
+	} `json:"fields"`
+}
+
+// jiraTransition is a transition available on an issue
+type jiraTransition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// jiraClient calls the REST API of a Jira instance
+type jiraClient struct {
+	url      string
+	username string
+	token    string
+	client   *http.Client
+}
+
+// RunJiraRelease extracts the keys of the Jira issues from the commits of the run, applies a transition on the issues
+// and generates the release notes of the run, uploaded as an artifact of the run
+func RunJiraRelease(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+
+	integrationName := sdk.ParameterValue(a.Parameters, "integration")
+	if integrationName == "" {
+		return res, fmt.Errorf("jira: integration is not set")
+	}
+	prefix := sdk.JiraVariablePrefix(integrationName)
+	instanceURL := sdk.VariableFind(secrets, prefix+"url")
+	username := sdk.VariableFind(secrets, prefix+"username")
+	token := sdk.VariableFind(secrets, prefix+"token")
+	if instanceURL == nil || username == nil || token == nil {
+		return res, fmt.Errorf("jira: integration %s not found", integrationName)
+	}
+	c := &jiraClient{
+		url:      strings.TrimSuffix(instanceURL.Value, "/"),
+		username: username.Value,
+		token:    token.Value,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+	var projects []string
+	if v := sdk.VariableFind(secrets, prefix+"projects"); v != nil {
+		projects = splitList(v.Value)
+	}
+	transition := sdk.ParameterValue(a.Parameters, "transition")
+	if v := sdk.VariableFind(secrets, prefix+"transition"); transition == "" && v != nil {
+		transition = v.Value
+	}
+
+	params := wk.Parameters()
+	projectKey := sdk.ParameterValue(params, "cds.project")
+	workflowName := sdk.ParameterValue(params, "cds.workflow")
+	number, err := strconv.ParseInt(sdk.ParameterValue(params, "cds.run.number"), 10, 64)
+	if err != nil {
+		return res, fmt.Errorf("jira: cds.run.number variable is not valid: %v", err)
+	}
+
+	wr, err := wk.Client().WorkflowRunGet(projectKey, workflowName, number)
+	if err != nil {
+		return res, fmt.Errorf("jira: cannot get workflow run: %v", err)
+	}
+	keys := jiraIssueKeys(workflowRunCommits(wr), projects)
+	if len(keys) == 0 {
+		wk.SendLog(ctx, workerruntime.LevelInfo, "No Jira issue found in the commits of the run")
+	}
+
+	issues := make([]jiraIssue, 0, len(keys))
+	for _, key := range keys {
+		issue, err := c.issue(ctx, key)
+		if err != nil {
+			// The key may match a string that is not an issue, or an issue of another instance
+			wk.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Jira issue %s ignored: %v", key, err))
+			continue
+		}
+		issues = append(issues, *issue)
+	}
+
+	if transition != "" {
+		for _, issue := range issues {
+			applied, err := c.transition(ctx, issue.Key, transition)
+			if err != nil {
+				return res, fmt.Errorf("jira: cannot apply transition %s on issue %s: %v", transition, issue.Key, err)
+			}
+			if applied {
+				wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Transition %s applied on issue %s", transition, issue.Key))
+			} else {
+				wk.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Transition %s is not available on issue %s (%s)", transition, issue.Key, issue.Fields.Status.Name))
+			}
+		}
+	}
+
+	if generate, _ := strconv.ParseBool(sdk.ParameterValue(a.Parameters, "releaseNotes")); generate {
+		fileName := sdk.ParameterValue(a.Parameters, "artifact")
+		if fileName == "" {
+			fileName = defaultJiraReleaseNotes
+		}
+		tmpDir, err := ioutil.TempDir("", "cds-jira-release")
+		if err != nil {
+			return res, fmt.Errorf("jira: cannot create temporary directory: %v", err)
+		}
+		defer os.RemoveAll(tmpDir) // nolint
+
+		title := fmt.Sprintf("%s/%s %s", projectKey, workflowName, sdk.ParameterValue(params, "cds.version"))
+		notes := jiraReleaseNotes(title, c.url, issues)
+		path := filepath.Join(tmpDir, filepath.Base(fileName))
+		if err := ioutil.WriteFile(path, []byte(notes), 0600); err != nil {
+			return res, fmt.Errorf("jira: cannot write release notes: %v", err)
+		}
+		uploadAction := sdk.Action{
+			Parameters: []sdk.Parameter{
+				{Name: "path", Type: sdk.StringParameter, Value: path},
+				{Name: "tag", Type: sdk.StringParameter, Value: sdk.ParameterValue(params, "cds.version")},
+			},
+		}
+		if _, err := RunArtifactUpload(ctx, wk, uploadAction, secrets); err != nil {
+			return res, fmt.Errorf("jira: cannot upload release notes: %v", err)
+		}
+	}
+
+	return sdk.Result{Status: sdk.StatusSuccess}, nil
+}
+
+// workflowRunCommits returns the messages of the commits of all the node runs of a workflow run
+func workflowRunCommits(wr *sdk.WorkflowRun) []string {
+	hashes := make(map[string]struct{})
+	var messages []string
+	for _, nrs := range wr.WorkflowNodeRuns {
+		for _, nr := range nrs {
+			for _, c := range nr.Commits {
+				if _, ok := hashes[c.Hash]; ok {
+					continue
+				}
+				hashes[c.Hash] = struct{}{}
+				messages = append(messages, c.Message)
+			}
+		}
+	}
+	return messages
+}
+
+// jiraIssueKeys returns the sorted keys of the issues found in commit messages, restricted to the given projects if any
+func jiraIssueKeys(messages []string, projects []string) []string {
+	found := make(map[string]struct{})
+	for _, m := range messages {
+		for _, key := range jiraIssueKeyRegexp.FindAllString(m, -1) {
+			if len(projects) > 0 && !sdk.IsInArray(strings.SplitN(key, "-", 2)[0], projects) {
+				continue
+			}
+			found[key] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(found))
+	for k := range found {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jiraReleaseNotes returns the release notes of the issues in markdown, grouped by issue type
+func jiraReleaseNotes(title, baseURL string, issues []jiraIssue) string {
+	byType := make(map[string][]jiraIssue)
+	var types []string
+	for _, i := range issues {
+		t := i.Fields.IssueType.Name
+		if _, ok := byType[t]; !ok {
+			types = append(types, t)
+		}
+		byType[t] = append(byType[t], i)
+	}
+	sort.Strings(types)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Release notes of %s\n", title)
+	if len(issues) == 0 {
+		buf.WriteString("\nNo issue.\n")
+	}
+	for _, t := range types {
+		fmt.Fprintf(&buf, "\n## %s\n\n", t)
+		for _, i := range byType[t] {
+			fmt.Fprintf(&buf, "* [%s](%s/browse/%s) %s\n", i.Key, baseURL, i.Key, i.Fields.Summary)
+		}
+	}
+	return buf.String()
+}
+
+func (c *jiraClient) issue(ctx context.Context, key string) (*jiraIssue, error) {
+	var issue jiraIssue
+	if err := c.do(ctx, http.MethodGet, "/rest/api/2/issue/"+url.PathEscape(key)+"?fields=summary,issuetype,status", nil, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// transition applies the transition with given name on an issue, it returns false if the transition is not available
+func (c *jiraClient) transition(ctx context.Context, key, name string) (bool, error) {
+	var res struct {
+		Transitions []jiraTransition `json:"transitions"`
+	}
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "/transitions"
+	if err := c.do(ctx, http.MethodGet, path, nil, &res); err != nil {
+		return false, err
+	}
+	for _, t := range res.Transitions {
+		if strings.EqualFold(t.Name, name) {
+			body := map[string]interface{}{"transition": map[string]string{"id": t.ID}}
+			return true, c.do(ctx, http.MethodPost, path, body, nil)
+		}
+	}
+	return false, nil
+}
+
+// do sends a JSON request to the API, the response is decoded in out
+func (c *jiraClient) do(ctx context.Context, method, path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, c.url+path, body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.SetBasicAuth(c.username, c.token)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("jira returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,109 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestJiraIssueKeys(t *testing.T) {
+	messages := []string{
+		"fix(api): ABC-12 check the token",
+		"feat: add the release notes (DEF-3, ABC-7)",
+		"chore: encode in UTF-8",
+		"Merge ABC-12",
+	}
+	require.Equal(t, []string{"ABC-12", "ABC-7", "DEF-3", "UTF-8"}, jiraIssueKeys(messages, nil))
+	require.Equal(t, []string{"ABC-12", "ABC-7"}, jiraIssueKeys(messages, []string{"ABC"}))
+}
+
+func TestWorkflowRunCommits(t *testing.T) {
+	wr := &sdk.WorkflowRun{
+		WorkflowNodeRuns: map[int64][]sdk.WorkflowNodeRun{
+			1: {{Commits: []sdk.VCSCommit{{Hash: "a", Message: "ABC-1"}, {Hash: "b", Message: "ABC-2"}}}},
+			2: {{Commits: []sdk.VCSCommit{{Hash: "a", Message: "ABC-1"}}}},
+		},
+	}
+	require.Len(t, workflowRunCommits(wr), 2)
+}
+
+func TestJiraReleaseNotes(t *testing.T) {
+	var bug, story, story2 jiraIssue
+	bug.Key = "ABC-12"
+	bug.Fields.Summary = "Fix login"
+	bug.Fields.IssueType.Name = "Bug"
+	story.Key = "ABC-7"
+	story.Fields.Summary = "Release notes"
+	story.Fields.IssueType.Name = "Story"
+	story2.Key = "DEF-3"
+	story2.Fields.Summary = "Transitions"
+	story2.Fields.IssueType.Name = "Story"
+
+	require.Equal(t, `# Release notes of PROJ/release 12
+
+## Bug
+
+* [ABC-12](https://jira.example.com/browse/ABC-12) Fix login
+
+## Story
+
+* [ABC-7](https://jira.example.com/browse/ABC-7) Release notes
+* [DEF-3](https://jira.example.com/browse/DEF-3) Transitions
+`, jiraReleaseNotes("PROJ/release 12", "https://jira.example.com", []jiraIssue{story, bug, story2}))
+
+	require.Equal(t, "# Release notes of PROJ/release 12\n\nNo issue.\n", jiraReleaseNotes("PROJ/release 12", "https://jira.example.com", nil))
+}
+
+func TestJiraClient(t *testing.T) {
+	var transitioned string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, token, _ := r.BasicAuth()
+		if user != "cds" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/ABC-12":
+			w.Write([]byte(`{"key": "ABC-12", "fields": {"summary": "Fix login", "issuetype": {"name": "Bug"}, "status": {"name": "Done"}}}`)) // nolint
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/ABC-12/transitions":
+			w.Write([]byte(`{"transitions": [{"id": "21", "name": "In Progress"}, {"id": "31", "name": "Deployed"}]}`)) // nolint
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/ABC-12/transitions":
+			var body struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			transitioned = body.Transition.ID
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := &jiraClient{url: srv.URL, username: "cds", token: "secret", client: http.DefaultClient}
+	ctx := context.Background()
+
+	issue, err := c.issue(ctx, "ABC-12")
+	require.NoError(t, err)
+	require.Equal(t, "Bug", issue.Fields.IssueType.Name)
+
+	_, err = c.issue(ctx, "UTF-8")
+	require.Error(t, err)
+
+	applied, err := c.transition(ctx, "ABC-12", "deployed")
+	require.NoError(t, err)
+	require.True(t, applied)
+	require.Equal(t, "31", transitioned)
+
+	applied, err = c.transition(ctx, "ABC-12", "Closed")
+	require.NoError(t, err)
+	require.False(t, applied)
+}
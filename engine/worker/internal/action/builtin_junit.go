@@ -57,27 +57,11 @@ func RunParseJunitTestResultAction(ctx context.Context, wk workerruntime.Runtime
 		return res, errors.New("UnitTest parser: Cannot find requested files, invalid pattern")
 	}
 
-	var tests venom.Tests
 	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%d", len(files))+" file(s) to analyze")
 
-	for _, f := range files {
-		var ftests venom.Tests
-
-		data, errRead := afero.ReadFile(afero.NewOsFs(), f)
-		if errRead != nil {
-			return res, fmt.Errorf("UnitTest parser: cannot read file %s (%s)", f, errRead)
-		}
-
-		var vf venom.Tests
-		if err := xml.Unmarshal(data, &vf); err != nil {
-			// Check if file contains testsuite only (and no testsuites)
-			if s, ok := ParseTestsuiteAlone(data); ok {
-				ftests.TestSuites = append(ftests.TestSuites, s)
-			}
-			tests.TestSuites = append(tests.TestSuites, ftests.TestSuites...)
-		} else {
-			tests.TestSuites = append(tests.TestSuites, vf.TestSuites...)
-		}
+	tests, err := ParseJUnitFiles(files)
+	if err != nil {
+		return res, err
 	}
 
 	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%d", len(tests.TestSuites))+" Total Testsuite(s)")
@@ -196,6 +180,31 @@ func ComputeStats(res *sdk.Result, v *venom.Tests) []string {
 	return reasons
 }
 
+// ParseJUnitFiles reads given JUnit files and merges their testsuites.
+func ParseJUnitFiles(files []string) (venom.Tests, error) {
+	var tests venom.Tests
+	for _, f := range files {
+		var ftests venom.Tests
+
+		data, errRead := afero.ReadFile(afero.NewOsFs(), f)
+		if errRead != nil {
+			return tests, fmt.Errorf("UnitTest parser: cannot read file %s (%s)", f, errRead)
+		}
+
+		var vf venom.Tests
+		if err := xml.Unmarshal(data, &vf); err != nil {
+			// Check if file contains testsuite only (and no testsuites)
+			if s, ok := ParseTestsuiteAlone(data); ok {
+				ftests.TestSuites = append(ftests.TestSuites, s)
+			}
+			tests.TestSuites = append(tests.TestSuites, ftests.TestSuites...)
+		} else {
+			tests.TestSuites = append(tests.TestSuites, vf.TestSuites...)
+		}
+	}
+	return tests, nil
+}
+
 func ParseTestsuiteAlone(data []byte) (venom.TestSuite, bool) {
 	var s venom.TestSuite
 	err := xml.Unmarshal([]byte(data), &s)
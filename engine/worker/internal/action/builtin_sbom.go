@@ -0,0 +1,102 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+// RunSBOM generates a SBOM with syft, or reads a SBOM generated by another tool, and stores it with the workflow run
+func RunSBOM(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+
+	jobID, err := workerruntime.JobID(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	source := sdk.ParameterValue(a.Parameters, "source")
+	file := sdk.ParameterValue(a.Parameters, "file")
+	format := sdk.ParameterValue(a.Parameters, "format")
+	if format != "" && format != sdk.SBOMFormatCycloneDX && format != sdk.SBOMFormatSPDX {
+		return res, fmt.Errorf("sbom: invalid format %q, must be %s or %s", format, sdk.SBOMFormatCycloneDX, sdk.SBOMFormatSPDX)
+	}
+	sbom := sdk.WorkflowRunSBOM{
+		Name:     sdk.ParameterValue(a.Parameters, "name"),
+		Artifact: sdk.ParameterValue(a.Parameters, "artifact"),
+		Format:   format,
+	}
+
+	workdir, err := workerruntime.WorkingDirectory(ctx)
+	if err != nil {
+		return res, err
+	}
+	var dir string
+	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		dir, _ = x.RealPath(workdir.Name())
+	} else {
+		dir = workdir.Name()
+	}
+
+	var path string
+	if file != "" {
+		path = file
+		if !sdk.PathIsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if sbom.Name == "" {
+			sbom.Name = filepath.Base(file)
+		}
+	} else {
+		if source == "" {
+			source = "dir:."
+		}
+		if sbom.Format == "" {
+			sbom.Format = sdk.SBOMFormatCycloneDX
+		}
+		if sbom.Name == "" {
+			sbom.Name = source
+		}
+		tmpDir, err := ioutil.TempDir("", "cds-sbom")
+		if err != nil {
+			return res, fmt.Errorf("sbom: cannot create temporary directory: %v", err)
+		}
+		defer os.RemoveAll(tmpDir) // nolint
+		path = filepath.Join(tmpDir, "sbom.json")
+
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Generating %s SBOM of %s", sbom.Format, source))
+		if _, err := runCommand(ctx, wk, wk.Environ(), dir, nil, "syft", syftArgs(source, sbom.Format, path)...); err != nil {
+			return res, fmt.Errorf("sbom: cannot generate SBOM: %v", err)
+		}
+	}
+
+	sbom.Document, err = ioutil.ReadFile(path)
+	if err != nil {
+		return res, fmt.Errorf("sbom: cannot read SBOM: %v", err)
+	}
+
+	stored, err := wk.Client().QueueJobSBOM(ctx, jobID, sbom)
+	if err != nil {
+		return res, fmt.Errorf("sbom: cannot store SBOM: %v", err)
+	}
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("SBOM %s stored with %d components", stored.Name, len(stored.Components)))
+
+	return sdk.Result{Status: sdk.StatusSuccess}, nil
+}
+
+// syftArgs returns the arguments of the syft command writing the SBOM of a source in a file
+func syftArgs(source, format, output string) []string {
+	syftFormat := "cyclonedx-json"
+	if format == sdk.SBOMFormatSPDX {
+		syftFormat = "spdx-json"
+	}
+	return []string{source, "--output", syftFormat, "--file", output}
+}
@@ -0,0 +1,14 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSyftArgs(t *testing.T) {
+	require.Equal(t, []string{"dir:.", "--output", "cyclonedx-json", "--file", "/tmp/sbom.json"}, syftArgs("dir:.", sdk.SBOMFormatCycloneDX, "/tmp/sbom.json"))
+	require.Equal(t, []string{"registry:ovhcom/cds-engine:latest", "--output", "spdx-json", "--file", "/tmp/sbom.json"}, syftArgs("registry:ovhcom/cds-engine:latest", sdk.SBOMFormatSPDX, "/tmp/sbom.json"))
+}
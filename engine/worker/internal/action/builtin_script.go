@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -46,7 +45,7 @@ func prepareScriptContent(parameters []sdk.Parameter, basedir afero.Fs, workdir
 
 	// except on windows where it's powershell
 	if isWindows() {
-		script.shell = "PowerShell"
+		script.shell = powershellBinary()
 		script.opts = []string{"-ExecutionPolicy", "Bypass", "-Command"}
 		// on windows, we add ErrorActionPreference just below
 	} else if strings.HasPrefix(scriptContent, "#!") { // If user wants a specific shell, use it
@@ -78,6 +77,17 @@ func prepareScriptContent(parameters []sdk.Parameter, basedir afero.Fs, workdir
 	return &script, nil
 }
 
+// powershellBinary returns the PowerShell binary available on the host, Windows Nano Server images
+// only provide PowerShell Core.
+func powershellBinary() string {
+	if _, err := exec.LookPath("PowerShell"); err != nil {
+		if _, err := exec.LookPath("pwsh"); err == nil {
+			return "pwsh"
+		}
+	}
+	return "PowerShell"
+}
+
 func isWindows() bool {
 	return sdk.GOOS == "windows" || runtime.GOOS == "windows" || os.Getenv("CDS_WORKER_PSHELL_MODE") == "true"
 }
@@ -106,7 +116,7 @@ func writeScriptContent(ctx context.Context, script *script, fs afero.Fs, basedi
 		log.Debug("runScriptAction> renaming powershell script to %s", tmpFileName)
 	}
 
-	scriptPath := filepath.Join(path.Dir(basedir.Name()), tmpFileName)
+	scriptPath := filepath.Join(filepath.Dir(basedir.Name()), tmpFileName)
 	log.Debug("writeScriptContent> Opening file %s", scriptPath)
 
 	tmpscript, err := fs.OpenFile(scriptPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0700)
@@ -147,7 +157,7 @@ func writeScriptContent(ctx context.Context, script *script, fs afero.Fs, basedi
 
 	if isWindows() {
 		//This aims to stop a the very first error and return the right exit code
-		psCommand := fmt.Sprintf("& { $ErrorActionPreference='Stop'; & %s ;exit $LastExitCode}", realScriptPath)
+		psCommand := fmt.Sprintf("& { $ErrorActionPreference='Stop'; & '%s' ;exit $LastExitCode}", realScriptPath)
 		script.opts = append(script.opts, psCommand)
 	} else {
 		script.opts = append(script.opts, realScriptPath)
@@ -157,7 +167,7 @@ func writeScriptContent(ctx context.Context, script *script, fs afero.Fs, basedi
 	log.Debug("writeScriptContent> script directory is %s", script.dir)
 
 	deferFunc := func() {
-		filename := filepath.Join(path.Dir(basedir.Name()), tmpFileName)
+		filename := filepath.Join(filepath.Dir(basedir.Name()), tmpFileName)
 		log.Debug("writeScriptContent> removing file %s", filename)
 		if err := fs.Remove(filename); err != nil {
 			log.Error(ctx, "unable to remove %s: %v", filename, err)
@@ -209,10 +219,11 @@ func RunScriptAction(ctx context.Context, wk workerruntime.Runtime, a sdk.Action
 			chanErr <- fmt.Errorf("Failure due to internal error (Worker Path): %v", err)
 		}
 
-		log.Debug("runScriptAction> Worker binary path: %s", path.Dir(workerpath))
+		log.Debug("runScriptAction> Worker binary path: %s", filepath.Dir(workerpath))
 		for i := range cmd.Env {
-			if strings.HasPrefix(cmd.Env[i], "PATH") {
-				cmd.Env[i] = fmt.Sprintf("%s:%s", cmd.Env[i], path.Dir(workerpath))
+			// the variable is named Path on windows
+			if strings.HasPrefix(strings.ToUpper(cmd.Env[i]), "PATH=") {
+				cmd.Env[i] = fmt.Sprintf("%s%c%s", cmd.Env[i], os.PathListSeparator, filepath.Dir(workerpath))
 				break
 			}
 		}
@@ -263,6 +274,11 @@ func RunScriptAction(ctx context.Context, wk workerruntime.Runtime, a sdk.Action
 		<-outchan
 		<-errchan
 		if err := cmd.Wait(); err != nil {
+			if reason, ok := outOfMemoryReason(err); ok {
+				wk.SendLog(ctx, workerruntime.LevelError, reason)
+				chanErr <- errors.New(reason)
+				return
+			}
 			chanErr <- fmt.Errorf("command failure: %v", err)
 		}
 
@@ -0,0 +1,279 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	defaultServiceNowApprovalTimeout = 3600
+	serviceNowApprovalApproved       = "approved"
+	serviceNowApprovalRejected       = "rejected"
+)
+
+// serviceNowPollInterval is the delay between two checks of the approval of a change request
+var serviceNowPollInterval = 30 * time.Second
+
+// serviceNowChange is a change request returned by the table API of ServiceNow
+type serviceNowChange struct {
+	SysID    string `json:"sys_id"`
+	Number   string `json:"number"`
+	State    string `json:"state"`
+	Approval string `json:"approval"`
+}
+
+// serviceNowClient calls the REST API of a ServiceNow instance
+type serviceNowClient struct {
+	url      string
+	username string
+	password string
+	client   *http.Client
+}
+
+// RunServiceNowChangeRequest creates or updates a change request on a ServiceNow instance, attaches the report of the
+// run and waits for the approval of the change request if asked
+func RunServiceNowChangeRequest(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+
+	integrationName := sdk.ParameterValue(a.Parameters, "integration")
+	if integrationName == "" {
+		return res, fmt.Errorf("servicenow: integration is not set")
+	}
+	prefix := sdk.ServiceNowVariablePrefix(integrationName)
+	instanceURL := sdk.VariableFind(secrets, prefix+"url")
+	username := sdk.VariableFind(secrets, prefix+"username")
+	password := sdk.VariableFind(secrets, prefix+"password")
+	if instanceURL == nil || username == nil || password == nil {
+		return res, fmt.Errorf("servicenow: integration %s not found", integrationName)
+	}
+	c := &serviceNowClient{
+		url:      strings.TrimSuffix(instanceURL.Value, "/"),
+		username: username.Value,
+		password: password.Value,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+
+	timeout := defaultServiceNowApprovalTimeout
+	if t := sdk.ParameterValue(a.Parameters, "timeout"); t != "" {
+		var err error
+		timeout, err = strconv.Atoi(t)
+		if err != nil {
+			return res, fmt.Errorf("servicenow: invalid timeout %q: %v", t, err)
+		}
+	}
+
+	params := wk.Parameters()
+	projectKey := sdk.ParameterValue(params, "cds.project")
+	workflowName := sdk.ParameterValue(params, "cds.workflow")
+	number, err := strconv.ParseInt(sdk.ParameterValue(params, "cds.run.number"), 10, 64)
+	if err != nil {
+		return res, fmt.Errorf("servicenow: cds.run.number variable is not valid: %v", err)
+	}
+
+	var change *serviceNowChange
+	fields := make(map[string]string)
+	if state := sdk.ParameterValue(a.Parameters, "state"); state != "" {
+		fields["state"] = state
+	}
+	if changeNumber := sdk.ParameterValue(a.Parameters, "changeRequest"); changeNumber != "" {
+		change, err = c.find(ctx, changeNumber)
+		if err != nil {
+			return res, fmt.Errorf("servicenow: cannot find change request %s: %v", changeNumber, err)
+		}
+		if description := sdk.ParameterValue(a.Parameters, "description"); description != "" {
+			fields["work_notes"] = description
+		}
+		if len(fields) > 0 {
+			change, err = c.update(ctx, change.SysID, fields)
+			if err != nil {
+				return res, fmt.Errorf("servicenow: cannot update change request %s: %v", changeNumber, err)
+			}
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Change request %s updated", change.Number))
+	} else {
+		fields["short_description"] = sdk.ParameterValue(a.Parameters, "shortDescription")
+		if fields["short_description"] == "" {
+			fields["short_description"] = fmt.Sprintf("Deployment of %s/%s %s", projectKey, workflowName, sdk.ParameterValue(params, "cds.version"))
+		}
+		fields["description"] = sdk.ParameterValue(a.Parameters, "description")
+		if fields["description"] == "" {
+			fields["description"] = sdk.ParameterValue(params, "cds.ui.pipeline.run")
+		}
+		if group := sdk.VariableFind(secrets, prefix+"assignment_group"); group != nil && group.Value != "" {
+			fields["assignment_group"] = group.Value
+		}
+		change, err = c.create(ctx, fields)
+		if err != nil {
+			return res, fmt.Errorf("servicenow: cannot create change request: %v", err)
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Change request %s created", change.Number))
+	}
+
+	if attach, _ := strconv.ParseBool(sdk.ParameterValue(a.Parameters, "attachReport")); attach {
+		report := new(bytes.Buffer)
+		if err := wk.Client().WorkflowRunReportDownload(projectKey, workflowName, number, "html", report); err != nil {
+			return res, fmt.Errorf("servicenow: cannot download run report: %v", err)
+		}
+		fileName := fmt.Sprintf("%s-%s-%d.html", projectKey, workflowName, number)
+		if err := c.attach(ctx, change.SysID, fileName, "text/html", report); err != nil {
+			return res, fmt.Errorf("servicenow: cannot attach run report to change request %s: %v", change.Number, err)
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Run report attached to change request %s", change.Number))
+	}
+
+	if wait, _ := strconv.ParseBool(sdk.ParameterValue(a.Parameters, "waitApproval")); wait {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Waiting for the approval of change request %s", change.Number))
+		ctxWait, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancel()
+		change, err = c.waitApproval(ctxWait, change)
+		if err != nil {
+			return res, fmt.Errorf("servicenow: change request %s is not approved: %v", change.Number, err)
+		}
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Change request %s approved", change.Number))
+	}
+
+	return sdk.Result{
+		Status: sdk.StatusSuccess,
+		NewVariables: []sdk.Variable{{
+			Name:  "cds.build.servicenow.change",
+			Type:  sdk.StringVariable,
+			Value: change.Number,
+		}},
+	}, nil
+}
+
+// waitApproval polls the change request until it is approved, rejected or until the end of the context
+func (c *serviceNowClient) waitApproval(ctx context.Context, change *serviceNowChange) (*serviceNowChange, error) {
+	tick := time.NewTicker(serviceNowPollInterval)
+	defer tick.Stop()
+	for {
+		switch change.Approval {
+		case serviceNowApprovalApproved:
+			return change, nil
+		case serviceNowApprovalRejected:
+			return change, fmt.Errorf("change request rejected")
+		}
+		select {
+		case <-ctx.Done():
+			return change, fmt.Errorf("timeout while waiting for the approval")
+		case <-tick.C:
+			updated, err := c.get(ctx, change.SysID)
+			if err != nil {
+				return change, err
+			}
+			change = updated
+		}
+	}
+}
+
+func (c *serviceNowClient) create(ctx context.Context, fields map[string]string) (*serviceNowChange, error) {
+	var change serviceNowChange
+	if err := c.do(ctx, http.MethodPost, "/api/now/table/change_request", fields, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (c *serviceNowClient) update(ctx context.Context, sysID string, fields map[string]string) (*serviceNowChange, error) {
+	var change serviceNowChange
+	if err := c.do(ctx, http.MethodPatch, "/api/now/table/change_request/"+url.PathEscape(sysID), fields, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (c *serviceNowClient) get(ctx context.Context, sysID string) (*serviceNowChange, error) {
+	var change serviceNowChange
+	if err := c.do(ctx, http.MethodGet, "/api/now/table/change_request/"+url.PathEscape(sysID), nil, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (c *serviceNowClient) find(ctx context.Context, number string) (*serviceNowChange, error) {
+	var changes []serviceNowChange
+	q := url.Values{}
+	q.Set("sysparm_query", "number="+number)
+	q.Set("sysparm_limit", "1")
+	if err := c.do(ctx, http.MethodGet, "/api/now/table/change_request?"+q.Encode(), nil, &changes); err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("change request not found")
+	}
+	return &changes[0], nil
+}
+
+func (c *serviceNowClient) attach(ctx context.Context, sysID, fileName, contentType string, content io.Reader) error {
+	q := url.Values{}
+	q.Set("table_name", "change_request")
+	q.Set("table_sys_id", sysID)
+	q.Set("file_name", fileName)
+	req, err := http.NewRequest(http.MethodPost, c.url+"/api/now/attachment/file?"+q.Encode(), content)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.send(ctx, req, nil)
+}
+
+// do sends a JSON request to the API, the result of the response is decoded in out
+func (c *serviceNowClient) do(ctx context.Context, method, path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, c.url+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.send(ctx, req, out)
+}
+
+func (c *serviceNowClient) send(ctx context.Context, req *http.Request, out interface{}) error {
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("servicenow returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if out == nil {
+		return nil
+	}
+	var result struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	return json.Unmarshal(result.Result, out)
+}
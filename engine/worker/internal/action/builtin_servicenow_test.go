@@ -0,0 +1,85 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceNowClient(t *testing.T) {
+	var attachment string
+	var polls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if user != "cds" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		change := map[string]string{"sys_id": "a1b2", "number": "CHG0012345", "approval": "requested"}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/now/table/change_request":
+			var fields map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&fields))
+			require.Equal(t, "Deployment of PROJ/release 12", fields["short_description"])
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/table/change_request":
+			require.Equal(t, "number=CHG0012345", r.URL.Query().Get("sysparm_query"))
+			json.NewEncoder(w).Encode(map[string]interface{}{"result": []interface{}{change}}) // nolint
+			return
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/table/change_request/a1b2":
+			polls++
+			if polls > 1 {
+				change["approval"] = "approved"
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/api/now/attachment/file":
+			require.Equal(t, "a1b2", r.URL.Query().Get("table_sys_id"))
+			body, _ := ioutil.ReadAll(r.Body)
+			attachment = r.URL.Query().Get("file_name") + ":" + string(body)
+			w.WriteHeader(http.StatusCreated)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": change}) // nolint
+	}))
+	defer srv.Close()
+
+	c := &serviceNowClient{url: srv.URL, username: "cds", password: "secret", client: http.DefaultClient}
+	ctx := context.Background()
+
+	change, err := c.create(ctx, map[string]string{"short_description": "Deployment of PROJ/release 12"})
+	require.NoError(t, err)
+	require.Equal(t, "CHG0012345", change.Number)
+	require.Equal(t, "a1b2", change.SysID)
+
+	change, err = c.find(ctx, "CHG0012345")
+	require.NoError(t, err)
+	require.Equal(t, "a1b2", change.SysID)
+
+	require.NoError(t, c.attach(ctx, change.SysID, "report.html", "text/html", strings.NewReader("<html></html>")))
+	require.Equal(t, "report.html:<html></html>", attachment)
+
+	serviceNowPollInterval = 10 * time.Millisecond
+	change, err = c.waitApproval(ctx, change)
+	require.NoError(t, err)
+	require.Equal(t, "approved", change.Approval)
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	_, err = c.waitApproval(ctxTimeout, &serviceNowChange{SysID: "a1b2", Approval: "requested"})
+	require.Error(t, err)
+
+	_, err = c.waitApproval(ctx, &serviceNowChange{SysID: "a1b2", Approval: "rejected"})
+	require.Error(t, err)
+
+	c.password = "wrong"
+	_, err = c.create(ctx, map[string]string{})
+	require.Error(t, err)
+}
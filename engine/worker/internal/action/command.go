@@ -0,0 +1,51 @@
+package action
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os/exec"
+	"sync"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+)
+
+// runCommand runs a command and sends its output in the logs of the step, it returns the lines of the standard output
+func runCommand(ctx context.Context, wk workerruntime.Runtime, env []string, dir string, stdin io.Reader, name string, args ...string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = stdin
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+	readLines := func(r io.Reader, keep bool) {
+		defer wg.Done()
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if keep {
+				lines = append(lines, scanner.Text())
+			}
+			wk.SendLog(ctx, workerruntime.LevelInfo, scanner.Text())
+		}
+	}
+	go readLines(stdout, true)
+	go readLines(stderr, false)
+	wg.Wait()
+
+	return lines, cmd.Wait()
+}
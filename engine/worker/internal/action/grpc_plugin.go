@@ -4,10 +4,12 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -74,7 +76,9 @@ func enablePluginLogger(ctx context.Context, done chan struct{}, c *pluginClient
 	}
 }
 
-func RunGRPCPlugin(ctx context.Context, actionName string, params []sdk.Parameter, action sdk.Action, w workerruntime.Runtime, chanRes chan sdk.Result, done chan struct{}) {
+// RunGRPCPlugin runs an action plugin. The binary of the plugin is the installed one if p is nil, or a version
+// from the plugin registry.
+func RunGRPCPlugin(ctx context.Context, actionName string, p *sdk.GRPCPluginBinary, params []sdk.Parameter, action sdk.Action, w workerruntime.Runtime, chanRes chan sdk.Result, done chan struct{}) {
 	//For the moment we consider that plugin name = action name
 	pluginName := actionName
 
@@ -94,7 +98,7 @@ func RunGRPCPlugin(ctx context.Context, actionName string, params []sdk.Paramete
 		envs = append(envs, fmt.Sprintf("%s=%s", envName, p.Value))
 	}
 
-	pluginSocket, err := startGRPCPlugin(ctx, pluginName, w, nil, startGRPCPluginOptions{
+	pluginSocket, err := startGRPCPlugin(ctx, pluginName, w, p, startGRPCPluginOptions{
 		envs: envs,
 	})
 	if err != nil {
@@ -182,24 +186,33 @@ func startGRPCPlugin(ctx context.Context, pluginName string, w workerruntime.Run
 		}
 	}
 
-	// then try to download the plugin
+	// then try to download the plugin, the binary in cache can be another version of the plugin
 	pluginBinary := binary.Name
-	if _, err := w.BaseDir().Stat(pluginBinary); os.IsNotExist(err) {
+	if _, err := w.BaseDir().Stat(pluginBinary); os.IsNotExist(err) || !checkPluginBinarySum(w.BaseDir(), binary) {
 		log.Debug("Downloading the plugin %s", binary.PluginName)
 		//If the file doesn't exist. Download it.
-		fi, err := w.BaseDir().OpenFile(pluginBinary, os.O_CREATE|os.O_RDWR, os.FileMode(binary.Perm))
+		fi, err := w.BaseDir().OpenFile(pluginBinary, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(binary.Perm))
 		if err != nil {
 			return nil, sdk.WrapError(err, "unable to create the file %s", pluginBinary)
 		}
 
 		log.Debug("Get the binary plugin %s", binary.PluginName)
 		//TODO: put afero in the client
-		if err := w.Client().PluginGetBinary(binary.PluginName, currentOS, currentARCH, fi); err != nil {
+		if binary.Version != "" {
+			err = w.Client().PluginRegistryGetBinary(binary.PluginName, binary.Version, currentOS, currentARCH, fi)
+		} else {
+			err = w.Client().PluginGetBinary(binary.PluginName, currentOS, currentARCH, fi)
+		}
+		if err != nil {
 			_ = fi.Close()
 			return nil, sdk.WrapError(err, "unable to get the binary plugin the file %s", binary.PluginName)
 		}
 		//It's downloaded. Close the file
 		_ = fi.Close()
+
+		if !checkPluginBinarySum(w.BaseDir(), binary) {
+			return nil, fmt.Errorf("plugin:%s invalid checksum of the binary %s", pluginName, pluginBinary)
+		}
 	} else {
 		log.Debug("plugin binary is in cache %s", pluginBinary)
 	}
@@ -211,6 +224,10 @@ func startGRPCPlugin(ctx context.Context, pluginName string, w workerruntime.Run
 		if strings.HasPrefix(env, "CDS_") {
 			continue
 		}
+		// a sandboxed plugin doesn't get the environment of the worker
+		if binary.Sandbox != nil && binary.Sandbox.IsEnabled() && !isSandboxEnv(env) {
+			continue
+		}
 		envs = append(envs, env)
 	}
 	envs = append(envs, opts.envs...)
@@ -243,10 +260,10 @@ func startGRPCPlugin(ctx context.Context, pluginName string, w workerruntime.Run
 	if _, err := sdk.LookPath(w.BaseDir(), cmd); err != nil {
 		return nil, sdk.WrapError(err, "plugin:%s unable to find GRPC plugin, binary command not found.", pluginName)
 	}
-	cmd = path.Join(basedir, cmd)
+	cmd = filepath.Join(basedir, cmd)
 
 	for i := range binary.Entrypoints {
-		binary.Entrypoints[i] = path.Join(basedir, binary.Entrypoints[i])
+		binary.Entrypoints[i] = filepath.Join(basedir, binary.Entrypoints[i])
 	}
 	args := append(binary.Entrypoints, binary.Args...)
 	var errstart error
@@ -262,12 +279,40 @@ func startGRPCPlugin(ctx context.Context, pluginName string, w workerruntime.Run
 		dir = workdir.Name()
 	}
 
-	if c.StdPipe, c.Socket, errstart = grpcplugin.StartPlugin(ctx, pluginName, dir, cmd, args, envs); errstart != nil {
+	if c.StdPipe, c.Socket, errstart = grpcplugin.StartPlugin(ctx, pluginName, dir, cmd, args, envs, binary.Sandbox); errstart != nil {
 		return nil, sdk.WrapError(errstart, "plugin:%s unable to start GRPC plugin... Aborting", pluginName)
 	}
 	return &c, nil
 }
 
+// isSandboxEnv returns true for the variables of the worker environment given to a sandboxed plugin.
+func isSandboxEnv(env string) bool {
+	for _, prefix := range []string{"PATH=", "HOME_CDS_PLUGINS=", "TMPDIR=", "XDG_CACHE_HOME="} {
+		if strings.HasPrefix(env, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPluginBinarySum returns true if the sha512sum of the binary file matches the one of the plugin binary,
+// or if the plugin binary has no checksum.
+func checkPluginBinarySum(fs afero.Fs, binary *sdk.GRPCPluginBinary) bool {
+	if binary.SHA512sum == "" {
+		return true
+	}
+	f, err := fs.Open(binary.Name)
+	if err != nil {
+		return false
+	}
+	defer f.Close() // nolint
+	h := sha512.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false
+	}
+	return hex.EncodeToString(h.Sum(nil)) == binary.SHA512sum
+}
+
 func pluginFail(ctx context.Context, w workerruntime.Runtime, chanRes chan<- sdk.Result, reason string) {
 	res := sdk.Result{
 		Reason: reason,
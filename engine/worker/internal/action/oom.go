@@ -0,0 +1,94 @@
+package action
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// cgroupRoot is the mount point of the cgroup filesystem of the worker's container
+var cgroupRoot = "/sys/fs/cgroup"
+
+// exit code of a process killed by SIGKILL through a shell (128 + 9)
+const sigKillExitCode = 137
+
+type cgroupMemoryStats struct {
+	limit     int64
+	peak      int64
+	oomKilled bool
+}
+
+// outOfMemoryReason checks if a command was killed by the kernel OOM killer by looking at its exit status
+// and at the memory cgroup of the worker. It returns an actionable message if it's the case.
+func outOfMemoryReason(err error) (string, bool) {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ProcessState == nil {
+		return "", false
+	}
+	if exitErr.ProcessState.ExitCode() != sigKillExitCode && !strings.Contains(exitErr.Error(), "signal: killed") {
+		return "", false
+	}
+	stats, ok := readCgroupMemoryStats()
+	if !ok || !stats.oomKilled {
+		return "", false
+	}
+	return sdk.WorkerOutOfMemoryMessage(stats.limit, stats.peak), true
+}
+
+// readCgroupMemoryStats supports both cgroup v2 (unified hierarchy) and cgroup v1 memory controller.
+func readCgroupMemoryStats() (cgroupMemoryStats, bool) {
+	var stats cgroupMemoryStats
+	if events, err := ioutil.ReadFile(filepath.Join(cgroupRoot, "memory.events")); err == nil {
+		stats.oomKilled = readCgroupKeyValue(events, "oom_kill") > 0
+		stats.limit = readCgroupInt(filepath.Join(cgroupRoot, "memory.max"))
+		stats.peak = readCgroupInt(filepath.Join(cgroupRoot, "memory.peak"))
+		return stats, true
+	}
+
+	v1 := filepath.Join(cgroupRoot, "memory")
+	oomControl, err := ioutil.ReadFile(filepath.Join(v1, "memory.oom_control"))
+	if err != nil {
+		return stats, false
+	}
+	stats.limit = readCgroupInt(filepath.Join(v1, "memory.limit_in_bytes"))
+	stats.peak = readCgroupInt(filepath.Join(v1, "memory.max_usage_in_bytes"))
+	stats.oomKilled = readCgroupKeyValue(oomControl, "oom_kill") > 0 ||
+		(stats.limit > 0 && stats.peak >= stats.limit)
+	return stats, true
+}
+
+// readCgroupInt returns the value of a single value cgroup file, "max" or an unknown value returns 0
+func readCgroupInt(path string) int64 {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	v, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	// cgroup v1 reports a huge value when there is no limit
+	if v >= 1<<62 {
+		return 0
+	}
+	return v
+}
+
+// readCgroupKeyValue returns the value for given key in a flat keyed cgroup file like memory.events
+func readCgroupKeyValue(content []byte, key string) int64 {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == key {
+			v, _ := strconv.ParseInt(fields[1], 10, 64)
+			return v
+		}
+	}
+	return 0
+}
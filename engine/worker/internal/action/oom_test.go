@@ -0,0 +1,40 @@
+package action
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_outOfMemoryReason(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.SkipNow()
+	}
+	dir, err := ioutil.TempDir("", "cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+	cgroupRoot = dir
+	defer func() { cgroupRoot = "/sys/fs/cgroup" }()
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "memory.events"), []byte("low 0\nhigh 0\nmax 12\noom 1\noom_kill 1\n"), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "memory.max"), []byte("2147483648\n"), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "memory.peak"), []byte("2577000000\n"), os.ModePerm))
+
+	errKilled := exec.Command("sh", "-c", "exit 137").Run()
+	reason, ok := outOfMemoryReason(errKilled)
+	require.True(t, ok)
+	assert.Equal(t, "job killed: out of memory (limit 2Gi, peak 2.4Gi), increase the \"memory\" requirement of the job", reason)
+
+	_, ok = outOfMemoryReason(exec.Command("sh", "-c", "exit 1").Run())
+	assert.False(t, ok)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "memory.events"), []byte("oom 0\noom_kill 0\n"), os.ModePerm))
+	_, ok = outOfMemoryReason(errKilled)
+	assert.False(t, ok)
+}
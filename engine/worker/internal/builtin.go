@@ -25,6 +25,13 @@ func init() {
 	mapBuiltinActions[sdk.CoverageAction] = action.RunParseCoverageResultAction
 	mapBuiltinActions[sdk.ServeStaticFiles] = action.RunServeStaticFiles
 	mapBuiltinActions[sdk.InstallKeyAction] = action.RunInstallKey
+	mapBuiltinActions[sdk.DockerBuildAction] = action.RunDockerBuild
+	mapBuiltinActions[sdk.DeployKubernetesAction] = action.RunDeployKubernetes
+	mapBuiltinActions[sdk.HelmPushAction] = action.RunHelmPush
+	mapBuiltinActions[sdk.SBOMAction] = action.RunSBOM
+	mapBuiltinActions[sdk.CoverageCheckAction] = action.RunCoverageCheck
+	mapBuiltinActions[sdk.ServiceNowAction] = action.RunServiceNowChangeRequest
+	mapBuiltinActions[sdk.JiraReleaseAction] = action.RunJiraRelease
 }
 
 func (w *CurrentWorker) runBuiltin(ctx context.Context, a sdk.Action, secrets []sdk.Variable) sdk.Result {
@@ -51,10 +58,25 @@ func (w *CurrentWorker) runBuiltin(ctx context.Context, a sdk.Action, secrets []
 }
 
 func (w *CurrentWorker) runGRPCPlugin(ctx context.Context, a sdk.Action) sdk.Result {
+	// the job can require a version of the plugin from the plugin registry
+	var binary *sdk.GRPCPluginBinary
+	if version := w.pluginVersion(a.Name); version != "" {
+		var err error
+		binary, err = w.pluginRegistryBinary(a.Name, version)
+		if err != nil {
+			res := sdk.Result{
+				Status: sdk.StatusFail,
+				Reason: fmt.Sprintf("unable to get version %s of plugin %s: %v", version, a.Name, err),
+			}
+			w.SendLog(ctx, workerruntime.LevelError, res.Reason)
+			return res
+		}
+	}
+
 	chanRes := make(chan sdk.Result, 1)
 	done := make(chan struct{})
 	sdk.GoRoutine(ctx, "runGRPCPlugin", func(ctx context.Context) {
-		action.RunGRPCPlugin(ctx, a.Name, w.currentJob.params, a, w, chanRes, done)
+		action.RunGRPCPlugin(ctx, a.Name, binary, w.currentJob.params, a, w, chanRes, done)
 	})
 
 	select {
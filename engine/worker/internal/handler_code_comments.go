@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func codeCommentsHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		var comments []sdk.WorkflowNodeRunCodeComment
+		if err := json.Unmarshal(data, &comments); err != nil {
+			writeError(w, r, sdk.NewErrorWithStack(err, sdk.ErrWrongRequest))
+			return
+		}
+
+		jobID, err := workerruntime.JobID(wk.currentJob.context)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		if err := wk.Client().QueueSendCodeComments(wk.currentJob.context, jobID, comments); err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
-	"path"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"sync"
@@ -41,6 +41,13 @@ func downloadHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			reqArgs.Workflow = currentWorkflow
 		}
 
+		if reqArgs.Promoted {
+			if err := downloadPromotedArtifacts(wk, currentProject, reqArgs); err != nil {
+				writeError(w, r, err)
+			}
+			return
+		}
+
 		// If the reqArgs.Number is empty and if the reqArgs.Workflow is the current workflow, take the current build number
 		if reqArgs.Number == 0 {
 			if reqArgs.Workflow == currentWorkflow {
@@ -110,7 +117,7 @@ func downloadHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			go func(a *sdk.WorkflowNodeRunArtifact) {
 				defer wg.Done()
 
-				path := path.Join(reqArgs.Destination, a.Name)
+				path := filepath.Join(reqArgs.Destination, a.Name)
 				f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(a.Perm))
 				if err != nil {
 					//wk.SendLog(ctx,workerruntime.LevelError, fmt.Sprintf("Cannot download artifact (OpenFile) %s: %s", a.Name, err))
@@ -146,3 +153,45 @@ func downloadHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 		}
 	}
 }
+
+// downloadPromotedArtifacts downloads the last artifacts promoted to a workflow, for each artifact name
+func downloadPromotedArtifacts(wk *CurrentWorker, projectKey string, reqArgs workerruntime.DownloadArtifact) error {
+	regexp, err := regexp.Compile(reqArgs.Pattern)
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pattern %s: %v", reqArgs.Pattern, err)
+	}
+
+	promotions, err := wk.client.WorkflowArtifactPromotions(projectKey, reqArgs.Workflow)
+	if err != nil {
+		return err
+	}
+
+	lasts := make(map[string]sdk.WorkflowArtifactPromotion)
+	for _, p := range promotions {
+		if reqArgs.Pattern != "" && !regexp.MatchString(p.Name) {
+			continue
+		}
+		if reqArgs.Tag != "" && p.Tag != reqArgs.Tag {
+			continue
+		}
+		if last, has := lasts[p.Name]; !has || p.Created.After(last.Created) {
+			lasts[p.Name] = p
+		}
+	}
+
+	for _, p := range lasts {
+		path := filepath.Join(reqArgs.Destination, p.Name)
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(p.Perm))
+		if err != nil {
+			return sdk.WrapError(err, "cannot download promoted artifact %s", p.Name)
+		}
+		if err := wk.client.WorkflowArtifactPromotionDownload(projectKey, reqArgs.Workflow, p, f); err != nil {
+			f.Close() // nolint
+			return sdk.WrapError(err, "cannot download promoted artifact %s", p.Name)
+		}
+		if err := f.Close(); err != nil {
+			return sdk.WrapError(err, "cannot download promoted artifact %s", p.Name)
+		}
+	}
+	return nil
+}
@@ -26,7 +26,17 @@ func addBuildVarHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-		v.Name = "cds.build." + v.Name
+
+		if r.FormValue("output") == "true" {
+			if !sdk.NodeOutputKeyRegex.MatchString(v.Name) {
+				log.Error(ctx, "addBuildVarHandler> Invalid output name %q", v.Name)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			v.Name = sdk.NodeRunOutputPrefix + v.Name
+		} else {
+			v.Name = "cds.build." + v.Name
+		}
 
 		wk.currentJob.newVariables = append(wk.currentJob.newVariables, v)
 		log.Debug("Variable %s added to %+v", v.Name, wk.currentJob.newVariables)
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func promoteHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		var req sdk.WorkflowArtifactPromotionRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			writeError(w, r, sdk.NewErrorWithStack(err, sdk.ErrWrongRequest))
+			return
+		}
+
+		projectKey := sdk.ParameterValue(wk.currentJob.params, "cds.project")
+		workflowName := sdk.ParameterValue(wk.currentJob.params, "cds.workflow")
+		number, err := strconv.ParseInt(sdk.ParameterValue(wk.currentJob.params, "cds.run.number"), 10, 64)
+		if err != nil {
+			writeError(w, r, sdk.NewErrorWithStack(err, sdk.ErrWrongRequest))
+			return
+		}
+		if req.ProjectKey == "" {
+			req.ProjectKey = projectKey
+		}
+
+		promotions, err := wk.client.WorkflowRunArtifactsPromote(projectKey, workflowName, number, req)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		jobCtx := wk.currentJob.context
+		for _, p := range promotions {
+			wk.SendLog(jobCtx, workerruntime.LevelInfo, fmt.Sprintf("artifact %s promoted to workflow %s/%s with tag %s (%s)", p.Name, req.ProjectKey, req.WorkflowName, p.Tag, p.Mode))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	"github.com/ovh/cds/engine/worker/internal/action"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func testReportHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		var report workerruntime.TestReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			writeError(w, r, sdk.NewErrorWithStack(err, sdk.ErrWrongRequest))
+			return
+		}
+
+		jobCtx := wk.currentJob.context
+		jobID, err := workerruntime.JobID(jobCtx)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		if len(report.JUnit) > 0 {
+			var files []string
+			for _, p := range report.JUnit {
+				matches, err := filepath.Glob(p)
+				if err != nil {
+					writeError(w, r, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pattern %s", p))
+					return
+				}
+				files = append(files, matches...)
+			}
+			wk.SendLog(jobCtx, workerruntime.LevelInfo, fmt.Sprintf("%d JUnit file(s) to analyze", len(files)))
+
+			tests, err := action.ParseJUnitFiles(files)
+			if err != nil {
+				writeError(w, r, sdk.NewErrorWithStack(err, sdk.ErrWrongRequest))
+				return
+			}
+			var res sdk.Result
+			for _, reason := range action.ComputeStats(&res, &tests) {
+				wk.SendLog(jobCtx, workerruntime.LevelInfo, reason)
+			}
+			if err := wk.Blur(&tests); err != nil {
+				writeError(w, r, err)
+				return
+			}
+			if err := wk.Client().QueueSendUnitTests(jobCtx, jobID, tests); err != nil {
+				writeError(w, r, err)
+				return
+			}
+		}
+
+		if report.Coverage != "" {
+			cov, err := action.ParseCoverageReport(report.Coverage, report.CoverageFormat)
+			if err != nil {
+				writeError(w, r, sdk.NewErrorWithStack(err, sdk.ErrWrongRequest))
+				return
+			}
+			if p, ok := sdk.CoveragePercent(cov); ok {
+				wk.SendLog(jobCtx, workerruntime.LevelInfo, fmt.Sprintf("Coverage: %.2f%%", p))
+			}
+			if err := wk.Client().QueueSendCoverage(jobCtx, jobID, cov); err != nil {
+				writeError(w, r, err)
+				return
+			}
+		}
+	}
+}
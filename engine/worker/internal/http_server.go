@@ -51,11 +51,14 @@ func (w *CurrentWorker) Serve(c context.Context) error {
 	r.HandleFunc("/cache/{ref}/push", LogMiddleware(cachePushHandler(c, w)))
 	r.HandleFunc("/download", LogMiddleware(downloadHandler(c, w)))
 	r.HandleFunc("/exit", LogMiddleware(exitHandler(c, w)))
+	r.HandleFunc("/promote", LogMiddleware(promoteHandler(c, w)))
 	r.HandleFunc("/key/{key}/install", LogMiddleware(keyInstallHandler(c, w)))
 	r.HandleFunc("/tag", LogMiddleware(tagHandler(c, w)))
 	r.HandleFunc("/tmpl", LogMiddleware(tmplHandler(c, w)))
 	r.HandleFunc("/upload", LogMiddleware(uploadHandler(c, w)))
 	r.HandleFunc("/checksecret", LogMiddleware(checkSecretHandler(c, w)))
+	r.HandleFunc("/code-comments", LogMiddleware(codeCommentsHandler(c, w)))
+	r.HandleFunc("/test-report", LogMiddleware(testReportHandler(c, w)))
 	r.HandleFunc("/var", LogMiddleware(addBuildVarHandler(c, w)))
 	r.HandleFunc("/vulnerability", LogMiddleware(vulnerabilityHandler(c, w)))
 
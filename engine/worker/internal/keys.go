@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
 
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
@@ -25,7 +24,7 @@ func (wk *CurrentWorker) InstallKey(key sdk.Variable) (*workerruntime.KeyRespons
 			return nil, sdk.WithStack(err)
 		}
 
-		installedKeyPath := path.Join(keysDirectory.Name(), key.Name)
+		installedKeyPath := filepath.Join(keysDirectory.Name(), key.Name)
 		if err := vcs.CleanAllSSHKeys(wk.basedir, keysDirectory.Name()); err != nil {
 			errClean := sdk.Error{
 				Message: fmt.Sprintf("Cannot clean ssh keys : %v", err),
@@ -138,7 +137,7 @@ func (wk *CurrentWorker) InstallKeyTo(key sdk.Variable, destinationPath string)
 		var absPath string
 		if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
 			absPath, _ = x.RealPath(destinationPath)
-			absPath, _ = filepath.Abs(path.Dir(absPath))
+			absPath, _ = filepath.Abs(filepath.Dir(absPath))
 		}
 
 		if !sdk.PathIsAbs(destinationPath) {
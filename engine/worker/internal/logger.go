@@ -3,12 +3,20 @@ package internal
 import (
 	"container/list"
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/logbuffer"
 )
 
+// logBufferMaxSize is the max size of the logs kept on disk while the API is unreachable
+const logBufferMaxSize = 50 * 1024 * 1024
+
 func (wk *CurrentWorker) sendLog(buildID int64, value string, stepOrder int, final bool) error {
 	if wk.currentJob.wJob == nil {
 		log.Error(wk.GetContext(), "unable to send log: %s", value)
@@ -33,6 +41,16 @@ func (wk *CurrentWorker) logProcessor(ctx context.Context, jobID int64) error {
 	}()
 
 	wk.logger.llist = list.New()
+	wk.logger.droppedLines = make(map[int64]int)
+	wk.logger.buffer = nil
+	if wk.basedir != nil {
+		buffer, err := logbuffer.New(wk.basedir, fmt.Sprintf(".logs-buffer-%d", jobID), logBufferMaxSize)
+		if err != nil {
+			log.Error(ctx, "unable to create logs buffer, logs will be lost if the API is unreachable: %v", err)
+		}
+		wk.logger.buffer = buffer
+	}
+
 	for {
 		select {
 		case l := <-wk.logger.logChan:
@@ -75,26 +93,93 @@ func (wk *CurrentWorker) sendHTTPLog(ctx context.Context, jobID int64) {
 	}
 
 	if len(logs) == 0 {
+		wk.flushLogBuffer(ctx, jobID)
 		return
 	}
 
 	for _, l := range logs {
 		log.Debug("LOG: %v", l.Val)
-		// TODO: stop the worker a nice way,
-		// for the moment we are using context.Background and not the job context
+		// Logs are sent in order, so the new logs are buffered while the buffer is not empty
+		if wk.logger.buffer == nil || wk.logger.buffer.Len() == 0 {
+			// TODO: stop the worker a nice way,
+			// for the moment we are using context.Background and not the job context
+			err := wk.Client().QueueSendLogs(context.Background(), jobID, *l)
+			if err == nil {
+				continue
+			}
+			log.Error(ctx, "error: cannot send logs: %s", err)
+			if wk.logger.buffer == nil {
+				continue
+			}
+		}
+		wk.bufferLog(ctx, *l)
+	}
+
+	wk.flushLogBuffer(ctx, jobID)
+}
+
+// bufferLog keeps a log on disk to send it later, if the buffer is full the log is dropped
+func (wk *CurrentWorker) bufferLog(ctx context.Context, l sdk.Log) {
+	entry, err := json.Marshal(l)
+	if err != nil {
+		log.Error(ctx, "error: cannot marshal logs: %v", err)
+		return
+	}
+	pushed, err := wk.logger.buffer.Push(entry)
+	if err != nil {
+		log.Error(ctx, "error: cannot buffer logs: %v", err)
+	}
+	if !pushed {
+		wk.logger.droppedLines[l.StepOrder] += countLines(l.Val)
+	}
+}
+
+// flushLogBuffer sends the buffered logs when the retry delay is over. Once all the logs are sent,
+// a warning is added to the steps which lost logs.
+func (wk *CurrentWorker) flushLogBuffer(ctx context.Context, jobID int64) {
+	if wk.logger.buffer == nil || !wk.logger.buffer.Ready(time.Now()) {
+		return
+	}
+
+	if err := wk.logger.buffer.Flush(func(entry []byte) error {
+		var l sdk.Log
+		if err := json.Unmarshal(entry, &l); err != nil {
+			log.Error(ctx, "error: cannot unmarshal buffered logs: %v", err)
+			return nil
+		}
+		return wk.Client().QueueSendLogs(context.Background(), jobID, l)
+	}); err != nil {
+		log.Warning(ctx, "cannot send buffered logs, %d entries are still buffered: %v", wk.logger.buffer.Len(), err)
+		return
+	}
+
+	for stepOrder, n := range wk.logger.droppedLines {
+		l := sdk.NewLog(jobID, wk.currentJob.wJob.WorkflowNodeRunID, fmt.Sprintf("[%s] %d log lines were dropped because the CDS API was unreachable\n", workerruntime.LevelWarn, n), int(stepOrder))
 		if err := wk.Client().QueueSendLogs(context.Background(), jobID, *l); err != nil {
 			log.Error(ctx, "error: cannot send logs: %s", err)
-			continue
+			return
 		}
+		delete(wk.logger.droppedLines, stepOrder)
+	}
+}
+
+func countLines(s string) int {
+	n := strings.Count(s, "\n")
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		n++
 	}
+	return n
 }
 
 func (wk *CurrentWorker) drainLogsAndCloseLogger(c context.Context) error {
 	var i int
-	for (len(wk.logger.logChan) > 0 || (wk.logger.llist != nil && wk.logger.llist.Len() > 0)) && i < 60 {
+	for (len(wk.logger.logChan) > 0 || (wk.logger.llist != nil && wk.logger.llist.Len() > 0) || (wk.logger.buffer != nil && wk.logger.buffer.Len() > 0)) && i < 60 {
 		log.Debug("Draining logs...")
 		i++
 		time.Sleep(1 * time.Second)
 	}
+	if wk.logger.buffer != nil && wk.logger.buffer.Len() > 0 {
+		log.Error(c, "unable to send %d buffered log entries, they are lost", wk.logger.buffer.Len())
+	}
 	return c.Err()
 }
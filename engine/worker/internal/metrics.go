@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/mem"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// stepMetricsInterval is the interval between two samples of the resources used by a step.
+var stepMetricsInterval = 5 * time.Second
+
+// resourcesCounters are the cumulative counters of the host, the samples are computed from their difference.
+type resourcesCounters struct {
+	cpuBusy    float64
+	cpuTotal   float64
+	readBytes  uint64
+	writeBytes uint64
+}
+
+func readResourcesCounters() (resourcesCounters, error) {
+	var c resourcesCounters
+	times, err := cpu.Times(false)
+	if err != nil {
+		return c, sdk.WrapError(err, "cannot read cpu times")
+	}
+	for _, t := range times {
+		c.cpuTotal += t.Total()
+		c.cpuBusy += t.Total() - t.Idle - t.Iowait
+	}
+	ios, err := disk.IOCounters()
+	if err != nil {
+		return c, sdk.WrapError(err, "cannot read disk counters")
+	}
+	for _, d := range ios {
+		c.readBytes += d.ReadBytes
+		c.writeBytes += d.WriteBytes
+	}
+	return c, nil
+}
+
+func (c resourcesCounters) sample(prev resourcesCounters) sdk.StepMetricsSample {
+	s := sdk.StepMetricsSample{Time: time.Now()}
+	if total := c.cpuTotal - prev.cpuTotal; total > 0 {
+		s.CPU = (c.cpuBusy - prev.cpuBusy) / total * 100
+	}
+	if c.readBytes >= prev.readBytes {
+		s.ReadBytes = c.readBytes - prev.readBytes
+	}
+	if c.writeBytes >= prev.writeBytes {
+		s.WriteBytes = c.writeBytes - prev.writeBytes
+	}
+	if v, err := mem.VirtualMemory(); err == nil {
+		s.Memory = v.Used
+	}
+	return s
+}
+
+// collectStepMetrics samples the resources used by the worker until the context is done, the timeline of the
+// step is then sent on the returned channel. Nothing is sent if the resources of the host can't be read.
+func collectStepMetrics(ctx context.Context, interval time.Duration) <-chan *sdk.StepMetrics {
+	res := make(chan *sdk.StepMetrics, 1)
+	go func() {
+		defer close(res)
+		prev, err := readResourcesCounters()
+		if err != nil {
+			log.Warning(ctx, "unable to collect step metrics: %v", err)
+			return
+		}
+		m := sdk.StepMetrics{Interval: int64(interval / time.Second)}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				// the last sample covers the end of the step
+				if c, err := readResourcesCounters(); err == nil {
+					m.Add(c.sample(prev))
+				}
+				res <- &m
+				return
+			case <-ticker.C:
+				c, err := readResourcesCounters()
+				if err != nil {
+					log.Warning(ctx, "unable to collect step metrics: %v", err)
+					continue
+				}
+				m.Add(c.sample(prev))
+				prev = c
+			}
+		}
+	}()
+	return res
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// pluginVersion returns the version of a plugin required by the current job, or an empty string if the job
+// uses the installed plugin.
+func (w *CurrentWorker) pluginVersion(name string) string {
+	if w.currentJob.wJob == nil {
+		return ""
+	}
+	for _, r := range w.currentJob.wJob.Job.Action.Requirements {
+		if r.Type != sdk.PluginRequirement {
+			continue
+		}
+		if n, version := sdk.ParsePluginRequirement(r.Value); n == name && version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
+// pluginRegistryBinary returns the binary of a version of a plugin published in the plugin registry.
+func (w *CurrentWorker) pluginRegistryBinary(name, version string) (*sdk.GRPCPluginBinary, error) {
+	v, err := w.client.PluginRegistryGet(name, version)
+	if err != nil {
+		return nil, err
+	}
+	currentOS := strings.ToLower(sdk.GOOS)
+	currentARCH := strings.ToLower(sdk.GOARCH)
+	b := v.GetBinary(currentOS, currentARCH)
+	if b == nil {
+		return nil, fmt.Errorf("version %s of plugin %s is not available for %s/%s", version, name, currentOS, currentARCH)
+	}
+	return b, nil
+}
+
+// pinnedPluginRequirements returns the plugins required with a version in a list of requirements.
+func pinnedPluginRequirements(requirements []sdk.Requirement) map[string]struct{} {
+	pinned := make(map[string]struct{})
+	for _, r := range requirements {
+		if r.Type != sdk.PluginRequirement {
+			continue
+		}
+		if name, version := sdk.ParsePluginRequirement(r.Value); version != "" {
+			pinned[name] = struct{}{}
+		}
+	}
+	return pinned
+}
@@ -24,6 +24,7 @@ func (w *CurrentWorker) Register(ctx context.Context) error {
 	form.Version = sdk.VERSION
 	form.OS = sdk.GOOS
 	form.Arch = sdk.GOARCH
+	form.SessionRotation = true
 
 	worker, uptodate, err := w.client.WorkerRegister(context.Background(), w.register.token, form)
 	if err != nil {
@@ -75,6 +76,10 @@ func LoopPath(w *CurrentWorker, reqs []sdk.Requirement) []string {
 	binaries := []string{}
 	for _, req := range reqs {
 		if req.Type == sdk.BinaryRequirement {
+			// tools with a version are installed from the tool registry when a job needs them
+			if _, version := sdk.ParseBinaryRequirement(req.Value); version != "" {
+				continue
+			}
 			if b, _ := checkBinaryRequirement(w, req); b {
 				binaries = append(binaries, req.Value)
 			}
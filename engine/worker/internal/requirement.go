@@ -6,6 +6,8 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -26,6 +28,7 @@ var requirementCheckFuncs = map[string]func(w *CurrentWorker, r sdk.Requirement)
 	sdk.MemoryRequirement:        checkMemoryRequirement,
 	sdk.VolumeRequirement:        checkVolumeRequirement,
 	sdk.OSArchRequirement:        checkOSArchRequirement,
+	sdk.CPURequirement:           checkCPURequirement,
 }
 
 func checkRequirements(ctx context.Context, w *CurrentWorker, a *sdk.Action) (bool, []sdk.Requirement) {
@@ -33,7 +36,12 @@ func checkRequirements(ctx context.Context, w *CurrentWorker, a *sdk.Action) (bo
 	errRequirements := []sdk.Requirement{}
 
 	log.Debug("requirements for %s >>> %+v\n", a.Name, a.Requirements)
+	pinnedPlugins := pinnedPluginRequirements(a.Requirements)
 	for _, r := range a.Requirements {
+		// the installed plugin is not needed if the job requires a version from the plugin registry
+		if _, pinned := pinnedPlugins[r.Value]; pinned && r.Type == sdk.PluginRequirement {
+			continue
+		}
 		ok, err := checkRequirement(w, r)
 		if err != nil {
 			log.Warning(ctx, "checkQueue> error on checkRequirement %s", err)
@@ -58,6 +66,14 @@ func checkRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
 }
 
 func checkPluginRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
+	// a version of the plugin registry is downloaded when the plugin is started
+	if name, version := sdk.ParsePluginRequirement(r.Value); version != "" {
+		if _, err := w.pluginRegistryBinary(name, version); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
 	var currentOS = strings.ToLower(sdk.GOOS)
 	var currentARCH = strings.ToLower(sdk.GOARCH)
 
@@ -97,8 +113,12 @@ func checkHostnameRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error)
 	return h == r.Value, nil
 }
 
-// checkBinaryRequirement returns true is binary requirement is in worker's PATH
+// checkBinaryRequirement returns true is binary requirement is in worker's PATH, or if the tool required
+// with a version is installed from the tool registry
 func checkBinaryRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
+	if name, version := sdk.ParseBinaryRequirement(r.Value); version != "" {
+		return w.checkToolRequirement(name, version)
+	}
 	if _, err := exec.LookPath(r.Value); err != nil {
 		// Return nil because the error contains 'Executable file not found', that's what we wanted
 		return false, nil
@@ -147,6 +167,9 @@ func checkServiceRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error)
 		for _, ip := range ips {
 			s += s + ip.String() + " "
 		}
+		if port := servicePort(r); port != "" {
+			return waitForServicePort(r.Name, port), nil
+		}
 		log.Info(context.TODO(), "Service requirement %s is ready %s", r.Name, s)
 		return true, nil
 	}
@@ -154,6 +177,33 @@ func checkServiceRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error)
 	return false, nil
 }
 
+var serviceRequirementPortRegexp = regexp.MustCompile(`\sCDS_SERVICE_PORT=(\d+)`)
+
+// servicePort returns the port of a service requirement given with the option CDS_SERVICE_PORT, if any
+func servicePort(r sdk.Requirement) string {
+	if m := serviceRequirementPortRegexp.FindStringSubmatch(r.Value); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// waitForServicePort waits for a service to accept connections, services may take time to start
+func waitForServicePort(host, port string) bool {
+	address := net.JoinHostPort(host, port)
+	for attempt := 0; attempt < 30; attempt++ {
+		conn, err := net.DialTimeout("tcp", address, 2*time.Second)
+		if err != nil {
+			log.Debug("Service requirement %s is not ready: %s", address, err)
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		conn.Close() // nolint
+		log.Info(context.TODO(), "Service requirement %s is ready", address)
+		return true
+	}
+	return false
+}
+
 func checkMemoryRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
 	var totalMemory int64
 	neededMemory, err := strconv.ParseInt(r.Value, 10, 64)
@@ -183,6 +233,14 @@ func checkMemoryRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
 	return totalMemory >= (neededMemory*1024*1024)*90/100, nil
 }
 
+func checkCPURequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
+	neededCPUs, err := strconv.ParseFloat(r.Value, 64)
+	if err != nil {
+		return false, err
+	}
+	return float64(runtime.NumCPU()) >= neededCPUs, nil
+}
+
 func checkVolumeRequirement(w *CurrentWorker, r sdk.Requirement) (bool, error) {
 	// volume are supported only for Model Docker
 	if w.model.Type != sdk.Docker {
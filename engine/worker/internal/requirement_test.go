@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"net"
 	"os"
 	"testing"
 
@@ -89,3 +90,30 @@ func TestNetworkAccessRequirement(t *testing.T) {
 		t.Fatalf("Requirement should not be ok")
 	}
 }
+
+func TestCheckServiceRequirementPort(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	defer l.Close() // nolint
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+
+	w := &CurrentWorker{model: sdk.Model{Type: sdk.Docker}}
+	r := sdk.Requirement{
+		Name:  "localhost",
+		Type:  sdk.ServiceRequirement,
+		Value: "postgres:9.6 POSTGRES_USER=cds CDS_SERVICE_PORT=" + port,
+	}
+	if p := servicePort(r); p != port {
+		t.Fatalf("service port should be %s, got %s", port, p)
+	}
+
+	ok, err := checkRequirement(w, r)
+	if err != nil {
+		t.Fatalf("checkRequirement should not fail: %s", err)
+	}
+	if !ok {
+		t.Fatalf("Requirement should be ok")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"os"
 	"os/user"
-	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -111,7 +110,7 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 	var nDisabled, nCriticalFailed int
 	for jobStepIndex, step := range a.Actions {
 		ctx = workerruntime.SetStepOrder(ctx, jobStepIndex)
-		if err := w.updateStepStatus(ctx, jobID, jobStepIndex, sdk.StatusBuilding); err != nil {
+		if err := w.updateStepStatus(ctx, jobID, jobStepIndex, sdk.StatusBuilding, nil); err != nil {
 			jobResult.Status = sdk.StatusFail
 			jobResult.Reason = fmt.Sprintf("Cannot update step (%d) status (%s): %v", jobStepIndex, sdk.StatusBuilding, err)
 			return jobResult, err
@@ -120,8 +119,13 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 			Status:  sdk.StatusNeverBuilt,
 			BuildID: jobID,
 		}
+		var stepMetrics *sdk.StepMetrics
 		if nCriticalFailed == 0 || step.AlwaysExecuted {
+			ctxMetrics, stopMetrics := context.WithCancel(ctx)
+			metrics := collectStepMetrics(ctxMetrics, stepMetricsInterval)
 			stepResult = w.runAction(ctx, step, jobID, secrets, step.Name)
+			stopMetrics()
+			stepMetrics = <-metrics
 
 			// Check if all newVariables are in currentJob.params
 			// variable can be add in w.currentJob.newVariables by worker command export
@@ -150,7 +154,7 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 				}
 			}
 		}
-		if err := w.updateStepStatus(ctx, jobID, jobStepIndex, stepResult.Status); err != nil {
+		if err := w.updateStepStatus(ctx, jobID, jobStepIndex, stepResult.Status, stepMetrics); err != nil {
 			jobResult.Status = sdk.StatusFail
 			jobResult.Reason = fmt.Sprintf("Cannot update step (%d) status (%s): %v", jobStepIndex, sdk.StatusBuilding, err)
 			return jobResult, err
@@ -296,12 +300,13 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 	return r, nbDisabledChildren
 }
 
-func (w *CurrentWorker) updateStepStatus(ctx context.Context, buildID int64, stepOrder int, status string) error {
+func (w *CurrentWorker) updateStepStatus(ctx context.Context, buildID int64, stepOrder int, status string, metrics *sdk.StepMetrics) error {
 	step := sdk.StepStatus{
 		StepOrder: stepOrder,
 		Status:    status,
 		Start:     time.Now(),
 		Done:      time.Now(),
+		Metrics:   metrics,
 	}
 
 	for try := 1; try <= 10; try++ {
@@ -362,7 +367,7 @@ func teardownDirectory(fs afero.Fs, dir string) error {
 func workingDirectory(ctx context.Context, fs afero.Fs, jobInfo sdk.WorkflowNodeJobRunData, suffixes ...string) (string, error) {
 	var encodedName = base64.RawStdEncoding.EncodeToString([]byte(jobInfo.NodeJobRun.Job.Job.Action.Name))
 	paths := append([]string{encodedName}, suffixes...)
-	dir := path.Join(paths...)
+	dir := filepath.Join(paths...)
 
 	if _, err := fs.Stat(dir); os.IsExist(err) {
 		log.Info(ctx, "cleaning working directory %s", dir)
@@ -513,6 +518,17 @@ func (w *CurrentWorker) ProcessJob(jobInfo sdk.WorkflowNodeJobRunData) (sdk.Resu
 		Value: jobInfo.NodeJobRun.Job.WorkerName,
 	})
 
+	// add the hostname of each service on parameters available
+	for _, r := range jobInfo.NodeJobRun.Job.Action.Requirements {
+		if r.Type == sdk.ServiceRequirement {
+			jobParameters = append(jobParameters, sdk.Parameter{
+				Name:  "cds.service." + r.Name + ".hostname",
+				Type:  sdk.StringParameter,
+				Value: r.Name,
+			})
+		}
+	}
+
 	// REPLACE ALL VARIABLE EVEN SECRETS HERE
 	processJobParameter(jobParameters, jobInfo.Secrets)
 	if err := w.processActionVariables(&jobInfo.NodeJobRun.Job.Action, nil, jobParameters, jobInfo.Secrets); err != nil {
@@ -10,6 +10,19 @@ import (
 	"github.com/ovh/cds/sdk/log"
 )
 
+const (
+	// sessionRotationInterval is the interval between two worker session rotations.
+	sessionRotationInterval = 15 * time.Minute
+	// sessionRotationTimeout is the time during which a failed rotation is retried, the interval and
+	// the timeout should be lower than the session duration given by the API.
+	sessionRotationTimeout = 30 * time.Minute
+	// sessionRotationMaxDelay is the max delay between two retries of a failed rotation.
+	sessionRotationMaxDelay = 2 * time.Minute
+)
+
+// sessionRotationMinDelay is the delay before the first retry of a failed rotation.
+var sessionRotationMinDelay = 5 * time.Second
+
 func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (mainError error) {
 	log.Info(ctx, "Starting worker %s", w.Name())
 	ctx, cancel := context.WithCancel(ctx)
@@ -28,6 +41,9 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 	//Register every 10 seconds
 	refreshTick := time.NewTicker(30 * time.Second)
 
+	// Worker sessions are short-lived, they must be rotated before they expire
+	rotateTick := time.NewTicker(sessionRotationInterval)
+
 	// start queue polling
 	jobsChan := make(chan sdk.WorkflowNodeJobRun, 50)
 	errsChan := make(chan error, 1)
@@ -40,6 +56,7 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 			mainError = err
 		}
 		refreshTick.Stop()
+		rotateTick.Stop()
 		cancel()
 		stopHTTPServer()
 
@@ -107,6 +124,28 @@ func StartWorker(ctx context.Context, w *CurrentWorker, bookedJobID int64) (main
 		}
 	}()
 
+	// Session rotation loop
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-rotateTick.C:
+				session, err := rotateSession(ctx, w, sessionRotationTimeout)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					// The session will expire, the worker can't go on
+					log.Error(ctx, "Session rotation failed, stopping worker: %v", err)
+					endFunc()
+					return
+				}
+				log.Debug("Worker session rotated, new session expires at %v", session.ExpireAt)
+			}
+		}
+	}()
+
 	// main loop
 	for {
 		if ctx.Err() != nil {
@@ -203,3 +242,31 @@ func processBookedWJob(ctx context.Context, w *CurrentWorker, wjobs chan<- sdk.W
 
 	return nil
 }
+
+// rotateSession rotates the session of the worker. A failed rotation is retried with an exponential backoff
+// until the timeout, except if the API refuses to rotate the session.
+func rotateSession(ctx context.Context, w *CurrentWorker, timeout time.Duration) (*sdk.AuthSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	delay := sessionRotationMinDelay
+	for {
+		session, err := w.Client().WorkerRotateSession(ctx)
+		if err == nil {
+			return session, nil
+		}
+		if sdk.ErrorIs(err, sdk.ErrUnauthorized) || sdk.ErrorIs(err, sdk.ErrForbidden) {
+			return nil, sdk.WrapError(err, "worker session rotation refused")
+		}
+		log.Warning(ctx, "Session rotation failed, retrying in %v: %v", delay, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, sdk.WrapError(err, "unable to rotate worker session before %v", timeout)
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > sessionRotationMaxDelay {
+			delay = sessionRotationMaxDelay
+		}
+	}
+}
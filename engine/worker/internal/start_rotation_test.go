@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+)
+
+func TestRotateSession(t *testing.T) {
+	sessionRotationMinDelay = time.Millisecond
+	defer func() { sessionRotationMinDelay = 5 * time.Second }()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_cdsclient.NewMockWorkerInterface(ctrl)
+	w := &CurrentWorker{client: client}
+
+	// A failed rotation is retried
+	expireAt := time.Now().Add(time.Hour)
+	gomock.InOrder(
+		client.EXPECT().WorkerRotateSession(gomock.Any()).Return(nil, fmt.Errorf("HTTP 503")).Times(2),
+		client.EXPECT().WorkerRotateSession(gomock.Any()).Return(&sdk.AuthSession{ExpireAt: expireAt}, nil),
+	)
+	session, err := rotateSession(context.TODO(), w, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, expireAt, session.ExpireAt)
+
+	// A rotation refused by the API is not retried
+	client.EXPECT().WorkerRotateSession(gomock.Any()).Return(nil, sdk.WithStack(sdk.ErrForbidden))
+	_, err = rotateSession(context.TODO(), w, time.Minute)
+	require.Error(t, err)
+
+	// A rotation is retried until the timeout
+	client.EXPECT().WorkerRotateSession(gomock.Any()).Return(nil, fmt.Errorf("HTTP 503")).MinTimes(2)
+	_, err = rotateSession(context.TODO(), w, 50*time.Millisecond)
+	require.Error(t, err)
+}
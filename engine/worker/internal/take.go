@@ -8,9 +8,13 @@ import (
 	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/tracingutils"
 )
 
 func (w *CurrentWorker) Take(ctx context.Context, job sdk.WorkflowNodeJobRun) error {
+	// The calls to the api are part of the trace of the workflow run
+	ctx = tracingutils.DefaultFormat.Extract(ctx, tracingutils.MapCarrier(job.Header))
+
 	ctxQueueTakeJob, cancelQueueTakeJob := context.WithTimeout(ctx, 20*time.Second)
 	defer cancelQueueTakeJob()
 	info, err := w.client.QueueTakeJob(ctxQueueTakeJob, job)
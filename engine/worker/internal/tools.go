@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var toolHTTPClient = &http.Client{Timeout: 10 * time.Minute}
+
+// SetToolRegistry sets the registry from which the tools required by the jobs are downloaded, and the directory
+// where the downloaded tools are kept between the jobs.
+func (wk *CurrentWorker) SetToolRegistry(registry, cacheDir string) {
+	wk.tools.registry = registry
+	wk.tools.cacheDir = cacheDir
+}
+
+// toolPath returns the path of a tool in the cache of the worker.
+func (wk *CurrentWorker) toolPath(name, version string) string {
+	return filepath.Join(wk.tools.cacheDir, name, version, name)
+}
+
+// checkToolRequirement returns true if the tool is in the cache of the worker, or once it has been downloaded
+// from the tool registry.
+func (wk *CurrentWorker) checkToolRequirement(name, version string) (bool, error) {
+	if wk == nil || wk.tools.cacheDir == "" {
+		return false, nil
+	}
+	if _, err := os.Stat(wk.toolPath(name, version)); err == nil {
+		log.Debug("tool %s@%s is in cache", name, version)
+		return true, nil
+	}
+	if wk.tools.registry == "" {
+		return false, nil
+	}
+	if err := wk.installTool(context.Background(), name, version); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// installTool downloads the static binary of a tool from the tool registry. If the registry provides a
+// checksum file next to the binary ({url}.sha256), the binary is verified before being installed.
+func (wk *CurrentWorker) installTool(ctx context.Context, name, version string) error {
+	url := sdk.ToolURL(wk.tools.registry, name, version, strings.ToLower(sdk.GOOS), strings.ToLower(sdk.GOARCH))
+	log.Info(ctx, "installing tool %s@%s from %s", name, version, url)
+
+	dir := filepath.Dir(wk.toolPath(name, version))
+	if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	resp, err := toolHTTPClient.Get(url)
+	if err != nil {
+		return sdk.WrapError(err, "cannot download tool %s@%s", name, version)
+	}
+	defer resp.Body.Close() // nolint
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot download tool %s@%s: %s returns %s", name, version, url, resp.Status)
+	}
+
+	tmp, err := ioutil.TempFile(dir, name)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer os.Remove(tmp.Name()) // nolint
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		_ = tmp.Close()
+		return sdk.WrapError(err, "cannot download tool %s@%s", name, version)
+	}
+	if err := tmp.Close(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	expected, err := toolChecksum(url)
+	if err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); expected != "" && expected != sum {
+		return fmt.Errorf("invalid checksum for tool %s@%s: got %s, expected %s", name, version, sum, expected)
+	}
+
+	if err := os.Chmod(tmp.Name(), os.FileMode(0755)); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(os.Rename(tmp.Name(), wk.toolPath(name, version)))
+}
+
+// toolChecksum returns the sha256 checksum published next to a tool, or an empty string if there is none.
+func toolChecksum(url string) (string, error) {
+	resp, err := toolHTTPClient.Get(url + ".sha256")
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot download checksum of %s", url)
+	}
+	defer resp.Body.Close() // nolint
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cannot download checksum of %s: %s", url, resp.Status)
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	// the checksum file can be the output of sha256sum: "<sum>  <file>"
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// toolsPath returns the directories of the tools required by a job, to be added to the PATH of the steps.
+func (wk *CurrentWorker) toolsPath(requirements []sdk.Requirement) []string {
+	var dirs []string
+	for _, r := range requirements {
+		if r.Type != sdk.BinaryRequirement {
+			continue
+		}
+		name, version := sdk.ParseBinaryRequirement(r.Value)
+		if version == "" || wk.tools.cacheDir == "" {
+			continue
+		}
+		dirs = append(dirs, filepath.Dir(wk.toolPath(name, version)))
+	}
+	return dirs
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestCheckToolRequirement(t *testing.T) {
+	content := []byte("#!/bin/sh\necho lint\n")
+	sum := sha256.Sum256(content)
+	goos, goarch := strings.ToLower(sdk.GOOS), strings.ToLower(sdk.GOARCH)
+
+	var downloads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/lint/1.0/" + goos + "/" + goarch + "/lint":
+			downloads++
+			_, _ = w.Write(content)
+		case "/lint/1.0/" + goos + "/" + goarch + "/lint.sha256":
+			_, _ = w.Write([]byte(hex.EncodeToString(sum[:]) + "  lint\n"))
+		case "/lint/2.0/" + goos + "/" + goarch + "/lint":
+			_, _ = w.Write(content)
+		case "/lint/2.0/" + goos + "/" + goarch + "/lint.sha256":
+			_, _ = w.Write([]byte("0000"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "tools")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	w := new(CurrentWorker)
+	w.SetToolRegistry(srv.URL, dir)
+
+	r := sdk.Requirement{Name: "lint@1.0", Type: sdk.BinaryRequirement, Value: "lint@1.0"}
+	ok, err := checkRequirement(w, r)
+	require.NoError(t, err)
+	require.True(t, ok)
+	b, err := ioutil.ReadFile(filepath.Join(dir, "lint", "1.0", "lint"))
+	require.NoError(t, err)
+	require.Equal(t, content, b)
+
+	// the tool is in cache
+	ok, err = checkRequirement(w, r)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, downloads)
+	require.Equal(t, []string{filepath.Join(dir, "lint", "1.0")}, w.toolsPath([]sdk.Requirement{r}))
+
+	// invalid checksum
+	ok, err = checkRequirement(w, sdk.Requirement{Name: "lint@2.0", Type: sdk.BinaryRequirement, Value: "lint@2.0"})
+	require.Error(t, err)
+	require.False(t, ok)
+
+	// unknown tool
+	ok, err = checkRequirement(w, sdk.Requirement{Name: "other@1.0", Type: sdk.BinaryRequirement, Value: "other@1.0"})
+	require.Error(t, err)
+	require.False(t, ok)
+
+	// no tool registry
+	w.SetToolRegistry("", dir)
+	ok, err = checkRequirement(w, sdk.Requirement{Name: "other@1.0", Type: sdk.BinaryRequirement, Value: "other@1.0"})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/logbuffer"
 )
 
 // WorkerServerPort is name of environment variable set to local worker HTTP server port
@@ -29,6 +30,10 @@ type CurrentWorker struct {
 	logger     struct {
 		logChan chan sdk.Log
 		llist   *list.List
+		// buffer keeps the logs that can't be sent to the API
+		buffer *logbuffer.Buffer
+		// droppedLines is the number of log lines dropped because the buffer was full, by step order
+		droppedLines map[int64]int
 	}
 	httpPort int32
 	register struct {
@@ -43,6 +48,10 @@ type CurrentWorker struct {
 		secrets      []sdk.Variable
 		context      context.Context
 	}
+	tools struct {
+		registry string
+		cacheDir string
+	}
 	status struct {
 		Name   string `json:"name"`
 		Status string `json:"status"`
@@ -112,6 +121,19 @@ func (w *CurrentWorker) Environ() []string {
 		newEnv = append(newEnv, e)
 	}
 
+	// add the tools installed from the tool registry for the current job in the PATH
+	if w.currentJob.wJob != nil {
+		if dirs := w.toolsPath(w.currentJob.wJob.Job.Action.Requirements); len(dirs) > 0 {
+			for i := range newEnv {
+				// the variable is named Path on windows
+				if strings.HasPrefix(strings.ToUpper(newEnv[i]), "PATH=") {
+					newEnv[i] = newEnv[i][:5] + strings.Join(dirs, string(os.PathListSeparator)) + string(os.PathListSeparator) + newEnv[i][5:]
+					break
+				}
+			}
+		}
+	}
+
 	//We have to let it here for some legacy reason
 	newEnv = append(newEnv, "CDS_KEY=********")
 
@@ -2,12 +2,15 @@ package main
 
 func main() {
 	cmd := cmdMain()
-	cmd.AddCommand(cmdExport)
+	cmd.AddCommand(cmdExport())
 	cmd.AddCommand(cmdUpload())
 	cmd.AddCommand(cmdArtifacts())
 	cmd.AddCommand(cmdDownload())
+	cmd.AddCommand(cmdPromote())
 	cmd.AddCommand(cmdTmpl())
 	cmd.AddCommand(cmdCheckSecret())
+	cmd.AddCommand(cmdCodeComments())
+	cmd.AddCommand(cmdTestReport())
 	cmd.AddCommand(cmdTag())
 	cmd.AddCommand(cmdRun())
 	cmd.AddCommand(cmdExit())
@@ -18,6 +18,7 @@ type DownloadArtifact struct {
 	Pattern     string `json:"pattern" cli:"pattern"`
 	Tag         string `json:"tag" cli:"tag"`
 	Destination string `json:"destination"`
+	Promoted    bool   `json:"promoted"`
 }
 
 type UploadArtifact struct {
@@ -41,6 +42,12 @@ type TmplPath struct {
 	Destination string `json:"destination"`
 }
 
+type TestReport struct {
+	JUnit          []string `json:"junit"`
+	Coverage       string   `json:"coverage"`
+	CoverageFormat string   `json:"coverage_format"`
+}
+
 type Level string
 
 type (
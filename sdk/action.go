@@ -12,6 +12,7 @@ const (
 	BuiltinAction = "Builtin"
 	PluginAction  = "Plugin"
 	JoinedAction  = "Joined"
+	ProjectAction = "Project"
 )
 
 // Builtin Action
@@ -25,6 +26,13 @@ const (
 	CheckoutApplicationAction = "CheckoutApplication"
 	DeployApplicationAction   = "DeployApplication"
 	InstallKeyAction          = "InstallKey"
+	DockerBuildAction         = "DockerBuild"
+	DeployKubernetesAction    = "DeployKubernetes"
+	HelmPushAction            = "HelmPush"
+	SBOMAction                = "SBOM"
+	CoverageCheckAction       = "CoverageCheck"
+	ServiceNowAction          = "ServiceNowChangeRequest"
+	JiraReleaseAction         = "JiraRelease"
 
 	DefaultGitCloneParameterTagValue = "{{.git.tag}}"
 )
@@ -41,6 +49,7 @@ func NewAction(name string) *Action {
 type Action struct {
 	ID          int64  `json:"id" yaml:"-" db:"id"`
 	GroupID     *int64 `json:"group_id,omitempty" yaml:"-" db:"group_id"`
+	ProjectID   *int64 `json:"project_id,omitempty" yaml:"-" db:"project_id"`
 	Name        string `json:"name" db:"name"`
 	Type        string `json:"type" yaml:"-" db:"type"`
 	Description string `json:"description" yaml:"desc,omitempty" db:"description"`
@@ -87,6 +96,15 @@ func (a Action) IsValidDefault() error {
 	return a.IsValid()
 }
 
+// IsValidProject returns project action validity.
+func (a Action) IsValidProject() error {
+	if a.ProjectID == nil || *a.ProjectID == 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid project id for action")
+	}
+
+	return a.IsValid()
+}
+
 // IsValid returns error if the action is not valid.
 func (a Action) IsValid() error {
 	if a.Name == "" {
@@ -16,14 +16,21 @@ var List = []Manifest{
 	ArtifactUpload,
 	CheckoutApplication,
 	Coverage,
+	CoverageCheck,
 	DeployApplication,
+	DeployKubernetes,
+	DockerBuild,
 	GitClone,
 	GitTag,
+	HelmPush,
 	InstallKey,
+	JiraRelease,
 	JUnit,
 	Release,
+	SBOM,
 	Script,
 	ServeStaticFiles,
+	ServiceNowChangeRequest,
 }
 
 // Manifest for a action.
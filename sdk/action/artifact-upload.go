@@ -29,6 +29,13 @@ var ArtifactUpload = Manifest{
 				Type:        sdk.StringParameter,
 				Advanced:    true,
 			},
+			{
+				Name:        "signKey",
+				Description: "(optional) Name of a PGP key of the project used to sign a SLSA provenance attestation of the uploaded artifacts.",
+				Value:       "",
+				Type:        sdk.KeyParameter,
+				Advanced:    true,
+			},
 		},
 	},
 	Example: exportentities.PipelineV1{
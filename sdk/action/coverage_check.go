@@ -0,0 +1,48 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// CoverageCheck action definition.
+var CoverageCheck = Manifest{
+	Action: sdk.Action{
+		Name: sdk.CoverageCheckAction,
+		Description: `CDS Builtin Action.
+Check the coverage of the run against the baseline of the default branch.
+
+The coverage report must have been sent by a previous Coverage step of the pipeline.
+The step fails if the coverage dropped more than the maximum delta compared to the last coverage report of the default branch.`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "maxDelta",
+				Description: `Maximum drop of the coverage, in percentage points, compared to the default branch.`,
+				Type:        sdk.NumberParameter,
+				Value:       "0",
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					Coverage: &exportentities.StepCoverage{
+						Format: "cobertura",
+						Path:   "./coverage.xml",
+					},
+				},
+				{
+					CoverageCheck: &exportentities.StepCoverageCheck{
+						MaxDelta: "0.5",
+					},
+				},
+			},
+		}},
+	},
+}
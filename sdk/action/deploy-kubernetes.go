@@ -0,0 +1,93 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// DeployKubernetes action definition.
+var DeployKubernetes = Manifest{
+	Action: sdk.Action{
+		Name: sdk.DeployKubernetesAction,
+		Description: `CDS Builtin Action.
+Deploy on a kubernetes cluster configured with a project integration of model "Kubernetes".
+
+The action applies manifests or a kustomization with kubectl, or installs a helm chart, then waits for the rollout
+of the deployments, statefulsets and daemonsets. On failure, the warning events of the namespace are printed in the logs.`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "integration",
+				Description: "Name of the Kubernetes project integration.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "namespace",
+				Description: "Kubernetes namespace in which the components are deployed.",
+				Value:       "default",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "manifests",
+				Description: "(optional) Comma separated list of globs matching the manifests to apply.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "kustomize",
+				Description: "(optional) Path of the kustomization directory to apply.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "helmChart",
+				Description: "(optional) Reference of the helm chart to install: chart reference (stable/mariadb), URL, path to a packaged chart or to a chart directory.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "helmValues",
+				Description: "(optional) Comma separated list of values files or URLs of the helm chart.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "releaseName",
+				Description: "(optional) Name of the helm release, default: the name of the application.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "timeout",
+				Description: "Timeout of the rollout in seconds.",
+				Value:       "180",
+				Type:        sdk.NumberParameter,
+				Advanced:    true,
+			},
+		},
+		Requirements: []sdk.Requirement{
+			{
+				Name:  "kubectl",
+				Type:  sdk.BinaryRequirement,
+				Value: "kubectl",
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					Checkout: &checkoutExample,
+				},
+				{
+					DeployKubernetes: &exportentities.StepDeployKubernetes{
+						Integration: "my-cluster",
+						Namespace:   "production",
+						Kustomize:   "k8s/overlays/production",
+					},
+				},
+			},
+		}},
+	},
+}
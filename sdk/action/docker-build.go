@@ -0,0 +1,113 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// DockerBuild action definition.
+var DockerBuild = Manifest{
+	Action: sdk.Action{
+		Name: sdk.DockerBuildAction,
+		Description: `CDS Builtin Action.
+Build a docker image with BuildKit and push it to a registry.
+
+The credentials of the registry are given by a project integration of model "Docker Registry".
+Multi-arch images are built with buildx when several platforms are given, they have to be pushed.
+The layer cache can be exported to the CDS cache of the project, to be reused by the next builds.
+
+The image is labelled with the OCI annotations (org.opencontainers.image.*) and the metadata of the run (com.ovh.cds.*).`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "image",
+				Description: "Name of the image, including the registry host, for example registry.example.com/team/app.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "tags",
+				Description: "Comma separated list of tags of the image.",
+				Value:       "{{.cds.version}}",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "registry",
+				Description: "(optional) Name of the Docker Registry project integration used to push the image.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "context",
+				Description: "Path of the build context.",
+				Value:       ".",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "dockerfile",
+				Description: "(optional) Path of the Dockerfile, default: Dockerfile in the build context.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "platforms",
+				Description: "(optional) Comma separated list of the platforms of the image, for example linux/amd64,linux/arm64.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "buildArgs",
+				Description: "(optional) Build arguments, one KEY=VALUE by line.",
+				Type:        sdk.TextParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "labels",
+				Description: "(optional) Additional labels, one KEY=VALUE by line.",
+				Type:        sdk.TextParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "cache",
+				Description: "Import and export the layer cache from the CDS cache.",
+				Value:       "false",
+				Type:        sdk.BooleanParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "push",
+				Description: "Push the image to the registry.",
+				Value:       "true",
+				Type:        sdk.BooleanParameter,
+				Advanced:    true,
+			},
+		},
+		Requirements: []sdk.Requirement{
+			{
+				Name:  "docker",
+				Type:  sdk.BinaryRequirement,
+				Value: "docker",
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					Checkout: &checkoutExample,
+				},
+				{
+					DockerBuild: &exportentities.StepDockerBuild{
+						Image:     "registry.example.com/team/app",
+						Tags:      "{{.cds.version}},latest",
+						Registry:  "my-registry",
+						Platforms: "linux/amd64,linux/arm64",
+						Cache:     "true",
+					},
+				},
+			},
+		}},
+	},
+}
@@ -0,0 +1,83 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// HelmPush action definition.
+var HelmPush = Manifest{
+	Action: sdk.Action{
+		Name: sdk.HelmPushAction,
+		Description: `CDS Builtin Action.
+Package a helm chart and push it to a chart repository configured with a project integration of model "Helm Registry".
+
+The chart package can be signed with a PGP key of the project or the application, the provenance file is pushed with the chart.
+The chart package and its provenance file are uploaded as artifacts of the run, even if no registry is given.
+OCI registries (oci://) and ChartMuseum repositories are supported.`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "chart",
+				Description: "Path of the chart directory.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "registry",
+				Description: "(optional) Name of the Helm Registry project integration used to push the chart.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "version",
+				Description: "(optional) Version of the chart, default: the version of Chart.yaml.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "appVersion",
+				Description: "(optional) Version of the application of the chart, default: the appVersion of Chart.yaml.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "signKey",
+				Description: "(optional) PGP key used to sign the chart package.",
+				Type:        sdk.KeyParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "tag",
+				Description: "(optional) Tag of the artifacts of the chart package, default: the name of the package.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+		},
+		Requirements: []sdk.Requirement{
+			{
+				Name:  "helm",
+				Type:  sdk.BinaryRequirement,
+				Value: "helm",
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					Checkout: &checkoutExample,
+				},
+				{
+					HelmPush: &exportentities.StepHelmPush{
+						Chart:    "./chart",
+						Registry: "my-charts",
+						Version:  "1.0.{{.cds.version}}",
+						SignKey:  "proj-charts",
+					},
+				},
+			},
+		}},
+	},
+}
@@ -0,0 +1,61 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// JiraRelease action definition.
+var JiraRelease = Manifest{
+	Action: sdk.Action{
+		Name: sdk.JiraReleaseAction,
+		Description: `CDS Builtin Action.
+Apply a transition on the Jira issues of the run, on a Jira instance configured with a project integration of model "Jira".
+
+The keys of the issues are extracted from the messages of the commits of the workflow run (ex: ABC-123). Add this
+step after the steps of a deployment job, so the issues are transitioned only when the deployment is successful.
+The release notes of the run, with the issues grouped by type, can be uploaded as an artifact of the run.`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "integration",
+				Description: "Name of the Jira project integration.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "transition",
+				Description: "(optional) Name of the transition applied to the issues, default: the transition of the integration.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "releaseNotes",
+				Description: "Generate the release notes of the run and upload them as an artifact of the run.",
+				Type:        sdk.BooleanParameter,
+				Value:       "false",
+			},
+			{
+				Name:        "artifact",
+				Description: "(optional) Name of the release notes artifact, default: release-notes.md.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					JiraRelease: &exportentities.StepJiraRelease{
+						Integration:  "my-jira",
+						Transition:   "Deployed",
+						ReleaseNotes: "true",
+					},
+				},
+			},
+		}},
+	},
+}
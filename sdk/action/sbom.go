@@ -0,0 +1,68 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// SBOM action definition.
+var SBOM = Manifest{
+	Action: sdk.Action{
+		Name: sdk.SBOMAction,
+		Description: `CDS Builtin Action.
+Generate a software bill of materials (SBOM) and store it with the workflow run.
+
+The SBOM is generated with syft, which must be installed on the worker, or read from a CycloneDX or SPDX JSON file generated by another tool.
+The dependencies of the SBOMs are indexed: the runs containing a given dependency can be searched for a project or for all the projects.`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "source",
+				Description: "(optional) Source analyzed by syft: a directory (dir:./src), a docker image (registry:my/image:tag) or an archive, default: dir:.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "file",
+				Description: "(optional) Path of a CycloneDX or SPDX JSON file to store instead of generating the SBOM.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "format",
+				Description: "(optional) Format of the SBOM: cyclonedx or spdx, default: cyclonedx, or detected from the file.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "name",
+				Description: "(optional) Name of the SBOM, default: the source or the name of the file.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+			{
+				Name:        "artifact",
+				Description: "(optional) Name of the artifact described by the SBOM.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					Checkout: &checkoutExample,
+				},
+				{
+					SBOM: &exportentities.StepSBOM{
+						Source:   "dir:.",
+						Format:   "cyclonedx",
+						Artifact: "my-app.tar.gz",
+					},
+				},
+			},
+		}},
+	},
+}
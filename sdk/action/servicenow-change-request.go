@@ -0,0 +1,81 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// ServiceNowChangeRequest action definition.
+var ServiceNowChangeRequest = Manifest{
+	Action: sdk.Action{
+		Name: sdk.ServiceNowAction,
+		Description: `CDS Builtin Action.
+Create or update a change request on a ServiceNow instance configured with a project integration of model "ServiceNow".
+
+The report of the workflow run is attached to the change request. The number of the change request is exported
+as the build variable cds.build.servicenow.change, so the change request can be updated by the next pipelines.
+The action can wait until the change request is approved, it fails if the change request is rejected.`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "integration",
+				Description: "Name of the ServiceNow project integration.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "changeRequest",
+				Description: "(optional) Number of the change request to update, a change request is created if not given.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "shortDescription",
+				Description: "(optional) Short description of the created change request, default: the name and the version of the workflow.",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "description",
+				Description: "(optional) Description of the created change request, or work note added to the updated change request.",
+				Type:        sdk.TextParameter,
+			},
+			{
+				Name:        "state",
+				Description: "(optional) Value of the state field set on the change request (ex: -1 for Implement, 0 for Review, 3 for Closed).",
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "attachReport",
+				Description: "Attach the report of the workflow run to the change request.",
+				Type:        sdk.BooleanParameter,
+				Value:       "true",
+			},
+			{
+				Name:        "waitApproval",
+				Description: "Wait until the change request is approved.",
+				Type:        sdk.BooleanParameter,
+				Value:       "false",
+			},
+			{
+				Name:        "timeout",
+				Description: "(optional) Time to wait for the approval in seconds, default: 3600.",
+				Type:        sdk.StringParameter,
+				Advanced:    true,
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					ServiceNowChangeRequest: &exportentities.StepServiceNowChangeRequest{
+						Integration:  "my-servicenow",
+						WaitApproval: "true",
+					},
+				},
+			},
+		}},
+	},
+}
@@ -0,0 +1,140 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ActionVersion is a version of an action published by its group. The definition of the action, with its
+// parameters, requirements and steps, is kept as it was when the version was published.
+type ActionVersion struct {
+	ID        int64     `json:"id" db:"id" cli:"-"`
+	ActionID  int64     `json:"action_id" db:"action_id" cli:"-"`
+	Version   string    `json:"version" db:"version" cli:"version,key"`
+	Action    Action    `json:"action" db:"action" cli:"-"`
+	Publisher string    `json:"publisher" db:"publisher" cli:"publisher"`
+	Published time.Time `json:"published" db:"published" cli:"published"`
+}
+
+// IsValid returns an error if the version can't be published.
+func (v ActionVersion) IsValid() error {
+	if !NamePatternRegex.MatchString(v.Version) {
+		return NewErrorFrom(ErrWrongRequest, "invalid action version %q", v.Version)
+	}
+	return nil
+}
+
+// ActionVersionUsage is a workflow that pins a version of an action.
+type ActionVersionUsage struct {
+	ProjectID    int64  `json:"project_id" cli:"-"`
+	ProjectKey   string `json:"project_key" cli:"project,key"`
+	ProjectName  string `json:"project_name" cli:"-"`
+	WorkflowID   int64  `json:"workflow_id" cli:"-"`
+	WorkflowName string `json:"workflow_name" cli:"workflow,key"`
+}
+
+// UseVersion replaces the definition of a step with the one of a version of its action. The parameters keep
+// the values given to the step, the step name and the step options are unchanged.
+func (a *Action) UseVersion(v ActionVersion) {
+	params := make([]Parameter, len(v.Action.Parameters))
+	for i := range v.Action.Parameters {
+		params[i] = v.Action.Parameters[i]
+		for j := range a.Parameters {
+			if a.Parameters[j].Name == params[i].Name {
+				params[i].Value = a.Parameters[j].Value
+				break
+			}
+		}
+	}
+	a.Parameters = params
+	a.Description = v.Action.Description
+	a.Requirements = v.Action.Requirements
+	a.Actions = v.Action.Actions
+}
+
+// WorkflowPinnedVersions are the versions of the actions and of the plugins used by the runs of a workflow,
+// given as name@version. The steps of the jobs use these versions instead of the latest definition of the
+// actions and the installed plugins.
+type WorkflowPinnedVersions []string
+
+// IsValid returns an error if a pinned version is not given as name@version, or if a name is pinned twice.
+func (p WorkflowPinnedVersions) IsValid() error {
+	names := make(map[string]struct{}, len(p))
+	for _, v := range p {
+		name, version := ParseBinaryRequirement(v)
+		if version == "" || !NamePatternRegex.MatchString(version) {
+			return NewErrorFrom(ErrWrongRequest, "invalid pinned version %q, it should be name@version", v)
+		}
+		if _, ok := names[name]; ok {
+			return NewErrorFrom(ErrWrongRequest, "invalid pinned versions, %s is pinned twice", name)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
+
+// Version returns the pinned version of an action or a plugin, or an empty string if it is not pinned.
+func (p WorkflowPinnedVersions) Version(name string) string {
+	for _, v := range p {
+		if n, version := ParseBinaryRequirement(v); n == name {
+			return version
+		}
+	}
+	return ""
+}
+
+// PinPluginRequirements requires the pinned version of the plugins used by the steps of a job, the worker
+// downloads this version from the plugin registry.
+func (p WorkflowPinnedVersions) PinPluginRequirements(job *Action) {
+	var walk func(as []Action)
+	walk = func(as []Action) {
+		for i := range as {
+			if as[i].Type == PluginAction {
+				if version := p.Version(as[i].Name); version != "" {
+					p.setPluginRequirement(job, as[i].Name, version)
+				}
+			}
+			walk(as[i].Actions)
+		}
+	}
+	walk(job.Actions)
+}
+
+func (p WorkflowPinnedVersions) setPluginRequirement(job *Action, name, version string) {
+	value := name + "@" + version
+	for i := range job.Requirements {
+		r := &job.Requirements[i]
+		if r.Type != PluginRequirement {
+			continue
+		}
+		if n, _ := ParsePluginRequirement(r.Value); n == name {
+			r.Value = value
+			return
+		}
+	}
+	job.Requirements = append(job.Requirements, Requirement{Name: name, Type: PluginRequirement, Value: value})
+}
+
+// Scan pinned versions.
+func (p *WorkflowPinnedVersions) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, p), "cannot unmarshal WorkflowPinnedVersions")
+}
+
+// Value returns driver.Value from pinned versions.
+func (p WorkflowPinnedVersions) Value() (driver.Value, error) {
+	if p == nil {
+		p = WorkflowPinnedVersions{}
+	}
+	j, err := json.Marshal(p)
+	return j, WrapError(err, "cannot marshal WorkflowPinnedVersions")
+}
@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionUseVersion(t *testing.T) {
+	step := Action{
+		Name:       "my-action",
+		StepName:   "build",
+		Enabled:    true,
+		Parameters: []Parameter{{Name: "path", Value: "./src"}, {Name: "removed", Value: "foo"}},
+	}
+	step.UseVersion(ActionVersion{
+		Version: "1.0.0",
+		Action: Action{
+			Name:        "my-action",
+			Description: "build something",
+			Parameters:  []Parameter{{Name: "path", Value: "."}, {Name: "verbose", Value: "false"}},
+			Actions:     []Action{{Name: ScriptAction}},
+		},
+	})
+	assert.Equal(t, "build", step.StepName)
+	assert.True(t, step.Enabled)
+	assert.Equal(t, "build something", step.Description)
+	assert.Equal(t, []Parameter{{Name: "path", Value: "./src"}, {Name: "verbose", Value: "false"}}, step.Parameters)
+	require.Len(t, step.Actions, 1)
+}
+
+func TestWorkflowPinnedVersionsIsValid(t *testing.T) {
+	assert.NoError(t, WorkflowPinnedVersions{"my-action@1.0.0", "plugin-npm@1.2.0"}.IsValid())
+	assert.Error(t, WorkflowPinnedVersions{"my-action"}.IsValid())
+	assert.Error(t, WorkflowPinnedVersions{"my-action@"}.IsValid())
+	assert.Error(t, WorkflowPinnedVersions{"my-action@1.0.0", "my-action@1.1.0"}.IsValid())
+}
+
+func TestWorkflowPinnedVersionsPinPluginRequirements(t *testing.T) {
+	p := WorkflowPinnedVersions{"my-action@1.0.0", "plugin-npm@1.2.0", "plugin-tmpl@2.0.0"}
+	assert.Equal(t, "1.0.0", p.Version("my-action"))
+	assert.Equal(t, "", p.Version("other"))
+
+	job := Action{
+		Requirements: []Requirement{
+			{Name: "plugin-tmpl", Type: PluginRequirement, Value: "plugin-tmpl"},
+			{Name: "bash", Type: BinaryRequirement, Value: "bash"},
+		},
+		Actions: []Action{
+			{Name: "plugin-tmpl", Type: PluginAction},
+			{Name: "my-action", Type: DefaultAction, Actions: []Action{{Name: "plugin-npm", Type: PluginAction}}},
+			{Name: "plugin-other", Type: PluginAction},
+		},
+	}
+	p.PinPluginRequirements(&job)
+	assert.Equal(t, RequirementList{
+		{Name: "plugin-tmpl", Type: PluginRequirement, Value: "plugin-tmpl@2.0.0"},
+		{Name: "bash", Type: BinaryRequirement, Value: "bash"},
+		{Name: "plugin-npm", Type: PluginRequirement, Value: "plugin-npm@1.2.0"},
+	}, job.Requirements)
+}
@@ -25,6 +25,7 @@ type Application struct {
 	LastModified         time.Time                    `json:"last_modified" db:"last_modified" mapstructure:"-"`
 	VCSServer            string                       `json:"vcs_server,omitempty" db:"vcs_server"`
 	RepositoryFullname   string                       `json:"repository_fullname,omitempty" db:"repo_fullname" cli:"repository_fullname"`
+	RepositoryURL        string                       `json:"repository_url,omitempty" db:"repository_url" cli:"repository_url"`
 	RepositoryStrategy   RepositoryStrategy           `json:"vcs_strategy,omitempty" db:"-"`
 	Metadata             Metadata                     `json:"metadata" yaml:"metadata" db:"-"`
 	Keys                 []ApplicationKey             `json:"keys" yaml:"keys" db:"-"`
@@ -49,9 +50,23 @@ func (app Application) IsValid() error {
 		}
 	}
 
+	if app.RepositoryURL != "" {
+		if app.VCSServer != "" {
+			return NewErrorFrom(ErrWrongRequest, "a repository url can't be given for an application linked to a repositories manager")
+		}
+		if !IsValidGitURL(app.RepositoryURL) {
+			return NewErrorFrom(ErrWrongRequest, "invalid repository url %s, it should be an https or ssh git url", app.RepositoryURL)
+		}
+	}
+
 	return nil
 }
 
+// IsPlainGit returns true if the application is linked to a raw git repository without repositories manager.
+func (app Application) IsPlainGit() bool {
+	return app.VCSServer == "" && app.RepositoryURL != ""
+}
+
 // SSHKeys returns the slice of ssh key for an application
 func (app Application) SSHKeys() []ApplicationKey {
 	keys := []ApplicationKey{}
@@ -41,3 +41,16 @@ func (d AsCodeEventData) Value() (driver.Value, error) {
 	j, err := json.Marshal(d)
 	return j, WrapError(err, "cannot marshal AsCodeEventData")
 }
+
+// There are the formats of the CI files of other tools that can be converted to CDS files.
+const (
+	AsCodeConvertGitHub   = "github"
+	AsCodeConvertGitLabCI = "gitlab-ci"
+)
+
+// AsCodeConversion contains the files to convert by file name, and the converted files with the constructs that
+// were not converted.
+type AsCodeConversion struct {
+	Files    map[string]string `json:"files"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
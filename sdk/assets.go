@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"path"
+	"strings"
+)
+
+// Directories and files of an assets bundle. A bundle is a tar.gz archive that contains the binaries served by the
+// API, the GRPC plugins with their binaries and the default actions. It is exported from a CDS with an internet
+// access and imported on an air-gapped installation.
+//
+//	binaries/<filename>                  binaries of the download directory (worker, cdsctl, engine)
+//	plugins/<name>/plugin.json           plugin with the description of its binaries
+//	plugins/<name>/<os>-<arch>/<binary>  binary of a plugin
+//	actions/<group>/<name>.yml           default action
+const (
+	AssetsBundleBinariesDirectory = "binaries"
+	AssetsBundlePluginsDirectory  = "plugins"
+	AssetsBundleActionsDirectory  = "actions"
+	AssetsBundlePluginFile        = "plugin.json"
+)
+
+// AssetsBundleReport lists the assets imported from a bundle.
+type AssetsBundleReport struct {
+	Binaries []string `json:"binaries" cli:"binaries"`
+	Plugins  []string `json:"plugins" cli:"plugins"`
+	Actions  []string `json:"actions" cli:"actions"`
+}
+
+// AssetsBundlePluginBinaryPath returns the path of a plugin binary in an assets bundle.
+func AssetsBundlePluginBinaryPath(pluginName string, b GRPCPluginBinary) string {
+	return path.Join(AssetsBundlePluginsDirectory, pluginName, b.OS+"-"+b.Arch, b.Name)
+}
+
+// IsDownloadableResourceFilename returns true if given file name is the file name of a binary served by the API.
+func IsDownloadableResourceFilename(filename string) bool {
+	for _, r := range AllDownloadableResources() {
+		if GetArtifactFilename(r.Name, r.OS, r.Arch, r.Variant) == filename {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitAssetsBundlePath returns the directory of a file in an assets bundle and its path in this directory.
+func SplitAssetsBundlePath(p string) (string, string) {
+	p = path.Clean(strings.TrimPrefix(p, "./"))
+	i := strings.Index(p, "/")
+	if i < 0 {
+		return "", p
+	}
+	return p[:i], p[i+1:]
+}
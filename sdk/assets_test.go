@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitAssetsBundlePath(t *testing.T) {
+	dir, p := SplitAssetsBundlePath("./plugins/plugin-venom/linux-amd64/plugin-venom")
+	assert.Equal(t, AssetsBundlePluginsDirectory, dir)
+	assert.Equal(t, "plugin-venom/linux-amd64/plugin-venom", p)
+
+	dir, p = SplitAssetsBundlePath("binaries/cds-worker-linux-amd64")
+	assert.Equal(t, AssetsBundleBinariesDirectory, dir)
+	assert.Equal(t, "cds-worker-linux-amd64", p)
+
+	dir, p = SplitAssetsBundlePath("VERSION")
+	assert.Equal(t, "", dir)
+	assert.Equal(t, "VERSION", p)
+}
+
+func TestAssetsBundlePluginBinaryPath(t *testing.T) {
+	p := AssetsBundlePluginBinaryPath("plugin-venom", GRPCPluginBinary{OS: "linux", Arch: "amd64", Name: "plugin-venom"})
+	assert.Equal(t, "plugins/plugin-venom/linux-amd64/plugin-venom", p)
+}
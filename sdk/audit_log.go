@@ -0,0 +1,248 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Audit log export formats.
+const (
+	AuditLogFormatJSON = "json"
+	AuditLogFormatCSV  = "csv"
+)
+
+// AuditLog is a record of a mutating call on CDS API.
+type AuditLog struct {
+	ID           int64           `json:"id" cli:"id,key" db:"id"`
+	Created      time.Time       `json:"created" cli:"created" db:"created"`
+	Method       string          `json:"method" cli:"method" db:"method"`
+	Path         string          `json:"path" cli:"path" db:"path"`
+	Route        string          `json:"route" db:"route"`
+	StatusCode   int             `json:"status_code" cli:"status" db:"status_code"`
+	EntityType   string          `json:"entity_type" cli:"entity_type" db:"entity_type"`
+	EntityName   string          `json:"entity_name,omitempty" cli:"entity_name" db:"entity_name"`
+	ProjectKey   string          `json:"project_key,omitempty" cli:"project_key" db:"project_key"`
+	UserID       string          `json:"user_id,omitempty" db:"user_id"`
+	Username     string          `json:"username" cli:"username" db:"username"`
+	ConsumerID   string          `json:"consumer_id" db:"consumer_id"`
+	ConsumerType string          `json:"consumer_type" cli:"consumer_type" db:"consumer_type"`
+	IPAddress    string          `json:"ip_address" cli:"ip_address" db:"ip_address"`
+	DataBefore   AuditLogData    `json:"data_before,omitempty" db:"data_before"`
+	DataAfter    AuditLogData    `json:"data_after,omitempty" db:"data_after"`
+	Changes      AuditLogChanges `json:"changes,omitempty" db:"changes"`
+}
+
+// AuditLogFilter contains filters to search audit logs.
+type AuditLogFilter struct {
+	EntityType string
+	ProjectKey string
+	Username   string
+	Since      time.Time
+	Until      time.Time
+	Offset     int
+	Limit      int
+}
+
+// AuditLogData is a JSON document attached to an audit log.
+type AuditLogData json.RawMessage
+
+// MarshalJSON returns raw data.
+func (d AuditLogData) MarshalJSON() ([]byte, error) {
+	if len(d) == 0 {
+		return []byte("null"), nil
+	}
+	return d, nil
+}
+
+// UnmarshalJSON keeps given raw data.
+func (d *AuditLogData) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = nil
+		return nil
+	}
+	*d = append((*d)[0:0], data...)
+	return nil
+}
+
+// Scan audit log data.
+func (d *AuditLogData) Scan(src interface{}) error {
+	if src == nil {
+		*d = nil
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	*d = append((*d)[0:0], source...)
+	return nil
+}
+
+// Value returns driver.Value from audit log data.
+func (d AuditLogData) Value() (driver.Value, error) {
+	if len(d) == 0 {
+		return nil, nil
+	}
+	return []byte(d), nil
+}
+
+// AuditLogChange is a value that changed between before and after data of an audit log.
+type AuditLogChange struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// AuditLogChanges is a list of changes.
+type AuditLogChanges []AuditLogChange
+
+// Scan audit log changes.
+func (c *AuditLogChanges) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, c), "cannot unmarshal AuditLogChanges")
+}
+
+// Value returns driver.Value from audit log changes.
+func (c AuditLogChanges) Value() (driver.Value, error) {
+	j, err := json.Marshal(c)
+	return j, WrapError(err, "cannot marshal AuditLogChanges")
+}
+
+// auditLogSensitiveKeys contains parts of JSON keys for which values are never stored in audit logs.
+var auditLogSensitiveKeys = []string{"password", "secret", "token", "private", "passphrase"}
+
+// NewAuditLogData returns a JSON document for given value where sensitive values are redacted.
+// Given value can be raw JSON, nil is returned if it is not a valid JSON document.
+func NewAuditLogData(v interface{}) AuditLogData {
+	var raw []byte
+	switch d := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		raw = d
+	case json.RawMessage:
+		raw = d
+	case AuditLogData:
+		raw = d
+	default:
+		var err error
+		raw, err = json.Marshal(v)
+		if err != nil {
+			return nil
+		}
+	}
+
+	var i interface{}
+	if err := json.Unmarshal(raw, &i); err != nil {
+		return nil
+	}
+	res, err := json.Marshal(redactAuditLogValue(i))
+	if err != nil {
+		return nil
+	}
+	return AuditLogData(res)
+}
+
+func redactAuditLogValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		// Variables and integration configs contain their type next to their value
+		isPassword := t["type"] == SecretVariable || t["type"] == IntegrationConfigTypePassword
+		for k, value := range t {
+			if (isPassword && k == "value") || isAuditLogSensitiveKey(k) {
+				if value != nil && value != "" {
+					t[k] = PasswordPlaceholder
+				}
+				continue
+			}
+			t[k] = redactAuditLogValue(value)
+		}
+		return t
+	case []interface{}:
+		for i := range t {
+			t[i] = redactAuditLogValue(t[i])
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+func isAuditLogSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range auditLogSensitiveKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeAuditLogChanges returns the list of leaf values that differ between before and after documents.
+func ComputeAuditLogChanges(before, after AuditLogData) AuditLogChanges {
+	var b, a interface{}
+	if len(before) > 0 {
+		_ = json.Unmarshal(before, &b)
+	}
+	if len(after) > 0 {
+		_ = json.Unmarshal(after, &a)
+	}
+
+	mBefore := make(map[string]interface{})
+	mAfter := make(map[string]interface{})
+	flattenAuditLogValue("", b, mBefore)
+	flattenAuditLogValue("", a, mAfter)
+
+	paths := make(map[string]struct{}, len(mBefore)+len(mAfter))
+	for k := range mBefore {
+		paths[k] = struct{}{}
+	}
+	for k := range mAfter {
+		paths[k] = struct{}{}
+	}
+
+	var changes AuditLogChanges
+	for p := range paths {
+		vb, okb := mBefore[p]
+		va, oka := mAfter[p]
+		if okb && oka && fmt.Sprintf("%v", vb) == fmt.Sprintf("%v", va) {
+			continue
+		}
+		changes = append(changes, AuditLogChange{Path: p, Before: vb, After: va})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes
+}
+
+func flattenAuditLogValue(prefix string, v interface{}, res map[string]interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, value := range t {
+			p := k
+			if prefix != "" {
+				p = prefix + "." + k
+			}
+			flattenAuditLogValue(p, value, res)
+		}
+	case []interface{}:
+		for i, value := range t {
+			flattenAuditLogValue(fmt.Sprintf("%s[%d]", prefix, i), value, res)
+		}
+	case nil:
+	default:
+		res[prefix] = v
+	}
+}
@@ -0,0 +1,30 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditLogData(t *testing.T) {
+	data := NewAuditLogData([]byte(`{"name": "my-app", "vcs_strategy": {"password": "secret"}, "variables": [{"name": "foo", "type": "password", "value": "bar"}, {"name": "baz", "type": "string", "value": "qux"}]}`))
+	require.NotNil(t, data)
+	assert.JSONEq(t, `{"name": "my-app", "vcs_strategy": {"password": "**********"}, "variables": [{"name": "foo", "type": "password", "value": "**********"}, {"name": "baz", "type": "string", "value": "qux"}]}`, string(data))
+
+	assert.Nil(t, NewAuditLogData([]byte("not json")))
+	assert.Nil(t, NewAuditLogData(nil))
+}
+
+func TestComputeAuditLogChanges(t *testing.T) {
+	before := NewAuditLogData(map[string]interface{}{"name": "foo", "tags": []string{"a", "b"}, "same": 1})
+	after := NewAuditLogData(map[string]interface{}{"name": "bar", "tags": []string{"a"}, "same": 1, "new": true})
+
+	changes := ComputeAuditLogChanges(before, after)
+	require.Len(t, changes, 3)
+	assert.Equal(t, AuditLogChange{Path: "name", Before: "foo", After: "bar"}, changes[0])
+	assert.Equal(t, AuditLogChange{Path: "new", After: true}, changes[1])
+	assert.Equal(t, AuditLogChange{Path: "tags[1]", Before: "b"}, changes[2])
+
+	assert.Len(t, ComputeAuditLogChanges(nil, after), 4)
+}
@@ -64,10 +64,11 @@ func (j ExecutedJob) ToSummary() ExecutedJobSummary {
 
 // StepStatus Represent a step and his status
 type StepStatus struct {
-	StepOrder int       `json:"step_order" db:"-"`
-	Status    string    `json:"status" db:"-"`
-	Start     time.Time `json:"start" db:"-"`
-	Done      time.Time `json:"done" db:"-"`
+	StepOrder int          `json:"step_order" db:"-"`
+	Status    string       `json:"status" db:"-"`
+	Start     time.Time    `json:"start" db:"-"`
+	Done      time.Time    `json:"done" db:"-"`
+	Metrics   *StepMetrics `json:"metrics,omitempty" db:"-"`
 }
 
 // StepStatusSummary Represent a step and his status for CDS event
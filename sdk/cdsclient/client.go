@@ -155,7 +155,7 @@ retry:
 		}
 		return nil, nil, sdk.WithStack(err)
 	}
-	cli.config.SessionToken = res.Token
+	cli.config.SetSessionToken(res.Token)
 
 	base64EncodedPubKey := headers.Get("X-Api-Pub-Signing-Key")
 	pubKey, err := base64.StdEncoding.DecodeString(base64EncodedPubKey)
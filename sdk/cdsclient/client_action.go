@@ -84,6 +84,33 @@ func (c *client) ActionExport(groupName, name string, format string) ([]byte, er
 	return body, nil
 }
 
+func (c *client) ActionVersionList(groupName, name string) ([]sdk.ActionVersion, error) {
+	var vs []sdk.ActionVersion
+	path := fmt.Sprintf("/action/%s/%s/version", groupName, name)
+	if _, err := c.GetJSON(context.Background(), path, &vs); err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
+func (c *client) ActionVersionPublish(groupName, name, version string) (*sdk.ActionVersion, error) {
+	v := sdk.ActionVersion{Version: version}
+	path := fmt.Sprintf("/action/%s/%s/version", groupName, name)
+	if _, err := c.PostJSON(context.Background(), path, v, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *client) ActionVersionUsage(groupName, name, version string) ([]sdk.ActionVersionUsage, error) {
+	var us []sdk.ActionVersionUsage
+	path := fmt.Sprintf("/action/%s/%s/version/%s/usage", groupName, name, version)
+	if _, err := c.GetJSON(context.Background(), path, &us); err != nil {
+		return nil, err
+	}
+	return us, nil
+}
+
 func (c *client) ActionBuiltinList() ([]sdk.Action, error) {
 	actions := []sdk.Action{}
 	if _, err := c.GetJSON(context.Background(), "/actionBuiltin", &actions); err != nil {
@@ -38,6 +38,53 @@ func (c *client) AdminCDSMigrationReset(id int64) error {
 	return err
 }
 
+func (c *client) AdminUserScrub(req sdk.UserScrubRequest) (*sdk.UserScrubReport, error) {
+	var report sdk.UserScrubReport
+	if _, err := c.PostJSON(context.Background(), "/admin/user/scrub", req, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (c *client) AdminProjectQueueSettingsGet(projectKey string) (*sdk.ProjectQueueSettings, error) {
+	var settings sdk.ProjectQueueSettings
+	if _, err := c.GetJSON(context.Background(), "/admin/project/"+projectKey+"/queue", &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (c *client) AdminProjectQueueSettingsUpdate(projectKey string, settings sdk.ProjectQueueSettings) error {
+	_, err := c.PutJSON(context.Background(), "/admin/project/"+projectKey+"/queue", settings, nil)
+	return err
+}
+
+func (c *client) AdminQuotaList() ([]sdk.QuotaReport, error) {
+	var reports []sdk.QuotaReport
+	if _, err := c.GetJSON(context.Background(), "/admin/quota", &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (c *client) AdminQuotaGet(entityType, name string) (*sdk.QuotaReport, error) {
+	var report sdk.QuotaReport
+	if _, err := c.GetJSON(context.Background(), "/admin/quota/"+entityType+"/"+url.PathEscape(name), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (c *client) AdminQuotaUpdate(entityType, name string, q sdk.Quota) error {
+	_, err := c.PutJSON(context.Background(), "/admin/quota/"+entityType+"/"+url.PathEscape(name), q, nil)
+	return err
+}
+
+func (c *client) AdminQuotaDelete(entityType, name string) error {
+	_, err := c.DeleteJSON(context.Background(), "/admin/quota/"+entityType+"/"+url.PathEscape(name), nil)
+	return err
+}
+
 func (c *client) AdminCDSMigrationList() ([]sdk.Migration, error) {
 	var migrations []sdk.Migration
 	if _, err := c.GetJSON(context.Background(), "/admin/cds/migration", &migrations); err != nil {
@@ -75,6 +122,11 @@ func (c *client) ServiceNameCallGET(name string, query string) ([]byte, error) {
 	return btes, err
 }
 
+func (c *client) ServiceNameCallPOST(name string, query string, body []byte) ([]byte, error) {
+	btes, _, _, err := c.Request(context.Background(), "POST", "/admin/services/call?name="+name+"&query="+url.QueryEscape(query), bytes.NewReader(body))
+	return btes, err
+}
+
 func (c *client) ServiceDelete(name string) error {
 	_, err := c.DeleteJSON(context.Background(), "/admin/service/"+name, nil)
 	return err
@@ -184,3 +236,12 @@ func (c *client) AdminDatabaseRollAllEncryptedEntities() error {
 	}
 	return nil
 }
+
+func (c *client) AdminSBOMSearch(name, version string) ([]sdk.SBOMSearchResult, error) {
+	var res []sdk.SBOMSearchResult
+	path := fmt.Sprintf("/admin/sbom/search?name=%s&version=%s", url.QueryEscape(name), url.QueryEscape(version))
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,54 @@
+package cdsclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) AdminAssetsExport(w io.Writer) error {
+	reader, _, code, err := c.Stream(context.Background(), "GET", "/admin/assets/bundle", nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if code >= 400 {
+		body, _ := ioutil.ReadAll(reader)
+		if err := sdk.DecodeError(body); err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot export assets bundle: HTTP code %d", code)
+	}
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+func (c *client) AdminAssetsImport(r io.Reader) (*sdk.AssetsBundleReport, error) {
+	reader, _, code, err := c.Stream(context.Background(), "POST", "/admin/assets/bundle", r, true, SetHeader("Content-Type", "application/gzip"))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if code >= 400 {
+		if err := sdk.DecodeError(body); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("cannot import assets bundle: HTTP code %d", code)
+	}
+
+	var report sdk.AssetsBundleReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
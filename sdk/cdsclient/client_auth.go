@@ -76,6 +76,25 @@ func (c *client) AuthConsumerCreateForUser(username string, request sdk.AuthCons
 	return consumer, err
 }
 
+func (c *client) AuthConsumerListByProject(projectKey string) (sdk.AuthConsumers, error) {
+	var consumers sdk.AuthConsumers
+	if _, err := c.GetJSON(context.Background(), "/project/"+projectKey+"/auth/consumer", &consumers); err != nil {
+		return nil, err
+	}
+	return consumers, nil
+}
+
+func (c *client) AuthConsumerCreateForProject(projectKey string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error) {
+	var consumer sdk.AuthConsumerCreateResponse
+	_, _, _, err := c.RequestJSON(context.Background(), "POST", "/project/"+projectKey+"/auth/consumer", request, &consumer)
+	return consumer, err
+}
+
+func (c *client) AuthConsumerDeleteForProject(projectKey, id string) error {
+	_, err := c.DeleteJSON(context.Background(), "/project/"+projectKey+"/auth/consumer/"+id, nil)
+	return err
+}
+
 func (c *client) AuthSessionListByUser(username string) (sdk.AuthSessions, error) {
 	var sessions sdk.AuthSessions
 	if _, err := c.GetJSON(context.Background(), "/user/"+username+"/auth/session", &sessions); err != nil {
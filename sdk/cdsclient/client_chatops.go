@@ -0,0 +1,34 @@
+package cdsclient
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ChatOpsCommand(cmd sdk.ChatOpsCommand) (*sdk.ChatOpsCommandResult, error) {
+	var res sdk.ChatOpsCommandResult
+	if _, err := c.PostJSON(context.Background(), "/chatops/command", cmd, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) ChatOpsLinkToken(req sdk.ChatOpsLinkTokenRequest) (*sdk.ChatOpsLinkToken, error) {
+	var token sdk.ChatOpsLinkToken
+	if _, err := c.PostJSON(context.Background(), "/chatops/link/token", req, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (c *client) ChatOpsLink(token string) error {
+	_, err := c.PostJSON(context.Background(), "/user/chatops/link", sdk.ChatOpsLinkRequest{Token: token}, nil)
+	return err
+}
+
+func (c *client) ChatOpsUnlink(provider string) error {
+	_, err := c.DeleteJSON(context.Background(), "/user/chatops/link/"+url.PathEscape(provider), nil)
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"log"
 	"time"
+
+	"github.com/ovh/cds/sdk"
 )
 
 func (c *client) EventsListen(ctx context.Context, chanSSEvt chan<- SSEvent) {
@@ -14,3 +16,12 @@ func (c *client) EventsListen(ctx context.Context, chanSSEvt chan<- SSEvent) {
 		time.Sleep(1 * time.Second)
 	}
 }
+
+func (c *client) WebsocketEventsListen(ctx context.Context, filters []sdk.WebsocketFilter, chanMsgReceived chan<- sdk.WebsocketEvent) {
+	for ctx.Err() == nil {
+		if err := c.RequestWebsocket(ctx, "/ws", filters, chanMsgReceived); err != nil {
+			log.Println("WebsocketEventsListen", err)
+		}
+		time.Sleep(1 * time.Second)
+	}
+}
@@ -198,3 +198,27 @@ func (c *client) WorkflowPush(projectKey string, tarContent io.Reader, mods ...R
 
 	return messages, tarReader, nil
 }
+
+func (c *client) ProjectPush(projectKey string, tarContent io.Reader, mods ...RequestModifier) ([]string, error) {
+	url := fmt.Sprintf("/project/%s/push", projectKey)
+
+	mods = append(mods,
+		func(r *http.Request) {
+			r.Header.Set("Content-Type", "application/tar")
+		})
+
+	btes, _, code, err := c.Request(context.Background(), "POST", url, tarContent, mods...)
+	if err != nil {
+		return nil, err
+	}
+
+	if code >= 400 {
+		return nil, fmt.Errorf("HTTP Status code %d", code)
+	}
+
+	messages := []string{}
+	if err := json.Unmarshal(btes, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
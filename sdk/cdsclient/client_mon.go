@@ -32,6 +32,14 @@ func (c *client) MonDBMigrate() ([]sdk.MonDBMigrate, error) {
 	return monDBMigrate, nil
 }
 
+func (c *client) MonServices() (*sdk.MonitoringServicesMatrix, error) {
+	var m sdk.MonitoringServicesMatrix
+	if _, err := c.GetJSON(context.Background(), "/mon/services", &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
 func (c *client) MonErrorsGet(requestID string) ([]sdk.Error, error) {
 	res, _, _, err := c.Request(context.Background(), "GET", fmt.Sprintf("/mon/errors/%s", requestID), nil)
 	if err != nil {
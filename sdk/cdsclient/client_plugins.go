@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -74,3 +75,54 @@ func (c client) PluginGetBinary(name, os, arch string, w io.Writer) error {
 	_, err = io.Copy(w, reader)
 	return err
 }
+
+func (c client) PluginRegistryList(name string) ([]sdk.GRPCPluginVersion, error) {
+	path := "/plugin/registry"
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+	var res []sdk.GRPCPluginVersion
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c client) PluginRegistryGet(name, version string) (*sdk.GRPCPluginVersion, error) {
+	path := fmt.Sprintf("/plugin/registry/%s/%s", url.PathEscape(name), url.PathEscape(version))
+	var res sdk.GRPCPluginVersion
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c client) PluginRegistryPublish(name string, v *sdk.GRPCPluginVersion) error {
+	path := fmt.Sprintf("/admin/plugin/%s/version", url.PathEscape(name))
+	_, err := c.PostJSON(context.Background(), path, v, v)
+	return err
+}
+
+func (c client) PluginRegistryDelete(name, version string) error {
+	path := fmt.Sprintf("/admin/plugin/%s/version/%s", url.PathEscape(name), url.PathEscape(version))
+	_, err := c.DeleteJSON(context.Background(), path, nil)
+	return err
+}
+
+func (c client) PluginRegistryInstall(name, version string) error {
+	path := fmt.Sprintf("/admin/plugin/%s/version/%s/install", url.PathEscape(name), url.PathEscape(version))
+	_, err := c.PostJSON(context.Background(), path, nil, nil)
+	return err
+}
+
+func (c client) PluginRegistryGetBinary(name, version, os, arch string, w io.Writer) error {
+	path := fmt.Sprintf("/plugin/registry/%s/%s/binary/%s/%s", url.PathEscape(name), url.PathEscape(version), os, arch)
+	reader, _, _, err := c.Stream(context.Background(), "GET", path, nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
@@ -83,3 +83,87 @@ func (c *client) ProjectGroupsImport(projectKey string, content io.Reader, forma
 
 	return proj, nil
 }
+
+func (c *client) ProjectTestHistory(projectKey string, suite, name string) ([]sdk.TestHistory, error) {
+	var res []sdk.TestHistory
+	path := fmt.Sprintf("/project/%s/tests/history?suite=%s&name=%s", projectKey, url.QueryEscape(suite), url.QueryEscape(name))
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) ProjectFlakyTests(projectKey string, limit, days int) ([]sdk.FlakyTest, error) {
+	var res []sdk.FlakyTest
+	path := fmt.Sprintf("/project/%s/tests/flaky?limit=%d&days=%d", projectKey, limit, days)
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) ProjectDORAMetrics(projectKey string, days int) (*sdk.DORAReport, error) {
+	var res sdk.DORAReport
+	path := fmt.Sprintf("/project/%s/dora?days=%d", projectKey, days)
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) ProjectRetentionSettingsGet(projectKey string) (*sdk.ProjectRetentionSettings, error) {
+	var res sdk.ProjectRetentionSettings
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/project/%s/retention", projectKey), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) ProjectRetentionSettingsUpdate(projectKey string, s sdk.ProjectRetentionSettings) error {
+	_, err := c.PutJSON(context.Background(), fmt.Sprintf("/project/%s/retention", projectKey), &s, nil)
+	return err
+}
+
+func (c *client) ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error) {
+	var res []sdk.FreezeWindow
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/project/%s/freeze", projectKey), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) ProjectFreezeWindowAdd(projectKey string, w *sdk.FreezeWindow) error {
+	_, err := c.PostJSON(context.Background(), fmt.Sprintf("/project/%s/freeze", projectKey), w, w)
+	return err
+}
+
+func (c *client) ProjectFreezeWindowDelete(projectKey string, name string) error {
+	_, err := c.DeleteJSON(context.Background(), fmt.Sprintf("/project/%s/freeze/%s", projectKey, url.PathEscape(name)), nil)
+	return err
+}
+
+func (c *client) ProjectFreezeOverrideList(projectKey string) ([]sdk.FreezeWindowOverride, error) {
+	var res []sdk.FreezeWindowOverride
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/project/%s/freeze/override", projectKey), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) ProjectDeploymentList(projectKey string, application, environment string) ([]sdk.EnvironmentDeployment, error) {
+	var res []sdk.EnvironmentDeployment
+	path := fmt.Sprintf("/project/%s/deployments?application=%s&environment=%s", projectKey, url.QueryEscape(application), url.QueryEscape(environment))
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) ProjectSBOMSearch(projectKey string, name, version string) ([]sdk.SBOMSearchResult, error) {
+	var res []sdk.SBOMSearchResult
+	path := fmt.Sprintf("/project/%s/sbom/search?name=%s&version=%s", projectKey, url.QueryEscape(name), url.QueryEscape(version))
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
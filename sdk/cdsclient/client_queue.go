@@ -255,6 +255,16 @@ func (c *client) QueueSendCoverage(ctx context.Context, id int64, report coverag
 	return err
 }
 
+// QueueJobCoverage returns the coverage of the node run of a job, with the baseline of the default branch.
+func (c *client) QueueJobCoverage(ctx context.Context, id int64) (*sdk.WorkflowNodeRunCoverage, error) {
+	var cov sdk.WorkflowNodeRunCoverage
+	path := fmt.Sprintf("/queue/workflows/%d/coverage", id)
+	if _, err := c.GetJSON(ctx, path, &cov); err != nil {
+		return nil, err
+	}
+	return &cov, nil
+}
+
 func (c *client) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
 	path := fmt.Sprintf("/queue/workflows/%d/test", id)
 	_, err := c.PostJSON(ctx, path, report, nil)
@@ -273,6 +283,12 @@ func (c *client) QueueSendVulnerability(ctx context.Context, id int64, report sd
 	return err
 }
 
+func (c *client) QueueSendCodeComments(ctx context.Context, id int64, comments []sdk.WorkflowNodeRunCodeComment) error {
+	path := fmt.Sprintf("/queue/workflows/%d/comments", id)
+	_, err := c.PostJSON(ctx, path, comments, nil)
+	return err
+}
+
 func (c *client) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
 	path := fmt.Sprintf("/queue/workflows/%d/step", id)
 	_, err := c.PostJSON(ctx, path, res, nil)
@@ -453,30 +469,26 @@ func (c *client) queueDirectArtifactUpload(projectKey, integrationName string, n
 		return errmd5
 	}
 
-	//Read the file once
-	fileContent, errFileContent := ioutil.ReadAll(f)
-	if errFileContent != nil {
-		return errFileContent
-	}
-
 	_, name := filepath.Split(filePath)
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
+
+	// Form values have to be sent before the file to allow the API to stream it to the storage backend
+	writer.WriteField("size", strconv.FormatInt(stat.Size(), 10))                 // nolint
+	writer.WriteField("perm", strconv.FormatUint(uint64(stat.Mode().Perm()), 10)) // nolint
+	writer.WriteField("md5sum", md5sum)                                           // nolint
+	writer.WriteField("sha512sum", sha512sum)                                     // nolint
+	writer.WriteField("nodeJobRunID", fmt.Sprintf("%d", nodeJobRunID))            // nolint
+
 	part, errc := writer.CreateFormFile(name, filepath.Base(filePath))
 	if errc != nil {
 		return errc
 	}
 
-	if _, err := io.Copy(part, bytes.NewBuffer(fileContent)); err != nil {
+	if _, err := io.Copy(part, f); err != nil {
 		return err
 	}
 
-	writer.WriteField("size", strconv.FormatInt(stat.Size(), 10))                 // nolint
-	writer.WriteField("perm", strconv.FormatUint(uint64(stat.Mode().Perm()), 10)) // nolint
-	writer.WriteField("md5sum", md5sum)                                           // nolint
-	writer.WriteField("sha512sum", sha512sum)                                     // nolint
-	writer.WriteField("nodeJobRunID", fmt.Sprintf("%d", nodeJobRunID))            // nolint
-
 	if errclose := writer.Close(); errclose != nil {
 		return errclose
 	}
@@ -507,6 +519,24 @@ func (c *client) QueueJobTag(ctx context.Context, jobID int64, tags []sdk.Workfl
 	return err
 }
 
+func (c *client) QueueArtifactAttestation(ctx context.Context, jobID int64, req sdk.WorkflowArtifactAttestationRequest) ([]sdk.WorkflowArtifactAttestation, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/artifact/attestation", jobID)
+	var attestations []sdk.WorkflowArtifactAttestation
+	if _, err := c.PostJSON(ctx, path, req, &attestations); err != nil {
+		return nil, err
+	}
+	return attestations, nil
+}
+
+func (c *client) QueueJobSBOM(ctx context.Context, jobID int64, sbom sdk.WorkflowRunSBOM) (*sdk.WorkflowRunSBOM, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/sbom", jobID)
+	var res sdk.WorkflowRunSBOM
+	if _, err := c.PostJSON(ctx, path, sbom, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func (c *client) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	status, err := c.PostJSON(ctx, "/queue/workflows/log/service", logs, nil)
 	if status >= 400 {
@@ -540,6 +570,13 @@ func (c *client) QueueStaticFilesUpload(ctx context.Context, projectKey, integra
 func (c *client) queueDirectStaticFilesUpload(projectKey, integrationName string, staticFile *sdk.StaticFiles, tarContent io.Reader) (string, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
+
+	// Form values have to be sent before the file to allow the API to stream it to the storage backend
+	writer.WriteField("name", staticFile.Name)                                    // nolint
+	writer.WriteField("entrypoint", staticFile.EntryPoint)                        // nolint
+	writer.WriteField("static_key", staticFile.StaticKey)                         // nolint
+	writer.WriteField("nodeJobRunID", fmt.Sprintf("%d", staticFile.NodeJobRunID)) // nolint
+
 	part, errc := writer.CreateFormFile("archive.tar", "archive.tar")
 	if errc != nil {
 		return "", errc
@@ -549,11 +586,6 @@ func (c *client) queueDirectStaticFilesUpload(projectKey, integrationName string
 		return "", err
 	}
 
-	writer.WriteField("name", staticFile.Name)                                    // nolint
-	writer.WriteField("entrypoint", staticFile.EntryPoint)                        // nolint
-	writer.WriteField("static_key", staticFile.StaticKey)                         // nolint
-	writer.WriteField("nodeJobRunID", fmt.Sprintf("%d", staticFile.NodeJobRunID)) // nolint
-
 	if errclose := writer.Close(); errclose != nil {
 		return "", errclose
 	}
@@ -151,3 +151,25 @@ func (c *client) TemplateDeleteInstance(groupName, templateSlug string, id int64
 
 	return nil
 }
+
+func (c *client) TemplateGetDrift(groupName, templateSlug string) ([]sdk.WorkflowTemplateInstanceDrift, error) {
+	url := fmt.Sprintf("/template/%s/%s/drift", groupName, templateSlug)
+
+	var drifts []sdk.WorkflowTemplateInstanceDrift
+	if _, err := c.GetJSON(context.Background(), url, &drifts); err != nil {
+		return nil, err
+	}
+
+	return drifts, nil
+}
+
+func (c *client) TemplateReapply(groupName, templateSlug string, req sdk.WorkflowTemplateReapplyRequest) (*sdk.WorkflowTemplateBulk, error) {
+	url := fmt.Sprintf("/template/%s/%s/reapply", groupName, templateSlug)
+
+	var res sdk.WorkflowTemplateBulk
+	if _, err := c.PostJSON(context.Background(), url, req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
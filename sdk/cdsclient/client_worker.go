@@ -64,15 +64,29 @@ func (c *client) WorkerRegister(ctx context.Context, authToken string, form sdk.
 	if err != nil {
 		return nil, false, err
 	}
-	c.config.SessionToken = headers.Get("X-CDS-JWT")
+	c.config.SetSessionToken(headers.Get("X-CDS-JWT"))
 
 	if c.config.Verbose {
-		fmt.Printf("Registering session %s for worker %s\n", c.config.SessionToken[:12], w.Name)
+		fmt.Printf("Registering session %s for worker %s\n", c.config.GetSessionToken()[:12], w.Name)
 	}
 
 	return &w, w.Uptodate, nil
 }
 
+func (c *client) WorkerRotateSession(ctx context.Context) (*sdk.AuthSession, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var session sdk.AuthSession
+
+	_, headers, _, err := c.RequestJSON(ctx, "POST", "/auth/consumer/worker/rotate", nil, &session)
+	if err != nil {
+		return nil, err
+	}
+	c.config.SetSessionToken(headers.Get("X-CDS-JWT"))
+
+	return &session, nil
+}
+
 func (c *client) WorkerSetStatus(ctx context.Context, status string) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -36,6 +36,24 @@ func (c *client) WorkerModelsEnabled() ([]sdk.Model, error) {
 	return models, nil
 }
 
+// WorkerModelTrustPolicy retrieves the trust policy for worker model images.
+func (c *client) WorkerModelTrustPolicy() (sdk.WorkerModelTrustPolicy, error) {
+	var p sdk.WorkerModelTrustPolicy
+	if _, err := c.GetJSON(context.Background(), "/worker/model/trust-policy", &p); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// WorkerModelTrustPolicyUpdate sets the trust policy for worker model images.
+func (c *client) WorkerModelTrustPolicyUpdate(p sdk.WorkerModelTrustPolicy) (sdk.WorkerModelTrustPolicy, error) {
+	var res sdk.WorkerModelTrustPolicy
+	if _, err := c.PutJSON(context.Background(), "/worker/model/trust-policy", &p, &res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
 // WorkerModels retrieves all worker models.
 func (c *client) WorkerModels(filter *WorkerModelFilter) ([]sdk.Model, error) {
 	var mods []RequestModifier
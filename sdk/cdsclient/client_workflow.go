@@ -41,6 +41,15 @@ func (c *client) WorkflowUpdate(projectKey, name string, wf *sdk.Workflow) error
 	return nil
 }
 
+func (c *client) WorkflowGraphPatch(projectKey, name string, patch sdk.WorkflowGraphPatch, dryRun bool) (*sdk.Workflow, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/graph?dryRun=%t", projectKey, name, dryRun)
+	var wf sdk.Workflow
+	if _, _, _, err := c.RequestJSON(context.Background(), http.MethodPatch, url, patch, &wf); err != nil {
+		return nil, err
+	}
+	return &wf, nil
+}
+
 func (c *client) WorkflowGroupAdd(projectKey, name, groupName string, permission int) error {
 	gp := sdk.GroupPermission{
 		Group:      sdk.Group{Name: groupName},
@@ -106,6 +115,14 @@ func (c *client) WorkflowRunSearch(projectKey string, offset, limit int64, filte
 	return runs, nil
 }
 
+func (c *client) WorkflowRunsSearch(projectKey string, search sdk.WorkflowRunSearch) (*sdk.WorkflowRunSearchResult, error) {
+	var res sdk.WorkflowRunSearchResult
+	if _, err := c.PostJSON(context.Background(), fmt.Sprintf("/project/%s/runs/search", projectKey), search, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func (c *client) WorkflowRunList(projectKey string, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error) {
 	if offset < 0 {
 		offset = 0
@@ -136,6 +153,135 @@ func (c *client) WorkflowRunArtifacts(projectKey string, workflowName string, nu
 	return arts, nil
 }
 
+func (c *client) WorkflowRunArtifactsDiff(projectKey string, workflowName string, number int64, withFiles bool) (*sdk.WorkflowRunArtifactsDiff, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/artifacts/diff?files=%t", projectKey, workflowName, number, withFiles)
+	var diff sdk.WorkflowRunArtifactsDiff
+	if _, err := c.GetJSON(context.Background(), url, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+func (c *client) WorkflowRunArtifactsPromote(projectKey string, workflowName string, number int64, req sdk.WorkflowArtifactPromotionRequest) ([]sdk.WorkflowArtifactPromotion, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/artifacts/promote", projectKey, workflowName, number)
+	ps := []sdk.WorkflowArtifactPromotion{}
+	if _, err := c.PostJSON(context.Background(), url, req, &ps); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+func (c *client) WorkflowArtifactPromotions(projectKey string, workflowName string) ([]sdk.WorkflowArtifactPromotion, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/artifacts/promoted", projectKey, workflowName)
+	ps := []sdk.WorkflowArtifactPromotion{}
+	if _, err := c.GetJSON(context.Background(), url, &ps); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+func (c *client) WorkflowArtifactPromotionDownload(projectKey string, workflowName string, p sdk.WorkflowArtifactPromotion, w io.Writer) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/artifacts/promoted/%d", projectKey, workflowName, p.ID)
+	reader, _, _, err := c.Stream(context.Background(), "GET", url, nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+func (c *client) WorkflowArtifactPromotionDelete(projectKey string, workflowName string, id int64) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/artifacts/promoted/%d", projectKey, workflowName, id)
+	_, err := c.DeleteJSON(context.Background(), url, nil)
+	return err
+}
+
+func (c *client) WorkflowArtifactAttestation(projectKey string, workflowName string, artifactID int64) (*sdk.WorkflowArtifactAttestation, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/artifact/%d/attestation", projectKey, workflowName, artifactID)
+	var a sdk.WorkflowArtifactAttestation
+	if _, err := c.GetJSON(context.Background(), url, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (c *client) WorkflowArtifactAttestationVerify(projectKey string, workflowName string, artifactID int64) (*sdk.WorkflowArtifactAttestationVerification, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/artifact/%d/attestation/verify", projectKey, workflowName, artifactID)
+	var v sdk.WorkflowArtifactAttestationVerification
+	if _, err := c.GetJSON(context.Background(), url, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (c *client) WorkflowRunSBOMs(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunSBOM, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/sbom", projectKey, workflowName, number)
+	sboms := []sdk.WorkflowRunSBOM{}
+	if _, err := c.GetJSON(context.Background(), url, &sboms); err != nil {
+		return nil, err
+	}
+	return sboms, nil
+}
+
+func (c *client) WorkflowRunChangelogs(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunChangelog, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/changelog", projectKey, workflowName, number)
+	changelogs := []sdk.WorkflowRunChangelog{}
+	if _, err := c.GetJSON(context.Background(), url, &changelogs); err != nil {
+		return nil, err
+	}
+	return changelogs, nil
+}
+
+func (c *client) WorkflowRunTestResults(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunTestResult, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/tests", projectKey, workflowName, number)
+	rs := []sdk.WorkflowRunTestResult{}
+	if _, err := c.GetJSON(context.Background(), url, &rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (c *client) WorkflowCoverageTrend(projectKey string, workflowName string, branch string, limit int64) ([]sdk.WorkflowCoverageTrend, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/coverage?branch=%s&limit=%d", projectKey, workflowName, url.QueryEscape(branch), limit)
+	ts := []sdk.WorkflowCoverageTrend{}
+	if _, err := c.GetJSON(context.Background(), path, &ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+func (c *client) WorkflowInsights(projectKey string, workflowName string, days int) (*sdk.WorkflowInsights, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/insights?days=%d", projectKey, workflowName, days)
+	var is sdk.WorkflowInsights
+	if _, err := c.GetJSON(context.Background(), path, &is); err != nil {
+		return nil, err
+	}
+	return &is, nil
+}
+
+func (c *client) WorkflowRunSBOMDownload(projectKey string, workflowName string, number, id int64, w io.Writer) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/sbom/%d", projectKey, workflowName, number, id)
+	reader, _, _, err := c.Stream(context.Background(), "GET", url, nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+func (c *client) WorkflowRunNotificationDeliveries(projectKey string, workflowName string, number int64) ([]sdk.WorkflowNotificationDelivery, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/notifications/deliveries", projectKey, workflowName, number)
+	ds := []sdk.WorkflowNotificationDelivery{}
+	if _, err := c.GetJSON(context.Background(), url, &ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
 func (c *client) WorkflowNodeRun(projectKey string, workflowName string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d", projectKey, workflowName, number, nodeRunID)
 	run := sdk.WorkflowNodeRun{}
@@ -145,6 +291,15 @@ func (c *client) WorkflowNodeRun(projectKey string, workflowName string, number
 	return &run, nil
 }
 
+func (c *client) WorkflowNodeRunManifest(projectKey string, workflowName string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRunManifest, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/manifest", projectKey, workflowName, number, nodeRunID)
+	var m sdk.WorkflowNodeRunManifest
+	if _, err := c.GetJSON(context.Background(), url, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
 func (c *client) WorkflowRunNumberGet(projectKey string, workflowName string) (*sdk.WorkflowRunNumber, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/num", projectKey, workflowName)
 	runNumber := sdk.WorkflowRunNumber{}
@@ -176,6 +331,71 @@ func (c *client) WorkflowNodeRunJobStep(projectKey string, workflowName string,
 	return &buildState, nil
 }
 
+func (c *client) WorkflowRunLogsDownload(projectKey string, workflowName string, number int64, format string, w io.Writer) error {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/logs/download?format=%s", projectKey, workflowName, number, url.QueryEscape(format))
+	reader, _, code, err := c.Stream(context.Background(), "GET", path, nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if code >= 400 {
+		body, _ := ioutil.ReadAll(reader)
+		if err := sdk.DecodeError(body); err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot download logs of workflow run %d: HTTP code %d", number, code)
+	}
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+func (c *client) WorkflowRunReportDownload(projectKey string, workflowName string, number int64, format string, w io.Writer) error {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/report?format=%s", projectKey, workflowName, number, url.QueryEscape(format))
+	reader, _, code, err := c.Stream(context.Background(), "GET", path, nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if code >= 400 {
+		body, _ := ioutil.ReadAll(reader)
+		if err := sdk.DecodeError(body); err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot download report of workflow run %d: HTTP code %d", number, code)
+	}
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+func (c *client) WorkflowRunLinks(projectKey string, workflowName string, number int64) (*sdk.WorkflowRunLinks, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/links", projectKey, workflowName, number)
+	var links sdk.WorkflowRunLinks
+	if _, err := c.GetJSON(context.Background(), url, &links); err != nil {
+		return nil, err
+	}
+	return &links, nil
+}
+
+func (c *client) WorkflowNodeRunGateURL(projectKey string, workflowName string, number, nodeRunID int64) (*sdk.WorkflowNodeRunGateURL, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/gate/url", projectKey, workflowName, number, nodeRunID)
+	var res sdk.WorkflowNodeRunGateURL
+	if _, err := c.PostJSON(context.Background(), url, nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) WorkflowNodeRunChangelogCompute(projectKey string, workflowName string, number, nodeRunID int64) (*sdk.WorkflowRunChangelog, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/changelog", projectKey, workflowName, number, nodeRunID)
+	var res sdk.WorkflowRunChangelog
+	if _, err := c.PostJSON(context.Background(), url, nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func (c *client) WorkflowNodeRunArtifactDownload(projectKey string, workflowName string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error {
 	var url = fmt.Sprintf("/project/%s/workflows/%s/artifact/%d", projectKey, workflowName, a.ID)
 	var reader io.ReadCloser
@@ -244,7 +464,6 @@ func (c *client) WorkflowRunFromManual(projectKey string, workflowName string, m
 		log.Println("Payload: ", manual.Payload)
 	}
 
-	url := fmt.Sprintf("/project/%s/workflows/%s/runs", projectKey, workflowName)
 	content := sdk.WorkflowRunPostHandlerOption{Manual: &manual}
 	if number > 0 {
 		content.Number = &number
@@ -252,6 +471,11 @@ func (c *client) WorkflowRunFromManual(projectKey string, workflowName string, m
 	if fromNodeID > 0 {
 		content.FromNodeIDs = []int64{fromNodeID}
 	}
+	return c.WorkflowRunWithOptions(projectKey, workflowName, content)
+}
+
+func (c *client) WorkflowRunWithOptions(projectKey string, workflowName string, content sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRun, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs", projectKey, workflowName)
 	run := &sdk.WorkflowRun{}
 	code, err := c.PostJSON(context.Background(), url, &content, run)
 	if err != nil {
@@ -264,6 +488,90 @@ func (c *client) WorkflowRunFromManual(projectKey string, workflowName string, m
 	return run, nil
 }
 
+func (c *client) WorkflowTriggerURLGenerate(projectKey string, workflowName string, req sdk.WorkflowTriggerURLRequest) (*sdk.WorkflowTriggerURL, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/trigger/url", projectKey, workflowName)
+	var res sdk.WorkflowTriggerURL
+	if _, err := c.PostJSON(context.Background(), url, req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) WorkflowTriggerURLList(projectKey string, workflowName string) ([]sdk.WorkflowTriggerURL, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/trigger/url", projectKey, workflowName)
+	var res []sdk.WorkflowTriggerURL
+	if _, err := c.GetJSON(context.Background(), url, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) WorkflowTriggerURLRevoke(projectKey string, workflowName string, id string) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/trigger/url/%s", projectKey, workflowName, id)
+	_, err := c.DeleteJSON(context.Background(), url, nil)
+	return err
+}
+
+func (c *client) WorkflowTriggerExplain(projectKey string, workflowName string, commit, branch string) (*sdk.WorkflowTriggerExplain, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/trigger/explain?commit=%s&branch=%s", projectKey, workflowName, url.QueryEscape(commit), url.QueryEscape(branch))
+	var res sdk.WorkflowTriggerExplain
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) WorkflowTimerList(projectKey string, workflowName string) ([]sdk.WorkflowTimer, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/timers", projectKey, workflowName)
+	var res []sdk.WorkflowTimer
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) WorkflowTimerAdd(projectKey string, workflowName string, t *sdk.WorkflowTimer) error {
+	path := fmt.Sprintf("/project/%s/workflows/%s/timers", projectKey, workflowName)
+	_, err := c.PostJSON(context.Background(), path, t, t)
+	return err
+}
+
+func (c *client) WorkflowTimerDelete(projectKey string, workflowName string, timerName string) error {
+	path := fmt.Sprintf("/project/%s/workflows/%s/timers/%s", projectKey, workflowName, url.PathEscape(timerName))
+	_, err := c.DeleteJSON(context.Background(), path, nil)
+	return err
+}
+
+func (c *client) WorkflowRunRehydrate(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/rehydrate", projectKey, workflowName, number)
+	run := &sdk.WorkflowRun{}
+	if _, err := c.PostJSON(context.Background(), url, nil, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (c *client) WorkflowScheduledRunList(projectKey string, workflowName string) ([]sdk.WorkflowScheduledRun, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/scheduled-runs", projectKey, workflowName)
+	var res []sdk.WorkflowScheduledRun
+	if _, err := c.GetJSON(context.Background(), path, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) WorkflowScheduledRunAdd(projectKey string, workflowName string, r *sdk.WorkflowScheduledRun) error {
+	path := fmt.Sprintf("/project/%s/workflows/%s/scheduled-runs", projectKey, workflowName)
+	_, err := c.PostJSON(context.Background(), path, r, r)
+	return err
+}
+
+func (c *client) WorkflowScheduledRunCancel(projectKey string, workflowName string, uuid string) error {
+	path := fmt.Sprintf("/project/%s/workflows/%s/scheduled-runs/%s/cancel", projectKey, workflowName, url.PathEscape(uuid))
+	_, err := c.PostJSON(context.Background(), path, nil, nil)
+	return err
+}
+
 func (c *client) WorkflowStop(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/stop", projectKey, workflowName, number)
 
@@ -54,3 +54,12 @@ func (c *client) WorkflowAsCodePerform(projectKey string, operationID string) ([
 	}
 	return messages, nil
 }
+
+func (c *client) WorkflowAsCodeConvert(format string, files map[string]string) (*sdk.AsCodeConversion, error) {
+	res := new(sdk.AsCodeConversion)
+	path := fmt.Sprintf("/ascode/convert/%s", format)
+	if _, err := c.PostJSON(context.Background(), path, sdk.AsCodeConversion{Files: files}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -15,3 +15,11 @@ func (c *client) WorkflowAllHooksList() ([]sdk.NodeHook, error) {
 	}
 	return w, nil
 }
+
+func (c *client) WorkflowScheduledRunExecute(r sdk.WorkflowScheduledRun) (*sdk.WorkflowRun, error) {
+	var run sdk.WorkflowRun
+	if _, err := c.PostJSON(context.Background(), "/workflow/hook/scheduled-run", r, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
@@ -38,6 +38,20 @@ type ServiceConfig struct {
 	Verbose               bool
 }
 
+// GetSessionToken returns the current session token, it can be rotated while requests are sent.
+func (c *Config) GetSessionToken() string {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+	return c.SessionToken
+}
+
+// SetSessionToken replaces the current session token.
+func (c *Config) SetSessionToken(token string) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+	c.SessionToken = token
+}
+
 func (c *Config) HasValidSessionToken() bool {
 	c.Mutex.Lock()
 	defer c.Mutex.Unlock()
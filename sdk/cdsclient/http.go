@@ -18,6 +18,7 @@ import (
 	"github.com/ovh/cds/cli"
 
 	"github.com/dgrijalva/jwt-go"
+	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/tracingutils"
@@ -173,7 +174,7 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 
 	if checkToken && !c.config.HasValidSessionToken() && c.config.BuitinConsumerAuthenticationToken != "" {
 		if c.config.Verbose {
-			fmt.Printf("session token invalid: (%s). Relogin...\n", c.config.SessionToken)
+			fmt.Printf("session token invalid: (%s). Relogin...\n", c.config.GetSessionToken())
 		}
 		resp, err := c.AuthConsumerSignin(sdk.ConsumerBuiltin, sdk.AuthConsumerSigninRequest{"token": c.config.BuitinConsumerAuthenticationToken})
 		if err != nil {
@@ -182,7 +183,7 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 		if c.config.Verbose {
 			fmt.Println("jwt: ", resp.Token[:12])
 		}
-		c.config.SessionToken = resp.Token
+		c.config.SetSessionToken(resp.Token)
 	}
 
 	labels := pprof.Labels("path", path, "method", method)
@@ -218,12 +219,9 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 		req.Header.Set("Date", date)
 		req.Header.Set("X-CDS-RemoteTime", date)
 
+		tracingutils.DefaultFormat.Inject(ctx, propagation.HeaderCarrier(req.Header))
 		if c.config.Verbose {
-			log.Printf("Stream > context> %s\n", tracingutils.DumpContext(ctx))
-		}
-		spanCtx, ok := tracingutils.ContextToSpanContext(ctx)
-		if ok {
-			tracingutils.DefaultFormat.SpanContextToRequest(spanCtx, req)
+			log.Printf("Stream > context> %s=%s\n", tracingutils.TraceParentHeader, req.Header.Get(tracingutils.TraceParentHeader))
 		}
 
 		for i := range mods {
@@ -240,11 +238,12 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 
 		//No auth on signing routes or on url that is not cds configured in config.Host
 		if strings.HasPrefix(url, c.config.Host) && !signinRouteRegexp.MatchString(path) {
-			if _, _, err := new(jwt.Parser).ParseUnverified(c.config.SessionToken, &sdk.AuthSessionJWTClaims{}); err == nil {
+			sessionToken := c.config.GetSessionToken()
+			if _, _, err := new(jwt.Parser).ParseUnverified(sessionToken, &sdk.AuthSessionJWTClaims{}); err == nil {
 				if c.config.Verbose {
 					fmt.Println("JWT recognized")
 				}
-				auth := "Bearer " + c.config.SessionToken
+				auth := "Bearer " + sessionToken
 				req.Header.Add("Authorization", auth)
 			}
 		}
@@ -275,7 +274,7 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 		}
 
 		if resp.StatusCode == 401 {
-			c.config.SessionToken = ""
+			c.config.SetSessionToken("")
 		}
 
 		// if everything is fine, return body
@@ -324,7 +323,7 @@ func (c *client) UploadMultiPart(method string, path string, body *bytes.Buffer,
 		if err != nil {
 			return nil, -1, err
 		}
-		c.config.SessionToken = resp.Token
+		c.config.SetSessionToken(resp.Token)
 	}
 
 	var req *http.Request
@@ -342,11 +341,12 @@ func (c *client) UploadMultiPart(method string, path string, body *bytes.Buffer,
 
 	//No auth on signing routes
 	if !signinRouteRegexp.MatchString(path) {
-		if _, _, err := new(jwt.Parser).ParseUnverified(c.config.SessionToken, &sdk.AuthSessionJWTClaims{}); err == nil {
+		sessionToken := c.config.GetSessionToken()
+		if _, _, err := new(jwt.Parser).ParseUnverified(sessionToken, &sdk.AuthSessionJWTClaims{}); err == nil {
 			if c.config.Verbose {
 				fmt.Println("JWT recognized")
 			}
-			auth := "Bearer " + c.config.SessionToken
+			auth := "Bearer " + sessionToken
 			req.Header.Add("Authorization", auth)
 		}
 	}
@@ -365,7 +365,7 @@ func (c *client) UploadMultiPart(method string, path string, body *bytes.Buffer,
 	}
 
 	if resp.StatusCode == 401 {
-		c.config.SessionToken = ""
+		c.config.SetSessionToken("")
 	}
 
 	var respBody []byte
@@ -36,7 +36,7 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 		if err != nil {
 			return err
 		}
-		c.config.SessionToken = resp.Token
+		c.config.SetSessionToken(resp.Token)
 	}
 
 	labels := pprof.Labels("path", path, "method", "GET")
@@ -62,7 +62,7 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Connection", "close")
 
-	auth := "Bearer " + c.config.SessionToken
+	auth := "Bearer " + c.config.GetSessionToken()
 	req.Header.Add("Authorization", auth)
 
 	resp, err := c.httpSSEClient.Do(req)
@@ -71,7 +71,7 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 	}
 
 	if resp.StatusCode == 401 {
-		c.config.SessionToken = ""
+		c.config.SetSessionToken("")
 	}
 
 	br := bufio.NewReader(resp.Body)
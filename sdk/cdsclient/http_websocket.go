@@ -0,0 +1,88 @@
+package cdsclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"runtime/pprof"
+	"strings"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// RequestWebsocket opens a websocket on the given path, subscribes to the events selected by the filters and sends
+// the received events down the channel until the websocket is closed. This is blocking.
+func (c *client) RequestWebsocket(ctx context.Context, path string, filters []sdk.WebsocketFilter, evCh chan<- sdk.WebsocketEvent) error {
+	// Checks that current session_token is still valid
+	// If not, challenge a new one against the authenticationToken
+	if !c.config.HasValidSessionToken() && c.config.BuitinConsumerAuthenticationToken != "" {
+		resp, err := c.AuthConsumerSignin(sdk.ConsumerBuiltin, sdk.AuthConsumerSigninRequest{"token": c.config.BuitinConsumerAuthenticationToken})
+		if err != nil {
+			return err
+		}
+		c.config.SetSessionToken(resp.Token)
+	}
+
+	labels := pprof.Labels("path", path, "method", "GET")
+	ctx = pprof.WithLabels(ctx, labels)
+	pprof.SetGoroutineLabels(ctx)
+
+	uri := c.config.Host + path
+	if strings.HasPrefix(path, "http") {
+		uri = path
+	}
+	uri = "ws" + strings.TrimPrefix(uri, "http")
+
+	config, err := websocket.NewConfig(uri, c.config.Host)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	config.Header = http.Header{}
+	config.Header.Set("Authorization", "Bearer "+c.config.GetSessionToken())
+	if c.config.InsecureSkipVerifyTLS {
+		config.TlsConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		if e, ok := err.(*websocket.DialError); ok && e.Err == websocket.ErrBadStatus {
+			c.config.SetSessionToken("")
+		}
+		return sdk.WithStack(err)
+	}
+	defer conn.Close() // nolint
+
+	// Closing the connection stops the reading when the context is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close() // nolint
+		case <-done:
+		}
+	}()
+
+	for _, f := range filters {
+		if err := websocket.JSON.Send(conn, sdk.WebsocketMessage{Type: sdk.WebsocketMessageSubscribe, Filter: f}); err != nil {
+			return sdk.WithStack(err)
+		}
+	}
+
+	for {
+		var e sdk.WebsocketEvent
+		if err := websocket.JSON.Receive(conn, &e); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return sdk.WithStack(err)
+		}
+		select {
+		case evCh <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
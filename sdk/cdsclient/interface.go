@@ -29,6 +29,8 @@ type TemplateClient interface {
 	TemplateDelete(groupName, templateSlug string) error
 	TemplateGetInstances(groupName, templateSlug string) ([]sdk.WorkflowTemplateInstance, error)
 	TemplateDeleteInstance(groupName, templateSlug string, id int64) error
+	TemplateGetDrift(groupName, templateSlug string) ([]sdk.WorkflowTemplateInstanceDrift, error)
+	TemplateReapply(groupName, templateSlug string, req sdk.WorkflowTemplateReapplyRequest) (*sdk.WorkflowTemplateBulk, error)
 }
 
 // Admin expose all function to CDS administration
@@ -45,11 +47,22 @@ type Admin interface {
 	AdminCDSMigrationList() ([]sdk.Migration, error)
 	AdminCDSMigrationCancel(id int64) error
 	AdminCDSMigrationReset(id int64) error
+	AdminUserScrub(req sdk.UserScrubRequest) (*sdk.UserScrubReport, error)
+	AdminProjectQueueSettingsGet(projectKey string) (*sdk.ProjectQueueSettings, error)
+	AdminProjectQueueSettingsUpdate(projectKey string, settings sdk.ProjectQueueSettings) error
+	AdminQuotaList() ([]sdk.QuotaReport, error)
+	AdminQuotaGet(entityType, name string) (*sdk.QuotaReport, error)
+	AdminQuotaUpdate(entityType, name string, q sdk.Quota) error
+	AdminQuotaDelete(entityType, name string) error
+	AdminSBOMSearch(name, version string) ([]sdk.SBOMSearchResult, error)
+	AdminAssetsExport(w io.Writer) error
+	AdminAssetsImport(r io.Reader) (*sdk.AssetsBundleReport, error)
 	Services() ([]sdk.Service, error)
 	ServicesByName(name string) (*sdk.Service, error)
 	ServiceDelete(name string) error
 	ServicesByType(stype string) ([]sdk.Service, error)
 	ServiceNameCallGET(name string, url string) ([]byte, error)
+	ServiceNameCallPOST(name string, url string, body []byte) ([]byte, error)
 	ServiceCallGET(stype string, url string) ([]byte, error)
 	ServiceCallPOST(stype string, url string, body []byte) ([]byte, error)
 	ServiceCallPUT(stype string, url string, body []byte) ([]byte, error)
@@ -68,6 +81,7 @@ type ExportImportInterface interface {
 	WorkerModelExport(groupName, name, format string) ([]byte, error)
 	WorkerModelImport(content io.Reader, format string, force bool) (*sdk.Model, error)
 	WorkflowPush(projectKey string, tarContent io.Reader, mods ...RequestModifier) ([]string, *tar.Reader, error)
+	ProjectPush(projectKey string, tarContent io.Reader, mods ...RequestModifier) ([]string, error)
 	WorkflowAsCodeInterface
 }
 
@@ -76,6 +90,7 @@ type WorkflowAsCodeInterface interface {
 	WorkflowAsCodeStart(projectKey string, repoURL string, repoStrategy sdk.RepositoryStrategy) (*sdk.Operation, error)
 	WorkflowAsCodeInfo(projectKey string, operationID string) (*sdk.Operation, error)
 	WorkflowAsCodePerform(projectKey string, operationID string) ([]string, error)
+	WorkflowAsCodeConvert(format string, files map[string]string) (*sdk.AsCodeConversion, error)
 }
 
 // RepositoriesManagerInterface exposes all repostories manager functions
@@ -143,6 +158,8 @@ type EnvironmentVariableClient interface {
 type EventsClient interface {
 	// Must be  run in a go routine
 	EventsListen(ctx context.Context, chanSSEvt chan<- SSEvent)
+	// Must be run in a go routine, receives only the events selected by the filters
+	WebsocketEventsListen(ctx context.Context, filters []sdk.WebsocketFilter, chanMsgReceived chan<- sdk.WebsocketEvent)
 }
 
 // DownloadClient exposes download related functions
@@ -159,6 +176,9 @@ type ActionClient interface {
 	ActionList() ([]sdk.Action, error)
 	ActionImport(content io.Reader, format string) error
 	ActionExport(groupName, name string, format string) ([]byte, error)
+	ActionVersionList(groupName, name string) ([]sdk.ActionVersion, error)
+	ActionVersionPublish(groupName, name, version string) (*sdk.ActionVersion, error)
+	ActionVersionUsage(groupName, name, version string) ([]sdk.ActionVersionUsage, error)
 	ActionBuiltinList() ([]sdk.Action, error)
 	ActionBuiltinGet(name string, mods ...RequestModifier) (*sdk.Action, error)
 }
@@ -214,6 +234,17 @@ type ProjectClient interface {
 	ProjectIntegrationDelete(projectKey string, integrationName string) error
 	ProjectRepositoryManagerList(projectKey string) ([]sdk.ProjectVCSServer, error)
 	ProjectRepositoryManagerDelete(projectKey string, repoManagerName string, force bool) error
+	ProjectSBOMSearch(projectKey string, name, version string) ([]sdk.SBOMSearchResult, error)
+	ProjectTestHistory(projectKey string, suite, name string) ([]sdk.TestHistory, error)
+	ProjectFlakyTests(projectKey string, limit, days int) ([]sdk.FlakyTest, error)
+	ProjectDORAMetrics(projectKey string, days int) (*sdk.DORAReport, error)
+	ProjectRetentionSettingsGet(projectKey string) (*sdk.ProjectRetentionSettings, error)
+	ProjectRetentionSettingsUpdate(projectKey string, s sdk.ProjectRetentionSettings) error
+	ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error)
+	ProjectFreezeWindowAdd(projectKey string, w *sdk.FreezeWindow) error
+	ProjectFreezeWindowDelete(projectKey string, name string) error
+	ProjectFreezeOverrideList(projectKey string) ([]sdk.FreezeWindowOverride, error)
+	ProjectDeploymentList(projectKey string, application, environment string) ([]sdk.EnvironmentDeployment, error)
 }
 
 // ProjectKeysClient exposes project keys related functions
@@ -244,14 +275,18 @@ type QueueClient interface {
 	QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error)
 	QueueJobSendSpawnInfo(ctx context.Context, id int64, in []sdk.SpawnInfo) error
 	QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error
+	QueueJobCoverage(ctx context.Context, id int64) (*sdk.WorkflowNodeRunCoverage, error)
 	QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error
 	QueueSendLogs(ctx context.Context, id int64, log sdk.Log) error
 	QueueSendVulnerability(ctx context.Context, id int64, report sdk.VulnerabilityWorkerReport) error
+	QueueSendCodeComments(ctx context.Context, id int64, comments []sdk.WorkflowNodeRunCodeComment) error
 	QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error
 	QueueSendResult(ctx context.Context, id int64, res sdk.Result) error
 	QueueArtifactUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, time.Duration, error)
 	QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error)
 	QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error
+	QueueArtifactAttestation(ctx context.Context, jobID int64, req sdk.WorkflowArtifactAttestationRequest) ([]sdk.WorkflowArtifactAttestation, error)
+	QueueJobSBOM(ctx context.Context, jobID int64, sbom sdk.WorkflowRunSBOM) (*sdk.WorkflowRunSBOM, error)
 	QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error
 }
 
@@ -278,7 +313,10 @@ type WorkerClient interface {
 	WorkerModelSpawnError(groupName, name string, info sdk.SpawnErrorForm) error
 	WorkerModels(*WorkerModelFilter) ([]sdk.Model, error)
 	WorkerModelsEnabled() ([]sdk.Model, error)
+	WorkerModelTrustPolicy() (sdk.WorkerModelTrustPolicy, error)
+	WorkerModelTrustPolicyUpdate(p sdk.WorkerModelTrustPolicy) (sdk.WorkerModelTrustPolicy, error)
 	WorkerRegister(ctx context.Context, authToken string, form sdk.WorkerRegistrationForm) (*sdk.Worker, bool, error)
+	WorkerRotateSession(ctx context.Context) (*sdk.AuthSession, error)
 	WorkerSetStatus(ctx context.Context, status string) error
 }
 
@@ -286,6 +324,15 @@ type WorkerClient interface {
 type HookClient interface {
 	PollVCSEvents(uuid string, workflowID int64, vcsServer string, timestamp int64) (events sdk.RepositoryEvents, interval time.Duration, err error)
 	VCSConfiguration() (map[string]sdk.VCSConfiguration, error)
+	WorkflowScheduledRunExecute(r sdk.WorkflowScheduledRun) (*sdk.WorkflowRun, error)
+}
+
+// ChatOpsClient exposes functions used for chatops services and identity linkage
+type ChatOpsClient interface {
+	ChatOpsCommand(cmd sdk.ChatOpsCommand) (*sdk.ChatOpsCommandResult, error)
+	ChatOpsLinkToken(req sdk.ChatOpsLinkTokenRequest) (*sdk.ChatOpsLinkToken, error)
+	ChatOpsLink(token string) error
+	ChatOpsUnlink(provider string) error
 }
 
 // WorkflowClient exposes workflows functions
@@ -293,6 +340,7 @@ type WorkflowClient interface {
 	WorkflowList(projectKey string) ([]sdk.Workflow, error)
 	WorkflowGet(projectKey, name string, opts ...RequestModifier) (*sdk.Workflow, error)
 	WorkflowUpdate(projectKey, name string, wf *sdk.Workflow) error
+	WorkflowGraphPatch(projectKey, name string, patch sdk.WorkflowGraphPatch, dryRun bool) (*sdk.Workflow, error)
 	WorkflowDelete(projectKey string, workflowName string) error
 	WorkflowGroupAdd(projectKey, name, groupName string, permission int) error
 	WorkflowGroupDelete(projectKey, name, groupName string) error
@@ -301,16 +349,49 @@ type WorkflowClient interface {
 	WorkflowRunResync(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowRunSearch(projectKey string, offset, limit int64, filter ...Filter) ([]sdk.WorkflowRun, error)
 	WorkflowRunList(projectKey string, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error)
+	WorkflowRunsSearch(projectKey string, search sdk.WorkflowRunSearch) (*sdk.WorkflowRunSearchResult, error)
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
+	WorkflowRunArtifactsDiff(projectKey string, name string, number int64, withFiles bool) (*sdk.WorkflowRunArtifactsDiff, error)
+	WorkflowRunArtifactsPromote(projectKey string, name string, number int64, req sdk.WorkflowArtifactPromotionRequest) ([]sdk.WorkflowArtifactPromotion, error)
+	WorkflowArtifactPromotions(projectKey string, name string) ([]sdk.WorkflowArtifactPromotion, error)
+	WorkflowArtifactPromotionDownload(projectKey string, name string, p sdk.WorkflowArtifactPromotion, w io.Writer) error
+	WorkflowArtifactPromotionDelete(projectKey string, name string, id int64) error
+	WorkflowArtifactAttestation(projectKey string, name string, artifactID int64) (*sdk.WorkflowArtifactAttestation, error)
+	WorkflowArtifactAttestationVerify(projectKey string, name string, artifactID int64) (*sdk.WorkflowArtifactAttestationVerification, error)
+	WorkflowRunSBOMs(projectKey string, name string, number int64) ([]sdk.WorkflowRunSBOM, error)
+	WorkflowRunChangelogs(projectKey string, workflowName string, number int64) ([]sdk.WorkflowRunChangelog, error)
+	WorkflowRunSBOMDownload(projectKey string, name string, number, id int64, w io.Writer) error
+	WorkflowRunTestResults(projectKey string, name string, number int64) ([]sdk.WorkflowRunTestResult, error)
+	WorkflowCoverageTrend(projectKey string, name string, branch string, limit int64) ([]sdk.WorkflowCoverageTrend, error)
+	WorkflowInsights(projectKey string, name string, days int) (*sdk.WorkflowInsights, error)
+	WorkflowRunNotificationDeliveries(projectKey string, name string, number int64) ([]sdk.WorkflowNotificationDelivery, error)
 	WorkflowRunFromHook(projectKey string, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error)
 	WorkflowRunFromManual(projectKey string, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error)
+	WorkflowRunWithOptions(projectKey string, workflowName string, opts sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRun, error)
+	WorkflowTriggerURLGenerate(projectKey string, workflowName string, req sdk.WorkflowTriggerURLRequest) (*sdk.WorkflowTriggerURL, error)
+	WorkflowTriggerURLList(projectKey string, workflowName string) ([]sdk.WorkflowTriggerURL, error)
+	WorkflowTriggerURLRevoke(projectKey string, workflowName string, id string) error
+	WorkflowTriggerExplain(projectKey string, workflowName string, commit, branch string) (*sdk.WorkflowTriggerExplain, error)
+	WorkflowTimerList(projectKey string, workflowName string) ([]sdk.WorkflowTimer, error)
+	WorkflowTimerAdd(projectKey string, workflowName string, t *sdk.WorkflowTimer) error
+	WorkflowTimerDelete(projectKey string, workflowName string, timerName string) error
+	WorkflowScheduledRunList(projectKey string, workflowName string) ([]sdk.WorkflowScheduledRun, error)
+	WorkflowScheduledRunAdd(projectKey string, workflowName string, r *sdk.WorkflowScheduledRun) error
+	WorkflowScheduledRunCancel(projectKey string, workflowName string, uuid string) error
 	WorkflowRunNumberGet(projectKey string, workflowName string) (*sdk.WorkflowRunNumber, error)
 	WorkflowRunNumberSet(projectKey string, workflowName string, number int64) error
 	WorkflowStop(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
+	WorkflowRunRehydrate(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowNodeStop(projectKey string, workflowName string, number, fromNodeID int64) (*sdk.WorkflowNodeRun, error)
 	WorkflowNodeRun(projectKey string, name string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error)
+	WorkflowNodeRunManifest(projectKey string, name string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRunManifest, error)
 	WorkflowNodeRunArtifactDownload(projectKey string, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error
 	WorkflowNodeRunJobStep(projectKey string, workflowName string, number int64, nodeRunID, job int64, step int) (*sdk.BuildState, error)
+	WorkflowRunLogsDownload(projectKey string, workflowName string, number int64, format string, w io.Writer) error
+	WorkflowRunReportDownload(projectKey string, workflowName string, number int64, format string, w io.Writer) error
+	WorkflowRunLinks(projectKey string, workflowName string, number int64) (*sdk.WorkflowRunLinks, error)
+	WorkflowNodeRunGateURL(projectKey string, workflowName string, number, nodeRunID int64) (*sdk.WorkflowNodeRunGateURL, error)
+	WorkflowNodeRunChangelogCompute(projectKey string, workflowName string, number, nodeRunID int64) (*sdk.WorkflowRunChangelog, error)
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
 	WorkflowAllHooksList() ([]sdk.NodeHook, error)
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
@@ -325,6 +406,7 @@ type MonitoringClient interface {
 	MonStatus() (*sdk.MonitoringStatus, error)
 	MonVersion() (*sdk.Version, error)
 	MonDBMigrate() ([]sdk.MonDBMigrate, error)
+	MonServices() (*sdk.MonitoringServicesMatrix, error)
 	MonErrorsGet(requestID string) ([]sdk.Error, error)
 }
 
@@ -368,6 +450,7 @@ type Interface interface {
 	WorkflowClient
 	MonitoringClient
 	HookClient
+	ChatOpsClient
 	Version() (*sdk.Version, error)
 	TemplateClient
 }
@@ -378,7 +461,12 @@ type WorkerInterface interface {
 	QueueClient
 	Requirements() ([]sdk.Requirement, error)
 	WorkerClient
+	WorkflowRunGet(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
+	WorkflowRunArtifactsPromote(projectKey string, name string, number int64, req sdk.WorkflowArtifactPromotionRequest) ([]sdk.WorkflowArtifactPromotion, error)
+	WorkflowArtifactPromotions(projectKey string, name string) ([]sdk.WorkflowArtifactPromotion, error)
+	WorkflowArtifactPromotionDownload(projectKey string, name string, p sdk.WorkflowArtifactPromotion, w io.Writer) error
+	WorkflowRunReportDownload(projectKey string, workflowName string, number int64, format string, w io.Writer) error
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
 	WorkflowCachePull(projectKey, integrationName, ref string) (io.Reader, error)
 	WorkflowRunSearch(projectKey string, offset, limit int64, filter ...Filter) ([]sdk.WorkflowRun, error)
@@ -409,6 +497,12 @@ type GRPCPluginsClient interface {
 	PluginDeleteBinary(name, os, arch string) error
 	PluginGetBinary(name, os, arch string, w io.Writer) error
 	PluginGetBinaryInfos(name, os, arch string) (*sdk.GRPCPluginBinary, error)
+	PluginRegistryList(name string) ([]sdk.GRPCPluginVersion, error)
+	PluginRegistryGet(name, version string) (*sdk.GRPCPluginVersion, error)
+	PluginRegistryPublish(name string, v *sdk.GRPCPluginVersion) error
+	PluginRegistryDelete(name, version string) error
+	PluginRegistryInstall(name, version string) error
+	PluginRegistryGetBinary(name, version, os, arch string, w io.Writer) error
 }
 
 /* ProviderClient exposes allowed methods for providers
@@ -467,6 +561,15 @@ func WithWorkflows() RequestModifier {
 	}
 }
 
+// WithDeepPipelines allow a provider to retrieve a workflow with its pipelines parameters and stages
+func WithDeepPipelines() RequestModifier {
+	return func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("withDeepPipelines", "true")
+		r.URL.RawQuery = q.Encode()
+	}
+}
+
 // AuthClient is the interface for authentication management.
 type AuthClient interface {
 	AuthDriverList() (sdk.AuthDriverResponse, error)
@@ -480,6 +583,9 @@ type AuthClient interface {
 	AuthConsumerDelete(username, id string) error
 	AuthConsumerRegen(username, id string) (sdk.AuthConsumerCreateResponse, error)
 	AuthConsumerCreateForUser(username string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error)
+	AuthConsumerListByProject(projectKey string) (sdk.AuthConsumers, error)
+	AuthConsumerCreateForProject(projectKey string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error)
+	AuthConsumerDeleteForProject(projectKey, id string) error
 	AuthSessionListByUser(username string) (sdk.AuthSessions, error)
 	AuthSessionDelete(username, id string) error
 	AuthMe() (sdk.AuthCurrentConsumerResponse, error)
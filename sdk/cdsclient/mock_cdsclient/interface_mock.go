@@ -191,6 +191,36 @@ func (mr *MockTemplateClientMockRecorder) TemplateDeleteInstance(groupName, temp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateDeleteInstance", reflect.TypeOf((*MockTemplateClient)(nil).TemplateDeleteInstance), groupName, templateSlug, id)
 }
 
+// TemplateGetDrift mocks base method
+func (m *MockTemplateClient) TemplateGetDrift(groupName, templateSlug string) ([]sdk.WorkflowTemplateInstanceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateGetDrift", groupName, templateSlug)
+	ret0, _ := ret[0].([]sdk.WorkflowTemplateInstanceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateGetDrift indicates an expected call of TemplateGetDrift
+func (mr *MockTemplateClientMockRecorder) TemplateGetDrift(groupName, templateSlug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateGetDrift", reflect.TypeOf((*MockTemplateClient)(nil).TemplateGetDrift), groupName, templateSlug)
+}
+
+// TemplateReapply mocks base method
+func (m *MockTemplateClient) TemplateReapply(groupName, templateSlug string, req sdk.WorkflowTemplateReapplyRequest) (*sdk.WorkflowTemplateBulk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateReapply", groupName, templateSlug, req)
+	ret0, _ := ret[0].(*sdk.WorkflowTemplateBulk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateReapply indicates an expected call of TemplateReapply
+func (mr *MockTemplateClientMockRecorder) TemplateReapply(groupName, templateSlug, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateReapply", reflect.TypeOf((*MockTemplateClient)(nil).TemplateReapply), groupName, templateSlug, req)
+}
+
 // MockAdmin is a mock of Admin interface
 type MockAdmin struct {
 	ctrl     *gomock.Controller
@@ -386,6 +416,152 @@ func (mr *MockAdminMockRecorder) AdminCDSMigrationReset(id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationReset", reflect.TypeOf((*MockAdmin)(nil).AdminCDSMigrationReset), id)
 }
 
+// AdminUserScrub mocks base method
+func (m *MockAdmin) AdminUserScrub(req sdk.UserScrubRequest) (*sdk.UserScrubReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminUserScrub", req)
+	ret0, _ := ret[0].(*sdk.UserScrubReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminUserScrub indicates an expected call of AdminUserScrub
+func (mr *MockAdminMockRecorder) AdminUserScrub(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserScrub", reflect.TypeOf((*MockAdmin)(nil).AdminUserScrub), req)
+}
+
+// AdminProjectQueueSettingsGet mocks base method
+func (m *MockAdmin) AdminProjectQueueSettingsGet(projectKey string) (*sdk.ProjectQueueSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminProjectQueueSettingsGet", projectKey)
+	ret0, _ := ret[0].(*sdk.ProjectQueueSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminProjectQueueSettingsGet indicates an expected call of AdminProjectQueueSettingsGet
+func (mr *MockAdminMockRecorder) AdminProjectQueueSettingsGet(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminProjectQueueSettingsGet", reflect.TypeOf((*MockAdmin)(nil).AdminProjectQueueSettingsGet), projectKey)
+}
+
+// AdminProjectQueueSettingsUpdate mocks base method
+func (m *MockAdmin) AdminProjectQueueSettingsUpdate(projectKey string, settings sdk.ProjectQueueSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminProjectQueueSettingsUpdate", projectKey, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminProjectQueueSettingsUpdate indicates an expected call of AdminProjectQueueSettingsUpdate
+func (mr *MockAdminMockRecorder) AdminProjectQueueSettingsUpdate(projectKey, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminProjectQueueSettingsUpdate", reflect.TypeOf((*MockAdmin)(nil).AdminProjectQueueSettingsUpdate), projectKey, settings)
+}
+
+// AdminQuotaList mocks base method
+func (m *MockAdmin) AdminQuotaList() ([]sdk.QuotaReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuotaList")
+	ret0, _ := ret[0].([]sdk.QuotaReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminQuotaList indicates an expected call of AdminQuotaList
+func (mr *MockAdminMockRecorder) AdminQuotaList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuotaList", reflect.TypeOf((*MockAdmin)(nil).AdminQuotaList))
+}
+
+// AdminQuotaGet mocks base method
+func (m *MockAdmin) AdminQuotaGet(entityType, name string) (*sdk.QuotaReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuotaGet", entityType, name)
+	ret0, _ := ret[0].(*sdk.QuotaReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminQuotaGet indicates an expected call of AdminQuotaGet
+func (mr *MockAdminMockRecorder) AdminQuotaGet(entityType, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuotaGet", reflect.TypeOf((*MockAdmin)(nil).AdminQuotaGet), entityType, name)
+}
+
+// AdminQuotaUpdate mocks base method
+func (m *MockAdmin) AdminQuotaUpdate(entityType, name string, q sdk.Quota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuotaUpdate", entityType, name, q)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQuotaUpdate indicates an expected call of AdminQuotaUpdate
+func (mr *MockAdminMockRecorder) AdminQuotaUpdate(entityType, name, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuotaUpdate", reflect.TypeOf((*MockAdmin)(nil).AdminQuotaUpdate), entityType, name, q)
+}
+
+// AdminQuotaDelete mocks base method
+func (m *MockAdmin) AdminQuotaDelete(entityType, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuotaDelete", entityType, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQuotaDelete indicates an expected call of AdminQuotaDelete
+func (mr *MockAdminMockRecorder) AdminQuotaDelete(entityType, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuotaDelete", reflect.TypeOf((*MockAdmin)(nil).AdminQuotaDelete), entityType, name)
+}
+
+// AdminSBOMSearch mocks base method
+func (m *MockAdmin) AdminSBOMSearch(name, version string) ([]sdk.SBOMSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminSBOMSearch", name, version)
+	ret0, _ := ret[0].([]sdk.SBOMSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminSBOMSearch indicates an expected call of AdminSBOMSearch
+func (mr *MockAdminMockRecorder) AdminSBOMSearch(name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminSBOMSearch", reflect.TypeOf((*MockAdmin)(nil).AdminSBOMSearch), name, version)
+}
+
+// AdminAssetsExport mocks base method
+func (m *MockAdmin) AdminAssetsExport(w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminAssetsExport", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminAssetsExport indicates an expected call of AdminAssetsExport
+func (mr *MockAdminMockRecorder) AdminAssetsExport(w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAssetsExport", reflect.TypeOf((*MockAdmin)(nil).AdminAssetsExport), w)
+}
+
+// AdminAssetsImport mocks base method
+func (m *MockAdmin) AdminAssetsImport(r io.Reader) (*sdk.AssetsBundleReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminAssetsImport", r)
+	ret0, _ := ret[0].(*sdk.AssetsBundleReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminAssetsImport indicates an expected call of AdminAssetsImport
+func (mr *MockAdminMockRecorder) AdminAssetsImport(r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAssetsImport", reflect.TypeOf((*MockAdmin)(nil).AdminAssetsImport), r)
+}
+
 // Services mocks base method
 func (m *MockAdmin) Services() ([]sdk.Service, error) {
 	m.ctrl.T.Helper()
@@ -460,6 +636,21 @@ func (mr *MockAdminMockRecorder) ServiceNameCallGET(name, url interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceNameCallGET", reflect.TypeOf((*MockAdmin)(nil).ServiceNameCallGET), name, url)
 }
 
+// ServiceNameCallPOST mocks base method
+func (m *MockAdmin) ServiceNameCallPOST(name, url string, body []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceNameCallPOST", name, url, body)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceNameCallPOST indicates an expected call of ServiceNameCallPOST
+func (mr *MockAdminMockRecorder) ServiceNameCallPOST(name, url, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceNameCallPOST", reflect.TypeOf((*MockAdmin)(nil).ServiceNameCallPOST), name, url, body)
+}
+
 // ServiceCallGET mocks base method
 func (m *MockAdmin) ServiceCallGET(stype, url string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -708,6 +899,26 @@ func (mr *MockExportImportInterfaceMockRecorder) WorkflowPush(projectKey, tarCon
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowPush", reflect.TypeOf((*MockExportImportInterface)(nil).WorkflowPush), varargs...)
 }
 
+// ProjectPush mocks base method
+func (m *MockExportImportInterface) ProjectPush(projectKey string, tarContent io.Reader, mods ...cdsclient.RequestModifier) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, tarContent}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectPush", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectPush indicates an expected call of ProjectPush
+func (mr *MockExportImportInterfaceMockRecorder) ProjectPush(projectKey, tarContent interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, tarContent}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPush", reflect.TypeOf((*MockExportImportInterface)(nil).ProjectPush), varargs...)
+}
+
 // WorkflowAsCodeStart mocks base method
 func (m *MockExportImportInterface) WorkflowAsCodeStart(projectKey, repoURL string, repoStrategy sdk.RepositoryStrategy) (*sdk.Operation, error) {
 	m.ctrl.T.Helper()
@@ -753,6 +964,21 @@ func (mr *MockExportImportInterfaceMockRecorder) WorkflowAsCodePerform(projectKe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodePerform", reflect.TypeOf((*MockExportImportInterface)(nil).WorkflowAsCodePerform), projectKey, operationID)
 }
 
+// WorkflowAsCodeConvert mocks base method
+func (m *MockExportImportInterface) WorkflowAsCodeConvert(format string, files map[string]string) (*sdk.AsCodeConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowAsCodeConvert", format, files)
+	ret0, _ := ret[0].(*sdk.AsCodeConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowAsCodeConvert indicates an expected call of WorkflowAsCodeConvert
+func (mr *MockExportImportInterfaceMockRecorder) WorkflowAsCodeConvert(format, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeConvert", reflect.TypeOf((*MockExportImportInterface)(nil).WorkflowAsCodeConvert), format, files)
+}
+
 // MockWorkflowAsCodeInterface is a mock of WorkflowAsCodeInterface interface
 type MockWorkflowAsCodeInterface struct {
 	ctrl     *gomock.Controller
@@ -821,6 +1047,21 @@ func (mr *MockWorkflowAsCodeInterfaceMockRecorder) WorkflowAsCodePerform(project
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodePerform", reflect.TypeOf((*MockWorkflowAsCodeInterface)(nil).WorkflowAsCodePerform), projectKey, operationID)
 }
 
+// WorkflowAsCodeConvert mocks base method
+func (m *MockWorkflowAsCodeInterface) WorkflowAsCodeConvert(format string, files map[string]string) (*sdk.AsCodeConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowAsCodeConvert", format, files)
+	ret0, _ := ret[0].(*sdk.AsCodeConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowAsCodeConvert indicates an expected call of WorkflowAsCodeConvert
+func (mr *MockWorkflowAsCodeInterfaceMockRecorder) WorkflowAsCodeConvert(format, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeConvert", reflect.TypeOf((*MockWorkflowAsCodeInterface)(nil).WorkflowAsCodeConvert), format, files)
+}
+
 // MockRepositoriesManagerInterface is a mock of RepositoriesManagerInterface interface
 type MockRepositoriesManagerInterface struct {
 	ctrl     *gomock.Controller
@@ -1676,6 +1917,18 @@ func (mr *MockEventsClientMockRecorder) EventsListen(ctx, chanSSEvt interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsListen", reflect.TypeOf((*MockEventsClient)(nil).EventsListen), ctx, chanSSEvt)
 }
 
+// WebsocketEventsListen mocks base method
+func (m *MockEventsClient) WebsocketEventsListen(ctx context.Context, filters []sdk.WebsocketFilter, chanMsgReceived chan<- sdk.WebsocketEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WebsocketEventsListen", ctx, filters, chanMsgReceived)
+}
+
+// WebsocketEventsListen indicates an expected call of WebsocketEventsListen
+func (mr *MockEventsClientMockRecorder) WebsocketEventsListen(ctx, filters, chanMsgReceived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebsocketEventsListen", reflect.TypeOf((*MockEventsClient)(nil).WebsocketEventsListen), ctx, filters, chanMsgReceived)
+}
+
 // MockDownloadClient is a mock of DownloadClient interface
 type MockDownloadClient struct {
 	ctrl     *gomock.Controller
@@ -1844,6 +2097,51 @@ func (mr *MockActionClientMockRecorder) ActionExport(groupName, name, format int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionExport", reflect.TypeOf((*MockActionClient)(nil).ActionExport), groupName, name, format)
 }
 
+// ActionVersionList mocks base method
+func (m *MockActionClient) ActionVersionList(groupName, name string) ([]sdk.ActionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionList", groupName, name)
+	ret0, _ := ret[0].([]sdk.ActionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionList indicates an expected call of ActionVersionList
+func (mr *MockActionClientMockRecorder) ActionVersionList(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionList", reflect.TypeOf((*MockActionClient)(nil).ActionVersionList), groupName, name)
+}
+
+// ActionVersionPublish mocks base method
+func (m *MockActionClient) ActionVersionPublish(groupName, name, version string) (*sdk.ActionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionPublish", groupName, name, version)
+	ret0, _ := ret[0].(*sdk.ActionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionPublish indicates an expected call of ActionVersionPublish
+func (mr *MockActionClientMockRecorder) ActionVersionPublish(groupName, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionPublish", reflect.TypeOf((*MockActionClient)(nil).ActionVersionPublish), groupName, name, version)
+}
+
+// ActionVersionUsage mocks base method
+func (m *MockActionClient) ActionVersionUsage(groupName, name, version string) ([]sdk.ActionVersionUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionUsage", groupName, name, version)
+	ret0, _ := ret[0].([]sdk.ActionVersionUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionUsage indicates an expected call of ActionVersionUsage
+func (mr *MockActionClientMockRecorder) ActionVersionUsage(groupName, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionUsage", reflect.TypeOf((*MockActionClient)(nil).ActionVersionUsage), groupName, name, version)
+}
+
 // ActionBuiltinList mocks base method
 func (m *MockActionClient) ActionBuiltinList() ([]sdk.Action, error) {
 	m.ctrl.T.Helper()
@@ -2128,10 +2426,10 @@ func (m *MockPipelineClient) EXPECT() *MockPipelineClientMockRecorder {
 }
 
 // PipelineGet mocks base method
-func (m *MockInterface) PipelineGet(projectKey, name string, opts ...cdsclient.RequestModifier) (*sdk.Pipeline, error) {
+func (m *MockPipelineClient) PipelineGet(projectKey, name string, mods ...cdsclient.RequestModifier) (*sdk.Pipeline, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{projectKey, name}
-	for _, a := range opts {
+	for _, a := range mods {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "PipelineGet", varargs...)
@@ -2141,10 +2439,10 @@ func (m *MockInterface) PipelineGet(projectKey, name string, opts ...cdsclient.R
 }
 
 // PipelineGet indicates an expected call of PipelineGet
-func (mr *MockInterfaceMockRecorder) PipelineGet(projectKey, name interface{}, opts ...interface{}) *gomock.Call {
+func (mr *MockPipelineClientMockRecorder) PipelineGet(projectKey, name interface{}, mods ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, name}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineGet", reflect.TypeOf((*MockInterface)(nil).PipelineGet), varargs...)
+	varargs := append([]interface{}{projectKey, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineGet", reflect.TypeOf((*MockPipelineClient)(nil).PipelineGet), varargs...)
 }
 
 // PipelineDelete mocks base method
@@ -2593,120 +2891,282 @@ func (mr *MockProjectClientMockRecorder) ProjectRepositoryManagerDelete(projectK
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
 }
 
-// MockProjectKeysClient is a mock of ProjectKeysClient interface
-type MockProjectKeysClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockProjectKeysClientMockRecorder
-}
-
-// MockProjectKeysClientMockRecorder is the mock recorder for MockProjectKeysClient
-type MockProjectKeysClientMockRecorder struct {
-	mock *MockProjectKeysClient
-}
-
-// NewMockProjectKeysClient creates a new mock instance
-func NewMockProjectKeysClient(ctrl *gomock.Controller) *MockProjectKeysClient {
-	mock := &MockProjectKeysClient{ctrl: ctrl}
-	mock.recorder = &MockProjectKeysClientMockRecorder{mock}
-	return mock
+// ProjectSBOMSearch mocks base method
+func (m *MockProjectClient) ProjectSBOMSearch(projectKey, name, version string) ([]sdk.SBOMSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSBOMSearch", projectKey, name, version)
+	ret0, _ := ret[0].([]sdk.SBOMSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockProjectKeysClient) EXPECT() *MockProjectKeysClientMockRecorder {
-	return m.recorder
+// ProjectSBOMSearch indicates an expected call of ProjectSBOMSearch
+func (mr *MockProjectClientMockRecorder) ProjectSBOMSearch(projectKey, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSBOMSearch", reflect.TypeOf((*MockProjectClient)(nil).ProjectSBOMSearch), projectKey, name, version)
 }
 
-// ProjectKeysList mocks base method
-func (m *MockProjectKeysClient) ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error) {
+// ProjectTestHistory mocks base method
+func (m *MockProjectClient) ProjectTestHistory(projectKey, suite, name string) ([]sdk.TestHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeysList", projectKey)
-	ret0, _ := ret[0].([]sdk.ProjectKey)
+	ret := m.ctrl.Call(m, "ProjectTestHistory", projectKey, suite, name)
+	ret0, _ := ret[0].([]sdk.TestHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectKeysList indicates an expected call of ProjectKeysList
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeysList(projectKey interface{}) *gomock.Call {
+// ProjectTestHistory indicates an expected call of ProjectTestHistory
+func (mr *MockProjectClientMockRecorder) ProjectTestHistory(projectKey, suite, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysList", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectTestHistory", reflect.TypeOf((*MockProjectClient)(nil).ProjectTestHistory), projectKey, suite, name)
 }
 
-// ProjectKeyCreate mocks base method
-func (m *MockProjectKeysClient) ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error {
+// ProjectFlakyTests mocks base method
+func (m *MockProjectClient) ProjectFlakyTests(projectKey string, limit, days int) ([]sdk.FlakyTest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeyCreate", projectKey, key)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectFlakyTests", projectKey, limit, days)
+	ret0, _ := ret[0].([]sdk.FlakyTest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ProjectKeyCreate indicates an expected call of ProjectKeyCreate
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeyCreate(projectKey, key interface{}) *gomock.Call {
+// ProjectFlakyTests indicates an expected call of ProjectFlakyTests
+func (mr *MockProjectClientMockRecorder) ProjectFlakyTests(projectKey, limit, days interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyCreate), projectKey, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFlakyTests", reflect.TypeOf((*MockProjectClient)(nil).ProjectFlakyTests), projectKey, limit, days)
 }
 
-// ProjectKeysDelete mocks base method
-func (m *MockProjectKeysClient) ProjectKeysDelete(projectKey, keyProjectName string) error {
+// ProjectDORAMetrics mocks base method
+func (m *MockProjectClient) ProjectDORAMetrics(projectKey string, days int) (*sdk.DORAReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeysDelete", projectKey, keyProjectName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectDORAMetrics", projectKey, days)
+	ret0, _ := ret[0].(*sdk.DORAReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ProjectKeysDelete indicates an expected call of ProjectKeysDelete
-func (mr *MockProjectKeysClientMockRecorder) ProjectKeysDelete(projectKey, keyProjectName interface{}) *gomock.Call {
+// ProjectDORAMetrics indicates an expected call of ProjectDORAMetrics
+func (mr *MockProjectClientMockRecorder) ProjectDORAMetrics(projectKey, days interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysDelete), projectKey, keyProjectName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDORAMetrics", reflect.TypeOf((*MockProjectClient)(nil).ProjectDORAMetrics), projectKey, days)
 }
 
-// MockProjectVariablesClient is a mock of ProjectVariablesClient interface
-type MockProjectVariablesClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockProjectVariablesClientMockRecorder
+// ProjectRetentionSettingsGet mocks base method
+func (m *MockProjectClient) ProjectRetentionSettingsGet(projectKey string) (*sdk.ProjectRetentionSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRetentionSettingsGet", projectKey)
+	ret0, _ := ret[0].(*sdk.ProjectRetentionSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockProjectVariablesClientMockRecorder is the mock recorder for MockProjectVariablesClient
-type MockProjectVariablesClientMockRecorder struct {
-	mock *MockProjectVariablesClient
+// ProjectRetentionSettingsGet indicates an expected call of ProjectRetentionSettingsGet
+func (mr *MockProjectClientMockRecorder) ProjectRetentionSettingsGet(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRetentionSettingsGet", reflect.TypeOf((*MockProjectClient)(nil).ProjectRetentionSettingsGet), projectKey)
 }
 
-// NewMockProjectVariablesClient creates a new mock instance
-func NewMockProjectVariablesClient(ctrl *gomock.Controller) *MockProjectVariablesClient {
-	mock := &MockProjectVariablesClient{ctrl: ctrl}
-	mock.recorder = &MockProjectVariablesClientMockRecorder{mock}
-	return mock
+// ProjectRetentionSettingsUpdate mocks base method
+func (m *MockProjectClient) ProjectRetentionSettingsUpdate(projectKey string, s sdk.ProjectRetentionSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRetentionSettingsUpdate", projectKey, s)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockProjectVariablesClient) EXPECT() *MockProjectVariablesClientMockRecorder {
-	return m.recorder
+// ProjectRetentionSettingsUpdate indicates an expected call of ProjectRetentionSettingsUpdate
+func (mr *MockProjectClientMockRecorder) ProjectRetentionSettingsUpdate(projectKey, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRetentionSettingsUpdate", reflect.TypeOf((*MockProjectClient)(nil).ProjectRetentionSettingsUpdate), projectKey, s)
 }
 
-// ProjectVariablesList mocks base method
-func (m *MockProjectVariablesClient) ProjectVariablesList(key string) ([]sdk.Variable, error) {
+// ProjectFreezeWindowList mocks base method
+func (m *MockProjectClient) ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectVariablesList", key)
-	ret0, _ := ret[0].([]sdk.Variable)
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowList", projectKey)
+	ret0, _ := ret[0].([]sdk.FreezeWindow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectVariablesList indicates an expected call of ProjectVariablesList
-func (mr *MockProjectVariablesClientMockRecorder) ProjectVariablesList(key interface{}) *gomock.Call {
+// ProjectFreezeWindowList indicates an expected call of ProjectFreezeWindowList
+func (mr *MockProjectClientMockRecorder) ProjectFreezeWindowList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariablesList", reflect.TypeOf((*MockProjectVariablesClient)(nil).ProjectVariablesList), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowList", reflect.TypeOf((*MockProjectClient)(nil).ProjectFreezeWindowList), projectKey)
 }
 
-// ProjectVariableCreate mocks base method
-func (m *MockProjectVariablesClient) ProjectVariableCreate(projectKey string, variable *sdk.Variable) error {
+// ProjectFreezeWindowAdd mocks base method
+func (m *MockProjectClient) ProjectFreezeWindowAdd(projectKey string, w *sdk.FreezeWindow) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectVariableCreate", projectKey, variable)
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowAdd", projectKey, w)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectVariableCreate indicates an expected call of ProjectVariableCreate
-func (mr *MockProjectVariablesClientMockRecorder) ProjectVariableCreate(projectKey, variable interface{}) *gomock.Call {
+// ProjectFreezeWindowAdd indicates an expected call of ProjectFreezeWindowAdd
+func (mr *MockProjectClientMockRecorder) ProjectFreezeWindowAdd(projectKey, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowAdd", reflect.TypeOf((*MockProjectClient)(nil).ProjectFreezeWindowAdd), projectKey, w)
+}
+
+// ProjectFreezeWindowDelete mocks base method
+func (m *MockProjectClient) ProjectFreezeWindowDelete(projectKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowDelete", projectKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectFreezeWindowDelete indicates an expected call of ProjectFreezeWindowDelete
+func (mr *MockProjectClientMockRecorder) ProjectFreezeWindowDelete(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectFreezeWindowDelete), projectKey, name)
+}
+
+// ProjectFreezeOverrideList mocks base method
+func (m *MockProjectClient) ProjectFreezeOverrideList(projectKey string) ([]sdk.FreezeWindowOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeOverrideList", projectKey)
+	ret0, _ := ret[0].([]sdk.FreezeWindowOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeOverrideList indicates an expected call of ProjectFreezeOverrideList
+func (mr *MockProjectClientMockRecorder) ProjectFreezeOverrideList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeOverrideList", reflect.TypeOf((*MockProjectClient)(nil).ProjectFreezeOverrideList), projectKey)
+}
+
+// ProjectDeploymentList mocks base method
+func (m *MockProjectClient) ProjectDeploymentList(projectKey, application, environment string) ([]sdk.EnvironmentDeployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectDeploymentList", projectKey, application, environment)
+	ret0, _ := ret[0].([]sdk.EnvironmentDeployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectDeploymentList indicates an expected call of ProjectDeploymentList
+func (mr *MockProjectClientMockRecorder) ProjectDeploymentList(projectKey, application, environment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDeploymentList", reflect.TypeOf((*MockProjectClient)(nil).ProjectDeploymentList), projectKey, application, environment)
+}
+
+// MockProjectKeysClient is a mock of ProjectKeysClient interface
+type MockProjectKeysClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectKeysClientMockRecorder
+}
+
+// MockProjectKeysClientMockRecorder is the mock recorder for MockProjectKeysClient
+type MockProjectKeysClientMockRecorder struct {
+	mock *MockProjectKeysClient
+}
+
+// NewMockProjectKeysClient creates a new mock instance
+func NewMockProjectKeysClient(ctrl *gomock.Controller) *MockProjectKeysClient {
+	mock := &MockProjectKeysClient{ctrl: ctrl}
+	mock.recorder = &MockProjectKeysClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProjectKeysClient) EXPECT() *MockProjectKeysClientMockRecorder {
+	return m.recorder
+}
+
+// ProjectKeysList mocks base method
+func (m *MockProjectKeysClient) ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeysList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectKeysList indicates an expected call of ProjectKeysList
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeysList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysList", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysList), projectKey)
+}
+
+// ProjectKeyCreate mocks base method
+func (m *MockProjectKeysClient) ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeyCreate", projectKey, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeyCreate indicates an expected call of ProjectKeyCreate
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeyCreate(projectKey, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeyCreate), projectKey, key)
+}
+
+// ProjectKeysDelete mocks base method
+func (m *MockProjectKeysClient) ProjectKeysDelete(projectKey, keyProjectName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectKeysDelete", projectKey, keyProjectName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectKeysDelete indicates an expected call of ProjectKeysDelete
+func (mr *MockProjectKeysClientMockRecorder) ProjectKeysDelete(projectKey, keyProjectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockProjectKeysClient)(nil).ProjectKeysDelete), projectKey, keyProjectName)
+}
+
+// MockProjectVariablesClient is a mock of ProjectVariablesClient interface
+type MockProjectVariablesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectVariablesClientMockRecorder
+}
+
+// MockProjectVariablesClientMockRecorder is the mock recorder for MockProjectVariablesClient
+type MockProjectVariablesClientMockRecorder struct {
+	mock *MockProjectVariablesClient
+}
+
+// NewMockProjectVariablesClient creates a new mock instance
+func NewMockProjectVariablesClient(ctrl *gomock.Controller) *MockProjectVariablesClient {
+	mock := &MockProjectVariablesClient{ctrl: ctrl}
+	mock.recorder = &MockProjectVariablesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProjectVariablesClient) EXPECT() *MockProjectVariablesClientMockRecorder {
+	return m.recorder
+}
+
+// ProjectVariablesList mocks base method
+func (m *MockProjectVariablesClient) ProjectVariablesList(key string) ([]sdk.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectVariablesList", key)
+	ret0, _ := ret[0].([]sdk.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectVariablesList indicates an expected call of ProjectVariablesList
+func (mr *MockProjectVariablesClientMockRecorder) ProjectVariablesList(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariablesList", reflect.TypeOf((*MockProjectVariablesClient)(nil).ProjectVariablesList), key)
+}
+
+// ProjectVariableCreate mocks base method
+func (m *MockProjectVariablesClient) ProjectVariableCreate(projectKey string, variable *sdk.Variable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectVariableCreate", projectKey, variable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectVariableCreate indicates an expected call of ProjectVariableCreate
+func (mr *MockProjectVariablesClientMockRecorder) ProjectVariableCreate(projectKey, variable interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableCreate", reflect.TypeOf((*MockProjectVariablesClient)(nil).ProjectVariableCreate), projectKey, variable)
 }
@@ -2926,6 +3386,21 @@ func (mr *MockQueueClientMockRecorder) QueueSendCoverage(ctx, id, report interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockQueueClient)(nil).QueueSendCoverage), ctx, id, report)
 }
 
+// QueueJobCoverage mocks base method
+func (m *MockQueueClient) QueueJobCoverage(ctx context.Context, id int64) (*sdk.WorkflowNodeRunCoverage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobCoverage", ctx, id)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunCoverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobCoverage indicates an expected call of QueueJobCoverage
+func (mr *MockQueueClientMockRecorder) QueueJobCoverage(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobCoverage", reflect.TypeOf((*MockQueueClient)(nil).QueueJobCoverage), ctx, id)
+}
+
 // QueueSendUnitTests mocks base method
 func (m *MockQueueClient) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
 	m.ctrl.T.Helper()
@@ -2968,6 +3443,20 @@ func (mr *MockQueueClientMockRecorder) QueueSendVulnerability(ctx, id, report in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendVulnerability", reflect.TypeOf((*MockQueueClient)(nil).QueueSendVulnerability), ctx, id, report)
 }
 
+// QueueSendCodeComments mocks base method
+func (m *MockQueueClient) QueueSendCodeComments(ctx context.Context, id int64, comments []sdk.WorkflowNodeRunCodeComment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendCodeComments", ctx, id, comments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueSendCodeComments indicates an expected call of QueueSendCodeComments
+func (mr *MockQueueClientMockRecorder) QueueSendCodeComments(ctx, id, comments interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCodeComments", reflect.TypeOf((*MockQueueClient)(nil).QueueSendCodeComments), ctx, id, comments)
+}
+
 // QueueSendStepResult mocks base method
 func (m *MockQueueClient) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
 	m.ctrl.T.Helper()
@@ -3043,6 +3532,36 @@ func (mr *MockQueueClientMockRecorder) QueueJobTag(ctx, jobID, tags interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockQueueClient)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
+// QueueArtifactAttestation mocks base method
+func (m *MockQueueClient) QueueArtifactAttestation(ctx context.Context, jobID int64, req sdk.WorkflowArtifactAttestationRequest) ([]sdk.WorkflowArtifactAttestation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueArtifactAttestation", ctx, jobID, req)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactAttestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueArtifactAttestation indicates an expected call of QueueArtifactAttestation
+func (mr *MockQueueClientMockRecorder) QueueArtifactAttestation(ctx, jobID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactAttestation", reflect.TypeOf((*MockQueueClient)(nil).QueueArtifactAttestation), ctx, jobID, req)
+}
+
+// QueueJobSBOM mocks base method
+func (m *MockQueueClient) QueueJobSBOM(ctx context.Context, jobID int64, sbom sdk.WorkflowRunSBOM) (*sdk.WorkflowRunSBOM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobSBOM", ctx, jobID, sbom)
+	ret0, _ := ret[0].(*sdk.WorkflowRunSBOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobSBOM indicates an expected call of QueueJobSBOM
+func (mr *MockQueueClientMockRecorder) QueueJobSBOM(ctx, jobID, sbom interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobSBOM", reflect.TypeOf((*MockQueueClient)(nil).QueueJobSBOM), ctx, jobID, sbom)
+}
+
 // QueueServiceLogs mocks base method
 func (m *MockQueueClient) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
@@ -3352,6 +3871,36 @@ func (mr *MockWorkerClientMockRecorder) WorkerModelsEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelsEnabled", reflect.TypeOf((*MockWorkerClient)(nil).WorkerModelsEnabled))
 }
 
+// WorkerModelTrustPolicy mocks base method
+func (m *MockWorkerClient) WorkerModelTrustPolicy() (sdk.WorkerModelTrustPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerModelTrustPolicy")
+	ret0, _ := ret[0].(sdk.WorkerModelTrustPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerModelTrustPolicy indicates an expected call of WorkerModelTrustPolicy
+func (mr *MockWorkerClientMockRecorder) WorkerModelTrustPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelTrustPolicy", reflect.TypeOf((*MockWorkerClient)(nil).WorkerModelTrustPolicy))
+}
+
+// WorkerModelTrustPolicyUpdate mocks base method
+func (m *MockWorkerClient) WorkerModelTrustPolicyUpdate(p sdk.WorkerModelTrustPolicy) (sdk.WorkerModelTrustPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerModelTrustPolicyUpdate", p)
+	ret0, _ := ret[0].(sdk.WorkerModelTrustPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerModelTrustPolicyUpdate indicates an expected call of WorkerModelTrustPolicyUpdate
+func (mr *MockWorkerClientMockRecorder) WorkerModelTrustPolicyUpdate(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelTrustPolicyUpdate", reflect.TypeOf((*MockWorkerClient)(nil).WorkerModelTrustPolicyUpdate), p)
+}
+
 // WorkerRegister mocks base method
 func (m *MockWorkerClient) WorkerRegister(ctx context.Context, authToken string, form sdk.WorkerRegistrationForm) (*sdk.Worker, bool, error) {
 	m.ctrl.T.Helper()
@@ -3368,6 +3917,21 @@ func (mr *MockWorkerClientMockRecorder) WorkerRegister(ctx, authToken, form inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRegister", reflect.TypeOf((*MockWorkerClient)(nil).WorkerRegister), ctx, authToken, form)
 }
 
+// WorkerRotateSession mocks base method
+func (m *MockWorkerClient) WorkerRotateSession(ctx context.Context) (*sdk.AuthSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerRotateSession", ctx)
+	ret0, _ := ret[0].(*sdk.AuthSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerRotateSession indicates an expected call of WorkerRotateSession
+func (mr *MockWorkerClientMockRecorder) WorkerRotateSession(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRotateSession", reflect.TypeOf((*MockWorkerClient)(nil).WorkerRotateSession), ctx)
+}
+
 // WorkerSetStatus mocks base method
 func (m *MockWorkerClient) WorkerSetStatus(ctx context.Context, status string) error {
 	m.ctrl.T.Helper()
@@ -3436,6 +4000,102 @@ func (mr *MockHookClientMockRecorder) VCSConfiguration() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VCSConfiguration", reflect.TypeOf((*MockHookClient)(nil).VCSConfiguration))
 }
 
+// WorkflowScheduledRunExecute mocks base method
+func (m *MockHookClient) WorkflowScheduledRunExecute(r sdk.WorkflowScheduledRun) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowScheduledRunExecute", r)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowScheduledRunExecute indicates an expected call of WorkflowScheduledRunExecute
+func (mr *MockHookClientMockRecorder) WorkflowScheduledRunExecute(r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowScheduledRunExecute", reflect.TypeOf((*MockHookClient)(nil).WorkflowScheduledRunExecute), r)
+}
+
+// MockChatOpsClient is a mock of ChatOpsClient interface
+type MockChatOpsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatOpsClientMockRecorder
+}
+
+// MockChatOpsClientMockRecorder is the mock recorder for MockChatOpsClient
+type MockChatOpsClientMockRecorder struct {
+	mock *MockChatOpsClient
+}
+
+// NewMockChatOpsClient creates a new mock instance
+func NewMockChatOpsClient(ctrl *gomock.Controller) *MockChatOpsClient {
+	mock := &MockChatOpsClient{ctrl: ctrl}
+	mock.recorder = &MockChatOpsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockChatOpsClient) EXPECT() *MockChatOpsClientMockRecorder {
+	return m.recorder
+}
+
+// ChatOpsCommand mocks base method
+func (m *MockChatOpsClient) ChatOpsCommand(cmd sdk.ChatOpsCommand) (*sdk.ChatOpsCommandResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatOpsCommand", cmd)
+	ret0, _ := ret[0].(*sdk.ChatOpsCommandResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChatOpsCommand indicates an expected call of ChatOpsCommand
+func (mr *MockChatOpsClientMockRecorder) ChatOpsCommand(cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatOpsCommand", reflect.TypeOf((*MockChatOpsClient)(nil).ChatOpsCommand), cmd)
+}
+
+// ChatOpsLinkToken mocks base method
+func (m *MockChatOpsClient) ChatOpsLinkToken(req sdk.ChatOpsLinkTokenRequest) (*sdk.ChatOpsLinkToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatOpsLinkToken", req)
+	ret0, _ := ret[0].(*sdk.ChatOpsLinkToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChatOpsLinkToken indicates an expected call of ChatOpsLinkToken
+func (mr *MockChatOpsClientMockRecorder) ChatOpsLinkToken(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatOpsLinkToken", reflect.TypeOf((*MockChatOpsClient)(nil).ChatOpsLinkToken), req)
+}
+
+// ChatOpsLink mocks base method
+func (m *MockChatOpsClient) ChatOpsLink(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatOpsLink", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChatOpsLink indicates an expected call of ChatOpsLink
+func (mr *MockChatOpsClientMockRecorder) ChatOpsLink(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatOpsLink", reflect.TypeOf((*MockChatOpsClient)(nil).ChatOpsLink), token)
+}
+
+// ChatOpsUnlink mocks base method
+func (m *MockChatOpsClient) ChatOpsUnlink(provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatOpsUnlink", provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChatOpsUnlink indicates an expected call of ChatOpsUnlink
+func (mr *MockChatOpsClientMockRecorder) ChatOpsUnlink(provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatOpsUnlink", reflect.TypeOf((*MockChatOpsClient)(nil).ChatOpsUnlink), provider)
+}
+
 // MockWorkflowClient is a mock of WorkflowClient interface
 type MockWorkflowClient struct {
 	ctrl     *gomock.Controller
@@ -3508,13 +4168,28 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowUpdate(projectKey, name, wf in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowUpdate", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowUpdate), projectKey, name, wf)
 }
 
-// WorkflowDelete mocks base method
-func (m *MockWorkflowClient) WorkflowDelete(projectKey, workflowName string) error {
+// WorkflowGraphPatch mocks base method
+func (m *MockWorkflowClient) WorkflowGraphPatch(projectKey, name string, patch sdk.WorkflowGraphPatch, dryRun bool) (*sdk.Workflow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowDelete", projectKey, workflowName)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
+	ret := m.ctrl.Call(m, "WorkflowGraphPatch", projectKey, name, patch, dryRun)
+	ret0, _ := ret[0].(*sdk.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowGraphPatch indicates an expected call of WorkflowGraphPatch
+func (mr *MockWorkflowClientMockRecorder) WorkflowGraphPatch(projectKey, name, patch, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGraphPatch", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowGraphPatch), projectKey, name, patch, dryRun)
+}
+
+// WorkflowDelete mocks base method
+func (m *MockWorkflowClient) WorkflowDelete(projectKey, workflowName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowDelete", projectKey, workflowName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
 
 // WorkflowDelete indicates an expected call of WorkflowDelete
 func (mr *MockWorkflowClientMockRecorder) WorkflowDelete(projectKey, workflowName interface{}) *gomock.Call {
@@ -3629,6 +4304,21 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowRunList(projectKey, workflowNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunList), projectKey, workflowName, offset, limit)
 }
 
+// WorkflowRunsSearch mocks base method
+func (m *MockWorkflowClient) WorkflowRunsSearch(projectKey string, search sdk.WorkflowRunSearch) (*sdk.WorkflowRunSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunsSearch", projectKey, search)
+	ret0, _ := ret[0].(*sdk.WorkflowRunSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunsSearch indicates an expected call of WorkflowRunsSearch
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunsSearch(projectKey, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunsSearch", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunsSearch), projectKey, search)
+}
+
 // WorkflowRunArtifacts mocks base method
 func (m *MockWorkflowClient) WorkflowRunArtifacts(projectKey, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error) {
 	m.ctrl.T.Helper()
@@ -3644,916 +4334,1490 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowRunArtifacts(projectKey, name,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunArtifacts), projectKey, name, number)
 }
 
-// WorkflowRunFromHook mocks base method
-func (m *MockWorkflowClient) WorkflowRunFromHook(projectKey, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error) {
+// WorkflowRunArtifactsDiff mocks base method
+func (m *MockWorkflowClient) WorkflowRunArtifactsDiff(projectKey, name string, number int64, withFiles bool) (*sdk.WorkflowRunArtifactsDiff, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunFromHook", projectKey, workflowName, hook)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowRunArtifactsDiff", projectKey, name, number, withFiles)
+	ret0, _ := ret[0].(*sdk.WorkflowRunArtifactsDiff)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunFromHook indicates an expected call of WorkflowRunFromHook
-func (mr *MockWorkflowClientMockRecorder) WorkflowRunFromHook(projectKey, workflowName, hook interface{}) *gomock.Call {
+// WorkflowRunArtifactsDiff indicates an expected call of WorkflowRunArtifactsDiff
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunArtifactsDiff(projectKey, name, number, withFiles interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromHook", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunFromHook), projectKey, workflowName, hook)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifactsDiff", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunArtifactsDiff), projectKey, name, number, withFiles)
 }
 
-// WorkflowRunFromManual mocks base method
-func (m *MockWorkflowClient) WorkflowRunFromManual(projectKey, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error) {
+// WorkflowRunArtifactsPromote mocks base method
+func (m *MockWorkflowClient) WorkflowRunArtifactsPromote(projectKey, name string, number int64, req sdk.WorkflowArtifactPromotionRequest) ([]sdk.WorkflowArtifactPromotion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunFromManual", projectKey, workflowName, manual, number, fromNodeID)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowRunArtifactsPromote", projectKey, name, number, req)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactPromotion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunFromManual indicates an expected call of WorkflowRunFromManual
-func (mr *MockWorkflowClientMockRecorder) WorkflowRunFromManual(projectKey, workflowName, manual, number, fromNodeID interface{}) *gomock.Call {
+// WorkflowRunArtifactsPromote indicates an expected call of WorkflowRunArtifactsPromote
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunArtifactsPromote(projectKey, name, number, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromManual", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunFromManual), projectKey, workflowName, manual, number, fromNodeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifactsPromote", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunArtifactsPromote), projectKey, name, number, req)
 }
 
-// WorkflowRunNumberGet mocks base method
-func (m *MockWorkflowClient) WorkflowRunNumberGet(projectKey, workflowName string) (*sdk.WorkflowRunNumber, error) {
+// WorkflowArtifactPromotions mocks base method
+func (m *MockWorkflowClient) WorkflowArtifactPromotions(projectKey, name string) ([]sdk.WorkflowArtifactPromotion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunNumberGet", projectKey, workflowName)
-	ret0, _ := ret[0].(*sdk.WorkflowRunNumber)
+	ret := m.ctrl.Call(m, "WorkflowArtifactPromotions", projectKey, name)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactPromotion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunNumberGet indicates an expected call of WorkflowRunNumberGet
-func (mr *MockWorkflowClientMockRecorder) WorkflowRunNumberGet(projectKey, workflowName interface{}) *gomock.Call {
+// WorkflowArtifactPromotions indicates an expected call of WorkflowArtifactPromotions
+func (mr *MockWorkflowClientMockRecorder) WorkflowArtifactPromotions(projectKey, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunNumberGet", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunNumberGet), projectKey, workflowName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPromotions", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowArtifactPromotions), projectKey, name)
 }
 
-// WorkflowRunNumberSet mocks base method
-func (m *MockWorkflowClient) WorkflowRunNumberSet(projectKey, workflowName string, number int64) error {
+// WorkflowArtifactPromotionDownload mocks base method
+func (m *MockWorkflowClient) WorkflowArtifactPromotionDownload(projectKey, name string, p sdk.WorkflowArtifactPromotion, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunNumberSet", projectKey, workflowName, number)
+	ret := m.ctrl.Call(m, "WorkflowArtifactPromotionDownload", projectKey, name, p, w)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WorkflowRunNumberSet indicates an expected call of WorkflowRunNumberSet
-func (mr *MockWorkflowClientMockRecorder) WorkflowRunNumberSet(projectKey, workflowName, number interface{}) *gomock.Call {
+// WorkflowArtifactPromotionDownload indicates an expected call of WorkflowArtifactPromotionDownload
+func (mr *MockWorkflowClientMockRecorder) WorkflowArtifactPromotionDownload(projectKey, name, p, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunNumberSet", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunNumberSet), projectKey, workflowName, number)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPromotionDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowArtifactPromotionDownload), projectKey, name, p, w)
 }
 
-// WorkflowStop mocks base method
-func (m *MockWorkflowClient) WorkflowStop(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+// WorkflowArtifactPromotionDelete mocks base method
+func (m *MockWorkflowClient) WorkflowArtifactPromotionDelete(projectKey, name string, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowStop", projectKey, workflowName, number)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WorkflowArtifactPromotionDelete", projectKey, name, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowStop indicates an expected call of WorkflowStop
-func (mr *MockWorkflowClientMockRecorder) WorkflowStop(projectKey, workflowName, number interface{}) *gomock.Call {
+// WorkflowArtifactPromotionDelete indicates an expected call of WorkflowArtifactPromotionDelete
+func (mr *MockWorkflowClientMockRecorder) WorkflowArtifactPromotionDelete(projectKey, name, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowStop", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowStop), projectKey, workflowName, number)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPromotionDelete", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowArtifactPromotionDelete), projectKey, name, id)
 }
 
-// WorkflowNodeStop mocks base method
-func (m *MockWorkflowClient) WorkflowNodeStop(projectKey, workflowName string, number, fromNodeID int64) (*sdk.WorkflowNodeRun, error) {
+// WorkflowArtifactAttestation mocks base method
+func (m *MockWorkflowClient) WorkflowArtifactAttestation(projectKey, name string, artifactID int64) (*sdk.WorkflowArtifactAttestation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowNodeStop", projectKey, workflowName, number, fromNodeID)
-	ret0, _ := ret[0].(*sdk.WorkflowNodeRun)
+	ret := m.ctrl.Call(m, "WorkflowArtifactAttestation", projectKey, name, artifactID)
+	ret0, _ := ret[0].(*sdk.WorkflowArtifactAttestation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowNodeStop indicates an expected call of WorkflowNodeStop
-func (mr *MockWorkflowClientMockRecorder) WorkflowNodeStop(projectKey, workflowName, number, fromNodeID interface{}) *gomock.Call {
+// WorkflowArtifactAttestation indicates an expected call of WorkflowArtifactAttestation
+func (mr *MockWorkflowClientMockRecorder) WorkflowArtifactAttestation(projectKey, name, artifactID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeStop", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeStop), projectKey, workflowName, number, fromNodeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactAttestation", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowArtifactAttestation), projectKey, name, artifactID)
 }
 
-// WorkflowNodeRun mocks base method
-func (m *MockWorkflowClient) WorkflowNodeRun(projectKey, name string, number, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
+// WorkflowArtifactAttestationVerify mocks base method
+func (m *MockWorkflowClient) WorkflowArtifactAttestationVerify(projectKey, name string, artifactID int64) (*sdk.WorkflowArtifactAttestationVerification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowNodeRun", projectKey, name, number, nodeRunID)
-	ret0, _ := ret[0].(*sdk.WorkflowNodeRun)
+	ret := m.ctrl.Call(m, "WorkflowArtifactAttestationVerify", projectKey, name, artifactID)
+	ret0, _ := ret[0].(*sdk.WorkflowArtifactAttestationVerification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowNodeRun indicates an expected call of WorkflowNodeRun
-func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRun(projectKey, name, number, nodeRunID interface{}) *gomock.Call {
+// WorkflowArtifactAttestationVerify indicates an expected call of WorkflowArtifactAttestationVerify
+func (mr *MockWorkflowClientMockRecorder) WorkflowArtifactAttestationVerify(projectKey, name, artifactID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRun", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRun), projectKey, name, number, nodeRunID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactAttestationVerify", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowArtifactAttestationVerify), projectKey, name, artifactID)
 }
 
-// WorkflowNodeRunArtifactDownload mocks base method
-func (m *MockWorkflowClient) WorkflowNodeRunArtifactDownload(projectKey, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error {
+// WorkflowRunSBOMs mocks base method
+func (m *MockWorkflowClient) WorkflowRunSBOMs(projectKey, name string, number int64) ([]sdk.WorkflowRunSBOM, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowNodeRunArtifactDownload", projectKey, name, a, w)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunSBOMs", projectKey, name, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunSBOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowNodeRunArtifactDownload indicates an expected call of WorkflowNodeRunArtifactDownload
-func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunArtifactDownload(projectKey, name, a, w interface{}) *gomock.Call {
+// WorkflowRunSBOMs indicates an expected call of WorkflowRunSBOMs
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunSBOMs(projectKey, name, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunArtifactDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunArtifactDownload), projectKey, name, a, w)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSBOMs", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunSBOMs), projectKey, name, number)
 }
 
-// WorkflowNodeRunJobStep mocks base method
-func (m *MockWorkflowClient) WorkflowNodeRunJobStep(projectKey, workflowName string, number, nodeRunID, job int64, step int) (*sdk.BuildState, error) {
+// WorkflowRunChangelogs mocks base method
+func (m *MockWorkflowClient) WorkflowRunChangelogs(projectKey, workflowName string, number int64) ([]sdk.WorkflowRunChangelog, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowNodeRunJobStep", projectKey, workflowName, number, nodeRunID, job, step)
-	ret0, _ := ret[0].(*sdk.BuildState)
+	ret := m.ctrl.Call(m, "WorkflowRunChangelogs", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunChangelog)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowNodeRunJobStep indicates an expected call of WorkflowNodeRunJobStep
-func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunJobStep(projectKey, workflowName, number, nodeRunID, job, step interface{}) *gomock.Call {
+// WorkflowRunChangelogs indicates an expected call of WorkflowRunChangelogs
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunChangelogs(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobStep", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunJobStep), projectKey, workflowName, number, nodeRunID, job, step)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunChangelogs", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunChangelogs), projectKey, workflowName, number)
 }
 
-// WorkflowNodeRunRelease mocks base method
-func (m *MockWorkflowClient) WorkflowNodeRunRelease(projectKey, workflowName string, runNumber, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
+// WorkflowRunSBOMDownload mocks base method
+func (m *MockWorkflowClient) WorkflowRunSBOMDownload(projectKey, name string, number, id int64, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowNodeRunRelease", projectKey, workflowName, runNumber, nodeRunID, release)
+	ret := m.ctrl.Call(m, "WorkflowRunSBOMDownload", projectKey, name, number, id, w)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WorkflowNodeRunRelease indicates an expected call of WorkflowNodeRunRelease
-func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunRelease(projectKey, workflowName, runNumber, nodeRunID, release interface{}) *gomock.Call {
+// WorkflowRunSBOMDownload indicates an expected call of WorkflowRunSBOMDownload
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunSBOMDownload(projectKey, name, number, id, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunRelease", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunRelease), projectKey, workflowName, runNumber, nodeRunID, release)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSBOMDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunSBOMDownload), projectKey, name, number, id, w)
 }
 
-// WorkflowAllHooksList mocks base method
-func (m *MockWorkflowClient) WorkflowAllHooksList() ([]sdk.NodeHook, error) {
+// WorkflowRunTestResults mocks base method
+func (m *MockWorkflowClient) WorkflowRunTestResults(projectKey, name string, number int64) ([]sdk.WorkflowRunTestResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowAllHooksList")
-	ret0, _ := ret[0].([]sdk.NodeHook)
+	ret := m.ctrl.Call(m, "WorkflowRunTestResults", projectKey, name, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunTestResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowAllHooksList indicates an expected call of WorkflowAllHooksList
-func (mr *MockWorkflowClientMockRecorder) WorkflowAllHooksList() *gomock.Call {
+// WorkflowRunTestResults indicates an expected call of WorkflowRunTestResults
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunTestResults(projectKey, name, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAllHooksList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowAllHooksList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunTestResults", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunTestResults), projectKey, name, number)
 }
 
-// WorkflowCachePush mocks base method
-func (m *MockWorkflowClient) WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error {
+// WorkflowCoverageTrend mocks base method
+func (m *MockWorkflowClient) WorkflowCoverageTrend(projectKey, name, branch string, limit int64) ([]sdk.WorkflowCoverageTrend, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowCachePush", projectKey, integrationName, ref, tarContent, size)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowCoverageTrend", projectKey, name, branch, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowCoverageTrend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowCachePush indicates an expected call of WorkflowCachePush
-func (mr *MockWorkflowClientMockRecorder) WorkflowCachePush(projectKey, integrationName, ref, tarContent, size interface{}) *gomock.Call {
+// WorkflowCoverageTrend indicates an expected call of WorkflowCoverageTrend
+func (mr *MockWorkflowClientMockRecorder) WorkflowCoverageTrend(projectKey, name, branch, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCachePush", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCachePush), projectKey, integrationName, ref, tarContent, size)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCoverageTrend", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCoverageTrend), projectKey, name, branch, limit)
 }
 
-// WorkflowCachePull mocks base method
-func (m *MockWorkflowClient) WorkflowCachePull(projectKey, integrationName, ref string) (io.Reader, error) {
+// WorkflowInsights mocks base method
+func (m *MockWorkflowClient) WorkflowInsights(projectKey, name string, days int) (*sdk.WorkflowInsights, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowCachePull", projectKey, integrationName, ref)
-	ret0, _ := ret[0].(io.Reader)
+	ret := m.ctrl.Call(m, "WorkflowInsights", projectKey, name, days)
+	ret0, _ := ret[0].(*sdk.WorkflowInsights)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowCachePull indicates an expected call of WorkflowCachePull
-func (mr *MockWorkflowClientMockRecorder) WorkflowCachePull(projectKey, integrationName, ref interface{}) *gomock.Call {
+// WorkflowInsights indicates an expected call of WorkflowInsights
+func (mr *MockWorkflowClientMockRecorder) WorkflowInsights(projectKey, name, days interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCachePull", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCachePull), projectKey, integrationName, ref)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowInsights", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowInsights), projectKey, name, days)
 }
 
-// WorkflowTemplateInstanceGet mocks base method
-func (m *MockWorkflowClient) WorkflowTemplateInstanceGet(projectKey, workflowName string) (*sdk.WorkflowTemplateInstance, error) {
+// WorkflowRunNotificationDeliveries mocks base method
+func (m *MockWorkflowClient) WorkflowRunNotificationDeliveries(projectKey, name string, number int64) ([]sdk.WorkflowNotificationDelivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowTemplateInstanceGet", projectKey, workflowName)
-	ret0, _ := ret[0].(*sdk.WorkflowTemplateInstance)
+	ret := m.ctrl.Call(m, "WorkflowRunNotificationDeliveries", projectKey, name, number)
+	ret0, _ := ret[0].([]sdk.WorkflowNotificationDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowTemplateInstanceGet indicates an expected call of WorkflowTemplateInstanceGet
-func (mr *MockWorkflowClientMockRecorder) WorkflowTemplateInstanceGet(projectKey, workflowName interface{}) *gomock.Call {
+// WorkflowRunNotificationDeliveries indicates an expected call of WorkflowRunNotificationDeliveries
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunNotificationDeliveries(projectKey, name, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTemplateInstanceGet", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTemplateInstanceGet), projectKey, workflowName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunNotificationDeliveries", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunNotificationDeliveries), projectKey, name, number)
 }
 
-// WorkflowTransformAsCode mocks base method
-func (m *MockWorkflowClient) WorkflowTransformAsCode(projectKey, workflowName string) (*sdk.Operation, error) {
+// WorkflowRunFromHook mocks base method
+func (m *MockWorkflowClient) WorkflowRunFromHook(projectKey, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowTransformAsCode", projectKey, workflowName)
-	ret0, _ := ret[0].(*sdk.Operation)
+	ret := m.ctrl.Call(m, "WorkflowRunFromHook", projectKey, workflowName, hook)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowTransformAsCode indicates an expected call of WorkflowTransformAsCode
-func (mr *MockWorkflowClientMockRecorder) WorkflowTransformAsCode(projectKey, workflowName interface{}) *gomock.Call {
+// WorkflowRunFromHook indicates an expected call of WorkflowRunFromHook
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunFromHook(projectKey, workflowName, hook interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTransformAsCode", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTransformAsCode), projectKey, workflowName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromHook", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunFromHook), projectKey, workflowName, hook)
 }
 
-// WorkflowTransformAsCodeFollow mocks base method
-func (m *MockWorkflowClient) WorkflowTransformAsCodeFollow(projectKey, workflowName string, ope *sdk.Operation) error {
+// WorkflowRunFromManual mocks base method
+func (m *MockWorkflowClient) WorkflowRunFromManual(projectKey, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowTransformAsCodeFollow", projectKey, workflowName, ope)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunFromManual", projectKey, workflowName, manual, number, fromNodeID)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowTransformAsCodeFollow indicates an expected call of WorkflowTransformAsCodeFollow
-func (mr *MockWorkflowClientMockRecorder) WorkflowTransformAsCodeFollow(projectKey, workflowName, ope interface{}) *gomock.Call {
+// WorkflowRunFromManual indicates an expected call of WorkflowRunFromManual
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunFromManual(projectKey, workflowName, manual, number, fromNodeID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTransformAsCodeFollow", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTransformAsCodeFollow), projectKey, workflowName, ope)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromManual", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunFromManual), projectKey, workflowName, manual, number, fromNodeID)
 }
 
-// MockMonitoringClient is a mock of MonitoringClient interface
-type MockMonitoringClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockMonitoringClientMockRecorder
+// WorkflowRunWithOptions mocks base method
+func (m *MockWorkflowClient) WorkflowRunWithOptions(projectKey, workflowName string, opts sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunWithOptions", projectKey, workflowName, opts)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockMonitoringClientMockRecorder is the mock recorder for MockMonitoringClient
-type MockMonitoringClientMockRecorder struct {
-	mock *MockMonitoringClient
+// WorkflowRunWithOptions indicates an expected call of WorkflowRunWithOptions
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunWithOptions(projectKey, workflowName, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunWithOptions", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunWithOptions), projectKey, workflowName, opts)
 }
 
-// NewMockMonitoringClient creates a new mock instance
-func NewMockMonitoringClient(ctrl *gomock.Controller) *MockMonitoringClient {
-	mock := &MockMonitoringClient{ctrl: ctrl}
-	mock.recorder = &MockMonitoringClientMockRecorder{mock}
-	return mock
+// WorkflowTriggerURLGenerate mocks base method
+func (m *MockWorkflowClient) WorkflowTriggerURLGenerate(projectKey, workflowName string, req sdk.WorkflowTriggerURLRequest) (*sdk.WorkflowTriggerURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTriggerURLGenerate", projectKey, workflowName, req)
+	ret0, _ := ret[0].(*sdk.WorkflowTriggerURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockMonitoringClient) EXPECT() *MockMonitoringClientMockRecorder {
-	return m.recorder
+// WorkflowTriggerURLGenerate indicates an expected call of WorkflowTriggerURLGenerate
+func (mr *MockWorkflowClientMockRecorder) WorkflowTriggerURLGenerate(projectKey, workflowName, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTriggerURLGenerate", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTriggerURLGenerate), projectKey, workflowName, req)
 }
 
-// MonStatus mocks base method
-func (m *MockMonitoringClient) MonStatus() (*sdk.MonitoringStatus, error) {
+// WorkflowTriggerURLList mocks base method
+func (m *MockWorkflowClient) WorkflowTriggerURLList(projectKey, workflowName string) ([]sdk.WorkflowTriggerURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MonStatus")
-	ret0, _ := ret[0].(*sdk.MonitoringStatus)
+	ret := m.ctrl.Call(m, "WorkflowTriggerURLList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowTriggerURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MonStatus indicates an expected call of MonStatus
-func (mr *MockMonitoringClientMockRecorder) MonStatus() *gomock.Call {
+// WorkflowTriggerURLList indicates an expected call of WorkflowTriggerURLList
+func (mr *MockWorkflowClientMockRecorder) WorkflowTriggerURLList(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonStatus", reflect.TypeOf((*MockMonitoringClient)(nil).MonStatus))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTriggerURLList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTriggerURLList), projectKey, workflowName)
 }
 
-// MonVersion mocks base method
-func (m *MockMonitoringClient) MonVersion() (*sdk.Version, error) {
+// WorkflowTriggerURLRevoke mocks base method
+func (m *MockWorkflowClient) WorkflowTriggerURLRevoke(projectKey, workflowName, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MonVersion")
-	ret0, _ := ret[0].(*sdk.Version)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WorkflowTriggerURLRevoke", projectKey, workflowName, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MonVersion indicates an expected call of MonVersion
-func (mr *MockMonitoringClientMockRecorder) MonVersion() *gomock.Call {
+// WorkflowTriggerURLRevoke indicates an expected call of WorkflowTriggerURLRevoke
+func (mr *MockWorkflowClientMockRecorder) WorkflowTriggerURLRevoke(projectKey, workflowName, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonVersion", reflect.TypeOf((*MockMonitoringClient)(nil).MonVersion))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTriggerURLRevoke", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTriggerURLRevoke), projectKey, workflowName, id)
 }
 
-// MonDBMigrate mocks base method
-func (m *MockMonitoringClient) MonDBMigrate() ([]sdk.MonDBMigrate, error) {
+// WorkflowTriggerExplain mocks base method
+func (m *MockWorkflowClient) WorkflowTriggerExplain(projectKey, workflowName, commit, branch string) (*sdk.WorkflowTriggerExplain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MonDBMigrate")
-	ret0, _ := ret[0].([]sdk.MonDBMigrate)
+	ret := m.ctrl.Call(m, "WorkflowTriggerExplain", projectKey, workflowName, commit, branch)
+	ret0, _ := ret[0].(*sdk.WorkflowTriggerExplain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MonDBMigrate indicates an expected call of MonDBMigrate
-func (mr *MockMonitoringClientMockRecorder) MonDBMigrate() *gomock.Call {
+// WorkflowTriggerExplain indicates an expected call of WorkflowTriggerExplain
+func (mr *MockWorkflowClientMockRecorder) WorkflowTriggerExplain(projectKey, workflowName, commit, branch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonDBMigrate", reflect.TypeOf((*MockMonitoringClient)(nil).MonDBMigrate))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTriggerExplain", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTriggerExplain), projectKey, workflowName, commit, branch)
 }
 
-// MonErrorsGet mocks base method
-func (m *MockMonitoringClient) MonErrorsGet(requestID string) ([]sdk.Error, error) {
+// WorkflowTimerList mocks base method
+func (m *MockWorkflowClient) WorkflowTimerList(projectKey, workflowName string) ([]sdk.WorkflowTimer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MonErrorsGet", requestID)
-	ret0, _ := ret[0].([]sdk.Error)
+	ret := m.ctrl.Call(m, "WorkflowTimerList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowTimer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MonErrorsGet indicates an expected call of MonErrorsGet
-func (mr *MockMonitoringClientMockRecorder) MonErrorsGet(requestID interface{}) *gomock.Call {
+// WorkflowTimerList indicates an expected call of WorkflowTimerList
+func (mr *MockWorkflowClientMockRecorder) WorkflowTimerList(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonErrorsGet", reflect.TypeOf((*MockMonitoringClient)(nil).MonErrorsGet), requestID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTimerList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTimerList), projectKey, workflowName)
 }
 
-// MockIntegrationClient is a mock of IntegrationClient interface
-type MockIntegrationClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockIntegrationClientMockRecorder
+// WorkflowTimerAdd mocks base method
+func (m *MockWorkflowClient) WorkflowTimerAdd(projectKey, workflowName string, t *sdk.WorkflowTimer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTimerAdd", projectKey, workflowName, t)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockIntegrationClientMockRecorder is the mock recorder for MockIntegrationClient
-type MockIntegrationClientMockRecorder struct {
-	mock *MockIntegrationClient
+// WorkflowTimerAdd indicates an expected call of WorkflowTimerAdd
+func (mr *MockWorkflowClientMockRecorder) WorkflowTimerAdd(projectKey, workflowName, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTimerAdd", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTimerAdd), projectKey, workflowName, t)
 }
 
-// NewMockIntegrationClient creates a new mock instance
-func NewMockIntegrationClient(ctrl *gomock.Controller) *MockIntegrationClient {
-	mock := &MockIntegrationClient{ctrl: ctrl}
-	mock.recorder = &MockIntegrationClientMockRecorder{mock}
-	return mock
+// WorkflowTimerDelete mocks base method
+func (m *MockWorkflowClient) WorkflowTimerDelete(projectKey, workflowName, timerName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTimerDelete", projectKey, workflowName, timerName)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockIntegrationClient) EXPECT() *MockIntegrationClientMockRecorder {
-	return m.recorder
+// WorkflowTimerDelete indicates an expected call of WorkflowTimerDelete
+func (mr *MockWorkflowClientMockRecorder) WorkflowTimerDelete(projectKey, workflowName, timerName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTimerDelete", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTimerDelete), projectKey, workflowName, timerName)
 }
 
-// IntegrationModelList mocks base method
-func (m *MockIntegrationClient) IntegrationModelList() ([]sdk.IntegrationModel, error) {
+// WorkflowScheduledRunList mocks base method
+func (m *MockWorkflowClient) WorkflowScheduledRunList(projectKey, workflowName string) ([]sdk.WorkflowScheduledRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IntegrationModelList")
-	ret0, _ := ret[0].([]sdk.IntegrationModel)
+	ret := m.ctrl.Call(m, "WorkflowScheduledRunList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowScheduledRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IntegrationModelList indicates an expected call of IntegrationModelList
-func (mr *MockIntegrationClientMockRecorder) IntegrationModelList() *gomock.Call {
+// WorkflowScheduledRunList indicates an expected call of WorkflowScheduledRunList
+func (mr *MockWorkflowClientMockRecorder) WorkflowScheduledRunList(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelList", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowScheduledRunList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowScheduledRunList), projectKey, workflowName)
 }
 
-// IntegrationModelGet mocks base method
-func (m *MockIntegrationClient) IntegrationModelGet(name string) (sdk.IntegrationModel, error) {
+// WorkflowScheduledRunAdd mocks base method
+func (m *MockWorkflowClient) WorkflowScheduledRunAdd(projectKey, workflowName string, r *sdk.WorkflowScheduledRun) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IntegrationModelGet", name)
-	ret0, _ := ret[0].(sdk.IntegrationModel)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WorkflowScheduledRunAdd", projectKey, workflowName, r)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// IntegrationModelGet indicates an expected call of IntegrationModelGet
-func (mr *MockIntegrationClientMockRecorder) IntegrationModelGet(name interface{}) *gomock.Call {
+// WorkflowScheduledRunAdd indicates an expected call of WorkflowScheduledRunAdd
+func (mr *MockWorkflowClientMockRecorder) WorkflowScheduledRunAdd(projectKey, workflowName, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelGet", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelGet), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowScheduledRunAdd", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowScheduledRunAdd), projectKey, workflowName, r)
 }
 
-// IntegrationModelAdd mocks base method
-func (m_2 *MockIntegrationClient) IntegrationModelAdd(m *sdk.IntegrationModel) error {
-	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "IntegrationModelAdd", m)
+// WorkflowScheduledRunCancel mocks base method
+func (m *MockWorkflowClient) WorkflowScheduledRunCancel(projectKey, workflowName, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowScheduledRunCancel", projectKey, workflowName, uuid)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IntegrationModelAdd indicates an expected call of IntegrationModelAdd
-func (mr *MockIntegrationClientMockRecorder) IntegrationModelAdd(m interface{}) *gomock.Call {
+// WorkflowScheduledRunCancel indicates an expected call of WorkflowScheduledRunCancel
+func (mr *MockWorkflowClientMockRecorder) WorkflowScheduledRunCancel(projectKey, workflowName, uuid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelAdd", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelAdd), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowScheduledRunCancel", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowScheduledRunCancel), projectKey, workflowName, uuid)
 }
 
-// IntegrationModelUpdate mocks base method
-func (m_2 *MockIntegrationClient) IntegrationModelUpdate(m *sdk.IntegrationModel) error {
-	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "IntegrationModelUpdate", m)
-	ret0, _ := ret[0].(error)
-	return ret0
+// WorkflowRunNumberGet mocks base method
+func (m *MockWorkflowClient) WorkflowRunNumberGet(projectKey, workflowName string) (*sdk.WorkflowRunNumber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunNumberGet", projectKey, workflowName)
+	ret0, _ := ret[0].(*sdk.WorkflowRunNumber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// IntegrationModelUpdate indicates an expected call of IntegrationModelUpdate
-func (mr *MockIntegrationClientMockRecorder) IntegrationModelUpdate(m interface{}) *gomock.Call {
+// WorkflowRunNumberGet indicates an expected call of WorkflowRunNumberGet
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunNumberGet(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelUpdate", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelUpdate), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunNumberGet", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunNumberGet), projectKey, workflowName)
 }
 
-// IntegrationModelDelete mocks base method
-func (m *MockIntegrationClient) IntegrationModelDelete(name string) error {
+// WorkflowRunNumberSet mocks base method
+func (m *MockWorkflowClient) WorkflowRunNumberSet(projectKey, workflowName string, number int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IntegrationModelDelete", name)
+	ret := m.ctrl.Call(m, "WorkflowRunNumberSet", projectKey, workflowName, number)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IntegrationModelDelete indicates an expected call of IntegrationModelDelete
-func (mr *MockIntegrationClientMockRecorder) IntegrationModelDelete(name interface{}) *gomock.Call {
+// WorkflowRunNumberSet indicates an expected call of WorkflowRunNumberSet
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunNumberSet(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelDelete", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelDelete), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunNumberSet", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunNumberSet), projectKey, workflowName, number)
 }
 
-// MockInterface is a mock of Interface interface
-type MockInterface struct {
-	ctrl     *gomock.Controller
-	recorder *MockInterfaceMockRecorder
+// WorkflowStop mocks base method
+func (m *MockWorkflowClient) WorkflowStop(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowStop", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockInterfaceMockRecorder is the mock recorder for MockInterface
-type MockInterfaceMockRecorder struct {
-	mock *MockInterface
+// WorkflowStop indicates an expected call of WorkflowStop
+func (mr *MockWorkflowClientMockRecorder) WorkflowStop(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowStop", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowStop), projectKey, workflowName, number)
 }
 
-// NewMockInterface creates a new mock instance
-func NewMockInterface(ctrl *gomock.Controller) *MockInterface {
-	mock := &MockInterface{ctrl: ctrl}
-	mock.recorder = &MockInterfaceMockRecorder{mock}
-	return mock
+// WorkflowRunRehydrate mocks base method
+func (m *MockWorkflowClient) WorkflowRunRehydrate(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunRehydrate", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
-	return m.recorder
+// WorkflowRunRehydrate indicates an expected call of WorkflowRunRehydrate
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunRehydrate(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunRehydrate", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunRehydrate), projectKey, workflowName, number)
 }
 
-// PostJSON mocks base method
-func (m *MockInterface) PostJSON(ctx context.Context, path string, in, out interface{}, mods ...cdsclient.RequestModifier) (int, error) {
+// WorkflowNodeStop mocks base method
+func (m *MockWorkflowClient) WorkflowNodeStop(projectKey, workflowName string, number, fromNodeID int64) (*sdk.WorkflowNodeRun, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, path, in, out}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "PostJSON", varargs...)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "WorkflowNodeStop", projectKey, workflowName, number, fromNodeID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PostJSON indicates an expected call of PostJSON
-func (mr *MockInterfaceMockRecorder) PostJSON(ctx, path, in, out interface{}, mods ...interface{}) *gomock.Call {
+// WorkflowNodeStop indicates an expected call of WorkflowNodeStop
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeStop(projectKey, workflowName, number, fromNodeID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, path, in, out}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostJSON", reflect.TypeOf((*MockInterface)(nil).PostJSON), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeStop", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeStop), projectKey, workflowName, number, fromNodeID)
 }
 
-// PutJSON mocks base method
-func (m *MockInterface) PutJSON(ctx context.Context, path string, in, out interface{}, mods ...cdsclient.RequestModifier) (int, error) {
+// WorkflowNodeRun mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRun(projectKey, name string, number, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, path, in, out}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "PutJSON", varargs...)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "WorkflowNodeRun", projectKey, name, number, nodeRunID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PutJSON indicates an expected call of PutJSON
-func (mr *MockInterfaceMockRecorder) PutJSON(ctx, path, in, out interface{}, mods ...interface{}) *gomock.Call {
+// WorkflowNodeRun indicates an expected call of WorkflowNodeRun
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRun(projectKey, name, number, nodeRunID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, path, in, out}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutJSON", reflect.TypeOf((*MockInterface)(nil).PutJSON), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRun", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRun), projectKey, name, number, nodeRunID)
 }
 
-// GetJSON mocks base method
-func (m *MockInterface) GetJSON(ctx context.Context, path string, out interface{}, mods ...cdsclient.RequestModifier) (int, error) {
+// WorkflowNodeRunManifest mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunManifest(projectKey, name string, number, nodeRunID int64) (*sdk.WorkflowNodeRunManifest, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, path, out}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "GetJSON", varargs...)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "WorkflowNodeRunManifest", projectKey, name, number, nodeRunID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunManifest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetJSON indicates an expected call of GetJSON
-func (mr *MockInterfaceMockRecorder) GetJSON(ctx, path, out interface{}, mods ...interface{}) *gomock.Call {
+// WorkflowNodeRunManifest indicates an expected call of WorkflowNodeRunManifest
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunManifest(projectKey, name, number, nodeRunID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, path, out}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJSON", reflect.TypeOf((*MockInterface)(nil).GetJSON), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunManifest", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunManifest), projectKey, name, number, nodeRunID)
 }
 
-// DeleteJSON mocks base method
-func (m *MockInterface) DeleteJSON(ctx context.Context, path string, out interface{}, mods ...cdsclient.RequestModifier) (int, error) {
+// WorkflowNodeRunArtifactDownload mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunArtifactDownload(projectKey, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, path, out}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "DeleteJSON", varargs...)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WorkflowNodeRunArtifactDownload", projectKey, name, a, w)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// DeleteJSON indicates an expected call of DeleteJSON
-func (mr *MockInterfaceMockRecorder) DeleteJSON(ctx, path, out interface{}, mods ...interface{}) *gomock.Call {
+// WorkflowNodeRunArtifactDownload indicates an expected call of WorkflowNodeRunArtifactDownload
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunArtifactDownload(projectKey, name, a, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, path, out}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJSON", reflect.TypeOf((*MockInterface)(nil).DeleteJSON), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunArtifactDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunArtifactDownload), projectKey, name, a, w)
 }
 
-// RequestJSON mocks base method
-func (m *MockInterface) RequestJSON(ctx context.Context, method, path string, in, out interface{}, mods ...cdsclient.RequestModifier) ([]byte, http.Header, int, error) {
+// WorkflowNodeRunJobStep mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunJobStep(projectKey, workflowName string, number, nodeRunID, job int64, step int) (*sdk.BuildState, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, method, path, in, out}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "RequestJSON", varargs...)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(http.Header)
-	ret2, _ := ret[2].(int)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret := m.ctrl.Call(m, "WorkflowNodeRunJobStep", projectKey, workflowName, number, nodeRunID, job, step)
+	ret0, _ := ret[0].(*sdk.BuildState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RequestJSON indicates an expected call of RequestJSON
-func (mr *MockInterfaceMockRecorder) RequestJSON(ctx, method, path, in, out interface{}, mods ...interface{}) *gomock.Call {
+// WorkflowNodeRunJobStep indicates an expected call of WorkflowNodeRunJobStep
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunJobStep(projectKey, workflowName, number, nodeRunID, job, step interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, method, path, in, out}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestJSON", reflect.TypeOf((*MockInterface)(nil).RequestJSON), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobStep", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunJobStep), projectKey, workflowName, number, nodeRunID, job, step)
 }
 
-// Request mocks base method
-func (m *MockInterface) Request(ctx context.Context, method, path string, body io.Reader, mods ...cdsclient.RequestModifier) ([]byte, http.Header, int, error) {
+// WorkflowRunLogsDownload mocks base method
+func (m *MockWorkflowClient) WorkflowRunLogsDownload(projectKey, workflowName string, number int64, format string, w io.Writer) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, method, path, body}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "Request", varargs...)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(http.Header)
-	ret2, _ := ret[2].(int)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret := m.ctrl.Call(m, "WorkflowRunLogsDownload", projectKey, workflowName, number, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Request indicates an expected call of Request
-func (mr *MockInterfaceMockRecorder) Request(ctx, method, path, body interface{}, mods ...interface{}) *gomock.Call {
+// WorkflowRunLogsDownload indicates an expected call of WorkflowRunLogsDownload
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunLogsDownload(projectKey, workflowName, number, format, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, method, path, body}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockInterface)(nil).Request), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunLogsDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunLogsDownload), projectKey, workflowName, number, format, w)
 }
 
-// HTTPClient mocks base method
-func (m *MockInterface) HTTPClient() *http.Client {
+// WorkflowRunReportDownload mocks base method
+func (m *MockWorkflowClient) WorkflowRunReportDownload(projectKey, workflowName string, number int64, format string, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HTTPClient")
-	ret0, _ := ret[0].(*http.Client)
+	ret := m.ctrl.Call(m, "WorkflowRunReportDownload", projectKey, workflowName, number, format, w)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// HTTPClient indicates an expected call of HTTPClient
-func (mr *MockInterfaceMockRecorder) HTTPClient() *gomock.Call {
+// WorkflowRunReportDownload indicates an expected call of WorkflowRunReportDownload
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunReportDownload(projectKey, workflowName, number, format, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTTPClient", reflect.TypeOf((*MockInterface)(nil).HTTPClient))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunReportDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunReportDownload), projectKey, workflowName, number, format, w)
 }
 
-// HTTPSSEClient mocks base method
-func (m *MockInterface) HTTPSSEClient() *http.Client {
+// WorkflowRunLinks mocks base method
+func (m *MockWorkflowClient) WorkflowRunLinks(projectKey, workflowName string, number int64) (*sdk.WorkflowRunLinks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HTTPSSEClient")
-	ret0, _ := ret[0].(*http.Client)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunLinks", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRunLinks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// HTTPSSEClient indicates an expected call of HTTPSSEClient
-func (mr *MockInterfaceMockRecorder) HTTPSSEClient() *gomock.Call {
+// WorkflowRunLinks indicates an expected call of WorkflowRunLinks
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunLinks(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTTPSSEClient", reflect.TypeOf((*MockInterface)(nil).HTTPSSEClient))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunLinks", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunLinks), projectKey, workflowName, number)
 }
 
-// AuthDriverList mocks base method
-func (m *MockInterface) AuthDriverList() (sdk.AuthDriverResponse, error) {
+// WorkflowNodeRunGateURL mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunGateURL(projectKey, workflowName string, number, nodeRunID int64) (*sdk.WorkflowNodeRunGateURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthDriverList")
-	ret0, _ := ret[0].(sdk.AuthDriverResponse)
+	ret := m.ctrl.Call(m, "WorkflowNodeRunGateURL", projectKey, workflowName, number, nodeRunID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunGateURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AuthDriverList indicates an expected call of AuthDriverList
-func (mr *MockInterfaceMockRecorder) AuthDriverList() *gomock.Call {
+// WorkflowNodeRunGateURL indicates an expected call of WorkflowNodeRunGateURL
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunGateURL(projectKey, workflowName, number, nodeRunID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthDriverList", reflect.TypeOf((*MockInterface)(nil).AuthDriverList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunGateURL", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunGateURL), projectKey, workflowName, number, nodeRunID)
 }
 
-// AuthConsumerSignin mocks base method
-func (m *MockInterface) AuthConsumerSignin(arg0 sdk.AuthConsumerType, arg1 sdk.AuthConsumerSigninRequest) (sdk.AuthConsumerSigninResponse, error) {
+// WorkflowNodeRunChangelogCompute mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunChangelogCompute(projectKey, workflowName string, number, nodeRunID int64) (*sdk.WorkflowRunChangelog, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerSignin", arg0, arg1)
-	ret0, _ := ret[0].(sdk.AuthConsumerSigninResponse)
+	ret := m.ctrl.Call(m, "WorkflowNodeRunChangelogCompute", projectKey, workflowName, number, nodeRunID)
+	ret0, _ := ret[0].(*sdk.WorkflowRunChangelog)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AuthConsumerSignin indicates an expected call of AuthConsumerSignin
-func (mr *MockInterfaceMockRecorder) AuthConsumerSignin(arg0, arg1 interface{}) *gomock.Call {
+// WorkflowNodeRunChangelogCompute indicates an expected call of WorkflowNodeRunChangelogCompute
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunChangelogCompute(projectKey, workflowName, number, nodeRunID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerSignin", reflect.TypeOf((*MockInterface)(nil).AuthConsumerSignin), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunChangelogCompute", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunChangelogCompute), projectKey, workflowName, number, nodeRunID)
 }
 
-// AuthConsumerLocalAskResetPassword mocks base method
-func (m *MockInterface) AuthConsumerLocalAskResetPassword(arg0 sdk.AuthConsumerSigninRequest) error {
+// WorkflowNodeRunRelease mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunRelease(projectKey, workflowName string, runNumber, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerLocalAskResetPassword", arg0)
+	ret := m.ctrl.Call(m, "WorkflowNodeRunRelease", projectKey, workflowName, runNumber, nodeRunID, release)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AuthConsumerLocalAskResetPassword indicates an expected call of AuthConsumerLocalAskResetPassword
-func (mr *MockInterfaceMockRecorder) AuthConsumerLocalAskResetPassword(arg0 interface{}) *gomock.Call {
+// WorkflowNodeRunRelease indicates an expected call of WorkflowNodeRunRelease
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunRelease(projectKey, workflowName, runNumber, nodeRunID, release interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalAskResetPassword", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalAskResetPassword), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunRelease", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunRelease), projectKey, workflowName, runNumber, nodeRunID, release)
 }
 
-// AuthConsumerLocalResetPassword mocks base method
-func (m *MockInterface) AuthConsumerLocalResetPassword(token, newPassword string) (sdk.AuthConsumerSigninResponse, error) {
+// WorkflowAllHooksList mocks base method
+func (m *MockWorkflowClient) WorkflowAllHooksList() ([]sdk.NodeHook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerLocalResetPassword", token, newPassword)
-	ret0, _ := ret[0].(sdk.AuthConsumerSigninResponse)
+	ret := m.ctrl.Call(m, "WorkflowAllHooksList")
+	ret0, _ := ret[0].([]sdk.NodeHook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AuthConsumerLocalResetPassword indicates an expected call of AuthConsumerLocalResetPassword
-func (mr *MockInterfaceMockRecorder) AuthConsumerLocalResetPassword(token, newPassword interface{}) *gomock.Call {
+// WorkflowAllHooksList indicates an expected call of WorkflowAllHooksList
+func (mr *MockWorkflowClientMockRecorder) WorkflowAllHooksList() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalResetPassword", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalResetPassword), token, newPassword)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAllHooksList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowAllHooksList))
 }
 
-// AuthConsumerLocalSignup mocks base method
-func (m *MockInterface) AuthConsumerLocalSignup(arg0 sdk.AuthConsumerSigninRequest) error {
+// WorkflowCachePush mocks base method
+func (m *MockWorkflowClient) WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerLocalSignup", arg0)
+	ret := m.ctrl.Call(m, "WorkflowCachePush", projectKey, integrationName, ref, tarContent, size)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AuthConsumerLocalSignup indicates an expected call of AuthConsumerLocalSignup
-func (mr *MockInterfaceMockRecorder) AuthConsumerLocalSignup(arg0 interface{}) *gomock.Call {
+// WorkflowCachePush indicates an expected call of WorkflowCachePush
+func (mr *MockWorkflowClientMockRecorder) WorkflowCachePush(projectKey, integrationName, ref, tarContent, size interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalSignup", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalSignup), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCachePush", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCachePush), projectKey, integrationName, ref, tarContent, size)
 }
 
-// AuthConsumerLocalSignupVerify mocks base method
-func (m *MockInterface) AuthConsumerLocalSignupVerify(token, initToken string) (sdk.AuthConsumerSigninResponse, error) {
+// WorkflowCachePull mocks base method
+func (m *MockWorkflowClient) WorkflowCachePull(projectKey, integrationName, ref string) (io.Reader, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerLocalSignupVerify", token, initToken)
-	ret0, _ := ret[0].(sdk.AuthConsumerSigninResponse)
+	ret := m.ctrl.Call(m, "WorkflowCachePull", projectKey, integrationName, ref)
+	ret0, _ := ret[0].(io.Reader)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AuthConsumerLocalSignupVerify indicates an expected call of AuthConsumerLocalSignupVerify
-func (mr *MockInterfaceMockRecorder) AuthConsumerLocalSignupVerify(token, initToken interface{}) *gomock.Call {
+// WorkflowCachePull indicates an expected call of WorkflowCachePull
+func (mr *MockWorkflowClientMockRecorder) WorkflowCachePull(projectKey, integrationName, ref interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalSignupVerify", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalSignupVerify), token, initToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCachePull", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCachePull), projectKey, integrationName, ref)
 }
 
-// AuthConsumerSignout mocks base method
-func (m *MockInterface) AuthConsumerSignout() error {
+// WorkflowTemplateInstanceGet mocks base method
+func (m *MockWorkflowClient) WorkflowTemplateInstanceGet(projectKey, workflowName string) (*sdk.WorkflowTemplateInstance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerSignout")
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowTemplateInstanceGet", projectKey, workflowName)
+	ret0, _ := ret[0].(*sdk.WorkflowTemplateInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AuthConsumerSignout indicates an expected call of AuthConsumerSignout
-func (mr *MockInterfaceMockRecorder) AuthConsumerSignout() *gomock.Call {
+// WorkflowTemplateInstanceGet indicates an expected call of WorkflowTemplateInstanceGet
+func (mr *MockWorkflowClientMockRecorder) WorkflowTemplateInstanceGet(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerSignout", reflect.TypeOf((*MockInterface)(nil).AuthConsumerSignout))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTemplateInstanceGet", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTemplateInstanceGet), projectKey, workflowName)
 }
 
-// AuthConsumerListByUser mocks base method
-func (m *MockInterface) AuthConsumerListByUser(username string) (sdk.AuthConsumers, error) {
+// WorkflowTransformAsCode mocks base method
+func (m *MockWorkflowClient) WorkflowTransformAsCode(projectKey, workflowName string) (*sdk.Operation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerListByUser", username)
-	ret0, _ := ret[0].(sdk.AuthConsumers)
+	ret := m.ctrl.Call(m, "WorkflowTransformAsCode", projectKey, workflowName)
+	ret0, _ := ret[0].(*sdk.Operation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AuthConsumerListByUser indicates an expected call of AuthConsumerListByUser
-func (mr *MockInterfaceMockRecorder) AuthConsumerListByUser(username interface{}) *gomock.Call {
+// WorkflowTransformAsCode indicates an expected call of WorkflowTransformAsCode
+func (mr *MockWorkflowClientMockRecorder) WorkflowTransformAsCode(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerListByUser", reflect.TypeOf((*MockInterface)(nil).AuthConsumerListByUser), username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTransformAsCode", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTransformAsCode), projectKey, workflowName)
 }
 
-// AuthConsumerDelete mocks base method
-func (m *MockInterface) AuthConsumerDelete(username, id string) error {
+// WorkflowTransformAsCodeFollow mocks base method
+func (m *MockWorkflowClient) WorkflowTransformAsCodeFollow(projectKey, workflowName string, ope *sdk.Operation) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerDelete", username, id)
+	ret := m.ctrl.Call(m, "WorkflowTransformAsCodeFollow", projectKey, workflowName, ope)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AuthConsumerDelete indicates an expected call of AuthConsumerDelete
-func (mr *MockInterfaceMockRecorder) AuthConsumerDelete(username, id interface{}) *gomock.Call {
+// WorkflowTransformAsCodeFollow indicates an expected call of WorkflowTransformAsCodeFollow
+func (mr *MockWorkflowClientMockRecorder) WorkflowTransformAsCodeFollow(projectKey, workflowName, ope interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerDelete", reflect.TypeOf((*MockInterface)(nil).AuthConsumerDelete), username, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTransformAsCodeFollow", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTransformAsCodeFollow), projectKey, workflowName, ope)
 }
 
-// AuthConsumerRegen mocks base method
-func (m *MockInterface) AuthConsumerRegen(username, id string) (sdk.AuthConsumerCreateResponse, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerRegen", username, id)
-	ret0, _ := ret[0].(sdk.AuthConsumerCreateResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockMonitoringClient is a mock of MonitoringClient interface
+type MockMonitoringClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMonitoringClientMockRecorder
 }
 
-// AuthConsumerRegen indicates an expected call of AuthConsumerRegen
-func (mr *MockInterfaceMockRecorder) AuthConsumerRegen(username, id interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerRegen", reflect.TypeOf((*MockInterface)(nil).AuthConsumerRegen), username, id)
+// MockMonitoringClientMockRecorder is the mock recorder for MockMonitoringClient
+type MockMonitoringClientMockRecorder struct {
+	mock *MockMonitoringClient
 }
 
-// AuthConsumerCreateForUser mocks base method
-func (m *MockInterface) AuthConsumerCreateForUser(username string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthConsumerCreateForUser", username, request)
-	ret0, _ := ret[0].(sdk.AuthConsumerCreateResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// NewMockMonitoringClient creates a new mock instance
+func NewMockMonitoringClient(ctrl *gomock.Controller) *MockMonitoringClient {
+	mock := &MockMonitoringClient{ctrl: ctrl}
+	mock.recorder = &MockMonitoringClientMockRecorder{mock}
+	return mock
 }
 
-// AuthConsumerCreateForUser indicates an expected call of AuthConsumerCreateForUser
-func (mr *MockInterfaceMockRecorder) AuthConsumerCreateForUser(username, request interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerCreateForUser", reflect.TypeOf((*MockInterface)(nil).AuthConsumerCreateForUser), username, request)
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMonitoringClient) EXPECT() *MockMonitoringClientMockRecorder {
+	return m.recorder
 }
 
-// AuthSessionListByUser mocks base method
-func (m *MockInterface) AuthSessionListByUser(username string) (sdk.AuthSessions, error) {
+// MonStatus mocks base method
+func (m *MockMonitoringClient) MonStatus() (*sdk.MonitoringStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthSessionListByUser", username)
-	ret0, _ := ret[0].(sdk.AuthSessions)
+	ret := m.ctrl.Call(m, "MonStatus")
+	ret0, _ := ret[0].(*sdk.MonitoringStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AuthSessionListByUser indicates an expected call of AuthSessionListByUser
-func (mr *MockInterfaceMockRecorder) AuthSessionListByUser(username interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthSessionListByUser", reflect.TypeOf((*MockInterface)(nil).AuthSessionListByUser), username)
-}
-
-// AuthSessionDelete mocks base method
-func (m *MockInterface) AuthSessionDelete(username, id string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthSessionDelete", username, id)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// AuthSessionDelete indicates an expected call of AuthSessionDelete
-func (mr *MockInterfaceMockRecorder) AuthSessionDelete(username, id interface{}) *gomock.Call {
+// MonStatus indicates an expected call of MonStatus
+func (mr *MockMonitoringClientMockRecorder) MonStatus() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthSessionDelete", reflect.TypeOf((*MockInterface)(nil).AuthSessionDelete), username, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonStatus", reflect.TypeOf((*MockMonitoringClient)(nil).MonStatus))
 }
 
-// AuthMe mocks base method
-func (m *MockInterface) AuthMe() (sdk.AuthCurrentConsumerResponse, error) {
+// MonVersion mocks base method
+func (m *MockMonitoringClient) MonVersion() (*sdk.Version, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthMe")
-	ret0, _ := ret[0].(sdk.AuthCurrentConsumerResponse)
+	ret := m.ctrl.Call(m, "MonVersion")
+	ret0, _ := ret[0].(*sdk.Version)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AuthMe indicates an expected call of AuthMe
-func (mr *MockInterfaceMockRecorder) AuthMe() *gomock.Call {
+// MonVersion indicates an expected call of MonVersion
+func (mr *MockMonitoringClientMockRecorder) MonVersion() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthMe", reflect.TypeOf((*MockInterface)(nil).AuthMe))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonVersion", reflect.TypeOf((*MockMonitoringClient)(nil).MonVersion))
 }
 
-// ActionDelete mocks base method
-func (m *MockInterface) ActionDelete(groupName, name string) error {
+// MonDBMigrate mocks base method
+func (m *MockMonitoringClient) MonDBMigrate() ([]sdk.MonDBMigrate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActionDelete", groupName, name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "MonDBMigrate")
+	ret0, _ := ret[0].([]sdk.MonDBMigrate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ActionDelete indicates an expected call of ActionDelete
-func (mr *MockInterfaceMockRecorder) ActionDelete(groupName, name interface{}) *gomock.Call {
+// MonDBMigrate indicates an expected call of MonDBMigrate
+func (mr *MockMonitoringClientMockRecorder) MonDBMigrate() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionDelete", reflect.TypeOf((*MockInterface)(nil).ActionDelete), groupName, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonDBMigrate", reflect.TypeOf((*MockMonitoringClient)(nil).MonDBMigrate))
 }
 
-// ActionGet mocks base method
-func (m *MockInterface) ActionGet(groupName, name string, mods ...cdsclient.RequestModifier) (*sdk.Action, error) {
+// MonServices mocks base method
+func (m *MockMonitoringClient) MonServices() (*sdk.MonitoringServicesMatrix, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{groupName, name}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "ActionGet", varargs...)
-	ret0, _ := ret[0].(*sdk.Action)
+	ret := m.ctrl.Call(m, "MonServices")
+	ret0, _ := ret[0].(*sdk.MonitoringServicesMatrix)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ActionGet indicates an expected call of ActionGet
-func (mr *MockInterfaceMockRecorder) ActionGet(groupName, name interface{}, mods ...interface{}) *gomock.Call {
+// MonServices indicates an expected call of MonServices
+func (mr *MockMonitoringClientMockRecorder) MonServices() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{groupName, name}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionGet", reflect.TypeOf((*MockInterface)(nil).ActionGet), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonServices", reflect.TypeOf((*MockMonitoringClient)(nil).MonServices))
 }
 
-// ActionList mocks base method
-func (m *MockInterface) ActionList() ([]sdk.Action, error) {
+// MonErrorsGet mocks base method
+func (m *MockMonitoringClient) MonErrorsGet(requestID string) ([]sdk.Error, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActionList")
-	ret0, _ := ret[0].([]sdk.Action)
+	ret := m.ctrl.Call(m, "MonErrorsGet", requestID)
+	ret0, _ := ret[0].([]sdk.Error)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ActionList indicates an expected call of ActionList
-func (mr *MockInterfaceMockRecorder) ActionList() *gomock.Call {
+// MonErrorsGet indicates an expected call of MonErrorsGet
+func (mr *MockMonitoringClientMockRecorder) MonErrorsGet(requestID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionList", reflect.TypeOf((*MockInterface)(nil).ActionList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonErrorsGet", reflect.TypeOf((*MockMonitoringClient)(nil).MonErrorsGet), requestID)
 }
 
-// ActionImport mocks base method
-func (m *MockInterface) ActionImport(content io.Reader, format string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActionImport", content, format)
-	ret0, _ := ret[0].(error)
-	return ret0
+// MockIntegrationClient is a mock of IntegrationClient interface
+type MockIntegrationClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntegrationClientMockRecorder
 }
 
-// ActionImport indicates an expected call of ActionImport
-func (mr *MockInterfaceMockRecorder) ActionImport(content, format interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionImport", reflect.TypeOf((*MockInterface)(nil).ActionImport), content, format)
+// MockIntegrationClientMockRecorder is the mock recorder for MockIntegrationClient
+type MockIntegrationClientMockRecorder struct {
+	mock *MockIntegrationClient
 }
 
-// ActionExport mocks base method
-func (m *MockInterface) ActionExport(groupName, name, format string) ([]byte, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActionExport", groupName, name, format)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// NewMockIntegrationClient creates a new mock instance
+func NewMockIntegrationClient(ctrl *gomock.Controller) *MockIntegrationClient {
+	mock := &MockIntegrationClient{ctrl: ctrl}
+	mock.recorder = &MockIntegrationClientMockRecorder{mock}
+	return mock
 }
 
-// ActionExport indicates an expected call of ActionExport
-func (mr *MockInterfaceMockRecorder) ActionExport(groupName, name, format interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionExport", reflect.TypeOf((*MockInterface)(nil).ActionExport), groupName, name, format)
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIntegrationClient) EXPECT() *MockIntegrationClientMockRecorder {
+	return m.recorder
 }
 
-// ActionBuiltinList mocks base method
-func (m *MockInterface) ActionBuiltinList() ([]sdk.Action, error) {
+// IntegrationModelList mocks base method
+func (m *MockIntegrationClient) IntegrationModelList() ([]sdk.IntegrationModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActionBuiltinList")
-	ret0, _ := ret[0].([]sdk.Action)
+	ret := m.ctrl.Call(m, "IntegrationModelList")
+	ret0, _ := ret[0].([]sdk.IntegrationModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ActionBuiltinList indicates an expected call of ActionBuiltinList
-func (mr *MockInterfaceMockRecorder) ActionBuiltinList() *gomock.Call {
+// IntegrationModelList indicates an expected call of IntegrationModelList
+func (mr *MockIntegrationClientMockRecorder) IntegrationModelList() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionBuiltinList", reflect.TypeOf((*MockInterface)(nil).ActionBuiltinList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelList", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelList))
 }
 
-// ActionBuiltinGet mocks base method
-func (m *MockInterface) ActionBuiltinGet(name string, mods ...cdsclient.RequestModifier) (*sdk.Action, error) {
+// IntegrationModelGet mocks base method
+func (m *MockIntegrationClient) IntegrationModelGet(name string) (sdk.IntegrationModel, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{name}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "ActionBuiltinGet", varargs...)
-	ret0, _ := ret[0].(*sdk.Action)
+	ret := m.ctrl.Call(m, "IntegrationModelGet", name)
+	ret0, _ := ret[0].(sdk.IntegrationModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ActionBuiltinGet indicates an expected call of ActionBuiltinGet
-func (mr *MockInterfaceMockRecorder) ActionBuiltinGet(name interface{}, mods ...interface{}) *gomock.Call {
+// IntegrationModelGet indicates an expected call of IntegrationModelGet
+func (mr *MockIntegrationClientMockRecorder) IntegrationModelGet(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{name}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionBuiltinGet", reflect.TypeOf((*MockInterface)(nil).ActionBuiltinGet), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelGet", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelGet), name)
 }
 
-// AdminDatabaseMigrationDelete mocks base method
-func (m *MockInterface) AdminDatabaseMigrationDelete(id string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseMigrationDelete", id)
+// IntegrationModelAdd mocks base method
+func (m_2 *MockIntegrationClient) IntegrationModelAdd(m *sdk.IntegrationModel) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "IntegrationModelAdd", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IntegrationModelAdd indicates an expected call of IntegrationModelAdd
+func (mr *MockIntegrationClientMockRecorder) IntegrationModelAdd(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelAdd", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelAdd), m)
+}
+
+// IntegrationModelUpdate mocks base method
+func (m_2 *MockIntegrationClient) IntegrationModelUpdate(m *sdk.IntegrationModel) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "IntegrationModelUpdate", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IntegrationModelUpdate indicates an expected call of IntegrationModelUpdate
+func (mr *MockIntegrationClientMockRecorder) IntegrationModelUpdate(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelUpdate", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelUpdate), m)
+}
+
+// IntegrationModelDelete mocks base method
+func (m *MockIntegrationClient) IntegrationModelDelete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntegrationModelDelete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IntegrationModelDelete indicates an expected call of IntegrationModelDelete
+func (mr *MockIntegrationClientMockRecorder) IntegrationModelDelete(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelDelete", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelDelete), name)
+}
+
+// MockInterface is a mock of Interface interface
+type MockInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInterfaceMockRecorder
+}
+
+// MockInterfaceMockRecorder is the mock recorder for MockInterface
+type MockInterfaceMockRecorder struct {
+	mock *MockInterface
+}
+
+// NewMockInterface creates a new mock instance
+func NewMockInterface(ctrl *gomock.Controller) *MockInterface {
+	mock := &MockInterface{ctrl: ctrl}
+	mock.recorder = &MockInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
+	return m.recorder
+}
+
+// PostJSON mocks base method
+func (m *MockInterface) PostJSON(ctx context.Context, path string, in, out interface{}, mods ...cdsclient.RequestModifier) (int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, path, in, out}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PostJSON", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostJSON indicates an expected call of PostJSON
+func (mr *MockInterfaceMockRecorder) PostJSON(ctx, path, in, out interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, path, in, out}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostJSON", reflect.TypeOf((*MockInterface)(nil).PostJSON), varargs...)
+}
+
+// PutJSON mocks base method
+func (m *MockInterface) PutJSON(ctx context.Context, path string, in, out interface{}, mods ...cdsclient.RequestModifier) (int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, path, in, out}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutJSON", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutJSON indicates an expected call of PutJSON
+func (mr *MockInterfaceMockRecorder) PutJSON(ctx, path, in, out interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, path, in, out}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutJSON", reflect.TypeOf((*MockInterface)(nil).PutJSON), varargs...)
+}
+
+// GetJSON mocks base method
+func (m *MockInterface) GetJSON(ctx context.Context, path string, out interface{}, mods ...cdsclient.RequestModifier) (int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, path, out}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetJSON", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJSON indicates an expected call of GetJSON
+func (mr *MockInterfaceMockRecorder) GetJSON(ctx, path, out interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, path, out}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJSON", reflect.TypeOf((*MockInterface)(nil).GetJSON), varargs...)
+}
+
+// DeleteJSON mocks base method
+func (m *MockInterface) DeleteJSON(ctx context.Context, path string, out interface{}, mods ...cdsclient.RequestModifier) (int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, path, out}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteJSON", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteJSON indicates an expected call of DeleteJSON
+func (mr *MockInterfaceMockRecorder) DeleteJSON(ctx, path, out interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, path, out}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJSON", reflect.TypeOf((*MockInterface)(nil).DeleteJSON), varargs...)
+}
+
+// RequestJSON mocks base method
+func (m *MockInterface) RequestJSON(ctx context.Context, method, path string, in, out interface{}, mods ...cdsclient.RequestModifier) ([]byte, http.Header, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, method, path, in, out}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestJSON", varargs...)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(http.Header)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// RequestJSON indicates an expected call of RequestJSON
+func (mr *MockInterfaceMockRecorder) RequestJSON(ctx, method, path, in, out interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, method, path, in, out}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestJSON", reflect.TypeOf((*MockInterface)(nil).RequestJSON), varargs...)
+}
+
+// Request mocks base method
+func (m *MockInterface) Request(ctx context.Context, method, path string, body io.Reader, mods ...cdsclient.RequestModifier) ([]byte, http.Header, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, method, path, body}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Request", varargs...)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(http.Header)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Request indicates an expected call of Request
+func (mr *MockInterfaceMockRecorder) Request(ctx, method, path, body interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, method, path, body}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockInterface)(nil).Request), varargs...)
+}
+
+// HTTPClient mocks base method
+func (m *MockInterface) HTTPClient() *http.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HTTPClient")
+	ret0, _ := ret[0].(*http.Client)
+	return ret0
+}
+
+// HTTPClient indicates an expected call of HTTPClient
+func (mr *MockInterfaceMockRecorder) HTTPClient() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTTPClient", reflect.TypeOf((*MockInterface)(nil).HTTPClient))
+}
+
+// HTTPSSEClient mocks base method
+func (m *MockInterface) HTTPSSEClient() *http.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HTTPSSEClient")
+	ret0, _ := ret[0].(*http.Client)
+	return ret0
+}
+
+// HTTPSSEClient indicates an expected call of HTTPSSEClient
+func (mr *MockInterfaceMockRecorder) HTTPSSEClient() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTTPSSEClient", reflect.TypeOf((*MockInterface)(nil).HTTPSSEClient))
+}
+
+// AuthDriverList mocks base method
+func (m *MockInterface) AuthDriverList() (sdk.AuthDriverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthDriverList")
+	ret0, _ := ret[0].(sdk.AuthDriverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthDriverList indicates an expected call of AuthDriverList
+func (mr *MockInterfaceMockRecorder) AuthDriverList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthDriverList", reflect.TypeOf((*MockInterface)(nil).AuthDriverList))
+}
+
+// AuthConsumerSignin mocks base method
+func (m *MockInterface) AuthConsumerSignin(arg0 sdk.AuthConsumerType, arg1 sdk.AuthConsumerSigninRequest) (sdk.AuthConsumerSigninResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerSignin", arg0, arg1)
+	ret0, _ := ret[0].(sdk.AuthConsumerSigninResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerSignin indicates an expected call of AuthConsumerSignin
+func (mr *MockInterfaceMockRecorder) AuthConsumerSignin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerSignin", reflect.TypeOf((*MockInterface)(nil).AuthConsumerSignin), arg0, arg1)
+}
+
+// AuthConsumerLocalAskResetPassword mocks base method
+func (m *MockInterface) AuthConsumerLocalAskResetPassword(arg0 sdk.AuthConsumerSigninRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerLocalAskResetPassword", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthConsumerLocalAskResetPassword indicates an expected call of AuthConsumerLocalAskResetPassword
+func (mr *MockInterfaceMockRecorder) AuthConsumerLocalAskResetPassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalAskResetPassword", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalAskResetPassword), arg0)
+}
+
+// AuthConsumerLocalResetPassword mocks base method
+func (m *MockInterface) AuthConsumerLocalResetPassword(token, newPassword string) (sdk.AuthConsumerSigninResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerLocalResetPassword", token, newPassword)
+	ret0, _ := ret[0].(sdk.AuthConsumerSigninResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerLocalResetPassword indicates an expected call of AuthConsumerLocalResetPassword
+func (mr *MockInterfaceMockRecorder) AuthConsumerLocalResetPassword(token, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalResetPassword", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalResetPassword), token, newPassword)
+}
+
+// AuthConsumerLocalSignup mocks base method
+func (m *MockInterface) AuthConsumerLocalSignup(arg0 sdk.AuthConsumerSigninRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerLocalSignup", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthConsumerLocalSignup indicates an expected call of AuthConsumerLocalSignup
+func (mr *MockInterfaceMockRecorder) AuthConsumerLocalSignup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalSignup", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalSignup), arg0)
+}
+
+// AuthConsumerLocalSignupVerify mocks base method
+func (m *MockInterface) AuthConsumerLocalSignupVerify(token, initToken string) (sdk.AuthConsumerSigninResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerLocalSignupVerify", token, initToken)
+	ret0, _ := ret[0].(sdk.AuthConsumerSigninResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerLocalSignupVerify indicates an expected call of AuthConsumerLocalSignupVerify
+func (mr *MockInterfaceMockRecorder) AuthConsumerLocalSignupVerify(token, initToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerLocalSignupVerify", reflect.TypeOf((*MockInterface)(nil).AuthConsumerLocalSignupVerify), token, initToken)
+}
+
+// AuthConsumerSignout mocks base method
+func (m *MockInterface) AuthConsumerSignout() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerSignout")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthConsumerSignout indicates an expected call of AuthConsumerSignout
+func (mr *MockInterfaceMockRecorder) AuthConsumerSignout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerSignout", reflect.TypeOf((*MockInterface)(nil).AuthConsumerSignout))
+}
+
+// AuthConsumerListByUser mocks base method
+func (m *MockInterface) AuthConsumerListByUser(username string) (sdk.AuthConsumers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerListByUser", username)
+	ret0, _ := ret[0].(sdk.AuthConsumers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerListByUser indicates an expected call of AuthConsumerListByUser
+func (mr *MockInterfaceMockRecorder) AuthConsumerListByUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerListByUser", reflect.TypeOf((*MockInterface)(nil).AuthConsumerListByUser), username)
+}
+
+// AuthConsumerDelete mocks base method
+func (m *MockInterface) AuthConsumerDelete(username, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerDelete", username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthConsumerDelete indicates an expected call of AuthConsumerDelete
+func (mr *MockInterfaceMockRecorder) AuthConsumerDelete(username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerDelete", reflect.TypeOf((*MockInterface)(nil).AuthConsumerDelete), username, id)
+}
+
+// AuthConsumerRegen mocks base method
+func (m *MockInterface) AuthConsumerRegen(username, id string) (sdk.AuthConsumerCreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerRegen", username, id)
+	ret0, _ := ret[0].(sdk.AuthConsumerCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerRegen indicates an expected call of AuthConsumerRegen
+func (mr *MockInterfaceMockRecorder) AuthConsumerRegen(username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerRegen", reflect.TypeOf((*MockInterface)(nil).AuthConsumerRegen), username, id)
+}
+
+// AuthConsumerCreateForUser mocks base method
+func (m *MockInterface) AuthConsumerCreateForUser(username string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerCreateForUser", username, request)
+	ret0, _ := ret[0].(sdk.AuthConsumerCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerCreateForUser indicates an expected call of AuthConsumerCreateForUser
+func (mr *MockInterfaceMockRecorder) AuthConsumerCreateForUser(username, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerCreateForUser", reflect.TypeOf((*MockInterface)(nil).AuthConsumerCreateForUser), username, request)
+}
+
+// AuthConsumerListByProject mocks base method
+func (m *MockInterface) AuthConsumerListByProject(projectKey string) (sdk.AuthConsumers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerListByProject", projectKey)
+	ret0, _ := ret[0].(sdk.AuthConsumers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerListByProject indicates an expected call of AuthConsumerListByProject
+func (mr *MockInterfaceMockRecorder) AuthConsumerListByProject(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerListByProject", reflect.TypeOf((*MockInterface)(nil).AuthConsumerListByProject), projectKey)
+}
+
+// AuthConsumerCreateForProject mocks base method
+func (m *MockInterface) AuthConsumerCreateForProject(projectKey string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerCreateForProject", projectKey, request)
+	ret0, _ := ret[0].(sdk.AuthConsumerCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerCreateForProject indicates an expected call of AuthConsumerCreateForProject
+func (mr *MockInterfaceMockRecorder) AuthConsumerCreateForProject(projectKey, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerCreateForProject", reflect.TypeOf((*MockInterface)(nil).AuthConsumerCreateForProject), projectKey, request)
+}
+
+// AuthConsumerDeleteForProject mocks base method
+func (m *MockInterface) AuthConsumerDeleteForProject(projectKey, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerDeleteForProject", projectKey, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthConsumerDeleteForProject indicates an expected call of AuthConsumerDeleteForProject
+func (mr *MockInterfaceMockRecorder) AuthConsumerDeleteForProject(projectKey, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerDeleteForProject", reflect.TypeOf((*MockInterface)(nil).AuthConsumerDeleteForProject), projectKey, id)
+}
+
+// AuthSessionListByUser mocks base method
+func (m *MockInterface) AuthSessionListByUser(username string) (sdk.AuthSessions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthSessionListByUser", username)
+	ret0, _ := ret[0].(sdk.AuthSessions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthSessionListByUser indicates an expected call of AuthSessionListByUser
+func (mr *MockInterfaceMockRecorder) AuthSessionListByUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthSessionListByUser", reflect.TypeOf((*MockInterface)(nil).AuthSessionListByUser), username)
+}
+
+// AuthSessionDelete mocks base method
+func (m *MockInterface) AuthSessionDelete(username, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthSessionDelete", username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthSessionDelete indicates an expected call of AuthSessionDelete
+func (mr *MockInterfaceMockRecorder) AuthSessionDelete(username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthSessionDelete", reflect.TypeOf((*MockInterface)(nil).AuthSessionDelete), username, id)
+}
+
+// AuthMe mocks base method
+func (m *MockInterface) AuthMe() (sdk.AuthCurrentConsumerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthMe")
+	ret0, _ := ret[0].(sdk.AuthCurrentConsumerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthMe indicates an expected call of AuthMe
+func (mr *MockInterfaceMockRecorder) AuthMe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthMe", reflect.TypeOf((*MockInterface)(nil).AuthMe))
+}
+
+// ActionDelete mocks base method
+func (m *MockInterface) ActionDelete(groupName, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionDelete", groupName, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActionDelete indicates an expected call of ActionDelete
+func (mr *MockInterfaceMockRecorder) ActionDelete(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionDelete", reflect.TypeOf((*MockInterface)(nil).ActionDelete), groupName, name)
+}
+
+// ActionGet mocks base method
+func (m *MockInterface) ActionGet(groupName, name string, mods ...cdsclient.RequestModifier) (*sdk.Action, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{groupName, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ActionGet", varargs...)
+	ret0, _ := ret[0].(*sdk.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionGet indicates an expected call of ActionGet
+func (mr *MockInterfaceMockRecorder) ActionGet(groupName, name interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{groupName, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionGet", reflect.TypeOf((*MockInterface)(nil).ActionGet), varargs...)
+}
+
+// ActionList mocks base method
+func (m *MockInterface) ActionList() ([]sdk.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionList")
+	ret0, _ := ret[0].([]sdk.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionList indicates an expected call of ActionList
+func (mr *MockInterfaceMockRecorder) ActionList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionList", reflect.TypeOf((*MockInterface)(nil).ActionList))
+}
+
+// ActionImport mocks base method
+func (m *MockInterface) ActionImport(content io.Reader, format string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionImport", content, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActionImport indicates an expected call of ActionImport
+func (mr *MockInterfaceMockRecorder) ActionImport(content, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionImport", reflect.TypeOf((*MockInterface)(nil).ActionImport), content, format)
+}
+
+// ActionExport mocks base method
+func (m *MockInterface) ActionExport(groupName, name, format string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionExport", groupName, name, format)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionExport indicates an expected call of ActionExport
+func (mr *MockInterfaceMockRecorder) ActionExport(groupName, name, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionExport", reflect.TypeOf((*MockInterface)(nil).ActionExport), groupName, name, format)
+}
+
+// ActionVersionList mocks base method
+func (m *MockInterface) ActionVersionList(groupName, name string) ([]sdk.ActionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionList", groupName, name)
+	ret0, _ := ret[0].([]sdk.ActionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionList indicates an expected call of ActionVersionList
+func (mr *MockInterfaceMockRecorder) ActionVersionList(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionList", reflect.TypeOf((*MockInterface)(nil).ActionVersionList), groupName, name)
+}
+
+// ActionVersionPublish mocks base method
+func (m *MockInterface) ActionVersionPublish(groupName, name, version string) (*sdk.ActionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionPublish", groupName, name, version)
+	ret0, _ := ret[0].(*sdk.ActionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionPublish indicates an expected call of ActionVersionPublish
+func (mr *MockInterfaceMockRecorder) ActionVersionPublish(groupName, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionPublish", reflect.TypeOf((*MockInterface)(nil).ActionVersionPublish), groupName, name, version)
+}
+
+// ActionVersionUsage mocks base method
+func (m *MockInterface) ActionVersionUsage(groupName, name, version string) ([]sdk.ActionVersionUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionUsage", groupName, name, version)
+	ret0, _ := ret[0].([]sdk.ActionVersionUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionUsage indicates an expected call of ActionVersionUsage
+func (mr *MockInterfaceMockRecorder) ActionVersionUsage(groupName, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionUsage", reflect.TypeOf((*MockInterface)(nil).ActionVersionUsage), groupName, name, version)
+}
+
+// ActionBuiltinList mocks base method
+func (m *MockInterface) ActionBuiltinList() ([]sdk.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionBuiltinList")
+	ret0, _ := ret[0].([]sdk.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionBuiltinList indicates an expected call of ActionBuiltinList
+func (mr *MockInterfaceMockRecorder) ActionBuiltinList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionBuiltinList", reflect.TypeOf((*MockInterface)(nil).ActionBuiltinList))
+}
+
+// ActionBuiltinGet mocks base method
+func (m *MockInterface) ActionBuiltinGet(name string, mods ...cdsclient.RequestModifier) (*sdk.Action, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ActionBuiltinGet", varargs...)
+	ret0, _ := ret[0].(*sdk.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionBuiltinGet indicates an expected call of ActionBuiltinGet
+func (mr *MockInterfaceMockRecorder) ActionBuiltinGet(name interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionBuiltinGet", reflect.TypeOf((*MockInterface)(nil).ActionBuiltinGet), varargs...)
+}
+
+// AdminDatabaseMigrationDelete mocks base method
+func (m *MockInterface) AdminDatabaseMigrationDelete(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseMigrationDelete", id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
@@ -4561,2621 +5825,3598 @@ func (m *MockInterface) AdminDatabaseMigrationDelete(id string) error {
 // AdminDatabaseMigrationDelete indicates an expected call of AdminDatabaseMigrationDelete
 func (mr *MockInterfaceMockRecorder) AdminDatabaseMigrationDelete(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseMigrationDelete", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseMigrationDelete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseMigrationDelete", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseMigrationDelete), id)
+}
+
+// AdminDatabaseMigrationUnlock mocks base method
+func (m *MockInterface) AdminDatabaseMigrationUnlock(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseMigrationUnlock", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDatabaseMigrationUnlock indicates an expected call of AdminDatabaseMigrationUnlock
+func (mr *MockInterfaceMockRecorder) AdminDatabaseMigrationUnlock(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseMigrationUnlock", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseMigrationUnlock), id)
+}
+
+// AdminDatabaseMigrationsList mocks base method
+func (m *MockInterface) AdminDatabaseMigrationsList() ([]sdk.DatabaseMigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseMigrationsList")
+	ret0, _ := ret[0].([]sdk.DatabaseMigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseMigrationsList indicates an expected call of AdminDatabaseMigrationsList
+func (mr *MockInterfaceMockRecorder) AdminDatabaseMigrationsList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseMigrationsList", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseMigrationsList))
+}
+
+// AdminDatabaseSignaturesResume mocks base method
+func (m *MockInterface) AdminDatabaseSignaturesResume() (sdk.CanonicalFormUsageResume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseSignaturesResume")
+	ret0, _ := ret[0].(sdk.CanonicalFormUsageResume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseSignaturesResume indicates an expected call of AdminDatabaseSignaturesResume
+func (mr *MockInterfaceMockRecorder) AdminDatabaseSignaturesResume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseSignaturesResume", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseSignaturesResume))
+}
+
+// AdminDatabaseSignaturesRollEntity mocks base method
+func (m *MockInterface) AdminDatabaseSignaturesRollEntity(e string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseSignaturesRollEntity", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDatabaseSignaturesRollEntity indicates an expected call of AdminDatabaseSignaturesRollEntity
+func (mr *MockInterfaceMockRecorder) AdminDatabaseSignaturesRollEntity(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseSignaturesRollEntity", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseSignaturesRollEntity), e)
+}
+
+// AdminDatabaseSignaturesRollAllEntities mocks base method
+func (m *MockInterface) AdminDatabaseSignaturesRollAllEntities() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseSignaturesRollAllEntities")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDatabaseSignaturesRollAllEntities indicates an expected call of AdminDatabaseSignaturesRollAllEntities
+func (mr *MockInterfaceMockRecorder) AdminDatabaseSignaturesRollAllEntities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseSignaturesRollAllEntities", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseSignaturesRollAllEntities))
+}
+
+// AdminDatabaseListEncryptedEntities mocks base method
+func (m *MockInterface) AdminDatabaseListEncryptedEntities() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseListEncryptedEntities")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminDatabaseListEncryptedEntities indicates an expected call of AdminDatabaseListEncryptedEntities
+func (mr *MockInterfaceMockRecorder) AdminDatabaseListEncryptedEntities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseListEncryptedEntities", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseListEncryptedEntities))
+}
+
+// AdminDatabaseRollEncryptedEntity mocks base method
+func (m *MockInterface) AdminDatabaseRollEncryptedEntity(e string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollEncryptedEntity", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDatabaseRollEncryptedEntity indicates an expected call of AdminDatabaseRollEncryptedEntity
+func (mr *MockInterfaceMockRecorder) AdminDatabaseRollEncryptedEntity(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollEncryptedEntity", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollEncryptedEntity), e)
+}
+
+// AdminDatabaseRollAllEncryptedEntities mocks base method
+func (m *MockInterface) AdminDatabaseRollAllEncryptedEntities() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminDatabaseRollAllEncryptedEntities")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminDatabaseRollAllEncryptedEntities indicates an expected call of AdminDatabaseRollAllEncryptedEntities
+func (mr *MockInterfaceMockRecorder) AdminDatabaseRollAllEncryptedEntities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollAllEncryptedEntities", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollAllEncryptedEntities))
+}
+
+// AdminCDSMigrationList mocks base method
+func (m *MockInterface) AdminCDSMigrationList() ([]sdk.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminCDSMigrationList")
+	ret0, _ := ret[0].([]sdk.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminCDSMigrationList indicates an expected call of AdminCDSMigrationList
+func (mr *MockInterfaceMockRecorder) AdminCDSMigrationList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationList", reflect.TypeOf((*MockInterface)(nil).AdminCDSMigrationList))
+}
+
+// AdminCDSMigrationCancel mocks base method
+func (m *MockInterface) AdminCDSMigrationCancel(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminCDSMigrationCancel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminCDSMigrationCancel indicates an expected call of AdminCDSMigrationCancel
+func (mr *MockInterfaceMockRecorder) AdminCDSMigrationCancel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationCancel", reflect.TypeOf((*MockInterface)(nil).AdminCDSMigrationCancel), id)
+}
+
+// AdminCDSMigrationReset mocks base method
+func (m *MockInterface) AdminCDSMigrationReset(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminCDSMigrationReset", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminCDSMigrationReset indicates an expected call of AdminCDSMigrationReset
+func (mr *MockInterfaceMockRecorder) AdminCDSMigrationReset(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationReset", reflect.TypeOf((*MockInterface)(nil).AdminCDSMigrationReset), id)
+}
+
+// AdminUserScrub mocks base method
+func (m *MockInterface) AdminUserScrub(req sdk.UserScrubRequest) (*sdk.UserScrubReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminUserScrub", req)
+	ret0, _ := ret[0].(*sdk.UserScrubReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminUserScrub indicates an expected call of AdminUserScrub
+func (mr *MockInterfaceMockRecorder) AdminUserScrub(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserScrub", reflect.TypeOf((*MockInterface)(nil).AdminUserScrub), req)
+}
+
+// AdminProjectQueueSettingsGet mocks base method
+func (m *MockInterface) AdminProjectQueueSettingsGet(projectKey string) (*sdk.ProjectQueueSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminProjectQueueSettingsGet", projectKey)
+	ret0, _ := ret[0].(*sdk.ProjectQueueSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminProjectQueueSettingsGet indicates an expected call of AdminProjectQueueSettingsGet
+func (mr *MockInterfaceMockRecorder) AdminProjectQueueSettingsGet(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminProjectQueueSettingsGet", reflect.TypeOf((*MockInterface)(nil).AdminProjectQueueSettingsGet), projectKey)
+}
+
+// AdminProjectQueueSettingsUpdate mocks base method
+func (m *MockInterface) AdminProjectQueueSettingsUpdate(projectKey string, settings sdk.ProjectQueueSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminProjectQueueSettingsUpdate", projectKey, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminProjectQueueSettingsUpdate indicates an expected call of AdminProjectQueueSettingsUpdate
+func (mr *MockInterfaceMockRecorder) AdminProjectQueueSettingsUpdate(projectKey, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminProjectQueueSettingsUpdate", reflect.TypeOf((*MockInterface)(nil).AdminProjectQueueSettingsUpdate), projectKey, settings)
+}
+
+// AdminQuotaList mocks base method
+func (m *MockInterface) AdminQuotaList() ([]sdk.QuotaReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuotaList")
+	ret0, _ := ret[0].([]sdk.QuotaReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminQuotaList indicates an expected call of AdminQuotaList
+func (mr *MockInterfaceMockRecorder) AdminQuotaList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuotaList", reflect.TypeOf((*MockInterface)(nil).AdminQuotaList))
+}
+
+// AdminQuotaGet mocks base method
+func (m *MockInterface) AdminQuotaGet(entityType, name string) (*sdk.QuotaReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuotaGet", entityType, name)
+	ret0, _ := ret[0].(*sdk.QuotaReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminQuotaGet indicates an expected call of AdminQuotaGet
+func (mr *MockInterfaceMockRecorder) AdminQuotaGet(entityType, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuotaGet", reflect.TypeOf((*MockInterface)(nil).AdminQuotaGet), entityType, name)
+}
+
+// AdminQuotaUpdate mocks base method
+func (m *MockInterface) AdminQuotaUpdate(entityType, name string, q sdk.Quota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuotaUpdate", entityType, name, q)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQuotaUpdate indicates an expected call of AdminQuotaUpdate
+func (mr *MockInterfaceMockRecorder) AdminQuotaUpdate(entityType, name, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuotaUpdate", reflect.TypeOf((*MockInterface)(nil).AdminQuotaUpdate), entityType, name, q)
+}
+
+// AdminQuotaDelete mocks base method
+func (m *MockInterface) AdminQuotaDelete(entityType, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminQuotaDelete", entityType, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminQuotaDelete indicates an expected call of AdminQuotaDelete
+func (mr *MockInterfaceMockRecorder) AdminQuotaDelete(entityType, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminQuotaDelete", reflect.TypeOf((*MockInterface)(nil).AdminQuotaDelete), entityType, name)
+}
+
+// AdminSBOMSearch mocks base method
+func (m *MockInterface) AdminSBOMSearch(name, version string) ([]sdk.SBOMSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminSBOMSearch", name, version)
+	ret0, _ := ret[0].([]sdk.SBOMSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminSBOMSearch indicates an expected call of AdminSBOMSearch
+func (mr *MockInterfaceMockRecorder) AdminSBOMSearch(name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminSBOMSearch", reflect.TypeOf((*MockInterface)(nil).AdminSBOMSearch), name, version)
+}
+
+// AdminAssetsExport mocks base method
+func (m *MockInterface) AdminAssetsExport(w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminAssetsExport", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminAssetsExport indicates an expected call of AdminAssetsExport
+func (mr *MockInterfaceMockRecorder) AdminAssetsExport(w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAssetsExport", reflect.TypeOf((*MockInterface)(nil).AdminAssetsExport), w)
+}
+
+// AdminAssetsImport mocks base method
+func (m *MockInterface) AdminAssetsImport(r io.Reader) (*sdk.AssetsBundleReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminAssetsImport", r)
+	ret0, _ := ret[0].(*sdk.AssetsBundleReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminAssetsImport indicates an expected call of AdminAssetsImport
+func (mr *MockInterfaceMockRecorder) AdminAssetsImport(r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminAssetsImport", reflect.TypeOf((*MockInterface)(nil).AdminAssetsImport), r)
+}
+
+// Services mocks base method
+func (m *MockInterface) Services() ([]sdk.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Services")
+	ret0, _ := ret[0].([]sdk.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Services indicates an expected call of Services
+func (mr *MockInterfaceMockRecorder) Services() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Services", reflect.TypeOf((*MockInterface)(nil).Services))
+}
+
+// ServicesByName mocks base method
+func (m *MockInterface) ServicesByName(name string) (*sdk.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServicesByName", name)
+	ret0, _ := ret[0].(*sdk.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServicesByName indicates an expected call of ServicesByName
+func (mr *MockInterfaceMockRecorder) ServicesByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServicesByName", reflect.TypeOf((*MockInterface)(nil).ServicesByName), name)
+}
+
+// ServiceDelete mocks base method
+func (m *MockInterface) ServiceDelete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceDelete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ServiceDelete indicates an expected call of ServiceDelete
+func (mr *MockInterfaceMockRecorder) ServiceDelete(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceDelete", reflect.TypeOf((*MockInterface)(nil).ServiceDelete), name)
+}
+
+// ServicesByType mocks base method
+func (m *MockInterface) ServicesByType(stype string) ([]sdk.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServicesByType", stype)
+	ret0, _ := ret[0].([]sdk.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServicesByType indicates an expected call of ServicesByType
+func (mr *MockInterfaceMockRecorder) ServicesByType(stype interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServicesByType", reflect.TypeOf((*MockInterface)(nil).ServicesByType), stype)
+}
+
+// ServiceNameCallGET mocks base method
+func (m *MockInterface) ServiceNameCallGET(name, url string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceNameCallGET", name, url)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceNameCallGET indicates an expected call of ServiceNameCallGET
+func (mr *MockInterfaceMockRecorder) ServiceNameCallGET(name, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceNameCallGET", reflect.TypeOf((*MockInterface)(nil).ServiceNameCallGET), name, url)
+}
+
+// ServiceNameCallPOST mocks base method
+func (m *MockInterface) ServiceNameCallPOST(name, url string, body []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceNameCallPOST", name, url, body)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceNameCallPOST indicates an expected call of ServiceNameCallPOST
+func (mr *MockInterfaceMockRecorder) ServiceNameCallPOST(name, url, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceNameCallPOST", reflect.TypeOf((*MockInterface)(nil).ServiceNameCallPOST), name, url, body)
+}
+
+// ServiceCallGET mocks base method
+func (m *MockInterface) ServiceCallGET(stype, url string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceCallGET", stype, url)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceCallGET indicates an expected call of ServiceCallGET
+func (mr *MockInterfaceMockRecorder) ServiceCallGET(stype, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallGET", reflect.TypeOf((*MockInterface)(nil).ServiceCallGET), stype, url)
+}
+
+// ServiceCallPOST mocks base method
+func (m *MockInterface) ServiceCallPOST(stype, url string, body []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceCallPOST", stype, url, body)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceCallPOST indicates an expected call of ServiceCallPOST
+func (mr *MockInterfaceMockRecorder) ServiceCallPOST(stype, url, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallPOST", reflect.TypeOf((*MockInterface)(nil).ServiceCallPOST), stype, url, body)
+}
+
+// ServiceCallPUT mocks base method
+func (m *MockInterface) ServiceCallPUT(stype, url string, body []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceCallPUT", stype, url, body)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceCallPUT indicates an expected call of ServiceCallPUT
+func (mr *MockInterfaceMockRecorder) ServiceCallPUT(stype, url, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallPUT", reflect.TypeOf((*MockInterface)(nil).ServiceCallPUT), stype, url, body)
+}
+
+// ServiceCallDELETE mocks base method
+func (m *MockInterface) ServiceCallDELETE(stype, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceCallDELETE", stype, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ServiceCallDELETE indicates an expected call of ServiceCallDELETE
+func (mr *MockInterfaceMockRecorder) ServiceCallDELETE(stype, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallDELETE", reflect.TypeOf((*MockInterface)(nil).ServiceCallDELETE), stype, url)
+}
+
+// APIURL mocks base method
+func (m *MockInterface) APIURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// APIURL indicates an expected call of APIURL
+func (mr *MockInterfaceMockRecorder) APIURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIURL", reflect.TypeOf((*MockInterface)(nil).APIURL))
+}
+
+// ApplicationAttachToReposistoriesManager mocks base method
+func (m *MockInterface) ApplicationAttachToReposistoriesManager(projectKey, appName, reposManager, repoFullname string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationAttachToReposistoriesManager", projectKey, appName, reposManager, repoFullname)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationAttachToReposistoriesManager indicates an expected call of ApplicationAttachToReposistoriesManager
+func (mr *MockInterfaceMockRecorder) ApplicationAttachToReposistoriesManager(projectKey, appName, reposManager, repoFullname interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationAttachToReposistoriesManager", reflect.TypeOf((*MockInterface)(nil).ApplicationAttachToReposistoriesManager), projectKey, appName, reposManager, repoFullname)
+}
+
+// ApplicationCreate mocks base method
+func (m *MockInterface) ApplicationCreate(projectKey string, app *sdk.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationCreate", projectKey, app)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationCreate indicates an expected call of ApplicationCreate
+func (mr *MockInterfaceMockRecorder) ApplicationCreate(projectKey, app interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationCreate", reflect.TypeOf((*MockInterface)(nil).ApplicationCreate), projectKey, app)
+}
+
+// ApplicationUpdate mocks base method
+func (m *MockInterface) ApplicationUpdate(projectKey, appName string, app *sdk.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationUpdate", projectKey, appName, app)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationUpdate indicates an expected call of ApplicationUpdate
+func (mr *MockInterfaceMockRecorder) ApplicationUpdate(projectKey, appName, app interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationUpdate", reflect.TypeOf((*MockInterface)(nil).ApplicationUpdate), projectKey, appName, app)
+}
+
+// ApplicationDelete mocks base method
+func (m *MockInterface) ApplicationDelete(projectKey, appName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationDelete", projectKey, appName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationDelete indicates an expected call of ApplicationDelete
+func (mr *MockInterfaceMockRecorder) ApplicationDelete(projectKey, appName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationDelete", reflect.TypeOf((*MockInterface)(nil).ApplicationDelete), projectKey, appName)
+}
+
+// ApplicationGet mocks base method
+func (m *MockInterface) ApplicationGet(projectKey, appName string, opts ...cdsclient.RequestModifier) (*sdk.Application, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, appName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ApplicationGet", varargs...)
+	ret0, _ := ret[0].(*sdk.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationGet indicates an expected call of ApplicationGet
+func (mr *MockInterfaceMockRecorder) ApplicationGet(projectKey, appName interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{projectKey, appName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationGet", reflect.TypeOf((*MockInterface)(nil).ApplicationGet), varargs...)
+}
+
+// ApplicationList mocks base method
+func (m *MockInterface) ApplicationList(projectKey string) ([]sdk.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationList", projectKey)
+	ret0, _ := ret[0].([]sdk.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationList indicates an expected call of ApplicationList
+func (mr *MockInterfaceMockRecorder) ApplicationList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationList", reflect.TypeOf((*MockInterface)(nil).ApplicationList), projectKey)
+}
+
+// ApplicationVariablesList mocks base method
+func (m *MockInterface) ApplicationVariablesList(projectKey, appName string) ([]sdk.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationVariablesList", projectKey, appName)
+	ret0, _ := ret[0].([]sdk.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationVariablesList indicates an expected call of ApplicationVariablesList
+func (mr *MockInterfaceMockRecorder) ApplicationVariablesList(projectKey, appName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariablesList", reflect.TypeOf((*MockInterface)(nil).ApplicationVariablesList), projectKey, appName)
+}
+
+// ApplicationVariableCreate mocks base method
+func (m *MockInterface) ApplicationVariableCreate(projectKey, appName string, variable *sdk.Variable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationVariableCreate", projectKey, appName, variable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationVariableCreate indicates an expected call of ApplicationVariableCreate
+func (mr *MockInterfaceMockRecorder) ApplicationVariableCreate(projectKey, appName, variable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableCreate", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableCreate), projectKey, appName, variable)
+}
+
+// ApplicationVariableDelete mocks base method
+func (m *MockInterface) ApplicationVariableDelete(projectKey, appName, varName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationVariableDelete", projectKey, appName, varName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationVariableDelete indicates an expected call of ApplicationVariableDelete
+func (mr *MockInterfaceMockRecorder) ApplicationVariableDelete(projectKey, appName, varName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableDelete", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableDelete), projectKey, appName, varName)
+}
+
+// ApplicationVariableGet mocks base method
+func (m *MockInterface) ApplicationVariableGet(projectKey, appName, varName string) (*sdk.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationVariableGet", projectKey, appName, varName)
+	ret0, _ := ret[0].(*sdk.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationVariableGet indicates an expected call of ApplicationVariableGet
+func (mr *MockInterfaceMockRecorder) ApplicationVariableGet(projectKey, appName, varName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableGet", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableGet), projectKey, appName, varName)
+}
+
+// ApplicationVariableUpdate mocks base method
+func (m *MockInterface) ApplicationVariableUpdate(projectKey, appName string, variable *sdk.Variable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationVariableUpdate", projectKey, appName, variable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationVariableUpdate indicates an expected call of ApplicationVariableUpdate
+func (mr *MockInterfaceMockRecorder) ApplicationVariableUpdate(projectKey, appName, variable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableUpdate", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableUpdate), projectKey, appName, variable)
+}
+
+// ApplicationKeysList mocks base method
+func (m *MockInterface) ApplicationKeysList(projectKey, appName string) ([]sdk.ApplicationKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationKeysList", projectKey, appName)
+	ret0, _ := ret[0].([]sdk.ApplicationKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationKeysList indicates an expected call of ApplicationKeysList
+func (mr *MockInterfaceMockRecorder) ApplicationKeysList(projectKey, appName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeysList", reflect.TypeOf((*MockInterface)(nil).ApplicationKeysList), projectKey, appName)
+}
+
+// ApplicationKeyCreate mocks base method
+func (m *MockInterface) ApplicationKeyCreate(projectKey, appName string, keyApp *sdk.ApplicationKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationKeyCreate", projectKey, appName, keyApp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationKeyCreate indicates an expected call of ApplicationKeyCreate
+func (mr *MockInterfaceMockRecorder) ApplicationKeyCreate(projectKey, appName, keyApp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeyCreate", reflect.TypeOf((*MockInterface)(nil).ApplicationKeyCreate), projectKey, appName, keyApp)
+}
+
+// ApplicationKeysDelete mocks base method
+func (m *MockInterface) ApplicationKeysDelete(projectKey, appName, KeyAppName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationKeysDelete", projectKey, appName, KeyAppName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationKeysDelete indicates an expected call of ApplicationKeysDelete
+func (mr *MockInterfaceMockRecorder) ApplicationKeysDelete(projectKey, appName, KeyAppName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeysDelete", reflect.TypeOf((*MockInterface)(nil).ApplicationKeysDelete), projectKey, appName, KeyAppName)
+}
+
+// ConfigUser mocks base method
+func (m *MockInterface) ConfigUser() (sdk.ConfigUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigUser")
+	ret0, _ := ret[0].(sdk.ConfigUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigUser indicates an expected call of ConfigUser
+func (mr *MockInterfaceMockRecorder) ConfigUser() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigUser", reflect.TypeOf((*MockInterface)(nil).ConfigUser))
 }
 
-// AdminDatabaseMigrationUnlock mocks base method
-func (m *MockInterface) AdminDatabaseMigrationUnlock(id string) error {
+// Download mocks base method
+func (m *MockInterface) Download() ([]sdk.DownloadableResource, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseMigrationUnlock", id)
+	ret := m.ctrl.Call(m, "Download")
+	ret0, _ := ret[0].([]sdk.DownloadableResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Download indicates an expected call of Download
+func (mr *MockInterfaceMockRecorder) Download() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockInterface)(nil).Download))
+}
+
+// DownloadURLFromAPI mocks base method
+func (m *MockInterface) DownloadURLFromAPI(name, os, arch, variant string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadURLFromAPI", name, os, arch, variant)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// DownloadURLFromAPI indicates an expected call of DownloadURLFromAPI
+func (mr *MockInterfaceMockRecorder) DownloadURLFromAPI(name, os, arch, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURLFromAPI", reflect.TypeOf((*MockInterface)(nil).DownloadURLFromAPI), name, os, arch, variant)
+}
+
+// DownloadURLFromGithub mocks base method
+func (m *MockInterface) DownloadURLFromGithub(filename string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadURLFromGithub", filename)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadURLFromGithub indicates an expected call of DownloadURLFromGithub
+func (mr *MockInterfaceMockRecorder) DownloadURLFromGithub(filename interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURLFromGithub", reflect.TypeOf((*MockInterface)(nil).DownloadURLFromGithub), filename)
+}
+
+// EnvironmentCreate mocks base method
+func (m *MockInterface) EnvironmentCreate(projectKey string, env *sdk.Environment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentCreate", projectKey, env)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AdminDatabaseMigrationUnlock indicates an expected call of AdminDatabaseMigrationUnlock
-func (mr *MockInterfaceMockRecorder) AdminDatabaseMigrationUnlock(id interface{}) *gomock.Call {
+// EnvironmentCreate indicates an expected call of EnvironmentCreate
+func (mr *MockInterfaceMockRecorder) EnvironmentCreate(projectKey, env interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseMigrationUnlock", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseMigrationUnlock), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentCreate", reflect.TypeOf((*MockInterface)(nil).EnvironmentCreate), projectKey, env)
 }
 
-// AdminDatabaseMigrationsList mocks base method
-func (m *MockInterface) AdminDatabaseMigrationsList() ([]sdk.DatabaseMigrationStatus, error) {
+// EnvironmentDelete mocks base method
+func (m *MockInterface) EnvironmentDelete(projectKey, envName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseMigrationsList")
-	ret0, _ := ret[0].([]sdk.DatabaseMigrationStatus)
+	ret := m.ctrl.Call(m, "EnvironmentDelete", projectKey, envName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnvironmentDelete indicates an expected call of EnvironmentDelete
+func (mr *MockInterfaceMockRecorder) EnvironmentDelete(projectKey, envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentDelete", reflect.TypeOf((*MockInterface)(nil).EnvironmentDelete), projectKey, envName)
+}
+
+// EnvironmentGet mocks base method
+func (m *MockInterface) EnvironmentGet(projectKey, envName string, opts ...cdsclient.RequestModifier) (*sdk.Environment, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{projectKey, envName}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnvironmentGet", varargs...)
+	ret0, _ := ret[0].(*sdk.Environment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AdminDatabaseMigrationsList indicates an expected call of AdminDatabaseMigrationsList
-func (mr *MockInterfaceMockRecorder) AdminDatabaseMigrationsList() *gomock.Call {
+// EnvironmentGet indicates an expected call of EnvironmentGet
+func (mr *MockInterfaceMockRecorder) EnvironmentGet(projectKey, envName interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseMigrationsList", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseMigrationsList))
+	varargs := append([]interface{}{projectKey, envName}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentGet", reflect.TypeOf((*MockInterface)(nil).EnvironmentGet), varargs...)
 }
 
-// AdminDatabaseSignaturesResume mocks base method
-func (m *MockInterface) AdminDatabaseSignaturesResume() (sdk.CanonicalFormUsageResume, error) {
+// EnvironmentList mocks base method
+func (m *MockInterface) EnvironmentList(projectKey string) ([]sdk.Environment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseSignaturesResume")
-	ret0, _ := ret[0].(sdk.CanonicalFormUsageResume)
+	ret := m.ctrl.Call(m, "EnvironmentList", projectKey)
+	ret0, _ := ret[0].([]sdk.Environment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AdminDatabaseSignaturesResume indicates an expected call of AdminDatabaseSignaturesResume
-func (mr *MockInterfaceMockRecorder) AdminDatabaseSignaturesResume() *gomock.Call {
+// EnvironmentList indicates an expected call of EnvironmentList
+func (mr *MockInterfaceMockRecorder) EnvironmentList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseSignaturesResume", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseSignaturesResume))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentList", reflect.TypeOf((*MockInterface)(nil).EnvironmentList), projectKey)
 }
 
-// AdminDatabaseSignaturesRollEntity mocks base method
-func (m *MockInterface) AdminDatabaseSignaturesRollEntity(e string) error {
+// EnvironmentExport mocks base method
+func (m *MockInterface) EnvironmentExport(projectKey, name, format string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseSignaturesRollEntity", e)
+	ret := m.ctrl.Call(m, "EnvironmentExport", projectKey, name, format)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentExport indicates an expected call of EnvironmentExport
+func (mr *MockInterfaceMockRecorder) EnvironmentExport(projectKey, name, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentExport", reflect.TypeOf((*MockInterface)(nil).EnvironmentExport), projectKey, name, format)
+}
+
+// EnvironmentImport mocks base method
+func (m *MockInterface) EnvironmentImport(projectKey string, content io.Reader, format string, force bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentImport", projectKey, content, format, force)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentImport indicates an expected call of EnvironmentImport
+func (mr *MockInterfaceMockRecorder) EnvironmentImport(projectKey, content, format, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentImport", reflect.TypeOf((*MockInterface)(nil).EnvironmentImport), projectKey, content, format, force)
+}
+
+// EnvironmentVariablesList mocks base method
+func (m *MockInterface) EnvironmentVariablesList(key, envName string) ([]sdk.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentVariablesList", key, envName)
+	ret0, _ := ret[0].([]sdk.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentVariablesList indicates an expected call of EnvironmentVariablesList
+func (mr *MockInterfaceMockRecorder) EnvironmentVariablesList(key, envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariablesList", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariablesList), key, envName)
+}
+
+// EnvironmentVariableCreate mocks base method
+func (m *MockInterface) EnvironmentVariableCreate(projectKey, envName string, variable *sdk.Variable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentVariableCreate", projectKey, envName, variable)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AdminDatabaseSignaturesRollEntity indicates an expected call of AdminDatabaseSignaturesRollEntity
-func (mr *MockInterfaceMockRecorder) AdminDatabaseSignaturesRollEntity(e interface{}) *gomock.Call {
+// EnvironmentVariableCreate indicates an expected call of EnvironmentVariableCreate
+func (mr *MockInterfaceMockRecorder) EnvironmentVariableCreate(projectKey, envName, variable interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseSignaturesRollEntity", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseSignaturesRollEntity), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableCreate", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableCreate), projectKey, envName, variable)
 }
 
-// AdminDatabaseSignaturesRollAllEntities mocks base method
-func (m *MockInterface) AdminDatabaseSignaturesRollAllEntities() error {
+// EnvironmentVariableDelete mocks base method
+func (m *MockInterface) EnvironmentVariableDelete(projectKey, envName, varName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseSignaturesRollAllEntities")
+	ret := m.ctrl.Call(m, "EnvironmentVariableDelete", projectKey, envName, varName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnvironmentVariableDelete indicates an expected call of EnvironmentVariableDelete
+func (mr *MockInterfaceMockRecorder) EnvironmentVariableDelete(projectKey, envName, varName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableDelete", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableDelete), projectKey, envName, varName)
+}
+
+// EnvironmentVariableGet mocks base method
+func (m *MockInterface) EnvironmentVariableGet(projectKey, envName, varName string) (*sdk.Variable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentVariableGet", projectKey, envName, varName)
+	ret0, _ := ret[0].(*sdk.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentVariableGet indicates an expected call of EnvironmentVariableGet
+func (mr *MockInterfaceMockRecorder) EnvironmentVariableGet(projectKey, envName, varName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableGet", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableGet), projectKey, envName, varName)
+}
+
+// EnvironmentVariableUpdate mocks base method
+func (m *MockInterface) EnvironmentVariableUpdate(projectKey, envName string, variable *sdk.Variable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentVariableUpdate", projectKey, envName, variable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnvironmentVariableUpdate indicates an expected call of EnvironmentVariableUpdate
+func (mr *MockInterfaceMockRecorder) EnvironmentVariableUpdate(projectKey, envName, variable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableUpdate", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableUpdate), projectKey, envName, variable)
+}
+
+// EnvironmentKeysList mocks base method
+func (m *MockInterface) EnvironmentKeysList(projectKey, envName string) ([]sdk.EnvironmentKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentKeysList", projectKey, envName)
+	ret0, _ := ret[0].([]sdk.EnvironmentKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnvironmentKeysList indicates an expected call of EnvironmentKeysList
+func (mr *MockInterfaceMockRecorder) EnvironmentKeysList(projectKey, envName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentKeysList", reflect.TypeOf((*MockInterface)(nil).EnvironmentKeysList), projectKey, envName)
+}
+
+// EnvironmentKeyCreate mocks base method
+func (m *MockInterface) EnvironmentKeyCreate(projectKey, envName string, keyEnv *sdk.EnvironmentKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentKeyCreate", projectKey, envName, keyEnv)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnvironmentKeyCreate indicates an expected call of EnvironmentKeyCreate
+func (mr *MockInterfaceMockRecorder) EnvironmentKeyCreate(projectKey, envName, keyEnv interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentKeyCreate", reflect.TypeOf((*MockInterface)(nil).EnvironmentKeyCreate), projectKey, envName, keyEnv)
+}
+
+// EnvironmentKeysDelete mocks base method
+func (m *MockInterface) EnvironmentKeysDelete(projectKey, envName, keyEnvName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvironmentKeysDelete", projectKey, envName, keyEnvName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AdminDatabaseSignaturesRollAllEntities indicates an expected call of AdminDatabaseSignaturesRollAllEntities
-func (mr *MockInterfaceMockRecorder) AdminDatabaseSignaturesRollAllEntities() *gomock.Call {
+// EnvironmentKeysDelete indicates an expected call of EnvironmentKeysDelete
+func (mr *MockInterfaceMockRecorder) EnvironmentKeysDelete(projectKey, envName, keyEnvName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentKeysDelete", reflect.TypeOf((*MockInterface)(nil).EnvironmentKeysDelete), projectKey, envName, keyEnvName)
+}
+
+// EventsListen mocks base method
+func (m *MockInterface) EventsListen(ctx context.Context, chanSSEvt chan<- cdsclient.SSEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EventsListen", ctx, chanSSEvt)
+}
+
+// EventsListen indicates an expected call of EventsListen
+func (mr *MockInterfaceMockRecorder) EventsListen(ctx, chanSSEvt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseSignaturesRollAllEntities", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseSignaturesRollAllEntities))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsListen", reflect.TypeOf((*MockInterface)(nil).EventsListen), ctx, chanSSEvt)
 }
 
-// AdminDatabaseListEncryptedEntities mocks base method
-func (m *MockInterface) AdminDatabaseListEncryptedEntities() ([]string, error) {
+// WebsocketEventsListen mocks base method
+func (m *MockInterface) WebsocketEventsListen(ctx context.Context, filters []sdk.WebsocketFilter, chanMsgReceived chan<- sdk.WebsocketEvent) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseListEncryptedEntities")
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	m.ctrl.Call(m, "WebsocketEventsListen", ctx, filters, chanMsgReceived)
 }
 
-// AdminDatabaseListEncryptedEntities indicates an expected call of AdminDatabaseListEncryptedEntities
-func (mr *MockInterfaceMockRecorder) AdminDatabaseListEncryptedEntities() *gomock.Call {
+// WebsocketEventsListen indicates an expected call of WebsocketEventsListen
+func (mr *MockInterfaceMockRecorder) WebsocketEventsListen(ctx, filters, chanMsgReceived interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseListEncryptedEntities", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseListEncryptedEntities))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebsocketEventsListen", reflect.TypeOf((*MockInterface)(nil).WebsocketEventsListen), ctx, filters, chanMsgReceived)
 }
 
-// AdminDatabaseRollEncryptedEntity mocks base method
-func (m *MockInterface) AdminDatabaseRollEncryptedEntity(e string) error {
+// PipelineExport mocks base method
+func (m *MockInterface) PipelineExport(projectKey, name, exportFormat string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseRollEncryptedEntity", e)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PipelineExport", projectKey, name, exportFormat)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AdminDatabaseRollEncryptedEntity indicates an expected call of AdminDatabaseRollEncryptedEntity
-func (mr *MockInterfaceMockRecorder) AdminDatabaseRollEncryptedEntity(e interface{}) *gomock.Call {
+// PipelineExport indicates an expected call of PipelineExport
+func (mr *MockInterfaceMockRecorder) PipelineExport(projectKey, name, exportFormat interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollEncryptedEntity", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollEncryptedEntity), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineExport", reflect.TypeOf((*MockInterface)(nil).PipelineExport), projectKey, name, exportFormat)
 }
 
-// AdminDatabaseRollAllEncryptedEntities mocks base method
-func (m *MockInterface) AdminDatabaseRollAllEncryptedEntities() error {
+// PipelineImport mocks base method
+func (m *MockInterface) PipelineImport(projectKey string, content io.Reader, format string, force bool) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminDatabaseRollAllEncryptedEntities")
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PipelineImport", projectKey, content, format, force)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AdminDatabaseRollAllEncryptedEntities indicates an expected call of AdminDatabaseRollAllEncryptedEntities
-func (mr *MockInterfaceMockRecorder) AdminDatabaseRollAllEncryptedEntities() *gomock.Call {
+// PipelineImport indicates an expected call of PipelineImport
+func (mr *MockInterfaceMockRecorder) PipelineImport(projectKey, content, format, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminDatabaseRollAllEncryptedEntities", reflect.TypeOf((*MockInterface)(nil).AdminDatabaseRollAllEncryptedEntities))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineImport", reflect.TypeOf((*MockInterface)(nil).PipelineImport), projectKey, content, format, force)
 }
 
-// AdminCDSMigrationList mocks base method
-func (m *MockInterface) AdminCDSMigrationList() ([]sdk.Migration, error) {
+// ApplicationExport mocks base method
+func (m *MockInterface) ApplicationExport(projectKey, name, format string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminCDSMigrationList")
-	ret0, _ := ret[0].([]sdk.Migration)
+	ret := m.ctrl.Call(m, "ApplicationExport", projectKey, name, format)
+	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AdminCDSMigrationList indicates an expected call of AdminCDSMigrationList
-func (mr *MockInterfaceMockRecorder) AdminCDSMigrationList() *gomock.Call {
+// ApplicationExport indicates an expected call of ApplicationExport
+func (mr *MockInterfaceMockRecorder) ApplicationExport(projectKey, name, format interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationList", reflect.TypeOf((*MockInterface)(nil).AdminCDSMigrationList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationExport", reflect.TypeOf((*MockInterface)(nil).ApplicationExport), projectKey, name, format)
 }
 
-// AdminCDSMigrationCancel mocks base method
-func (m *MockInterface) AdminCDSMigrationCancel(id int64) error {
+// ApplicationImport mocks base method
+func (m *MockInterface) ApplicationImport(projectKey string, content io.Reader, format string, force bool) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminCDSMigrationCancel", id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ApplicationImport", projectKey, content, format, force)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AdminCDSMigrationCancel indicates an expected call of AdminCDSMigrationCancel
-func (mr *MockInterfaceMockRecorder) AdminCDSMigrationCancel(id interface{}) *gomock.Call {
+// ApplicationImport indicates an expected call of ApplicationImport
+func (mr *MockInterfaceMockRecorder) ApplicationImport(projectKey, content, format, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationCancel", reflect.TypeOf((*MockInterface)(nil).AdminCDSMigrationCancel), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationImport", reflect.TypeOf((*MockInterface)(nil).ApplicationImport), projectKey, content, format, force)
 }
 
-// AdminCDSMigrationReset mocks base method
-func (m *MockInterface) AdminCDSMigrationReset(id int64) error {
+// WorkflowExport mocks base method
+func (m *MockInterface) WorkflowExport(projectKey, name string, mods ...cdsclient.RequestModifier) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminCDSMigrationReset", id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []interface{}{projectKey, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowExport", varargs...)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AdminCDSMigrationReset indicates an expected call of AdminCDSMigrationReset
-func (mr *MockInterfaceMockRecorder) AdminCDSMigrationReset(id interface{}) *gomock.Call {
+// WorkflowExport indicates an expected call of WorkflowExport
+func (mr *MockInterfaceMockRecorder) WorkflowExport(projectKey, name interface{}, mods ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationReset", reflect.TypeOf((*MockInterface)(nil).AdminCDSMigrationReset), id)
+	varargs := append([]interface{}{projectKey, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowExport", reflect.TypeOf((*MockInterface)(nil).WorkflowExport), varargs...)
 }
 
-// Services mocks base method
-func (m *MockInterface) Services() ([]sdk.Service, error) {
+// WorkflowPull mocks base method
+func (m *MockInterface) WorkflowPull(projectKey, name string, mods ...cdsclient.RequestModifier) (*tar.Reader, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Services")
-	ret0, _ := ret[0].([]sdk.Service)
+	varargs := []interface{}{projectKey, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowPull", varargs...)
+	ret0, _ := ret[0].(*tar.Reader)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Services indicates an expected call of Services
-func (mr *MockInterfaceMockRecorder) Services() *gomock.Call {
+// WorkflowPull indicates an expected call of WorkflowPull
+func (mr *MockInterfaceMockRecorder) WorkflowPull(projectKey, name interface{}, mods ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Services", reflect.TypeOf((*MockInterface)(nil).Services))
+	varargs := append([]interface{}{projectKey, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowPull", reflect.TypeOf((*MockInterface)(nil).WorkflowPull), varargs...)
 }
 
-// ServicesByName mocks base method
-func (m *MockInterface) ServicesByName(name string) (*sdk.Service, error) {
+// WorkflowImport mocks base method
+func (m *MockInterface) WorkflowImport(projectKey string, content io.Reader, format string, force bool) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServicesByName", name)
-	ret0, _ := ret[0].(*sdk.Service)
+	ret := m.ctrl.Call(m, "WorkflowImport", projectKey, content, format, force)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ServicesByName indicates an expected call of ServicesByName
-func (mr *MockInterfaceMockRecorder) ServicesByName(name interface{}) *gomock.Call {
+// WorkflowImport indicates an expected call of WorkflowImport
+func (mr *MockInterfaceMockRecorder) WorkflowImport(projectKey, content, format, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServicesByName", reflect.TypeOf((*MockInterface)(nil).ServicesByName), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowImport", reflect.TypeOf((*MockInterface)(nil).WorkflowImport), projectKey, content, format, force)
 }
 
-// ServiceDelete mocks base method
-func (m *MockInterface) ServiceDelete(name string) error {
+// WorkerModelExport mocks base method
+func (m *MockInterface) WorkerModelExport(groupName, name, format string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServiceDelete", name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkerModelExport", groupName, name, format)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ServiceDelete indicates an expected call of ServiceDelete
-func (mr *MockInterfaceMockRecorder) ServiceDelete(name interface{}) *gomock.Call {
+// WorkerModelExport indicates an expected call of WorkerModelExport
+func (mr *MockInterfaceMockRecorder) WorkerModelExport(groupName, name, format interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceDelete", reflect.TypeOf((*MockInterface)(nil).ServiceDelete), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelExport", reflect.TypeOf((*MockInterface)(nil).WorkerModelExport), groupName, name, format)
 }
 
-// ServicesByType mocks base method
-func (m *MockInterface) ServicesByType(stype string) ([]sdk.Service, error) {
+// WorkerModelImport mocks base method
+func (m *MockInterface) WorkerModelImport(content io.Reader, format string, force bool) (*sdk.Model, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServicesByType", stype)
-	ret0, _ := ret[0].([]sdk.Service)
+	ret := m.ctrl.Call(m, "WorkerModelImport", content, format, force)
+	ret0, _ := ret[0].(*sdk.Model)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ServicesByType indicates an expected call of ServicesByType
-func (mr *MockInterfaceMockRecorder) ServicesByType(stype interface{}) *gomock.Call {
+// WorkerModelImport indicates an expected call of WorkerModelImport
+func (mr *MockInterfaceMockRecorder) WorkerModelImport(content, format, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServicesByType", reflect.TypeOf((*MockInterface)(nil).ServicesByType), stype)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelImport", reflect.TypeOf((*MockInterface)(nil).WorkerModelImport), content, format, force)
 }
 
-// ServiceNameCallGET mocks base method
-func (m *MockInterface) ServiceNameCallGET(name, url string) ([]byte, error) {
+// WorkflowPush mocks base method
+func (m *MockInterface) WorkflowPush(projectKey string, tarContent io.Reader, mods ...cdsclient.RequestModifier) ([]string, *tar.Reader, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServiceNameCallGET", name, url)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	varargs := []interface{}{projectKey, tarContent}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowPush", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*tar.Reader)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// ServiceNameCallGET indicates an expected call of ServiceNameCallGET
-func (mr *MockInterfaceMockRecorder) ServiceNameCallGET(name, url interface{}) *gomock.Call {
+// WorkflowPush indicates an expected call of WorkflowPush
+func (mr *MockInterfaceMockRecorder) WorkflowPush(projectKey, tarContent interface{}, mods ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceNameCallGET", reflect.TypeOf((*MockInterface)(nil).ServiceNameCallGET), name, url)
+	varargs := append([]interface{}{projectKey, tarContent}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowPush", reflect.TypeOf((*MockInterface)(nil).WorkflowPush), varargs...)
 }
 
-// ServiceCallGET mocks base method
-func (m *MockInterface) ServiceCallGET(stype, url string) ([]byte, error) {
+// ProjectPush mocks base method
+func (m *MockInterface) ProjectPush(projectKey string, tarContent io.Reader, mods ...cdsclient.RequestModifier) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServiceCallGET", stype, url)
-	ret0, _ := ret[0].([]byte)
+	varargs := []interface{}{projectKey, tarContent}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectPush", varargs...)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ServiceCallGET indicates an expected call of ServiceCallGET
-func (mr *MockInterfaceMockRecorder) ServiceCallGET(stype, url interface{}) *gomock.Call {
+// ProjectPush indicates an expected call of ProjectPush
+func (mr *MockInterfaceMockRecorder) ProjectPush(projectKey, tarContent interface{}, mods ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallGET", reflect.TypeOf((*MockInterface)(nil).ServiceCallGET), stype, url)
+	varargs := append([]interface{}{projectKey, tarContent}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPush", reflect.TypeOf((*MockInterface)(nil).ProjectPush), varargs...)
 }
 
-// ServiceCallPOST mocks base method
-func (m *MockInterface) ServiceCallPOST(stype, url string, body []byte) ([]byte, error) {
+// WorkflowAsCodeStart mocks base method
+func (m *MockInterface) WorkflowAsCodeStart(projectKey, repoURL string, repoStrategy sdk.RepositoryStrategy) (*sdk.Operation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServiceCallPOST", stype, url, body)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "WorkflowAsCodeStart", projectKey, repoURL, repoStrategy)
+	ret0, _ := ret[0].(*sdk.Operation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ServiceCallPOST indicates an expected call of ServiceCallPOST
-func (mr *MockInterfaceMockRecorder) ServiceCallPOST(stype, url, body interface{}) *gomock.Call {
+// WorkflowAsCodeStart indicates an expected call of WorkflowAsCodeStart
+func (mr *MockInterfaceMockRecorder) WorkflowAsCodeStart(projectKey, repoURL, repoStrategy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallPOST", reflect.TypeOf((*MockInterface)(nil).ServiceCallPOST), stype, url, body)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeStart", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodeStart), projectKey, repoURL, repoStrategy)
 }
 
-// ServiceCallPUT mocks base method
-func (m *MockInterface) ServiceCallPUT(stype, url string, body []byte) ([]byte, error) {
+// WorkflowAsCodeInfo mocks base method
+func (m *MockInterface) WorkflowAsCodeInfo(projectKey, operationID string) (*sdk.Operation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServiceCallPUT", stype, url, body)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "WorkflowAsCodeInfo", projectKey, operationID)
+	ret0, _ := ret[0].(*sdk.Operation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ServiceCallPUT indicates an expected call of ServiceCallPUT
-func (mr *MockInterfaceMockRecorder) ServiceCallPUT(stype, url, body interface{}) *gomock.Call {
+// WorkflowAsCodeInfo indicates an expected call of WorkflowAsCodeInfo
+func (mr *MockInterfaceMockRecorder) WorkflowAsCodeInfo(projectKey, operationID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallPUT", reflect.TypeOf((*MockInterface)(nil).ServiceCallPUT), stype, url, body)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeInfo", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodeInfo), projectKey, operationID)
 }
 
-// ServiceCallDELETE mocks base method
-func (m *MockInterface) ServiceCallDELETE(stype, url string) error {
+// WorkflowAsCodePerform mocks base method
+func (m *MockInterface) WorkflowAsCodePerform(projectKey, operationID string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServiceCallDELETE", stype, url)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowAsCodePerform", projectKey, operationID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ServiceCallDELETE indicates an expected call of ServiceCallDELETE
-func (mr *MockInterfaceMockRecorder) ServiceCallDELETE(stype, url interface{}) *gomock.Call {
+// WorkflowAsCodePerform indicates an expected call of WorkflowAsCodePerform
+func (mr *MockInterfaceMockRecorder) WorkflowAsCodePerform(projectKey, operationID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceCallDELETE", reflect.TypeOf((*MockInterface)(nil).ServiceCallDELETE), stype, url)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodePerform", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodePerform), projectKey, operationID)
 }
 
-// APIURL mocks base method
-func (m *MockInterface) APIURL() string {
+// WorkflowAsCodeConvert mocks base method
+func (m *MockInterface) WorkflowAsCodeConvert(format string, files map[string]string) (*sdk.AsCodeConversion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "APIURL")
-	ret0, _ := ret[0].(string)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowAsCodeConvert", format, files)
+	ret0, _ := ret[0].(*sdk.AsCodeConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// APIURL indicates an expected call of APIURL
-func (mr *MockInterfaceMockRecorder) APIURL() *gomock.Call {
+// WorkflowAsCodeConvert indicates an expected call of WorkflowAsCodeConvert
+func (mr *MockInterfaceMockRecorder) WorkflowAsCodeConvert(format, files interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIURL", reflect.TypeOf((*MockInterface)(nil).APIURL))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeConvert", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodeConvert), format, files)
 }
 
-// ApplicationAttachToReposistoriesManager mocks base method
-func (m *MockInterface) ApplicationAttachToReposistoriesManager(projectKey, appName, reposManager, repoFullname string) error {
+// GroupList mocks base method
+func (m *MockInterface) GroupList() ([]sdk.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationAttachToReposistoriesManager", projectKey, appName, reposManager, repoFullname)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GroupList")
+	ret0, _ := ret[0].([]sdk.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ApplicationAttachToReposistoriesManager indicates an expected call of ApplicationAttachToReposistoriesManager
-func (mr *MockInterfaceMockRecorder) ApplicationAttachToReposistoriesManager(projectKey, appName, reposManager, repoFullname interface{}) *gomock.Call {
+// GroupList indicates an expected call of GroupList
+func (mr *MockInterfaceMockRecorder) GroupList() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationAttachToReposistoriesManager", reflect.TypeOf((*MockInterface)(nil).ApplicationAttachToReposistoriesManager), projectKey, appName, reposManager, repoFullname)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupList", reflect.TypeOf((*MockInterface)(nil).GroupList))
 }
 
-// ApplicationCreate mocks base method
-func (m *MockInterface) ApplicationCreate(projectKey string, app *sdk.Application) error {
+// GroupGet mocks base method
+func (m *MockInterface) GroupGet(name string, mods ...cdsclient.RequestModifier) (*sdk.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationCreate", projectKey, app)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []interface{}{name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GroupGet", varargs...)
+	ret0, _ := ret[0].(*sdk.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ApplicationCreate indicates an expected call of ApplicationCreate
-func (mr *MockInterfaceMockRecorder) ApplicationCreate(projectKey, app interface{}) *gomock.Call {
+// GroupGet indicates an expected call of GroupGet
+func (mr *MockInterfaceMockRecorder) GroupGet(name interface{}, mods ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationCreate", reflect.TypeOf((*MockInterface)(nil).ApplicationCreate), projectKey, app)
+	varargs := append([]interface{}{name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupGet", reflect.TypeOf((*MockInterface)(nil).GroupGet), varargs...)
 }
 
-// ApplicationUpdate mocks base method
-func (m *MockInterface) ApplicationUpdate(projectKey, appName string, app *sdk.Application) error {
+// GroupCreate mocks base method
+func (m *MockInterface) GroupCreate(group *sdk.Group) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationUpdate", projectKey, appName, app)
+	ret := m.ctrl.Call(m, "GroupCreate", group)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ApplicationUpdate indicates an expected call of ApplicationUpdate
-func (mr *MockInterfaceMockRecorder) ApplicationUpdate(projectKey, appName, app interface{}) *gomock.Call {
+// GroupCreate indicates an expected call of GroupCreate
+func (mr *MockInterfaceMockRecorder) GroupCreate(group interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationUpdate", reflect.TypeOf((*MockInterface)(nil).ApplicationUpdate), projectKey, appName, app)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupCreate", reflect.TypeOf((*MockInterface)(nil).GroupCreate), group)
 }
 
-// ApplicationDelete mocks base method
-func (m *MockInterface) ApplicationDelete(projectKey, appName string) error {
+// GroupRename mocks base method
+func (m *MockInterface) GroupRename(oldName, newName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationDelete", projectKey, appName)
+	ret := m.ctrl.Call(m, "GroupRename", oldName, newName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ApplicationDelete indicates an expected call of ApplicationDelete
-func (mr *MockInterfaceMockRecorder) ApplicationDelete(projectKey, appName interface{}) *gomock.Call {
+// GroupRename indicates an expected call of GroupRename
+func (mr *MockInterfaceMockRecorder) GroupRename(oldName, newName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationDelete", reflect.TypeOf((*MockInterface)(nil).ApplicationDelete), projectKey, appName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupRename", reflect.TypeOf((*MockInterface)(nil).GroupRename), oldName, newName)
 }
 
-// ApplicationGet mocks base method
-func (m *MockInterface) ApplicationGet(projectKey, appName string, opts ...cdsclient.RequestModifier) (*sdk.Application, error) {
+// GroupDelete mocks base method
+func (m *MockInterface) GroupDelete(name string) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey, appName}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "ApplicationGet", varargs...)
-	ret0, _ := ret[0].(*sdk.Application)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GroupDelete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ApplicationGet indicates an expected call of ApplicationGet
-func (mr *MockInterfaceMockRecorder) ApplicationGet(projectKey, appName interface{}, opts ...interface{}) *gomock.Call {
+// GroupDelete indicates an expected call of GroupDelete
+func (mr *MockInterfaceMockRecorder) GroupDelete(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, appName}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationGet", reflect.TypeOf((*MockInterface)(nil).ApplicationGet), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupDelete", reflect.TypeOf((*MockInterface)(nil).GroupDelete), name)
 }
 
-// ApplicationList mocks base method
-func (m *MockInterface) ApplicationList(projectKey string) ([]sdk.Application, error) {
+// GroupMemberAdd mocks base method
+func (m *MockInterface) GroupMemberAdd(groupName string, member *sdk.GroupMember) (sdk.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationList", projectKey)
-	ret0, _ := ret[0].([]sdk.Application)
+	ret := m.ctrl.Call(m, "GroupMemberAdd", groupName, member)
+	ret0, _ := ret[0].(sdk.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ApplicationList indicates an expected call of ApplicationList
-func (mr *MockInterfaceMockRecorder) ApplicationList(projectKey interface{}) *gomock.Call {
+// GroupMemberAdd indicates an expected call of GroupMemberAdd
+func (mr *MockInterfaceMockRecorder) GroupMemberAdd(groupName, member interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationList", reflect.TypeOf((*MockInterface)(nil).ApplicationList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberAdd", reflect.TypeOf((*MockInterface)(nil).GroupMemberAdd), groupName, member)
 }
 
-// ApplicationVariablesList mocks base method
-func (m *MockInterface) ApplicationVariablesList(projectKey, appName string) ([]sdk.Variable, error) {
+// GroupMemberEdit mocks base method
+func (m *MockInterface) GroupMemberEdit(groupName string, member *sdk.GroupMember) (sdk.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationVariablesList", projectKey, appName)
-	ret0, _ := ret[0].([]sdk.Variable)
+	ret := m.ctrl.Call(m, "GroupMemberEdit", groupName, member)
+	ret0, _ := ret[0].(sdk.Group)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ApplicationVariablesList indicates an expected call of ApplicationVariablesList
-func (mr *MockInterfaceMockRecorder) ApplicationVariablesList(projectKey, appName interface{}) *gomock.Call {
+// GroupMemberEdit indicates an expected call of GroupMemberEdit
+func (mr *MockInterfaceMockRecorder) GroupMemberEdit(groupName, member interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariablesList", reflect.TypeOf((*MockInterface)(nil).ApplicationVariablesList), projectKey, appName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberEdit", reflect.TypeOf((*MockInterface)(nil).GroupMemberEdit), groupName, member)
 }
 
-// ApplicationVariableCreate mocks base method
-func (m *MockInterface) ApplicationVariableCreate(projectKey, appName string, variable *sdk.Variable) error {
+// GroupMemberRemove mocks base method
+func (m *MockInterface) GroupMemberRemove(groupName, username string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationVariableCreate", projectKey, appName, variable)
+	ret := m.ctrl.Call(m, "GroupMemberRemove", groupName, username)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ApplicationVariableCreate indicates an expected call of ApplicationVariableCreate
-func (mr *MockInterfaceMockRecorder) ApplicationVariableCreate(projectKey, appName, variable interface{}) *gomock.Call {
+// GroupMemberRemove indicates an expected call of GroupMemberRemove
+func (mr *MockInterfaceMockRecorder) GroupMemberRemove(groupName, username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableCreate", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableCreate), projectKey, appName, variable)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberRemove", reflect.TypeOf((*MockInterface)(nil).GroupMemberRemove), groupName, username)
 }
 
-// ApplicationVariableDelete mocks base method
-func (m *MockInterface) ApplicationVariableDelete(projectKey, appName, varName string) error {
+// PluginsList mocks base method
+func (m *MockInterface) PluginsList() ([]sdk.GRPCPlugin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationVariableDelete", projectKey, appName, varName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PluginsList")
+	ret0, _ := ret[0].([]sdk.GRPCPlugin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ApplicationVariableDelete indicates an expected call of ApplicationVariableDelete
-func (mr *MockInterfaceMockRecorder) ApplicationVariableDelete(projectKey, appName, varName interface{}) *gomock.Call {
+// PluginsList indicates an expected call of PluginsList
+func (mr *MockInterfaceMockRecorder) PluginsList() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableDelete", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableDelete), projectKey, appName, varName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginsList", reflect.TypeOf((*MockInterface)(nil).PluginsList))
 }
 
-// ApplicationVariableGet mocks base method
-func (m *MockInterface) ApplicationVariableGet(projectKey, appName, varName string) (*sdk.Variable, error) {
+// PluginsGet mocks base method
+func (m *MockInterface) PluginsGet(arg0 string) (*sdk.GRPCPlugin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationVariableGet", projectKey, appName, varName)
-	ret0, _ := ret[0].(*sdk.Variable)
+	ret := m.ctrl.Call(m, "PluginsGet", arg0)
+	ret0, _ := ret[0].(*sdk.GRPCPlugin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ApplicationVariableGet indicates an expected call of ApplicationVariableGet
-func (mr *MockInterfaceMockRecorder) ApplicationVariableGet(projectKey, appName, varName interface{}) *gomock.Call {
+// PluginsGet indicates an expected call of PluginsGet
+func (mr *MockInterfaceMockRecorder) PluginsGet(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableGet", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableGet), projectKey, appName, varName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginsGet", reflect.TypeOf((*MockInterface)(nil).PluginsGet), arg0)
 }
 
-// ApplicationVariableUpdate mocks base method
-func (m *MockInterface) ApplicationVariableUpdate(projectKey, appName string, variable *sdk.Variable) error {
+// PluginAdd mocks base method
+func (m *MockInterface) PluginAdd(arg0 *sdk.GRPCPlugin) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationVariableUpdate", projectKey, appName, variable)
+	ret := m.ctrl.Call(m, "PluginAdd", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ApplicationVariableUpdate indicates an expected call of ApplicationVariableUpdate
-func (mr *MockInterfaceMockRecorder) ApplicationVariableUpdate(projectKey, appName, variable interface{}) *gomock.Call {
+// PluginAdd indicates an expected call of PluginAdd
+func (mr *MockInterfaceMockRecorder) PluginAdd(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariableUpdate", reflect.TypeOf((*MockInterface)(nil).ApplicationVariableUpdate), projectKey, appName, variable)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginAdd", reflect.TypeOf((*MockInterface)(nil).PluginAdd), arg0)
 }
 
-// ApplicationKeysList mocks base method
-func (m *MockInterface) ApplicationKeysList(projectKey, appName string) ([]sdk.ApplicationKey, error) {
+// PluginUpdate mocks base method
+func (m *MockInterface) PluginUpdate(arg0 *sdk.GRPCPlugin) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationKeysList", projectKey, appName)
-	ret0, _ := ret[0].([]sdk.ApplicationKey)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "PluginUpdate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ApplicationKeysList indicates an expected call of ApplicationKeysList
-func (mr *MockInterfaceMockRecorder) ApplicationKeysList(projectKey, appName interface{}) *gomock.Call {
+// PluginUpdate indicates an expected call of PluginUpdate
+func (mr *MockInterfaceMockRecorder) PluginUpdate(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeysList", reflect.TypeOf((*MockInterface)(nil).ApplicationKeysList), projectKey, appName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginUpdate", reflect.TypeOf((*MockInterface)(nil).PluginUpdate), arg0)
 }
 
-// ApplicationKeyCreate mocks base method
-func (m *MockInterface) ApplicationKeyCreate(projectKey, appName string, keyApp *sdk.ApplicationKey) error {
+// PluginDelete mocks base method
+func (m *MockInterface) PluginDelete(arg0 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationKeyCreate", projectKey, appName, keyApp)
+	ret := m.ctrl.Call(m, "PluginDelete", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ApplicationKeyCreate indicates an expected call of ApplicationKeyCreate
-func (mr *MockInterfaceMockRecorder) ApplicationKeyCreate(projectKey, appName, keyApp interface{}) *gomock.Call {
+// PluginDelete indicates an expected call of PluginDelete
+func (mr *MockInterfaceMockRecorder) PluginDelete(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeyCreate", reflect.TypeOf((*MockInterface)(nil).ApplicationKeyCreate), projectKey, appName, keyApp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginDelete", reflect.TypeOf((*MockInterface)(nil).PluginDelete), arg0)
 }
 
-// ApplicationKeysDelete mocks base method
-func (m *MockInterface) ApplicationKeysDelete(projectKey, appName, KeyAppName string) error {
+// PluginAddBinary mocks base method
+func (m *MockInterface) PluginAddBinary(arg0 *sdk.GRPCPlugin, arg1 *sdk.GRPCPluginBinary) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationKeysDelete", projectKey, appName, KeyAppName)
+	ret := m.ctrl.Call(m, "PluginAddBinary", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ApplicationKeysDelete indicates an expected call of ApplicationKeysDelete
-func (mr *MockInterfaceMockRecorder) ApplicationKeysDelete(projectKey, appName, KeyAppName interface{}) *gomock.Call {
+// PluginAddBinary indicates an expected call of PluginAddBinary
+func (mr *MockInterfaceMockRecorder) PluginAddBinary(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationKeysDelete", reflect.TypeOf((*MockInterface)(nil).ApplicationKeysDelete), projectKey, appName, KeyAppName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginAddBinary", reflect.TypeOf((*MockInterface)(nil).PluginAddBinary), arg0, arg1)
 }
 
-// ConfigUser mocks base method
-func (m *MockInterface) ConfigUser() (sdk.ConfigUser, error) {
+// PluginDeleteBinary mocks base method
+func (m *MockInterface) PluginDeleteBinary(name, os, arch string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfigUser")
-	ret0, _ := ret[0].(sdk.ConfigUser)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "PluginDeleteBinary", name, os, arch)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ConfigUser indicates an expected call of ConfigUser
-func (mr *MockInterfaceMockRecorder) ConfigUser() *gomock.Call {
+// PluginDeleteBinary indicates an expected call of PluginDeleteBinary
+func (mr *MockInterfaceMockRecorder) PluginDeleteBinary(name, os, arch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigUser", reflect.TypeOf((*MockInterface)(nil).ConfigUser))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginDeleteBinary", reflect.TypeOf((*MockInterface)(nil).PluginDeleteBinary), name, os, arch)
 }
 
-// Download mocks base method
-func (m *MockInterface) Download() ([]sdk.DownloadableResource, error) {
+// PluginGetBinary mocks base method
+func (m *MockInterface) PluginGetBinary(name, os, arch string, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Download")
-	ret0, _ := ret[0].([]sdk.DownloadableResource)
+	ret := m.ctrl.Call(m, "PluginGetBinary", name, os, arch, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginGetBinary indicates an expected call of PluginGetBinary
+func (mr *MockInterfaceMockRecorder) PluginGetBinary(name, os, arch, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinary", reflect.TypeOf((*MockInterface)(nil).PluginGetBinary), name, os, arch, w)
+}
+
+// PluginGetBinaryInfos mocks base method
+func (m *MockInterface) PluginGetBinaryInfos(name, os, arch string) (*sdk.GRPCPluginBinary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginGetBinaryInfos", name, os, arch)
+	ret0, _ := ret[0].(*sdk.GRPCPluginBinary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
-
-// Download indicates an expected call of Download
-func (mr *MockInterfaceMockRecorder) Download() *gomock.Call {
+
+// PluginGetBinaryInfos indicates an expected call of PluginGetBinaryInfos
+func (mr *MockInterfaceMockRecorder) PluginGetBinaryInfos(name, os, arch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockInterface)(nil).Download))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinaryInfos", reflect.TypeOf((*MockInterface)(nil).PluginGetBinaryInfos), name, os, arch)
 }
 
-// DownloadURLFromAPI mocks base method
-func (m *MockInterface) DownloadURLFromAPI(name, os, arch, variant string) string {
+// PluginRegistryList mocks base method
+func (m *MockInterface) PluginRegistryList(name string) ([]sdk.GRPCPluginVersion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DownloadURLFromAPI", name, os, arch, variant)
-	ret0, _ := ret[0].(string)
-	return ret0
+	ret := m.ctrl.Call(m, "PluginRegistryList", name)
+	ret0, _ := ret[0].([]sdk.GRPCPluginVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DownloadURLFromAPI indicates an expected call of DownloadURLFromAPI
-func (mr *MockInterfaceMockRecorder) DownloadURLFromAPI(name, os, arch, variant interface{}) *gomock.Call {
+// PluginRegistryList indicates an expected call of PluginRegistryList
+func (mr *MockInterfaceMockRecorder) PluginRegistryList(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURLFromAPI", reflect.TypeOf((*MockInterface)(nil).DownloadURLFromAPI), name, os, arch, variant)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryList", reflect.TypeOf((*MockInterface)(nil).PluginRegistryList), name)
 }
 
-// DownloadURLFromGithub mocks base method
-func (m *MockInterface) DownloadURLFromGithub(filename string) (string, error) {
+// PluginRegistryGet mocks base method
+func (m *MockInterface) PluginRegistryGet(name, version string) (*sdk.GRPCPluginVersion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DownloadURLFromGithub", filename)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "PluginRegistryGet", name, version)
+	ret0, _ := ret[0].(*sdk.GRPCPluginVersion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DownloadURLFromGithub indicates an expected call of DownloadURLFromGithub
-func (mr *MockInterfaceMockRecorder) DownloadURLFromGithub(filename interface{}) *gomock.Call {
+// PluginRegistryGet indicates an expected call of PluginRegistryGet
+func (mr *MockInterfaceMockRecorder) PluginRegistryGet(name, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURLFromGithub", reflect.TypeOf((*MockInterface)(nil).DownloadURLFromGithub), filename)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryGet", reflect.TypeOf((*MockInterface)(nil).PluginRegistryGet), name, version)
 }
 
-// EnvironmentCreate mocks base method
-func (m *MockInterface) EnvironmentCreate(projectKey string, env *sdk.Environment) error {
+// PluginRegistryPublish mocks base method
+func (m *MockInterface) PluginRegistryPublish(name string, v *sdk.GRPCPluginVersion) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentCreate", projectKey, env)
+	ret := m.ctrl.Call(m, "PluginRegistryPublish", name, v)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EnvironmentCreate indicates an expected call of EnvironmentCreate
-func (mr *MockInterfaceMockRecorder) EnvironmentCreate(projectKey, env interface{}) *gomock.Call {
+// PluginRegistryPublish indicates an expected call of PluginRegistryPublish
+func (mr *MockInterfaceMockRecorder) PluginRegistryPublish(name, v interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentCreate", reflect.TypeOf((*MockInterface)(nil).EnvironmentCreate), projectKey, env)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryPublish", reflect.TypeOf((*MockInterface)(nil).PluginRegistryPublish), name, v)
 }
 
-// EnvironmentDelete mocks base method
-func (m *MockInterface) EnvironmentDelete(projectKey, envName string) error {
+// PluginRegistryDelete mocks base method
+func (m *MockInterface) PluginRegistryDelete(name, version string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentDelete", projectKey, envName)
+	ret := m.ctrl.Call(m, "PluginRegistryDelete", name, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EnvironmentDelete indicates an expected call of EnvironmentDelete
-func (mr *MockInterfaceMockRecorder) EnvironmentDelete(projectKey, envName interface{}) *gomock.Call {
+// PluginRegistryDelete indicates an expected call of PluginRegistryDelete
+func (mr *MockInterfaceMockRecorder) PluginRegistryDelete(name, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentDelete", reflect.TypeOf((*MockInterface)(nil).EnvironmentDelete), projectKey, envName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryDelete", reflect.TypeOf((*MockInterface)(nil).PluginRegistryDelete), name, version)
 }
 
-// EnvironmentGet mocks base method
-func (m *MockInterface) EnvironmentGet(projectKey, envName string, opts ...cdsclient.RequestModifier) (*sdk.Environment, error) {
+// PluginRegistryInstall mocks base method
+func (m *MockInterface) PluginRegistryInstall(name, version string) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey, envName}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "EnvironmentGet", varargs...)
-	ret0, _ := ret[0].(*sdk.Environment)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "PluginRegistryInstall", name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EnvironmentGet indicates an expected call of EnvironmentGet
-func (mr *MockInterfaceMockRecorder) EnvironmentGet(projectKey, envName interface{}, opts ...interface{}) *gomock.Call {
+// PluginRegistryInstall indicates an expected call of PluginRegistryInstall
+func (mr *MockInterfaceMockRecorder) PluginRegistryInstall(name, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, envName}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentGet", reflect.TypeOf((*MockInterface)(nil).EnvironmentGet), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryInstall", reflect.TypeOf((*MockInterface)(nil).PluginRegistryInstall), name, version)
 }
 
-// EnvironmentList mocks base method
-func (m *MockInterface) EnvironmentList(projectKey string) ([]sdk.Environment, error) {
+// PluginRegistryGetBinary mocks base method
+func (m *MockInterface) PluginRegistryGetBinary(name, version, os, arch string, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentList", projectKey)
-	ret0, _ := ret[0].([]sdk.Environment)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "PluginRegistryGetBinary", name, version, os, arch, w)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EnvironmentList indicates an expected call of EnvironmentList
-func (mr *MockInterfaceMockRecorder) EnvironmentList(projectKey interface{}) *gomock.Call {
+// PluginRegistryGetBinary indicates an expected call of PluginRegistryGetBinary
+func (mr *MockInterfaceMockRecorder) PluginRegistryGetBinary(name, version, os, arch, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentList", reflect.TypeOf((*MockInterface)(nil).EnvironmentList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryGetBinary", reflect.TypeOf((*MockInterface)(nil).PluginRegistryGetBinary), name, version, os, arch, w)
 }
 
-// EnvironmentExport mocks base method
-func (m *MockInterface) EnvironmentExport(projectKey, name, format string) ([]byte, error) {
+// Broadcasts mocks base method
+func (m *MockInterface) Broadcasts() ([]sdk.Broadcast, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentExport", projectKey, name, format)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "Broadcasts")
+	ret0, _ := ret[0].([]sdk.Broadcast)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// EnvironmentExport indicates an expected call of EnvironmentExport
-func (mr *MockInterfaceMockRecorder) EnvironmentExport(projectKey, name, format interface{}) *gomock.Call {
+// Broadcasts indicates an expected call of Broadcasts
+func (mr *MockInterfaceMockRecorder) Broadcasts() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentExport", reflect.TypeOf((*MockInterface)(nil).EnvironmentExport), projectKey, name, format)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Broadcasts", reflect.TypeOf((*MockInterface)(nil).Broadcasts))
 }
 
-// EnvironmentImport mocks base method
-func (m *MockInterface) EnvironmentImport(projectKey string, content io.Reader, format string, force bool) ([]string, error) {
+// BroadcastCreate mocks base method
+func (m *MockInterface) BroadcastCreate(broadcast *sdk.Broadcast) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentImport", projectKey, content, format, force)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "BroadcastCreate", broadcast)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EnvironmentImport indicates an expected call of EnvironmentImport
-func (mr *MockInterfaceMockRecorder) EnvironmentImport(projectKey, content, format, force interface{}) *gomock.Call {
+// BroadcastCreate indicates an expected call of BroadcastCreate
+func (mr *MockInterfaceMockRecorder) BroadcastCreate(broadcast interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentImport", reflect.TypeOf((*MockInterface)(nil).EnvironmentImport), projectKey, content, format, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastCreate", reflect.TypeOf((*MockInterface)(nil).BroadcastCreate), broadcast)
 }
 
-// EnvironmentVariablesList mocks base method
-func (m *MockInterface) EnvironmentVariablesList(key, envName string) ([]sdk.Variable, error) {
+// BroadcastGet mocks base method
+func (m *MockInterface) BroadcastGet(id string) (*sdk.Broadcast, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentVariablesList", key, envName)
-	ret0, _ := ret[0].([]sdk.Variable)
+	ret := m.ctrl.Call(m, "BroadcastGet", id)
+	ret0, _ := ret[0].(*sdk.Broadcast)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// EnvironmentVariablesList indicates an expected call of EnvironmentVariablesList
-func (mr *MockInterfaceMockRecorder) EnvironmentVariablesList(key, envName interface{}) *gomock.Call {
+// BroadcastGet indicates an expected call of BroadcastGet
+func (mr *MockInterfaceMockRecorder) BroadcastGet(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariablesList", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariablesList), key, envName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastGet", reflect.TypeOf((*MockInterface)(nil).BroadcastGet), id)
 }
 
-// EnvironmentVariableCreate mocks base method
-func (m *MockInterface) EnvironmentVariableCreate(projectKey, envName string, variable *sdk.Variable) error {
+// BroadcastDelete mocks base method
+func (m *MockInterface) BroadcastDelete(id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentVariableCreate", projectKey, envName, variable)
+	ret := m.ctrl.Call(m, "BroadcastDelete", id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EnvironmentVariableCreate indicates an expected call of EnvironmentVariableCreate
-func (mr *MockInterfaceMockRecorder) EnvironmentVariableCreate(projectKey, envName, variable interface{}) *gomock.Call {
+// BroadcastDelete indicates an expected call of BroadcastDelete
+func (mr *MockInterfaceMockRecorder) BroadcastDelete(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableCreate", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableCreate), projectKey, envName, variable)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastDelete", reflect.TypeOf((*MockInterface)(nil).BroadcastDelete), id)
 }
 
-// EnvironmentVariableDelete mocks base method
-func (m *MockInterface) EnvironmentVariableDelete(projectKey, envName, varName string) error {
+// Maintenance mocks base method
+func (m *MockInterface) Maintenance(enable, hooks bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentVariableDelete", projectKey, envName, varName)
+	ret := m.ctrl.Call(m, "Maintenance", enable, hooks)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EnvironmentVariableDelete indicates an expected call of EnvironmentVariableDelete
-func (mr *MockInterfaceMockRecorder) EnvironmentVariableDelete(projectKey, envName, varName interface{}) *gomock.Call {
+// Maintenance indicates an expected call of Maintenance
+func (mr *MockInterfaceMockRecorder) Maintenance(enable, hooks interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableDelete", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableDelete), projectKey, envName, varName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Maintenance", reflect.TypeOf((*MockInterface)(nil).Maintenance), enable, hooks)
 }
 
-// EnvironmentVariableGet mocks base method
-func (m *MockInterface) EnvironmentVariableGet(projectKey, envName, varName string) (*sdk.Variable, error) {
+// PipelineGet mocks base method
+func (m *MockInterface) PipelineGet(projectKey, name string, mods ...cdsclient.RequestModifier) (*sdk.Pipeline, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentVariableGet", projectKey, envName, varName)
-	ret0, _ := ret[0].(*sdk.Variable)
+	varargs := []interface{}{projectKey, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PipelineGet", varargs...)
+	ret0, _ := ret[0].(*sdk.Pipeline)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// EnvironmentVariableGet indicates an expected call of EnvironmentVariableGet
-func (mr *MockInterfaceMockRecorder) EnvironmentVariableGet(projectKey, envName, varName interface{}) *gomock.Call {
+// PipelineGet indicates an expected call of PipelineGet
+func (mr *MockInterfaceMockRecorder) PipelineGet(projectKey, name interface{}, mods ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableGet", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableGet), projectKey, envName, varName)
+	varargs := append([]interface{}{projectKey, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineGet", reflect.TypeOf((*MockInterface)(nil).PipelineGet), varargs...)
 }
 
-// EnvironmentVariableUpdate mocks base method
-func (m *MockInterface) EnvironmentVariableUpdate(projectKey, envName string, variable *sdk.Variable) error {
+// PipelineDelete mocks base method
+func (m *MockInterface) PipelineDelete(projectKey, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentVariableUpdate", projectKey, envName, variable)
+	ret := m.ctrl.Call(m, "PipelineDelete", projectKey, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EnvironmentVariableUpdate indicates an expected call of EnvironmentVariableUpdate
-func (mr *MockInterfaceMockRecorder) EnvironmentVariableUpdate(projectKey, envName, variable interface{}) *gomock.Call {
+// PipelineDelete indicates an expected call of PipelineDelete
+func (mr *MockInterfaceMockRecorder) PipelineDelete(projectKey, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentVariableUpdate", reflect.TypeOf((*MockInterface)(nil).EnvironmentVariableUpdate), projectKey, envName, variable)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineDelete", reflect.TypeOf((*MockInterface)(nil).PipelineDelete), projectKey, name)
 }
 
-// EnvironmentKeysList mocks base method
-func (m *MockInterface) EnvironmentKeysList(projectKey, envName string) ([]sdk.EnvironmentKey, error) {
+// PipelineCreate mocks base method
+func (m *MockInterface) PipelineCreate(projectKey string, pip *sdk.Pipeline) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentKeysList", projectKey, envName)
-	ret0, _ := ret[0].([]sdk.EnvironmentKey)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "PipelineCreate", projectKey, pip)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EnvironmentKeysList indicates an expected call of EnvironmentKeysList
-func (mr *MockInterfaceMockRecorder) EnvironmentKeysList(projectKey, envName interface{}) *gomock.Call {
+// PipelineCreate indicates an expected call of PipelineCreate
+func (mr *MockInterfaceMockRecorder) PipelineCreate(projectKey, pip interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentKeysList", reflect.TypeOf((*MockInterface)(nil).EnvironmentKeysList), projectKey, envName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineCreate", reflect.TypeOf((*MockInterface)(nil).PipelineCreate), projectKey, pip)
 }
 
-// EnvironmentKeyCreate mocks base method
-func (m *MockInterface) EnvironmentKeyCreate(projectKey, envName string, keyEnv *sdk.EnvironmentKey) error {
+// PipelineList mocks base method
+func (m *MockInterface) PipelineList(projectKey string) ([]sdk.Pipeline, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentKeyCreate", projectKey, envName, keyEnv)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PipelineList", projectKey)
+	ret0, _ := ret[0].([]sdk.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EnvironmentKeyCreate indicates an expected call of EnvironmentKeyCreate
-func (mr *MockInterfaceMockRecorder) EnvironmentKeyCreate(projectKey, envName, keyEnv interface{}) *gomock.Call {
+// PipelineList indicates an expected call of PipelineList
+func (mr *MockInterfaceMockRecorder) PipelineList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentKeyCreate", reflect.TypeOf((*MockInterface)(nil).EnvironmentKeyCreate), projectKey, envName, keyEnv)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineList", reflect.TypeOf((*MockInterface)(nil).PipelineList), projectKey)
 }
 
-// EnvironmentKeysDelete mocks base method
-func (m *MockInterface) EnvironmentKeysDelete(projectKey, envName, keyEnvName string) error {
+// IntegrationModelList mocks base method
+func (m *MockInterface) IntegrationModelList() ([]sdk.IntegrationModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvironmentKeysDelete", projectKey, envName, keyEnvName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "IntegrationModelList")
+	ret0, _ := ret[0].([]sdk.IntegrationModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EnvironmentKeysDelete indicates an expected call of EnvironmentKeysDelete
-func (mr *MockInterfaceMockRecorder) EnvironmentKeysDelete(projectKey, envName, keyEnvName interface{}) *gomock.Call {
+// IntegrationModelList indicates an expected call of IntegrationModelList
+func (mr *MockInterfaceMockRecorder) IntegrationModelList() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvironmentKeysDelete", reflect.TypeOf((*MockInterface)(nil).EnvironmentKeysDelete), projectKey, envName, keyEnvName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelList", reflect.TypeOf((*MockInterface)(nil).IntegrationModelList))
 }
 
-// EventsListen mocks base method
-func (m *MockInterface) EventsListen(ctx context.Context, chanSSEvt chan<- cdsclient.SSEvent) {
+// IntegrationModelGet mocks base method
+func (m *MockInterface) IntegrationModelGet(name string) (sdk.IntegrationModel, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "EventsListen", ctx, chanSSEvt)
+	ret := m.ctrl.Call(m, "IntegrationModelGet", name)
+	ret0, _ := ret[0].(sdk.IntegrationModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EventsListen indicates an expected call of EventsListen
-func (mr *MockInterfaceMockRecorder) EventsListen(ctx, chanSSEvt interface{}) *gomock.Call {
+// IntegrationModelGet indicates an expected call of IntegrationModelGet
+func (mr *MockInterfaceMockRecorder) IntegrationModelGet(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsListen", reflect.TypeOf((*MockInterface)(nil).EventsListen), ctx, chanSSEvt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelGet", reflect.TypeOf((*MockInterface)(nil).IntegrationModelGet), name)
 }
 
-// PipelineExport mocks base method
-func (m *MockInterface) PipelineExport(projectKey, name, exportFormat string) ([]byte, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PipelineExport", projectKey, name, exportFormat)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// IntegrationModelAdd mocks base method
+func (m_2 *MockInterface) IntegrationModelAdd(m *sdk.IntegrationModel) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "IntegrationModelAdd", m)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// PipelineExport indicates an expected call of PipelineExport
-func (mr *MockInterfaceMockRecorder) PipelineExport(projectKey, name, exportFormat interface{}) *gomock.Call {
+// IntegrationModelAdd indicates an expected call of IntegrationModelAdd
+func (mr *MockInterfaceMockRecorder) IntegrationModelAdd(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineExport", reflect.TypeOf((*MockInterface)(nil).PipelineExport), projectKey, name, exportFormat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelAdd", reflect.TypeOf((*MockInterface)(nil).IntegrationModelAdd), m)
 }
 
-// PipelineImport mocks base method
-func (m *MockInterface) PipelineImport(projectKey string, content io.Reader, format string, force bool) ([]string, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PipelineImport", projectKey, content, format, force)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// IntegrationModelUpdate mocks base method
+func (m_2 *MockInterface) IntegrationModelUpdate(m *sdk.IntegrationModel) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "IntegrationModelUpdate", m)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// PipelineImport indicates an expected call of PipelineImport
-func (mr *MockInterfaceMockRecorder) PipelineImport(projectKey, content, format, force interface{}) *gomock.Call {
+// IntegrationModelUpdate indicates an expected call of IntegrationModelUpdate
+func (mr *MockInterfaceMockRecorder) IntegrationModelUpdate(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineImport", reflect.TypeOf((*MockInterface)(nil).PipelineImport), projectKey, content, format, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelUpdate", reflect.TypeOf((*MockInterface)(nil).IntegrationModelUpdate), m)
 }
 
-// ApplicationExport mocks base method
-func (m *MockInterface) ApplicationExport(projectKey, name, format string) ([]byte, error) {
+// IntegrationModelDelete mocks base method
+func (m *MockInterface) IntegrationModelDelete(name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationExport", projectKey, name, format)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "IntegrationModelDelete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ApplicationExport indicates an expected call of ApplicationExport
-func (mr *MockInterfaceMockRecorder) ApplicationExport(projectKey, name, format interface{}) *gomock.Call {
+// IntegrationModelDelete indicates an expected call of IntegrationModelDelete
+func (mr *MockInterfaceMockRecorder) IntegrationModelDelete(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationExport", reflect.TypeOf((*MockInterface)(nil).ApplicationExport), projectKey, name, format)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelDelete", reflect.TypeOf((*MockInterface)(nil).IntegrationModelDelete), name)
 }
 
-// ApplicationImport mocks base method
-func (m *MockInterface) ApplicationImport(projectKey string, content io.Reader, format string, force bool) ([]string, error) {
+// ProjectCreate mocks base method
+func (m *MockInterface) ProjectCreate(proj *sdk.Project) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplicationImport", projectKey, content, format, force)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectCreate", proj)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ApplicationImport indicates an expected call of ApplicationImport
-func (mr *MockInterfaceMockRecorder) ApplicationImport(projectKey, content, format, force interface{}) *gomock.Call {
+// ProjectCreate indicates an expected call of ProjectCreate
+func (mr *MockInterfaceMockRecorder) ProjectCreate(proj interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationImport", reflect.TypeOf((*MockInterface)(nil).ApplicationImport), projectKey, content, format, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCreate", reflect.TypeOf((*MockInterface)(nil).ProjectCreate), proj)
 }
 
-// WorkflowExport mocks base method
-func (m *MockInterface) WorkflowExport(projectKey, name string, mods ...cdsclient.RequestModifier) ([]byte, error) {
+// ProjectDelete mocks base method
+func (m *MockInterface) ProjectDelete(projectKey string) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey, name}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "WorkflowExport", varargs...)
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectDelete", projectKey)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowExport indicates an expected call of WorkflowExport
-func (mr *MockInterfaceMockRecorder) WorkflowExport(projectKey, name interface{}, mods ...interface{}) *gomock.Call {
+// ProjectDelete indicates an expected call of ProjectDelete
+func (mr *MockInterfaceMockRecorder) ProjectDelete(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, name}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowExport", reflect.TypeOf((*MockInterface)(nil).WorkflowExport), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDelete", reflect.TypeOf((*MockInterface)(nil).ProjectDelete), projectKey)
 }
 
-// WorkflowPull mocks base method
-func (m *MockInterface) WorkflowPull(projectKey, name string, mods ...cdsclient.RequestModifier) (*tar.Reader, error) {
+// ProjectGroupAdd mocks base method
+func (m *MockInterface) ProjectGroupAdd(projectKey, groupName string, permission int, projectOnly bool) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey, name}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "WorkflowPull", varargs...)
-	ret0, _ := ret[0].(*tar.Reader)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectGroupAdd", projectKey, groupName, permission, projectOnly)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowPull indicates an expected call of WorkflowPull
-func (mr *MockInterfaceMockRecorder) WorkflowPull(projectKey, name interface{}, mods ...interface{}) *gomock.Call {
+// ProjectGroupAdd indicates an expected call of ProjectGroupAdd
+func (mr *MockInterfaceMockRecorder) ProjectGroupAdd(projectKey, groupName, permission, projectOnly interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, name}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowPull", reflect.TypeOf((*MockInterface)(nil).WorkflowPull), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGroupAdd", reflect.TypeOf((*MockInterface)(nil).ProjectGroupAdd), projectKey, groupName, permission, projectOnly)
 }
 
-// WorkflowImport mocks base method
-func (m *MockInterface) WorkflowImport(projectKey string, content io.Reader, format string, force bool) ([]string, error) {
+// ProjectGroupDelete mocks base method
+func (m *MockInterface) ProjectGroupDelete(projectKey, groupName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowImport", projectKey, content, format, force)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectGroupDelete", projectKey, groupName)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowImport indicates an expected call of WorkflowImport
-func (mr *MockInterfaceMockRecorder) WorkflowImport(projectKey, content, format, force interface{}) *gomock.Call {
+// ProjectGroupDelete indicates an expected call of ProjectGroupDelete
+func (mr *MockInterfaceMockRecorder) ProjectGroupDelete(projectKey, groupName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowImport", reflect.TypeOf((*MockInterface)(nil).WorkflowImport), projectKey, content, format, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGroupDelete", reflect.TypeOf((*MockInterface)(nil).ProjectGroupDelete), projectKey, groupName)
 }
 
-// WorkerModelExport mocks base method
-func (m *MockInterface) WorkerModelExport(groupName, name, format string) ([]byte, error) {
+// ProjectGet mocks base method
+func (m *MockInterface) ProjectGet(projectKey string, opts ...cdsclient.RequestModifier) (*sdk.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModelExport", groupName, name, format)
-	ret0, _ := ret[0].([]byte)
+	varargs := []interface{}{projectKey}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectGet", varargs...)
+	ret0, _ := ret[0].(*sdk.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkerModelExport indicates an expected call of WorkerModelExport
-func (mr *MockInterfaceMockRecorder) WorkerModelExport(groupName, name, format interface{}) *gomock.Call {
+// ProjectGet indicates an expected call of ProjectGet
+func (mr *MockInterfaceMockRecorder) ProjectGet(projectKey interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelExport", reflect.TypeOf((*MockInterface)(nil).WorkerModelExport), groupName, name, format)
+	varargs := append([]interface{}{projectKey}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGet", reflect.TypeOf((*MockInterface)(nil).ProjectGet), varargs...)
 }
 
-// WorkerModelImport mocks base method
-func (m *MockInterface) WorkerModelImport(content io.Reader, format string, force bool) (*sdk.Model, error) {
+// ProjectUpdate mocks base method
+func (m *MockInterface) ProjectUpdate(key string, project *sdk.Project) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModelImport", content, format, force)
-	ret0, _ := ret[0].(*sdk.Model)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectUpdate", key, project)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkerModelImport indicates an expected call of WorkerModelImport
-func (mr *MockInterfaceMockRecorder) WorkerModelImport(content, format, force interface{}) *gomock.Call {
+// ProjectUpdate indicates an expected call of ProjectUpdate
+func (mr *MockInterfaceMockRecorder) ProjectUpdate(key, project interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelImport", reflect.TypeOf((*MockInterface)(nil).WorkerModelImport), content, format, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectUpdate), key, project)
 }
 
-// WorkflowPush mocks base method
-func (m *MockInterface) WorkflowPush(projectKey string, tarContent io.Reader, mods ...cdsclient.RequestModifier) ([]string, *tar.Reader, error) {
+// ProjectList mocks base method
+func (m *MockInterface) ProjectList(withApplications, withWorkflow bool, filters ...cdsclient.Filter) ([]sdk.Project, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey, tarContent}
-	for _, a := range mods {
+	varargs := []interface{}{withApplications, withWorkflow}
+	for _, a := range filters {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "WorkflowPush", varargs...)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(*tar.Reader)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "ProjectList", varargs...)
+	ret0, _ := ret[0].([]sdk.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowPush indicates an expected call of WorkflowPush
-func (mr *MockInterfaceMockRecorder) WorkflowPush(projectKey, tarContent interface{}, mods ...interface{}) *gomock.Call {
+// ProjectList indicates an expected call of ProjectList
+func (mr *MockInterfaceMockRecorder) ProjectList(withApplications, withWorkflow interface{}, filters ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, tarContent}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowPush", reflect.TypeOf((*MockInterface)(nil).WorkflowPush), varargs...)
+	varargs := append([]interface{}{withApplications, withWorkflow}, filters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectList", reflect.TypeOf((*MockInterface)(nil).ProjectList), varargs...)
 }
 
-// WorkflowAsCodeStart mocks base method
-func (m *MockInterface) WorkflowAsCodeStart(projectKey, repoURL string, repoStrategy sdk.RepositoryStrategy) (*sdk.Operation, error) {
+// ProjectKeysList mocks base method
+func (m *MockInterface) ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowAsCodeStart", projectKey, repoURL, repoStrategy)
-	ret0, _ := ret[0].(*sdk.Operation)
+	ret := m.ctrl.Call(m, "ProjectKeysList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowAsCodeStart indicates an expected call of WorkflowAsCodeStart
-func (mr *MockInterfaceMockRecorder) WorkflowAsCodeStart(projectKey, repoURL, repoStrategy interface{}) *gomock.Call {
+// ProjectKeysList indicates an expected call of ProjectKeysList
+func (mr *MockInterfaceMockRecorder) ProjectKeysList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeStart", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodeStart), projectKey, repoURL, repoStrategy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysList", reflect.TypeOf((*MockInterface)(nil).ProjectKeysList), projectKey)
 }
 
-// WorkflowAsCodeInfo mocks base method
-func (m *MockInterface) WorkflowAsCodeInfo(projectKey, operationID string) (*sdk.Operation, error) {
+// ProjectKeyCreate mocks base method
+func (m *MockInterface) ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowAsCodeInfo", projectKey, operationID)
-	ret0, _ := ret[0].(*sdk.Operation)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectKeyCreate", projectKey, key)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowAsCodeInfo indicates an expected call of WorkflowAsCodeInfo
-func (mr *MockInterfaceMockRecorder) WorkflowAsCodeInfo(projectKey, operationID interface{}) *gomock.Call {
+// ProjectKeyCreate indicates an expected call of ProjectKeyCreate
+func (mr *MockInterfaceMockRecorder) ProjectKeyCreate(projectKey, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodeInfo", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodeInfo), projectKey, operationID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockInterface)(nil).ProjectKeyCreate), projectKey, key)
 }
 
-// WorkflowAsCodePerform mocks base method
-func (m *MockInterface) WorkflowAsCodePerform(projectKey, operationID string) ([]string, error) {
+// ProjectKeysDelete mocks base method
+func (m *MockInterface) ProjectKeysDelete(projectKey, keyProjectName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowAsCodePerform", projectKey, operationID)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectKeysDelete", projectKey, keyProjectName)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowAsCodePerform indicates an expected call of WorkflowAsCodePerform
-func (mr *MockInterfaceMockRecorder) WorkflowAsCodePerform(projectKey, operationID interface{}) *gomock.Call {
+// ProjectKeysDelete indicates an expected call of ProjectKeysDelete
+func (mr *MockInterfaceMockRecorder) ProjectKeysDelete(projectKey, keyProjectName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowAsCodePerform", reflect.TypeOf((*MockInterface)(nil).WorkflowAsCodePerform), projectKey, operationID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockInterface)(nil).ProjectKeysDelete), projectKey, keyProjectName)
 }
 
-// GroupList mocks base method
-func (m *MockInterface) GroupList() ([]sdk.Group, error) {
+// ProjectVariablesList mocks base method
+func (m *MockInterface) ProjectVariablesList(key string) ([]sdk.Variable, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupList")
-	ret0, _ := ret[0].([]sdk.Group)
+	ret := m.ctrl.Call(m, "ProjectVariablesList", key)
+	ret0, _ := ret[0].([]sdk.Variable)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GroupList indicates an expected call of GroupList
-func (mr *MockInterfaceMockRecorder) GroupList() *gomock.Call {
+// ProjectVariablesList indicates an expected call of ProjectVariablesList
+func (mr *MockInterfaceMockRecorder) ProjectVariablesList(key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupList", reflect.TypeOf((*MockInterface)(nil).GroupList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariablesList", reflect.TypeOf((*MockInterface)(nil).ProjectVariablesList), key)
 }
 
-// GroupGet mocks base method
-func (m *MockInterface) GroupGet(name string, mods ...cdsclient.RequestModifier) (*sdk.Group, error) {
+// ProjectVariableCreate mocks base method
+func (m *MockInterface) ProjectVariableCreate(projectKey string, variable *sdk.Variable) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{name}
-	for _, a := range mods {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "GroupGet", varargs...)
-	ret0, _ := ret[0].(*sdk.Group)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ProjectVariableCreate", projectKey, variable)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GroupGet indicates an expected call of GroupGet
-func (mr *MockInterfaceMockRecorder) GroupGet(name interface{}, mods ...interface{}) *gomock.Call {
+// ProjectVariableCreate indicates an expected call of ProjectVariableCreate
+func (mr *MockInterfaceMockRecorder) ProjectVariableCreate(projectKey, variable interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{name}, mods...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupGet", reflect.TypeOf((*MockInterface)(nil).GroupGet), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableCreate", reflect.TypeOf((*MockInterface)(nil).ProjectVariableCreate), projectKey, variable)
 }
 
-// GroupCreate mocks base method
-func (m *MockInterface) GroupCreate(group *sdk.Group) error {
+// ProjectVariableDelete mocks base method
+func (m *MockInterface) ProjectVariableDelete(projectKey, varName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupCreate", group)
+	ret := m.ctrl.Call(m, "ProjectVariableDelete", projectKey, varName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
-
-// GroupCreate indicates an expected call of GroupCreate
-func (mr *MockInterfaceMockRecorder) GroupCreate(group interface{}) *gomock.Call {
+
+// ProjectVariableDelete indicates an expected call of ProjectVariableDelete
+func (mr *MockInterfaceMockRecorder) ProjectVariableDelete(projectKey, varName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupCreate", reflect.TypeOf((*MockInterface)(nil).GroupCreate), group)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableDelete", reflect.TypeOf((*MockInterface)(nil).ProjectVariableDelete), projectKey, varName)
 }
 
-// GroupRename mocks base method
-func (m *MockInterface) GroupRename(oldName, newName string) error {
+// ProjectVariableGet mocks base method
+func (m *MockInterface) ProjectVariableGet(projectKey, varName string) (*sdk.Variable, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupRename", oldName, newName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectVariableGet", projectKey, varName)
+	ret0, _ := ret[0].(*sdk.Variable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GroupRename indicates an expected call of GroupRename
-func (mr *MockInterfaceMockRecorder) GroupRename(oldName, newName interface{}) *gomock.Call {
+// ProjectVariableGet indicates an expected call of ProjectVariableGet
+func (mr *MockInterfaceMockRecorder) ProjectVariableGet(projectKey, varName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupRename", reflect.TypeOf((*MockInterface)(nil).GroupRename), oldName, newName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableGet", reflect.TypeOf((*MockInterface)(nil).ProjectVariableGet), projectKey, varName)
 }
 
-// GroupDelete mocks base method
-func (m *MockInterface) GroupDelete(name string) error {
+// ProjectVariableUpdate mocks base method
+func (m *MockInterface) ProjectVariableUpdate(projectKey string, variable *sdk.Variable) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupDelete", name)
+	ret := m.ctrl.Call(m, "ProjectVariableUpdate", projectKey, variable)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GroupDelete indicates an expected call of GroupDelete
-func (mr *MockInterfaceMockRecorder) GroupDelete(name interface{}) *gomock.Call {
+// ProjectVariableUpdate indicates an expected call of ProjectVariableUpdate
+func (mr *MockInterfaceMockRecorder) ProjectVariableUpdate(projectKey, variable interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupDelete", reflect.TypeOf((*MockInterface)(nil).GroupDelete), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectVariableUpdate), projectKey, variable)
 }
 
-// GroupMemberAdd mocks base method
-func (m *MockInterface) GroupMemberAdd(groupName string, member *sdk.GroupMember) (sdk.Group, error) {
+// VariableEncrypt mocks base method
+func (m *MockInterface) VariableEncrypt(projectKey, varName, content string) (*sdk.Variable, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupMemberAdd", groupName, member)
-	ret0, _ := ret[0].(sdk.Group)
+	ret := m.ctrl.Call(m, "VariableEncrypt", projectKey, varName, content)
+	ret0, _ := ret[0].(*sdk.Variable)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GroupMemberAdd indicates an expected call of GroupMemberAdd
-func (mr *MockInterfaceMockRecorder) GroupMemberAdd(groupName, member interface{}) *gomock.Call {
+// VariableEncrypt indicates an expected call of VariableEncrypt
+func (mr *MockInterfaceMockRecorder) VariableEncrypt(projectKey, varName, content interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberAdd", reflect.TypeOf((*MockInterface)(nil).GroupMemberAdd), groupName, member)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariableEncrypt", reflect.TypeOf((*MockInterface)(nil).VariableEncrypt), projectKey, varName, content)
 }
 
-// GroupMemberEdit mocks base method
-func (m *MockInterface) GroupMemberEdit(groupName string, member *sdk.GroupMember) (sdk.Group, error) {
+// ProjectGroupsImport mocks base method
+func (m *MockInterface) ProjectGroupsImport(projectKey string, content io.Reader, format string, force bool) (sdk.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupMemberEdit", groupName, member)
-	ret0, _ := ret[0].(sdk.Group)
+	ret := m.ctrl.Call(m, "ProjectGroupsImport", projectKey, content, format, force)
+	ret0, _ := ret[0].(sdk.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GroupMemberEdit indicates an expected call of GroupMemberEdit
-func (mr *MockInterfaceMockRecorder) GroupMemberEdit(groupName, member interface{}) *gomock.Call {
+// ProjectGroupsImport indicates an expected call of ProjectGroupsImport
+func (mr *MockInterfaceMockRecorder) ProjectGroupsImport(projectKey, content, format, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberEdit", reflect.TypeOf((*MockInterface)(nil).GroupMemberEdit), groupName, member)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGroupsImport", reflect.TypeOf((*MockInterface)(nil).ProjectGroupsImport), projectKey, content, format, force)
 }
 
-// GroupMemberRemove mocks base method
-func (m *MockInterface) GroupMemberRemove(groupName, username string) error {
+// ProjectIntegrationImport mocks base method
+func (m *MockInterface) ProjectIntegrationImport(projectKey string, content io.Reader, format string, force bool) (sdk.ProjectIntegration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GroupMemberRemove", groupName, username)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectIntegrationImport", projectKey, content, format, force)
+	ret0, _ := ret[0].(sdk.ProjectIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GroupMemberRemove indicates an expected call of GroupMemberRemove
-func (mr *MockInterfaceMockRecorder) GroupMemberRemove(groupName, username interface{}) *gomock.Call {
+// ProjectIntegrationImport indicates an expected call of ProjectIntegrationImport
+func (mr *MockInterfaceMockRecorder) ProjectIntegrationImport(projectKey, content, format, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupMemberRemove", reflect.TypeOf((*MockInterface)(nil).GroupMemberRemove), groupName, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationImport", reflect.TypeOf((*MockInterface)(nil).ProjectIntegrationImport), projectKey, content, format, force)
 }
 
-// PluginsList mocks base method
-func (m *MockInterface) PluginsList() ([]sdk.GRPCPlugin, error) {
+// ProjectIntegrationGet mocks base method
+func (m *MockInterface) ProjectIntegrationGet(projectKey, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginsList")
-	ret0, _ := ret[0].([]sdk.GRPCPlugin)
+	ret := m.ctrl.Call(m, "ProjectIntegrationGet", projectKey, integrationName, clearPassword)
+	ret0, _ := ret[0].(sdk.ProjectIntegration)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PluginsList indicates an expected call of PluginsList
-func (mr *MockInterfaceMockRecorder) PluginsList() *gomock.Call {
+// ProjectIntegrationGet indicates an expected call of ProjectIntegrationGet
+func (mr *MockInterfaceMockRecorder) ProjectIntegrationGet(projectKey, integrationName, clearPassword interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginsList", reflect.TypeOf((*MockInterface)(nil).PluginsList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationGet", reflect.TypeOf((*MockInterface)(nil).ProjectIntegrationGet), projectKey, integrationName, clearPassword)
 }
 
-// PluginsGet mocks base method
-func (m *MockInterface) PluginsGet(arg0 string) (*sdk.GRPCPlugin, error) {
+// ProjectIntegrationList mocks base method
+func (m *MockInterface) ProjectIntegrationList(projectKey string) ([]sdk.ProjectIntegration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginsGet", arg0)
-	ret0, _ := ret[0].(*sdk.GRPCPlugin)
+	ret := m.ctrl.Call(m, "ProjectIntegrationList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectIntegration)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PluginsGet indicates an expected call of PluginsGet
-func (mr *MockInterfaceMockRecorder) PluginsGet(arg0 interface{}) *gomock.Call {
+// ProjectIntegrationList indicates an expected call of ProjectIntegrationList
+func (mr *MockInterfaceMockRecorder) ProjectIntegrationList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginsGet", reflect.TypeOf((*MockInterface)(nil).PluginsGet), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationList", reflect.TypeOf((*MockInterface)(nil).ProjectIntegrationList), projectKey)
 }
 
-// PluginAdd mocks base method
-func (m *MockInterface) PluginAdd(arg0 *sdk.GRPCPlugin) error {
+// ProjectIntegrationDelete mocks base method
+func (m *MockInterface) ProjectIntegrationDelete(projectKey, integrationName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginAdd", arg0)
+	ret := m.ctrl.Call(m, "ProjectIntegrationDelete", projectKey, integrationName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PluginAdd indicates an expected call of PluginAdd
-func (mr *MockInterfaceMockRecorder) PluginAdd(arg0 interface{}) *gomock.Call {
+// ProjectIntegrationDelete indicates an expected call of ProjectIntegrationDelete
+func (mr *MockInterfaceMockRecorder) ProjectIntegrationDelete(projectKey, integrationName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginAdd", reflect.TypeOf((*MockInterface)(nil).PluginAdd), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationDelete", reflect.TypeOf((*MockInterface)(nil).ProjectIntegrationDelete), projectKey, integrationName)
 }
 
-// PluginUpdate mocks base method
-func (m *MockInterface) PluginUpdate(arg0 *sdk.GRPCPlugin) error {
+// ProjectRepositoryManagerList mocks base method
+func (m *MockInterface) ProjectRepositoryManagerList(projectKey string) ([]sdk.ProjectVCSServer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginUpdate", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectRepositoryManagerList", projectKey)
+	ret0, _ := ret[0].([]sdk.ProjectVCSServer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// PluginUpdate indicates an expected call of PluginUpdate
-func (mr *MockInterfaceMockRecorder) PluginUpdate(arg0 interface{}) *gomock.Call {
+// ProjectRepositoryManagerList indicates an expected call of ProjectRepositoryManagerList
+func (mr *MockInterfaceMockRecorder) ProjectRepositoryManagerList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginUpdate", reflect.TypeOf((*MockInterface)(nil).PluginUpdate), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerList", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryManagerList), projectKey)
 }
 
-// PluginDelete mocks base method
-func (m *MockInterface) PluginDelete(arg0 string) error {
+// ProjectRepositoryManagerDelete mocks base method
+func (m *MockInterface) ProjectRepositoryManagerDelete(projectKey, repoManagerName string, force bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginDelete", arg0)
+	ret := m.ctrl.Call(m, "ProjectRepositoryManagerDelete", projectKey, repoManagerName, force)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PluginDelete indicates an expected call of PluginDelete
-func (mr *MockInterfaceMockRecorder) PluginDelete(arg0 interface{}) *gomock.Call {
+// ProjectRepositoryManagerDelete indicates an expected call of ProjectRepositoryManagerDelete
+func (mr *MockInterfaceMockRecorder) ProjectRepositoryManagerDelete(projectKey, repoManagerName, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginDelete", reflect.TypeOf((*MockInterface)(nil).PluginDelete), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
 }
 
-// PluginAddBinary mocks base method
-func (m *MockInterface) PluginAddBinary(arg0 *sdk.GRPCPlugin, arg1 *sdk.GRPCPluginBinary) error {
+// ProjectSBOMSearch mocks base method
+func (m *MockInterface) ProjectSBOMSearch(projectKey, name, version string) ([]sdk.SBOMSearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginAddBinary", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectSBOMSearch", projectKey, name, version)
+	ret0, _ := ret[0].([]sdk.SBOMSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// PluginAddBinary indicates an expected call of PluginAddBinary
-func (mr *MockInterfaceMockRecorder) PluginAddBinary(arg0, arg1 interface{}) *gomock.Call {
+// ProjectSBOMSearch indicates an expected call of ProjectSBOMSearch
+func (mr *MockInterfaceMockRecorder) ProjectSBOMSearch(projectKey, name, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginAddBinary", reflect.TypeOf((*MockInterface)(nil).PluginAddBinary), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSBOMSearch", reflect.TypeOf((*MockInterface)(nil).ProjectSBOMSearch), projectKey, name, version)
 }
 
-// PluginDeleteBinary mocks base method
-func (m *MockInterface) PluginDeleteBinary(name, os, arch string) error {
+// ProjectTestHistory mocks base method
+func (m *MockInterface) ProjectTestHistory(projectKey, suite, name string) ([]sdk.TestHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginDeleteBinary", name, os, arch)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectTestHistory", projectKey, suite, name)
+	ret0, _ := ret[0].([]sdk.TestHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// PluginDeleteBinary indicates an expected call of PluginDeleteBinary
-func (mr *MockInterfaceMockRecorder) PluginDeleteBinary(name, os, arch interface{}) *gomock.Call {
+// ProjectTestHistory indicates an expected call of ProjectTestHistory
+func (mr *MockInterfaceMockRecorder) ProjectTestHistory(projectKey, suite, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginDeleteBinary", reflect.TypeOf((*MockInterface)(nil).PluginDeleteBinary), name, os, arch)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectTestHistory", reflect.TypeOf((*MockInterface)(nil).ProjectTestHistory), projectKey, suite, name)
 }
 
-// PluginGetBinary mocks base method
-func (m *MockInterface) PluginGetBinary(name, os, arch string, w io.Writer) error {
+// ProjectFlakyTests mocks base method
+func (m *MockInterface) ProjectFlakyTests(projectKey string, limit, days int) ([]sdk.FlakyTest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginGetBinary", name, os, arch, w)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectFlakyTests", projectKey, limit, days)
+	ret0, _ := ret[0].([]sdk.FlakyTest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// PluginGetBinary indicates an expected call of PluginGetBinary
-func (mr *MockInterfaceMockRecorder) PluginGetBinary(name, os, arch, w interface{}) *gomock.Call {
+// ProjectFlakyTests indicates an expected call of ProjectFlakyTests
+func (mr *MockInterfaceMockRecorder) ProjectFlakyTests(projectKey, limit, days interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinary", reflect.TypeOf((*MockInterface)(nil).PluginGetBinary), name, os, arch, w)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFlakyTests", reflect.TypeOf((*MockInterface)(nil).ProjectFlakyTests), projectKey, limit, days)
 }
 
-// PluginGetBinaryInfos mocks base method
-func (m *MockInterface) PluginGetBinaryInfos(name, os, arch string) (*sdk.GRPCPluginBinary, error) {
+// ProjectDORAMetrics mocks base method
+func (m *MockInterface) ProjectDORAMetrics(projectKey string, days int) (*sdk.DORAReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PluginGetBinaryInfos", name, os, arch)
-	ret0, _ := ret[0].(*sdk.GRPCPluginBinary)
+	ret := m.ctrl.Call(m, "ProjectDORAMetrics", projectKey, days)
+	ret0, _ := ret[0].(*sdk.DORAReport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PluginGetBinaryInfos indicates an expected call of PluginGetBinaryInfos
-func (mr *MockInterfaceMockRecorder) PluginGetBinaryInfos(name, os, arch interface{}) *gomock.Call {
+// ProjectDORAMetrics indicates an expected call of ProjectDORAMetrics
+func (mr *MockInterfaceMockRecorder) ProjectDORAMetrics(projectKey, days interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinaryInfos", reflect.TypeOf((*MockInterface)(nil).PluginGetBinaryInfos), name, os, arch)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDORAMetrics", reflect.TypeOf((*MockInterface)(nil).ProjectDORAMetrics), projectKey, days)
 }
 
-// Broadcasts mocks base method
-func (m *MockInterface) Broadcasts() ([]sdk.Broadcast, error) {
+// ProjectRetentionSettingsGet mocks base method
+func (m *MockInterface) ProjectRetentionSettingsGet(projectKey string) (*sdk.ProjectRetentionSettings, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Broadcasts")
-	ret0, _ := ret[0].([]sdk.Broadcast)
+	ret := m.ctrl.Call(m, "ProjectRetentionSettingsGet", projectKey)
+	ret0, _ := ret[0].(*sdk.ProjectRetentionSettings)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Broadcasts indicates an expected call of Broadcasts
-func (mr *MockInterfaceMockRecorder) Broadcasts() *gomock.Call {
+// ProjectRetentionSettingsGet indicates an expected call of ProjectRetentionSettingsGet
+func (mr *MockInterfaceMockRecorder) ProjectRetentionSettingsGet(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Broadcasts", reflect.TypeOf((*MockInterface)(nil).Broadcasts))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRetentionSettingsGet", reflect.TypeOf((*MockInterface)(nil).ProjectRetentionSettingsGet), projectKey)
 }
 
-// BroadcastCreate mocks base method
-func (m *MockInterface) BroadcastCreate(broadcast *sdk.Broadcast) error {
+// ProjectRetentionSettingsUpdate mocks base method
+func (m *MockInterface) ProjectRetentionSettingsUpdate(projectKey string, s sdk.ProjectRetentionSettings) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BroadcastCreate", broadcast)
+	ret := m.ctrl.Call(m, "ProjectRetentionSettingsUpdate", projectKey, s)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// BroadcastCreate indicates an expected call of BroadcastCreate
-func (mr *MockInterfaceMockRecorder) BroadcastCreate(broadcast interface{}) *gomock.Call {
+// ProjectRetentionSettingsUpdate indicates an expected call of ProjectRetentionSettingsUpdate
+func (mr *MockInterfaceMockRecorder) ProjectRetentionSettingsUpdate(projectKey, s interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastCreate", reflect.TypeOf((*MockInterface)(nil).BroadcastCreate), broadcast)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRetentionSettingsUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectRetentionSettingsUpdate), projectKey, s)
 }
 
-// BroadcastGet mocks base method
-func (m *MockInterface) BroadcastGet(id string) (*sdk.Broadcast, error) {
+// ProjectFreezeWindowList mocks base method
+func (m *MockInterface) ProjectFreezeWindowList(projectKey string) ([]sdk.FreezeWindow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BroadcastGet", id)
-	ret0, _ := ret[0].(*sdk.Broadcast)
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowList", projectKey)
+	ret0, _ := ret[0].([]sdk.FreezeWindow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// BroadcastGet indicates an expected call of BroadcastGet
-func (mr *MockInterfaceMockRecorder) BroadcastGet(id interface{}) *gomock.Call {
+// ProjectFreezeWindowList indicates an expected call of ProjectFreezeWindowList
+func (mr *MockInterfaceMockRecorder) ProjectFreezeWindowList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastGet", reflect.TypeOf((*MockInterface)(nil).BroadcastGet), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowList", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeWindowList), projectKey)
 }
 
-// BroadcastDelete mocks base method
-func (m *MockInterface) BroadcastDelete(id string) error {
+// ProjectFreezeWindowAdd mocks base method
+func (m *MockInterface) ProjectFreezeWindowAdd(projectKey string, w *sdk.FreezeWindow) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BroadcastDelete", id)
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowAdd", projectKey, w)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// BroadcastDelete indicates an expected call of BroadcastDelete
-func (mr *MockInterfaceMockRecorder) BroadcastDelete(id interface{}) *gomock.Call {
+// ProjectFreezeWindowAdd indicates an expected call of ProjectFreezeWindowAdd
+func (mr *MockInterfaceMockRecorder) ProjectFreezeWindowAdd(projectKey, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastDelete", reflect.TypeOf((*MockInterface)(nil).BroadcastDelete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowAdd", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeWindowAdd), projectKey, w)
 }
 
-// Maintenance mocks base method
-func (m *MockInterface) Maintenance(enable, hooks bool) error {
+// ProjectFreezeWindowDelete mocks base method
+func (m *MockInterface) ProjectFreezeWindowDelete(projectKey, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Maintenance", enable, hooks)
+	ret := m.ctrl.Call(m, "ProjectFreezeWindowDelete", projectKey, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Maintenance indicates an expected call of Maintenance
-func (mr *MockInterfaceMockRecorder) Maintenance(enable, hooks interface{}) *gomock.Call {
+// ProjectFreezeWindowDelete indicates an expected call of ProjectFreezeWindowDelete
+func (mr *MockInterfaceMockRecorder) ProjectFreezeWindowDelete(projectKey, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Maintenance", reflect.TypeOf((*MockInterface)(nil).Maintenance), enable, hooks)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeWindowDelete", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeWindowDelete), projectKey, name)
 }
 
-// PipelineDelete mocks base method
-func (m *MockInterface) PipelineDelete(projectKey, name string) error {
+// ProjectFreezeOverrideList mocks base method
+func (m *MockInterface) ProjectFreezeOverrideList(projectKey string) ([]sdk.FreezeWindowOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFreezeOverrideList", projectKey)
+	ret0, _ := ret[0].([]sdk.FreezeWindowOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFreezeOverrideList indicates an expected call of ProjectFreezeOverrideList
+func (mr *MockInterfaceMockRecorder) ProjectFreezeOverrideList(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFreezeOverrideList", reflect.TypeOf((*MockInterface)(nil).ProjectFreezeOverrideList), projectKey)
+}
+
+// ProjectDeploymentList mocks base method
+func (m *MockInterface) ProjectDeploymentList(projectKey, application, environment string) ([]sdk.EnvironmentDeployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectDeploymentList", projectKey, application, environment)
+	ret0, _ := ret[0].([]sdk.EnvironmentDeployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectDeploymentList indicates an expected call of ProjectDeploymentList
+func (mr *MockInterfaceMockRecorder) ProjectDeploymentList(projectKey, application, environment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDeploymentList", reflect.TypeOf((*MockInterface)(nil).ProjectDeploymentList), projectKey, application, environment)
+}
+
+// QueueWorkflowNodeJobRun mocks base method
+func (m *MockInterface) QueueWorkflowNodeJobRun(status ...string) ([]sdk.WorkflowNodeJobRun, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range status {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueueWorkflowNodeJobRun", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowNodeJobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueWorkflowNodeJobRun indicates an expected call of QueueWorkflowNodeJobRun
+func (mr *MockInterfaceMockRecorder) QueueWorkflowNodeJobRun(status ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueWorkflowNodeJobRun", reflect.TypeOf((*MockInterface)(nil).QueueWorkflowNodeJobRun), status...)
+}
+
+// QueueCountWorkflowNodeJobRun mocks base method
+func (m *MockInterface) QueueCountWorkflowNodeJobRun(since, until *time.Time, modelType string, ratioService *int) (sdk.WorkflowNodeJobRunCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PipelineDelete", projectKey, name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "QueueCountWorkflowNodeJobRun", since, until, modelType, ratioService)
+	ret0, _ := ret[0].(sdk.WorkflowNodeJobRunCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// PipelineDelete indicates an expected call of PipelineDelete
-func (mr *MockInterfaceMockRecorder) PipelineDelete(projectKey, name interface{}) *gomock.Call {
+// QueueCountWorkflowNodeJobRun indicates an expected call of QueueCountWorkflowNodeJobRun
+func (mr *MockInterfaceMockRecorder) QueueCountWorkflowNodeJobRun(since, until, modelType, ratioService interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineDelete", reflect.TypeOf((*MockInterface)(nil).PipelineDelete), projectKey, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueCountWorkflowNodeJobRun", reflect.TypeOf((*MockInterface)(nil).QueueCountWorkflowNodeJobRun), since, until, modelType, ratioService)
 }
 
-// PipelineCreate mocks base method
-func (m *MockInterface) PipelineCreate(projectKey string, pip *sdk.Pipeline) error {
+// QueuePolling mocks base method
+func (m *MockInterface) QueuePolling(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, delay time.Duration, modelType string, ratioService *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PipelineCreate", projectKey, pip)
+	ret := m.ctrl.Call(m, "QueuePolling", ctx, jobs, errs, delay, modelType, ratioService)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PipelineCreate indicates an expected call of PipelineCreate
-func (mr *MockInterfaceMockRecorder) PipelineCreate(projectKey, pip interface{}) *gomock.Call {
+// QueuePolling indicates an expected call of QueuePolling
+func (mr *MockInterfaceMockRecorder) QueuePolling(ctx, jobs, errs, delay, modelType, ratioService interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineCreate", reflect.TypeOf((*MockInterface)(nil).PipelineCreate), projectKey, pip)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockInterface)(nil).QueuePolling), ctx, jobs, errs, delay, modelType, ratioService)
 }
 
-// PipelineList mocks base method
-func (m *MockInterface) PipelineList(projectKey string) ([]sdk.Pipeline, error) {
+// QueueTakeJob mocks base method
+func (m *MockInterface) QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PipelineList", projectKey)
-	ret0, _ := ret[0].([]sdk.Pipeline)
+	ret := m.ctrl.Call(m, "QueueTakeJob", ctx, job)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRunData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// PipelineList indicates an expected call of PipelineList
-func (mr *MockInterfaceMockRecorder) PipelineList(projectKey interface{}) *gomock.Call {
+// QueueTakeJob indicates an expected call of QueueTakeJob
+func (mr *MockInterfaceMockRecorder) QueueTakeJob(ctx, job interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PipelineList", reflect.TypeOf((*MockInterface)(nil).PipelineList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueTakeJob", reflect.TypeOf((*MockInterface)(nil).QueueTakeJob), ctx, job)
 }
 
-// IntegrationModelList mocks base method
-func (m *MockInterface) IntegrationModelList() ([]sdk.IntegrationModel, error) {
+// QueueJobBook mocks base method
+func (m *MockInterface) QueueJobBook(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IntegrationModelList")
-	ret0, _ := ret[0].([]sdk.IntegrationModel)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "QueueJobBook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// IntegrationModelList indicates an expected call of IntegrationModelList
-func (mr *MockInterfaceMockRecorder) IntegrationModelList() *gomock.Call {
+// QueueJobBook indicates an expected call of QueueJobBook
+func (mr *MockInterfaceMockRecorder) QueueJobBook(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelList", reflect.TypeOf((*MockInterface)(nil).IntegrationModelList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobBook", reflect.TypeOf((*MockInterface)(nil).QueueJobBook), ctx, id)
 }
 
-// IntegrationModelGet mocks base method
-func (m *MockInterface) IntegrationModelGet(name string) (sdk.IntegrationModel, error) {
+// QueueJobRelease mocks base method
+func (m *MockInterface) QueueJobRelease(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IntegrationModelGet", name)
-	ret0, _ := ret[0].(sdk.IntegrationModel)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "QueueJobRelease", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// IntegrationModelGet indicates an expected call of IntegrationModelGet
-func (mr *MockInterfaceMockRecorder) IntegrationModelGet(name interface{}) *gomock.Call {
+// QueueJobRelease indicates an expected call of QueueJobRelease
+func (mr *MockInterfaceMockRecorder) QueueJobRelease(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelGet", reflect.TypeOf((*MockInterface)(nil).IntegrationModelGet), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRelease", reflect.TypeOf((*MockInterface)(nil).QueueJobRelease), ctx, id)
 }
 
-// IntegrationModelAdd mocks base method
-func (m_2 *MockInterface) IntegrationModelAdd(m *sdk.IntegrationModel) error {
-	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "IntegrationModelAdd", m)
-	ret0, _ := ret[0].(error)
-	return ret0
+// QueueJobInfo mocks base method
+func (m *MockInterface) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobInfo", ctx, id)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// IntegrationModelAdd indicates an expected call of IntegrationModelAdd
-func (mr *MockInterfaceMockRecorder) IntegrationModelAdd(m interface{}) *gomock.Call {
+// QueueJobInfo indicates an expected call of QueueJobInfo
+func (mr *MockInterfaceMockRecorder) QueueJobInfo(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelAdd", reflect.TypeOf((*MockInterface)(nil).IntegrationModelAdd), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobInfo", reflect.TypeOf((*MockInterface)(nil).QueueJobInfo), ctx, id)
 }
 
-// IntegrationModelUpdate mocks base method
-func (m_2 *MockInterface) IntegrationModelUpdate(m *sdk.IntegrationModel) error {
-	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "IntegrationModelUpdate", m)
+// QueueJobSendSpawnInfo mocks base method
+func (m *MockInterface) QueueJobSendSpawnInfo(ctx context.Context, id int64, in []sdk.SpawnInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobSendSpawnInfo", ctx, id, in)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IntegrationModelUpdate indicates an expected call of IntegrationModelUpdate
-func (mr *MockInterfaceMockRecorder) IntegrationModelUpdate(m interface{}) *gomock.Call {
+// QueueJobSendSpawnInfo indicates an expected call of QueueJobSendSpawnInfo
+func (mr *MockInterfaceMockRecorder) QueueJobSendSpawnInfo(ctx, id, in interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelUpdate", reflect.TypeOf((*MockInterface)(nil).IntegrationModelUpdate), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobSendSpawnInfo", reflect.TypeOf((*MockInterface)(nil).QueueJobSendSpawnInfo), ctx, id, in)
 }
 
-// IntegrationModelDelete mocks base method
-func (m *MockInterface) IntegrationModelDelete(name string) error {
+// QueueSendCoverage mocks base method
+func (m *MockInterface) QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IntegrationModelDelete", name)
+	ret := m.ctrl.Call(m, "QueueSendCoverage", ctx, id, report)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IntegrationModelDelete indicates an expected call of IntegrationModelDelete
-func (mr *MockInterfaceMockRecorder) IntegrationModelDelete(name interface{}) *gomock.Call {
+// QueueSendCoverage indicates an expected call of QueueSendCoverage
+func (mr *MockInterfaceMockRecorder) QueueSendCoverage(ctx, id, report interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelDelete", reflect.TypeOf((*MockInterface)(nil).IntegrationModelDelete), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockInterface)(nil).QueueSendCoverage), ctx, id, report)
 }
 
-// ProjectCreate mocks base method
-func (m *MockInterface) ProjectCreate(proj *sdk.Project) error {
+// QueueJobCoverage mocks base method
+func (m *MockInterface) QueueJobCoverage(ctx context.Context, id int64) (*sdk.WorkflowNodeRunCoverage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectCreate", proj)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "QueueJobCoverage", ctx, id)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunCoverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ProjectCreate indicates an expected call of ProjectCreate
-func (mr *MockInterfaceMockRecorder) ProjectCreate(proj interface{}) *gomock.Call {
+// QueueJobCoverage indicates an expected call of QueueJobCoverage
+func (mr *MockInterfaceMockRecorder) QueueJobCoverage(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCreate", reflect.TypeOf((*MockInterface)(nil).ProjectCreate), proj)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobCoverage", reflect.TypeOf((*MockInterface)(nil).QueueJobCoverage), ctx, id)
 }
 
-// ProjectDelete mocks base method
-func (m *MockInterface) ProjectDelete(projectKey string) error {
+// QueueSendUnitTests mocks base method
+func (m *MockInterface) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectDelete", projectKey)
+	ret := m.ctrl.Call(m, "QueueSendUnitTests", ctx, id, report)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectDelete indicates an expected call of ProjectDelete
-func (mr *MockInterfaceMockRecorder) ProjectDelete(projectKey interface{}) *gomock.Call {
+// QueueSendUnitTests indicates an expected call of QueueSendUnitTests
+func (mr *MockInterfaceMockRecorder) QueueSendUnitTests(ctx, id, report interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDelete", reflect.TypeOf((*MockInterface)(nil).ProjectDelete), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendUnitTests", reflect.TypeOf((*MockInterface)(nil).QueueSendUnitTests), ctx, id, report)
 }
 
-// ProjectGroupAdd mocks base method
-func (m *MockInterface) ProjectGroupAdd(projectKey, groupName string, permission int, projectOnly bool) error {
+// QueueSendLogs mocks base method
+func (m *MockInterface) QueueSendLogs(ctx context.Context, id int64, log sdk.Log) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectGroupAdd", projectKey, groupName, permission, projectOnly)
+	ret := m.ctrl.Call(m, "QueueSendLogs", ctx, id, log)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectGroupAdd indicates an expected call of ProjectGroupAdd
-func (mr *MockInterfaceMockRecorder) ProjectGroupAdd(projectKey, groupName, permission, projectOnly interface{}) *gomock.Call {
+// QueueSendLogs indicates an expected call of QueueSendLogs
+func (mr *MockInterfaceMockRecorder) QueueSendLogs(ctx, id, log interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGroupAdd", reflect.TypeOf((*MockInterface)(nil).ProjectGroupAdd), projectKey, groupName, permission, projectOnly)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendLogs", reflect.TypeOf((*MockInterface)(nil).QueueSendLogs), ctx, id, log)
 }
 
-// ProjectGroupDelete mocks base method
-func (m *MockInterface) ProjectGroupDelete(projectKey, groupName string) error {
+// QueueSendVulnerability mocks base method
+func (m *MockInterface) QueueSendVulnerability(ctx context.Context, id int64, report sdk.VulnerabilityWorkerReport) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectGroupDelete", projectKey, groupName)
+	ret := m.ctrl.Call(m, "QueueSendVulnerability", ctx, id, report)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectGroupDelete indicates an expected call of ProjectGroupDelete
-func (mr *MockInterfaceMockRecorder) ProjectGroupDelete(projectKey, groupName interface{}) *gomock.Call {
+// QueueSendVulnerability indicates an expected call of QueueSendVulnerability
+func (mr *MockInterfaceMockRecorder) QueueSendVulnerability(ctx, id, report interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGroupDelete", reflect.TypeOf((*MockInterface)(nil).ProjectGroupDelete), projectKey, groupName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendVulnerability", reflect.TypeOf((*MockInterface)(nil).QueueSendVulnerability), ctx, id, report)
 }
 
-// ProjectGet mocks base method
-func (m *MockInterface) ProjectGet(projectKey string, opts ...cdsclient.RequestModifier) (*sdk.Project, error) {
+// QueueSendCodeComments mocks base method
+func (m *MockInterface) QueueSendCodeComments(ctx context.Context, id int64, comments []sdk.WorkflowNodeRunCodeComment) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "ProjectGet", varargs...)
-	ret0, _ := ret[0].(*sdk.Project)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "QueueSendCodeComments", ctx, id, comments)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ProjectGet indicates an expected call of ProjectGet
-func (mr *MockInterfaceMockRecorder) ProjectGet(projectKey interface{}, opts ...interface{}) *gomock.Call {
+// QueueSendCodeComments indicates an expected call of QueueSendCodeComments
+func (mr *MockInterfaceMockRecorder) QueueSendCodeComments(ctx, id, comments interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGet", reflect.TypeOf((*MockInterface)(nil).ProjectGet), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCodeComments", reflect.TypeOf((*MockInterface)(nil).QueueSendCodeComments), ctx, id, comments)
 }
 
-// ProjectUpdate mocks base method
-func (m *MockInterface) ProjectUpdate(key string, project *sdk.Project) error {
+// QueueSendStepResult mocks base method
+func (m *MockInterface) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectUpdate", key, project)
+	ret := m.ctrl.Call(m, "QueueSendStepResult", ctx, id, res)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectUpdate indicates an expected call of ProjectUpdate
-func (mr *MockInterfaceMockRecorder) ProjectUpdate(key, project interface{}) *gomock.Call {
+// QueueSendStepResult indicates an expected call of QueueSendStepResult
+func (mr *MockInterfaceMockRecorder) QueueSendStepResult(ctx, id, res interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectUpdate), key, project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendStepResult", reflect.TypeOf((*MockInterface)(nil).QueueSendStepResult), ctx, id, res)
 }
 
-// ProjectList mocks base method
-func (m *MockInterface) ProjectList(withApplications, withWorkflow bool, filters ...cdsclient.Filter) ([]sdk.Project, error) {
+// QueueSendResult mocks base method
+func (m *MockInterface) QueueSendResult(ctx context.Context, id int64, res sdk.Result) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{withApplications, withWorkflow}
-	for _, a := range filters {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "ProjectList", varargs...)
-	ret0, _ := ret[0].([]sdk.Project)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "QueueSendResult", ctx, id, res)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ProjectList indicates an expected call of ProjectList
-func (mr *MockInterfaceMockRecorder) ProjectList(withApplications, withWorkflow interface{}, filters ...interface{}) *gomock.Call {
+// QueueSendResult indicates an expected call of QueueSendResult
+func (mr *MockInterfaceMockRecorder) QueueSendResult(ctx, id, res interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{withApplications, withWorkflow}, filters...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectList", reflect.TypeOf((*MockInterface)(nil).ProjectList), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendResult", reflect.TypeOf((*MockInterface)(nil).QueueSendResult), ctx, id, res)
 }
 
-// ProjectKeysList mocks base method
-func (m *MockInterface) ProjectKeysList(projectKey string) ([]sdk.ProjectKey, error) {
+// QueueArtifactUpload mocks base method
+func (m *MockInterface) QueueArtifactUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, time.Duration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeysList", projectKey)
-	ret0, _ := ret[0].([]sdk.ProjectKey)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "QueueArtifactUpload", ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// ProjectKeysList indicates an expected call of ProjectKeysList
-func (mr *MockInterfaceMockRecorder) ProjectKeysList(projectKey interface{}) *gomock.Call {
+// QueueArtifactUpload indicates an expected call of QueueArtifactUpload
+func (mr *MockInterfaceMockRecorder) QueueArtifactUpload(ctx, projectKey, integrationName, nodeJobRunID, tag, filePath interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysList", reflect.TypeOf((*MockInterface)(nil).ProjectKeysList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactUpload", reflect.TypeOf((*MockInterface)(nil).QueueArtifactUpload), ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
 }
 
-// ProjectKeyCreate mocks base method
-func (m *MockInterface) ProjectKeyCreate(projectKey string, key *sdk.ProjectKey) error {
+// QueueStaticFilesUpload mocks base method
+func (m *MockInterface) QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeyCreate", projectKey, key)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "QueueStaticFilesUpload", ctx, projectKey, integrationName, nodeJobRunID, name, entrypoint, staticKey, tarContent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(time.Duration)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
-// ProjectKeyCreate indicates an expected call of ProjectKeyCreate
-func (mr *MockInterfaceMockRecorder) ProjectKeyCreate(projectKey, key interface{}) *gomock.Call {
+// QueueStaticFilesUpload indicates an expected call of QueueStaticFilesUpload
+func (mr *MockInterfaceMockRecorder) QueueStaticFilesUpload(ctx, projectKey, integrationName, nodeJobRunID, name, entrypoint, staticKey, tarContent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeyCreate", reflect.TypeOf((*MockInterface)(nil).ProjectKeyCreate), projectKey, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueStaticFilesUpload", reflect.TypeOf((*MockInterface)(nil).QueueStaticFilesUpload), ctx, projectKey, integrationName, nodeJobRunID, name, entrypoint, staticKey, tarContent)
 }
 
-// ProjectKeysDelete mocks base method
-func (m *MockInterface) ProjectKeysDelete(projectKey, keyProjectName string) error {
+// QueueJobTag mocks base method
+func (m *MockInterface) QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectKeysDelete", projectKey, keyProjectName)
+	ret := m.ctrl.Call(m, "QueueJobTag", ctx, jobID, tags)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectKeysDelete indicates an expected call of ProjectKeysDelete
-func (mr *MockInterfaceMockRecorder) ProjectKeysDelete(projectKey, keyProjectName interface{}) *gomock.Call {
+// QueueJobTag indicates an expected call of QueueJobTag
+func (mr *MockInterfaceMockRecorder) QueueJobTag(ctx, jobID, tags interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectKeysDelete", reflect.TypeOf((*MockInterface)(nil).ProjectKeysDelete), projectKey, keyProjectName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockInterface)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
-// ProjectVariablesList mocks base method
-func (m *MockInterface) ProjectVariablesList(key string) ([]sdk.Variable, error) {
+// QueueArtifactAttestation mocks base method
+func (m *MockInterface) QueueArtifactAttestation(ctx context.Context, jobID int64, req sdk.WorkflowArtifactAttestationRequest) ([]sdk.WorkflowArtifactAttestation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectVariablesList", key)
-	ret0, _ := ret[0].([]sdk.Variable)
+	ret := m.ctrl.Call(m, "QueueArtifactAttestation", ctx, jobID, req)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactAttestation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectVariablesList indicates an expected call of ProjectVariablesList
-func (mr *MockInterfaceMockRecorder) ProjectVariablesList(key interface{}) *gomock.Call {
+// QueueArtifactAttestation indicates an expected call of QueueArtifactAttestation
+func (mr *MockInterfaceMockRecorder) QueueArtifactAttestation(ctx, jobID, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariablesList", reflect.TypeOf((*MockInterface)(nil).ProjectVariablesList), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactAttestation", reflect.TypeOf((*MockInterface)(nil).QueueArtifactAttestation), ctx, jobID, req)
 }
 
-// ProjectVariableCreate mocks base method
-func (m *MockInterface) ProjectVariableCreate(projectKey string, variable *sdk.Variable) error {
+// QueueJobSBOM mocks base method
+func (m *MockInterface) QueueJobSBOM(ctx context.Context, jobID int64, sbom sdk.WorkflowRunSBOM) (*sdk.WorkflowRunSBOM, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectVariableCreate", projectKey, variable)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "QueueJobSBOM", ctx, jobID, sbom)
+	ret0, _ := ret[0].(*sdk.WorkflowRunSBOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ProjectVariableCreate indicates an expected call of ProjectVariableCreate
-func (mr *MockInterfaceMockRecorder) ProjectVariableCreate(projectKey, variable interface{}) *gomock.Call {
+// QueueJobSBOM indicates an expected call of QueueJobSBOM
+func (mr *MockInterfaceMockRecorder) QueueJobSBOM(ctx, jobID, sbom interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableCreate", reflect.TypeOf((*MockInterface)(nil).ProjectVariableCreate), projectKey, variable)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobSBOM", reflect.TypeOf((*MockInterface)(nil).QueueJobSBOM), ctx, jobID, sbom)
 }
 
-// ProjectVariableDelete mocks base method
-func (m *MockInterface) ProjectVariableDelete(projectKey, varName string) error {
+// QueueServiceLogs mocks base method
+func (m *MockInterface) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectVariableDelete", projectKey, varName)
+	ret := m.ctrl.Call(m, "QueueServiceLogs", ctx, logs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ProjectVariableDelete indicates an expected call of ProjectVariableDelete
-func (mr *MockInterfaceMockRecorder) ProjectVariableDelete(projectKey, varName interface{}) *gomock.Call {
+// QueueServiceLogs indicates an expected call of QueueServiceLogs
+func (mr *MockInterfaceMockRecorder) QueueServiceLogs(ctx, logs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableDelete", reflect.TypeOf((*MockInterface)(nil).ProjectVariableDelete), projectKey, varName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueServiceLogs", reflect.TypeOf((*MockInterface)(nil).QueueServiceLogs), ctx, logs)
 }
 
-// ProjectVariableGet mocks base method
-func (m *MockInterface) ProjectVariableGet(projectKey, varName string) (*sdk.Variable, error) {
+// Navbar mocks base method
+func (m *MockInterface) Navbar() ([]sdk.NavbarProjectData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectVariableGet", projectKey, varName)
-	ret0, _ := ret[0].(*sdk.Variable)
+	ret := m.ctrl.Call(m, "Navbar")
+	ret0, _ := ret[0].([]sdk.NavbarProjectData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectVariableGet indicates an expected call of ProjectVariableGet
-func (mr *MockInterfaceMockRecorder) ProjectVariableGet(projectKey, varName interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableGet", reflect.TypeOf((*MockInterface)(nil).ProjectVariableGet), projectKey, varName)
-}
-
-// ProjectVariableUpdate mocks base method
-func (m *MockInterface) ProjectVariableUpdate(projectKey string, variable *sdk.Variable) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectVariableUpdate", projectKey, variable)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// ProjectVariableUpdate indicates an expected call of ProjectVariableUpdate
-func (mr *MockInterfaceMockRecorder) ProjectVariableUpdate(projectKey, variable interface{}) *gomock.Call {
+// Navbar indicates an expected call of Navbar
+func (mr *MockInterfaceMockRecorder) Navbar() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectVariableUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectVariableUpdate), projectKey, variable)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Navbar", reflect.TypeOf((*MockInterface)(nil).Navbar))
 }
 
-// VariableEncrypt mocks base method
-func (m *MockInterface) VariableEncrypt(projectKey, varName, content string) (*sdk.Variable, error) {
+// Requirements mocks base method
+func (m *MockInterface) Requirements() ([]sdk.Requirement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VariableEncrypt", projectKey, varName, content)
-	ret0, _ := ret[0].(*sdk.Variable)
+	ret := m.ctrl.Call(m, "Requirements")
+	ret0, _ := ret[0].([]sdk.Requirement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// VariableEncrypt indicates an expected call of VariableEncrypt
-func (mr *MockInterfaceMockRecorder) VariableEncrypt(projectKey, varName, content interface{}) *gomock.Call {
+// Requirements indicates an expected call of Requirements
+func (mr *MockInterfaceMockRecorder) Requirements() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariableEncrypt", reflect.TypeOf((*MockInterface)(nil).VariableEncrypt), projectKey, varName, content)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requirements", reflect.TypeOf((*MockInterface)(nil).Requirements))
 }
 
-// ProjectGroupsImport mocks base method
-func (m *MockInterface) ProjectGroupsImport(projectKey string, content io.Reader, format string, force bool) (sdk.Project, error) {
+// RepositoriesList mocks base method
+func (m *MockInterface) RepositoriesList(projectKey, repoManager string, resync bool) ([]sdk.VCSRepo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectGroupsImport", projectKey, content, format, force)
-	ret0, _ := ret[0].(sdk.Project)
+	ret := m.ctrl.Call(m, "RepositoriesList", projectKey, repoManager, resync)
+	ret0, _ := ret[0].([]sdk.VCSRepo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectGroupsImport indicates an expected call of ProjectGroupsImport
-func (mr *MockInterfaceMockRecorder) ProjectGroupsImport(projectKey, content, format, force interface{}) *gomock.Call {
+// RepositoriesList indicates an expected call of RepositoriesList
+func (mr *MockInterfaceMockRecorder) RepositoriesList(projectKey, repoManager, resync interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGroupsImport", reflect.TypeOf((*MockInterface)(nil).ProjectGroupsImport), projectKey, content, format, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepositoriesList", reflect.TypeOf((*MockInterface)(nil).RepositoriesList), projectKey, repoManager, resync)
 }
 
-// ProjectIntegrationImport mocks base method
-func (m *MockInterface) ProjectIntegrationImport(projectKey string, content io.Reader, format string, force bool) (sdk.ProjectIntegration, error) {
+// ServiceRegister mocks base method
+func (m *MockInterface) ServiceRegister(arg0 context.Context, arg1 sdk.Service) (*sdk.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectIntegrationImport", projectKey, content, format, force)
-	ret0, _ := ret[0].(sdk.ProjectIntegration)
+	ret := m.ctrl.Call(m, "ServiceRegister", arg0, arg1)
+	ret0, _ := ret[0].(*sdk.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectIntegrationImport indicates an expected call of ProjectIntegrationImport
-func (mr *MockInterfaceMockRecorder) ProjectIntegrationImport(projectKey, content, format, force interface{}) *gomock.Call {
+// ServiceRegister indicates an expected call of ServiceRegister
+func (mr *MockInterfaceMockRecorder) ServiceRegister(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationImport", reflect.TypeOf((*MockInterface)(nil).ProjectIntegrationImport), projectKey, content, format, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceRegister", reflect.TypeOf((*MockInterface)(nil).ServiceRegister), arg0, arg1)
 }
 
-// ProjectIntegrationGet mocks base method
-func (m *MockInterface) ProjectIntegrationGet(projectKey, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error) {
+// ServiceHeartbeat mocks base method
+func (m *MockInterface) ServiceHeartbeat(arg0 sdk.MonitoringStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectIntegrationGet", projectKey, integrationName, clearPassword)
-	ret0, _ := ret[0].(sdk.ProjectIntegration)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ServiceHeartbeat", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ProjectIntegrationGet indicates an expected call of ProjectIntegrationGet
-func (mr *MockInterfaceMockRecorder) ProjectIntegrationGet(projectKey, integrationName, clearPassword interface{}) *gomock.Call {
+// ServiceHeartbeat indicates an expected call of ServiceHeartbeat
+func (mr *MockInterfaceMockRecorder) ServiceHeartbeat(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationGet", reflect.TypeOf((*MockInterface)(nil).ProjectIntegrationGet), projectKey, integrationName, clearPassword)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceHeartbeat", reflect.TypeOf((*MockInterface)(nil).ServiceHeartbeat), arg0)
 }
 
-// ProjectIntegrationList mocks base method
-func (m *MockInterface) ProjectIntegrationList(projectKey string) ([]sdk.ProjectIntegration, error) {
+// UserList mocks base method
+func (m *MockInterface) UserList() ([]sdk.AuthentifiedUser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectIntegrationList", projectKey)
-	ret0, _ := ret[0].([]sdk.ProjectIntegration)
+	ret := m.ctrl.Call(m, "UserList")
+	ret0, _ := ret[0].([]sdk.AuthentifiedUser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectIntegrationList indicates an expected call of ProjectIntegrationList
-func (mr *MockInterfaceMockRecorder) ProjectIntegrationList(projectKey interface{}) *gomock.Call {
+// UserList indicates an expected call of UserList
+func (mr *MockInterfaceMockRecorder) UserList() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationList", reflect.TypeOf((*MockInterface)(nil).ProjectIntegrationList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserList", reflect.TypeOf((*MockInterface)(nil).UserList))
 }
 
-// ProjectIntegrationDelete mocks base method
-func (m *MockInterface) ProjectIntegrationDelete(projectKey, integrationName string) error {
+// UserGet mocks base method
+func (m *MockInterface) UserGet(username string) (*sdk.AuthentifiedUser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectIntegrationDelete", projectKey, integrationName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UserGet", username)
+	ret0, _ := ret[0].(*sdk.AuthentifiedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ProjectIntegrationDelete indicates an expected call of ProjectIntegrationDelete
-func (mr *MockInterfaceMockRecorder) ProjectIntegrationDelete(projectKey, integrationName interface{}) *gomock.Call {
+// UserGet indicates an expected call of UserGet
+func (mr *MockInterfaceMockRecorder) UserGet(username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIntegrationDelete", reflect.TypeOf((*MockInterface)(nil).ProjectIntegrationDelete), projectKey, integrationName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGet", reflect.TypeOf((*MockInterface)(nil).UserGet), username)
 }
 
-// ProjectRepositoryManagerList mocks base method
-func (m *MockInterface) ProjectRepositoryManagerList(projectKey string) ([]sdk.ProjectVCSServer, error) {
+// UserGetMe mocks base method
+func (m *MockInterface) UserGetMe() (*sdk.AuthentifiedUser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectRepositoryManagerList", projectKey)
-	ret0, _ := ret[0].([]sdk.ProjectVCSServer)
+	ret := m.ctrl.Call(m, "UserGetMe")
+	ret0, _ := ret[0].(*sdk.AuthentifiedUser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectRepositoryManagerList indicates an expected call of ProjectRepositoryManagerList
-func (mr *MockInterfaceMockRecorder) ProjectRepositoryManagerList(projectKey interface{}) *gomock.Call {
+// UserGetMe indicates an expected call of UserGetMe
+func (mr *MockInterfaceMockRecorder) UserGetMe() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerList", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryManagerList), projectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetMe", reflect.TypeOf((*MockInterface)(nil).UserGetMe))
 }
 
-// ProjectRepositoryManagerDelete mocks base method
-func (m *MockInterface) ProjectRepositoryManagerDelete(projectKey, repoManagerName string, force bool) error {
+// UserGetGroups mocks base method
+func (m *MockInterface) UserGetGroups(username string) (map[string][]sdk.Group, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectRepositoryManagerDelete", projectKey, repoManagerName, force)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UserGetGroups", username)
+	ret0, _ := ret[0].(map[string][]sdk.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ProjectRepositoryManagerDelete indicates an expected call of ProjectRepositoryManagerDelete
-func (mr *MockInterfaceMockRecorder) ProjectRepositoryManagerDelete(projectKey, repoManagerName, force interface{}) *gomock.Call {
+// UserGetGroups indicates an expected call of UserGetGroups
+func (mr *MockInterfaceMockRecorder) UserGetGroups(username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetGroups", reflect.TypeOf((*MockInterface)(nil).UserGetGroups), username)
 }
 
-// QueueWorkflowNodeJobRun mocks base method
-func (m *MockInterface) QueueWorkflowNodeJobRun(status ...string) ([]sdk.WorkflowNodeJobRun, error) {
+// UpdateFavorite mocks base method
+func (m *MockInterface) UpdateFavorite(params sdk.FavoriteParams) (interface{}, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range status {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "QueueWorkflowNodeJobRun", varargs...)
-	ret0, _ := ret[0].([]sdk.WorkflowNodeJobRun)
+	ret := m.ctrl.Call(m, "UpdateFavorite", params)
+	ret0, _ := ret[0].(interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueueWorkflowNodeJobRun indicates an expected call of QueueWorkflowNodeJobRun
-func (mr *MockInterfaceMockRecorder) QueueWorkflowNodeJobRun(status ...interface{}) *gomock.Call {
+// UpdateFavorite indicates an expected call of UpdateFavorite
+func (mr *MockInterfaceMockRecorder) UpdateFavorite(params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueWorkflowNodeJobRun", reflect.TypeOf((*MockInterface)(nil).QueueWorkflowNodeJobRun), status...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavorite", reflect.TypeOf((*MockInterface)(nil).UpdateFavorite), params)
 }
 
-// QueueCountWorkflowNodeJobRun mocks base method
-func (m *MockInterface) QueueCountWorkflowNodeJobRun(since, until *time.Time, modelType string, ratioService *int) (sdk.WorkflowNodeJobRunCount, error) {
+// UserGetSchema mocks base method
+func (m *MockInterface) UserGetSchema() (sdk.SchemaResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueCountWorkflowNodeJobRun", since, until, modelType, ratioService)
-	ret0, _ := ret[0].(sdk.WorkflowNodeJobRunCount)
+	ret := m.ctrl.Call(m, "UserGetSchema")
+	ret0, _ := ret[0].(sdk.SchemaResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueueCountWorkflowNodeJobRun indicates an expected call of QueueCountWorkflowNodeJobRun
-func (mr *MockInterfaceMockRecorder) QueueCountWorkflowNodeJobRun(since, until, modelType, ratioService interface{}) *gomock.Call {
+// UserGetSchema indicates an expected call of UserGetSchema
+func (mr *MockInterfaceMockRecorder) UserGetSchema() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueCountWorkflowNodeJobRun", reflect.TypeOf((*MockInterface)(nil).QueueCountWorkflowNodeJobRun), since, until, modelType, ratioService)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetSchema", reflect.TypeOf((*MockInterface)(nil).UserGetSchema))
 }
 
-// QueuePolling mocks base method
-func (m *MockInterface) QueuePolling(ctx context.Context, jobs chan<- sdk.WorkflowNodeJobRun, errs chan<- error, delay time.Duration, modelType string, ratioService *int) error {
+// WorkerModelBook mocks base method
+func (m *MockInterface) WorkerModelBook(groupName, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueuePolling", ctx, jobs, errs, delay, modelType, ratioService)
+	ret := m.ctrl.Call(m, "WorkerModelBook", groupName, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// QueuePolling indicates an expected call of QueuePolling
-func (mr *MockInterfaceMockRecorder) QueuePolling(ctx, jobs, errs, delay, modelType, ratioService interface{}) *gomock.Call {
+// WorkerModelBook indicates an expected call of WorkerModelBook
+func (mr *MockInterfaceMockRecorder) WorkerModelBook(groupName, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueuePolling", reflect.TypeOf((*MockInterface)(nil).QueuePolling), ctx, jobs, errs, delay, modelType, ratioService)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelBook", reflect.TypeOf((*MockInterface)(nil).WorkerModelBook), groupName, name)
 }
 
-// QueueTakeJob mocks base method
-func (m *MockInterface) QueueTakeJob(ctx context.Context, job sdk.WorkflowNodeJobRun) (*sdk.WorkflowNodeJobRunData, error) {
+// WorkerList mocks base method
+func (m *MockInterface) WorkerList(ctx context.Context) ([]sdk.Worker, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueTakeJob", ctx, job)
-	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRunData)
+	ret := m.ctrl.Call(m, "WorkerList", ctx)
+	ret0, _ := ret[0].([]sdk.Worker)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueueTakeJob indicates an expected call of QueueTakeJob
-func (mr *MockInterfaceMockRecorder) QueueTakeJob(ctx, job interface{}) *gomock.Call {
+// WorkerList indicates an expected call of WorkerList
+func (mr *MockInterfaceMockRecorder) WorkerList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerList", reflect.TypeOf((*MockInterface)(nil).WorkerList), ctx)
+}
+
+// WorkerRefresh mocks base method
+func (m *MockInterface) WorkerRefresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerRefresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkerRefresh indicates an expected call of WorkerRefresh
+func (mr *MockInterfaceMockRecorder) WorkerRefresh(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueTakeJob", reflect.TypeOf((*MockInterface)(nil).QueueTakeJob), ctx, job)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRefresh", reflect.TypeOf((*MockInterface)(nil).WorkerRefresh), ctx)
 }
 
-// QueueJobBook mocks base method
-func (m *MockInterface) QueueJobBook(ctx context.Context, id int64) error {
+// WorkerUnregister mocks base method
+func (m *MockInterface) WorkerUnregister(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueJobBook", ctx, id)
+	ret := m.ctrl.Call(m, "WorkerUnregister", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// QueueJobBook indicates an expected call of QueueJobBook
-func (mr *MockInterfaceMockRecorder) QueueJobBook(ctx, id interface{}) *gomock.Call {
+// WorkerUnregister indicates an expected call of WorkerUnregister
+func (mr *MockInterfaceMockRecorder) WorkerUnregister(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobBook", reflect.TypeOf((*MockInterface)(nil).QueueJobBook), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerUnregister", reflect.TypeOf((*MockInterface)(nil).WorkerUnregister), ctx)
 }
 
-// QueueJobRelease mocks base method
-func (m *MockInterface) QueueJobRelease(ctx context.Context, id int64) error {
+// WorkerDisable mocks base method
+func (m *MockInterface) WorkerDisable(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueJobRelease", ctx, id)
+	ret := m.ctrl.Call(m, "WorkerDisable", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// QueueJobRelease indicates an expected call of QueueJobRelease
-func (mr *MockInterfaceMockRecorder) QueueJobRelease(ctx, id interface{}) *gomock.Call {
+// WorkerDisable indicates an expected call of WorkerDisable
+func (mr *MockInterfaceMockRecorder) WorkerDisable(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobRelease", reflect.TypeOf((*MockInterface)(nil).QueueJobRelease), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerDisable", reflect.TypeOf((*MockInterface)(nil).WorkerDisable), ctx, id)
 }
 
-// QueueJobInfo mocks base method
-func (m *MockInterface) QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error) {
+// WorkerModelAdd mocks base method
+func (m *MockInterface) WorkerModelAdd(name, modelType, patternName string, dockerModel *sdk.ModelDocker, vmModel *sdk.ModelVirtualMachine, groupID int64) (sdk.Model, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueJobInfo", ctx, id)
-	ret0, _ := ret[0].(*sdk.WorkflowNodeJobRun)
+	ret := m.ctrl.Call(m, "WorkerModelAdd", name, modelType, patternName, dockerModel, vmModel, groupID)
+	ret0, _ := ret[0].(sdk.Model)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// QueueJobInfo indicates an expected call of QueueJobInfo
-func (mr *MockInterfaceMockRecorder) QueueJobInfo(ctx, id interface{}) *gomock.Call {
+// WorkerModelAdd indicates an expected call of WorkerModelAdd
+func (mr *MockInterfaceMockRecorder) WorkerModelAdd(name, modelType, patternName, dockerModel, vmModel, groupID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobInfo", reflect.TypeOf((*MockInterface)(nil).QueueJobInfo), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelAdd", reflect.TypeOf((*MockInterface)(nil).WorkerModelAdd), name, modelType, patternName, dockerModel, vmModel, groupID)
 }
 
-// QueueJobSendSpawnInfo mocks base method
-func (m *MockInterface) QueueJobSendSpawnInfo(ctx context.Context, id int64, in []sdk.SpawnInfo) error {
+// WorkerModel mocks base method
+func (m *MockInterface) WorkerModel(groupName, name string) (sdk.Model, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueJobSendSpawnInfo", ctx, id, in)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkerModel", groupName, name)
+	ret0, _ := ret[0].(sdk.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueJobSendSpawnInfo indicates an expected call of QueueJobSendSpawnInfo
-func (mr *MockInterfaceMockRecorder) QueueJobSendSpawnInfo(ctx, id, in interface{}) *gomock.Call {
+// WorkerModel indicates an expected call of WorkerModel
+func (mr *MockInterfaceMockRecorder) WorkerModel(groupName, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobSendSpawnInfo", reflect.TypeOf((*MockInterface)(nil).QueueJobSendSpawnInfo), ctx, id, in)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModel", reflect.TypeOf((*MockInterface)(nil).WorkerModel), groupName, name)
 }
 
-// QueueSendCoverage mocks base method
-func (m *MockInterface) QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error {
+// WorkerModelDelete mocks base method
+func (m *MockInterface) WorkerModelDelete(groupName, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendCoverage", ctx, id, report)
+	ret := m.ctrl.Call(m, "WorkerModelDelete", groupName, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// QueueSendCoverage indicates an expected call of QueueSendCoverage
-func (mr *MockInterfaceMockRecorder) QueueSendCoverage(ctx, id, report interface{}) *gomock.Call {
+// WorkerModelDelete indicates an expected call of WorkerModelDelete
+func (mr *MockInterfaceMockRecorder) WorkerModelDelete(groupName, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockInterface)(nil).QueueSendCoverage), ctx, id, report)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelDelete", reflect.TypeOf((*MockInterface)(nil).WorkerModelDelete), groupName, name)
 }
 
-// QueueSendUnitTests mocks base method
-func (m *MockInterface) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
+// WorkerModelSpawnError mocks base method
+func (m *MockInterface) WorkerModelSpawnError(groupName, name string, info sdk.SpawnErrorForm) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendUnitTests", ctx, id, report)
+	ret := m.ctrl.Call(m, "WorkerModelSpawnError", groupName, name, info)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// QueueSendUnitTests indicates an expected call of QueueSendUnitTests
-func (mr *MockInterfaceMockRecorder) QueueSendUnitTests(ctx, id, report interface{}) *gomock.Call {
+// WorkerModelSpawnError indicates an expected call of WorkerModelSpawnError
+func (mr *MockInterfaceMockRecorder) WorkerModelSpawnError(groupName, name, info interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendUnitTests", reflect.TypeOf((*MockInterface)(nil).QueueSendUnitTests), ctx, id, report)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelSpawnError", reflect.TypeOf((*MockInterface)(nil).WorkerModelSpawnError), groupName, name, info)
 }
 
-// QueueSendLogs mocks base method
-func (m *MockInterface) QueueSendLogs(ctx context.Context, id int64, log sdk.Log) error {
+// WorkerModels mocks base method
+func (m *MockInterface) WorkerModels(arg0 *cdsclient.WorkerModelFilter) ([]sdk.Model, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendLogs", ctx, id, log)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkerModels", arg0)
+	ret0, _ := ret[0].([]sdk.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueSendLogs indicates an expected call of QueueSendLogs
-func (mr *MockInterfaceMockRecorder) QueueSendLogs(ctx, id, log interface{}) *gomock.Call {
+// WorkerModels indicates an expected call of WorkerModels
+func (mr *MockInterfaceMockRecorder) WorkerModels(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendLogs", reflect.TypeOf((*MockInterface)(nil).QueueSendLogs), ctx, id, log)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModels", reflect.TypeOf((*MockInterface)(nil).WorkerModels), arg0)
 }
 
-// QueueSendVulnerability mocks base method
-func (m *MockInterface) QueueSendVulnerability(ctx context.Context, id int64, report sdk.VulnerabilityWorkerReport) error {
+// WorkerModelsEnabled mocks base method
+func (m *MockInterface) WorkerModelsEnabled() ([]sdk.Model, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendVulnerability", ctx, id, report)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkerModelsEnabled")
+	ret0, _ := ret[0].([]sdk.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueSendVulnerability indicates an expected call of QueueSendVulnerability
-func (mr *MockInterfaceMockRecorder) QueueSendVulnerability(ctx, id, report interface{}) *gomock.Call {
+// WorkerModelsEnabled indicates an expected call of WorkerModelsEnabled
+func (mr *MockInterfaceMockRecorder) WorkerModelsEnabled() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendVulnerability", reflect.TypeOf((*MockInterface)(nil).QueueSendVulnerability), ctx, id, report)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelsEnabled", reflect.TypeOf((*MockInterface)(nil).WorkerModelsEnabled))
 }
 
-// QueueSendStepResult mocks base method
-func (m *MockInterface) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
+// WorkerModelTrustPolicy mocks base method
+func (m *MockInterface) WorkerModelTrustPolicy() (sdk.WorkerModelTrustPolicy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendStepResult", ctx, id, res)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkerModelTrustPolicy")
+	ret0, _ := ret[0].(sdk.WorkerModelTrustPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueSendStepResult indicates an expected call of QueueSendStepResult
-func (mr *MockInterfaceMockRecorder) QueueSendStepResult(ctx, id, res interface{}) *gomock.Call {
+// WorkerModelTrustPolicy indicates an expected call of WorkerModelTrustPolicy
+func (mr *MockInterfaceMockRecorder) WorkerModelTrustPolicy() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendStepResult", reflect.TypeOf((*MockInterface)(nil).QueueSendStepResult), ctx, id, res)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelTrustPolicy", reflect.TypeOf((*MockInterface)(nil).WorkerModelTrustPolicy))
 }
 
-// QueueSendResult mocks base method
-func (m *MockInterface) QueueSendResult(ctx context.Context, id int64, res sdk.Result) error {
+// WorkerModelTrustPolicyUpdate mocks base method
+func (m *MockInterface) WorkerModelTrustPolicyUpdate(p sdk.WorkerModelTrustPolicy) (sdk.WorkerModelTrustPolicy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueSendResult", ctx, id, res)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkerModelTrustPolicyUpdate", p)
+	ret0, _ := ret[0].(sdk.WorkerModelTrustPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueSendResult indicates an expected call of QueueSendResult
-func (mr *MockInterfaceMockRecorder) QueueSendResult(ctx, id, res interface{}) *gomock.Call {
+// WorkerModelTrustPolicyUpdate indicates an expected call of WorkerModelTrustPolicyUpdate
+func (mr *MockInterfaceMockRecorder) WorkerModelTrustPolicyUpdate(p interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendResult", reflect.TypeOf((*MockInterface)(nil).QueueSendResult), ctx, id, res)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelTrustPolicyUpdate", reflect.TypeOf((*MockInterface)(nil).WorkerModelTrustPolicyUpdate), p)
 }
 
-// QueueArtifactUpload mocks base method
-func (m *MockInterface) QueueArtifactUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, time.Duration, error) {
+// WorkerRegister mocks base method
+func (m *MockInterface) WorkerRegister(ctx context.Context, authToken string, form sdk.WorkerRegistrationForm) (*sdk.Worker, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueArtifactUpload", ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(time.Duration)
+	ret := m.ctrl.Call(m, "WorkerRegister", ctx, authToken, form)
+	ret0, _ := ret[0].(*sdk.Worker)
+	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// QueueArtifactUpload indicates an expected call of QueueArtifactUpload
-func (mr *MockInterfaceMockRecorder) QueueArtifactUpload(ctx, projectKey, integrationName, nodeJobRunID, tag, filePath interface{}) *gomock.Call {
+// WorkerRegister indicates an expected call of WorkerRegister
+func (mr *MockInterfaceMockRecorder) WorkerRegister(ctx, authToken, form interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactUpload", reflect.TypeOf((*MockInterface)(nil).QueueArtifactUpload), ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRegister", reflect.TypeOf((*MockInterface)(nil).WorkerRegister), ctx, authToken, form)
 }
 
-// QueueStaticFilesUpload mocks base method
-func (m *MockInterface) QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error) {
+// WorkerRotateSession mocks base method
+func (m *MockInterface) WorkerRotateSession(ctx context.Context) (*sdk.AuthSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueStaticFilesUpload", ctx, projectKey, integrationName, nodeJobRunID, name, entrypoint, staticKey, tarContent)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(bool)
-	ret2, _ := ret[2].(time.Duration)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret := m.ctrl.Call(m, "WorkerRotateSession", ctx)
+	ret0, _ := ret[0].(*sdk.AuthSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueStaticFilesUpload indicates an expected call of QueueStaticFilesUpload
-func (mr *MockInterfaceMockRecorder) QueueStaticFilesUpload(ctx, projectKey, integrationName, nodeJobRunID, name, entrypoint, staticKey, tarContent interface{}) *gomock.Call {
+// WorkerRotateSession indicates an expected call of WorkerRotateSession
+func (mr *MockInterfaceMockRecorder) WorkerRotateSession(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueStaticFilesUpload", reflect.TypeOf((*MockInterface)(nil).QueueStaticFilesUpload), ctx, projectKey, integrationName, nodeJobRunID, name, entrypoint, staticKey, tarContent)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRotateSession", reflect.TypeOf((*MockInterface)(nil).WorkerRotateSession), ctx)
 }
 
-// QueueJobTag mocks base method
-func (m *MockInterface) QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error {
+// WorkerSetStatus mocks base method
+func (m *MockInterface) WorkerSetStatus(ctx context.Context, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueJobTag", ctx, jobID, tags)
+	ret := m.ctrl.Call(m, "WorkerSetStatus", ctx, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// QueueJobTag indicates an expected call of QueueJobTag
-func (mr *MockInterfaceMockRecorder) QueueJobTag(ctx, jobID, tags interface{}) *gomock.Call {
+// WorkerSetStatus indicates an expected call of WorkerSetStatus
+func (mr *MockInterfaceMockRecorder) WorkerSetStatus(ctx, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockInterface)(nil).QueueJobTag), ctx, jobID, tags)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerSetStatus", reflect.TypeOf((*MockInterface)(nil).WorkerSetStatus), ctx, status)
 }
 
-// QueueServiceLogs mocks base method
-func (m *MockInterface) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
+// WorkflowList mocks base method
+func (m *MockInterface) WorkflowList(projectKey string) ([]sdk.Workflow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueueServiceLogs", ctx, logs)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowList", projectKey)
+	ret0, _ := ret[0].([]sdk.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// QueueServiceLogs indicates an expected call of QueueServiceLogs
-func (mr *MockInterfaceMockRecorder) QueueServiceLogs(ctx, logs interface{}) *gomock.Call {
+// WorkflowList indicates an expected call of WorkflowList
+func (mr *MockInterfaceMockRecorder) WorkflowList(projectKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueServiceLogs", reflect.TypeOf((*MockInterface)(nil).QueueServiceLogs), ctx, logs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowList", reflect.TypeOf((*MockInterface)(nil).WorkflowList), projectKey)
 }
 
-// Navbar mocks base method
-func (m *MockInterface) Navbar() ([]sdk.NavbarProjectData, error) {
+// WorkflowGet mocks base method
+func (m *MockInterface) WorkflowGet(projectKey, name string, opts ...cdsclient.RequestModifier) (*sdk.Workflow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Navbar")
-	ret0, _ := ret[0].([]sdk.NavbarProjectData)
+	varargs := []interface{}{projectKey, name}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowGet", varargs...)
+	ret0, _ := ret[0].(*sdk.Workflow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Navbar indicates an expected call of Navbar
-func (mr *MockInterfaceMockRecorder) Navbar() *gomock.Call {
+// WorkflowGet indicates an expected call of WorkflowGet
+func (mr *MockInterfaceMockRecorder) WorkflowGet(projectKey, name interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Navbar", reflect.TypeOf((*MockInterface)(nil).Navbar))
+	varargs := append([]interface{}{projectKey, name}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGet", reflect.TypeOf((*MockInterface)(nil).WorkflowGet), varargs...)
 }
 
-// Requirements mocks base method
-func (m *MockInterface) Requirements() ([]sdk.Requirement, error) {
+// WorkflowUpdate mocks base method
+func (m *MockInterface) WorkflowUpdate(projectKey, name string, wf *sdk.Workflow) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Requirements")
-	ret0, _ := ret[0].([]sdk.Requirement)
+	ret := m.ctrl.Call(m, "WorkflowUpdate", projectKey, name, wf)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowUpdate indicates an expected call of WorkflowUpdate
+func (mr *MockInterfaceMockRecorder) WorkflowUpdate(projectKey, name, wf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowUpdate", reflect.TypeOf((*MockInterface)(nil).WorkflowUpdate), projectKey, name, wf)
+}
+
+// WorkflowGraphPatch mocks base method
+func (m *MockInterface) WorkflowGraphPatch(projectKey, name string, patch sdk.WorkflowGraphPatch, dryRun bool) (*sdk.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowGraphPatch", projectKey, name, patch, dryRun)
+	ret0, _ := ret[0].(*sdk.Workflow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Requirements indicates an expected call of Requirements
-func (mr *MockInterfaceMockRecorder) Requirements() *gomock.Call {
+// WorkflowGraphPatch indicates an expected call of WorkflowGraphPatch
+func (mr *MockInterfaceMockRecorder) WorkflowGraphPatch(projectKey, name, patch, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGraphPatch", reflect.TypeOf((*MockInterface)(nil).WorkflowGraphPatch), projectKey, name, patch, dryRun)
+}
+
+// WorkflowDelete mocks base method
+func (m *MockInterface) WorkflowDelete(projectKey, workflowName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowDelete", projectKey, workflowName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowDelete indicates an expected call of WorkflowDelete
+func (mr *MockInterfaceMockRecorder) WorkflowDelete(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowDelete), projectKey, workflowName)
+}
+
+// WorkflowGroupAdd mocks base method
+func (m *MockInterface) WorkflowGroupAdd(projectKey, name, groupName string, permission int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowGroupAdd", projectKey, name, groupName, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowGroupAdd indicates an expected call of WorkflowGroupAdd
+func (mr *MockInterfaceMockRecorder) WorkflowGroupAdd(projectKey, name, groupName, permission interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requirements", reflect.TypeOf((*MockInterface)(nil).Requirements))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupAdd), projectKey, name, groupName, permission)
 }
 
-// RepositoriesList mocks base method
-func (m *MockInterface) RepositoriesList(projectKey, repoManager string, resync bool) ([]sdk.VCSRepo, error) {
+// WorkflowGroupDelete mocks base method
+func (m *MockInterface) WorkflowGroupDelete(projectKey, name, groupName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RepositoriesList", projectKey, repoManager, resync)
-	ret0, _ := ret[0].([]sdk.VCSRepo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WorkflowGroupDelete", projectKey, name, groupName)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// RepositoriesList indicates an expected call of RepositoriesList
-func (mr *MockInterfaceMockRecorder) RepositoriesList(projectKey, repoManager, resync interface{}) *gomock.Call {
+// WorkflowGroupDelete indicates an expected call of WorkflowGroupDelete
+func (mr *MockInterfaceMockRecorder) WorkflowGroupDelete(projectKey, name, groupName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepositoriesList", reflect.TypeOf((*MockInterface)(nil).RepositoriesList), projectKey, repoManager, resync)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupDelete), projectKey, name, groupName)
 }
 
-// ServiceRegister mocks base method
-func (m *MockInterface) ServiceRegister(arg0 context.Context, arg1 sdk.Service) (*sdk.Service, error) {
+// WorkflowRunGet mocks base method
+func (m *MockInterface) WorkflowRunGet(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServiceRegister", arg0, arg1)
-	ret0, _ := ret[0].(*sdk.Service)
+	ret := m.ctrl.Call(m, "WorkflowRunGet", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ServiceRegister indicates an expected call of ServiceRegister
-func (mr *MockInterfaceMockRecorder) ServiceRegister(arg0, arg1 interface{}) *gomock.Call {
+// WorkflowRunGet indicates an expected call of WorkflowRunGet
+func (mr *MockInterfaceMockRecorder) WorkflowRunGet(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceRegister", reflect.TypeOf((*MockInterface)(nil).ServiceRegister), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunGet", reflect.TypeOf((*MockInterface)(nil).WorkflowRunGet), projectKey, workflowName, number)
 }
 
-// ServiceHeartbeat mocks base method
-func (m *MockInterface) ServiceHeartbeat(arg0 sdk.MonitoringStatus) error {
+// WorkflowRunsDeleteByBranch mocks base method
+func (m *MockInterface) WorkflowRunsDeleteByBranch(projectKey, workflowName, branch string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ServiceHeartbeat", arg0)
+	ret := m.ctrl.Call(m, "WorkflowRunsDeleteByBranch", projectKey, workflowName, branch)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ServiceHeartbeat indicates an expected call of ServiceHeartbeat
-func (mr *MockInterfaceMockRecorder) ServiceHeartbeat(arg0 interface{}) *gomock.Call {
+// WorkflowRunsDeleteByBranch indicates an expected call of WorkflowRunsDeleteByBranch
+func (mr *MockInterfaceMockRecorder) WorkflowRunsDeleteByBranch(projectKey, workflowName, branch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceHeartbeat", reflect.TypeOf((*MockInterface)(nil).ServiceHeartbeat), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunsDeleteByBranch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunsDeleteByBranch), projectKey, workflowName, branch)
 }
 
-// UserList mocks base method
-func (m *MockInterface) UserList() ([]sdk.AuthentifiedUser, error) {
+// WorkflowRunResync mocks base method
+func (m *MockInterface) WorkflowRunResync(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserList")
-	ret0, _ := ret[0].([]sdk.AuthentifiedUser)
+	ret := m.ctrl.Call(m, "WorkflowRunResync", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserList indicates an expected call of UserList
-func (mr *MockInterfaceMockRecorder) UserList() *gomock.Call {
+// WorkflowRunResync indicates an expected call of WorkflowRunResync
+func (mr *MockInterfaceMockRecorder) WorkflowRunResync(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserList", reflect.TypeOf((*MockInterface)(nil).UserList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunResync", reflect.TypeOf((*MockInterface)(nil).WorkflowRunResync), projectKey, workflowName, number)
 }
 
-// UserGet mocks base method
-func (m *MockInterface) UserGet(username string) (*sdk.AuthentifiedUser, error) {
+// WorkflowRunSearch mocks base method
+func (m *MockInterface) WorkflowRunSearch(projectKey string, offset, limit int64, filter ...cdsclient.Filter) ([]sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserGet", username)
-	ret0, _ := ret[0].(*sdk.AuthentifiedUser)
+	varargs := []interface{}{projectKey, offset, limit}
+	for _, a := range filter {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowRunSearch", varargs...)
+	ret0, _ := ret[0].([]sdk.WorkflowRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserGet indicates an expected call of UserGet
-func (mr *MockInterfaceMockRecorder) UserGet(username interface{}) *gomock.Call {
+// WorkflowRunSearch indicates an expected call of WorkflowRunSearch
+func (mr *MockInterfaceMockRecorder) WorkflowRunSearch(projectKey, offset, limit interface{}, filter ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGet", reflect.TypeOf((*MockInterface)(nil).UserGet), username)
+	varargs := append([]interface{}{projectKey, offset, limit}, filter...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSearch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSearch), varargs...)
 }
 
-// UserGetMe mocks base method
-func (m *MockInterface) UserGetMe() (*sdk.AuthentifiedUser, error) {
+// WorkflowRunList mocks base method
+func (m *MockInterface) WorkflowRunList(projectKey, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserGetMe")
-	ret0, _ := ret[0].(*sdk.AuthentifiedUser)
+	ret := m.ctrl.Call(m, "WorkflowRunList", projectKey, workflowName, offset, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserGetMe indicates an expected call of UserGetMe
-func (mr *MockInterfaceMockRecorder) UserGetMe() *gomock.Call {
+// WorkflowRunList indicates an expected call of WorkflowRunList
+func (mr *MockInterfaceMockRecorder) WorkflowRunList(projectKey, workflowName, offset, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetMe", reflect.TypeOf((*MockInterface)(nil).UserGetMe))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunList), projectKey, workflowName, offset, limit)
 }
 
-// UserGetGroups mocks base method
-func (m *MockInterface) UserGetGroups(username string) (map[string][]sdk.Group, error) {
+// WorkflowRunsSearch mocks base method
+func (m *MockInterface) WorkflowRunsSearch(projectKey string, search sdk.WorkflowRunSearch) (*sdk.WorkflowRunSearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserGetGroups", username)
-	ret0, _ := ret[0].(map[string][]sdk.Group)
+	ret := m.ctrl.Call(m, "WorkflowRunsSearch", projectKey, search)
+	ret0, _ := ret[0].(*sdk.WorkflowRunSearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserGetGroups indicates an expected call of UserGetGroups
-func (mr *MockInterfaceMockRecorder) UserGetGroups(username interface{}) *gomock.Call {
+// WorkflowRunsSearch indicates an expected call of WorkflowRunsSearch
+func (mr *MockInterfaceMockRecorder) WorkflowRunsSearch(projectKey, search interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetGroups", reflect.TypeOf((*MockInterface)(nil).UserGetGroups), username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunsSearch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunsSearch), projectKey, search)
 }
 
-// UpdateFavorite mocks base method
-func (m *MockInterface) UpdateFavorite(params sdk.FavoriteParams) (interface{}, error) {
+// WorkflowRunArtifacts mocks base method
+func (m *MockInterface) WorkflowRunArtifacts(projectKey, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFavorite", params)
-	ret0, _ := ret[0].(interface{})
+	ret := m.ctrl.Call(m, "WorkflowRunArtifacts", projectKey, name, number)
+	ret0, _ := ret[0].([]sdk.WorkflowNodeRunArtifact)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateFavorite indicates an expected call of UpdateFavorite
-func (mr *MockInterfaceMockRecorder) UpdateFavorite(params interface{}) *gomock.Call {
+// WorkflowRunArtifacts indicates an expected call of WorkflowRunArtifacts
+func (mr *MockInterfaceMockRecorder) WorkflowRunArtifacts(projectKey, name, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavorite", reflect.TypeOf((*MockInterface)(nil).UpdateFavorite), params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockInterface)(nil).WorkflowRunArtifacts), projectKey, name, number)
 }
 
-// UserGetSchema mocks base method
-func (m *MockInterface) UserGetSchema() (sdk.SchemaResponse, error) {
+// WorkflowRunArtifactsDiff mocks base method
+func (m *MockInterface) WorkflowRunArtifactsDiff(projectKey, name string, number int64, withFiles bool) (*sdk.WorkflowRunArtifactsDiff, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserGetSchema")
-	ret0, _ := ret[0].(sdk.SchemaResponse)
+	ret := m.ctrl.Call(m, "WorkflowRunArtifactsDiff", projectKey, name, number, withFiles)
+	ret0, _ := ret[0].(*sdk.WorkflowRunArtifactsDiff)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserGetSchema indicates an expected call of UserGetSchema
-func (mr *MockInterfaceMockRecorder) UserGetSchema() *gomock.Call {
+// WorkflowRunArtifactsDiff indicates an expected call of WorkflowRunArtifactsDiff
+func (mr *MockInterfaceMockRecorder) WorkflowRunArtifactsDiff(projectKey, name, number, withFiles interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetSchema", reflect.TypeOf((*MockInterface)(nil).UserGetSchema))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifactsDiff", reflect.TypeOf((*MockInterface)(nil).WorkflowRunArtifactsDiff), projectKey, name, number, withFiles)
 }
 
-// WorkerModelBook mocks base method
-func (m *MockInterface) WorkerModelBook(groupName, name string) error {
+// WorkflowRunArtifactsPromote mocks base method
+func (m *MockInterface) WorkflowRunArtifactsPromote(projectKey, name string, number int64, req sdk.WorkflowArtifactPromotionRequest) ([]sdk.WorkflowArtifactPromotion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModelBook", groupName, name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunArtifactsPromote", projectKey, name, number, req)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactPromotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkerModelBook indicates an expected call of WorkerModelBook
-func (mr *MockInterfaceMockRecorder) WorkerModelBook(groupName, name interface{}) *gomock.Call {
+// WorkflowRunArtifactsPromote indicates an expected call of WorkflowRunArtifactsPromote
+func (mr *MockInterfaceMockRecorder) WorkflowRunArtifactsPromote(projectKey, name, number, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelBook", reflect.TypeOf((*MockInterface)(nil).WorkerModelBook), groupName, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifactsPromote", reflect.TypeOf((*MockInterface)(nil).WorkflowRunArtifactsPromote), projectKey, name, number, req)
 }
 
-// WorkerList mocks base method
-func (m *MockInterface) WorkerList(ctx context.Context) ([]sdk.Worker, error) {
+// WorkflowArtifactPromotions mocks base method
+func (m *MockInterface) WorkflowArtifactPromotions(projectKey, name string) ([]sdk.WorkflowArtifactPromotion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerList", ctx)
-	ret0, _ := ret[0].([]sdk.Worker)
+	ret := m.ctrl.Call(m, "WorkflowArtifactPromotions", projectKey, name)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactPromotion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkerList indicates an expected call of WorkerList
-func (mr *MockInterfaceMockRecorder) WorkerList(ctx interface{}) *gomock.Call {
+// WorkflowArtifactPromotions indicates an expected call of WorkflowArtifactPromotions
+func (mr *MockInterfaceMockRecorder) WorkflowArtifactPromotions(projectKey, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerList", reflect.TypeOf((*MockInterface)(nil).WorkerList), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPromotions", reflect.TypeOf((*MockInterface)(nil).WorkflowArtifactPromotions), projectKey, name)
 }
 
-// WorkerRefresh mocks base method
-func (m *MockInterface) WorkerRefresh(ctx context.Context) error {
+// WorkflowArtifactPromotionDownload mocks base method
+func (m *MockInterface) WorkflowArtifactPromotionDownload(projectKey, name string, p sdk.WorkflowArtifactPromotion, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerRefresh", ctx)
+	ret := m.ctrl.Call(m, "WorkflowArtifactPromotionDownload", projectKey, name, p, w)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WorkerRefresh indicates an expected call of WorkerRefresh
-func (mr *MockInterfaceMockRecorder) WorkerRefresh(ctx interface{}) *gomock.Call {
+// WorkflowArtifactPromotionDownload indicates an expected call of WorkflowArtifactPromotionDownload
+func (mr *MockInterfaceMockRecorder) WorkflowArtifactPromotionDownload(projectKey, name, p, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRefresh", reflect.TypeOf((*MockInterface)(nil).WorkerRefresh), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPromotionDownload", reflect.TypeOf((*MockInterface)(nil).WorkflowArtifactPromotionDownload), projectKey, name, p, w)
 }
 
-// WorkerUnregister mocks base method
-func (m *MockInterface) WorkerUnregister(ctx context.Context) error {
+// WorkflowArtifactPromotionDelete mocks base method
+func (m *MockInterface) WorkflowArtifactPromotionDelete(projectKey, name string, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerUnregister", ctx)
+	ret := m.ctrl.Call(m, "WorkflowArtifactPromotionDelete", projectKey, name, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WorkerUnregister indicates an expected call of WorkerUnregister
-func (mr *MockInterfaceMockRecorder) WorkerUnregister(ctx interface{}) *gomock.Call {
+// WorkflowArtifactPromotionDelete indicates an expected call of WorkflowArtifactPromotionDelete
+func (mr *MockInterfaceMockRecorder) WorkflowArtifactPromotionDelete(projectKey, name, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerUnregister", reflect.TypeOf((*MockInterface)(nil).WorkerUnregister), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPromotionDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowArtifactPromotionDelete), projectKey, name, id)
 }
 
-// WorkerDisable mocks base method
-func (m *MockInterface) WorkerDisable(ctx context.Context, id string) error {
+// WorkflowArtifactAttestation mocks base method
+func (m *MockInterface) WorkflowArtifactAttestation(projectKey, name string, artifactID int64) (*sdk.WorkflowArtifactAttestation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerDisable", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowArtifactAttestation", projectKey, name, artifactID)
+	ret0, _ := ret[0].(*sdk.WorkflowArtifactAttestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkerDisable indicates an expected call of WorkerDisable
-func (mr *MockInterfaceMockRecorder) WorkerDisable(ctx, id interface{}) *gomock.Call {
+// WorkflowArtifactAttestation indicates an expected call of WorkflowArtifactAttestation
+func (mr *MockInterfaceMockRecorder) WorkflowArtifactAttestation(projectKey, name, artifactID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerDisable", reflect.TypeOf((*MockInterface)(nil).WorkerDisable), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactAttestation", reflect.TypeOf((*MockInterface)(nil).WorkflowArtifactAttestation), projectKey, name, artifactID)
 }
 
-// WorkerModelAdd mocks base method
-func (m *MockInterface) WorkerModelAdd(name, modelType, patternName string, dockerModel *sdk.ModelDocker, vmModel *sdk.ModelVirtualMachine, groupID int64) (sdk.Model, error) {
+// WorkflowArtifactAttestationVerify mocks base method
+func (m *MockInterface) WorkflowArtifactAttestationVerify(projectKey, name string, artifactID int64) (*sdk.WorkflowArtifactAttestationVerification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModelAdd", name, modelType, patternName, dockerModel, vmModel, groupID)
-	ret0, _ := ret[0].(sdk.Model)
+	ret := m.ctrl.Call(m, "WorkflowArtifactAttestationVerify", projectKey, name, artifactID)
+	ret0, _ := ret[0].(*sdk.WorkflowArtifactAttestationVerification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkerModelAdd indicates an expected call of WorkerModelAdd
-func (mr *MockInterfaceMockRecorder) WorkerModelAdd(name, modelType, patternName, dockerModel, vmModel, groupID interface{}) *gomock.Call {
+// WorkflowArtifactAttestationVerify indicates an expected call of WorkflowArtifactAttestationVerify
+func (mr *MockInterfaceMockRecorder) WorkflowArtifactAttestationVerify(projectKey, name, artifactID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelAdd", reflect.TypeOf((*MockInterface)(nil).WorkerModelAdd), name, modelType, patternName, dockerModel, vmModel, groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactAttestationVerify", reflect.TypeOf((*MockInterface)(nil).WorkflowArtifactAttestationVerify), projectKey, name, artifactID)
 }
 
-// WorkerModel mocks base method
-func (m *MockInterface) WorkerModel(groupName, name string) (sdk.Model, error) {
+// WorkflowRunSBOMs mocks base method
+func (m *MockInterface) WorkflowRunSBOMs(projectKey, name string, number int64) ([]sdk.WorkflowRunSBOM, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModel", groupName, name)
-	ret0, _ := ret[0].(sdk.Model)
+	ret := m.ctrl.Call(m, "WorkflowRunSBOMs", projectKey, name, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunSBOM)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkerModel indicates an expected call of WorkerModel
-func (mr *MockInterfaceMockRecorder) WorkerModel(groupName, name interface{}) *gomock.Call {
+// WorkflowRunSBOMs indicates an expected call of WorkflowRunSBOMs
+func (mr *MockInterfaceMockRecorder) WorkflowRunSBOMs(projectKey, name, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModel", reflect.TypeOf((*MockInterface)(nil).WorkerModel), groupName, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSBOMs", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSBOMs), projectKey, name, number)
 }
 
-// WorkerModelDelete mocks base method
-func (m *MockInterface) WorkerModelDelete(groupName, name string) error {
+// WorkflowRunChangelogs mocks base method
+func (m *MockInterface) WorkflowRunChangelogs(projectKey, workflowName string, number int64) ([]sdk.WorkflowRunChangelog, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModelDelete", groupName, name)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunChangelogs", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunChangelog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkerModelDelete indicates an expected call of WorkerModelDelete
-func (mr *MockInterfaceMockRecorder) WorkerModelDelete(groupName, name interface{}) *gomock.Call {
+// WorkflowRunChangelogs indicates an expected call of WorkflowRunChangelogs
+func (mr *MockInterfaceMockRecorder) WorkflowRunChangelogs(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelDelete", reflect.TypeOf((*MockInterface)(nil).WorkerModelDelete), groupName, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunChangelogs", reflect.TypeOf((*MockInterface)(nil).WorkflowRunChangelogs), projectKey, workflowName, number)
 }
 
-// WorkerModelSpawnError mocks base method
-func (m *MockInterface) WorkerModelSpawnError(groupName, name string, info sdk.SpawnErrorForm) error {
+// WorkflowRunSBOMDownload mocks base method
+func (m *MockInterface) WorkflowRunSBOMDownload(projectKey, name string, number, id int64, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModelSpawnError", groupName, name, info)
+	ret := m.ctrl.Call(m, "WorkflowRunSBOMDownload", projectKey, name, number, id, w)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WorkerModelSpawnError indicates an expected call of WorkerModelSpawnError
-func (mr *MockInterfaceMockRecorder) WorkerModelSpawnError(groupName, name, info interface{}) *gomock.Call {
+// WorkflowRunSBOMDownload indicates an expected call of WorkflowRunSBOMDownload
+func (mr *MockInterfaceMockRecorder) WorkflowRunSBOMDownload(projectKey, name, number, id, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelSpawnError", reflect.TypeOf((*MockInterface)(nil).WorkerModelSpawnError), groupName, name, info)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSBOMDownload", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSBOMDownload), projectKey, name, number, id, w)
 }
 
-// WorkerModels mocks base method
-func (m *MockInterface) WorkerModels(arg0 *cdsclient.WorkerModelFilter) ([]sdk.Model, error) {
+// WorkflowRunTestResults mocks base method
+func (m *MockInterface) WorkflowRunTestResults(projectKey, name string, number int64) ([]sdk.WorkflowRunTestResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModels", arg0)
-	ret0, _ := ret[0].([]sdk.Model)
+	ret := m.ctrl.Call(m, "WorkflowRunTestResults", projectKey, name, number)
+	ret0, _ := ret[0].([]sdk.WorkflowRunTestResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkerModels indicates an expected call of WorkerModels
-func (mr *MockInterfaceMockRecorder) WorkerModels(arg0 interface{}) *gomock.Call {
+// WorkflowRunTestResults indicates an expected call of WorkflowRunTestResults
+func (mr *MockInterfaceMockRecorder) WorkflowRunTestResults(projectKey, name, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModels", reflect.TypeOf((*MockInterface)(nil).WorkerModels), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunTestResults", reflect.TypeOf((*MockInterface)(nil).WorkflowRunTestResults), projectKey, name, number)
 }
 
-// WorkerModelsEnabled mocks base method
-func (m *MockInterface) WorkerModelsEnabled() ([]sdk.Model, error) {
+// WorkflowCoverageTrend mocks base method
+func (m *MockInterface) WorkflowCoverageTrend(projectKey, name, branch string, limit int64) ([]sdk.WorkflowCoverageTrend, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerModelsEnabled")
-	ret0, _ := ret[0].([]sdk.Model)
+	ret := m.ctrl.Call(m, "WorkflowCoverageTrend", projectKey, name, branch, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowCoverageTrend)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkerModelsEnabled indicates an expected call of WorkerModelsEnabled
-func (mr *MockInterfaceMockRecorder) WorkerModelsEnabled() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelsEnabled", reflect.TypeOf((*MockInterface)(nil).WorkerModelsEnabled))
-}
-
-// WorkerRegister mocks base method
-func (m *MockInterface) WorkerRegister(ctx context.Context, authToken string, form sdk.WorkerRegistrationForm) (*sdk.Worker, bool, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerRegister", ctx, authToken, form)
-	ret0, _ := ret[0].(*sdk.Worker)
-	ret1, _ := ret[1].(bool)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
-}
-
-// WorkerRegister indicates an expected call of WorkerRegister
-func (mr *MockInterfaceMockRecorder) WorkerRegister(ctx, authToken, form interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRegister", reflect.TypeOf((*MockInterface)(nil).WorkerRegister), ctx, authToken, form)
-}
-
-// WorkerSetStatus mocks base method
-func (m *MockInterface) WorkerSetStatus(ctx context.Context, status string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkerSetStatus", ctx, status)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// WorkerSetStatus indicates an expected call of WorkerSetStatus
-func (mr *MockInterfaceMockRecorder) WorkerSetStatus(ctx, status interface{}) *gomock.Call {
+// WorkflowCoverageTrend indicates an expected call of WorkflowCoverageTrend
+func (mr *MockInterfaceMockRecorder) WorkflowCoverageTrend(projectKey, name, branch, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerSetStatus", reflect.TypeOf((*MockInterface)(nil).WorkerSetStatus), ctx, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCoverageTrend", reflect.TypeOf((*MockInterface)(nil).WorkflowCoverageTrend), projectKey, name, branch, limit)
 }
 
-// WorkflowList mocks base method
-func (m *MockInterface) WorkflowList(projectKey string) ([]sdk.Workflow, error) {
+// WorkflowInsights mocks base method
+func (m *MockInterface) WorkflowInsights(projectKey, name string, days int) (*sdk.WorkflowInsights, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowList", projectKey)
-	ret0, _ := ret[0].([]sdk.Workflow)
+	ret := m.ctrl.Call(m, "WorkflowInsights", projectKey, name, days)
+	ret0, _ := ret[0].(*sdk.WorkflowInsights)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowList indicates an expected call of WorkflowList
-func (mr *MockInterfaceMockRecorder) WorkflowList(projectKey interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowList", reflect.TypeOf((*MockInterface)(nil).WorkflowList), projectKey)
-}
-
-// WorkflowGet mocks base method
-func (m *MockInterface) WorkflowGet(projectKey, name string, opts ...cdsclient.RequestModifier) (*sdk.Workflow, error) {
-	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey, name}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "WorkflowGet", varargs...)
-	ret0, _ := ret[0].(*sdk.Workflow)
+// WorkflowInsights indicates an expected call of WorkflowInsights
+func (mr *MockInterfaceMockRecorder) WorkflowInsights(projectKey, name, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowInsights", reflect.TypeOf((*MockInterface)(nil).WorkflowInsights), projectKey, name, days)
+}
+
+// WorkflowRunNotificationDeliveries mocks base method
+func (m *MockInterface) WorkflowRunNotificationDeliveries(projectKey, name string, number int64) ([]sdk.WorkflowNotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunNotificationDeliveries", projectKey, name, number)
+	ret0, _ := ret[0].([]sdk.WorkflowNotificationDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowGet indicates an expected call of WorkflowGet
-func (mr *MockInterfaceMockRecorder) WorkflowGet(projectKey, name interface{}, opts ...interface{}) *gomock.Call {
+// WorkflowRunNotificationDeliveries indicates an expected call of WorkflowRunNotificationDeliveries
+func (mr *MockInterfaceMockRecorder) WorkflowRunNotificationDeliveries(projectKey, name, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, name}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGet", reflect.TypeOf((*MockInterface)(nil).WorkflowGet), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunNotificationDeliveries", reflect.TypeOf((*MockInterface)(nil).WorkflowRunNotificationDeliveries), projectKey, name, number)
 }
 
-// WorkflowUpdate mocks base method
-func (m *MockInterface) WorkflowUpdate(projectKey, name string, wf *sdk.Workflow) error {
+// WorkflowRunFromHook mocks base method
+func (m *MockInterface) WorkflowRunFromHook(projectKey, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowUpdate", projectKey, name, wf)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunFromHook", projectKey, workflowName, hook)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowUpdate indicates an expected call of WorkflowUpdate
-func (mr *MockInterfaceMockRecorder) WorkflowUpdate(projectKey, name, wf interface{}) *gomock.Call {
+// WorkflowRunFromHook indicates an expected call of WorkflowRunFromHook
+func (mr *MockInterfaceMockRecorder) WorkflowRunFromHook(projectKey, workflowName, hook interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowUpdate", reflect.TypeOf((*MockInterface)(nil).WorkflowUpdate), projectKey, name, wf)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromHook", reflect.TypeOf((*MockInterface)(nil).WorkflowRunFromHook), projectKey, workflowName, hook)
 }
 
-// WorkflowDelete mocks base method
-func (m *MockInterface) WorkflowDelete(projectKey, workflowName string) error {
+// WorkflowRunFromManual mocks base method
+func (m *MockInterface) WorkflowRunFromManual(projectKey, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowDelete", projectKey, workflowName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunFromManual", projectKey, workflowName, manual, number, fromNodeID)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowDelete indicates an expected call of WorkflowDelete
-func (mr *MockInterfaceMockRecorder) WorkflowDelete(projectKey, workflowName interface{}) *gomock.Call {
+// WorkflowRunFromManual indicates an expected call of WorkflowRunFromManual
+func (mr *MockInterfaceMockRecorder) WorkflowRunFromManual(projectKey, workflowName, manual, number, fromNodeID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowDelete), projectKey, workflowName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromManual", reflect.TypeOf((*MockInterface)(nil).WorkflowRunFromManual), projectKey, workflowName, manual, number, fromNodeID)
 }
 
-// WorkflowGroupAdd mocks base method
-func (m *MockInterface) WorkflowGroupAdd(projectKey, name, groupName string, permission int) error {
+// WorkflowRunWithOptions mocks base method
+func (m *MockInterface) WorkflowRunWithOptions(projectKey, workflowName string, opts sdk.WorkflowRunPostHandlerOption) (*sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowGroupAdd", projectKey, name, groupName, permission)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowRunWithOptions", projectKey, workflowName, opts)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowGroupAdd indicates an expected call of WorkflowGroupAdd
-func (mr *MockInterfaceMockRecorder) WorkflowGroupAdd(projectKey, name, groupName, permission interface{}) *gomock.Call {
+// WorkflowRunWithOptions indicates an expected call of WorkflowRunWithOptions
+func (mr *MockInterfaceMockRecorder) WorkflowRunWithOptions(projectKey, workflowName, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupAdd), projectKey, name, groupName, permission)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunWithOptions", reflect.TypeOf((*MockInterface)(nil).WorkflowRunWithOptions), projectKey, workflowName, opts)
 }
 
-// WorkflowGroupDelete mocks base method
-func (m *MockInterface) WorkflowGroupDelete(projectKey, name, groupName string) error {
+// WorkflowTriggerURLGenerate mocks base method
+func (m *MockInterface) WorkflowTriggerURLGenerate(projectKey, workflowName string, req sdk.WorkflowTriggerURLRequest) (*sdk.WorkflowTriggerURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowGroupDelete", projectKey, name, groupName)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "WorkflowTriggerURLGenerate", projectKey, workflowName, req)
+	ret0, _ := ret[0].(*sdk.WorkflowTriggerURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// WorkflowGroupDelete indicates an expected call of WorkflowGroupDelete
-func (mr *MockInterfaceMockRecorder) WorkflowGroupDelete(projectKey, name, groupName interface{}) *gomock.Call {
+// WorkflowTriggerURLGenerate indicates an expected call of WorkflowTriggerURLGenerate
+func (mr *MockInterfaceMockRecorder) WorkflowTriggerURLGenerate(projectKey, workflowName, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowGroupDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowGroupDelete), projectKey, name, groupName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTriggerURLGenerate", reflect.TypeOf((*MockInterface)(nil).WorkflowTriggerURLGenerate), projectKey, workflowName, req)
 }
 
-// WorkflowRunGet mocks base method
-func (m *MockInterface) WorkflowRunGet(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+// WorkflowTriggerURLList mocks base method
+func (m *MockInterface) WorkflowTriggerURLList(projectKey, workflowName string) ([]sdk.WorkflowTriggerURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunGet", projectKey, workflowName, number)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowTriggerURLList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowTriggerURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunGet indicates an expected call of WorkflowRunGet
-func (mr *MockInterfaceMockRecorder) WorkflowRunGet(projectKey, workflowName, number interface{}) *gomock.Call {
+// WorkflowTriggerURLList indicates an expected call of WorkflowTriggerURLList
+func (mr *MockInterfaceMockRecorder) WorkflowTriggerURLList(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunGet", reflect.TypeOf((*MockInterface)(nil).WorkflowRunGet), projectKey, workflowName, number)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTriggerURLList", reflect.TypeOf((*MockInterface)(nil).WorkflowTriggerURLList), projectKey, workflowName)
 }
 
-// WorkflowRunsDeleteByBranch mocks base method
-func (m *MockInterface) WorkflowRunsDeleteByBranch(projectKey, workflowName, branch string) error {
+// WorkflowTriggerURLRevoke mocks base method
+func (m *MockInterface) WorkflowTriggerURLRevoke(projectKey, workflowName, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunsDeleteByBranch", projectKey, workflowName, branch)
+	ret := m.ctrl.Call(m, "WorkflowTriggerURLRevoke", projectKey, workflowName, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// WorkflowRunsDeleteByBranch indicates an expected call of WorkflowRunsDeleteByBranch
-func (mr *MockInterfaceMockRecorder) WorkflowRunsDeleteByBranch(projectKey, workflowName, branch interface{}) *gomock.Call {
+// WorkflowTriggerURLRevoke indicates an expected call of WorkflowTriggerURLRevoke
+func (mr *MockInterfaceMockRecorder) WorkflowTriggerURLRevoke(projectKey, workflowName, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunsDeleteByBranch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunsDeleteByBranch), projectKey, workflowName, branch)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTriggerURLRevoke", reflect.TypeOf((*MockInterface)(nil).WorkflowTriggerURLRevoke), projectKey, workflowName, id)
 }
 
-// WorkflowRunResync mocks base method
-func (m *MockInterface) WorkflowRunResync(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+// WorkflowTriggerExplain mocks base method
+func (m *MockInterface) WorkflowTriggerExplain(projectKey, workflowName, commit, branch string) (*sdk.WorkflowTriggerExplain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunResync", projectKey, workflowName, number)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowTriggerExplain", projectKey, workflowName, commit, branch)
+	ret0, _ := ret[0].(*sdk.WorkflowTriggerExplain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunResync indicates an expected call of WorkflowRunResync
-func (mr *MockInterfaceMockRecorder) WorkflowRunResync(projectKey, workflowName, number interface{}) *gomock.Call {
+// WorkflowTriggerExplain indicates an expected call of WorkflowTriggerExplain
+func (mr *MockInterfaceMockRecorder) WorkflowTriggerExplain(projectKey, workflowName, commit, branch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunResync", reflect.TypeOf((*MockInterface)(nil).WorkflowRunResync), projectKey, workflowName, number)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTriggerExplain", reflect.TypeOf((*MockInterface)(nil).WorkflowTriggerExplain), projectKey, workflowName, commit, branch)
 }
 
-// WorkflowRunSearch mocks base method
-func (m *MockInterface) WorkflowRunSearch(projectKey string, offset, limit int64, filter ...cdsclient.Filter) ([]sdk.WorkflowRun, error) {
+// WorkflowTimerList mocks base method
+func (m *MockInterface) WorkflowTimerList(projectKey, workflowName string) ([]sdk.WorkflowTimer, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{projectKey, offset, limit}
-	for _, a := range filter {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "WorkflowRunSearch", varargs...)
-	ret0, _ := ret[0].([]sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowTimerList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowTimer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunSearch indicates an expected call of WorkflowRunSearch
-func (mr *MockInterfaceMockRecorder) WorkflowRunSearch(projectKey, offset, limit interface{}, filter ...interface{}) *gomock.Call {
+// WorkflowTimerList indicates an expected call of WorkflowTimerList
+func (mr *MockInterfaceMockRecorder) WorkflowTimerList(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{projectKey, offset, limit}, filter...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSearch", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSearch), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTimerList", reflect.TypeOf((*MockInterface)(nil).WorkflowTimerList), projectKey, workflowName)
 }
 
-// WorkflowRunList mocks base method
-func (m *MockInterface) WorkflowRunList(projectKey, workflowName string, offset, limit int64) ([]sdk.WorkflowRun, error) {
+// WorkflowTimerAdd mocks base method
+func (m *MockInterface) WorkflowTimerAdd(projectKey, workflowName string, t *sdk.WorkflowTimer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunList", projectKey, workflowName, offset, limit)
-	ret0, _ := ret[0].([]sdk.WorkflowRun)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WorkflowTimerAdd", projectKey, workflowName, t)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowRunList indicates an expected call of WorkflowRunList
-func (mr *MockInterfaceMockRecorder) WorkflowRunList(projectKey, workflowName, offset, limit interface{}) *gomock.Call {
+// WorkflowTimerAdd indicates an expected call of WorkflowTimerAdd
+func (mr *MockInterfaceMockRecorder) WorkflowTimerAdd(projectKey, workflowName, t interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunList), projectKey, workflowName, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTimerAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowTimerAdd), projectKey, workflowName, t)
 }
 
-// WorkflowRunArtifacts mocks base method
-func (m *MockInterface) WorkflowRunArtifacts(projectKey, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error) {
+// WorkflowTimerDelete mocks base method
+func (m *MockInterface) WorkflowTimerDelete(projectKey, workflowName, timerName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunArtifacts", projectKey, name, number)
-	ret0, _ := ret[0].([]sdk.WorkflowNodeRunArtifact)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WorkflowTimerDelete", projectKey, workflowName, timerName)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowRunArtifacts indicates an expected call of WorkflowRunArtifacts
-func (mr *MockInterfaceMockRecorder) WorkflowRunArtifacts(projectKey, name, number interface{}) *gomock.Call {
+// WorkflowTimerDelete indicates an expected call of WorkflowTimerDelete
+func (mr *MockInterfaceMockRecorder) WorkflowTimerDelete(projectKey, workflowName, timerName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockInterface)(nil).WorkflowRunArtifacts), projectKey, name, number)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTimerDelete", reflect.TypeOf((*MockInterface)(nil).WorkflowTimerDelete), projectKey, workflowName, timerName)
 }
 
-// WorkflowRunFromHook mocks base method
-func (m *MockInterface) WorkflowRunFromHook(projectKey, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error) {
+// WorkflowScheduledRunList mocks base method
+func (m *MockInterface) WorkflowScheduledRunList(projectKey, workflowName string) ([]sdk.WorkflowScheduledRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunFromHook", projectKey, workflowName, hook)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret := m.ctrl.Call(m, "WorkflowScheduledRunList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowScheduledRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WorkflowRunFromHook indicates an expected call of WorkflowRunFromHook
-func (mr *MockInterfaceMockRecorder) WorkflowRunFromHook(projectKey, workflowName, hook interface{}) *gomock.Call {
+// WorkflowScheduledRunList indicates an expected call of WorkflowScheduledRunList
+func (mr *MockInterfaceMockRecorder) WorkflowScheduledRunList(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromHook", reflect.TypeOf((*MockInterface)(nil).WorkflowRunFromHook), projectKey, workflowName, hook)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowScheduledRunList", reflect.TypeOf((*MockInterface)(nil).WorkflowScheduledRunList), projectKey, workflowName)
 }
 
-// WorkflowRunFromManual mocks base method
-func (m *MockInterface) WorkflowRunFromManual(projectKey, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error) {
+// WorkflowScheduledRunAdd mocks base method
+func (m *MockInterface) WorkflowScheduledRunAdd(projectKey, workflowName string, r *sdk.WorkflowScheduledRun) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkflowRunFromManual", projectKey, workflowName, manual, number, fromNodeID)
-	ret0, _ := ret[0].(*sdk.WorkflowRun)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WorkflowScheduledRunAdd", projectKey, workflowName, r)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// WorkflowRunFromManual indicates an expected call of WorkflowRunFromManual
-func (mr *MockInterfaceMockRecorder) WorkflowRunFromManual(projectKey, workflowName, manual, number, fromNodeID interface{}) *gomock.Call {
+// WorkflowScheduledRunAdd indicates an expected call of WorkflowScheduledRunAdd
+func (mr *MockInterfaceMockRecorder) WorkflowScheduledRunAdd(projectKey, workflowName, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunFromManual", reflect.TypeOf((*MockInterface)(nil).WorkflowRunFromManual), projectKey, workflowName, manual, number, fromNodeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowScheduledRunAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowScheduledRunAdd), projectKey, workflowName, r)
+}
+
+// WorkflowScheduledRunCancel mocks base method
+func (m *MockInterface) WorkflowScheduledRunCancel(projectKey, workflowName, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowScheduledRunCancel", projectKey, workflowName, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowScheduledRunCancel indicates an expected call of WorkflowScheduledRunCancel
+func (mr *MockInterfaceMockRecorder) WorkflowScheduledRunCancel(projectKey, workflowName, uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowScheduledRunCancel", reflect.TypeOf((*MockInterface)(nil).WorkflowScheduledRunCancel), projectKey, workflowName, uuid)
 }
 
 // WorkflowRunNumberGet mocks base method
@@ -7222,6 +9463,21 @@ func (mr *MockInterfaceMockRecorder) WorkflowStop(projectKey, workflowName, numb
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowStop", reflect.TypeOf((*MockInterface)(nil).WorkflowStop), projectKey, workflowName, number)
 }
 
+// WorkflowRunRehydrate mocks base method
+func (m *MockInterface) WorkflowRunRehydrate(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunRehydrate", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunRehydrate indicates an expected call of WorkflowRunRehydrate
+func (mr *MockInterfaceMockRecorder) WorkflowRunRehydrate(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunRehydrate", reflect.TypeOf((*MockInterface)(nil).WorkflowRunRehydrate), projectKey, workflowName, number)
+}
+
 // WorkflowNodeStop mocks base method
 func (m *MockInterface) WorkflowNodeStop(projectKey, workflowName string, number, fromNodeID int64) (*sdk.WorkflowNodeRun, error) {
 	m.ctrl.T.Helper()
@@ -7252,6 +9508,21 @@ func (mr *MockInterfaceMockRecorder) WorkflowNodeRun(projectKey, name, number, n
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRun", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRun), projectKey, name, number, nodeRunID)
 }
 
+// WorkflowNodeRunManifest mocks base method
+func (m *MockInterface) WorkflowNodeRunManifest(projectKey, name string, number, nodeRunID int64) (*sdk.WorkflowNodeRunManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunManifest", projectKey, name, number, nodeRunID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowNodeRunManifest indicates an expected call of WorkflowNodeRunManifest
+func (mr *MockInterfaceMockRecorder) WorkflowNodeRunManifest(projectKey, name, number, nodeRunID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunManifest", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunManifest), projectKey, name, number, nodeRunID)
+}
+
 // WorkflowNodeRunArtifactDownload mocks base method
 func (m *MockInterface) WorkflowNodeRunArtifactDownload(projectKey, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error {
 	m.ctrl.T.Helper()
@@ -7281,6 +9552,79 @@ func (mr *MockInterfaceMockRecorder) WorkflowNodeRunJobStep(projectKey, workflow
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunJobStep", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunJobStep), projectKey, workflowName, number, nodeRunID, job, step)
 }
 
+// WorkflowRunLogsDownload mocks base method
+func (m *MockInterface) WorkflowRunLogsDownload(projectKey, workflowName string, number int64, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunLogsDownload", projectKey, workflowName, number, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowRunLogsDownload indicates an expected call of WorkflowRunLogsDownload
+func (mr *MockInterfaceMockRecorder) WorkflowRunLogsDownload(projectKey, workflowName, number, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunLogsDownload", reflect.TypeOf((*MockInterface)(nil).WorkflowRunLogsDownload), projectKey, workflowName, number, format, w)
+}
+
+// WorkflowRunReportDownload mocks base method
+func (m *MockInterface) WorkflowRunReportDownload(projectKey, workflowName string, number int64, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunReportDownload", projectKey, workflowName, number, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowRunReportDownload indicates an expected call of WorkflowRunReportDownload
+func (mr *MockInterfaceMockRecorder) WorkflowRunReportDownload(projectKey, workflowName, number, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunReportDownload", reflect.TypeOf((*MockInterface)(nil).WorkflowRunReportDownload), projectKey, workflowName, number, format, w)
+}
+
+// WorkflowRunLinks mocks base method
+func (m *MockInterface) WorkflowRunLinks(projectKey, workflowName string, number int64) (*sdk.WorkflowRunLinks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunLinks", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRunLinks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunLinks indicates an expected call of WorkflowRunLinks
+func (mr *MockInterfaceMockRecorder) WorkflowRunLinks(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunLinks", reflect.TypeOf((*MockInterface)(nil).WorkflowRunLinks), projectKey, workflowName, number)
+}
+
+// WorkflowNodeRunGateURL mocks base method
+func (m *MockInterface) WorkflowNodeRunGateURL(projectKey, workflowName string, number, nodeRunID int64) (*sdk.WorkflowNodeRunGateURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunGateURL", projectKey, workflowName, number, nodeRunID)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunGateURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowNodeRunGateURL indicates an expected call of WorkflowNodeRunGateURL
+func (mr *MockInterfaceMockRecorder) WorkflowNodeRunGateURL(projectKey, workflowName, number, nodeRunID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunGateURL", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunGateURL), projectKey, workflowName, number, nodeRunID)
+}
+
+// WorkflowNodeRunChangelogCompute mocks base method
+func (m *MockInterface) WorkflowNodeRunChangelogCompute(projectKey, workflowName string, number, nodeRunID int64) (*sdk.WorkflowRunChangelog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunChangelogCompute", projectKey, workflowName, number, nodeRunID)
+	ret0, _ := ret[0].(*sdk.WorkflowRunChangelog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowNodeRunChangelogCompute indicates an expected call of WorkflowNodeRunChangelogCompute
+func (mr *MockInterfaceMockRecorder) WorkflowNodeRunChangelogCompute(projectKey, workflowName, number, nodeRunID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunChangelogCompute", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunChangelogCompute), projectKey, workflowName, number, nodeRunID)
+}
+
 // WorkflowNodeRunRelease mocks base method
 func (m *MockInterface) WorkflowNodeRunRelease(projectKey, workflowName string, runNumber, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error {
 	m.ctrl.T.Helper()
@@ -7428,6 +9772,21 @@ func (mr *MockInterfaceMockRecorder) MonDBMigrate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonDBMigrate", reflect.TypeOf((*MockInterface)(nil).MonDBMigrate))
 }
 
+// MonServices mocks base method
+func (m *MockInterface) MonServices() (*sdk.MonitoringServicesMatrix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MonServices")
+	ret0, _ := ret[0].(*sdk.MonitoringServicesMatrix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MonServices indicates an expected call of MonServices
+func (mr *MockInterfaceMockRecorder) MonServices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonServices", reflect.TypeOf((*MockInterface)(nil).MonServices))
+}
+
 // MonErrorsGet mocks base method
 func (m *MockInterface) MonErrorsGet(requestID string) ([]sdk.Error, error) {
 	m.ctrl.T.Helper()
@@ -7453,25 +9812,98 @@ func (m *MockInterface) PollVCSEvents(uuid string, workflowID int64, vcsServer s
 	return ret0, ret1, ret2
 }
 
-// PollVCSEvents indicates an expected call of PollVCSEvents
-func (mr *MockInterfaceMockRecorder) PollVCSEvents(uuid, workflowID, vcsServer, timestamp interface{}) *gomock.Call {
+// PollVCSEvents indicates an expected call of PollVCSEvents
+func (mr *MockInterfaceMockRecorder) PollVCSEvents(uuid, workflowID, vcsServer, timestamp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PollVCSEvents", reflect.TypeOf((*MockInterface)(nil).PollVCSEvents), uuid, workflowID, vcsServer, timestamp)
+}
+
+// VCSConfiguration mocks base method
+func (m *MockInterface) VCSConfiguration() (map[string]sdk.VCSConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VCSConfiguration")
+	ret0, _ := ret[0].(map[string]sdk.VCSConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VCSConfiguration indicates an expected call of VCSConfiguration
+func (mr *MockInterfaceMockRecorder) VCSConfiguration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VCSConfiguration", reflect.TypeOf((*MockInterface)(nil).VCSConfiguration))
+}
+
+// WorkflowScheduledRunExecute mocks base method
+func (m *MockInterface) WorkflowScheduledRunExecute(r sdk.WorkflowScheduledRun) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowScheduledRunExecute", r)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowScheduledRunExecute indicates an expected call of WorkflowScheduledRunExecute
+func (mr *MockInterfaceMockRecorder) WorkflowScheduledRunExecute(r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowScheduledRunExecute", reflect.TypeOf((*MockInterface)(nil).WorkflowScheduledRunExecute), r)
+}
+
+// ChatOpsCommand mocks base method
+func (m *MockInterface) ChatOpsCommand(cmd sdk.ChatOpsCommand) (*sdk.ChatOpsCommandResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatOpsCommand", cmd)
+	ret0, _ := ret[0].(*sdk.ChatOpsCommandResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChatOpsCommand indicates an expected call of ChatOpsCommand
+func (mr *MockInterfaceMockRecorder) ChatOpsCommand(cmd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatOpsCommand", reflect.TypeOf((*MockInterface)(nil).ChatOpsCommand), cmd)
+}
+
+// ChatOpsLinkToken mocks base method
+func (m *MockInterface) ChatOpsLinkToken(req sdk.ChatOpsLinkTokenRequest) (*sdk.ChatOpsLinkToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatOpsLinkToken", req)
+	ret0, _ := ret[0].(*sdk.ChatOpsLinkToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChatOpsLinkToken indicates an expected call of ChatOpsLinkToken
+func (mr *MockInterfaceMockRecorder) ChatOpsLinkToken(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatOpsLinkToken", reflect.TypeOf((*MockInterface)(nil).ChatOpsLinkToken), req)
+}
+
+// ChatOpsLink mocks base method
+func (m *MockInterface) ChatOpsLink(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatOpsLink", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChatOpsLink indicates an expected call of ChatOpsLink
+func (mr *MockInterfaceMockRecorder) ChatOpsLink(token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PollVCSEvents", reflect.TypeOf((*MockInterface)(nil).PollVCSEvents), uuid, workflowID, vcsServer, timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatOpsLink", reflect.TypeOf((*MockInterface)(nil).ChatOpsLink), token)
 }
 
-// VCSConfiguration mocks base method
-func (m *MockInterface) VCSConfiguration() (map[string]sdk.VCSConfiguration, error) {
+// ChatOpsUnlink mocks base method
+func (m *MockInterface) ChatOpsUnlink(provider string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VCSConfiguration")
-	ret0, _ := ret[0].(map[string]sdk.VCSConfiguration)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ChatOpsUnlink", provider)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// VCSConfiguration indicates an expected call of VCSConfiguration
-func (mr *MockInterfaceMockRecorder) VCSConfiguration() *gomock.Call {
+// ChatOpsUnlink indicates an expected call of ChatOpsUnlink
+func (mr *MockInterfaceMockRecorder) ChatOpsUnlink(provider interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VCSConfiguration", reflect.TypeOf((*MockInterface)(nil).VCSConfiguration))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatOpsUnlink", reflect.TypeOf((*MockInterface)(nil).ChatOpsUnlink), provider)
 }
 
 // Version mocks base method
@@ -7638,6 +10070,36 @@ func (mr *MockInterfaceMockRecorder) TemplateDeleteInstance(groupName, templateS
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateDeleteInstance", reflect.TypeOf((*MockInterface)(nil).TemplateDeleteInstance), groupName, templateSlug, id)
 }
 
+// TemplateGetDrift mocks base method
+func (m *MockInterface) TemplateGetDrift(groupName, templateSlug string) ([]sdk.WorkflowTemplateInstanceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateGetDrift", groupName, templateSlug)
+	ret0, _ := ret[0].([]sdk.WorkflowTemplateInstanceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateGetDrift indicates an expected call of TemplateGetDrift
+func (mr *MockInterfaceMockRecorder) TemplateGetDrift(groupName, templateSlug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateGetDrift", reflect.TypeOf((*MockInterface)(nil).TemplateGetDrift), groupName, templateSlug)
+}
+
+// TemplateReapply mocks base method
+func (m *MockInterface) TemplateReapply(groupName, templateSlug string, req sdk.WorkflowTemplateReapplyRequest) (*sdk.WorkflowTemplateBulk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateReapply", groupName, templateSlug, req)
+	ret0, _ := ret[0].(*sdk.WorkflowTemplateBulk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateReapply indicates an expected call of TemplateReapply
+func (mr *MockInterfaceMockRecorder) TemplateReapply(groupName, templateSlug, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateReapply", reflect.TypeOf((*MockInterface)(nil).TemplateReapply), groupName, templateSlug, req)
+}
+
 // MockWorkerInterface is a mock of WorkerInterface interface
 type MockWorkerInterface struct {
 	ctrl     *gomock.Controller
@@ -7790,6 +10252,92 @@ func (mr *MockWorkerInterfaceMockRecorder) PluginGetBinaryInfos(name, os, arch i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinaryInfos", reflect.TypeOf((*MockWorkerInterface)(nil).PluginGetBinaryInfos), name, os, arch)
 }
 
+// PluginRegistryList mocks base method
+func (m *MockWorkerInterface) PluginRegistryList(name string) ([]sdk.GRPCPluginVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryList", name)
+	ret0, _ := ret[0].([]sdk.GRPCPluginVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PluginRegistryList indicates an expected call of PluginRegistryList
+func (mr *MockWorkerInterfaceMockRecorder) PluginRegistryList(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryList", reflect.TypeOf((*MockWorkerInterface)(nil).PluginRegistryList), name)
+}
+
+// PluginRegistryGet mocks base method
+func (m *MockWorkerInterface) PluginRegistryGet(name, version string) (*sdk.GRPCPluginVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryGet", name, version)
+	ret0, _ := ret[0].(*sdk.GRPCPluginVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PluginRegistryGet indicates an expected call of PluginRegistryGet
+func (mr *MockWorkerInterfaceMockRecorder) PluginRegistryGet(name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryGet", reflect.TypeOf((*MockWorkerInterface)(nil).PluginRegistryGet), name, version)
+}
+
+// PluginRegistryPublish mocks base method
+func (m *MockWorkerInterface) PluginRegistryPublish(name string, v *sdk.GRPCPluginVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryPublish", name, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginRegistryPublish indicates an expected call of PluginRegistryPublish
+func (mr *MockWorkerInterfaceMockRecorder) PluginRegistryPublish(name, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryPublish", reflect.TypeOf((*MockWorkerInterface)(nil).PluginRegistryPublish), name, v)
+}
+
+// PluginRegistryDelete mocks base method
+func (m *MockWorkerInterface) PluginRegistryDelete(name, version string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryDelete", name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginRegistryDelete indicates an expected call of PluginRegistryDelete
+func (mr *MockWorkerInterfaceMockRecorder) PluginRegistryDelete(name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryDelete", reflect.TypeOf((*MockWorkerInterface)(nil).PluginRegistryDelete), name, version)
+}
+
+// PluginRegistryInstall mocks base method
+func (m *MockWorkerInterface) PluginRegistryInstall(name, version string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryInstall", name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginRegistryInstall indicates an expected call of PluginRegistryInstall
+func (mr *MockWorkerInterfaceMockRecorder) PluginRegistryInstall(name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryInstall", reflect.TypeOf((*MockWorkerInterface)(nil).PluginRegistryInstall), name, version)
+}
+
+// PluginRegistryGetBinary mocks base method
+func (m *MockWorkerInterface) PluginRegistryGetBinary(name, version, os, arch string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryGetBinary", name, version, os, arch, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginRegistryGetBinary indicates an expected call of PluginRegistryGetBinary
+func (mr *MockWorkerInterfaceMockRecorder) PluginRegistryGetBinary(name, version, os, arch, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryGetBinary", reflect.TypeOf((*MockWorkerInterface)(nil).PluginRegistryGetBinary), name, version, os, arch, w)
+}
+
 // ProjectIntegrationGet mocks base method
 func (m *MockWorkerInterface) ProjectIntegrationGet(projectKey, integrationName string, clearPassword bool) (sdk.ProjectIntegration, error) {
 	m.ctrl.T.Helper()
@@ -7939,6 +10487,21 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueSendCoverage(ctx, id, report int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendCoverage), ctx, id, report)
 }
 
+// QueueJobCoverage mocks base method
+func (m *MockWorkerInterface) QueueJobCoverage(ctx context.Context, id int64) (*sdk.WorkflowNodeRunCoverage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobCoverage", ctx, id)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunCoverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobCoverage indicates an expected call of QueueJobCoverage
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobCoverage(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobCoverage", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobCoverage), ctx, id)
+}
+
 // QueueSendUnitTests mocks base method
 func (m *MockWorkerInterface) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
 	m.ctrl.T.Helper()
@@ -7981,6 +10544,20 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueSendVulnerability(ctx, id, repor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendVulnerability", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendVulnerability), ctx, id, report)
 }
 
+// QueueSendCodeComments mocks base method
+func (m *MockWorkerInterface) QueueSendCodeComments(ctx context.Context, id int64, comments []sdk.WorkflowNodeRunCodeComment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendCodeComments", ctx, id, comments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueSendCodeComments indicates an expected call of QueueSendCodeComments
+func (mr *MockWorkerInterfaceMockRecorder) QueueSendCodeComments(ctx, id, comments interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCodeComments", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendCodeComments), ctx, id, comments)
+}
+
 // QueueSendStepResult mocks base method
 func (m *MockWorkerInterface) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
 	m.ctrl.T.Helper()
@@ -8056,6 +10633,36 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueJobTag(ctx, jobID, tags interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobTag", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobTag), ctx, jobID, tags)
 }
 
+// QueueArtifactAttestation mocks base method
+func (m *MockWorkerInterface) QueueArtifactAttestation(ctx context.Context, jobID int64, req sdk.WorkflowArtifactAttestationRequest) ([]sdk.WorkflowArtifactAttestation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueArtifactAttestation", ctx, jobID, req)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactAttestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueArtifactAttestation indicates an expected call of QueueArtifactAttestation
+func (mr *MockWorkerInterfaceMockRecorder) QueueArtifactAttestation(ctx, jobID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactAttestation", reflect.TypeOf((*MockWorkerInterface)(nil).QueueArtifactAttestation), ctx, jobID, req)
+}
+
+// QueueJobSBOM mocks base method
+func (m *MockWorkerInterface) QueueJobSBOM(ctx context.Context, jobID int64, sbom sdk.WorkflowRunSBOM) (*sdk.WorkflowRunSBOM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueJobSBOM", ctx, jobID, sbom)
+	ret0, _ := ret[0].(*sdk.WorkflowRunSBOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueJobSBOM indicates an expected call of QueueJobSBOM
+func (mr *MockWorkerInterfaceMockRecorder) QueueJobSBOM(ctx, jobID, sbom interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueJobSBOM", reflect.TypeOf((*MockWorkerInterface)(nil).QueueJobSBOM), ctx, jobID, sbom)
+}
+
 // QueueServiceLogs mocks base method
 func (m *MockWorkerInterface) QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error {
 	m.ctrl.T.Helper()
@@ -8244,6 +10851,36 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkerModelsEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelsEnabled", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerModelsEnabled))
 }
 
+// WorkerModelTrustPolicy mocks base method
+func (m *MockWorkerInterface) WorkerModelTrustPolicy() (sdk.WorkerModelTrustPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerModelTrustPolicy")
+	ret0, _ := ret[0].(sdk.WorkerModelTrustPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerModelTrustPolicy indicates an expected call of WorkerModelTrustPolicy
+func (mr *MockWorkerInterfaceMockRecorder) WorkerModelTrustPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelTrustPolicy", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerModelTrustPolicy))
+}
+
+// WorkerModelTrustPolicyUpdate mocks base method
+func (m *MockWorkerInterface) WorkerModelTrustPolicyUpdate(p sdk.WorkerModelTrustPolicy) (sdk.WorkerModelTrustPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerModelTrustPolicyUpdate", p)
+	ret0, _ := ret[0].(sdk.WorkerModelTrustPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerModelTrustPolicyUpdate indicates an expected call of WorkerModelTrustPolicyUpdate
+func (mr *MockWorkerInterfaceMockRecorder) WorkerModelTrustPolicyUpdate(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerModelTrustPolicyUpdate", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerModelTrustPolicyUpdate), p)
+}
+
 // WorkerRegister mocks base method
 func (m *MockWorkerInterface) WorkerRegister(ctx context.Context, authToken string, form sdk.WorkerRegistrationForm) (*sdk.Worker, bool, error) {
 	m.ctrl.T.Helper()
@@ -8260,6 +10897,21 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkerRegister(ctx, authToken, form i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRegister", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerRegister), ctx, authToken, form)
 }
 
+// WorkerRotateSession mocks base method
+func (m *MockWorkerInterface) WorkerRotateSession(ctx context.Context) (*sdk.AuthSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerRotateSession", ctx)
+	ret0, _ := ret[0].(*sdk.AuthSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerRotateSession indicates an expected call of WorkerRotateSession
+func (mr *MockWorkerInterfaceMockRecorder) WorkerRotateSession(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerRotateSession", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerRotateSession), ctx)
+}
+
 // WorkerSetStatus mocks base method
 func (m *MockWorkerInterface) WorkerSetStatus(ctx context.Context, status string) error {
 	m.ctrl.T.Helper()
@@ -8274,6 +10926,21 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkerSetStatus(ctx, status interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerSetStatus", reflect.TypeOf((*MockWorkerInterface)(nil).WorkerSetStatus), ctx, status)
 }
 
+// WorkflowRunGet mocks base method
+func (m *MockWorkerInterface) WorkflowRunGet(projectKey, workflowName string, number int64) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunGet", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunGet indicates an expected call of WorkflowRunGet
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowRunGet(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunGet", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowRunGet), projectKey, workflowName, number)
+}
+
 // WorkflowRunArtifacts mocks base method
 func (m *MockWorkerInterface) WorkflowRunArtifacts(projectKey, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error) {
 	m.ctrl.T.Helper()
@@ -8289,6 +10956,64 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkflowRunArtifacts(projectKey, name
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifacts", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowRunArtifacts), projectKey, name, number)
 }
 
+// WorkflowRunArtifactsPromote mocks base method
+func (m *MockWorkerInterface) WorkflowRunArtifactsPromote(projectKey, name string, number int64, req sdk.WorkflowArtifactPromotionRequest) ([]sdk.WorkflowArtifactPromotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunArtifactsPromote", projectKey, name, number, req)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactPromotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunArtifactsPromote indicates an expected call of WorkflowRunArtifactsPromote
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowRunArtifactsPromote(projectKey, name, number, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunArtifactsPromote", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowRunArtifactsPromote), projectKey, name, number, req)
+}
+
+// WorkflowArtifactPromotions mocks base method
+func (m *MockWorkerInterface) WorkflowArtifactPromotions(projectKey, name string) ([]sdk.WorkflowArtifactPromotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowArtifactPromotions", projectKey, name)
+	ret0, _ := ret[0].([]sdk.WorkflowArtifactPromotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowArtifactPromotions indicates an expected call of WorkflowArtifactPromotions
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowArtifactPromotions(projectKey, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPromotions", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowArtifactPromotions), projectKey, name)
+}
+
+// WorkflowArtifactPromotionDownload mocks base method
+func (m *MockWorkerInterface) WorkflowArtifactPromotionDownload(projectKey, name string, p sdk.WorkflowArtifactPromotion, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowArtifactPromotionDownload", projectKey, name, p, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowArtifactPromotionDownload indicates an expected call of WorkflowArtifactPromotionDownload
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowArtifactPromotionDownload(projectKey, name, p, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowArtifactPromotionDownload", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowArtifactPromotionDownload), projectKey, name, p, w)
+}
+
+// WorkflowRunReportDownload mocks base method
+func (m *MockWorkerInterface) WorkflowRunReportDownload(projectKey, workflowName string, number int64, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunReportDownload", projectKey, workflowName, number, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowRunReportDownload indicates an expected call of WorkflowRunReportDownload
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowRunReportDownload(projectKey, workflowName, number, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunReportDownload", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowRunReportDownload), projectKey, workflowName, number, format, w)
+}
+
 // WorkflowCachePush mocks base method
 func (m *MockWorkerInterface) WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error {
 	m.ctrl.T.Helper()
@@ -8693,6 +11418,92 @@ func (mr *MockGRPCPluginsClientMockRecorder) PluginGetBinaryInfos(name, os, arch
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginGetBinaryInfos", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginGetBinaryInfos), name, os, arch)
 }
 
+// PluginRegistryList mocks base method
+func (m *MockGRPCPluginsClient) PluginRegistryList(name string) ([]sdk.GRPCPluginVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryList", name)
+	ret0, _ := ret[0].([]sdk.GRPCPluginVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PluginRegistryList indicates an expected call of PluginRegistryList
+func (mr *MockGRPCPluginsClientMockRecorder) PluginRegistryList(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryList", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginRegistryList), name)
+}
+
+// PluginRegistryGet mocks base method
+func (m *MockGRPCPluginsClient) PluginRegistryGet(name, version string) (*sdk.GRPCPluginVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryGet", name, version)
+	ret0, _ := ret[0].(*sdk.GRPCPluginVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PluginRegistryGet indicates an expected call of PluginRegistryGet
+func (mr *MockGRPCPluginsClientMockRecorder) PluginRegistryGet(name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryGet", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginRegistryGet), name, version)
+}
+
+// PluginRegistryPublish mocks base method
+func (m *MockGRPCPluginsClient) PluginRegistryPublish(name string, v *sdk.GRPCPluginVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryPublish", name, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginRegistryPublish indicates an expected call of PluginRegistryPublish
+func (mr *MockGRPCPluginsClientMockRecorder) PluginRegistryPublish(name, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryPublish", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginRegistryPublish), name, v)
+}
+
+// PluginRegistryDelete mocks base method
+func (m *MockGRPCPluginsClient) PluginRegistryDelete(name, version string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryDelete", name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginRegistryDelete indicates an expected call of PluginRegistryDelete
+func (mr *MockGRPCPluginsClientMockRecorder) PluginRegistryDelete(name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryDelete", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginRegistryDelete), name, version)
+}
+
+// PluginRegistryInstall mocks base method
+func (m *MockGRPCPluginsClient) PluginRegistryInstall(name, version string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryInstall", name, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginRegistryInstall indicates an expected call of PluginRegistryInstall
+func (mr *MockGRPCPluginsClientMockRecorder) PluginRegistryInstall(name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryInstall", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginRegistryInstall), name, version)
+}
+
+// PluginRegistryGetBinary mocks base method
+func (m *MockGRPCPluginsClient) PluginRegistryGetBinary(name, version, os, arch string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PluginRegistryGetBinary", name, version, os, arch, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PluginRegistryGetBinary indicates an expected call of PluginRegistryGetBinary
+func (mr *MockGRPCPluginsClientMockRecorder) PluginRegistryGetBinary(name, version, os, arch, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PluginRegistryGetBinary", reflect.TypeOf((*MockGRPCPluginsClient)(nil).PluginRegistryGetBinary), name, version, os, arch, w)
+}
+
 // MockProviderClient is a mock of ProviderClient interface
 type MockProviderClient struct {
 	ctrl     *gomock.Controller
@@ -8997,6 +11808,50 @@ func (mr *MockAuthClientMockRecorder) AuthConsumerCreateForUser(username, reques
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerCreateForUser", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerCreateForUser), username, request)
 }
 
+// AuthConsumerListByProject mocks base method
+func (m *MockAuthClient) AuthConsumerListByProject(projectKey string) (sdk.AuthConsumers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerListByProject", projectKey)
+	ret0, _ := ret[0].(sdk.AuthConsumers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerListByProject indicates an expected call of AuthConsumerListByProject
+func (mr *MockAuthClientMockRecorder) AuthConsumerListByProject(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerListByProject", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerListByProject), projectKey)
+}
+
+// AuthConsumerCreateForProject mocks base method
+func (m *MockAuthClient) AuthConsumerCreateForProject(projectKey string, request sdk.AuthConsumer) (sdk.AuthConsumerCreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerCreateForProject", projectKey, request)
+	ret0, _ := ret[0].(sdk.AuthConsumerCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthConsumerCreateForProject indicates an expected call of AuthConsumerCreateForProject
+func (mr *MockAuthClientMockRecorder) AuthConsumerCreateForProject(projectKey, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerCreateForProject", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerCreateForProject), projectKey, request)
+}
+
+// AuthConsumerDeleteForProject mocks base method
+func (m *MockAuthClient) AuthConsumerDeleteForProject(projectKey, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthConsumerDeleteForProject", projectKey, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthConsumerDeleteForProject indicates an expected call of AuthConsumerDeleteForProject
+func (mr *MockAuthClientMockRecorder) AuthConsumerDeleteForProject(projectKey, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthConsumerDeleteForProject", reflect.TypeOf((*MockAuthClient)(nil).AuthConsumerDeleteForProject), projectKey, id)
+}
+
 // AuthSessionListByUser mocks base method
 func (m *MockAuthClient) AuthSessionListByUser(username string) (sdk.AuthSessions, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChatOps providers.
+const (
+	ChatOpsProviderSlack = "slack"
+)
+
+// ChatOps commands.
+const (
+	ChatOpsCommandRun     = "run"
+	ChatOpsCommandStatus  = "status"
+	ChatOpsCommandStop    = "stop"
+	ChatOpsCommandApprove = "approve"
+)
+
+// ChatOpsLinkTokenDuration is the validity duration of a token used to link a chatops identity to a CDS user.
+const ChatOpsLinkTokenDuration = 15 * time.Minute
+
+// ChatOpsCommand is a command received by a chatops service, executed by the API for the CDS user
+// linked to the external user.
+type ChatOpsCommand struct {
+	Provider       string            `json:"provider"`
+	ExternalUserID string            `json:"external_user_id"`
+	Command        string            `json:"command"`
+	ProjectKey     string            `json:"project_key"`
+	WorkflowName   string            `json:"workflow_name"`
+	RunNumber      int64             `json:"run_number,omitempty"`
+	NodeName       string            `json:"node_name,omitempty"`
+	Payload        map[string]string `json:"payload,omitempty"`
+}
+
+// IsValid returns an error if the command is not valid.
+func (c ChatOpsCommand) IsValid() error {
+	if c.Provider == "" || c.ExternalUserID == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing chatops provider or user")
+	}
+	if c.ProjectKey == "" || c.WorkflowName == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing project key or workflow name")
+	}
+	switch c.Command {
+	case ChatOpsCommandRun, ChatOpsCommandStatus:
+	case ChatOpsCommandStop:
+		if c.RunNumber == 0 {
+			return NewErrorFrom(ErrWrongRequest, "missing run number")
+		}
+	case ChatOpsCommandApprove:
+		if c.RunNumber == 0 || c.NodeName == "" {
+			return NewErrorFrom(ErrWrongRequest, "missing run number or node name")
+		}
+	default:
+		return NewErrorFrom(ErrWrongRequest, "unknown command %q", c.Command)
+	}
+	return nil
+}
+
+// ExternalID returns the identifier of the external user for its provider.
+func (c ChatOpsCommand) ExternalID() string {
+	return ChatOpsExternalID(c.Provider, c.ExternalUserID)
+}
+
+// ChatOpsExternalID returns the identifier of an external user for given provider.
+func ChatOpsExternalID(provider, userID string) string {
+	return fmt.Sprintf("%s:%s", provider, userID)
+}
+
+// ChatOpsCommandResult is returned by the API after a chatops command execution.
+type ChatOpsCommandResult struct {
+	Message     string       `json:"message"`
+	WorkflowRun *WorkflowRun `json:"workflow_run,omitempty"`
+}
+
+// ChatOpsLinkTokenRequest is sent by a chatops service to get a link token for an unknown external user.
+type ChatOpsLinkTokenRequest struct {
+	Provider         string `json:"provider"`
+	ExternalUserID   string `json:"external_user_id"`
+	ExternalUsername string `json:"external_username"`
+}
+
+// ChatOpsLinkToken is a signed token that allows a CDS user to link its account to an external identity.
+type ChatOpsLinkToken struct {
+	Token  string    `json:"token"`
+	Expire time.Time `json:"expire"`
+}
+
+// ChatOpsLinkRequest is sent by a CDS user to link its account to an external identity.
+type ChatOpsLinkRequest struct {
+	Token string `json:"token"`
+}
+
+// IsChatOpsExternalIDForProvider returns true if given external id was computed for given provider.
+func IsChatOpsExternalIDForProvider(externalID, provider string) bool {
+	return strings.HasPrefix(externalID, provider+":")
+}
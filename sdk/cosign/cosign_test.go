@@ -0,0 +1,162 @@
+package cosign
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{image: "ubuntu", name: "docker.io/library/ubuntu", tag: "latest"},
+		{image: "ubuntu:18.04", name: "docker.io/library/ubuntu", tag: "18.04"},
+		{image: "ovhcom/cds-engine:latest", name: "docker.io/ovhcom/cds-engine", tag: "latest"},
+		{image: "index.docker.io/ovhcom/cds-engine", name: "docker.io/ovhcom/cds-engine", tag: "latest"},
+		{image: "localhost:5000/team/image:1.0", name: "localhost:5000/team/image", tag: "1.0"},
+		{image: "registry.example.com/team/image", name: "registry.example.com/team/image", tag: "latest"},
+	}
+	for _, tt := range tests {
+		ref, err := ParseReference(tt.image)
+		require.NoError(t, err, tt.image)
+		assert.Equal(t, tt.name, ref.Name(), tt.image)
+		assert.Equal(t, tt.tag, ref.Tag, tt.image)
+	}
+
+	ref, err := ParseReference("registry.example.com/image@sha256:abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abcd", ref.Digest)
+	assert.Empty(t, ref.Tag)
+
+	_, err = ParseReference("registry.example.com/Image")
+	assert.Error(t, err)
+
+	ref, _ = ParseReference("registry.example.com/team/image:1.0")
+	assert.True(t, ref.MatchPrefix("registry.example.com"))
+	assert.True(t, ref.MatchPrefix("registry.example.com/team/"))
+	assert.False(t, ref.MatchPrefix("registry.example.com/te"))
+	assert.False(t, ref.MatchPrefix("registry.example"))
+}
+
+// testRegistry serves an image and its cosign signature.
+type testRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+}
+
+func digestOf(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer token" {
+		if req.URL.Path == "/token" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "token"})
+			return
+		}
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="test"`, req.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	parts := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/v2/team/image/"), "/", 2)
+	var content []byte
+	switch parts[0] {
+	case "manifests":
+		content = r.manifests[parts[1]]
+	case "blobs":
+		content = r.blobs[parts[1]]
+	}
+	if content == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write(content)
+}
+
+func (r *testRegistry) sign(t *testing.T, key *ecdsa.PrivateKey, digest, signedDigest string) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"team/image"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, signedDigest))
+	hash := sha256.Sum256(payload)
+	r1, s1, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+	sig, err := asn1.Marshal(struct{ R, S *big.Int }{r1, s1})
+	require.NoError(t, err)
+	r.blobs[digestOf(payload)] = payload
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"layers": []map[string]interface{}{{
+			"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+			"digest":      digestOf(payload),
+			"annotations": map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+		}},
+	})
+	require.NoError(t, err)
+	r.manifests[strings.Replace(digest, ":", "-", 1)+".sig"] = manifest
+}
+
+func TestVerifier(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pubPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}))
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	imageManifest := []byte(`{"schemaVersion":2,"layers":[]}`)
+	digest := digestOf(imageManifest)
+	registry := &testRegistry{
+		manifests: map[string][]byte{"signed": imageManifest, "unsigned": []byte(`{"schemaVersion":2}`), digest: imageManifest},
+		blobs:     map[string][]byte{},
+	}
+	registry.sign(t, key, digest, digest)
+	srv := httptest.NewServer(registry)
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	v, err := NewVerifier([]string{pubPEM})
+	require.NoError(t, err)
+	v.Insecure = true
+
+	verified, err := v.Verify(context.TODO(), host+"/team/image:signed", nil)
+	require.NoError(t, err)
+	assert.Equal(t, digest, verified)
+
+	_, err = v.Verify(context.TODO(), host+"/team/image:unsigned", nil)
+	assert.Error(t, err)
+
+	_, err = v.Verify(context.TODO(), host+"/team/image:missing", nil)
+	assert.Error(t, err)
+
+	// Signature made by another key
+	registry.sign(t, otherKey, digest, digest)
+	_, err = v.Verify(context.TODO(), host+"/team/image:signed", nil)
+	assert.Error(t, err)
+
+	// Signature made for another image
+	registry.sign(t, key, digest, "sha256:0000")
+	_, err = v.Verify(context.TODO(), host+"/team/image:signed", nil)
+	assert.Error(t, err)
+}
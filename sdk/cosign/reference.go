@@ -0,0 +1,107 @@
+package cosign
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	dockerHubDomain    = "docker.io"
+	dockerHubAPIDomain = "registry-1.docker.io"
+)
+
+// Reference is a parsed container image reference.
+type Reference struct {
+	// Domain of the registry, ie. docker.io
+	Domain string
+	// Repository path in the registry, ie. library/ubuntu
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses a container image reference like "ubuntu:18.04", "registry.example.com/team/image:tag"
+// or "image@sha256:...". Images without domain are from the Docker Hub.
+func ParseReference(image string) (Reference, error) {
+	var ref Reference
+	name := strings.TrimSpace(image)
+	if name == "" {
+		return ref, fmt.Errorf("empty image reference")
+	}
+
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+		if !strings.HasPrefix(ref.Digest, "sha256:") {
+			return ref, fmt.Errorf("invalid digest in image reference %q", image)
+		}
+	}
+
+	// A tag is after the last colon only if it is not part of the domain port
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i+1:], "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Domain = parts[0]
+		ref.Repository = parts[1]
+	} else {
+		ref.Domain = dockerHubDomain
+		ref.Repository = name
+	}
+	if ref.Domain == "index.docker.io" {
+		ref.Domain = dockerHubDomain
+	}
+	if ref.Domain == dockerHubDomain && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	if ref.Repository == "" || strings.HasSuffix(ref.Repository, "/") || ref.Repository != strings.ToLower(ref.Repository) {
+		return ref, fmt.Errorf("invalid repository in image reference %q", image)
+	}
+	return ref, nil
+}
+
+// Name returns the full name of the image without tag or digest, ie. docker.io/library/ubuntu.
+func (r Reference) Name() string {
+	return r.Domain + "/" + r.Repository
+}
+
+// String returns the full reference of the image.
+func (r Reference) String() string {
+	s := r.Name()
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// apiDomain returns the domain that serves the registry API.
+func (r Reference) apiDomain() string {
+	if r.Domain == dockerHubDomain {
+		return dockerHubAPIDomain
+	}
+	return r.Domain
+}
+
+// MatchPrefix returns true if the image is in the given registry or repository prefix, ie. "docker.io/library"
+// or "registry.example.com". Matching is done on path boundaries.
+func (r Reference) MatchPrefix(prefix string) bool {
+	prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return false
+	}
+	if prefix == "index.docker.io" || strings.HasPrefix(prefix, "index.docker.io/") {
+		prefix = dockerHubDomain + strings.TrimPrefix(prefix, "index.docker.io")
+	}
+	name := r.Name()
+	return name == prefix || strings.HasPrefix(name, prefix+"/")
+}
@@ -0,0 +1,234 @@
+package cosign
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Accepted media types for manifests, the digest of an image is the digest of its manifest or index.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+// maxRegistryResponseSize limits the size of manifests and signature payloads read from registries.
+const maxRegistryResponseSize = 4 << 20
+
+// Credentials to access a private registry.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// ociManifest contains the fields of an OCI manifest used to read cosign signatures.
+type ociManifest struct {
+	Layers []struct {
+		MediaType   string            `json:"mediaType"`
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+// registryClient is a minimal client for the Docker Registry HTTP API V2.
+type registryClient struct {
+	httpClient  *http.Client
+	ref         Reference
+	credentials *Credentials
+	insecure    bool
+
+	mutex sync.Mutex
+	auth  string
+}
+
+// errNotFound is returned when a manifest or blob does not exist in the registry.
+var errNotFound = fmt.Errorf("not found")
+
+func (c *registryClient) url(path string) string {
+	scheme := "https"
+	if c.insecure {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, c.ref.apiDomain(), c.ref.Repository, path)
+}
+
+// get sends a request to the registry, and authenticates if challenged by the registry.
+func (c *registryClient) get(ctx context.Context, method, path string, accept []string) (*http.Response, error) {
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(method, c.url(path), nil)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+		c.mutex.Lock()
+		if c.auth != "" {
+			req.Header.Set("Authorization", c.auth)
+		}
+		c.mutex.Unlock()
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && i == 0:
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close() // nolint
+			if err := c.authenticate(ctx, challenge); err != nil {
+				return nil, err
+			}
+			continue
+		case resp.StatusCode == http.StatusNotFound:
+			resp.Body.Close() // nolint
+			return nil, errNotFound
+		case resp.StatusCode >= 300:
+			resp.Body.Close() // nolint
+			return nil, fmt.Errorf("registry %s returned status %d for %s", c.ref.Domain, resp.StatusCode, path)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("unauthorized access to %s on registry %s", c.ref.Repository, c.ref.Domain)
+}
+
+// authenticate handles basic and bearer token challenges of the registry.
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if c.credentials == nil {
+			return fmt.Errorf("registry %s requires credentials", c.ref.Domain)
+		}
+		c.setAuth("Basic " + base64.StdEncoding.EncodeToString([]byte(c.credentials.Username+":"+c.credentials.Password)))
+		return nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return fmt.Errorf("invalid token realm from registry %s", c.ref.Domain)
+		}
+		q := realm.Query()
+		if params["service"] != "" {
+			q.Set("service", params["service"])
+		}
+		q.Set("scope", "repository:"+c.ref.Repository+":pull")
+		realm.RawQuery = q.Encode()
+
+		req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return err
+		}
+		req = req.WithContext(ctx)
+		if c.credentials != nil && c.credentials.Username != "" {
+			req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("cannot get token from %s: %v", realm.Host, err)
+		}
+		defer resp.Body.Close() // nolint
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("cannot get token from %s: status %d", realm.Host, resp.StatusCode)
+		}
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxRegistryResponseSize)).Decode(&token); err != nil {
+			return fmt.Errorf("invalid token from %s: %v", realm.Host, err)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		c.setAuth("Bearer " + token.Token)
+		return nil
+	}
+	return fmt.Errorf("unsupported authentication %q from registry %s", scheme, c.ref.Domain)
+}
+
+func (c *registryClient) setAuth(auth string) {
+	c.mutex.Lock()
+	c.auth = auth
+	c.mutex.Unlock()
+}
+
+// parseChallenge parses a WWW-Authenticate header like: Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	scheme := strings.ToLower(parts[0])
+	if len(parts) < 2 {
+		return scheme, params
+	}
+	for _, p := range strings.Split(parts[1], ",") {
+		kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return scheme, params
+}
+
+// resolveDigest returns the digest of the manifest for given tag or digest.
+func (c *registryClient) resolveDigest(ctx context.Context, reference string) (string, error) {
+	resp, err := c.get(ctx, http.MethodGet, "manifests/"+reference, manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() // nolint
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRegistryResponseSize))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	if d := resp.Header.Get("Docker-Content-Digest"); d != "" && d != digest {
+		return "", fmt.Errorf("manifest digest mismatch for %s: %s != %s", reference, d, digest)
+	}
+	return digest, nil
+}
+
+// manifest returns the OCI manifest for given tag.
+func (c *registryClient) manifest(ctx context.Context, reference string) (*ociManifest, error) {
+	resp, err := c.get(ctx, http.MethodGet, "manifests/"+reference, manifestMediaTypes)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint
+	var m ociManifest
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxRegistryResponseSize)).Decode(&m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %v", reference, err)
+	}
+	return &m, nil
+}
+
+// blob returns the content of a blob and checks its digest.
+func (c *registryClient) blob(ctx context.Context, digest string) ([]byte, error) {
+	resp, err := c.get(ctx, http.MethodGet, "blobs/"+digest, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRegistryResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	if "sha256:"+hex.EncodeToString(sum[:]) != digest {
+		return nil, fmt.Errorf("blob digest mismatch for %s", digest)
+	}
+	return body, nil
+}
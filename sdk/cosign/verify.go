@@ -0,0 +1,190 @@
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureAnnotation is the annotation of a signature layer that contains the base64 signature of the payload.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// SignaturePayloadType is the type of the payload signed by cosign.
+	SignaturePayloadType = "cosign container image signature"
+)
+
+// SignaturePayload is the simple signing payload signed by cosign.
+type SignaturePayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional,omitempty"`
+}
+
+// ParsePublicKey parses a PEM encoded ECDSA or RSA public key as generated by "cosign generate-key-pair".
+func ParsePublicKey(s string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(s)))
+	if block == nil {
+		return nil, fmt.Errorf("invalid PEM public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type %T", key)
+}
+
+// Verifier checks that container images are signed with cosign by one of the trusted keys.
+type Verifier struct {
+	PublicKeys []crypto.PublicKey
+	HTTPClient *http.Client
+	// Insecure uses plain HTTP to contact registries, only for tests.
+	Insecure bool
+}
+
+// NewVerifier returns a verifier for given PEM encoded public keys.
+func NewVerifier(publicKeys []string) (*Verifier, error) {
+	v := &Verifier{HTTPClient: &http.Client{Timeout: 30 * time.Second}}
+	for _, s := range publicKeys {
+		k, err := ParsePublicKey(s)
+		if err != nil {
+			return nil, err
+		}
+		v.PublicKeys = append(v.PublicKeys, k)
+	}
+	if len(v.PublicKeys) == 0 {
+		return nil, fmt.Errorf("no public key given to verify signatures")
+	}
+	return v, nil
+}
+
+// Verify resolves the digest of the image and checks that it has a cosign signature made by one of
+// the trusted keys. It returns the verified digest.
+func (v *Verifier) Verify(ctx context.Context, image string, creds *Credentials) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+
+	c := &registryClient{httpClient: v.HTTPClient, ref: ref, credentials: creds, insecure: v.Insecure}
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+
+	reference := ref.Digest
+	if reference == "" {
+		reference = ref.Tag
+	}
+	digest, err := c.resolveDigest(ctx, reference)
+	if err != nil {
+		if err == errNotFound {
+			return "", fmt.Errorf("image %s not found", ref)
+		}
+		return "", fmt.Errorf("cannot resolve digest of image %s: %v", ref, err)
+	}
+	if ref.Digest != "" && ref.Digest != digest {
+		return "", fmt.Errorf("digest mismatch for image %s", ref)
+	}
+
+	// Cosign stores signatures in a manifest tagged from the image digest, ie. sha256-<hex>.sig
+	sigTag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	m, err := c.manifest(ctx, sigTag)
+	if err != nil {
+		if err == errNotFound {
+			return "", fmt.Errorf("no signature found for image %s", ref)
+		}
+		return "", fmt.Errorf("cannot get signatures of image %s: %v", ref, err)
+	}
+
+	var lastErr error
+	for _, l := range m.Layers {
+		sig, ok := l.Annotations[SignatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, err := c.blob(ctx, l.Digest)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if err := v.verifySignature(payload, sig, digest); err != nil {
+			lastErr = err
+			continue
+		}
+		return digest, nil
+	}
+	if lastErr != nil {
+		return "", fmt.Errorf("no valid signature for image %s: %v", ref, lastErr)
+	}
+	return "", fmt.Errorf("no signature found for image %s", ref)
+}
+
+// verifySignature checks the signature of a payload with the trusted keys and that the payload is for given digest.
+func (v *Verifier) verifySignature(payload []byte, signature, digest string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %v", err)
+	}
+	hash := sha256.Sum256(payload)
+
+	var verified bool
+	for _, k := range v.PublicKeys {
+		if verifyWithKey(k, hash[:], sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return fmt.Errorf("signature not made by a trusted key")
+	}
+
+	var p SignaturePayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("invalid signature payload: %v", err)
+	}
+	if p.Critical.Type != SignaturePayloadType {
+		return fmt.Errorf("invalid signature payload type %q", p.Critical.Type)
+	}
+	if p.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for digest %s", p.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+func verifyWithKey(key crypto.PublicKey, hash, sig []byte) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		var esig struct {
+			R, S *big.Int
+		}
+		if rest, err := asn1.Unmarshal(sig, &esig); err != nil || len(rest) != 0 {
+			return false
+		}
+		return ecdsa.Verify(k, hash, esig.R, esig.S)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash, sig) == nil
+	}
+	return false
+}
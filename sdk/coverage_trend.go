@@ -0,0 +1,31 @@
+package sdk
+
+import "fmt"
+
+// WorkflowCoverageTrend is the coverage of an application in a run of a workflow.
+type WorkflowCoverageTrend struct {
+	RunNumber       int64   `json:"run_number" db:"run_number" cli:"run,key"`
+	ApplicationName string  `json:"application_name" db:"application_name" cli:"application"`
+	Repository      string  `json:"repository" db:"repository" cli:"repository"`
+	Branch          string  `json:"branch" db:"branch" cli:"branch"`
+	Percent         float64 `json:"percent" db:"percent" cli:"coverage"`
+}
+
+// CheckCoverageDelta compares the coverage of a node run with the baseline of the default branch. It returns a
+// message describing the comparison, and an error if the coverage dropped more than maxDelta percentage points.
+func CheckCoverageDelta(cov WorkflowNodeRunCoverage, maxDelta float64) (string, error) {
+	current, ok := CoveragePercent(cov.Report)
+	if !ok {
+		return "", NewErrorFrom(ErrNotFound, "no coverage report for this run")
+	}
+	baseline, ok := CoveragePercent(cov.Trend.DefaultBranch)
+	if !ok {
+		return fmt.Sprintf("coverage: %.2f%%, no baseline on the default branch", current), nil
+	}
+	delta := current - baseline
+	msg := fmt.Sprintf("coverage: %.2f%%, default branch: %.2f%% (%+.2f%%)", current, baseline, delta)
+	if delta < -maxDelta {
+		return msg, fmt.Errorf("coverage dropped by %.2f%%, more than the maximum of %.2f%%", -delta, maxDelta)
+	}
+	return msg, nil
+}
@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/sguiheux/go-coverage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCoverageDelta(t *testing.T) {
+	_, err := CheckCoverageDelta(WorkflowNodeRunCoverage{}, 1)
+	require.Error(t, err)
+
+	cov := WorkflowNodeRunCoverage{Report: coverage.Report{TotalLines: 100, CoveredLines: 80}}
+	msg, err := CheckCoverageDelta(cov, 1)
+	require.NoError(t, err)
+	require.Equal(t, "coverage: 80.00%, no baseline on the default branch", msg)
+
+	cov.Trend.DefaultBranch = coverage.Report{TotalLines: 100, CoveredLines: 81}
+	msg, err = CheckCoverageDelta(cov, 1)
+	require.NoError(t, err)
+	require.Equal(t, "coverage: 80.00%, default branch: 81.00% (-1.00%)", msg)
+
+	cov.Trend.DefaultBranch = coverage.Report{TotalLines: 100, CoveredLines: 85}
+	_, err = CheckCoverageDelta(cov, 1)
+	require.Error(t, err)
+	_, err = CheckCoverageDelta(cov, 5)
+	require.NoError(t, err)
+}
@@ -0,0 +1,183 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Rollout strategies of a deployment.
+const (
+	RolloutStrategyBlueGreen = "blue-green"
+	RolloutStrategyCanary    = "canary"
+)
+
+// Metric providers watched during a rollout.
+const (
+	RolloutMetricProviderPrometheus = "prometheus"
+)
+
+// Operators comparing the metric of a rollout to its threshold.
+const (
+	RolloutMetricAbove = "above"
+	RolloutMetricBelow = "below"
+)
+
+// Default steps of the rollout strategies, the new version is deployed without traffic for a blue/green deployment.
+var (
+	defaultRolloutStepsBlueGreen = []int{0, 100}
+	defaultRolloutStepsCanary    = []int{10, 50, 100}
+)
+
+// DeploymentRollout is the rollout of a deployment on an environment. The traffic is shifted to the new version
+// step by step, the deployment is rolled back if the metric crosses its threshold while baking.
+type DeploymentRollout struct {
+	Strategy string `json:"strategy" yaml:"strategy" jsonschema_description:"The rollout strategy: blue-green or canary."`
+	// Steps are the percentages of the traffic sent to the new version, the last one must be 100
+	Steps []int `json:"steps,omitempty" yaml:"steps,omitempty" jsonschema_description:"The percentages of the traffic sent to the new version at each step, the last one must be 100."`
+	// BakeTime is the delay in seconds before the next step, while the metric is watched
+	BakeTime int                      `json:"bake_time,omitempty" yaml:"bake_time,omitempty" jsonschema_description:"The delay in seconds before the next step."`
+	Metric   *DeploymentRolloutMetric `json:"metric,omitempty" yaml:"metric,omitempty" jsonschema_description:"The metric that triggers an automatic rollback."`
+}
+
+// DeploymentRolloutMetric is a metric that triggers the rollback of a deployment when it crosses its threshold.
+type DeploymentRolloutMetric struct {
+	Provider  string  `json:"provider" yaml:"provider" jsonschema_description:"The metric provider: prometheus."`
+	URL       string  `json:"url" yaml:"url" jsonschema_description:"The URL of the metric provider."`
+	Query     string  `json:"query" yaml:"query" jsonschema_description:"The query returning the value of the metric."`
+	Threshold float64 `json:"threshold" yaml:"threshold" jsonschema_description:"The value that triggers the rollback."`
+	Operator  string  `json:"operator,omitempty" yaml:"operator,omitempty" jsonschema_description:"above (default) or below the threshold."`
+}
+
+// IsValid returns an error if the rollout is not valid.
+func (r DeploymentRollout) IsValid() error {
+	if r.Strategy != RolloutStrategyBlueGreen && r.Strategy != RolloutStrategyCanary {
+		return NewErrorFrom(ErrWrongRequest, "invalid rollout strategy %q, should be %s or %s", r.Strategy, RolloutStrategyBlueGreen, RolloutStrategyCanary)
+	}
+	previous := -1
+	for _, s := range r.Steps {
+		if s < 0 || s > 100 || s <= previous {
+			return NewErrorFrom(ErrWrongRequest, "invalid rollout steps %v, should be increasing percentages", r.Steps)
+		}
+		previous = s
+	}
+	if len(r.Steps) > 0 && previous != 100 {
+		return NewErrorFrom(ErrWrongRequest, "invalid rollout steps %v, the last step should be 100", r.Steps)
+	}
+	if r.BakeTime < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid rollout bake time %d", r.BakeTime)
+	}
+	if r.Metric != nil {
+		if r.Metric.Provider != RolloutMetricProviderPrometheus {
+			return NewErrorFrom(ErrWrongRequest, "invalid rollout metric provider %q", r.Metric.Provider)
+		}
+		if r.Metric.URL == "" || r.Metric.Query == "" {
+			return NewErrorFrom(ErrWrongRequest, "the url and the query of the rollout metric are mandatory")
+		}
+		if r.Metric.Operator != "" && r.Metric.Operator != RolloutMetricAbove && r.Metric.Operator != RolloutMetricBelow {
+			return NewErrorFrom(ErrWrongRequest, "invalid rollout metric operator %q, should be %s or %s", r.Metric.Operator, RolloutMetricAbove, RolloutMetricBelow)
+		}
+	}
+	return nil
+}
+
+// RolloutSteps returns the steps of the rollout, or the default steps of its strategy.
+func (r DeploymentRollout) RolloutSteps() []int {
+	if len(r.Steps) > 0 {
+		return r.Steps
+	}
+	if r.Strategy == RolloutStrategyBlueGreen {
+		return defaultRolloutStepsBlueGreen
+	}
+	return defaultRolloutStepsCanary
+}
+
+// Breached returns true if the value of the metric crosses its threshold.
+func (m DeploymentRolloutMetric) Breached(value float64) bool {
+	if m.Operator == RolloutMetricBelow {
+		return value < m.Threshold
+	}
+	return value > m.Threshold
+}
+
+// Scan rollout.
+func (r *DeploymentRollout) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, r), "cannot unmarshal DeploymentRollout")
+}
+
+// Value returns driver.Value from rollout.
+func (r DeploymentRollout) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	return j, WrapError(err, "cannot marshal DeploymentRollout")
+}
+
+// ParametersFromRollout returns the variables given to the jobs of an environment with a rollout.
+func ParametersFromRollout(r DeploymentRollout) map[string]string {
+	steps := make([]string, len(r.RolloutSteps()))
+	for i, s := range r.RolloutSteps() {
+		steps[i] = strconv.Itoa(s)
+	}
+	res := map[string]string{
+		"cds.rollout.strategy":  r.Strategy,
+		"cds.rollout.steps":     strings.Join(steps, ","),
+		"cds.rollout.bake_time": strconv.Itoa(r.BakeTime),
+	}
+	if r.Metric != nil {
+		res["cds.rollout.metric.provider"] = r.Metric.Provider
+		res["cds.rollout.metric.url"] = r.Metric.URL
+		res["cds.rollout.metric.query"] = r.Metric.Query
+		res["cds.rollout.metric.threshold"] = strconv.FormatFloat(r.Metric.Threshold, 'f', -1, 64)
+		res["cds.rollout.metric.operator"] = r.Metric.Operator
+	}
+	return res
+}
+
+// RolloutFromParameters returns the rollout given to a job, or nil if its environment has no rollout.
+func RolloutFromParameters(params []Parameter) (*DeploymentRollout, error) {
+	strategy := ParameterValue(params, "cds.rollout.strategy")
+	if strategy == "" {
+		return nil, nil
+	}
+	r := DeploymentRollout{Strategy: strategy}
+	for _, s := range strings.Split(ParameterValue(params, "cds.rollout.steps"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		step, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid rollout step %q", s)
+		}
+		r.Steps = append(r.Steps, step)
+	}
+	if s := ParameterValue(params, "cds.rollout.bake_time"); s != "" {
+		bake, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid rollout bake time %q", s)
+		}
+		r.BakeTime = bake
+	}
+	if provider := ParameterValue(params, "cds.rollout.metric.provider"); provider != "" {
+		threshold, err := strconv.ParseFloat(ParameterValue(params, "cds.rollout.metric.threshold"), 64)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid rollout metric threshold %q", ParameterValue(params, "cds.rollout.metric.threshold"))
+		}
+		r.Metric = &DeploymentRolloutMetric{
+			Provider:  provider,
+			URL:       ParameterValue(params, "cds.rollout.metric.url"),
+			Query:     ParameterValue(params, "cds.rollout.metric.query"),
+			Threshold: threshold,
+			Operator:  ParameterValue(params, "cds.rollout.metric.operator"),
+		}
+	}
+	return &r, r.IsValid()
+}
@@ -0,0 +1,66 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentRolloutIsValid(t *testing.T) {
+	require.NoError(t, DeploymentRollout{Strategy: RolloutStrategyCanary}.IsValid())
+	require.NoError(t, DeploymentRollout{Strategy: RolloutStrategyBlueGreen, Steps: []int{0, 100}, BakeTime: 60}.IsValid())
+	require.Error(t, DeploymentRollout{Strategy: "rolling"}.IsValid())
+	require.Error(t, DeploymentRollout{Strategy: RolloutStrategyCanary, Steps: []int{50, 10, 100}}.IsValid())
+	require.Error(t, DeploymentRollout{Strategy: RolloutStrategyCanary, Steps: []int{10, 50}}.IsValid())
+	require.Error(t, DeploymentRollout{Strategy: RolloutStrategyCanary, BakeTime: -1}.IsValid())
+	require.Error(t, DeploymentRollout{Strategy: RolloutStrategyCanary, Metric: &DeploymentRolloutMetric{Provider: "datadog"}}.IsValid())
+	require.Error(t, DeploymentRollout{Strategy: RolloutStrategyCanary, Metric: &DeploymentRolloutMetric{Provider: RolloutMetricProviderPrometheus}}.IsValid())
+}
+
+func TestDeploymentRolloutSteps(t *testing.T) {
+	require.Equal(t, []int{0, 100}, DeploymentRollout{Strategy: RolloutStrategyBlueGreen}.RolloutSteps())
+	require.Equal(t, []int{10, 50, 100}, DeploymentRollout{Strategy: RolloutStrategyCanary}.RolloutSteps())
+	require.Equal(t, []int{25, 100}, DeploymentRollout{Strategy: RolloutStrategyCanary, Steps: []int{25, 100}}.RolloutSteps())
+}
+
+func TestDeploymentRolloutMetricBreached(t *testing.T) {
+	m := DeploymentRolloutMetric{Threshold: 0.05}
+	require.True(t, m.Breached(0.1))
+	require.False(t, m.Breached(0.01))
+	m.Operator = RolloutMetricBelow
+	require.True(t, m.Breached(0.01))
+	require.False(t, m.Breached(0.1))
+}
+
+func TestRolloutParameters(t *testing.T) {
+	r, err := RolloutFromParameters(nil)
+	require.NoError(t, err)
+	require.True(t, r == nil)
+
+	rollout := DeploymentRollout{
+		Strategy: RolloutStrategyCanary,
+		Steps:    []int{20, 100},
+		BakeTime: 300,
+		Metric: &DeploymentRolloutMetric{
+			Provider:  RolloutMetricProviderPrometheus,
+			URL:       "http://prometheus:9090",
+			Query:     `sum(rate(http_requests_total{code=~"5.."}[5m]))`,
+			Threshold: 0.5,
+		},
+	}
+	var params []Parameter
+	for k, v := range ParametersFromRollout(rollout) {
+		params = append(params, Parameter{Name: k, Value: v})
+	}
+	r, err = RolloutFromParameters(params)
+	require.NoError(t, err)
+	require.Equal(t, rollout, *r)
+
+	params = []Parameter{{Name: "cds.rollout.strategy", Value: RolloutStrategyBlueGreen}, {Name: "cds.rollout.steps", Value: "0,100"}}
+	r, err = RolloutFromParameters(params)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 100}, r.Steps)
+
+	_, err = RolloutFromParameters([]Parameter{{Name: "cds.rollout.strategy", Value: RolloutStrategyCanary}, {Name: "cds.rollout.steps", Value: "ten"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"sort"
+	"time"
+)
+
+// DORADeployment is a run of a production deployment node, used to compute the DORA metrics.
+type DORADeployment struct {
+	WorkflowName string    `json:"workflow_name" db:"workflow_name"`
+	NodeName     string    `json:"node_name" db:"node_name"`
+	Number       int64     `json:"run_number" db:"run_number"`
+	Status       string    `json:"status" db:"status"`
+	VCSHash      string    `json:"vcs_hash" db:"vcs_hash"`
+	FirstBuild   time.Time `json:"first_build" db:"first_build"`
+	Start        time.Time `json:"start" db:"start"`
+	Done         time.Time `json:"done" db:"done"`
+}
+
+// DORAMetrics are the four DORA metrics of a project or of a workflow. The durations are in seconds.
+type DORAMetrics struct {
+	WorkflowName        string  `json:"workflow_name,omitempty" cli:"workflow,key"`
+	Deployments         int64   `json:"deployments" cli:"deployments"`
+	Failures            int64   `json:"failures" cli:"failures"`
+	DeploymentFrequency float64 `json:"deployment_frequency" cli:"deployments_per_day"`
+	LeadTime            int64   `json:"lead_time" cli:"lead_time"`
+	ChangeFailureRate   float64 `json:"change_failure_rate" cli:"change_failure_rate"`
+	TimeToRestore       int64   `json:"time_to_restore" cli:"time_to_restore"`
+}
+
+// DORAReport contains the DORA metrics of a project and of each of its workflows over a period.
+type DORAReport struct {
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Project   DORAMetrics   `json:"project"`
+	Workflows []DORAMetrics `json:"workflows"`
+}
+
+// ComputeDORAReport computes the DORA metrics of the production deployments done between from and to:
+//   - deployment frequency: the number of successful deployments per day
+//   - lead time for changes: the median time between the first build of a commit and its successful deployment
+//   - change failure rate: the ratio of failed deployments
+//   - time to restore: the median time between a failed deployment and the next successful deployment of the same node
+func ComputeDORAReport(ds []DORADeployment, from, to time.Time) DORAReport {
+	sorted := make([]DORADeployment, len(ds))
+	copy(sorted, ds)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Done.Before(sorted[j].Done) })
+
+	byWorkflow := make(map[string][]DORADeployment)
+	var names []string
+	for _, d := range sorted {
+		if _, ok := byWorkflow[d.WorkflowName]; !ok {
+			names = append(names, d.WorkflowName)
+		}
+		byWorkflow[d.WorkflowName] = append(byWorkflow[d.WorkflowName], d)
+	}
+	sort.Strings(names)
+
+	days := to.Sub(from).Hours() / 24
+	r := DORAReport{
+		From:      from,
+		To:        to,
+		Project:   computeDORAMetrics(sorted, days),
+		Workflows: make([]DORAMetrics, 0, len(names)),
+	}
+	for _, name := range names {
+		m := computeDORAMetrics(byWorkflow[name], days)
+		m.WorkflowName = name
+		r.Workflows = append(r.Workflows, m)
+	}
+	return r
+}
+
+// computeDORAMetrics computes the metrics of deployments sorted by date.
+func computeDORAMetrics(ds []DORADeployment, days float64) DORAMetrics {
+	var m DORAMetrics
+	var leadTimes, restoreTimes []time.Duration
+	failedSince := make(map[string]time.Time)
+	for _, d := range ds {
+		node := d.WorkflowName + "/" + d.NodeName
+		switch d.Status {
+		case StatusSuccess:
+			m.Deployments++
+			if !d.FirstBuild.IsZero() && d.Done.After(d.FirstBuild) {
+				leadTimes = append(leadTimes, d.Done.Sub(d.FirstBuild))
+			}
+			if t, ok := failedSince[node]; ok {
+				restoreTimes = append(restoreTimes, d.Done.Sub(t))
+				delete(failedSince, node)
+			}
+		case StatusFail:
+			m.Deployments++
+			m.Failures++
+			if _, ok := failedSince[node]; !ok {
+				failedSince[node] = d.Done
+			}
+		}
+	}
+	if days > 0 {
+		m.DeploymentFrequency = float64(m.Deployments-m.Failures) / days
+	}
+	if m.Deployments > 0 {
+		m.ChangeFailureRate = float64(m.Failures) / float64(m.Deployments)
+	}
+	m.LeadTime = int64(medianDuration(leadTimes).Seconds())
+	m.TimeToRestore = int64(medianDuration(restoreTimes).Seconds())
+	return m
+}
+
+func medianDuration(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	if len(ds)%2 == 1 {
+		return ds[len(ds)/2]
+	}
+	return (ds[len(ds)/2-1] + ds[len(ds)/2]) / 2
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDORAReport(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * 24 * time.Hour)
+	at := func(h int) time.Time { return from.Add(time.Duration(h) * time.Hour) }
+
+	ds := []DORADeployment{
+		{WorkflowName: "api", NodeName: "deploy", Number: 1, Status: StatusSuccess, FirstBuild: at(0), Done: at(2)},
+		{WorkflowName: "api", NodeName: "deploy", Number: 3, Status: StatusFail, FirstBuild: at(20), Done: at(24)},
+		{WorkflowName: "api", NodeName: "deploy", Number: 2, Status: StatusFail, FirstBuild: at(10), Done: at(12)},
+		{WorkflowName: "api", NodeName: "deploy", Number: 4, Status: StatusSuccess, FirstBuild: at(30), Done: at(36)},
+		{WorkflowName: "ui", NodeName: "deploy", Number: 1, Status: StatusSuccess, FirstBuild: at(0), Done: at(4)},
+		{WorkflowName: "ui", NodeName: "deploy", Number: 2, Status: StatusStopped, FirstBuild: at(5), Done: at(6)},
+	}
+
+	r := ComputeDORAReport(ds, from, to)
+	require.Equal(t, from, r.From)
+	require.Equal(t, to, r.To)
+
+	require.Equal(t, int64(5), r.Project.Deployments)
+	require.Equal(t, int64(2), r.Project.Failures)
+	require.Equal(t, 0.3, r.Project.DeploymentFrequency)
+	require.Equal(t, 0.4, r.Project.ChangeFailureRate)
+	// lead times: 2h, 6h, 4h
+	require.Equal(t, int64(4*3600), r.Project.LeadTime)
+	// restored 24h after the first failure
+	require.Equal(t, int64(24*3600), r.Project.TimeToRestore)
+
+	require.Len(t, r.Workflows, 2)
+	require.Equal(t, "api", r.Workflows[0].WorkflowName)
+	require.Equal(t, int64(4), r.Workflows[0].Deployments)
+	require.Equal(t, 0.5, r.Workflows[0].ChangeFailureRate)
+	require.Equal(t, int64(4*3600), r.Workflows[0].LeadTime)
+	require.Equal(t, "ui", r.Workflows[1].WorkflowName)
+	require.Equal(t, int64(1), r.Workflows[1].Deployments)
+	require.Equal(t, int64(0), r.Workflows[1].TimeToRestore)
+
+	empty := ComputeDORAReport(nil, from, to)
+	require.Equal(t, int64(0), empty.Project.Deployments)
+	require.Len(t, empty.Workflows, 0)
+}
@@ -56,18 +56,34 @@ func AllDownloadableResources() []DownloadableResource {
 	return all
 }
 
-// getArchName returns 386 for "386", "i386", "i686"
+// GetArchName returns 386 for "386", "i386", "i686"
 // amd64 for "amd64", "x86_64" (uname -m)
-func getArchName(a string) string {
+// arm64 for "arm64", "aarch64" (uname -m)
+// arm for "arm", "armv6l", "armv7l" (uname -m)
+func GetArchName(a string) string {
 	switch a {
 	case "386", "i386", "i686":
 		return "386"
 	case "amd64", "x86_64":
 		return "amd64"
+	case "arm64", "aarch64":
+		return "arm64"
+	case "arm", "armv6l", "armv7l":
+		return "arm"
 	}
 	return a
 }
 
+// IsSupportedArch returns true if binaries are built for given architecture.
+func IsSupportedArch(arch string) bool {
+	for _, a := range supportedARCH {
+		if a == arch {
+			return true
+		}
+	}
+	return false
+}
+
 // GetArtifactFilename returns artifact name cds-name-os-arch-variant
 // this name is used on Github Releases
 func GetArtifactFilename(name, os, arch, variant string) string {
@@ -82,7 +98,7 @@ func GetArtifactFilename(name, os, arch, variant string) string {
 	if os == "windows" {
 		suffix = ".exe"
 	}
-	return fmt.Sprintf("%s%s-%s-%s%s%s", prefix, name, os, getArchName(arch), variant, suffix)
+	return fmt.Sprintf("%s%s-%s-%s%s%s", prefix, name, os, GetArchName(arch), variant, suffix)
 }
 
 // AllDownloadableResourcesWithAvailability set flag Available on downloads list
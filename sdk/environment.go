@@ -6,15 +6,16 @@ import (
 
 // Environment represent a deployment environment
 type Environment struct {
-	ID             int64            `json:"id" yaml:"-"`
-	Name           string           `json:"name" yaml:"name" cli:"name,key"`
-	Variable       []Variable       `json:"variables,omitempty" yaml:"variables"`
-	ProjectID      int64            `json:"-" yaml:"-"`
-	ProjectKey     string           `json:"project_key" yaml:"-"`
-	LastModified   int64            `json:"last_modified"`
-	Keys           []EnvironmentKey `json:"keys"`
-	Usage          *Usage           `json:"usage,omitempty"`
-	FromRepository string           `json:"from_repository,omitempty"`
+	ID             int64              `json:"id" yaml:"-"`
+	Name           string             `json:"name" yaml:"name" cli:"name,key"`
+	Variable       []Variable         `json:"variables,omitempty" yaml:"variables"`
+	ProjectID      int64              `json:"-" yaml:"-"`
+	ProjectKey     string             `json:"project_key" yaml:"-"`
+	LastModified   int64              `json:"last_modified"`
+	Keys           []EnvironmentKey   `json:"keys"`
+	Usage          *Usage             `json:"usage,omitempty"`
+	FromRepository string             `json:"from_repository,omitempty"`
+	Rollout        *DeploymentRollout `json:"rollout,omitempty" yaml:"rollout,omitempty"`
 }
 
 // EnvironmentVariableAudit represents an audit on an environment variable
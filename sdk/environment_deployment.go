@@ -0,0 +1,64 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EnvironmentDeployment is the last successful deployment of an application on an environment, it is the last
+// successful run of a pipeline linked to both the application and the environment.
+type EnvironmentDeployment struct {
+	ID                int64               `json:"id" db:"id" cli:"-"`
+	ProjectID         int64               `json:"project_id" db:"project_id" cli:"-"`
+	ApplicationID     int64               `json:"application_id" db:"application_id" cli:"-"`
+	ApplicationName   string              `json:"application_name" db:"application_name" cli:"application,key"`
+	EnvironmentID     int64               `json:"environment_id" db:"environment_id" cli:"-"`
+	EnvironmentName   string              `json:"environment_name" db:"environment_name" cli:"environment,key"`
+	WorkflowID        int64               `json:"workflow_id" db:"workflow_id" cli:"-"`
+	WorkflowName      string              `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	WorkflowRunID     int64               `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64               `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"-"`
+	RunNumber         int64               `json:"run_number" db:"run_number" cli:"run"`
+	NodeName          string              `json:"node_name" db:"node_name" cli:"node"`
+	Version           string              `json:"version" db:"version" cli:"version"`
+	VCSRepository     string              `json:"vcs_repository,omitempty" db:"vcs_repository" cli:"-"`
+	VCSBranch         string              `json:"vcs_branch,omitempty" db:"vcs_branch" cli:"branch"`
+	VCSTag            string              `json:"vcs_tag,omitempty" db:"vcs_tag" cli:"tag"`
+	VCSHash           string              `json:"vcs_hash,omitempty" db:"vcs_hash" cli:"commit"`
+	Artifacts         DeploymentArtifacts `json:"artifacts" db:"artifacts" cli:"-"`
+	DeployedBy        string              `json:"deployed_by" db:"deployed_by" cli:"deployed_by"`
+	Deployed          time.Time           `json:"deployed" db:"deployed" cli:"deployed"`
+}
+
+// DeploymentArtifact is an artifact of the workflow run of a deployment.
+type DeploymentArtifact struct {
+	Name string `json:"name" db:"name"`
+	Tag  string `json:"tag" db:"tag"`
+}
+
+// DeploymentArtifacts is the list of the artifacts of a deployment.
+type DeploymentArtifacts []DeploymentArtifact
+
+// Scan artifacts.
+func (a *DeploymentArtifacts) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, a), "cannot unmarshal DeploymentArtifacts")
+}
+
+// Value returns driver.Value from artifacts.
+func (a DeploymentArtifacts) Value() (driver.Value, error) {
+	if a == nil {
+		a = DeploymentArtifacts{}
+	}
+	j, err := json.Marshal(a)
+	return j, WrapError(err, "cannot marshal DeploymentArtifacts")
+}
@@ -0,0 +1,22 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentArtifactsScanValue(t *testing.T) {
+	var a DeploymentArtifacts
+	v, err := a.Value()
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(v.([]byte)))
+
+	v, err = DeploymentArtifacts{{Name: "api.tar.gz", Tag: "1.2.0"}}.Value()
+	require.NoError(t, err)
+	require.NoError(t, a.Scan(v))
+	require.Equal(t, DeploymentArtifacts{{Name: "api.tar.gz", Tag: "1.2.0"}}, a)
+
+	require.NoError(t, a.Scan(nil))
+	require.Error(t, a.Scan(42))
+}
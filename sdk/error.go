@@ -201,6 +201,12 @@ var (
 	ErrInvalidWorkerModelNamePattern                 = Error{ID: 185, Status: http.StatusBadRequest}
 	ErrWorkflowAsCodeResync                          = Error{ID: 186, Status: http.StatusForbidden}
 	ErrWorkflowNodeNameDuplicate                     = Error{ID: 187, Status: http.StatusBadRequest}
+	ErrRequestEntityTooLarge                         = Error{ID: 188, Status: http.StatusRequestEntityTooLarge}
+	ErrChatOpsUserNotLinked                          = Error{ID: 189, Status: http.StatusForbidden}
+	ErrWorkerModelUntrusted                          = Error{ID: 190, Status: http.StatusForbidden}
+	ErrServiceIncompatible                           = Error{ID: 191, Status: http.StatusConflict}
+	ErrQuotaExceeded                                 = Error{ID: 193, Status: http.StatusForbidden}
+	ErrWorkflowRunArchived                           = Error{ID: 194, Status: http.StatusConflict}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -384,6 +390,12 @@ var errorsAmericanEnglish = map[int]string{
 	ErrInvalidJobRequirementNetworkAccess.ID:            "Invalid job requirement: network requirement must contains ':'. Example: golang.org:http, golang.org:443",
 	ErrWorkflowAsCodeResync.ID:                          "You cannot resynchronize an as-code workflow",
 	ErrWorkflowNodeNameDuplicate.ID:                     "You cannot have same name for different pipelines in your workflow",
+	ErrRequestEntityTooLarge.ID:                         "Request body is too large",
+	ErrChatOpsUserNotLinked.ID:                          "No CDS user linked to this chat account",
+	ErrWorkerModelUntrusted.ID:                          "Worker model image is not allowed by the trust policy",
+	ErrServiceIncompatible.ID:                           "Service contract version is not supported by the API",
+	ErrQuotaExceeded.ID:                                 "The quota is exceeded",
+	ErrWorkflowRunArchived.ID:                           "The workflow run is archived, it should be rehydrated first",
 }
 
 var errorsFrench = map[int]string{
@@ -566,6 +578,12 @@ var errorsFrench = map[int]string{
 	ErrInvalidJobRequirementNetworkAccess.ID:            "Pré-requis de job invalide: Le pré-requis network doit contenir un ':'. Exemple: golang.org:http, golang.org:443",
 	ErrWorkflowAsCodeResync.ID:                          "Impossible de resynchroniser un workflow en mode as-code",
 	ErrWorkflowNodeNameDuplicate.ID:                     "Vous ne pouvez pas avoir plusieurs fois le même nom de pipeline dans votre workflow",
+	ErrRequestEntityTooLarge.ID:                         "Le corps de la requête est trop volumineux",
+	ErrChatOpsUserNotLinked.ID:                          "Aucun utilisateur CDS n'est lié à ce compte de messagerie",
+	ErrWorkerModelUntrusted.ID:                          "L'image du modèle de worker n'est pas autorisée par la politique de confiance",
+	ErrServiceIncompatible.ID:                           "La version du contrat du service n'est pas supportée par l'API",
+	ErrQuotaExceeded.ID:                                 "Le quota est dépassé",
+	ErrWorkflowRunArchived.ID:                           "L'exécution du workflow est archivée, elle doit d'abord être réhydratée",
 }
 
 var errorsLanguages = []map[int]string{
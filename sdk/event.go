@@ -82,6 +82,8 @@ type EventRunWorkflowNode struct {
 	HookLog               string                    `json:"log,omitempty"`
 	NodeType              string                    `json:"node_type,omitempty"`
 	GerritChange          *GerritChangeEvent        `json:"gerrit_change,omitempty"`
+	Failures              *WorkflowNodeRunFailures  `json:"failures,omitempty"`
+	Artifacts             []string                  `json:"artifacts,omitempty"`
 	EventIntegrations     []int64                   `json:"event_integrations_id,omitempty"`
 }
 
@@ -93,6 +95,34 @@ type GerritChangeEvent struct {
 	Revision   string `json:"revision,omitempty"`
 	Report     string `json:"report,omitempty"`
 	URL        string `json:"url,omitempty"`
+	// Labels voted with the result of the node run, Verified is voted when empty
+	Labels   map[string]string            `json:"labels,omitempty"`
+	Comments []WorkflowNodeRunCodeComment `json:"comments,omitempty"`
+}
+
+// WorkflowNodeRunFailures contains the failed steps and tests of a node run, it is sent to VCS servers that report
+// detailed results like GitHub check runs.
+type WorkflowNodeRunFailures struct {
+	Steps []WorkflowNodeRunFailedStep `json:"steps,omitempty"`
+	Tests []WorkflowNodeRunFailedTest `json:"tests,omitempty"`
+}
+
+// WorkflowNodeRunFailedStep is a failed step of a node run with the end of its logs.
+type WorkflowNodeRunFailedStep struct {
+	Stage      string `json:"stage"`
+	Job        string `json:"job"`
+	StepOrder  int    `json:"step_order"`
+	StepName   string `json:"step_name"`
+	LogExcerpt string `json:"log_excerpt,omitempty"`
+}
+
+// WorkflowNodeRunFailedTest is a failed JUnit test case of a node run.
+type WorkflowNodeRunFailedTest struct {
+	TestSuite string `json:"test_suite"`
+	TestCase  string `json:"test_case"`
+	Classname string `json:"classname,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Details   string `json:"details,omitempty"`
 }
 
 // EventRunWorkflowOutgoingHook contains event data for a workflow outgoing hook run
@@ -109,10 +139,11 @@ type EventRunWorkflowOutgoingHook struct {
 
 // EventRunWorkflowJob contains event data for a workflow job node run
 type EventRunWorkflowJob struct {
-	ID     int64  `json:"id,omitempty"`
-	Status string `json:"status,omitempty"`
-	Start  int64  `json:"start,omitempty"`
-	Done   int64  `json:"done,omitempty"`
+	ID           int64   `json:"id,omitempty"`
+	Status       string  `json:"status,omitempty"`
+	Start        int64   `json:"start,omitempty"`
+	Done         int64   `json:"done,omitempty"`
+	ExecGroupIDs []int64 `json:"exec_group_ids,omitempty"`
 }
 
 // EventRunWorkflow contains event data for a workflow run
@@ -0,0 +1,13 @@
+package sdk
+
+// EventChatOpsCommand represents the event when a command was received from a chatops provider.
+type EventChatOpsCommand struct {
+	Provider       string `json:"provider"`
+	ExternalUserID string `json:"external_user_id"`
+	Command        string `json:"command"`
+	ProjectKey     string `json:"project_key"`
+	WorkflowName   string `json:"workflow_name"`
+	RunNumber      int64  `json:"run_number"`
+	NodeName       string `json:"node_name"`
+	Error          string `json:"error,omitempty"`
+}
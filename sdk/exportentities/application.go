@@ -11,6 +11,7 @@ type Application struct {
 	Description          string                              `json:"description,omitempty" yaml:"description,omitempty"`
 	VCSServer            string                              `json:"vcs_server,omitempty" yaml:"vcs_server,omitempty" jsonschema_description:"Name of the vcs (github, gitlab...), vcs should be linked to target project."`
 	RepositoryName       string                              `json:"repo,omitempty" yaml:"repo,omitempty" jsonschema_description:"Name of the repository including project owner, ex: ovh/cds."`
+	RepositoryURL        string                              `json:"repo_url,omitempty" yaml:"repo_url,omitempty" jsonschema_description:"URL of a git repository without repositories manager (https or ssh), ex: https://git.example.com/ovh/cds.git."`
 	Variables            map[string]VariableValue            `json:"variables,omitempty" yaml:"variables,omitempty"`
 	Keys                 map[string]KeyValue                 `json:"keys,omitempty" yaml:"keys,omitempty"`
 	VCSConnectionType    string                              `json:"vcs_connection_type,omitempty" yaml:"vcs_connection_type,omitempty" jsonschema_description:"Connection type should be 'ssh' or 'https'."`
@@ -45,6 +46,8 @@ func NewApplication(app sdk.Application, keys []EncryptedKey) (a Application, er
 	if app.VCSServer != "" {
 		a.VCSServer = app.VCSServer
 		a.RepositoryName = app.RepositoryFullname
+	} else if app.RepositoryURL != "" {
+		a.RepositoryURL = app.RepositoryURL
 	}
 
 	a.Variables = make(map[string]VariableValue, len(app.Variable))
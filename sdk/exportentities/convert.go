@@ -0,0 +1,155 @@
+package exportentities
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Conversion is the result of the conversion of the CI files of another tool to CDS files. The constructs that
+// can't be converted are reported as warnings.
+type Conversion struct {
+	Workflows map[string]Workflow
+	Pipelines map[string]PipelineV1
+	Warnings  []string
+}
+
+func newConversion() *Conversion {
+	return &Conversion{
+		Workflows: make(map[string]Workflow),
+		Pipelines: make(map[string]PipelineV1),
+	}
+}
+
+func (c *Conversion) warn(file, format string, args ...interface{}) {
+	w := fmt.Sprintf("%s: %s", file, fmt.Sprintf(format, args...))
+	if !sdk.IsInArray(w, c.Warnings) {
+		c.Warnings = append(c.Warnings, w)
+	}
+}
+
+// Files returns the content of the CDS files of the conversion by file name.
+func (c Conversion) Files() (map[string]string, error) {
+	res := make(map[string]string, len(c.Workflows)+len(c.Pipelines))
+	for name, w := range c.Workflows {
+		btes, err := Marshal(w, FormatYAML)
+		if err != nil {
+			return nil, err
+		}
+		res[fmt.Sprintf(PullWorkflowName, name)] = string(btes)
+	}
+	for name, p := range c.Pipelines {
+		btes, err := Marshal(p, FormatYAML)
+		if err != nil {
+			return nil, err
+		}
+		res[fmt.Sprintf(PullPipelineName, name)] = string(btes)
+	}
+	return res, nil
+}
+
+var convertNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// convertName returns a valid CDS name from the name of a file or of a job of another tool.
+func convertName(s string) string {
+	s = strings.TrimSuffix(strings.TrimSuffix(s, ".yml"), ".yaml")
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		s = s[i+1:]
+	}
+	return strings.Trim(convertNameRegexp.ReplaceAllString(s, "-"), "-.")
+}
+
+// convertDecode decodes a value of a yaml document that was unmarshalled in an interface.
+func convertDecode(v interface{}, i interface{}) error {
+	if v == nil {
+		return nil
+	}
+	btes, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(btes, i)
+}
+
+// convertLevels returns the stage of each job from its dependencies, a job is in the stage after the ones of all
+// the jobs it depends on.
+func convertLevels(deps map[string][]string) (map[string]int, error) {
+	levels := make(map[string]int, len(deps))
+	var level func(name string, stack []string) (int, error)
+	level = func(name string, stack []string) (int, error) {
+		if l, ok := levels[name]; ok {
+			return l, nil
+		}
+		if sdk.IsInArray(name, stack) {
+			return 0, fmt.Errorf("job %s depends on itself", name)
+		}
+		var l int
+		for _, d := range deps[name] {
+			if _, ok := deps[d]; !ok {
+				return 0, fmt.Errorf("job %s depends on unknown job %s", name, d)
+			}
+			dl, err := level(d, append(stack, name))
+			if err != nil {
+				return 0, err
+			}
+			if dl+1 > l {
+				l = dl + 1
+			}
+		}
+		levels[name] = l
+		return l, nil
+	}
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := level(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return levels, nil
+}
+
+// convertMatrix returns all the combinations of the values of a matrix, the keys are given in order.
+func convertMatrix(keys []string, values map[string][]string) []map[string]string {
+	res := []map[string]string{{}}
+	for _, k := range keys {
+		var next []map[string]string
+		for _, comb := range res {
+			for _, v := range values[k] {
+				c := make(map[string]string, len(comb)+1)
+				for ck, cv := range comb {
+					c[ck] = cv
+				}
+				c[k] = v
+				next = append(next, c)
+			}
+		}
+		res = next
+	}
+	return res
+}
+
+// convertScript returns the lines of a script step, with the variables exported before the commands.
+func convertScript(env map[string]string, dir, script string) []string {
+	var lines []string
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("export %s=%q", k, env[k]))
+	}
+	if dir != "" {
+		lines = append(lines, fmt.Sprintf("cd %s", dir))
+	}
+	return append(lines, strings.Split(strings.TrimRight(script, "\n"), "\n")...)
+}
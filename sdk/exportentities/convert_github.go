@@ -0,0 +1,469 @@
+package exportentities
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+)
+
+type githubWorkflow struct {
+	Name string            `yaml:"name"`
+	On   interface{}       `yaml:"on"`
+	Env  map[string]string `yaml:"env"`
+	Jobs yaml.MapSlice     `yaml:"jobs"`
+}
+
+type githubJob struct {
+	Name            string                   `yaml:"name"`
+	Needs           interface{}              `yaml:"needs"`
+	RunsOn          interface{}              `yaml:"runs-on"`
+	Container       interface{}              `yaml:"container"`
+	Services        map[string]githubService `yaml:"services"`
+	Env             map[string]string        `yaml:"env"`
+	If              string                   `yaml:"if"`
+	Uses            string                   `yaml:"uses"`
+	Outputs         map[string]string        `yaml:"outputs"`
+	TimeoutMinutes  interface{}              `yaml:"timeout-minutes"`
+	ContinueOnError interface{}              `yaml:"continue-on-error"`
+	Strategy        struct {
+		Matrix yaml.MapSlice `yaml:"matrix"`
+	} `yaml:"strategy"`
+	Defaults struct {
+		Run struct {
+			WorkingDirectory string `yaml:"working-directory"`
+		} `yaml:"run"`
+	} `yaml:"defaults"`
+	Steps []githubStep `yaml:"steps"`
+}
+
+type githubService struct {
+	Image string            `yaml:"image"`
+	Env   map[string]string `yaml:"env"`
+}
+
+type githubStep struct {
+	Name             string            `yaml:"name"`
+	If               string            `yaml:"if"`
+	Uses             string            `yaml:"uses"`
+	Run              string            `yaml:"run"`
+	Shell            string            `yaml:"shell"`
+	With             map[string]string `yaml:"with"`
+	Env              map[string]string `yaml:"env"`
+	WorkingDirectory string            `yaml:"working-directory"`
+	ContinueOnError  interface{}       `yaml:"continue-on-error"`
+}
+
+type githubInput struct {
+	Description string      `yaml:"description"`
+	Default     interface{} `yaml:"default"`
+	Type        string      `yaml:"type"`
+}
+
+// githubContexts are the properties of the github context with an equivalent CDS variable.
+var githubContexts = map[string]string{
+	"github.sha":        "{{.git.hash}}",
+	"github.ref_name":   "{{.git.branch}}",
+	"github.head_ref":   "{{.git.branch}}",
+	"github.ref":        "refs/heads/{{.git.branch}}",
+	"github.repository": "{{.git.repository}}",
+	"github.run_number": "{{.cds.version}}",
+	"github.workspace":  "{{.cds.workspace}}",
+	"github.actor":      "{{.cds.triggered_by.username}}",
+	"runner.temp":       "{{.cds.workspace}}",
+}
+
+// githubSetupBinaries are the setup actions replaced by a binary requirement.
+var githubSetupBinaries = map[string]string{
+	"actions/setup-go":     "go",
+	"actions/setup-node":   "node",
+	"actions/setup-python": "python",
+	"actions/setup-java":   "java",
+	"actions/setup-dotnet": "dotnet",
+}
+
+var githubExpressionRegexp = regexp.MustCompile(`\$\{\{\s*(.*?)\s*\}\}`)
+
+// ConvertGitHubActions converts GitHub Actions workflow files, given by file name, to a CDS workflow and a CDS
+// pipeline for each file. The jobs are grouped in stages from their dependencies.
+func ConvertGitHubActions(files map[string]string) (*Conversion, error) {
+	c := newConversion()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, file := range names {
+		var gw githubWorkflow
+		if err := yaml.Unmarshal([]byte(files[file]), &gw); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to parse %s: %v", file, err)
+		}
+		name := convertName(file)
+		if name == "" {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid file name %s", file)
+		}
+		if err := c.convertGitHubWorkflow(file, name, gw); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to convert %s: %v", file, err)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Conversion) convertGitHubWorkflow(file, name string, gw githubWorkflow) error {
+	w := Workflow{
+		Name:         name,
+		Description:  gw.Name,
+		Version:      WorkflowVersion1,
+		PipelineName: name,
+	}
+	p := PipelineV1{
+		Name:        name,
+		Description: gw.Name,
+		Version:     PipelineVersion1,
+	}
+
+	triggers, err := githubTriggers(gw.On)
+	if err != nil {
+		return err
+	}
+	for _, t := range triggers {
+		switch t.Key {
+		case "schedule":
+			var crons []struct {
+				Cron string `yaml:"cron"`
+			}
+			if err := convertDecode(t.Value, &crons); err != nil {
+				return err
+			}
+			for _, cr := range crons {
+				w.PipelineHooks = append(w.PipelineHooks, HookEntry{
+					Model: sdk.SchedulerModelName,
+					Config: map[string]string{
+						sdk.SchedulerModelCron:     cr.Cron,
+						sdk.SchedulerModelTimezone: "UTC",
+					},
+				})
+			}
+		case "workflow_dispatch":
+			var dispatch struct {
+				Inputs map[string]githubInput `yaml:"inputs"`
+			}
+			if err := convertDecode(t.Value, &dispatch); err != nil {
+				return err
+			}
+			for k, in := range dispatch.Inputs {
+				if p.Parameters == nil {
+					p.Parameters = make(map[string]ParameterValue)
+				}
+				pv := ParameterValue{Type: sdk.StringParameter, Description: in.Description}
+				if in.Type == "boolean" {
+					pv.Type = sdk.BooleanParameter
+				}
+				if in.Default != nil {
+					pv.DefaultValue = fmt.Sprintf("%v", in.Default)
+				}
+				p.Parameters[k] = pv
+			}
+		case "push", "pull_request":
+			c.warn(file, "trigger %s is not converted, attach an application with a repository to the workflow to run it on each push", t.Key)
+		default:
+			c.warn(file, "trigger %s is not converted", t.Key)
+		}
+	}
+
+	jobs := make(map[string]githubJob, len(gw.Jobs))
+	deps := make(map[string][]string, len(gw.Jobs))
+	var ids []string
+	for _, item := range gw.Jobs {
+		id := fmt.Sprintf("%v", item.Key)
+		var j githubJob
+		if err := convertDecode(item.Value, &j); err != nil {
+			return fmt.Errorf("job %s: %v", id, err)
+		}
+		switch needs := j.Needs.(type) {
+		case string:
+			deps[id] = []string{needs}
+		case []interface{}:
+			for _, n := range needs {
+				deps[id] = append(deps[id], fmt.Sprintf("%v", n))
+			}
+		default:
+			deps[id] = nil
+		}
+		jobs[id] = j
+		ids = append(ids, id)
+	}
+
+	levels, err := convertLevels(deps)
+	if err != nil {
+		return err
+	}
+	var nbStages int
+	for _, l := range levels {
+		if l+1 > nbStages {
+			nbStages = l + 1
+		}
+	}
+	if nbStages > 1 {
+		for i := 0; i < nbStages; i++ {
+			p.Stages = append(p.Stages, fmt.Sprintf("Stage %d", i+1))
+		}
+	}
+
+	for _, id := range ids {
+		j := jobs[id]
+		var stage string
+		if nbStages > 1 {
+			stage = p.Stages[levels[id]]
+		}
+		converted, err := c.convertGitHubJob(file, id, stage, gw.Env, j)
+		if err != nil {
+			return fmt.Errorf("job %s: %v", id, err)
+		}
+		p.Jobs = append(p.Jobs, converted...)
+	}
+
+	c.Workflows[name] = w
+	c.Pipelines[name] = p
+	return nil
+}
+
+// githubTriggers returns the events of the on property of a workflow, that can be a string, a list or a map.
+func githubTriggers(on interface{}) (yaml.MapSlice, error) {
+	switch t := on.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return yaml.MapSlice{{Key: t}}, nil
+	case []interface{}:
+		var res yaml.MapSlice
+		for _, e := range t {
+			res = append(res, yaml.MapItem{Key: fmt.Sprintf("%v", e)})
+		}
+		return res, nil
+	}
+	btes, err := yaml.Marshal(on)
+	if err != nil {
+		return nil, err
+	}
+	var res yaml.MapSlice
+	if err := yaml.Unmarshal(btes, &res); err != nil {
+		return nil, fmt.Errorf("invalid triggers: %v", err)
+	}
+	for i := range res {
+		res[i].Key = fmt.Sprintf("%v", res[i].Key)
+	}
+	return res, nil
+}
+
+func (c *Conversion) convertGitHubJob(file, id, stage string, workflowEnv map[string]string, j githubJob) ([]Job, error) {
+	name := j.Name
+	if name == "" {
+		name = id
+	}
+	if j.Uses != "" {
+		c.warn(file, "job %s calls the reusable workflow %s, it is not converted", id, j.Uses)
+		return nil, nil
+	}
+	if j.If != "" {
+		c.warn(file, "condition %q of job %s is not converted", j.If, id)
+	}
+	if j.RunsOn != nil {
+		c.warn(file, "runs-on of job %s is not converted, add a model requirement to the job", id)
+	}
+	if j.Container != nil {
+		c.warn(file, "container of job %s is not converted, add a model requirement to the job", id)
+	}
+	if j.TimeoutMinutes != nil {
+		c.warn(file, "timeout-minutes of job %s is not converted", id)
+	}
+	if len(j.Outputs) > 0 {
+		c.warn(file, "outputs of job %s are not converted", id)
+	}
+
+	// matrix values are replaced in the jobs generated for each combination
+	var keys []string
+	values := make(map[string][]string)
+	for _, item := range j.Strategy.Matrix {
+		k := fmt.Sprintf("%v", item.Key)
+		if k == "include" || k == "exclude" {
+			c.warn(file, "matrix %s of job %s is not converted", k, id)
+			continue
+		}
+		list, ok := item.Value.([]interface{})
+		if !ok {
+			c.warn(file, "matrix %s of job %s is not a list, it is not converted", k, id)
+			continue
+		}
+		keys = append(keys, k)
+		for _, v := range list {
+			values[k] = append(values[k], fmt.Sprintf("%v", v))
+		}
+	}
+
+	var res []Job
+	for _, matrix := range convertMatrix(keys, values) {
+		jo := Job{Name: name, Stage: stage}
+		if len(keys) > 0 {
+			parts := make([]string, len(keys))
+			for i, k := range keys {
+				parts[i] = fmt.Sprintf("%s=%s", k, matrix[k])
+			}
+			jo.Name = fmt.Sprintf("%s (%s)", name, strings.Join(parts, ", "))
+		}
+		if githubTrue(j.ContinueOnError) {
+			jo.Optional = &sdk.True
+		}
+
+		expr := func(s string) string { return c.convertGitHubExpressions(file, s, matrix) }
+
+		serviceNames := make([]string, 0, len(j.Services))
+		for k := range j.Services {
+			serviceNames = append(serviceNames, k)
+		}
+		sort.Strings(serviceNames)
+		for _, k := range serviceNames {
+			s := j.Services[k]
+			value := expr(s.Image)
+			envKeys := make([]string, 0, len(s.Env))
+			for ek := range s.Env {
+				envKeys = append(envKeys, ek)
+			}
+			sort.Strings(envKeys)
+			for _, ek := range envKeys {
+				value += fmt.Sprintf(" %s=%s", ek, expr(s.Env[ek]))
+			}
+			jo.Requirements = append(jo.Requirements, Requirement{Service: ServiceRequirement{Name: k, Value: value}})
+		}
+
+		for i, s := range j.Steps {
+			step, binary := c.convertGitHubStep(file, fmt.Sprintf("%s step %d", id, i+1), workflowEnv, j, s, expr)
+			if binary != "" {
+				var found bool
+				for _, r := range jo.Requirements {
+					found = found || r.Binary == binary
+				}
+				if !found {
+					jo.Requirements = append(jo.Requirements, Requirement{Binary: binary})
+				}
+			}
+			if step != nil {
+				jo.Steps = append(jo.Steps, *step)
+			}
+		}
+		res = append(res, jo)
+	}
+	return res, nil
+}
+
+// convertGitHubStep returns the CDS step of a GitHub step, or the binary required by a setup action.
+func (c *Conversion) convertGitHubStep(file, ref string, workflowEnv map[string]string, j githubJob, s githubStep, expr func(string) string) (*Step, string) {
+	step := Step{Name: expr(s.Name)}
+	if s.If != "" {
+		c.warn(file, "condition %q of %s is not converted", s.If, ref)
+	}
+	if githubTrue(s.ContinueOnError) {
+		step.Optional = &sdk.True
+	}
+
+	if s.Run != "" {
+		if s.Shell != "" && s.Shell != "bash" && s.Shell != "sh" {
+			c.warn(file, "shell %s of %s is not converted, the script is run with the default shell", s.Shell, ref)
+		}
+		env := make(map[string]string)
+		for _, e := range []map[string]string{workflowEnv, j.Env, s.Env} {
+			for k, v := range e {
+				env[k] = expr(v)
+			}
+		}
+		dir := s.WorkingDirectory
+		if dir == "" {
+			dir = j.Defaults.Run.WorkingDirectory
+		}
+		step.Script = convertScript(env, expr(dir), expr(s.Run))
+		return &step, ""
+	}
+
+	uses := s.Uses
+	if i := strings.Index(uses, "@"); i > 0 {
+		uses = uses[:i]
+	}
+	switch {
+	case uses == "actions/checkout":
+		checkout := StepCheckout("{{.cds.workspace}}")
+		if path := s.With["path"]; path != "" {
+			checkout = StepCheckout("{{.cds.workspace}}/" + expr(path))
+		}
+		step.Checkout = &checkout
+	case uses == "actions/upload-artifact":
+		path := expr(s.With["path"])
+		if strings.Contains(strings.TrimSpace(path), "\n") {
+			c.warn(file, "only the first path of %s is uploaded", ref)
+			path = strings.Split(strings.TrimSpace(path), "\n")[0]
+		}
+		step.ArtifactUpload = &StepArtifactUpload{Path: path, Tag: "{{.cds.version}}"}
+	case uses == "actions/download-artifact":
+		path := expr(s.With["path"])
+		if path == "" {
+			path = "."
+		}
+		step.ArtifactDownload = &StepArtifactDownload{Path: path, Tag: "{{.cds.version}}"}
+	case githubSetupBinaries[uses] != "":
+		for k, v := range s.With {
+			if strings.HasSuffix(k, "-version") {
+				c.warn(file, "%s %s of %s must be installed on the worker model", k, expr(v), ref)
+			}
+		}
+		return nil, githubSetupBinaries[uses]
+	default:
+		c.warn(file, "action %s of %s is not converted", s.Uses, ref)
+		return nil, ""
+	}
+	return &step, ""
+}
+
+// convertGitHubExpressions replaces the GitHub expressions by the CDS variables, the matrix values are replaced by
+// their value in the given combination.
+func (c *Conversion) convertGitHubExpressions(file, s string, matrix map[string]string) string {
+	return githubExpressionRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		e := githubExpressionRegexp.FindStringSubmatch(m)[1]
+		if v, ok := githubContexts[e]; ok {
+			return v
+		}
+		parts := strings.SplitN(e, ".", 2)
+		if len(parts) == 2 && sdk.NamePatternRegex.MatchString(strings.Replace(parts[1], "-", "_", -1)) {
+			switch parts[0] {
+			case "matrix":
+				if v, ok := matrix[parts[1]]; ok {
+					return v
+				}
+			case "env":
+				return "${" + parts[1] + "}"
+			case "secrets":
+				if parts[1] == "GITHUB_TOKEN" {
+					break
+				}
+				c.warn(file, "secret %s must be added to the project as a password variable named %s", parts[1], parts[1])
+				return "{{.cds.proj." + parts[1] + "}}"
+			case "vars":
+				c.warn(file, "variable %s must be added to the project", parts[1])
+				return "{{.cds.proj." + parts[1] + "}}"
+			case "inputs":
+				return "{{.cds.pip." + parts[1] + "}}"
+			}
+		}
+		c.warn(file, "expression %s is not converted", m)
+		return m
+	})
+}
+
+func githubTrue(v interface{}) bool {
+	b, ok := v.(bool)
+	return ok && b
+}
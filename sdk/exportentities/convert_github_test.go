@@ -0,0 +1,124 @@
+package exportentities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const githubTestWorkflow = `name: CI
+on:
+  push:
+    branches: [master]
+  schedule:
+  - cron: "0 2 * * *"
+  workflow_dispatch:
+    inputs:
+      debug:
+        description: Debug mode
+        type: boolean
+        default: false
+env:
+  GO111MODULE: "on"
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        go: ["1.12", "1.13"]
+        os: [linux]
+    services:
+      postgres:
+        image: postgres:9.6
+        env:
+          POSTGRES_PASSWORD: ${{ secrets.PG_PASSWORD }}
+    steps:
+    - uses: actions/checkout@v2
+    - uses: actions/setup-go@v1
+      with:
+        go-version: ${{ matrix.go }}
+    - uses: actions/cache@v1
+    - name: Test
+      run: |
+        go test ./...
+        echo ${{ github.sha }}
+      env:
+        GOOS: ${{ matrix.os }}
+      working-directory: engine
+  build:
+    name: Build binary
+    needs: test
+    steps:
+    - run: make build
+      continue-on-error: true
+    - uses: actions/upload-artifact@v1
+      with:
+        path: dist/cds
+  release:
+    needs: [test, build]
+    if: github.ref == 'refs/heads/master'
+    steps:
+    - uses: actions/download-artifact@v1
+    - run: echo ${{ inputs.debug }} ${{ steps.foo.outputs.bar }}
+`
+
+func TestConvertGitHubActions(t *testing.T) {
+	c, err := ConvertGitHubActions(map[string]string{".github/workflows/ci.yml": githubTestWorkflow})
+	require.NoError(t, err)
+
+	require.Len(t, c.Workflows, 1)
+	w := c.Workflows["ci"]
+	assert.Equal(t, "ci", w.PipelineName)
+	require.Len(t, w.PipelineHooks, 1)
+	assert.Equal(t, sdk.SchedulerModelName, w.PipelineHooks[0].Model)
+	assert.Equal(t, "0 2 * * *", w.PipelineHooks[0].Config[sdk.SchedulerModelCron])
+
+	require.Len(t, c.Pipelines, 1)
+	p := c.Pipelines["ci"]
+	assert.Equal(t, []string{"Stage 1", "Stage 2", "Stage 3"}, p.Stages)
+	assert.Equal(t, sdk.BooleanParameter, p.Parameters["debug"].Type)
+	assert.Equal(t, "false", p.Parameters["debug"].DefaultValue)
+
+	require.Len(t, p.Jobs, 4)
+	assert.Equal(t, "test (go=1.12, os=linux)", p.Jobs[0].Name)
+	assert.Equal(t, "test (go=1.13, os=linux)", p.Jobs[1].Name)
+	assert.Equal(t, "Stage 1", p.Jobs[1].Stage)
+	assert.Equal(t, []Requirement{
+		{Service: ServiceRequirement{Name: "postgres", Value: "postgres:9.6 POSTGRES_PASSWORD={{.cds.proj.PG_PASSWORD}}"}},
+		{Binary: "go"},
+	}, p.Jobs[1].Requirements)
+	require.Len(t, p.Jobs[1].Steps, 2)
+	require.NotNil(t, p.Jobs[1].Steps[0].Checkout)
+	assert.Equal(t, StepCheckout("{{.cds.workspace}}"), *p.Jobs[1].Steps[0].Checkout)
+	assert.Equal(t, []string{
+		`export GO111MODULE="on"`,
+		`export GOOS="linux"`,
+		"cd engine",
+		"go test ./...",
+		"echo {{.git.hash}}",
+	}, p.Jobs[1].Steps[1].Script)
+
+	assert.Equal(t, "Build binary", p.Jobs[2].Name)
+	assert.Equal(t, "Stage 2", p.Jobs[2].Stage)
+	require.Len(t, p.Jobs[2].Steps, 2)
+	assert.NotNil(t, p.Jobs[2].Steps[0].Optional)
+	assert.Equal(t, &StepArtifactUpload{Path: "dist/cds", Tag: "{{.cds.version}}"}, p.Jobs[2].Steps[1].ArtifactUpload)
+
+	assert.Equal(t, "Stage 3", p.Jobs[3].Stage)
+	assert.Equal(t, `echo {{.cds.pip.debug}} ${{ steps.foo.outputs.bar }}`, p.Jobs[3].Steps[1].Script.([]string)[1])
+
+	assert.Contains(t, c.Warnings, ".github/workflows/ci.yml: action actions/cache@v1 of test step 3 is not converted")
+	assert.Contains(t, c.Warnings, ".github/workflows/ci.yml: expression ${{ steps.foo.outputs.bar }} is not converted")
+	assert.Contains(t, c.Warnings, ".github/workflows/ci.yml: condition \"github.ref == 'refs/heads/master'\" of job release is not converted")
+
+	files, err := c.Files()
+	require.NoError(t, err)
+	assert.Contains(t, files, "ci.yml")
+	assert.Contains(t, files, "ci.pip.yml")
+
+	_, err = ConvertGitHubActions(map[string]string{"ci.yml": "jobs:\n  a:\n    needs: b\n"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,508 @@
+package exportentities
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+)
+
+type gitlabConfig struct {
+	Stages    []string               `yaml:"stages"`
+	Types     []string               `yaml:"types"`
+	Variables map[string]interface{} `yaml:"variables"`
+	Include   interface{}            `yaml:"include"`
+	Workflow  interface{}            `yaml:"workflow"`
+	Default   map[string]interface{} `yaml:"default"`
+}
+
+type gitlabJob struct {
+	Stage        string                 `yaml:"stage"`
+	Image        interface{}            `yaml:"image"`
+	Services     []interface{}          `yaml:"services"`
+	Variables    map[string]interface{} `yaml:"variables"`
+	BeforeScript interface{}            `yaml:"before_script"`
+	Script       interface{}            `yaml:"script"`
+	AfterScript  interface{}            `yaml:"after_script"`
+	Artifacts    struct {
+		Paths     []string               `yaml:"paths"`
+		Reports   map[string]interface{} `yaml:"reports"`
+		When      string                 `yaml:"when"`
+		Untracked bool                   `yaml:"untracked"`
+	} `yaml:"artifacts"`
+	Dependencies interface{} `yaml:"dependencies"`
+	Needs        interface{} `yaml:"needs"`
+	Rules        interface{} `yaml:"rules"`
+	Only         interface{} `yaml:"only"`
+	Except       interface{} `yaml:"except"`
+	When         string      `yaml:"when"`
+	AllowFailure interface{} `yaml:"allow_failure"`
+	Tags         []string    `yaml:"tags"`
+	Parallel     interface{} `yaml:"parallel"`
+}
+
+// gitlabKeywords are the top level keys of a GitLab CI file that are not jobs.
+var gitlabKeywords = []string{"image", "services", "stages", "types", "before_script", "after_script", "variables", "cache", "include", "default", "workflow"}
+
+// gitlabDefaults are the top level keys that are the default values of the jobs.
+var gitlabDefaults = []string{"image", "services", "before_script", "after_script", "cache"}
+
+// gitlabUnsupported are the keys of a job without equivalent.
+var gitlabUnsupported = []string{"cache", "coverage", "environment", "inherit", "interruptible", "release", "resource_group", "retry", "secrets", "timeout", "trigger"}
+
+var gitlabDefaultStages = []string{".pre", "build", "test", "deploy", ".post"}
+
+// gitlabVariables are the predefined variables of GitLab with an equivalent CDS variable.
+var gitlabVariables = map[string]string{
+	"CI_COMMIT_SHA":        "{{.git.hash}}",
+	"CI_COMMIT_SHORT_SHA":  "{{.git.hash.short}}",
+	"CI_COMMIT_BEFORE_SHA": "{{.git.hash.before}}",
+	"CI_COMMIT_REF_NAME":   "{{.git.branch}}",
+	"CI_COMMIT_BRANCH":     "{{.git.branch}}",
+	"CI_COMMIT_TAG":        "{{.git.tag}}",
+	"CI_COMMIT_MESSAGE":    "{{.git.message}}",
+	"CI_COMMIT_AUTHOR":     "{{.git.author}}",
+	"CI_PROJECT_PATH":      "{{.git.repository}}",
+	"CI_REPOSITORY_URL":    "{{.git.url}}",
+	"CI_PROJECT_DIR":       "{{.cds.workspace}}",
+	"CI_BUILDS_DIR":        "{{.cds.workspace}}",
+	"CI_PIPELINE_ID":       "{{.cds.version}}",
+	"CI_PIPELINE_IID":      "{{.cds.version}}",
+	"CI_JOB_NAME":          "{{.cds.job}}",
+	"CI_JOB_STAGE":         "{{.cds.stage}}",
+	"CI_ENVIRONMENT_NAME":  "{{.cds.environment}}",
+	"GITLAB_USER_LOGIN":    "{{.cds.triggered_by.username}}",
+	"GITLAB_USER_EMAIL":    "{{.cds.triggered_by.email}}",
+	"GITLAB_USER_NAME":     "{{.cds.triggered_by.fullname}}",
+}
+
+var gitlabVariableRegexp = regexp.MustCompile(`\$\{((?:CI|GITLAB)_[A-Z0-9_]+)\}|\$((?:CI|GITLAB)_[A-Z0-9_]+)\b`)
+
+// ConvertGitLabCI converts GitLab CI files, given by file name, to a CDS workflow and a CDS pipeline for each file.
+// The stages of the file are the stages of the pipeline.
+func ConvertGitLabCI(files map[string]string) (*Conversion, error) {
+	c := newConversion()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, file := range names {
+		var doc yaml.MapSlice
+		if err := yaml.Unmarshal([]byte(files[file]), &doc); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to parse %s: %v", file, err)
+		}
+		name := convertName(file)
+		if name == "" {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid file name %s", file)
+		}
+		// the merge keys are only resolved when decoding a map, the slice gives the order of the jobs
+		var content map[string]interface{}
+		if err := yaml.Unmarshal([]byte(files[file]), &content); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to parse %s: %v", file, err)
+		}
+		if err := c.convertGitLabCI(file, name, doc, content); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to convert %s: %v", file, err)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Conversion) convertGitLabCI(file, name string, doc yaml.MapSlice, content map[string]interface{}) error {
+	var cfg gitlabConfig
+	if err := convertDecode(content, &cfg); err != nil {
+		return err
+	}
+	if cfg.Include != nil {
+		c.warn(file, "included files are not converted, convert them with this file")
+	}
+	if cfg.Workflow != nil {
+		c.warn(file, "workflow rules are not converted")
+	}
+	c.warn(file, "the pipeline is not triggered on each push, attach an application with a repository to the workflow")
+
+	stages := cfg.Stages
+	if len(stages) == 0 {
+		stages = cfg.Types
+	}
+	if len(stages) == 0 {
+		stages = gitlabDefaultStages
+	} else {
+		stages = append(append([]string{".pre"}, stages...), ".post")
+	}
+
+	// the default values of the jobs are the legacy top level keys, overridden by the default section
+	defaults := make(map[interface{}]interface{})
+	raws := make(map[string]map[interface{}]interface{})
+	var ids []string
+	for _, item := range doc {
+		k := fmt.Sprintf("%v", item.Key)
+		if sdk.IsInArray(k, gitlabKeywords) {
+			if sdk.IsInArray(k, gitlabDefaults) {
+				defaults[k] = content[k]
+			}
+			continue
+		}
+		m, ok := content[k].(map[interface{}]interface{})
+		if !ok {
+			// hidden keys can be used for anchors of any type
+			if strings.HasPrefix(k, ".") {
+				continue
+			}
+			return fmt.Errorf("job %s: invalid definition", k)
+		}
+		raws[k] = m
+		if !strings.HasPrefix(k, ".") {
+			ids = append(ids, k)
+		}
+	}
+	for k, v := range cfg.Default {
+		defaults[k] = v
+	}
+
+	w := Workflow{
+		Name:         name,
+		Version:      WorkflowVersion1,
+		PipelineName: name,
+	}
+	p := PipelineV1{
+		Name:    name,
+		Version: PipelineVersion1,
+	}
+
+	jobs := make(map[string]gitlabJob, len(ids))
+	for _, id := range ids {
+		raw, err := gitlabExtends(raws, id, nil)
+		if err != nil {
+			return err
+		}
+		for k, v := range defaults {
+			if _, ok := raw[k]; !ok {
+				raw[k] = v
+			}
+		}
+		for _, k := range gitlabUnsupported {
+			if _, ok := raw[k]; ok {
+				c.warn(file, "%s of job %s is not converted", k, id)
+			}
+		}
+		var j gitlabJob
+		if err := convertDecode(raw, &j); err != nil {
+			return fmt.Errorf("job %s: %v", id, err)
+		}
+		if j.Stage == "" {
+			j.Stage = "test"
+		}
+		if !sdk.IsInArray(j.Stage, stages) {
+			return fmt.Errorf("job %s: unknown stage %s", id, j.Stage)
+		}
+		jobs[id] = j
+	}
+
+	// the jobs download the artifacts of the jobs of the previous stages
+	var download bool
+	for _, stage := range stages {
+		var used, uploads bool
+		for _, id := range ids {
+			j := jobs[id]
+			if j.Stage != stage {
+				continue
+			}
+			used = true
+			uploads = uploads || len(j.Artifacts.Paths) > 0
+			converted, err := c.convertGitLabJob(file, id, cfg.Variables, download, j)
+			if err != nil {
+				return fmt.Errorf("job %s: %v", id, err)
+			}
+			p.Jobs = append(p.Jobs, converted...)
+		}
+		if used {
+			p.Stages = append(p.Stages, stage)
+		}
+		download = download || uploads
+	}
+	for i := range p.Jobs {
+		if len(p.Stages) == 1 {
+			p.Jobs[i].Stage = ""
+		}
+	}
+	if len(p.Stages) == 1 {
+		p.Stages = nil
+	}
+
+	c.Workflows[name] = w
+	c.Pipelines[name] = p
+	return nil
+}
+
+// gitlabExtends returns the definition of a job merged with the definitions of the jobs it extends.
+func gitlabExtends(raws map[string]map[interface{}]interface{}, id string, stack []string) (map[interface{}]interface{}, error) {
+	if sdk.IsInArray(id, stack) {
+		return nil, fmt.Errorf("job %s extends itself", id)
+	}
+	raw, ok := raws[id]
+	if !ok {
+		return nil, fmt.Errorf("job %s extends unknown job %s", stack[len(stack)-1], id)
+	}
+	var parents []string
+	switch e := raw["extends"].(type) {
+	case string:
+		parents = []string{e}
+	case []interface{}:
+		for _, p := range e {
+			parents = append(parents, fmt.Sprintf("%v", p))
+		}
+	}
+	res := make(map[interface{}]interface{})
+	for _, parent := range parents {
+		m, err := gitlabExtends(raws, parent, append(stack, id))
+		if err != nil {
+			return nil, err
+		}
+		gitlabMerge(res, m)
+	}
+	gitlabMerge(res, raw)
+	delete(res, "extends")
+	return res, nil
+}
+
+// gitlabMerge merges the maps of src in the ones of dst, the other values of src override the ones of dst.
+func gitlabMerge(dst, src map[interface{}]interface{}) {
+	for k, v := range src {
+		sm, ok := v.(map[interface{}]interface{})
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		dm, ok := dst[k].(map[interface{}]interface{})
+		if !ok {
+			dm = make(map[interface{}]interface{})
+		} else {
+			copied := make(map[interface{}]interface{}, len(dm))
+			gitlabMerge(copied, dm)
+			dm = copied
+		}
+		gitlabMerge(dm, sm)
+		dst[k] = dm
+	}
+}
+
+func (c *Conversion) convertGitLabJob(file, id string, globalVariables map[string]interface{}, download bool, j gitlabJob) ([]Job, error) {
+	if j.Script == nil {
+		c.warn(file, "job %s has no script, it is not converted", id)
+		return nil, nil
+	}
+	if j.Rules != nil || j.Only != nil || j.Except != nil {
+		c.warn(file, "rules of job %s are not converted, the job is always run", id)
+	}
+	if j.Needs != nil {
+		c.warn(file, "needs of job %s are not converted, the job is run after the jobs of the previous stages", id)
+	}
+	if len(j.Tags) > 0 {
+		c.warn(file, "tags of job %s are not converted, add a model requirement to the job", id)
+	}
+	if j.Image != nil {
+		c.warn(file, "image of job %s is not converted, add a model requirement to the job", id)
+	}
+	if j.Artifacts.Untracked {
+		c.warn(file, "untracked artifacts of job %s are not converted", id)
+	}
+	switch j.When {
+	case "", "on_success", "always":
+	default:
+		c.warn(file, "when %s of job %s is not converted, the job is run when the previous stages succeed", j.When, id)
+	}
+	if deps, ok := j.Dependencies.([]interface{}); ok && len(deps) == 0 {
+		download = false
+	}
+
+	// parallel matrix values are variables of the jobs generated for each combination
+	var combinations []map[string]string
+	switch parallel := j.Parallel.(type) {
+	case nil:
+		combinations = []map[string]string{{}}
+	case map[interface{}]interface{}:
+		var matrix []map[string]interface{}
+		if err := convertDecode(parallel["matrix"], &matrix); err != nil {
+			return nil, err
+		}
+		for _, m := range matrix {
+			var keys []string
+			values := make(map[string][]string)
+			for k, v := range m {
+				keys = append(keys, k)
+				if list, ok := v.([]interface{}); ok {
+					for _, e := range list {
+						values[k] = append(values[k], fmt.Sprintf("%v", e))
+					}
+				} else {
+					values[k] = []string{fmt.Sprintf("%v", v)}
+				}
+			}
+			sort.Strings(keys)
+			combinations = append(combinations, convertMatrix(keys, values)...)
+		}
+	default:
+		c.warn(file, "parallel of job %s is not converted", id)
+		combinations = []map[string]string{{}}
+	}
+
+	var res []Job
+	for _, combination := range combinations {
+		jo := Job{Name: id, Stage: j.Stage}
+		if len(combination) > 0 {
+			keys := make([]string, 0, len(combination))
+			for k := range combination {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			parts := make([]string, len(keys))
+			for i, k := range keys {
+				parts[i] = fmt.Sprintf("%s=%s", k, combination[k])
+			}
+			jo.Name = fmt.Sprintf("%s (%s)", id, strings.Join(parts, ", "))
+		}
+		if b, ok := j.AllowFailure.(bool); ok && b {
+			jo.Optional = &sdk.True
+		} else if j.AllowFailure != nil && !ok {
+			c.warn(file, "allow_failure exit codes of job %s are not converted", id)
+		}
+		if j.When == "always" {
+			jo.AlwaysExecuted = &sdk.True
+		}
+
+		expr := func(s string) string { return c.convertGitLabVariables(file, s) }
+
+		env := make(map[string]string)
+		for _, vars := range []map[string]interface{}{globalVariables, j.Variables} {
+			for k, v := range vars {
+				// a variable can be defined with its description
+				if m, ok := v.(map[interface{}]interface{}); ok {
+					v = m["value"]
+				}
+				env[k] = expr(fmt.Sprintf("%v", v))
+			}
+		}
+		for k, v := range combination {
+			env[k] = v
+		}
+
+		for _, s := range j.Services {
+			var service struct {
+				Name  string `yaml:"name"`
+				Alias string `yaml:"alias"`
+			}
+			if image, ok := s.(string); ok {
+				service.Name = image
+			} else if err := convertDecode(s, &service); err != nil {
+				return nil, err
+			}
+			alias := service.Alias
+			if alias == "" {
+				alias = service.Name
+				if i := strings.LastIndex(alias, "/"); i >= 0 {
+					alias = alias[i+1:]
+				}
+				if i := strings.Index(alias, ":"); i >= 0 {
+					alias = alias[:i]
+				}
+			}
+			value := expr(service.Name)
+			keys := make([]string, 0, len(env))
+			for k := range env {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				value += fmt.Sprintf(" %s=%s", k, env[k])
+			}
+			jo.Requirements = append(jo.Requirements, Requirement{Service: ServiceRequirement{Name: alias, Value: value}})
+		}
+
+		if env["GIT_STRATEGY"] != "none" {
+			checkout := StepCheckout("{{.cds.workspace}}")
+			jo.Steps = append(jo.Steps, Step{Checkout: &checkout})
+		}
+		if download {
+			jo.Steps = append(jo.Steps, Step{ArtifactDownload: &StepArtifactDownload{Path: ".", Tag: "{{.cds.version}}"}})
+		}
+
+		script := append(gitlabScript(j.BeforeScript), gitlabScript(j.Script)...)
+		jo.Steps = append(jo.Steps, Step{Script: convertScript(env, "", expr(strings.Join(script, "\n")))})
+		if after := gitlabScript(j.AfterScript); len(after) > 0 {
+			jo.Steps = append(jo.Steps, Step{
+				Script:         convertScript(env, "", expr(strings.Join(after, "\n"))),
+				AlwaysExecuted: &sdk.True,
+			})
+		}
+
+		for _, path := range j.Artifacts.Paths {
+			step := Step{ArtifactUpload: &StepArtifactUpload{Path: expr(path), Tag: "{{.cds.version}}"}}
+			switch j.Artifacts.When {
+			case "always":
+				step.AlwaysExecuted = &sdk.True
+			case "on_failure":
+				c.warn(file, "artifacts of job %s are uploaded only on success", id)
+			}
+			jo.Steps = append(jo.Steps, step)
+		}
+		reports := make([]string, 0, len(j.Artifacts.Reports))
+		for k := range j.Artifacts.Reports {
+			reports = append(reports, k)
+		}
+		sort.Strings(reports)
+		for _, k := range reports {
+			switch k {
+			case "junit":
+				for _, path := range gitlabScript(j.Artifacts.Reports[k]) {
+					report := StepJUnitReport(expr(path))
+					jo.Steps = append(jo.Steps, Step{JUnitReport: &report, AlwaysExecuted: &sdk.True})
+				}
+			case "cobertura":
+				for _, path := range gitlabScript(j.Artifacts.Reports[k]) {
+					jo.Steps = append(jo.Steps, Step{Coverage: &StepCoverage{Format: "cobertura", Path: expr(path)}})
+				}
+			default:
+				c.warn(file, "%s report of job %s is not converted", k, id)
+			}
+		}
+
+		res = append(res, jo)
+	}
+	return res, nil
+}
+
+// gitlabScript returns the lines of a script, that can be a string or a list with nested lists.
+func gitlabScript(v interface{}) []string {
+	switch s := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		var res []string
+		for _, e := range s {
+			res = append(res, gitlabScript(e)...)
+		}
+		return res
+	default:
+		return []string{fmt.Sprintf("%v", s)}
+	}
+}
+
+// convertGitLabVariables replaces the predefined variables of GitLab by the CDS variables.
+func (c *Conversion) convertGitLabVariables(file, s string) string {
+	return gitlabVariableRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		sub := gitlabVariableRegexp.FindStringSubmatch(m)
+		name := sub[1] + sub[2]
+		if v, ok := gitlabVariables[name]; ok {
+			return v
+		}
+		c.warn(file, "variable %s is not converted", name)
+		return m
+	})
+}
@@ -0,0 +1,130 @@
+package exportentities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gitlabTestFile = `stages:
+- build
+- test
+- deploy
+
+variables:
+  GOPATH: /go
+  VERSION:
+    value: "1.0"
+    description: The version
+
+before_script:
+- echo start
+
+.go: &go
+  image: golang:1.13
+  tags: [docker]
+
+build:
+  <<: *go
+  stage: build
+  script:
+  - make build VERSION=$VERSION-$CI_COMMIT_SHORT_SHA
+  artifacts:
+    paths:
+    - dist/
+
+.tests:
+  stage: test
+  variables:
+    GOFLAGS: -mod=vendor
+  services:
+  - postgres:9.6
+  artifacts:
+    when: always
+    reports:
+      junit: report.xml
+      dotenv: build.env
+
+test:
+  extends: .tests
+  script: go test ./...
+  after_script:
+  - rm -rf tmp
+  allow_failure: true
+  parallel:
+    matrix:
+    - DB: [postgres, mysql]
+
+deploy:
+  stage: deploy
+  dependencies: []
+  variables:
+    GIT_STRATEGY: none
+  script:
+  - ./deploy.sh ${CI_COMMIT_REF_NAME} $CI_JOB_TOKEN
+  rules:
+  - if: $CI_COMMIT_BRANCH == "master"
+  environment: production
+`
+
+func TestConvertGitLabCI(t *testing.T) {
+	c, err := ConvertGitLabCI(map[string]string{".gitlab-ci.yml": gitlabTestFile})
+	require.NoError(t, err)
+
+	require.Len(t, c.Workflows, 1)
+	assert.Equal(t, "gitlab-ci", c.Workflows["gitlab-ci"].PipelineName)
+
+	require.Len(t, c.Pipelines, 1)
+	p := c.Pipelines["gitlab-ci"]
+	assert.Equal(t, []string{"build", "test", "deploy"}, p.Stages)
+	require.Len(t, p.Jobs, 4)
+
+	build := p.Jobs[0]
+	assert.Equal(t, "build", build.Name)
+	require.Len(t, build.Steps, 3)
+	assert.NotNil(t, build.Steps[0].Checkout)
+	assert.Equal(t, []string{
+		`export GOPATH="/go"`,
+		`export VERSION="1.0"`,
+		"echo start",
+		"make build VERSION=$VERSION-{{.git.hash.short}}",
+	}, build.Steps[1].Script)
+	assert.Equal(t, &StepArtifactUpload{Path: "dist/", Tag: "{{.cds.version}}"}, build.Steps[2].ArtifactUpload)
+
+	test := p.Jobs[2]
+	assert.Equal(t, "test (DB=mysql)", test.Name)
+	assert.Equal(t, "test", test.Stage)
+	assert.NotNil(t, test.Optional)
+	require.Len(t, test.Requirements, 1)
+	assert.Equal(t, "postgres", test.Requirements[0].Service.Name)
+	assert.Equal(t, "postgres:9.6 DB=mysql GOFLAGS=-mod=vendor GOPATH=/go VERSION=1.0", test.Requirements[0].Service.Value)
+	require.Len(t, test.Steps, 5)
+	assert.NotNil(t, test.Steps[1].ArtifactDownload)
+	assert.Equal(t, "go test ./...", test.Steps[2].Script.([]string)[5])
+	assert.Equal(t, "rm -rf tmp", test.Steps[3].Script.([]string)[4])
+	assert.NotNil(t, test.Steps[3].AlwaysExecuted)
+	require.NotNil(t, test.Steps[4].JUnitReport)
+	assert.Equal(t, StepJUnitReport("report.xml"), *test.Steps[4].JUnitReport)
+
+	deploy := p.Jobs[3]
+	require.Len(t, deploy.Steps, 1)
+	assert.Equal(t, "./deploy.sh {{.git.branch}} $CI_JOB_TOKEN", deploy.Steps[0].Script.([]string)[4])
+
+	assert.Contains(t, c.Warnings, ".gitlab-ci.yml: image of job build is not converted, add a model requirement to the job")
+	assert.Contains(t, c.Warnings, ".gitlab-ci.yml: dotenv report of job test is not converted")
+	assert.Contains(t, c.Warnings, ".gitlab-ci.yml: environment of job deploy is not converted")
+	assert.Contains(t, c.Warnings, ".gitlab-ci.yml: rules of job deploy are not converted, the job is always run")
+	assert.Contains(t, c.Warnings, ".gitlab-ci.yml: variable CI_JOB_TOKEN is not converted")
+
+	_, err = ConvertGitLabCI(map[string]string{".gitlab-ci.yml": "lint:\n  stage: check\n  script: make lint\n"})
+	assert.Error(t, err)
+
+	_, err = ConvertGitLabCI(map[string]string{".gitlab-ci.yml": "lint:\n  extends: .lint\n  script: make lint\n"})
+	assert.Error(t, err)
+
+	c, err = ConvertGitLabCI(map[string]string{".gitlab-ci.yml": "lint:\n  script: make lint\n"})
+	require.NoError(t, err)
+	assert.Empty(t, c.Pipelines["gitlab-ci"].Stages)
+	assert.Equal(t, "", c.Pipelines["gitlab-ci"].Jobs[0].Stage)
+}
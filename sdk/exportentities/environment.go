@@ -6,11 +6,18 @@ import (
 
 // Environment is a struct to export sdk.Environment
 type Environment struct {
-	Name   string                   `json:"name" yaml:"name" jsonschema_description:"The name of the environment."`
-	Values map[string]VariableValue `json:"values,omitempty" yaml:"values,omitempty"`
-	Keys   map[string]KeyValue      `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Name    string                   `json:"name" yaml:"name" jsonschema_description:"The name of the environment."`
+	Values  map[string]VariableValue `json:"values,omitempty" yaml:"values,omitempty"`
+	Keys    map[string]KeyValue      `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Rollout *sdk.DeploymentRollout   `json:"rollout,omitempty" yaml:"rollout,omitempty" jsonschema_description:"The rollout strategy of the deployments on the environment."`
 }
 
+// There are the supported versions, the environment files have no version field so it is only used for their
+// JSON schema.
+const (
+	EnvironmentVersion1 = "v1.0"
+)
+
 //NewEnvironment returns an Environment from an sdk.Environment pointer
 func NewEnvironment(e sdk.Environment, keys []EncryptedKey) (env *Environment) {
 	env = new(Environment)
@@ -29,6 +36,7 @@ func NewEnvironment(e sdk.Environment, keys []EncryptedKey) (env *Environment) {
 			Value: k.Content,
 		}
 	}
+	env.Rollout = e.Rollout
 	return
 }
 
@@ -49,6 +57,7 @@ func (e *Environment) Environment() (env *sdk.Environment) {
 		}
 		i++
 	}
+	env.Rollout = e.Rollout
 
 	return
 }
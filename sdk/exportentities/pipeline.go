@@ -20,6 +20,7 @@ type PipelineV1 struct {
 	Version      string                    `json:"version,omitempty" yaml:"version,omitempty" jsonschema_description:"The version for the current pipeline file (v1.0)."`
 	Name         string                    `json:"name,omitempty" yaml:"name,omitempty" jsonschema_description:"The name of the pipeline."`
 	Description  string                    `json:"description,omitempty" yaml:"description,omitempty" jsonschema_description:"The description of the pipeline."`
+	Include      []string                  `json:"include,omitempty" yaml:"include,omitempty" jsonschema_description:"The names of the files of the .cds directory (name.inc.yml) which parameters, stages and jobs are included in the pipeline."`
 	Parameters   map[string]ParameterValue `json:"parameters,omitempty" yaml:"parameters,omitempty" jsonschema_description:"The list of parameters of the pipeline."`
 	Stages       []string                  `json:"stages,omitempty" yaml:"stages,omitempty" jsonschema_description:"The list of stage's names for the pipeline."`
 	StageOptions map[string]Stage          `json:"options,omitempty" yaml:"options,omitempty" jsonschema_description:"The options for stages of the pipeline."` //Here Stage.Jobs will NEVER be set
@@ -62,6 +63,7 @@ type Requirement struct {
 	Plugin            string             `json:"plugin,omitempty" yaml:"plugin,omitempty"`
 	Service           ServiceRequirement `json:"service,omitempty" yaml:"service,omitempty"`
 	Memory            string             `json:"memory,omitempty" yaml:"memory,omitempty"`
+	CPU               string             `json:"cpu,omitempty" yaml:"cpu,omitempty"`
 	OSArchRequirement string             `json:"os-architecture,omitempty" yaml:"os-architecture,omitempty"`
 }
 
@@ -156,6 +158,8 @@ func newRequirements(req []sdk.Requirement) []Requirement {
 			res = append(res, Requirement{OSArchRequirement: r.Value})
 		case sdk.MemoryRequirement:
 			res = append(res, Requirement{Memory: r.Value})
+		case sdk.CPURequirement:
+			res = append(res, Requirement{CPU: r.Value})
 		}
 	}
 	return res
@@ -213,6 +217,10 @@ func computeJobRequirements(req []Requirement) []sdk.Requirement {
 			name = "memory"
 			val = r.Memory
 			tpe = sdk.MemoryRequirement
+		} else if r.CPU != "" {
+			name = "cpu"
+			val = r.CPU
+			tpe = sdk.CPURequirement
 		} else if r.Model != "" {
 			name = "model"
 			val = r.Model
@@ -271,6 +279,10 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 
 //Pipeline returns a sdk.Pipeline entity
 func (p PipelineV1) Pipeline() (pip *sdk.Pipeline, err error) {
+	if len(p.Include) > 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "pipeline %s includes files, it must be pushed with its workflow", p.Name)
+	}
+
 	pip = new(sdk.Pipeline)
 	pip.Name = p.Name
 	pip.Description = p.Description
@@ -0,0 +1,151 @@
+package exportentities
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// PipelineInclude represents the content of a file shared by the pipelines of a repository. Its parameters, stages
+// and jobs are added to the pipelines that include it, and its blocks of steps can be used in the jobs.
+type PipelineInclude struct {
+	Version      string                    `json:"version,omitempty" yaml:"version,omitempty" jsonschema_description:"The version for the current file (v1.0)."`
+	Name         string                    `json:"name,omitempty" yaml:"name,omitempty" jsonschema_description:"The name of the file, used by the pipelines to include it."`
+	Include      []string                  `json:"include,omitempty" yaml:"include,omitempty" jsonschema_description:"The names of the other files included by this file."`
+	Parameters   map[string]ParameterValue `json:"parameters,omitempty" yaml:"parameters,omitempty" jsonschema_description:"The parameters added to the pipeline if not defined by it."`
+	Stages       []string                  `json:"stages,omitempty" yaml:"stages,omitempty" jsonschema_description:"The stages added before the stages of the pipeline."`
+	StageOptions map[string]Stage          `json:"options,omitempty" yaml:"options,omitempty" jsonschema_description:"The options for stages, the options of the pipeline take precedence."`
+	Jobs         []Job                     `json:"jobs,omitempty" yaml:"jobs,omitempty" jsonschema_description:"The jobs added to the pipeline, a job of the pipeline with the same name and stage replaces it."`
+	Steps        map[string][]Step         `json:"steps,omitempty" yaml:"steps,omitempty" jsonschema_description:"The blocks of steps that can be included in the jobs by name."`
+}
+
+// ResolveIncludes adds the content of the included files to the pipeline, and replaces the included blocks of
+// steps in its jobs. The files are given by name.
+func (p *PipelineV1) ResolveIncludes(includes map[string]PipelineInclude) error {
+	var incs []PipelineInclude
+	if err := collectIncludes(includes, p.Include, nil, &incs); err != nil {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pipeline %s: %v", p.Name, err)
+	}
+
+	var stages []string
+	stageOptions := make(map[string]Stage)
+	parameters := make(map[string]ParameterValue)
+	var jobs []Job
+	steps := make(map[string][]Step)
+	for _, inc := range incs {
+		for _, s := range inc.Stages {
+			if !sdk.IsInArray(s, stages) {
+				stages = append(stages, s)
+			}
+		}
+		for s, opt := range inc.StageOptions {
+			stageOptions[s] = opt
+		}
+		for k, v := range inc.Parameters {
+			parameters[k] = v
+		}
+		for _, j := range inc.Jobs {
+			jobs = setJob(jobs, j)
+		}
+		for k, v := range inc.Steps {
+			if _, ok := steps[k]; !ok {
+				steps[k] = v
+			}
+		}
+	}
+
+	for _, s := range p.Stages {
+		if !sdk.IsInArray(s, stages) {
+			stages = append(stages, s)
+		}
+	}
+	for s, opt := range p.StageOptions {
+		stageOptions[s] = opt
+	}
+	for k, v := range p.Parameters {
+		parameters[k] = v
+	}
+	for _, j := range p.Jobs {
+		jobs = setJob(jobs, j)
+	}
+
+	for i := range jobs {
+		s, err := includeSteps(jobs[i].Steps, steps)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pipeline %s: job %s: %v", p.Name, jobs[i].Name, err)
+		}
+		jobs[i].Steps = s
+	}
+
+	p.Include = nil
+	p.Stages = stages
+	p.StageOptions = stageOptions
+	if len(stageOptions) == 0 {
+		p.StageOptions = nil
+	}
+	p.Parameters = parameters
+	if len(parameters) == 0 {
+		p.Parameters = nil
+	}
+	p.Jobs = jobs
+	return nil
+}
+
+// collectIncludes appends the included files, after the files they include themselves.
+func collectIncludes(includes map[string]PipelineInclude, names []string, stack []string, res *[]PipelineInclude) error {
+	for _, name := range names {
+		if sdk.IsInArray(name, stack) {
+			return fmt.Errorf("file %s includes itself", name)
+		}
+		inc, ok := includes[name]
+		if !ok {
+			return fmt.Errorf("included file %s not found", name)
+		}
+		if err := collectIncludes(includes, inc.Include, append(stack, name), res); err != nil {
+			return err
+		}
+		var found bool
+		for _, i := range *res {
+			if i.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*res = append(*res, inc)
+		}
+	}
+	return nil
+}
+
+// setJob adds a job, or replaces the job with the same name and stage.
+func setJob(jobs []Job, j Job) []Job {
+	for i := range jobs {
+		if jobs[i].Name == j.Name && jobs[i].Stage == j.Stage {
+			jobs[i] = j
+			return jobs
+		}
+	}
+	return append(jobs, j)
+}
+
+func includeSteps(steps []Step, blocks map[string][]Step) ([]Step, error) {
+	var res []Step
+	for _, s := range steps {
+		if s.Include == "" {
+			res = append(res, s)
+			continue
+		}
+		block, ok := blocks[s.Include]
+		if !ok {
+			return nil, fmt.Errorf("steps %s not found in the included files", s.Include)
+		}
+		for _, b := range block {
+			if b.Include != "" {
+				return nil, fmt.Errorf("steps %s cannot include steps %s", s.Include, b.Include)
+			}
+		}
+		res = append(res, block...)
+	}
+	return res, nil
+}
@@ -0,0 +1,122 @@
+package exportentities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+func TestPipelineResolveIncludes(t *testing.T) {
+	var pip exportentities.PipelineV1
+	require.NoError(t, yaml.Unmarshal([]byte(`version: v1.0
+name: build
+include:
+- go
+parameters:
+  target:
+    default: linux
+stages:
+- build
+- package
+jobs:
+- job: compile
+  stage: build
+  steps:
+  - include: setup
+  - script: go build
+- job: lint
+  stage: build
+  steps:
+  - script: golint
+`), &pip))
+
+	includes := map[string]exportentities.PipelineInclude{}
+	for _, s := range []string{`name: go
+include:
+- common
+parameters:
+  target:
+    default: darwin
+  race:
+    type: boolean
+stages:
+- build
+- test
+jobs:
+- job: lint
+  stage: build
+  steps:
+  - script: gometalinter
+- job: unit
+  stage: test
+  steps:
+  - include: setup
+  - script: go test
+`, `name: common
+steps:
+  setup:
+  - checkout: '{{.cds.workspace}}'
+  - script: make deps
+`} {
+		var inc exportentities.PipelineInclude
+		require.NoError(t, yaml.Unmarshal([]byte(s), &inc))
+		includes[inc.Name] = inc
+	}
+
+	require.NoError(t, pip.ResolveIncludes(includes))
+	assert.Empty(t, pip.Include)
+	assert.Equal(t, []string{"build", "test", "package"}, pip.Stages)
+	assert.Equal(t, "linux", pip.Parameters["target"].DefaultValue)
+	assert.Equal(t, "boolean", pip.Parameters["race"].Type)
+
+	require.Len(t, pip.Jobs, 3)
+	assert.Equal(t, "lint", pip.Jobs[0].Name)
+	assert.Equal(t, "golint", pip.Jobs[0].Steps[0].Script)
+	assert.Equal(t, "unit", pip.Jobs[1].Name)
+	require.Len(t, pip.Jobs[1].Steps, 3)
+	assert.NotNil(t, pip.Jobs[1].Steps[0].Checkout)
+	assert.Equal(t, "compile", pip.Jobs[2].Name)
+	require.Len(t, pip.Jobs[2].Steps, 3)
+	assert.Equal(t, "make deps", pip.Jobs[2].Steps[1].Script)
+
+	p, err := pip.Pipeline()
+	require.NoError(t, err)
+	require.Len(t, p.Stages, 3)
+	assert.Equal(t, "test", p.Stages[1].Name)
+	require.Len(t, p.Stages[0].Jobs, 2)
+	assert.Len(t, p.Stages[0].Jobs[1].Action.Actions, 3)
+}
+
+func TestPipelineResolveIncludesErrors(t *testing.T) {
+	includes := map[string]exportentities.PipelineInclude{
+		"a": {Name: "a", Include: []string{"b"}},
+		"b": {Name: "b", Include: []string{"a"}},
+		"c": {Name: "c"},
+	}
+
+	pip := exportentities.PipelineV1{Name: "build", Include: []string{"d"}}
+	_, err := pip.Pipeline()
+	assert.Error(t, err)
+	err = pip.ResolveIncludes(includes)
+	require.Error(t, err)
+	assert.Equal(t, "pipeline build: included file d not found", sdk.Cause(err).Error())
+
+	pip = exportentities.PipelineV1{Name: "build", Include: []string{"a"}}
+	err = pip.ResolveIncludes(includes)
+	require.Error(t, err)
+	assert.Equal(t, "pipeline build: file a includes itself", sdk.Cause(err).Error())
+
+	pip = exportentities.PipelineV1{
+		Name:    "build",
+		Include: []string{"c"},
+		Jobs:    []exportentities.Job{{Name: "compile", Steps: []exportentities.Step{{Include: "setup"}}}},
+	}
+	err = pip.ResolveIncludes(includes)
+	require.Error(t, err)
+	assert.Equal(t, "pipeline build: job compile: steps setup not found in the included files", sdk.Cause(err).Error())
+}
@@ -0,0 +1,32 @@
+package exportentities
+
+import (
+	"sort"
+)
+
+// The kinds of as code files which have a JSON schema.
+const (
+	SchemaWorkflow    = "workflow"
+	SchemaPipeline    = "pipeline"
+	SchemaApplication = "application"
+	SchemaEnvironment = "environment"
+)
+
+// SchemaTypes are the types of the as code files by kind and by version of the yaml syntax, their JSON schemas
+// are generated from the types.
+var SchemaTypes = map[string]map[string]interface{}{
+	SchemaWorkflow:    {WorkflowVersion1: Workflow{}},
+	SchemaPipeline:    {PipelineVersion1: PipelineV1{}},
+	SchemaApplication: {ApplicationVersion1: Application{}},
+	SchemaEnvironment: {EnvironmentVersion1: Environment{}},
+}
+
+// SchemaVersions returns the versions of the yaml syntax of a kind of as code files, the latest is the last one.
+func SchemaVersions(kind string) []string {
+	versions := make([]string, 0, len(SchemaTypes[kind]))
+	for v := range SchemaTypes[kind] {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return versions
+}
@@ -49,6 +49,12 @@ func newStep(act sdk.Action) Step {
 			if minimum != nil {
 				s.Coverage.Minimum = minimum.Value
 			}
+		case sdk.CoverageCheckAction:
+			s.CoverageCheck = &StepCoverageCheck{}
+			maxDelta := sdk.ParameterFind(act.Parameters, "maxDelta")
+			if maxDelta != nil {
+				s.CoverageCheck.MaxDelta = maxDelta.Value
+			}
 		case sdk.ArtifactDownload:
 			s.ArtifactDownload = &StepArtifactDownload{}
 			path := sdk.ParameterFind(act.Parameters, "path")
@@ -77,6 +83,10 @@ func newStep(act sdk.Action) Step {
 			if destination != nil {
 				s.ArtifactUpload.Destination = destination.Value
 			}
+			signKey := sdk.ParameterFind(act.Parameters, "signKey")
+			if signKey != nil {
+				s.ArtifactUpload.SignKey = signKey.Value
+			}
 		case sdk.ServeStaticFiles:
 			s.ServeStaticFiles = &StepServeStaticFiles{}
 			name := sdk.ParameterFind(act.Parameters, "name")
@@ -217,6 +227,182 @@ func newStep(act sdk.Action) Step {
 		case sdk.DeployApplicationAction:
 			step := StepDeploy("{{.cds.application}}")
 			s.Deploy = &step
+		case sdk.DeployKubernetesAction:
+			s.DeployKubernetes = &StepDeployKubernetes{}
+			integration := sdk.ParameterFind(act.Parameters, "integration")
+			if integration != nil {
+				s.DeployKubernetes.Integration = integration.Value
+			}
+			namespace := sdk.ParameterFind(act.Parameters, "namespace")
+			if namespace != nil {
+				s.DeployKubernetes.Namespace = namespace.Value
+			}
+			manifests := sdk.ParameterFind(act.Parameters, "manifests")
+			if manifests != nil {
+				s.DeployKubernetes.Manifests = manifests.Value
+			}
+			kustomize := sdk.ParameterFind(act.Parameters, "kustomize")
+			if kustomize != nil {
+				s.DeployKubernetes.Kustomize = kustomize.Value
+			}
+			helmChart := sdk.ParameterFind(act.Parameters, "helmChart")
+			if helmChart != nil {
+				s.DeployKubernetes.HelmChart = helmChart.Value
+			}
+			helmValues := sdk.ParameterFind(act.Parameters, "helmValues")
+			if helmValues != nil {
+				s.DeployKubernetes.HelmValues = helmValues.Value
+			}
+			releaseName := sdk.ParameterFind(act.Parameters, "releaseName")
+			if releaseName != nil {
+				s.DeployKubernetes.ReleaseName = releaseName.Value
+			}
+			timeout := sdk.ParameterFind(act.Parameters, "timeout")
+			if timeout != nil {
+				s.DeployKubernetes.Timeout = timeout.Value
+			}
+		case sdk.HelmPushAction:
+			s.HelmPush = &StepHelmPush{}
+			chart := sdk.ParameterFind(act.Parameters, "chart")
+			if chart != nil {
+				s.HelmPush.Chart = chart.Value
+			}
+			registry := sdk.ParameterFind(act.Parameters, "registry")
+			if registry != nil {
+				s.HelmPush.Registry = registry.Value
+			}
+			version := sdk.ParameterFind(act.Parameters, "version")
+			if version != nil {
+				s.HelmPush.Version = version.Value
+			}
+			appVersion := sdk.ParameterFind(act.Parameters, "appVersion")
+			if appVersion != nil {
+				s.HelmPush.AppVersion = appVersion.Value
+			}
+			signKey := sdk.ParameterFind(act.Parameters, "signKey")
+			if signKey != nil {
+				s.HelmPush.SignKey = signKey.Value
+			}
+			tag := sdk.ParameterFind(act.Parameters, "tag")
+			if tag != nil {
+				s.HelmPush.Tag = tag.Value
+			}
+		case sdk.SBOMAction:
+			s.SBOM = &StepSBOM{}
+			source := sdk.ParameterFind(act.Parameters, "source")
+			if source != nil {
+				s.SBOM.Source = source.Value
+			}
+			file := sdk.ParameterFind(act.Parameters, "file")
+			if file != nil {
+				s.SBOM.File = file.Value
+			}
+			format := sdk.ParameterFind(act.Parameters, "format")
+			if format != nil {
+				s.SBOM.Format = format.Value
+			}
+			name := sdk.ParameterFind(act.Parameters, "name")
+			if name != nil {
+				s.SBOM.Name = name.Value
+			}
+			artifact := sdk.ParameterFind(act.Parameters, "artifact")
+			if artifact != nil {
+				s.SBOM.Artifact = artifact.Value
+			}
+		case sdk.DockerBuildAction:
+			s.DockerBuild = &StepDockerBuild{}
+			image := sdk.ParameterFind(act.Parameters, "image")
+			if image != nil {
+				s.DockerBuild.Image = image.Value
+			}
+			tags := sdk.ParameterFind(act.Parameters, "tags")
+			if tags != nil {
+				s.DockerBuild.Tags = tags.Value
+			}
+			registry := sdk.ParameterFind(act.Parameters, "registry")
+			if registry != nil {
+				s.DockerBuild.Registry = registry.Value
+			}
+			context := sdk.ParameterFind(act.Parameters, "context")
+			if context != nil {
+				s.DockerBuild.Context = context.Value
+			}
+			dockerfile := sdk.ParameterFind(act.Parameters, "dockerfile")
+			if dockerfile != nil {
+				s.DockerBuild.Dockerfile = dockerfile.Value
+			}
+			platforms := sdk.ParameterFind(act.Parameters, "platforms")
+			if platforms != nil {
+				s.DockerBuild.Platforms = platforms.Value
+			}
+			buildArgs := sdk.ParameterFind(act.Parameters, "buildArgs")
+			if buildArgs != nil {
+				s.DockerBuild.BuildArgs = buildArgs.Value
+			}
+			labels := sdk.ParameterFind(act.Parameters, "labels")
+			if labels != nil {
+				s.DockerBuild.Labels = labels.Value
+			}
+			cache := sdk.ParameterFind(act.Parameters, "cache")
+			if cache != nil {
+				s.DockerBuild.Cache = cache.Value
+			}
+			push := sdk.ParameterFind(act.Parameters, "push")
+			if push != nil {
+				s.DockerBuild.Push = push.Value
+			}
+		case sdk.JiraReleaseAction:
+			s.JiraRelease = &StepJiraRelease{}
+			integration := sdk.ParameterFind(act.Parameters, "integration")
+			if integration != nil {
+				s.JiraRelease.Integration = integration.Value
+			}
+			transition := sdk.ParameterFind(act.Parameters, "transition")
+			if transition != nil {
+				s.JiraRelease.Transition = transition.Value
+			}
+			releaseNotes := sdk.ParameterFind(act.Parameters, "releaseNotes")
+			if releaseNotes != nil {
+				s.JiraRelease.ReleaseNotes = releaseNotes.Value
+			}
+			artifact := sdk.ParameterFind(act.Parameters, "artifact")
+			if artifact != nil {
+				s.JiraRelease.Artifact = artifact.Value
+			}
+		case sdk.ServiceNowAction:
+			s.ServiceNowChangeRequest = &StepServiceNowChangeRequest{}
+			integration := sdk.ParameterFind(act.Parameters, "integration")
+			if integration != nil {
+				s.ServiceNowChangeRequest.Integration = integration.Value
+			}
+			changeRequest := sdk.ParameterFind(act.Parameters, "changeRequest")
+			if changeRequest != nil {
+				s.ServiceNowChangeRequest.ChangeRequest = changeRequest.Value
+			}
+			shortDescription := sdk.ParameterFind(act.Parameters, "shortDescription")
+			if shortDescription != nil {
+				s.ServiceNowChangeRequest.ShortDescription = shortDescription.Value
+			}
+			description := sdk.ParameterFind(act.Parameters, "description")
+			if description != nil {
+				s.ServiceNowChangeRequest.Description = description.Value
+			}
+			state := sdk.ParameterFind(act.Parameters, "state")
+			if state != nil {
+				s.ServiceNowChangeRequest.State = state.Value
+			}
+			attachReport := sdk.ParameterFind(act.Parameters, "attachReport")
+			if attachReport != nil {
+				s.ServiceNowChangeRequest.AttachReport = attachReport.Value
+			}
+			waitApproval := sdk.ParameterFind(act.Parameters, "waitApproval")
+			if waitApproval != nil {
+				s.ServiceNowChangeRequest.WaitApproval = waitApproval.Value
+			}
+			timeout := sdk.ParameterFind(act.Parameters, "timeout")
+			if timeout != nil {
+				s.ServiceNowChangeRequest.Timeout = timeout.Value
+			}
 		}
 	default:
 		args := make(StepParameters)
@@ -252,6 +438,11 @@ type StepCoverage struct {
 	Path    string `json:"path,omitempty" yaml:"path,omitempty"`
 }
 
+// StepCoverageCheck represents exported coverage check step.
+type StepCoverageCheck struct {
+	MaxDelta string `json:"maxDelta,omitempty" yaml:"maxDelta,omitempty"`
+}
+
 // StepArtifactDownload represents exported artifact download step.
 type StepArtifactDownload struct {
 	Path    string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"required"`
@@ -264,6 +455,7 @@ type StepArtifactUpload struct {
 	Destination string `json:"destination,omitempty" yaml:"destination,omitempty"`
 	Path        string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"required"`
 	Tag         string `json:"tag,omitempty" yaml:"tag,omitempty" jsonschema:"required"`
+	SignKey     string `json:"signKey,omitempty" yaml:"signKey,omitempty"`
 }
 
 // StepServeStaticFiles represents exported serve static files step.
@@ -307,6 +499,71 @@ type StepGitTag struct {
 	TagPrerelease string `json:"tagPrerelease,omitempty" yaml:"tagPrerelease,omitempty"`
 }
 
+// StepDeployKubernetes represents exported deploy kubernetes step.
+type StepDeployKubernetes struct {
+	HelmChart   string `json:"helmChart,omitempty" yaml:"helmChart,omitempty"`
+	HelmValues  string `json:"helmValues,omitempty" yaml:"helmValues,omitempty"`
+	Integration string `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema:"required"`
+	Kustomize   string `json:"kustomize,omitempty" yaml:"kustomize,omitempty"`
+	Manifests   string `json:"manifests,omitempty" yaml:"manifests,omitempty"`
+	Namespace   string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	ReleaseName string `json:"releaseName,omitempty" yaml:"releaseName,omitempty"`
+	Timeout     string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// StepDockerBuild represents exported docker build step.
+type StepDockerBuild struct {
+	BuildArgs  string `json:"buildArgs,omitempty" yaml:"buildArgs,omitempty"`
+	Cache      string `json:"cache,omitempty" yaml:"cache,omitempty"`
+	Context    string `json:"context,omitempty" yaml:"context,omitempty"`
+	Dockerfile string `json:"dockerfile,omitempty" yaml:"dockerfile,omitempty"`
+	Image      string `json:"image,omitempty" yaml:"image,omitempty" jsonschema:"required"`
+	Labels     string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Platforms  string `json:"platforms,omitempty" yaml:"platforms,omitempty"`
+	Push       string `json:"push,omitempty" yaml:"push,omitempty"`
+	Registry   string `json:"registry,omitempty" yaml:"registry,omitempty"`
+	Tags       string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// StepHelmPush represents exported helm push step.
+type StepHelmPush struct {
+	AppVersion string `json:"appVersion,omitempty" yaml:"appVersion,omitempty"`
+	Chart      string `json:"chart,omitempty" yaml:"chart,omitempty" jsonschema:"required"`
+	Registry   string `json:"registry,omitempty" yaml:"registry,omitempty"`
+	SignKey    string `json:"signKey,omitempty" yaml:"signKey,omitempty"`
+	Tag        string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Version    string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// StepSBOM represents exported sbom step.
+type StepSBOM struct {
+	Artifact string `json:"artifact,omitempty" yaml:"artifact,omitempty"`
+	File     string `json:"file,omitempty" yaml:"file,omitempty"`
+	Format   string `json:"format,omitempty" yaml:"format,omitempty"`
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
+	Source   string `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// StepServiceNowChangeRequest represents exported servicenow change request step.
+type StepServiceNowChangeRequest struct {
+	AttachReport     string `json:"attachReport,omitempty" yaml:"attachReport,omitempty"`
+	ChangeRequest    string `json:"changeRequest,omitempty" yaml:"changeRequest,omitempty"`
+	Description      string `json:"description,omitempty" yaml:"description,omitempty"`
+	Integration      string `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema:"required"`
+	ShortDescription string `json:"shortDescription,omitempty" yaml:"shortDescription,omitempty"`
+	State            string `json:"state,omitempty" yaml:"state,omitempty"`
+	Timeout          string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	WaitApproval     string `json:"waitApproval,omitempty" yaml:"waitApproval,omitempty"`
+}
+
+// StepJiraRelease represents exported jira release step.
+type StepJiraRelease struct {
+	Artifact     string `json:"artifact,omitempty" yaml:"artifact,omitempty"`
+	Integration  string `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema:"required"`
+	ReleaseNotes string `json:"releaseNotes,omitempty" yaml:"releaseNotes,omitempty"`
+	Transition   string `json:"transition,omitempty" yaml:"transition,omitempty"`
+}
+
 // StepJUnitReport represents exported junit report step.
 type StepJUnitReport string
 
@@ -327,19 +584,27 @@ type Step struct {
 	Optional       *bool  `json:"optional,omitempty" yaml:"optional,omitempty"`
 	AlwaysExecuted *bool  `json:"always_executed,omitempty" yaml:"always_executed,omitempty"`
 	// step specific data, only one option should be set
-	StepCustom       `json:"-" yaml:",inline"`
-	Script           interface{}           `json:"script,omitempty" yaml:"script,omitempty" jsonschema:"oneof_type=string;array,oneof_required=actionScript" jsonschema_description:"Script.\nhttps://ovh.github.io/cds/docs/actions/builtin-script"`
-	Coverage         *StepCoverage         `json:"coverage,omitempty" yaml:"coverage,omitempty" jsonschema:"oneof_required=actionCoverage" jsonschema_description:"Parse coverage report.\nhttps://ovh.github.io/cds/docs/actions/builtin-coverage"`
-	ArtifactDownload *StepArtifactDownload `json:"artifactDownload,omitempty" yaml:"artifactDownload,omitempty" jsonschema:"oneof_required=actionArtifactDownload" jsonschema_description:"Download artifacts in workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-download"`
-	ArtifactUpload   *StepArtifactUpload   `json:"artifactUpload,omitempty" yaml:"artifactUpload,omitempty" jsonschema:"oneof_required=actionArtifactUpload" jsonschema_description:"Upload artifacts from workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-upload"`
-	ServeStaticFiles *StepServeStaticFiles `json:"serveStaticFiles,omitempty" yaml:"serveStaticFiles,omitempty" jsonschema:"oneof_required=actionServeStaticFiles" jsonschema_description:"Serve static files.\nhttps://ovh.github.io/cds/docs/actions/builtin-serve-static-files"`
-	GitClone         *StepGitClone         `json:"gitClone,omitempty" yaml:"gitClone,omitempty" jsonschema:"oneof_required=actionGitClone" jsonschema_description:"Clone a git repository.\nhttps://ovh.github.io/cds/docs/actions/builtin-gitclone"`
-	GitTag           *StepGitTag           `json:"gitTag,omitempty" yaml:"gitTag,omitempty" jsonschema:"oneof_required=actionGitTag" jsonschema_description:"Create a git tag.\nhttps://ovh.github.io/cds/docs/actions/builtin-gittag"`
-	Release          *StepRelease          `json:"release,omitempty" yaml:"release,omitempty" jsonschema:"oneof_required=actionRelease" jsonschema_description:"Release an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-release"`
-	JUnitReport      *StepJUnitReport      `json:"jUnitReport,omitempty" yaml:"jUnitReport,omitempty" jsonschema:"oneof_required=actionJUNit" jsonschema_description:"Parse JUnit report.\nhttps://ovh.github.io/cds/docs/actions/builtin-junit"`
-	Checkout         *StepCheckout         `json:"checkout,omitempty" yaml:"checkout,omitempty" jsonschema:"oneof_required=actionCheckout" jsonschema_description:"Checkout repository for an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-checkoutapplication"`
-	InstallKey       *StepInstallKey       `json:"installKey,omitempty" yaml:"installKey,omitempty" jsonschema:"oneof_required=actionInstallKey" jsonschema_description:"Install a key (GPG, SSH) in your current workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-installkey"`
-	Deploy           *StepDeploy           `json:"deploy,omitempty" yaml:"deploy,omitempty" jsonschema:"oneof_required=actionDeploy" jsonschema_description:"Deploy an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-deployapplication"`
+	StepCustom              `json:"-" yaml:",inline"`
+	Script                  interface{}                  `json:"script,omitempty" yaml:"script,omitempty" jsonschema:"oneof_type=string;array,oneof_required=actionScript" jsonschema_description:"Script.\nhttps://ovh.github.io/cds/docs/actions/builtin-script"`
+	Coverage                *StepCoverage                `json:"coverage,omitempty" yaml:"coverage,omitempty" jsonschema:"oneof_required=actionCoverage" jsonschema_description:"Parse coverage report.\nhttps://ovh.github.io/cds/docs/actions/builtin-coverage"`
+	CoverageCheck           *StepCoverageCheck           `json:"coverageCheck,omitempty" yaml:"coverageCheck,omitempty" jsonschema:"oneof_required=actionCoverageCheck" jsonschema_description:"Check the coverage against the default branch.\nhttps://ovh.github.io/cds/docs/actions/builtin-coveragecheck"`
+	ArtifactDownload        *StepArtifactDownload        `json:"artifactDownload,omitempty" yaml:"artifactDownload,omitempty" jsonschema:"oneof_required=actionArtifactDownload" jsonschema_description:"Download artifacts in workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-download"`
+	ArtifactUpload          *StepArtifactUpload          `json:"artifactUpload,omitempty" yaml:"artifactUpload,omitempty" jsonschema:"oneof_required=actionArtifactUpload" jsonschema_description:"Upload artifacts from workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-artifact-upload"`
+	ServeStaticFiles        *StepServeStaticFiles        `json:"serveStaticFiles,omitempty" yaml:"serveStaticFiles,omitempty" jsonschema:"oneof_required=actionServeStaticFiles" jsonschema_description:"Serve static files.\nhttps://ovh.github.io/cds/docs/actions/builtin-serve-static-files"`
+	GitClone                *StepGitClone                `json:"gitClone,omitempty" yaml:"gitClone,omitempty" jsonschema:"oneof_required=actionGitClone" jsonschema_description:"Clone a git repository.\nhttps://ovh.github.io/cds/docs/actions/builtin-gitclone"`
+	GitTag                  *StepGitTag                  `json:"gitTag,omitempty" yaml:"gitTag,omitempty" jsonschema:"oneof_required=actionGitTag" jsonschema_description:"Create a git tag.\nhttps://ovh.github.io/cds/docs/actions/builtin-gittag"`
+	Release                 *StepRelease                 `json:"release,omitempty" yaml:"release,omitempty" jsonschema:"oneof_required=actionRelease" jsonschema_description:"Release an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-release"`
+	JUnitReport             *StepJUnitReport             `json:"jUnitReport,omitempty" yaml:"jUnitReport,omitempty" jsonschema:"oneof_required=actionJUNit" jsonschema_description:"Parse JUnit report.\nhttps://ovh.github.io/cds/docs/actions/builtin-junit"`
+	Checkout                *StepCheckout                `json:"checkout,omitempty" yaml:"checkout,omitempty" jsonschema:"oneof_required=actionCheckout" jsonschema_description:"Checkout repository for an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-checkoutapplication"`
+	InstallKey              *StepInstallKey              `json:"installKey,omitempty" yaml:"installKey,omitempty" jsonschema:"oneof_required=actionInstallKey" jsonschema_description:"Install a key (GPG, SSH) in your current workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-installkey"`
+	Deploy                  *StepDeploy                  `json:"deploy,omitempty" yaml:"deploy,omitempty" jsonschema:"oneof_required=actionDeploy" jsonschema_description:"Deploy an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-deployapplication"`
+	DeployKubernetes        *StepDeployKubernetes        `json:"deployKubernetes,omitempty" yaml:"deployKubernetes,omitempty" jsonschema:"oneof_required=actionDeployKubernetes" jsonschema_description:"Deploy on a kubernetes cluster.\nhttps://ovh.github.io/cds/docs/actions/builtin-deploykubernetes"`
+	HelmPush                *StepHelmPush                `json:"helmPush,omitempty" yaml:"helmPush,omitempty" jsonschema:"oneof_required=actionHelmPush" jsonschema_description:"Package and push a helm chart.\nhttps://ovh.github.io/cds/docs/actions/builtin-helmpush"`
+	SBOM                    *StepSBOM                    `json:"sbom,omitempty" yaml:"sbom,omitempty" jsonschema:"oneof_required=actionSBOM" jsonschema_description:"Generate and store a software bill of materials.\nhttps://ovh.github.io/cds/docs/actions/builtin-sbom"`
+	DockerBuild             *StepDockerBuild             `json:"dockerBuild,omitempty" yaml:"dockerBuild,omitempty" jsonschema:"oneof_required=actionDockerBuild" jsonschema_description:"Build and push a docker image.\nhttps://ovh.github.io/cds/docs/actions/builtin-dockerbuild"`
+	ServiceNowChangeRequest *StepServiceNowChangeRequest `json:"serviceNowChangeRequest,omitempty" yaml:"serviceNowChangeRequest,omitempty" jsonschema:"oneof_required=actionServiceNowChangeRequest" jsonschema_description:"Create or update a ServiceNow change request.\nhttps://ovh.github.io/cds/docs/actions/builtin-servicenowchangerequest"`
+	JiraRelease             *StepJiraRelease             `json:"jiraRelease,omitempty" yaml:"jiraRelease,omitempty" jsonschema:"oneof_required=actionJiraRelease" jsonschema_description:"Transition the Jira issues of the run and generate its release notes.\nhttps://ovh.github.io/cds/docs/actions/builtin-jirarelease"`
+	Include                 string                       `json:"include,omitempty" yaml:"include,omitempty" jsonschema:"oneof_required=include" jsonschema_description:"The name of a block of steps of an included file, replaced by its steps.\nhttps://ovh.github.io/cds/docs/concepts/files/pipeline-syntax/#includes"`
 }
 
 // MarshalJSON custom marshal json impl to inline custom step.
@@ -456,9 +721,33 @@ func (s Step) IsValid() bool {
 	if s.isCoverage() {
 		count++
 	}
+	if s.isCoverageCheck() {
+		count++
+	}
+	if s.isDockerBuild() {
+		count++
+	}
+	if s.isDeployKubernetes() {
+		count++
+	}
+	if s.isHelmPush() {
+		count++
+	}
+	if s.isSBOM() {
+		count++
+	}
+	if s.isServiceNowChangeRequest() {
+		count++
+	}
+	if s.isJiraRelease() {
+		count++
+	}
 	if s.isScript() {
 		count++
 	}
+	if s.Include != "" {
+		count++
+	}
 	count += len(s.StepCustom)
 
 	return count == 1
@@ -468,6 +757,9 @@ func (s Step) toAction() (*sdk.Action, error) {
 	if !s.IsValid() {
 		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "malformatted step")
 	}
+	if s.Include != "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "steps %s are not included by the pipeline", s.Include)
+	}
 
 	var a sdk.Action
 	var err error
@@ -493,6 +785,20 @@ func (s Step) toAction() (*sdk.Action, error) {
 		a = s.asDeployApplication()
 	} else if s.isCoverage() {
 		a, err = s.asCoverage()
+	} else if s.isCoverageCheck() {
+		a, err = s.asCoverageCheck()
+	} else if s.isDockerBuild() {
+		a, err = s.asDockerBuild()
+	} else if s.isDeployKubernetes() {
+		a, err = s.asDeployKubernetes()
+	} else if s.isHelmPush() {
+		a, err = s.asHelmPush()
+	} else if s.isSBOM() {
+		a, err = s.asSBOM()
+	} else if s.isServiceNowChangeRequest() {
+		a, err = s.asServiceNowChangeRequest()
+	} else if s.isJiraRelease() {
+		a, err = s.asJiraRelease()
 	} else if s.isScript() {
 		a, err = s.asScript()
 	} else {
@@ -638,6 +944,136 @@ func (s Step) asCoverage() (sdk.Action, error) {
 	return a, nil
 }
 
+func (s Step) isCoverageCheck() bool { return s.CoverageCheck != nil }
+
+func (s Step) asCoverageCheck() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.CoverageCheck)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.CoverageCheckAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
+func (s Step) isDockerBuild() bool { return s.DockerBuild != nil }
+
+func (s Step) asDockerBuild() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.DockerBuild)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.DockerBuildAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
+func (s Step) isDeployKubernetes() bool { return s.DeployKubernetes != nil }
+
+func (s Step) asDeployKubernetes() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.DeployKubernetes)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.DeployKubernetesAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
+func (s Step) isHelmPush() bool { return s.HelmPush != nil }
+
+func (s Step) asHelmPush() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.HelmPush)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.HelmPushAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	for i := range a.Parameters {
+		if a.Parameters[i].Name == "signKey" {
+			a.Parameters[i].Type = sdk.KeyParameter
+		}
+	}
+	return a, nil
+}
+
+func (s Step) isSBOM() bool { return s.SBOM != nil }
+
+func (s Step) asSBOM() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.SBOM)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.SBOMAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
+func (s Step) isServiceNowChangeRequest() bool { return s.ServiceNowChangeRequest != nil }
+
+func (s Step) asServiceNowChangeRequest() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.ServiceNowChangeRequest)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.ServiceNowAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	for i := range a.Parameters {
+		switch a.Parameters[i].Name {
+		case "description":
+			a.Parameters[i].Type = sdk.TextParameter
+		case "attachReport", "waitApproval":
+			a.Parameters[i].Type = sdk.BooleanParameter
+		}
+	}
+	return a, nil
+}
+
+func (s Step) isJiraRelease() bool { return s.JiraRelease != nil }
+
+func (s Step) asJiraRelease() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.JiraRelease)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.JiraReleaseAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	for i := range a.Parameters {
+		if a.Parameters[i].Name == "releaseNotes" {
+			a.Parameters[i].Type = sdk.BooleanParameter
+		}
+	}
+	return a, nil
+}
+
 func (s Step) isDeploy() bool { return s.Deploy != nil }
 
 func (s Step) asDeployApplication() sdk.Action {
@@ -740,6 +1176,11 @@ func (s Step) asArtifactUpload() (sdk.Action, error) {
 		Type:       sdk.BuiltinAction,
 		Parameters: sdk.ParametersFromMap(m),
 	}
+	for i := range a.Parameters {
+		if a.Parameters[i].Name == "signKey" {
+			a.Parameters[i].Type = sdk.KeyParameter
+		}
+	}
 	return a, nil
 }
 
@@ -29,9 +29,14 @@ type Template struct {
 
 // TemplateParameter is the "as code" representation of a sdk.TemplateParameter.
 type TemplateParameter struct {
-	Key      string `json:"key" yaml:"key"`
-	Type     string `json:"type" yaml:"type"`
-	Required bool   `json:"required" yaml:"required"`
+	Key         string   `json:"key" yaml:"key"`
+	Type        string   `json:"type" yaml:"type"`
+	Required    bool     `json:"required" yaml:"required"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Regex       string   `json:"regex,omitempty" yaml:"regex,omitempty"`
+	Choices     []string `json:"choices,omitempty" yaml:"choices,omitempty"`
+	Default     string   `json:"default,omitempty" yaml:"default,omitempty"`
+	DefaultFrom string   `json:"default_from,omitempty" yaml:"default_from,omitempty"`
 }
 
 // Name pattern for template files.
@@ -60,6 +65,11 @@ func NewTemplate(wt sdk.WorkflowTemplate) (Template, error) {
 		exportedTemplate.Parameters[i].Key = p.Key
 		exportedTemplate.Parameters[i].Type = string(p.Type)
 		exportedTemplate.Parameters[i].Required = p.Required
+		exportedTemplate.Parameters[i].Description = p.Description
+		exportedTemplate.Parameters[i].Regex = p.Regex
+		exportedTemplate.Parameters[i].Choices = p.Choices
+		exportedTemplate.Parameters[i].Default = p.Default
+		exportedTemplate.Parameters[i].DefaultFrom = string(p.DefaultFrom)
 	}
 
 	for i := range wt.Pipelines {
@@ -92,9 +102,14 @@ func (w Template) GetTemplate(wkf []byte, pips, apps, envs [][]byte) sdk.Workflo
 
 	for _, p := range w.Parameters {
 		wt.Parameters = append(wt.Parameters, sdk.WorkflowTemplateParameter{
-			Key:      p.Key,
-			Type:     sdk.TemplateParameterType(p.Type),
-			Required: p.Required,
+			Key:         p.Key,
+			Type:        sdk.TemplateParameterType(p.Type),
+			Required:    p.Required,
+			Description: p.Description,
+			Regex:       p.Regex,
+			Choices:     p.Choices,
+			Default:     p.Default,
+			DefaultFrom: sdk.TemplateParameterDefaultFrom(p.DefaultFrom),
 		})
 	}
 
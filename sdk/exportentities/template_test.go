@@ -20,6 +20,7 @@ func TestNewTemplateAndGetTemplate(t *testing.T) {
 			{Key: "my-boolean", Type: "boolean", Required: true},
 			{Key: "my-string", Type: "string", Required: true},
 			{Key: "my-repository", Type: "repository", Required: true},
+			{Key: "my-language", Type: "string", Description: "Language of the project", Choices: []string{"go", "java"}, Default: "go", DefaultFrom: "repository.language"},
 		},
 		Workflow: "workflow.yml",
 	}
@@ -37,6 +38,7 @@ func TestNewTemplateAndGetTemplate(t *testing.T) {
 			{Key: "my-boolean", Type: "boolean", Required: true},
 			{Key: "my-string", Type: "string", Required: true},
 			{Key: "my-repository", Type: "repository", Required: true},
+			{Key: "my-language", Type: "string", Description: "Language of the project", Choices: []string{"go", "java"}, Default: "go", DefaultFrom: sdk.ParameterDefaultFromRepositoryLanguage},
 		},
 	}
 	sdkTemplateYaml, err := yaml.Marshal(sdkTemplate)
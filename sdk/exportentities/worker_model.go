@@ -6,24 +6,31 @@ import (
 
 // WorkerModel is the as code format of a worker model
 type WorkerModel struct {
-	Name          string            `json:"name" yaml:"name"`
-	Group         string            `json:"group" yaml:"group"`
-	Communication string            `json:"communication,omitempty" yaml:"communication,omitempty"`
-	Image         string            `json:"image" yaml:"image"`
-	Registry      string            `json:"registry,omitempty" yaml:"registry,omitempty"`
-	Username      string            `json:"username,omitempty" yaml:"username,omitempty"`
-	Password      string            `json:"password,omitempty" yaml:"password,omitempty"`
-	Description   string            `json:"description" yaml:"description"`
-	Type          string            `json:"type" yaml:"type"`
-	Flavor        string            `json:"flavor,omitempty" yaml:"flavor,omitempty"`
-	Envs          map[string]string `json:"envs,omitempty" yaml:"envs,omitempty"`
-	PatternName   string            `json:"pattern_name,omitempty" yaml:"pattern_name,omitempty"`
-	Shell         string            `json:"shell,omitempty" yaml:"shell,omitempty"`
-	PreCmd        string            `json:"pre_cmd,omitempty" yaml:"pre_cmd,omitempty"`
-	Cmd           string            `json:"cmd,omitempty" yaml:"cmd,omitempty"`
-	PostCmd       string            `json:"post_cmd,omitempty" yaml:"post_cmd,omitempty"`
-	Restricted    bool              `json:"restricted,omitempty" yaml:"restricted,omitempty"`
-	IsDeprecated  bool              `json:"is_deprecated,omitempty" yaml:"is_deprecated,omitempty"`
+	Name           string            `json:"name" yaml:"name"`
+	Group          string            `json:"group" yaml:"group"`
+	Communication  string            `json:"communication,omitempty" yaml:"communication,omitempty"`
+	Image          string            `json:"image" yaml:"image"`
+	Registry       string            `json:"registry,omitempty" yaml:"registry,omitempty"`
+	Username       string            `json:"username,omitempty" yaml:"username,omitempty"`
+	Password       string            `json:"password,omitempty" yaml:"password,omitempty"`
+	OS             string            `json:"os,omitempty" yaml:"os,omitempty"`
+	Isolation      string            `json:"isolation,omitempty" yaml:"isolation,omitempty"`
+	Arch           string            `json:"arch,omitempty" yaml:"arch,omitempty"`
+	Description    string            `json:"description" yaml:"description"`
+	Type           string            `json:"type" yaml:"type"`
+	Flavor         string            `json:"flavor,omitempty" yaml:"flavor,omitempty"`
+	Envs           map[string]string `json:"envs,omitempty" yaml:"envs,omitempty"`
+	PatternName    string            `json:"pattern_name,omitempty" yaml:"pattern_name,omitempty"`
+	Shell          string            `json:"shell,omitempty" yaml:"shell,omitempty"`
+	PreCmd         string            `json:"pre_cmd,omitempty" yaml:"pre_cmd,omitempty"`
+	Cmd            string            `json:"cmd,omitempty" yaml:"cmd,omitempty"`
+	PostCmd        string            `json:"post_cmd,omitempty" yaml:"post_cmd,omitempty"`
+	Subnet         string            `json:"subnet,omitempty" yaml:"subnet,omitempty"`
+	SecurityGroups []string          `json:"security_groups,omitempty" yaml:"security_groups,omitempty"`
+	Spot           bool              `json:"spot,omitempty" yaml:"spot,omitempty"`
+	Preemptible    bool              `json:"preemptible,omitempty" yaml:"preemptible,omitempty"`
+	Restricted     bool              `json:"restricted,omitempty" yaml:"restricted,omitempty"`
+	IsDeprecated   bool              `json:"is_deprecated,omitempty" yaml:"is_deprecated,omitempty"`
 }
 
 type WorkerModelOption func(sdk.Model, *WorkerModel) error
@@ -62,6 +69,9 @@ func NewWorkerModel(wm sdk.Model, opts ...WorkerModelOption) WorkerModel {
 		model.Image = wm.ModelDocker.Image
 		model.Cmd = wm.ModelDocker.Cmd
 		model.Envs = wm.ModelDocker.Envs
+		model.OS = wm.ModelDocker.OS
+		model.Isolation = wm.ModelDocker.Isolation
+		model.Arch = wm.ModelDocker.Arch
 		if wm.ModelDocker.Private {
 			model.Registry = wm.ModelDocker.Registry
 			model.Username = wm.ModelDocker.Username
@@ -73,6 +83,25 @@ func NewWorkerModel(wm sdk.Model, opts ...WorkerModelOption) WorkerModel {
 		model.PreCmd = wm.ModelVirtualMachine.PreCmd
 		model.Cmd = wm.ModelVirtualMachine.Cmd
 		model.PostCmd = wm.ModelVirtualMachine.PostCmd
+		model.Arch = wm.ModelVirtualMachine.Arch
+	case sdk.EC2:
+		model.Flavor = wm.ModelVirtualMachine.Flavor
+		model.Image = wm.ModelVirtualMachine.Image
+		model.PreCmd = wm.ModelVirtualMachine.PreCmd
+		model.Cmd = wm.ModelVirtualMachine.Cmd
+		model.PostCmd = wm.ModelVirtualMachine.PostCmd
+		model.Subnet = wm.ModelVirtualMachine.Subnet
+		model.SecurityGroups = wm.ModelVirtualMachine.SecurityGroups
+		model.Spot = wm.ModelVirtualMachine.Spot
+		model.Arch = wm.ModelVirtualMachine.Arch
+	case sdk.GCE:
+		model.Flavor = wm.ModelVirtualMachine.Flavor
+		model.Image = wm.ModelVirtualMachine.Image
+		model.PreCmd = wm.ModelVirtualMachine.PreCmd
+		model.Cmd = wm.ModelVirtualMachine.Cmd
+		model.PostCmd = wm.ModelVirtualMachine.PostCmd
+		model.Preemptible = wm.ModelVirtualMachine.Preemptible
+		model.Arch = wm.ModelVirtualMachine.Arch
 	}
 
 	for _, opt := range opts {
@@ -100,10 +129,13 @@ func (wm WorkerModel) GetWorkerModel() sdk.Model {
 	switch wm.Type {
 	case sdk.Docker:
 		model.ModelDocker = sdk.ModelDocker{
-			Shell: wm.Shell,
-			Image: wm.Image,
-			Cmd:   wm.Cmd,
-			Envs:  wm.Envs,
+			Shell:     wm.Shell,
+			Image:     wm.Image,
+			Cmd:       wm.Cmd,
+			Envs:      wm.Envs,
+			OS:        wm.OS,
+			Isolation: wm.Isolation,
+			Arch:      wm.Arch,
 		}
 		if wm.Username != "" || wm.Registry != "" || wm.Password != "" {
 			model.ModelDocker.Registry = wm.Registry
@@ -118,6 +150,29 @@ func (wm WorkerModel) GetWorkerModel() sdk.Model {
 			Cmd:     wm.Cmd,
 			PostCmd: wm.PostCmd,
 			PreCmd:  wm.PreCmd,
+			Arch:    wm.Arch,
+		}
+	case sdk.EC2:
+		model.ModelVirtualMachine = sdk.ModelVirtualMachine{
+			Image:          wm.Image,
+			Flavor:         wm.Flavor,
+			Cmd:            wm.Cmd,
+			PostCmd:        wm.PostCmd,
+			PreCmd:         wm.PreCmd,
+			Subnet:         wm.Subnet,
+			SecurityGroups: wm.SecurityGroups,
+			Spot:           wm.Spot,
+			Arch:           wm.Arch,
+		}
+	case sdk.GCE:
+		model.ModelVirtualMachine = sdk.ModelVirtualMachine{
+			Image:       wm.Image,
+			Flavor:      wm.Flavor,
+			Cmd:         wm.Cmd,
+			PostCmd:     wm.PostCmd,
+			PreCmd:      wm.PreCmd,
+			Preemptible: wm.Preemptible,
+			Arch:        wm.Arch,
 		}
 	}
 
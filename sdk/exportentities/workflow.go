@@ -2,6 +2,7 @@ package exportentities
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"sort"
@@ -19,6 +20,7 @@ const (
 	PullPipelineName    = "%s.pip.yml"
 	PullApplicationName = "%s.app.yml"
 	PullEnvironmentName = "%s.env.yml"
+	PullIncludeName     = "%s.inc.yml"
 )
 
 // Workflow is the "as code" representation of a sdk.Workflow
@@ -32,6 +34,8 @@ type Workflow struct {
 	Hooks    map[string][]HookEntry `json:"hooks,omitempty" yaml:"hooks,omitempty" jsonschema_description:"Workflow hooks list."`
 	// this will be filled for simple workflows
 	OneAtATime             *bool                  `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty" jsonschema_description:"Set to true if you want to limit the execution of this node to one at a time."`
+	Concurrency            *ConcurrencyEntry      `json:"concurrency,omitempty" yaml:"concurrency,omitempty" jsonschema_description:"Concurrency group shared with other workflows of the project.\nhttps://ovh.github.io/cds/docs/concepts/workflow/concurrency-groups"`
+	ProductionDeployment   *bool                  `json:"production_deployment,omitempty" yaml:"production_deployment,omitempty" jsonschema_description:"Set to true if the node deploys to production, its runs are used to compute the DORA metrics of the project.\nhttps://ovh.github.io/cds/docs/concepts/workflow/dora-metrics"`
 	Conditions             *ConditionEntry        `json:"conditions,omitempty" yaml:"conditions,omitempty" jsonschema_description:"Conditions to run this node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/run-conditions."`
 	When                   []string               `json:"when,omitempty" yaml:"when,omitempty" jsonschema_description:"Set manual and status condition (ex: 'success')."` //This is used only for manual and success condition
 	PipelineName           string                 `json:"pipeline,omitempty" yaml:"pipeline,omitempty" jsonschema_description:"The name of a pipeline used for pipeline node."`
@@ -42,12 +46,16 @@ type Workflow struct {
 	ProjectIntegrationName string                 `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema_description:"The integration to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	PipelineHooks          []HookEntry            `json:"pipeline_hooks,omitempty" yaml:"pipeline_hooks,omitempty"`
 	// extra workflow data
-	Permissions      map[string]int                 `json:"permissions,omitempty" yaml:"permissions,omitempty" jsonschema_description:"The permissions for the workflow (ex: myGroup: 7).\nhttps://ovh.github.io/cds/docs/concepts/permissions"`
-	Metadata         map[string]string              `json:"metadata,omitempty" yaml:"metadata,omitempty"`
-	PurgeTags        []string                       `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
-	Notifications    []NotificationEntry            `json:"notify,omitempty" yaml:"notify,omitempty"` // This is used when the workflow have only one pipeline
-	HistoryLength    *int64                         `json:"history_length,omitempty" yaml:"history_length,omitempty"`
-	MapNotifications map[string][]NotificationEntry `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have more than one pipeline
+	Permissions       map[string]int                 `json:"permissions,omitempty" yaml:"permissions,omitempty" jsonschema_description:"The permissions for the workflow (ex: myGroup: 7).\nhttps://ovh.github.io/cds/docs/concepts/permissions"`
+	Metadata          map[string]string              `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	PurgeTags         []string                       `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
+	Notifications     []NotificationEntry            `json:"notify,omitempty" yaml:"notify,omitempty"` // This is used when the workflow have only one pipeline
+	HistoryLength     *int64                         `json:"history_length,omitempty" yaml:"history_length,omitempty"`
+	CancelInProgress  bool                           `json:"cancel_in_progress,omitempty" yaml:"cancel_in_progress,omitempty" jsonschema_description:"Set to true to stop the runs in progress on a branch when a new run is triggered on the same branch."`
+	Deployment        bool                           `json:"deployment,omitempty" yaml:"deployment,omitempty" jsonschema_description:"Set to true if the workflow deploys, its runs are frozen during the freeze windows of the project.\nhttps://ovh.github.io/cds/docs/concepts/workflow/freeze-windows"`
+	MapNotifications  map[string][]NotificationEntry `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have more than one pipeline
+	EventIntegrations []string                       `json:"event_integrations,omitempty" yaml:"event_integrations,omitempty" jsonschema_description:"Names of the event integrations of the project that receive the events of the workflow."`
+	PinnedVersions    []string                       `json:"pinned_versions,omitempty" yaml:"pinned_versions,omitempty" jsonschema_description:"Versions of the actions and plugins used by the runs of the workflow (ex: my-action@1.0.0).\nhttps://ovh.github.io/cds/docs/concepts/workflow/pinned-versions"`
 }
 
 // WorkflowPulled contains all the yaml base64 that are needed to generate a workflow tar file.
@@ -68,6 +76,7 @@ type WorkflowPulledItem struct {
 type NodeEntry struct {
 	ID                     int64                  `json:"-" yaml:"-"`
 	DependsOn              []string               `json:"depends_on,omitempty" yaml:"depends_on,omitempty" jsonschema_description:"Names of the parent nodes, can be pipelines, forks or joins."`
+	Join                   *JoinEntry             `json:"join,omitempty" yaml:"join,omitempty" jsonschema_description:"Success policy of the join of the parent nodes.\nhttps://ovh.github.io/cds/docs/concepts/workflow/join"`
 	Conditions             *ConditionEntry        `json:"conditions,omitempty" yaml:"conditions,omitempty" jsonschema_description:"Conditions to run this node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/run-conditions."`
 	When                   []string               `json:"when,omitempty" yaml:"when,omitempty" jsonschema_description:"Set manual and status condition (ex: 'success')."` //This is used only for manual and success condition
 	PipelineName           string                 `json:"pipeline,omitempty" yaml:"pipeline,omitempty" jsonschema_description:"The name of a pipeline used for pipeline node."`
@@ -75,13 +84,44 @@ type NodeEntry struct {
 	EnvironmentName        string                 `json:"environment,omitempty" yaml:"environment,omitempty" jsonschema_description:"The environment to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	ProjectIntegrationName string                 `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema_description:"The integration to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	OneAtATime             *bool                  `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty" jsonschema_description:"Set to true if you want to limit the execution of this node to one at a time."`
+	Concurrency            *ConcurrencyEntry      `json:"concurrency,omitempty" yaml:"concurrency,omitempty" jsonschema_description:"Concurrency group shared with other workflows of the project.\nhttps://ovh.github.io/cds/docs/concepts/workflow/concurrency-groups"`
+	ProductionDeployment   *bool                  `json:"production_deployment,omitempty" yaml:"production_deployment,omitempty" jsonschema_description:"Set to true if the node deploys to production, its runs are used to compute the DORA metrics of the project.\nhttps://ovh.github.io/cds/docs/concepts/workflow/dora-metrics"`
+	Verification           *VerificationEntry     `json:"verification,omitempty" yaml:"verification,omitempty" jsonschema_description:"Deployment node verified by the node, the rollback node is triggered if the node fails.\nhttps://ovh.github.io/cds/docs/concepts/workflow/verifications"`
 	Payload                map[string]interface{} `json:"payload,omitempty" yaml:"payload,omitempty"`
 	Parameters             map[string]string      `json:"parameters,omitempty" yaml:"parameters,omitempty" jsonschema_description:"List of parameters for the workflow."`
+	SubWorkflowName        string                 `json:"workflow,omitempty" yaml:"workflow,omitempty" jsonschema_description:"The name of a workflow of the project called by the node, the node waits for the end of its run.\nhttps://ovh.github.io/cds/docs/concepts/workflow/sub-workflows"`
+	Gate                   *GateEntry             `json:"gate,omitempty" yaml:"gate,omitempty" jsonschema_description:"Gate waiting for the decision of an external system before the children of the node are triggered.\nhttps://ovh.github.io/cds/docs/concepts/workflow/gates"`
 	OutgoingHookModelName  string                 `json:"trigger,omitempty" yaml:"trigger,omitempty"`
 	OutgoingHookConfig     map[string]string      `json:"config,omitempty" yaml:"config,omitempty"`
 	Permissions            map[string]int         `json:"permissions,omitempty" yaml:"permissions,omitempty" jsonschema_description:"The permissions for the node (ex: myGroup: 7).\nhttps://ovh.github.io/cds/docs/concepts/permissions"`
 }
 
+// ConcurrencyEntry represents the concurrency group of a node as code
+type ConcurrencyEntry struct {
+	Group  string `json:"group" yaml:"group" jsonschema_description:"Name of the concurrency group (ex: deploy-prod)."`
+	Policy string `json:"policy,omitempty" yaml:"policy,omitempty" jsonschema_description:"Policy applied to the runs waiting in the group: queue (default) or cancel-superseded."`
+}
+
+// VerificationEntry represents the verification of a deployment node as code
+type VerificationEntry struct {
+	Deployment string `json:"deployment" yaml:"deployment" jsonschema_description:"Name of the verified deployment node, it should be an ancestor of the node."`
+	Rollback   string `json:"rollback" yaml:"rollback" jsonschema_description:"Name of the node triggered with the previously deployed version when the verification fails."`
+}
+
+// GateEntry represents a gate node as code
+type GateEntry struct {
+	Timeout   int64  `json:"timeout,omitempty" yaml:"timeout,omitempty" jsonschema_description:"Time given to take a decision in seconds, 24 hours if not given."`
+	OnTimeout string `json:"on_timeout,omitempty" yaml:"on_timeout,omitempty" jsonschema_description:"Decision taken when the timeout is over: deny (default) or approve."`
+	Webhook   string `json:"webhook,omitempty" yaml:"webhook,omitempty" jsonschema_description:"URL notified with the callback url of the gate when it is opened."`
+}
+
+// JoinEntry represents the success policy of a join as code
+type JoinEntry struct {
+	Policy   string   `json:"policy,omitempty" yaml:"policy,omitempty" jsonschema_description:"Number of parents that must succeed: all (default), any or at-least."`
+	Min      int      `json:"min,omitempty" yaml:"min,omitempty" jsonschema_description:"Number of parents that must succeed with the policy at-least."`
+	Optional []string `json:"optional,omitempty" yaml:"optional,omitempty" jsonschema_description:"Names of the parents that are not awaited by the join."`
+}
+
 type ConditionEntry struct {
 	PlainConditions []PlainConditionEntry `json:"plain,omitempty" yaml:"check,omitempty"`
 	LuaScript       string                `json:"script,omitempty" yaml:"script,omitempty"`
@@ -130,6 +170,7 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 							}
 							ancestors = append(ancestors, parentNode.Name)
 						}
+						entry.Join = newJoinEntry(w, *node)
 					} else {
 						ancestors = append(ancestors, node.Name)
 					}
@@ -140,6 +181,7 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 		for _, jc := range n.JoinContext {
 			ancestors = append(ancestors, jc.ParentName)
 		}
+		entry.Join = newJoinEntry(w, n)
 	}
 
 	sort.Strings(ancestors)
@@ -193,6 +235,24 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 			entry.OneAtATime = &n.Context.Mutex
 		}
 
+		if n.Context.ConcurrencyGroup != "" {
+			entry.Concurrency = &ConcurrencyEntry{
+				Group:  n.Context.ConcurrencyGroup,
+				Policy: n.Context.ConcurrencyPolicy,
+			}
+		}
+
+		if n.Context.ProductionDeployment {
+			entry.ProductionDeployment = &n.Context.ProductionDeployment
+		}
+
+		if n.Context.VerificationOf != "" {
+			entry.Verification = &VerificationEntry{
+				Deployment: n.Context.VerificationOf,
+				Rollback:   n.Context.RollbackNode,
+			}
+		}
+
 		if n.Context.HasDefaultPayload() {
 			enc := dump.NewDefaultEncoder()
 			enc.ExtraFields.DetailedMap = false
@@ -219,6 +279,23 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 		}
 	}
 
+	if n.SubWorkflowContext != nil {
+		entry.SubWorkflowName = n.SubWorkflowContext.WorkflowName
+		if n.SubWorkflowContext.Payload != "" {
+			if err := json.Unmarshal([]byte(n.SubWorkflowContext.Payload), &entry.Payload); err != nil {
+				return entry, sdk.WrapError(err, "unable to decode payload of sub-workflow %s", n.Name)
+			}
+		}
+	}
+
+	if n.GateContext != nil {
+		entry.Gate = &GateEntry{
+			Timeout:   n.GateContext.Timeout,
+			OnTimeout: n.GateContext.OnTimeout,
+			Webhook:   n.GateContext.Webhook,
+		}
+	}
+
 	if n.OutGoingHookContext != nil {
 		entry.OutgoingHookModelName = n.OutGoingHookContext.HookModelName
 
@@ -300,6 +377,62 @@ func joinAsNode(n *sdk.Node) bool {
 	return n.Context != nil && (n.Context.Conditions.LuaScript != "" || len(n.Context.Conditions.PlainConditions) > 0)
 }
 
+// newJoinEntry returns the success policy of a join, or nil if all the parents of the join must succeed.
+func newJoinEntry(w sdk.Workflow, n sdk.Node) *JoinEntry {
+	var entry JoinEntry
+	if n.JoinPolicy != nil && n.JoinPolicy.Type != sdk.JoinPolicyAll {
+		entry.Policy = n.JoinPolicy.Type
+		entry.Min = n.JoinPolicy.Min
+	}
+	for _, jc := range n.JoinContext {
+		if !jc.Optional {
+			continue
+		}
+		name := jc.ParentName
+		if parentNode := w.WorkflowData.NodeByRef(jc.ParentName); parentNode != nil {
+			name = parentNode.Name
+		}
+		entry.Optional = append(entry.Optional, name)
+	}
+	if entry.Policy == "" && len(entry.Optional) == 0 {
+		return nil
+	}
+	sort.Strings(entry.Optional)
+	return &entry
+}
+
+// setJoinPolicy sets the success policy and the optional parents of a join.
+func (j *JoinEntry) setJoinPolicy(join *sdk.Node) error {
+	if j == nil {
+		return nil
+	}
+	if j.Policy != "" || j.Min != 0 {
+		join.JoinPolicy = &sdk.NodeJoinPolicy{Type: j.Policy, Min: j.Min}
+	}
+	for _, o := range j.Optional {
+		var found bool
+		for i := range join.JoinContext {
+			if join.JoinContext[i].ParentName == o {
+				join.JoinContext[i].Optional = true
+				found = true
+			}
+		}
+		if !found {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "optional parent %s of the join is not in depends_on", o)
+		}
+	}
+	return nil
+}
+
+// sameJoinPolicy returns true if two joins have the same success policy and the same optional parents.
+func sameJoinPolicy(a, b sdk.Node) bool {
+	ea, eb := newJoinEntry(sdk.Workflow{}, a), newJoinEntry(sdk.Workflow{}, b)
+	if ea == nil || eb == nil {
+		return ea == eb
+	}
+	return ea.Policy == eb.Policy && ea.Min == eb.Min && strings.Join(ea.Optional, ",") == strings.Join(eb.Optional, ",")
+}
+
 //NewWorkflow creates a new exportable workflow
 func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (Workflow, error) {
 	exportedWorkflow := Workflow{}
@@ -322,6 +455,10 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 		exportedWorkflow.HistoryLength = &w.HistoryLength
 	}
 
+	exportedWorkflow.CancelInProgress = w.CancelInProgress
+	exportedWorkflow.Deployment = w.Deployment
+	exportedWorkflow.PinnedVersions = w.PinnedVersions
+
 	exportedWorkflow.PurgeTags = w.PurgeTags
 
 	nodes := w.WorkflowData.Array()
@@ -337,6 +474,8 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 		exportedWorkflow.EnvironmentName = entry.EnvironmentName
 		exportedWorkflow.ProjectIntegrationName = entry.ProjectIntegrationName
 		exportedWorkflow.OneAtATime = entry.OneAtATime
+		exportedWorkflow.Concurrency = entry.Concurrency
+		exportedWorkflow.ProductionDeployment = entry.ProductionDeployment
 		if entry.Conditions != nil && (len(entry.Conditions.PlainConditions) > 0 || entry.Conditions.LuaScript != "") {
 			exportedWorkflow.When = entry.When
 			exportedWorkflow.Conditions = entry.Conditions
@@ -406,6 +545,10 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 		return exportedWorkflow, err
 	}
 
+	for _, integ := range w.EventIntegrations {
+		exportedWorkflow.EventIntegrations = append(exportedWorkflow.EventIntegrations, integ.Name)
+	}
+
 	for _, f := range opts {
 		if err := f(w, &exportedWorkflow); err != nil {
 			return exportedWorkflow, sdk.WrapError(err, "Unable to run function")
@@ -436,6 +579,8 @@ func (w Workflow) Entries() map[string]NodeEntry {
 		Payload:                w.Payload,
 		Parameters:             w.Parameters,
 		OneAtATime:             w.OneAtATime,
+		Concurrency:            w.Concurrency,
+		ProductionDeployment:   w.ProductionDeployment,
 	}
 	return map[string]NodeEntry{
 		w.PipelineName: singleEntry,
@@ -549,6 +694,12 @@ func (w Workflow) GetWorkflow() (*sdk.Workflow, error) {
 	} else {
 		wf.HistoryLength = sdk.DefaultHistoryLength
 	}
+	wf.CancelInProgress = w.CancelInProgress
+	wf.Deployment = w.Deployment
+	wf.PinnedVersions = w.PinnedVersions
+	if err := wf.PinnedVersions.IsValid(); err != nil {
+		return nil, err
+	}
 
 	rand.Seed(time.Now().Unix())
 	entries := w.Entries()
@@ -588,6 +739,11 @@ func (w Workflow) GetWorkflow() (*sdk.Workflow, error) {
 		return nil, err
 	}
 
+	// Event integrations are given by name, their ids are set when importing the workflow in a project
+	for _, name := range w.EventIntegrations {
+		wf.EventIntegrations = append(wf.EventIntegrations, sdk.ProjectIntegration{Name: name})
+	}
+
 	// if there is a template instance id on the workflow export, add it
 	if w.Template != nil {
 		templatePath := strings.Split(*w.Template, "/")
@@ -627,6 +783,16 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 		node.Type = sdk.NodeTypePipeline
 	} else if e.OutgoingHookModelName != "" {
 		node.Type = sdk.NodeTypeOutGoingHook
+	} else if e.SubWorkflowName != "" {
+		node.Type = sdk.NodeTypeSubWorkflow
+		node.SubWorkflowContext = &sdk.NodeSubWorkflow{WorkflowName: e.SubWorkflowName}
+	} else if e.Gate != nil {
+		node.Type = sdk.NodeTypeGate
+		node.GateContext = &sdk.NodeGate{
+			Timeout:   e.Gate.Timeout,
+			OnTimeout: e.Gate.OnTimeout,
+			Webhook:   e.Gate.Webhook,
+		}
 	} else if len(e.DependsOn) > 1 {
 		node.Type = sdk.NodeTypeJoin
 		node.JoinContext = make([]sdk.NodeJoin, 0, len(e.DependsOn))
@@ -635,6 +801,15 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 		}
 	}
 
+	if e.Join != nil && len(e.DependsOn) < 2 {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "join of node %s can only be set with several parents in depends_on", name)
+	}
+	if node.Type == sdk.NodeTypeJoin {
+		if err := e.Join.setJoinPolicy(node); err != nil {
+			return nil, err
+		}
+	}
+
 	if len(e.Permissions) > 0 {
 		//Compute permissions
 		node.Groups = make([]sdk.GroupPermission, 0, len(e.Permissions))
@@ -658,7 +833,13 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 		}
 	}
 
-	if len(e.Payload) > 0 {
+	if len(e.Payload) > 0 && node.SubWorkflowContext != nil {
+		payload, err := subWorkflowPayload(e.Payload)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid payload of sub-workflow %s: %v", name, err)
+		}
+		node.SubWorkflowContext.Payload = payload
+	} else if len(e.Payload) > 0 {
 		if len(e.DependsOn) > 0 {
 			return nil, sdk.WrapError(sdk.ErrInvalidNodeDefaultPayload, "Default payload cannot be set on another node than the first one (node : %s)", name)
 		}
@@ -691,6 +872,23 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 		node.Context.Mutex = *e.OneAtATime
 	}
 
+	if e.Concurrency != nil {
+		node.Context.ConcurrencyGroup = e.Concurrency.Group
+		node.Context.ConcurrencyPolicy = e.Concurrency.Policy
+		if err := node.Context.CheckConcurrency(); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.ProductionDeployment != nil {
+		node.Context.ProductionDeployment = *e.ProductionDeployment
+	}
+
+	if e.Verification != nil {
+		node.Context.VerificationOf = e.Verification.Deployment
+		node.Context.RollbackNode = e.Verification.Rollback
+	}
+
 	if e.OutgoingHookModelName != "" {
 		node.Type = sdk.NodeTypeOutGoingHook
 		config := sdk.WorkflowNodeHookConfig{}
@@ -707,11 +905,54 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 	return node, nil
 }
 
+// subWorkflowPayload returns the payload of a sub-workflow as JSON, the maps decoded from YAML have
+// interface keys that are converted to strings.
+func subWorkflowPayload(p map[string]interface{}) (string, error) {
+	var convert func(i interface{}) interface{}
+	convert = func(i interface{}) interface{} {
+		switch v := i.(type) {
+		case map[interface{}]interface{}:
+			m := make(map[string]interface{}, len(v))
+			for k, val := range v {
+				m[fmt.Sprintf("%v", k)] = convert(val)
+			}
+			return m
+		case map[string]interface{}:
+			m := make(map[string]interface{}, len(v))
+			for k, val := range v {
+				m[k] = convert(val)
+			}
+			return m
+		case []interface{}:
+			a := make([]interface{}, len(v))
+			for j := range v {
+				a[j] = convert(v[j])
+			}
+			return a
+		}
+		return i
+	}
+	btes, err := json.Marshal(convert(p))
+	if err != nil {
+		return "", err
+	}
+	return string(btes), nil
+}
+
 func (w *Workflow) processHooks(n *sdk.Node, wf *sdk.Workflow) {
 	var addHooks = func(hooks []HookEntry) {
 		for _, h := range hooks {
 			cfg := make(sdk.WorkflowNodeHookConfig, len(h.Config))
+			// The type and choices of the values are the ones of the builtin model
+			m := sdk.GetBuiltinHookModelByName(h.Model)
 			for k, v := range h.Config {
+				if m != nil {
+					if d, ok := m.DefaultConfig[k]; ok {
+						d.Value = v
+						cfg[k] = d
+						continue
+					}
+				}
 				var hType string
 				switch h.Model {
 				case sdk.KafkaHookModelName, sdk.RabbitMQHookModelName:
@@ -814,11 +1055,26 @@ func (e *NodeEntry) processNodeAncestors(name string, w *sdk.Workflow) (bool, er
 
 	// Compute join
 
-	// Try to find an existing join with the same references
+	joinContext := make([]sdk.NodeJoin, 0, len(e.DependsOn))
+	for _, d := range e.DependsOn {
+		joinContext = append(joinContext, sdk.NodeJoin{
+			ParentName: d,
+		})
+	}
+	newJoin := &sdk.Node{
+		JoinContext: joinContext,
+		Type:        sdk.NodeTypeJoin,
+		Ref:         fmt.Sprintf("fakeRef%d", e.ID),
+	}
+	if err := e.Join.setJoinPolicy(newJoin); err != nil {
+		return false, err
+	}
+
+	// Try to find an existing join with the same references and the same policy
 	var join *sdk.Node
 	for i := range w.WorkflowData.Joins {
 		j := &w.WorkflowData.Joins[i]
-		var joinFound = true
+		var joinFound = sameJoinPolicy(*j, *newJoin)
 
 		for _, ref := range j.JoinContext {
 			var refFound bool
@@ -842,17 +1098,7 @@ func (e *NodeEntry) processNodeAncestors(name string, w *sdk.Workflow) (bool, er
 
 	var appendJoin bool
 	if join == nil {
-		joinContext := make([]sdk.NodeJoin, 0, len(e.DependsOn))
-		for _, d := range e.DependsOn {
-			joinContext = append(joinContext, sdk.NodeJoin{
-				ParentName: d,
-			})
-		}
-		join = &sdk.Node{
-			JoinContext: joinContext,
-			Type:        sdk.NodeTypeJoin,
-			Ref:         fmt.Sprintf("fakeRef%d", e.ID),
-		}
+		join = newJoin
 		appendJoin = true
 	}
 
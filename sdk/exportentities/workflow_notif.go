@@ -68,7 +68,8 @@ func craftNotificationEntry(ctx context.Context, w sdk.Workflow, notif sdk.Workf
 			entry.Settings.Template.Body = ""
 		}
 		if entry.Settings.Template.Body == "" && entry.Settings.Template.Subject == "" {
-			if entry.Settings.Template.DisableComment == nil || !*entry.Settings.Template.DisableComment {
+			if (entry.Settings.Template.DisableComment == nil || !*entry.Settings.Template.DisableComment) &&
+				(entry.Settings.Template.TestsSummary == nil || !*entry.Settings.Template.TestsSummary) {
 				entry.Settings.Template = nil
 			}
 		}
@@ -81,7 +82,11 @@ func craftNotificationEntry(ctx context.Context, w sdk.Workflow, notif sdk.Workf
 		len(entry.Settings.Recipients) == 0 &&
 		entry.Settings.SendToAuthor == nil &&
 		entry.Settings.SendToGroups == nil &&
-		entry.Settings.Template == nil {
+		entry.Settings.Template == nil &&
+		entry.Settings.Webhook == nil &&
+		len(entry.Settings.Labels) == 0 &&
+		entry.Settings.Conditions.LuaScript == "" &&
+		len(entry.Settings.Conditions.PlainConditions) == 0 {
 		entry.Settings = nil
 	}
 
@@ -185,6 +190,9 @@ func ProcessNotificationValues(notif NotificationEntry) (sdk.WorkflowNotificatio
 		if n.Settings.Template.DisableComment == nil || !*n.Settings.Template.DisableComment {
 			n.Settings.Template.DisableComment = nil
 		}
+		if n.Settings.Template.TestsSummary == nil || !*n.Settings.Template.TestsSummary {
+			n.Settings.Template.TestsSummary = nil
+		}
 	}
 	return n, nil
 }
@@ -1133,6 +1133,99 @@ workflow:
     - aa_2
     when:
     - manual
+`,
+		},
+		{
+			name: "Join with success policy",
+			yaml: `name: regional
+version: v1.0
+workflow:
+  build:
+    pipeline: build
+  build-asia:
+    depends_on:
+    - build
+    pipeline: build
+  build-eu:
+    depends_on:
+    - build
+    pipeline: build
+  build-us:
+    depends_on:
+    - build
+    pipeline: build
+  deploy:
+    depends_on:
+    - build-asia
+    - build-eu
+    - build-us
+    join:
+      policy: at-least
+      min: 1
+      optional:
+      - build-asia
+    pipeline: deploy
+`,
+		},
+		{
+			name: "Sub-workflow node",
+			yaml: `name: release
+version: v1.0
+workflow:
+  build:
+    pipeline: build
+  infra:
+    depends_on:
+    - build
+    payload:
+      env: prod
+      version: '{{.cds.version}}'
+    workflow: infra
+`,
+		},
+		{
+			name: "Gate node",
+			yaml: `name: release
+version: v1.0
+workflow:
+  build:
+    pipeline: build
+  deploy:
+    depends_on:
+    - gate
+    pipeline: deploy
+  gate:
+    depends_on:
+    - build
+    gate:
+      timeout: 3600
+      on_timeout: approve
+      webhook: https://change.example.com/cds
+`,
+		},
+		{
+			name: "Verification node",
+			yaml: `name: release
+version: v1.0
+workflow:
+  deploy:
+    pipeline: deploy
+    application: api
+    environment: production
+  rollback:
+    depends_on:
+    - deploy
+    pipeline: deploy
+    application: api
+    environment: production
+  smoke-tests:
+    depends_on:
+    - deploy
+    pipeline: smoke-tests
+    environment: production
+    verification:
+      deployment: deploy
+      rollback: rollback
 `,
 		},
 		{
@@ -1158,6 +1251,73 @@ workflow:
     - success
     pipeline: env
     one_at_a_time: true
+`,
+		},
+		{
+			name: "Workflow with concurrency group on root",
+			yaml: `name: myconcurrency
+version: v1.0
+concurrency:
+  group: deploy-prod
+pipeline: deploy
+`,
+		},
+		{
+			name: "Workflow with cancel in progress",
+			yaml: `name: mycancel
+version: v1.0
+pipeline: build
+cancel_in_progress: true
+`,
+		},
+		{
+			name: "Deployment workflow",
+			yaml: `name: mydeploy
+version: v1.0
+pipeline: deploy
+deployment: true
+`,
+		},
+		{
+			name: "Workflow with concurrency group pipeline child",
+			yaml: `name: myconcurrency
+version: v1.0
+workflow:
+  build:
+    pipeline: build
+  deploy:
+    depends_on:
+    - build
+    when:
+    - success
+    pipeline: deploy
+    concurrency:
+      group: deploy-prod
+      policy: cancel-superseded
+`,
+		},
+		{
+			name: "Workflow with hook config, notification conditions and event integrations",
+			yaml: `name: mynightly
+version: v1.0
+pipeline: build
+pipeline_hooks:
+- type: Scheduler
+  config:
+    catchup_policy: run-all-missed
+    cron: 0 2 * * *
+    payload: '{}'
+    timezone: Europe/Paris
+notify:
+- type: email
+  settings:
+    conditions:
+      check:
+      - variable: cds.status
+        operator: eq
+        value: Success
+event_integrations:
+- my-kafka
 `,
 		},
 	}
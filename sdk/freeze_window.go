@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/pkg/errors"
+)
+
+// Freeze window policies, applied to the node runs of the deployment workflows during the window.
+const (
+	// FreezeWindowPolicyBlock stops the node runs.
+	FreezeWindowPolicyBlock = "block"
+	// FreezeWindowPolicyQueue keeps the node runs waiting until the end of the window.
+	FreezeWindowPolicyQueue = "queue"
+)
+
+// FreezeWindow is a period during which the deployment workflows of a project can't deploy. A window is ad-hoc
+// with a start and an end date, or recurring with a cron expression and a duration.
+type FreezeWindow struct {
+	ID                int64     `json:"id" db:"id" cli:"id,key"`
+	ProjectID         int64     `json:"project_id" db:"project_id"`
+	Name              string    `json:"name" db:"name" cli:"name"`
+	Environment       string    `json:"environment,omitempty" db:"environment" cli:"environment"`
+	Start             time.Time `json:"start,omitempty" db:"start_date" cli:"start"`
+	End               time.Time `json:"end,omitempty" db:"end_date" cli:"end"`
+	Cron              string    `json:"cron,omitempty" db:"cron" cli:"cron"`
+	Duration          int64     `json:"duration,omitempty" db:"duration" cli:"duration"`
+	Timezone          string    `json:"timezone,omitempty" db:"timezone" cli:"timezone"`
+	Policy            string    `json:"policy" db:"policy" cli:"policy"`
+	OverrideGroupID   *int64    `json:"override_group_id,omitempty" db:"override_group_id"`
+	OverrideGroupName string    `json:"override_group_name,omitempty" db:"-" cli:"override_group"`
+	Author            string    `json:"author" db:"author" cli:"author"`
+	Created           time.Time `json:"created" db:"created"`
+}
+
+// IsRecurring returns true if the window is given by a cron expression.
+func (w FreezeWindow) IsRecurring() bool {
+	return w.Cron != ""
+}
+
+// IsValid returns an error if the window is not valid.
+func (w FreezeWindow) IsValid() error {
+	if !NamePatternRegex.MatchString(w.Name) {
+		return NewErrorFrom(ErrWrongRequest, "invalid freeze window name %q, should match %s", w.Name, NamePattern)
+	}
+	switch w.Policy {
+	case FreezeWindowPolicyBlock, FreezeWindowPolicyQueue:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid freeze window policy %q", w.Policy)
+	}
+	if !w.IsRecurring() {
+		if w.Start.IsZero() || !w.End.After(w.Start) {
+			return NewErrorFrom(ErrWrongRequest, "invalid freeze window, the end should be after the start")
+		}
+		return nil
+	}
+	if !w.Start.IsZero() || !w.End.IsZero() {
+		return NewErrorFrom(ErrWrongRequest, "invalid freeze window, a recurring window has no start and end dates")
+	}
+	if _, err := cronexpr.Parse(w.Cron); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid freeze window cron expression %q: %v", w.Cron, err)
+	}
+	if w.Duration <= 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid freeze window, the duration of a recurring window should be positive")
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid freeze window timezone %q", w.Timezone)
+	}
+	return nil
+}
+
+// MatchEnvironment returns true if the window applies to given environment, a window without environment applies
+// to all the environments.
+func (w FreezeWindow) MatchEnvironment(env string) bool {
+	return w.Environment == "" || w.Environment == env
+}
+
+// ActiveAt returns true with the end of the window if the window is active at given time. For a recurring window
+// the end is the end of the current occurrence.
+func (w FreezeWindow) ActiveAt(now time.Time) (bool, time.Time) {
+	if !w.IsRecurring() {
+		return !now.Before(w.Start) && now.Before(w.End), w.End
+	}
+	expr, err := cronexpr.Parse(w.Cron)
+	if err != nil {
+		return false, time.Time{}
+	}
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false, time.Time{}
+	}
+	// The current occurrence is the first one that started during the last duration
+	duration := time.Duration(w.Duration) * time.Second
+	start := expr.Next(now.In(loc).Add(-duration))
+	if start.IsZero() || start.After(now) {
+		return false, time.Time{}
+	}
+	return true, start.Add(duration)
+}
+
+// FreezeWindowOverride is the audit of a run started during active freeze windows by a user allowed to override
+// them.
+type FreezeWindowOverride struct {
+	ID                int64             `json:"id" db:"id" cli:"id,key"`
+	ProjectID         int64             `json:"project_id" db:"project_id"`
+	WorkflowName      string            `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	WorkflowRunNumber int64             `json:"workflow_run_number" db:"workflow_run_number" cli:"run"`
+	FreezeWindows     FreezeWindowNames `json:"freeze_windows" db:"freeze_windows" cli:"freeze_windows"`
+	Username          string            `json:"username" db:"username" cli:"username"`
+	Reason            string            `json:"reason" db:"reason" cli:"reason"`
+	Created           time.Time         `json:"created" db:"created" cli:"created"`
+}
+
+// FreezeWindowNames is the list of the freeze windows overridden by a run.
+type FreezeWindowNames []string
+
+// Value returns driver.Value from freeze window names.
+func (n FreezeWindowNames) Value() (driver.Value, error) {
+	j, err := json.Marshal(n)
+	return j, WrapError(err, "cannot marshal FreezeWindowNames")
+}
+
+// Scan freeze window names.
+func (n *FreezeWindowNames) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, n), "cannot unmarshal FreezeWindowNames")
+}
+
+// WorkflowRunFreeze is the override of the freeze windows given to a workflow run, only the windows that were
+// active when the run was started are overridden.
+type WorkflowRunFreeze struct {
+	Username  string  `json:"username"`
+	Reason    string  `json:"reason"`
+	WindowIDs []int64 `json:"window_ids"`
+}
+
+// Overrides returns true if the override applies to given window.
+func (f *WorkflowRunFreeze) Overrides(w FreezeWindow) bool {
+	if f == nil {
+		return false
+	}
+	for _, id := range f.WindowIDs {
+		if id == w.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns driver.Value from workflow run freeze.
+func (f WorkflowRunFreeze) Value() (driver.Value, error) {
+	j, err := json.Marshal(f)
+	return j, WrapError(err, "cannot marshal WorkflowRunFreeze")
+}
+
+// Scan workflow run freeze.
+func (f *WorkflowRunFreeze) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, f), "cannot unmarshal WorkflowRunFreeze")
+}
@@ -0,0 +1,43 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreezeWindowIsValid(t *testing.T) {
+	start := time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, FreezeWindow{Name: "christmas", Policy: FreezeWindowPolicyBlock, Start: start, End: start.Add(24 * time.Hour)}.IsValid())
+	require.NoError(t, FreezeWindow{Name: "weekend", Policy: FreezeWindowPolicyQueue, Cron: "0 18 * * 5", Duration: 3600, Timezone: "Europe/Paris"}.IsValid())
+
+	require.Error(t, FreezeWindow{Name: "christmas", Policy: "skip", Start: start, End: start.Add(time.Hour)}.IsValid())
+	require.Error(t, FreezeWindow{Name: "christmas", Policy: FreezeWindowPolicyBlock, Start: start, End: start}.IsValid())
+	require.Error(t, FreezeWindow{Name: "weekend", Policy: FreezeWindowPolicyQueue, Cron: "0 18 * * 5"}.IsValid())
+	require.Error(t, FreezeWindow{Name: "weekend", Policy: FreezeWindowPolicyQueue, Cron: "0 18 * * 5", Duration: 3600, Start: start}.IsValid())
+	require.Error(t, FreezeWindow{Name: "weekend", Policy: FreezeWindowPolicyQueue, Cron: "foo", Duration: 3600}.IsValid())
+}
+
+func TestFreezeWindowActiveAt(t *testing.T) {
+	start := time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC)
+	w := FreezeWindow{Start: start, End: start.Add(24 * time.Hour)}
+	active, end := w.ActiveAt(start.Add(time.Hour))
+	assert.True(t, active)
+	assert.Equal(t, start.Add(24*time.Hour), end)
+	active, _ = w.ActiveAt(start.Add(-time.Hour))
+	assert.False(t, active)
+	active, _ = w.ActiveAt(start.Add(24 * time.Hour))
+	assert.False(t, active)
+
+	// Every friday at 18:00 for 62 hours, the 2020-12-18 is a friday
+	w = FreezeWindow{Cron: "0 18 * * 5", Duration: 62 * 3600, Timezone: "UTC"}
+	active, end = w.ActiveAt(time.Date(2020, 12, 19, 12, 0, 0, 0, time.UTC))
+	assert.True(t, active)
+	assert.Equal(t, time.Date(2020, 12, 21, 8, 0, 0, 0, time.UTC), end.UTC())
+	active, _ = w.ActiveAt(time.Date(2020, 12, 18, 17, 0, 0, 0, time.UTC))
+	assert.False(t, active)
+	active, _ = w.ActiveAt(time.Date(2020, 12, 21, 9, 0, 0, 0, time.UTC))
+	assert.False(t, active)
+}
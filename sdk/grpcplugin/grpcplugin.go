@@ -33,11 +33,15 @@ type Plugin interface {
 	Instance() *Common
 }
 
-// StartPlugin starts a plugin, returns stdoutPipe, stderrPipe and socketName
-func StartPlugin(ctx context.Context, pluginName string, workdir, cmd string, args []string, env []string) (io.Reader, string, error) {
+// StartPlugin starts a plugin, returns stdoutPipe, stderrPipe and socketName. The plugin is run in the sandbox
+// if it is not nil.
+func StartPlugin(ctx context.Context, pluginName string, workdir, cmd string, args []string, env []string, sandbox *sdk.GRPCPluginSandbox) (io.Reader, string, error) {
 	c := exec.CommandContext(ctx, cmd, args...)
 	c.Dir = workdir
 	c.Env = env
+	if err := sandboxCommand(c, sandbox); err != nil {
+		return nil, "", err
+	}
 	stdoutPipe, err := c.StdoutPipe()
 	if err != nil {
 		return nil, "", err
@@ -55,12 +59,20 @@ func StartPlugin(ctx context.Context, pluginName string, workdir, cmd string, ar
 		return nil, "", err
 	}
 
+	cleanup, errSandbox := sandboxProcess(pluginName, c.Process.Pid, sandbox)
 	go func() {
 		if err := c.Wait(); err != nil {
 			log.Info(ctx, "GRPC Plugin %s wait failed:%+v", cmd, err)
 		}
+		if cleanup != nil {
+			cleanup()
+		}
 		log.Info(ctx, "GRPC Plugin %s end", cmd)
 	}()
+	if errSandbox != nil {
+		_ = c.Process.Kill()
+		return nil, "", errSandbox
+	}
 
 	log.Info(ctx, "GRPC Plugin %s started", cmd)
 
@@ -0,0 +1,86 @@
+package grpcplugin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// cgroupRoot is the mount point of the cgroup v2 hierarchy.
+var cgroupRoot = "/sys/fs/cgroup"
+
+// sandboxCommand runs the plugin with the user of the sandbox. The output directories of the workspace are
+// given to this user, the rest of the workspace is only readable by the plugin.
+func sandboxCommand(c *exec.Cmd, s *sdk.GRPCPluginSandbox) error {
+	if s == nil || s.User == "" {
+		return nil
+	}
+	u, err := user.Lookup(s.User)
+	if err != nil {
+		return sdk.WrapError(err, "unable to find the user %s of the plugin sandbox", s.User)
+	}
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	gid, err := strconv.Atoi(u.Gid)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	c.SysProcAttr = &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+	}
+
+	for _, d := range s.OutputDirs {
+		dir := filepath.Join(c.Dir, d)
+		if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+			return sdk.WrapError(err, "unable to create output directory %s", d)
+		}
+		if err := filepath.Walk(dir, func(path string, _ os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Lchown(path, uid, gid)
+		}); err != nil {
+			return sdk.WrapError(err, "unable to give output directory %s to user %s", d, s.User)
+		}
+	}
+	return nil
+}
+
+// sandboxProcess moves the plugin process in a cgroup limiting its cpu and memory. The returned func removes
+// the cgroup once the process is over.
+func sandboxProcess(pluginName string, pid int, s *sdk.GRPCPluginSandbox) (func(), error) {
+	if s == nil || (s.CPUs <= 0 && s.Memory <= 0) {
+		return func() {}, nil
+	}
+	dir := filepath.Join(cgroupRoot, fmt.Sprintf("cds-plugin-%s-%d", pluginName, pid))
+	if err := os.Mkdir(dir, os.FileMode(0755)); err != nil {
+		return nil, sdk.WrapError(err, "unable to create cgroup of plugin %s", pluginName)
+	}
+	cleanup := func() { _ = os.Remove(dir) }
+
+	limits := map[string]string{}
+	if s.CPUs > 0 {
+		limits["cpu.max"] = fmt.Sprintf("%d 100000", int64(s.CPUs*100000))
+	}
+	if s.Memory > 0 {
+		limits["memory.max"] = strconv.FormatInt(s.Memory*1024*1024, 10)
+	}
+	for file, value := range limits {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(value), os.FileMode(0644)); err != nil {
+			return cleanup, sdk.WrapError(err, "unable to set %s of plugin %s", file, pluginName)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "cgroup.procs"), []byte(strconv.Itoa(pid)), os.FileMode(0644)); err != nil {
+		return cleanup, sdk.WrapError(err, "unable to move plugin %s in its cgroup", pluginName)
+	}
+	return cleanup, nil
+}
@@ -0,0 +1,22 @@
+//go:build !linux
+// +build !linux
+
+package grpcplugin
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func sandboxCommand(c *exec.Cmd, s *sdk.GRPCPluginSandbox) error {
+	if s != nil && s.IsEnabled() {
+		return fmt.Errorf("plugin sandbox is only supported on linux")
+	}
+	return nil
+}
+
+func sandboxProcess(pluginName string, pid int, s *sdk.GRPCPluginSandbox) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,304 @@
+package hatchery
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	// warmPoolWorkerPrefix is the prefix of the name of the workers spawned for the warm pool
+	warmPoolWorkerPrefix = "warm-"
+	// maxWarmPoolSpawnDurations is the number of spawn durations kept to compute the average spawn duration of a model
+	maxWarmPoolSpawnDurations = 20
+	// defaultWarmPoolSpawnDuration is used for the models which were never spawned by the hatchery
+	defaultWarmPoolSpawnDuration = time.Minute
+)
+
+var warmPool = newWarmPoolState()
+
+// warmPoolState keeps the queue statistics used to size the warm pool, and the warm workers spawned by the hatchery
+type warmPoolState struct {
+	mu sync.Mutex
+	// arrivals are the times each job was first seen by the hatchery, by worker model
+	arrivals map[int64]map[int64]time.Time
+	// spawnDurations are the last spawn durations, by worker model
+	spawnDurations map[int64][]time.Duration
+	// workers are the worker models of the warm workers, by worker name
+	workers map[string]int64
+	// starting is the number of warm workers requested but not spawned yet, by worker model
+	starting map[int64]int
+	// available is the number of warm workers waiting for a job, by worker model
+	available map[int64]int
+}
+
+func newWarmPoolState() *warmPoolState {
+	return &warmPoolState{
+		arrivals:       make(map[int64]map[int64]time.Time),
+		spawnDurations: make(map[int64][]time.Duration),
+		workers:        make(map[string]int64),
+		starting:       make(map[int64]int),
+		available:      make(map[int64]int),
+	}
+}
+
+// recordJob records the arrival of a job for a model, a job is counted once even if it is seen several times in the queue
+func (p *warmPoolState) recordJob(modelID, jobID int64, t time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.arrivals[modelID] == nil {
+		p.arrivals[modelID] = make(map[int64]time.Time)
+	}
+	if _, has := p.arrivals[modelID][jobID]; !has {
+		p.arrivals[modelID][jobID] = t
+	}
+}
+
+// recordSpawnDuration records the duration of a worker spawn for a model
+func (p *warmPoolState) recordSpawnDuration(modelID int64, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ds := append(p.spawnDurations[modelID], d)
+	if len(ds) > maxWarmPoolSpawnDurations {
+		ds = ds[len(ds)-maxWarmPoolSpawnDurations:]
+	}
+	p.spawnDurations[modelID] = ds
+}
+
+// targetSize returns the number of warm workers needed for a model, the arrivals older than the window are dropped
+func (p *warmPoolState) targetSize(modelID int64, now time.Time, window time.Duration, maxSize int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for jobID, t := range p.arrivals[modelID] {
+		if now.Sub(t) > window {
+			delete(p.arrivals[modelID], jobID)
+		}
+	}
+
+	spawnDuration := defaultWarmPoolSpawnDuration
+	if ds := p.spawnDurations[modelID]; len(ds) > 0 {
+		var total time.Duration
+		for _, d := range ds {
+			total += d
+		}
+		spawnDuration = total / time.Duration(len(ds))
+	}
+
+	return warmPoolSize(len(p.arrivals[modelID]), window, spawnDuration, maxSize)
+}
+
+// warmPoolSize returns the number of jobs expected during a spawn: the rate of incoming jobs multiplied by the spawn duration
+func warmPoolSize(arrivals int, window, spawnDuration time.Duration, maxSize int) int {
+	if arrivals == 0 || window <= 0 || maxSize <= 0 {
+		return 0
+	}
+	size := int(math.Ceil(float64(arrivals) * spawnDuration.Seconds() / window.Seconds()))
+	if size > maxSize {
+		return maxSize
+	}
+	return size
+}
+
+// size returns the number of warm workers of a model, waiting for a job or starting
+func (p *warmPoolState) size(modelID int64) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.available[modelID] + p.starting[modelID]
+}
+
+func (p *warmPoolState) requestWorker(modelID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.starting[modelID]++
+}
+
+func (p *warmPoolState) workerSpawned(name string, modelID int64, spawned bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.starting[modelID] > 0 {
+		p.starting[modelID]--
+	}
+	if spawned {
+		p.workers[name] = modelID
+		p.available[modelID]++
+	}
+}
+
+// take reserves a warm worker of a model for a job, it returns false if there is no warm worker available
+func (p *warmPoolState) take(modelID int64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.available[modelID] == 0 {
+		return false
+	}
+	p.available[modelID]--
+	return true
+}
+
+// refresh counts the warm workers waiting for a job from the worker pool, and forgets the warm workers which are gone.
+// It returns the registered warm workers waiting for a job, by worker model.
+func (p *warmPoolState) refresh(pool []sdk.Worker) map[int64][]sdk.Worker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	poolByName := make(map[string]sdk.Worker, len(pool))
+	for _, w := range pool {
+		poolByName[w.Name] = w
+	}
+
+	p.available = make(map[int64]int)
+	idleWorkers := make(map[int64][]sdk.Worker)
+	for name, modelID := range p.workers {
+		w, has := poolByName[name]
+		if !has {
+			delete(p.workers, name)
+			continue
+		}
+		switch w.Status {
+		case sdk.StatusWorkerPending:
+			p.available[modelID]++
+		case sdk.StatusWaiting:
+			p.available[modelID]++
+			idleWorkers[modelID] = append(idleWorkers[modelID], w)
+		}
+	}
+	return idleWorkers
+}
+
+// canUseWarmWorker returns true if a job can be run by a warm worker. The warm workers are spawned
+// without the requirements of the jobs, so they can't run the jobs which need services, volumes, resources or options
+func canUseWarmWorker(requirements []sdk.Requirement) bool {
+	for _, r := range requirements {
+		switch r.Type {
+		case sdk.ServiceRequirement, sdk.VolumeRequirement, sdk.MemoryRequirement, sdk.CPURequirement, sdk.HostnameRequirement:
+			return false
+		case sdk.ModelRequirement:
+			if len(strings.Fields(r.Value)) > 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// provisionWarmPool spawns or disables warm workers for each worker model to match the size computed from the queue statistics
+func provisionWarmPool(ctx context.Context, h InterfaceWithModels, startWorkerChan chan<- workerStarterRequest) error {
+	ctx = observability.ContextWithTag(ctx,
+		observability.TagServiceName, h.Name(),
+		observability.TagServiceType, h.Type(),
+	)
+
+	pool, err := WorkerPool(ctx, h)
+	if err != nil {
+		return err
+	}
+	idleWorkers := warmPool.refresh(pool)
+
+	conf := h.Configuration().Provision.WarmPool
+	window := time.Duration(conf.Window) * time.Second
+	now := time.Now()
+
+	var nbWarmWorkers int
+	for i := range models {
+		m := &models[i]
+		target := warmPool.targetSize(m.ID, now, window, conf.MaxSize)
+		current := warmPool.size(m.ID)
+
+		// Disable the warm workers that are not needed anymore
+		for _, w := range idleWorkers[m.ID] {
+			if current <= target {
+				break
+			}
+			log.Info(ctx, "provisionWarmPool> disabling warm worker %s of model %s/%s", w.Name, m.Group.Name, m.Name)
+			if err := h.CDSClient().WorkerDisable(ctx, w.ID); err != nil {
+				log.Error(ctx, "provisionWarmPool> unable to disable worker %s: %v", w.Name, err)
+				continue
+			}
+			current--
+		}
+
+		if current < target && canWarmModel(ctx, h, m) {
+			for ; current < target; current++ {
+				if !checkCapacities(ctx, h) {
+					break
+				}
+				log.Debug("provisionWarmPool> spawning warm worker for model %s/%s", m.Group.Name, m.Name)
+				warmPool.requestWorker(m.ID)
+				startWorkerChan <- workerStarterRequest{warmPoolModel: m}
+			}
+		}
+		nbWarmWorkers += current
+	}
+
+	stats.Record(ctx, GetMetrics().WarmWorkers.M(int64(nbWarmWorkers)))
+	return nil
+}
+
+func canWarmModel(ctx context.Context, h InterfaceWithModels, m *sdk.Model) bool {
+	if m.Type != h.ModelType() || m.IsDeprecated || m.NbSpawnErr > 5 {
+		return false
+	}
+	if h.NeedRegistration(ctx, m) {
+		return false
+	}
+	return h.CanSpawn(ctx, m, 0, nil)
+}
+
+func spawnWarmWorker(ctx context.Context, h Interface, m *sdk.Model) bool {
+	arg := SpawnArguments{
+		WorkerName:   generateWorkerNameWithPrefix(warmPoolWorkerPrefix, h.Service().Name, m.Group.Name+"/"+m.Name),
+		Model:        m,
+		WarmPool:     true,
+		HatcheryName: h.Service().Name,
+	}
+
+	var spawned bool
+	defer func() {
+		warmPool.workerSpawned(arg.WorkerName, m.ID, spawned)
+	}()
+
+	maxProv := h.Configuration().Provision.MaxConcurrentProvisioning
+	if maxProv < 1 {
+		maxProv = defaultMaxProvisioning
+	}
+	if atomic.LoadInt64(&nbWorkerToStart) >= int64(maxProv) {
+		log.Debug("hatchery> spawnWarmWorker> max concurrent provisioning reached")
+		return false
+	}
+
+	atomic.AddInt64(&nbWorkerToStart, 1)
+	defer func(i *int64) {
+		atomic.AddInt64(i, -1)
+	}(&nbWorkerToStart)
+
+	// Get a JWT to authentified the worker
+	jwt, err := NewWorkerToken(h.Service().Name, h.GetPrivateKey(), time.Now().Add(1*time.Hour), arg)
+	if err != nil {
+		log.Error(ctx, "hatchery> spawnWarmWorker> cannot get a token for warm worker of model %s: %v", m.Name, err)
+		return false
+	}
+	arg.WorkerToken = jwt
+
+	start := time.Now()
+	err = checkWorkerModelTrust(ctx, h, m)
+	if err == nil {
+		err = h.SpawnWorker(ctx, arg)
+	}
+	if err != nil {
+		log.Warning(ctx, "hatchery> spawnWarmWorker> cannot spawn warm worker for model %s: %v", m.Name, err)
+		return false
+	}
+	warmPool.recordSpawnDuration(m.ID, time.Since(start))
+
+	spawned = true
+	return true
+}
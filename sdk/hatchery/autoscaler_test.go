@@ -0,0 +1,67 @@
+package hatchery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_warmPoolSize(t *testing.T) {
+	// no job
+	require.Equal(t, 0, warmPoolSize(0, 10*time.Minute, time.Minute, 3))
+	// 10 jobs in 10 minutes, 1 minute to spawn a worker
+	require.Equal(t, 1, warmPoolSize(10, 10*time.Minute, time.Minute, 3))
+	// 20 jobs in 10 minutes, 1 minute to spawn a worker
+	require.Equal(t, 2, warmPoolSize(20, 10*time.Minute, time.Minute, 3))
+	// 1 job in 10 minutes, 10 seconds to spawn a worker
+	require.Equal(t, 1, warmPoolSize(1, 10*time.Minute, 10*time.Second, 3))
+	// limited by the max size
+	require.Equal(t, 3, warmPoolSize(100, 10*time.Minute, time.Minute, 3))
+	require.Equal(t, 0, warmPoolSize(100, 10*time.Minute, time.Minute, 0))
+}
+
+func Test_warmPoolState(t *testing.T) {
+	p := newWarmPoolState()
+	now := time.Now()
+
+	// a job seen twice is counted once, old jobs are dropped
+	p.recordJob(1, 100, now.Add(-15*time.Minute))
+	for i := int64(0); i < 20; i++ {
+		p.recordJob(1, i, now.Add(-time.Minute))
+		p.recordJob(1, i, now)
+	}
+	p.recordSpawnDuration(1, 20*time.Second)
+	p.recordSpawnDuration(1, 100*time.Second)
+	require.Equal(t, 2, p.targetSize(1, now, 10*time.Minute, 5))
+	require.Len(t, p.arrivals[1], 20)
+	require.Equal(t, 0, p.targetSize(2, now, 10*time.Minute, 5))
+
+	p.requestWorker(1)
+	p.requestWorker(1)
+	require.Equal(t, 2, p.size(1))
+	p.workerSpawned("warm-w1", 1, true)
+	p.workerSpawned("warm-w2", 1, false)
+	require.Equal(t, 1, p.size(1))
+
+	require.True(t, p.take(1))
+	require.False(t, p.take(1))
+
+	idle := p.refresh([]sdk.Worker{{ID: "1", Name: "warm-w1", Status: sdk.StatusWaiting}})
+	require.Equal(t, 1, p.size(1))
+	require.Len(t, idle[1], 1)
+
+	p.refresh(nil)
+	require.Equal(t, 0, p.size(1))
+	require.Empty(t, p.workers)
+}
+
+func Test_canUseWarmWorker(t *testing.T) {
+	require.True(t, canUseWarmWorker(nil))
+	require.True(t, canUseWarmWorker([]sdk.Requirement{{Type: sdk.BinaryRequirement, Value: "git"}, {Type: sdk.ModelRequirement, Value: "shared.infra/debian"}}))
+	require.False(t, canUseWarmWorker([]sdk.Requirement{{Type: sdk.ModelRequirement, Value: "shared.infra/debian --privileged"}}))
+	require.False(t, canUseWarmWorker([]sdk.Requirement{{Type: sdk.ServiceRequirement, Name: "pg", Value: "postgres:9.5"}}))
+	require.False(t, canUseWarmWorker([]sdk.Requirement{{Type: sdk.MemoryRequirement, Value: "4096"}}))
+}
@@ -0,0 +1,128 @@
+package hatchery
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// defaultDrainTimeout is used when a drain is requested without timeout and no drain timeout is configured
+const defaultDrainTimeout = 10 * time.Minute
+
+var drain = newDrainer()
+
+type drainer struct {
+	mu     sync.Mutex
+	status sdk.HatcheryDrainStatus
+	start  chan struct{}
+}
+
+func newDrainer() *drainer {
+	return &drainer{start: make(chan struct{})}
+}
+
+// Drain puts the hatchery in drain mode: it stops provisioning new workers, waits for the running jobs
+// at most until the timeout, then deregisters and stops. Calling Drain on a draining hatchery has no effect.
+func Drain(timeout time.Duration) sdk.HatcheryDrainStatus {
+	drain.mu.Lock()
+	defer drain.mu.Unlock()
+	if !drain.status.Draining {
+		if timeout <= 0 {
+			timeout = defaultDrainTimeout
+		}
+		drain.status.Draining = true
+		drain.status.Since = time.Now()
+		drain.status.Deadline = drain.status.Since.Add(timeout)
+		close(drain.start)
+	}
+	return drain.status
+}
+
+// DrainStatus returns the progress of the drain of the hatchery
+func DrainStatus() sdk.HatcheryDrainStatus {
+	drain.mu.Lock()
+	defer drain.mu.Unlock()
+	return drain.status
+}
+
+// IsDraining returns true if the hatchery is in drain mode
+func IsDraining() bool {
+	return DrainStatus().Draining
+}
+
+func (d *drainer) setRunningWorkers(n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.status.RunningWorkers = n
+}
+
+func (d *drainer) setDone() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.status.Done = true
+}
+
+// drainRunningWorkers counts the workers that are running or may run a job, and returns the idle registered workers
+func drainRunningWorkers(pool []sdk.Worker) (int, []sdk.Worker) {
+	var running int
+	var idle []sdk.Worker
+	for _, w := range pool {
+		switch w.Status {
+		case sdk.StatusWaiting:
+			idle = append(idle, w)
+		case sdk.StatusDisabled:
+		default:
+			running++
+		}
+	}
+	return running, idle
+}
+
+// waitDrain waits for the workers of the hatchery to finish their jobs, until the deadline of the drain.
+// The idle workers are disabled so they don't take new jobs. Then the hatchery signs out from the API.
+func waitDrain(ctx context.Context, h Interface) {
+	status := DrainStatus()
+	log.Info(ctx, "hatchery> drain> waiting for running jobs until %s", status.Deadline)
+
+	tick := time.NewTicker(5 * time.Second)
+	defer tick.Stop()
+	for {
+		pool, err := WorkerPool(ctx, h)
+		if err != nil {
+			log.Warning(ctx, "hatchery> drain> unable to get the worker pool: %v", err)
+		} else {
+			running, idle := drainRunningWorkers(pool)
+			for _, w := range idle {
+				log.Info(ctx, "hatchery> drain> disabling idle worker %s", w.Name)
+				if err := h.CDSClient().WorkerDisable(ctx, w.ID); err != nil {
+					log.Error(ctx, "hatchery> drain> unable to disable worker %s: %v", w.Name, err)
+				}
+			}
+			drain.setRunningWorkers(running)
+			if running == 0 {
+				log.Info(ctx, "hatchery> drain> no more running workers")
+				break
+			}
+			log.Info(ctx, "hatchery> drain> %d workers still running", running)
+		}
+
+		if time.Now().After(status.Deadline) {
+			log.Warning(ctx, "hatchery> drain> timeout reached, stopping the hatchery with running workers")
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+
+	if err := h.CDSClient().AuthConsumerSignout(); err != nil {
+		log.Error(ctx, "hatchery> drain> unable to sign out: %v", err)
+	}
+	drain.setDone()
+}
@@ -0,0 +1,43 @@
+package hatchery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_drainRunningWorkers(t *testing.T) {
+	running, idle := drainRunningWorkers([]sdk.Worker{
+		{Name: "w1", Status: sdk.StatusWaiting},
+		{Name: "w2", Status: sdk.StatusBuilding},
+		{Name: "w3", Status: sdk.StatusWorkerPending},
+		{Name: "w4", Status: sdk.StatusDisabled},
+		{Name: "w5", Status: sdk.StatusChecking},
+	})
+	require.Equal(t, 3, running)
+	require.Len(t, idle, 1)
+	require.Equal(t, "w1", idle[0].Name)
+}
+
+func TestDrain(t *testing.T) {
+	drain = newDrainer()
+	defer func() { drain = newDrainer() }()
+
+	require.False(t, IsDraining())
+
+	status := Drain(time.Minute)
+	require.True(t, status.Draining)
+	require.Equal(t, status.Since.Add(time.Minute), status.Deadline)
+	select {
+	case <-drain.start:
+	default:
+		t.Fatal("drain not started")
+	}
+
+	// a second drain keeps the first deadline
+	require.Equal(t, status, Drain(time.Hour))
+	require.True(t, IsDraining())
+}
@@ -12,7 +12,7 @@ import (
 
 	cache "github.com/patrickmn/go-cache"
 	"go.opencensus.io/stats"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/sdk"
@@ -50,13 +50,31 @@ func Create(ctx context.Context, h Interface) error {
 		cancel()
 	}()
 
+	go func() {
+		for {
+			select {
+			case <-c:
+				// With a drain timeout, the first signal drains the hatchery and the next one stops it
+				drainTimeout := h.Configuration().Provision.DrainTimeout
+				if drainTimeout > 0 && !IsDraining() {
+					log.Info(ctx, "hatchery> signal received, draining the hatchery")
+					Drain(time.Duration(drainTimeout) * time.Second)
+					continue
+				}
+				cancel()
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	go func() {
 		select {
-		case <-c:
-			defer cancel()
-			return
+		case <-drain.start:
+			waitDrain(ctx, h)
+			cancel()
 		case <-ctx.Done():
-			return
 		}
 	}()
 
@@ -65,7 +83,7 @@ func Create(ctx context.Context, h Interface) error {
 		return fmt.Errorf("Create> Init error: %v", err)
 	}
 
-	var chanRegister, chanGetModels <-chan time.Time
+	var chanRegister, chanGetModels, chanWarmPool <-chan time.Time
 	var modelType string
 
 	hWithModels, isWithModels := h.(InterfaceWithModels)
@@ -82,6 +100,14 @@ func Create(ctx context.Context, h Interface) error {
 		chanRegister = time.Tick(time.Duration(h.Configuration().Provision.RegisterFrequency) * time.Second) // nolint
 		chanGetModels = time.Tick(10 * time.Second)                                                          // nolint
 
+		if warmPoolConf := h.Configuration().Provision.WarmPool; warmPoolConf.Enabled {
+			frequency := warmPoolConf.Frequency
+			if frequency <= 0 {
+				frequency = 30
+			}
+			chanWarmPool = time.Tick(time.Duration(frequency) * time.Second) // nolint
+		}
+
 		modelType = hWithModels.ModelType()
 	}
 
@@ -138,7 +164,9 @@ func Create(ctx context.Context, h Interface) error {
 			var traceEnded *struct{}
 			currentCtx, currentCancel := context.WithTimeout(ctx, 10*time.Minute)
 			if val, has := j.Header.Get(tracingutils.SampledHeader); has && val == "1" {
-				currentCtx, _ = observability.New(currentCtx, h, "hatchery.JobReceive", trace.AlwaysSample(), trace.SpanKindServer)
+				// the span is a child of the span of the workflow run if the api sent its trace context
+				currentCtx = tracingutils.DefaultFormat.Extract(currentCtx, tracingutils.MapCarrier(j.Header))
+				currentCtx, _ = observability.New(currentCtx, h, "hatchery.JobReceive", trace.SpanKindServer)
 
 				r, _ := j.Header.Get(sdk.WorkflowRunHeader)
 				w, _ := j.Header.Get(sdk.WorkflowHeader)
@@ -199,6 +227,13 @@ func Create(ctx context.Context, h Interface) error {
 				continue
 			}
 
+			//Check if hatchery is draining
+			if IsDraining() {
+				log.Debug("hatchery> job %d not taken, hatchery is draining", j.ID)
+				endTrace("draining")
+				continue
+			}
+
 			//Check if hatchery if able to start a new worker
 			if !checkCapacities(ctx, h) {
 				log.Info(ctx, "hatchery %s is not able to provision new worker", h.Service().Name)
@@ -251,6 +286,16 @@ func Create(ctx context.Context, h Interface) error {
 			if chosenModel != nil {
 				//We got a model, let's start a worker
 				workerRequest.model = chosenModel
+
+				if chanWarmPool != nil {
+					warmPool.recordJob(chosenModel.ID, j.ID, t0)
+					// A warm worker of the model is waiting for a job, let it take this one
+					if canUseWarmWorker(workerRequest.requirements) && warmPool.take(chosenModel.ID) {
+						log.Info(ctx, "hatchery> job %d will be taken by a warm worker of model %s/%s", j.ID, chosenModel.Group.Name, chosenModel.Name)
+						endTrace("warm worker")
+						continue
+					}
+				}
 			}
 
 			//Ask to start
@@ -258,9 +303,20 @@ func Create(ctx context.Context, h Interface) error {
 			workersStartChan <- workerRequest
 
 		case <-chanRegister:
+			if IsDraining() {
+				continue
+			}
 			if err := workerRegister(ctx, hWithModels, workersStartChan); err != nil {
 				log.Warning(ctx, "Error on workerRegister: %s", err)
 			}
+
+		case <-chanWarmPool:
+			if IsDraining() {
+				continue
+			}
+			if err := provisionWarmPool(ctx, hWithModels, workersStartChan); err != nil {
+				log.Warning(ctx, "Error on provisionWarmPool: %s", err)
+			}
 		}
 	}
 }
@@ -279,7 +335,7 @@ func canRunJob(ctx context.Context, h Interface, j workerStarterRequest) bool {
 		}
 
 		// Skip others requirement as we can't check it
-		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement {
+		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement {
 			log.Debug("canRunJob> %d - job %d - job with service, plugin, network or memory requirement. Skip these check as we can't checkt it on hatchery routine", j.timestamp, j.id)
 			continue
 		}
@@ -353,8 +409,8 @@ func canRunJobWithModel(ctx context.Context, h InterfaceWithModels, j workerStar
 			}
 		}
 
-		// service and memory requirements are only supported by docker model
-		if model.Type != sdk.Docker && (r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement) {
+		// service, memory and cpu requirements are only supported by docker model
+		if model.Type != sdk.Docker && (r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement) {
 			log.Debug("canRunJob> %d - job %d - job with service requirement or memory requirement: only for model docker. current model:%s", j.timestamp, j.id, model.Type)
 			return false
 		}
@@ -365,18 +421,22 @@ func canRunJobWithModel(ctx context.Context, h InterfaceWithModels, j workerStar
 		}
 
 		// Skip other requirement as we can't check it
-		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement {
+		if r.Type == sdk.PluginRequirement || r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement || r.Type == sdk.CPURequirement {
 			log.Debug("canRunJob> %d - job %d - job with service, plugin, network or memory requirement. Skip these check as we can't check it on hatchery routine", j.timestamp, j.id)
 			continue
 		}
 
-		if r.Type == sdk.OSArchRequirement && model.RegisteredOS != "" && model.RegisteredArch != "" && r.Value != (model.RegisteredOS+"/"+model.RegisteredArch) {
-			log.Debug("canRunJob> %d - job %d - job with OSArch requirement: cannot spawn on this OSArch. current model: %s/%s", j.timestamp, j.id, model.RegisteredOS, model.RegisteredArch)
+		if r.Type == sdk.OSArchRequirement && !model.MatchOSArch(r.Value) {
+			log.Debug("canRunJob> %d - job %d - job with OSArch requirement: cannot spawn on this OSArch. current model: %s/%s", j.timestamp, j.id, model.GetOS(), model.GetArch())
 			return false
 		}
 
 		if !containsModelRequirement && !containsHostnameRequirement {
 			if r.Type == sdk.BinaryRequirement {
+				// tools with a version are installed by the worker from the tool registry
+				if _, version := sdk.ParseBinaryRequirement(r.Value); version != "" && h.Configuration().Provision.WorkerToolRegistry != "" {
+					continue
+				}
 				found := false
 				// Check binary requirement against worker model capabilities
 				for _, c := range model.RegisteredCapabilities {
@@ -27,6 +27,7 @@ type workerStarterRequest struct {
 	timestamp           int64
 	workflowNodeRunID   int64
 	registerWorkerModel *sdk.Model
+	warmPoolModel       *sdk.Model
 }
 
 func PanicDump(h Interface) func(s string) (io.WriteCloser, error) {
@@ -58,6 +59,12 @@ func startWorkerStarters(ctx context.Context, h Interface) chan<- workerStarterR
 
 func workerStarter(ctx context.Context, h Interface, workerNum string, jobs <-chan workerStarterRequest) {
 	for j := range jobs {
+		// Start a worker for the warm pool
+		if j.warmPoolModel != nil {
+			_ = spawnWarmWorker(ctx, h, j.warmPoolModel)
+			continue
+		}
+
 		// Start a worker for a job
 		if m := j.registerWorkerModel; m == nil {
 			_ = spawnWorkerForJob(ctx, h, j)
@@ -92,7 +99,11 @@ func workerStarter(ctx context.Context, h Interface, workerNum string, jobs <-ch
 			}
 			arg.WorkerToken = jwt
 
-			if err := h.SpawnWorker(ctx, arg); err != nil {
+			err = checkWorkerModelTrust(ctx, h, m)
+			if err == nil {
+				err = h.SpawnWorker(ctx, arg)
+			}
+			if err != nil {
 				log.Warning(ctx, "workerRegister> cannot spawn worker for register:%s err:%v", m.Name, err)
 				var spawnError = sdk.SpawnErrorForm{
 					Error: fmt.Sprintf("cannot spawn worker for register: %v", err),
@@ -192,8 +203,12 @@ func spawnWorkerForJob(ctx context.Context, h Interface, j workerStarterRequest)
 	arg.WorkerToken = jwt
 	log.Debug("hatchery> spawnWorkerForJob> new JWT for worker: %s", jwt)
 
-	errSpawn := h.SpawnWorker(ctx, arg)
+	errSpawn := checkWorkerModelTrust(ctx, h, j.model)
+	if errSpawn == nil {
+		errSpawn = h.SpawnWorker(ctx, arg)
+	}
 	next()
+	recordSpawnDuration(ctxJob, time.Since(start), errSpawn)
 	if errSpawn != nil {
 		ctxSendSpawnInfo, next = observability.Span(ctxJob, "hatchery.QueueJobSendSpawnInfo", observability.Tag("status", "errSpawn"), observability.Tag("msg", sdk.MsgSpawnInfoHatcheryErrorSpawn.ID))
 		SendSpawnInfo(ctxSendSpawnInfo, h, j.id, sdk.SpawnMsg{
@@ -204,6 +219,9 @@ func spawnWorkerForJob(ctx context.Context, h Interface, j workerStarterRequest)
 		next()
 		return false
 	}
+	if j.model != nil {
+		warmPool.recordSpawnDuration(j.model.ID, time.Since(start))
+	}
 
 	ctxSendSpawnInfo, next = observability.Span(ctxJob, "hatchery.SendSpawnInfo", observability.Tag("msg", sdk.MsgSpawnInfoHatcheryStartsSuccessfully.ID))
 	SendSpawnInfo(ctxSendSpawnInfo, h, j.id, sdk.SpawnMsg{
@@ -232,7 +250,10 @@ func generateWorkerName(hatcheryName string, isRegister bool, modelName string)
 	if isRegister {
 		prefix = "register-"
 	}
+	return generateWorkerNameWithPrefix(prefix, hatcheryName, modelName)
+}
 
+func generateWorkerNameWithPrefix(prefix, hatcheryName, modelName string) string {
 	maxLength := 63
 	hName := hatcheryName + "-"
 	random := namesgenerator.GetRandomNameCDS(0)
@@ -261,3 +282,13 @@ func generateWorkerName(hatcheryName string, isRegister bool, modelName string)
 	}
 	return slug.Convert(workerName)
 }
+
+// recordSpawnDuration records the time spent to spawn a worker, with the status of the spawn.
+func recordSpawnDuration(ctx context.Context, d time.Duration, errSpawn error) {
+	status := sdk.StatusSuccess
+	if errSpawn != nil {
+		status = sdk.StatusFail
+	}
+	ctx = observability.ContextWithTag(ctx, "status", status)
+	observability.RecordFloat64(ctx, GetMetrics().SpawnDuration, d.Seconds())
+}
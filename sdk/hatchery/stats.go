@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 
 	"github.com/ovh/cds/engine/api/observability"
@@ -33,6 +34,10 @@ func initMetrics() error {
 		metrics.CheckingWorkers = stats.Int64("cds/checking_workers", "number of checking workers", stats.UnitDimensionless)
 		metrics.BuildingWorkers = stats.Int64("cds/building_workers", "number of building workers", stats.UnitDimensionless)
 		metrics.DisabledWorkers = stats.Int64("cds/disabled_workers", "number of disabled workers", stats.UnitDimensionless)
+		metrics.WarmWorkers = stats.Int64("cds/warm_workers", "number of warm workers waiting for a job", stats.UnitDimensionless)
+		metrics.BufferedLogs = stats.Int64("cds/buffered_logs", "number of service logs buffered while the api is unreachable", stats.UnitDimensionless)
+		metrics.DroppedLogs = stats.Int64("cds/dropped_logs", "number of service logs dropped because the buffer is full", stats.UnitDimensionless)
+		metrics.SpawnDuration = stats.Float64("cds/spawn_duration", "time spent to spawn a worker for a job in seconds", "s")
 
 		tags := []tag.Key{observability.MustNewKey(observability.TagServiceType), observability.MustNewKey(observability.TagServiceName)}
 		err = observability.RegisterView(
@@ -45,6 +50,16 @@ func initMetrics() error {
 			observability.NewViewLast("cds/hatchery/checking_workers", metrics.CheckingWorkers, tags),
 			observability.NewViewLast("cds/hatchery/building_workers", metrics.BuildingWorkers, tags),
 			observability.NewViewLast("cds/hatchery/disabled_workers", metrics.DisabledWorkers, tags),
+			observability.NewViewLast("cds/hatchery/warm_workers", metrics.WarmWorkers, tags),
+			observability.NewViewLast("cds/hatchery/buffered_logs", metrics.BufferedLogs, tags),
+			observability.NewViewCount("cds/hatchery/dropped_logs_count", metrics.DroppedLogs, tags),
+			&view.View{
+				Name:        "cds/hatchery/spawn_duration",
+				Description: metrics.SpawnDuration.Description(),
+				Measure:     metrics.SpawnDuration,
+				TagKeys:     append(tags, observability.MustNewKey("status")),
+				Aggregation: observability.DefaultDurationDistribution,
+			},
 		)
 	})
 	return err
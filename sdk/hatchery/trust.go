@@ -0,0 +1,99 @@
+package hatchery
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cosign"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	// trustPolicyRefreshDelay is the delay before the trust policy is loaded again from the API.
+	trustPolicyRefreshDelay = time.Minute
+	// trustVerificationDelay is the delay while an image signature verification is kept.
+	trustVerificationDelay = 10 * time.Minute
+)
+
+// trustPolicyCache keeps the worker model trust policy and the images already verified.
+var trustPolicyCache = struct {
+	sync.Mutex
+	policy   *sdk.WorkerModelTrustPolicy
+	verifier sdk.ImageVerifier
+	loaded   time.Time
+	verified map[string]time.Time
+}{verified: make(map[string]time.Time)}
+
+// loadTrustPolicy returns the trust policy from cache or from the API.
+func loadTrustPolicy(ctx context.Context, h Interface) (sdk.WorkerModelTrustPolicy, sdk.ImageVerifier, error) {
+	trustPolicyCache.Lock()
+	defer trustPolicyCache.Unlock()
+
+	if trustPolicyCache.policy != nil && time.Since(trustPolicyCache.loaded) < trustPolicyRefreshDelay {
+		return *trustPolicyCache.policy, trustPolicyCache.verifier, nil
+	}
+
+	p, err := h.CDSClient().WorkerModelTrustPolicy()
+	if err != nil {
+		// API without trust policy
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			p = sdk.WorkerModelTrustPolicy{}
+		} else if trustPolicyCache.policy != nil {
+			log.Warning(ctx, "hatchery> cannot refresh worker model trust policy: %v", err)
+			return *trustPolicyCache.policy, trustPolicyCache.verifier, nil
+		} else {
+			return p, nil, sdk.WrapError(err, "cannot load worker model trust policy")
+		}
+	}
+
+	var verifier sdk.ImageVerifier
+	if p.Enabled && p.RequireSignature {
+		v, err := cosign.NewVerifier(p.PublicKeys)
+		if err != nil {
+			return p, nil, sdk.NewErrorFrom(sdk.ErrWorkerModelUntrusted, "invalid trust policy: %v", err)
+		}
+		verifier = v
+	}
+
+	if trustPolicyCache.policy == nil || !trustPolicyCache.policy.Modified.Equal(p.Modified) {
+		trustPolicyCache.verified = make(map[string]time.Time)
+	}
+	trustPolicyCache.policy = &p
+	trustPolicyCache.verifier = verifier
+	trustPolicyCache.loaded = time.Now()
+	return p, verifier, nil
+}
+
+// checkWorkerModelTrust checks again the image of a container worker model before spawning a worker, because the
+// trust policy or the image could have changed since the registration of the model.
+func checkWorkerModelTrust(ctx context.Context, h Interface, m *sdk.Model) error {
+	if m == nil || m.Type != sdk.Docker {
+		return nil
+	}
+
+	p, verifier, err := loadTrustPolicy(ctx, h)
+	if err != nil {
+		return err
+	}
+	if !p.Enabled {
+		return nil
+	}
+
+	trustPolicyCache.Lock()
+	verified, ok := trustPolicyCache.verified[m.ModelDocker.Image]
+	trustPolicyCache.Unlock()
+	if ok && time.Since(verified) < trustVerificationDelay {
+		return nil
+	}
+
+	if err := p.CheckModel(ctx, *m, verifier); err != nil {
+		return err
+	}
+
+	trustPolicyCache.Lock()
+	trustPolicyCache.verified[m.ModelDocker.Image] = time.Now()
+	trustPolicyCache.Unlock()
+	return nil
+}
@@ -67,6 +67,7 @@ type SpawnArguments struct {
 	JobID        int64             `json:"job_id"`
 	Requirements []sdk.Requirement `json:"requirements"`
 	RegisterOnly bool              `json:"register_only"`
+	WarmPool     bool              `json:"warm_pool"`
 	HatcheryName string            `json:"hatchery_name"`
 }
 
@@ -121,4 +122,8 @@ type Metrics struct {
 	WaitingWorkers     *stats.Int64Measure
 	BuildingWorkers    *stats.Int64Measure
 	DisabledWorkers    *stats.Int64Measure
+	WarmWorkers        *stats.Int64Measure
+	BufferedLogs       *stats.Int64Measure
+	DroppedLogs        *stats.Int64Measure
+	SpawnDuration      *stats.Float64Measure
 }
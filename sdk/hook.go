@@ -1,41 +1,66 @@
 package sdk
 
+import (
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
 // These are constants about hooks
 const (
-	WebHookModelName              = "WebHook"
-	RepositoryWebHookModelName    = "RepositoryWebHook"
-	GerritHookModelName           = "GerritHook"
-	SchedulerModelName            = "Scheduler"
-	GitPollerModelName            = "Git Repository Poller"
-	KafkaHookModelName            = "Kafka hook"
-	RabbitMQHookModelName         = "RabbitMQ hook"
-	WorkflowModelName             = "Workflow"
-	HookConfigProject             = "project"
-	HookConfigWorkflow            = "workflow"
-	HookConfigTargetProject       = "target_project"
-	HookConfigTargetWorkflow      = "target_workflow"
-	HookConfigTargetHook          = "target_hook"
-	HookConfigWorkflowID          = "workflow_id"
-	HookConfigWebHookID           = "webHookID"
-	HookConfigVCSServer           = "vcsServer"
-	HookConfigEventFilter         = "eventFilter"
-	HookConfigRepoFullName        = "repoFullName"
-	HookConfigModelType           = "model_type"
-	HookConfigModelName           = "model_name"
-	HookConfigIcon                = "hookIcon"
-	WebHookModelConfigMethod      = "method"
-	RepositoryWebHookModelMethod  = "method"
-	SchedulerModelCron            = "cron"
-	SchedulerModelTimezone        = "timezone"
-	Payload                       = "payload"
-	HookModelIntegration          = "integration"
-	KafkaHookModelConsumerGroup   = "consumer group"
-	KafkaHookModelTopic           = "topic"
-	RabbitMQHookModelQueue        = "queue"
-	RabbitMQHookModelBindingKey   = "binding_key"
-	RabbitMQHookModelExchangeType = "exchange_type"
-	RabbitMQHookModelExchangeName = "exchange_name"
-	RabbitMQHookModelConsumerTag  = "consumer_tag"
+	WebHookModelName                       = "WebHook"
+	RepositoryWebHookModelName             = "RepositoryWebHook"
+	PullRequestHookModelName               = "PullRequestHook"
+	GerritHookModelName                    = "GerritHook"
+	SchedulerModelName                     = "Scheduler"
+	GitPollerModelName                     = "Git Repository Poller"
+	KafkaHookModelName                     = "Kafka hook"
+	RabbitMQHookModelName                  = "RabbitMQ hook"
+	WorkflowModelName                      = "Workflow"
+	HookConfigProject                      = "project"
+	HookConfigWorkflow                     = "workflow"
+	HookConfigTargetProject                = "target_project"
+	HookConfigTargetWorkflow               = "target_workflow"
+	HookConfigTargetHook                   = "target_hook"
+	HookConfigWorkflowID                   = "workflow_id"
+	HookConfigWebHookID                    = "webHookID"
+	HookConfigVCSServer                    = "vcsServer"
+	HookConfigEventFilter                  = "eventFilter"
+	HookConfigRepoFullName                 = "repoFullName"
+	HookConfigModelType                    = "model_type"
+	HookConfigModelName                    = "model_name"
+	HookConfigIcon                         = "hookIcon"
+	WebHookModelConfigMethod               = "method"
+	RepositoryWebHookModelMethod           = "method"
+	RepositoryWebHookModelBranches         = "branches"
+	RepositoryWebHookModelExcludedBranches = "excluded_branches"
+	RepositoryWebHookModelPaths            = "paths"
+	RepositoryWebHookModelExcludedPaths    = "excluded_paths"
+	RepositoryWebHookModelTags             = "tags"
+	RepositoryWebHookModelSemver           = "semver"
+	RepositoryWebHookModelSemverPrerelease = "semver_prereleases"
+	PullRequestHookModelActions            = "actions"
+	PullRequestHookModelLabels             = "labels"
+	GitPollerModelInterval                 = "interval"
+	GitPollerModelBranches                 = "branches"
+	GitPollerModelTags                     = "tags"
+	GitPollerModelSemver                   = "semver"
+	GitPollerModelSemverPrerelease         = "semver_prereleases"
+	SchedulerModelCron                     = "cron"
+	SchedulerModelTimezone                 = "timezone"
+	SchedulerModelCatchUpPolicy            = "catchup_policy"
+	Payload                                = "payload"
+	HookModelIntegration                   = "integration"
+	KafkaHookModelConsumerGroup            = "consumer group"
+	KafkaHookModelTopic                    = "topic"
+	RabbitMQHookModelQueue                 = "queue"
+	RabbitMQHookModelBindingKey            = "binding_key"
+	RabbitMQHookModelExchangeType          = "exchange_type"
+	RabbitMQHookModelExchangeName          = "exchange_name"
+	RabbitMQHookModelConsumerTag           = "consumer_tag"
 )
 
 // Here are the default hooks
@@ -43,6 +68,7 @@ var (
 	BuiltinHookModels = []*WorkflowHookModel{
 		&WebHookModel,
 		&RepositoryWebHookModel,
+		&PullRequestHookModel,
 		&GitPollerModel,
 		&SchedulerModel,
 		&KafkaHookModel,
@@ -143,6 +169,66 @@ var (
 				Configurable: false,
 				Type:         HookConfigTypeString,
 			},
+			RepositoryWebHookModelBranches: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			RepositoryWebHookModelExcludedBranches: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			RepositoryWebHookModelPaths: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			RepositoryWebHookModelExcludedPaths: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			RepositoryWebHookModelTags: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			RepositoryWebHookModelSemver: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			RepositoryWebHookModelSemverPrerelease: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+		},
+	}
+
+	PullRequestHookModel = WorkflowHookModel{
+		Author:     "CDS",
+		Type:       WorkflowHookModelBuiltin,
+		Identifier: "github.com/ovh/cds/hook/builtin/pullrequesthook",
+		Name:       PullRequestHookModelName,
+		Icon:       "code branch",
+		DefaultConfig: WorkflowNodeHookConfig{
+			RepositoryWebHookModelMethod: {
+				Value:        "POST",
+				Configurable: false,
+				Type:         HookConfigTypeString,
+			},
+			PullRequestHookModelActions: {
+				Value:        strings.Join([]string{PullRequestActionOpened, PullRequestActionSynchronized, PullRequestActionReopened}, ","),
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			PullRequestHookModelLabels: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -158,6 +244,31 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeString,
 			},
+			GitPollerModelInterval: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			GitPollerModelBranches: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			GitPollerModelTags: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			GitPollerModelSemver: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			GitPollerModelSemverPrerelease: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -178,6 +289,12 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeString,
 			},
+			SchedulerModelCatchUpPolicy: {
+				Value:              SchedulerCatchUpRunOnce,
+				Configurable:       true,
+				Type:               HookConfigTypeMultiChoice,
+				MultipleChoiceList: SchedulerCatchUpPolicies,
+			},
 			Payload: {
 				Value:        "{}",
 				Configurable: true,
@@ -268,6 +385,8 @@ func GetDefaultHookModel(modelName string) WorkflowHookModel {
 		return SchedulerModel
 	case RepositoryWebHookModelName:
 		return RepositoryWebHookModel
+	case PullRequestHookModelName:
+		return PullRequestHookModel
 	case WebHookModelName:
 		return WebHookModel
 	case GitPollerModelName:
@@ -278,3 +397,217 @@ func GetDefaultHookModel(modelName string) WorkflowHookModel {
 
 	return WebHookModel
 }
+
+// Pull request actions handled by the pull request hooks
+const (
+	PullRequestActionOpened       = "opened"
+	PullRequestActionSynchronized = "synchronized"
+	PullRequestActionLabeled      = "labeled"
+	PullRequestActionMerged       = "merged"
+	PullRequestActionClosed       = "closed"
+	PullRequestActionReopened     = "reopened"
+)
+
+// PullRequestActions is the list of the actions that can trigger a pull request hook
+var PullRequestActions = []string{
+	PullRequestActionOpened,
+	PullRequestActionSynchronized,
+	PullRequestActionLabeled,
+	PullRequestActionMerged,
+	PullRequestActionClosed,
+	PullRequestActionReopened,
+}
+
+// pullRequestVCSEvents are the events sent by the repositories managers for pull requests (GitHub and Gitea, GitLab)
+var pullRequestVCSEvents = []string{"pull_request", "Merge Request Hook"}
+
+// FilterPullRequestVCSEvents returns the pull request events in the given events supported by a repositories manager.
+func FilterPullRequestVCSEvents(events []string) []string {
+	var res []string
+	for _, e := range events {
+		if IsInArray(e, pullRequestVCSEvents) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// IsRepositoryWebHookModel returns true for the hook models that need a webhook on the repository.
+func IsRepositoryWebHookModel(name string) bool {
+	return name == RepositoryWebHookModelName || name == PullRequestHookModelName
+}
+
+// CheckPullRequestHookConfig checks the actions of a pull request hook.
+func CheckPullRequestHookConfig(c WorkflowNodeHookConfig) error {
+	actions := HookGlobPatterns(c[PullRequestHookModelActions].Value)
+	if len(actions) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "at least one action is required for a pull request hook")
+	}
+	for _, a := range actions {
+		if !IsInArray(a, PullRequestActions) {
+			return NewErrorFrom(ErrWrongRequest, "invalid pull request action %q, it should be one of %s", a, strings.Join(PullRequestActions, ", "))
+		}
+	}
+	return nil
+}
+
+// GitPollerMinInterval is the minimal interval between two polls of a repository
+const GitPollerMinInterval = time.Minute
+
+// GitPollerInterval returns the interval configured on a git poller hook, zero if not set.
+func GitPollerInterval(c WorkflowNodeHookConfig) (time.Duration, error) {
+	v := strings.TrimSpace(c[GitPollerModelInterval].Value)
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, NewErrorFrom(ErrWrongRequest, "invalid git poller interval %q, it should be a duration like 5m", v)
+	}
+	if d < GitPollerMinInterval {
+		return 0, NewErrorFrom(ErrWrongRequest, "invalid git poller interval %q, minimum is %s", v, GitPollerMinInterval)
+	}
+	return d, nil
+}
+
+// HookGlobPatterns returns the list of the comma separated glob patterns of a hook config value.
+func HookGlobPatterns(v string) []string {
+	var patterns []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// CheckGitPollerConfig checks the interval and the branch, tag and semver filters of a git poller hook.
+func CheckGitPollerConfig(c WorkflowNodeHookConfig) error {
+	if _, err := GitPollerInterval(c); err != nil {
+		return err
+	}
+	for _, k := range []string{GitPollerModelBranches, GitPollerModelTags} {
+		for _, p := range HookGlobPatterns(c[k].Value) {
+			if _, err := path.Match(p, ""); err != nil {
+				return NewErrorFrom(ErrWrongRequest, "invalid pattern %q for git poller %s", p, k)
+			}
+		}
+	}
+	return checkHookSemverConfig(c[GitPollerModelSemver].Value, c[GitPollerModelSemverPrerelease].Value)
+}
+
+// CheckRepositoryWebHookConfig checks the branch, tag and semver filters of a repository webhook.
+func CheckRepositoryWebHookConfig(c WorkflowNodeHookConfig) error {
+	for _, k := range []string{RepositoryWebHookModelBranches, RepositoryWebHookModelExcludedBranches, RepositoryWebHookModelTags} {
+		for _, p := range HookGlobPatterns(c[k].Value) {
+			if _, err := path.Match(p, ""); err != nil {
+				return NewErrorFrom(ErrWrongRequest, "invalid pattern %q for repository webhook %s", p, k)
+			}
+		}
+	}
+	return checkHookSemverConfig(c[RepositoryWebHookModelSemver].Value, c[RepositoryWebHookModelSemverPrerelease].Value)
+}
+
+func checkHookSemverConfig(r, prereleases string) error {
+	if prereleases != "" && prereleases != "true" && prereleases != "false" {
+		return NewErrorFrom(ErrWrongRequest, "invalid value %q for %s, it should be true or false", prereleases, RepositoryWebHookModelSemverPrerelease)
+	}
+	if r == "" {
+		return nil
+	}
+	if _, err := semver.ParseRange(r); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid semver range %q: %v", r, err)
+	}
+	return nil
+}
+
+// ParseHookTagSemver parses a git tag as a semantic version, a "v" prefix is allowed.
+func ParseHookTagSemver(tag string) (*semver.Version, error) {
+	v, err := semver.ParseTolerant(tag)
+	if err != nil {
+		return nil, WithStack(err)
+	}
+	return &v, nil
+}
+
+// MatchHookSemverRange returns true if given tag is a semantic version in the range, or if there is no range.
+// Prerelease versions are excluded unless includePrereleases is true.
+func MatchHookSemverRange(r string, includePrereleases bool, tag string) bool {
+	if r == "" {
+		return true
+	}
+	rg, err := semver.ParseRange(r)
+	if err != nil {
+		return false
+	}
+	v, err := ParseHookTagSemver(tag)
+	if err != nil {
+		return false
+	}
+	if len(v.Pre) > 0 && !includePrereleases {
+		return false
+	}
+	return rg(*v)
+}
+
+// MatchHookGlobPatterns returns true if given value matches one of the comma separated glob patterns, or if there is no pattern.
+func MatchHookGlobPatterns(patterns, value string) bool {
+	ps := HookGlobPatterns(patterns)
+	if len(ps) == 0 {
+		return true
+	}
+	for _, p := range ps {
+		if ok, _ := path.Match(p, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPathGlobPatterns returns true if given file path matches one of the comma separated glob patterns.
+// Unlike branch patterns, '**' matches any number of directories (ex: services/api/**).
+func MatchPathGlobPatterns(patterns, file string) bool {
+	for _, p := range HookGlobPatterns(patterns) {
+		if pathGlobRegexp(p).MatchString(file) {
+			return true
+		}
+	}
+	return false
+}
+
+func pathGlobRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '*' && strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// Catch-up policies of the scheduler hooks, applied to the schedules missed while the hooks service was down
+const (
+	SchedulerCatchUpSkip         = "skip"
+	SchedulerCatchUpRunOnce      = "run-once"
+	SchedulerCatchUpRunAllMissed = "run-all-missed"
+)
+
+// SchedulerCatchUpPolicies is the list of the catch-up policies of the scheduler hooks
+var SchedulerCatchUpPolicies = []string{
+	SchedulerCatchUpSkip,
+	SchedulerCatchUpRunOnce,
+	SchedulerCatchUpRunAllMissed,
+}
@@ -1,5 +1,7 @@
 package sdk
 
+import "time"
+
 // Task is a generic hook tasks such as webhook, scheduler,... which will be started and wait for execution
 type Task struct {
 	UUID              string                 `json:"uuid" cli:"UUID,key"`
@@ -28,6 +30,14 @@ type TaskExecution struct {
 	ScheduledTask       *ScheduledTaskExecution `json:"scheduled_task,omitempty" cli:"-"`
 	GerritEvent         *GerritEventExecution   `json:"gerrit,omitempty" cli:"-"`
 	Status              string                  `json:"status" cli:"status"`
+	Events              []TaskExecutionEvent    `json:"events,omitempty" cli:"-"`
+}
+
+// TaskExecutionEvent contains the payload computed from a task execution and what the API did with it
+type TaskExecutionEvent struct {
+	Payload     map[string]string `json:"payload"`
+	WorkflowRun int64             `json:"workflow_run,omitempty"`
+	Error       string            `json:"error,omitempty"`
 }
 
 // GerritEventExecution contains specific data for a gerrit event execution
@@ -56,4 +66,14 @@ type RabbitMQTaskExecution struct {
 // ScheduledTaskExecution contains specific data for a scheduled task execution
 type ScheduledTaskExecution struct {
 	DateScheduledExecution string `json:"date_scheduled_execution"`
+	CatchUp                bool   `json:"catch_up,omitempty"`
+}
+
+// MissedSchedule is a schedule of a scheduler hook that was not executed on time because the hooks service was down
+type MissedSchedule struct {
+	UUID       string    `json:"uuid" cli:"uuid"`
+	Date       time.Time `json:"date" cli:"date,key"`
+	DetectedAt time.Time `json:"detected_at" cli:"detected_at"`
+	Policy     string    `json:"policy" cli:"policy"`
+	Executed   bool      `json:"executed" cli:"executed"`
 }
@@ -2,20 +2,32 @@ package sdk
 
 // This is the buitin integration model
 const (
-	KafkaIntegrationModel         = "Kafka"
-	RabbitMQIntegrationModel      = "RabbitMQ"
-	OpenstackIntegrationModel     = "Openstack"
-	AWSIntegrationModel           = "AWS"
-	DefaultStorageIntegrationName = "shared.infra"
+	KafkaIntegrationModel          = "Kafka"
+	NATSIntegrationModel           = "NATS"
+	RabbitMQIntegrationModel       = "RabbitMQ"
+	OpenstackIntegrationModel      = "Openstack"
+	AWSIntegrationModel            = "AWS"
+	DockerRegistryIntegrationModel = "Docker Registry"
+	KubernetesIntegrationModel     = "Kubernetes"
+	HelmRegistryIntegrationModel   = "Helm Registry"
+	ServiceNowIntegrationModel     = "ServiceNow"
+	JiraIntegrationModel           = "Jira"
+	DefaultStorageIntegrationName  = "shared.infra"
 )
 
 // Here are the default plateform models
 var (
 	BuiltinIntegrationModels = []*IntegrationModel{
 		&KafkaIntegration,
+		&NATSIntegration,
 		&RabbitMQIntegration,
 		&OpenstackIntegration,
 		&AWSIntegration,
+		&DockerRegistryIntegration,
+		&KubernetesIntegration,
+		&HelmRegistryIntegration,
+		&ServiceNowIntegration,
+		&JiraIntegration,
 	}
 	// KafkaIntegration represents a kafka integration
 	KafkaIntegration = IntegrationModel{
@@ -42,6 +54,35 @@ var (
 		Hook:     true,
 		Event:    true,
 	}
+	// NATSIntegration represents a NATS integration, events can be published with NATS JetStream
+	NATSIntegration = IntegrationModel{
+		Name:       NATSIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/nats",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Comma separated list of NATS server urls",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			"subject": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"jetstream": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeBoolean,
+				Description: "Wait for JetStream acknowledgement of published events",
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+		Event:    true,
+	}
 	// RabbitMQIntegration represents a kafka integration
 	RabbitMQIntegration = IntegrationModel{
 		Name:       RabbitMQIntegrationModel,
@@ -134,8 +175,185 @@ var (
 		Disabled: false,
 		Hook:     false,
 	}
+	// DockerRegistryIntegration represents the credentials of a docker registry, used by the DockerBuild action
+	DockerRegistryIntegration = IntegrationModel{
+		Name:       DockerRegistryIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/docker-registry",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Registry host, for example registry.example.com",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+	}
+	// KubernetesIntegration represents the credentials of a kubernetes cluster, used by the DeployKubernetes action
+	// and by the DeployApplication action with the kubernetes deployment plugin
+	KubernetesIntegration = IntegrationModel{
+		Name:       KubernetesIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/kubernetes",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"api_url": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"ca_certificate": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeText,
+				Description: "Certificate Authority bundle (PEM format)",
+			},
+			"token": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			"kubeconfig": IntegrationConfigValue{
+				Type:        IntegrationConfigTypePassword,
+				Description: "Content of a kubeconfig file, used instead of the api url, certificate and token if set",
+			},
+		},
+		Deployment: true,
+		DeploymentDefaultConfig: IntegrationConfig{
+			"release_name": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Fill it if you want to use another name than your application name for the Helm release. Leave empty if not",
+			},
+			"timeout": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       "180",
+				Description: "timeout in seconds",
+			},
+			"namespace": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Value:       "default",
+				Description: "Kubernetes namespace in which you want to deploy your components (OPTIONAL)",
+			},
+			"deployment_files": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Glob to yaml filepaths",
+			},
+			"helm_chart": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Keep empty if you don't use helm. Indicate a chart reference by chart reference himself (stable/mariadb), absolute URL (https://example.com/charts/nginx-1.2.3.tgz), path to a packaged chart (./nginx-1.2.3.tgz), path to an unpacked chart directory (./nginx) or even by your chart reference and repo URL (https://example.com/charts/ nginx).",
+			},
+			"helm_values": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "specify helm values in a YAML file or a URL to configure/override your helm chart",
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+	}
+	// HelmRegistryIntegration represents the credentials of a helm chart repository, used by the HelmPush action
+	HelmRegistryIntegration = IntegrationModel{
+		Name:       HelmRegistryIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/helm-registry",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "oci://registry.example.com/charts for an OCI registry, https://charts.example.com for a ChartMuseum repository",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+	}
+	// ServiceNowIntegration represents the credentials of a ServiceNow instance, used by the ServiceNowChangeRequest action
+	ServiceNowIntegration = IntegrationModel{
+		Name:       ServiceNowIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/servicenow",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "URL of the ServiceNow instance (ex: https://mycompany.service-now.com)",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			"assignment_group": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Group assigned to the change requests created by CDS (OPTIONAL)",
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+	}
+	// JiraIntegration represents the credentials of a Jira instance, used by the JiraRelease action
+	JiraIntegration = IntegrationModel{
+		Name:       JiraIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/jira",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "URL of the Jira instance (ex: https://mycompany.atlassian.net)",
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"token": IntegrationConfigValue{
+				Type:        IntegrationConfigTypePassword,
+				Description: "API token or password of the user",
+			},
+			"projects": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Comma separated keys of the Jira projects, the issues of the other projects are ignored (OPTIONAL)",
+			},
+			"transition": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Name of the transition applied to the deployed issues (OPTIONAL)",
+			},
+		},
+		Disabled: false,
+		Hook:     false,
+	}
 )
 
+// DockerRegistryVariablePrefix returns the prefix of the job secrets containing the configuration of a docker registry integration
+func DockerRegistryVariablePrefix(integrationName string) string {
+	return "cds.registry." + integrationName + "."
+}
+
+// KubernetesVariablePrefix returns the prefix of the job secrets containing the configuration of a kubernetes integration
+func KubernetesVariablePrefix(integrationName string) string {
+	return "cds.kubernetes." + integrationName + "."
+}
+
+// HelmRegistryVariablePrefix returns the prefix of the job secrets containing the configuration of a helm registry integration
+func HelmRegistryVariablePrefix(integrationName string) string {
+	return "cds.helm." + integrationName + "."
+}
+
+// ServiceNowVariablePrefix returns the prefix of the job secrets containing the configuration of a ServiceNow integration
+func ServiceNowVariablePrefix(integrationName string) string {
+	return "cds.servicenow." + integrationName + "."
+}
+
+// JiraVariablePrefix returns the prefix of the job secrets containing the configuration of a Jira integration
+func JiraVariablePrefix(integrationName string) string {
+	return "cds.jira." + integrationName + "."
+}
+
 // IntegrationType represents all different type of integrations
 type IntegrationType string
 
@@ -0,0 +1,140 @@
+package logbuffer
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// Buffer is a bounded FIFO of log entries stored on disk, it keeps the logs that can't be sent to the API
+// and retries to send them with an exponential backoff. When the buffer is full the new entries are dropped.
+type Buffer struct {
+	mu        sync.Mutex
+	fs        afero.Fs
+	path      string
+	maxSize   int64
+	size      int64
+	len       int
+	dropped   int64
+	backoff   time.Duration
+	nextRetry time.Time
+}
+
+// New returns a buffer stored in given file, the file is removed if it already exists
+func New(fs afero.Fs, path string, maxSize int64) (*Buffer, error) {
+	if err := fs.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, sdk.WrapError(err, "cannot remove log buffer file %s", path)
+	}
+	return &Buffer{fs: fs, path: path, maxSize: maxSize}, nil
+}
+
+// Len returns the number of entries in the buffer
+func (b *Buffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.len
+}
+
+// Dropped returns the number of entries dropped because the buffer was full
+func (b *Buffer) Dropped() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Push appends an entry to the buffer, it returns false if the entry was dropped because the buffer is full.
+// An entry must not contain a new line.
+func (b *Buffer) Push(entry []byte) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.size+int64(len(entry))+1 > b.maxSize {
+		b.dropped++
+		return false, nil
+	}
+
+	f, err := b.fs.OpenFile(b.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return false, sdk.WrapError(err, "cannot open log buffer file %s", b.path)
+	}
+	defer f.Close() // nolint
+	if _, err := f.Write(append(entry, '\n')); err != nil {
+		return false, sdk.WrapError(err, "cannot write log buffer file %s", b.path)
+	}
+
+	if b.len == 0 {
+		b.backoff = minBackoff
+		b.nextRetry = time.Now().Add(b.backoff)
+	}
+	b.size += int64(len(entry)) + 1
+	b.len++
+	return true, nil
+}
+
+// Ready returns true if the buffer contains entries and the backoff delay since the last failure is over
+func (b *Buffer) Ready(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.len > 0 && !now.Before(b.nextRetry)
+}
+
+// Flush sends the entries of the buffer in order. It stops at the first error, keeps the entries
+// not sent and doubles the backoff delay.
+func (b *Buffer) Flush(send func(entry []byte) error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.len == 0 {
+		return nil
+	}
+
+	content, err := afero.ReadFile(b.fs, b.path)
+	if err != nil {
+		return sdk.WrapError(err, "cannot read log buffer file %s", b.path)
+	}
+
+	var sendErr error
+	var remaining []byte
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), int(b.maxSize))
+	for scanner.Scan() {
+		entry := scanner.Bytes()
+		if sendErr == nil {
+			if sendErr = send(entry); sendErr == nil {
+				continue
+			}
+		}
+		remaining = append(remaining, entry...)
+		remaining = append(remaining, '\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return sdk.WrapError(err, "cannot read log buffer file %s", b.path)
+	}
+
+	if err := afero.WriteFile(b.fs, b.path, remaining, 0600); err != nil {
+		return sdk.WrapError(err, "cannot write log buffer file %s", b.path)
+	}
+	b.size = int64(len(remaining))
+	b.len = bytes.Count(remaining, []byte{'\n'})
+
+	if sendErr != nil {
+		b.backoff *= 2
+		if b.backoff > maxBackoff {
+			b.backoff = maxBackoff
+		}
+		b.nextRetry = time.Now().Add(b.backoff)
+		return sendErr
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package logbuffer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	b, err := New(fs, "logs.buffer", 14)
+	require.NoError(t, err)
+	require.False(t, b.Ready(time.Now()))
+
+	for _, e := range []string{"one", "two", "three", "four"} {
+		_, err := b.Push([]byte(e))
+		require.NoError(t, err)
+	}
+	// "four" does not fit in the buffer
+	require.Equal(t, 3, b.Len())
+	require.Equal(t, int64(1), b.Dropped())
+	require.False(t, b.Ready(time.Now()))
+	require.True(t, b.Ready(time.Now().Add(minBackoff)))
+
+	// the flush stops at the first error
+	var sent []string
+	err = b.Flush(func(entry []byte) error {
+		if string(entry) == "two" {
+			return fmt.Errorf("unreachable")
+		}
+		sent = append(sent, string(entry))
+		return nil
+	})
+	require.Error(t, err)
+	require.Equal(t, []string{"one"}, sent)
+	require.Equal(t, 2, b.Len())
+	require.False(t, b.Ready(time.Now().Add(minBackoff)))
+	require.True(t, b.Ready(time.Now().Add(2*minBackoff)))
+
+	_, err = b.Push([]byte("six"))
+	require.NoError(t, err)
+	require.NoError(t, b.Flush(func(entry []byte) error {
+		sent = append(sent, string(entry))
+		return nil
+	}))
+	require.Equal(t, []string{"one", "two", "three", "six"}, sent)
+	require.Equal(t, 0, b.Len())
+	require.False(t, b.Ready(time.Now().Add(time.Hour)))
+}
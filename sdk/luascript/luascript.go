@@ -97,3 +97,11 @@ func (c *Check) Perform(script string) error {
 	c.Result = ok
 	return nil
 }
+
+// Compile checks the syntax of a lua script without running it
+func Compile(script string) error {
+	state := lua.NewState(lua.Options{SkipOpenLibs: true})
+	defer state.Close()
+	_, err := state.LoadString(script)
+	return err
+}
@@ -73,12 +73,22 @@ var (
 	MsgSpawnInfoWorkerForJob               = &Message{"MsgSpawnInfoWorkerForJob", trad{FR: "Ce worker %s a été créé pour lancer ce job", EN: "This worker %s was created to take this action"}, nil}
 	MsgSpawnInfoWorkerForJobError          = &Message{"MsgSpawnInfoWorkerForJobError", trad{FR: "⚠ Ce worker %s a été créé pour lancer ce job, mais ne possède pas tous les pré-requis. Vérifiez que les prérequis suivants:%s", EN: "⚠ This worker %s was created to take this action, but does not have all prerequisites. Please verify the following prerequisites:%s"}, nil}
 	MsgSpawnInfoJobError                   = &Message{"MsgSpawnInfoJobError", trad{FR: "⚠ Impossible de lancer ce job : %s", EN: "⚠ Unable to run this job: %s"}, nil}
+	MsgSpawnInfoWorkerOOMKilled            = &Message{"MsgSpawnInfoWorkerOOMKilled", trad{FR: "⚠ Le worker %s a été tué : mémoire insuffisante (limite %s, pic %s). Augmentez le prérequis mémoire du job.", EN: "⚠ Worker %s killed: out of memory (limit %s, peak %s). Please increase the memory requirement of the job."}, nil}
+	MsgSpawnInfoWorkerEvicted              = &Message{"MsgSpawnInfoWorkerEvicted", trad{FR: "⚠ Le worker %s a été évincé de son hôte : %s. Si l'hôte manquait de mémoire, augmentez le prérequis mémoire du job.", EN: "⚠ Worker %s was evicted from its host: %s. If the host was running out of memory, please increase the memory requirement of the job."}, nil}
 	MsgWorkflowStarting                    = &Message{"MsgWorkflowStarting", trad{FR: "Le workflow %s#%s a été démarré", EN: "Workflow %s#%s has been started"}, nil}
 	MsgWorkflowError                       = &Message{"MsgWorkflowError", trad{FR: "⚠ Une erreur est survenue: %v", EN: "⚠ An error has occurred: %v"}, nil}
 	MsgWorkflowConditionError              = &Message{"MsgWorkflowConditionError", trad{FR: "Les conditions de lancement ne sont pas respectées.", EN: "Run conditions aren't ok."}, nil}
 	MsgWorkflowNodeStop                    = &Message{"MsgWorkflowNodeStop", trad{FR: "Le pipeline a été arrété par %s", EN: "The pipeline has been stopped by %s"}, nil}
 	MsgWorkflowNodeMutex                   = &Message{"MsgWorkflowNodeMutex", trad{FR: "Le pipeline %s est mis en attente tant qu'il est en cours sur un autre run", EN: "The pipeline %s is waiting while it's running on another run"}, nil}
 	MsgWorkflowNodeMutexRelease            = &Message{"MsgWorkflowNodeMutexRelease", trad{FR: "Lancement du pipeline %s", EN: "Triggering pipeline %s"}, nil}
+	MsgWorkflowNodeConcurrencyGroup        = &Message{"MsgWorkflowNodeConcurrencyGroup", trad{FR: "Le pipeline %s est mis en attente tant que le groupe de concurrence %s est occupé", EN: "The pipeline %s is waiting while the concurrency group %s is busy"}, nil}
+	MsgWorkflowRunCancelledByNewRun        = &Message{"MsgWorkflowRunCancelledByNewRun", trad{FR: "Le run a été annulé par le run %d sur la branche %s", EN: "The run has been cancelled by run %d on branch %s"}, nil}
+	MsgWorkflowNodeConcurrencySuperseded   = &Message{"MsgWorkflowNodeConcurrencySuperseded", trad{FR: "Le pipeline %s a été annulé car remplacé par un run plus récent dans le groupe de concurrence %s", EN: "The pipeline %s has been cancelled because it was superseded by a newer run in the concurrency group %s"}, nil}
+	MsgWorkflowNodeFreezeBlocked           = &Message{"MsgWorkflowNodeFreezeBlocked", trad{FR: "Le pipeline %s a été arrêté par la période de gel %s", EN: "The pipeline %s has been stopped by the freeze window %s"}, nil}
+	MsgWorkflowNodeFreezeQueued            = &Message{"MsgWorkflowNodeFreezeQueued", trad{FR: "Le pipeline %s est mis en attente jusqu'à la fin de la période de gel %s à %s", EN: "The pipeline %s is waiting until the end of the freeze window %s at %s"}, nil}
+	MsgWorkflowNodeFreezeOverridden        = &Message{"MsgWorkflowNodeFreezeOverridden", trad{FR: "La période de gel %s a été outrepassée pour le pipeline %s par %s: %s", EN: "The freeze window %s has been overridden for pipeline %s by %s: %s"}, nil}
+	MsgWorkflowNodeRollback                = &Message{"MsgWorkflowNodeRollback", trad{FR: "La vérification %s a échoué, lancement du pipeline de rollback %s vers la version %s", EN: "The verification %s failed, triggering rollback pipeline %s to version %s"}, nil}
+	MsgWorkflowNodeRollbackNoDeployment    = &Message{"MsgWorkflowNodeRollbackNoDeployment", trad{FR: "La vérification %s a échoué mais aucun déploiement précédent de %s n'a été trouvé pour le rollback", EN: "The verification %s failed but no previous deployment of %s was found to roll back to"}, nil}
 	MsgWorkflowImportedUpdated             = &Message{"MsgWorkflowImportedUpdated", trad{FR: "Le workflow %s a été mis à jour", EN: "Workflow %s has been updated"}, nil}
 	MsgWorkflowImportedInserted            = &Message{"MsgWorkflowImportedInserted", trad{FR: "Le workflow %s a été créé", EN: "Workflow %s has been created"}, nil}
 	MsgSpawnInfoHatcheryCannotStartJob     = &Message{"MsgSpawnInfoHatcheryCannotStart", trad{FR: "Aucune hatchery n'a pu démarrer de worker respectant vos pré-requis de job, merci de les vérifier.", EN: "No hatchery can spawn a worker corresponding your job's requirements. Please check your job's requirements."}, nil}
@@ -149,12 +159,22 @@ var Messages = map[string]*Message{
 	MsgSpawnInfoWorkerForJob.ID:               MsgSpawnInfoWorkerForJob,
 	MsgSpawnInfoWorkerForJobError.ID:          MsgSpawnInfoWorkerForJobError,
 	MsgSpawnInfoJobError.ID:                   MsgSpawnInfoJobError,
+	MsgSpawnInfoWorkerOOMKilled.ID:            MsgSpawnInfoWorkerOOMKilled,
+	MsgSpawnInfoWorkerEvicted.ID:              MsgSpawnInfoWorkerEvicted,
 	MsgWorkflowStarting.ID:                    MsgWorkflowStarting,
 	MsgWorkflowError.ID:                       MsgWorkflowError,
 	MsgWorkflowConditionError.ID:              MsgWorkflowConditionError,
 	MsgWorkflowNodeStop.ID:                    MsgWorkflowNodeStop,
 	MsgWorkflowNodeMutex.ID:                   MsgWorkflowNodeMutex,
 	MsgWorkflowNodeMutexRelease.ID:            MsgWorkflowNodeMutexRelease,
+	MsgWorkflowNodeConcurrencyGroup.ID:        MsgWorkflowNodeConcurrencyGroup,
+	MsgWorkflowNodeConcurrencySuperseded.ID:   MsgWorkflowNodeConcurrencySuperseded,
+	MsgWorkflowNodeFreezeBlocked.ID:           MsgWorkflowNodeFreezeBlocked,
+	MsgWorkflowNodeFreezeQueued.ID:            MsgWorkflowNodeFreezeQueued,
+	MsgWorkflowNodeFreezeOverridden.ID:        MsgWorkflowNodeFreezeOverridden,
+	MsgWorkflowNodeRollback.ID:                MsgWorkflowNodeRollback,
+	MsgWorkflowNodeRollbackNoDeployment.ID:    MsgWorkflowNodeRollbackNoDeployment,
+	MsgWorkflowRunCancelledByNewRun.ID:        MsgWorkflowRunCancelledByNewRun,
 	MsgWorkflowImportedUpdated.ID:             MsgWorkflowImportedUpdated,
 	MsgWorkflowImportedInserted.ID:            MsgWorkflowImportedInserted,
 	MsgSpawnInfoHatcheryCannotStartJob.ID:     MsgSpawnInfoHatcheryCannotStartJob,
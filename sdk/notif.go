@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"bytes"
+	"strconv"
 	"text/template"
 	"time"
 
@@ -11,9 +12,10 @@ import (
 
 //const
 const (
-	EmailUserNotification  = "email"
-	JabberUserNotification = "jabber"
-	VCSUserNotification    = "vcs"
+	EmailUserNotification   = "email"
+	JabberUserNotification  = "jabber"
+	VCSUserNotification     = "vcs"
+	WebhookUserNotification = "webhook"
 )
 
 //const
@@ -42,6 +44,36 @@ type UserNotificationSettings struct {
 	Recipients   []string                  `json:"recipients,omitempty" yaml:"recipients,omitempty"`
 	Template     *UserNotificationTemplate `json:"template,omitempty" yaml:"template,omitempty"`
 	Conditions   WorkflowNodeConditions    `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Webhook      *UserNotificationWebhook  `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+	// For VCS, labels voted on the change with the result of the workflow node
+	Labels []UserNotificationLabel `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// UserNotificationLabel is a label voted on the change that triggered a workflow, like Verified or Code-Review on Gerrit.
+type UserNotificationLabel struct {
+	Name      string `json:"name" yaml:"name"`
+	OnSuccess int    `json:"on_success" yaml:"on_success"`
+	OnFailure int    `json:"on_failure" yaml:"on_failure"`
+}
+
+// UserNotificationLabelsVotes returns the votes of given labels for the status of a node run, nil if the status is
+// not final.
+func UserNotificationLabelsVotes(labels []UserNotificationLabel, status string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	votes := make(map[string]string, len(labels))
+	for _, l := range labels {
+		switch status {
+		case StatusSuccess:
+			votes[l.Name] = strconv.Itoa(l.OnSuccess)
+		case StatusFail, StatusStopped:
+			votes[l.Name] = strconv.Itoa(l.OnFailure)
+		default:
+			return nil
+		}
+	}
+	return votes
 }
 
 // UserNotificationTemplate is the notification content
@@ -50,6 +82,8 @@ type UserNotificationTemplate struct {
 	Body    string `json:"body,omitempty" yaml:"body,omitempty"`
 	// For VCS
 	DisableComment *bool `json:"disable_comment,omitempty" yaml:"disable_comment,omitempty"`
+	// TestsSummary posts a summary of the unit tests and the coverage of the run on the pull request
+	TestsSummary *bool `json:"tests_summary,omitempty" yaml:"tests_summary,omitempty"`
 }
 
 //userNotificationInput is a way to parse notification
@@ -78,9 +112,24 @@ Branch : {{.git.branch | default "n/a"}}`,
 		Body:    `{{.cds.buildURL}}`,
 	}
 
+	UserNotificationTemplateWebhook = UserNotificationTemplate{
+		Body: `{
+  "project": {{ toJSON .Project }},
+  "workflow": {{ toJSON .Workflow }},
+  "number": {{ .Number }},
+  "sub_number": {{ .SubNumber }},
+  "node": {{ toJSON .Node }},
+  "status": {{ toJSON .Status }},
+  "url": {{ toJSON .URL }},
+  "triggered_by": {{ toJSON .TriggeredBy }},
+  "branch": {{ toJSON (index .Params "git.branch") }}
+}`,
+	}
+
 	UserNotificationTemplateMap = map[string]UserNotificationTemplate{
-		EmailUserNotification:  UserNotificationTemplateEmail,
-		JabberUserNotification: UserNotificationTemplateJabber,
+		EmailUserNotification:   UserNotificationTemplateEmail,
+		JabberUserNotification:  UserNotificationTemplateJabber,
+		WebhookUserNotification: UserNotificationTemplateWebhook,
 		VCSUserNotification: UserNotificationTemplate{
 			Body: DefaultWorkflowNodeRunReport,
 		},
@@ -0,0 +1,19 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserNotificationLabelsVotes(t *testing.T) {
+	labels := []UserNotificationLabel{
+		{Name: "Verified", OnSuccess: 1, OnFailure: -1},
+		{Name: "Code-Review", OnSuccess: 0, OnFailure: -2},
+	}
+	assert.Equal(t, map[string]string{"Verified": "1", "Code-Review": "0"}, UserNotificationLabelsVotes(labels, StatusSuccess))
+	assert.Equal(t, map[string]string{"Verified": "-1", "Code-Review": "-2"}, UserNotificationLabelsVotes(labels, StatusFail))
+	assert.Equal(t, map[string]string{"Verified": "-1", "Code-Review": "-2"}, UserNotificationLabelsVotes(labels, StatusStopped))
+	assert.Nil(t, UserNotificationLabelsVotes(labels, StatusBuilding))
+	assert.Nil(t, UserNotificationLabelsVotes(nil, StatusSuccess))
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/ovh/cds/sdk/interpolate"
+)
+
+// Webhook notification headers.
+const (
+	WebhookNotificationHeaderSignature = "X-CDS-Signature"
+	WebhookNotificationHeaderDelivery  = "X-CDS-Delivery"
+	WebhookNotificationHeaderEvent     = "X-CDS-Event"
+)
+
+// WebhookNotificationMaxAttempts is the max count of attempts to deliver a webhook notification.
+const WebhookNotificationMaxAttempts = 5
+
+// UserNotificationWebhook contains the target of a webhook notification.
+// The secret is only given in clear by the user, it is stored encrypted and used to sign the payload.
+type UserNotificationWebhook struct {
+	URL             string            `json:"url" yaml:"url"`
+	Headers         map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Secret          string            `json:"secret,omitempty" yaml:"secret,omitempty"`
+	EncryptedSecret string            `json:"encrypted_secret,omitempty" yaml:"encrypted_secret,omitempty"`
+}
+
+// IsValid returns an error if the webhook is not valid.
+func (w *UserNotificationWebhook) IsValid() error {
+	if w == nil || w.URL == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing webhook notification url")
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid webhook notification url %q", w.URL)
+	}
+	return nil
+}
+
+// WebhookNotificationData contains the fields available in the template of a webhook notification.
+type WebhookNotificationData struct {
+	Project     string            `json:"project"`
+	Workflow    string            `json:"workflow"`
+	Number      int64             `json:"number"`
+	SubNumber   int64             `json:"sub_number"`
+	Node        string            `json:"node"`
+	Status      string            `json:"status"`
+	URL         string            `json:"url"`
+	TriggeredBy string            `json:"triggered_by"`
+	Start       time.Time         `json:"start"`
+	Done        time.Time         `json:"done"`
+	Params      map[string]string `json:"params"`
+	// Changelog is the list of the commits since the last successful run of the node, if computed
+	Changelog *WorkflowRunChangelog `json:"changelog,omitempty"`
+}
+
+// RenderWebhookNotification executes the given template and checks that the result is valid JSON.
+func RenderWebhookNotification(tmpl string, data WebhookNotificationData) ([]byte, error) {
+	t, err := template.New("webhook").Funcs(interpolate.InterpolateHelperFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid webhook notification template: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "cannot execute webhook notification template: %v", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, NewErrorFrom(ErrWrongRequest, "webhook notification template does not produce valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+// SignWebhookNotification returns the signature of a webhook payload, given in header X-CDS-Signature.
+func SignWebhookNotification(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WorkflowNotificationDelivery is the delivery status of a webhook notification for a workflow node run.
+type WorkflowNotificationDelivery struct {
+	ID                int64      `json:"id" db:"id" cli:"id,key"`
+	UUID              string     `json:"uuid" db:"uuid" cli:"uuid"`
+	WorkflowRunID     int64      `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID int64      `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	NotificationID    int64      `json:"notification_id" db:"notification_id"`
+	NodeName          string     `json:"node_name" db:"node_name" cli:"node"`
+	NodeStatus        string     `json:"node_status" db:"node_status" cli:"node_status"`
+	URL               string     `json:"url" db:"url" cli:"url"`
+	Status            string     `json:"status" db:"status" cli:"status"`
+	Attempts          int        `json:"attempts" db:"attempts" cli:"attempts"`
+	StatusCode        int        `json:"status_code,omitempty" db:"status_code" cli:"status_code"`
+	Error             string     `json:"error,omitempty" db:"error" cli:"error"`
+	Created           time.Time  `json:"created" db:"created" cli:"created"`
+	LastAttempt       *time.Time `json:"last_attempt,omitempty" db:"last_attempt" cli:"last_attempt"`
+}
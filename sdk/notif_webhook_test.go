@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderWebhookNotification(t *testing.T) {
+	data := WebhookNotificationData{
+		Project:   "PROJ",
+		Workflow:  "my-workflow",
+		Number:    12,
+		Node:      "build",
+		Status:    StatusFail,
+		Params:    map[string]string{"git.branch": "feat/\"quoted\""},
+		SubNumber: 1,
+	}
+
+	payload, err := RenderWebhookNotification(UserNotificationTemplateWebhook.Body, data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"project":"PROJ","workflow":"my-workflow","number":12,"sub_number":1,"node":"build","status":"Fail","url":"","triggered_by":"","branch":"feat/\"quoted\""}`, string(payload))
+
+	_, err = RenderWebhookNotification(`{"status": {{ .Status }}}`, data)
+	assert.Error(t, err, "template should not produce valid JSON")
+
+	_, err = RenderWebhookNotification(`{{ .Unknown }}`, data)
+	assert.Error(t, err)
+}
+
+func TestSignWebhookNotification(t *testing.T) {
+	assert.Equal(t, "sha256=aa9e2e3575f5d7098b6caccd790888c36d5fdb63342a73bada2d6a51747a8494", SignWebhookNotification("secret", []byte(`{"a":1}`)))
+}
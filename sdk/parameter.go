@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -70,6 +71,25 @@ func (p Parameter) IsValid() error {
 	return nil
 }
 
+// CheckValue returns an error if given value is not valid for the type of the parameter.
+func (p Parameter) CheckValue(v string) error {
+	switch p.Type {
+	case NumberParameter:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return NewErrorFrom(ErrInvalidData, "Given value it's not a number for %s", p.Name)
+		}
+	case BooleanParameter:
+		if !(v == "true" || v == "false") {
+			return NewErrorFrom(ErrInvalidData, "Given value it's not a boolean for %s", p.Name)
+		}
+	case ListParameter:
+		if !IsInArray(v, strings.Split(p.Value, ";")) {
+			return NewErrorFrom(ErrInvalidData, "Given value for %s should be one of: %s", p.Name, strings.Join(strings.Split(p.Value, ";"), ", "))
+		}
+	}
+	return nil
+}
+
 // CheckFunc is a function to check key of a map for map merge
 type CheckFunc func(string) bool
 
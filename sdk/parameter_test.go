@@ -60,3 +60,13 @@ func TestParametersMerge(t *testing.T) {
 		}
 	}
 }
+
+func TestParameterCheckValue(t *testing.T) {
+	assert.NoError(t, Parameter{Name: "count", Type: NumberParameter}.CheckValue("1.5"))
+	assert.Error(t, Parameter{Name: "count", Type: NumberParameter}.CheckValue("one"))
+	assert.NoError(t, Parameter{Name: "debug", Type: BooleanParameter}.CheckValue("false"))
+	assert.Error(t, Parameter{Name: "debug", Type: BooleanParameter}.CheckValue("no"))
+	assert.NoError(t, Parameter{Name: "env", Type: ListParameter, Value: "dev;prod"}.CheckValue("prod"))
+	assert.Error(t, Parameter{Name: "env", Type: ListParameter, Value: "dev;prod"}.CheckValue("staging"))
+	assert.NoError(t, Parameter{Name: "name", Type: StringParameter}.CheckValue(""))
+}
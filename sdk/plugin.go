@@ -17,6 +17,7 @@ type GRPCPlugin struct {
 	Binaries           []GRPCPluginBinary `json:"binaries" yaml:"binaries" cli:"-" db:"-"`
 	IntegrationModelID *int64             `json:"-" db:"integration_model_id" yaml:"-" cli:"-"`
 	Integration        string             `json:"integration" db:"-" yaml:"integration" cli:"integration"`
+	Sandbox            GRPCPluginSandbox  `json:"sandbox" db:"sandbox" yaml:"sandbox,omitempty" cli:"-"`
 }
 
 // GetBinary returns the binary for a specific os and arch
@@ -31,22 +32,24 @@ func (p GRPCPlugin) GetBinary(os, arch string) *GRPCPluginBinary {
 
 // GRPCPluginBinary represents a binary file (for a specific os and arch) serving a GRPCPlugin
 type GRPCPluginBinary struct {
-	OS               string          `json:"os,omitempty" yaml:"os"`
-	Arch             string          `json:"arch,omitempty" yaml:"arch"`
-	Name             string          `json:"name,omitempty" yaml:"-"`
-	ObjectPath       string          `json:"object_path,omitempty" yaml:"-"`
-	Size             int64           `json:"size,omitempty" yaml:"-"`
-	Perm             uint32          `json:"perm,omitempty" yaml:"-"`
-	MD5sum           string          `json:"md5sum,omitempty" yaml:"-"`
-	SHA512sum        string          `json:"sha512sum,omitempty" yaml:"-"`
-	TempURL          string          `json:"temp_url,omitempty" yaml:"-"`
-	TempURLSecretKey string          `json:"-" yaml:"-"`
-	Entrypoints      []string        `json:"entrypoints,omitempty" yaml:"entrypoints"`
-	Cmd              string          `json:"cmd,omitempty" yaml:"cmd"`
-	Args             []string        `json:"args,omitempty" yaml:"args"`
-	Requirements     RequirementList `json:"requirements,omitempty" yaml:"requirements"`
-	FileContent      []byte          `json:"file_content,omitempty" yaml:"-"` //only used for upload
-	PluginName       string          `json:"plugin_name,omitempty" yaml:"-"`
+	OS               string             `json:"os,omitempty" yaml:"os"`
+	Arch             string             `json:"arch,omitempty" yaml:"arch"`
+	Name             string             `json:"name,omitempty" yaml:"-"`
+	ObjectPath       string             `json:"object_path,omitempty" yaml:"-"`
+	Size             int64              `json:"size,omitempty" yaml:"-"`
+	Perm             uint32             `json:"perm,omitempty" yaml:"-"`
+	MD5sum           string             `json:"md5sum,omitempty" yaml:"-"`
+	SHA512sum        string             `json:"sha512sum,omitempty" yaml:"-"`
+	TempURL          string             `json:"temp_url,omitempty" yaml:"-"`
+	TempURLSecretKey string             `json:"-" yaml:"-"`
+	Entrypoints      []string           `json:"entrypoints,omitempty" yaml:"entrypoints"`
+	Cmd              string             `json:"cmd,omitempty" yaml:"cmd"`
+	Args             []string           `json:"args,omitempty" yaml:"args"`
+	Requirements     RequirementList    `json:"requirements,omitempty" yaml:"requirements"`
+	FileContent      []byte             `json:"file_content,omitempty" yaml:"-"` //only used for upload
+	PluginName       string             `json:"plugin_name,omitempty" yaml:"-"`
+	Version          string             `json:"version,omitempty" yaml:"-"` //only set for the binaries of the plugin registry
+	Sandbox          *GRPCPluginSandbox `json:"sandbox,omitempty" yaml:"-"` //only set for the workers, from the plugin
 }
 
 // GetName is a part of the objectstore.Object interface implementation
@@ -56,5 +59,8 @@ func (b GRPCPluginBinary) GetName() string {
 
 // GetPath is a part of the objectstore.Object interface implementation
 func (b GRPCPluginBinary) GetPath() string {
+	if b.Version != "" {
+		return b.Name + "-" + b.Version + "-" + b.OS + "-" + b.Arch
+	}
 	return b.Name + "-" + b.OS + "-" + b.Arch
 }
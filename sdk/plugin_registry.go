@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// GRPCPluginVersion is a version of a GRPC plugin published in the plugin registry, with its binaries for each
+// os and arch and its documentation.
+type GRPCPluginVersion struct {
+	ID            int64              `json:"id" db:"id" cli:"-"`
+	PluginID      int64              `json:"plugin_id" db:"grpc_plugin_id" cli:"-"`
+	PluginName    string             `json:"plugin_name" db:"-" cli:"plugin,key"`
+	Version       string             `json:"version" db:"version" cli:"version,key"`
+	Documentation string             `json:"documentation,omitempty" db:"documentation" cli:"-"`
+	Binaries      GRPCPluginBinaries `json:"binaries" db:"binaries" cli:"-"`
+	Publisher     string             `json:"publisher" db:"publisher" cli:"publisher"`
+	Published     time.Time          `json:"published" db:"published" cli:"published"`
+	Installed     bool               `json:"installed" db:"-" cli:"installed"`
+}
+
+// IsValid returns an error if the version can't be published.
+func (v GRPCPluginVersion) IsValid() error {
+	if !NamePatternRegex.MatchString(v.Version) {
+		return NewErrorFrom(ErrWrongRequest, "invalid plugin version %q", v.Version)
+	}
+	if len(v.Binaries) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "a plugin version must have at least one binary")
+	}
+	for i, b := range v.Binaries {
+		if b.OS == "" || b.Arch == "" || b.Name == "" {
+			return NewErrorFrom(ErrWrongRequest, "invalid binary %d: name, os and arch are mandatory", i)
+		}
+		for j := range v.Binaries[:i] {
+			if v.Binaries[j].OS == b.OS && v.Binaries[j].Arch == b.Arch {
+				return NewErrorFrom(ErrWrongRequest, "duplicate binary for %s/%s", b.OS, b.Arch)
+			}
+		}
+	}
+	return nil
+}
+
+// GetBinary returns the binary of the version for a specific os and arch.
+func (v GRPCPluginVersion) GetBinary(os, arch string) *GRPCPluginBinary {
+	for i := range v.Binaries {
+		if v.Binaries[i].OS == os && v.Binaries[i].Arch == arch {
+			return &v.Binaries[i]
+		}
+	}
+	return nil
+}
+
+// GRPCPluginBinaries is the list of the binaries of a plugin version.
+type GRPCPluginBinaries []GRPCPluginBinary
+
+// Scan binaries.
+func (b *GRPCPluginBinaries) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, b), "cannot unmarshal GRPCPluginBinaries")
+}
+
+// Value returns driver.Value from binaries.
+func (b GRPCPluginBinaries) Value() (driver.Value, error) {
+	if b == nil {
+		b = GRPCPluginBinaries{}
+	}
+	j, err := json.Marshal(b)
+	return j, WrapError(err, "cannot marshal GRPCPluginBinaries")
+}
+
+// ParsePluginRequirement returns the name and the version of a plugin requirement value. A plugin requirement
+// given as name@version uses this version from the plugin registry, the installed plugin is used otherwise.
+func ParsePluginRequirement(value string) (string, string) {
+	return ParseBinaryRequirement(value)
+}
@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GRPCPluginSandbox restricts an action plugin run by a worker: the plugin is run by another user, which can only
+// write in the output directories of the workspace, and its cpu and memory are limited with a cgroup.
+type GRPCPluginSandbox struct {
+	User       string   `json:"user,omitempty" yaml:"user,omitempty"`
+	CPUs       float64  `json:"cpus,omitempty" yaml:"cpus,omitempty"`
+	Memory     int64    `json:"memory,omitempty" yaml:"memory,omitempty"` // in MB
+	OutputDirs []string `json:"output_dirs,omitempty" yaml:"output_dirs,omitempty"`
+}
+
+// IsEnabled returns true if the plugin must be run in a sandbox.
+func (s GRPCPluginSandbox) IsEnabled() bool {
+	return s.User != "" || s.CPUs > 0 || s.Memory > 0
+}
+
+// IsValid returns an error if the sandbox is invalid.
+func (s GRPCPluginSandbox) IsValid() error {
+	if s.CPUs < 0 || s.Memory < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid plugin sandbox: cpus and memory must be positive")
+	}
+	if len(s.OutputDirs) > 0 && s.User == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid plugin sandbox: output directories require a user")
+	}
+	for _, d := range s.OutputDirs {
+		d = filepath.Clean(d)
+		if filepath.IsAbs(d) || d == "." || d == ".." || strings.HasPrefix(d, ".."+string(filepath.Separator)) {
+			return NewErrorFrom(ErrWrongRequest, "invalid plugin sandbox: output directory %q must be a sub directory of the workspace", d)
+		}
+	}
+	return nil
+}
+
+// Scan sandbox.
+func (s *GRPCPluginSandbox) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, s), "cannot unmarshal GRPCPluginSandbox")
+}
+
+// Value returns driver.Value from sandbox.
+func (s GRPCPluginSandbox) Value() (driver.Value, error) {
+	j, err := json.Marshal(s)
+	return j, WrapError(err, "cannot marshal GRPCPluginSandbox")
+}
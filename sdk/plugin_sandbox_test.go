@@ -0,0 +1,22 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCPluginSandboxIsValid(t *testing.T) {
+	require.NoError(t, GRPCPluginSandbox{}.IsValid())
+	require.False(t, GRPCPluginSandbox{}.IsEnabled())
+
+	s := GRPCPluginSandbox{User: "cds-plugin", CPUs: 0.5, Memory: 512, OutputDirs: []string{"dist", "./reports/junit"}}
+	require.NoError(t, s.IsValid())
+	require.True(t, s.IsEnabled())
+
+	require.Error(t, GRPCPluginSandbox{Memory: -1}.IsValid())
+	require.Error(t, GRPCPluginSandbox{OutputDirs: []string{"dist"}}.IsValid())
+	for _, d := range []string{"/tmp", ".", "..", "../other", "dist/../.."} {
+		require.Error(t, GRPCPluginSandbox{User: "cds-plugin", OutputDirs: []string{d}}.IsValid(), d)
+	}
+}
@@ -152,6 +152,16 @@ func (proj Project) GetSSHKey(name string) *ProjectKey {
 	return nil
 }
 
+// GetPGPKey returns a pgp key given his name
+func (proj Project) GetPGPKey(name string) *ProjectKey {
+	for _, k := range proj.Keys {
+		if k.Type == KeyTypePGP && k.Name == name {
+			return &k
+		}
+	}
+	return nil
+}
+
 // SSHKeys returns the slice of ssh key for an application
 func (proj Project) SSHKeys() []ProjectKey {
 	keys := []ProjectKey{}
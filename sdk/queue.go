@@ -0,0 +1,38 @@
+package sdk
+
+// Bounds of the priority of a workflow run, the jobs of the runs with a higher priority are served first to hatcheries.
+const (
+	WorkflowRunPriorityMin = -10
+	WorkflowRunPriorityMax = 10
+)
+
+// HookConfigPriority is the key of the hook configuration that gives the priority of the runs started by a hook.
+const HookConfigPriority = "priority"
+
+// IsValidWorkflowRunPriority checks that given priority is in the allowed bounds.
+func IsValidWorkflowRunPriority(p int) error {
+	if p < WorkflowRunPriorityMin || p > WorkflowRunPriorityMax {
+		return NewErrorFrom(ErrWrongRequest, "invalid priority %d, it should be between %d and %d", p, WorkflowRunPriorityMin, WorkflowRunPriorityMax)
+	}
+	return nil
+}
+
+// ProjectQueueSettings contains the settings of a project in the job queue, they can only be set by CDS administrators.
+type ProjectQueueSettings struct {
+	ProjectID int64 `json:"project_id" db:"project_id"`
+	// Priority is the default priority of the workflow runs of the project
+	Priority int `json:"priority" db:"priority"`
+	// MaxBuildingJobs is the maximum number of jobs of the project that can be built at the same time, 0 means no limit
+	MaxBuildingJobs int `json:"max_building_jobs" db:"max_building_jobs"`
+}
+
+// IsValid returns an error if the settings are not valid.
+func (s ProjectQueueSettings) IsValid() error {
+	if err := IsValidWorkflowRunPriority(s.Priority); err != nil {
+		return err
+	}
+	if s.MaxBuildingJobs < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid max building jobs %d", s.MaxBuildingJobs)
+	}
+	return nil
+}
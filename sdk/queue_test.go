@@ -0,0 +1,13 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectQueueSettingsIsValid(t *testing.T) {
+	assert.NoError(t, ProjectQueueSettings{Priority: 10, MaxBuildingJobs: 5}.IsValid())
+	assert.Error(t, ProjectQueueSettings{Priority: 11}.IsValid())
+	assert.Error(t, ProjectQueueSettings{MaxBuildingJobs: -1}.IsValid())
+}
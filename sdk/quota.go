@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"strconv"
+)
+
+// Resources used by a job that has no cpu or memory requirement, they are the defaults of the hatcheries.
+const (
+	QuotaDefaultJobCPUs   = 1
+	QuotaDefaultJobMemory = 1024
+)
+
+// Quota limits the resources used at the same time by a project, or by all the projects of a group.
+// A group quota applies to the projects on which the group has the read/write/execute permission.
+// A zero value means no limit.
+type Quota struct {
+	ID        int64  `json:"id" db:"id" cli:"-"`
+	ProjectID *int64 `json:"project_id,omitempty" db:"project_id" cli:"-"`
+	GroupID   *int64 `json:"group_id,omitempty" db:"group_id" cli:"-"`
+	// MaxBuildingJobs is the maximum number of building jobs
+	MaxBuildingJobs int `json:"max_building_jobs" db:"max_building_jobs" cli:"max_building_jobs"`
+	// MaxCPUs is the maximum number of CPUs used by the workers of the building jobs
+	MaxCPUs float64 `json:"max_cpus" db:"max_cpus" cli:"max_cpus"`
+	// MaxMemory is the maximum memory in MB used by the workers of the building jobs
+	MaxMemory int64 `json:"max_memory" db:"max_memory" cli:"max_memory"`
+	// MaxArtifactStorage is the maximum size in bytes of the stored artifacts
+	MaxArtifactStorage int64 `json:"max_artifact_storage" db:"max_artifact_storage" cli:"max_artifact_storage"`
+}
+
+// IsValid returns an error if the quota is not valid.
+func (q Quota) IsValid() error {
+	if (q.ProjectID == nil) == (q.GroupID == nil) {
+		return NewErrorFrom(ErrWrongRequest, "a quota should be set either on a project or on a group")
+	}
+	if q.MaxBuildingJobs < 0 || q.MaxCPUs < 0 || q.MaxMemory < 0 || q.MaxArtifactStorage < 0 {
+		return NewErrorFrom(ErrWrongRequest, "quota limits can't be negative")
+	}
+	return nil
+}
+
+// QuotaUsage contains the resources currently used by the projects of a quota.
+type QuotaUsage struct {
+	BuildingJobs    int     `json:"building_jobs" cli:"building_jobs"`
+	CPUs            float64 `json:"cpus" cli:"cpus"`
+	Memory          int64   `json:"memory" cli:"memory"`
+	ArtifactStorage int64   `json:"artifact_storage" cli:"artifact_storage"`
+}
+
+// QuotaReport contains a quota and its current usage.
+type QuotaReport struct {
+	Quota  Quota      `json:"quota"`
+	Entity string     `json:"entity"`
+	Usage  QuotaUsage `json:"usage"`
+}
+
+// JobResources returns the CPUs and the memory in MB needed by a job, given its requirements.
+func JobResources(reqs RequirementList) (float64, int64) {
+	cpus, memory := float64(QuotaDefaultJobCPUs), int64(QuotaDefaultJobMemory)
+	for _, r := range reqs {
+		switch r.Type {
+		case CPURequirement:
+			if v, err := strconv.ParseFloat(r.Value, 64); err == nil {
+				cpus = v
+			}
+		case MemoryRequirement:
+			if v, err := strconv.ParseInt(r.Value, 10, 64); err == nil {
+				memory = v
+			}
+		}
+	}
+	return cpus, memory
+}
+
+// LimitsJobs returns true if the quota limits the building jobs.
+func (q Quota) LimitsJobs() bool {
+	return q.MaxBuildingJobs > 0 || q.MaxCPUs > 0 || q.MaxMemory > 0
+}
+
+// CheckJob returns an error if a new job that needs given resources can't be started.
+func (q Quota) CheckJob(u QuotaUsage, cpus float64, memory int64) error {
+	if q.MaxBuildingJobs > 0 && u.BuildingJobs+1 > q.MaxBuildingJobs {
+		return NewErrorFrom(ErrQuotaExceeded, "%d jobs are already building, the quota is %d", u.BuildingJobs, q.MaxBuildingJobs)
+	}
+	if q.MaxCPUs > 0 && u.CPUs+cpus > q.MaxCPUs {
+		return NewErrorFrom(ErrQuotaExceeded, "%g CPUs are already used, the job needs %g and the quota is %g", u.CPUs, cpus, q.MaxCPUs)
+	}
+	if q.MaxMemory > 0 && u.Memory+memory > q.MaxMemory {
+		return NewErrorFrom(ErrQuotaExceeded, "%dMB of memory are already used, the job needs %dMB and the quota is %dMB", u.Memory, memory, q.MaxMemory)
+	}
+	return nil
+}
+
+// CheckArtifact returns an error if an artifact of given size in bytes can't be stored.
+func (q Quota) CheckArtifact(u QuotaUsage, size int64) error {
+	if q.MaxArtifactStorage > 0 && u.ArtifactStorage+size > q.MaxArtifactStorage {
+		return NewErrorFrom(ErrQuotaExceeded, "%d bytes of artifacts are already stored, the artifact needs %d bytes and the quota is %d bytes", u.ArtifactStorage, size, q.MaxArtifactStorage)
+	}
+	return nil
+}
+
+// Exhausted returns true if no job can be started whatever its requirements.
+func (q Quota) Exhausted(u QuotaUsage) bool {
+	return (q.MaxBuildingJobs > 0 && u.BuildingJobs >= q.MaxBuildingJobs) ||
+		(q.MaxCPUs > 0 && u.CPUs >= q.MaxCPUs) ||
+		(q.MaxMemory > 0 && u.Memory >= q.MaxMemory)
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobResources(t *testing.T) {
+	cpus, memory := JobResources(nil)
+	assert.Equal(t, float64(QuotaDefaultJobCPUs), cpus)
+	assert.Equal(t, int64(QuotaDefaultJobMemory), memory)
+
+	cpus, memory = JobResources(RequirementList{
+		{Name: "cpu", Type: CPURequirement, Value: "0.5"},
+		{Name: "memory", Type: MemoryRequirement, Value: "4096"},
+	})
+	assert.Equal(t, 0.5, cpus)
+	assert.Equal(t, int64(4096), memory)
+}
+
+func TestQuotaCheck(t *testing.T) {
+	id := int64(1)
+	q := Quota{ProjectID: &id, MaxBuildingJobs: 3, MaxCPUs: 4, MaxArtifactStorage: 100}
+	assert.NoError(t, q.IsValid())
+	assert.True(t, q.LimitsJobs())
+
+	u := QuotaUsage{BuildingJobs: 2, CPUs: 3, ArtifactStorage: 60}
+	assert.NoError(t, q.CheckJob(u, 1, 1024))
+	assert.True(t, ErrorIs(q.CheckJob(u, 2, 1024), ErrQuotaExceeded))
+	assert.False(t, q.Exhausted(u))
+
+	u.BuildingJobs = 3
+	assert.True(t, ErrorIs(q.CheckJob(u, 1, 1024), ErrQuotaExceeded))
+	assert.True(t, q.Exhausted(u))
+
+	assert.NoError(t, q.CheckArtifact(u, 40))
+	assert.Error(t, q.CheckArtifact(u, 41))
+
+	assert.Error(t, Quota{}.IsValid())
+	assert.Error(t, Quota{ProjectID: &id, MaxMemory: -1}.IsValid())
+}
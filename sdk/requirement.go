@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"net"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -24,6 +26,8 @@ const (
 	VolumeRequirement = "volume"
 	// OSArchRequirement checks the 'dist' of a worker eg {GOOS}/{GOARCH}
 	OSArchRequirement = "os-architecture"
+	// CPURequirement set the number of CPUs of a container
+	CPURequirement = "cpu"
 )
 
 // RequirementList is a list of requirement
@@ -81,9 +85,44 @@ func (l RequirementList) IsValid() error {
 		return WithStack(ErrInvalidJobRequirementDuplicateHostname)
 	}
 
+	// check that the tools installed from the tool registry have a valid name and version
+	for i := range l {
+		if l[i].Type != BinaryRequirement {
+			continue
+		}
+		name, version := ParseBinaryRequirement(l[i].Value)
+		if version == "" {
+			continue
+		}
+		if !toolPatternRegex.MatchString(name) || !toolPatternRegex.MatchString(version) {
+			return NewErrorFrom(ErrInvalidJobRequirement, "invalid binary requirement %s, must be name@version", l[i].Value)
+		}
+	}
+
 	return nil
 }
 
+var toolPatternRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._+-]*$`)
+
+// ParseBinaryRequirement returns the name and the version of a binary requirement value. A binary requirement
+// given as name@version is a tool installed by the worker from the tool registry, the version is empty otherwise.
+func ParseBinaryRequirement(value string) (string, string) {
+	i := strings.LastIndex(value, "@")
+	if i <= 0 {
+		return value, ""
+	}
+	return value[:i], value[i+1:]
+}
+
+// ToolURL returns the URL of the static binary of a tool in a tool registry. The URL of the registry can contain
+// the placeholders {name}, {version}, {os} and {arch}, the binary is {registry}/{name}/{version}/{os}/{arch}/{name} otherwise.
+func ToolURL(registry, name, version, goos, goarch string) string {
+	if !strings.Contains(registry, "{name}") {
+		registry = strings.TrimSuffix(registry, "/") + "/{name}/{version}/{os}/{arch}/{name}"
+	}
+	return strings.NewReplacer("{name}", name, "{version}", version, "{os}", goos, "{arch}", goarch).Replace(registry)
+}
+
 var (
 	// AvailableRequirementsType List of all requirements
 	AvailableRequirementsType = []string{
@@ -96,6 +135,7 @@ var (
 		MemoryRequirement,
 		VolumeRequirement,
 		OSArchRequirement,
+		CPURequirement,
 	}
 
 	// OSArchRequirementValues comes from go tool dist list
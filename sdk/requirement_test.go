@@ -100,3 +100,40 @@ func TestRequirementListDeduplicate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBinaryRequirement(t *testing.T) {
+	tests := []struct {
+		value, name, version string
+	}{
+		{"git", "git", ""},
+		{"golangci-lint@1.55", "golangci-lint", "1.55"},
+		{"helm@v3.2.1", "helm", "v3.2.1"},
+		{"@1.0", "@1.0", ""},
+	}
+	for _, tt := range tests {
+		name, version := ParseBinaryRequirement(tt.value)
+		if name != tt.name || version != tt.version {
+			t.Errorf("ParseBinaryRequirement(%q) = %q, %q, want %q, %q", tt.value, name, version, tt.name, tt.version)
+		}
+	}
+}
+
+func TestToolURL(t *testing.T) {
+	if got := ToolURL("https://tools.example.com/", "jq", "1.6", "linux", "amd64"); got != "https://tools.example.com/jq/1.6/linux/amd64/jq" {
+		t.Errorf("ToolURL() = %s", got)
+	}
+	if got := ToolURL("https://tools.example.com/{name}-{version}-{os}-{arch}", "jq", "1.6", "linux", "amd64"); got != "https://tools.example.com/jq-1.6-linux-amd64" {
+		t.Errorf("ToolURL() = %s", got)
+	}
+}
+
+func TestRequirementListIsValidTool(t *testing.T) {
+	valid := RequirementList{{Name: "golangci-lint@1.55", Type: BinaryRequirement, Value: "golangci-lint@1.55"}}
+	if err := valid.IsValid(); err != nil {
+		t.Errorf("IsValid() = %v", err)
+	}
+	invalid := RequirementList{{Name: "lint", Type: BinaryRequirement, Value: "../lint@1.55"}}
+	if err := invalid.IsValid(); err == nil {
+		t.Errorf("IsValid() must return an error for %s", invalid[0].Value)
+	}
+}
@@ -0,0 +1,154 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SBOM formats, only the JSON encodings are supported
+const (
+	SBOMFormatCycloneDX = "cyclonedx"
+	SBOMFormatSPDX      = "spdx"
+)
+
+// WorkflowRunSBOM is a software bill of materials produced by a job of a workflow run.
+type WorkflowRunSBOM struct {
+	ID                int64  `json:"id" db:"id" cli:"id"`
+	WorkflowID        int64  `json:"workflow_id" db:"workflow_id" cli:"-"`
+	WorkflowRunID     int64  `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64  `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"-"`
+	Name              string `json:"name" db:"name" cli:"name,key"`
+	// Artifact is the name of the artifact described by the SBOM, if any
+	Artifact   string          `json:"artifact,omitempty" db:"artifact" cli:"artifact"`
+	Format     string          `json:"format" db:"format" cli:"format"`
+	Document   json.RawMessage `json:"document,omitempty" db:"document" cli:"-"`
+	Components SBOMComponents  `json:"components,omitempty" db:"components" cli:"-"`
+	Created    time.Time       `json:"created" db:"created" cli:"created"`
+}
+
+// SBOMComponent is a dependency listed in a SBOM.
+type SBOMComponent struct {
+	Name    string `json:"name" cli:"name"`
+	Version string `json:"version,omitempty" cli:"version"`
+	PURL    string `json:"purl,omitempty" cli:"purl"`
+}
+
+// SBOMComponents is the list of the dependencies of a SBOM.
+type SBOMComponents []SBOMComponent
+
+// Scan components.
+func (c *SBOMComponents) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, c), "cannot unmarshal SBOMComponents")
+}
+
+// Value returns driver.Value from components.
+func (c SBOMComponents) Value() (driver.Value, error) {
+	if c == nil {
+		c = SBOMComponents{}
+	}
+	j, err := json.Marshal(c)
+	return j, WrapError(err, "cannot marshal SBOMComponents")
+}
+
+// SBOMSearchResult is a run containing a dependency.
+type SBOMSearchResult struct {
+	ProjectKey        string    `json:"project_key" db:"project_key" cli:"project"`
+	WorkflowName      string    `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	RunNumber         int64     `json:"run_number" db:"run_number" cli:"run"`
+	WorkflowNodeRunID int64     `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"-"`
+	SBOMID            int64     `json:"sbom_id" db:"sbom_id" cli:"-"`
+	SBOMName          string    `json:"sbom_name" db:"sbom_name" cli:"sbom"`
+	Artifact          string    `json:"artifact,omitempty" db:"artifact" cli:"artifact"`
+	Name              string    `json:"name" db:"name" cli:"name"`
+	Version           string    `json:"version" db:"version" cli:"version"`
+	PURL              string    `json:"purl,omitempty" db:"purl" cli:"purl"`
+	Created           time.Time `json:"created" db:"created" cli:"created"`
+}
+
+type cycloneDXComponent struct {
+	Name       string               `json:"name"`
+	Version    string               `json:"version"`
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXDocument struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type spdxDocument struct {
+	Packages []struct {
+		Name         string `json:"name"`
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// ParseSBOM returns the format and the components of a CycloneDX or SPDX JSON document. The format is
+// detected from the document if it is not given.
+func ParseSBOM(format string, document []byte) (string, SBOMComponents, error) {
+	if format == "" {
+		var header struct {
+			BOMFormat   string `json:"bomFormat"`
+			SPDXVersion string `json:"spdxVersion"`
+		}
+		if err := json.Unmarshal(document, &header); err != nil {
+			return "", nil, NewErrorFrom(ErrWrongRequest, "invalid SBOM: %v", err)
+		}
+		switch {
+		case header.BOMFormat == "CycloneDX":
+			format = SBOMFormatCycloneDX
+		case header.SPDXVersion != "":
+			format = SBOMFormatSPDX
+		default:
+			return "", nil, NewErrorFrom(ErrWrongRequest, "unknown SBOM format, must be CycloneDX or SPDX JSON")
+		}
+	}
+
+	components := SBOMComponents{}
+	switch format {
+	case SBOMFormatCycloneDX:
+		var doc cycloneDXDocument
+		if err := json.Unmarshal(document, &doc); err != nil {
+			return "", nil, NewErrorFrom(ErrWrongRequest, "invalid CycloneDX SBOM: %v", err)
+		}
+		var walk func(cs []cycloneDXComponent)
+		walk = func(cs []cycloneDXComponent) {
+			for _, c := range cs {
+				components = append(components, SBOMComponent{Name: c.Name, Version: c.Version, PURL: c.PURL})
+				walk(c.Components)
+			}
+		}
+		walk(doc.Components)
+	case SBOMFormatSPDX:
+		var doc spdxDocument
+		if err := json.Unmarshal(document, &doc); err != nil {
+			return "", nil, NewErrorFrom(ErrWrongRequest, "invalid SPDX SBOM: %v", err)
+		}
+		for _, p := range doc.Packages {
+			c := SBOMComponent{Name: p.Name, Version: p.VersionInfo}
+			for _, ref := range p.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					c.PURL = ref.ReferenceLocator
+				}
+			}
+			components = append(components, c)
+		}
+	default:
+		return "", nil, NewErrorFrom(ErrWrongRequest, "invalid SBOM format %q, must be %s or %s", format, SBOMFormatCycloneDX, SBOMFormatSPDX)
+	}
+	return format, components, nil
+}
@@ -0,0 +1,43 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSBOM(t *testing.T) {
+	cyclonedx := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "components": [
+    {"type": "library", "group": "org.apache.logging.log4j", "name": "log4j-core", "version": "2.14.1", "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+     "components": [{"type": "library", "name": "log4j-api", "version": "2.14.1"}]},
+    {"type": "library", "name": "github.com/pkg/errors", "version": "v0.9.1", "purl": "pkg:golang/github.com/pkg/errors@v0.9.1"}
+  ]
+}`
+	format, components, err := ParseSBOM("", []byte(cyclonedx))
+	require.NoError(t, err)
+	require.Equal(t, SBOMFormatCycloneDX, format)
+	require.Equal(t, SBOMComponents{
+		{Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+		{Name: "log4j-api", Version: "2.14.1"},
+		{Name: "github.com/pkg/errors", Version: "v0.9.1", PURL: "pkg:golang/github.com/pkg/errors@v0.9.1"},
+	}, components)
+
+	spdx := `{
+  "spdxVersion": "SPDX-2.2",
+  "packages": [
+    {"name": "openssl", "versionInfo": "1.1.1k", "externalRefs": [{"referenceCategory": "PACKAGE_MANAGER", "referenceType": "purl", "referenceLocator": "pkg:deb/debian/openssl@1.1.1k"}]}
+  ]
+}`
+	format, components, err = ParseSBOM("", []byte(spdx))
+	require.NoError(t, err)
+	require.Equal(t, SBOMFormatSPDX, format)
+	require.Equal(t, SBOMComponents{{Name: "openssl", Version: "1.1.1k", PURL: "pkg:deb/debian/openssl@1.1.1k"}}, components)
+
+	_, _, err = ParseSBOM("", []byte(`{"foo": "bar"}`))
+	require.Error(t, err)
+	_, _, err = ParseSBOM("swid", []byte(cyclonedx))
+	require.Error(t, err)
+}
@@ -7,3 +7,11 @@ type SchemaResponse struct {
 	Pipeline    string `json:"pipeline"`
 	Environment string `json:"environment"`
 }
+
+// SchemaFile is the JSON schema of a kind of as code files, published by the API for the editors.
+type SchemaFile struct {
+	Kind    string `json:"kind"`
+	Version string `json:"version"`
+	Latest  bool   `json:"latest"`
+	URL     string `json:"url"`
+}
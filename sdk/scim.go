@@ -0,0 +1,249 @@
+package sdk
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// SCIM 2.0 schemas, see RFC 7643 and RFC 7644.
+const (
+	SCIMSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCIMSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+// SCIM patch operations.
+const (
+	SCIMPatchOpAdd     = "add"
+	SCIMPatchOpRemove  = "remove"
+	SCIMPatchOpReplace = "replace"
+)
+
+// SCIMDefaultCount is the default page size for SCIM list requests.
+const SCIMDefaultCount = 100
+
+// SCIMUser is a SCIM user resource.
+type SCIMUser struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id,omitempty"`
+	ExternalID  string           `json:"externalId,omitempty"`
+	UserName    string           `json:"userName"`
+	Name        SCIMName         `json:"name"`
+	DisplayName string           `json:"displayName,omitempty"`
+	Emails      []SCIMMultiValue `json:"emails,omitempty"`
+	Active      *bool            `json:"active,omitempty"`
+	Groups      []SCIMMultiValue `json:"groups,omitempty"`
+	Meta        *SCIMMeta        `json:"meta,omitempty"`
+}
+
+// IsValid returns an error if the user can't be provisioned.
+func (u SCIMUser) IsValid() error {
+	if u.UserName == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing userName")
+	}
+	if u.PrimaryEmail() == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing email")
+	}
+	return nil
+}
+
+// Fullname returns the best available full name for the user.
+func (u SCIMUser) Fullname() string {
+	switch {
+	case u.DisplayName != "":
+		return u.DisplayName
+	case u.Name.Formatted != "":
+		return u.Name.Formatted
+	case u.Name.GivenName != "" || u.Name.FamilyName != "":
+		return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+	}
+	return u.UserName
+}
+
+// PrimaryEmail returns the primary email of the user or the first one if none is primary.
+func (u SCIMUser) PrimaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// IsActive returns false only if the user was explicitly deactivated.
+func (u SCIMUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// SCIMName is the name of a SCIM user.
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMMultiValue is a SCIM multi-valued attribute (emails, group members...).
+type SCIMMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMMeta contains resource metadata.
+type SCIMMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// SCIMGroup is a SCIM group resource.
+type SCIMGroup struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id,omitempty"`
+	ExternalID  string           `json:"externalId,omitempty"`
+	DisplayName string           `json:"displayName"`
+	Members     []SCIMMultiValue `json:"members,omitempty"`
+	Meta        *SCIMMeta        `json:"meta,omitempty"`
+}
+
+// SCIMListResponse is returned when listing SCIM resources.
+type SCIMListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// SCIMPatchRequest contains operations to apply on a SCIM resource.
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation is a single patch operation.
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Operation returns the lower cased op value, some providers send it capitalized.
+func (o SCIMPatchOperation) Operation() string {
+	return strings.ToLower(o.Op)
+}
+
+// MembersValue returns the members given in the operation value.
+func (o SCIMPatchOperation) MembersValue() ([]SCIMMultiValue, error) {
+	var ms []SCIMMultiValue
+	if len(o.Value) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(o.Value, &ms); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid members value")
+	}
+	return ms, nil
+}
+
+// SCIMServiceProviderConfig describes the supported SCIM features.
+type SCIMServiceProviderConfig struct {
+	Schemas []string `json:"schemas"`
+	Patch   struct {
+		Supported bool `json:"supported"`
+	} `json:"patch"`
+	Bulk struct {
+		Supported bool `json:"supported"`
+	} `json:"bulk"`
+	Filter struct {
+		Supported  bool `json:"supported"`
+		MaxResults int  `json:"maxResults"`
+	} `json:"filter"`
+	ChangePassword struct {
+		Supported bool `json:"supported"`
+	} `json:"changePassword"`
+	Sort struct {
+		Supported bool `json:"supported"`
+	} `json:"sort"`
+	Etag struct {
+		Supported bool `json:"supported"`
+	} `json:"etag"`
+}
+
+// SCIMFilter is a simple SCIM filter expression like 'userName eq "fry"'.
+// Only the equal operator is supported as it's the one used by identity providers to lookup resources.
+type SCIMFilter struct {
+	Attribute string
+	Value     string
+}
+
+// ParseSCIMFilter returns a filter for given expression, nil is returned if expression is empty.
+func ParseSCIMFilter(s string) (*SCIMFilter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(s, " ", 3)
+	if len(parts) != 3 || strings.ToLower(parts[1]) != "eq" {
+		return nil, NewErrorFrom(ErrWrongRequest, "unsupported filter %q", s)
+	}
+
+	value := strings.TrimSpace(parts[2])
+	if len(value) < 2 || !strings.HasPrefix(value, "\"") || !strings.HasSuffix(value, "\"") {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid filter value %q", value)
+	}
+
+	return &SCIMFilter{
+		Attribute: parts[0],
+		Value:     strings.Replace(value[1:len(value)-1], "\\\"", "\"", -1),
+	}, nil
+}
+
+// ParseSCIMMemberPath returns the member id from a patch path like 'members[value eq "id"]'.
+func ParseSCIMMemberPath(path string) (string, bool) {
+	if !strings.HasPrefix(path, "members[") || !strings.HasSuffix(path, "]") {
+		return "", false
+	}
+	f, err := ParseSCIMFilter(strings.TrimSuffix(strings.TrimPrefix(path, "members["), "]"))
+	if err != nil || f == nil || f.Attribute != "value" {
+		return "", false
+	}
+	return f.Value, true
+}
+
+// NewSCIMListResponse returns a page of given resources, startIndex is 1-based.
+func NewSCIMListResponse(total, startIndex int, resources interface{}, itemsPerPage int) SCIMListResponse {
+	return SCIMListResponse{
+		Schemas:      []string{SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: itemsPerPage,
+		Resources:    resources,
+	}
+}
+
+// SCIMPage returns the bounds of the page in a slice of given length for startIndex (1-based) and count.
+func SCIMPage(length, startIndex, count int) (int, int) {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count < 0 {
+		count = 0
+	}
+	from := startIndex - 1
+	if from > length {
+		from = length
+	}
+	to := from + count
+	if to > length {
+		to = length
+	}
+	return from, to
+}
@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	f, err := ParseSCIMFilter(`userName eq "fry"`)
+	require.NoError(t, err)
+	assert.Equal(t, &SCIMFilter{Attribute: "userName", Value: "fry"}, f)
+
+	f, err = ParseSCIMFilter(`displayName Eq "planet express"`)
+	require.NoError(t, err)
+	assert.Equal(t, &SCIMFilter{Attribute: "displayName", Value: "planet express"}, f)
+
+	f, err = ParseSCIMFilter("")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = ParseSCIMFilter(`userName co "fry"`)
+	assert.Error(t, err)
+	_, err = ParseSCIMFilter(`userName eq fry`)
+	assert.Error(t, err)
+}
+
+func TestParseSCIMMemberPath(t *testing.T) {
+	id, ok := ParseSCIMMemberPath(`members[value eq "1234"]`)
+	assert.True(t, ok)
+	assert.Equal(t, "1234", id)
+
+	_, ok = ParseSCIMMemberPath("members")
+	assert.False(t, ok)
+	_, ok = ParseSCIMMemberPath(`members[display eq "fry"]`)
+	assert.False(t, ok)
+}
+
+func TestSCIMUser(t *testing.T) {
+	active := false
+	u := SCIMUser{
+		UserName: "fry",
+		Name:     SCIMName{GivenName: "Philip", FamilyName: "Fry"},
+		Emails: []SCIMMultiValue{
+			{Value: "fry@planet-express.com"},
+			{Value: "philip.fry@planet-express.com", Primary: true},
+		},
+		Active: &active,
+	}
+	require.NoError(t, u.IsValid())
+	assert.Equal(t, "Philip Fry", u.Fullname())
+	assert.Equal(t, "philip.fry@planet-express.com", u.PrimaryEmail())
+	assert.False(t, u.IsActive())
+
+	assert.Error(t, SCIMUser{UserName: "fry"}.IsValid())
+	assert.True(t, SCIMUser{}.IsActive())
+}
+
+func TestSCIMPage(t *testing.T) {
+	from, to := SCIMPage(10, 1, 3)
+	assert.Equal(t, 0, from)
+	assert.Equal(t, 3, to)
+
+	from, to = SCIMPage(10, 9, 3)
+	assert.Equal(t, 8, from)
+	assert.Equal(t, 10, to)
+
+	from, to = SCIMPage(10, 20, 3)
+	assert.Equal(t, 10, from)
+	assert.Equal(t, 10, to)
+}
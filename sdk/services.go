@@ -22,10 +22,61 @@ type Service struct {
 	CanonicalService
 	LastHeartbeat    time.Time        `json:"last_heartbeat" db:"last_heartbeat" cli:"heartbeat"`
 	MonitoringStatus MonitoringStatus `json:"monitoring_status" db:"monitoring_status" cli:"-"`
-	Version          string           `json:"version" db:"-" cli:"version"`
+	Version          string           `json:"version" db:"version" cli:"version"`
+	ContractVersion  int              `json:"contract_version" db:"contract_version" cli:"contract_version"`
 	Uptodate         bool             `json:"up_to_date" db:"-"`
 }
 
+// Versions of the contract between the API and the µservices. The contract version is increased when the API
+// changes in a way that services built before can't handle, ServiceContractMinVersion is the oldest contract
+// still supported by the API.
+const (
+	ServiceContractVersion    = 1
+	ServiceContractMinVersion = 1
+)
+
+// Compatibility of a service contract version with the API.
+const (
+	ServiceCompatibilityOK           = "OK"
+	ServiceCompatibilityUnknown      = "unknown"
+	ServiceCompatibilityNewer        = "newer"
+	ServiceCompatibilityIncompatible = "incompatible"
+)
+
+// ServiceContractCompatibility returns the compatibility of given contract version with the API.
+// Services that don't send their contract version were built before contracts were introduced.
+func ServiceContractCompatibility(contractVersion int) string {
+	switch {
+	case contractVersion == 0:
+		return ServiceCompatibilityUnknown
+	case contractVersion < ServiceContractMinVersion:
+		return ServiceCompatibilityIncompatible
+	case contractVersion > ServiceContractVersion:
+		return ServiceCompatibilityNewer
+	}
+	return ServiceCompatibilityOK
+}
+
+// MonitoringServicesMatrix gives the versions of all the registered services, to see skew during upgrades.
+type MonitoringServicesMatrix struct {
+	APIVersion            string                      `json:"api_version"`
+	ContractVersion       int                         `json:"contract_version"`
+	ContractMinVersion    int                         `json:"contract_min_version"`
+	Services              []MonitoringServiceVersion  `json:"services"`
+	VersionsByServiceType map[string]map[string]int64 `json:"versions_by_service_type"`
+}
+
+// MonitoringServiceVersion is the version of a registered service.
+type MonitoringServiceVersion struct {
+	Name            string    `json:"name" cli:"name,key"`
+	Type            string    `json:"type" cli:"type"`
+	Version         string    `json:"version" cli:"version"`
+	ContractVersion int       `json:"contract_version" cli:"contract_version"`
+	Compatibility   string    `json:"compatibility" cli:"compatibility"`
+	Uptodate        bool      `json:"up_to_date" cli:"up_to_date"`
+	LastHeartbeat   time.Time `json:"last_heartbeat" cli:"heartbeat"`
+}
+
 // Update service field from new data.
 func (s *Service) Update(data Service) {
 	s.Name = data.Name
@@ -34,6 +85,8 @@ func (s *Service) Update(data Service) {
 	s.PublicKey = data.PublicKey
 	s.LastHeartbeat = data.LastHeartbeat
 	s.MonitoringStatus = data.MonitoringStatus
+	s.Version = data.Version
+	s.ContractVersion = data.ContractVersion
 }
 
 type ServiceConfig map[string]interface{}
@@ -73,3 +126,13 @@ func (e ExternalService) ServiceConfig() ServiceConfig {
 	json.Unmarshal(b, &cfg) // nolint
 	return cfg
 }
+
+// HatcheryDrainStatus is the progress of the drain of a hatchery. A draining hatchery doesn't provision new workers,
+// it waits for the running jobs before stopping.
+type HatcheryDrainStatus struct {
+	Draining       bool      `json:"draining" cli:"draining"`
+	Since          time.Time `json:"since,omitempty" cli:"since"`
+	Deadline       time.Time `json:"deadline,omitempty" cli:"deadline"`
+	RunningWorkers int       `json:"running_workers" cli:"running_workers"`
+	Done           bool      `json:"done" cli:"done"`
+}
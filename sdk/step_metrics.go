@@ -0,0 +1,62 @@
+package sdk
+
+import "time"
+
+// StepMetricsMaxSamples is the maximum number of samples kept for a step, the timeline of a longer step is
+// downsampled.
+const StepMetricsMaxSamples = 360
+
+// StepMetrics is the timeline of the resources used by the worker during a step, with their peak and total.
+type StepMetrics struct {
+	Interval   int64               `json:"interval"` // in seconds
+	Samples    []StepMetricsSample `json:"samples"`
+	MaxCPU     float64             `json:"max_cpu"`
+	MaxMemory  uint64              `json:"max_memory"`
+	ReadBytes  uint64              `json:"read_bytes"`
+	WriteBytes uint64              `json:"write_bytes"`
+}
+
+// StepMetricsSample is the resources used by the worker during an interval of a step. CPU is a percentage of all
+// the cpus, Memory is the used memory and ReadBytes/WriteBytes the disk I/O during the interval.
+type StepMetricsSample struct {
+	Time       time.Time `json:"time"`
+	CPU        float64   `json:"cpu"`
+	Memory     uint64    `json:"memory"`
+	ReadBytes  uint64    `json:"read_bytes"`
+	WriteBytes uint64    `json:"write_bytes"`
+}
+
+// Add adds a sample to the timeline. When the timeline is full, two consecutive samples are merged in one
+// and the interval is doubled.
+func (m *StepMetrics) Add(s StepMetricsSample) {
+	if s.CPU > m.MaxCPU {
+		m.MaxCPU = s.CPU
+	}
+	if s.Memory > m.MaxMemory {
+		m.MaxMemory = s.Memory
+	}
+	m.ReadBytes += s.ReadBytes
+	m.WriteBytes += s.WriteBytes
+	m.Samples = append(m.Samples, s)
+
+	if len(m.Samples) <= StepMetricsMaxSamples {
+		return
+	}
+	samples := make([]StepMetricsSample, 0, len(m.Samples)/2+1)
+	for i := 0; i < len(m.Samples); i += 2 {
+		merged := m.Samples[i]
+		if i+1 < len(m.Samples) {
+			next := m.Samples[i+1]
+			merged.Time = next.Time
+			merged.CPU = (merged.CPU + next.CPU) / 2
+			if next.Memory > merged.Memory {
+				merged.Memory = next.Memory
+			}
+			merged.ReadBytes += next.ReadBytes
+			merged.WriteBytes += next.WriteBytes
+		}
+		samples = append(samples, merged)
+	}
+	m.Samples = samples
+	m.Interval *= 2
+}
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepMetricsAdd(t *testing.T) {
+	m := StepMetrics{Interval: 5}
+	t0 := time.Now()
+	for i := 0; i < StepMetricsMaxSamples; i++ {
+		m.Add(StepMetricsSample{
+			Time:       t0.Add(time.Duration(i*5) * time.Second),
+			CPU:        float64(i % 100),
+			Memory:     uint64(i),
+			ReadBytes:  10,
+			WriteBytes: 1,
+		})
+	}
+	require.Len(t, m.Samples, StepMetricsMaxSamples)
+	require.Equal(t, int64(5), m.Interval)
+	require.Equal(t, float64(99), m.MaxCPU)
+	require.Equal(t, uint64(StepMetricsMaxSamples-1), m.MaxMemory)
+
+	// the timeline is full, the samples are merged
+	m.Add(StepMetricsSample{Time: t0.Add(time.Hour), CPU: 50, Memory: 1024, ReadBytes: 10, WriteBytes: 1})
+	require.Len(t, m.Samples, StepMetricsMaxSamples/2+1)
+	require.Equal(t, int64(10), m.Interval)
+	require.Equal(t, uint64(1024), m.MaxMemory)
+	require.Equal(t, uint64((StepMetricsMaxSamples+1)*10), m.ReadBytes)
+	require.Equal(t, uint64((StepMetricsMaxSamples+1)*1), m.WriteBytes)
+	require.Equal(t, 0.5, m.Samples[0].CPU)
+	require.Equal(t, uint64(1), m.Samples[0].Memory)
+	require.Equal(t, uint64(20), m.Samples[0].ReadBytes)
+	require.Equal(t, t0.Add(time.Hour), m.Samples[len(m.Samples)-1].Time)
+}
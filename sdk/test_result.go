@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ovh/venom"
+)
+
+// Status of a test case in the test results of a workflow run
+const (
+	TestResultSuccess = "success"
+	TestResultFailure = "failure"
+	TestResultSkipped = "skipped"
+)
+
+// WorkflowRunTestResult is the result of a test case in a workflow run, kept to compute the history of the test.
+type WorkflowRunTestResult struct {
+	ID                int64     `json:"id" db:"id" cli:"-"`
+	WorkflowID        int64     `json:"workflow_id" db:"workflow_id" cli:"-"`
+	WorkflowRunID     int64     `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64     `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"-"`
+	RunNumber         int64     `json:"run_number" db:"run_number" cli:"run"`
+	VCSHash           string    `json:"vcs_hash,omitempty" db:"vcs_hash" cli:"hash"`
+	VCSBranch         string    `json:"vcs_branch,omitempty" db:"vcs_branch" cli:"branch"`
+	Suite             string    `json:"suite" db:"suite" cli:"suite"`
+	Name              string    `json:"name" db:"name" cli:"name"`
+	Status            string    `json:"status" db:"status" cli:"status"`
+	Duration          float64   `json:"duration" db:"duration" cli:"duration"` // in seconds
+	Created           time.Time `json:"created" db:"created" cli:"created"`
+}
+
+// NewWorkflowRunTestResults returns the results of the test cases of the test suites sent by a job of a node run.
+func NewWorkflowRunTestResults(nr WorkflowNodeRun, suites []venom.TestSuite) []WorkflowRunTestResult {
+	var res []WorkflowRunTestResult
+	for _, ts := range suites {
+		for _, tc := range ts.TestCases {
+			r := WorkflowRunTestResult{
+				WorkflowID:        nr.WorkflowID,
+				WorkflowRunID:     nr.WorkflowRunID,
+				WorkflowNodeRunID: nr.ID,
+				RunNumber:         nr.Number,
+				VCSHash:           nr.VCSHash,
+				VCSBranch:         nr.VCSBranch,
+				Suite:             ts.Name,
+				Name:              tc.Name,
+				Status:            TestResultSuccess,
+			}
+			if tc.Classname != "" {
+				r.Name = tc.Classname + "." + tc.Name
+			}
+			switch {
+			case len(tc.Failures) > 0 || len(tc.Errors) > 0:
+				r.Status = TestResultFailure
+			case len(tc.Skipped) > 0:
+				r.Status = TestResultSkipped
+			}
+			r.Duration, _ = strconv.ParseFloat(tc.Time, 64)
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// TestHistory is the result of a test case in a run of a workflow of a project. The result is flaky if the test
+// has another result for the same commit.
+type TestHistory struct {
+	WorkflowName string    `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	RunNumber    int64     `json:"run_number" db:"run_number" cli:"run"`
+	VCSHash      string    `json:"vcs_hash,omitempty" db:"vcs_hash" cli:"hash"`
+	VCSBranch    string    `json:"vcs_branch,omitempty" db:"vcs_branch" cli:"branch"`
+	Status       string    `json:"status" db:"status" cli:"status"`
+	Duration     float64   `json:"duration" db:"duration" cli:"duration"`
+	Created      time.Time `json:"created" db:"created" cli:"created"`
+	Flaky        bool      `json:"flaky" db:"-" cli:"flaky"`
+}
+
+// SetFlakyTestHistory flags the results of a test which passed and failed on the same commit.
+func SetFlakyTestHistory(hs []TestHistory) {
+	statuses := make(map[string]map[string]struct{})
+	for _, h := range hs {
+		if h.VCSHash == "" || h.Status == TestResultSkipped {
+			continue
+		}
+		if statuses[h.VCSHash] == nil {
+			statuses[h.VCSHash] = make(map[string]struct{})
+		}
+		statuses[h.VCSHash][h.Status] = struct{}{}
+	}
+	for i := range hs {
+		hs[i].Flaky = hs[i].Status != TestResultSkipped && len(statuses[hs[i].VCSHash]) > 1
+	}
+}
+
+// FlakyTest is a test of a project which passed and failed on the same commit. FlakyCommits is the number of
+// these commits, Flips is the number of times the result of the test changed from a run to the next one.
+type FlakyTest struct {
+	Suite        string     `json:"suite" db:"suite" cli:"suite,key"`
+	Name         string     `json:"name" db:"name" cli:"name,key"`
+	Runs         int64      `json:"runs" db:"runs" cli:"runs"`
+	Failures     int64      `json:"failures" db:"failures" cli:"failures"`
+	Flips        int64      `json:"flips" db:"flips" cli:"flips"`
+	FlakyCommits int64      `json:"flaky_commits" db:"flaky_commits" cli:"flaky_commits"`
+	LastFailure  *time.Time `json:"last_failure,omitempty" db:"last_failure" cli:"last_failure"`
+}
@@ -0,0 +1,47 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/ovh/venom"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorkflowRunTestResults(t *testing.T) {
+	nr := WorkflowNodeRun{ID: 3, WorkflowID: 1, WorkflowRunID: 2, Number: 42, VCSHash: "abcd", VCSBranch: "master"}
+	rs := NewWorkflowRunTestResults(nr, []venom.TestSuite{{
+		Name: "user",
+		TestCases: []venom.TestCase{
+			{Name: "TestLoad", Classname: "user.dao", Time: "1.5"},
+			{Name: "TestInsert", Failures: []venom.Failure{{Message: "fail"}}},
+			{Name: "TestDelete", Skipped: []venom.Skipped{{Value: "skip"}}},
+		},
+	}})
+	require.Len(t, rs, 3)
+	require.Equal(t, WorkflowRunTestResult{
+		WorkflowID: 1, WorkflowRunID: 2, WorkflowNodeRunID: 3, RunNumber: 42, VCSHash: "abcd", VCSBranch: "master",
+		Suite: "user", Name: "user.dao.TestLoad", Status: TestResultSuccess, Duration: 1.5,
+	}, rs[0])
+	require.Equal(t, TestResultFailure, rs[1].Status)
+	require.Equal(t, "TestInsert", rs[1].Name)
+	require.Equal(t, TestResultSkipped, rs[2].Status)
+}
+
+func TestSetFlakyTestHistory(t *testing.T) {
+	hs := []TestHistory{
+		{VCSHash: "a", Status: TestResultFailure},
+		{VCSHash: "a", Status: TestResultSuccess},
+		{VCSHash: "a", Status: TestResultSkipped},
+		{VCSHash: "b", Status: TestResultFailure},
+		{VCSHash: "c", Status: TestResultSuccess},
+		{VCSHash: "c", Status: TestResultSkipped},
+		{Status: TestResultFailure},
+		{Status: TestResultSuccess},
+	}
+	SetFlakyTestHistory(hs)
+	var flaky []bool
+	for _, h := range hs {
+		flaky = append(flaky, h.Flaky)
+	}
+	require.Equal(t, []bool{true, true, false, false, false, false, false, false}, flaky)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	json "encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -29,6 +30,20 @@ type AuthDriverWithSigninStateToken interface {
 	CheckSigninStateToken(AuthConsumerSigninRequest) error
 }
 
+// AuthDriverWithGroupSync is implemented by drivers that can synchronize user's groups on signin.
+// Only groups starting with the returned prefix will be managed by the synchronization.
+type AuthDriverWithGroupSync interface {
+	AuthDriver
+	GetGroupSyncPrefix() (string, bool)
+}
+
+// AuthDriverWithEmailTrust is implemented by drivers that can disable the linking of a new consumer
+// to an existing user that have the same email.
+type AuthDriverWithEmailTrust interface {
+	AuthDriver
+	IsEmailTrusted() bool
+}
+
 type AuthDriverSigningRedirect struct {
 	Method      string            `json:"method"`
 	URL         string            `json:"url"`
@@ -255,6 +270,7 @@ type AuthDriverUserInfo struct {
 	Fullname   string
 	Email      string
 	MFA        bool
+	Groups     []string
 }
 
 // AuthCurrentConsumerResponse describe the current consumer and the current session
@@ -274,6 +290,8 @@ const (
 	ConsumerCorporateSSO AuthConsumerType = "corporate-sso"
 	ConsumerGithub       AuthConsumerType = "github"
 	ConsumerGitlab       AuthConsumerType = "gitlab"
+	ConsumerOIDC         AuthConsumerType = "oidc"
+	ConsumerChatOps      AuthConsumerType = "chatops"
 	ConsumerTest         AuthConsumerType = "futurama"
 	ConsumerTest2        AuthConsumerType = "planet-express"
 )
@@ -290,7 +308,7 @@ func (t AuthConsumerType) IsValid() bool {
 // IsValidExternal returns validity of given auth consumer type.
 func (t AuthConsumerType) IsValidExternal() bool {
 	switch t {
-	case ConsumerLDAP, ConsumerCorporateSSO, ConsumerGithub, ConsumerGitlab, ConsumerTest, ConsumerTest2:
+	case ConsumerLDAP, ConsumerCorporateSSO, ConsumerGithub, ConsumerGitlab, ConsumerOIDC, ConsumerTest, ConsumerTest2:
 		return true
 	}
 	return false
@@ -299,6 +317,10 @@ func (t AuthConsumerType) IsValidExternal() bool {
 // AuthConsumerData contains specific information from the auth driver.
 type AuthConsumerData map[string]string
 
+// AuthConsumerDataWorkerJobRunID is the key of consumer data that contains the job run id
+// that a worker consumer is restricted to.
+const AuthConsumerDataWorkerJobRunID = "job_run_id"
+
 // Scan consumer data.
 func (d *AuthConsumerData) Scan(src interface{}) error {
 	source, ok := src.([]byte)
@@ -319,9 +341,10 @@ type AuthConsumerWarningType string
 
 // Consumer warning types.
 const (
-	WarningGroupInvalid     AuthConsumerWarningType = "group-invalid"
-	WarningGroupRemoved     AuthConsumerWarningType = "group-removed"
-	WarningLastGroupRemoved AuthConsumerWarningType = "last-group-removed"
+	WarningGroupInvalid      AuthConsumerWarningType = "group-invalid"
+	WarningGroupRemoved      AuthConsumerWarningType = "group-removed"
+	WarningLastGroupRemoved  AuthConsumerWarningType = "last-group-removed"
+	WarningUserDeprovisioned AuthConsumerWarningType = "user-deprovisioned"
 )
 
 // AuthConsumerWarnings contains specific information from the auth driver.
@@ -350,6 +373,11 @@ func NewConsumerWarningLastGroupRemoved() AuthConsumerWarning {
 	return AuthConsumerWarning{Type: WarningLastGroupRemoved}
 }
 
+// NewConsumerWarningUserDeprovisioned returns a new warning.
+func NewConsumerWarningUserDeprovisioned() AuthConsumerWarning {
+	return AuthConsumerWarning{Type: WarningUserDeprovisioned}
+}
+
 // AuthConsumerWarning contains info about a warning.
 type AuthConsumerWarning struct {
 	Type      AuthConsumerWarningType `json:"type"`
@@ -391,6 +419,8 @@ type AuthConsumer struct {
 	IssuedAt           time.Time                `json:"issued_at" cli:"issued_at" db:"issued_at"`
 	Disabled           bool                     `json:"disabled" cli:"disabled" db:"disabled"`
 	Warnings           AuthConsumerWarnings     `json:"warnings,omitempty" db:"warnings"`
+	ProjectKey         string                   `json:"project_key,omitempty" cli:"project_key" db:"project_key"`
+	ExpireAt           *time.Time               `json:"expire_at,omitempty" cli:"expire_at" db:"expire_at"`
 	// aggregates
 	AuthentifiedUser *AuthentifiedUser `json:"user,omitempty" db:"-"`
 	Groups           Groups            `json:"groups,omitempty" db:"-"`
@@ -448,6 +478,11 @@ func (c AuthConsumer) GetGroupIDs() []int64 {
 	return groupIDs
 }
 
+// Expired returns true if an expiration date was set for the consumer and is passed.
+func (c AuthConsumer) Expired() bool {
+	return c.ExpireAt != nil && c.ExpireAt.Before(time.Now())
+}
+
 func (c AuthConsumer) Admin() bool {
 	return c.AuthentifiedUser.Ring == UserRingAdmin
 }
@@ -477,6 +512,13 @@ func (c AuthConsumer) GetFullname() string {
 	return c.AuthentifiedUser.GetFullname()
 }
 
+// WorkerJobRunID returns the id of the job run that a worker consumer is scoped to,
+// or 0 if the consumer is not restricted to a single job.
+func (c AuthConsumer) WorkerJobRunID() int64 {
+	id, _ := strconv.ParseInt(c.Data[AuthConsumerDataWorkerJobRunID], 10, 64)
+	return id
+}
+
 // AuthSessions gives functions for auth session slice.
 type AuthSessions []AuthSession
 
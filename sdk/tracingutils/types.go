@@ -1,9 +1,30 @@
 package tracingutils
 
 import (
-	"go.opencensus.io/plugin/ochttp/propagation/b3"
-	"go.opencensus.io/trace/propagation"
+	"go.opentelemetry.io/otel/propagation"
 )
 
-// DefaultFormat used by observability as: observability.DefaultFormat.SpanContextToRequest
-var DefaultFormat propagation.HTTPFormat = &b3.HTTPFormat{}
+// DefaultFormat propagates the W3C trace context, ie. DefaultFormat.Inject(ctx, propagation.HeaderCarrier(req.Header))
+var DefaultFormat propagation.TextMapPropagator = propagation.TraceContext{}
+
+// MapCarrier is a propagation.TextMapCarrier for the headers of the workflow runs and of the jobs.
+type MapCarrier map[string]string
+
+// Get returns the value of a key.
+func (c MapCarrier) Get(key string) string {
+	return c[key]
+}
+
+// Set sets a key.
+func (c MapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys lists the keys of the carrier.
+func (c MapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
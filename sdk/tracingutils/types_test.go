@@ -0,0 +1,33 @@
+package tracingutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMapCarrier(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	headers := map[string]string{"Cds-Workflow": "my-workflow"}
+	DefaultFormat.Inject(trace.ContextWithSpanContext(context.Background(), sc), MapCarrier(headers))
+	require.Equal(t, "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01", headers[TraceParentHeader])
+	require.Equal(t, "my-workflow", headers["Cds-Workflow"])
+
+	ctx := DefaultFormat.Extract(context.Background(), MapCarrier(headers))
+	res := trace.SpanContextFromContext(ctx)
+	require.True(t, res.IsRemote())
+	require.Equal(t, sc.TraceID(), res.TraceID())
+	require.Equal(t, sc.SpanID(), res.SpanID())
+	require.True(t, res.IsSampled())
+
+	// without trace context, the context is unchanged
+	ctx = DefaultFormat.Extract(context.Background(), MapCarrier(map[string]string{}))
+	require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
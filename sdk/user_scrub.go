@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"time"
+)
+
+// UserScrubRequest contains personal identifiers of a deleted user that should be pseudonymized.
+type UserScrubRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+	Fullname string `json:"fullname,omitempty"`
+}
+
+// IsValid returns an error if given scrub request is not valid.
+func (r UserScrubRequest) IsValid() error {
+	if r.Username == "" || r.Username == "me" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given username")
+	}
+	return nil
+}
+
+// NewUserPseudonym returns a random pseudonym used to replace a deleted user's identifiers.
+func NewUserPseudonym() string {
+	return "deleted-user-" + RandomString(10)
+}
+
+// UserPseudonymEmail returns the email used to replace a deleted user's email.
+func UserPseudonymEmail(pseudonym string) string {
+	return pseudonym + "@deleted.invalid"
+}
+
+// UserScrubReport is the completion report of a user scrubbing.
+type UserScrubReport struct {
+	Username  string                 `json:"username" cli:"username"`
+	Pseudonym string                 `json:"pseudonym" cli:"pseudonym"`
+	Started   time.Time              `json:"started" cli:"started"`
+	Done      time.Time              `json:"done" cli:"done"`
+	Stores    []UserScrubStoreReport `json:"stores"`
+}
+
+// UserScrubStoreReport contains the count of scrubbed items for a store.
+type UserScrubStoreReport struct {
+	Store string `json:"store" cli:"store,key"`
+	Count int64  `json:"count" cli:"count"`
+	Error string `json:"error,omitempty" cli:"error"`
+}
+
+// HasErrors returns true if scrubbing failed for at least one store.
+func (r UserScrubReport) HasErrors() bool {
+	for _, s := range r.Stores {
+		if s.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// EventScrubRequest is sent to the elasticsearch service to pseudonymize a deleted user's events.
+type EventScrubRequest struct {
+	Username       string `json:"username"`
+	Email          string `json:"email,omitempty"`
+	Pseudonym      string `json:"pseudonym"`
+	EmailPseudonym string `json:"email_pseudonym,omitempty"`
+}
+
+// EventScrubResponse contains the count of events updated by the elasticsearch service.
+type EventScrubResponse struct {
+	Updated int64 `json:"updated"`
+}
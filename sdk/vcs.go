@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -14,6 +17,34 @@ const (
 	HeaderXAccessTokenSecret  = "X-CDS-ACCESS-TOKEN-SECRET"
 )
 
+// PlainGitVCSServer is the vcs server name set on the hooks of an application linked to a raw git repository.
+const PlainGitVCSServer = "git"
+
+var scpLikeGitURLRegex = regexp.MustCompile(`^[\w.-]+@[\w.-]+:[^\s]+$`)
+
+// IsValidGitURL returns true if given url is an https or ssh git url (ex: https://host/group/repo.git, ssh://git@host/group/repo.git, git@host:group/repo.git).
+func IsValidGitURL(s string) bool {
+	if scpLikeGitURLRegex.MatchString(s) {
+		return true
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return false
+	}
+	return u.Scheme == "https" || u.Scheme == "ssh"
+}
+
+// GitRepositoryFullnameFromURL returns the path of the repository for given git url without the .git suffix (ex: group/repo).
+func GitRepositoryFullnameFromURL(s string) string {
+	var path string
+	if scpLikeGitURLRegex.MatchString(s) {
+		path = s[strings.Index(s, ":")+1:]
+	} else if u, err := url.Parse(s); err == nil {
+		path = u.Path
+	}
+	return strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+}
+
 // BuildNumberAndHash represents BuildNumber, Commit Hash and Branch for a Pipeline Build or Node Run
 type BuildNumberAndHash struct {
 	BuildNumber int64
@@ -0,0 +1,47 @@
+package git
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Ref represents a reference of a remote repository
+type Ref struct {
+	Name string
+	Hash string
+}
+
+// LsRemote lists the branches and the tags of a remote repository without cloning it
+func LsRemote(repo string, auth *AuthOpts) ([]Ref, error) {
+	repoURL, err := getRepoURL(repo, auth)
+	if err != nil {
+		return nil, err
+	}
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	if err := runGitCommands(repo, prepareGitLsRemoteCommands(repoURL), auth, &OutputOpts{Stdout: stdout, Stderr: stderr}); err != nil {
+		return nil, sdk.WrapError(err, "unable to list references: %s", stderr.String())
+	}
+	return parseLsRemote(stdout.String()), nil
+}
+
+func prepareGitLsRemoteCommands(repoURL string) cmds {
+	return cmds{{
+		cmd:  "git",
+		args: []string{"ls-remote", "--heads", "--tags", "--refs", repoURL},
+	}}
+}
+
+func parseLsRemote(out string) []Ref {
+	var refs []Ref
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		refs = append(refs, Ref{Hash: fields[0], Name: fields[1]})
+	}
+	return refs
+}
@@ -236,3 +236,21 @@ func Test_gitCommand(t *testing.T) {
 		}
 	}
 }
+
+func Test_parseLsRemote(t *testing.T) {
+	out := `eb8b87a6b3c6a5b7d0ea0f1bd6f0c5d0b2a9c001	refs/heads/master
+1f6e8c5e2a9e6f4a8ab9c6d8d3c0a0f6a2e4d002	refs/heads/feat/my-feature
+7c3a2a1b0f9e8d7c6b5a4f3e2d1c0b9a8f7e6003	refs/tags/v1.0.0
+`
+	want := []Ref{
+		{Name: "refs/heads/master", Hash: "eb8b87a6b3c6a5b7d0ea0f1bd6f0c5d0b2a9c001"},
+		{Name: "refs/heads/feat/my-feature", Hash: "1f6e8c5e2a9e6f4a8ab9c6d8d3c0a0f6a2e4d002"},
+		{Name: "refs/tags/v1.0.0", Hash: "7c3a2a1b0f9e8d7c6b5a4f3e2d1c0b9a8f7e6003"},
+	}
+	if got := parseLsRemote(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLsRemote() = %v, want %v", got, want)
+	}
+	if got := prepareGitLsRemoteCommands("https://github.com/ovh/cds.git").Strings(); !reflect.DeepEqual(got, []string{"git ls-remote --heads --tags --refs https://github.com/ovh/cds.git"}) {
+		t.Errorf("prepareGitLsRemoteCommands() = %v", got)
+	}
+}
@@ -0,0 +1,24 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitURL(t *testing.T) {
+	cases := map[string]string{
+		"https://git.example.com/group/repo.git":        "group/repo",
+		"https://git.example.com/scm/group/repo":        "scm/group/repo",
+		"ssh://git@git.example.com:7999/group/repo.git": "group/repo",
+		"git@git.example.com:group/repo.git":            "group/repo",
+	}
+	for u, fullname := range cases {
+		assert.True(t, IsValidGitURL(u), u)
+		assert.Equal(t, fullname, GitRepositoryFullnameFromURL(u))
+	}
+
+	for _, u := range []string{"", "http://git.example.com/group/repo.git", "https://git.example.com", "/tmp/repo", "git.example.com/group/repo"} {
+		assert.False(t, IsValidGitURL(u), u)
+	}
+}
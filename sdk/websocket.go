@@ -0,0 +1,116 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Websocket message types, sent by the clients to manage their subscriptions.
+const (
+	WebsocketMessageSubscribe   = "subscribe"
+	WebsocketMessageUnsubscribe = "unsubscribe"
+)
+
+// Websocket filter types.
+const (
+	WebsocketFilterTypeWorkflowRun = "workflow-run"
+	WebsocketFilterTypeQueue       = "queue"
+	WebsocketFilterTypeAsCodeEvent = "ascode-event"
+)
+
+// WebsocketFilter selects the events sent to a websocket client:
+//   - workflow-run: the events of the runs of a workflow, or of a run if a number is given.
+//   - queue: the events of the jobs executable by given groups, by all the groups of the consumer if none given.
+//   - ascode-event: the as code events of a project.
+type WebsocketFilter struct {
+	Type              string  `json:"type"`
+	ProjectKey        string  `json:"project_key,omitempty"`
+	WorkflowName      string  `json:"workflow_name,omitempty"`
+	WorkflowRunNumber int64   `json:"workflow_run_num,omitempty"`
+	GroupIDs          []int64 `json:"group_ids,omitempty"`
+}
+
+// IsValid returns an error if the filter misses the fields required by its type.
+func (f WebsocketFilter) IsValid() error {
+	switch f.Type {
+	case WebsocketFilterTypeWorkflowRun:
+		if f.ProjectKey == "" || f.WorkflowName == "" {
+			return NewErrorFrom(ErrWrongRequest, "project key and workflow name are required for filter %s", f.Type)
+		}
+	case WebsocketFilterTypeQueue:
+	case WebsocketFilterTypeAsCodeEvent:
+		if f.ProjectKey == "" {
+			return NewErrorFrom(ErrWrongRequest, "project key is required for filter %s", f.Type)
+		}
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid filter type %q", f.Type)
+	}
+	return nil
+}
+
+// Key identifies the filter in the subscriptions of a client.
+func (f WebsocketFilter) Key() string {
+	groups := make([]string, len(f.GroupIDs))
+	for i := range f.GroupIDs {
+		groups[i] = fmt.Sprintf("%d", f.GroupIDs[i])
+	}
+	return fmt.Sprintf("%s/%s/%s/%d/%s", f.Type, f.ProjectKey, f.WorkflowName, f.WorkflowRunNumber, strings.Join(groups, ","))
+}
+
+// Match returns true if the event is selected by the filter.
+func (f WebsocketFilter) Match(e Event) bool {
+	switch f.Type {
+	case WebsocketFilterTypeWorkflowRun:
+		return strings.HasPrefix(e.EventType, "sdk.EventRunWorkflow") &&
+			e.ProjectKey == f.ProjectKey && e.WorkflowName == f.WorkflowName &&
+			(f.WorkflowRunNumber == 0 || e.WorkflowRunNum == f.WorkflowRunNumber)
+	case WebsocketFilterTypeQueue:
+		if e.EventType != "sdk.EventRunWorkflowJob" {
+			return false
+		}
+		if len(f.GroupIDs) == 0 {
+			return true
+		}
+		for _, id := range eventPayloadInt64s(e.Payload["ExecGroupIDs"]) {
+			for _, groupID := range f.GroupIDs {
+				if id == groupID {
+					return true
+				}
+			}
+		}
+		return false
+	case WebsocketFilterTypeAsCodeEvent:
+		return e.EventType == "sdk.EventAsCodeEvent" && e.ProjectKey == f.ProjectKey
+	}
+	return false
+}
+
+// eventPayloadInt64s returns the integers of a payload value, which are float64 once the event was decoded from JSON.
+func eventPayloadInt64s(v interface{}) []int64 {
+	switch vs := v.(type) {
+	case []int64:
+		return vs
+	case []interface{}:
+		res := make([]int64, 0, len(vs))
+		for _, i := range vs {
+			if f, ok := i.(float64); ok {
+				res = append(res, int64(f))
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+// WebsocketMessage is sent by a websocket client to subscribe to the events selected by a filter, or unsubscribe.
+type WebsocketMessage struct {
+	Type   string          `json:"type"`
+	Filter WebsocketFilter `json:"filter"`
+}
+
+// WebsocketEvent is sent to a websocket client: an event, or the error of one of its messages.
+type WebsocketEvent struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Event  Event  `json:"event"`
+}
@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsocketFilter(t *testing.T) {
+	assert.Error(t, WebsocketFilter{Type: "unknown"}.IsValid())
+	assert.Error(t, WebsocketFilter{Type: WebsocketFilterTypeWorkflowRun, ProjectKey: "PROJ"}.IsValid())
+	assert.Error(t, WebsocketFilter{Type: WebsocketFilterTypeAsCodeEvent}.IsValid())
+	assert.NoError(t, WebsocketFilter{Type: WebsocketFilterTypeQueue}.IsValid())
+
+	runEvent := Event{EventType: "sdk.EventRunWorkflowNode", ProjectKey: "PROJ", WorkflowName: "build", WorkflowRunNum: 12}
+	assert.True(t, WebsocketFilter{Type: WebsocketFilterTypeWorkflowRun, ProjectKey: "PROJ", WorkflowName: "build"}.Match(runEvent))
+	assert.True(t, WebsocketFilter{Type: WebsocketFilterTypeWorkflowRun, ProjectKey: "PROJ", WorkflowName: "build", WorkflowRunNumber: 12}.Match(runEvent))
+	assert.False(t, WebsocketFilter{Type: WebsocketFilterTypeWorkflowRun, ProjectKey: "PROJ", WorkflowName: "build", WorkflowRunNumber: 13}.Match(runEvent))
+	assert.False(t, WebsocketFilter{Type: WebsocketFilterTypeWorkflowRun, ProjectKey: "PROJ", WorkflowName: "deploy"}.Match(runEvent))
+	assert.False(t, WebsocketFilter{Type: WebsocketFilterTypeQueue}.Match(runEvent))
+
+	// the events are decoded from JSON by the API
+	b, err := json.Marshal(Event{EventType: "sdk.EventRunWorkflowJob", Payload: map[string]interface{}{"ExecGroupIDs": []int64{1, 2}}})
+	require.NoError(t, err)
+	var jobEvent Event
+	require.NoError(t, json.Unmarshal(b, &jobEvent))
+	assert.True(t, WebsocketFilter{Type: WebsocketFilterTypeQueue}.Match(jobEvent))
+	assert.True(t, WebsocketFilter{Type: WebsocketFilterTypeQueue, GroupIDs: []int64{2, 3}}.Match(jobEvent))
+	assert.False(t, WebsocketFilter{Type: WebsocketFilterTypeQueue, GroupIDs: []int64{3}}.Match(jobEvent))
+
+	asCodeEvent := Event{EventType: "sdk.EventAsCodeEvent", ProjectKey: "PROJ"}
+	assert.True(t, WebsocketFilter{Type: WebsocketFilterTypeAsCodeEvent, ProjectKey: "PROJ"}.Match(asCodeEvent))
+	assert.False(t, WebsocketFilter{Type: WebsocketFilterTypeAsCodeEvent, ProjectKey: "OTHER"}.Match(asCodeEvent))
+}
@@ -28,6 +28,8 @@ type WorkerRegistrationForm struct {
 	Version            string
 	OS                 string
 	Arch               string
+	// Set by workers that rotate their session, they get short-lived sessions
+	SessionRotation bool
 }
 
 // SpawnErrorForm represents the arguments needed to add error registration on worker model
@@ -53,6 +55,7 @@ type WorkerArgs struct {
 	GraylogPort       int    `json:"graylog_port"`
 	GraylogExtraKey   string `json:"graylog_extra_key"`
 	GraylogExtraValue string `json:"graylog_extra_value"`
+	ToolRegistry      string `json:"tool_registry"`
 	WorkerBinary      string
 }
 
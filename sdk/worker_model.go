@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -11,6 +12,17 @@ const (
 	HostProcess = "host"
 	Openstack   = "openstack"
 	VSphere     = "vsphere"
+	EC2         = "ec2"
+	GCE         = "gce"
+)
+
+// Operating systems and isolation modes of docker worker models
+const (
+	ModelDockerOSLinux   = "linux"
+	ModelDockerOSWindows = "windows"
+
+	ModelDockerIsolationProcess = "process"
+	ModelDockerIsolationHyperV  = "hyperv"
 )
 
 // WorkerModelValidate returns if given strings are valid worker model type.
@@ -30,6 +42,8 @@ var (
 		string(HostProcess),
 		string(Openstack),
 		string(VSphere),
+		string(EC2),
+		string(GCE),
 	}
 )
 
@@ -106,12 +120,29 @@ func (m Model) IsValid() error {
 func (m Model) IsValidType() error {
 	switch m.Type {
 	case Docker:
+		if m.ModelDocker.Arch != "" && !IsSupportedArch(m.ModelDocker.Arch) {
+			return NewErrorFrom(ErrWrongRequest, "invalid worker model architecture %s", m.ModelDocker.Arch)
+		}
 		if m.ModelDocker.Image == "" {
 			return NewErrorFrom(ErrWrongRequest, "invalid worker model image")
 		}
 		if m.PatternName == "" && (m.ModelDocker.Cmd == "" || m.ModelDocker.Shell == "") {
 			return WrapError(ErrWrongRequest, "invalid worker model command or shell command")
 		}
+		switch m.ModelDocker.OS {
+		case "", ModelDockerOSLinux, ModelDockerOSWindows:
+		default:
+			return NewErrorFrom(ErrWrongRequest, "invalid worker model os %s", m.ModelDocker.OS)
+		}
+		switch m.ModelDocker.Isolation {
+		case "":
+		case ModelDockerIsolationProcess, ModelDockerIsolationHyperV:
+			if m.ModelDocker.OS != ModelDockerOSWindows {
+				return NewErrorFrom(ErrWrongRequest, "isolation mode is only available for windows worker models")
+			}
+		default:
+			return NewErrorFrom(ErrWrongRequest, "invalid worker model isolation mode %s", m.ModelDocker.Isolation)
+		}
 	case Openstack:
 		if m.ModelVirtualMachine.Image == "" {
 			return WrapError(ErrWrongRequest, "invalid worker model image")
@@ -122,6 +153,26 @@ func (m Model) IsValidType() error {
 		if m.PatternName == "" && m.ModelVirtualMachine.Cmd == "" {
 			return WrapError(ErrWrongRequest, "invalid worker model command")
 		}
+	case EC2:
+		if m.ModelVirtualMachine.Image == "" {
+			return WrapError(ErrWrongRequest, "invalid worker model image")
+		}
+		if m.ModelVirtualMachine.Flavor == "" {
+			return WrapError(ErrWrongRequest, "invalid worker model instance type")
+		}
+		if m.PatternName == "" && m.ModelVirtualMachine.Cmd == "" {
+			return WrapError(ErrWrongRequest, "invalid worker model command")
+		}
+	case GCE:
+		if m.ModelVirtualMachine.Image == "" {
+			return WrapError(ErrWrongRequest, "invalid worker model image family")
+		}
+		if m.ModelVirtualMachine.Flavor == "" {
+			return WrapError(ErrWrongRequest, "invalid worker model machine type")
+		}
+		if m.PatternName == "" && m.ModelVirtualMachine.Cmd == "" {
+			return WrapError(ErrWrongRequest, "invalid worker model command")
+		}
 	case VSphere:
 		if m.ModelVirtualMachine.Image == "" {
 			return WrapError(ErrWrongRequest, "invalid worker model image")
@@ -132,9 +183,59 @@ func (m Model) IsValidType() error {
 	default:
 		return NewErrorFrom(ErrWrongRequest, "invalid worker model type")
 	}
+	if m.Type != Docker && m.ModelVirtualMachine.Arch != "" && !IsSupportedArch(m.ModelVirtualMachine.Arch) {
+		return NewErrorFrom(ErrWrongRequest, "invalid worker model architecture %s", m.ModelVirtualMachine.Arch)
+	}
 	return nil
 }
 
+// DeclaredArch returns the architecture set on the worker model, if any.
+func (m Model) DeclaredArch() string {
+	switch m.Type {
+	case Docker:
+		return m.ModelDocker.Arch
+	case Openstack, VSphere, EC2, GCE:
+		return m.ModelVirtualMachine.Arch
+	}
+	return ""
+}
+
+// GetArch returns the architecture of the worker model, the declared one
+// or the one given by the worker at registration. Empty if unknown.
+func (m Model) GetArch() string {
+	if arch := m.DeclaredArch(); arch != "" {
+		return arch
+	}
+	return m.RegisteredArch
+}
+
+// GetOS returns the operating system of the worker model, the one given by the worker
+// at registration or the declared one for docker models. Empty if unknown.
+func (m Model) GetOS() string {
+	if m.RegisteredOS != "" {
+		return m.RegisteredOS
+	}
+	if m.Type == Docker {
+		return m.ModelDocker.GetOS()
+	}
+	return ""
+}
+
+// MatchOSArch returns true if the worker model can run a job with given os-architecture requirement value.
+func (m Model) MatchOSArch(osArch string) bool {
+	t := strings.Split(osArch, "/")
+	if len(t) != 2 {
+		return false
+	}
+	if os := m.GetOS(); os != "" && os != t[0] {
+		return false
+	}
+	if arch := m.GetArch(); arch != "" && arch != t[1] {
+		return false
+	}
+	return true
+}
+
 // GetPath returns path for model.
 func (m Model) GetPath(groupName string) string {
 	if groupName == SharedInfraGroupName {
@@ -143,13 +244,21 @@ func (m Model) GetPath(groupName string) string {
 	return fmt.Sprintf("%s/%s", groupName, m.Name)
 }
 
-// ModelVirtualMachine for openstack or vsphere
+// ModelVirtualMachine for openstack, vsphere, ec2 or gce
 type ModelVirtualMachine struct {
 	Image   string `json:"image,omitempty"`
 	Flavor  string `json:"flavor,omitempty"`
 	PreCmd  string `json:"pre_cmd,omitempty"`
 	Cmd     string `json:"cmd,omitempty"`
 	PostCmd string `json:"post_cmd,omitempty"`
+	// Subnet, SecurityGroups and Spot are only used by ec2 worker models
+	Subnet         string   `json:"subnet,omitempty"`
+	SecurityGroups []string `json:"security_groups,omitempty"`
+	Spot           bool     `json:"spot,omitempty"`
+	// Preemptible is only used by gce worker models
+	Preemptible bool `json:"preemptible,omitempty"`
+	// Arch is the architecture of the image (amd64, arm64...)
+	Arch string `json:"arch,omitempty"`
 }
 
 // ModelDocker for swarm, marathon and kubernetes
@@ -163,6 +272,20 @@ type ModelDocker struct {
 	Envs     map[string]string `json:"envs,omitempty"`
 	Shell    string            `json:"shell,omitempty"`
 	Cmd      string            `json:"cmd,omitempty"`
+	// OS is the operating system of the image, linux if not set
+	OS string `json:"os,omitempty"`
+	// Isolation is the isolation mode of windows containers: process or hyperv
+	Isolation string `json:"isolation,omitempty"`
+	// Arch is the architecture of the image (amd64, arm64...), multi-arch images can run on any node if not set
+	Arch string `json:"arch,omitempty"`
+}
+
+// GetOS returns the operating system of the docker image, linux by default.
+func (m ModelDocker) GetOS() string {
+	if m.OS == "" {
+		return ModelDockerOSLinux
+	}
+	return m.OS
 }
 
 // ModelPattern represent patterns for users and admin when creating a worker model
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelMatchOSArch(t *testing.T) {
+	docker := Model{Type: Docker, ModelDocker: ModelDocker{Arch: "arm64"}}
+	assert.True(t, docker.MatchOSArch("linux/arm64"))
+	assert.False(t, docker.MatchOSArch("linux/amd64"))
+	assert.False(t, docker.MatchOSArch("windows/arm64"))
+
+	// Multi-arch image, only the os is known
+	multiArch := Model{Type: Docker}
+	assert.True(t, multiArch.MatchOSArch("linux/arm64"))
+	assert.True(t, multiArch.MatchOSArch("linux/amd64"))
+
+	// Unknown os and arch for a virtual machine model until its registration
+	vm := Model{Type: Openstack}
+	assert.True(t, vm.MatchOSArch("freebsd/amd64"))
+	vm.RegisteredOS, vm.RegisteredArch = "linux", "amd64"
+	assert.False(t, vm.MatchOSArch("freebsd/amd64"))
+	assert.True(t, vm.MatchOSArch("linux/amd64"))
+	vm.ModelVirtualMachine.Arch = "arm64"
+	assert.False(t, vm.MatchOSArch("linux/amd64"))
+
+	assert.False(t, docker.MatchOSArch("arm64"))
+}
+
+func TestGetArchName(t *testing.T) {
+	assert.Equal(t, "amd64", GetArchName("x86_64"))
+	assert.Equal(t, "arm64", GetArchName("aarch64"))
+	assert.Equal(t, "arm", GetArchName("armv7l"))
+	assert.Equal(t, "ppc64le", GetArchName("ppc64le"))
+	assert.Equal(t, "cds-worker-linux-arm64", GetArtifactFilename("worker", "linux", "aarch64", ""))
+}
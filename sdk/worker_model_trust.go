@@ -0,0 +1,116 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk/cosign"
+)
+
+// Worker model trust policy modes.
+const (
+	// WorkerModelTrustModeAll requires all the configured checks to succeed.
+	WorkerModelTrustModeAll = "all"
+	// WorkerModelTrustModeAny requires at least one of the configured checks to succeed.
+	WorkerModelTrustModeAny = "any"
+)
+
+// WorkerModelTrustPolicy restricts the images of container worker models to allowlisted registries and/or to images
+// signed with cosign. It is set by CDS administrators, checked when a worker model is saved and by hatcheries
+// before spawning a worker.
+type WorkerModelTrustPolicy struct {
+	Enabled           bool      `json:"enabled" cli:"enabled"`
+	Mode              string    `json:"mode" cli:"mode"`
+	AllowedRegistries []string  `json:"allowed_registries,omitempty" cli:"allowed_registries"`
+	RequireSignature  bool      `json:"require_signature" cli:"require_signature"`
+	PublicKeys        []string  `json:"public_keys,omitempty"`
+	Modified          time.Time `json:"modified" cli:"modified"`
+}
+
+// IsValid returns an error if the policy is not valid.
+func (p WorkerModelTrustPolicy) IsValid() error {
+	switch p.Mode {
+	case WorkerModelTrustModeAll, WorkerModelTrustModeAny:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid trust policy mode %q", p.Mode)
+	}
+	if p.Enabled && len(p.AllowedRegistries) == 0 && !p.RequireSignature {
+		return NewErrorFrom(ErrWrongRequest, "trust policy requires allowed registries or signatures")
+	}
+	for _, r := range p.AllowedRegistries {
+		if strings.TrimSpace(r) == "" || strings.Contains(r, "://") {
+			return NewErrorFrom(ErrWrongRequest, "invalid allowed registry %q, should be like registry.example.com/team", r)
+		}
+	}
+	if p.RequireSignature && len(p.PublicKeys) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "trust policy requires public keys to verify signatures")
+	}
+	for _, k := range p.PublicKeys {
+		if _, err := cosign.ParsePublicKey(k); err != nil {
+			return NewErrorFrom(ErrWrongRequest, "invalid trust policy public key: %v", err)
+		}
+	}
+	return nil
+}
+
+// ImageVerifier checks the signature of an image and returns its verified digest.
+type ImageVerifier interface {
+	Verify(ctx context.Context, image string, creds *cosign.Credentials) (string, error)
+}
+
+// CheckModel returns an ErrWorkerModelUntrusted error if the image of given container worker model is not
+// allowed by the policy. Virtual machine models are not concerned by the policy.
+func (p WorkerModelTrustPolicy) CheckModel(ctx context.Context, m Model, verifier ImageVerifier) error {
+	if !p.Enabled || m.Type != Docker {
+		return nil
+	}
+
+	ref, err := cosign.ParseReference(m.ModelDocker.Image)
+	if err != nil {
+		return NewErrorFrom(ErrWorkerModelUntrusted, "%v", err)
+	}
+
+	var reasons []string
+	var allowed, checked int
+
+	if len(p.AllowedRegistries) > 0 {
+		checked++
+		var match bool
+		for _, r := range p.AllowedRegistries {
+			if ref.MatchPrefix(r) {
+				match = true
+				break
+			}
+		}
+		if match {
+			allowed++
+		} else {
+			reasons = append(reasons, fmt.Sprintf("registry of image %s is not allowed", ref.Name()))
+		}
+	}
+
+	if p.RequireSignature && (p.Mode == WorkerModelTrustModeAll || allowed == 0) {
+		checked++
+		var creds *cosign.Credentials
+		if m.ModelDocker.Private {
+			creds = &cosign.Credentials{Username: m.ModelDocker.Username, Password: m.ModelDocker.Password}
+		}
+		if verifier == nil {
+			reasons = append(reasons, "no verifier for signatures")
+		} else if _, err := verifier.Verify(ctx, ref.String(), creds); err != nil {
+			reasons = append(reasons, err.Error())
+		} else {
+			allowed++
+		}
+	}
+
+	switch {
+	case p.Mode == WorkerModelTrustModeAny && allowed > 0:
+		return nil
+	case p.Mode == WorkerModelTrustModeAll && allowed == checked:
+		return nil
+	}
+	return NewErrorFrom(ErrWorkerModelUntrusted, "image %s of worker model %s is not trusted: %s", m.ModelDocker.Image, m.Name, strings.Join(reasons, ", "))
+}
@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk/cosign"
+)
+
+type fakeImageVerifier map[string]bool
+
+func (f fakeImageVerifier) Verify(ctx context.Context, image string, creds *cosign.Credentials) (string, error) {
+	if f[image] {
+		return "sha256:1234", nil
+	}
+	return "", fmt.Errorf("no signature found for image %s", image)
+}
+
+func TestWorkerModelTrustPolicyCheckModel(t *testing.T) {
+	verifier := fakeImageVerifier{"registry.example.com/team/signed:latest": true, "docker.io/library/signed:latest": true}
+	model := func(image string) Model {
+		return Model{Name: "my-model", Type: Docker, ModelDocker: ModelDocker{Image: image}}
+	}
+
+	disabled := WorkerModelTrustPolicy{Mode: WorkerModelTrustModeAll, AllowedRegistries: []string{"registry.example.com"}}
+	assert.NoError(t, disabled.CheckModel(context.TODO(), model("ubuntu"), verifier))
+
+	allowlist := WorkerModelTrustPolicy{Enabled: true, Mode: WorkerModelTrustModeAll, AllowedRegistries: []string{"registry.example.com/team"}}
+	assert.NoError(t, allowlist.CheckModel(context.TODO(), model("registry.example.com/team/image:1.0"), verifier))
+	err := allowlist.CheckModel(context.TODO(), model("ubuntu"), verifier)
+	assert.True(t, ErrorIs(err, ErrWorkerModelUntrusted))
+	assert.NoError(t, allowlist.CheckModel(context.TODO(), Model{Type: Openstack}, verifier), "vm models are not concerned")
+
+	all := WorkerModelTrustPolicy{Enabled: true, Mode: WorkerModelTrustModeAll, AllowedRegistries: []string{"registry.example.com"}, RequireSignature: true}
+	assert.NoError(t, all.CheckModel(context.TODO(), model("registry.example.com/team/signed"), verifier))
+	assert.Error(t, all.CheckModel(context.TODO(), model("registry.example.com/team/unsigned"), verifier))
+	assert.Error(t, all.CheckModel(context.TODO(), model("signed"), verifier))
+
+	anyPolicy := all
+	anyPolicy.Mode = WorkerModelTrustModeAny
+	assert.NoError(t, anyPolicy.CheckModel(context.TODO(), model("registry.example.com/team/unsigned"), verifier))
+	assert.NoError(t, anyPolicy.CheckModel(context.TODO(), model("signed"), verifier))
+	assert.Error(t, anyPolicy.CheckModel(context.TODO(), model("unsigned"), verifier))
+}
+
+func TestWorkerModelTrustPolicyIsValid(t *testing.T) {
+	assert.NoError(t, WorkerModelTrustPolicy{Mode: WorkerModelTrustModeAll}.IsValid())
+	assert.Error(t, WorkerModelTrustPolicy{Mode: "sometimes"}.IsValid())
+	assert.Error(t, WorkerModelTrustPolicy{Enabled: true, Mode: WorkerModelTrustModeAll}.IsValid())
+	assert.Error(t, WorkerModelTrustPolicy{Enabled: true, Mode: WorkerModelTrustModeAll, AllowedRegistries: []string{"https://registry.example.com"}}.IsValid())
+	assert.Error(t, WorkerModelTrustPolicy{Enabled: true, Mode: WorkerModelTrustModeAll, RequireSignature: true}.IsValid())
+	assert.Error(t, WorkerModelTrustPolicy{Enabled: true, Mode: WorkerModelTrustModeAll, RequireSignature: true, PublicKeys: []string{"invalid"}}.IsValid())
+}
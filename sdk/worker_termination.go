@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var memoryUnits = []string{"Ki", "Mi", "Gi", "Ti"}
+
+// FormatMemory returns a human readable memory quantity with binary units (ex: 2Gi, 2.4Gi), or "unknown" for non positive values.
+func FormatMemory(bytes int64) string {
+	if bytes <= 0 {
+		return "unknown"
+	}
+	if bytes < 1024 {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	v := float64(bytes)
+	var unit string
+	for _, u := range memoryUnits {
+		v = v / 1024
+		unit = u
+		if v < 1024 {
+			break
+		}
+	}
+	return strings.TrimSuffix(strconv.FormatFloat(v, 'f', 1, 64), ".0") + unit
+}
+
+// WorkerOutOfMemoryMessage returns the reason displayed on a job killed because it ran out of memory.
+func WorkerOutOfMemoryMessage(limit, peak int64) string {
+	return fmt.Sprintf("job killed: out of memory (limit %s, peak %s), increase the %q requirement of the job", FormatMemory(limit), FormatMemory(peak), MemoryRequirement)
+}
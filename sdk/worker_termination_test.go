@@ -0,0 +1,16 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMemory(t *testing.T) {
+	assert.Equal(t, "unknown", FormatMemory(0))
+	assert.Equal(t, "512B", FormatMemory(512))
+	assert.Equal(t, "2Gi", FormatMemory(2*1024*1024*1024))
+	assert.Equal(t, "2.4Gi", FormatMemory(2577000000))
+	assert.Equal(t, "512Mi", FormatMemory(512*1024*1024))
+	assert.Equal(t, "job killed: out of memory (limit 2Gi, peak 2.4Gi), increase the \"memory\" requirement of the job", WorkerOutOfMemoryMessage(2*1024*1024*1024, 2577000000))
+}
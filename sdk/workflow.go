@@ -33,6 +33,9 @@ type Workflow struct {
 	Metadata                Metadata                     `json:"metadata" yaml:"metadata" db:"-"`
 	Usage                   *Usage                       `json:"usage,omitempty" db:"-" cli:"-"`
 	HistoryLength           int64                        `json:"history_length" db:"history_length" cli:"-"`
+	CancelInProgress        bool                         `json:"cancel_in_progress,omitempty" db:"cancel_in_progress" cli:"-"`
+	Deployment              bool                         `json:"deployment,omitempty" db:"deployment" cli:"-"`
+	PinnedVersions          WorkflowPinnedVersions       `json:"pinned_versions,omitempty" db:"pinned_versions" cli:"-"`
 	PurgeTags               []string                     `json:"purge_tags,omitempty" db:"-" cli:"-"`
 	Notifications           []WorkflowNotification       `json:"notifications,omitempty" db:"-" cli:"-"`
 	FromRepository          string                       `json:"from_repository,omitempty" db:"from_repository" cli:"from"`
@@ -160,6 +163,10 @@ func (w *Workflow) AssignEmptyType() {
 				n.Type = NodeTypePipeline
 			} else if n.OutGoingHookContext != nil && n.OutGoingHookContext.HookModelID != 0 {
 				n.Type = NodeTypeOutGoingHook
+			} else if n.SubWorkflowContext != nil && n.SubWorkflowContext.WorkflowName != "" {
+				n.Type = NodeTypeSubWorkflow
+			} else if n.GateContext != nil {
+				n.Type = NodeTypeGate
 			} else {
 				n.Type = NodeTypeFork
 			}
@@ -172,26 +179,7 @@ func (w *Workflow) ValidateType() error {
 	namesInError := make([]string, 0)
 
 	for _, n := range w.WorkflowData.Array() {
-		switch n.Type {
-		case NodeTypePipeline:
-			if n.Context == nil || (n.Context.PipelineID == 0 && n.Context.PipelineName == "") {
-				namesInError = append(namesInError, n.Name)
-			}
-		case NodeTypeOutGoingHook:
-			if n.OutGoingHookContext == nil || (n.OutGoingHookContext.HookModelID == 0 && n.OutGoingHookContext.HookModelName == "") {
-				namesInError = append(namesInError, n.Name)
-			}
-		case NodeTypeJoin:
-			if n.JoinContext == nil || len(n.JoinContext) == 0 {
-				namesInError = append(namesInError, n.Name)
-			}
-		case NodeTypeFork:
-			if (n.Context != nil && (n.Context.PipelineID != 0 || n.Context.PipelineName != "")) ||
-				(n.OutGoingHookContext != nil && (n.OutGoingHookContext.HookModelID != 0 || n.OutGoingHookContext.HookModelName != "")) ||
-				(n.JoinContext != nil && len(n.JoinContext) > 0) {
-				namesInError = append(namesInError, n.Name)
-			}
-		default:
+		if !n.HasValidType() {
 			namesInError = append(namesInError, n.Name)
 		}
 	}
@@ -201,6 +189,29 @@ func (w *Workflow) ValidateType() error {
 	return nil
 }
 
+// HasValidType returns true if the context of the node matches its type.
+func (n *Node) HasValidType() bool {
+	switch n.Type {
+	case NodeTypePipeline:
+		return n.Context != nil && (n.Context.PipelineID != 0 || n.Context.PipelineName != "")
+	case NodeTypeOutGoingHook:
+		return n.OutGoingHookContext != nil && (n.OutGoingHookContext.HookModelID != 0 || n.OutGoingHookContext.HookModelName != "")
+	case NodeTypeJoin:
+		return len(n.JoinContext) > 0
+	case NodeTypeSubWorkflow:
+		return n.SubWorkflowContext != nil && n.SubWorkflowContext.WorkflowName != ""
+	case NodeTypeGate:
+		return n.GateContext != nil
+	case NodeTypeFork:
+		return !((n.Context != nil && (n.Context.PipelineID != 0 || n.Context.PipelineName != "")) ||
+			(n.OutGoingHookContext != nil && (n.OutGoingHookContext.HookModelID != 0 || n.OutGoingHookContext.HookModelName != "")) ||
+			(n.SubWorkflowContext != nil && n.SubWorkflowContext.WorkflowName != "") ||
+			n.GateContext != nil ||
+			len(n.JoinContext) > 0)
+	}
+	return false
+}
+
 //WorkflowNodeConditions is either an array of WorkflowNodeCondition or a lua script
 type WorkflowNodeConditions struct {
 	PlainConditions []WorkflowNodeCondition `json:"plain,omitempty" yaml:"check,omitempty"`
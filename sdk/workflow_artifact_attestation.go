@@ -0,0 +1,257 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// in-toto attestation of the provenance of the artifacts, see https://github.com/in-toto/attestation and https://slsa.dev/provenance/v0.2
+const (
+	InTotoStatementType         = "https://in-toto.io/Statement/v0.1"
+	InTotoPayloadType           = "application/vnd.in-toto+json"
+	SLSAProvenancePredicateType = "https://slsa.dev/provenance/v0.2"
+	// ArtifactProvenanceBuildType describes how an artifact was built: by a job of a CDS workflow run
+	ArtifactProvenanceBuildType = "https://ovh.github.io/cds/attestation/workflow-job@v1"
+)
+
+// WorkflowArtifactAttestationRequest is the request of a worker to sign the artifacts uploaded by its job
+type WorkflowArtifactAttestationRequest struct {
+	// KeyName is the name of the PGP project key used to sign the attestations
+	KeyName string `json:"key_name"`
+	Tag     string `json:"tag"`
+	// Names of the artifacts to sign, all the artifacts with the tag are signed if empty
+	Names []string `json:"names,omitempty"`
+}
+
+// IsValid returns an error if the attestation request is invalid.
+func (r WorkflowArtifactAttestationRequest) IsValid() error {
+	if r.KeyName == "" {
+		return NewErrorFrom(ErrWrongRequest, "the key used to sign the artifacts is mandatory")
+	}
+	return nil
+}
+
+// Match returns true if the artifact must be signed.
+func (r WorkflowArtifactAttestationRequest) Match(a WorkflowNodeRunArtifact) bool {
+	if a.Tag != r.Tag {
+		return false
+	}
+	if len(r.Names) == 0 {
+		return true
+	}
+	return IsInArray(a.Name, r.Names)
+}
+
+// WorkflowArtifactAttestation is the signed provenance attestation of an artifact of a workflow run.
+type WorkflowArtifactAttestation struct {
+	ID         int64        `json:"id" db:"id" cli:"-"`
+	ArtifactID int64        `json:"artifact_id" db:"workflow_node_run_artifact_id" cli:"artifact_id"`
+	KeyName    string       `json:"key_name" db:"key_name" cli:"key"`
+	KeyID      string       `json:"key_id" db:"key_id" cli:"key_id"`
+	Envelope   DSSEEnvelope `json:"envelope" db:"envelope" cli:"-"`
+	Created    time.Time    `json:"created" db:"created" cli:"created"`
+}
+
+// WorkflowArtifactAttestationVerification is the result of the verification of the attestation of an artifact.
+type WorkflowArtifactAttestationVerification struct {
+	ArtifactID int64            `json:"artifact_id" cli:"artifact_id"`
+	Verified   bool             `json:"verified" cli:"verified"`
+	Reason     string           `json:"reason,omitempty" cli:"reason"`
+	KeyName    string           `json:"key_name" cli:"key"`
+	KeyID      string           `json:"key_id" cli:"key_id"`
+	Statement  *InTotoStatement `json:"statement,omitempty" cli:"-"`
+}
+
+// DSSEEnvelope is a signed payload, see https://github.com/secure-systems-lab/dsse
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []DSSESignature `json:"signatures"`
+}
+
+// DSSESignature is the signature of the payload of an envelope, the signature is encoded in base64.
+type DSSESignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Scan envelope.
+func (e *DSSEEnvelope) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, e), "cannot unmarshal DSSEEnvelope")
+}
+
+// Value returns driver.Value from envelope.
+func (e DSSEEnvelope) Value() (driver.Value, error) {
+	j, err := json.Marshal(e)
+	return j, WrapError(err, "cannot marshal DSSEEnvelope")
+}
+
+// DSSEPreAuthEncoding returns the data signed for a payload, which includes the type of the payload.
+func DSSEPreAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// NewDSSEEnvelope returns an unsigned envelope of an in-toto statement.
+func NewDSSEEnvelope(s InTotoStatement) (DSSEEnvelope, error) {
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return DSSEEnvelope{}, WithStack(err)
+	}
+	return DSSEEnvelope{
+		PayloadType: InTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+	}, nil
+}
+
+// SignedData returns the data to sign or verify for the payload of the envelope.
+func (e DSSEEnvelope) SignedData() ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid envelope payload: %v", err)
+	}
+	return DSSEPreAuthEncoding(e.PayloadType, payload), nil
+}
+
+// Statement returns the in-toto statement of the envelope.
+func (e DSSEEnvelope) Statement() (*InTotoStatement, error) {
+	if e.PayloadType != InTotoPayloadType {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid envelope payload type %q", e.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid envelope payload: %v", err)
+	}
+	var s InTotoStatement
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid in-toto statement: %v", err)
+	}
+	return &s, nil
+}
+
+// InTotoStatement binds the provenance of a build to the artifacts it produced.
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []InTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     SLSAProvenance  `json:"predicate"`
+}
+
+// InTotoSubject is an artifact identified by its digests.
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// SLSAProvenance describes how an artifact was built.
+type SLSAProvenance struct {
+	Builder    SLSABuilder    `json:"builder"`
+	BuildType  string         `json:"buildType"`
+	Invocation SLSAInvocation `json:"invocation"`
+	Metadata   SLSAMetadata   `json:"metadata"`
+	Materials  []SLSAMaterial `json:"materials,omitempty"`
+}
+
+// SLSABuilder identifies the platform that built the artifact.
+type SLSABuilder struct {
+	ID string `json:"id"`
+}
+
+// SLSAInvocation describes the event that started the build.
+type SLSAInvocation struct {
+	ConfigSource SLSAConfigSource  `json:"configSource"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+}
+
+// SLSAConfigSource identifies the workflow that defined the build.
+type SLSAConfigSource struct {
+	URI        string            `json:"uri,omitempty"`
+	Digest     map[string]string `json:"digest,omitempty"`
+	EntryPoint string            `json:"entryPoint,omitempty"`
+}
+
+// SLSAMetadata contains the timestamps of the build.
+type SLSAMetadata struct {
+	BuildInvocationID string     `json:"buildInvocationId,omitempty"`
+	BuildStartedOn    *time.Time `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *time.Time `json:"buildFinishedOn,omitempty"`
+}
+
+// SLSAMaterial is a source used by the build.
+type SLSAMaterial struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// NewArtifactProvenanceStatement returns the provenance statement of an artifact built by a job, from the
+// parameters of the job. The secret parameters are not part of the statement.
+func NewArtifactProvenanceStatement(art WorkflowNodeRunArtifact, builderID string, params []Parameter, started, finished time.Time) InTotoStatement {
+	invocationParams := make(map[string]string, len(params))
+	for _, p := range params {
+		if p.Type == SecretVariable || p.Type == KeyParameter {
+			continue
+		}
+		invocationParams[p.Name] = p.Value
+	}
+
+	entryPoint := fmt.Sprintf("%s/%s/%s", ParameterValue(params, "cds.project"), ParameterValue(params, "cds.workflow"), ParameterValue(params, "cds.node"))
+	provenance := SLSAProvenance{
+		Builder:   SLSABuilder{ID: builderID},
+		BuildType: ArtifactProvenanceBuildType,
+		Invocation: SLSAInvocation{
+			ConfigSource: SLSAConfigSource{
+				URI:        ParameterValue(params, "cds.ui.pipeline.run"),
+				EntryPoint: entryPoint,
+			},
+			Parameters: invocationParams,
+		},
+		Metadata: SLSAMetadata{
+			BuildInvocationID: fmt.Sprintf("%s/%s/%d", entryPoint, ParameterValue(params, "cds.version"), art.WorkflowNodeRunID),
+			BuildStartedOn:    &started,
+			BuildFinishedOn:   &finished,
+		},
+	}
+
+	repository := ParameterValue(params, "git.http_url")
+	if repository == "" {
+		repository = ParameterValue(params, "git.url")
+	}
+	if repository != "" {
+		material := SLSAMaterial{URI: repository}
+		if hash := ParameterValue(params, "git.hash"); hash != "" {
+			material.Digest = map[string]string{"sha1": hash}
+		}
+		provenance.Materials = append(provenance.Materials, material)
+	}
+
+	return InTotoStatement{
+		Type:          InTotoStatementType,
+		PredicateType: SLSAProvenancePredicateType,
+		Subject: []InTotoSubject{{
+			Name:   art.Name,
+			Digest: map[string]string{"sha512": art.SHA512sum},
+		}},
+		Predicate: provenance,
+	}
+}
+
+// MatchDigest returns true if the statement is about an artifact with the given sha512 digest.
+func (s InTotoStatement) MatchDigest(sha512sum string) bool {
+	for _, sub := range s.Subject {
+		if sha512sum != "" && sub.Digest["sha512"] == sha512sum {
+			return true
+		}
+	}
+	return false
+}
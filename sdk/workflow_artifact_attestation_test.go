@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArtifactProvenanceStatement(t *testing.T) {
+	art := WorkflowNodeRunArtifact{Name: "app.tar.gz", Tag: "v1", SHA512sum: "abcdef", WorkflowNodeRunID: 42}
+	params := []Parameter{
+		{Name: "cds.project", Type: StringParameter, Value: "PRJ"},
+		{Name: "cds.workflow", Type: StringParameter, Value: "build"},
+		{Name: "cds.node", Type: StringParameter, Value: "compile"},
+		{Name: "cds.version", Type: StringParameter, Value: "12"},
+		{Name: "git.url", Type: StringParameter, Value: "ssh://git@github.com/ovh/cds.git"},
+		{Name: "git.http_url", Type: StringParameter, Value: "https://github.com/ovh/cds.git"},
+		{Name: "git.hash", Type: StringParameter, Value: "0123456789"},
+		{Name: "cds.env.password", Type: SecretVariable, Value: "secret"},
+	}
+	started := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	finished := started.Add(time.Minute)
+
+	s := NewArtifactProvenanceStatement(art, "https://cds.example.com", params, started, finished)
+	require.Equal(t, InTotoStatementType, s.Type)
+	require.Equal(t, SLSAProvenancePredicateType, s.PredicateType)
+	require.Equal(t, "app.tar.gz", s.Subject[0].Name)
+	require.True(t, s.MatchDigest("abcdef"))
+	require.False(t, s.MatchDigest("other"))
+	require.False(t, s.MatchDigest(""))
+	require.Equal(t, "https://cds.example.com", s.Predicate.Builder.ID)
+	require.Equal(t, "PRJ/build/compile", s.Predicate.Invocation.ConfigSource.EntryPoint)
+	require.Equal(t, "PRJ/build/compile/12/42", s.Predicate.Metadata.BuildInvocationID)
+	require.Equal(t, []SLSAMaterial{{URI: "https://github.com/ovh/cds.git", Digest: map[string]string{"sha1": "0123456789"}}}, s.Predicate.Materials)
+	require.Equal(t, "0123456789", s.Predicate.Invocation.Parameters["git.hash"])
+	_, has := s.Predicate.Invocation.Parameters["cds.env.password"]
+	require.False(t, has)
+}
+
+func TestDSSEEnvelope(t *testing.T) {
+	require.Equal(t, "DSSEv1 28 application/vnd.in-toto+json 2 {}", string(DSSEPreAuthEncoding(InTotoPayloadType, []byte("{}"))))
+
+	s := InTotoStatement{Type: InTotoStatementType, Subject: []InTotoSubject{{Name: "app.tar.gz", Digest: map[string]string{"sha512": "abcdef"}}}}
+	e, err := NewDSSEEnvelope(s)
+	require.NoError(t, err)
+
+	decoded, err := e.Statement()
+	require.NoError(t, err)
+	require.Equal(t, s, *decoded)
+
+	data, err := e.SignedData()
+	require.NoError(t, err)
+	require.Contains(t, string(data), "DSSEv1 28 application/vnd.in-toto+json ")
+
+	e.PayloadType = "text/plain"
+	_, err = e.Statement()
+	require.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Artifact promotion modes
+const (
+	// ArtifactPromotionModeCopy copies the artifact in the storage, the promoted artifact is kept when the source run is purged
+	ArtifactPromotionModeCopy = "copy"
+	// ArtifactPromotionModeReference references the artifact of the source run, the artifact is not purged with the source run
+	ArtifactPromotionModeReference = "reference"
+)
+
+// WorkflowArtifactPromotionRequest is the request to promote the artifacts of a workflow run to another workflow
+type WorkflowArtifactPromotionRequest struct {
+	ProjectKey   string `json:"project_key"`
+	WorkflowName string `json:"workflow_name"`
+	// Pattern is a regular expression matching the name of the artifacts to promote, all the artifacts are promoted if empty
+	Pattern string `json:"pattern,omitempty"`
+	// Tag of the artifacts to promote, all the artifacts are promoted if empty
+	Tag string `json:"tag,omitempty"`
+	// PromotedTag is the tag of the promoted artifacts, the tag of the source artifacts is kept if empty
+	PromotedTag string `json:"promoted_tag,omitempty"`
+	Mode        string `json:"mode"`
+}
+
+// IsValid returns an error if the promotion request is invalid.
+func (r WorkflowArtifactPromotionRequest) IsValid() error {
+	if r.ProjectKey == "" || r.WorkflowName == "" {
+		return NewErrorFrom(ErrWrongRequest, "project key and workflow name of the promotion are mandatory")
+	}
+	if r.Mode != ArtifactPromotionModeCopy && r.Mode != ArtifactPromotionModeReference {
+		return NewErrorFrom(ErrWrongRequest, "invalid promotion mode %q, must be %s or %s", r.Mode, ArtifactPromotionModeCopy, ArtifactPromotionModeReference)
+	}
+	if _, err := regexp.Compile(r.Pattern); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid pattern %q: %v", r.Pattern, err)
+	}
+	return nil
+}
+
+// Match returns true if the artifact must be promoted.
+func (r WorkflowArtifactPromotionRequest) Match(a WorkflowNodeRunArtifact) bool {
+	if r.Tag != "" && a.Tag != r.Tag {
+		return false
+	}
+	if r.Pattern == "" {
+		return true
+	}
+	match, _ := regexp.MatchString(r.Pattern, a.Name)
+	return match
+}
+
+// WorkflowArtifactPromotion is an artifact of a workflow run promoted to another workflow.
+type WorkflowArtifactPromotion struct {
+	ID         int64  `json:"id" db:"id" cli:"id"`
+	WorkflowID int64  `json:"workflow_id" db:"workflow_id" cli:"-"`
+	Name       string `json:"name" db:"name" cli:"name,key"`
+	Tag        string `json:"tag" db:"tag" cli:"tag"`
+	Mode       string `json:"mode" db:"mode" cli:"mode"`
+	// ContainerPath is the path of the container of the artifact in the storage
+	ContainerPath string `json:"-" db:"container_path" cli:"-"`
+	// StorageProjectKey is the key of the project owning the storage integration of the artifact
+	StorageProjectKey    string                     `json:"storage_project_key" db:"storage_project_key" cli:"-"`
+	ProjectIntegrationID *int64                     `json:"project_integration_id" db:"project_integration_id" cli:"-"`
+	Size                 int64                      `json:"size,omitempty" db:"size" cli:"size"`
+	Perm                 uint32                     `json:"perm,omitempty" db:"perm" cli:"-"`
+	MD5sum               string                     `json:"md5sum,omitempty" db:"md5sum" cli:"-"`
+	SHA512sum            string                     `json:"sha512sum,omitempty" db:"sha512sum" cli:"sha512sum"`
+	SourceWorkflowRunID  int64                      `json:"source_workflow_run_id" db:"source_workflow_run_id" cli:"-"`
+	SourceArtifactID     int64                      `json:"source_artifact_id" db:"source_artifact_id" cli:"-"`
+	Provenance           WorkflowArtifactProvenance `json:"provenance" db:"provenance" cli:"-"`
+	Created              time.Time                  `json:"created" db:"created" cli:"created"`
+}
+
+// GetName returns the name of the promoted artifact.
+func (p *WorkflowArtifactPromotion) GetName() string {
+	return p.Name
+}
+
+// GetPath returns the path of the container of the promoted artifact.
+func (p *WorkflowArtifactPromotion) GetPath() string {
+	return p.ContainerPath
+}
+
+// PromotedContainerPath returns the path of the container of the artifacts copied to a workflow.
+func PromotedContainerPath(workflowID int64, tag string) string {
+	container := url.QueryEscape(fmt.Sprintf("promoted-%d-%s", workflowID, tag))
+	return strings.Replace(container, "/", "-", -1)
+}
+
+// WorkflowArtifactProvenance describes the workflow run that produced a promoted artifact.
+type WorkflowArtifactProvenance struct {
+	ProjectKey        string `json:"project_key"`
+	WorkflowName      string `json:"workflow_name"`
+	RunNumber         int64  `json:"run_number"`
+	WorkflowNodeRunID int64  `json:"workflow_node_run_id"`
+	ArtifactTag       string `json:"artifact_tag"`
+	PromotedBy        string `json:"promoted_by"`
+}
+
+// Scan artifact provenance.
+func (p *WorkflowArtifactProvenance) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, p), "cannot unmarshal WorkflowArtifactProvenance")
+}
+
+// Value returns driver.Value from artifact provenance.
+func (p WorkflowArtifactProvenance) Value() (driver.Value, error) {
+	j, err := json.Marshal(p)
+	return j, WrapError(err, "cannot marshal WorkflowArtifactProvenance")
+}
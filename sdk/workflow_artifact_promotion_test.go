@@ -0,0 +1,31 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowArtifactPromotionRequest(t *testing.T) {
+	req := WorkflowArtifactPromotionRequest{ProjectKey: "DEPLOY", WorkflowName: "deploy-app", Mode: ArtifactPromotionModeCopy}
+	require.NoError(t, req.IsValid())
+	require.True(t, req.Match(WorkflowNodeRunArtifact{Name: "app.tar.gz", Tag: "v1"}))
+
+	req.Pattern = `app-.*\.tar\.gz`
+	req.Tag = "v1"
+	require.NoError(t, req.IsValid())
+	require.True(t, req.Match(WorkflowNodeRunArtifact{Name: "app-linux.tar.gz", Tag: "v1"}))
+	require.False(t, req.Match(WorkflowNodeRunArtifact{Name: "app-linux.tar.gz", Tag: "v2"}))
+	require.False(t, req.Match(WorkflowNodeRunArtifact{Name: "README.md", Tag: "v1"}))
+
+	req.Mode = "move"
+	require.Error(t, req.IsValid())
+
+	req.Mode = ArtifactPromotionModeReference
+	req.Pattern = "app-["
+	require.Error(t, req.IsValid())
+
+	req.Pattern = ""
+	req.WorkflowName = ""
+	require.Error(t, req.IsValid())
+}
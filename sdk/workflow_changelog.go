@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WorkflowRunChangelogMaxCommits is the max count of commits stored in the changelog of a node run.
+const WorkflowRunChangelogMaxCommits = 250
+
+// WorkflowRunChangelog is the list of the commits between the commit of the last successful run of a node and the
+// commit of a node run.
+type WorkflowRunChangelog struct {
+	ID                int64  `json:"id" db:"id" cli:"-"`
+	WorkflowRunID     int64  `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64  `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"-"`
+	NodeName          string `json:"node_name" db:"node_name" cli:"node,key"`
+	Repository        string `json:"repository" db:"repository" cli:"repository"`
+	Branch            string `json:"branch,omitempty" db:"branch" cli:"branch"`
+	// FromNumber is the number of the run that deployed FromHash, it is empty if the node never succeeded
+	FromNumber int64            `json:"from_number,omitempty" db:"from_number" cli:"from_number"`
+	FromHash   string           `json:"from_hash,omitempty" db:"from_hash" cli:"from"`
+	ToHash     string           `json:"to_hash" db:"to_hash" cli:"to"`
+	Commits    ChangelogCommits `json:"commits" db:"commits" cli:"-"`
+	Truncated  bool             `json:"truncated,omitempty" db:"truncated" cli:"truncated"`
+	Created    time.Time        `json:"created" db:"created" cli:"created"`
+}
+
+// Markdown returns the changelog as a markdown list, one line per commit.
+func (c WorkflowRunChangelog) Markdown() string {
+	var buf bytes.Buffer
+	for _, commit := range c.Commits {
+		hash := commit.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		message := strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)[0]
+		author := commit.Author.DisplayName
+		if author == "" {
+			author = commit.Author.Name
+		}
+		fmt.Fprintf(&buf, "* %s %s", hash, message)
+		if author != "" {
+			fmt.Fprintf(&buf, " (%s)", author)
+		}
+		buf.WriteString("\n")
+	}
+	if c.Truncated {
+		buf.WriteString("* ...\n")
+	}
+	return buf.String()
+}
+
+// ChangelogCommits is the list of the commits of a changelog.
+type ChangelogCommits []VCSCommit
+
+// Scan commits.
+func (c *ChangelogCommits) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, c), "cannot unmarshal ChangelogCommits")
+}
+
+// Value returns driver.Value from commits.
+func (c ChangelogCommits) Value() (driver.Value, error) {
+	if c == nil {
+		c = ChangelogCommits{}
+	}
+	j, err := json.Marshal(c)
+	return j, WrapError(err, "cannot marshal ChangelogCommits")
+}
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunChangelogMarkdown(t *testing.T) {
+	c := WorkflowRunChangelog{
+		Commits: ChangelogCommits{
+			{Hash: "8ca1018e4d3c", Message: "Fix the login\n\nDetails", Author: VCSAuthor{Name: "jdoe", DisplayName: "John Doe"}},
+			{Hash: "7133a27", Message: "Add a button", Author: VCSAuthor{Name: "asmith"}},
+			{Hash: "d5e923d", Message: "Update readme"},
+		},
+	}
+	require.Equal(t, "* 8ca1018 Fix the login (John Doe)\n* 7133a27 Add a button (asmith)\n* d5e923d Update readme\n", c.Markdown())
+
+	c.Truncated = true
+	require.Contains(t, c.Markdown(), "\n* ...\n")
+
+	require.Equal(t, "", WorkflowRunChangelog{}.Markdown())
+}
+
+func TestChangelogCommitsScanValue(t *testing.T) {
+	var c ChangelogCommits
+	v, err := c.Value()
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(v.([]byte)))
+
+	v, err = ChangelogCommits{{Hash: "abc", Message: "msg"}}.Value()
+	require.NoError(t, err)
+	require.NoError(t, c.Scan(v))
+	require.Len(t, c, 1)
+	require.Equal(t, "abc", c[0].Hash)
+
+	require.Error(t, c.Scan("not bytes"))
+}
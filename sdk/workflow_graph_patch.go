@@ -0,0 +1,213 @@
+package sdk
+
+import (
+	"fmt"
+)
+
+// Workflow graph operations
+const (
+	WorkflowGraphOperationAddNode        = "add_node"
+	WorkflowGraphOperationRewireTrigger  = "rewire_trigger"
+	WorkflowGraphOperationEditConditions = "edit_conditions"
+)
+
+// WorkflowGraphPatch is a list of incremental edits applied on the graph of a workflow.
+type WorkflowGraphPatch struct {
+	Operations []WorkflowGraphOperation `json:"operations"`
+}
+
+// WorkflowGraphOperation is an edit of the workflow graph.
+//   - add_node: adds NewNode as a child of Parent
+//   - rewire_trigger: moves Node and its children under Parent
+//   - edit_conditions: replaces the run conditions of Node
+type WorkflowGraphOperation struct {
+	Type       string                  `json:"type"`
+	Node       string                  `json:"node,omitempty"`
+	Parent     string                  `json:"parent,omitempty"`
+	NewNode    *Node                   `json:"new_node,omitempty"`
+	Conditions *WorkflowNodeConditions `json:"conditions,omitempty"`
+}
+
+// WorkflowGraphOperationError returns an invalid workflow error for the operation at given index.
+func WorkflowGraphOperationError(index int, op WorkflowGraphOperation, format string, args ...interface{}) error {
+	return NewErrorFrom(ErrWorkflowInvalid, "operation %d (%s): %s", index, op.Type, fmt.Sprintf(format, args...))
+}
+
+// Apply applies all the operations of the patch on given workflow, it returns for each operation the
+// name of the node that was changed so only these nodes can be validated.
+func (p WorkflowGraphPatch) Apply(w *Workflow) ([]string, error) {
+	if w.WorkflowData == nil {
+		return nil, WithStack(fmt.Errorf("bad workflow, workflow data must not be null"))
+	}
+	if len(p.Operations) == 0 {
+		return nil, NewErrorFrom(ErrWrongRequest, "no operation given")
+	}
+
+	affected := make([]string, len(p.Operations))
+	for i, op := range p.Operations {
+		var err error
+		switch op.Type {
+		case WorkflowGraphOperationAddNode:
+			err = w.WorkflowData.graphAddNode(op)
+			if op.NewNode != nil {
+				affected[i] = op.NewNode.Name
+			}
+		case WorkflowGraphOperationRewireTrigger:
+			err = w.WorkflowData.graphRewireTrigger(op)
+			affected[i] = op.Node
+		case WorkflowGraphOperationEditConditions:
+			err = w.WorkflowData.graphEditConditions(op)
+			affected[i] = op.Node
+		default:
+			return nil, NewErrorFrom(ErrWrongRequest, "operation %d: invalid type %q", i, op.Type)
+		}
+		if err != nil {
+			return nil, WorkflowGraphOperationError(i, op, "%v", err)
+		}
+	}
+	return affected, nil
+}
+
+func (w *WorkflowData) graphAddNode(op WorkflowGraphOperation) error {
+	if op.NewNode == nil {
+		return fmt.Errorf("new node is mandatory")
+	}
+	n := *op.NewNode
+	if !NamePatternRegex.MatchString(n.Name) {
+		return fmt.Errorf("invalid node name %q, it should match %s", n.Name, NamePattern)
+	}
+	if w.NodeByName(n.Name) != nil {
+		return fmt.Errorf("node %s already exists", n.Name)
+	}
+	if len(n.Triggers) > 0 || len(n.JoinContext) > 0 || n.Type == NodeTypeJoin {
+		return fmt.Errorf("node %s must be added without triggers, add its children with other operations", n.Name)
+	}
+	parent := w.NodeByName(op.Parent)
+	if parent == nil {
+		return fmt.Errorf("parent node %q not found", op.Parent)
+	}
+	if parent.Type == NodeTypeOutGoingHook && n.Type == NodeTypeOutGoingHook {
+		return fmt.Errorf("outgoing hook %s can't be triggered by outgoing hook %s", n.Name, parent.Name)
+	}
+
+	n.ID = 0
+	n.Hooks = nil
+	parent.Triggers = append(parent.Triggers, NodeTrigger{ParentNodeName: parent.Name, ChildNode: n})
+	return nil
+}
+
+func (w *WorkflowData) graphRewireTrigger(op WorkflowGraphOperation) error {
+	if op.Node == w.Node.Name {
+		return fmt.Errorf("root node %s can't be triggered", op.Node)
+	}
+	n := w.NodeByName(op.Node)
+	if n == nil {
+		return fmt.Errorf("node %q not found", op.Node)
+	}
+	if n.Type == NodeTypeJoin {
+		return fmt.Errorf("join %s is triggered by its parents, edit them instead", op.Node)
+	}
+	if w.NodeByName(op.Parent) == nil {
+		return fmt.Errorf("parent node %q not found", op.Parent)
+	}
+	if w.dependsOn(op.Parent, n) {
+		return fmt.Errorf("node %s can't be triggered by itself or one of its children", op.Node)
+	}
+
+	oldParent := w.parentOf(op.Node)
+	if oldParent == nil {
+		return fmt.Errorf("parent of node %s not found", op.Node)
+	}
+	if oldParent.Name == op.Parent {
+		return nil
+	}
+	var t NodeTrigger
+	for i := range oldParent.Triggers {
+		if oldParent.Triggers[i].ChildNode.Name == op.Node {
+			t = oldParent.Triggers[i]
+			oldParent.Triggers = append(oldParent.Triggers[:i], oldParent.Triggers[i+1:]...)
+			break
+		}
+	}
+
+	// Pointers on nodes are invalid after the triggers of the old parent were changed
+	parent := w.NodeByName(op.Parent)
+	t.ID = 0
+	t.ParentNodeID = 0
+	t.ParentNodeName = parent.Name
+	parent.Triggers = append(parent.Triggers, t)
+	return nil
+}
+
+func (w *WorkflowData) graphEditConditions(op WorkflowGraphOperation) error {
+	if op.Conditions == nil {
+		return fmt.Errorf("conditions are mandatory")
+	}
+	n := w.NodeByName(op.Node)
+	if n == nil {
+		return fmt.Errorf("node %q not found", op.Node)
+	}
+	if n.Context == nil {
+		return fmt.Errorf("node %s of type %s has no conditions", n.Name, n.Type)
+	}
+	for i, c := range op.Conditions.PlainConditions {
+		if c.Variable == "" {
+			return fmt.Errorf("condition %d: variable is mandatory", i)
+		}
+		if _, ok := WorkflowConditionsOperators[c.Operator]; !ok {
+			return fmt.Errorf("condition %d: invalid operator %q", i, c.Operator)
+		}
+	}
+	n.Context.Conditions = *op.Conditions
+	return nil
+}
+
+// parentOf returns the node that triggers the node with given name.
+func (w *WorkflowData) parentOf(name string) *Node {
+	for _, n := range w.Array() {
+		for i := range n.Triggers {
+			if n.Triggers[i].ChildNode.Name == name {
+				return n
+			}
+		}
+	}
+	return nil
+}
+
+// dependsOn returns true if the node with given name is in the subtree of n, or in the subtree of a join
+// that has a parent in the subtree of n.
+func (w *WorkflowData) dependsOn(name string, n *Node) bool {
+	if n.nodeByName(name) != nil {
+		return true
+	}
+	for i := range w.Joins {
+		j := &w.Joins[i]
+		if j.nodeByName(name) == nil {
+			continue
+		}
+		for _, jc := range j.JoinContext {
+			parentName := jc.ParentName
+			if parentName == "" {
+				if p := w.NodeByID(jc.ParentID); p != nil {
+					parentName = p.Name
+				}
+			}
+			if parentName != "" && w.dependsOn(parentName, n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n *Node) nodeByName(name string) *Node {
+	if n.Name == name {
+		return n
+	}
+	for i := range n.Triggers {
+		if c := (&n.Triggers[i].ChildNode).nodeByName(name); c != nil {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkflowForGraphPatch() Workflow {
+	pipNode := func(id int64, name string, children ...Node) Node {
+		n := Node{ID: id, Name: name, Type: NodeTypePipeline, Context: &NodeContext{PipelineID: id}}
+		for _, c := range children {
+			n.Triggers = append(n.Triggers, NodeTrigger{ParentNodeName: name, ChildNode: c})
+		}
+		return n
+	}
+	return Workflow{
+		WorkflowData: &WorkflowData{
+			Node: pipNode(1, "root", pipNode(2, "build", pipNode(3, "test")), pipNode(4, "lint")),
+			Joins: []Node{{
+				ID:          5,
+				Name:        "join",
+				Type:        NodeTypeJoin,
+				JoinContext: []NodeJoin{{ParentName: "test"}, {ParentName: "lint"}},
+				Triggers:    []NodeTrigger{{ParentNodeName: "join", ChildNode: pipNode(6, "deploy")}},
+			}},
+		},
+	}
+}
+
+func TestWorkflowGraphPatchApply(t *testing.T) {
+	w := testWorkflowForGraphPatch()
+	names, err := WorkflowGraphPatch{Operations: []WorkflowGraphOperation{
+		{Type: WorkflowGraphOperationAddNode, Parent: "build", NewNode: &Node{Name: "package", Type: NodeTypePipeline, Context: &NodeContext{PipelineID: 7}}},
+		{Type: WorkflowGraphOperationRewireTrigger, Node: "test", Parent: "package"},
+		{Type: WorkflowGraphOperationEditConditions, Node: "package", Conditions: &WorkflowNodeConditions{
+			PlainConditions: []WorkflowNodeCondition{{Variable: "git.branch", Operator: WorkflowConditionsOperatorEquals, Value: "master"}},
+		}},
+	}}.Apply(&w)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"package", "test", "package"}, names)
+
+	build := w.WorkflowData.NodeByName("build")
+	require.Len(t, build.Triggers, 1)
+	pkg := build.Triggers[0].ChildNode
+	assert.Equal(t, "package", pkg.Name)
+	require.Len(t, pkg.Triggers, 1)
+	assert.Equal(t, "test", pkg.Triggers[0].ChildNode.Name)
+	assert.Equal(t, "package", pkg.Triggers[0].ParentNodeName)
+	assert.Equal(t, "master", pkg.Context.Conditions.PlainConditions[0].Value)
+}
+
+func TestWorkflowGraphPatchApplyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		op   WorkflowGraphOperation
+		err  string
+	}{
+		{"unknown type", WorkflowGraphOperation{Type: "delete_node"}, "operation 0: invalid type"},
+		{"existing node", WorkflowGraphOperation{Type: WorkflowGraphOperationAddNode, Parent: "root", NewNode: &Node{Name: "lint", Type: NodeTypeFork}}, "node lint already exists"},
+		{"invalid name", WorkflowGraphOperation{Type: WorkflowGraphOperationAddNode, Parent: "root", NewNode: &Node{Name: "my node", Type: NodeTypeFork}}, "invalid node name"},
+		{"unknown parent", WorkflowGraphOperation{Type: WorkflowGraphOperationAddNode, Parent: "unknown", NewNode: &Node{Name: "fork", Type: NodeTypeFork}}, "parent node \"unknown\" not found"},
+		{"move root", WorkflowGraphOperation{Type: WorkflowGraphOperationRewireTrigger, Node: "root", Parent: "lint"}, "root node root can't be triggered"},
+		{"move join", WorkflowGraphOperation{Type: WorkflowGraphOperationRewireTrigger, Node: "join", Parent: "lint"}, "join join is triggered by its parents"},
+		{"cycle", WorkflowGraphOperation{Type: WorkflowGraphOperationRewireTrigger, Node: "build", Parent: "test"}, "can't be triggered by itself or one of its children"},
+		{"cycle through join", WorkflowGraphOperation{Type: WorkflowGraphOperationRewireTrigger, Node: "lint", Parent: "deploy"}, "can't be triggered by itself or one of its children"},
+		{"invalid operator", WorkflowGraphOperation{Type: WorkflowGraphOperationEditConditions, Node: "lint", Conditions: &WorkflowNodeConditions{
+			PlainConditions: []WorkflowNodeCondition{{Variable: "git.branch", Operator: "like"}},
+		}}, "condition 0: invalid operator \"like\""},
+		{"conditions on join", WorkflowGraphOperation{Type: WorkflowGraphOperationEditConditions, Node: "join", Conditions: &WorkflowNodeConditions{}}, "node join of type join has no conditions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := testWorkflowForGraphPatch()
+			_, err := WorkflowGraphPatch{Operations: []WorkflowGraphOperation{tt.op}}.Apply(&w)
+			require.Error(t, err)
+			assert.Contains(t, ExtractHTTPError(err, "").Error(), tt.err)
+		})
+	}
+}
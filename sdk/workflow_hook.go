@@ -11,10 +11,12 @@ import (
 
 // Those are icon for hooks
 const (
-	GitlabIcon    = "Gitlab"
-	GitHubIcon    = "Github"
-	BitbucketIcon = "Bitbucket"
-	GerritIcon    = "git"
+	GitlabIcon     = "Gitlab"
+	GitHubIcon     = "Github"
+	BitbucketIcon  = "Bitbucket"
+	GerritIcon     = "git"
+	GiteaIcon      = "git"
+	CodeCommitIcon = "git"
 )
 
 //NodeHook represents a hook which cann trigger the workflow from a given node
@@ -134,7 +136,11 @@ func GetBuiltinOutgoingHookModelByName(name string) *WorkflowHookModel {
 func (cfg WorkflowNodeHookConfig) Values(model WorkflowNodeHookConfig) map[string]string {
 	r := make(map[string]string)
 	for k, v := range cfg {
-		if model[k].Configurable {
+		// Optional settings left empty are not exported
+		if v.Value == "" && model[k].Value == "" {
+			continue
+		}
+		if model[k].Configurable || (k == HookConfigPriority && v.Value != "") {
 			r[k] = v.Value
 		}
 	}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"sort"
+	"time"
+)
+
+// WorkflowInsightJob contains the timestamps of a terminated job run, used to compute the insights of a workflow.
+type WorkflowInsightJob struct {
+	NodeName   string
+	Model      string
+	Queued     time.Time
+	SpawnStart time.Time
+	Start      time.Time
+	Done       time.Time
+}
+
+// NewWorkflowInsightJob returns the timestamps of a job run of a node. The spawn starts when a hatchery starts the
+// first worker for the job, it's the start of the job if no worker was spawned for it. It returns false if the job
+// was not executed.
+func NewWorkflowInsightJob(nodeName string, j WorkflowNodeJobRun) (WorkflowInsightJob, bool) {
+	if !StatusIsTerminated(j.Status) || j.Status == StatusSkipped || j.Status == StatusDisabled ||
+		j.Queued.IsZero() || j.Start.Before(j.Queued) || j.Done.Before(j.Start) {
+		return WorkflowInsightJob{}, false
+	}
+	job := WorkflowInsightJob{
+		NodeName:   nodeName,
+		Model:      j.Model,
+		Queued:     j.Queued,
+		SpawnStart: j.Start,
+		Start:      j.Start,
+		Done:       j.Done,
+	}
+	for _, info := range j.SpawnInfos {
+		if info.Message.ID == MsgSpawnInfoHatcheryStarts.ID && !info.APITime.Before(j.Queued) && info.APITime.Before(job.SpawnStart) {
+			job.SpawnStart = info.APITime
+		}
+	}
+	return job, true
+}
+
+// WorkflowInsight contains the percentiles of the time spent by the jobs of a node or of a worker model in the queue,
+// waiting for a worker and executing their steps. The durations are in seconds.
+type WorkflowInsight struct {
+	Name         string `json:"name" cli:"name,key"`
+	Jobs         int64  `json:"jobs" cli:"jobs"`
+	QueueP50     int64  `json:"queue_p50" cli:"queue_p50"`
+	QueueP90     int64  `json:"queue_p90" cli:"queue_p90"`
+	QueueP99     int64  `json:"queue_p99" cli:"queue_p99"`
+	SpawnP50     int64  `json:"spawn_p50" cli:"spawn_p50"`
+	SpawnP90     int64  `json:"spawn_p90" cli:"spawn_p90"`
+	SpawnP99     int64  `json:"spawn_p99" cli:"spawn_p99"`
+	ExecutionP50 int64  `json:"execution_p50" cli:"execution_p50"`
+	ExecutionP90 int64  `json:"execution_p90" cli:"execution_p90"`
+	ExecutionP99 int64  `json:"execution_p99" cli:"execution_p99"`
+}
+
+// WorkflowInsights contains the insights of the jobs of a workflow over a period, by node and by worker model.
+type WorkflowInsights struct {
+	From   time.Time         `json:"from"`
+	To     time.Time         `json:"to"`
+	Nodes  []WorkflowInsight `json:"nodes"`
+	Models []WorkflowInsight `json:"models"`
+}
+
+// ComputeWorkflowInsights computes the percentiles of the time spent by the jobs:
+//   - queue: from the job being queued to a hatchery starting a worker for it
+//   - spawn: from a hatchery starting a worker to the job being taken by a worker
+//   - execution: from the job being taken to its end
+//
+// A long queue time means that the hatcheries lack capacity, a long execution time comes from the pipeline itself.
+func ComputeWorkflowInsights(jobs []WorkflowInsightJob, from, to time.Time) WorkflowInsights {
+	byNode := make(map[string][]WorkflowInsightJob)
+	byModel := make(map[string][]WorkflowInsightJob)
+	for _, j := range jobs {
+		byNode[j.NodeName] = append(byNode[j.NodeName], j)
+		if j.Model != "" {
+			byModel[j.Model] = append(byModel[j.Model], j)
+		}
+	}
+	return WorkflowInsights{
+		From:   from,
+		To:     to,
+		Nodes:  computeWorkflowInsightsByName(byNode),
+		Models: computeWorkflowInsightsByName(byModel),
+	}
+}
+
+func computeWorkflowInsightsByName(jobs map[string][]WorkflowInsightJob) []WorkflowInsight {
+	names := make([]string, 0, len(jobs))
+	for name := range jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	is := make([]WorkflowInsight, 0, len(names))
+	for _, name := range names {
+		js := jobs[name]
+		queue := make([]time.Duration, len(js))
+		spawn := make([]time.Duration, len(js))
+		execution := make([]time.Duration, len(js))
+		for i, j := range js {
+			queue[i] = j.SpawnStart.Sub(j.Queued)
+			spawn[i] = j.Start.Sub(j.SpawnStart)
+			execution[i] = j.Done.Sub(j.Start)
+		}
+		is = append(is, WorkflowInsight{
+			Name:         name,
+			Jobs:         int64(len(js)),
+			QueueP50:     percentileDuration(queue, 50),
+			QueueP90:     percentileDuration(queue, 90),
+			QueueP99:     percentileDuration(queue, 99),
+			SpawnP50:     percentileDuration(spawn, 50),
+			SpawnP90:     percentileDuration(spawn, 90),
+			SpawnP99:     percentileDuration(spawn, 99),
+			ExecutionP50: percentileDuration(execution, 50),
+			ExecutionP90: percentileDuration(execution, 90),
+			ExecutionP99: percentileDuration(execution, 99),
+		})
+	}
+	return is
+}
+
+// percentileDuration returns the nearest-rank percentile of durations, in seconds.
+func percentileDuration(ds []time.Duration, p int) int64 {
+	if len(ds) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(ds))
+	copy(sorted, ds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return int64(sorted[rank-1].Seconds())
+}
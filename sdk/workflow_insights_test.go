@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeWorkflowInsights(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+	at := func(s int) time.Time { return from.Add(time.Duration(s) * time.Second) }
+
+	spawned := WorkflowNodeJobRun{
+		Status: StatusSuccess,
+		Model:  "go",
+		Queued: at(0),
+		Start:  at(40),
+		Done:   at(100),
+		SpawnInfos: []SpawnInfo{
+			{APITime: at(0), Message: SpawnMsg{ID: MsgSpawnInfoJobInQueue.ID}},
+			{APITime: at(10), Message: SpawnMsg{ID: MsgSpawnInfoHatcheryStarts.ID}},
+			{APITime: at(25), Message: SpawnMsg{ID: MsgSpawnInfoHatcheryStarts.ID}},
+		},
+	}
+	j1, ok := NewWorkflowInsightJob("build", spawned)
+	require.True(t, ok)
+	require.Equal(t, at(10), j1.SpawnStart)
+
+	// a job taken by a worker which was not spawned for it
+	j2, ok := NewWorkflowInsightJob("build", WorkflowNodeJobRun{Status: StatusFail, Model: "go", Queued: at(0), Start: at(5), Done: at(20)})
+	require.True(t, ok)
+	require.Equal(t, at(5), j2.SpawnStart)
+
+	j3, ok := NewWorkflowInsightJob("deploy", WorkflowNodeJobRun{Status: StatusSuccess, Queued: at(0), Start: at(2), Done: at(3)})
+	require.True(t, ok)
+
+	_, ok = NewWorkflowInsightJob("build", WorkflowNodeJobRun{Status: StatusBuilding, Queued: at(0), Start: at(5)})
+	require.False(t, ok)
+	_, ok = NewWorkflowInsightJob("build", WorkflowNodeJobRun{Status: StatusSkipped})
+	require.False(t, ok)
+
+	is := ComputeWorkflowInsights([]WorkflowInsightJob{j1, j2, j3}, from, to)
+	require.Equal(t, from, is.From)
+	require.Equal(t, to, is.To)
+
+	require.Len(t, is.Nodes, 2)
+	require.Equal(t, WorkflowInsight{
+		Name:         "build",
+		Jobs:         2,
+		QueueP50:     5,
+		QueueP90:     10,
+		QueueP99:     10,
+		SpawnP50:     0,
+		SpawnP90:     30,
+		SpawnP99:     30,
+		ExecutionP50: 15,
+		ExecutionP90: 60,
+		ExecutionP99: 60,
+	}, is.Nodes[0])
+	require.Equal(t, "deploy", is.Nodes[1].Name)
+	require.Equal(t, int64(1), is.Nodes[1].ExecutionP50)
+
+	// the jobs without model are not in the models insights
+	require.Len(t, is.Models, 1)
+	require.Equal(t, "go", is.Models[0].Name)
+	require.Equal(t, int64(2), is.Models[0].Jobs)
+}
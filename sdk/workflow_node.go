@@ -12,6 +12,8 @@ const (
 	NodeTypeJoin         = "join"
 	NodeTypeOutGoingHook = "outgoinghook"
 	NodeTypeFork         = "fork"
+	NodeTypeSubWorkflow  = "subworkflow"
+	NodeTypeGate         = "gate"
 )
 
 // Node represents a node in a workflow
@@ -26,6 +28,9 @@ type Node struct {
 	Context             *NodeContext      `json:"context" db:"-"`
 	OutGoingHookContext *NodeOutGoingHook `json:"outgoing_hook" db:"-"`
 	JoinContext         []NodeJoin        `json:"parents" db:"-"`
+	JoinPolicy          *NodeJoinPolicy   `json:"join_policy,omitempty" db:"-"`
+	SubWorkflowContext  *NodeSubWorkflow  `json:"subworkflow,omitempty" db:"-"`
+	GateContext         *NodeGate         `json:"gate,omitempty" db:"-"`
 	Hooks               []NodeHook        `json:"hooks" db:"-"`
 	Groups              []GroupPermission `json:"groups,omitempty" db:"-"`
 }
@@ -55,6 +60,25 @@ type NodeContext struct {
 	DefaultPipelineParameters []Parameter            `json:"default_pipeline_parameters" db:"-"`
 	Conditions                WorkflowNodeConditions `json:"conditions" db:"-"`
 	Mutex                     bool                   `json:"mutex" db:"mutex"`
+	ConcurrencyGroup          string                 `json:"concurrency_group,omitempty" db:"concurrency_group"`
+	ConcurrencyPolicy         string                 `json:"concurrency_policy,omitempty" db:"concurrency_policy"`
+	ProductionDeployment      bool                   `json:"production_deployment,omitempty" db:"production_deployment"`
+	VerificationOf            string                 `json:"verification_of,omitempty" db:"verification_of"`
+	RollbackNode              string                 `json:"rollback_node,omitempty" db:"rollback_node"`
+}
+
+// Concurrency policies of a node context, applied to the runs waiting in the same concurrency group
+const (
+	ConcurrencyPolicyQueue            = "queue"
+	ConcurrencyPolicyCancelSuperseded = "cancel-superseded"
+)
+
+// ConcurrencyPolicies contains all the available concurrency policies
+var ConcurrencyPolicies = []string{ConcurrencyPolicyQueue, ConcurrencyPolicyCancelSuperseded}
+
+// IsValidConcurrencyGroup checks the name of a concurrency group
+func IsValidConcurrencyGroup(name string) bool {
+	return NamePatternRegex.MatchString(name)
 }
 
 // FilterHooksConfig filter all hooks configuration and remove somme configuration key
@@ -105,6 +129,26 @@ func (n *Node) VisitNode(w *Workflow, visitor func(node *Node, w *Workflow)) {
 	}
 }
 
+// CheckConcurrency checks the concurrency group and policy of the node context
+func (c *NodeContext) CheckConcurrency() error {
+	if c == nil {
+		return nil
+	}
+	if c.ConcurrencyGroup == "" {
+		if c.ConcurrencyPolicy != "" {
+			return NewErrorFrom(ErrWrongRequest, "concurrency policy %s is set without concurrency group", c.ConcurrencyPolicy)
+		}
+		return nil
+	}
+	if !IsValidConcurrencyGroup(c.ConcurrencyGroup) {
+		return NewErrorFrom(ErrWrongRequest, "invalid concurrency group name %s, should match %s", c.ConcurrencyGroup, NamePattern)
+	}
+	if c.ConcurrencyPolicy != "" && !IsInArray(c.ConcurrencyPolicy, ConcurrencyPolicies) {
+		return NewErrorFrom(ErrWrongRequest, "invalid concurrency policy %s, should be one of %v", c.ConcurrencyPolicy, ConcurrencyPolicies)
+	}
+	return nil
+}
+
 func (c *NodeContext) HasDefaultPayload() bool {
 	if c == nil {
 		return false
@@ -162,6 +206,7 @@ type NodeJoin struct {
 	NodeID     int64  `json:"node_id" db:"node_id"`
 	ParentName string `json:"parent_name,omitempty" db:"-"`
 	ParentID   int64  `json:"parent_id,omitempty" db:"parent_id"`
+	Optional   bool   `json:"optional,omitempty" db:"-"`
 }
 
 func (n *Node) nodeByRef(ref string) *Node {
@@ -0,0 +1,142 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Gate decisions, posted by the external system to the callback url of a gate node run.
+const (
+	GateDecisionApprove = "approve"
+	GateDecisionDeny    = "deny"
+)
+
+// GateDefaultTimeout is the time given to the external system to take a decision if not given.
+const GateDefaultTimeout = 24 * time.Hour
+
+// GateMaxTimeout is the max time given to the external system to take a decision.
+const GateMaxTimeout = 30 * 24 * time.Hour
+
+// NodeGate is the context of a node that waits for the decision of an external system, like a change management
+// tool. The decision is posted to a signed callback url generated for each node run.
+type NodeGate struct {
+	// Timeout is the time given to take a decision in seconds
+	Timeout int64 `json:"timeout,omitempty"`
+	// OnTimeout is the decision taken when the timeout is over, deny if not given
+	OnTimeout string `json:"on_timeout,omitempty"`
+	// Webhook is an url notified with the callback url when the gate is opened
+	Webhook string `json:"webhook,omitempty"`
+}
+
+// IsValid returns an error if the gate is not valid.
+func (g NodeGate) IsValid() error {
+	if g.Timeout < 0 || time.Duration(g.Timeout)*time.Second > GateMaxTimeout {
+		return NewErrorFrom(ErrWorkflowInvalid, "invalid gate timeout, should be between 0 and %d seconds", int64(GateMaxTimeout.Seconds()))
+	}
+	switch g.OnTimeout {
+	case "", GateDecisionApprove, GateDecisionDeny:
+	default:
+		return NewErrorFrom(ErrWorkflowInvalid, "invalid gate decision on timeout %q, should be %s or %s", g.OnTimeout, GateDecisionApprove, GateDecisionDeny)
+	}
+	if g.Webhook != "" {
+		u, err := url.Parse(g.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return NewErrorFrom(ErrWorkflowInvalid, "invalid gate webhook %q", g.Webhook)
+		}
+	}
+	return nil
+}
+
+// GetTimeout returns the time given to take a decision.
+func (g NodeGate) GetTimeout() time.Duration {
+	if g.Timeout == 0 {
+		return GateDefaultTimeout
+	}
+	return time.Duration(g.Timeout) * time.Second
+}
+
+// GetOnTimeout returns the decision taken when the timeout is over.
+func (g NodeGate) GetOnTimeout() string {
+	if g.OnTimeout == "" {
+		return GateDecisionDeny
+	}
+	return g.OnTimeout
+}
+
+// WorkflowNodeRunGate is the gate opened by a node run. The decision and its author are given by the callback
+// of the node run.
+type WorkflowNodeRunGate struct {
+	Expire    time.Time `json:"expire"`
+	OnTimeout string    `json:"on_timeout"`
+	Webhook   string    `json:"webhook,omitempty"`
+}
+
+// IsExpired returns true if no decision can be taken anymore on the gate.
+func (g WorkflowNodeRunGate) IsExpired(now time.Time) bool {
+	return !now.Before(g.Expire)
+}
+
+// WorkflowNodeRunGateDecision is the decision posted to the callback url of a gate node run.
+type WorkflowNodeRunGateDecision struct {
+	Decision string `json:"decision"`
+	Author   string `json:"author,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// IsValid returns an error if the decision is not valid.
+func (d WorkflowNodeRunGateDecision) IsValid() error {
+	if d.Decision != GateDecisionApprove && d.Decision != GateDecisionDeny {
+		return NewErrorFrom(ErrWrongRequest, "invalid gate decision %q, should be %s or %s", d.Decision, GateDecisionApprove, GateDecisionDeny)
+	}
+	return nil
+}
+
+// Status returns the status of the gate node run for the decision.
+func (d WorkflowNodeRunGateDecision) Status() string {
+	if d.Decision == GateDecisionApprove {
+		return StatusSuccess
+	}
+	return StatusFail
+}
+
+// String returns the decision as it is logged on the callback of the gate node run.
+func (d WorkflowNodeRunGateDecision) String() string {
+	s := "Denied"
+	if d.Decision == GateDecisionApprove {
+		s = "Approved"
+	}
+	if d.Author != "" {
+		s += " by " + d.Author
+	}
+	if d.Comment != "" {
+		s += ": " + d.Comment
+	}
+	return s
+}
+
+// WorkflowNodeRunGateURL is the signed callback url of a gate node run, the external system posts its decision
+// to this url without authentication.
+type WorkflowNodeRunGateURL struct {
+	URL    string    `json:"url" cli:"url"`
+	Expire time.Time `json:"expire" cli:"expire"`
+}
+
+// WorkflowNodeRunGateNotification is the body posted to the webhook of a gate when it is opened.
+type WorkflowNodeRunGateNotification struct {
+	ProjectKey   string    `json:"project_key"`
+	WorkflowName string    `json:"workflow_name"`
+	Number       int64     `json:"num"`
+	NodeName     string    `json:"node_name"`
+	URL          string    `json:"url"`
+	CallbackURL  string    `json:"callback_url"`
+	Expire       time.Time `json:"expire"`
+}
+
+// GateTimeoutDecision returns the decision taken on a gate node run when its timeout is over.
+func GateTimeoutDecision(g WorkflowNodeRunGate) WorkflowNodeRunGateDecision {
+	return WorkflowNodeRunGateDecision{
+		Decision: g.OnTimeout,
+		Comment:  fmt.Sprintf("no decision was taken before %s", g.Expire.Format(time.RFC3339)),
+	}
+}
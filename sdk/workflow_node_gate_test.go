@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeGateIsValid(t *testing.T) {
+	assert.NoError(t, NodeGate{}.IsValid())
+	assert.NoError(t, NodeGate{Timeout: 3600, OnTimeout: GateDecisionApprove, Webhook: "https://change.example.com/cds"}.IsValid())
+	assert.Error(t, NodeGate{Timeout: -1}.IsValid())
+	assert.Error(t, NodeGate{Timeout: int64(GateMaxTimeout.Seconds()) + 1}.IsValid())
+	assert.Error(t, NodeGate{OnTimeout: "skip"}.IsValid())
+	assert.Error(t, NodeGate{Webhook: "ftp://change.example.com"}.IsValid())
+	assert.Error(t, NodeGate{Webhook: "change.example.com"}.IsValid())
+}
+
+func TestNodeGateDefaults(t *testing.T) {
+	assert.Equal(t, GateDefaultTimeout, NodeGate{}.GetTimeout())
+	assert.Equal(t, time.Hour, NodeGate{Timeout: 3600}.GetTimeout())
+	assert.Equal(t, GateDecisionDeny, NodeGate{}.GetOnTimeout())
+	assert.Equal(t, GateDecisionApprove, NodeGate{OnTimeout: GateDecisionApprove}.GetOnTimeout())
+}
+
+func TestWorkflowNodeRunGateDecision(t *testing.T) {
+	assert.Error(t, WorkflowNodeRunGateDecision{}.IsValid())
+	assert.Error(t, WorkflowNodeRunGateDecision{Decision: "maybe"}.IsValid())
+
+	d := WorkflowNodeRunGateDecision{Decision: GateDecisionApprove, Author: "john", Comment: "CHG0012345"}
+	assert.NoError(t, d.IsValid())
+	assert.Equal(t, StatusSuccess, d.Status())
+	assert.Equal(t, "Approved by john: CHG0012345", d.String())
+
+	d = WorkflowNodeRunGateDecision{Decision: GateDecisionDeny}
+	assert.Equal(t, StatusFail, d.Status())
+	assert.Equal(t, "Denied", d.String())
+
+	now := time.Now()
+	g := WorkflowNodeRunGate{Expire: now, OnTimeout: GateDecisionDeny}
+	assert.True(t, g.IsExpired(now))
+	assert.False(t, g.IsExpired(now.Add(-time.Second)))
+	assert.Equal(t, StatusFail, GateTimeoutDecision(g).Status())
+}
@@ -0,0 +1,96 @@
+package sdk
+
+// Join policies, they define how many parents of a join must succeed to trigger it
+const (
+	JoinPolicyAll     = "all"
+	JoinPolicyAny     = "any"
+	JoinPolicyAtLeast = "at-least"
+)
+
+// JoinPolicies contains all the available join policies
+var JoinPolicies = []string{JoinPolicyAll, JoinPolicyAny, JoinPolicyAtLeast}
+
+// NodeJoinPolicy is the success policy of a join. The optional parents of the join are not awaited and are not
+// counted by the policy, all the other parents must succeed if the join has no policy.
+type NodeJoinPolicy struct {
+	Type string `json:"type"`
+	Min  int    `json:"min,omitempty"`
+}
+
+// IsValidJoinPolicy checks the policy and the optional parents of a join.
+func (n Node) IsValidJoinPolicy() error {
+	required := n.joinRequiredParents()
+	if required == 0 {
+		return NewErrorFrom(ErrWorkflowInvalid, "join %s must have at least one parent that is not optional", n.Name)
+	}
+	if n.JoinPolicy == nil {
+		return nil
+	}
+	switch n.JoinPolicy.Type {
+	case JoinPolicyAll, JoinPolicyAny:
+		if n.JoinPolicy.Min != 0 {
+			return NewErrorFrom(ErrWorkflowInvalid, "min of join %s can only be set with the policy %s", n.Name, JoinPolicyAtLeast)
+		}
+	case JoinPolicyAtLeast:
+		if n.JoinPolicy.Min < 1 || n.JoinPolicy.Min > required {
+			return NewErrorFrom(ErrWorkflowInvalid, "min of join %s must be between 1 and %d", n.Name, required)
+		}
+	default:
+		return NewErrorFrom(ErrWorkflowInvalid, "invalid policy %q for join %s, available policies are %v", n.JoinPolicy.Type, n.Name, JoinPolicies)
+	}
+	return nil
+}
+
+// JoinMinSuccess returns the number of parents that are not optional that must succeed to trigger the join.
+func (n Node) JoinMinSuccess() int {
+	required := n.joinRequiredParents()
+	if n.JoinPolicy == nil {
+		return required
+	}
+	switch n.JoinPolicy.Type {
+	case JoinPolicyAny:
+		return 1
+	case JoinPolicyAtLeast:
+		return n.JoinPolicy.Min
+	default:
+		return required
+	}
+}
+
+func (n Node) joinRequiredParents() int {
+	var required int
+	for _, j := range n.JoinContext {
+		if !j.Optional {
+			required++
+		}
+	}
+	return required
+}
+
+// JoinSources returns the last runs of the parents of a join that succeeded, and true if they satisfy the policy
+// of the join. The runs of the optional parents that already succeeded are part of the sources.
+func (n Node) JoinSources(runs map[int64][]WorkflowNodeRun) ([]*WorkflowNodeRun, bool) {
+	sources := make([]*WorkflowNodeRun, 0, len(n.JoinContext))
+	var success int
+	for _, j := range n.JoinContext {
+		nrs, has := runs[j.ParentID]
+		if !has || len(nrs) == 0 {
+			continue
+		}
+		// Get latest run on parent
+		nr := &nrs[0]
+		if !IsJoinSourceSuccess(nr.Status) {
+			continue
+		}
+		sources = append(sources, nr)
+		if !j.Optional {
+			success++
+		}
+	}
+	return sources, success >= n.JoinMinSuccess()
+}
+
+// IsJoinSourceSuccess returns true if the run of a parent of a join is terminated and can trigger the join.
+func IsJoinSourceSuccess(status string) bool {
+	return StatusIsTerminated(status) && status != StatusFail && status != StatusNeverBuilt && status != StatusStopped
+}
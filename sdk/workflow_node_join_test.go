@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeIsValidJoinPolicy(t *testing.T) {
+	parents := []NodeJoin{{ParentID: 1}, {ParentID: 2}, {ParentID: 3, Optional: true}}
+	assert.NoError(t, Node{Name: "join", JoinContext: parents}.IsValidJoinPolicy())
+	assert.NoError(t, Node{Name: "join", JoinContext: parents, JoinPolicy: &NodeJoinPolicy{Type: JoinPolicyAny}}.IsValidJoinPolicy())
+	assert.NoError(t, Node{Name: "join", JoinContext: parents, JoinPolicy: &NodeJoinPolicy{Type: JoinPolicyAtLeast, Min: 2}}.IsValidJoinPolicy())
+	assert.Error(t, Node{Name: "join", JoinContext: parents, JoinPolicy: &NodeJoinPolicy{Type: JoinPolicyAtLeast, Min: 3}}.IsValidJoinPolicy())
+	assert.Error(t, Node{Name: "join", JoinContext: parents, JoinPolicy: &NodeJoinPolicy{Type: JoinPolicyAny, Min: 1}}.IsValidJoinPolicy())
+	assert.Error(t, Node{Name: "join", JoinContext: parents, JoinPolicy: &NodeJoinPolicy{Type: "unknown"}}.IsValidJoinPolicy())
+	assert.Error(t, Node{Name: "join", JoinContext: []NodeJoin{{ParentID: 1, Optional: true}}}.IsValidJoinPolicy())
+}
+
+func TestNodeJoinSources(t *testing.T) {
+	join := Node{JoinContext: []NodeJoin{{ParentID: 1}, {ParentID: 2}, {ParentID: 3, Optional: true}}}
+	runs := map[int64][]WorkflowNodeRun{
+		1: {{ID: 11, Status: StatusSuccess}, {ID: 10, Status: StatusFail}},
+		2: {{ID: 20, Status: StatusBuilding}},
+		3: {{ID: 30, Status: StatusSuccess}},
+	}
+
+	sources, ok := join.JoinSources(runs)
+	assert.False(t, ok)
+	require.Len(t, sources, 2)
+	assert.Equal(t, int64(11), sources[0].ID)
+	assert.Equal(t, int64(30), sources[1].ID)
+
+	join.JoinPolicy = &NodeJoinPolicy{Type: JoinPolicyAny}
+	_, ok = join.JoinSources(runs)
+	assert.True(t, ok)
+
+	join.JoinPolicy = &NodeJoinPolicy{Type: JoinPolicyAtLeast, Min: 2}
+	_, ok = join.JoinSources(runs)
+	assert.False(t, ok)
+
+	runs[2][0].Status = StatusFail
+	join.JoinPolicy = nil
+	_, ok = join.JoinSources(runs)
+	assert.False(t, ok)
+
+	runs[2][0].Status = StatusSuccess
+	_, ok = join.JoinSources(runs)
+	assert.True(t, ok)
+}
@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The outputs of a node run are exported by its jobs with the worker export command. They are available in the
+// following stages of the pipeline as cds.output.key, and in the downstream nodes as outputs.nodename.key.
+const (
+	NodeRunOutputPrefix = "cds.output."
+	NodeOutputsPrefix   = "outputs."
+)
+
+// NodeOutputKeyRegex is the pattern of the key of an output, the key can't contain a dot.
+var NodeOutputKeyRegex = regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+
+// IsNodeRunOutput returns true if the parameter is an output exported by a job of the node run.
+func IsNodeRunOutput(name string) bool {
+	return strings.HasPrefix(name, NodeRunOutputPrefix)
+}
+
+// NodeOutputParameterName returns the name of the parameter of an output of a node in the downstream nodes.
+func NodeOutputParameterName(nodeName, name string) string {
+	return NodeOutputsPrefix + nodeName + "." + strings.TrimPrefix(name, NodeRunOutputPrefix)
+}
@@ -0,0 +1,16 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeOutputParameterName(t *testing.T) {
+	assert.True(t, IsNodeRunOutput("cds.output.version"))
+	assert.False(t, IsNodeRunOutput("cds.build.version"))
+	assert.Equal(t, "outputs.build-app.version", NodeOutputParameterName("build-app", "cds.output.version"))
+
+	assert.True(t, NodeOutputKeyRegex.MatchString("image_tag"))
+	assert.False(t, NodeOutputKeyRegex.MatchString("image.tag"))
+}
@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/fsamin/go-dump"
+)
+
+// NodeSubWorkflow is the context of a node that runs another workflow of the same project. The node run waits
+// for the end of the run of the called workflow and takes its status.
+type NodeSubWorkflow struct {
+	WorkflowName string `json:"workflow_name"`
+	// Payload is a JSON object given to the run of the called workflow, it is interpolated with the
+	// parameters of the parent node runs.
+	Payload string `json:"payload,omitempty"`
+}
+
+// IsValid returns an error if the called workflow is the workflow itself or if the payload is not a JSON object.
+func (s NodeSubWorkflow) IsValid(w *Workflow) error {
+	if !NamePatternRegex.MatchString(s.WorkflowName) {
+		return NewErrorFrom(ErrWorkflowInvalid, "invalid sub-workflow name %q", s.WorkflowName)
+	}
+	if s.WorkflowName == w.Name {
+		return NewErrorFrom(ErrWorkflowInvalid, "workflow %s can't call itself", w.Name)
+	}
+	if s.Payload != "" {
+		var payload map[string]interface{}
+		if err := json.Unmarshal([]byte(s.Payload), &payload); err != nil {
+			return NewErrorFrom(ErrWorkflowInvalid, "invalid payload for sub-workflow %s, it should be a JSON object", s.WorkflowName)
+		}
+	}
+	return nil
+}
+
+// WorkflowNodeRunSubWorkflow is the call of a workflow by a node run. The number of the run of the called
+// workflow and its status are given by the callback of the node run.
+type WorkflowNodeRunSubWorkflow struct {
+	WorkflowName string            `json:"workflow_name"`
+	Payload      map[string]string `json:"payload,omitempty"`
+}
+
+// IsSubWorkflowCall returns true if the event starts the run of a workflow called by a sub-workflow node. The
+// event has no hook, the node run of the parent workflow is given by the hook run id.
+func (e WorkflowNodeRunHookEvent) IsSubWorkflowCall() bool {
+	return e.WorkflowNodeHookUUID == "" && e.ParentWorkflow.Key != "" && e.ParentWorkflow.HookRunID != ""
+}
+
+// SubWorkflowPayloadToMap returns the values of an interpolated sub-workflow payload as they are given to the
+// run of the called workflow, the raw payload is given as "payload".
+func SubWorkflowPayloadToMap(payload string) (map[string]string, error) {
+	res := map[string]string{}
+	if payload == "" || payload == "{}" {
+		return res, nil
+	}
+	var i interface{}
+	if err := json.Unmarshal([]byte(payload), &i); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid sub-workflow payload: %v", err)
+	}
+	e := dump.NewDefaultEncoder()
+	e.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
+	e.ExtraFields.DetailedMap = false
+	e.ExtraFields.DetailedStruct = false
+	e.ExtraFields.Len = false
+	e.ExtraFields.Type = false
+	m, err := e.ToStringMap(i)
+	if err != nil {
+		return nil, WrapError(err, "cannot convert sub-workflow payload")
+	}
+	for k, v := range m {
+		res[k] = v
+	}
+	res["payload"] = payload
+	return res, nil
+}
+
+// WorkflowRunLink is a run of a workflow that called, or was called by, another run.
+type WorkflowRunLink struct {
+	ProjectKey   string `json:"project_key" cli:"project"`
+	WorkflowName string `json:"workflow_name" cli:"workflow"`
+	Number       int64  `json:"num" cli:"num"`
+	NodeName     string `json:"node_name,omitempty" cli:"node"`
+	Status       string `json:"status,omitempty" cli:"status"`
+}
+
+// WorkflowRunLinks are the runs linked to a workflow run: the parent run that triggered it and the runs of the
+// workflows it triggered, with sub-workflow nodes or with outgoing workflow hooks.
+type WorkflowRunLinks struct {
+	Parent   *WorkflowRunLink  `json:"parent,omitempty"`
+	Children []WorkflowRunLink `json:"children"`
+}
+
+// Links returns the parent and the children of a workflow run. The status of a child is the last one received
+// by the node run that triggered it.
+func (r *WorkflowRun) Links() WorkflowRunLinks {
+	res := WorkflowRunLinks{Children: []WorkflowRunLink{}}
+	if r.HasParentWorkflow() {
+		parent := r.RootRun().HookEvent.ParentWorkflow
+		res.Parent = &WorkflowRunLink{
+			ProjectKey:   parent.Key,
+			WorkflowName: parent.Name,
+			Number:       parent.Run,
+		}
+	}
+
+	ids := make([]int64, 0, len(r.WorkflowNodeRuns))
+	for id := range r.WorkflowNodeRuns {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		for _, nr := range r.WorkflowNodeRuns[id] {
+			if nr.Callback == nil || nr.Callback.WorkflowRunNumber == nil {
+				continue
+			}
+			link := WorkflowRunLink{
+				Number:   *nr.Callback.WorkflowRunNumber,
+				NodeName: nr.WorkflowNodeName,
+				Status:   nr.Callback.Status,
+			}
+			switch {
+			case nr.SubWorkflow != nil:
+				link.ProjectKey = r.Workflow.ProjectKey
+				link.WorkflowName = nr.SubWorkflow.WorkflowName
+			case nr.OutgoingHook != nil:
+				link.ProjectKey = nr.OutgoingHook.Config[HookConfigTargetProject].Value
+				link.WorkflowName = nr.OutgoingHook.Config[HookConfigTargetWorkflow].Value
+			default:
+				continue
+			}
+			res.Children = append(res.Children, link)
+		}
+	}
+	return res
+}
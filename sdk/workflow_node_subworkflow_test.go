@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeSubWorkflowIsValid(t *testing.T) {
+	w := &Workflow{Name: "release"}
+	assert.NoError(t, NodeSubWorkflow{WorkflowName: "infra"}.IsValid(w))
+	assert.NoError(t, NodeSubWorkflow{WorkflowName: "infra", Payload: `{"env": "prod"}`}.IsValid(w))
+	assert.Error(t, NodeSubWorkflow{WorkflowName: "release"}.IsValid(w))
+	assert.Error(t, NodeSubWorkflow{WorkflowName: "my infra"}.IsValid(w))
+	assert.Error(t, NodeSubWorkflow{WorkflowName: "infra", Payload: `["prod"]`}.IsValid(w))
+}
+
+func TestWorkflowNodeRunHookEventIsSubWorkflowCall(t *testing.T) {
+	var e WorkflowNodeRunHookEvent
+	e.ParentWorkflow.Key = "PROJ"
+	e.ParentWorkflow.HookRunID = "abcd"
+	assert.True(t, e.IsSubWorkflowCall())
+
+	e.WorkflowNodeHookUUID = "efgh"
+	assert.False(t, e.IsSubWorkflowCall())
+}
+
+func TestSubWorkflowPayloadToMap(t *testing.T) {
+	m, err := SubWorkflowPayloadToMap("{}")
+	require.NoError(t, err)
+	assert.Len(t, m, 0)
+
+	m, err = SubWorkflowPayloadToMap(`{"env": "prod", "Git": {"Branch": "master"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", m["env"])
+	assert.Equal(t, "master", m["git.branch"])
+	assert.Equal(t, `{"env": "prod", "Git": {"Branch": "master"}}`, m["payload"])
+
+	_, err = SubWorkflowPayloadToMap("env")
+	assert.Error(t, err)
+}
+
+func TestWorkflowRunLinks(t *testing.T) {
+	child, other := int64(3), int64(7)
+	r := WorkflowRun{
+		Workflow: Workflow{ProjectKey: "PROJ", WorkflowData: &WorkflowData{Node: Node{ID: 3}}},
+		WorkflowNodeRuns: map[int64][]WorkflowNodeRun{
+			2: {{
+				WorkflowNodeName: "deploy",
+				OutgoingHook: &NodeOutGoingHook{Config: WorkflowNodeHookConfig{
+					HookConfigTargetProject:  {Value: "OTHER"},
+					HookConfigTargetWorkflow: {Value: "deploy"},
+				}},
+				Callback: &WorkflowNodeOutgoingHookRunCallback{WorkflowRunNumber: &other, Status: StatusBuilding},
+			}},
+			1: {{
+				WorkflowNodeName: "infra",
+				SubWorkflow:      &WorkflowNodeRunSubWorkflow{WorkflowName: "infra"},
+				Callback:         &WorkflowNodeOutgoingHookRunCallback{WorkflowRunNumber: &child, Status: StatusSuccess},
+			}},
+			3: {{WorkflowNodeName: "build", Status: StatusSuccess}},
+		},
+	}
+
+	links := r.Links()
+	assert.Nil(t, links.Parent)
+	require.Len(t, links.Children, 2)
+	assert.Equal(t, WorkflowRunLink{ProjectKey: "PROJ", WorkflowName: "infra", Number: 3, NodeName: "infra", Status: StatusSuccess}, links.Children[0])
+	assert.Equal(t, WorkflowRunLink{ProjectKey: "OTHER", WorkflowName: "deploy", Number: 7, NodeName: "deploy", Status: StatusBuilding}, links.Children[1])
+}
@@ -137,3 +137,12 @@ func TestAncestorsSimple(t *testing.T) {
 
 	}
 }
+
+func TestNodeContextCheckConcurrency(t *testing.T) {
+	assert.NoError(t, (&NodeContext{}).CheckConcurrency())
+	assert.NoError(t, (&NodeContext{ConcurrencyGroup: "deploy-prod"}).CheckConcurrency())
+	assert.NoError(t, (&NodeContext{ConcurrencyGroup: "deploy-prod", ConcurrencyPolicy: ConcurrencyPolicyCancelSuperseded}).CheckConcurrency())
+	assert.Error(t, (&NodeContext{ConcurrencyPolicy: ConcurrencyPolicyQueue}).CheckConcurrency())
+	assert.Error(t, (&NodeContext{ConcurrencyGroup: "deploy prod"}).CheckConcurrency())
+	assert.Error(t, (&NodeContext{ConcurrencyGroup: "deploy-prod", ConcurrencyPolicy: "unknown"}).CheckConcurrency())
+}
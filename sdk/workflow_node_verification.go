@@ -0,0 +1,81 @@
+package sdk
+
+// CheckVerification returns an error if the node verifies a deployment node that is not one of its ancestors, or
+// if its rollback node is not a pipeline or a sub-workflow node of the workflow.
+func (n *Node) CheckVerification(w *WorkflowData) error {
+	if n.Context == nil || (n.Context.VerificationOf == "" && n.Context.RollbackNode == "") {
+		return nil
+	}
+	c := n.Context
+	if c.VerificationOf == "" || c.RollbackNode == "" {
+		return NewErrorFrom(ErrWorkflowInvalid, "node %s: a verification needs a deployment node and a rollback node", n.Name)
+	}
+
+	deployment := w.NodeByName(c.VerificationOf)
+	if deployment == nil || deployment.Context == nil || (deployment.Context.EnvironmentID == 0 && deployment.Context.EnvironmentName == "") {
+		return NewErrorFrom(ErrWorkflowInvalid, "node %s: verified node %s should be a pipeline linked to an environment", n.Name, c.VerificationOf)
+	}
+	if !w.IsAncestor(c.VerificationOf, *n) {
+		return NewErrorFrom(ErrWorkflowInvalid, "node %s: verified node %s should be one of its ancestors", n.Name, c.VerificationOf)
+	}
+
+	rollback := w.NodeByName(c.RollbackNode)
+	if rollback == nil || rollback.Name == n.Name || rollback.Name == deployment.Name {
+		return NewErrorFrom(ErrWorkflowInvalid, "node %s: invalid rollback node %s", n.Name, c.RollbackNode)
+	}
+	if rollback.Type != NodeTypePipeline && rollback.Type != NodeTypeSubWorkflow {
+		return NewErrorFrom(ErrWorkflowInvalid, "node %s: rollback node %s should be a pipeline or a sub-workflow", n.Name, c.RollbackNode)
+	}
+	return nil
+}
+
+// IsAncestor returns true if the node with given name is an ancestor of the node n.
+func (w *WorkflowData) IsAncestor(name string, n Node) bool {
+	visited := map[string]bool{n.Name: true}
+	parents := w.AncestorsNames(n)
+	for len(parents) > 0 {
+		p := parents[0]
+		parents = parents[1:]
+		if p == name {
+			return true
+		}
+		if visited[p] {
+			continue
+		}
+		visited[p] = true
+		if node := w.NodeByName(p); node != nil {
+			parents = append(parents, w.AncestorsNames(*node)...)
+		}
+	}
+	return false
+}
+
+// IsRollbackNode returns true if the node with given name is the rollback node of a verification. A rollback node
+// is not triggered by its parents, it only runs when the verification fails or when it is run manually.
+func (w *WorkflowData) IsRollbackNode(name string) bool {
+	for _, n := range w.Array() {
+		if n.Context != nil && n.Context.VerificationOf != "" && n.Context.RollbackNode == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RollbackPayload returns the payload of the run of a rollback node, with the version and the commit of the
+// deployment to roll back to. The commit is not given as git.* values, they are overridden by the parents of the node.
+func RollbackPayload(verification, deployment string, previous WorkflowNodeRun) map[string]interface{} {
+	rollback := map[string]interface{}{
+		"verification": verification,
+		"deployment":   deployment,
+		"number":       previous.Number,
+		"version":      ParameterValue(previous.BuildParameters, "cds.version"),
+	}
+	if previous.VCSHash != "" {
+		rollback["git"] = map[string]interface{}{
+			"hash":   previous.VCSHash,
+			"branch": previous.VCSBranch,
+			"tag":    previous.VCSTag,
+		}
+	}
+	return map[string]interface{}{"rollback": rollback}
+}
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testVerificationWorkflow(verification NodeContext) *WorkflowData {
+	return &WorkflowData{
+		Node: Node{
+			Name:    "build",
+			Type:    NodeTypePipeline,
+			Context: &NodeContext{PipelineName: "build"},
+			Triggers: []NodeTrigger{{
+				ChildNode: Node{
+					Name:    "deploy",
+					Type:    NodeTypePipeline,
+					Context: &NodeContext{PipelineName: "deploy", EnvironmentName: "production"},
+					Triggers: []NodeTrigger{
+						{ChildNode: Node{Name: "rollback", Type: NodeTypePipeline, Context: &NodeContext{PipelineName: "deploy", EnvironmentName: "production"}}},
+						{ChildNode: Node{Name: "smoke-tests", Type: NodeTypePipeline, Context: &verification}},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestNodeCheckVerification(t *testing.T) {
+	tests := []struct {
+		name    string
+		context NodeContext
+		wantErr bool
+	}{
+		{name: "no verification", context: NodeContext{}},
+		{name: "valid", context: NodeContext{VerificationOf: "deploy", RollbackNode: "rollback"}},
+		{name: "no rollback", context: NodeContext{VerificationOf: "deploy"}, wantErr: true},
+		{name: "no deployment", context: NodeContext{RollbackNode: "rollback"}, wantErr: true},
+		{name: "unknown deployment", context: NodeContext{VerificationOf: "unknown", RollbackNode: "rollback"}, wantErr: true},
+		{name: "deployment without environment", context: NodeContext{VerificationOf: "build", RollbackNode: "rollback"}, wantErr: true},
+		{name: "rollback is the deployment", context: NodeContext{VerificationOf: "deploy", RollbackNode: "deploy"}, wantErr: true},
+		{name: "rollback is the verification", context: NodeContext{VerificationOf: "deploy", RollbackNode: "smoke-tests"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := testVerificationWorkflow(tt.context)
+			err := w.NodeByName("smoke-tests").CheckVerification(w)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// The verified node should be an ancestor of the verification
+	w := testVerificationWorkflow(NodeContext{})
+	w.NodeByName("rollback").Context.VerificationOf = "deploy"
+	w.NodeByName("rollback").Context.RollbackNode = "smoke-tests"
+	assert.NoError(t, w.NodeByName("rollback").CheckVerification(w))
+	w.NodeByName("deploy").Context.VerificationOf = "rollback"
+	w.NodeByName("deploy").Context.RollbackNode = "smoke-tests"
+	assert.Error(t, w.NodeByName("deploy").CheckVerification(w))
+}
+
+func TestWorkflowDataIsRollbackNode(t *testing.T) {
+	w := testVerificationWorkflow(NodeContext{VerificationOf: "deploy", RollbackNode: "rollback"})
+	assert.True(t, w.IsRollbackNode("rollback"))
+	assert.False(t, w.IsRollbackNode("deploy"))
+	assert.True(t, w.IsAncestor("build", *w.NodeByName("smoke-tests")))
+	assert.False(t, w.IsAncestor("rollback", *w.NodeByName("smoke-tests")))
+}
+
+func TestRollbackPayload(t *testing.T) {
+	previous := WorkflowNodeRun{
+		Number:          41,
+		VCSHash:         "a1b2c3",
+		VCSBranch:       "master",
+		BuildParameters: []Parameter{{Name: "cds.version", Value: "1.4.2"}},
+	}
+	payload := RollbackPayload("smoke-tests", "deploy", previous)
+	assert.Equal(t, map[string]interface{}{
+		"rollback": map[string]interface{}{
+			"verification": "smoke-tests",
+			"deployment":   "deploy",
+			"number":       int64(41),
+			"version":      "1.4.2",
+			"git": map[string]interface{}{
+				"hash":   "a1b2c3",
+				"branch": "master",
+				"tag":    "",
+			},
+		},
+	}, payload)
+
+	previous.VCSHash = ""
+	_, hasGit := RollbackPayload("smoke-tests", "deploy", previous)["rollback"].(map[string]interface{})["git"]
+	assert.False(t, hasGit)
+}
@@ -52,6 +52,9 @@ type WorkflowRun struct {
 	ToDelete         bool                             `json:"to_delete" db:"to_delete" cli:"-"`
 	JoinTriggersRun  map[int64]WorkflowNodeTriggerRun `json:"join_triggers_run,omitempty" db:"-"`
 	Header           WorkflowRunHeaders               `json:"header,omitempty" db:"-"`
+	Priority         int                              `json:"priority" db:"priority"`
+	Archived         bool                             `json:"archived,omitempty" db:"archived" cli:"archived"`
+	FreezeOverride   *WorkflowRunFreeze               `json:"freeze_override,omitempty" db:"freeze_override"`
 }
 
 // WorkflowNodeRunRelease represents the request struct use by release builtin action for workflow
@@ -64,10 +67,12 @@ type WorkflowNodeRunRelease struct {
 
 // WorkflowRunPostHandlerOption contains the body content for launch a workflow
 type WorkflowRunPostHandlerOption struct {
-	Hook        *WorkflowNodeRunHookEvent `json:"hook,omitempty"`
-	Manual      *WorkflowNodeRunManual    `json:"manual,omitempty"`
-	Number      *int64                    `json:"number,omitempty"`
-	FromNodeIDs []int64                   `json:"from_nodes,omitempty"`
+	Hook           *WorkflowNodeRunHookEvent `json:"hook,omitempty"`
+	Manual         *WorkflowNodeRunManual    `json:"manual,omitempty"`
+	Number         *int64                    `json:"number,omitempty"`
+	FromNodeIDs    []int64                   `json:"from_nodes,omitempty"`
+	Priority       *int                      `json:"priority,omitempty"`
+	FreezeOverride string                    `json:"freeze_override,omitempty"`
 }
 
 //WorkflowRunNumber contains a workflow run number
@@ -87,7 +92,7 @@ func (r *WorkflowRun) PendingOutgoingHook() map[string]*WorkflowNodeRun {
 	nrs := make(map[string]*WorkflowNodeRun)
 	for i := range r.WorkflowNodeRuns {
 		runs := r.WorkflowNodeRuns[i]
-		if len(runs) > 0 && runs[0].OutgoingHook == nil {
+		if len(runs) > 0 && runs[0].OutgoingHook == nil && runs[0].SubWorkflow == nil && runs[0].Gate == nil {
 			continue
 		}
 		for j := range runs {
@@ -141,6 +146,16 @@ func (r *WorkflowRun) TagExists(tag string) bool {
 	return false
 }
 
+// TagValue returns the value of given tag, empty if it does not exist
+func (r *WorkflowRun) TagValue(tag string) string {
+	for i := range r.Tags {
+		if r.Tags[i].Tag == tag {
+			return r.Tags[i].Value
+		}
+	}
+	return ""
+}
+
 func (r *WorkflowRun) RootRun() *WorkflowNodeRun {
 	rootNodeRuns, has := r.WorkflowNodeRuns[r.Workflow.WorkflowData.Node.ID]
 	if !has || len(rootNodeRuns) < 1 {
@@ -169,7 +184,7 @@ func (r *WorkflowRun) HasParentWorkflow() bool {
 func (r *WorkflowRun) GetOutgoingHookRun(uuid string) *WorkflowNodeRun {
 	for i := range r.WorkflowNodeRuns {
 		nodeRuns := r.WorkflowNodeRuns[i]
-		if len(nodeRuns) == 0 || nodeRuns[0].OutgoingHook == nil {
+		if len(nodeRuns) == 0 || (nodeRuns[0].OutgoingHook == nil && nodeRuns[0].SubWorkflow == nil && nodeRuns[0].Gate == nil) {
 			continue
 		}
 		for j := range nodeRuns {
@@ -207,6 +222,9 @@ type WorkflowNodeRun struct {
 	ID                     int64                                `json:"id"`
 	WorkflowNodeID         int64                                `json:"workflow_node_id"`
 	WorkflowNodeName       string                               `json:"workflow_node_name"`
+	ConcurrencyGroup       string                               `json:"concurrency_group,omitempty"`
+	ProductionDeployment   bool                                 `json:"production_deployment,omitempty"`
+	Frozen                 bool                                 `json:"frozen,omitempty"`
 	Number                 int64                                `json:"num"`
 	SubNumber              int64                                `json:"subnumber"`
 	Status                 string                               `json:"status"`
@@ -226,6 +244,7 @@ type WorkflowNodeRun struct {
 	VulnerabilitiesReport  WorkflowNodeRunVulnerabilityReport   `json:"vulnerabilities_report,omitempty"`
 	Tests                  *venom.Tests                         `json:"tests,omitempty"`
 	Commits                []VCSCommit                          `json:"commits,omitempty"`
+	Changelog              *WorkflowRunChangelog                `json:"changelog,omitempty"`
 	TriggersRun            map[int64]WorkflowNodeTriggerRun     `json:"triggers_run,omitempty"`
 	VCSRepository          string                               `json:"vcs_repository"`
 	VCSTag                 string                               `json:"vcs_tag"`
@@ -239,6 +258,8 @@ type WorkflowNodeRun struct {
 	HookExecutionTimeStamp int64                                `json:"hook_execution_timestamp,omitempty"`
 	HookExecutionID        string                               `json:"execution_id,omitempty"`
 	Callback               *WorkflowNodeOutgoingHookRunCallback `json:"callback,omitempty"`
+	SubWorkflow            *WorkflowNodeRunSubWorkflow          `json:"subworkflow,omitempty"`
+	Gate                   *WorkflowNodeRunGate                 `json:"gate,omitempty"`
 	VCSReport              string                               `json:"vcs_report,omitempty"`
 }
 
@@ -281,6 +302,7 @@ type WorkflowNodeRunCoverage struct {
 	Num               int64                         `json:"run_number" db:"run_number"`
 	Repository        string                        `json:"repository" db:"repository"`
 	Branch            string                        `json:"branch" db:"branch"`
+	Percent           float64                       `json:"percent" db:"percent"`
 	Report            coverage.Report               `json:"report" db:"-"`
 	Trend             WorkflowNodeRunCoverageTrends `json:"trend" db:"-"`
 }
@@ -325,6 +347,7 @@ type WorkflowNodeRunArtifact struct {
 	TempURL              string    `json:"temp_url,omitempty" db:"-"`
 	TempURLSecretKey     string    `json:"-" db:"-"`
 	ProjectIntegrationID *int64    `json:"project_integration_id" db:"project_integration_id"`
+	Archived             bool      `json:"archived,omitempty" db:"archived"`
 }
 
 // Equal returns true if w WorkflowNodeRunArtifact equals c
@@ -366,6 +389,7 @@ type WorkflowNodeJobRun struct {
 	IntegrationPluginBinaries []GRPCPluginBinary `json:"integration_plugin_binaries,omitempty"`
 	Header                    WorkflowRunHeaders `json:"header,omitempty"`
 	ContainsService           bool               `json:"contains_service,omitempty"`
+	Priority                  int                `json:"priority,omitempty"`
 }
 
 // WorkflowNodeJobRunSummary is a light representation of WorkflowNodeJobRun for CDS event
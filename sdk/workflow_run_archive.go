@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// ProjectRetentionSettings configures the archival of the workflow runs of a project. The logs and the artifacts of
+// the runs terminated for more than ArchiveAfterDays days are moved to the storage integration IntegrationName, or to
+// the shared storage if it's empty. The archived runs can still be listed, and rehydrated on demand.
+type ProjectRetentionSettings struct {
+	ProjectID int64 `json:"project_id" db:"project_id" cli:"-"`
+	// ArchiveAfterDays is the age in days of the runs to archive, 0 means that the runs are never archived
+	ArchiveAfterDays int `json:"archive_after_days" db:"archive_after_days" cli:"archive_after_days"`
+	// IntegrationName is the name of the storage integration of the project used to store the archives
+	IntegrationName string `json:"integration_name" db:"integration_name" cli:"integration_name"`
+}
+
+// IsValid returns an error if the settings are not valid.
+func (s ProjectRetentionSettings) IsValid() error {
+	if s.ArchiveAfterDays < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid archive after days %d", s.ArchiveAfterDays)
+	}
+	return nil
+}
+
+// StorageIntegrationName returns the name of the storage integration used to store the archives.
+func (s ProjectRetentionSettings) StorageIntegrationName() string {
+	if s.IntegrationName == "" {
+		return DefaultStorageIntegrationName
+	}
+	return s.IntegrationName
+}
+
+// WorkflowRunArchive describes where the logs and the artifacts of an archived workflow run are stored.
+type WorkflowRunArchive struct {
+	WorkflowRunID   int64     `json:"workflow_run_id" db:"workflow_run_id"`
+	IntegrationName string    `json:"integration_name" db:"integration_name"`
+	Container       string    `json:"container" db:"container"`
+	Size            int64     `json:"size" db:"size"`
+	Created         time.Time `json:"created" db:"created"`
+}
+
+// NewWorkflowRunArchive returns the archive of a workflow run, stored in a storage integration.
+func NewWorkflowRunArchive(workflowRunID int64, integrationName string) WorkflowRunArchive {
+	return WorkflowRunArchive{
+		WorkflowRunID:   workflowRunID,
+		IntegrationName: integrationName,
+		Container:       fmt.Sprintf("archive-%d", workflowRunID),
+		Created:         time.Now(),
+	}
+}
+
+// LogsObject returns the object containing the logs of the archived run.
+func (a WorkflowRunArchive) LogsObject() WorkflowRunArchiveObject {
+	return WorkflowRunArchiveObject{Container: a.Container, Name: "logs.json.gz"}
+}
+
+// ArtifactObject returns the object containing an artifact of the archived run.
+func (a WorkflowRunArchive) ArtifactObject(art WorkflowNodeRunArtifact) WorkflowRunArchiveObject {
+	return WorkflowRunArchiveObject{Container: a.Container, Name: fmt.Sprintf("artifact-%d", art.ID)}
+}
+
+// WorkflowRunArchiveObject is an object stored in the container of a workflow run archive.
+type WorkflowRunArchiveObject struct {
+	Container string
+	Name      string
+}
+
+// GetName returns the name of the object.
+func (o WorkflowRunArchiveObject) GetName() string {
+	return o.Name
+}
+
+// GetPath returns the container of the object.
+func (o WorkflowRunArchiveObject) GetPath() string {
+	return o.Container
+}
+
+// WorkflowRunArchiveLogs contains the logs of the steps and of the services of the jobs of an archived run.
+type WorkflowRunArchiveLogs struct {
+	Steps    []Log        `json:"steps"`
+	Services []ServiceLog `json:"services"`
+}
@@ -0,0 +1,224 @@
+package sdk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// Status of an artifact compared with the previous successful run.
+const (
+	ArtifactDiffStatusAdded     = "added"
+	ArtifactDiffStatusRemoved   = "removed"
+	ArtifactDiffStatusChanged   = "changed"
+	ArtifactDiffStatusUnchanged = "unchanged"
+)
+
+// MaxArtifactDiffArchiveSize is the max size of an archive that is read to compare the files inside it.
+const MaxArtifactDiffArchiveSize = 200 * 1024 * 1024
+
+// WorkflowRunArtifactsDiff compares the artifacts of a workflow run with the artifacts of the last successful run
+// on the same branch.
+type WorkflowRunArtifactsDiff struct {
+	Number         int64                     `json:"number"`
+	PreviousNumber int64                     `json:"previous_number,omitempty"`
+	Branch         string                    `json:"branch"`
+	Hash           string                    `json:"hash"`
+	PreviousHash   string                    `json:"previous_hash,omitempty"`
+	Artifacts      []WorkflowRunArtifactDiff `json:"artifacts"`
+}
+
+// WorkflowRunArtifactDiff is the result of the comparison of an artifact, identified by its name and the name of
+// the node that uploaded it. An artifact that changed while the commit did not is flagged as unexpected, it usually
+// means that the build is not reproducible.
+type WorkflowRunArtifactDiff struct {
+	NodeName     string                   `json:"node_name" cli:"node,key"`
+	Name         string                   `json:"name" cli:"name,key"`
+	Status       string                   `json:"status" cli:"status"`
+	Unexpected   bool                     `json:"unexpected" cli:"unexpected"`
+	Current      *WorkflowNodeRunArtifact `json:"current,omitempty" cli:"-"`
+	Previous     *WorkflowNodeRunArtifact `json:"previous,omitempty" cli:"-"`
+	SizeDelta    int64                    `json:"size_delta" cli:"size_delta"`
+	FilesAdded   []string                 `json:"files_added,omitempty" cli:"files_added"`
+	FilesRemoved []string                 `json:"files_removed,omitempty" cli:"files_removed"`
+	FilesChanged []string                 `json:"files_changed,omitempty" cli:"files_changed"`
+}
+
+// Unexpected returns true if at least one artifact changed unexpectedly.
+func (d WorkflowRunArtifactsDiff) Unexpected() bool {
+	for _, a := range d.Artifacts {
+		if a.Unexpected {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeRunArtifact is an artifact with the name of the node that uploaded it.
+type NodeRunArtifact struct {
+	NodeName string
+	WorkflowNodeRunArtifact
+}
+
+// DiffWorkflowRunArtifacts compares the artifacts of two runs, if the commit hash of both runs is the same, all the
+// changed artifacts are flagged as unexpected.
+func DiffWorkflowRunArtifacts(current, previous []NodeRunArtifact, sameCommit bool) []WorkflowRunArtifactDiff {
+	key := func(a NodeRunArtifact) string { return a.NodeName + "/" + a.Name }
+	previousByKey := make(map[string]NodeRunArtifact, len(previous))
+	for _, a := range previous {
+		previousByKey[key(a)] = a
+	}
+
+	diffs := make([]WorkflowRunArtifactDiff, 0, len(current)+len(previous))
+	for i := range current {
+		c := current[i]
+		d := WorkflowRunArtifactDiff{
+			NodeName:  c.NodeName,
+			Name:      c.Name,
+			Current:   &current[i].WorkflowNodeRunArtifact,
+			Status:    ArtifactDiffStatusAdded,
+			SizeDelta: c.Size,
+		}
+		if p, ok := previousByKey[key(c)]; ok {
+			delete(previousByKey, key(c))
+			d.Previous = &p.WorkflowNodeRunArtifact
+			d.SizeDelta = c.Size - p.Size
+			d.Status = ArtifactDiffStatusUnchanged
+			if !sameArtifactContent(c.WorkflowNodeRunArtifact, p.WorkflowNodeRunArtifact) {
+				d.Status = ArtifactDiffStatusChanged
+				d.Unexpected = sameCommit
+			}
+		}
+		diffs = append(diffs, d)
+	}
+	for i := range previous {
+		p := previous[i]
+		if _, ok := previousByKey[key(p)]; !ok {
+			continue
+		}
+		diffs = append(diffs, WorkflowRunArtifactDiff{
+			NodeName:   p.NodeName,
+			Name:       p.Name,
+			Previous:   &previous[i].WorkflowNodeRunArtifact,
+			Status:     ArtifactDiffStatusRemoved,
+			SizeDelta:  -p.Size,
+			Unexpected: sameCommit,
+		})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].NodeName != diffs[j].NodeName {
+			return diffs[i].NodeName < diffs[j].NodeName
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// sameArtifactContent compares the checksums of two artifacts, the strongest checksum known for both is used.
+func sameArtifactContent(a, b WorkflowNodeRunArtifact) bool {
+	if a.SHA512sum != "" && b.SHA512sum != "" {
+		return a.SHA512sum == b.SHA512sum
+	}
+	if a.MD5sum != "" && b.MD5sum != "" {
+		return a.MD5sum == b.MD5sum
+	}
+	return a.Size == b.Size
+}
+
+// SetFiles compares the files of the current and previous versions of an archive artifact, given as maps of the
+// checksums of the files by path.
+func (d *WorkflowRunArtifactDiff) SetFiles(current, previous map[string]string) {
+	d.FilesAdded, d.FilesRemoved, d.FilesChanged = nil, nil, nil
+	for path, sum := range current {
+		previousSum, ok := previous[path]
+		switch {
+		case !ok:
+			d.FilesAdded = append(d.FilesAdded, path)
+		case previousSum != sum:
+			d.FilesChanged = append(d.FilesChanged, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			d.FilesRemoved = append(d.FilesRemoved, path)
+		}
+	}
+	sort.Strings(d.FilesAdded)
+	sort.Strings(d.FilesRemoved)
+	sort.Strings(d.FilesChanged)
+}
+
+// IsArchive returns true if the files of the artifact can be listed by ArchiveFiles.
+func IsArchive(name string) bool {
+	for _, ext := range []string{".tar", ".tar.gz", ".tgz", ".zip", ".jar", ".war"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchiveFiles reads a tar, gzipped tar or zip archive and returns the checksums of its regular files by path.
+func ArchiveFiles(name string, r io.Reader) (map[string]string, error) {
+	files := make(map[string]string)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, WrapError(err, "cannot read gzip archive %s", name)
+		}
+		defer gr.Close() // nolint
+		r = gr
+		fallthrough
+	case strings.HasSuffix(name, ".tar"):
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, WrapError(err, "cannot read tar archive %s", name)
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			h := sha256.New()
+			if _, err := io.Copy(h, tr); err != nil {
+				return nil, WrapError(err, "cannot read file %s in archive %s", hdr.Name, name)
+			}
+			files[hdr.Name] = hex.EncodeToString(h.Sum(nil))
+		}
+	case strings.HasSuffix(name, ".zip"), strings.HasSuffix(name, ".jar"), strings.HasSuffix(name, ".war"):
+		// A zip archive can only be read from its end, it is loaded in memory
+		buf, err := ioutil.ReadAll(io.LimitReader(r, MaxArtifactDiffArchiveSize+1))
+		if err != nil {
+			return nil, WrapError(err, "cannot read zip archive %s", name)
+		}
+		if len(buf) > MaxArtifactDiffArchiveSize {
+			return nil, NewErrorFrom(ErrWrongRequest, "archive %s is too big to be compared", name)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+		if err != nil {
+			return nil, WrapError(err, "cannot read zip archive %s", name)
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			files[f.Name] = fmt.Sprintf("%08x", f.CRC32)
+		}
+	default:
+		return nil, NewErrorFrom(ErrWrongRequest, "artifact %s is not an archive", name)
+	}
+	return files, nil
+}
@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffWorkflowRunArtifacts(t *testing.T) {
+	art := func(node, name, sum string, size int64) NodeRunArtifact {
+		return NodeRunArtifact{NodeName: node, WorkflowNodeRunArtifact: WorkflowNodeRunArtifact{Name: name, SHA512sum: sum, Size: size}}
+	}
+	current := []NodeRunArtifact{
+		art("build", "app.tar", "aaa", 10),
+		art("build", "README.md", "bbb", 5),
+		art("package", "app.deb", "ccc", 20),
+	}
+	previous := []NodeRunArtifact{
+		art("build", "app.tar", "zzz", 8),
+		art("build", "README.md", "bbb", 5),
+		art("build", "old.log", "ddd", 3),
+	}
+
+	diffs := DiffWorkflowRunArtifacts(current, previous, true)
+	require.Len(t, diffs, 4)
+
+	assert.Equal(t, "README.md", diffs[0].Name)
+	assert.Equal(t, ArtifactDiffStatusUnchanged, diffs[0].Status)
+	assert.False(t, diffs[0].Unexpected)
+
+	assert.Equal(t, "app.tar", diffs[1].Name)
+	assert.Equal(t, ArtifactDiffStatusChanged, diffs[1].Status)
+	assert.Equal(t, int64(2), diffs[1].SizeDelta)
+	assert.True(t, diffs[1].Unexpected)
+
+	assert.Equal(t, "old.log", diffs[2].Name)
+	assert.Equal(t, ArtifactDiffStatusRemoved, diffs[2].Status)
+
+	assert.Equal(t, "app.deb", diffs[3].Name)
+	assert.Equal(t, ArtifactDiffStatusAdded, diffs[3].Status)
+
+	diffs = DiffWorkflowRunArtifacts(current, previous, false)
+	assert.False(t, WorkflowRunArtifactsDiff{Artifacts: diffs}.Unexpected())
+}
+
+func TestArchiveFiles(t *testing.T) {
+	buildTar := func(files map[string]string) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+		tw := tar.NewWriter(buf)
+		for name, content := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return buf
+	}
+
+	current, err := ArchiveFiles("app.tar", buildTar(map[string]string{"bin/app": "v2", "conf.yml": "a: 1", "new.txt": "new"}))
+	require.NoError(t, err)
+	previous, err := ArchiveFiles("app.tar", buildTar(map[string]string{"bin/app": "v1", "conf.yml": "a: 1", "old.txt": "old"}))
+	require.NoError(t, err)
+
+	var d WorkflowRunArtifactDiff
+	d.SetFiles(current, previous)
+	assert.Equal(t, []string{"new.txt"}, d.FilesAdded)
+	assert.Equal(t, []string{"old.txt"}, d.FilesRemoved)
+	assert.Equal(t, []string{"bin/app"}, d.FilesChanged)
+
+	_, err = ArchiveFiles("app.deb", new(bytes.Buffer))
+	assert.Error(t, err)
+}
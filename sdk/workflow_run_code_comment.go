@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severities of code comments.
+const (
+	CodeCommentSeverityInfo    = "info"
+	CodeCommentSeverityWarning = "warning"
+	CodeCommentSeverityError   = "error"
+)
+
+// MaxCodeCommentsByNodeRun is the max number of code comments kept for a node run.
+const MaxCodeCommentsByNodeRun = 500
+
+// WorkflowNodeRunCodeComment is a comment on a line of a file of the repository, sent by a step like a static
+// analysis. Comments are posted on the change that triggered the workflow by VCS servers that support it.
+type WorkflowNodeRunCodeComment struct {
+	ID                   int64  `json:"id" db:"id"`
+	WorkflowNodeRunID    int64  `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	WorkflowNodeJobRunID int64  `json:"workflow_node_job_run_id" db:"workflow_node_job_run_id"`
+	Path                 string `json:"path" db:"path"`
+	Line                 int    `json:"line" db:"line"`
+	Message              string `json:"message" db:"message"`
+	Severity             string `json:"severity" db:"severity"`
+}
+
+// IsValid returns an error if the comment is not attached to a file or has no message.
+func (c *WorkflowNodeRunCodeComment) IsValid() error {
+	if c.Path == "" || strings.HasPrefix(c.Path, "/") {
+		return NewErrorFrom(ErrWrongRequest, "invalid path %q, it should be relative to the root of the repository", c.Path)
+	}
+	if c.Line < 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid line %d for %s", c.Line, c.Path)
+	}
+	if c.Message == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing message for %s:%d", c.Path, c.Line)
+	}
+	switch c.Severity {
+	case "":
+		c.Severity = CodeCommentSeverityWarning
+	case CodeCommentSeverityInfo, CodeCommentSeverityWarning, CodeCommentSeverityError:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid severity %q for %s:%d", c.Severity, c.Path, c.Line)
+	}
+	return nil
+}
+
+// String returns the message of the comment prefixed by its severity.
+func (c WorkflowNodeRunCodeComment) String() string {
+	return fmt.Sprintf("[%s] %s", c.Severity, c.Message)
+}
@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Formats of a workflow run logs archive.
+const (
+	WorkflowRunLogsArchiveZip   = "zip"
+	WorkflowRunLogsArchiveTarGz = "tar.gz"
+)
+
+// WorkflowRunLogsManifestFilename is the name of the manifest file at the root of a workflow run logs archive.
+const WorkflowRunLogsManifestFilename = "manifest.json"
+
+// IsValidWorkflowRunLogsArchiveFormat returns true if given format is a known logs archive format.
+func IsValidWorkflowRunLogsArchiveFormat(format string) bool {
+	return format == WorkflowRunLogsArchiveZip || format == WorkflowRunLogsArchiveTarGz
+}
+
+// WorkflowRunLogsManifest describes the content of a workflow run logs archive.
+type WorkflowRunLogsManifest struct {
+	ProjectKey   string                         `json:"project_key"`
+	WorkflowName string                         `json:"workflow_name"`
+	Number       int64                          `json:"number"`
+	Status       string                         `json:"status"`
+	Generated    time.Time                      `json:"generated"`
+	Files        []WorkflowRunLogsManifestEntry `json:"files"`
+}
+
+// WorkflowRunLogsManifestEntry describes one log file of a workflow run logs archive.
+type WorkflowRunLogsManifestEntry struct {
+	Path        string     `json:"path"`
+	Node        string     `json:"node"`
+	SubNumber   int64      `json:"subnumber"`
+	NodeRunID   int64      `json:"node_run_id"`
+	Stage       string     `json:"stage"`
+	Job         string     `json:"job"`
+	JobRunID    int64      `json:"job_run_id"`
+	StepOrder   *int64     `json:"step_order,omitempty"`
+	StepName    string     `json:"step_name,omitempty"`
+	ServiceName string     `json:"service_name,omitempty"`
+	Status      string     `json:"status"`
+	Start       *time.Time `json:"start,omitempty"`
+	Done        *time.Time `json:"done,omitempty"`
+	Size        int64      `json:"size"`
+}
+
+var workflowRunLogsPathRegex = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// WorkflowRunLogsPathElement returns given name cleaned to be used as a directory or file name in a logs archive.
+func WorkflowRunLogsPathElement(name string) string {
+	s := strings.Trim(workflowRunLogsPathRegex.ReplaceAllString(name, "_"), "._")
+	if s == "" {
+		return "_"
+	}
+	return s
+}
+
+// WorkflowRunLogsJobPath returns the directory of the logs of a job in a logs archive, ie. "build.1/Stage_1/Compile.42".
+// The sub number and job run id keep paths unique when a node or a job was run several times.
+func WorkflowRunLogsJobPath(nodeName string, subNumber int64, stageName, jobName string, jobRunID int64) string {
+	return fmt.Sprintf("%s.%d/%s/%s.%d", WorkflowRunLogsPathElement(nodeName), subNumber,
+		WorkflowRunLogsPathElement(stageName), WorkflowRunLogsPathElement(jobName), jobRunID)
+}
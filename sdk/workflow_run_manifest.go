@@ -0,0 +1,196 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WorkflowNodeRunManifest describes everything used by the jobs of a node run: the resolved parameters, the
+// workers, the worker models, the steps and the plugins. It is kept with the workflow run to reproduce it later.
+type WorkflowNodeRunManifest struct {
+	ID                int64                       `json:"id" db:"id"`
+	WorkflowID        int64                       `json:"workflow_id" db:"workflow_id"`
+	WorkflowRunID     int64                       `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID int64                       `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	Number            int64                       `json:"num" db:"num"`
+	SubNumber         int64                       `json:"subnumber" db:"sub_num"`
+	Jobs              WorkflowNodeRunManifestJobs `json:"jobs" db:"jobs"`
+	Created           time.Time                   `json:"created" db:"created"`
+	LastModified      time.Time                   `json:"last_modified" db:"last_modified"`
+}
+
+// WorkflowNodeRunManifestJob describes a job taken by a worker. A job that is restarted has an entry for each
+// worker that took it.
+type WorkflowNodeRunManifestJob struct {
+	WorkflowNodeJobRunID int64                           `json:"workflow_node_job_run_id"`
+	Name                 string                          `json:"name"`
+	Taken                time.Time                       `json:"taken"`
+	Worker               WorkflowNodeRunManifestWorker   `json:"worker"`
+	Model                *WorkflowNodeRunManifestModel   `json:"model,omitempty"`
+	Requirements         RequirementList                 `json:"requirements,omitempty"`
+	Parameters           []Parameter                     `json:"parameters"`
+	Secrets              []string                        `json:"secrets,omitempty"`
+	Steps                []WorkflowNodeRunManifestStep   `json:"steps"`
+	Plugins              []WorkflowNodeRunManifestPlugin `json:"plugins,omitempty"`
+}
+
+// WorkflowNodeRunManifestWorker is the worker that took a job.
+type WorkflowNodeRunManifestWorker struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+}
+
+// WorkflowNodeRunManifestModel is the worker model of the worker that took a job. The digest is computed from
+// the definition of the model, without its credentials.
+type WorkflowNodeRunManifestModel struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Image  string `json:"image,omitempty"`
+	Digest string `json:"digest"`
+}
+
+// WorkflowNodeRunManifestStep is a step of a job, the digest is computed from the definition of the step with
+// its parameters and its children.
+type WorkflowNodeRunManifestStep struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Digest string `json:"digest"`
+}
+
+// WorkflowNodeRunManifestPlugin is a plugin used by a job. The version is empty for the installed plugin.
+type WorkflowNodeRunManifestPlugin struct {
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	SHA512sum string `json:"sha512sum,omitempty"`
+}
+
+// WorkflowNodeRunManifestJobs is a list of jobs stored as JSON.
+type WorkflowNodeRunManifestJobs []WorkflowNodeRunManifestJob
+
+// Scan manifest jobs.
+func (j *WorkflowNodeRunManifestJobs) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, j), "cannot unmarshal WorkflowNodeRunManifestJobs")
+}
+
+// Value returns driver.Value from manifest jobs.
+func (j WorkflowNodeRunManifestJobs) Value() (driver.Value, error) {
+	if j == nil {
+		j = WorkflowNodeRunManifestJobs{}
+	}
+	btes, err := json.Marshal(j)
+	return btes, WrapError(err, "cannot marshal WorkflowNodeRunManifestJobs")
+}
+
+// NewWorkflowNodeRunManifestJob returns the manifest entry of a job taken by a worker. The values of the
+// secrets are masked in the parameters, the model is nil if the worker was not spawned from a model.
+func NewWorkflowNodeRunManifestJob(j WorkflowNodeJobRun, secrets []Variable, wk Worker, m *Model, plugins []GRPCPluginBinary) WorkflowNodeRunManifestJob {
+	res := WorkflowNodeRunManifestJob{
+		WorkflowNodeJobRunID: j.ID,
+		Name:                 j.Job.Action.Name,
+		Taken:                j.Start,
+		Worker: WorkflowNodeRunManifestWorker{
+			Name:    wk.Name,
+			Version: wk.Version,
+			OS:      wk.OS,
+			Arch:    wk.Arch,
+		},
+		Requirements: j.Job.Action.Requirements,
+		Parameters:   maskManifestParameters(j.Parameters, secrets),
+		Steps:        make([]WorkflowNodeRunManifestStep, 0, len(j.Job.Action.Actions)),
+	}
+
+	if m != nil {
+		res.Model = &WorkflowNodeRunManifestModel{
+			Name:   m.Name,
+			Type:   m.Type,
+			Image:  m.ModelDocker.Image,
+			Digest: workerModelDigest(*m),
+		}
+		if res.Model.Image == "" {
+			res.Model.Image = m.ModelVirtualMachine.Image
+		}
+	}
+
+	for _, s := range secrets {
+		res.Secrets = append(res.Secrets, s.Name)
+	}
+
+	for _, a := range j.Job.Action.Actions {
+		name := a.StepName
+		if name == "" {
+			name = a.Name
+		}
+		res.Steps = append(res.Steps, WorkflowNodeRunManifestStep{
+			Name:   name,
+			Type:   a.Type,
+			Digest: manifestDigest(a),
+		})
+	}
+
+	for _, p := range plugins {
+		res.Plugins = append(res.Plugins, WorkflowNodeRunManifestPlugin{
+			Name:      p.PluginName,
+			Version:   p.Version,
+			SHA512sum: p.SHA512sum,
+		})
+	}
+
+	return res
+}
+
+// maskManifestParameters returns a copy of the parameters where the values of the secrets are masked.
+func maskManifestParameters(params []Parameter, secrets []Variable) []Parameter {
+	res := make([]Parameter, len(params))
+	for i, p := range params {
+		res[i] = p
+		if NeedPlaceholder(p.Type) {
+			res[i].Value = PasswordPlaceholder
+			continue
+		}
+		for _, s := range secrets {
+			if s.Value != "" && strings.Contains(p.Value, s.Value) {
+				res[i].Value = PasswordPlaceholder
+				break
+			}
+		}
+	}
+	return res
+}
+
+// workerModelDigest returns the digest of the definition of a worker model, the credentials of the docker
+// registry are not part of the digest.
+func workerModelDigest(m Model) string {
+	docker := m.ModelDocker
+	docker.Username = ""
+	docker.Password = ""
+	return manifestDigest(struct {
+		Type           string              `json:"type"`
+		Docker         ModelDocker         `json:"docker"`
+		VirtualMachine ModelVirtualMachine `json:"virtual_machine"`
+	}{
+		Type:           m.Type,
+		Docker:         docker,
+		VirtualMachine: m.ModelVirtualMachine,
+	})
+}
+
+func manifestDigest(i interface{}) string {
+	btes, _ := json.Marshal(i)
+	sum := sha256.Sum256(btes)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,366 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/venom"
+)
+
+// Formats of a workflow run report.
+const (
+	WorkflowRunReportHTML     = "html"
+	WorkflowRunReportMarkdown = "md"
+	WorkflowRunReportJUnit    = "junit"
+)
+
+// IsValidWorkflowRunReportFormat returns true if given format is a known report format.
+func IsValidWorkflowRunReportFormat(format string) bool {
+	return format == WorkflowRunReportHTML || format == WorkflowRunReportMarkdown || format == WorkflowRunReportJUnit
+}
+
+// WorkflowRunReport is a self-contained report of a workflow run, with the latest run of each node.
+type WorkflowRunReport struct {
+	ProjectKey   string                  `json:"project_key"`
+	WorkflowName string                  `json:"workflow_name"`
+	Number       int64                   `json:"number"`
+	Status       string                  `json:"status"`
+	Start        time.Time               `json:"start"`
+	Done         time.Time               `json:"done"`
+	Generated    time.Time               `json:"generated"`
+	Branch       string                  `json:"branch,omitempty"`
+	Hash         string                  `json:"hash,omitempty"`
+	Repository   string                  `json:"repository,omitempty"`
+	TriggeredBy  string                  `json:"triggered_by,omitempty"`
+	URL          string                  `json:"url"`
+	Nodes        []WorkflowRunReportNode `json:"nodes"`
+}
+
+// WorkflowRunReportNode is the report of the run of a node, with the results of its jobs and tests.
+type WorkflowRunReportNode struct {
+	Name        string                      `json:"name"`
+	SubNumber   int64                       `json:"subnumber"`
+	Status      string                      `json:"status"`
+	Start       time.Time                   `json:"start"`
+	Done        time.Time                   `json:"done"`
+	URL         string                      `json:"url"`
+	Tests       WorkflowNodeRunTestsSummary `json:"tests"`
+	Jobs        []WorkflowRunReportJob      `json:"jobs"`
+	FailedTests []WorkflowRunReportTest     `json:"failed_tests,omitempty"`
+	suites      []venom.TestSuite
+}
+
+// WorkflowRunReportJob is the result of a job of a node run.
+type WorkflowRunReportJob struct {
+	Stage  string    `json:"stage"`
+	Name   string    `json:"name"`
+	Status string    `json:"status"`
+	Start  time.Time `json:"start"`
+	Done   time.Time `json:"done"`
+}
+
+// WorkflowRunReportTest is a failed test case of a node run.
+type WorkflowRunReportTest struct {
+	Suite   string `json:"suite"`
+	Name    string `json:"name"`
+	Message string `json:"message,omitempty"`
+}
+
+// NewWorkflowRunReport returns the report of given workflow run, the links target the given UI url.
+func NewWorkflowRunReport(wr WorkflowRun, uiURL string) WorkflowRunReport {
+	r := WorkflowRunReport{
+		ProjectKey:   wr.Workflow.ProjectKey,
+		WorkflowName: wr.Workflow.Name,
+		Number:       wr.Number,
+		Status:       wr.Status,
+		Start:        wr.Start,
+		Done:         wr.LastModified,
+		Generated:    time.Now(),
+		Branch:       wr.TagValue("git.branch"),
+		Hash:         wr.TagValue("git.hash"),
+		Repository:   wr.TagValue("git.repository"),
+		TriggeredBy:  wr.TagValue("triggered_by"),
+	}
+	r.URL = fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", strings.TrimSuffix(uiURL, "/"), r.ProjectKey, r.WorkflowName, r.Number)
+
+	for _, runs := range wr.WorkflowNodeRuns {
+		if len(runs) == 0 {
+			continue
+		}
+		nodeRun := runs[0]
+		for _, nr := range runs {
+			if nr.SubNumber > nodeRun.SubNumber {
+				nodeRun = nr
+			}
+		}
+		n := WorkflowRunReportNode{
+			Name:      nodeRun.WorkflowNodeName,
+			SubNumber: nodeRun.SubNumber,
+			Status:    nodeRun.Status,
+			Start:     nodeRun.Start,
+			Done:      nodeRun.Done,
+			URL:       fmt.Sprintf("%s/node/%d?name=%s", r.URL, nodeRun.ID, url.QueryEscape(nodeRun.WorkflowNodeName)),
+		}
+		n.Tests, _ = NewWorkflowNodeRunTestsSummary(nodeRun)
+		for _, s := range nodeRun.Stages {
+			for _, rj := range s.RunJobs {
+				n.Jobs = append(n.Jobs, WorkflowRunReportJob{
+					Stage:  s.Name,
+					Name:   rj.Job.Action.Name,
+					Status: rj.Status,
+					Start:  rj.Start,
+					Done:   rj.Done,
+				})
+			}
+		}
+		if nodeRun.Tests != nil {
+			n.suites = nodeRun.Tests.TestSuites
+			for _, ts := range n.suites {
+				for _, tc := range ts.TestCases {
+					for _, fs := range [][]venom.Failure{tc.Failures, tc.Errors} {
+						for _, f := range fs {
+							n.FailedTests = append(n.FailedTests, WorkflowRunReportTest{Suite: ts.Name, Name: tc.Name, Message: f.Message})
+						}
+					}
+				}
+			}
+		}
+		r.Nodes = append(r.Nodes, n)
+	}
+	sort.Slice(r.Nodes, func(i, j int) bool {
+		if !r.Nodes[i].Start.Equal(r.Nodes[j].Start) {
+			return r.Nodes[i].Start.Before(r.Nodes[j].Start)
+		}
+		return r.Nodes[i].Name < r.Nodes[j].Name
+	})
+	return r
+}
+
+// reportDuration returns the rounded duration between two dates, or an empty string if it is not finished.
+func reportDuration(start, done time.Time) string {
+	if start.IsZero() || done.Before(start) {
+		return ""
+	}
+	return done.Sub(start).Round(time.Second).String()
+}
+
+// Markdown returns the report in markdown.
+func (r WorkflowRunReport) Markdown() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s/%s #%d: %s\n\n", r.ProjectKey, r.WorkflowName, r.Number, r.Status)
+	fmt.Fprintf(&buf, "- **Started**: %s\n", r.Start.Format(time.RFC3339))
+	fmt.Fprintf(&buf, "- **Duration**: %s\n", reportDuration(r.Start, r.Done))
+	if r.Repository != "" {
+		fmt.Fprintf(&buf, "- **Repository**: %s\n", r.Repository)
+	}
+	if r.Branch != "" {
+		fmt.Fprintf(&buf, "- **Branch**: %s\n", r.Branch)
+	}
+	if r.Hash != "" {
+		fmt.Fprintf(&buf, "- **Commit**: %s\n", r.Hash)
+	}
+	if r.TriggeredBy != "" {
+		fmt.Fprintf(&buf, "- **Triggered by**: %s\n", r.TriggeredBy)
+	}
+	fmt.Fprintf(&buf, "- **Link**: %s\n", r.URL)
+	fmt.Fprintf(&buf, "- **Generated**: %s\n\n", r.Generated.Format(time.RFC3339))
+
+	buf.WriteString("| Pipeline | Status | Duration | Tests | Passed | Failed | Skipped |\n")
+	buf.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, n := range r.Nodes {
+		fmt.Fprintf(&buf, "| [%s](%s) | %s | %s | %d | %d | %d | %d |\n", n.Name, n.URL, n.Status, reportDuration(n.Start, n.Done), n.Tests.Total, n.Tests.OK, n.Tests.KO, n.Tests.Skipped)
+	}
+
+	for _, n := range r.Nodes {
+		fmt.Fprintf(&buf, "\n## %s\n\n", n.Name)
+		if len(n.Jobs) > 0 {
+			buf.WriteString("| Stage | Job | Status | Duration |\n")
+			buf.WriteString("|---|---|---|---|\n")
+			for _, j := range n.Jobs {
+				fmt.Fprintf(&buf, "| %s | %s | %s | %s |\n", j.Stage, j.Name, j.Status, reportDuration(j.Start, j.Done))
+			}
+		}
+		if len(n.FailedTests) > 0 {
+			buf.WriteString("\nFailed tests:\n\n")
+			for _, t := range n.FailedTests {
+				fmt.Fprintf(&buf, "- %s / %s", t.Suite, t.Name)
+				if t.Message != "" {
+					fmt.Fprintf(&buf, ": %s", t.Message)
+				}
+				buf.WriteString("\n")
+			}
+		}
+	}
+	return buf.String()
+}
+
+var workflowRunReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": reportDuration,
+	"date":     func(t time.Time) string { return t.Format(time.RFC3339) },
+	"status":   func(s string) string { return strings.ToLower(s) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.ProjectKey}}/{{.WorkflowName}} #{{.Number}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #333; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+th { background: #f5f5f5; }
+.success { color: #21ba45; }
+.fail, .stopped { color: #db2828; }
+.skipped, .disabled { color: #999; }
+</style>
+</head>
+<body>
+<h1>{{.ProjectKey}}/{{.WorkflowName}} #{{.Number}}: <span class="{{status .Status}}">{{.Status}}</span></h1>
+<table>
+<tr><th>Started</th><td>{{date .Start}}</td></tr>
+<tr><th>Duration</th><td>{{duration .Start .Done}}</td></tr>
+{{- if .Repository}}<tr><th>Repository</th><td>{{.Repository}}</td></tr>{{end}}
+{{- if .Branch}}<tr><th>Branch</th><td>{{.Branch}}</td></tr>{{end}}
+{{- if .Hash}}<tr><th>Commit</th><td>{{.Hash}}</td></tr>{{end}}
+{{- if .TriggeredBy}}<tr><th>Triggered by</th><td>{{.TriggeredBy}}</td></tr>{{end}}
+<tr><th>Link</th><td><a href="{{.URL}}">{{.URL}}</a></td></tr>
+<tr><th>Generated</th><td>{{date .Generated}}</td></tr>
+</table>
+<table>
+<tr><th>Pipeline</th><th>Status</th><th>Duration</th><th>Tests</th><th>Passed</th><th>Failed</th><th>Skipped</th></tr>
+{{- range .Nodes}}
+<tr><td><a href="{{.URL}}">{{.Name}}</a></td><td class="{{status .Status}}">{{.Status}}</td><td>{{duration .Start .Done}}</td><td>{{.Tests.Total}}</td><td>{{.Tests.OK}}</td><td>{{.Tests.KO}}</td><td>{{.Tests.Skipped}}</td></tr>
+{{- end}}
+</table>
+{{- range .Nodes}}
+<h2>{{.Name}}</h2>
+{{- if .Jobs}}
+<table>
+<tr><th>Stage</th><th>Job</th><th>Status</th><th>Duration</th></tr>
+{{- range .Jobs}}
+<tr><td>{{.Stage}}</td><td>{{.Name}}</td><td class="{{status .Status}}">{{.Status}}</td><td>{{duration .Start .Done}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .FailedTests}}
+<p>Failed tests:</p>
+<ul>
+{{- range .FailedTests}}
+<li>{{.Suite}} / {{.Name}}{{if .Message}}: {{.Message}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML returns the report as a self-contained HTML page.
+func (r WorkflowRunReport) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := workflowRunReportTemplate.Execute(&buf, r); err != nil {
+		return nil, WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr,omitempty"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Classname string         `xml:"classname,attr,omitempty"`
+	Name      string         `xml:"name,attr"`
+	Time      string         `xml:"time,attr,omitempty"`
+	Failures  []junitFailure `xml:"failure"`
+	Skipped   *struct{}      `xml:"skipped"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnit returns the report as a JUnit XML file. Each node run gives a test suite with a test case by job, followed by
+// its own test suites.
+func (r WorkflowRunReport) JUnit() ([]byte, error) {
+	res := junitTestSuites{Name: fmt.Sprintf("%s/%s #%d", r.ProjectKey, r.WorkflowName, r.Number)}
+	add := func(s junitTestSuite) {
+		for _, c := range s.Cases {
+			s.Tests++
+			if len(c.Failures) > 0 {
+				s.Failures++
+			}
+			if c.Skipped != nil {
+				s.Skipped++
+			}
+		}
+		res.Tests += s.Tests
+		res.Failures += s.Failures
+		res.Suites = append(res.Suites, s)
+	}
+
+	for _, n := range r.Nodes {
+		s := junitTestSuite{Name: n.Name, Time: reportSeconds(n.Start, n.Done)}
+		for _, j := range n.Jobs {
+			c := junitTestCase{Classname: n.Name + "." + j.Stage, Name: j.Name, Time: reportSeconds(j.Start, j.Done)}
+			switch j.Status {
+			case StatusFail, StatusStopped:
+				c.Failures = []junitFailure{{Message: fmt.Sprintf("job %s: %s", j.Name, j.Status)}}
+			case StatusSkipped, StatusDisabled:
+				c.Skipped = &struct{}{}
+			}
+			s.Cases = append(s.Cases, c)
+		}
+		add(s)
+
+		for _, ts := range n.suites {
+			s := junitTestSuite{Name: n.Name + "/" + ts.Name}
+			for _, tc := range ts.TestCases {
+				c := junitTestCase{Classname: tc.Classname, Name: tc.Name, Time: tc.Time}
+				for _, fs := range [][]venom.Failure{tc.Failures, tc.Errors} {
+					for _, f := range fs {
+						c.Failures = append(c.Failures, junitFailure{Message: f.Message})
+					}
+				}
+				if len(tc.Skipped) > 0 && len(c.Failures) == 0 {
+					c.Skipped = &struct{}{}
+				}
+				s.Cases = append(s.Cases, c)
+			}
+			add(s)
+		}
+	}
+
+	btes, err := xml.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return nil, WithStack(err)
+	}
+	return append([]byte(xml.Header), btes...), nil
+}
+
+// reportSeconds returns the duration between two dates in seconds, or an empty string if it is not finished.
+func reportSeconds(start, done time.Time) string {
+	if start.IsZero() || done.Before(start) {
+		return ""
+	}
+	return strconv.FormatFloat(done.Sub(start).Seconds(), 'f', 3, 64)
+}
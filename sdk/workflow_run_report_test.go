@@ -0,0 +1,114 @@
+package sdk
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/ovh/venom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorkflowRunReport(t *testing.T) {
+	start := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	wr := WorkflowRun{
+		Number:       12,
+		Status:       StatusFail,
+		Start:        start,
+		LastModified: start.Add(5 * time.Minute),
+		Workflow:     Workflow{ProjectKey: "KEY", Name: "my-workflow"},
+		Tags: []WorkflowRunTag{
+			{Tag: "git.branch", Value: "master"},
+			{Tag: "triggered_by", Value: "john"},
+		},
+		WorkflowNodeRuns: map[int64][]WorkflowNodeRun{
+			1: {
+				{ID: 10, WorkflowNodeName: "build", SubNumber: 0, Status: StatusFail, Start: start, Done: start.Add(time.Minute)},
+				{
+					ID:               11,
+					WorkflowNodeName: "build",
+					SubNumber:        1,
+					Status:           StatusSuccess,
+					Start:            start.Add(2 * time.Minute),
+					Done:             start.Add(3 * time.Minute),
+					Stages: []Stage{{
+						Name: "Compile",
+						RunJobs: []WorkflowNodeJobRun{
+							{Job: ExecutedJob{Job: Job{Action: Action{Name: "go build"}}}, Status: StatusSuccess, Start: start.Add(2 * time.Minute), Done: start.Add(150 * time.Second)},
+							{Job: ExecutedJob{Job: Job{Action: Action{Name: "lint"}}}, Status: StatusDisabled},
+						},
+					}},
+				},
+			},
+			2: {
+				{
+					ID:               12,
+					WorkflowNodeName: "tests",
+					Status:           StatusFail,
+					Start:            start.Add(3 * time.Minute),
+					Done:             start.Add(5 * time.Minute),
+					Stages: []Stage{{
+						Name: "Test",
+						RunJobs: []WorkflowNodeJobRun{
+							{Job: ExecutedJob{Job: Job{Action: Action{Name: "unit"}}}, Status: StatusFail, Start: start.Add(3 * time.Minute), Done: start.Add(5 * time.Minute)},
+						},
+					}},
+					Tests: &venom.Tests{
+						Total:   2,
+						TotalOK: 1,
+						TotalKO: 1,
+						TestSuites: []venom.TestSuite{{
+							Name: "api",
+							TestCases: []venom.TestCase{
+								{Name: "TestOK", Time: "0.1"},
+								{Name: "TestKO", Failures: []venom.Failure{{Message: "expected 1"}}},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	r := NewWorkflowRunReport(wr, "https://cds/")
+	assert.Equal(t, "https://cds/project/KEY/workflow/my-workflow/run/12", r.URL)
+	require.Len(t, r.Nodes, 2)
+	assert.Equal(t, "build", r.Nodes[0].Name)
+	assert.Equal(t, int64(1), r.Nodes[0].SubNumber)
+	assert.Equal(t, "https://cds/project/KEY/workflow/my-workflow/run/12/node/11?name=build", r.Nodes[0].URL)
+	require.Len(t, r.Nodes[0].Jobs, 2)
+	assert.Equal(t, "tests", r.Nodes[1].Name)
+	assert.Equal(t, []WorkflowRunReportTest{{Suite: "api", Name: "TestKO", Message: "expected 1"}}, r.Nodes[1].FailedTests)
+
+	md := r.Markdown()
+	assert.Contains(t, md, "# KEY/my-workflow #12: Fail")
+	assert.Contains(t, md, "- **Duration**: 5m0s")
+	assert.Contains(t, md, "- **Triggered by**: john")
+	assert.Contains(t, md, "| [build](https://cds/project/KEY/workflow/my-workflow/run/12/node/11?name=build) | Success | 1m0s | 0 | 0 | 0 | 0 |")
+	assert.Contains(t, md, "| Compile | go build | Success | 30s |")
+	assert.Contains(t, md, "- api / TestKO: expected 1")
+
+	html, err := r.HTML()
+	require.NoError(t, err)
+	assert.Contains(t, string(html), `<a href="https://cds/project/KEY/workflow/my-workflow/run/12/node/12?name=tests">tests</a>`)
+	assert.Contains(t, string(html), `<td class="fail">Fail</td>`)
+
+	btes, err := r.JUnit()
+	require.NoError(t, err)
+	var junit struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name    string `xml:"name,attr"`
+			Skipped int    `xml:"skipped,attr"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(btes, &junit))
+	assert.Equal(t, 5, junit.Tests)
+	assert.Equal(t, 2, junit.Failures)
+	require.Len(t, junit.Suites, 3)
+	assert.Equal(t, "build", junit.Suites[0].Name)
+	assert.Equal(t, 1, junit.Suites[0].Skipped)
+	assert.Equal(t, "tests/api", junit.Suites[2].Name)
+}
@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Sort options of a search of workflow runs.
+const (
+	WorkflowRunSearchSortStart        = "start"
+	WorkflowRunSearchSortLastModified = "last_modified"
+	WorkflowRunSearchSortNumber       = "number"
+
+	DefaultWorkflowRunSearchLimit = 50
+	MaxWorkflowRunSearchLimit     = 500
+)
+
+// WorkflowRunSearch is a search of the runs of the workflows of a project. All the filters are optional, the runs
+// must match all the given filters. The runs are sorted by start date, last modification date or number, the most
+// recent first unless ascending is set. The next page is requested with the cursor returned by the previous one.
+type WorkflowRunSearch struct {
+	Workflows []string          `json:"workflows,omitempty"`
+	Status    []string          `json:"status,omitempty"`
+	Branch    string            `json:"branch,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Triggerer string            `json:"triggerer,omitempty"`
+	From      *time.Time        `json:"from,omitempty"`
+	To        *time.Time        `json:"to,omitempty"`
+	NodeName  string            `json:"node_name,omitempty"`
+	Payload   map[string]string `json:"payload,omitempty"`
+	Sort      string            `json:"sort,omitempty"`
+	Ascending bool              `json:"ascending,omitempty"`
+	Limit     int               `json:"limit,omitempty"`
+	Cursor    string            `json:"cursor,omitempty"`
+}
+
+// IsValid returns an error if the search is invalid, and sets the default sort and limit.
+func (s *WorkflowRunSearch) IsValid() error {
+	if s.Sort == "" {
+		s.Sort = WorkflowRunSearchSortStart
+	}
+	switch s.Sort {
+	case WorkflowRunSearchSortStart, WorkflowRunSearchSortLastModified, WorkflowRunSearchSortNumber:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid sort %q", s.Sort)
+	}
+
+	if s.Limit == 0 {
+		s.Limit = DefaultWorkflowRunSearchLimit
+	}
+	if s.Limit < 0 || s.Limit > MaxWorkflowRunSearchLimit {
+		return NewErrorFrom(ErrWrongRequest, "limit must be between 1 and %d", MaxWorkflowRunSearchLimit)
+	}
+
+	if !StatusValidate(s.Status...) {
+		return NewErrorFrom(ErrWrongRequest, "invalid status %v", s.Status)
+	}
+	if s.From != nil && s.To != nil && !s.From.Before(*s.To) {
+		return NewErrorFrom(ErrWrongRequest, "invalid date range")
+	}
+
+	if s.Cursor != "" {
+		c, err := ParseWorkflowRunSearchCursor(s.Cursor)
+		if err != nil {
+			return err
+		}
+		if c.Sort != s.Sort || c.Ascending != s.Ascending {
+			return NewErrorFrom(ErrWrongRequest, "the cursor doesn't match the sort of the search")
+		}
+	}
+	return nil
+}
+
+// WorkflowRunSearchResult is a page of the runs matching a search. The next cursor is empty on the last page.
+type WorkflowRunSearchResult struct {
+	Runs       []WorkflowRun `json:"runs"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// WorkflowRunSearchCursor is the position of the last run of a page of a search: the value of its sort key and
+// its id, to sort the runs having the same value.
+type WorkflowRunSearchCursor struct {
+	Sort      string `json:"sort"`
+	Ascending bool   `json:"ascending,omitempty"`
+	Value     string `json:"value"`
+	ID        int64  `json:"id"`
+}
+
+// NewWorkflowRunSearchCursor returns the cursor of the page following a run.
+func NewWorkflowRunSearchCursor(s WorkflowRunSearch, r WorkflowRun) string {
+	c := WorkflowRunSearchCursor{Sort: s.Sort, Ascending: s.Ascending, ID: r.ID}
+	switch s.Sort {
+	case WorkflowRunSearchSortLastModified:
+		c.Value = r.LastModified.UTC().Format(time.RFC3339Nano)
+	case WorkflowRunSearchSortNumber:
+		c.Value = strconv.FormatInt(r.Number, 10)
+	default:
+		c.Value = r.Start.UTC().Format(time.RFC3339Nano)
+	}
+	btes, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(btes)
+}
+
+// ParseWorkflowRunSearchCursor decodes a cursor returned by a search.
+func ParseWorkflowRunSearchCursor(s string) (*WorkflowRunSearchCursor, error) {
+	btes, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid cursor")
+	}
+	var c WorkflowRunSearchCursor
+	if err := json.Unmarshal(btes, &c); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid cursor")
+	}
+	if _, err := c.SortValue(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// SortValue returns the value of the sort key of the cursor: a time or a run number.
+func (c WorkflowRunSearchCursor) SortValue() (interface{}, error) {
+	switch c.Sort {
+	case WorkflowRunSearchSortStart, WorkflowRunSearchSortLastModified:
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid cursor")
+		}
+		return t, nil
+	case WorkflowRunSearchSortNumber:
+		n, err := strconv.ParseInt(c.Value, 10, 64)
+		if err != nil {
+			return nil, NewErrorFrom(ErrWrongRequest, "invalid cursor")
+		}
+		return n, nil
+	}
+	return nil, NewErrorFrom(ErrWrongRequest, "invalid cursor")
+}
@@ -0,0 +1,46 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunSearchCursor(t *testing.T) {
+	start := time.Date(2020, 1, 1, 10, 0, 0, 123456000, time.UTC)
+	r := WorkflowRun{ID: 42, Number: 7, Start: start, LastModified: start.Add(time.Minute)}
+
+	s := WorkflowRunSearch{}
+	require.NoError(t, s.IsValid())
+	require.Equal(t, WorkflowRunSearchSortStart, s.Sort)
+	require.Equal(t, DefaultWorkflowRunSearchLimit, s.Limit)
+
+	s.Cursor = NewWorkflowRunSearchCursor(s, r)
+	require.NoError(t, s.IsValid())
+	c, err := ParseWorkflowRunSearchCursor(s.Cursor)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), c.ID)
+	v, err := c.SortValue()
+	require.NoError(t, err)
+	require.True(t, start.Equal(v.(time.Time)))
+
+	// the cursor of another sort is rejected
+	s.Sort = WorkflowRunSearchSortNumber
+	require.Error(t, s.IsValid())
+	s.Cursor = NewWorkflowRunSearchCursor(s, r)
+	require.NoError(t, s.IsValid())
+	c, err = ParseWorkflowRunSearchCursor(s.Cursor)
+	require.NoError(t, err)
+	v, err = c.SortValue()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), v)
+
+	_, err = ParseWorkflowRunSearchCursor("invalid")
+	require.Error(t, err)
+
+	from := start
+	require.Error(t, (&WorkflowRunSearch{From: &from, To: &from}).IsValid())
+	require.Error(t, (&WorkflowRunSearch{Sort: "name"}).IsValid())
+	require.Error(t, (&WorkflowRunSearch{Limit: MaxWorkflowRunSearchLimit + 1}).IsValid())
+}
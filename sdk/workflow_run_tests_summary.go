@@ -0,0 +1,92 @@
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/sguiheux/go-coverage"
+)
+
+// WorkflowRunTestsSummary aggregates the unit tests results and the code coverage of the nodes of a workflow run.
+type WorkflowRunTestsSummary struct {
+	WorkflowName string                        `json:"workflow_name"`
+	Number       int64                         `json:"number"`
+	Branch       string                        `json:"branch"`
+	Nodes        []WorkflowNodeRunTestsSummary `json:"nodes"`
+}
+
+// WorkflowNodeRunTestsSummary is the summary of the unit tests and the coverage of a node run. The coverage delta is
+// computed against the latest coverage report of the default branch, it is not set for a run on the default branch.
+type WorkflowNodeRunTestsSummary struct {
+	NodeName              string   `json:"node_name"`
+	Status                string   `json:"status"`
+	Total                 int      `json:"total"`
+	OK                    int      `json:"ok"`
+	KO                    int      `json:"ko"`
+	Skipped               int      `json:"skipped"`
+	Coverage              *float64 `json:"coverage,omitempty"`
+	DefaultBranchCoverage *float64 `json:"default_branch_coverage,omitempty"`
+}
+
+// NewWorkflowNodeRunTestsSummary returns the summary of given node run, false if the node run has no tests results
+// and no coverage report.
+func NewWorkflowNodeRunTestsSummary(nodeRun WorkflowNodeRun) (WorkflowNodeRunTestsSummary, bool) {
+	s := WorkflowNodeRunTestsSummary{
+		NodeName: nodeRun.WorkflowNodeName,
+		Status:   nodeRun.Status,
+	}
+	if nodeRun.Tests != nil {
+		s.Total = nodeRun.Tests.Total
+		s.OK = nodeRun.Tests.TotalOK
+		s.KO = nodeRun.Tests.TotalKO
+		s.Skipped = nodeRun.Tests.TotalSkipped
+	}
+	if p, ok := CoveragePercent(nodeRun.Coverage.Report); ok {
+		s.Coverage = &p
+	}
+	if p, ok := CoveragePercent(nodeRun.Coverage.Trend.DefaultBranch); ok {
+		s.DefaultBranchCoverage = &p
+	}
+	return s, s.Total > 0 || s.Coverage != nil
+}
+
+// CoveragePercent returns the percentage of covered lines of a coverage report, false if the report is empty.
+func CoveragePercent(r coverage.Report) (float64, bool) {
+	if r.TotalLines == 0 {
+		return 0, false
+	}
+	return float64(r.CoveredLines) / float64(r.TotalLines) * 100, true
+}
+
+// IsEmpty returns true if no node of the run reported tests results or coverage.
+func (s WorkflowRunTestsSummary) IsEmpty() bool {
+	return len(s.Nodes) == 0
+}
+
+// Markdown returns the summary as a markdown table, ready to be posted as a pull request comment.
+func (s WorkflowRunTestsSummary) Markdown() string {
+	var total, ok, ko, skipped int
+	for _, n := range s.Nodes {
+		total += n.Total
+		ok += n.OK
+		ko += n.KO
+		skipped += n.Skipped
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "### Tests summary of %s #%d\n\n", s.WorkflowName, s.Number)
+	fmt.Fprintf(&buf, "**%d** tests, **%d** passed, **%d** failed, **%d** skipped\n\n", total, ok, ko, skipped)
+	buf.WriteString("| Pipeline | Status | Tests | Passed | Failed | Skipped | Coverage | Coverage delta with default branch |\n")
+	buf.WriteString("|---|---|---|---|---|---|---|---|\n")
+	for _, n := range s.Nodes {
+		cov, delta := "-", "-"
+		if n.Coverage != nil {
+			cov = fmt.Sprintf("%.2f%%", *n.Coverage)
+			if n.DefaultBranchCoverage != nil {
+				delta = fmt.Sprintf("%+.2f%%", *n.Coverage-*n.DefaultBranchCoverage)
+			}
+		}
+		fmt.Fprintf(&buf, "| %s | %s | %d | %d | %d | %d | %s | %s |\n", n.NodeName, n.Status, n.Total, n.OK, n.KO, n.Skipped, cov, delta)
+	}
+	return buf.String()
+}
@@ -0,0 +1,37 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/ovh/venom"
+	"github.com/sguiheux/go-coverage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunTestsSummary(t *testing.T) {
+	build, ok := NewWorkflowNodeRunTestsSummary(WorkflowNodeRun{
+		WorkflowNodeName: "build",
+		Status:           StatusFail,
+		Tests:            &venom.Tests{Total: 10, TotalOK: 8, TotalKO: 1, TotalSkipped: 1},
+		Coverage: WorkflowNodeRunCoverage{
+			Report: coverage.Report{TotalLines: 200, CoveredLines: 150},
+			Trend: WorkflowNodeRunCoverageTrends{
+				DefaultBranch: coverage.Report{TotalLines: 100, CoveredLines: 80},
+			},
+		},
+	})
+	require.True(t, ok)
+	require.NotNil(t, build.Coverage)
+	assert.Equal(t, 75.0, *build.Coverage)
+
+	_, ok = NewWorkflowNodeRunTestsSummary(WorkflowNodeRun{WorkflowNodeName: "deploy", Status: StatusSuccess})
+	assert.False(t, ok)
+
+	s := WorkflowRunTestsSummary{WorkflowName: "my-workflow", Number: 3, Nodes: []WorkflowNodeRunTestsSummary{build}}
+	assert.False(t, s.IsEmpty())
+	md := s.Markdown()
+	assert.Contains(t, md, "### Tests summary of my-workflow #3")
+	assert.Contains(t, md, "**10** tests, **8** passed, **1** failed, **1** skipped")
+	assert.Contains(t, md, "| build | Fail | 10 | 8 | 1 | 1 | 75.00% | -5.00% |")
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"time"
+)
+
+// Workflow scheduled run statuses.
+const (
+	// WorkflowScheduledRunStatusScheduled is the status of a scheduled run waiting for its date.
+	WorkflowScheduledRunStatusScheduled = "Scheduled"
+	// WorkflowScheduledRunStatusTriggered is the status of a scheduled run that started a workflow run.
+	WorkflowScheduledRunStatusTriggered = "Triggered"
+	// WorkflowScheduledRunStatusFail is the status of a scheduled run that was not able to start a workflow run.
+	WorkflowScheduledRunStatusFail = "Fail"
+)
+
+// WorkflowScheduledRun is a single run of a workflow scheduled at a given date. It is kept and executed by the
+// hooks µservice, the workflow run is started as the user that scheduled it.
+type WorkflowScheduledRun struct {
+	UUID             string            `json:"uuid" cli:"uuid,key"`
+	ProjectKey       string            `json:"project_key" cli:"-"`
+	WorkflowName     string            `json:"workflow_name" cli:"-"`
+	Date             time.Time         `json:"date" cli:"date"`
+	Payload          map[string]string `json:"payload,omitempty" cli:"-"`
+	Author           string            `json:"author" cli:"author"`
+	AuthorConsumerID string            `json:"author_consumer_id,omitempty" cli:"-"`
+	Status           string            `json:"status" cli:"status"`
+	WorkflowRun      int64             `json:"workflow_run,omitempty" cli:"workflow_run"`
+	LastError        string            `json:"last_error,omitempty" cli:"last_error"`
+}
+
+// IsValid returns an error if the run is not scheduled in the future.
+func (r WorkflowScheduledRun) IsValid(now time.Time) error {
+	if r.Date.IsZero() {
+		return NewErrorFrom(ErrWrongRequest, "invalid scheduled run, missing date")
+	}
+	if !r.Date.After(now) {
+		return NewErrorFrom(ErrWrongRequest, "invalid scheduled run, date %s is in the past", r.Date.Format(time.RFC3339))
+	}
+	return nil
+}
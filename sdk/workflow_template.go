@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	json "encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/ovh/cds/sdk/slug"
@@ -134,8 +135,7 @@ func (w *WorkflowTemplate) CheckParams(r WorkflowTemplateRequest) error {
 	if r.ProjectKey == "" {
 		return NewErrorFrom(ErrInvalidData, "Project key is required")
 	}
-	regexp := NamePatternRegex
-	if !regexp.MatchString(r.WorkflowName) {
+	if !NamePatternRegex.MatchString(r.WorkflowName) {
 		return NewErrorFrom(ErrInvalidData, "Invalid given workflow name, should match %s pattern", NamePattern)
 	}
 
@@ -145,26 +145,8 @@ func (w *WorkflowTemplate) CheckParams(r WorkflowTemplateRequest) error {
 			return NewErrorFrom(ErrInvalidData, "Param %s is required", p.Key)
 		}
 		if ok {
-			if p.Required && v == "" {
-				return NewErrorFrom(ErrInvalidData, "Param %s is required", p.Key)
-			}
-			switch p.Type {
-			case ParameterTypeBoolean:
-				if v != "" && !(v == "true" || v == "false") {
-					return NewErrorFrom(ErrInvalidData, "Given value it's not a boolean for %s", p.Key)
-				}
-			case ParameterTypeRepository:
-				sp := strings.Split(v, "/")
-				if len(sp) != 3 {
-					return NewErrorFrom(ErrInvalidData, "Given value don't match vcs/repository pattern for %s", p.Key)
-				}
-			case ParameterTypeJSON:
-				if v != "" {
-					var res interface{}
-					if err := json.Unmarshal([]byte(v), &res); err != nil {
-						return NewErrorFrom(ErrInvalidData, "Given value it's not json for %s", p.Key)
-					}
-				}
+			if err := p.CheckValue(v); err != nil {
+				return err
 			}
 		}
 	}
@@ -172,6 +154,34 @@ func (w *WorkflowTemplate) CheckParams(r WorkflowTemplateRequest) error {
 	return nil
 }
 
+// SetDefaultParams sets the default value of parameters that are not given in the request.
+func (w *WorkflowTemplate) SetDefaultParams(r *WorkflowTemplateRequest, info WorkflowTemplateRepositoryInfo) {
+	for _, p := range w.Parameters {
+		if _, ok := r.Parameters[p.Key]; ok {
+			continue
+		}
+		if v := p.DefaultValue(info); v != "" {
+			if r.Parameters == nil {
+				r.Parameters = make(map[string]string)
+			}
+			r.Parameters[p.Key] = v
+		}
+	}
+}
+
+// RepositoryInfo returns the info of the repository given for the first repository parameter of the request.
+func (w *WorkflowTemplate) RepositoryInfo(r WorkflowTemplateRequest) WorkflowTemplateRepositoryInfo {
+	for _, p := range w.Parameters {
+		if p.Type != ParameterTypeRepository {
+			continue
+		}
+		if v := r.Parameters[p.Key]; v != "" {
+			return DetectWorkflowTemplateRepositoryInfo(v, nil)
+		}
+	}
+	return WorkflowTemplateRepositoryInfo{}
+}
+
 // Update workflow template field from new data.
 func (w *WorkflowTemplate) Update(data WorkflowTemplate) {
 	w.Name = data.Name
@@ -264,11 +274,35 @@ func (t TemplateParameterType) IsValid() bool {
 	return false
 }
 
+// TemplateParameterDefaultFrom is the source used to compute the default value of a parameter.
+type TemplateParameterDefaultFrom string
+
+// Parameter default sources, values are detected from the repository of the generated workflow.
+const (
+	ParameterDefaultFromRepositoryName     TemplateParameterDefaultFrom = "repository.name"
+	ParameterDefaultFromRepositoryLanguage TemplateParameterDefaultFrom = "repository.language"
+	ParameterDefaultFromRepositoryModule   TemplateParameterDefaultFrom = "repository.module"
+)
+
+// IsValid returns parameter default source validity.
+func (d TemplateParameterDefaultFrom) IsValid() bool {
+	switch d {
+	case ParameterDefaultFromRepositoryName, ParameterDefaultFromRepositoryLanguage, ParameterDefaultFromRepositoryModule:
+		return true
+	}
+	return false
+}
+
 // WorkflowTemplateParameter struct.
 type WorkflowTemplateParameter struct {
-	Key      string                `json:"key"`
-	Type     TemplateParameterType `json:"type"`
-	Required bool                  `json:"required"`
+	Key         string                       `json:"key"`
+	Type        TemplateParameterType        `json:"type"`
+	Required    bool                         `json:"required"`
+	Description string                       `json:"description,omitempty"`
+	Regex       string                       `json:"regex,omitempty"`
+	Choices     []string                     `json:"choices,omitempty"`
+	Default     string                       `json:"default,omitempty"`
+	DefaultFrom TemplateParameterDefaultFrom `json:"default_from,omitempty"`
 }
 
 // WorkflowTemplateParameters struct.
@@ -355,14 +389,88 @@ func (e *EnvironmentTemplates) Scan(src interface{}) error {
 	return WrapError(json.Unmarshal(source, e), "cannot unmarshal EnvironmentTemplates")
 }
 
-// IsValid returns pipeline template validity.
+// IsValid returns template parameter validity.
 func (w *WorkflowTemplateParameter) IsValid() error {
 	if w.Key == "" || !w.Type.IsValid() {
 		return NewErrorFrom(ErrInvalidData, "Invalid given key or type for parameter")
 	}
+	if w.Regex != "" || len(w.Choices) > 0 {
+		if w.Type != ParameterTypeString {
+			return NewErrorFrom(ErrInvalidData, "Regex and choices are only available for string parameter %s", w.Key)
+		}
+	}
+	if w.Regex != "" {
+		if _, err := regexp.Compile(w.Regex); err != nil {
+			return NewErrorFrom(ErrInvalidData, "Invalid given regex for parameter %s: %v", w.Key, err)
+		}
+	}
+	if w.DefaultFrom != "" && !w.DefaultFrom.IsValid() {
+		return NewErrorFrom(ErrInvalidData, "Invalid given default source %s for parameter %s", w.DefaultFrom, w.Key)
+	}
+	if w.Default != "" {
+		if err := w.CheckValue(w.Default); err != nil {
+			return NewErrorFrom(ErrInvalidData, "Invalid default value for parameter %s", w.Key)
+		}
+	}
 	return nil
 }
 
+// CheckValue returns an error if given value is not valid for the parameter.
+func (w *WorkflowTemplateParameter) CheckValue(v string) error {
+	if w.Required && v == "" {
+		return NewErrorFrom(ErrInvalidData, "Param %s is required", w.Key)
+	}
+	switch w.Type {
+	case ParameterTypeString:
+		if v == "" {
+			return nil
+		}
+		if w.Regex != "" {
+			if ok, _ := regexp.MatchString(w.Regex, v); !ok {
+				return NewErrorFrom(ErrInvalidData, "Given value for %s doesn't match %s", w.Key, w.Regex)
+			}
+		}
+		if len(w.Choices) > 0 && !IsInArray(v, w.Choices) {
+			return NewErrorFrom(ErrInvalidData, "Given value for %s should be one of: %s", w.Key, strings.Join(w.Choices, ", "))
+		}
+	case ParameterTypeBoolean:
+		if v != "" && !(v == "true" || v == "false") {
+			return NewErrorFrom(ErrInvalidData, "Given value it's not a boolean for %s", w.Key)
+		}
+	case ParameterTypeRepository:
+		sp := strings.Split(v, "/")
+		if len(sp) != 3 {
+			return NewErrorFrom(ErrInvalidData, "Given value don't match vcs/repository pattern for %s", w.Key)
+		}
+	case ParameterTypeJSON:
+		if v != "" {
+			var res interface{}
+			if err := json.Unmarshal([]byte(v), &res); err != nil {
+				return NewErrorFrom(ErrInvalidData, "Given value it's not json for %s", w.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultValue returns the default value of the parameter, the value detected from the repository
+// is used if available else the static default value.
+func (w *WorkflowTemplateParameter) DefaultValue(info WorkflowTemplateRepositoryInfo) string {
+	var v string
+	switch w.DefaultFrom {
+	case ParameterDefaultFromRepositoryName:
+		v = info.Name
+	case ParameterDefaultFromRepositoryLanguage:
+		v = info.Language
+	case ParameterDefaultFromRepositoryModule:
+		v = info.Module
+	}
+	if v != "" && w.CheckValue(v) == nil {
+		return v
+	}
+	return w.Default
+}
+
 // WorkflowTemplateInstance struct.
 type WorkflowTemplateInstance struct {
 	ID                      int64                   `json:"id" db:"id"`
@@ -464,6 +572,54 @@ func (w *WorkflowTemplateBulkOperations) Scan(src interface{}) error {
 	return WrapError(json.Unmarshal(source, w), "cannot unmarshal WorkflowTemplateBulkOperations")
 }
 
+// WorkflowTemplateDifference kinds.
+const (
+	WorkflowTemplateDifferenceAdded    = "added"
+	WorkflowTemplateDifferenceRemoved  = "removed"
+	WorkflowTemplateDifferenceModified = "modified"
+)
+
+// WorkflowTemplateDifference contains the difference for an entity between the result of the
+// template and the entity that currently exists in the project.
+type WorkflowTemplateDifference struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Diff   string `json:"diff,omitempty"`
+}
+
+// WorkflowTemplateInstanceDrift contains info about drift between a template instance and the latest template version.
+type WorkflowTemplateInstanceDrift struct {
+	InstanceID      int64                        `json:"instance_id"`
+	ProjectKey      string                       `json:"project_key"`
+	WorkflowName    string                       `json:"workflow_name"`
+	InstanceVersion int64                        `json:"instance_version"`
+	TemplateVersion int64                        `json:"template_version"`
+	Outdated        bool                         `json:"outdated"`
+	Differences     []WorkflowTemplateDifference `json:"differences,omitempty"`
+	Error           string                       `json:"error,omitempty"`
+}
+
+// HasDrift returns true if the instance is not up to date with the template.
+func (w WorkflowTemplateInstanceDrift) HasDrift() bool {
+	return w.Outdated || len(w.Differences) > 0
+}
+
+// WorkflowTemplateReapplyRequest is used to re-apply a template on existing instances of a project or a group.
+type WorkflowTemplateReapplyRequest struct {
+	ProjectKey  string `json:"project_key,omitempty"`
+	GroupName   string `json:"group_name,omitempty"`
+	OnlyDrifted bool   `json:"only_drifted"`
+}
+
+// IsValid returns an error if the request is not valid.
+func (w WorkflowTemplateReapplyRequest) IsValid() error {
+	if (w.ProjectKey == "") == (w.GroupName == "") {
+		return NewErrorFrom(ErrWrongRequest, "a project key or a group name should be given")
+	}
+	return nil
+}
+
 // WorkflowTemplateError contains info about template parsing error.
 type WorkflowTemplateError struct {
 	Type    string `json:"type"`
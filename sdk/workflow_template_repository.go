@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	json "encoding/json"
+	"regexp"
+	"strings"
+)
+
+// WorkflowTemplateRepositoryInfo contains data detected from the repository of a generated workflow,
+// it's used to compute the default values of template parameters.
+type WorkflowTemplateRepositoryInfo struct {
+	Name     string `json:"name,omitempty"`
+	Language string `json:"language,omitempty"`
+	Module   string `json:"module,omitempty"`
+}
+
+var (
+	goModuleRegex     = regexp.MustCompile(`(?m)^module\s+"?([^\s"]+)"?`)
+	pomParentRegex    = regexp.MustCompile(`(?s)<parent>.*?</parent>`)
+	pomArtifactRegex  = regexp.MustCompile(`<artifactId>\s*([^<\s]+)\s*</artifactId>`)
+	tomlNameRegex     = regexp.MustCompile(`(?m)^name\s*=\s*["']([^"']+)["']`)
+	setupPyNameRegex  = regexp.MustCompile(`name\s*=\s*["']([^"']+)["']`)
+	gradleNameRegex   = regexp.MustCompile(`(?m)^rootProject\.name\s*=\s*["']([^"']+)["']`)
+	jsonNameExtractor = func(b []byte) string {
+		var res struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(b, &res); err != nil {
+			return ""
+		}
+		return res.Name
+	}
+)
+
+func regexExtractor(r *regexp.Regexp) func([]byte) string {
+	return func(b []byte) string {
+		if m := r.FindSubmatch(b); len(m) > 1 {
+			return string(m[1])
+		}
+		return ""
+	}
+}
+
+// templateRepositoryDetectors are ordered by priority, the first file found gives the language of the repository.
+var templateRepositoryDetectors = []struct {
+	file     string
+	language string
+	module   func([]byte) string
+}{
+	{"go.mod", "go", regexExtractor(goModuleRegex)},
+	{"package.json", "javascript", jsonNameExtractor},
+	{"pom.xml", "java", func(b []byte) string { return regexExtractor(pomArtifactRegex)(pomParentRegex.ReplaceAll(b, nil)) }},
+	{"settings.gradle", "java", regexExtractor(gradleNameRegex)},
+	{"build.gradle", "java", nil},
+	{"Cargo.toml", "rust", regexExtractor(tomlNameRegex)},
+	{"pyproject.toml", "python", regexExtractor(tomlNameRegex)},
+	{"setup.py", "python", regexExtractor(setupPyNameRegex)},
+	{"requirements.txt", "python", nil},
+	{"composer.json", "php", jsonNameExtractor},
+	{"Gemfile", "ruby", nil},
+	{"Dockerfile", "docker", nil},
+}
+
+// DetectWorkflowTemplateRepositoryInfo returns the info of a repository from its name (like vcs/owner/repo or owner/repo)
+// and the files at its root, readFile should return an error if the file doesn't exist.
+func DetectWorkflowTemplateRepositoryInfo(name string, readFile func(path string) ([]byte, error)) WorkflowTemplateRepositoryInfo {
+	var info WorkflowTemplateRepositoryInfo
+	if name != "" {
+		info.Name = name[strings.LastIndex(name, "/")+1:]
+	}
+	if readFile == nil {
+		return info
+	}
+
+	for _, d := range templateRepositoryDetectors {
+		b, err := readFile(d.file)
+		if err != nil {
+			continue
+		}
+		if info.Language == "" {
+			info.Language = d.language
+		}
+		if d.language != info.Language {
+			continue
+		}
+		if info.Module == "" && d.module != nil {
+			info.Module = d.module(b)
+		}
+		if info.Module != "" {
+			break
+		}
+	}
+	return info
+}
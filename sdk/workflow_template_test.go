@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowTemplateCheckParams(t *testing.T) {
+	wt := WorkflowTemplate{
+		Parameters: []WorkflowTemplateParameter{
+			{Key: "name", Type: ParameterTypeString, Required: true, Regex: "^[a-z-]+$"},
+			{Key: "language", Type: ParameterTypeString, Choices: []string{"go", "java"}},
+			{Key: "deploy", Type: ParameterTypeBoolean},
+		},
+	}
+	for _, p := range wt.Parameters {
+		require.NoError(t, p.IsValid())
+	}
+
+	req := WorkflowTemplateRequest{
+		ProjectKey:   "KEY",
+		WorkflowName: "my-workflow",
+		Parameters:   map[string]string{"name": "my-app", "language": "go", "deploy": "true"},
+	}
+	assert.NoError(t, wt.CheckParams(req))
+
+	req.Parameters["name"] = "My App"
+	assert.Error(t, wt.CheckParams(req))
+
+	req.Parameters["name"] = "my-app"
+	req.Parameters["language"] = "rust"
+	assert.Error(t, wt.CheckParams(req))
+
+	invalid := WorkflowTemplateParameter{Key: "language", Type: ParameterTypeString, Choices: []string{"go", "java"}, Default: "rust"}
+	assert.Error(t, invalid.IsValid())
+	invalid = WorkflowTemplateParameter{Key: "deploy", Type: ParameterTypeBoolean, Regex: "^true$"}
+	assert.Error(t, invalid.IsValid())
+}
+
+func TestWorkflowTemplateSetDefaultParams(t *testing.T) {
+	wt := WorkflowTemplate{
+		Parameters: []WorkflowTemplateParameter{
+			{Key: "repo", Type: ParameterTypeRepository},
+			{Key: "name", Type: ParameterTypeString, DefaultFrom: ParameterDefaultFromRepositoryName},
+			{Key: "language", Type: ParameterTypeString, Choices: []string{"go", "java"}, Default: "java", DefaultFrom: ParameterDefaultFromRepositoryLanguage},
+			{Key: "module", Type: ParameterTypeString, DefaultFrom: ParameterDefaultFromRepositoryModule},
+		},
+	}
+
+	req := WorkflowTemplateRequest{Parameters: map[string]string{"repo": "github/ovh/cds"}}
+	wt.SetDefaultParams(&req, wt.RepositoryInfo(req))
+	assert.Equal(t, map[string]string{"repo": "github/ovh/cds", "name": "cds", "language": "java"}, req.Parameters)
+
+	req = WorkflowTemplateRequest{Parameters: map[string]string{"name": "given"}}
+	wt.SetDefaultParams(&req, WorkflowTemplateRepositoryInfo{Name: "cds", Language: "go", Module: "github.com/ovh/cds"})
+	assert.Equal(t, map[string]string{"name": "given", "language": "go", "module": "github.com/ovh/cds"}, req.Parameters)
+}
+
+func TestDetectWorkflowTemplateRepositoryInfo(t *testing.T) {
+	files := map[string]string{
+		"Dockerfile": "FROM alpine",
+		"pom.xml":    "<project><parent><artifactId>parent</artifactId></parent><artifactId>my-service</artifactId></project>",
+	}
+	readFile := func(path string) ([]byte, error) {
+		if f, ok := files[path]; ok {
+			return []byte(f), nil
+		}
+		return nil, os.ErrNotExist
+	}
+
+	info := DetectWorkflowTemplateRepositoryInfo("ovh/my-service", readFile)
+	assert.Equal(t, WorkflowTemplateRepositoryInfo{Name: "my-service", Language: "java", Module: "my-service"}, info)
+
+	files = map[string]string{"go.mod": "module github.com/ovh/cds\n\ngo 1.13\n"}
+	info = DetectWorkflowTemplateRepositoryInfo("github/ovh/cds", readFile)
+	assert.Equal(t, WorkflowTemplateRepositoryInfo{Name: "cds", Language: "go", Module: "github.com/ovh/cds"}, info)
+
+	info = DetectWorkflowTemplateRepositoryInfo("", func(string) ([]byte, error) { return nil, errors.New("not found") })
+	assert.Equal(t, WorkflowTemplateRepositoryInfo{}, info)
+}
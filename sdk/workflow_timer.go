@@ -0,0 +1,144 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/pkg/errors"
+)
+
+// Workflow timer catch-up policies, used when executions were missed because the API was down.
+const (
+	// WorkflowTimerCatchUpSkip runs nothing for missed executions.
+	WorkflowTimerCatchUpSkip = "skip"
+	// WorkflowTimerCatchUpOnce runs only once for all missed executions.
+	WorkflowTimerCatchUpOnce = "once"
+	// WorkflowTimerCatchUpAll runs for each missed execution, up to WorkflowTimerMaxCatchUp.
+	WorkflowTimerCatchUpAll = "all"
+)
+
+// WorkflowTimerMaxCatchUp is the max count of missed executions that will be run with the "all" policy.
+const WorkflowTimerMaxCatchUp = 10
+
+// WorkflowTimerMissedDelay is the delay after which an execution is considered as missed.
+const WorkflowTimerMissedDelay = 5 * time.Minute
+
+// WorkflowTimer triggers periodic runs of a workflow from CDS API without the hooks µservice.
+type WorkflowTimer struct {
+	ID               int64                `json:"id" db:"id" cli:"id,key"`
+	WorkflowID       int64                `json:"workflow_id" db:"workflow_id"`
+	Name             string               `json:"name" db:"name" cli:"name"`
+	Cron             string               `json:"cron" db:"cron" cli:"cron"`
+	Timezone         string               `json:"timezone" db:"timezone" cli:"timezone"`
+	CatchUp          string               `json:"catch_up" db:"catch_up" cli:"catch_up"`
+	Payload          WorkflowTimerPayload `json:"payload,omitempty" db:"payload"`
+	Disabled         bool                 `json:"disabled" db:"disabled" cli:"disabled"`
+	AuthorConsumerID string               `json:"-" db:"author_consumer_id"`
+	Created          time.Time            `json:"created" db:"created"`
+	NextExecution    time.Time            `json:"next_execution" db:"next_execution" cli:"next_execution"`
+	LastExecution    *time.Time           `json:"last_execution,omitempty" db:"last_execution" cli:"last_execution"`
+	LastStatus       string               `json:"last_status,omitempty" db:"last_status" cli:"last_status"`
+	LastError        string               `json:"last_error,omitempty" db:"last_error" cli:"last_error"`
+	LastRunNumber    int64                `json:"last_run_number,omitempty" db:"last_run_number" cli:"last_run_number"`
+	MissedExecutions int64                `json:"missed_executions" db:"missed_executions" cli:"missed_executions"`
+}
+
+// WorkflowTimerPayload is the payload given to runs triggered by a timer.
+type WorkflowTimerPayload map[string]string
+
+// Value returns driver.Value from workflow timer payload.
+func (p WorkflowTimerPayload) Value() (driver.Value, error) {
+	j, err := json.Marshal(p)
+	return j, WrapError(err, "cannot marshal WorkflowTimerPayload")
+}
+
+// Scan workflow timer payload.
+func (p *WorkflowTimerPayload) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(errors.New("type assertion .([]byte) failed"))
+	}
+	return WrapError(json.Unmarshal(source, p), "cannot unmarshal WorkflowTimerPayload")
+}
+
+// IsValid returns an error if the timer is not valid.
+func (t WorkflowTimer) IsValid() error {
+	if !NamePatternRegex.MatchString(t.Name) {
+		return NewErrorFrom(ErrWrongRequest, "invalid workflow timer name %q, should match %s", t.Name, NamePattern)
+	}
+	if _, err := cronexpr.Parse(t.Cron); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid workflow timer cron expression %q: %v", t.Cron, err)
+	}
+	if _, err := time.LoadLocation(t.Timezone); err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid workflow timer timezone %q", t.Timezone)
+	}
+	switch t.CatchUp {
+	case WorkflowTimerCatchUpSkip, WorkflowTimerCatchUpOnce, WorkflowTimerCatchUpAll:
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid workflow timer catch up policy %q", t.CatchUp)
+	}
+	return nil
+}
+
+// Next returns the first scheduled execution of the timer strictly after given time.
+func (t WorkflowTimer) Next(after time.Time) (time.Time, error) {
+	expr, err := cronexpr.Parse(t.Cron)
+	if err != nil {
+		return time.Time{}, NewErrorFrom(ErrWrongRequest, "invalid workflow timer cron expression %q: %v", t.Cron, err)
+	}
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return time.Time{}, NewErrorFrom(ErrWrongRequest, "invalid workflow timer timezone %q", t.Timezone)
+	}
+	next := expr.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, NewErrorFrom(ErrWrongRequest, "workflow timer cron expression %q has no next execution", t.Cron)
+	}
+	return next, nil
+}
+
+// ExecutionsDue returns the executions to run at given time according to the catch-up policy, the count of
+// executions that were not run on time and the next scheduled execution.
+func (t WorkflowTimer) ExecutionsDue(now time.Time) (executions []time.Time, missed int64, next time.Time, err error) {
+	var scheduled []time.Time
+	next = t.NextExecution
+	for !next.After(now) {
+		scheduled = append(scheduled, next)
+		if next, err = t.Next(next); err != nil {
+			return nil, 0, time.Time{}, err
+		}
+	}
+	if len(scheduled) == 0 {
+		return nil, 0, next, nil
+	}
+
+	// The last scheduled execution is not missed if the scheduler is on time
+	last := scheduled[len(scheduled)-1]
+	onTime := now.Sub(last) <= WorkflowTimerMissedDelay
+
+	switch t.CatchUp {
+	case WorkflowTimerCatchUpAll:
+		executions = scheduled
+		if len(executions) > WorkflowTimerMaxCatchUp {
+			executions = executions[len(executions)-WorkflowTimerMaxCatchUp:]
+		}
+	case WorkflowTimerCatchUpOnce:
+		executions = []time.Time{last}
+	default:
+		if onTime {
+			executions = []time.Time{last}
+		}
+	}
+
+	// Count executions that were not run on time
+	missed = int64(len(scheduled))
+	if onTime {
+		missed--
+	}
+	return executions, missed, next, nil
+}
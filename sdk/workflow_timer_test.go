@@ -0,0 +1,76 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowTimerIsValid(t *testing.T) {
+	timer := WorkflowTimer{Name: "nightly", Cron: "0 2 * * *", Timezone: "Europe/Paris", CatchUp: WorkflowTimerCatchUpOnce}
+	assert.NoError(t, timer.IsValid())
+
+	invalid := timer
+	invalid.Cron = "not a cron"
+	assert.Error(t, invalid.IsValid())
+
+	invalid = timer
+	invalid.Timezone = "Unknown/Zone"
+	assert.Error(t, invalid.IsValid())
+
+	invalid = timer
+	invalid.CatchUp = "sometimes"
+	assert.Error(t, invalid.IsValid())
+}
+
+func TestWorkflowTimerExecutionsDue(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	timer := WorkflowTimer{Cron: "0 * * * *", Timezone: "UTC", NextExecution: start}
+
+	// Not yet due
+	executions, missed, next, err := timer.ExecutionsDue(start.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, executions)
+	assert.Equal(t, int64(0), missed)
+	assert.Equal(t, start, next)
+
+	// On time, whatever the policy
+	for _, p := range []string{WorkflowTimerCatchUpSkip, WorkflowTimerCatchUpOnce, WorkflowTimerCatchUpAll} {
+		timer.CatchUp = p
+		executions, missed, next, err = timer.ExecutionsDue(start.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{start}, executions, p)
+		assert.Equal(t, int64(0), missed, p)
+		assert.Equal(t, start.Add(time.Hour), next.UTC(), p)
+	}
+
+	// After a downtime of 3 hours and a half, four executions were missed
+	now := start.Add(3*time.Hour + 30*time.Minute)
+
+	timer.CatchUp = WorkflowTimerCatchUpSkip
+	executions, missed, next, err = timer.ExecutionsDue(now)
+	require.NoError(t, err)
+	assert.Empty(t, executions)
+	assert.Equal(t, int64(4), missed)
+	assert.Equal(t, start.Add(4*time.Hour), next.UTC())
+
+	timer.CatchUp = WorkflowTimerCatchUpOnce
+	executions, missed, _, err = timer.ExecutionsDue(now)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, start.Add(3*time.Hour), executions[0].UTC())
+	assert.Equal(t, int64(4), missed)
+
+	timer.CatchUp = WorkflowTimerCatchUpAll
+	executions, missed, _, err = timer.ExecutionsDue(now)
+	require.NoError(t, err)
+	assert.Len(t, executions, 4)
+	assert.Equal(t, int64(4), missed)
+
+	// Catch up is limited
+	executions, _, _, err = timer.ExecutionsDue(start.Add(24 * time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, executions, WorkflowTimerMaxCatchUp)
+}
@@ -0,0 +1,69 @@
+package sdk
+
+// Decisions returned when explaining what happened to a repository event.
+const (
+	WorkflowTriggerDecisionTriggered = "triggered"
+	WorkflowTriggerDecisionPending   = "pending"
+	WorkflowTriggerDecisionIgnored   = "ignored"
+	WorkflowTriggerDecisionRejected  = "rejected"
+	WorkflowTriggerDecisionError     = "error"
+)
+
+// Kinds of checks applied on a repository event before starting a workflow run.
+const (
+	WorkflowTriggerCheckHookCondition = "hook_condition"
+	WorkflowTriggerCheckNodeCondition = "node_condition"
+)
+
+// WorkflowTriggerExplain explains for each repository hook of a workflow what happened to received events.
+type WorkflowTriggerExplain struct {
+	ProjectKey   string                       `json:"project_key"`
+	WorkflowName string                       `json:"workflow_name"`
+	Commit       string                       `json:"commit,omitempty"`
+	Branch       string                       `json:"branch,omitempty"`
+	Hooks        []WorkflowTriggerExplainHook `json:"hooks"`
+}
+
+// WorkflowTriggerExplainHook contains the executions of a hook that match the explain request.
+type WorkflowTriggerExplainHook struct {
+	UUID       string                            `json:"uuid"`
+	ModelName  string                            `json:"model_name"`
+	NodeName   string                            `json:"node_name"`
+	Stopped    bool                              `json:"stopped"`
+	Error      string                            `json:"error,omitempty"`
+	Executions []WorkflowTriggerExplainExecution `json:"executions"`
+}
+
+// WorkflowTriggerExplainExecution explains what the hooks service did for a received event.
+type WorkflowTriggerExplainExecution struct {
+	Timestamp int64                         `json:"timestamp"`
+	EventType string                        `json:"event_type,omitempty"`
+	Status    string                        `json:"status"`
+	Decision  string                        `json:"decision"`
+	Reason    string                        `json:"reason,omitempty"`
+	Events    []WorkflowTriggerExplainEvent `json:"events,omitempty"`
+}
+
+// WorkflowTriggerExplainEvent explains what the API did for a payload sent by the hooks service.
+type WorkflowTriggerExplainEvent struct {
+	Branch      string                 `json:"branch,omitempty"`
+	Tag         string                 `json:"tag,omitempty"`
+	Commit      string                 `json:"commit,omitempty"`
+	Message     string                 `json:"message,omitempty"`
+	Author      string                 `json:"author,omitempty"`
+	Decision    string                 `json:"decision"`
+	Reason      string                 `json:"reason,omitempty"`
+	WorkflowRun int64                  `json:"workflow_run,omitempty"`
+	Checks      []WorkflowTriggerCheck `json:"checks,omitempty"`
+}
+
+// WorkflowTriggerCheck is the result of a check applied on an event payload.
+type WorkflowTriggerCheck struct {
+	Kind     string `json:"kind"`
+	Variable string `json:"variable,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Value    string `json:"value,omitempty"`
+	OK       bool   `json:"ok"`
+	Message  string `json:"message,omitempty"`
+}
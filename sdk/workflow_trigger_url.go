@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"regexp"
+	"time"
+)
+
+// Payload field types allowed in a workflow trigger payload schema.
+const (
+	WorkflowTriggerPayloadFieldString  = "string"
+	WorkflowTriggerPayloadFieldNumber  = "number"
+	WorkflowTriggerPayloadFieldBoolean = "boolean"
+)
+
+// WorkflowTriggerDefaultDuration is the validity duration of a signed trigger url if not given.
+const WorkflowTriggerDefaultDuration = 24 * time.Hour
+
+// WorkflowTriggerMaxDuration is the max validity duration of a signed trigger url.
+const WorkflowTriggerMaxDuration = 30 * 24 * time.Hour
+
+// WorkflowTriggerURLRequest is used to generate a signed url that triggers a workflow.
+type WorkflowTriggerURLRequest struct {
+	// Duration of validity for the url in seconds
+	Duration      int64                        `json:"duration"`
+	PayloadSchema WorkflowTriggerPayloadSchema `json:"payload_schema"`
+}
+
+// IsValid returns an error if the request is not valid.
+func (r WorkflowTriggerURLRequest) IsValid() error {
+	if r.Duration < 0 || time.Duration(r.Duration)*time.Second > WorkflowTriggerMaxDuration {
+		return NewErrorFrom(ErrWrongRequest, "invalid given duration, should be between 0 and %d seconds", int64(WorkflowTriggerMaxDuration.Seconds()))
+	}
+	return r.PayloadSchema.IsValid()
+}
+
+// GetDuration returns the validity duration for the signed url.
+func (r WorkflowTriggerURLRequest) GetDuration() time.Duration {
+	if r.Duration == 0 {
+		return WorkflowTriggerDefaultDuration
+	}
+	return time.Duration(r.Duration) * time.Second
+}
+
+// WorkflowTriggerURL is a signed url that allows to trigger a workflow without authentication. The url is only
+// returned when it is generated, it can be revoked with its id until it expires.
+type WorkflowTriggerURL struct {
+	ID         string    `json:"id" db:"id" cli:"id,key"`
+	WorkflowID int64     `json:"workflow_id" db:"workflow_id" cli:"-"`
+	ConsumerID string    `json:"consumer_id" db:"consumer_id" cli:"-"`
+	URL        string    `json:"url,omitempty" db:"-" cli:"url"`
+	Created    time.Time `json:"created" db:"created" cli:"created"`
+	Expire     time.Time `json:"expire" db:"expire" cli:"expire"`
+	Revoked    bool      `json:"revoked" db:"revoked" cli:"revoked"`
+}
+
+// WorkflowTriggerPayloadField describes a field allowed in the payload given to a signed trigger url.
+type WorkflowTriggerPayloadField struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+	Pattern  string `json:"pattern,omitempty"`
+}
+
+// WorkflowTriggerPayloadSchema is the list of fields allowed in a signed trigger payload.
+type WorkflowTriggerPayloadSchema []WorkflowTriggerPayloadField
+
+// IsValid returns an error if the schema is not valid.
+func (s WorkflowTriggerPayloadSchema) IsValid() error {
+	names := make(map[string]struct{}, len(s))
+	for _, f := range s {
+		if f.Name == "" {
+			return NewErrorFrom(ErrWrongRequest, "invalid given payload field name")
+		}
+		if _, ok := names[f.Name]; ok {
+			return NewErrorFrom(ErrWrongRequest, "duplicated payload field %s", f.Name)
+		}
+		names[f.Name] = struct{}{}
+		switch f.Type {
+		case WorkflowTriggerPayloadFieldString:
+			if f.Pattern != "" {
+				if _, err := regexp.Compile(f.Pattern); err != nil {
+					return NewErrorFrom(ErrWrongRequest, "invalid pattern for payload field %s: %v", f.Name, err)
+				}
+			}
+		case WorkflowTriggerPayloadFieldNumber, WorkflowTriggerPayloadFieldBoolean:
+			if f.Pattern != "" {
+				return NewErrorFrom(ErrWrongRequest, "pattern is only allowed for string payload field")
+			}
+		default:
+			return NewErrorFrom(ErrWrongRequest, "invalid type %q for payload field %s", f.Type, f.Name)
+		}
+	}
+	return nil
+}
+
+// Check returns an error if given payload doesn't match the schema. Fields that are not declared in
+// the schema are not allowed.
+func (s WorkflowTriggerPayloadSchema) Check(payload map[string]interface{}) error {
+	fields := make(map[string]WorkflowTriggerPayloadField, len(s))
+	for _, f := range s {
+		fields[f.Name] = f
+		if _, ok := payload[f.Name]; !ok && f.Required {
+			return NewErrorFrom(ErrInvalidData, "missing required payload field %s", f.Name)
+		}
+	}
+
+	for k, v := range payload {
+		f, ok := fields[k]
+		if !ok {
+			return NewErrorFrom(ErrInvalidData, "payload field %s is not allowed", k)
+		}
+		switch f.Type {
+		case WorkflowTriggerPayloadFieldString:
+			s, ok := v.(string)
+			if !ok {
+				return NewErrorFrom(ErrInvalidData, "payload field %s should be a string", k)
+			}
+			if f.Pattern != "" {
+				// pattern was already checked when the url was generated
+				if match, _ := regexp.MatchString(f.Pattern, s); !match {
+					return NewErrorFrom(ErrInvalidData, "payload field %s doesn't match pattern %s", k, f.Pattern)
+				}
+			}
+		case WorkflowTriggerPayloadFieldNumber:
+			if _, ok := v.(float64); !ok {
+				return NewErrorFrom(ErrInvalidData, "payload field %s should be a number", k)
+			}
+		case WorkflowTriggerPayloadFieldBoolean:
+			if _, ok := v.(bool); !ok {
+				return NewErrorFrom(ErrInvalidData, "payload field %s should be a boolean", k)
+			}
+		}
+	}
+
+	return nil
+}
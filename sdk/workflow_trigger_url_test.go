@@ -0,0 +1,71 @@
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestWorkflowTriggerPayloadSchemaCheck(t *testing.T) {
+	schema := sdk.WorkflowTriggerPayloadSchema{
+		{Name: "version", Type: sdk.WorkflowTriggerPayloadFieldString, Required: true, Pattern: `^v[0-9]+\.[0-9]+\.[0-9]+$`},
+		{Name: "replicas", Type: sdk.WorkflowTriggerPayloadFieldNumber},
+		{Name: "dry_run", Type: sdk.WorkflowTriggerPayloadFieldBoolean},
+	}
+	assert.NoError(t, schema.IsValid())
+
+	cases := []struct {
+		Name    string
+		Payload map[string]interface{}
+		Error   bool
+	}{
+		{
+			Name:    "Payload with all fields should be valid",
+			Payload: map[string]interface{}{"version": "v1.2.3", "replicas": float64(3), "dry_run": true},
+		},
+		{
+			Name:    "Payload with only required fields should be valid",
+			Payload: map[string]interface{}{"version": "v1.2.3"},
+		},
+		{
+			Name:    "Payload without required field should be invalid",
+			Payload: map[string]interface{}{"replicas": float64(3)},
+			Error:   true,
+		},
+		{
+			Name:    "Payload with unknown field should be invalid",
+			Payload: map[string]interface{}{"version": "v1.2.3", "branch": "master"},
+			Error:   true,
+		},
+		{
+			Name:    "Payload with invalid pattern should be invalid",
+			Payload: map[string]interface{}{"version": "latest"},
+			Error:   true,
+		},
+		{
+			Name:    "Payload with invalid type should be invalid",
+			Payload: map[string]interface{}{"version": "v1.2.3", "dry_run": "true"},
+			Error:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := schema.Check(c.Payload)
+			if c.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkflowTriggerPayloadSchemaIsValid(t *testing.T) {
+	assert.Error(t, sdk.WorkflowTriggerPayloadSchema{{Name: "a", Type: "object"}}.IsValid())
+	assert.Error(t, sdk.WorkflowTriggerPayloadSchema{{Name: "a", Type: "string"}, {Name: "a", Type: "number"}}.IsValid())
+	assert.Error(t, sdk.WorkflowTriggerPayloadSchema{{Name: "a", Type: "string", Pattern: "("}}.IsValid())
+	assert.Error(t, sdk.WorkflowTriggerPayloadSchema{{Name: "a", Type: "number", Pattern: "[0-9]"}}.IsValid())
+}
@@ -43,6 +43,9 @@ export class ModelDocker {
     envs: {};
     cmd: string;
     memory: number;
+    os: string;
+    isolation: string;
+    arch: string;
 }
 
 export class ModelVirtualMachine {
@@ -51,6 +54,11 @@ export class ModelVirtualMachine {
     pre_cmd: string;
     cmd: string;
     post_cmd: string;
+    subnet: string;
+    security_groups: Array<string>;
+    spot: boolean;
+    preemptible: boolean;
+    arch: string;
 }
 
 export class ModelPattern {
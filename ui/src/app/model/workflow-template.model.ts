@@ -28,6 +28,11 @@ export class WorkflowTemplateParameter {
     key: string;
     type: string;
     required: boolean;
+    description: string;
+    regex: string;
+    choices: Array<string>;
+    default: string;
+    default_from: string;
 }
 
 export class PipelineTemplate {
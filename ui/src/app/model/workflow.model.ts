@@ -18,6 +18,7 @@ export class WNodeType {
     static JOIN = 'join';
     static FORK = 'fork';
     static OUTGOINGHOOK = 'outgoinghook';
+    static SUBWORKFLOW = 'subworkflow';
 }
 
 // Workflow represents a pipeline based workflow
@@ -413,6 +414,7 @@ export class WNode {
     triggers: Array<WNodeTrigger>;
     context: WNodeContext;
     outgoing_hook: WNodeOutgoingHook;
+    subworkflow: WNodeSubWorkflow;
     parents: Array<WNodeJoin>;
     hooks: Array<WNodeHook>;
     groups: Array<GroupPermission>;
@@ -659,6 +661,11 @@ export class WNodeOutgoingHook {
     model: WorkflowHookModel;
 }
 
+export class WNodeSubWorkflow {
+    workflow_name: string;
+    payload: string;
+}
+
 export class WNodeJoin {
     id: number;
     node_id: number;
@@ -735,4 +742,5 @@ export class UserNotificationTemplate {
     subject: string;
     body: string;
     disable_comment: boolean;
+    tests_summary: boolean;
 }
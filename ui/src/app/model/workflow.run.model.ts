@@ -96,6 +96,7 @@ export class WorkflowNodeRun implements WithKey {
     hook_execution_timestamp: number;
     execution_id: string;
     callback: WorkflowNodeOutgoingHookRunCallback;
+    subworkflow: WorkflowNodeRunSubWorkflow;
     static_files: Array<WorkflowNodeRunStaticFiles>;
 
     key(): string {
@@ -112,6 +113,24 @@ export class WorkflowNodeOutgoingHookRunCallback {
     workflow_run_number: number;
 }
 
+export class WorkflowNodeRunSubWorkflow {
+    workflow_name: string;
+    payload: {};
+}
+
+export class WorkflowRunLink {
+    project_key: string;
+    workflow_name: string;
+    num: number;
+    node_name: string;
+    status: string;
+}
+
+export class WorkflowRunLinks {
+    parent: WorkflowRunLink;
+    children: Array<WorkflowRunLink>;
+}
+
 // WorkflowNodeRunArtifact represents tests list
 export class WorkflowNodeRunArtifact {
     workflow_id: number;
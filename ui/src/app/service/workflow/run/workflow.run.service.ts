@@ -2,7 +2,7 @@ import {HttpClient, HttpParams} from '@angular/common/http';
 import {Injectable} from '@angular/core';
 import {Commit} from 'app/model/repositories.model';
 import {Workflow} from 'app/model/workflow.model';
-import {RunNumber, WorkflowNodeRun, WorkflowRun, WorkflowRunRequest} from 'app/model/workflow.run.model';
+import {RunNumber, WorkflowNodeRun, WorkflowRun, WorkflowRunLinks, WorkflowRunRequest} from 'app/model/workflow.run.model';
 import {Observable} from 'rxjs';
 import {map} from 'rxjs/operators';
 
@@ -64,6 +64,17 @@ export class WorkflowRunService {
         });
     }
 
+    /**
+     * Get the parent run and the children runs of a workflow run
+     * @param {string} key Project unique key
+     * @param {string} workflowName Workflow name
+     * @param {number} number Number of the workflow run
+     * @returns {Observable<WorkflowRunLinks>}
+     */
+    getWorkflowRunLinks(key: string, workflowName: string, number: number): Observable<WorkflowRunLinks> {
+        return this._http.get<WorkflowRunLinks>('/project/' + key + '/workflows/' + workflowName + '/runs/' + number + '/links');
+    }
+
     /**
      * Get workflow node run
      * @param {string} key Project unique key
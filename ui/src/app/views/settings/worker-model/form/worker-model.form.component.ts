@@ -49,6 +49,9 @@ export class WorkerModelFormComponent implements OnInit {
     envNames: Array<string> = [];
     newEnvName: string;
     newEnvValue: string;
+    dockerOS = ['linux', 'windows'];
+    dockerIsolations = ['process', 'hyperv'];
+    archs = ['amd64', 'arm64', 'arm', '386', 'ppc64le'];
     themeSubscription: Subscription;
 
     constructor(
@@ -121,6 +124,8 @@ pattern_name: basic_unix`;
             case 'host':
             case 'openstack':
             case 'vsphere':
+            case 'ec2':
+            case 'gce':
                 let minimal_info_vm = !!this.workerModel.model_virtual_machine.image && !!this.workerModel.model_virtual_machine.cmd;
                 if (!minimal_info_vm) {
                     return false;